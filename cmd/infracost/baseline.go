@@ -0,0 +1,158 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/infracost/infracost/internal/baseline"
+	"github.com/infracost/infracost/internal/config"
+	"github.com/infracost/infracost/internal/schema"
+	"github.com/infracost/infracost/internal/ui"
+)
+
+func baselineCmd(ctx *config.RunContext) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "baseline",
+		Short: "Manage the baselines that costs are diffed against",
+		Long: `Manage the baselines that costs are diffed against
+
+A baseline is the Infracost JSON output of an approved state, e.g. what's
+deployed to prod. Diffing against it shows how a change compares to the
+approved costs without generating the estimate of another branch.`,
+		Example: `  Save the costs of the main branch as the prod baseline:
+
+      infracost baseline save --name prod --path /path/to/code
+
+  Diff a change against the prod baseline:
+
+      infracost diff --path /path/to/code --compare-to-baseline prod`,
+		ValidArgs: []string{"--", "-"},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cmd.Help()
+		},
+	}
+
+	cmd.AddCommand(baselineSaveCmd(ctx))
+
+	return cmd
+}
+
+func baselineSaveCmd(ctx *config.RunContext) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "save",
+		Short: "Save the costs of a project as a baseline",
+		Long: `Save the costs of a project as a baseline
+
+The Infracost JSON output of the projects is saved to .infracost/baselines/NAME.json,
+or to --url, which can be a path or an HTTP(S), s3:// or gs:// URL so CI jobs of
+other branches can load it. HTTP URLs are uploaded with a PUT request.`,
+		Example: `  Save the costs of a Terraform directory as the prod baseline:
+
+      infracost baseline save --name prod --path /path/to/code
+
+  Save the baseline of all the projects in a config file to S3:
+
+      infracost baseline save --name prod --config-file infracost.yml --url s3://my-bucket/baselines/prod.json`,
+		ValidArgs: []string{"--", "-"},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			name, _ := cmd.Flags().GetString("name")
+			dest, _ := cmd.Flags().GetString("url")
+
+			if name == "" && dest == "" {
+				ui.PrintUsage(cmd)
+				return errors.New("--name or --url must be set")
+			}
+
+			if dest == "" {
+				if err := baseline.ValidateName(name); err != nil {
+					ui.PrintUsage(cmd)
+					return err
+				}
+			}
+
+			if err := loadPricingFlags(ctx.Config, cmd); err != nil {
+				return err
+			}
+
+			if !ctx.Config.PricingOffline && !ctx.Config.UsesAWSPriceList() {
+				if err := checkAPIKey(ctx.Config.APIKey, ctx.Config.PricingAPIEndpoint, ctx.Config.DefaultPricingAPIEndpoint); err != nil {
+					return err
+				}
+			}
+
+			err := loadRunFlags(ctx.Config, cmd)
+			if err != nil {
+				return err
+			}
+
+			err = checkRunConfig(cmd.ErrOrStderr(), ctx.Config)
+			if err != nil {
+				ui.PrintUsage(cmd)
+				return err
+			}
+
+			ctx.Config.Format = "json"
+			ctx.Config.ShowSkippedJSON = false
+
+			return runBaselineSave(cmd, ctx, name, dest)
+		},
+	}
+
+	addRunFlags(cmd)
+	addParallelismFlag(cmd)
+	addPricingFlags(cmd)
+
+	cmd.Flags().String("name", "", "Name of the baseline, e.g. prod")
+	cmd.Flags().String("url", "", "Path or URL to save the baseline to instead of .infracost/baselines/NAME.json")
+	cmd.Flags().Bool("terraform-use-state", false, "Use Terraform state instead of generating a plan. Applicable when path is a Terraform directory")
+	cmd.Flags().Bool("terraform-parse-hcl", false, "Parse the HCL directly instead of generating a Terraform plan. This option does not need credentials and is faster (experimental)")
+	cmd.Flags().StringSlice("terraform-var-file", nil, "Load variable files from the given file, similar to Terraform's -var-file flag. Only supported with --terraform-parse-hcl (experimental)")
+	cmd.Flags().StringSlice("terraform-var", nil, "Set a value for one of the input variables, similar to Terraform's -var flag. Only supported with --terraform-parse-hcl (experimental)")
+
+	return cmd
+}
+
+// runBaselineSave runs the projects like breakdown with the JSON format, then
+// saves the output as the baseline instead of printing it.
+func runBaselineSave(cmd *cobra.Command, runCtx *config.RunContext, name string, dest string) error {
+	var buf bytes.Buffer
+	cmd.SetOut(&buf)
+	defer cmd.SetOut(nil)
+
+	err := runMain(cmd, runCtx)
+	if err != nil {
+		return err
+	}
+
+	location, err := baseline.Save(name, dest, bytes.TrimSpace(buf.Bytes()))
+	if err != nil {
+		return err
+	}
+
+	if name == "" {
+		cmd.PrintErrf("Baseline saved to %s\n", location)
+	} else {
+		cmd.PrintErrf("Baseline %s saved to %s\n", name, location)
+	}
+
+	return nil
+}
+
+// compareToBaseline replaces the past resources of the projects with the ones of
+// the baseline, so their diff is against the baseline.
+func compareToBaseline(cmd *cobra.Command, ref string, projects []*schema.Project) error {
+	base, err := baseline.Load(ref)
+	if err != nil {
+		return err
+	}
+
+	unmatched := baseline.Apply(base, projects)
+	if len(unmatched) > 0 {
+		ui.PrintWarningf(cmd.ErrOrStderr(), "Projects not found in baseline %s, their resources are shown as added: %s", ref, strings.Join(unmatched, ", "))
+	}
+
+	return nil
+}
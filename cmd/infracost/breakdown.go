@@ -4,6 +4,7 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/infracost/infracost/internal/config"
+	"github.com/infracost/infracost/internal/output"
 	"github.com/infracost/infracost/internal/ui"
 )
 
@@ -23,16 +24,24 @@ func breakdownCmd(ctx *config.RunContext) *cobra.Command {
       infracost breakdown --path plan.json`,
 		ValidArgs: []string{"--", "-"},
 		RunE: func(cmd *cobra.Command, args []string) error {
-			if err := checkAPIKey(ctx.Config.APIKey, ctx.Config.PricingAPIEndpoint, ctx.Config.DefaultPricingAPIEndpoint); err != nil {
+			if err := loadPricingFlags(ctx.Config, cmd); err != nil {
 				return err
 			}
 
+			if !ctx.Config.PricingOffline && !ctx.Config.UsesAWSPriceList() {
+				if err := checkAPIKey(ctx.Config.APIKey, ctx.Config.PricingAPIEndpoint, ctx.Config.DefaultPricingAPIEndpoint); err != nil {
+					return err
+				}
+			}
+
 			err := loadRunFlags(ctx.Config, cmd)
 			if err != nil {
 				return err
 			}
 
 			ctx.SetContextValue("outputFormat", ctx.Config.Format)
+			ctx.Config.CompareToRef, _ = cmd.Flags().GetString("compare-to-ref")
+			ctx.Config.SelectChangedProjects, _ = cmd.Flags().GetBool("select-changed-projects")
 
 			err = checkRunConfig(cmd.ErrOrStderr(), ctx.Config)
 			if err != nil {
@@ -45,15 +54,35 @@ func breakdownCmd(ctx *config.RunContext) *cobra.Command {
 	}
 
 	addRunFlags(cmd)
+	addParallelismFlag(cmd)
+	addPricingFlags(cmd)
 
 	cmd.Flags().String("out-file", "", "Save output to a file, helpful with format flag")
+	cmd.Flags().String("compare-to-ref", "", "Git ref, e.g. origin/main, whose projects are run in a temporary worktree so the output has the cost changes since then")
+	cmd.Flags().Bool("select-changed-projects", false, "Only estimate the projects affected by the files changed since the base branch of the pull request, including changes to local modules they call. The base is --compare-to-ref or the base branch detected from the CI environment")
 	cmd.Flags().Bool("terraform-use-state", false, "Use Terraform state instead of generating a plan. Applicable when path is a Terraform directory")
 	cmd.Flags().String("format", "table", "Output format: json, table, html")
 	cmd.Flags().StringSlice("fields", []string{"monthlyQuantity", "unit", "monthlyCost"}, "Comma separated list of output fields: all,price,monthlyQuantity,unit,hourlyCost,monthlyCost.\nSupported by table and html output formats")
+	cmd.Flags().StringArray("filter", nil, "Only show resources matching key=value, keys: type, module, tag, provider, min-monthly-cost. Repeat to match all")
+	cmd.Flags().String("group-by", "", "Group the costs of the resources by the value of a tag, e.g. tag:team. Supported by table, json and html output formats")
+	cmd.Flags().String("granularity", "monthly", "Time period of the costs: hourly, daily, monthly, annual. Supported by table output format")
+	cmd.Flags().Int("projection-months", 0, "Project the total monthly cost over this number of months")
+	cmd.Flags().Float64("monthly-growth-percent", 0, "Percentage the projected monthly cost grows by each month, e.g. 10 as usage ramps up. Used with --projection-months")
+	cmd.Flags().Bool("show-price-tiers", false, "Show the usage range of each price tier of graduated prices. Supported by json and html output formats")
 
 	cmd.Flags().Bool("terraform-parse-hcl", false, "Parse the HCL directly instead of generating a Terraform plan. This option does not need credentials and is faster (experimental)")
 	cmd.Flags().StringSlice("terraform-var-file", nil, "Load variable files from the given file, similar to Terraform's -var-file flag. Only supported with --terraform-parse-hcl (experimental)")
 	cmd.Flags().StringSlice("terraform-var", nil, "Set a value for one of the input variables, similar to Terraform's -var flag. Only supported with --terraform-parse-hcl (experimental)")
+	addLockFileFlags(cmd)
+
+	cmd.Flags().Bool("show-recommendations", false, "Check resources for savings opportunities, e.g. gp2 volumes, idle NAT gateways, unattached Elastic IPs and previous generation instances, and list them with their estimated savings")
+
+	cmd.Flags().Bool("cache-results", false, "Reuse the estimates of projects whose committed files, usage files, currency and CLI version haven't changed since they were last cached. Cached projects are marked in the output")
+	cmd.Flags().Bool("wait-for-approval", false, "When guardrails are breached, wait for the change to be approved through INFRACOST_APPROVAL_CALLBACK_URL after sending it to INFRACOST_APPROVAL_WEBHOOK_URL. Approved changes exit zero")
+
+	_ = cmd.RegisterFlagCompletionFunc("granularity", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return output.Granularities, cobra.ShellCompDirectiveDefault
+	})
 
 	_ = cmd.RegisterFlagCompletionFunc("format", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
 		return validRunFormats, cobra.ShellCompDirectiveDefault
@@ -230,3 +230,7 @@ func TestBreakdownInitFlagsError(t *testing.T) {
 		},
 	)
 }
+
+func TestBreakdownPricingOfflineMissingSnapshot(t *testing.T) {
+	GoldenFileCommandTest(t, testutil.CalcGoldenFileTestdataDirName(), []string{"breakdown", "--path", "../../examples/terraform", "--pricing-offline", "--pricing-snapshot", "does-not-exist.json.gz"}, nil)
+}
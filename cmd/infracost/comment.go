@@ -1,27 +1,28 @@
 package main
 
 import (
-	"context"
 	"fmt"
-	"os"
 	"strconv"
+	"strings"
 
-	"github.com/open-policy-agent/opa/ast"
-	"github.com/open-policy-agent/opa/rego"
+	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 
 	"github.com/pkg/errors"
 
+	"github.com/infracost/infracost/internal/budget"
+	"github.com/infracost/infracost/internal/comment"
 	"github.com/infracost/infracost/internal/config"
 	"github.com/infracost/infracost/internal/output"
+	"github.com/infracost/infracost/internal/policy"
 	"github.com/infracost/infracost/internal/ui"
 )
 
 func commentCmd(ctx *config.RunContext) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "comment",
-		Short: "Post an Infracost comment to GitHub, GitLab, Azure Repos or Bitbucket",
-		Long:  "Post an Infracost comment to GitHub, GitLab, Azure Repos or Bitbucket",
+		Short: "Post an Infracost comment to GitHub, GitLab, Azure Repos, Bitbucket or Gerrit",
+		Long:  "Post an Infracost comment to GitHub, GitLab, Azure Repos, Bitbucket or Gerrit",
 		Example: `  Update the Infracost comment on a GitHub pull request:
 
       infracost comment github --repo my-org/my-repo --pull-request 3 --path infracost.json --behavior update --github-token $GITHUB_TOKEN
@@ -32,16 +33,25 @@ func commentCmd(ctx *config.RunContext) *cobra.Command {
 
   Post a new comment to an Azure Repos pull request:
 
-      infracost comment azure-repos --repo-url https://dev.azure.com/my-org/my-project/_git/my-repo --pull-request 3 --path infracost.json --behavior new --azure-access-token $AZURE_ACCESS_TOKEN`,
+      infracost comment azure-repos --repo-url https://dev.azure.com/my-org/my-project/_git/my-repo --pull-request 3 --path infracost.json --behavior new --azure-access-token $AZURE_ACCESS_TOKEN
+
+  Post a review message with a vote on the Cost-Review label to a Gerrit change:
+
+      infracost comment gerrit --gerrit-url https://gerrit.example.com --change 1234 --path infracost.json --vote-label Cost-Review --fail-on-increase 10% --gerrit-username $GERRIT_USERNAME --gerrit-password $GERRIT_PASSWORD`,
 		ValidArgs: []string{"--", "-"},
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return cmd.Help()
 		},
 	}
 
-	cmds := []*cobra.Command{commentGitHubCmd(ctx), commentGitLabCmd(ctx), commentAzureReposCmd(ctx), commentBitbucketCmd(ctx)}
+	cmds := []*cobra.Command{commentGitHubCmd(ctx), commentGitLabCmd(ctx), commentAzureReposCmd(ctx), commentBitbucketCmd(ctx), commentGerritCmd(ctx)}
 	for _, subCmd := range cmds {
 		subCmd.Flags().StringArray("policy-path", nil, "Path to Infracost policy files, glob patterns need quotes (experimental)")
+		subCmd.Flags().StringArray("policy-pack", nil, "HTTPS URL or oci:// reference of a policy pack whose policies are added to --policy-path (experimental)")
+		subCmd.Flags().Bool("show-rightsizing", false, "Add the smaller sizes recommended for resources from the utilization in their usage files to the comment")
+		subCmd.Flags().String("shard", "", "Index and number of the parallel CI jobs the estimate is split across, e.g. 2/4. The comment is only posted by the last shard to report, with the combined estimate of all of them")
+		subCmd.Flags().String("shard-run", "", "ID of the CI run the shards are part of, defaults to the pipeline ID or commit of the Infracost JSON files")
+		subCmd.Flags().String("shard-state", "vcs", "Where the shards store their estimates until all of them have reported: vcs to store them in comments that are deleted once the combined comment is posted, or an HTTP(S) URL they're uploaded under")
 	}
 
 	cmd.AddCommand(cmds...)
@@ -49,17 +59,37 @@ func commentCmd(ctx *config.RunContext) *cobra.Command {
 	return cmd
 }
 
+// commentBehaviorUsage is the usage of the --behavior flag of the comment
+// commands, all the platforms support the same behaviors.
+const commentBehaviorUsage = `Behavior when posting comment, one of:
+  update (default)  Update latest comment and delete the comments of previous runs on other projects
+  new               Create a new comment
+  hide-and-new      Hide previous matching comments and create a new comment
+  delete-and-new    Delete previous matching comments and create a new comment`
+
 func buildCommentBody(cmd *cobra.Command, ctx *config.RunContext, paths []string, mdOpts output.MarkdownOptions) ([]byte, error) {
-	inputs, err := output.LoadPaths(paths)
+	combined, err := combineCommentInputs(cmd, ctx, paths)
 	if err != nil {
 		return nil, err
 	}
 
-	combined, err := output.Combine(inputs)
+	return renderCommentBody(cmd, ctx, combined, mdOpts)
+}
+
+// combineCommentInputs combines the Infracost JSON files of the comment, and
+// shares the combined run to the dashboard when it's enabled.
+func combineCommentInputs(cmd *cobra.Command, ctx *config.RunContext, paths []string) (output.Root, error) {
+	inputs, err := output.LoadPaths(paths)
 	if err != nil {
-		return nil, err
+		return output.Root{}, err
+	}
+
+	combined, err := output.Combine(inputs, output.CombineOptions{})
+	if err != nil {
+		return output.Root{}, err
 	}
 	combined.IsCIRun = ctx.IsCIRun()
+	output.AddProjectGroups(&combined)
 
 	dryRun, _ := cmd.Flags().GetBool("dry-run")
 	if ctx.Config.EnableDashboard && !dryRun {
@@ -70,10 +100,23 @@ func buildCommentBody(cmd *cobra.Command, ctx *config.RunContext, paths []string
 		combined.RunID, combined.ShareURL = shareCombinedRun(ctx, combined, inputs)
 	}
 
+	return combined, nil
+}
+
+// renderCommentBody evaluates the policies against the combined output and
+// returns the markdown of the comment. The policy failures are returned as the
+// error with the body so the comment is still posted.
+func renderCommentBody(cmd *cobra.Command, ctx *config.RunContext, combined output.Root, mdOpts output.MarkdownOptions) ([]byte, error) {
 	var policyChecks output.PolicyCheck
 	policyPaths, _ := cmd.Flags().GetStringArray("policy-path")
+	packPaths, err := policyPackPaths(cmd, ctx)
+	if err != nil {
+		return nil, err
+	}
+	policyPaths = append(policyPaths, packPaths...)
+
 	if len(policyPaths) > 0 {
-		policyChecks, err = queryPolicy(policyPaths, combined)
+		policyChecks, err = policy.Evaluate(policyPaths, combined)
 		if err != nil {
 			return nil, err
 		}
@@ -82,11 +125,14 @@ func buildCommentBody(cmd *cobra.Command, ctx *config.RunContext, paths []string
 		ctx.SetContextValue("failedPolicyCount", len(policyChecks.Failures))
 	}
 
+	showRightsizing, _ := cmd.Flags().GetBool("show-rightsizing")
+
 	opts := output.Options{
 		DashboardEnabled: ctx.Config.EnableDashboard,
 		NoColor:          ctx.Config.NoColor,
 		ShowSkipped:      true,
 		PolicyChecks:     policyChecks,
+		ShowRightsizing:  showRightsizing,
 	}
 
 	b, err := output.ToMarkdown(combined, opts, mdOpts)
@@ -101,97 +147,200 @@ func buildCommentBody(cmd *cobra.Command, ctx *config.RunContext, paths []string
 	return b, nil
 }
 
-type PRNumber int
+// combineCommentShards returns the combined output of all the shards of the CI
+// run when --shard is set, once they've all reported. It returns false while
+// shards are still to report, so nothing is posted, and a func that deletes the
+// stored shards to call once the comment is posted.
+func combineCommentShards(cmd *cobra.Command, ctx *config.RunContext, platformHandler comment.PlatformHandler, out output.Root) (output.Root, func(), bool, error) {
+	cleanup := func() {}
 
-func (p *PRNumber) Set(value string) error {
-	if value == "" {
-		return nil
+	spec, _ := cmd.Flags().GetString("shard")
+	if spec == "" {
+		return out, cleanup, true, nil
 	}
 
-	v, err := strconv.Atoi(value)
-	*p = PRNumber(v)
+	shard, err := comment.ParseShard(spec)
+	if err != nil {
+		ui.PrintUsage(cmd)
+		return out, cleanup, false, err
+	}
+
+	run, _ := cmd.Flags().GetString("shard-run")
+	if run == "" && out.VCS != nil {
+		run = out.VCS.PipelineID
+		if run == "" {
+			run = out.VCS.CommitSHA
+		}
+	}
+	if run == "" {
+		ui.PrintUsage(cmd)
+		return out, cleanup, false, errors.New("--shard needs --shard-run to identify the CI run of the shards, it couldn't be detected from the Infracost JSON files")
+	}
+
+	var store comment.ShardStore
+	state, _ := cmd.Flags().GetString("shard-state")
+	switch {
+	case state == "vcs" && platformHandler != nil:
+		store = comment.NewVCSShardStore(platformHandler)
+	case strings.HasPrefix(state, "http://") || strings.HasPrefix(state, "https://"):
+		store = comment.NewURLShardStore(state)
+	default:
+		ui.PrintUsage(cmd)
+		return out, cleanup, false, fmt.Errorf("--shard-state %q isn't supported, set it to an HTTP(S) URL", state)
+	}
 
+	data, err := output.ToJSON(out, output.Options{})
 	if err != nil {
-		return errors.New("must be integer")
+		return out, cleanup, false, err
 	}
 
-	return nil
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+	all, reported, err := comment.CollectShards(ctx.Context(), store, run, shard, data, dryRun)
+	if err != nil {
+		return out, cleanup, false, err
+	}
+
+	if dryRun {
+		cmd.Printf("Shard %s not stored (--dry-run was specified)\n", shard)
+	}
+
+	if all == nil {
+		cmd.Printf("%d of %d shards have reported, the comment is posted by the last one\n", reported, shard.Count)
+		return out, cleanup, false, nil
+	}
+
+	inputs := make([]output.ReportInput, 0, len(all))
+	for i, b := range all {
+		r, err := output.Load(b)
+		if err != nil {
+			return out, cleanup, false, errors.Wrapf(err, "Error parsing shard %d/%d", i+1, shard.Count)
+		}
+
+		inputs = append(inputs, output.ReportInput{Root: r})
+	}
+
+	combined, err := output.Combine(inputs, output.CombineOptions{})
+	if err != nil {
+		return out, cleanup, false, err
+	}
+	combined.IsCIRun = out.IsCIRun
+	output.AddProjectGroups(&combined)
+
+	cmd.Printf("All %d shards have reported, posting their combined estimate\n", shard.Count)
+
+	if !dryRun {
+		cleanup = func() {
+			err := store.DeleteShards(ctx.Context(), run)
+			if err != nil {
+				log.Errorf("Error deleting the stored shards: %s", err)
+			}
+		}
+	}
+
+	return combined, cleanup, true, nil
 }
 
-func (p *PRNumber) String() string {
-	return fmt.Sprintf("%d", *p)
+// commentTarget returns the target of the comments in their keys, the pull or
+// merge request number, or the commit if there's none.
+func commentTarget(number int, commit string) string {
+	if number != 0 {
+		return strconv.Itoa(number)
+	}
+
+	return commit
 }
 
-func (p *PRNumber) Type() string {
-	return "int"
+// commentProjectNames returns the names of the projects of the output, the
+// comments of the runs on other projects are stale.
+func commentProjectNames(out output.Root) []string {
+	names := make([]string, 0, len(out.Projects))
+	for _, p := range out.Projects {
+		names = append(names, p.Name)
+	}
+
+	return names
 }
 
-func queryPolicy(policyPaths []string, input output.Root) (output.PolicyCheck, error) {
-	checks := output.PolicyCheck{
-		Enabled: true,
+// commentCostLabelBands returns the bands of the cost labels of the pull
+// request, or nil if neither --cost-labels nor --cost-label-band is set.
+func commentCostLabelBands(cmd *cobra.Command) ([]output.CostLabelBand, error) {
+	specs, _ := cmd.Flags().GetStringArray("cost-label-band")
+	if enabled, _ := cmd.Flags().GetBool("cost-labels"); !enabled && len(specs) == 0 {
+		return nil, nil
 	}
 
-	inputValue, err := ast.InterfaceToValue(input)
-	if err != nil {
-		return checks, fmt.Errorf("Unable to process Infracost output into Rego input: %s", err.Error())
-	}
-
-	ctx := context.Background()
-	r := rego.New(
-		rego.Query("data.infracost.deny"),
-		rego.ParsedInput(inputValue),
-		rego.Load(policyPaths, func(abspath string, info os.FileInfo, depth int) bool {
-			return false
-		}),
-	)
-	pq, err := r.PrepareForEval(ctx)
-	if err != nil {
-		return checks, fmt.Errorf("Unable to query provided policies: %s", err.Error())
+	if len(specs) == 0 {
+		specs = output.DefaultCostLabelBands
 	}
 
-	res, err := pq.Eval(ctx)
-	if err != nil {
-		return checks, err
+	return output.ParseCostLabelBands(specs)
+}
+
+// printCostLabel prints the cost label set on the pull request, or the one
+// that would be set with --dry-run.
+func printCostLabel(cmd *cobra.Command, label string, platform string, dryRun bool) {
+	if dryRun {
+		if label == "" {
+			cmd.Println("No cost label, the monthly cost change is in none of the bands")
+		} else {
+			cmd.Printf("Cost label: %s\n", label)
+		}
+		cmd.Printf("Cost labels not set on %s (--dry-run was specified)\n", platform)
+		return
 	}
 
-	if len(res) == 0 {
-		return checks, fmt.Errorf("The provided polices returned no valid data.infracost.deny rules. Please check that the policies are formatted correctly.")
+	if label == "" {
+		cmd.Printf("Cost labels removed on %s\n", platform)
+	} else {
+		cmd.Printf("Cost label %s set on %s\n", label, platform)
 	}
+}
 
-	for _, e := range res[0].Expressions {
-		switch v := e.Value.(type) {
-		case map[string]interface{}:
-			readPolicyOut(v, &checks)
-		case []interface{}:
-			for _, ii := range v {
-				if m, ok := ii.(map[string]interface{}); ok {
-					readPolicyOut(m, &checks)
-				}
-			}
+// commentFailureReasons returns why the output fails, i.e. the costs exceed the
+// thresholds, the output has blocking guardrail violations or tag policies or
+// the policies failed, for the statuses and votes set with the comments.
+func commentFailureReasons(out output.Root, thresholds *config.Budget, policyFailed bool) []string {
+	var reasons []string
+
+	if thresholds != nil {
+		for _, v := range budget.Check(out, []*config.Budget{thresholds}) {
+			reasons = append(reasons, v.Message)
 		}
 	}
+	if n := out.BlockingGuardrailViolations(); n > 0 {
+		reasons = append(reasons, fmt.Sprintf("%d blocking guardrail violations", n))
+	}
+	if n := out.BlockingTagPolicies(); n > 0 {
+		reasons = append(reasons, fmt.Sprintf("%d blocking tag policies failed", n))
+	}
+	if policyFailed {
+		reasons = append(reasons, "policy checks failed")
+	}
 
-	return checks, nil
+	return reasons
 }
 
-func readPolicyOut(v map[string]interface{}, checks *output.PolicyCheck) {
-	if _, ok := v["msg"]; !ok {
-		checks.Failures = append(checks.Failures, "Policy rule invalid as it did not contain {msg: string} property in output object. Please edit rule output object.")
-		return
-	}
-	msg := v["msg"].(string)
+type PRNumber int
 
-	if _, ok := v["failed"]; !ok {
-		checks.Failures = append(checks.Failures, fmt.Sprintf("Policy rule: [%s] did not contain {failed: bool} output property. Please edit rule output object.", msg))
-		return
+func (p *PRNumber) Set(value string) error {
+	if value == "" {
+		return nil
 	}
 
-	failed, _ := v["failed"].(bool)
+	v, err := strconv.Atoi(value)
+	*p = PRNumber(v)
 
-	if failed {
-		checks.Failures = append(checks.Failures, msg)
-		return
+	if err != nil {
+		return errors.New("must be integer")
 	}
 
-	checks.Passed = append(checks.Passed, msg)
+	return nil
+}
+
+func (p *PRNumber) String() string {
+	return fmt.Sprintf("%d", *p)
+}
+
+func (p *PRNumber) Type() string {
+	return "int"
 }
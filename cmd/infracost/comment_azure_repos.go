@@ -9,14 +9,13 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/infracost/infracost/internal/apiclient"
+	"github.com/infracost/infracost/internal/budget"
 	"github.com/infracost/infracost/internal/comment"
 	"github.com/infracost/infracost/internal/config"
 	"github.com/infracost/infracost/internal/output"
 	"github.com/infracost/infracost/internal/ui"
 )
 
-var validCommentAzureReposBehaviors = []string{"update", "new", "delete-and-new"}
-
 func commentAzureReposCmd(ctx *config.RunContext) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "azure-repos",
@@ -24,7 +23,11 @@ func commentAzureReposCmd(ctx *config.RunContext) *cobra.Command {
 		Long:  "Post an Infracost comment to Azure Repos",
 		Example: `  Update comment on a pull request:
 
-      infracost comment azure-repos --repo-url https://dev.azure.com/my-org/my-project/_git/my-repo --pull-request 3 --path infracost.json --azure-access-token $AZURE_ACCESS_TOKEN`,
+      infracost comment azure-repos --repo-url https://dev.azure.com/my-org/my-project/_git/my-repo --pull-request 3 --path infracost.json --azure-access-token $AZURE_ACCESS_TOKEN
+
+  Authenticate with a service connection, set a failed status when the cost increase is over 10% and resolve the comment threads of previous runs:
+
+      infracost comment azure-repos --repo-url https://dev.azure.com/my-org/my-project/_git/my-repo --pull-request 3 --path infracost.json --azure-tenant-id $TENANT_ID --azure-client-id $CLIENT_ID --azure-client-secret $CLIENT_SECRET --status --fail-on-increase 10% --resolve-stale-threads`,
 		ValidArgs: []string{"--", "-"},
 		RunE: func(cmd *cobra.Command, args []string) error {
 			ctx.SetContextValue("platform", "azure-repos")
@@ -32,10 +35,29 @@ func commentAzureReposCmd(ctx *config.RunContext) *cobra.Command {
 			var err error
 
 			token, _ := cmd.Flags().GetString("azure-access-token")
+			tenantID, _ := cmd.Flags().GetString("azure-tenant-id")
+			clientID, _ := cmd.Flags().GetString("azure-client-id")
+			clientSecret, _ := cmd.Flags().GetString("azure-client-secret")
+			if token == "" && clientID == "" {
+				ui.PrintUsage(cmd)
+				return fmt.Errorf("either --azure-access-token or --azure-client-id is required")
+			}
+
 			tag, _ := cmd.Flags().GetString("tag")
 			extra := comment.AzureReposExtra{
-				Token: token,
-				Tag:   tag,
+				Token:        token,
+				TenantID:     tenantID,
+				ClientID:     clientID,
+				ClientSecret: clientSecret,
+				Tag:          tag,
+			}
+
+			failOnIncrease, _ := cmd.Flags().GetString("fail-on-increase")
+			failOnTotal, _ := cmd.Flags().GetString("fail-on-total")
+			thresholds, err := budget.ParseThresholds(failOnIncrease, failOnTotal)
+			if err != nil {
+				ui.PrintUsage(cmd)
+				return err
 			}
 
 			prNumber, _ := cmd.Flags().GetInt("pull-request")
@@ -55,15 +77,26 @@ func commentAzureReposCmd(ctx *config.RunContext) *cobra.Command {
 			}
 
 			behavior, _ := cmd.Flags().GetString("behavior")
-			if behavior != "" && !contains(validCommentAzureReposBehaviors, behavior) {
+			if behavior != "" && !contains(comment.Behaviors, behavior) {
 				ui.PrintUsage(cmd)
-				return fmt.Errorf("--behavior only supports %s", strings.Join(validCommentAzureReposBehaviors, ", "))
+				return fmt.Errorf("--behavior only supports %s", strings.Join(comment.Behaviors, ", "))
 			}
 			ctx.SetContextValue("behavior", behavior)
 
 			paths, _ := cmd.Flags().GetStringArray("path")
 
-			body, err := buildCommentBody(cmd, ctx, paths, output.MarkdownOptions{
+			combined, err := combineCommentInputs(cmd, ctx, paths)
+			if err != nil {
+				return err
+			}
+
+			combined, cleanupShards, ok, err := combineCommentShards(cmd, ctx, commentHandler.PlatformHandler, combined)
+			if err != nil || !ok {
+				return err
+			}
+			commentHandler.Key = comment.CommentKey(repoURL, strconv.Itoa(prNumber), commentProjectNames(combined))
+
+			body, err := renderCommentBody(cmd, ctx, combined, output.MarkdownOptions{
 				WillUpdate:          prNumber != 0 && behavior == "update",
 				WillReplace:         prNumber != 0 && behavior == "delete-and-new",
 				IncludeFeedbackLink: true,
@@ -83,6 +116,7 @@ func commentAzureReposCmd(ctx *config.RunContext) *cobra.Command {
 				if err != nil {
 					return err
 				}
+				cleanupShards()
 
 				pricingClient := apiclient.NewPricingAPIClient(ctx)
 				err = pricingClient.AddEvent("infracost-comment", ctx.EventEnv())
@@ -96,6 +130,35 @@ func commentAzureReposCmd(ctx *config.RunContext) *cobra.Command {
 				cmd.Println("Comment not posted to Azure Repos (--dry-run was specified)")
 			}
 
+			if setStatus, _ := cmd.Flags().GetBool("status"); setStatus {
+				status := azureReposPRStatus(combined, thresholds, policyFailure != nil)
+
+				if !dryRun {
+					err = comment.SetAzureReposPRStatus(ctx.Context(), repoURL, prNumber, extra, status)
+					if err != nil {
+						return err
+					}
+
+					cmd.Printf("Pull request status set to %s on Azure Repos\n", status.State)
+				} else {
+					cmd.Printf("\nStatus: %s (%s)\n", status.State, status.Description)
+					cmd.Println("Pull request status not set on Azure Repos (--dry-run was specified)")
+				}
+			}
+
+			if resolveStale, _ := cmd.Flags().GetBool("resolve-stale-threads"); resolveStale {
+				if !dryRun {
+					resolved, err := comment.ResolveAzureReposThreads(ctx.Context(), repoURL, prNumber, extra)
+					if err != nil {
+						return err
+					}
+
+					cmd.Printf("Resolved %d stale comment threads on Azure Repos\n", resolved)
+				} else {
+					cmd.Println("Stale comment threads not resolved on Azure Repos (--dry-run was specified)")
+				}
+			}
+
 			if policyFailure != nil {
 				return policyFailure
 			}
@@ -104,15 +167,16 @@ func commentAzureReposCmd(ctx *config.RunContext) *cobra.Command {
 		},
 	}
 
-	cmd.Flags().String("behavior", "update", `Behavior when posting comment, one of:
-  update (default)  Update latest comment
-  new               Create a new comment
-  delete-and-new    Delete previous matching comments and create a new comment`)
+	cmd.Flags().String("behavior", "update", commentBehaviorUsage)
 	_ = cmd.RegisterFlagCompletionFunc("behavior", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
-		return validCommentAzureReposBehaviors, cobra.ShellCompDirectiveDefault
+		return comment.Behaviors, cobra.ShellCompDirectiveDefault
 	})
 	cmd.Flags().String("azure-access-token", "", "Azure DevOps access token")
-	_ = cmd.MarkFlagRequired("azure-access-token")
+	cmd.Flags().String("azure-client-id", "", "Client ID of the service principal of an Azure service connection, used instead of an access token")
+	cmd.Flags().String("azure-client-secret", "", "Client secret of the service principal of an Azure service connection")
+	cmd.Flags().String("azure-tenant-id", "", "Tenant ID of the service principal of an Azure service connection")
+	cmd.Flags().String("fail-on-increase", "", "Set a failed status when the monthly cost increase is over an amount, e.g. 500, or a percentage of the past cost, e.g. 10%")
+	cmd.Flags().String("fail-on-total", "", "Set a failed status when the total monthly cost is over an amount, e.g. 5000")
 	cmd.Flags().StringArrayP("path", "p", []string{}, "Path to Infracost JSON files, glob patterns need quotes")
 	_ = cmd.MarkFlagRequired("path")
 	_ = cmd.MarkFlagFilename("path", "json")
@@ -121,8 +185,27 @@ func commentAzureReposCmd(ctx *config.RunContext) *cobra.Command {
 	_ = cmd.MarkFlagRequired("pull-request")
 	cmd.Flags().String("repo-url", "", "Repository URL, e.g. https://dev.azure.com/my-org/my-project/_git/my-repo")
 	_ = cmd.MarkFlagRequired("repo-url")
+	cmd.Flags().Bool("resolve-stale-threads", false, "Resolve the active comment threads of previous runs, except the latest one")
+	cmd.Flags().Bool("status", false, "Also set a pull request status, failed when the thresholds are exceeded or there are blocking policy violations")
 	cmd.Flags().String("tag", "", "Customize hidden markdown tag used to detect comments posted by Infracost")
 	cmd.Flags().Bool("dry-run", false, "Generate comment without actually posting to Azure Repos")
 
 	return cmd
 }
+
+// azureReposPRStatus fails the pull request status when the output fails the
+// thresholds or the policies.
+func azureReposPRStatus(out output.Root, thresholds *config.Budget, policyFailed bool) comment.AzureReposPRStatus {
+	status := comment.AzureReposPRStatus{
+		State:       comment.AzureReposStatusSucceeded,
+		Description: output.CheckRunTitle(out),
+		TargetURL:   out.ShareURL,
+	}
+
+	if reasons := commentFailureReasons(out, thresholds, policyFailed); len(reasons) > 0 {
+		status.State = comment.AzureReposStatusFailed
+		status.Description = strings.Join(reasons, "; ")
+	}
+
+	return status
+}
@@ -15,8 +15,6 @@ import (
 	"github.com/infracost/infracost/internal/ui"
 )
 
-var validCommentBitbucketBehaviors = []string{"update", "new", "delete-and-new"}
-
 func commentBitbucketCmd(ctx *config.RunContext) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "bitbucket",
@@ -28,7 +26,11 @@ func commentBitbucketCmd(ctx *config.RunContext) *cobra.Command {
 
   Post a new comment to a commit:
 
-      infracost comment bitbucket --repo my-org/my-repo --commit 2ca7182 --path infracost.json --behavior delete-and-new --bitbucket-token $BITBUCKET_TOKEN`,
+      infracost comment bitbucket --repo my-org/my-repo --commit 2ca7182 --path infracost.json --behavior delete-and-new --bitbucket-token $BITBUCKET_TOKEN
+
+  Update comment on a pull request and create a Code Insights report with annotations:
+
+      infracost comment bitbucket --repo my-org/my-repo --pull-request 3 --path infracost.json --code-insights --bitbucket-token $BITBUCKET_TOKEN`,
 		ValidArgs: []string{"--", "-"},
 		RunE: func(cmd *cobra.Command, args []string) error {
 			ctx.SetContextValue("platform", "bitbucket")
@@ -69,15 +71,26 @@ func commentBitbucketCmd(ctx *config.RunContext) *cobra.Command {
 			}
 
 			behavior, _ := cmd.Flags().GetString("behavior")
-			if behavior != "" && !contains(validCommentBitbucketBehaviors, behavior) {
+			if behavior != "" && !contains(comment.Behaviors, behavior) {
 				ui.PrintUsage(cmd)
-				return fmt.Errorf("--behavior only supports %s", strings.Join(validCommentBitbucketBehaviors, ", "))
+				return fmt.Errorf("--behavior only supports %s", strings.Join(comment.Behaviors, ", "))
 			}
 			ctx.SetContextValue("behavior", behavior)
 
 			paths, _ := cmd.Flags().GetStringArray("path")
 
-			body, err := buildCommentBody(cmd, ctx, paths, output.MarkdownOptions{
+			combined, err := combineCommentInputs(cmd, ctx, paths)
+			if err != nil {
+				return err
+			}
+
+			combined, cleanupShards, ok, err := combineCommentShards(cmd, ctx, commentHandler.PlatformHandler, combined)
+			if err != nil || !ok {
+				return err
+			}
+			commentHandler.Key = comment.CommentKey(repo, commentTarget(prNumber, commit), commentProjectNames(combined))
+
+			body, err := renderCommentBody(cmd, ctx, combined, output.MarkdownOptions{
 				WillUpdate:          prNumber != 0 && behavior == "update",
 				WillReplace:         prNumber != 0 && behavior == "delete-and-new",
 				IncludeFeedbackLink: true,
@@ -98,6 +111,7 @@ func commentBitbucketCmd(ctx *config.RunContext) *cobra.Command {
 				if err != nil {
 					return err
 				}
+				cleanupShards()
 
 				pricingClient := apiclient.NewPricingAPIClient(ctx)
 				err = pricingClient.AddEvent("infracost-comment", ctx.EventEnv())
@@ -111,6 +125,25 @@ func commentBitbucketCmd(ctx *config.RunContext) *cobra.Command {
 				cmd.Println("Comment not posted to Bitbucket (--dry-run was specified)")
 			}
 
+			if codeInsights, _ := cmd.Flags().GetBool("code-insights"); codeInsights {
+				report := bitbucketReport(combined, policyFailure != nil)
+
+				if !dryRun {
+					err = comment.CreateBitbucketReport(ctx.Context(), repo, commit, prNumber, extra, report)
+					if err != nil {
+						return err
+					}
+
+					cmd.Println("Code Insights report created on Bitbucket")
+				} else {
+					cmd.Printf("\n%s: %s\n", report.Title, report.Details)
+					for _, a := range report.Annotations {
+						cmd.Printf("  %s %s:%d %s\n", a.Severity, a.Path, a.Line, a.Summary)
+					}
+					cmd.Println("Code Insights report not created on Bitbucket (--dry-run was specified)")
+				}
+			}
+
 			if policyFailure != nil {
 				return policyFailure
 			}
@@ -119,16 +152,14 @@ func commentBitbucketCmd(ctx *config.RunContext) *cobra.Command {
 		},
 	}
 
-	cmd.Flags().String("behavior", "update", `Behavior when posting comment, one of:
-  update (default)  Update latest comment
-  new               Create a new comment
-  delete-and-new    Delete previous matching comments and create a new comment`)
+	cmd.Flags().String("behavior", "update", commentBehaviorUsage)
 	_ = cmd.RegisterFlagCompletionFunc("behavior", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
-		return validCommentBitbucketBehaviors, cobra.ShellCompDirectiveDefault
+		return comment.Behaviors, cobra.ShellCompDirectiveDefault
 	})
 	cmd.Flags().String("bitbucket-server-url", "https://bitbucket.org", "Bitbucket Server URL")
 	cmd.Flags().String("bitbucket-token", "", "Bitbucket access token. Use 'username:app-password' for Bitbucket Cloud and HTTP access token for Bitbucket Server")
 	_ = cmd.MarkFlagRequired("bitbucket-token")
+	cmd.Flags().Bool("code-insights", false, "Also create a Code Insights report with annotations at the lines of the costliest changes and policy violations")
 	cmd.Flags().String("commit", "", "Commit SHA to post comment on, mutually exclusive with pull-request. Not available when bitbucket-server-url is set")
 	cmd.Flags().StringArrayP("path", "p", []string{}, "Path to Infracost JSON files, glob patterns need quotes")
 	_ = cmd.MarkFlagRequired("path")
@@ -142,3 +173,44 @@ func commentBitbucketCmd(ctx *config.RunContext) *cobra.Command {
 
 	return cmd
 }
+
+// bitbucketSeverities maps the levels of check annotations to the severities of
+// Code Insights annotations.
+var bitbucketSeverities = map[string]string{
+	output.CheckAnnotationNotice:  comment.BitbucketSeverityLow,
+	output.CheckAnnotationWarning: comment.BitbucketSeverityMedium,
+	output.CheckAnnotationFailure: comment.BitbucketSeverityHigh,
+}
+
+// bitbucketReport returns the Code Insights report of the output. It fails when
+// the output has blocking guardrail violations or tag policies or the policies
+// failed.
+func bitbucketReport(out output.Root, policyFailed bool) comment.BitbucketReport {
+	nonCompliant := 0
+	for _, t := range out.TagPolicies {
+		nonCompliant += len(t.NonCompliantResources)
+	}
+
+	report := comment.BitbucketReport{
+		Title:   "Infracost",
+		Details: output.CheckRunTitle(out),
+		Passed:  !policyFailed && out.BlockingGuardrailViolations() == 0 && out.BlockingTagPolicies() == 0,
+		Data: []comment.BitbucketReportData{
+			{Title: "Monthly cost", Value: output.FormatCost(out.Currency, out.TotalMonthlyCost)},
+			{Title: "Guardrail violations", Value: strconv.Itoa(len(out.GuardrailViolations))},
+			{Title: "Non-compliant resources", Value: strconv.Itoa(nonCompliant)},
+		},
+	}
+
+	for i, a := range output.CheckAnnotations(out, maxCheckRunCostChanges) {
+		report.Annotations = append(report.Annotations, comment.BitbucketReportAnnotation{
+			ExternalID: fmt.Sprintf("infracost-%d", i+1),
+			Path:       a.Path,
+			Line:       a.StartLine,
+			Summary:    fmt.Sprintf("%s: %s", a.Title, a.Message),
+			Severity:   bitbucketSeverities[a.Level],
+		})
+	}
+
+	return report
+}
@@ -0,0 +1,145 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/infracost/infracost/internal/apiclient"
+	"github.com/infracost/infracost/internal/budget"
+	"github.com/infracost/infracost/internal/comment"
+	"github.com/infracost/infracost/internal/config"
+	"github.com/infracost/infracost/internal/output"
+)
+
+func commentGerritCmd(ctx *config.RunContext) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "gerrit",
+		Short: "Post an Infracost review message to Gerrit",
+		Long:  "Post an Infracost review message to Gerrit, with an optional vote on a label",
+		Example: `  Post a review message on the current revision of a change:
+
+      infracost comment gerrit --gerrit-url https://gerrit.example.com --change 1234 --path infracost.json --gerrit-username $GERRIT_USERNAME --gerrit-password $GERRIT_PASSWORD
+
+  Vote -1 on the Cost-Review label when the cost increase is over 10%, and +1 otherwise:
+
+      infracost comment gerrit --gerrit-url https://gerrit.example.com --change my-project~1234 --path infracost.json --vote-label Cost-Review --fail-on-increase 10% --gerrit-username $GERRIT_USERNAME --gerrit-password $GERRIT_PASSWORD`,
+		ValidArgs: []string{"--", "-"},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx.SetContextValue("platform", "gerrit")
+			ctx.SetContextValue("targetType", "change")
+
+			serverURL, _ := cmd.Flags().GetString("gerrit-url")
+			username, _ := cmd.Flags().GetString("gerrit-username")
+			password, _ := cmd.Flags().GetString("gerrit-password")
+			tag, _ := cmd.Flags().GetString("tag")
+			extra := comment.GerritExtra{
+				Username: username,
+				Password: password,
+				Tag:      tag,
+			}
+
+			change, _ := cmd.Flags().GetString("change")
+			revision, _ := cmd.Flags().GetString("revision")
+
+			failOnIncrease, _ := cmd.Flags().GetString("fail-on-increase")
+			failOnTotal, _ := cmd.Flags().GetString("fail-on-total")
+			thresholds, err := budget.ParseThresholds(failOnIncrease, failOnTotal)
+			if err != nil {
+				return err
+			}
+
+			paths, _ := cmd.Flags().GetStringArray("path")
+
+			combined, err := combineCommentInputs(cmd, ctx, paths)
+			if err != nil {
+				return err
+			}
+
+			combined, cleanupShards, ok, err := combineCommentShards(cmd, ctx, nil, combined)
+			if err != nil || !ok {
+				return err
+			}
+
+			body, err := renderCommentBody(cmd, ctx, combined, output.MarkdownOptions{
+				IncludeFeedbackLink: true,
+				BasicSyntax:         true,
+			})
+			var policyFailure output.PolicyCheckFailures
+			if err != nil {
+				if v, ok := err.(output.PolicyCheckFailures); ok {
+					policyFailure = v
+				} else {
+					return err
+				}
+			}
+
+			review := comment.GerritReview{Message: string(body)}
+
+			var reasons []string
+			if label, _ := cmd.Flags().GetString("vote-label"); label != "" {
+				review.Label = label
+				review.Vote, _ = cmd.Flags().GetInt("vote-pass")
+
+				reasons = commentFailureReasons(combined, thresholds, policyFailure != nil)
+				if len(reasons) > 0 {
+					review.Vote, _ = cmd.Flags().GetInt("vote-fail")
+				}
+			}
+
+			dryRun, _ := cmd.Flags().GetBool("dry-run")
+			if !dryRun {
+				err = comment.PostGerritReview(ctx.Context(), serverURL, change, revision, extra, review)
+				if err != nil {
+					return err
+				}
+				cleanupShards()
+
+				pricingClient := apiclient.NewPricingAPIClient(ctx)
+				err = pricingClient.AddEvent("infracost-comment", ctx.EventEnv())
+				if err != nil {
+					log.Errorf("Error reporting event: %s", err)
+				}
+
+				cmd.Println("Review posted to Gerrit")
+			} else {
+				cmd.Println(string(body))
+				if review.Label != "" {
+					cmd.Printf("Vote: %s %+d\n", review.Label, review.Vote)
+					if len(reasons) > 0 {
+						cmd.Printf("  %s\n", strings.Join(reasons, "\n  "))
+					}
+				}
+				cmd.Println("Review not posted to Gerrit (--dry-run was specified)")
+			}
+
+			if policyFailure != nil {
+				return policyFailure
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().String("change", "", "Change to post the review on, its number or an identifier like project~number")
+	_ = cmd.MarkFlagRequired("change")
+	cmd.Flags().String("fail-on-increase", "", "Vote --vote-fail when the monthly cost increase is over an amount, e.g. 500, or a percentage of the past cost, e.g. 10%")
+	cmd.Flags().String("fail-on-total", "", "Vote --vote-fail when the total monthly cost is over an amount, e.g. 5000")
+	cmd.Flags().String("gerrit-password", "", "Gerrit HTTP password")
+	cmd.Flags().String("gerrit-url", "", "Gerrit server URL, e.g. https://gerrit.example.com")
+	_ = cmd.MarkFlagRequired("gerrit-url")
+	cmd.Flags().String("gerrit-username", "", "Gerrit username")
+	cmd.Flags().StringArrayP("path", "p", []string{}, "Path to Infracost JSON files, glob patterns need quotes")
+	_ = cmd.MarkFlagRequired("path")
+	_ = cmd.MarkFlagFilename("path", "json")
+	cmd.Flags().String("revision", "current", "Revision of the change to post the review on")
+	cmd.Flags().String("tag", "", "Customize the tag of the review messages, so the messages of different pipelines are shown separately")
+	cmd.Flags().Int("vote-fail", -1, "Vote on --vote-label when the thresholds are exceeded or there are blocking policy violations")
+	cmd.Flags().String("vote-label", "", "Label to vote on, e.g. Cost-Review. No vote is posted when it's empty")
+	cmd.Flags().Int("vote-pass", 1, "Vote on --vote-label otherwise")
+	cmd.Flags().Bool("dry-run", false, "Generate review message without actually posting to Gerrit")
+
+	return cmd
+}
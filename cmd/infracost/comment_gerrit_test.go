@@ -0,0 +1,17 @@
+package main_test
+
+import (
+	"testing"
+
+	"github.com/infracost/infracost/internal/testutil"
+)
+
+func TestCommentGerritHelp(t *testing.T) {
+	GoldenFileCommandTest(t, testutil.CalcGoldenFileTestdataDirName(), []string{"comment", "gerrit", "--help"}, nil)
+}
+
+func TestCommentGerritChange(t *testing.T) {
+	GoldenFileCommandTest(t, testutil.CalcGoldenFileTestdataDirName(),
+		[]string{"comment", "gerrit", "--gerrit-url", "https://gerrit.example.com", "--change", "1234", "--path", "./testdata/terraform_v0.14_breakdown.json", "--vote-label", "Cost-Review", "--dry-run"},
+		nil)
+}
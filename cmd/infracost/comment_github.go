@@ -15,7 +15,9 @@ import (
 	"github.com/infracost/infracost/internal/ui"
 )
 
-var validCommentGitHubBehaviors = []string{"update", "new", "hide-and-new", "delete-and-new"}
+// maxCheckRunCostChanges is how many of the resources whose costs change the
+// most are annotated in a GitHub check run or a GitLab code quality report.
+const maxCheckRunCostChanges = 10
 
 func commentGitHubCmd(ctx *config.RunContext) *cobra.Command {
 	cmd := &cobra.Command{
@@ -28,7 +30,19 @@ func commentGitHubCmd(ctx *config.RunContext) *cobra.Command {
 
   Post a new comment to a commit:
 
-      infracost comment github --repo my-org/my-repo --commit 2ca7182 --path infracost.json --behavior hide-and-new --github-token $GITHUB_TOKEN`,
+      infracost comment github --repo my-org/my-repo --commit 2ca7182 --path infracost.json --behavior hide-and-new --github-token $GITHUB_TOKEN
+
+  Update comment on a pull request and create a check run with annotations:
+
+      infracost comment github --repo my-org/my-repo --pull-request 3 --path infracost.json --check-run --github-token $GITHUB_TOKEN
+
+  Update comment on a pull request and label it with the band of its monthly cost change, e.g. cost/increase-high:
+
+      infracost comment github --repo my-org/my-repo --pull-request 3 --path infracost.json --cost-labels --github-token $GITHUB_TOKEN
+
+  Post the combined comment of an estimate split across 4 parallel jobs, run in each of the jobs:
+
+      infracost comment github --repo my-org/my-repo --pull-request 3 --path infracost.json --shard $SHARD/4 --github-token $GITHUB_TOKEN`,
 		ValidArgs: []string{"--", "-"},
 		RunE: func(cmd *cobra.Command, args []string) error {
 			ctx.SetContextValue("platform", "github")
@@ -69,15 +83,36 @@ func commentGitHubCmd(ctx *config.RunContext) *cobra.Command {
 			}
 
 			behavior, _ := cmd.Flags().GetString("behavior")
-			if behavior != "" && !contains(validCommentGitHubBehaviors, behavior) {
+			if behavior != "" && !contains(comment.Behaviors, behavior) {
 				ui.PrintUsage(cmd)
-				return fmt.Errorf("--behavior only supports %s", strings.Join(validCommentGitHubBehaviors, ", "))
+				return fmt.Errorf("--behavior only supports %s", strings.Join(comment.Behaviors, ", "))
 			}
 			ctx.SetContextValue("behavior", behavior)
 
+			labelBands, err := commentCostLabelBands(cmd)
+			if err != nil {
+				ui.PrintUsage(cmd)
+				return err
+			}
+			if labelBands != nil && prNumber == 0 {
+				ui.PrintUsage(cmd)
+				return fmt.Errorf("--cost-labels and --cost-label-band need --pull-request")
+			}
+
 			paths, _ := cmd.Flags().GetStringArray("path")
 
-			body, err := buildCommentBody(cmd, ctx, paths, output.MarkdownOptions{
+			combined, err := combineCommentInputs(cmd, ctx, paths)
+			if err != nil {
+				return err
+			}
+
+			combined, cleanupShards, ok, err := combineCommentShards(cmd, ctx, commentHandler.PlatformHandler, combined)
+			if err != nil || !ok {
+				return err
+			}
+			commentHandler.Key = comment.CommentKey(repo, commentTarget(prNumber, commit), commentProjectNames(combined))
+
+			body, err := renderCommentBody(cmd, ctx, combined, output.MarkdownOptions{
 				WillUpdate:          prNumber != 0 && behavior == "update",
 				WillReplace:         prNumber != 0 && behavior == "delete-and-new",
 				IncludeFeedbackLink: true,
@@ -97,6 +132,7 @@ func commentGitHubCmd(ctx *config.RunContext) *cobra.Command {
 				if err != nil {
 					return err
 				}
+				cleanupShards()
 
 				pricingClient := apiclient.NewPricingAPIClient(ctx)
 				err = pricingClient.AddEvent("infracost-comment", ctx.EventEnv())
@@ -110,6 +146,53 @@ func commentGitHubCmd(ctx *config.RunContext) *cobra.Command {
 				cmd.Println("Comment not posted to GitHub (--dry-run was specified)")
 			}
 
+			if labelBands != nil {
+				label := output.CostLabel(combined, labelBands)
+				if !dryRun {
+					err = comment.SetGitHubPullRequestLabel(ctx.Context(), repo, prNumber, extra, label, output.CostLabels(labelBands))
+					if err != nil {
+						return err
+					}
+				}
+
+				printCostLabel(cmd, label, "GitHub", dryRun)
+			}
+
+			if createCheckRun, _ := cmd.Flags().GetBool("check-run"); createCheckRun {
+				checkRunName, _ := cmd.Flags().GetString("check-run-name")
+				checkRun := comment.GitHubCheckRun{
+					Name:       checkRunName,
+					Title:      output.CheckRunTitle(combined),
+					Summary:    string(body),
+					Conclusion: githubCheckRunConclusion(combined, policyFailure != nil),
+				}
+				for _, a := range output.CheckAnnotations(combined, maxCheckRunCostChanges) {
+					checkRun.Annotations = append(checkRun.Annotations, comment.GitHubCheckRunAnnotation{
+						Path:      a.Path,
+						StartLine: a.StartLine,
+						EndLine:   a.EndLine,
+						Level:     a.Level,
+						Title:     a.Title,
+						Message:   a.Message,
+					})
+				}
+
+				if !dryRun {
+					url, err := comment.CreateGitHubCheckRun(ctx.Context(), repo, commit, prNumber, extra, checkRun)
+					if err != nil {
+						return err
+					}
+
+					cmd.Printf("Check run created on GitHub: %s\n", url)
+				} else {
+					cmd.Printf("\n%s: %s (%s)\n", checkRun.Name, checkRun.Title, checkRun.Conclusion)
+					for _, a := range checkRun.Annotations {
+						cmd.Printf("  %s %s:%d-%d %s: %s\n", a.Level, a.Path, a.StartLine, a.EndLine, a.Title, a.Message)
+					}
+					cmd.Println("Check run not created on GitHub (--dry-run was specified)")
+				}
+			}
+
 			if policyFailure != nil {
 				cmd.Printf("\n")
 				return policyFailure
@@ -119,15 +202,15 @@ func commentGitHubCmd(ctx *config.RunContext) *cobra.Command {
 		},
 	}
 
-	cmd.Flags().String("behavior", "update", `Behavior when posting comment, one of:
-  update (default)  Update latest comment
-  new               Create a new comment
-  hide-and-new      Hide previous matching comments and create a new comment
-  delete-and-new    Delete previous matching comments and create a new comment`)
+	cmd.Flags().String("behavior", "update", commentBehaviorUsage)
 	_ = cmd.RegisterFlagCompletionFunc("behavior", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
-		return validCommentGitHubBehaviors, cobra.ShellCompDirectiveDefault
+		return comment.Behaviors, cobra.ShellCompDirectiveDefault
 	})
+	cmd.Flags().Bool("check-run", false, "Also create a check run with annotations at the lines of the costliest changes and policy violations")
+	cmd.Flags().String("check-run-name", "Infracost", "Name of the check run")
 	cmd.Flags().String("commit", "", "Commit SHA to post comment on, mutually exclusive with pull-request")
+	cmd.Flags().StringArray("cost-label-band", nil, "Band of the cost labels as a label and a comparison of the monthly cost change, e.g. cost/increase-high>=1000 or cost/review>20%. The first matching band is used, defaults to cost/increase-high>=1000, cost/increase-medium>=100, cost/increase-low>0 and cost/decrease<0")
+	cmd.Flags().Bool("cost-labels", false, "Label the pull request with the band of its monthly cost change, and remove the labels of the other bands")
 	cmd.Flags().String("github-api-url", "https://api.github.com", "GitHub API URL")
 	cmd.Flags().String("github-token", "", "GitHub token")
 	_ = cmd.MarkFlagRequired("github-token")
@@ -143,3 +226,24 @@ func commentGitHubCmd(ctx *config.RunContext) *cobra.Command {
 
 	return cmd
 }
+
+// githubCheckRunConclusion fails the check run when the output has blocking
+// guardrail violations or tag policies or the policies failed, and makes it
+// neutral when it only has warnings.
+func githubCheckRunConclusion(out output.Root, policyFailed bool) string {
+	if policyFailed || out.BlockingGuardrailViolations() > 0 || out.BlockingTagPolicies() > 0 {
+		return comment.GitHubCheckRunFailure
+	}
+
+	if len(out.GuardrailViolations) > 0 {
+		return comment.GitHubCheckRunNeutral
+	}
+
+	for _, t := range out.TagPolicies {
+		if len(t.NonCompliantResources) > 0 {
+			return comment.GitHubCheckRunNeutral
+		}
+	}
+
+	return comment.GitHubCheckRunSuccess
+}
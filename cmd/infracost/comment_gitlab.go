@@ -2,6 +2,7 @@ package main
 
 import (
 	"fmt"
+	"os"
 	"strconv"
 	"strings"
 
@@ -15,8 +16,6 @@ import (
 	"github.com/infracost/infracost/internal/ui"
 )
 
-var validCommentGitLabBehaviors = []string{"update", "new", "delete-and-new"}
-
 func commentGitLabCmd(ctx *config.RunContext) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "gitlab",
@@ -28,7 +27,11 @@ func commentGitLabCmd(ctx *config.RunContext) *cobra.Command {
 
   Post a new comment to a commit:
 
-      infracost comment gitlab --repo my-org/my-repo --commit 2ca7182 --path infracost.json --behavior delete-and-new --gitlab-token $GITLAB_TOKEN`,
+      infracost comment gitlab --repo my-org/my-repo --commit 2ca7182 --path infracost.json --behavior delete-and-new --gitlab-token $GITLAB_TOKEN
+
+  Update comment on a merge request and write the reports of the merge request widgets:
+
+      infracost comment gitlab --repo my-org/my-repo --merge-request 3 --path infracost.json --metrics-report metrics.txt --code-quality-report gl-code-quality-report.json --gitlab-token $GITLAB_TOKEN`,
 		ValidArgs: []string{"--", "-"},
 		RunE: func(cmd *cobra.Command, args []string) error {
 			ctx.SetContextValue("platform", "gitlab")
@@ -69,15 +72,41 @@ func commentGitLabCmd(ctx *config.RunContext) *cobra.Command {
 			}
 
 			behavior, _ := cmd.Flags().GetString("behavior")
-			if behavior != "" && !contains(validCommentGitLabBehaviors, behavior) {
+			if behavior != "" && !contains(comment.Behaviors, behavior) {
 				ui.PrintUsage(cmd)
-				return fmt.Errorf("--behavior only supports %s", strings.Join(validCommentGitLabBehaviors, ", "))
+				return fmt.Errorf("--behavior only supports %s", strings.Join(comment.Behaviors, ", "))
 			}
 			ctx.SetContextValue("behavior", behavior)
 
+			labelBands, err := commentCostLabelBands(cmd)
+			if err != nil {
+				ui.PrintUsage(cmd)
+				return err
+			}
+			if labelBands != nil && mrNumber == 0 {
+				ui.PrintUsage(cmd)
+				return fmt.Errorf("--cost-labels and --cost-label-band need --merge-request")
+			}
+
 			paths, _ := cmd.Flags().GetStringArray("path")
 
-			body, err := buildCommentBody(cmd, ctx, paths, output.MarkdownOptions{
+			combined, err := combineCommentInputs(cmd, ctx, paths)
+			if err != nil {
+				return err
+			}
+
+			combined, cleanupShards, ok, err := combineCommentShards(cmd, ctx, commentHandler.PlatformHandler, combined)
+			if err != nil || !ok {
+				return err
+			}
+			commentHandler.Key = comment.CommentKey(repo, commentTarget(mrNumber, commit), commentProjectNames(combined))
+
+			err = writeGitLabReports(cmd, combined)
+			if err != nil {
+				return err
+			}
+
+			body, err := renderCommentBody(cmd, ctx, combined, output.MarkdownOptions{
 				WillUpdate:          mrNumber != 0 && behavior == "update",
 				WillReplace:         mrNumber != 0 && behavior == "delete-and-new",
 				IncludeFeedbackLink: true,
@@ -97,6 +126,7 @@ func commentGitLabCmd(ctx *config.RunContext) *cobra.Command {
 				if err != nil {
 					return err
 				}
+				cleanupShards()
 
 				pricingClient := apiclient.NewPricingAPIClient(ctx)
 				err = pricingClient.AddEvent("infracost-comment", ctx.EventEnv())
@@ -110,6 +140,18 @@ func commentGitLabCmd(ctx *config.RunContext) *cobra.Command {
 				cmd.Println("Comment not posted to GitLab (--dry-run was specified)")
 			}
 
+			if labelBands != nil {
+				label := output.CostLabel(combined, labelBands)
+				if !dryRun {
+					err = comment.SetGitLabMergeRequestLabel(ctx.Context(), repo, mrNumber, extra, label, output.CostLabels(labelBands))
+					if err != nil {
+						return err
+					}
+				}
+
+				printCostLabel(cmd, label, "GitLab", dryRun)
+			}
+
 			if policyFailure != nil {
 				return policyFailure
 			}
@@ -118,14 +160,14 @@ func commentGitLabCmd(ctx *config.RunContext) *cobra.Command {
 		},
 	}
 
-	cmd.Flags().String("behavior", "update", `Behavior when posting comment, one of:
-  update (default)  Update latest comment
-  new               Create a new comment
-  delete-and-new    Delete previous matching comments and create a new comment`)
+	cmd.Flags().String("behavior", "update", commentBehaviorUsage)
 	_ = cmd.RegisterFlagCompletionFunc("behavior", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
-		return validCommentGitLabBehaviors, cobra.ShellCompDirectiveDefault
+		return comment.Behaviors, cobra.ShellCompDirectiveDefault
 	})
+	cmd.Flags().String("code-quality-report", "", "Path to write a code quality report of the costliest changes and policy violations to, for the merge request widget")
 	cmd.Flags().String("commit", "", "Commit SHA to post comment on, mutually exclusive with merge-request")
+	cmd.Flags().StringArray("cost-label-band", nil, "Band of the cost labels as a label and a comparison of the monthly cost change, e.g. cost/increase-high>=1000 or cost/review>20%. The first matching band is used, defaults to cost/increase-high>=1000, cost/increase-medium>=100, cost/increase-low>0 and cost/decrease<0")
+	cmd.Flags().Bool("cost-labels", false, "Label the merge request with the band of its monthly cost change, and remove the labels of the other bands")
 	cmd.Flags().String("gitlab-server-url", "https://gitlab.com", "GitLab Server URL")
 	cmd.Flags().String("gitlab-token", "", "GitLab token")
 	_ = cmd.MarkFlagRequired("gitlab-token")
@@ -134,6 +176,7 @@ func commentGitLabCmd(ctx *config.RunContext) *cobra.Command {
 	_ = cmd.MarkFlagFilename("path", "json")
 	var mrNumber PRNumber
 	cmd.Flags().Var(&mrNumber, "merge-request", "Merge request number to post comment on, mutually exclusive with commit")
+	cmd.Flags().String("metrics-report", "", "Path to write a metrics report of the monthly costs to, for the merge request widget")
 	cmd.Flags().String("repo", "", "Repository in format owner/repo")
 	_ = cmd.MarkFlagRequired("repo")
 	cmd.Flags().String("tag", "", "Customize hidden markdown tag used to detect comments posted by Infracost")
@@ -141,3 +184,29 @@ func commentGitLabCmd(ctx *config.RunContext) *cobra.Command {
 
 	return cmd
 }
+
+// writeGitLabReports writes the metrics and code quality reports of the output
+// when their paths are set. They're uploaded as the artifacts:reports of the job
+// so the merge request widgets show the cost changes.
+func writeGitLabReports(cmd *cobra.Command, out output.Root) error {
+	if path, _ := cmd.Flags().GetString("metrics-report"); path != "" {
+		err := os.WriteFile(path, output.ToGitLabMetrics(out), 0644) // nolint:gosec
+		if err != nil {
+			return fmt.Errorf("Error writing metrics report: %w", err)
+		}
+	}
+
+	if path, _ := cmd.Flags().GetString("code-quality-report"); path != "" {
+		b, err := output.ToGitLabCodeQuality(out, maxCheckRunCostChanges)
+		if err != nil {
+			return err
+		}
+
+		err = os.WriteFile(path, b, 0644) // nolint:gosec
+		if err != nil {
+			return fmt.Errorf("Error writing code quality report: %w", err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,86 @@
+package main
+
+import (
+	"os"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/infracost/infracost/internal/baseline"
+	"github.com/infracost/infracost/internal/config"
+	"github.com/infracost/infracost/internal/output"
+	"github.com/infracost/infracost/internal/ui"
+)
+
+// diffOutputFiles diffs two Infracost JSON files without running any projects,
+// e.g. a long-lived baseline and the output of a newer CLI version. The files
+// are normalized to the current version and the resource types whose support
+// changed between their versions are flagged, since their costs are only on one
+// side of the diff.
+func diffOutputFiles(cmd *cobra.Command, ctx *config.RunContext, pastPath string, currentPath string) error {
+	past, err := loadOutputFile(cmd, pastPath)
+	if err != nil {
+		return err
+	}
+
+	current, err := loadOutputFile(cmd, currentPath)
+	if err != nil {
+		return err
+	}
+
+	if !strings.EqualFold(past.Currency, current.Currency) {
+		return errors.Errorf("Can't diff %s in %s against %s in %s, the files must have the same currency", currentPath, current.Currency, pastPath, past.Currency)
+	}
+
+	projects := baseline.Projects(current)
+	unmatched := baseline.Apply(past, projects)
+	if len(unmatched) > 0 {
+		ui.PrintWarningf(cmd.ErrOrStderr(), "Projects not found in %s, their resources are shown as added: %s", pastPath, strings.Join(unmatched, ", "))
+	}
+
+	r, err := output.ToOutputFormat(projects)
+	if err != nil {
+		return err
+	}
+	r.Currency = current.Currency
+	r.IsCIRun = ctx.IsCIRun()
+
+	b, err := output.ToDiff(r, output.Options{NoColor: ctx.Config.NoColor})
+	if err != nil {
+		return errors.Wrap(err, "Error generating output")
+	}
+
+	if outFile, _ := cmd.Flags().GetString("out-file"); outFile != "" {
+		err = saveOutFile(ctx, cmd, outFile, b)
+		if err != nil {
+			return err
+		}
+	} else {
+		cmd.Println(string(b))
+	}
+
+	if report := output.SupportChangesReport(output.SupportChanges(past, current)); report != "" {
+		ui.PrintWarning(cmd.ErrOrStderr(), strings.TrimSpace(report))
+	}
+
+	return nil
+}
+
+func loadOutputFile(cmd *cobra.Command, path string) (output.Root, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return output.Root{}, errors.Wrapf(err, "Error reading %s", path)
+	}
+
+	r, notes, err := output.LoadCompatible(data)
+	if err != nil {
+		return output.Root{}, errors.Wrapf(err, "Error parsing %s, it must be the JSON output of Infracost", path)
+	}
+
+	for _, note := range notes {
+		ui.PrintWarningf(cmd.ErrOrStderr(), "%s: %s", path, note)
+	}
+
+	return r, nil
+}
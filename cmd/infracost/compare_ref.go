@@ -0,0 +1,135 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/infracost/infracost/internal/config"
+	"github.com/infracost/infracost/internal/schema"
+	"github.com/infracost/infracost/internal/ui"
+	"github.com/infracost/infracost/internal/worktree"
+)
+
+// compareToRef runs the projects at the git ref in temporary worktrees and
+// replaces the past resources of the projects with the resources of the same
+// projects at the ref, so their diff is against the ref.
+func compareToRef(cmd *cobra.Command, runCtx *config.RunContext, ref string, parallelism int, projects []*schema.Project) error {
+	if len(runCtx.Config.Projects) == 0 {
+		return nil
+	}
+
+	// Each repo the projects are in has its own worktree, so projects in other
+	// repos than the first project are also run at the ref.
+	worktrees := worktree.NewSet(ref)
+	defer func() {
+		if err := worktrees.Remove(); err != nil {
+			log.Debugf("Error removing worktrees of %s: %s", ref, err)
+		}
+	}()
+
+	// The usage files aren't mapped to the worktree so the projects at the ref
+	// are estimated with the same usage as the current projects.
+	refCfgs := make([]*config.Project, len(runCtx.Config.Projects))
+	for i, p := range runCtx.Config.Projects {
+		refPath, err := worktrees.Path(p.Path)
+		if err != nil {
+			return err
+		}
+
+		c := *p
+		c.Path = refPath
+		refCfgs[i] = &c
+
+		if runCtx.Config.NoModuleDownloads {
+			linkModuleDirs(p.Path, c.Path)
+		}
+	}
+
+	m := fmt.Sprintf("Running projects at %s to diff against", ref)
+	if runCtx.Config.IsLogging() {
+		log.Info(m)
+	} else {
+		cmd.PrintErrln(m)
+	}
+
+	results, err := runProjectConfigs(cmd, runCtx, refCfgs, parallelism)
+	if err != nil {
+		return err
+	}
+
+	refProjects := map[string]*schema.Project{}
+	for _, result := range results {
+		refPath := refCfgs[result.index].Path
+		path := runCtx.Config.Projects[result.index].Path
+
+		for _, p := range result.projectOut.projects {
+			// Name the project as if it was run from the repo, so it has the same
+			// name as the current project.
+			if p.Metadata != nil {
+				p.Metadata.Path = strings.Replace(p.Metadata.Path, refPath, path, 1)
+				p.Name = schema.GenerateProjectName(p.Metadata, runCtx.Config.EnableDashboard)
+			}
+		}
+		applyProjectConfigNames(runCtx.Config.Projects[result.index], result.projectOut.projects)
+
+		for _, p := range result.projectOut.projects {
+			refProjects[p.Name] = p
+		}
+	}
+
+	var unmatched []string
+	for _, p := range projects {
+		var past []*schema.Resource
+
+		if refProject, ok := refProjects[p.Name]; ok {
+			past = refProject.Resources
+		} else {
+			unmatched = append(unmatched, p.Name)
+		}
+
+		p.PastResources = past
+		p.HasDiff = true
+		p.CalculateDiff()
+	}
+
+	if len(unmatched) > 0 {
+		ui.PrintWarningf(cmd.ErrOrStderr(), "Projects not found at %s, their resources are shown as added: %s", ref, strings.Join(unmatched, ", "))
+	}
+
+	return nil
+}
+
+// linkModuleDirs links the downloaded modules of the project into the project at
+// the ref, since the modules aren't checked out in the worktree and can't be
+// downloaded with NoModuleDownloads. The ref is then estimated with the module
+// versions of the current project.
+func linkModuleDirs(path string, refPath string) {
+	for _, dir := range []string{filepath.Join(".infracost", "terraform_modules"), filepath.Join(".terraform", "modules")} {
+		src, err := filepath.Abs(filepath.Join(path, dir))
+		if err != nil {
+			continue
+		}
+
+		if _, err := os.Stat(src); err != nil {
+			continue
+		}
+
+		dest := filepath.Join(refPath, dir)
+		if _, err := os.Lstat(dest); err == nil {
+			continue
+		}
+
+		err = os.MkdirAll(filepath.Dir(dest), 0755)
+		if err == nil {
+			err = os.Symlink(src, dest)
+		}
+		if err != nil {
+			log.Debugf("Error linking the modules of %s to %s: %s", path, refPath, err)
+		}
+	}
+}
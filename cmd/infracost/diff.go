@@ -4,6 +4,7 @@ import (
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 
+	"github.com/infracost/infracost/internal/budget"
 	"github.com/infracost/infracost/internal/config"
 	"github.com/infracost/infracost/internal/ui"
 )
@@ -21,13 +22,41 @@ func diffCmd(ctx *config.RunContext) *cobra.Command {
 
       terraform plan -out tfplan.binary
       terraform show -json tfplan.binary > plan.json
-      infracost diff --path plan.json`,
+      infracost diff --path plan.json
+
+  Diff a branch against main without checking it out:
+
+      infracost diff --path /path/to/code --compare-to-ref origin/main
+
+  Diff the Infracost JSON files of two runs, even from different versions:
+
+      infracost diff --path infracost-base.json --compare-to infracost.json
+
+  Fail the pipeline when the monthly cost increases by more than 10%:
+
+      infracost diff --path /path/to/code --fail-on-increase 10%`,
 		ValidArgs: []string{"--", "-"},
 		RunE: func(cmd *cobra.Command, args []string) error {
-			if err := checkAPIKey(ctx.Config.APIKey, ctx.Config.PricingAPIEndpoint, ctx.Config.DefaultPricingAPIEndpoint); err != nil {
+			if compareTo, _ := cmd.Flags().GetString("compare-to"); compareTo != "" {
+				path, _ := cmd.Flags().GetString("path")
+				if path == "" {
+					ui.PrintUsage(cmd)
+					return errors.New("--compare-to needs --path to be the Infracost JSON file to diff against")
+				}
+
+				return diffOutputFiles(cmd, ctx, path, compareTo)
+			}
+
+			if err := loadPricingFlags(ctx.Config, cmd); err != nil {
 				return err
 			}
 
+			if !ctx.Config.PricingOffline && !ctx.Config.UsesAWSPriceList() {
+				if err := checkAPIKey(ctx.Config.APIKey, ctx.Config.PricingAPIEndpoint, ctx.Config.DefaultPricingAPIEndpoint); err != nil {
+					return err
+				}
+			}
+
 			err := loadRunFlags(ctx.Config, cmd)
 			if err != nil {
 				return err
@@ -46,14 +75,51 @@ func diffCmd(ctx *config.RunContext) *cobra.Command {
 			}
 
 			ctx.Config.Format = "diff"
+			ctx.Config.ComparePricesToPath, _ = cmd.Flags().GetString("compare-prices-to")
+			ctx.Config.CompareToBaseline, _ = cmd.Flags().GetString("compare-to-baseline")
+			ctx.Config.CompareToRef, _ = cmd.Flags().GetString("compare-to-ref")
+			ctx.Config.SelectChangedProjects, _ = cmd.Flags().GetBool("select-changed-projects")
+
+			if ctx.Config.CompareToBaseline != "" && ctx.Config.CompareToRef != "" {
+				ui.PrintUsage(cmd)
+				return errors.New("--compare-to-baseline and --compare-to-ref cannot be used together")
+			}
+
+			failOnIncrease, _ := cmd.Flags().GetString("fail-on-increase")
+			failOnTotal, _ := cmd.Flags().GetString("fail-on-total")
+			ctx.Config.DiffThresholds, err = budget.ParseThresholds(failOnIncrease, failOnTotal)
+			if err != nil {
+				ui.PrintUsage(cmd)
+				return err
+			}
 
 			return runMain(cmd, ctx)
 		},
 	}
 
 	addRunFlags(cmd)
+	addParallelismFlag(cmd)
+	addPricingFlags(cmd)
 
 	cmd.Flags().String("out-file", "", "Save output to a file")
+	cmd.Flags().String("compare-prices-to", "", "Path to the Infracost JSON output of a previous run. Cost changes caused by price changes since then are shown separately")
+
+	cmd.Flags().String("compare-to-baseline", "", "Name, path or URL of a baseline saved with 'infracost baseline save' to diff against instead of the current state")
+	cmd.Flags().String("compare-to-ref", "", "Git ref, e.g. origin/main, whose projects are run in a temporary worktree to diff against instead of the current state")
+	cmd.Flags().String("compare-to", "", "Path to an Infracost JSON file diffed against the Infracost JSON file of --path, which can be from another CLI version")
+	cmd.Flags().Bool("select-changed-projects", false, "Only estimate the projects affected by the files changed since the base branch of the pull request, including changes to local modules they call. The base is --compare-to-ref or the base branch detected from the CI environment")
+
+	addThresholdFlags(cmd)
+
+	addLockFileFlags(cmd)
+
+	cmd.Flags().Bool("show-recommendations", false, "Check resources for savings opportunities, e.g. gp2 volumes, idle NAT gateways, unattached Elastic IPs and previous generation instances, and list them with their estimated savings")
+
+	cmd.Flags().Bool("cache-results", false, "Reuse the estimates of projects whose committed files, usage files, currency and CLI version haven't changed since they were last cached. Cached projects are marked in the output")
+	cmd.Flags().Bool("wait-for-approval", false, "When guardrails are breached, wait for the change to be approved through INFRACOST_APPROVAL_CALLBACK_URL after sending it to INFRACOST_APPROVAL_WEBHOOK_URL. Approved changes exit zero")
+
+	_ = cmd.MarkFlagFilename("compare-prices-to", "json")
+	_ = cmd.MarkFlagFilename("compare-to", "json")
 
 	return cmd
 }
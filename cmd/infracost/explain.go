@@ -0,0 +1,60 @@
+package main
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/infracost/infracost/internal/config"
+	"github.com/infracost/infracost/internal/ui"
+)
+
+func explainCmd(ctx *config.RunContext) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "explain <resource address>",
+		Short: "Explain how the costs of a resource are calculated",
+		Long: `Explain how the costs of a resource are calculated: the filters used to look up
+the price of each cost component, the attributes they match, the unit price and
+its tier, the quantity, and the usage keys that change the costs`,
+		Example: `  Explain the costs of a resource in a Terraform directory:
+
+      infracost explain aws_instance.web --path /path/to/code
+
+  Explain the costs of a resource in a module:
+
+      infracost explain module.db.aws_db_instance.this --path plan.json`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := loadPricingFlags(ctx.Config, cmd); err != nil {
+				return err
+			}
+
+			if !ctx.Config.PricingOffline && !ctx.Config.UsesAWSPriceList() {
+				if err := checkAPIKey(ctx.Config.APIKey, ctx.Config.PricingAPIEndpoint, ctx.Config.DefaultPricingAPIEndpoint); err != nil {
+					return err
+				}
+			}
+
+			err := loadRunFlags(ctx.Config, cmd)
+			if err != nil {
+				return err
+			}
+
+			err = checkRunConfig(cmd.ErrOrStderr(), ctx.Config)
+			if err != nil {
+				ui.PrintUsage(cmd)
+				return err
+			}
+
+			ctx.Config.ExplainResource = args[0]
+			// The price tiers are needed to explain graduated prices.
+			ctx.Config.ShowPriceTiers = true
+
+			return runMain(cmd, ctx)
+		},
+	}
+
+	addRunFlags(cmd)
+	addParallelismFlag(cmd)
+	addPricingFlags(cmd)
+
+	return cmd
+}
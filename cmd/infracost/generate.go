@@ -0,0 +1,101 @@
+package main
+
+import (
+	"os"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/infracost/infracost/internal/config"
+	"github.com/infracost/infracost/internal/configgen"
+	"github.com/infracost/infracost/internal/providers"
+	"github.com/infracost/infracost/internal/ui"
+)
+
+func generateCmd(ctx *config.RunContext) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "generate",
+		Short: "Generate configuration to help run Infracost",
+		Long:  "Generate configuration to help run Infracost",
+		Example: `  Generate a config file for the projects of a repo:
+
+      infracost generate config --repo-path . --out-file infracost.yml`,
+		ValidArgs: []string{"--", "-"},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cmd.Help()
+		},
+	}
+
+	cmd.AddCommand(generateConfigCmd(ctx))
+
+	return cmd
+}
+
+func generateConfigCmd(ctx *config.RunContext) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "config",
+		Short: "Generate a config file from the root modules of a repo",
+		Long: `Generate a config file from the root modules of a repo
+
+The repo is scanned for root modules like when --path is a directory with
+multiple projects, skipping the directories in its .infracostignore. The config
+file is rendered from a Go template with the projects found, see
+https://infracost.io/config-file. Each project has a Path, Name, Env, VarFiles,
+EnvVarFiles and UsageFile, where Env is an environment like prod detected from
+its path and EnvVarFiles are its var files named after an environment, e.g.
+prod.tfvars. The projects are sorted by path so the output only changes when
+the repo does.`,
+		Example: `  Generate a config file with the default template:
+
+      infracost generate config --repo-path . --out-file infracost.yml
+
+  Generate a config file from a template, e.g. in CI before running breakdown:
+
+      infracost generate config --repo-path . --template-path infracost.yml.tmpl --out-file infracost.yml`,
+		ValidArgs: []string{"--", "-"},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			repoPath, _ := cmd.Flags().GetString("repo-path")
+			templatePath, _ := cmd.Flags().GetString("template-path")
+			outFile, _ := cmd.Flags().GetString("out-file")
+			maxDepth, _ := cmd.Flags().GetInt("max-depth")
+
+			if info, err := os.Stat(repoPath); err != nil || !info.IsDir() {
+				ui.PrintUsage(cmd)
+				return errors.Errorf("--repo-path %s is not a directory", repoPath)
+			}
+
+			b, err := configgen.Generate(repoPath, configgen.Options{
+				TemplatePath: templatePath,
+				MaxDepth:     maxDepth,
+			})
+			if err != nil {
+				return err
+			}
+
+			if outFile == "" {
+				cmd.Print(string(b))
+				return nil
+			}
+
+			err = os.WriteFile(outFile, b, 0600)
+			if err != nil {
+				return errors.Wrap(err, "Unable to write config file")
+			}
+
+			cmd.PrintErrf("Config file saved to %s\n", outFile)
+
+			return nil
+		},
+	}
+
+	cmd.Flags().String("repo-path", ".", "Path to the repo to find the root modules in")
+	cmd.Flags().String("template-path", "", "Path to a Go template of the config file, defaults to a config with a project for each root module")
+	cmd.Flags().String("out-file", "", "Save the config file to a file, defaults to stdout")
+	cmd.Flags().Int("max-depth", providers.DefaultAutodetectMaxDepth, "How many directories deep to look for root modules")
+
+	_ = cmd.MarkFlagFilename("repo-path")
+	_ = cmd.MarkFlagFilename("template-path", "tmpl")
+	_ = cmd.MarkFlagFilename("out-file", "yml", "yaml")
+
+	return cmd
+}
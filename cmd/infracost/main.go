@@ -113,8 +113,20 @@ func newRootCmd(ctx *config.RunContext) *cobra.Command {
 	rootCmd.AddCommand(configureCmd(ctx))
 	rootCmd.AddCommand(diffCmd(ctx))
 	rootCmd.AddCommand(breakdownCmd(ctx))
+	rootCmd.AddCommand(explainCmd(ctx))
 	rootCmd.AddCommand(outputCmd(ctx))
+	rootCmd.AddCommand(policyCmd(ctx))
 	rootCmd.AddCommand(commentCmd(ctx))
+	rootCmd.AddCommand(pricingCmd(ctx))
+	rootCmd.AddCommand(recommendCmd(ctx))
+	rootCmd.AddCommand(usageCmd(ctx))
+	rootCmd.AddCommand(serveCmd(ctx))
+	rootCmd.AddCommand(baselineCmd(ctx))
+	rootCmd.AddCommand(validateCmd(ctx))
+	rootCmd.AddCommand(precommitCmd(ctx))
+	rootCmd.AddCommand(pluginCmd(ctx))
+	rootCmd.AddCommand(notifyCmd(ctx))
+	rootCmd.AddCommand(generateCmd(ctx))
 	rootCmd.AddCommand(completionCmd())
 	rootCmd.AddCommand(figAutocompleteCmd())
 
@@ -0,0 +1,122 @@
+package main
+
+import (
+	"fmt"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/infracost/infracost/internal/apiclient"
+	"github.com/infracost/infracost/internal/config"
+	"github.com/infracost/infracost/internal/notify"
+)
+
+func notifyCmd(ctx *config.RunContext) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "notify",
+		Short: "Post cost changes to Slack, Microsoft Teams, Discord or webhooks",
+		Long: `Post cost changes to Slack, Microsoft Teams, Discord or webhooks
+
+The notifications are set in the config file, with rules of which projects and changes they're
+posted for. Without rules, a notification is posted when the monthly cost changes:
+
+  notifications:
+    - type: slack
+      url: ${SLACK_WEBHOOK_URL}
+      channel: "#platform-alerts"
+      min_monthly_increase: 500
+    - type: teams
+      url: ${TEAMS_WEBHOOK_URL}
+      project: "environments/prod*"
+      on_guardrail_violation: true
+
+Webhook notifications are posted the Infracost JSON of the projects.`,
+		Example: `  Post the notifications of the config file for an Infracost JSON file:
+
+      infracost notify --path infracost.json --config-file infracost.yml
+
+  Show which notifications would be posted and their messages:
+
+      infracost notify --path infracost.json --config-file infracost.yml --dry-run`,
+		ValidArgs: []string{"--", "-"},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfgFilePath, _ := cmd.Flags().GetString("config-file")
+			err := ctx.Config.LoadFromConfigFile(cfgFilePath)
+			if err != nil {
+				return err
+			}
+
+			if len(ctx.Config.Notifications) == 0 {
+				cmd.Printf("No notifications are set in %s\n", cfgFilePath)
+				return nil
+			}
+
+			paths, _ := cmd.Flags().GetStringArray("path")
+			combined, err := combineCommentInputs(cmd, ctx, paths)
+			if err != nil {
+				return err
+			}
+
+			dryRun, _ := cmd.Flags().GetBool("dry-run")
+
+			var sent int
+			var errs []error
+			for _, n := range ctx.Config.Notifications {
+				routed, ok, reason := notify.Route(combined, n)
+				if !ok {
+					cmd.Printf("Skipped %s: %s\n", n.Label(), reason)
+					continue
+				}
+
+				body, err := notify.Message(routed, n)
+				if err != nil {
+					return fmt.Errorf("Error generating %s notification: %w", n.Label(), err)
+				}
+
+				if dryRun {
+					cmd.Println(string(body))
+					cmd.Printf("Notification not posted to %s (--dry-run was specified)\n", n.Label())
+					continue
+				}
+
+				err = notify.Send(ctx.Context(), n, body)
+				if err != nil {
+					// The other notifications are still posted if one fails.
+					errs = append(errs, err)
+					continue
+				}
+
+				sent++
+				cmd.Printf("Notified %s\n", n.Label())
+			}
+
+			if sent > 0 {
+				pricingClient := apiclient.NewPricingAPIClient(ctx)
+				err = pricingClient.AddEvent("infracost-notify", ctx.EventEnv())
+				if err != nil {
+					log.Errorf("Error reporting event: %s", err)
+				}
+			}
+
+			for _, err := range errs {
+				cmd.PrintErrln(err)
+			}
+
+			if len(errs) > 0 {
+				return fmt.Errorf("%d notifications failed", len(errs))
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().String("config-file", "", "Path to Infracost config file with the notifications")
+	_ = cmd.MarkFlagRequired("config-file")
+	_ = cmd.MarkFlagFilename("config-file", "yml")
+	cmd.Flags().Bool("dry-run", false, "Print the notifications without posting them")
+	cmd.Flags().StringArrayP("path", "p", []string{}, "Path to Infracost JSON files, glob patterns need quotes")
+	_ = cmd.MarkFlagRequired("path")
+	_ = cmd.MarkFlagFilename("path", "json")
+
+	return cmd
+}
@@ -2,8 +2,12 @@ package main
 
 import (
 	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"strings"
 
+	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 
@@ -23,8 +27,19 @@ var validOutputFormats = []string{
 	"azure-repos-comment",
 	"bitbucket-comment",
 	"slack-message",
+	"sentinel-mock",
+	"sarif",
+	"junit",
 }
 
+// The stable names of the files of the bundle of reports, the ones the Jenkins
+// HTML Publisher and JUnit plugins can be pointed at.
+const (
+	bundleHTMLFile  = "index.html"
+	bundleJUnitFile = "junit.xml"
+	bundleJSONFile  = "infracost.json"
+)
+
 func outputCmd(ctx *config.RunContext) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "output",
@@ -42,6 +57,10 @@ func outputCmd(ctx *config.RunContext) *cobra.Command {
 
       infracost output --format json --path "out*.json" # glob needs quotes
 
+  Merge Infracost JSON files of CI jobs that may have run the same projects:
+
+      infracost output --format json --path "out*.json" --merge-strategy replace # glob needs quotes
+
   Create markdown report to post in a GitHub comment:
 
       infracost output --format github-comment --path "out*.json" # glob needs quotes
@@ -56,7 +75,19 @@ func outputCmd(ctx *config.RunContext) *cobra.Command {
 
   Create markdown report to post in a Bitbucket comment:
 
-      infracost output --format bitbucket-comment --path "out*.json" # glob needs quotes`,
+      infracost output --format bitbucket-comment --path "out*.json" # glob needs quotes
+
+  Create Sentinel mock data of the tfrun import to test Sentinel cost policies:
+
+      infracost output --format sentinel-mock --path "out*.json" --out-file mock-tfrun.sentinel # glob needs quotes
+
+  Create a SARIF report of the guardrail and tag policy failures for code scanning:
+
+      infracost output --format sarif --path "out*.json" --out-file infracost.sarif # glob needs quotes
+
+  Write the HTML report, JUnit XML and JSON to a directory for the Jenkins HTML Publisher and JUnit plugins:
+
+      infracost output --path "out*.json" --bundle-dir infracost-reports # glob needs quotes`,
 		ValidArgs: []string{"--", "-"},
 		RunE: func(cmd *cobra.Command, args []string) error {
 			var err error
@@ -76,12 +107,41 @@ func outputCmd(ctx *config.RunContext) *cobra.Command {
 				return err
 			}
 
-			combined, err := output.Combine(inputs)
+			mergeStrategy, _ := cmd.Flags().GetString("merge-strategy")
+			if !contains(output.MergeStrategies, mergeStrategy) {
+				ui.PrintUsage(cmd)
+				return fmt.Errorf("--merge-strategy only supports %s", strings.Join(output.MergeStrategies, ", "))
+			}
+
+			combined, err := output.Combine(inputs, output.CombineOptions{MergeStrategy: mergeStrategy})
 			if err != nil {
 				return err
 			}
 			combined.IsCIRun = ctx.IsCIRun()
 
+			if cmd.Flags().Changed("filter") {
+				exprs, _ := cmd.Flags().GetStringArray("filter")
+				filters, err := output.ParseFilters(exprs)
+				if err != nil {
+					ui.PrintUsage(cmd)
+					return err
+				}
+
+				combined = output.FilterResources(combined, filters)
+			}
+
+			output.AddProjectGroups(&combined)
+
+			if groupBy, _ := cmd.Flags().GetString("group-by"); groupBy != "" {
+				err = output.AddCostGroups(&combined, groupBy)
+				if err != nil {
+					ui.PrintUsage(cmd)
+					return err
+				}
+			}
+
+			output.AddOwnerCosts(&combined)
+
 			includeAllFields := "all"
 			validFields := []string{"price", "monthlyQuantity", "unit", "hourlyCost", "monthlyCost"}
 
@@ -141,6 +201,12 @@ func outputCmd(ctx *config.RunContext) *cobra.Command {
 				b, err = output.ToMarkdown(combined, opts, output.MarkdownOptions{BasicSyntax: true})
 			case "slack-message":
 				b, err = output.ToSlackMessage(combined, opts)
+			case "sentinel-mock":
+				b, err = output.ToSentinelMock(combined, opts)
+			case "sarif":
+				b, err = output.ToSARIF(combined, opts)
+			case "junit":
+				b, err = output.ToJUnit(combined, opts)
 			default:
 				b, err = output.ToTable(combined, opts)
 			}
@@ -154,6 +220,13 @@ func outputCmd(ctx *config.RunContext) *cobra.Command {
 				log.Errorf("Error reporting event: %s", err)
 			}
 
+			if bundleDir, _ := cmd.Flags().GetString("bundle-dir"); bundleDir != "" {
+				err = saveBundle(ctx, cmd, bundleDir, combined, opts)
+				if err != nil {
+					return err
+				}
+			}
+
 			if outFile, _ := cmd.Flags().GetString("out-file"); outFile != "" {
 				err = saveOutFile(ctx, cmd, outFile, b)
 				if err != nil {
@@ -170,8 +243,12 @@ func outputCmd(ctx *config.RunContext) *cobra.Command {
 	cmd.Flags().StringArrayP("path", "p", []string{}, "Path to Infracost JSON files, glob patterns need quotes")
 	cmd.Flags().StringP("out-file", "o", "", "Save output to a file, helpful with format flag")
 
-	cmd.Flags().String("format", "table", "Output format: json, diff, table, html, github-comment, gitlab-comment, azure-repos-comment, bitbucket-comment, slack-message")
+	cmd.Flags().String("bundle-dir", "", "Also write the HTML report, JUnit XML and JSON to index.html, junit.xml and infracost.json in a directory")
+	cmd.Flags().String("format", "table", "Output format: json, diff, table, html, github-comment, gitlab-comment, azure-repos-comment, bitbucket-comment, slack-message, sentinel-mock, sarif, junit")
 	cmd.Flags().Bool("show-skipped", false, "List unsupported and free resources")
+	cmd.Flags().String("merge-strategy", output.MergeStrategySum, "How projects with the same path and workspace in multiple files are merged: sum keeps them all, replace keeps the one from the last file")
+	cmd.Flags().StringArray("filter", nil, "Only show resources matching key=value, keys: type, module, tag, provider, min-monthly-cost. Repeat to match all")
+	cmd.Flags().String("group-by", "", "Group the costs of the resources by the value of a tag, e.g. tag:team. Supported by table, json and html output formats")
 	cmd.Flags().StringSlice("fields", []string{"monthlyQuantity", "unit", "monthlyCost"}, "Comma separated list of output fields: all,price,monthlyQuantity,unit,hourlyCost,monthlyCost.\nSupported by table and html output formats")
 
 	_ = cmd.MarkFlagRequired("path")
@@ -184,6 +261,45 @@ func outputCmd(ctx *config.RunContext) *cobra.Command {
 	return cmd
 }
 
+// saveBundle writes the HTML report, the JUnit XML and the JSON of the output
+// to their stable names in the directory, creating it if needed.
+func saveBundle(ctx *config.RunContext, cmd *cobra.Command, dir string, out output.Root, opts output.Options) error {
+	err := os.MkdirAll(dir, 0755)
+	if err != nil {
+		return errors.Wrap(err, "Unable to create bundle directory")
+	}
+
+	files := []struct {
+		name   string
+		format func(output.Root, output.Options) ([]byte, error)
+	}{
+		{bundleHTMLFile, output.ToHTML},
+		{bundleJUnitFile, output.ToJUnit},
+		{bundleJSONFile, output.ToJSON},
+	}
+
+	for _, f := range files {
+		b, err := f.format(out, opts)
+		if err != nil {
+			return err
+		}
+
+		err = ioutil.WriteFile(filepath.Join(dir, f.name), b, 0644) // nolint:gosec
+		if err != nil {
+			return errors.Wrap(err, "Unable to save bundle")
+		}
+	}
+
+	successMsg := fmt.Sprintf("Bundle saved to %s", dir)
+	if ctx.Config.IsLogging() {
+		log.Info(successMsg)
+	} else {
+		cmd.PrintErrf("%s\n", successMsg)
+	}
+
+	return nil
+}
+
 func shareCombinedRun(ctx *config.RunContext, combined output.Root, inputs []output.ReportInput) (string, string) {
 	if len(inputs) == 1 && inputs[0].Root.RunID != "" {
 		result := inputs[0].Root
@@ -0,0 +1,151 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/infracost/infracost/internal/budget"
+	"github.com/infracost/infracost/internal/config"
+	"github.com/infracost/infracost/internal/output"
+	"github.com/infracost/infracost/internal/plugin"
+)
+
+func pluginCmd(ctx *config.RunContext) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "plugin",
+		Short: "Run as a Drone or Woodpecker plugin",
+		Long: `Run as a Drone or Woodpecker plugin, configured by the settings of the pipeline step
+
+The projects are estimated and the comment is posted to the pull request of the build, or to
+its commit. The settings are read from the PLUGIN_* environment variables, e.g. config_file is
+read from PLUGIN_CONFIG_FILE:
+
+  path                 Path to the Terraform directory, defaults to the root of the repo
+  config_file          Path to an Infracost config file to use instead of path
+  usage_file           Path to an Infracost usage file
+  terraform_parse_hcl  Parse the HCL instead of running Terraform, defaults to true
+  compare_to_ref       Git ref whose projects are diffed against, e.g. origin/main
+  platform             github, gitlab, bitbucket or azure-repos, detected from the repo by default
+  server_url           URL of the GitHub API, GitLab or Bitbucket server when they're self-hosted
+  token                Token used to post the comment
+  behavior             Behavior when posting the comment, defaults to update
+  tag                  Tag of the comment, to post separate comments from several steps
+  status               Also create a check run, Code Insights report or pull request status
+  fail_on_increase     Fail when the monthly cost increase is over an amount or a percentage
+  fail_on_total        Fail when the total monthly cost is over an amount
+  api_key              Infracost API key
+  dry_run              Print the comment instead of posting it`,
+		Example: `  Use the plugin image in a pipeline step:
+
+      - name: infracost
+        image: infracost/infracost:plugin-latest
+        settings:
+          path: infra
+          compare_to_ref: origin/main
+          fail_on_increase: 10%
+          token:
+            from_secret: github_token
+          api_key:
+            from_secret: infracost_api_key`,
+		ValidArgs: []string{"--", "-"},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx.SetContextValue("platform", "plugin")
+
+			settings, err := plugin.LoadSettings()
+			if err != nil {
+				return err
+			}
+
+			thresholds, err := budget.ParseThresholds(settings.FailOnIncrease, settings.FailOnTotal)
+			if err != nil {
+				return err
+			}
+
+			// Each step reads its config from the environment, so the API key
+			// setting is passed on like INFRACOST_API_KEY.
+			if settings.APIKey != "" {
+				err = os.Setenv("INFRACOST_API_KEY", settings.APIKey)
+				if err != nil {
+					return err
+				}
+			}
+
+			tmpDir, err := os.MkdirTemp("", "infracost-plugin-")
+			if err != nil {
+				return errors.Wrap(err, "Error creating temp directory")
+			}
+			defer os.RemoveAll(tmpDir)
+
+			outFile := filepath.Join(tmpDir, "infracost.json")
+
+			// The comment args are checked before the projects are estimated so
+			// settings errors are reported straight away.
+			commentArgs, err := settings.CommentArgs(plugin.DetectBuild(), outFile)
+			if err != nil {
+				return err
+			}
+
+			// Failing guardrails and policies are returned after the output is
+			// written, so the comment is still posted.
+			breakdownErr := runPluginStep(ctx, settings.BreakdownArgs(outFile))
+			if breakdownErr != nil {
+				if _, err := os.Stat(outFile); err != nil {
+					return breakdownErr
+				}
+			}
+
+			err = runPluginStep(ctx, commentArgs)
+			if err != nil {
+				return err
+			}
+
+			if breakdownErr != nil {
+				return breakdownErr
+			}
+
+			if thresholds != nil {
+				data, err := os.ReadFile(outFile)
+				if err != nil {
+					return errors.Wrap(err, "Error reading Infracost JSON file")
+				}
+
+				out, err := output.Load(data)
+				if err != nil {
+					return errors.Wrap(err, "Error parsing Infracost JSON file")
+				}
+
+				violations := budget.Check(out, []*config.Budget{thresholds})
+				if len(violations) > 0 {
+					cmd.PrintErrln(strings.TrimSpace(budget.ThresholdReport(violations)))
+					return fmt.Errorf("%d diff thresholds exceeded, failing since fail_on_increase or fail_on_total is set", len(violations))
+				}
+			}
+
+			return nil
+		},
+	}
+
+	return cmd
+}
+
+// runPluginStep runs infracost with the args in a new run context, so each step
+// is configured like a separate run of the CLI.
+func runPluginStep(ctx *config.RunContext, args []string) error {
+	stepCtx, err := config.NewRunContextFromEnv(ctx.Context())
+	if err != nil {
+		return err
+	}
+
+	stepCtx.OutWriter = ctx.OutWriter
+	stepCtx.ErrWriter = ctx.ErrWriter
+
+	stepCmd := newRootCmd(stepCtx)
+	stepCmd.SetArgs(args)
+
+	return stepCmd.Execute()
+}
@@ -0,0 +1,220 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/infracost/infracost/internal/config"
+	"github.com/infracost/infracost/internal/output"
+	"github.com/infracost/infracost/internal/policy"
+	"github.com/infracost/infracost/internal/policypack"
+	"github.com/infracost/infracost/internal/ui"
+)
+
+var validPolicyFormats = []string{
+	"table",
+	"json",
+	"github-comment",
+	"gitlab-comment",
+	"azure-repos-comment",
+	"bitbucket-comment",
+}
+
+func policyCmd(ctx *config.RunContext) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "policy",
+		Short: "Evaluate Rego policies against Infracost JSON files",
+		Long:  "Evaluate Rego policies against Infracost JSON files",
+		Example: `  Check an Infracost JSON file against the policies in a directory:
+
+      infracost policy run --path infracost.json --policy-dir policies/`,
+		ValidArgs: []string{"--", "-"},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cmd.Help()
+		},
+	}
+
+	cmd.AddCommand(policyRunCmd(ctx))
+
+	return cmd
+}
+
+func policyRunCmd(ctx *config.RunContext) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "run",
+		Short: "Evaluate Rego policies against Infracost JSON files",
+		Long: `Evaluate Rego policies against Infracost JSON files
+
+The policies are evaluated against the combined Infracost JSON, the same input
+as the --policy-path flag of infracost comment. Each policy defines results of
+the data.infracost.deny rule, either an object with a msg and whether the check
+failed, e.g. {"msg": "Total monthly cost is under $1000", "failed": false}, or
+the message of a failed check. The command exits with an error if any check
+failed. See examples/policies for policies checking cost thresholds, forbidden
+instance types and missing usage.
+
+Policies can also be shared between repos in policy packs with --policy-pack,
+an HTTPS URL of a .tar.gz archive or an oci:// reference of the pack. Its
+checksum can be pinned by adding it after a #, e.g.
+https://example.com/pack.tar.gz#sha256:<hex digest>.`,
+		Example: `  Check the output of infracost breakdown:
+
+      infracost breakdown --path . --format json --out-file infracost.json
+      infracost policy run --path infracost.json --policy-dir policies/
+
+  Add the results of the checks to a GitHub comment:
+
+      infracost policy run --path infracost.json --policy-dir policies/ --format github-comment
+
+  Check the output against the policies of a pack in an OCI registry:
+
+      infracost policy run --path infracost.json --policy-pack oci://ghcr.io/my-org/infracost-policies:v1`,
+		ValidArgs: []string{"--", "-"},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			format, _ := cmd.Flags().GetString("format")
+			ctx.SetContextValue("outputFormat", format)
+
+			if !contains(validPolicyFormats, format) {
+				ui.PrintUsage(cmd)
+				return fmt.Errorf("--format only supports %s", strings.Join(validPolicyFormats, ", "))
+			}
+
+			paths, _ := cmd.Flags().GetStringArray("path")
+			policyDirs, _ := cmd.Flags().GetStringArray("policy-dir")
+
+			packPaths, err := policyPackPaths(cmd, ctx)
+			if err != nil {
+				return err
+			}
+			policyDirs = append(policyDirs, packPaths...)
+
+			if len(policyDirs) == 0 {
+				ui.PrintUsage(cmd)
+				return errors.New("--policy-dir or --policy-pack is required")
+			}
+
+			inputs, err := output.LoadPaths(paths)
+			if err != nil {
+				return err
+			}
+
+			combined, err := output.Combine(inputs, output.CombineOptions{})
+			if err != nil {
+				return err
+			}
+			combined.IsCIRun = ctx.IsCIRun()
+			output.AddProjectGroups(&combined)
+
+			checks, err := policy.Evaluate(policyDirs, combined)
+			if err != nil {
+				return err
+			}
+
+			ctx.SetContextValue("passedPolicyCount", len(checks.Passed))
+			ctx.SetContextValue("failedPolicyCount", len(checks.Failures))
+
+			opts := output.Options{
+				NoColor:      ctx.Config.NoColor,
+				ShowSkipped:  true,
+				PolicyChecks: checks,
+			}
+
+			var b []byte
+
+			switch format {
+			case "json":
+				b, err = output.ToPolicyJSON(checks, opts)
+			case "github-comment", "gitlab-comment", "azure-repos-comment":
+				b, err = output.ToMarkdown(combined, opts, output.MarkdownOptions{})
+			case "bitbucket-comment":
+				b, err = output.ToMarkdown(combined, opts, output.MarkdownOptions{BasicSyntax: true})
+			default:
+				b, err = output.ToPolicyTable(checks, opts)
+			}
+			if err != nil {
+				return err
+			}
+
+			if outFile, _ := cmd.Flags().GetString("out-file"); outFile != "" {
+				err = saveOutFile(ctx, cmd, outFile, b)
+				if err != nil {
+					return err
+				}
+			} else {
+				cmd.Println(string(b))
+			}
+
+			if checks.HasFailed() {
+				return fmt.Errorf("%d policy checks failed", len(checks.Failures))
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringArrayP("path", "p", []string{}, "Path to Infracost JSON files, glob patterns need quotes")
+	cmd.Flags().StringArray("policy-dir", nil, "Path to a directory or file of Rego policies. Repeat to use several")
+	cmd.Flags().StringArray("policy-pack", nil, "HTTPS URL or oci:// reference of a policy pack whose Rego policies are evaluated. Repeat to use several")
+	cmd.Flags().String("format", "table", "Output format: table, json, github-comment, gitlab-comment, azure-repos-comment, bitbucket-comment")
+	cmd.Flags().StringP("out-file", "o", "", "Save output to a file")
+
+	_ = cmd.MarkFlagRequired("path")
+	_ = cmd.MarkFlagFilename("path", "json")
+	_ = cmd.MarkFlagDirname("policy-dir")
+
+	_ = cmd.RegisterFlagCompletionFunc("format", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return validPolicyFormats, cobra.ShellCompDirectiveDefault
+	})
+
+	return cmd
+}
+
+// policyPackPaths returns the Rego policies of the packs of the --policy-pack
+// flag.
+func policyPackPaths(cmd *cobra.Command, ctx *config.RunContext) ([]string, error) {
+	sources, _ := cmd.Flags().GetStringArray("policy-pack")
+	if len(sources) == 0 {
+		return nil, nil
+	}
+
+	packs := make([]*config.PolicyPack, 0, len(sources))
+	for _, s := range sources {
+		p, err := config.ParsePolicyPack(s)
+		if err != nil {
+			ui.PrintUsage(cmd)
+			return nil, errors.Wrapf(err, "Invalid --policy-pack %s", s)
+		}
+
+		packs = append(packs, p)
+	}
+
+	err := loadPolicyPacks(ctx, packs)
+	if err != nil {
+		return nil, err
+	}
+
+	return ctx.Config.PolicyPaths, nil
+}
+
+// loadPolicyPacks fetches the packs and adds their contents to the config.
+func loadPolicyPacks(ctx *config.RunContext, packs []*config.PolicyPack) error {
+	fetcher := policypack.NewFetcher(ctx.Config.PolicyPackCacheDir, ctx.Config.PolicyPackToken)
+
+	for _, p := range packs {
+		dir, err := fetcher.Fetch(context.Background(), p.Source, p.Checksum)
+		if err != nil {
+			return err
+		}
+
+		err = ctx.Config.LoadPolicyPack(dir)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
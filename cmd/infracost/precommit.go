@@ -0,0 +1,175 @@
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/infracost/infracost/internal/budget"
+	"github.com/infracost/infracost/internal/config"
+	"github.com/infracost/infracost/internal/hcl/modules"
+	"github.com/infracost/infracost/internal/ui"
+	"github.com/infracost/infracost/internal/worktree"
+)
+
+func precommitCmd(ctx *config.RunContext) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "precommit",
+		Short: "Show the cost change of the staged Terraform changes in one line",
+		Long: `Show the monthly cost change of the staged Terraform changes in one line, e.g. from a pre-commit hook.
+
+Only the projects with staged .tf files, in their directory or in the local modules they
+call, are estimated, against their state at HEAD. Their Terraform directories are parsed
+without running Terraform, the estimates and prices are cached on disk, and remote modules
+that haven't been downloaded yet are skipped.`,
+		Example: `  Show the cost change of the staged changes of the repo:
+
+      infracost precommit
+
+  Only estimate the projects of a config file and fail the commit when the increase is over $500:
+
+      infracost precommit --config-file infracost.yml --fail-on-increase 500
+
+  Use it with the pre-commit framework by adding this to .pre-commit-config.yaml:
+
+      - repo: https://github.com/infracost/infracost
+        rev: master
+        hooks:
+          - id: infracost`,
+		ValidArgs: []string{"--", "-"},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if !ctx.Config.PricingOffline && !ctx.Config.UsesAWSPriceList() {
+				if err := checkAPIKey(ctx.Config.APIKey, ctx.Config.PricingAPIEndpoint, ctx.Config.DefaultPricingAPIEndpoint); err != nil {
+					return err
+				}
+			}
+
+			// Only warnings are logged so the progress of the run isn't shown and the
+			// output is a single line.
+			if !ctx.Config.IsLogging() {
+				ctx.Config.LogLevel = "warn"
+				err := ctx.Config.ConfigureLogger()
+				if err != nil {
+					return err
+				}
+			}
+
+			path, _ := cmd.Flags().GetString("path")
+			staged, err := worktree.StagedFiles(path)
+			if err != nil {
+				return err
+			}
+
+			var dirs []string
+			for _, f := range staged {
+				if filepath.Ext(f) == ".tf" {
+					dirs = append(dirs, filepath.Dir(f))
+				}
+			}
+
+			if len(dirs) == 0 {
+				cmd.Println("Infracost: no staged Terraform changes")
+				return nil
+			}
+
+			if cfgFilePath, _ := cmd.Flags().GetString("config-file"); cfgFilePath != "" {
+				err = ctx.Config.LoadFromConfigFile(cfgFilePath)
+			} else {
+				ctx.Config.Projects[0].Path = path
+				err = autodetectProjects(cmd, ctx.Config)
+			}
+			if err != nil {
+				return err
+			}
+
+			var projects []*config.Project
+			for _, p := range ctx.Config.Projects {
+				affected, err := projectHasStagedChanges(p, dirs)
+				if err != nil {
+					return err
+				}
+
+				if affected {
+					p.TerraformParseHCL = true
+					projects = append(projects, p)
+				}
+			}
+
+			if len(projects) == 0 {
+				cmd.Println("Infracost: no projects with staged Terraform changes")
+				return nil
+			}
+
+			ctx.Config.Projects = projects
+			ctx.Config.Format = "oneline"
+			ctx.Config.CompareToRef = "HEAD"
+			ctx.Config.CacheResults = true
+			ctx.Config.NoModuleDownloads = true
+
+			failOnIncrease, _ := cmd.Flags().GetString("fail-on-increase")
+			failOnTotal, _ := cmd.Flags().GetString("fail-on-total")
+			ctx.Config.DiffThresholds, err = budget.ParseThresholds(failOnIncrease, failOnTotal)
+			if err != nil {
+				ui.PrintUsage(cmd)
+				return err
+			}
+
+			return runMain(cmd, ctx)
+		},
+	}
+
+	cmd.Flags().StringP("path", "p", ".", "Path to the repo, or the directory in it, whose projects are estimated")
+	cmd.Flags().String("config-file", "", "Path to Infracost config file whose projects are estimated instead of the autodetected ones")
+
+	addThresholdFlags(cmd)
+	addParallelismFlag(cmd)
+
+	_ = cmd.MarkFlagDirname("path")
+	_ = cmd.MarkFlagFilename("config-file", "yml")
+
+	return cmd
+}
+
+// projectHasStagedChanges returns true if any of the directories with staged
+// changes is in the project or in a local module it calls, so staged changes to
+// a shared module select every project that uses it.
+func projectHasStagedChanges(p *config.Project, dirs []string) (bool, error) {
+	moduleDirs, err := modules.LocalModuleDirs(p.Path)
+	if err != nil {
+		return false, fmt.Errorf("Error finding the modules of %s: %w", ui.DisplayPath(p.Path), err)
+	}
+
+	for _, dir := range moduleDirs {
+		if projectContainsAny(dir, dirs) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// projectContainsAny returns true if any of the directories is the directory of
+// the project or in it.
+func projectContainsAny(projectPath string, dirs []string) bool {
+	abs, err := filepath.Abs(projectPath)
+	if err != nil {
+		return false
+	}
+
+	// The staged files are in the top level directory of the repo, which git
+	// returns with any symlinks resolved.
+	if resolved, err := filepath.EvalSymlinks(abs); err == nil {
+		abs = resolved
+	}
+
+	for _, dir := range dirs {
+		rel, err := filepath.Rel(abs, dir)
+		if err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,42 @@
+package main
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/infracost/infracost/internal/config"
+)
+
+func TestProjectHasStagedChanges(t *testing.T) {
+	dir := writeSelectChangedFixture(t)
+	prod := &config.Project{Path: filepath.Join(dir, "infra", "prod")}
+	dev := &config.Project{Path: filepath.Join(dir, "infra", "dev")}
+
+	tests := []struct {
+		name   string
+		staged string
+		prod   bool
+		dev    bool
+	}{
+		{name: "project", staged: "infra/prod", prod: true},
+		{name: "shared module", staged: "modules/app", prod: true, dev: true},
+		{name: "other directory", staged: "docs"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dirs := []string{filepath.Join(dir, filepath.FromSlash(tt.staged))}
+
+			affected, err := projectHasStagedChanges(prod, dirs)
+			require.NoError(t, err)
+			assert.Equal(t, tt.prod, affected, "prod")
+
+			affected, err = projectHasStagedChanges(dev, dirs)
+			require.NoError(t, err)
+			assert.Equal(t, tt.dev, affected, "dev")
+		})
+	}
+}
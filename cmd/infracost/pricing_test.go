@@ -0,0 +1,19 @@
+package main_test
+
+import (
+	"testing"
+
+	"github.com/infracost/infracost/internal/testutil"
+)
+
+func TestPricingHelp(t *testing.T) {
+	GoldenFileCommandTest(t, testutil.CalcGoldenFileTestdataDirName(), []string{"pricing", "--help"}, nil)
+}
+
+func TestPricingDownloadHelp(t *testing.T) {
+	GoldenFileCommandTest(t, testutil.CalcGoldenFileTestdataDirName(), []string{"pricing", "download", "--help"}, nil)
+}
+
+func TestPricingSyncHelp(t *testing.T) {
+	GoldenFileCommandTest(t, testutil.CalcGoldenFileTestdataDirName(), []string{"pricing", "sync", "--help"}, nil)
+}
@@ -0,0 +1,116 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/infracost/infracost/internal/config"
+	"github.com/infracost/infracost/internal/output"
+	"github.com/infracost/infracost/internal/ui"
+)
+
+var validRecommendFormats = []string{"table", "json"}
+
+func recommendCmd(ctx *config.RunContext) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "recommend",
+		Short: "Recommend ways to reduce costs",
+		Long:  "Recommend ways to reduce costs",
+		Example: `  Recommend Savings Plans, Reserved Instances and Committed Use Discounts for a breakdown:
+
+      infracost breakdown --path /path/to/code --format json --out-file infracost-base.json
+      infracost recommend commitments --path infracost-base.json`,
+		ValidArgs: []string{"--", "-"},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cmd.Help()
+		},
+	}
+
+	cmd.AddCommand(recommendCommitmentsCmd(ctx))
+
+	return cmd
+}
+
+func recommendCommitmentsCmd(ctx *config.RunContext) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "commitments",
+		Short: "Recommend Savings Plan, Reserved Instance and Committed Use purchases",
+		Long: `Recommend Savings Plan, Reserved Instance and Committed Use purchases
+
+Analyzes the on-demand instance usage of Infracost JSON files and shows the
+commitments that would cover it, with the hourly commitment to purchase and the
+estimated monthly savings of each term. Savings are estimated using typical no
+upfront discounts, actual discounts vary by instance type and region.`,
+		Example: `  Recommend commitments for a breakdown:
+
+      infracost recommend commitments --path infracost-base.json
+
+  Recommend commitments covering 70% of the on-demand usage as JSON:
+
+      infracost recommend commitments --path "out*.json" --coverage 70 --format json # glob needs quotes`,
+		ValidArgs: []string{"--", "-"},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			format, _ := cmd.Flags().GetString("format")
+			if !contains(validRecommendFormats, format) {
+				ui.PrintUsage(cmd)
+				return fmt.Errorf("--format only supports %s", strings.Join(validRecommendFormats, ", "))
+			}
+
+			coverage, _ := cmd.Flags().GetFloat64("coverage")
+			if coverage <= 0 || coverage > 100 {
+				ui.PrintUsage(cmd)
+				return fmt.Errorf("--coverage must be greater than 0 and at most 100")
+			}
+
+			paths, _ := cmd.Flags().GetStringArray("path")
+
+			inputs, err := output.LoadPaths(paths)
+			if err != nil {
+				return err
+			}
+
+			combined, err := output.Combine(inputs, output.CombineOptions{})
+			if err != nil {
+				return err
+			}
+
+			report := output.RecommendCommitments(combined, output.CommitmentOptions{
+				CoveragePercent: coverage,
+			})
+
+			var b []byte
+			if format == "json" {
+				b, err = output.ToCommitmentsJSON(report)
+			} else {
+				b, err = output.ToCommitmentsTable(report)
+			}
+			if err != nil {
+				return err
+			}
+
+			if outFile, _ := cmd.Flags().GetString("out-file"); outFile != "" {
+				return saveOutFile(ctx, cmd, outFile, b)
+			}
+
+			cmd.Println(string(b))
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringArrayP("path", "p", []string{}, "Path to Infracost JSON files, glob patterns need quotes")
+	cmd.Flags().StringP("out-file", "o", "", "Save output to a file, helpful with format flag")
+	cmd.Flags().String("format", "table", "Output format: table, json")
+	cmd.Flags().Float64("coverage", 100, "Percentage of the on-demand instance usage to cover with commitments")
+
+	_ = cmd.MarkFlagRequired("path")
+	_ = cmd.MarkFlagFilename("path", "json")
+
+	_ = cmd.RegisterFlagCompletionFunc("format", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return validRecommendFormats, cobra.ShellCompDirectiveDefault
+	})
+
+	return cmd
+}
@@ -0,0 +1,21 @@
+package main_test
+
+import (
+	"testing"
+
+	"github.com/infracost/infracost/internal/testutil"
+)
+
+func TestRecommendHelp(t *testing.T) {
+	GoldenFileCommandTest(t, testutil.CalcGoldenFileTestdataDirName(), []string{"recommend", "--help"}, nil)
+}
+
+func TestRecommendCommitmentsHelp(t *testing.T) {
+	GoldenFileCommandTest(t, testutil.CalcGoldenFileTestdataDirName(), []string{"recommend", "commitments", "--help"}, nil)
+}
+
+func TestRecommendCommitmentsJSON(t *testing.T) {
+	opts := DefaultOptions()
+	opts.IsJSON = true
+	GoldenFileCommandTest(t, testutil.CalcGoldenFileTestdataDirName(), []string{"recommend", "commitments", "--format", "json", "--path", "./testdata/example_out.json"}, opts)
+}
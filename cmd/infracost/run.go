@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"path/filepath"
 	"runtime"
 	"runtime/debug"
 	"sort"
@@ -21,15 +22,30 @@ import (
 	"golang.org/x/sync/errgroup"
 
 	"github.com/infracost/infracost/internal/apiclient"
+	"github.com/infracost/infracost/internal/approval"
+	"github.com/infracost/infracost/internal/awspricelist"
+	"github.com/infracost/infracost/internal/bestpractice"
+	"github.com/infracost/infracost/internal/budget"
 	"github.com/infracost/infracost/internal/clierror"
 	"github.com/infracost/infracost/internal/config"
+	"github.com/infracost/infracost/internal/idle"
+	"github.com/infracost/infracost/internal/jira"
 	"github.com/infracost/infracost/internal/output"
+	"github.com/infracost/infracost/internal/owners"
+	"github.com/infracost/infracost/internal/pricebook"
+	"github.com/infracost/infracost/internal/pricecache"
 	"github.com/infracost/infracost/internal/prices"
+	"github.com/infracost/infracost/internal/pricesnapshot"
 	"github.com/infracost/infracost/internal/providers"
 	"github.com/infracost/infracost/internal/providers/terraform"
+	"github.com/infracost/infracost/internal/providers/terraform/hcloud"
+	"github.com/infracost/infracost/internal/resultcache"
+	"github.com/infracost/infracost/internal/rightsizing"
 	"github.com/infracost/infracost/internal/schema"
+	"github.com/infracost/infracost/internal/tagpolicy"
 	"github.com/infracost/infracost/internal/ui"
 	"github.com/infracost/infracost/internal/usage"
+	"github.com/infracost/infracost/internal/waiver"
 )
 
 type projectJob struct {
@@ -50,11 +66,45 @@ type hclRunDiff struct {
 
 var validRunFormats = []string{"json", "table", "html"}
 
+// showSkippedValue is the value of --show-skipped. It's used like a bool flag
+// but can also be set to json to output a report of each skipped resource.
+type showSkippedValue string
+
+func (v *showSkippedValue) String() string {
+	if *v == "" {
+		return "false"
+	}
+
+	return string(*v)
+}
+
+func (v *showSkippedValue) Set(s string) error {
+	switch strings.ToLower(s) {
+	case "true", "1":
+		*v = "true"
+	case "false", "0":
+		*v = ""
+	case "json":
+		*v = "json"
+	default:
+		return fmt.Errorf("must be true, false or json")
+	}
+
+	return nil
+}
+
+// Type is bool so the flag's help is the same as other bool flags.
+func (v *showSkippedValue) Type() string {
+	return "bool"
+}
+
 func addRunFlags(cmd *cobra.Command) {
 	cmd.Flags().StringP("path", "p", "", "Path to the Terraform directory or JSON/plan file")
 
 	cmd.Flags().String("config-file", "", "Path to Infracost config file. Cannot be used with path, terraform* or usage-file flags")
 	cmd.Flags().String("usage-file", "", "Path to Infracost usage file that specifies values for usage-based resources")
+	cmd.Flags().String("usage-profile", "", "Profile of the usage file to use, e.g. prod. Overrides the usage_profile of projects in the config file")
+	cmd.Flags().String("usage-scenario", "", "Scenario of usage values given as low, expected and high to use: low, expected, high, or all to show the monthly cost of each. Supported by table and json output formats")
 
 	cmd.Flags().String("terraform-plan-flags", "", "Flags to pass to 'terraform plan'. Applicable when path is a Terraform directory")
 	cmd.Flags().String("terraform-init-flags", "", "Flags to pass to 'terraform init'. Applicable when path is a Terraform directory")
@@ -62,7 +112,9 @@ func addRunFlags(cmd *cobra.Command) {
 
 	cmd.Flags().Bool("no-cache", false, "Don't attempt to cache Terraform plans")
 
-	cmd.Flags().Bool("show-skipped", false, "List unsupported and free resources")
+	showSkipped := showSkippedValue("")
+	cmd.Flags().Var(&showSkipped, "show-skipped", "List unsupported and free resources, or set to json to output a report of each skipped resource")
+	cmd.Flags().Lookup("show-skipped").NoOptDefVal = "true"
 
 	cmd.Flags().Bool("sync-usage-file", false, "Sync usage-file with missing resources, needs usage-file too (experimental)")
 
@@ -71,6 +123,86 @@ func addRunFlags(cmd *cobra.Command) {
 	_ = cmd.MarkFlagFilename("usage-file", "yml")
 }
 
+// addParallelismFlag adds --parallelism to the commands that run their projects
+// at the same time with runProjectConfigs.
+func addParallelismFlag(cmd *cobra.Command) {
+	cmd.Flags().Int("parallelism", 0, "Number of projects to run at the same time, defaults to 4 per CPU up to 16. Also set by INFRACOST_PARALLELISM")
+}
+
+// addThresholdFlags adds the flags that fail the run when the diff of its
+// projects is over a threshold.
+func addThresholdFlags(cmd *cobra.Command) {
+	cmd.Flags().String("fail-on-increase", "", "Exit with an error when the monthly cost increase is over an amount, e.g. 500, or a percentage of the past cost, e.g. 10%")
+	cmd.Flags().String("fail-on-total", "", "Exit with an error when the total monthly cost is over an amount, e.g. 5000")
+}
+
+func addLockFileFlags(cmd *cobra.Command) {
+	cmd.Flags().Bool("write-lock-file", false, "Record the module versions and provider regions of each project in its .infracost.lock.json. Only supported with --terraform-parse-hcl (experimental)")
+	cmd.Flags().Bool("locked", false, "Pin the module versions and provider regions of each project to its .infracost.lock.json so runs of a commit give identical estimates. Only supported with --terraform-parse-hcl (experimental)")
+}
+
+func addPricingFlags(cmd *cobra.Command) {
+	cmd.Flags().String("pricing-backend", config.PricingBackendInfracost, "Source of AWS prices: infracost, aws-price-list. aws-price-list uses the AWS Price List API with your AWS credentials")
+	cmd.Flags().Bool("pricing-offline", false, "Use prices from the pricing snapshot instead of the Cloud Pricing API, see 'infracost pricing download'")
+	cmd.Flags().String("pricing-snapshot", pricesnapshot.DefaultPath, "Path to the pricing snapshot used with pricing-offline")
+	cmd.Flags().Bool("no-price-cache", false, "Don't use the cache of Cloud Pricing API results shared by runs on this machine")
+	cmd.Flags().Bool("allow-unavailable-prices", false, "Mark cost components as price unavailable when their prices can't be looked up instead of failing")
+	cmd.Flags().Bool("fail-on-pricing-issues", false, "Exit with an error when the prices of cost components can't be found or are ambiguous")
+	cmd.Flags().Bool("free-tier", false, "Subtract the cloud providers' free tier allowances from the costs")
+
+	_ = cmd.MarkFlagFilename("pricing-snapshot", "gz")
+
+	_ = cmd.RegisterFlagCompletionFunc("pricing-backend", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return config.PricingBackends, cobra.ShellCompDirectiveDefault
+	})
+}
+
+func loadPricingFlags(cfg *config.Config, cmd *cobra.Command) error {
+	if cmd.Flags().Changed("pricing-backend") {
+		cfg.PricingBackend, _ = cmd.Flags().GetString("pricing-backend")
+	}
+
+	if !contains(config.PricingBackends, cfg.PricingBackend) {
+		ui.PrintUsage(cmd)
+		return fmt.Errorf("--pricing-backend only supports %s", strings.Join(config.PricingBackends, ", "))
+	}
+
+	if cmd.Flags().Changed("pricing-offline") {
+		cfg.PricingOffline, _ = cmd.Flags().GetBool("pricing-offline")
+	}
+
+	if cmd.Flags().Changed("pricing-snapshot") {
+		cfg.PricingSnapshotPath, _ = cmd.Flags().GetString("pricing-snapshot")
+	}
+
+	if cmd.Flags().Changed("no-price-cache") {
+		cfg.NoPriceCache, _ = cmd.Flags().GetBool("no-price-cache")
+	}
+
+	if cmd.Flags().Changed("allow-unavailable-prices") {
+		cfg.AllowUnavailablePrices, _ = cmd.Flags().GetBool("allow-unavailable-prices")
+	}
+
+	if cmd.Flags().Changed("fail-on-pricing-issues") {
+		cfg.FailOnPricingIssues, _ = cmd.Flags().GetBool("fail-on-pricing-issues")
+	}
+
+	if cmd.Flags().Changed("free-tier") {
+		cfg.FreeTier, _ = cmd.Flags().GetBool("free-tier")
+	}
+
+	if cfg.FreeTierAccountCreated != "" && cfg.FreeTierAccountCreatedAt().IsZero() {
+		return errors.New("INFRACOST_FREE_TIER_ACCOUNT_CREATED must be a date in the format YYYY-MM-DD")
+	}
+
+	if cfg.PricingOffline && cfg.UsesAWSPriceList() {
+		ui.PrintUsage(cmd)
+		return errors.New("--pricing-offline cannot be used with --pricing-backend aws-price-list")
+	}
+
+	return nil
+}
+
 // panicError is used to collect goroutine panics into an error interface so
 // that we can do type assertion on err checking.
 type panicError struct {
@@ -92,87 +224,73 @@ func runMain(cmd *cobra.Command, runCtx *config.RunContext) error {
 	}
 	runCtx.SetContextValue("parallelism", parallelism)
 
-	numJobs := len(runCtx.Config.Projects)
-	jobs := make(chan projectJob, numJobs)
+	if runCtx.Config.PricingOffline {
+		err := loadPriceSnapshot(runCtx)
+		if err != nil {
+			return err
+		}
+	}
 
-	projectResultChan := make(chan projectResult, numJobs)
-	errGroup, _ := errgroup.WithContext(context.Background())
+	if runCtx.Config.UsesPriceCache() {
+		runCtx.PriceCache = pricecache.New(runCtx.Config.PriceCacheDir, runCtx.Config.PriceCacheTTL)
+	}
 
-	runInParallel := parallelism > 1 && numJobs > 1
-	if (runInParallel || runCtx.IsCIRun()) && !runCtx.Config.IsLogging() {
-		if runInParallel {
-			cmd.PrintErrln("Running multiple projects in parallel, so log-level=info is enabled by default.")
-			cmd.PrintErrln("Run with INFRACOST_PARALLELISM=1 to disable parallelism to help debugging.")
-			cmd.PrintErrln()
-		}
+	if runCtx.Config.CacheResults {
+		runCtx.ResultCache = resultcache.New(runCtx.Config.ResultCacheDir, runCtx.Config.ResultCacheTTL)
+	}
 
-		runCtx.Config.LogLevel = "info"
-		err := runCtx.Config.ConfigureLogger()
+	if runCtx.Config.UsesAWSPriceList() {
+		runCtx.AWSPriceList, err = awspricelist.New(context.Background(), awspricelist.DefaultCacheDir())
 		if err != nil {
 			return err
 		}
 	}
 
-	// Create a mutex for each path, so we can synchronize the runs of any
-	// projects that have the same path. This is necessary because Terraform
-	// can't run multiple operations in parallel on the same path.
-	pathMuxs := map[string]*sync.Mutex{}
-	for _, projectCfg := range runCtx.Config.Projects {
-		pathMuxs[projectCfg.Path] = &sync.Mutex{}
+	err = loadPolicyPacks(runCtx, runCtx.Config.PolicyPacks)
+	if err != nil {
+		return err
 	}
 
-	for i := 0; i < parallelism; i++ {
-		errGroup.Go(func() (err error) {
-			// defer a function to recover from any panics spawned by child goroutines.
-			// This is done as recover works only in the same goroutine that it is called.
-			// We need to catch any child goroutine panics and hand them up to the main caller
-			// so that it can be caught and displayed correctly to the user.
-			defer func() {
-				e := recover()
-				if e != nil {
-					err = &panicError{msg: fmt.Sprintf("%s\n%s", e, debug.Stack())}
-				}
-			}()
-
-			for job := range jobs {
-				mux := pathMuxs[job.projectCfg.Path]
-
-				ctx := config.NewProjectContext(runCtx, job.projectCfg)
-				configProjects, err := runProjectConfig(cmd, runCtx, ctx, job.projectCfg, mux)
-				if err != nil {
-					return err
-				}
+	if runCtx.Config.PriceBookPath != "" {
+		runCtx.Config.PriceBook, err = pricebook.Load(runCtx.Config.PriceBookPath)
+		if err != nil {
+			return err
+		}
+	}
 
-				projectResultChan <- projectResult{
-					index:      job.index,
-					ctx:        ctx,
-					projectOut: configProjects,
-				}
-			}
+	err = loadAzurePriceSheet(runCtx)
+	if err != nil {
+		return err
+	}
 
-			return nil
-		})
+	if runCtx.Config.SelectChangedProjects {
+		err = selectChangedProjects(cmd, runCtx)
+		if err != nil {
+			return err
+		}
 	}
 
-	for i, p := range runCtx.Config.Projects {
-		jobs <- projectJob{index: i, projectCfg: p}
+	numJobs := len(runCtx.Config.Projects)
+	runInParallel := parallelism > 1 && numJobs > 1
+	if (runInParallel || runCtx.IsCIRun()) && !runCtx.Config.IsLogging() {
+		if runInParallel {
+			cmd.PrintErrln("Running multiple projects in parallel, so log-level=info is enabled by default.")
+			cmd.PrintErrln("Run with --parallelism 1 to disable parallelism to help debugging.")
+			cmd.PrintErrln()
+		}
+
+		runCtx.Config.LogLevel = "info"
+		err := runCtx.Config.ConfigureLogger()
+		if err != nil {
+			return err
+		}
 	}
-	close(jobs)
 
-	err = errGroup.Wait()
+	projectResults, err := runProjectConfigs(cmd, runCtx, runCtx.Config.Projects, parallelism)
 	if err != nil {
 		return err
 	}
 
-	close(projectResultChan)
-	projectResults := make([]projectResult, 0, len(runCtx.Config.Projects))
-	for result := range projectResultChan {
-		projectResults = append(projectResults, result)
-	}
-	sort.Slice(projectResults, func(i, j int) bool {
-		return projectResults[i].index < projectResults[j].index
-	})
-
 	projects := make([]*schema.Project, 0)
 	projectContexts := make([]*config.ProjectContext, 0)
 
@@ -186,6 +304,30 @@ func runMain(cmd *cobra.Command, runCtx *config.RunContext) error {
 		hclProjects = append(hclProjects, projectResult.projectOut.hclProjects...)
 	}
 
+	if runCtx.Config.CompareToBaseline != "" {
+		err = compareToBaseline(cmd, runCtx.Config.CompareToBaseline, projects)
+		if err != nil {
+			return err
+		}
+	}
+
+	if runCtx.Config.CompareToRef != "" {
+		err = compareToRef(cmd, runCtx, runCtx.Config.CompareToRef, parallelism, projects)
+		if err != nil {
+			return err
+		}
+	}
+
+	if runCtx.Config.ExplainResource != "" {
+		b, err := output.ToExplanation(projects, runCtx.Config.ExplainResource, runCtx.Config.Currency)
+		if err != nil {
+			return err
+		}
+
+		cmd.Println(string(b))
+		return nil
+	}
+
 	wg := &sync.WaitGroup{}
 	var hclR *output.Root
 	if len(hclProjects) > 0 {
@@ -202,6 +344,64 @@ func runMain(cmd *cobra.Command, runCtx *config.RunContext) error {
 	wg.Wait()
 	r.IsCIRun = runCtx.IsCIRun()
 	r.Currency = runCtx.Config.Currency
+	r.ExchangeRate = runCtx.Config.CurrencyRate()
+
+	if r.IsCIRun && len(runCtx.Config.Projects) > 0 {
+		r.VCS = config.DetectVCSMetadata(runCtx.Config.Projects[0].Path)
+	}
+
+	err = output.ConvertProjectCurrencies(&r, projectCurrencyRates(runCtx.Config, projects))
+	if err != nil {
+		return err
+	}
+
+	if runCtx.Config.OwnersFile != "" {
+		o, err := owners.Load(runCtx.Config.OwnersFile)
+		if err != nil {
+			return err
+		}
+
+		o.Assign(&r)
+	} else {
+		for _, b := range runCtx.Config.Budgets {
+			if b.Owner != "" {
+				return fmt.Errorf("Budget %s has an owner but no owners_file is set", b.Label())
+			}
+		}
+	}
+
+	if runCtx.Config.ComparePricesToPath != "" {
+		err = addPriceChanges(&r, runCtx.Config.ComparePricesToPath)
+		if err != nil {
+			return err
+		}
+	}
+
+	if cmd.Name() == "breakdown" || cmd.Name() == "diff" {
+		r.GuardrailViolations = append(budget.CheckAnnotations(r), budget.CheckGuardrails(r, runCtx.Config.Guardrails)...)
+	}
+
+	if len(runCtx.Config.TagPolicies) > 0 && (cmd.Name() == "breakdown" || cmd.Name() == "diff") {
+		r.TagPolicies = tagpolicy.Check(r, runCtx.Config.TagPolicies)
+	}
+
+	if runCtx.Config.WaiversFile != "" && (cmd.Name() == "breakdown" || cmd.Name() == "diff") {
+		waivers, err := waiver.Load(runCtx.Config.WaiversFile)
+		if err != nil {
+			return err
+		}
+
+		waiver.Apply(&r, waivers, time.Now())
+	}
+
+	if runCtx.Config.ShowRecommendations && (cmd.Name() == "breakdown" || cmd.Name() == "diff") {
+		r.Recommendations = bestpractice.Check(projects, projectCurrencyRates(runCtx.Config, projects))
+	}
+
+	if cmd.Name() == "breakdown" || cmd.Name() == "diff" {
+		r.Rightsizing = rightsizing.Recommend(projects, projectCurrencyRates(runCtx.Config, projects))
+		r.IdleResources = idle.Detect(projects, projectCurrencyRates(runCtx.Config, projects))
+	}
 
 	dashboardClient := apiclient.NewDashboardAPIClient(runCtx)
 	result, err := dashboardClient.AddRun(runCtx, projectContexts, r)
@@ -211,57 +411,285 @@ func runMain(cmd *cobra.Command, runCtx *config.RunContext) error {
 
 	r.RunID, r.ShareURL = result.RunID, result.ShareURL
 
+	// Filters only change what's shown, budgets and pricing issues are still
+	// checked against all the resources.
+	out := r
+	if len(runCtx.Config.Filters) > 0 {
+		filters, err := output.ParseFilters(runCtx.Config.Filters)
+		if err != nil {
+			return err
+		}
+
+		out = output.FilterResources(r, filters)
+	}
+
+	if runCtx.Config.ProjectionMonths > 0 {
+		output.AddProjection(&out, runCtx.Config.ProjectionMonths, runCtx.Config.ProjectionGrowthPercent)
+	}
+
+	output.AddProjectGroups(&out)
+
+	if runCtx.Config.GroupBy != "" {
+		err = output.AddCostGroups(&out, runCtx.Config.GroupBy)
+		if err != nil {
+			return err
+		}
+	}
+
+	output.AddOwnerCosts(&out)
+
 	opts := output.Options{
 		DashboardEnabled: runCtx.Config.EnableDashboard,
 		ShowSkipped:      runCtx.Config.ShowSkipped,
 		NoColor:          runCtx.Config.NoColor,
 		Fields:           runCtx.Config.Fields,
+		Granularity:      runCtx.Config.Granularity,
 	}
 
 	var b []byte
 
-	switch strings.ToLower(runCtx.Config.Format) {
+	format := strings.ToLower(runCtx.Config.Format)
+	if runCtx.Config.ShowSkippedJSON {
+		format = "skipped-json"
+	}
+
+	switch format {
+	case "skipped-json":
+		b, err = output.ToSkippedJSON(out, opts)
 	case "json":
-		b, err = output.ToJSON(r, opts)
+		b, err = output.ToJSON(out, opts)
 	case "html":
-		b, err = output.ToHTML(r, opts)
+		b, err = output.ToHTML(out, opts)
 	case "diff":
-		b, err = output.ToDiff(r, opts)
+		b, err = output.ToDiff(out, opts)
+	case "oneline":
+		b, err = output.ToOneLine(out, opts)
 	default:
-		b, err = output.ToTable(r, opts)
+		b, err = output.ToTable(out, opts)
+	}
+
+	if err != nil {
+		return errors.Wrap(err, "Error generating output")
+	}
+
+	// The thresholds are checked before the output is written so the output can
+	// be annotated with the exceeded ones.
+	var thresholdViolations []budget.Violation
+	if runCtx.Config.DiffThresholds != nil {
+		thresholdViolations = budget.Check(r, []*config.Budget{runCtx.Config.DiffThresholds})
+		if len(thresholdViolations) > 0 && format == "diff" {
+			b = append(b, []byte("\n\n"+strings.TrimSpace(budget.ThresholdReport(thresholdViolations)))...)
+		}
+	}
+
+	if runCtx.Config.Format == "diff" || runCtx.Config.Format == "table" {
+		lines := bytes.Count(b, []byte("\n")) + 1
+		runCtx.SetContextValue("lineCount", lines)
+	}
+
+	env := buildRunEnv(runCtx, projectContexts, r, projects, hclR, hclProjects)
+
+	pricingClient := apiclient.NewPricingAPIClient(runCtx)
+	err = pricingClient.AddEvent("infracost-run", env)
+	if err != nil {
+		log.Errorf("Error reporting event: %s", err)
+	}
+
+	if outFile, _ := cmd.Flags().GetString("out-file"); outFile != "" {
+		err = saveOutFile(runCtx, cmd, outFile, b)
+		if err != nil {
+			return err
+		}
+	} else {
+		// Print a new line to separate the logs from the output
+		if runCtx.Config.IsLogging() {
+			cmd.PrintErrln()
+		}
+		cmd.Println(string(b))
+	}
+
+	if len(runCtx.Config.Budgets) > 0 && (cmd.Name() == "breakdown" || cmd.Name() == "diff") {
+		violations := budget.Check(r, runCtx.Config.Budgets)
+		if len(violations) > 0 {
+			return errors.New(strings.TrimSpace(budget.Report(violations)))
+		}
+	}
+
+	approved := false
+	if len(r.GuardrailViolations) > 0 && runCtx.Config.ApprovalWebhookURL != "" {
+		approved, err = requestApproval(cmd, runCtx, r)
+		if err != nil {
+			return err
+		}
+	}
+
+	if len(r.GuardrailViolations) > 0 && len(runCtx.Config.JiraIssues) > 0 {
+		syncJiraIssues(cmd, runCtx, r)
 	}
 
-	if err != nil {
-		return errors.Wrap(err, "Error generating output")
+	if r.BlockingGuardrailViolations() > 0 && !approved {
+		return errors.New(strings.TrimSpace(budget.GuardrailReport(r.GuardrailViolations)))
+	}
+
+	if r.BlockingTagPolicies() > 0 {
+		return errors.New(strings.TrimSpace(tagpolicy.Report(r.TagPolicies, r.Currency)))
+	}
+
+	if len(thresholdViolations) > 0 {
+		return fmt.Errorf("%d diff thresholds exceeded, failing since --fail-on-increase or --fail-on-total is set", len(thresholdViolations))
+	}
+
+	if runCtx.Config.FailOnPricingIssues && len(r.PricingIssues) > 0 {
+		return fmt.Errorf("%d cost components have pricing issues, failing since --fail-on-pricing-issues is set", len(r.PricingIssues))
+	}
+
+	return nil
+}
+
+// requestApproval sends the diff of a run that breaches guardrails to the
+// approval webhook and, with --wait-for-approval, returns whether the change was
+// approved. Without --wait-for-approval the webhook is only notified, so errors
+// sending to it don't fail the run.
+func requestApproval(cmd *cobra.Command, runCtx *config.RunContext, r output.Root) (bool, error) {
+	cfg := runCtx.Config
+	client := approval.NewClient(cfg.ApprovalWebhookURL, cfg.ApprovalCallbackURL, cfg.ApprovalWebhookToken, cfg.ApprovalPollInterval, cfg.ApprovalTimeout)
+
+	req, err := client.Send(context.Background(), r)
+	if err != nil {
+		if !cfg.WaitForApproval {
+			log.Errorf("Error sending guardrail violations to the approval webhook: %s", err)
+			return false, nil
+		}
+
+		return false, err
+	}
+
+	if !cfg.WaitForApproval {
+		return false, nil
+	}
+
+	cmd.PrintErrf("Waiting up to %s for approval request %s to be approved\n", cfg.ApprovalTimeout, req.ID)
+
+	d, err := client.Wait(context.Background(), req)
+	if err != nil {
+		return false, err
+	}
+
+	by := ""
+	if d.Approver != "" {
+		by = " by " + d.Approver
+	}
+	comment := ""
+	if d.Comment != "" {
+		comment = ": " + d.Comment
+	}
+
+	if d.Status == approval.StatusRejected {
+		return false, fmt.Errorf("Approval request %s was rejected%s%s", req.ID, by, comment)
+	}
+
+	cmd.PrintErrf("Approval request %s was approved%s%s\n", req.ID, by, comment)
+
+	return true, nil
+}
+
+// syncJiraIssues opens or updates the Jira issues of the projects whose
+// guardrails are violated. Errors opening them are logged so they don't fail
+// the run.
+func syncJiraIssues(cmd *cobra.Command, runCtx *config.RunContext, r output.Root) {
+	cfg := runCtx.Config
+	if cfg.JiraURL == "" {
+		log.Warn("Not opening Jira issues for the guardrail violations since INFRACOST_JIRA_URL isn't set")
+		return
+	}
+
+	client := jira.NewClient(cfg.JiraURL, cfg.JiraEmail, cfg.JiraToken)
+
+	issues, err := client.Sync(context.Background(), r, cfg.JiraIssues)
+	if err != nil {
+		log.Errorf("%s", err)
+	}
+
+	for _, issue := range issues {
+		action := "Updated"
+		if issue.Created {
+			action = "Opened"
+		}
+
+		cmd.PrintErrf("%s Jira issue %s/browse/%s for the guardrail violations of %s\n", action, strings.TrimSuffix(cfg.JiraURL, "/"), issue.Key, issue.Project)
+	}
+}
+
+// runProjectConfigs runs the projects of the configs with the given parallelism,
+// returning their results in the order of the configs.
+func runProjectConfigs(cmd *cobra.Command, runCtx *config.RunContext, projectCfgs []*config.Project, parallelism int) ([]projectResult, error) {
+	numJobs := len(projectCfgs)
+	jobs := make(chan projectJob, numJobs)
+
+	projectResultChan := make(chan projectResult, numJobs)
+	errGroup, _ := errgroup.WithContext(context.Background())
+
+	// Create a mutex for each path, so we can synchronize the runs of any
+	// projects that have the same path. This is necessary because Terraform
+	// can't run multiple operations in parallel on the same path.
+	pathMuxs := map[string]*sync.Mutex{}
+	for _, projectCfg := range projectCfgs {
+		pathMuxs[projectCfg.Path] = &sync.Mutex{}
+	}
+
+	for i := 0; i < parallelism; i++ {
+		errGroup.Go(func() (err error) {
+			// defer a function to recover from any panics spawned by child goroutines.
+			// This is done as recover works only in the same goroutine that it is called.
+			// We need to catch any child goroutine panics and hand them up to the main caller
+			// so that it can be caught and displayed correctly to the user.
+			defer func() {
+				e := recover()
+				if e != nil {
+					err = &panicError{msg: fmt.Sprintf("%s\n%s", e, debug.Stack())}
+				}
+			}()
+
+			for job := range jobs {
+				mux := pathMuxs[job.projectCfg.Path]
+
+				ctx := config.NewProjectContext(runCtx, job.projectCfg)
+				configProjects, err := runProjectConfig(cmd, runCtx, ctx, job.projectCfg, mux)
+				if err != nil {
+					return err
+				}
+
+				projectResultChan <- projectResult{
+					index:      job.index,
+					ctx:        ctx,
+					projectOut: configProjects,
+				}
+			}
+
+			return nil
+		})
 	}
 
-	if runCtx.Config.Format == "diff" || runCtx.Config.Format == "table" {
-		lines := bytes.Count(b, []byte("\n")) + 1
-		runCtx.SetContextValue("lineCount", lines)
+	for i, p := range projectCfgs {
+		jobs <- projectJob{index: i, projectCfg: p}
 	}
+	close(jobs)
 
-	env := buildRunEnv(runCtx, projectContexts, r, projects, hclR, hclProjects)
-
-	pricingClient := apiclient.NewPricingAPIClient(runCtx)
-	err = pricingClient.AddEvent("infracost-run", env)
+	err := errGroup.Wait()
 	if err != nil {
-		log.Errorf("Error reporting event: %s", err)
+		return nil, err
 	}
 
-	if outFile, _ := cmd.Flags().GetString("out-file"); outFile != "" {
-		err = saveOutFile(runCtx, cmd, outFile, b)
-		if err != nil {
-			return err
-		}
-	} else {
-		// Print a new line to separate the logs from the output
-		if runCtx.Config.IsLogging() {
-			cmd.PrintErrln()
-		}
-		cmd.Println(string(b))
+	close(projectResultChan)
+	projectResults := make([]projectResult, 0, len(projectCfgs))
+	for result := range projectResultChan {
+		projectResults = append(projectResults, result)
 	}
+	sort.Slice(projectResults, func(i, j int) bool {
+		return projectResults[i].index < projectResults[j].index
+	})
 
-	return nil
+	return projectResults, nil
 }
 
 func formatHCLProjects(wg *sync.WaitGroup, ctx *config.RunContext, hclProjects []*schema.Project, hclR *output.Root) {
@@ -323,9 +751,29 @@ func runProjectConfig(cmd *cobra.Command, runCtx *config.RunContext, ctx *config
 		fmt.Fprintln(os.Stderr, m)
 	}
 
+	cacheKey := projectResultCacheKey(runCtx, projectCfg, provider)
+	if cacheKey != "" {
+		if projects, ok := runCtx.ResultCache.Get(cacheKey); ok {
+			m := fmt.Sprintf("Using the cached estimate of %s since its files haven't changed", ui.DisplayPath(projectCfg.Path))
+			if runCtx.Config.IsLogging() {
+				log.Info(m)
+			} else {
+				fmt.Fprintln(os.Stderr, m)
+			}
+
+			ctx.SetContextValue("usingResultCache", true)
+
+			if !runCtx.Config.IsLogging() && !runCtx.Config.SkipErrLine {
+				cmd.PrintErrln()
+			}
+
+			return &projectOutput{projects: projects}, nil
+		}
+	}
+
 	// Generate usage file
 	if runCtx.Config.SyncUsageFile {
-		err := generateUsageFile(cmd, runCtx, ctx, projectCfg, provider)
+		err := generateUsageFile(cmd, runCtx, ctx, projectCfg, provider, usage.SyncOptions{})
 		if err != nil {
 			return nil, errors.Wrap(err, "Error generating usage file")
 		}
@@ -333,15 +781,12 @@ func runProjectConfig(cmd *cobra.Command, runCtx *config.RunContext, ctx *config
 
 	// Load usage data
 	usageData := make(map[string]*schema.UsageData)
-	var usageFile *usage.UsageFile
-
-	if projectCfg.UsageFile != "" {
-		var err error
-		usageFile, err = usage.LoadUsageFile(projectCfg.UsageFile)
-		if err != nil {
-			return nil, err
-		}
+	usageFile, hasUsageFile, err := loadProjectUsageFile(projectCfg)
+	if err != nil {
+		return nil, err
+	}
 
+	if hasUsageFile {
 		invalidKeys, err := usageFile.InvalidKeys()
 		if err != nil {
 			log.Errorf("Error checking usage file keys: %v", err)
@@ -351,8 +796,6 @@ func runProjectConfig(cmd *cobra.Command, runCtx *config.RunContext, ctx *config
 				strings.Join(invalidKeys, ", "),
 			)
 		}
-	} else {
-		usageFile = usage.NewBlankUsageFile()
 	}
 
 	if len(usageData) > 0 {
@@ -383,7 +826,12 @@ func runProjectConfig(cmd *cobra.Command, runCtx *config.RunContext, ctx *config
 		us.MergeResourceUsage(wildCardUsage[prefixName])
 	}
 
-	usageData = usageFile.ToUsageDataMap()
+	scenario := runCtx.Config.UsageScenario
+	if scenario == "" || scenario == usage.ScenarioAll {
+		scenario = usage.ScenarioExpected
+	}
+
+	usageData = usageFile.ToScenarioUsageDataMap(scenario)
 	out := &projectOutput{}
 	wg := &sync.WaitGroup{}
 
@@ -399,6 +847,7 @@ func runProjectConfig(cmd *cobra.Command, runCtx *config.RunContext, ctx *config
 		cmd.PrintErrln()
 		return nil, err
 	}
+	applyProjectConfigNames(projectCfg, projects)
 
 	spinnerOpts := ui.SpinnerOptions{
 		EnableLogging: runCtx.Config.IsLogging(),
@@ -408,13 +857,140 @@ func runProjectConfig(cmd *cobra.Command, runCtx *config.RunContext, ctx *config
 	spinner := ui.NewSpinner("Retrieving cloud prices to calculate costs", spinnerOpts)
 	defer spinner.Fail()
 
+	err = priceProjects(runCtx, projectCfg, usageFile, projects)
+	if err == nil && runCtx.Config.UsageScenario == usage.ScenarioAll {
+		err = addUsageScenarioCosts(runCtx, projectCfg, provider, usageFile, projects)
+	}
+	if err != nil {
+		spinner.Fail()
+		cmd.PrintErrln()
+		return nil, err
+	}
+
+	t2 := time.Now()
+	taken := t2.Sub(t1).Milliseconds()
+	ctx.SetContextValue("tfProjectRunTimeMs", taken)
+
+	// wait for the hcl provider to finish if it hasn't already
+	wg.Wait()
+
+	spinner.Success()
+	out.projects = projects
+
+	if cacheKey != "" {
+		runCtx.ResultCache.Set(cacheKey, projects)
+	}
+
+	if !runCtx.Config.IsLogging() && !runCtx.Config.SkipErrLine {
+		cmd.PrintErrln()
+	}
+
+	return out, nil
+}
+
+// projectResultCacheKey returns the key the estimate of the project is cached
+// under, or an empty string if it isn't cached. Terragrunt projects aren't
+// cached since their inputs can come from files outside their directory, and
+// projects whose usage file is synced aren't since the sync changes it.
+func projectResultCacheKey(runCtx *config.RunContext, projectCfg *config.Project, provider schema.Provider) string {
+	if runCtx.ResultCache == nil || runCtx.Config.SyncUsageFile || provider.Type() == "terragrunt" {
+		return ""
+	}
+
+	// Anything other than the project's files and usage files that its estimate
+	// depends on.
+	settings := struct {
+		Project                *config.Project
+		UsageScenario          string
+		PricingBackend         string
+		PricingOffline         bool
+		PriceBookPath          string
+		AzurePriceSheetPath    string
+		SpotDiscountPercent    float64
+		FreeTier               bool
+		FreeTierAccountCreated string
+		DeriveUsage            bool
+		ShowPriceTiers         bool
+		Discounts              []*config.Discount
+		Commitments            []*schema.Commitment
+		Locked                 bool
+		NoModuleDownloads      bool
+	}{
+		Project:                projectCfg,
+		UsageScenario:          runCtx.Config.UsageScenario,
+		PricingBackend:         runCtx.Config.PricingBackend,
+		PricingOffline:         runCtx.Config.PricingOffline,
+		PriceBookPath:          runCtx.Config.PriceBookPath,
+		AzurePriceSheetPath:    runCtx.Config.AzurePriceSheetPath,
+		SpotDiscountPercent:    runCtx.Config.SpotDiscountPercent,
+		FreeTier:               runCtx.Config.FreeTier,
+		FreeTierAccountCreated: runCtx.Config.FreeTierAccountCreated,
+		DeriveUsage:            runCtx.Config.DeriveUsage,
+		ShowPriceTiers:         runCtx.Config.ShowPriceTiers,
+		Discounts:              runCtx.Config.Discounts,
+		Commitments:            runCtx.Config.Commitments,
+		Locked:                 runCtx.Config.Locked,
+		NoModuleDownloads:      runCtx.Config.NoModuleDownloads,
+	}
+
+	currency := projectCfg.Currency
+	if currency == "" {
+		currency = runCtx.Config.Currency
+	}
+
+	key, err := resultcache.Key(projectCfg.Path, projectCfg.UsageFilePaths(), projectVarFilePaths(projectCfg), currency, settings)
+	if err != nil {
+		log.Debugf("Not caching the estimate of %s: %s", projectCfg.Path, err)
+		return ""
+	}
+
+	return key
+}
+
+// applyProjectConfigNames sets the name, labels and group of the projects from
+// the config of the project they were loaded from. When the config has more
+// than one project, e.g. a Terragrunt directory, each is named by its path
+// relative to the config's path so they stay distinct.
+func applyProjectConfigNames(projectCfg *config.Project, projects []*schema.Project) {
+	for _, p := range projects {
+		if p.Metadata != nil {
+			p.Metadata.Labels = projectCfg.Labels
+			p.Metadata.Group = projectCfg.Group
+		}
+
+		if projectCfg.Name == "" {
+			continue
+		}
+
+		p.Name = projectCfg.Name
+		if len(projects) == 1 || p.Metadata == nil {
+			continue
+		}
+
+		// The paths of Terragrunt units are absolute
+		base, _ := filepath.Abs(projectCfg.Path)
+		path, _ := filepath.Abs(p.Metadata.Path)
+
+		rel, err := filepath.Rel(base, path)
+		if err == nil && rel != "." && !strings.HasPrefix(rel, "..") {
+			p.Name += "/" + filepath.ToSlash(rel)
+		}
+
+		if p.Metadata.TerraformWorkspace != "" && p.Metadata.TerraformWorkspace != "default" {
+			p.Name += fmt.Sprintf(" (%s)", p.Metadata.TerraformWorkspace)
+		}
+	}
+}
+
+// priceProjects looks up the prices of the resources of the projects and
+// calculates their costs.
+func priceProjects(runCtx *config.RunContext, projectCfg *config.Project, usageFile *usage.UsageFile, projects []*schema.Project) error {
 	for _, project := range projects {
-		if err := prices.PopulatePrices(runCtx, project); err != nil {
-			spinner.Fail()
-			cmd.PrintErrln()
+		project.Currency = projectCurrency(runCtx.Config, projectCfg, project)
 
+		if err := prices.PopulatePrices(runCtx, project); err != nil {
 			if e := unwrapped(err); errors.Is(e, apiclient.ErrInvalidAPIKey) {
-				return nil, fmt.Errorf("%v\n%s %s %s %s %s\n%s",
+				return fmt.Errorf("%v\n%s %s %s %s %s\n%s",
 					e.Error(),
 					"Please check your",
 					ui.PrimaryString(config.CredentialsFilePath()),
@@ -426,31 +1002,63 @@ func runProjectConfig(cmd *cobra.Command, runCtx *config.RunContext, ctx *config
 			}
 
 			if e, ok := err.(*apiclient.APIError); ok {
-				return nil, fmt.Errorf("%v\n%s", e.Error(), "We have been notified of this issue.")
+				return fmt.Errorf("%v\n%s", e.Error(), "We have been notified of this issue.")
 			}
 
-			return nil, err
+			return err
 		}
 
+		prices.ApplyCommitments(runCtx, projectCommitments(runCtx, usageFile), project)
 		schema.CalculateCosts(project)
+		prices.ApplyFreeTier(runCtx.Config.FreeTier, runCtx.Config.FreeTierAccountCreatedAt(), project)
+		if runCtx.Config.ShowPriceTiers {
+			prices.AddPriceTiers(project)
+		}
+		prices.ApplyDiscounts(runCtx.Config.Discounts, project)
 		project.CalculateDiff()
 	}
 
-	t2 := time.Now()
-	taken := t2.Sub(t1).Milliseconds()
-	ctx.SetContextValue("tfProjectRunTimeMs", taken)
+	return nil
+}
 
-	// wait for the hcl provider to finish if it hasn't already
-	wg.Wait()
+// addUsageScenarioCosts sets the monthly cost of the projects for each usage
+// scenario. The projects have been priced with the expected usage, so their
+// resources are loaded and priced again with the low and high usage. This is
+// skipped if the usage file doesn't give any values per scenario since they'd
+// all cost the same.
+func addUsageScenarioCosts(runCtx *config.RunContext, projectCfg *config.Project, provider schema.Provider, usageFile *usage.UsageFile, projects []*schema.Project) error {
+	for _, project := range projects {
+		project.UsageScenarioCosts = make(map[string]decimal.Decimal, len(usage.Scenarios))
+		for _, scenario := range usage.Scenarios {
+			project.UsageScenarioCosts[scenario] = projectsMonthlyCost([]*schema.Project{project})
+		}
+	}
 
-	spinner.Success()
-	out.projects = projects
+	if !usageFile.HasScenarios() {
+		return nil
+	}
 
-	if !runCtx.Config.IsLogging() && !runCtx.Config.SkipErrLine {
-		cmd.PrintErrln()
+	for _, scenario := range []string{usage.ScenarioLow, usage.ScenarioHigh} {
+		scenarioProjects, err := provider.LoadResources(usageFile.ToScenarioUsageDataMap(scenario))
+		if err != nil {
+			return errors.Wrapf(err, "Error loading resources for the %s usage scenario", scenario)
+		}
+
+		if len(scenarioProjects) != len(projects) {
+			return fmt.Errorf("Error loading resources for the %s usage scenario: expected %d projects, got %d", scenario, len(projects), len(scenarioProjects))
+		}
+
+		err = priceProjects(runCtx, projectCfg, usageFile, scenarioProjects)
+		if err != nil {
+			return err
+		}
+
+		for i, project := range projects {
+			project.UsageScenarioCosts[scenario] = projectsMonthlyCost([]*schema.Project{scenarioProjects[i]})
+		}
 	}
 
-	return out, nil
+	return nil
 }
 
 func runHCLProvider(wg *sync.WaitGroup, ctx *config.ProjectContext, usageFile *usage.UsageFile, runCtx *config.RunContext, out *projectOutput) {
@@ -485,13 +1093,21 @@ func runHCLProvider(wg *sync.WaitGroup, ctx *config.ProjectContext, usageFile *u
 	}
 
 	for _, project := range projects {
+		project.Currency = projectCurrency(runCtx.Config, ctx.ProjectConfig, project)
+
 		err := prices.PopulatePrices(runCtx, project)
 		if err != nil {
 			log.Debugf("Error populating prices for HCL project: %s", err)
 			return
 		}
 
+		prices.ApplyCommitments(runCtx, projectCommitments(runCtx, usageFile), project)
 		schema.CalculateCosts(project)
+		prices.ApplyFreeTier(runCtx.Config.FreeTier, runCtx.Config.FreeTierAccountCreatedAt(), project)
+		if runCtx.Config.ShowPriceTiers {
+			prices.AddPriceTiers(project)
+		}
+		prices.ApplyDiscounts(runCtx.Config.Discounts, project)
 		project.CalculateDiff()
 	}
 
@@ -501,7 +1117,79 @@ func runHCLProvider(wg *sync.WaitGroup, ctx *config.ProjectContext, usageFile *u
 	ctx.SetContextValue("hclProjectRunTimeMs", taken)
 }
 
-func generateUsageFile(cmd *cobra.Command, runCtx *config.RunContext, projectCtx *config.ProjectContext, projectCfg *config.Project, provider schema.Provider) error {
+// projectCommitments returns the commitments of the config file followed by the
+// commitments of the project usage file.
+func projectCommitments(runCtx *config.RunContext, usageFile *usage.UsageFile) []*schema.Commitment {
+	commitments := make([]*schema.Commitment, 0, len(runCtx.Config.Commitments)+len(usageFile.Commitments))
+	commitments = append(commitments, runCtx.Config.Commitments...)
+
+	return append(commitments, usageFile.Commitments...)
+}
+
+// loadProjectUsageFile loads the usage files of the project and merges them over
+// the usage files shipped with its modules, then applies its usage profile. It
+// returns a blank usage file and false if the project has no usage files.
+func loadProjectUsageFile(projectCfg *config.Project) (*usage.UsageFile, bool, error) {
+	usageFilePaths := projectCfg.UsageFilePaths()
+
+	usageFile, err := usage.LoadUsageFiles(usageFilePaths)
+	if err != nil {
+		return nil, false, err
+	}
+
+	// The usage shipped with the project's modules is overridden by the project's
+	// own usage files
+	moduleUsageFile, err := usage.LoadModuleUsageFiles(projectDir(projectCfg.Path))
+	if err != nil {
+		return nil, false, err
+	}
+	if moduleUsageFile != nil {
+		moduleUsageFile.Merge(usageFile)
+		usageFile = moduleUsageFile
+	}
+
+	if len(usageFilePaths) == 0 && moduleUsageFile == nil {
+		return usageFile, false, nil
+	}
+
+	if projectCfg.UsageProfile != "" {
+		err = usageFile.ApplyProfile(projectCfg.UsageProfile)
+		if err != nil {
+			return nil, false, errors.Wrapf(err, "Error loading usage files of project %s", projectCfg.Path)
+		}
+	}
+
+	return usageFile, true, nil
+}
+
+// projectDir returns the directory of the project at path, which can be a plan
+// JSON or plan file rather than a directory.
+func projectDir(path string) string {
+	if config.FileExists(path) {
+		return filepath.Dir(path)
+	}
+
+	return path
+}
+
+// projectVarFilePaths returns the paths of the var files of the project. Var
+// files are relative to the project, like they are when it's parsed.
+func projectVarFilePaths(p *config.Project) []string {
+	paths := make([]string, 0, len(p.TerraformVarFiles))
+	for _, f := range p.TerraformVarFiles {
+		if !filepath.IsAbs(f) {
+			f = filepath.Join(projectDir(p.Path), f)
+		}
+
+		paths = append(paths, f)
+	}
+
+	return paths
+}
+
+// generateUsageFile adds the resources of the project to its usage file and
+// estimates their usage, with opts controlling where it's estimated from.
+func generateUsageFile(cmd *cobra.Command, runCtx *config.RunContext, projectCtx *config.ProjectContext, projectCfg *config.Project, provider schema.Provider, opts usage.SyncOptions) error {
 	if projectCfg.UsageFile == "" {
 		// This should not happen as we check earlier in the code that usage-file is not empty when sync-usage-file flag is on.
 		return fmt.Errorf("Error generating usage: no usage file given")
@@ -535,7 +1223,7 @@ func generateUsageFile(cmd *cobra.Command, runCtx *config.RunContext, projectCtx
 	spinner := ui.NewSpinner("Syncing usage data from cloud", spinnerOpts)
 	defer spinner.Fail()
 
-	syncResult, err := usage.SyncUsageData(usageFile, providerProjects)
+	syncResult, err := usage.SyncUsageDataWithOptions(usageFile, providerProjects, opts)
 
 	if err != nil {
 		spinner.Fail()
@@ -577,9 +1265,19 @@ func generateUsageFile(cmd *cobra.Command, runCtx *config.RunContext, projectCtx
 	return nil
 }
 
+// getParallelism returns the number of projects run at the same time, from
+// --parallelism or INFRACOST_PARALLELISM. The output is in the order of the
+// projects whatever the parallelism, since the results are sorted by index.
+// Memory use isn't bounded separately, it grows with the number of projects run
+// at the same time, so a lower parallelism also lowers the peak memory use.
 func getParallelism(cmd *cobra.Command, runCtx *config.RunContext) (int, error) {
 	var parallelism int
 
+	if cmd.Flags().Changed("parallelism") {
+		p, _ := cmd.Flags().GetInt("parallelism")
+		runCtx.Config.Parallelism = &p
+	}
+
 	if runCtx.Config.Parallelism == nil {
 		parallelism = 4
 		numCPU := runtime.NumCPU()
@@ -592,7 +1290,7 @@ func getParallelism(cmd *cobra.Command, runCtx *config.RunContext) (int, error)
 	} else {
 		parallelism = *runCtx.Config.Parallelism
 
-		if parallelism < 0 {
+		if parallelism < 1 {
 			return parallelism, fmt.Errorf("parallelism must be a positive number")
 		}
 
@@ -648,6 +1346,13 @@ func loadRunFlags(cfg *config.Config, cmd *cobra.Command) error {
 		}
 	}
 
+	if hasPathFlag {
+		err := autodetectProjects(cmd, cfg)
+		if err != nil {
+			return err
+		}
+	}
+
 	if hasConfigFile {
 		cfgFilePath, _ := cmd.Flags().GetString("config-file")
 		err := cfg.LoadFromConfigFile(cfgFilePath)
@@ -663,7 +1368,33 @@ func loadRunFlags(cfg *config.Config, cmd *cobra.Command) error {
 		}
 	}
 
+	if cmd.Flags().Changed("usage-profile") {
+		usageProfile, _ := cmd.Flags().GetString("usage-profile")
+		for _, p := range cfg.Projects {
+			p.UsageProfile = usageProfile
+		}
+	}
+
+	if cmd.Flags().Changed("usage-scenario") {
+		cfg.UsageScenario, _ = cmd.Flags().GetString("usage-scenario")
+	}
+
+	if err := usage.ValidateScenario(cfg.UsageScenario); err != nil {
+		ui.PrintUsage(cmd)
+		return err
+	}
+
 	cfg.NoCache, _ = cmd.Flags().GetBool("no-cache")
+	cfg.WriteLockFile, _ = cmd.Flags().GetBool("write-lock-file")
+	cfg.Locked, _ = cmd.Flags().GetBool("locked")
+	cfg.CacheResults, _ = cmd.Flags().GetBool("cache-results")
+	cfg.WaitForApproval, _ = cmd.Flags().GetBool("wait-for-approval")
+	cfg.ShowRecommendations, _ = cmd.Flags().GetBool("show-recommendations")
+
+	if cfg.WaitForApproval && (cfg.ApprovalWebhookURL == "" || cfg.ApprovalCallbackURL == "") {
+		ui.PrintUsage(cmd)
+		return errors.New("--wait-for-approval needs INFRACOST_APPROVAL_WEBHOOK_URL and INFRACOST_APPROVAL_CALLBACK_URL to be set")
+	}
 
 	cfg.Format, _ = cmd.Flags().GetString("format")
 
@@ -672,9 +1403,57 @@ func loadRunFlags(cfg *config.Config, cmd *cobra.Command) error {
 		return fmt.Errorf("--format only supports %s", strings.Join(validRunFormats, ", "))
 	}
 
-	cfg.ShowSkipped, _ = cmd.Flags().GetBool("show-skipped")
+	if f := cmd.Flags().Lookup("show-skipped"); f != nil {
+		cfg.ShowSkipped = f.Value.String() != "false"
+		cfg.ShowSkippedJSON = f.Value.String() == "json"
+	}
 	cfg.SyncUsageFile, _ = cmd.Flags().GetBool("sync-usage-file")
 
+	if cmd.Flags().Changed("show-price-tiers") {
+		cfg.ShowPriceTiers, _ = cmd.Flags().GetBool("show-price-tiers")
+	}
+
+	if cmd.Flags().Changed("filter") {
+		cfg.Filters, _ = cmd.Flags().GetStringArray("filter")
+		if _, err := output.ParseFilters(cfg.Filters); err != nil {
+			ui.PrintUsage(cmd)
+			return err
+		}
+	}
+
+	if cmd.Flags().Changed("group-by") {
+		cfg.GroupBy, _ = cmd.Flags().GetString("group-by")
+		if _, err := output.ParseGroupBy(cfg.GroupBy); err != nil {
+			ui.PrintUsage(cmd)
+			return err
+		}
+	}
+
+	if cmd.Flags().Changed("granularity") {
+		cfg.Granularity, _ = cmd.Flags().GetString("granularity")
+		if !contains(output.Granularities, cfg.Granularity) {
+			ui.PrintUsage(cmd)
+			return fmt.Errorf("--granularity only supports %s", strings.Join(output.Granularities, ", "))
+		}
+	}
+
+	if cmd.Flags().Changed("projection-months") {
+		cfg.ProjectionMonths, _ = cmd.Flags().GetInt("projection-months")
+		if cfg.ProjectionMonths < 1 {
+			ui.PrintUsage(cmd)
+			return errors.New("--projection-months must be at least 1")
+		}
+	}
+
+	if cmd.Flags().Changed("monthly-growth-percent") {
+		if cfg.ProjectionMonths == 0 {
+			ui.PrintUsage(cmd)
+			return errors.New("--monthly-growth-percent can only be used with --projection-months")
+		}
+
+		cfg.ProjectionGrowthPercent, _ = cmd.Flags().GetFloat64("monthly-growth-percent")
+	}
+
 	includeAllFields := "all"
 	validFields := []string{"price", "monthlyQuantity", "unit", "hourlyCost", "monthlyCost"}
 	validFieldsFormats := []string{"table", "html"}
@@ -703,11 +1482,64 @@ func loadRunFlags(cfg *config.Config, cmd *cobra.Command) error {
 	return nil
 }
 
+// autodetectProjects replaces the project of --path with a project for each root
+// module under it when it's a repo with multiple Terraform projects, reporting
+// why each directory with Terraform files was included or skipped.
+func autodetectProjects(cmd *cobra.Command, cfg *config.Config) error {
+	projectCfg := cfg.Projects[0]
+	if !providers.ShouldAutodetect(projectCfg.Path) {
+		return nil
+	}
+
+	results, err := providers.AutodetectProjects(projectCfg.Path, cfg.AutodetectMaxDepth)
+	if err != nil {
+		return err
+	}
+
+	var projects []*config.Project
+	for _, r := range results {
+		var m string
+		if r.Included {
+			m = fmt.Sprintf("Autodetected project %s: %s", ui.DisplayPath(r.Path), r.Reason)
+
+			p := *projectCfg
+			p.Path = r.Path
+			projects = append(projects, &p)
+		} else {
+			m = fmt.Sprintf("Skipped %s: %s", ui.DisplayPath(r.Path), r.Reason)
+		}
+
+		if cfg.IsLogging() {
+			log.Info(m)
+		} else {
+			cmd.PrintErrln(m)
+		}
+	}
+
+	if len(projects) > 0 {
+		cfg.Projects = projects
+	}
+
+	return nil
+}
+
 func checkRunConfig(warningWriter io.Writer, cfg *config.Config) error {
-	if cfg.Format == "json" && cfg.ShowSkipped {
+	if cfg.Format == "json" && cfg.ShowSkipped && !cfg.ShowSkippedJSON {
 		ui.PrintWarning(warningWriter, "show-skipped is not needed with JSON output format as that always includes them.\n")
 	}
 
+	if cfg.ShowPriceTiers && cfg.Format != "json" && cfg.Format != "html" {
+		ui.PrintWarning(warningWriter, "show-price-tiers is only supported for json and html output formats.\n")
+	}
+
+	if cfg.Granularity != "" && cfg.Granularity != output.GranularityMonthly && cfg.Format != "table" {
+		ui.PrintWarning(warningWriter, "granularity is only supported for table output format, costs are monthly in other formats.\n")
+	}
+
+	if cfg.UsageScenario == usage.ScenarioAll && cfg.Format == "html" {
+		ui.PrintWarning(warningWriter, "usage-scenario all is only supported for table and json output formats.\n")
+	}
+
 	if cfg.SyncUsageFile {
 		missingUsageFile := make([]string, 0)
 		for _, project := range cfg.Projects {
@@ -724,14 +1556,69 @@ func checkRunConfig(warningWriter io.Writer, cfg *config.Config) error {
 		}
 	}
 
+	for _, project := range cfg.Projects {
+		if project.UsageProfile != "" && len(project.UsageFilePaths()) == 0 {
+			ui.PrintWarning(warningWriter, fmt.Sprintf("Ignoring usage profile %s for project %s as no usage-file is specified.\n", project.UsageProfile, project.Path))
+		}
+	}
+
 	if money.GetCurrency(cfg.Currency) == nil {
 		ui.PrintWarning(warningWriter, fmt.Sprintf("Ignoring unknown currency '%s', using USD.\n", cfg.Currency))
 		cfg.Currency = "USD"
 	}
 
+	for _, project := range cfg.Projects {
+		if project.Currency == "" {
+			continue
+		}
+
+		project.Currency = strings.ToUpper(project.Currency)
+
+		if money.GetCurrency(project.Currency) == nil {
+			return fmt.Errorf("Unknown currency '%s' for project %s", project.Currency, project.Path)
+		}
+
+		if _, ok := cfg.ConversionRate(project.Currency, cfg.Currency); !ok {
+			return fmt.Errorf("No exchange rate to convert the costs of project %s from %s to %s, add the rates to exchange_rates in the config file", project.Path, project.Currency, cfg.Currency)
+		}
+	}
+
 	return nil
 }
 
+// projectCurrency returns the currency of the project, which is the currency set
+// in its config or, when the user hasn't set a currency, the currency its
+// provider bills in.
+func projectCurrency(cfg *config.Config, projectCfg *config.Project, project *schema.Project) string {
+	if projectCfg.Currency != "" || !cfg.CurrencyIsDefault() {
+		return projectCfg.Currency
+	}
+
+	if hcloud.IsProject(project) {
+		return hcloud.Currency
+	}
+
+	return ""
+}
+
+// projectCurrencyRates returns the rates for converting the costs of the projects
+// with their own currency to the currency of the run, keyed by project currency.
+func projectCurrencyRates(cfg *config.Config, projects []*schema.Project) map[string]decimal.Decimal {
+	rates := make(map[string]decimal.Decimal)
+
+	for _, project := range projects {
+		if project.Currency == "" {
+			continue
+		}
+
+		if rate, ok := cfg.ConversionRate(project.Currency, cfg.Currency); ok {
+			rates[strings.ToUpper(project.Currency)] = rate
+		}
+	}
+
+	return rates
+}
+
 func buildRunEnv(runCtx *config.RunContext, projectContexts []*config.ProjectContext, r output.Root, projects []*schema.Project, hclR *output.Root, hclProjects []*schema.Project) map[string]interface{} {
 	env := runCtx.EventEnvWithProjectContexts(projectContexts)
 	env["projectCount"] = len(projectContexts)
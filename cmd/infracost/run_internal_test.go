@@ -0,0 +1,52 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/infracost/infracost/internal/config"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+func TestProjectCurrency(t *testing.T) {
+	hcloudProject := &schema.Project{
+		Resources: []*schema.Resource{
+			{Name: "hcloud_server.web", ResourceType: "hcloud_server"},
+			{Name: "hcloud_ssh_key.default", ResourceType: "hcloud_ssh_key", NoPrice: true},
+			{Name: "random_password.db", ResourceType: "random_password", IsSkipped: true},
+		},
+	}
+	mixedProject := &schema.Project{
+		Resources: []*schema.Resource{
+			{Name: "hcloud_server.web", ResourceType: "hcloud_server"},
+			{Name: "cloudflare_r2_bucket.assets", ResourceType: "cloudflare_r2_bucket"},
+		},
+	}
+
+	t.Setenv("INFRACOST_CURRENCY", "")
+	runCtx, err := config.NewRunContextFromEnv(context.Background())
+	require.NoError(t, err)
+	defaultCfg := runCtx.Config
+
+	tests := []struct {
+		name       string
+		cfg        *config.Config
+		projectCfg *config.Project
+		project    *schema.Project
+		expected   string
+	}{
+		{name: "hcloud project", cfg: defaultCfg, projectCfg: &config.Project{}, project: hcloudProject, expected: "EUR"},
+		{name: "mixed project", cfg: defaultCfg, projectCfg: &config.Project{}, project: mixedProject, expected: ""},
+		{name: "project currency", cfg: defaultCfg, projectCfg: &config.Project{Currency: "GBP"}, project: hcloudProject, expected: "GBP"},
+		{name: "run currency", cfg: &config.Config{Currency: "USD"}, projectCfg: &config.Project{}, project: hcloudProject, expected: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, projectCurrency(tt.cfg, tt.projectCfg, tt.project))
+		})
+	}
+}
@@ -48,6 +48,20 @@ func TestFlagErrorsTerraformWorkspaceFlagAndEnv(t *testing.T) {
 	GoldenFileCommandTest(t, testutil.CalcGoldenFileTestdataDirName(), []string{"breakdown", "--path", "../../examples/terraform", "--terraform-workspace", "prod"}, nil)
 }
 
+func TestParallelismZeroErrors(t *testing.T) {
+	GoldenFileCommandTest(t, testutil.CalcGoldenFileTestdataDirName(), []string{"breakdown", "--path", "./testdata/example_plan.json", "--parallelism", "0"}, nil)
+}
+
+func TestParallelismEnvZeroErrors(t *testing.T) {
+	t.Setenv("INFRACOST_PARALLELISM", "0")
+	GoldenFileCommandTest(t, testutil.CalcGoldenFileTestdataDirName(), []string{"breakdown", "--path", "./testdata/example_plan.json"}, nil)
+}
+
+func TestParallelismFlagOverridesEnv(t *testing.T) {
+	t.Setenv("INFRACOST_PARALLELISM", "0")
+	GoldenFileCommandTest(t, testutil.CalcGoldenFileTestdataDirName(), []string{"breakdown", "--path", "./testdata/example_plan.json", "--usage-file", "./testdata/example_usage.yml", "--parallelism", "1"}, nil)
+}
+
 func TestCatchesRuntimeError(t *testing.T) {
 	GoldenFileCommandTest(t, testutil.CalcGoldenFileTestdataDirName(), []string{"breakdown", "--path", "../../examples/terraform", "--terraform-workspace", "prod"}, &GoldenFileOptions{CaptureLogs: true}, func(c *config.RunContext) {
 		// this should blow up the application
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/infracost/infracost/internal/config"
+	"github.com/infracost/infracost/internal/hcl/modules"
+	"github.com/infracost/infracost/internal/ui"
+	"github.com/infracost/infracost/internal/usage"
+	"github.com/infracost/infracost/internal/worktree"
+)
+
+// selectChangedProjects keeps the projects affected by the files changed since
+// the base of the pull request, so only they're estimated. A project is
+// affected by a change to its own files, its usage files, its Terraform var
+// files or the files of the local modules it calls, so edits to a shared module
+// select every project that uses it.
+func selectChangedProjects(cmd *cobra.Command, runCtx *config.RunContext) error {
+	if len(runCtx.Config.Projects) == 0 {
+		return nil
+	}
+
+	path := runCtx.Config.Projects[0].Path
+
+	ref, changed, err := changedFilesSinceBase(runCtx, path)
+	if err != nil {
+		return err
+	}
+
+	var projects []*config.Project
+	for _, p := range runCtx.Config.Projects {
+		affected, err := projectAffectedBy(p, changed)
+		if err != nil {
+			return err
+		}
+
+		if affected {
+			projects = append(projects, p)
+		}
+	}
+
+	m := fmt.Sprintf("Selected %d of %d projects affected by the files changed since %s", len(projects), len(runCtx.Config.Projects), ref)
+	if runCtx.Config.IsLogging() {
+		log.Info(m)
+	} else {
+		cmd.PrintErrln(m)
+	}
+
+	runCtx.Config.Projects = projects
+
+	return nil
+}
+
+// changedFilesSinceBase returns the files changed since the base of the pull
+// request, which is the --compare-to-ref ref if it's set and the base branch
+// detected from the CI environment otherwise.
+func changedFilesSinceBase(runCtx *config.RunContext, path string) (string, []string, error) {
+	if runCtx.Config.CompareToRef != "" {
+		changed, err := worktree.ChangedFiles(path, runCtx.Config.CompareToRef)
+		return runCtx.Config.CompareToRef, changed, err
+	}
+
+	branch := config.DetectVCSMetadata(path).BaseBranch
+	if branch == "" {
+		return "", nil, errors.New("--select-changed-projects needs the base branch of the pull request, set it with --compare-to-ref or INFRACOST_VCS_BASE_BRANCH")
+	}
+
+	// CI checkouts usually only have the base branch as a remote branch.
+	changed, err := worktree.ChangedFiles(path, "origin/"+branch)
+	if err == nil {
+		return "origin/" + branch, changed, nil
+	}
+
+	changed, err = worktree.ChangedFiles(path, branch)
+	return branch, changed, err
+}
+
+// projectAffectedBy returns true if any of the files is in the project, in a
+// local module it calls, or is one of its local usage files or Terraform var
+// files, which can be outside the project, e.g. ../env/prod.tfvars.
+func projectAffectedBy(p *config.Project, files []string) (bool, error) {
+	dirs, err := modules.LocalModuleDirs(p.Path)
+	if err != nil {
+		return false, fmt.Errorf("Error finding the modules of %s: %w", ui.DisplayPath(p.Path), err)
+	}
+
+	for _, f := range p.UsageFilePaths() {
+		if !usage.IsRemoteUsageFile(f) {
+			dirs = append(dirs, f)
+		}
+	}
+
+	dirs = append(dirs, projectVarFilePaths(p)...)
+
+	for _, dir := range dirs {
+		if projectContainsAny(dir, files) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
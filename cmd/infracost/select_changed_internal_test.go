@@ -0,0 +1,125 @@
+package main
+
+import (
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/infracost/infracost/internal/config"
+)
+
+// writeSelectChangedFixture writes a repo with a prod and a dev project that
+// call a shared module, where prod also has a var file outside of the project
+// and usage files.
+func writeSelectChangedFixture(t *testing.T) string {
+	t.Helper()
+
+	dir, err := filepath.EvalSymlinks(t.TempDir())
+	require.NoError(t, err)
+
+	files := map[string]string{
+		"infra/prod/main.tf":             "module \"app\" {\n  source = \"../../modules/app\"\n}\n",
+		"infra/prod/infracost-usage.yml": "version: 0.1\n",
+		"infra/dev/main.tf":              "module \"app\" {\n  source = \"../../modules/app\"\n}\n",
+		"modules/app/main.tf":            "variable \"size\" {}\n",
+		"env/prod.tfvars":                "size = 2\n",
+		"usage/defaults.yml":             "version: 0.1\n",
+		"docs/README.md":                 "# Infra\n",
+	}
+	for name, contents := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte(contents), 0600))
+	}
+
+	return dir
+}
+
+func selectChangedProjectConfigs(dir string) []*config.Project {
+	return []*config.Project{
+		{
+			Path:              filepath.Join(dir, "infra", "prod"),
+			UsageFile:         filepath.Join(dir, "infra", "prod", "infracost-usage.yml"),
+			UsageFiles:        []string{filepath.Join(dir, "usage", "defaults.yml"), "https://example.com/usage.yml"},
+			TerraformVarFiles: []string{filepath.Join("..", "..", "env", "prod.tfvars")},
+		},
+		{
+			Path: filepath.Join(dir, "infra", "dev"),
+		},
+	}
+}
+
+func TestProjectAffectedBy(t *testing.T) {
+	dir := writeSelectChangedFixture(t)
+	projects := selectChangedProjectConfigs(dir)
+
+	tests := []struct {
+		name    string
+		changed string
+		prod    bool
+		dev     bool
+	}{
+		{name: "project file", changed: "infra/prod/main.tf", prod: true},
+		{name: "shared module", changed: "modules/app/main.tf", prod: true, dev: true},
+		{name: "var file outside the project", changed: "env/prod.tfvars", prod: true},
+		{name: "usage file", changed: "infra/prod/infracost-usage.yml", prod: true},
+		{name: "usage files", changed: "usage/defaults.yml", prod: true},
+		{name: "unrelated file", changed: "docs/README.md"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			files := []string{filepath.Join(dir, filepath.FromSlash(tt.changed))}
+
+			affected, err := projectAffectedBy(projects[0], files)
+			require.NoError(t, err)
+			assert.Equal(t, tt.prod, affected, "prod")
+
+			affected, err = projectAffectedBy(projects[1], files)
+			require.NoError(t, err)
+			assert.Equal(t, tt.dev, affected, "dev")
+		})
+	}
+}
+
+func TestSelectChangedProjects(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+
+	dir := writeSelectChangedFixture(t)
+
+	git := func(args ...string) {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		cmd.Env = append(os.Environ(),
+			"GIT_AUTHOR_NAME=test", "GIT_AUTHOR_EMAIL=test@example.com",
+			"GIT_COMMITTER_NAME=test", "GIT_COMMITTER_EMAIL=test@example.com",
+		)
+		out, err := cmd.CombinedOutput()
+		require.NoError(t, err, string(out))
+	}
+
+	git("init", "-q")
+	git("add", "-A")
+	git("commit", "-q", "-m", "init")
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "env", "prod.tfvars"), []byte("size = 4\n"), 0600))
+
+	runCtx := config.EmptyRunContext()
+	runCtx.Config.CompareToRef = "HEAD"
+	runCtx.Config.Projects = selectChangedProjectConfigs(dir)
+
+	cmd := &cobra.Command{}
+	cmd.SetErr(io.Discard)
+
+	require.NoError(t, selectChangedProjects(cmd, runCtx))
+	require.Len(t, runCtx.Config.Projects, 1)
+	assert.Equal(t, filepath.Join(dir, "infra", "prod"), runCtx.Config.Projects[0].Path)
+}
@@ -0,0 +1,237 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/exec"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/infracost/infracost/internal/config"
+	"github.com/infracost/infracost/internal/server"
+)
+
+func serveCmd(ctx *config.RunContext) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "serve",
+		Short: "Run Infracost as an HTTP API server",
+		Long: `Run Infracost as an HTTP API server
+
+Platform teams can run the server as an internal service that pipelines send
+their Terraform plan JSON or a tarball of their Terraform code to, rather than
+installing and running the CLI in every pipeline. The request body is the plan
+JSON or the tarball, optionally gzipped, and the response is the output:
+
+  POST /v1/breakdown           Run a breakdown, format: json, table, html
+  POST /v1/diff                Run a diff, format: json, diff
+  GET  /v1/jobs/{id}           Get the status of a job
+  GET  /v1/jobs/{id}/output    Get the output of a finished job
+  GET  /health                 Check the server is up
+
+The query parameters of the POST endpoints are:
+
+  format                 Output format, defaults to json.
+  path                   Path of the Terraform directory or plan JSON in the tarball.
+  usage_file             Path of the usage file in the tarball.
+  terraform_workspace    Terraform workspace to use.
+  terraform_var_file     Variable file relative to path, can be repeated.
+  terraform_parse_hcl    Parse the HCL directly instead of generating a plan.
+  async                  Respond with the job straight away instead of its output.
+
+With --grpc-port, the same jobs can be run with the gRPC CostEstimation service
+defined in proto/infracost/v1/cost_estimation.proto, which also streams the
+progress of a job.
+
+Jobs are queued and run by a pool of workers. The output of a job is cached for
+--cache-ttl and reused for jobs with the same upload and parameters. If
+INFRACOST_SERVE_API_KEY is set, requests must send it in the X-Api-Key header,
+or the x-api-key metadata of gRPC calls.`,
+		Example: `  Run the server on port 8080:
+
+      infracost serve --port 8080
+
+  Run the server with the gRPC API on port 9090:
+
+      infracost serve --port 8080 --grpc-port 9090
+
+  Get a breakdown of a plan JSON:
+
+      curl -X POST --data-binary @plan.json http://localhost:8080/v1/breakdown
+
+  Get a diff of a Terraform directory:
+
+      tar -czf code.tar.gz -C /path/to/code .
+      curl -X POST --data-binary @code.tar.gz "http://localhost:8080/v1/diff?format=diff&terraform_parse_hcl=true"`,
+		ValidArgs: []string{"--", "-"},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if !ctx.Config.PricingOffline && !ctx.Config.UsesAWSPriceList() {
+				if err := checkAPIKey(ctx.Config.APIKey, ctx.Config.PricingAPIEndpoint, ctx.Config.DefaultPricingAPIEndpoint); err != nil {
+					return err
+				}
+			}
+
+			port, _ := cmd.Flags().GetInt("port")
+			grpcPort, _ := cmd.Flags().GetInt("grpc-port")
+			parallelism, _ := cmd.Flags().GetInt("parallelism")
+			queueSize, _ := cmd.Flags().GetInt("queue-size")
+			cacheTTL, _ := cmd.Flags().GetDuration("cache-ttl")
+			maxUploadSize, _ := cmd.Flags().GetInt64("max-upload-size")
+
+			if parallelism <= 0 {
+				return errors.New("--parallelism must be greater than 0")
+			}
+
+			if queueSize <= 0 {
+				return errors.New("--queue-size must be greater than 0")
+			}
+
+			runner, err := subprocessRunner()
+			if err != nil {
+				return err
+			}
+
+			return runServe(cmd, port, grpcPort, server.Options{
+				Runner:         runner,
+				Parallelism:    parallelism,
+				QueueSize:      queueSize,
+				CacheTTL:       cacheTTL,
+				MaxUploadBytes: maxUploadSize,
+				APIKey:         os.Getenv("INFRACOST_SERVE_API_KEY"),
+			})
+		},
+	}
+
+	cmd.Flags().Int("port", 8080, "Port to listen on")
+	cmd.Flags().Int("grpc-port", 0, "Port to serve the gRPC API on. The gRPC API is disabled if it's not set")
+	cmd.Flags().Int("parallelism", server.DefaultParallelism, "Number of jobs to run at the same time")
+	cmd.Flags().Int("queue-size", server.DefaultQueueSize, "Number of jobs that can wait to be run before new ones are rejected")
+	cmd.Flags().Duration("cache-ttl", server.DefaultCacheTTL, "How long the output of a job is reused for the same upload. Set to 0 to disable caching")
+	cmd.Flags().Int64("max-upload-size", server.DefaultMaxUploadBytes, "Maximum size in bytes of an uploaded plan JSON or tarball")
+
+	return cmd
+}
+
+// runServe serves the API until it's interrupted, then waits for the running
+// jobs to finish.
+func runServe(cmd *cobra.Command, port int, grpcPort int, opts server.Options) error {
+	s := server.New(opts)
+
+	httpServer := &http.Server{
+		Addr:              fmt.Sprintf(":%d", port),
+		Handler:           s.Handler(),
+		ReadHeaderTimeout: 30 * time.Second,
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	errChan := make(chan error, 2)
+	go func() {
+		cmd.PrintErrf("Listening on %s\n", httpServer.Addr)
+		errChan <- httpServer.ListenAndServe()
+	}()
+
+	grpcServer := s.GRPCServer()
+	if grpcPort > 0 {
+		lis, err := net.Listen("tcp", fmt.Sprintf(":%d", grpcPort))
+		if err != nil {
+			s.Close()
+			return errors.Wrap(err, "Error listening for gRPC")
+		}
+
+		go func() {
+			cmd.PrintErrf("Serving gRPC on %s\n", lis.Addr())
+			errChan <- grpcServer.Serve(lis)
+		}()
+	}
+
+	select {
+	case err := <-errChan:
+		s.Close()
+		return errors.Wrap(err, "Error running server")
+	case <-ctx.Done():
+	}
+
+	cmd.PrintErrln("Shutting down, waiting for running jobs to finish")
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	err := httpServer.Shutdown(shutdownCtx)
+	grpcServer.GracefulStop()
+	s.Close()
+
+	return err
+}
+
+// subprocessRunner returns a runner that runs each job as a separate infracost
+// process, since a run changes the process's environment and working directory
+// for the projects it runs, so jobs can't share a process.
+func subprocessRunner() (server.Runner, error) {
+	executable, err := os.Executable()
+	if err != nil {
+		return nil, errors.Wrap(err, "Error finding the infracost executable")
+	}
+
+	return func(ctx context.Context, req server.RunRequest) ([]byte, error) {
+		args := serveJobArgs(req)
+
+		log.Debugf("Running infracost %s", strings.Join(args, " "))
+
+		c := exec.CommandContext(ctx, executable, args...)
+		c.Dir = req.Dir
+
+		var stdout, stderr bytes.Buffer
+		c.Stdout = &stdout
+		c.Stderr = &stderr
+
+		err := c.Run()
+		if err != nil {
+			return nil, fmt.Errorf("%s: %s", err, strings.TrimSpace(stderr.String()))
+		}
+
+		return stdout.Bytes(), nil
+	}, nil
+}
+
+// serveJobArgs returns the arguments of the infracost command that runs the job.
+// A diff in JSON is a breakdown in JSON since it has the past and diff costs.
+func serveJobArgs(req server.RunRequest) []string {
+	var args []string
+
+	if req.Command == server.CommandDiff && req.Format == "diff" {
+		args = []string{"diff"}
+	} else {
+		args = []string{"breakdown", "--format", req.Format}
+	}
+
+	args = append(args, "--path", req.Path, "--no-color")
+
+	if req.UsageFile != "" {
+		args = append(args, "--usage-file", req.UsageFile)
+	}
+
+	if req.TerraformWorkspace != "" {
+		args = append(args, "--terraform-workspace", req.TerraformWorkspace)
+	}
+
+	for _, f := range req.TerraformVarFiles {
+		args = append(args, "--terraform-var-file", f)
+	}
+
+	if req.TerraformParseHCL {
+		args = append(args, "--terraform-parse-hcl")
+	}
+
+	return args
+}
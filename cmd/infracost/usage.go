@@ -0,0 +1,910 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+	"github.com/spf13/cobra"
+
+	"github.com/infracost/infracost/internal/clierror"
+	"github.com/infracost/infracost/internal/config"
+	"github.com/infracost/infracost/internal/output"
+	"github.com/infracost/infracost/internal/providers"
+	"github.com/infracost/infracost/internal/schema"
+	"github.com/infracost/infracost/internal/ui"
+	"github.com/infracost/infracost/internal/usage"
+	awsusage "github.com/infracost/infracost/internal/usage/aws"
+	k8susage "github.com/infracost/infracost/internal/usage/kubernetes"
+)
+
+const defaultUsageFile = "infracost-usage.yml"
+
+func usageCmd(ctx *config.RunContext) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "usage",
+		Short: "Manage the usage file used to estimate usage-based costs",
+		Long:  "Manage the usage file used to estimate usage-based costs",
+		Example: `  Sync the usage file of a Terraform directory with the usage reported by CloudWatch:
+
+      infracost usage sync --provider aws --path /path/to/code --usage-file infracost-usage.yml
+
+  Fill in the usage file of a Terraform directory interactively:
+
+      infracost usage wizard --path /path/to/code --usage-file infracost-usage.yml`,
+		ValidArgs: []string{"--", "-"},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cmd.Help()
+		},
+	}
+
+	cmd.AddCommand(usageSyncCmd(ctx))
+	cmd.AddCommand(usageRecordCmd(ctx))
+	cmd.AddCommand(usageWizardCmd(ctx))
+	cmd.AddCommand(usageValidateCmd(ctx))
+	cmd.AddCommand(usageMigrateCmd(ctx))
+
+	return cmd
+}
+
+func usageSyncCmd(ctx *config.RunContext) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "sync",
+		Short: "Sync the usage file with the usage reported by a cloud provider",
+		Long: `Sync the usage file with the usage reported by a cloud provider
+
+The resources of the project are added to the usage file and the usage of the
+ones that already exist is updated from the cloud provider's monitoring APIs:
+
+  aws: S3 bucket sizes, Lambda invocations, NAT gateway data processed and
+       DynamoDB read and write request units from CloudWatch, using the AWS
+       credentials of the environment.
+  azure: storage account capacity, function executions and VPN gateway
+       bandwidth from Azure Monitor, using INFRACOST_AZURE_ACCESS_TOKEN.
+  gcp: Cloud Storage bucket sizes and Cloud Functions executions and egress
+       from Cloud Monitoring, and the bytes billed for the queries of each
+       BigQuery dataset from its INFORMATION_SCHEMA jobs, using
+       INFRACOST_GCP_ACCESS_TOKEN.
+
+With --cur-file, the usage of aws resources is set from the actual usage in an
+AWS Cost and Usage Report (CUR) instead of CloudWatch. The report is a CSV file,
+optionally gzipped, that's local or in S3. Its line items are mapped to
+resources by a cost allocation tag whose value is the Terraform address, e.g.
+terraform_address = "aws_lambda_function.api", and their usage is scaled to a
+month from the period the report covers.
+
+With --prometheus-url or --metrics-server-url, the CPU and memory of a GKE
+Autopilot cluster set by --k8s-cluster-address are set from the utilization of
+its pods, rather than estimated from their requests. Prometheus gives the
+average utilization over the last 7 days from the cAdvisor metrics, while
+metrics-server only gives the current utilization, e.g. through kubectl proxy.
+INFRACOST_KUBERNETES_TOKEN is sent as a bearer token if it's set.
+
+Synced values are marked with their source in the usage file comments.`,
+		Example: `  Sync the usage file of a Terraform directory with the usage reported by CloudWatch:
+
+      infracost usage sync --provider aws --path /path/to/code
+
+  Sync the usage files of all the projects in a config file:
+
+      infracost usage sync --provider aws --config-file infracost.yml
+
+  Sync the usage file of a Terraform directory with the usage reported by Azure Monitor:
+
+      INFRACOST_AZURE_ACCESS_TOKEN=$(az account get-access-token --query accessToken -o tsv) \
+        infracost usage sync --provider azure --path /path/to/code
+
+  Sync the usage file of a Terraform directory with the usage of a linked account in a Cost and Usage Report:
+
+      infracost usage sync --provider aws --path /path/to/code \
+        --cur-file s3://my-billing-bucket/cur/2023-01.csv.gz --cur-account-id 123456789012
+
+  Sync the usage file of a Terraform directory with the utilization of the pods of a GKE Autopilot cluster:
+
+      infracost usage sync --provider gcp --path /path/to/code \
+        --prometheus-url http://prometheus:9090 --k8s-cluster-address google_container_cluster.autopilot`,
+		ValidArgs: []string{"--", "-"},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			err := validateUsageSyncFlags(cmd)
+			if err != nil {
+				return err
+			}
+
+			err = loadRunFlags(ctx.Config, cmd)
+			if err != nil {
+				return err
+			}
+
+			err = checkProjectUsageFiles(ctx.Config)
+			if err != nil {
+				return err
+			}
+
+			opts, err := usageSyncOptions(cmd)
+			if err != nil {
+				return err
+			}
+
+			return runUsageSync(cmd, ctx, opts)
+		},
+	}
+
+	addUsageSyncFlags(cmd)
+	addUsageProjectFlags(cmd, "Path to the Infracost usage file to sync")
+
+	_ = cmd.MarkFlagRequired("provider")
+
+	return cmd
+}
+
+// addUsageSyncFlags adds the flags that set where the usage is synced from.
+func addUsageSyncFlags(cmd *cobra.Command) {
+	cmd.Flags().String("provider", "", fmt.Sprintf("Cloud provider to get the usage from: %s", strings.Join(usage.SyncProviders(), ", ")))
+	cmd.Flags().String("cur-file", "", "Path or S3 URL of an AWS Cost and Usage Report CSV file to get the usage from instead of CloudWatch")
+	cmd.Flags().String("cur-address-tag", awsusage.DefaultCURAddressTag, "Cost allocation tag whose value is the Terraform address of the resource")
+	cmd.Flags().String("cur-account-id", "", "Only use the usage of this linked account from the Cost and Usage Report")
+	cmd.Flags().String("prometheus-url", "", "URL of a Prometheus server to get the CPU and memory utilization of Kubernetes pods from")
+	cmd.Flags().String("metrics-server-url", "", "URL of the Kubernetes API, e.g. from kubectl proxy, to get the current CPU and memory utilization of pods from metrics-server")
+	cmd.Flags().String("k8s-cluster-address", "", "Terraform address of the GKE Autopilot cluster the pods run on, e.g. google_container_cluster.autopilot")
+	cmd.Flags().String("k8s-namespace", "", "Only use the utilization of the pods in this Kubernetes namespace")
+
+	_ = cmd.MarkFlagFilename("cur-file", "csv", "gz")
+}
+
+// validateUsageSyncFlags returns an error if the flags that set where the usage
+// is synced from can't be used together.
+func validateUsageSyncFlags(cmd *cobra.Command) error {
+	cloudProvider, _ := cmd.Flags().GetString("provider")
+	if !contains(usage.SyncProviders(), cloudProvider) {
+		ui.PrintUsage(cmd)
+		return fmt.Errorf("--provider only supports %s", strings.Join(usage.SyncProviders(), ", "))
+	}
+
+	curFile, _ := cmd.Flags().GetString("cur-file")
+	if curFile != "" && cloudProvider != "aws" {
+		ui.PrintUsage(cmd)
+		return errors.New("--cur-file is only supported with --provider aws")
+	}
+
+	prometheusURL, _ := cmd.Flags().GetString("prometheus-url")
+	metricsServerURL, _ := cmd.Flags().GetString("metrics-server-url")
+	clusterAddress, _ := cmd.Flags().GetString("k8s-cluster-address")
+	if prometheusURL != "" && metricsServerURL != "" {
+		ui.PrintUsage(cmd)
+		return errors.New("--prometheus-url and --metrics-server-url cannot be used together")
+	}
+
+	if (prometheusURL != "" || metricsServerURL != "") && clusterAddress == "" {
+		ui.PrintUsage(cmd)
+		return errors.New("--k8s-cluster-address is required with --prometheus-url or --metrics-server-url")
+	}
+
+	if curFile != "" && (prometheusURL != "" || metricsServerURL != "") {
+		ui.PrintUsage(cmd)
+		return errors.New("--cur-file cannot be used with --prometheus-url or --metrics-server-url")
+	}
+
+	return nil
+}
+
+// checkProjectUsageFiles returns an error if a project has no usage file to sync.
+func checkProjectUsageFiles(cfg *config.Config) error {
+	for _, projectCfg := range cfg.Projects {
+		if projectCfg.UsageFile == "" {
+			return fmt.Errorf("Project %s has no usage file, set usage_file in the config file", projectCfg.Path)
+		}
+	}
+
+	return nil
+}
+
+// usageSyncOptions returns the options to sync the usage file from the source set
+// by the flags. Sources that aren't queried per resource, e.g. a Cost and Usage
+// Report, are read now, so they should be called again to get the latest usage.
+func usageSyncOptions(cmd *cobra.Command) (usage.SyncOptions, error) {
+	var err error
+
+	cloudProvider, _ := cmd.Flags().GetString("provider")
+	curFile, _ := cmd.Flags().GetString("cur-file")
+	prometheusURL, _ := cmd.Flags().GetString("prometheus-url")
+	metricsServerURL, _ := cmd.Flags().GetString("metrics-server-url")
+	clusterAddress, _ := cmd.Flags().GetString("k8s-cluster-address")
+
+	opts := usage.SyncOptions{Provider: cloudProvider}
+	if curFile != "" {
+		addressTag, _ := cmd.Flags().GetString("cur-address-tag")
+		accountID, _ := cmd.Flags().GetString("cur-account-id")
+
+		curUsage, err := awsusage.CURGetUsage(context.Background(), curFile, awsusage.CUROptions{
+			AddressTag: addressTag,
+			AccountID:  accountID,
+		})
+		if err != nil {
+			return opts, err
+		}
+
+		// Only the usage in the report is synced, rather than estimating the
+		// usage of the resources it doesn't have from CloudWatch
+		opts.SkipEstimates = true
+		opts.UsageData = schema.NewUsageMap(curUsage)
+		opts.UsageDataSource = "AWS Cost and Usage Report"
+	}
+
+	if prometheusURL != "" || metricsServerURL != "" {
+		namespace, _ := cmd.Flags().GetString("k8s-namespace")
+		metricsOpts := k8susage.MetricsOptions{
+			ClusterAddress: clusterAddress,
+			Namespace:      namespace,
+		}
+
+		var metricsUsage map[string]interface{}
+		if prometheusURL != "" {
+			metricsUsage, err = k8susage.PrometheusGetUsage(context.Background(), prometheusURL, metricsOpts)
+			opts.UsageDataSource = "Prometheus"
+		} else {
+			metricsUsage, err = k8susage.MetricsServerGetUsage(context.Background(), metricsServerURL, metricsOpts)
+			opts.UsageDataSource = "metrics-server"
+		}
+		if err != nil {
+			return opts, err
+		}
+
+		// Only the cluster's usage is synced from the pods' utilization
+		opts.SkipEstimates = true
+		opts.UsageData = schema.NewUsageMap(metricsUsage)
+	}
+
+	return opts, nil
+}
+
+func usageRecordCmd(ctx *config.RunContext) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "record",
+		Short: "Keep the usage file in sync with the usage reported by a cloud provider",
+		Long: `Keep the usage file in sync with the usage reported by a cloud provider
+
+Runs usage sync every interval until it's stopped, e.g. as a sidecar or a
+long-running job, so the usage file follows the actual usage of the resources
+without being updated by hand. It takes the same flags as usage sync to choose
+where the usage is read from.
+
+After each sync the usage file can be committed to the git repository it's in,
+and pushed, with --git-commit and --git-push, or uploaded with --upload-url to
+an s3://, gs:// or HTTP URL that CI loads it from as a remote usage file. HTTP
+URLs are uploaded with a PUT request.
+
+A failed sync is reported and retried at the next interval.`,
+		Example: `  Sync the usage file with CloudWatch every hour and commit it:
+
+      infracost usage record --provider aws --path /path/to/code --interval 1h --git-commit --git-push
+
+  Sync the usage file with CloudWatch every 6 hours and upload it to S3:
+
+      infracost usage record --provider aws --path /path/to/code --interval 6h \
+        --upload-url s3://my-bucket/usage/infracost-usage.yml`,
+		ValidArgs: []string{"--", "-"},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			err := validateUsageSyncFlags(cmd)
+			if err != nil {
+				return err
+			}
+
+			interval, _ := cmd.Flags().GetDuration("interval")
+			if interval <= 0 {
+				ui.PrintUsage(cmd)
+				return errors.New("--interval must be greater than 0")
+			}
+
+			gitCommit, _ := cmd.Flags().GetBool("git-commit")
+			gitPush, _ := cmd.Flags().GetBool("git-push")
+			if gitPush && !gitCommit {
+				ui.PrintUsage(cmd)
+				return errors.New("--git-push can only be used with --git-commit")
+			}
+
+			err = loadRunFlags(ctx.Config, cmd)
+			if err != nil {
+				return err
+			}
+
+			err = checkProjectUsageFiles(ctx.Config)
+			if err != nil {
+				return err
+			}
+
+			uploadURL, _ := cmd.Flags().GetString("upload-url")
+			if uploadURL != "" {
+				if !usage.IsRemoteUsageFile(uploadURL) {
+					ui.PrintUsage(cmd)
+					return errors.New("--upload-url must be an s3://, gs://, http:// or https:// URL")
+				}
+
+				if len(ctx.Config.Projects) > 1 {
+					return errors.New("--upload-url can only be used with a single project")
+				}
+			}
+
+			return runUsageRecord(cmd, ctx, usageRecordOptions{
+				interval:  interval,
+				gitCommit: gitCommit,
+				gitPush:   gitPush,
+				uploadURL: uploadURL,
+			})
+		},
+	}
+
+	addUsageSyncFlags(cmd)
+	addUsageProjectFlags(cmd, "Path to the Infracost usage file to keep in sync")
+
+	cmd.Flags().Duration("interval", time.Hour, "How often to sync the usage file, e.g. 30m, 1h or 24h")
+	cmd.Flags().Bool("git-commit", false, "Commit the usage file to its git repository after each sync if it changed")
+	cmd.Flags().Bool("git-push", false, "Push the commit of the usage file after each sync. Requires --git-commit")
+	cmd.Flags().String("upload-url", "", "s3://, gs:// or HTTP URL to upload the usage file to after each sync")
+
+	_ = cmd.MarkFlagRequired("provider")
+
+	return cmd
+}
+
+func usageWizardCmd(ctx *config.RunContext) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "wizard",
+		Short: "Fill in the usage file interactively",
+		Long: `Fill in the usage file interactively
+
+Walks through each resource of the project whose cost depends on usage, showing
+its monthly cost with the current usage and the price of its usage-based cost
+components, and asks for its usage values. The current value of each one is
+shown in brackets; leave the answer blank to keep it.
+
+The answers are saved to the usage file, followed by the monthly cost of the
+project before and after them.`,
+		Example: `  Fill in the usage file of a Terraform directory:
+
+      infracost usage wizard --path /path/to/code --usage-file infracost-usage.yml`,
+		ValidArgs: []string{"--", "-"},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			err := loadRunFlags(ctx.Config, cmd)
+			if err != nil {
+				return err
+			}
+
+			err = checkProjectUsageFiles(ctx.Config)
+			if err != nil {
+				return err
+			}
+
+			return runUsageWizard(cmd, ctx)
+		},
+	}
+
+	addUsageProjectFlags(cmd, "Path to the Infracost usage file to fill in")
+
+	return cmd
+}
+
+var validUsageValidateFormats = []string{"table", "json"}
+
+func usageValidateCmd(ctx *config.RunContext) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "validate",
+		Short: "Check the usage file for mistakes",
+		Long: `Check the usage file for mistakes
+
+Checks the usage file, including its profiles, against the resources of the
+project and the usage schema of their resource types. The usage files of a
+project are loaded as they are for an estimate: usage_files and the usage files
+of its modules are merged in, and its usage_profile is applied. It reports:
+
+  unknown_address: addresses, patterns or resource types that don't match any
+                   resources in the project.
+  unknown_key: keys that aren't usage values of the resource type.
+  invalid_type: values of the wrong type, e.g. a string for a number.
+
+Exits with an error if there are any issues, so it can be used in CI.`,
+		Example: `  Check the usage file of a Terraform directory:
+
+      infracost usage validate --path /path/to/code --usage-file infracost-usage.yml
+
+  Check the usage files of all the projects in a config file as JSON:
+
+      infracost usage validate --config-file infracost.yml --format json`,
+		ValidArgs: []string{"--", "-"},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			format, _ := cmd.Flags().GetString("format")
+			if !contains(validUsageValidateFormats, format) {
+				ui.PrintUsage(cmd)
+				return fmt.Errorf("--format only supports %s", strings.Join(validUsageValidateFormats, ", "))
+			}
+
+			err := loadRunFlags(ctx.Config, cmd)
+			if err != nil {
+				return err
+			}
+
+			return runUsageValidate(cmd, ctx, format)
+		},
+	}
+
+	addUsageProjectFlags(cmd, "Path to the Infracost usage file to validate")
+	cmd.Flags().String("format", "table", "Output format: table, json")
+
+	_ = cmd.RegisterFlagCompletionFunc("format", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return validUsageValidateFormats, cobra.ShellCompDirectiveDefault
+	})
+
+	return cmd
+}
+
+func usageMigrateCmd(ctx *config.RunContext) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "migrate",
+		Short: "Migrate the usage file to the latest version",
+		Long: `Migrate the usage file to the latest version
+
+Converts the usage file to version 0.2, which records the unit of each usage
+value, whether it was set by hand or synced, and when it was synced. Values
+synced by an earlier usage sync are marked as synced, all other values are
+marked as manual. Comments are kept when version 0.2 files are written.`,
+		Example: `  Migrate the usage file in place:
+
+      infracost usage migrate --usage-file infracost-usage.yml
+
+  Write the migrated usage file to a new file:
+
+      infracost usage migrate --usage-file infracost-usage.yml --out-file infracost-usage-v2.yml`,
+		ValidArgs: []string{"--", "-"},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			usageFilePath, _ := cmd.Flags().GetString("usage-file")
+			outFilePath, _ := cmd.Flags().GetString("out-file")
+			if outFilePath == "" {
+				outFilePath = usageFilePath
+			}
+
+			if _, err := os.Stat(usageFilePath); os.IsNotExist(err) {
+				return fmt.Errorf("Usage file %s does not exist", usageFilePath)
+			}
+
+			usageFile, err := usage.LoadUsageFile(usageFilePath)
+			if err != nil {
+				return err
+			}
+
+			if !usageFile.Migrate() {
+				cmd.PrintErrf("%s is already version %s\n", usageFilePath, usageFile.Version)
+				if outFilePath == usageFilePath {
+					return nil
+				}
+			}
+
+			err = usageFile.WriteToPath(outFilePath)
+			if err != nil {
+				return errors.Wrap(err, "Error writing usage file")
+			}
+
+			cmd.PrintErrf("Migrated %s to version %s in %s\n", usageFilePath, usageFile.Version, outFilePath)
+
+			return nil
+		},
+	}
+
+	cmd.Flags().String("usage-file", defaultUsageFile, "Path to the Infracost usage file to migrate")
+	cmd.Flags().String("out-file", "", "Path to write the migrated usage file to, defaults to the usage file")
+
+	_ = cmd.MarkFlagFilename("usage-file", "yml")
+	_ = cmd.MarkFlagFilename("out-file", "yml")
+
+	return cmd
+}
+
+func addUsageProjectFlags(cmd *cobra.Command, usageFileDescription string) {
+	cmd.Flags().StringP("path", "p", "", "Path to the Terraform directory or JSON/plan file")
+	cmd.Flags().String("config-file", "", "Path to Infracost config file. Cannot be used with path, terraform* or usage-file flags")
+	cmd.Flags().String("usage-file", defaultUsageFile, usageFileDescription)
+
+	cmd.Flags().String("terraform-plan-flags", "", "Flags to pass to 'terraform plan'. Applicable when path is a Terraform directory")
+	cmd.Flags().String("terraform-init-flags", "", "Flags to pass to 'terraform init'. Applicable when path is a Terraform directory")
+	cmd.Flags().String("terraform-workspace", "", "Terraform workspace to use. Applicable when path is a Terraform directory")
+	cmd.Flags().Bool("terraform-use-state", false, "Use Terraform state instead of generating a plan. Applicable when path is a Terraform directory")
+	cmd.Flags().Bool("terraform-parse-hcl", false, "Parse the HCL directly instead of generating a Terraform plan. This option does not need credentials and is faster (experimental)")
+	cmd.Flags().StringSlice("terraform-var-file", nil, "Load variable files from the given file, similar to Terraform's -var-file flag. Only supported with --terraform-parse-hcl (experimental)")
+	cmd.Flags().StringSlice("terraform-var", nil, "Set a value for one of the input variables, similar to Terraform's -var flag. Only supported with --terraform-parse-hcl (experimental)")
+
+	cmd.Flags().Bool("no-cache", false, "Don't attempt to cache Terraform plans")
+
+	_ = cmd.MarkFlagFilename("path", "json", "tf")
+	_ = cmd.MarkFlagFilename("config-file", "yml")
+	_ = cmd.MarkFlagFilename("usage-file", "yml")
+}
+
+// runUsageSync syncs the usage file of every project with the usage from the
+// source set by opts, without pricing the projects.
+func runUsageSync(cmd *cobra.Command, runCtx *config.RunContext, opts usage.SyncOptions) error {
+	for _, projectCfg := range runCtx.Config.Projects {
+		for k, v := range projectCfg.Env {
+			os.Setenv(k, v)
+		}
+
+		ctx := config.NewProjectContext(runCtx, projectCfg)
+
+		provider, err := providers.Detect(ctx)
+		if err != nil {
+			m := fmt.Sprintf("%s\n\n", err)
+			m += fmt.Sprintf("Try setting --path to a Terraform plan JSON file. See %s for how to generate this.", ui.LinkString("https://infracost.io/troubleshoot"))
+
+			return clierror.NewSanitizedError(errors.New(m), "Could not detect path type")
+		}
+
+		cmd.PrintErrf("Detected %s at %s\n", provider.DisplayType(), ui.DisplayPath(projectCfg.Path))
+
+		err = generateUsageFile(cmd, runCtx, ctx, projectCfg, provider, opts)
+		if err != nil {
+			return errors.Wrap(err, "Error syncing usage file")
+		}
+
+		cmd.PrintErrf("Saved usage to %s\n", projectCfg.UsageFile)
+	}
+
+	return nil
+}
+
+type usageRecordOptions struct {
+	interval  time.Duration
+	gitCommit bool
+	gitPush   bool
+	uploadURL string
+}
+
+// runUsageRecord syncs the usage files every interval until it's interrupted,
+// then commits or uploads them. Errors are printed rather than returned so a
+// failure, e.g. a throttled API, doesn't stop the next sync.
+func runUsageRecord(cmd *cobra.Command, runCtx *config.RunContext, opts usageRecordOptions) error {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	for {
+		err := recordUsage(cmd, runCtx, opts)
+		if err != nil {
+			ui.PrintErrorf(cmd.ErrOrStderr(), "%s", err)
+		}
+
+		cmd.PrintErrf("Next sync at %s\n", time.Now().Add(opts.interval).Format(time.RFC3339))
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(opts.interval):
+		}
+	}
+}
+
+func recordUsage(cmd *cobra.Command, runCtx *config.RunContext, opts usageRecordOptions) error {
+	syncOpts, err := usageSyncOptions(cmd)
+	if err != nil {
+		return err
+	}
+
+	err = runUsageSync(cmd, runCtx, syncOpts)
+	if err != nil {
+		return err
+	}
+
+	for _, projectCfg := range runCtx.Config.Projects {
+		if opts.gitCommit {
+			err = commitUsageFile(projectCfg.UsageFile, syncOpts.Provider, opts.gitPush)
+			if err != nil {
+				return err
+			}
+		}
+
+		if opts.uploadURL != "" {
+			err = usage.UploadUsageFile(projectCfg.UsageFile, opts.uploadURL)
+			if err != nil {
+				return errors.Wrapf(err, "Error uploading usage file to %s", opts.uploadURL)
+			}
+
+			cmd.PrintErrf("Uploaded usage to %s\n", opts.uploadURL)
+		}
+	}
+
+	return nil
+}
+
+// commitUsageFile commits the usage file to the git repository it's in if it
+// has changed, and pushes the commit if push is true.
+func commitUsageFile(path string, provider string, push bool) error {
+	dir := filepath.Dir(path)
+	name := filepath.Base(path)
+
+	git := func(args ...string) error {
+		c := exec.Command("git", args...)
+		c.Dir = dir
+
+		out, err := c.CombinedOutput()
+		if err != nil {
+			return errors.Wrapf(err, "Error running git %s: %s", args[0], strings.TrimSpace(string(out)))
+		}
+
+		return nil
+	}
+
+	err := git("add", "--", name)
+	if err != nil {
+		return err
+	}
+
+	// git diff exits with 1 if the usage file has staged changes
+	if git("diff", "--cached", "--quiet", "--", name) == nil {
+		return nil
+	}
+
+	err = git("commit", "-m", fmt.Sprintf("Update %s with the usage from %s", name, provider), "--", name)
+	if err != nil {
+		return err
+	}
+
+	if push {
+		return git("push")
+	}
+
+	return nil
+}
+
+// runUsageWizard asks for the usage of the resources of every project and saves
+// the answers to the project's usage file.
+func runUsageWizard(cmd *cobra.Command, runCtx *config.RunContext) error {
+	for _, projectCfg := range runCtx.Config.Projects {
+		err := usage.CreateUsageFile(projectCfg.UsageFile)
+		if err != nil {
+			return errors.Wrap(err, "Error creating usage file")
+		}
+
+		out, err := runProjectConfig(cmd, runCtx, config.NewProjectContext(runCtx, projectCfg), projectCfg, nil)
+		if err != nil {
+			return err
+		}
+
+		usageFile, hasUsageFile, err := loadProjectUsageFile(projectCfg)
+		if err != nil {
+			return err
+		}
+
+		if !hasUsageFile {
+			continue
+		}
+
+		// Add the usage items of any new resources so they can be asked for, but
+		// leave syncing them from the cloud provider to infracost usage sync
+		_, err = usage.SyncUsageDataWithOptions(usageFile, out.projects, usage.SyncOptions{SkipEstimates: true})
+		if err != nil {
+			return errors.Wrap(err, "Error synchronizing usage data")
+		}
+
+		resources := make(map[string]*schema.Resource)
+		for _, r := range schema.AllProjectResources(out.projects) {
+			resources[r.Name] = r
+		}
+
+		// Costs are in the currency prices were looked up in until they're converted for the output
+		currency := projectCfg.Currency
+		if currency == "" {
+			currency = runCtx.Config.Currency
+		}
+		currency = runCtx.Config.PricingCurrencyFor(currency)
+
+		answered := 0
+
+		for _, resourceUsage := range usageFile.ResourceUsages {
+			r, ok := resources[resourceUsage.Name]
+			if !ok || len(resourceUsage.Items) == 0 {
+				continue
+			}
+
+			printUsageWizardResource(cmd, r, currency)
+
+			n, err := usage.AskResourceUsage(resourceUsage, usageWizardPrompt)
+			if err != nil {
+				return err
+			}
+
+			answered += n
+			cmd.Println()
+		}
+
+		if answered == 0 {
+			cmd.PrintErrf("No usage values were changed, %s is unchanged\n", projectCfg.UsageFile)
+			continue
+		}
+
+		err = usageFile.WriteToPath(projectCfg.UsageFile)
+		if err != nil {
+			return errors.Wrap(err, "Error writing usage file")
+		}
+
+		updated, err := runProjectConfig(cmd, runCtx, config.NewProjectContext(runCtx, projectCfg), projectCfg, nil)
+		if err != nil {
+			return err
+		}
+
+		before := projectsMonthlyCost(out.projects)
+		after := projectsMonthlyCost(updated.projects)
+
+		cmd.PrintErrf("Saved %d usage values to %s\n", answered, projectCfg.UsageFile)
+		cmd.PrintErrf("Monthly cost: %s → %s\n", output.FormatCost(currency, &before), output.FormatCost(currency, &after))
+	}
+
+	return nil
+}
+
+// printUsageWizardResource prints the monthly cost of the resource and its cost
+// components, so it's clear how its usage affects its cost.
+func printUsageWizardResource(cmd *cobra.Command, r *schema.Resource, currency string) {
+	cmd.Printf("%s %s\n", ui.BoldString(r.Name), ui.FaintString(fmt.Sprintf("(%s/month)", output.FormatCost(currency, r.MonthlyCost))))
+
+	components := append([]*schema.CostComponent{}, r.CostComponents...)
+	for _, sub := range r.FlattenedSubResources() {
+		components = append(components, sub.CostComponents...)
+	}
+
+	for _, c := range components {
+		if c.MonthlyQuantity == nil && c.HourlyQuantity == nil {
+			cmd.Printf("  %s: depends on usage, %s per %s\n", c.Name, output.FormatPrice(currency, c.Price()), c.Unit)
+			continue
+		}
+
+		cmd.Printf("  %s: %s/month\n", c.Name, output.FormatCost(currency, c.MonthlyCost))
+	}
+}
+
+func usageWizardPrompt(key string, description string, current string, validate func(string) error) (string, error) {
+	label := key
+	if description != "" {
+		label = fmt.Sprintf("%s, %s", label, description)
+	}
+	if current != "" {
+		label = fmt.Sprintf("%s [%s]", label, current)
+	}
+
+	return stringPrompt(label, validate)
+}
+
+func projectsMonthlyCost(projects []*schema.Project) decimal.Decimal {
+	total := decimal.Zero
+	for _, r := range schema.AllProjectResources(projects) {
+		if r.MonthlyCost != nil {
+			total = total.Add(*r.MonthlyCost)
+		}
+	}
+
+	return total
+}
+
+// usageValidation is the JSON report of the issues in the usage file of a project.
+type usageValidation struct {
+	Path      string                  `json:"path"`
+	UsageFile string                  `json:"usageFile"`
+	Issues    []usage.ValidationIssue `json:"issues"`
+}
+
+// runUsageValidate validates the usage files of every project that has them, as
+// they're loaded for the estimate: merged over the usage files of its modules
+// with its usage profile applied. It returns an error if any have issues.
+func runUsageValidate(cmd *cobra.Command, runCtx *config.RunContext, format string) error {
+	validations := make([]usageValidation, 0, len(runCtx.Config.Projects))
+	issueCount := 0
+
+	for _, projectCfg := range runCtx.Config.Projects {
+		usageFile, hasUsageFile, err := loadProjectUsageFile(projectCfg)
+		if err != nil {
+			return err
+		}
+
+		if !hasUsageFile {
+			continue
+		}
+
+		for k, v := range projectCfg.Env {
+			os.Setenv(k, v)
+		}
+
+		ctx := config.NewProjectContext(runCtx, projectCfg)
+
+		provider, err := providers.Detect(ctx)
+		if err != nil {
+			m := fmt.Sprintf("%s\n\n", err)
+			m += fmt.Sprintf("Try setting --path to a Terraform plan JSON file. See %s for how to generate this.", ui.LinkString("https://infracost.io/troubleshoot"))
+
+			return clierror.NewSanitizedError(errors.New(m), "Could not detect path type")
+		}
+
+		cmd.PrintErrf("Detected %s at %s\n", provider.DisplayType(), ui.DisplayPath(projectCfg.Path))
+
+		projects, err := provider.LoadResources(usageFile.ToUsageDataMap())
+		if err != nil {
+			return errors.Wrap(err, "Error loading resources")
+		}
+
+		issues, err := usage.Validate(usageFile, projects)
+		if err != nil {
+			return errors.Wrap(err, "Error validating usage file")
+		}
+
+		issueCount += len(issues)
+		validations = append(validations, usageValidation{
+			Path:      projectCfg.Path,
+			UsageFile: usageFileDisplayName(projectCfg),
+			Issues:    issues,
+		})
+	}
+
+	if format == "json" {
+		b, err := json.MarshalIndent(map[string]interface{}{"projects": validations}, "", "  ")
+		if err != nil {
+			return errors.Wrap(err, "Error generating JSON output")
+		}
+
+		cmd.Println(string(b))
+	} else {
+		cmd.Print(formatUsageValidations(validations))
+	}
+
+	if issueCount > 0 {
+		return fmt.Errorf("Found %d issue%s in the usage file%s", issueCount, pluralize(issueCount), pluralize(len(validations)))
+	}
+
+	return nil
+}
+
+// usageFileDisplayName returns the usage files of the project in the order
+// they're merged, or the usage files of its modules if it has none of its own.
+func usageFileDisplayName(projectCfg *config.Project) string {
+	paths := projectCfg.UsageFilePaths()
+	if len(paths) == 0 {
+		return usage.ModuleUsageFileName
+	}
+
+	return strings.Join(paths, ", ")
+}
+
+func formatUsageValidations(validations []usageValidation) string {
+	var b strings.Builder
+
+	for _, v := range validations {
+		fmt.Fprintf(&b, "%s %s\n", ui.BoldString(v.UsageFile), ui.FaintString(fmt.Sprintf("(%s)", v.Path)))
+
+		if len(v.Issues) == 0 {
+			b.WriteString("  No issues found\n\n")
+			continue
+		}
+
+		for _, issue := range v.Issues {
+			address := issue.Address
+			if issue.Profile != "" {
+				address = fmt.Sprintf("profiles.%s.%s", issue.Profile, address)
+			}
+
+			fmt.Fprintf(&b, "  %s %s: %s %s\n", ui.ErrorString("✖"), address, issue.Message, ui.FaintString(fmt.Sprintf("[%s]", issue.Code)))
+		}
+
+		b.WriteString("\n")
+	}
+
+	return b.String()
+}
+
+func pluralize(n int) string {
+	if n == 1 {
+		return ""
+	}
+
+	return "s"
+}
@@ -0,0 +1,31 @@
+package main_test
+
+import (
+	"testing"
+
+	"github.com/infracost/infracost/internal/testutil"
+)
+
+func TestUsageHelp(t *testing.T) {
+	GoldenFileCommandTest(t, testutil.CalcGoldenFileTestdataDirName(), []string{"usage", "--help"}, nil)
+}
+
+func TestUsageSyncHelp(t *testing.T) {
+	GoldenFileCommandTest(t, testutil.CalcGoldenFileTestdataDirName(), []string{"usage", "sync", "--help"}, nil)
+}
+
+func TestUsageRecordHelp(t *testing.T) {
+	GoldenFileCommandTest(t, testutil.CalcGoldenFileTestdataDirName(), []string{"usage", "record", "--help"}, nil)
+}
+
+func TestUsageWizardHelp(t *testing.T) {
+	GoldenFileCommandTest(t, testutil.CalcGoldenFileTestdataDirName(), []string{"usage", "wizard", "--help"}, nil)
+}
+
+func TestUsageValidateHelp(t *testing.T) {
+	GoldenFileCommandTest(t, testutil.CalcGoldenFileTestdataDirName(), []string{"usage", "validate", "--help"}, nil)
+}
+
+func TestUsageMigrateHelp(t *testing.T) {
+	GoldenFileCommandTest(t, testutil.CalcGoldenFileTestdataDirName(), []string{"usage", "migrate", "--help"}, nil)
+}
@@ -0,0 +1,114 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/infracost/infracost/internal/apiclient"
+	"github.com/infracost/infracost/internal/config"
+	"github.com/infracost/infracost/internal/ui"
+	"github.com/infracost/infracost/internal/validate"
+)
+
+var validValidateFormats = []string{"table", "json"}
+
+func validateCmd(ctx *config.RunContext) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "validate",
+		Short: "Check the config file, projects and usage files without pricing them",
+		Long: `Check the config file, projects and usage files without pricing them: that the
+config file is valid, the project paths exist, the HCL of each project can be
+parsed, the usage files are valid and the Cloud Pricing API can be reached`,
+		Example: `  Validate a config file before running it in CI:
+
+      infracost validate --config-file infracost.yml
+
+  Validate a Terraform directory and usage file, outputting JSON:
+
+      infracost validate --path /path/to/code --usage-file infracost-usage.yml --format json`,
+		ValidArgs: []string{"--", "-"},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			format, _ := cmd.Flags().GetString("format")
+			if !contains(validValidateFormats, format) {
+				ui.PrintUsage(cmd)
+				return fmt.Errorf("--format only supports %s", strings.Join(validValidateFormats, ", "))
+			}
+
+			configFile, _ := cmd.Flags().GetString("config-file")
+			path, _ := cmd.Flags().GetString("path")
+
+			if configFile != "" && path != "" {
+				ui.PrintUsage(cmd)
+				return errors.New("--config-file and --path cannot be used together")
+			}
+
+			if configFile == "" && path == "" {
+				ui.PrintUsage(cmd)
+				return errors.New("--config-file or --path is required")
+			}
+
+			opts := validate.Options{ConfigFile: configFile}
+			opts.SkipHCL, _ = cmd.Flags().GetBool("skip-hcl")
+
+			if configFile != "" {
+				opts.ConfigFileErr = ctx.Config.LoadFromConfigFile(configFile)
+			} else {
+				usageFile, _ := cmd.Flags().GetString("usage-file")
+				ctx.Config.Projects = []*config.Project{{Path: path, UsageFile: usageFile}}
+			}
+
+			if err := loadPricingFlags(ctx.Config, cmd); err != nil {
+				return err
+			}
+
+			if !ctx.Config.PricingOffline && !ctx.Config.UsesAWSPriceList() {
+				opts.Ping = func() error {
+					if err := checkAPIKey(ctx.Config.APIKey, ctx.Config.PricingAPIEndpoint, ctx.Config.DefaultPricingAPIEndpoint); err != nil {
+						return err
+					}
+
+					return apiclient.NewPricingAPIClient(ctx).Ping()
+				}
+			}
+
+			report := validate.Run(ctx, opts)
+
+			if format == "json" {
+				b, err := report.ToJSON()
+				if err != nil {
+					return err
+				}
+				cmd.Println(string(b))
+			} else {
+				cmd.Print(report.ToTable())
+			}
+
+			if !report.Valid {
+				return errors.New("Config is invalid, see the failed checks above")
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringP("path", "p", "", "Path to the Terraform directory or JSON/plan file")
+	cmd.Flags().String("config-file", "", "Path to Infracost config file. Cannot be used with path or usage-file flags")
+	cmd.Flags().String("usage-file", "", "Path to Infracost usage file that specifies values for usage-based resources")
+	cmd.Flags().String("format", "table", "Output format: table, json")
+	cmd.Flags().Bool("skip-hcl", false, "Don't parse the HCL of the projects")
+	cmd.Flags().String("pricing-backend", config.PricingBackendInfracost, "Source of AWS prices: infracost, aws-price-list. The Cloud Pricing API isn't checked with aws-price-list")
+	cmd.Flags().Bool("pricing-offline", false, "Don't check the Cloud Pricing API since prices are from the pricing snapshot")
+
+	_ = cmd.MarkFlagFilename("path", "json", "tf")
+	_ = cmd.MarkFlagFilename("config-file", "yml")
+	_ = cmd.MarkFlagFilename("usage-file", "yml")
+
+	_ = cmd.RegisterFlagCompletionFunc("format", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return validValidateFormats, cobra.ShellCompDirectiveDefault
+	})
+
+	return cmd
+}
@@ -0,0 +1,13 @@
+package infracost
+
+# The costs in the Infracost JSON are strings, so they're converted to numbers.
+max_monthly_cost_increase := 1000
+
+deny[out] {
+	increase := to_number(input.diffTotalMonthlyCost)
+
+	out := {
+		"msg": sprintf("Total monthly cost increase must be less than $%v (actual increase is $%.2f)", [max_monthly_cost_increase, increase]),
+		"failed": increase >= max_monthly_cost_increase,
+	}
+}
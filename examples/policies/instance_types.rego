@@ -0,0 +1,14 @@
+package infracost
+
+# The instance type is part of the name of the cost component of an instance,
+# e.g. "Instance usage (Linux/UNIX, on-demand, m5.4xlarge)".
+forbidden_instance_types := {"m5.8xlarge", "m5.12xlarge", "m5.16xlarge", "m5.24xlarge"}
+
+deny[msg] {
+	r := input.projects[_].breakdown.resources[_]
+	c := r.costComponents[_]
+	t := forbidden_instance_types[_]
+	contains(c.name, sprintf("%s)", [t]))
+
+	msg := sprintf("%s must not use the %s instance type", [r.name, t])
+}
@@ -0,0 +1,11 @@
+package infracost
+
+# Usage-based cost components have no quantity when their usage isn't set in
+# the usage file.
+deny[msg] {
+	r := input.projects[_].breakdown.resources[_]
+	c := r.costComponents[_]
+	c.monthlyQuantity == null
+
+	msg := sprintf("%s is missing usage for %q, add it to the usage file", [r.name, c.name])
+}
@@ -6,7 +6,10 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"math/rand"
 	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/pkg/errors"
@@ -21,8 +24,19 @@ type APIClient struct {
 	apiKey    string
 	tlsConfig *tls.Config
 	uuid      uuid.UUID
+
+	// retries is the number of times a request is retried after a network error,
+	// a 429 or a 5xx response, with exponential backoff and jitter between attempts.
+	retries int
+	// timeout limits how long each attempt can take, 0 means no timeout.
+	timeout time.Duration
 }
 
+const (
+	retryBaseDelay = 500 * time.Millisecond
+	retryMaxDelay  = 10 * time.Second
+)
+
 type GraphQLQuery struct {
 	Query     string                 `json:"query"`
 	Variables map[string]interface{} `json:"variables"`
@@ -59,9 +73,31 @@ func (c *APIClient) doRequest(method string, path string, d interface{}) ([]byte
 		return []byte{}, errors.Wrap(err, "Error generating request body")
 	}
 
-	req, err := http.NewRequest(method, c.endpoint+path, bytes.NewBuffer(reqBody))
+	for attempt := 0; ; attempt++ {
+		respBody, retryAfter, err := c.doRequestAttempt(method, path, reqBody)
+		if err == nil || retryAfter < 0 || attempt >= c.retries {
+			return respBody, err
+		}
+
+		delay := backoff(attempt)
+		if retryAfter > delay {
+			delay = retryAfter
+		}
+		if delay > retryMaxDelay {
+			delay = retryMaxDelay
+		}
+
+		log.Debugf("Retrying API request in %s after error: %s", delay, err)
+		time.Sleep(delay)
+	}
+}
+
+// doRequestAttempt sends the request once. It returns how long to wait before
+// retrying the request if it can be retried, or -1 if it can't.
+func (c *APIClient) doRequestAttempt(method string, path string, reqBody []byte) ([]byte, time.Duration, error) {
+	req, err := http.NewRequest(method, c.endpoint+path, bytes.NewReader(reqBody))
 	if err != nil {
-		return []byte{}, errors.Wrap(err, "Error generating request")
+		return []byte{}, -1, errors.Wrap(err, "Error generating request")
 	}
 
 	c.AddAuthHeaders(req)
@@ -71,33 +107,60 @@ func (c *APIClient) doRequest(method string, path string, d interface{}) ([]byte
 	transport := http.DefaultTransport.(*http.Transport)
 	transport.TLSClientConfig = c.tlsConfig
 
-	client := &http.Client{Transport: transport}
+	client := &http.Client{Transport: transport, Timeout: c.timeout}
 	resp, err := client.Do(req)
 	if err != nil {
-		return []byte{}, errors.Wrap(err, "Error sending API request")
+		return []byte{}, 0, errors.Wrap(err, "Error sending API request")
 	}
 	defer resp.Body.Close()
 
 	respBody, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return []byte{}, &APIError{err, "Invalid API response"}
+		return []byte{}, 0, &APIError{err, "Invalid API response"}
 	}
 
 	if resp.StatusCode != 200 {
+		retryAfter := time.Duration(-1)
+		if resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500 {
+			retryAfter = parseRetryAfter(resp.Header.Get("Retry-After"))
+		}
+
 		var r APIErrorResponse
 
 		err = json.Unmarshal(respBody, &r)
 		if err != nil {
-			return []byte{}, &APIError{fmt.Errorf(resp.Status), "Invalid API response"}
+			return []byte{}, retryAfter, &APIError{fmt.Errorf(resp.Status), "Invalid API response"}
 		}
 
 		if r.Error == "Invalid API key" {
-			return []byte{}, ErrInvalidAPIKey
+			return []byte{}, -1, ErrInvalidAPIKey
 		}
-		return []byte{}, &APIError{fmt.Errorf("%v %v", resp.Status, r.Error), "Received error from API"}
+		return []byte{}, retryAfter, &APIError{fmt.Errorf("%v %v", resp.Status, r.Error), "Received error from API"}
+	}
+
+	return respBody, 0, nil
+}
+
+// backoff returns the delay before the given retry attempt. The delay doubles
+// with each attempt up to retryMaxDelay and full jitter is applied so clients
+// that failed at the same time don't retry at the same time.
+func backoff(attempt int) time.Duration {
+	d := retryBaseDelay << attempt
+	if d <= 0 || d > retryMaxDelay {
+		d = retryMaxDelay
+	}
+
+	return time.Duration(rand.Int63n(int64(d))) // nolint: gosec
+}
+
+// parseRetryAfter parses the number of seconds of a Retry-After header.
+func parseRetryAfter(v string) time.Duration {
+	secs, err := strconv.Atoi(v)
+	if err != nil || secs < 0 {
+		return 0
 	}
 
-	return respBody, nil
+	return time.Duration(secs) * time.Second
 }
 
 func (c *APIClient) AddDefaultHeaders(req *http.Request) {
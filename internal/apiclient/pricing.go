@@ -1,14 +1,24 @@
 package apiclient
 
 import (
+	"context"
 	"crypto/tls"
 	"crypto/x509"
+	"encoding/json"
 	"fmt"
 	"io/ioutil"
+	"runtime"
 
+	"github.com/infracost/infracost/internal/awspricelist"
 	"github.com/infracost/infracost/internal/config"
+	"github.com/infracost/infracost/internal/currency"
+	"github.com/infracost/infracost/internal/pricecache"
+	"github.com/infracost/infracost/internal/pricelist"
+	"github.com/infracost/infracost/internal/pricesnapshot"
 	"github.com/infracost/infracost/internal/schema"
 
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
 	log "github.com/sirupsen/logrus"
 	"github.com/tidwall/gjson"
 )
@@ -17,6 +27,51 @@ type PricingAPIClient struct {
 	APIClient
 	Currency       string
 	EventsDisabled bool
+
+	// Snapshot records the results of the queries run against the API. When Offline
+	// is set, queries are answered from the Snapshot and the API is never called.
+	Snapshot *pricesnapshot.Snapshot
+	Offline  bool
+
+	// AWSPriceList answers the queries of AWS cost components instead of the API.
+	AWSPriceList *awspricelist.Client
+
+	// PriceCache answers queries that were run recently so they're not sent to
+	// the API again.
+	PriceCache *pricecache.Cache
+
+	// BatchSize is the number of queries sent to the API in each request and
+	// Concurrency is the number of requests that are run at once.
+	BatchSize   int
+	Concurrency int
+
+	// AllowUnavailablePrices returns the errors of failed lookups with the results
+	// of the affected cost components instead of failing all the lookups.
+	AllowUnavailablePrices bool
+
+	// pricingCurrency is the currency prices are looked up in. When it differs from
+	// Currency the results are converted to Currency using rate.
+	pricingCurrency string
+	rate            *currency.Rate
+}
+
+// DefaultBatchSize is the number of queries sent to the API in each request
+// when BatchSize isn't set.
+const DefaultBatchSize = 100
+
+// DefaultConcurrency returns the number of requests run at once when Concurrency
+// isn't set. It's calculated using the following formula:
+// min(max(4, numCPU * 4), 16)
+func DefaultConcurrency() int {
+	n := runtime.NumCPU() * 4
+	if n < 4 {
+		n = 4
+	}
+	if n > 16 {
+		n = 16
+	}
+
+	return n
 }
 
 type PriceQueryKey struct {
@@ -27,12 +82,21 @@ type PriceQueryKey struct {
 type PriceQueryResult struct {
 	PriceQueryKey
 	Result gjson.Result
+
+	// Err is set when the price couldn't be looked up and AllowUnavailablePrices
+	// is set, so the cost component is marked as price unavailable.
+	Err error
 }
 
 func NewPricingAPIClient(ctx *config.RunContext) *PricingAPIClient {
-	currency := ctx.Config.Currency
-	if currency == "" {
-		currency = "USD"
+	return NewPricingAPIClientForCurrency(ctx, ctx.Config.Currency)
+}
+
+// NewPricingAPIClientForCurrency returns a client that returns prices in outCurrency
+// instead of the currency of the run, e.g. for projects with their own currency.
+func NewPricingAPIClientForCurrency(ctx *config.RunContext, outCurrency string) *PricingAPIClient {
+	if outCurrency == "" {
+		outCurrency = currency.USD
 	}
 
 	tlsConfig := tls.Config{} // nolint: gosec
@@ -69,9 +133,21 @@ func NewPricingAPIClient(ctx *config.RunContext) *PricingAPIClient {
 			apiKey:    ctx.Config.APIKey,
 			tlsConfig: &tlsConfig,
 			uuid:      ctx.UUID(),
+			retries:   ctx.Config.PricingAPIRetries,
+			timeout:   ctx.Config.PricingAPITimeout,
 		},
-		Currency:       currency,
-		EventsDisabled: ctx.Config.EventsDisabled,
+		Currency:       outCurrency,
+		EventsDisabled: ctx.Config.EventsDisabled || ctx.Config.PricingOffline || ctx.Config.UsesAWSPriceList(),
+		Snapshot:       ctx.PriceSnapshot,
+		Offline:        ctx.Config.PricingOffline,
+		AWSPriceList:   ctx.AWSPriceList,
+		PriceCache:     ctx.PriceCache,
+		Concurrency:    ctx.Config.PricingConcurrency,
+
+		AllowUnavailablePrices: ctx.Config.AllowUnavailablePrices,
+
+		pricingCurrency: ctx.Config.PricingCurrencyFor(outCurrency),
+		rate:            ctx.Config.RateFor(outCurrency),
 	}
 }
 
@@ -89,22 +165,314 @@ func (c *PricingAPIClient) AddEvent(name string, env map[string]interface{}) err
 	return err
 }
 
+// RunQueries gets the prices of the cost components of r and its sub resources.
 func (c *PricingAPIClient) RunQueries(r *schema.Resource) ([]PriceQueryResult, error) {
-	keys, queries := c.batchQueries(r)
+	return c.RunBatchedQueries([]*schema.Resource{r})
+}
+
+// RunBatchedQueries gets the prices of the cost components of all the resources.
+// Identical queries are coalesced so they're only run once, and the queries sent
+// to the API are split into batches of BatchSize that are run by Concurrency workers.
+func (c *PricingAPIClient) RunBatchedQueries(resources []*schema.Resource) ([]PriceQueryResult, error) {
+	keys := make([]PriceQueryKey, 0)
+	queries := make([]GraphQLQuery, 0)
+
+	for _, r := range resources {
+		k, q := c.batchQueries(r)
+		keys = append(keys, k...)
+		queries = append(queries, q...)
+	}
 
 	if len(queries) == 0 {
-		log.Debugf("Skipping getting pricing details for %s since there are no queries to run", r.Name)
+		log.Debugf("Skipping getting pricing details since there are no queries to run")
 		return []PriceQueryResult{}, nil
 	}
 
-	log.Debugf("Getting pricing details from %s for %s", c.endpoint, r.Name)
+	uniqueKeys, uniqueQueries, idxs := coalesceQueries(keys, queries)
+
+	var uniqueResults []gjson.Result
+	var uniqueErrs []error
+
+	if c.Offline {
+		log.Debugf("Getting pricing details from the pricing snapshot for %d queries", len(uniqueQueries))
+
+		uniqueResults = c.snapshotResults(uniqueKeys, uniqueQueries)
+	} else if c.AWSPriceList != nil {
+		log.Debugf("Getting pricing details from the AWS Price List API for %d queries", len(uniqueQueries))
+
+		var err error
+		uniqueResults, uniqueErrs, err = c.awsPriceListResults(uniqueKeys)
+		if err != nil {
+			return []PriceQueryResult{}, err
+		}
+	} else {
+		log.Debugf("Getting pricing details from %s for %d queries (%d unique)", c.endpoint, len(queries), len(uniqueQueries))
+
+		var err error
+		uniqueResults, uniqueErrs, err = c.doBatchedQueries(uniqueQueries)
+		if err != nil {
+			return []PriceQueryResult{}, err
+		}
+
+		if c.Snapshot != nil {
+			c.recordSnapshotResults(uniqueQueries, uniqueResults)
+		}
+	}
+
+	results := make([]gjson.Result, len(queries))
+	for i, idx := range idxs {
+		if idx < len(uniqueResults) {
+			results[i] = uniqueResults[idx]
+		}
+	}
+
+	zipped := c.zipQueryResults(keys, results)
+	for i, idx := range idxs {
+		if idx < len(uniqueErrs) {
+			zipped[i].Err = uniqueErrs[idx]
+		}
+	}
+
+	return zipped, nil
+}
+
+// coalesceQueries removes duplicate queries. It returns the unique queries with
+// the key of the first cost component for each, and the index of the unique
+// query for each of the queries.
+func coalesceQueries(keys []PriceQueryKey, queries []GraphQLQuery) ([]PriceQueryKey, []GraphQLQuery, []int) {
+	uniqueKeys := make([]PriceQueryKey, 0, len(keys))
+	uniqueQueries := make([]GraphQLQuery, 0, len(queries))
+	idxs := make([]int, len(queries))
+	seen := make(map[string]int, len(queries))
+
+	for i, q := range queries {
+		b, err := json.Marshal(q)
+		if err == nil {
+			if idx, ok := seen[string(b)]; ok {
+				idxs[i] = idx
+				continue
+			}
+
+			seen[string(b)] = len(uniqueQueries)
+		}
+
+		idxs[i] = len(uniqueQueries)
+		uniqueKeys = append(uniqueKeys, keys[i])
+		uniqueQueries = append(uniqueQueries, q)
+	}
+
+	return uniqueKeys, uniqueQueries, idxs
+}
+
+// doBatchedQueries splits the queries into batches of BatchSize and runs them
+// with Concurrency workers. Results are returned in the same order as the queries.
+// When AllowUnavailablePrices is set the errors of failed batches are returned
+// for each of their queries instead of failing all the queries.
+func (c *PricingAPIClient) doBatchedQueries(queries []GraphQLQuery) ([]gjson.Result, []error, error) {
+	batchSize := c.BatchSize
+	if batchSize <= 0 {
+		batchSize = DefaultBatchSize
+	}
+
+	numWorkers := c.Concurrency
+	if numWorkers <= 0 {
+		numWorkers = DefaultConcurrency()
+	}
+
+	type batch struct {
+		start   int
+		queries []GraphQLQuery
+	}
+
+	batches := make([]batch, 0, len(queries)/batchSize+1)
+	for start := 0; start < len(queries); start += batchSize {
+		end := start + batchSize
+		if end > len(queries) {
+			end = len(queries)
+		}
+
+		batches = append(batches, batch{start, queries[start:end]})
+	}
 
-	results, err := c.doQueries(queries)
+	if numWorkers > len(batches) {
+		numWorkers = len(batches)
+	}
+
+	results := make([]gjson.Result, len(queries))
+	errs := make([]error, len(queries))
+	jobs := make(chan batch, len(batches))
+	resultErrors := make(chan error, len(batches))
+
+	for i := 0; i < numWorkers; i++ {
+		go func() {
+			for b := range jobs {
+				// Each batch writes to its own range of results and errs
+				res, err := c.doCachedQueries(b.queries)
+				if err == nil {
+					copy(results[b.start:b.start+len(b.queries)], res)
+				} else if c.AllowUnavailablePrices && !errors.Is(err, ErrInvalidAPIKey) {
+					log.Warnf("Prices for %d cost components are unavailable: %s", len(b.queries), err)
+					for i := range b.queries {
+						errs[b.start+i] = err
+					}
+					err = nil
+				}
+				resultErrors <- err
+			}
+		}()
+	}
+
+	for _, b := range batches {
+		jobs <- b
+	}
+	close(jobs)
+
+	var firstErr error
+	for range batches {
+		err := <-resultErrors
+		if err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+
+	if firstErr != nil {
+		return []gjson.Result{}, nil, firstErr
+	}
+
+	return results, errs, nil
+}
+
+// snapshotResults looks up the results of the queries in the pricing snapshot.
+// Queries that aren't in the snapshot get an empty result so they're priced at 0.00
+// with a warning, the same as queries the API doesn't find any products for.
+func (c *PricingAPIClient) snapshotResults(keys []PriceQueryKey, queries []GraphQLQuery) []gjson.Result {
+	results := make([]gjson.Result, 0, len(queries))
+
+	for i, q := range queries {
+		var res gjson.Result
+		r := keys[i].Resource
+
+		key, err := pricesnapshot.Key(q)
+		if err != nil {
+			log.Warnf("Error looking up %s in the pricing snapshot: %s", r.Name, err)
+		} else if c.Snapshot == nil {
+			log.Warnf("No pricing snapshot loaded, skipping prices for %s", r.Name)
+		} else if snapshotRes, ok := c.Snapshot.Get(key); ok {
+			res = snapshotRes
+		} else {
+			log.Warnf("Prices for %s were not found in the pricing snapshot, run 'infracost pricing download' to update it", r.Name)
+		}
+
+		results = append(results, res)
+	}
+
+	return results
+}
+
+// awsPriceListResults queries the AWS Price List API for the AWS cost components.
+// Other vendors aren't in the AWS Price List API so their cost components get an
+// empty result and are priced at 0.00 with a warning.
+func (c *PricingAPIClient) awsPriceListResults(keys []PriceQueryKey) ([]gjson.Result, []error, error) {
+	results := make([]gjson.Result, 0, len(keys))
+	errs := make([]error, len(keys))
+
+	for i, k := range keys {
+		f := k.CostComponent.ProductFilter
+		if f == nil || f.VendorName == nil || *f.VendorName != "aws" {
+			log.Debugf("Skipping %s %s since only AWS prices are available from the AWS Price List API", k.Resource.Name, k.CostComponent.Name)
+			results = append(results, gjson.Result{})
+			continue
+		}
+
+		res, err := c.AWSPriceList.Query(context.Background(), c.pricingCurrency, f, k.CostComponent.PriceFilter)
+		if err != nil {
+			if !c.AllowUnavailablePrices {
+				return nil, nil, err
+			}
+
+			log.Warnf("Price for %s %s is unavailable: %s", k.Resource.Name, k.CostComponent.Name, err)
+			errs[i] = err
+		}
+
+		results = append(results, res)
+	}
+
+	return results, errs, nil
+}
+
+// doCachedQueries runs the queries that aren't in the price cache against the API
+// and caches their results. Results are returned in the same order as the queries.
+func (c *PricingAPIClient) doCachedQueries(queries []GraphQLQuery) ([]gjson.Result, error) {
+	if c.PriceCache == nil {
+		return c.doQueries(queries)
+	}
+
+	results := make([]gjson.Result, len(queries))
+	keys := make([]string, len(queries))
+
+	missIdxs := make([]int, 0, len(queries))
+	misses := make([]GraphQLQuery, 0, len(queries))
+
+	for i, q := range queries {
+		key, err := pricecache.Key(c.endpoint, q)
+		if err != nil {
+			log.Debugf("Error looking up query in the price cache: %s", err)
+		} else if res, ok := c.PriceCache.Get(key); ok {
+			results[i] = res
+			continue
+		}
+
+		keys[i] = key
+		missIdxs = append(missIdxs, i)
+		misses = append(misses, q)
+	}
+
+	log.Debugf("Found %d of %d queries in the price cache", len(queries)-len(misses), len(queries))
+
+	if len(misses) == 0 {
+		return results, nil
+	}
+
+	missResults, err := c.doQueries(misses)
 	if err != nil {
-		return []PriceQueryResult{}, err
+		return []gjson.Result{}, err
 	}
 
-	return c.zipQueryResults(keys, results), nil
+	for j, i := range missIdxs {
+		if j >= len(missResults) {
+			break
+		}
+
+		results[i] = missResults[j]
+
+		// Don't cache errors so the query is retried on the next run
+		if keys[i] != "" && missResults[j].Get("data").Exists() && !missResults[j].Get("errors").Exists() {
+			c.PriceCache.Set(keys[i], missResults[j])
+		}
+	}
+
+	return results, nil
+}
+
+func (c *PricingAPIClient) recordSnapshotResults(queries []GraphQLQuery, results []gjson.Result) {
+	for i, q := range queries {
+		if i >= len(results) {
+			return
+		}
+
+		// Failed lookups have no result to record
+		if results[i].Raw == "" {
+			continue
+		}
+
+		key, err := pricesnapshot.Key(q)
+		if err != nil {
+			log.Debugf("Error recording query in the pricing snapshot: %s", err)
+			continue
+		}
+
+		c.Snapshot.Add(key, results[i])
+	}
 }
 
 func (c *PricingAPIClient) buildQuery(product *schema.ProductFilter, price *schema.PriceFilter) GraphQLQuery {
@@ -121,24 +489,150 @@ func (c *PricingAPIClient) buildQuery(product *schema.ProductFilter, price *sche
 				}
 			}
 		}
-	`, c.Currency)
+	`, c.pricingCurrency)
 
 	return GraphQLQuery{query, v}
 }
 
+// RunProductAttributeQueries looks up the attributes of the products and the units
+// of the prices of the cost components of r, e.g. to match them with prices from
+// other sources. Sub resources and cost components of vendors that have a price
+// list are skipped.
+func (c *PricingAPIClient) RunProductAttributeQueries(r *schema.Resource) ([]PriceQueryResult, error) {
+	if c.Offline {
+		return []PriceQueryResult{}, errors.New("product attributes can't be looked up when pricing offline")
+	}
+
+	keys := make([]PriceQueryKey, 0, len(r.CostComponents))
+	queries := make([]GraphQLQuery, 0, len(r.CostComponents))
+
+	for _, component := range r.CostComponents {
+		if hasPriceList(component) {
+			continue
+		}
+
+		keys = append(keys, PriceQueryKey{r, component})
+		queries = append(queries, GraphQLQuery{
+			Query: `
+		query($productFilter: ProductFilter!, $priceFilter: PriceFilter) {
+			products(filter: $productFilter) {
+				attributes {
+					key
+					value
+				}
+				prices(filter: $priceFilter) {
+					unit
+				}
+			}
+		}
+	`,
+			Variables: map[string]interface{}{
+				"productFilter": component.ProductFilter,
+				"priceFilter":   component.PriceFilter,
+			},
+		})
+	}
+
+	log.Debugf("Getting product attributes from %s for %s", c.endpoint, r.Name)
+
+	results, err := c.doCachedQueries(queries)
+	if err != nil {
+		return []PriceQueryResult{}, err
+	}
+
+	return c.zipQueryResults(keys, results), nil
+}
+
+// RunProductQuery gets every product matching filter with all of its attributes
+// and prices, e.g. to copy them to a self-hosted Cloud Pricing API.
+func (c *PricingAPIClient) RunProductQuery(filter *schema.ProductFilter) (gjson.Result, error) {
+	if c.Offline {
+		return gjson.Result{}, errors.New("products can't be looked up when pricing offline")
+	}
+
+	query := GraphQLQuery{
+		Query: `
+		query($productFilter: ProductFilter!) {
+			products(filter: $productFilter) {
+				productHash
+				sku
+				vendorName
+				region
+				service
+				productFamily
+				attributes {
+					key
+					value
+				}
+				prices {
+					priceHash
+					purchaseOption
+					unit
+					USD
+					effectiveDateStart
+					effectiveDateEnd
+					startUsageAmount
+					endUsageAmount
+					termLength
+					termPurchaseOption
+					termOfferingClass
+					description
+				}
+			}
+		}
+	`,
+		Variables: map[string]interface{}{
+			"productFilter": filter,
+		},
+	}
+
+	log.Debugf("Getting products from %s", c.endpoint)
+
+	results, err := c.doQueries([]GraphQLQuery{query})
+	if err != nil {
+		return gjson.Result{}, err
+	}
+
+	if len(results) == 0 {
+		return gjson.Result{}, errors.New("no results returned for the products query")
+	}
+
+	return results[0], nil
+}
+
+// Ping checks the Cloud Pricing API can be reached with the API key, without
+// looking up any prices.
+func (c *PricingAPIClient) Ping() error {
+	if c.Offline {
+		return errors.New("the Cloud Pricing API isn't used when pricing offline")
+	}
+
+	_, err := c.doQueries([]GraphQLQuery{{Query: "query { __typename }"}})
+	return err
+}
+
 // Batch all the queries for this resource so we can use one GraphQL call.
 // Use PriceQueryKeys to keep track of which query maps to which sub-resource and price component.
+// Cost components for vendors that have a price list are skipped since they're priced locally.
 func (c *PricingAPIClient) batchQueries(r *schema.Resource) ([]PriceQueryKey, []GraphQLQuery) {
 	keys := make([]PriceQueryKey, 0)
 	queries := make([]GraphQLQuery, 0)
 
 	for _, component := range r.CostComponents {
+		if hasPriceList(component) {
+			continue
+		}
+
 		keys = append(keys, PriceQueryKey{r, component})
 		queries = append(queries, c.buildQuery(component.ProductFilter, component.PriceFilter))
 	}
 
 	for _, subresource := range r.FlattenedSubResources() {
 		for _, component := range subresource.CostComponents {
+			if hasPriceList(component) {
+				continue
+			}
+
 			keys = append(keys, PriceQueryKey{subresource, component})
 			queries = append(queries, c.buildQuery(component.ProductFilter, component.PriceFilter))
 		}
@@ -147,15 +641,130 @@ func (c *PricingAPIClient) batchQueries(r *schema.Resource) ([]PriceQueryKey, []
 	return keys, queries
 }
 
+// RunPriceListQueries gets the prices for the cost components of vendors that
+// aren't in the Cloud Pricing API from their price lists.
+func (c *PricingAPIClient) RunPriceListQueries(r *schema.Resource) []PriceQueryResult {
+	results := make([]PriceQueryResult, 0)
+
+	add := func(res *schema.Resource, component *schema.CostComponent) {
+		if !hasPriceList(component) {
+			return
+		}
+
+		results = append(results, PriceQueryResult{
+			PriceQueryKey: PriceQueryKey{res, component},
+			Result:        c.priceListResult(component.ProductFilter),
+		})
+	}
+
+	for _, component := range r.CostComponents {
+		add(r, component)
+	}
+
+	for _, subresource := range r.FlattenedSubResources() {
+		for _, component := range subresource.CostComponents {
+			add(subresource, component)
+		}
+	}
+
+	return results
+}
+
+func hasPriceList(component *schema.CostComponent) bool {
+	return component.ProductFilter != nil && pricelist.Has(component.ProductFilter.VendorName)
+}
+
 func (c *PricingAPIClient) zipQueryResults(k []PriceQueryKey, r []gjson.Result) []PriceQueryResult {
 	res := make([]PriceQueryResult, 0, len(k))
 
 	for i, k := range k {
 		res = append(res, PriceQueryResult{
 			PriceQueryKey: k,
-			Result:        r[i],
+			Result:        c.convertResult(r[i]),
 		})
 	}
 
 	return res
 }
+
+// priceListResult looks the product up in the static price list of its vendor.
+// Price lists only have prices in the currencies their vendor publishes, so if
+// the product has no price in the pricing currency its USD price is converted
+// with the built-in rate.
+func (c *PricingAPIClient) priceListResult(f *schema.ProductFilter) gjson.Result {
+	res := pricelist.Query(c.pricingCurrency, f)
+	if c.rate != nil {
+		return c.convertResult(res)
+	}
+
+	if !res.Get("data.products.0").Exists() || res.Get("data.products.0.prices.0."+c.pricingCurrency).Exists() {
+		return res
+	}
+
+	rate, ok := currency.BuiltInRate(c.pricingCurrency)
+	if !ok {
+		return res
+	}
+
+	return convertPrices(pricelist.Query(currency.USD, f), currency.USD, c.pricingCurrency, rate)
+}
+
+// convertResult adds the prices converted to Currency to the prices of the result,
+// so the result can be handled the same as results looked up in Currency.
+func (c *PricingAPIClient) convertResult(res gjson.Result) gjson.Result {
+	if c.rate == nil {
+		return res
+	}
+
+	return convertPrices(res, c.pricingCurrency, c.Currency, c.rate)
+}
+
+// convertPrices adds the prices in the from currency converted with rate to the
+// prices of the result in the to currency.
+func convertPrices(res gjson.Result, from, to string, rate *currency.Rate) gjson.Result {
+	if !res.Get("data.products.#.prices").Exists() {
+		return res
+	}
+
+	var v map[string]interface{}
+	err := json.Unmarshal([]byte(res.Raw), &v)
+	if err != nil {
+		log.Debugf("Error converting prices to %s: %s", to, err)
+		return res
+	}
+
+	data, _ := v["data"].(map[string]interface{})
+	products, _ := data["products"].([]interface{})
+
+	for _, product := range products {
+		p, _ := product.(map[string]interface{})
+		prices, _ := p["prices"].([]interface{})
+
+		for _, price := range prices {
+			m, ok := price.(map[string]interface{})
+			if !ok {
+				continue
+			}
+
+			amount, ok := m[from].(string)
+			if !ok {
+				continue
+			}
+
+			d, err := decimal.NewFromString(amount)
+			if err != nil {
+				continue
+			}
+
+			m[to] = rate.Convert(d).String()
+		}
+	}
+
+	b, err := json.Marshal(v)
+	if err != nil {
+		log.Debugf("Error converting prices to %s: %s", to, err)
+		return res
+	}
+
+	return gjson.ParseBytes(b)
+}
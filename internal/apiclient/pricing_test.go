@@ -0,0 +1,206 @@
+package apiclient
+
+import (
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/infracost/infracost/internal/currency"
+	"github.com/infracost/infracost/internal/pricelist"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+func strPtr(s string) *string { return &s }
+
+func instanceResource(name, instanceType string) *schema.Resource {
+	return &schema.Resource{
+		Name: name,
+		CostComponents: []*schema.CostComponent{
+			{
+				Name: "Instance usage",
+				ProductFilter: &schema.ProductFilter{
+					VendorName: strPtr("aws"),
+					AttributeFilters: []*schema.AttributeFilter{
+						{Key: "instanceType", Value: strPtr(instanceType)},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestRunBatchedQueries(t *testing.T) {
+	var mu sync.Mutex
+	batchSizes := []int{}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var queries []GraphQLQuery
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&queries))
+
+		mu.Lock()
+		batchSizes = append(batchSizes, len(queries))
+		mu.Unlock()
+
+		results := make([]interface{}, 0, len(queries))
+		for _, q := range queries {
+			b, _ := json.Marshal(q.Variables["productFilter"])
+
+			var f schema.ProductFilter
+			_ = json.Unmarshal(b, &f)
+
+			results = append(results, map[string]interface{}{
+				"data": map[string]interface{}{
+					"products": []interface{}{
+						map[string]interface{}{
+							"prices": []interface{}{
+								map[string]interface{}{"priceHash": *f.AttributeFilters[0].Value, "USD": "1"},
+							},
+						},
+					},
+				},
+			})
+		}
+
+		require.NoError(t, json.NewEncoder(w).Encode(results))
+	}))
+	defer server.Close()
+
+	c := &PricingAPIClient{
+		APIClient:       APIClient{endpoint: server.URL, tlsConfig: &tls.Config{}}, // nolint: gosec
+		Currency:        "USD",
+		BatchSize:       2,
+		Concurrency:     2,
+		pricingCurrency: "USD",
+	}
+
+	resources := make([]*schema.Resource, 0)
+	for i := 0; i < 6; i++ {
+		// Every other resource has the same instance type so half the queries are duplicates
+		resources = append(resources, instanceResource(fmt.Sprintf("r%d", i), fmt.Sprintf("t3.%d", i/2)))
+	}
+
+	results, err := c.RunBatchedQueries(resources)
+	require.NoError(t, err)
+	require.Len(t, results, 6)
+
+	for i, r := range results {
+		assert.Equal(t, resources[i], r.Resource)
+		assert.Equal(t, fmt.Sprintf("t3.%d", i/2), r.Result.Get("data.products.0.prices.0.priceHash").String())
+	}
+
+	// 3 unique queries in batches of 2
+	assert.ElementsMatch(t, []int{2, 1}, batchSizes)
+}
+
+func TestRunBatchedQueriesError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(`{"error": "Internal error"}`))
+	}))
+	defer server.Close()
+
+	c := &PricingAPIClient{
+		APIClient:       APIClient{endpoint: server.URL, tlsConfig: &tls.Config{}}, // nolint: gosec
+		Currency:        "USD",
+		BatchSize:       1,
+		pricingCurrency: "USD",
+	}
+
+	_, err := c.RunBatchedQueries([]*schema.Resource{instanceResource("a", "t3.micro"), instanceResource("b", "t3.small")})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "Internal error")
+}
+
+func TestRunBatchedQueriesRetries(t *testing.T) {
+	requests := 0
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if requests < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			_, _ = w.Write([]byte(`{"error": "Service unavailable"}`))
+			return
+		}
+
+		_, _ = w.Write([]byte(`[{"data": {"products": [{"prices": [{"priceHash": "abc", "USD": "1"}]}]}}]`))
+	}))
+	defer server.Close()
+
+	c := &PricingAPIClient{
+		APIClient:       APIClient{endpoint: server.URL, tlsConfig: &tls.Config{}, retries: 2}, // nolint: gosec
+		Currency:        "USD",
+		pricingCurrency: "USD",
+	}
+
+	results, err := c.RunBatchedQueries([]*schema.Resource{instanceResource("a", "t3.micro")})
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.Equal(t, "abc", results[0].Result.Get("data.products.0.prices.0.priceHash").String())
+	assert.Equal(t, 3, requests)
+}
+
+func TestRunBatchedQueriesAllowUnavailablePrices(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var queries []GraphQLQuery
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&queries))
+
+		b, _ := json.Marshal(queries[0].Variables["productFilter"])
+		if strings.Contains(string(b), "t3.small") {
+			w.WriteHeader(http.StatusBadGateway)
+			_, _ = w.Write([]byte(`{"error": "Bad gateway"}`))
+			return
+		}
+
+		_, _ = w.Write([]byte(`[{"data": {"products": [{"prices": [{"priceHash": "abc", "USD": "1"}]}]}}]`))
+	}))
+	defer server.Close()
+
+	c := &PricingAPIClient{
+		APIClient:              APIClient{endpoint: server.URL, tlsConfig: &tls.Config{}}, // nolint: gosec
+		Currency:               "USD",
+		BatchSize:              1,
+		AllowUnavailablePrices: true,
+		pricingCurrency:        "USD",
+	}
+
+	results, err := c.RunBatchedQueries([]*schema.Resource{instanceResource("a", "t3.micro"), instanceResource("b", "t3.small")})
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+
+	assert.NoError(t, results[0].Err)
+	assert.Equal(t, "abc", results[0].Result.Get("data.products.0.prices.0.priceHash").String())
+
+	require.Error(t, results[1].Err)
+	assert.Contains(t, results[1].Err.Error(), "Bad gateway")
+}
+
+func TestPriceListResultCurrency(t *testing.T) {
+	pricelist.Register("test-currency-vendor", []pricelist.Product{
+		{Service: "Compute", ProductFamily: "Server", Prices: map[string]string{"USD": "10", "EUR": "9"}},
+		{Service: "Storage", ProductFamily: "Volume", Prices: map[string]string{"USD": "10"}},
+	})
+
+	filter := func(service string) *schema.ProductFilter {
+		return &schema.ProductFilter{VendorName: strPtr("test-currency-vendor"), Service: strPtr(service)}
+	}
+
+	c := &PricingAPIClient{Currency: "EUR", pricingCurrency: "EUR"}
+
+	// The vendor's own EUR price is used
+	assert.Equal(t, "9", c.priceListResult(filter("Compute")).Get("data.products.0.prices.0.EUR").String())
+
+	// The USD price is converted with the built-in rate when there's no EUR price
+	assert.Equal(t, "8.929", c.priceListResult(filter("Storage")).Get("data.products.0.prices.0.EUR").String())
+
+	// A pinned rate converts the USD prices
+	c = &PricingAPIClient{Currency: "EUR", pricingCurrency: "USD", rate: currency.PinnedRate("EUR", 0.5, "")}
+	assert.Equal(t, "5", c.priceListResult(filter("Compute")).Get("data.products.0.prices.0.EUR").String())
+}
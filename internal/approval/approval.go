@@ -0,0 +1,176 @@
+// Package approval sends the diff of a run that breaches guardrails to an
+// approval webhook, and waits for the change to be approved or rejected, so
+// expensive changes can be gated by a human.
+package approval
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/infracost/infracost/internal/output"
+	"github.com/infracost/infracost/internal/version"
+)
+
+const (
+	StatusPending  = "pending"
+	StatusApproved = "approved"
+	StatusRejected = "rejected"
+)
+
+// idPlaceholder is replaced by the ID of the request in the callback URL, e.g.
+// https://approvals.example.com/requests/{id}.
+const idPlaceholder = "{id}"
+
+// Request is the payload posted to the webhook.
+type Request struct {
+	ID string `json:"id"`
+	// CallbackURL is the URL the approval decision is polled from, with the ID
+	// of the request.
+	CallbackURL string                      `json:"callbackUrl,omitempty"`
+	Violations  []output.GuardrailViolation `json:"violations"`
+	Diff        output.Root                 `json:"diff"`
+}
+
+// Decision is the response of the callback URL. A 404 response is taken to be
+// pending, e.g. before the webhook's receiver has stored the request.
+type Decision struct {
+	Status   string `json:"status"`
+	Approver string `json:"approver,omitempty"`
+	Comment  string `json:"comment,omitempty"`
+}
+
+// Client posts requests to the webhook and polls the callback URL.
+type Client struct {
+	WebhookURL  string
+	CallbackURL string
+	// Token is sent as a bearer token to the webhook and callback URL.
+	Token        string
+	PollInterval time.Duration
+	Timeout      time.Duration
+
+	httpClient *http.Client
+}
+
+// NewClient returns a Client of the webhook and callback URL.
+func NewClient(webhookURL string, callbackURL string, token string, pollInterval time.Duration, timeout time.Duration) *Client {
+	return &Client{
+		WebhookURL:   webhookURL,
+		CallbackURL:  callbackURL,
+		Token:        token,
+		PollInterval: pollInterval,
+		Timeout:      timeout,
+		httpClient:   &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+// Send posts the diff and guardrail violations of the run to the webhook and
+// returns the request, whose ID identifies it to the callback URL.
+func (c *Client) Send(ctx context.Context, r output.Root) (Request, error) {
+	id := uuid.NewString()
+
+	req := Request{
+		ID:         id,
+		Violations: r.GuardrailViolations,
+		Diff:       r,
+	}
+	if c.CallbackURL != "" {
+		req.CallbackURL = strings.ReplaceAll(c.CallbackURL, idPlaceholder, id)
+	}
+
+	b, err := json.Marshal(req)
+	if err != nil {
+		return req, fmt.Errorf("Error generating approval request: %w", err)
+	}
+
+	resp, err := c.do(ctx, http.MethodPost, c.WebhookURL, b)
+	if err != nil {
+		return req, fmt.Errorf("Error sending approval request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(resp.Body)
+		return req, fmt.Errorf("Approval webhook returned %s: %s", resp.Status, strings.TrimSpace(string(body)))
+	}
+
+	return req, nil
+}
+
+// Wait polls the callback URL of the request until the change is approved or
+// rejected, or the timeout passes.
+func (c *Client) Wait(ctx context.Context, req Request) (Decision, error) {
+	if req.CallbackURL == "" {
+		return Decision{}, fmt.Errorf("No callback URL to wait for the approval of request %s", req.ID)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, c.Timeout)
+	defer cancel()
+
+	for {
+		d, err := c.poll(ctx, req.CallbackURL)
+		if err != nil {
+			log.Debugf("Error polling approval of request %s: %s", req.ID, err)
+		}
+
+		if d.Status == StatusApproved || d.Status == StatusRejected {
+			return d, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return Decision{Status: StatusPending}, fmt.Errorf("Timed out after %s waiting for the approval of request %s", c.Timeout, req.ID)
+		case <-time.After(c.PollInterval):
+		}
+	}
+}
+
+func (c *Client) poll(ctx context.Context, url string) (Decision, error) {
+	d := Decision{Status: StatusPending}
+
+	resp, err := c.do(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return d, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return d, nil
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return d, fmt.Errorf("callback URL returned %s", resp.Status)
+	}
+
+	err = json.NewDecoder(resp.Body).Decode(&d)
+	if err != nil {
+		return Decision{Status: StatusPending}, fmt.Errorf("invalid callback response: %w", err)
+	}
+
+	d.Status = strings.ToLower(d.Status)
+
+	return d, nil
+}
+
+func (c *Client) do(ctx context.Context, method string, url string, body []byte) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, url, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", fmt.Sprintf("infracost-%s", version.Version))
+	if c.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.Token)
+	}
+
+	return c.httpClient.Do(req)
+}
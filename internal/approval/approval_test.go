@@ -0,0 +1,135 @@
+package approval
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/infracost/infracost/internal/output"
+)
+
+type fakeApprover struct {
+	mu        sync.Mutex
+	requests  []Request
+	polls     int
+	decisions []string
+	auth      string
+}
+
+func (f *fakeApprover) handler() http.Handler {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/webhook", func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+
+		f.auth = r.Header.Get("Authorization")
+
+		var req Request
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		f.requests = append(f.requests, req)
+		w.WriteHeader(http.StatusAccepted)
+	})
+
+	mux.HandleFunc("/requests/", func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+
+		if f.polls >= len(f.decisions) {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		status := f.decisions[f.polls]
+		f.polls++
+
+		_ = json.NewEncoder(w).Encode(Decision{Status: status, Approver: "alice", Comment: "budget agreed"})
+	})
+
+	return mux
+}
+
+func testRoot() output.Root {
+	return output.Root{
+		Currency: "USD",
+		GuardrailViolations: []output.GuardrailViolation{
+			{Guardrail: "prod", Scope: "project", ProjectName: "prod", Limit: "max_monthly_cost", Message: "monthly cost $423 is over the guardrail of $100", Blocking: true},
+		},
+	}
+}
+
+func TestSend(t *testing.T) {
+	f := &fakeApprover{}
+	ts := httptest.NewServer(f.handler())
+	defer ts.Close()
+
+	c := NewClient(ts.URL+"/webhook", ts.URL+"/requests/{id}", "secret", time.Millisecond, time.Second)
+
+	req, err := c.Send(context.Background(), testRoot())
+	require.NoError(t, err)
+
+	require.Len(t, f.requests, 1)
+	assert.Equal(t, req.ID, f.requests[0].ID)
+	assert.Equal(t, ts.URL+"/requests/"+req.ID, f.requests[0].CallbackURL)
+	assert.Equal(t, "max_monthly_cost", f.requests[0].Violations[0].Limit)
+	assert.Equal(t, "USD", f.requests[0].Diff.Currency)
+	assert.Equal(t, "Bearer secret", f.auth)
+}
+
+func TestSendError(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte("unavailable"))
+	}))
+	defer ts.Close()
+
+	c := NewClient(ts.URL, "", "", time.Millisecond, time.Second)
+
+	_, err := c.Send(context.Background(), testRoot())
+	assert.EqualError(t, err, "Approval webhook returned 500 Internal Server Error: unavailable")
+}
+
+func TestWait(t *testing.T) {
+	tests := []struct {
+		name      string
+		decisions []string
+		expected  string
+		err       bool
+	}{
+		{name: "approved after pending", decisions: []string{"pending", "APPROVED"}, expected: StatusApproved},
+		{name: "rejected", decisions: []string{"rejected"}, expected: StatusRejected},
+		{name: "timed out", decisions: nil, expected: StatusPending, err: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := &fakeApprover{decisions: tt.decisions}
+			ts := httptest.NewServer(f.handler())
+			defer ts.Close()
+
+			c := NewClient(ts.URL+"/webhook", ts.URL+"/requests/{id}", "", time.Millisecond, 50*time.Millisecond)
+
+			req, err := c.Send(context.Background(), testRoot())
+			require.NoError(t, err)
+
+			d, err := c.Wait(context.Background(), req)
+			if tt.err {
+				assert.Error(t, err)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, "alice", d.Approver)
+			}
+			assert.Equal(t, tt.expected, d.Status)
+		})
+	}
+}
@@ -0,0 +1,447 @@
+// Package awspricelist prices AWS cost components using the AWS Price List Query
+// API directly, as an alternative to the Cloud Pricing API for users who can't
+// send their resource data to a third-party endpoint. Responses are cached on
+// disk since the price list changes infrequently.
+package awspricelist
+
+import (
+	"bytes"
+	"context"
+	"crypto/md5" // nolint:gosec
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"github.com/tidwall/gjson"
+
+	"github.com/infracost/infracost/internal/pricelist"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+const (
+	// DefaultEndpoint is the endpoint of the AWS Price List Query API.
+	DefaultEndpoint = "https://api.pricing.us-east-1.amazonaws.com"
+	// DefaultCacheTTL is how long cached responses are used before they're fetched again.
+	DefaultCacheTTL = 24 * time.Hour
+
+	signingRegion  = "us-east-1"
+	signingService = "pricing"
+	maxResults     = 100
+)
+
+// Client queries the AWS Price List Query API. It's safe for concurrent use.
+type Client struct {
+	Endpoint    string
+	Credentials aws.CredentialsProvider
+	// CacheDir is the directory responses are cached in. Responses are only cached
+	// in memory if it's empty.
+	CacheDir string
+	CacheTTL time.Duration
+
+	cache map[string][]string
+	mu    sync.Mutex
+}
+
+type filter struct {
+	Type  string `json:"Type"`
+	Field string `json:"Field"`
+	Value string `json:"Value"`
+}
+
+type getProductsRequest struct {
+	ServiceCode   string   `json:"ServiceCode"`
+	Filters       []filter `json:"Filters"`
+	FormatVersion string   `json:"FormatVersion"`
+	MaxResults    int      `json:"MaxResults"`
+	NextToken     string   `json:"NextToken,omitempty"`
+}
+
+type getProductsResponse struct {
+	PriceList []string `json:"PriceList"`
+	NextToken string   `json:"NextToken"`
+}
+
+// New returns a client that signs requests with the default AWS credentials,
+// e.g. from the environment or the shared credentials file.
+func New(ctx context.Context, cacheDir string) (*Client, error) {
+	cfg, err := awsconfig.LoadDefaultConfig(ctx, awsconfig.WithRegion(signingRegion))
+	if err != nil {
+		return nil, errors.Wrap(err, "Error loading AWS credentials for the AWS Price List API")
+	}
+
+	return &Client{
+		Endpoint:    DefaultEndpoint,
+		Credentials: cfg.Credentials,
+		CacheDir:    cacheDir,
+		CacheTTL:    DefaultCacheTTL,
+	}, nil
+}
+
+// DefaultCacheDir returns the directory responses are cached in by default.
+func DefaultCacheDir() string {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return ""
+	}
+
+	return filepath.Join(dir, "infracost", "aws-price-list")
+}
+
+// Query finds the products matching the filters and returns their prices in the
+// same format as the Cloud Pricing API GraphQL response so they can be handled
+// the same way. Filters with exact values are sent to the API, regex filters are
+// applied to the products it returns.
+func (c *Client) Query(ctx context.Context, currency string, product *schema.ProductFilter, price *schema.PriceFilter) (gjson.Result, error) {
+	if product == nil || product.Service == nil {
+		return gjson.Result{}, errors.New("AWS Price List API queries need a service")
+	}
+
+	req := getProductsRequest{
+		ServiceCode:   *product.Service,
+		Filters:       apiFilters(product),
+		FormatVersion: "aws_v1",
+		MaxResults:    maxResults,
+	}
+
+	priceList, err := c.getProducts(ctx, req)
+	if err != nil {
+		return gjson.Result{}, err
+	}
+
+	products := make([]interface{}, 0)
+
+	for _, raw := range priceList {
+		p := gjson.Parse(raw)
+
+		if !matchesRegexFilters(product, p) {
+			continue
+		}
+
+		products = append(products, map[string]interface{}{
+			"prices": productPrices(currency, p, price),
+		})
+	}
+
+	b, err := json.Marshal(map[string]interface{}{
+		"data": map[string]interface{}{
+			"products": products,
+		},
+	})
+	if err != nil {
+		return gjson.Result{}, errors.Wrap(err, "Error building AWS Price List API result")
+	}
+
+	return gjson.ParseBytes(b), nil
+}
+
+// apiFilters returns the filters with exact values, the Region is filtered by the
+// regionCode attribute since the API's location attribute is the region name.
+func apiFilters(product *schema.ProductFilter) []filter {
+	filters := make([]filter, 0, len(product.AttributeFilters)+3)
+
+	add := func(field string, value *string) {
+		if value != nil {
+			filters = append(filters, filter{Type: "TERM_MATCH", Field: field, Value: *value})
+		}
+	}
+
+	add("regionCode", product.Region)
+	add("productFamily", product.ProductFamily)
+	add("sku", product.Sku)
+
+	for _, a := range product.AttributeFilters {
+		add(a.Key, a.Value)
+	}
+
+	return filters
+}
+
+func matchesRegexFilters(product *schema.ProductFilter, p gjson.Result) bool {
+	for _, a := range product.AttributeFilters {
+		if a.ValueRegex == nil {
+			continue
+		}
+
+		v := p.Get("product.attributes." + gjsonEscape(a.Key))
+		if !v.Exists() || !pricelist.MatchRegex(*a.ValueRegex, v.String()) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// productPrices returns the price dimensions of the product's terms that match the
+// price filter. On-demand terms map to the on_demand purchase option and reserved
+// terms to the reserved purchase option.
+func productPrices(currency string, p gjson.Result, f *schema.PriceFilter) []map[string]string {
+	prices := make([]map[string]string, 0)
+
+	terms := []struct {
+		termType       string
+		purchaseOption string
+	}{
+		{"OnDemand", "on_demand"},
+		{"Reserved", "reserved"},
+	}
+
+	for _, t := range terms {
+		if f != nil && f.PurchaseOption != nil && *f.PurchaseOption != t.purchaseOption {
+			continue
+		}
+
+		p.Get("terms." + t.termType).ForEach(func(_, term gjson.Result) bool {
+			if !matchesTermAttributes(f, term.Get("termAttributes")) {
+				return true
+			}
+
+			term.Get("priceDimensions").ForEach(func(_, dim gjson.Result) bool {
+				if !matchesPriceDimension(f, dim) {
+					return true
+				}
+
+				v := dim.Get("pricePerUnit." + currency)
+				if !v.Exists() {
+					log.Debugf("No %s price in the AWS Price List API for %s", currency, dim.Get("rateCode").String())
+					return true
+				}
+
+				prices = append(prices, map[string]string{
+					"priceHash": priceHash(dim.Get("rateCode").String()),
+					currency:    v.String(),
+				})
+
+				return true
+			})
+
+			return true
+		})
+	}
+
+	return prices
+}
+
+func matchesTermAttributes(f *schema.PriceFilter, attrs gjson.Result) bool {
+	if f == nil {
+		return true
+	}
+
+	return matchesValue(f.TermLength, attrs.Get("LeaseContractLength").String()) &&
+		matchesValue(f.TermPurchaseOption, attrs.Get("PurchaseOption").String()) &&
+		matchesValue(f.TermOfferingClass, attrs.Get("OfferingClass").String())
+}
+
+func matchesPriceDimension(f *schema.PriceFilter, dim gjson.Result) bool {
+	if f == nil {
+		return true
+	}
+
+	// Unbounded ranges can be filtered by an empty or Inf end usage amount
+	endRange := dim.Get("endRange").String()
+	if endRange == "Inf" && f.EndUsageAmount != nil && *f.EndUsageAmount == "" {
+		endRange = ""
+	}
+
+	if f.DescriptionRegex != nil && !pricelist.MatchRegex(*f.DescriptionRegex, dim.Get("description").String()) {
+		return false
+	}
+
+	return matchesValue(f.Unit, dim.Get("unit").String()) &&
+		matchesValue(f.Description, dim.Get("description").String()) &&
+		matchesValue(f.StartUsageAmount, dim.Get("beginRange").String()) &&
+		matchesValue(f.EndUsageAmount, endRange)
+}
+
+func matchesValue(want *string, v string) bool {
+	return want == nil || *want == v
+}
+
+func priceHash(rateCode string) string {
+	h := md5.Sum([]byte("aws-price-list|" + rateCode)) // nolint:gosec
+	return hex.EncodeToString(h[:])
+}
+
+func gjsonEscape(s string) string {
+	r := strings.NewReplacer(".", `\.`, "*", `\*`, "?", `\?`)
+	return r.Replace(s)
+}
+
+// getProducts returns the price list of all the pages of the request, from the
+// cache if it has been fetched within the cache TTL.
+func (c *Client) getProducts(ctx context.Context, req getProductsRequest) ([]string, error) {
+	key, err := cacheKey(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if priceList, ok := c.getCache(key); ok {
+		return priceList, nil
+	}
+
+	if priceList, ok := c.readCache(key); ok {
+		c.setCache(key, priceList)
+		return priceList, nil
+	}
+
+	var priceList []string
+
+	for {
+		resp, err := c.doRequest(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+
+		priceList = append(priceList, resp.PriceList...)
+
+		if resp.NextToken == "" {
+			break
+		}
+		req.NextToken = resp.NextToken
+	}
+
+	c.setCache(key, priceList)
+	c.writeCache(key, priceList)
+
+	return priceList, nil
+}
+
+func (c *Client) doRequest(ctx context.Context, req getProductsRequest) (*getProductsResponse, error) {
+	body, err := json.Marshal(req)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error generating AWS Price List API request body")
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, "POST", strings.TrimSuffix(c.Endpoint, "/")+"/", bytes.NewReader(body))
+	if err != nil {
+		return nil, errors.Wrap(err, "Error generating AWS Price List API request")
+	}
+
+	httpReq.Header.Set("Content-Type", "application/x-amz-json-1.1")
+	httpReq.Header.Set("X-Amz-Target", "AWSPriceListService.GetProducts")
+
+	creds, err := c.Credentials.Retrieve(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error retrieving AWS credentials for the AWS Price List API")
+	}
+
+	payloadHash := sha256.Sum256(body)
+	err = v4.NewSigner().SignHTTP(ctx, creds, httpReq, hex.EncodeToString(payloadHash[:]), signingService, signingRegion, time.Now())
+	if err != nil {
+		return nil, errors.Wrap(err, "Error signing AWS Price List API request")
+	}
+
+	log.Debugf("Getting products from the AWS Price List API for %s", req.ServiceCode)
+
+	resp, err := http.DefaultClient.Do(httpReq)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error sending AWS Price List API request")
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error reading AWS Price List API response")
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("AWS Price List API request failed: %s %s", resp.Status, strings.TrimSpace(string(respBody)))
+	}
+
+	var r getProductsResponse
+	err = json.Unmarshal(respBody, &r)
+	if err != nil {
+		return nil, errors.Wrap(err, "Invalid response from the AWS Price List API")
+	}
+
+	return &r, nil
+}
+
+func cacheKey(req getProductsRequest) (string, error) {
+	b, err := json.Marshal(req)
+	if err != nil {
+		return "", errors.Wrap(err, "Error generating AWS Price List API cache key")
+	}
+
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+func (c *Client) getCache(key string) ([]string, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	priceList, ok := c.cache[key]
+	return priceList, ok
+}
+
+func (c *Client) setCache(key string, priceList []string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.cache == nil {
+		c.cache = make(map[string][]string)
+	}
+
+	c.cache[key] = priceList
+}
+
+func (c *Client) readCache(key string) ([]string, bool) {
+	if c.CacheDir == "" {
+		return nil, false
+	}
+
+	path := filepath.Join(c.CacheDir, key+".json")
+
+	info, err := os.Stat(path)
+	if err != nil || time.Since(info.ModTime()) > c.CacheTTL {
+		return nil, false
+	}
+
+	b, err := os.ReadFile(path)
+	if err != nil {
+		log.Debugf("Error reading AWS Price List API cache %s: %s", path, err)
+		return nil, false
+	}
+
+	var priceList []string
+	err = json.Unmarshal(b, &priceList)
+	if err != nil {
+		log.Debugf("Error parsing AWS Price List API cache %s: %s", path, err)
+		return nil, false
+	}
+
+	return priceList, true
+}
+
+func (c *Client) writeCache(key string, priceList []string) {
+	if c.CacheDir == "" {
+		return
+	}
+
+	b, err := json.Marshal(priceList)
+	if err != nil {
+		log.Debugf("Error generating AWS Price List API cache: %s", err)
+		return
+	}
+
+	err = os.MkdirAll(c.CacheDir, 0700)
+	if err == nil {
+		err = os.WriteFile(filepath.Join(c.CacheDir, key+".json"), b, 0600)
+	}
+	if err != nil {
+		log.Debugf("Error writing AWS Price List API cache: %s", err)
+	}
+}
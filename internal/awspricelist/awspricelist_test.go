@@ -0,0 +1,171 @@
+package awspricelist
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/infracost/infracost/internal/schema"
+)
+
+func strPtr(s string) *string { return &s }
+
+const m5LargeProduct = `{
+  "product": {
+    "productFamily": "Compute Instance",
+    "attributes": {"instanceType": "m5.large", "operatingSystem": "Linux", "regionCode": "us-east-1"},
+    "sku": "SKU1"
+  },
+  "terms": {
+    "OnDemand": {
+      "SKU1.JRTCKXETXF": {
+        "priceDimensions": {
+          "SKU1.JRTCKXETXF.6YS6EN2CT7": {"rateCode": "SKU1.JRTCKXETXF.6YS6EN2CT7", "unit": "Hrs", "beginRange": "0", "endRange": "Inf", "description": "$0.096 per On Demand Linux m5.large Instance Hour", "pricePerUnit": {"USD": "0.0960000000"}}
+        },
+        "termAttributes": {}
+      }
+    },
+    "Reserved": {
+      "SKU1.4NA7Y494T4": {
+        "priceDimensions": {
+          "SKU1.4NA7Y494T4.6YS6EN2CT7": {"rateCode": "SKU1.4NA7Y494T4.6YS6EN2CT7", "unit": "Hrs", "beginRange": "0", "endRange": "Inf", "description": "Linux/UNIX (Amazon VPC), m5.large reserved instance applied", "pricePerUnit": {"USD": "0.0600000000"}}
+        },
+        "termAttributes": {"LeaseContractLength": "1yr", "OfferingClass": "standard", "PurchaseOption": "No Upfront"}
+      }
+    }
+  }
+}`
+
+const m5XLargeProduct = `{
+  "product": {
+    "productFamily": "Compute Instance",
+    "attributes": {"instanceType": "m5.xlarge", "operatingSystem": "Linux", "regionCode": "us-east-1"},
+    "sku": "SKU2"
+  },
+  "terms": {
+    "OnDemand": {
+      "SKU2.JRTCKXETXF": {
+        "priceDimensions": {
+          "SKU2.JRTCKXETXF.6YS6EN2CT7": {"rateCode": "SKU2.JRTCKXETXF.6YS6EN2CT7", "unit": "Hrs", "beginRange": "0", "endRange": "Inf", "pricePerUnit": {"USD": "0.1920000000"}}
+        },
+        "termAttributes": {}
+      }
+    }
+  }
+}`
+
+func testServer(t *testing.T, requests *int) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*requests++
+
+		assert.Equal(t, "AWSPriceListService.GetProducts", r.Header.Get("X-Amz-Target"))
+		assert.True(t, strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 Credential=AKID/"))
+
+		var req getProductsRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		assert.Equal(t, "AmazonEC2", req.ServiceCode)
+		assert.Contains(t, req.Filters, filter{Type: "TERM_MATCH", Field: "regionCode", Value: "us-east-1"})
+
+		resp := getProductsResponse{PriceList: []string{m5LargeProduct}, NextToken: "page2"}
+		if req.NextToken == "page2" {
+			resp = getProductsResponse{PriceList: []string{m5XLargeProduct}}
+		}
+
+		require.NoError(t, json.NewEncoder(w).Encode(resp))
+	}))
+}
+
+func testClient(endpoint, cacheDir string) *Client {
+	return &Client{
+		Endpoint: endpoint,
+		Credentials: aws.CredentialsProviderFunc(func(ctx context.Context) (aws.Credentials, error) {
+			return aws.Credentials{AccessKeyID: "AKID", SecretAccessKey: "SECRET"}, nil
+		}),
+		CacheDir: cacheDir,
+		CacheTTL: time.Hour,
+	}
+}
+
+func TestQuery(t *testing.T) {
+	requests := 0
+	server := testServer(t, &requests)
+	defer server.Close()
+
+	c := testClient(server.URL, "")
+
+	product := &schema.ProductFilter{
+		VendorName:    strPtr("aws"),
+		Region:        strPtr("us-east-1"),
+		Service:       strPtr("AmazonEC2"),
+		ProductFamily: strPtr("Compute Instance"),
+		AttributeFilters: []*schema.AttributeFilter{
+			{Key: "instanceType", ValueRegex: strPtr("/^m5\\.large$/i")},
+		},
+	}
+
+	res, err := c.Query(context.Background(), "USD", product, &schema.PriceFilter{PurchaseOption: strPtr("on_demand")})
+	require.NoError(t, err)
+
+	prices := res.Get("data.products.#.prices").Array()
+	require.Len(t, prices, 1)
+	assert.Equal(t, "0.0960000000", res.Get("data.products.0.prices.0.USD").String())
+	assert.NotEmpty(t, res.Get("data.products.0.prices.0.priceHash").String())
+
+	res, err = c.Query(context.Background(), "USD", product, &schema.PriceFilter{
+		PurchaseOption:     strPtr("reserved"),
+		TermLength:         strPtr("1yr"),
+		TermPurchaseOption: strPtr("No Upfront"),
+		TermOfferingClass:  strPtr("standard"),
+		StartUsageAmount:   strPtr("0"),
+		EndUsageAmount:     strPtr(""),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "0.0600000000", res.Get("data.products.0.prices.0.USD").String())
+
+	// Both queries have the same API filters, so the second is served from the cache
+	assert.Equal(t, 2, requests)
+}
+
+func TestQueryDiskCache(t *testing.T) {
+	requests := 0
+	server := testServer(t, &requests)
+	defer server.Close()
+
+	cacheDir := t.TempDir()
+	product := &schema.ProductFilter{
+		Region:  strPtr("us-east-1"),
+		Service: strPtr("AmazonEC2"),
+		AttributeFilters: []*schema.AttributeFilter{
+			{Key: "instanceType", Value: strPtr("m5.xlarge")},
+		},
+	}
+
+	_, err := testClient(server.URL, cacheDir).Query(context.Background(), "USD", product, nil)
+	require.NoError(t, err)
+
+	res, err := testClient(server.URL, cacheDir).Query(context.Background(), "USD", product, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "0.1920000000", res.Get("data.products.1.prices.0.USD").String())
+
+	assert.Equal(t, 2, requests)
+}
+
+func TestQueryError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"__type": "InvalidParameterException"}`))
+	}))
+	defer server.Close()
+
+	_, err := testClient(server.URL, "").Query(context.Background(), "USD", &schema.ProductFilter{Service: strPtr("AmazonEC2")}, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "InvalidParameterException")
+}
@@ -0,0 +1,290 @@
+// Package azurepricesheet loads an organization's Azure EA or CSP price sheet,
+// either from an export or from the Azure Consumption API, so its negotiated
+// rates can be used in place of the Azure list prices.
+package azurepricesheet
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+)
+
+// DefaultEndpoint is the Azure Resource Manager endpoint of the Consumption API.
+const DefaultEndpoint = "https://management.azure.com"
+
+const apiVersion = "2019-10-01"
+
+var unitQuantityRegex = regexp.MustCompile(`^\s*([0-9]+(?:\.[0-9]+)?)\s*([KkMm])?\b`)
+
+// Item is the negotiated price of an Azure meter. UnitPrice is the price of the
+// quantity in UnitOfMeasure, e.g. 100 Hours.
+type Item struct {
+	MeterID       string
+	MeterName     string
+	UnitOfMeasure string
+	UnitPrice     decimal.Decimal
+	CurrencyCode  string
+}
+
+// PriceSheet is a list of negotiated prices keyed by meter ID.
+type PriceSheet struct {
+	Currency string
+	items    map[string]*Item
+}
+
+type apiResponse struct {
+	Properties struct {
+		NextLink    string `json:"nextLink"`
+		PriceSheets []struct {
+			MeterID       string          `json:"meterId"`
+			UnitOfMeasure string          `json:"unitOfMeasure"`
+			UnitPrice     decimal.Decimal `json:"unitPrice"`
+			CurrencyCode  string          `json:"currencyCode"`
+			MeterDetails  *struct {
+				MeterName string `json:"meterName"`
+			} `json:"meterDetails"`
+		} `json:"pricesheets"`
+	} `json:"properties"`
+}
+
+// New returns a price sheet of the items. All items must be in the same currency.
+func New(items []*Item) (*PriceSheet, error) {
+	s := &PriceSheet{items: make(map[string]*Item, len(items))}
+
+	for _, i := range items {
+		if i.MeterID == "" {
+			continue
+		}
+
+		if i.CurrencyCode != "" {
+			if s.Currency != "" && !strings.EqualFold(s.Currency, i.CurrencyCode) {
+				return nil, fmt.Errorf("price sheet contains prices in %s and %s, only one currency is supported", s.Currency, i.CurrencyCode)
+			}
+			s.Currency = strings.ToUpper(i.CurrencyCode)
+		}
+
+		s.items[strings.ToLower(i.MeterID)] = i
+	}
+
+	return s, nil
+}
+
+// Load reads a price sheet exported from the Azure portal as CSV, or saved from
+// the Consumption API as JSON, the format is detected from the file extension.
+func Load(path string) (*PriceSheet, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error opening Azure price sheet")
+	}
+	defer f.Close()
+
+	var items []*Item
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".csv":
+		items, err = parseCSV(f)
+	case ".json":
+		var resp apiResponse
+		err = json.NewDecoder(f).Decode(&resp)
+		items = resp.items()
+	default:
+		return nil, fmt.Errorf("Azure price sheet %s must be a .csv or .json file", path)
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "Error parsing Azure price sheet %s", path)
+	}
+
+	return New(items)
+}
+
+// Fetch downloads the price sheet of the subscription's billing period from the
+// Consumption API. An empty billing period fetches the current billing period.
+// The access token can be generated with `az account get-access-token`.
+func Fetch(endpoint, subscriptionID, billingPeriod, accessToken string) (*PriceSheet, error) {
+	path := fmt.Sprintf("/subscriptions/%s/providers/Microsoft.Consumption/pricesheets/default", url.PathEscape(subscriptionID))
+	if billingPeriod != "" {
+		path = fmt.Sprintf("/subscriptions/%s/providers/Microsoft.Billing/billingPeriods/%s/providers/Microsoft.Consumption/pricesheets/default", url.PathEscape(subscriptionID), url.PathEscape(billingPeriod))
+	}
+
+	q := url.Values{}
+	q.Set("api-version", apiVersion)
+	q.Set("$expand", "properties/meterDetails")
+
+	next := strings.TrimSuffix(endpoint, "/") + path + "?" + q.Encode()
+
+	var items []*Item
+
+	for next != "" {
+		resp, err := fetchPage(next, accessToken)
+		if err != nil {
+			return nil, err
+		}
+
+		items = append(items, resp.items()...)
+		next = resp.Properties.NextLink
+	}
+
+	return New(items)
+}
+
+func fetchPage(u, accessToken string) (*apiResponse, error) {
+	req, err := http.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error generating Azure price sheet request")
+	}
+
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error fetching Azure price sheet")
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("Error fetching Azure price sheet: %s %s", resp.Status, strings.TrimSpace(string(body)))
+	}
+
+	var r apiResponse
+	err = json.NewDecoder(resp.Body).Decode(&r)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error parsing Azure price sheet response")
+	}
+
+	return &r, nil
+}
+
+func (r apiResponse) items() []*Item {
+	items := make([]*Item, 0, len(r.Properties.PriceSheets))
+
+	for _, p := range r.Properties.PriceSheets {
+		i := &Item{
+			MeterID:       p.MeterID,
+			UnitOfMeasure: p.UnitOfMeasure,
+			UnitPrice:     p.UnitPrice,
+			CurrencyCode:  p.CurrencyCode,
+		}
+		if p.MeterDetails != nil {
+			i.MeterName = p.MeterDetails.MeterName
+		}
+
+		items = append(items, i)
+	}
+
+	return items
+}
+
+// parseCSV parses a price sheet export. Column names are matched ignoring case
+// and spaces, so both "Meter ID" and "meterId" headers are supported.
+func parseCSV(r io.Reader) ([]*Item, error) {
+	records, err := csv.NewReader(r).ReadAll()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(records) == 0 {
+		return nil, nil
+	}
+
+	cols := make(map[string]int, len(records[0]))
+	for i, h := range records[0] {
+		cols[strings.ToLower(strings.ReplaceAll(strings.TrimSpace(h), " ", ""))] = i
+	}
+
+	for _, h := range []string{"meterid", "unitofmeasure", "unitprice"} {
+		if _, ok := cols[h]; !ok {
+			return nil, fmt.Errorf("missing %s column", h)
+		}
+	}
+
+	get := func(rec []string, col string) string {
+		i, ok := cols[col]
+		if !ok || i >= len(rec) {
+			return ""
+		}
+		return strings.TrimSpace(rec[i])
+	}
+
+	items := make([]*Item, 0, len(records)-1)
+
+	for i, rec := range records[1:] {
+		price, err := decimal.NewFromString(get(rec, "unitprice"))
+		if err != nil {
+			return nil, fmt.Errorf("invalid unit price on line %d: %w", i+2, err)
+		}
+
+		currency := get(rec, "currencycode")
+		if currency == "" {
+			currency = get(rec, "currency")
+		}
+
+		items = append(items, &Item{
+			MeterID:       get(rec, "meterid"),
+			MeterName:     get(rec, "metername"),
+			UnitOfMeasure: get(rec, "unitofmeasure"),
+			UnitPrice:     price,
+			CurrencyCode:  currency,
+		})
+	}
+
+	return items, nil
+}
+
+// Get returns the price sheet item of the meter.
+func (s *PriceSheet) Get(meterID string) (*Item, bool) {
+	if s == nil {
+		return nil, false
+	}
+
+	i, ok := s.items[strings.ToLower(meterID)]
+	return i, ok
+}
+
+// Len returns the number of items in the price sheet.
+func (s *PriceSheet) Len() int {
+	if s == nil {
+		return 0
+	}
+
+	return len(s.items)
+}
+
+// PriceFor returns the price of the quantity in unit, e.g. the price sheet price
+// of 100 Hours is divided by 100 for a unit of 1 Hour.
+func (i *Item) PriceFor(unit string) decimal.Decimal {
+	return i.UnitPrice.Div(unitQuantity(i.UnitOfMeasure)).Mul(unitQuantity(unit))
+}
+
+// unitQuantity returns the quantity a unit of measure is priced for, e.g. 100
+// for 100 Hours and 10000 for 10K. Units without a quantity are priced for 1.
+func unitQuantity(unit string) decimal.Decimal {
+	m := unitQuantityRegex.FindStringSubmatch(unit)
+	if m == nil {
+		return decimal.NewFromInt(1)
+	}
+
+	q, err := decimal.NewFromString(m[1])
+	if err != nil || q.IsZero() {
+		return decimal.NewFromInt(1)
+	}
+
+	switch strings.ToUpper(m[2]) {
+	case "K":
+		q = q.Mul(decimal.NewFromInt(1000))
+	case "M":
+		q = q.Mul(decimal.NewFromInt(1000000))
+	}
+
+	return q
+}
@@ -0,0 +1,131 @@
+package azurepricesheet
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeFile(t *testing.T, name, content string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0600))
+
+	return path
+}
+
+func TestLoadCSV(t *testing.T) {
+	path := writeFile(t, "pricesheet.csv", `Meter ID,Meter name,Unit of measure,Unit price,Currency code
+D1A0E8C3-1234-4F1B-9D3A-000000000001,D2s v3,100 Hours,8.1,USD
+d1a0e8c3-1234-4f1b-9d3a-000000000002,LRS Data Stored,1 GB/Month,0.018,USD
+`)
+
+	s, err := Load(path)
+	require.NoError(t, err)
+	assert.Equal(t, 2, s.Len())
+	assert.Equal(t, "USD", s.Currency)
+
+	i, ok := s.Get("d1a0e8c3-1234-4f1b-9d3a-000000000001")
+	require.True(t, ok)
+	assert.Equal(t, "D2s v3", i.MeterName)
+	assert.Equal(t, "0.081", i.PriceFor("1 Hour").String())
+}
+
+func TestLoadCSVMissingColumn(t *testing.T) {
+	path := writeFile(t, "pricesheet.csv", "meterId,unitPrice\nabc,1\n")
+
+	_, err := Load(path)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "missing unitofmeasure column")
+}
+
+func TestLoadMixedCurrencies(t *testing.T) {
+	path := writeFile(t, "pricesheet.csv", `meterId,unitOfMeasure,unitPrice,currencyCode
+a,1 Hour,1,USD
+b,1 Hour,1,EUR
+`)
+
+	_, err := Load(path)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "only one currency is supported")
+}
+
+func TestLoadJSON(t *testing.T) {
+	path := writeFile(t, "pricesheet.json", `{
+  "properties": {
+    "pricesheets": [
+      {"meterId": "abc", "unitOfMeasure": "10K", "unitPrice": 0.004, "currencyCode": "EUR", "meterDetails": {"meterName": "Read Operations"}}
+    ]
+  }
+}`)
+
+	s, err := Load(path)
+	require.NoError(t, err)
+	assert.Equal(t, "EUR", s.Currency)
+
+	i, ok := s.Get("ABC")
+	require.True(t, ok)
+	assert.Equal(t, "Read Operations", i.MeterName)
+	assert.Equal(t, "0.0004", i.PriceFor("1K").String())
+}
+
+func TestFetch(t *testing.T) {
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+		assert.Equal(t, apiVersion, r.URL.Query().Get("api-version"))
+
+		if r.URL.Query().Get("skiptoken") == "" {
+			assert.Equal(t, "/subscriptions/sub/providers/Microsoft.Billing/billingPeriods/202201/providers/Microsoft.Consumption/pricesheets/default", r.URL.Path)
+			fmt.Fprintf(w, `{"properties": {"nextLink": "%s%s?api-version=%s&skiptoken=1", "pricesheets": [{"meterId": "a", "unitOfMeasure": "1 Hour", "unitPrice": 1, "currencyCode": "USD"}]}}`, server.URL, r.URL.Path, apiVersion)
+			return
+		}
+
+		fmt.Fprint(w, `{"properties": {"pricesheets": [{"meterId": "b", "unitOfMeasure": "1 Hour", "unitPrice": 2, "currencyCode": "USD"}]}}`)
+	}))
+	defer server.Close()
+
+	s, err := Fetch(server.URL, "sub", "202201", "token")
+	require.NoError(t, err)
+	assert.Equal(t, 2, s.Len())
+
+	_, ok := s.Get("b")
+	assert.True(t, ok)
+}
+
+func TestFetchError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		fmt.Fprint(w, `{"error": {"code": "AuthorizationFailed"}}`)
+	}))
+	defer server.Close()
+
+	_, err := Fetch(server.URL, "sub", "", "token")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "403 Forbidden")
+}
+
+func TestUnitQuantity(t *testing.T) {
+	tests := []struct {
+		unit     string
+		expected string
+	}{
+		{"1 Hour", "1"},
+		{"100 Hours", "100"},
+		{"10K", "10000"},
+		{"1 GB/Month", "1"},
+		{"1M", "1000000"},
+		{"Hours", "1"},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, unitQuantity(tt.unit).String(), tt.unit)
+	}
+}
@@ -0,0 +1,215 @@
+// Package baseline saves the Infracost JSON output of an approved state, e.g. what's
+// deployed to prod, so later runs can be diffed against it instead of
+// regenerating the estimate of another branch.
+package baseline
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/infracost/infracost/internal/output"
+	awsusage "github.com/infracost/infracost/internal/usage/aws"
+	googleusage "github.com/infracost/infracost/internal/usage/google"
+)
+
+// DefaultDir is where baselines are saved by name, relative to the directory
+// infracost is run from.
+var DefaultDir = filepath.Join(".infracost", "baselines")
+
+var nameRegex = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]*$`)
+
+var remoteSchemes = []string{"http://", "https://", "s3://", "gs://"}
+
+var httpClient = &http.Client{Timeout: 30 * time.Second}
+
+// ValidateName returns an error if the name can't be used as a baseline name.
+func ValidateName(name string) error {
+	if !nameRegex.MatchString(name) {
+		return fmt.Errorf("Invalid baseline name %q, it can only contain letters, numbers, '.', '_' and '-'", name)
+	}
+
+	return nil
+}
+
+// Path returns the path of the baseline with the name in DefaultDir.
+func Path(name string) string {
+	return filepath.Join(DefaultDir, name+".json")
+}
+
+// IsRemote returns true if ref is the URL of a baseline rather than a name or
+// local path.
+func IsRemote(ref string) bool {
+	for _, scheme := range remoteSchemes {
+		if strings.HasPrefix(ref, scheme) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Save saves the Infracost JSON output as the baseline with the name, or to dest
+// if it's set. dest can be a local path or an HTTP(S), s3:// or gs:// URL, HTTP
+// URLs are uploaded with a PUT request. It returns where the baseline was saved.
+func Save(name string, dest string, data []byte) (string, error) {
+	if dest == "" {
+		err := ValidateName(name)
+		if err != nil {
+			return "", err
+		}
+
+		dest = Path(name)
+	}
+
+	if !IsRemote(dest) {
+		err := os.MkdirAll(filepath.Dir(dest), 0755)
+		if err != nil {
+			return "", errors.Wrap(err, "Error creating baseline directory")
+		}
+
+		err = os.WriteFile(dest, data, 0600)
+		if err != nil {
+			return "", errors.Wrap(err, "Error saving baseline")
+		}
+
+		return dest, nil
+	}
+
+	u, err := url.Parse(dest)
+	if err != nil {
+		return "", errors.Wrap(err, "Invalid baseline URL")
+	}
+
+	ctx := context.Background()
+	key := strings.TrimPrefix(u.Path, "/")
+
+	switch u.Scheme {
+	case "s3":
+		err = awsusage.S3PutObject(ctx, "", u.Host, key, data)
+	case "gs":
+		err = googleusage.StoragePutObject(ctx, u.Host, key, data)
+	default:
+		err = putHTTP(ctx, dest, data)
+	}
+
+	if err != nil {
+		return "", errors.Wrapf(err, "Error uploading baseline to %s", dest)
+	}
+
+	return dest, nil
+}
+
+func putHTTP(ctx context.Context, dest string, data []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, dest, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status %s", resp.Status)
+	}
+
+	return nil
+}
+
+// Load loads the baseline ref refers to: the name of a baseline saved in
+// DefaultDir, the path to a JSON file or an HTTP(S), s3:// or gs:// URL.
+func Load(ref string) (output.Root, error) {
+	data, err := read(ref)
+	if err != nil {
+		return output.Root{}, err
+	}
+
+	// Baselines can be long-lived, so they're loaded even if they were saved by
+	// another version of the CLI.
+	root, notes, err := output.LoadCompatible(data)
+	if err != nil {
+		return output.Root{}, errors.Wrapf(err, "Error parsing baseline %s, it must be the JSON output of Infracost", ref)
+	}
+
+	for _, note := range notes {
+		log.Infof("Baseline %s: %s", ref, note)
+	}
+
+	return root, nil
+}
+
+func read(ref string) ([]byte, error) {
+	if IsRemote(ref) {
+		data, err := readRemote(ref)
+		if err != nil {
+			return nil, errors.Wrapf(err, "Error downloading baseline %s", ref)
+		}
+
+		return data, nil
+	}
+
+	path := ref
+	if ValidateName(ref) == nil && !strings.HasSuffix(ref, ".json") {
+		path = Path(ref)
+	}
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) && path != ref {
+		return nil, fmt.Errorf("Baseline %s not found at %s, save it with 'infracost baseline save --name %s'", ref, path, ref)
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "Error reading baseline %s", ref)
+	}
+
+	return data, nil
+}
+
+func readRemote(ref string) ([]byte, error) {
+	u, err := url.Parse(ref)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx := context.Background()
+	key := strings.TrimPrefix(u.Path, "/")
+
+	switch u.Scheme {
+	case "s3":
+		return awsusage.S3GetObject(ctx, "", u.Host, key)
+	case "gs":
+		return googleusage.StorageGetObject(ctx, u.Host, key)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, ref, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %s", resp.Status)
+	}
+
+	return io.ReadAll(resp.Body)
+}
@@ -0,0 +1,81 @@
+package baseline
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testOutput = `{"version":"0.2","currency":"USD","projects":[{"name":"infracost/infracost/examples/terraform","breakdown":{"resources":[],"totalMonthlyCost":"10"}}],"totalMonthlyCost":"10"}`
+
+func TestValidateName(t *testing.T) {
+	assert.NoError(t, ValidateName("prod"))
+	assert.NoError(t, ValidateName("prod-eu_1.2"))
+	assert.Error(t, ValidateName(""))
+	assert.Error(t, ValidateName("../prod"))
+	assert.Error(t, ValidateName("prod/eu"))
+}
+
+func TestSaveAndLoadByName(t *testing.T) {
+	tmp := t.TempDir()
+	defaultDir := DefaultDir
+	DefaultDir = filepath.Join(tmp, "baselines")
+	defer func() { DefaultDir = defaultDir }()
+
+	location, err := Save("prod", "", []byte(testOutput))
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(tmp, "baselines", "prod.json"), location)
+
+	root, err := Load("prod")
+	require.NoError(t, err)
+	require.Len(t, root.Projects, 1)
+	assert.Equal(t, "10", root.TotalMonthlyCost.String())
+
+	root, err = Load(location)
+	require.NoError(t, err)
+	assert.Equal(t, "infracost/infracost/examples/terraform", root.Projects[0].Name)
+
+	_, err = Load("staging")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "infracost baseline save --name staging")
+}
+
+func TestSaveAndLoadURL(t *testing.T) {
+	var stored []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPut:
+			stored, _ = io.ReadAll(r.Body)
+			w.WriteHeader(http.StatusCreated)
+		case http.MethodGet:
+			_, _ = w.Write(stored)
+		}
+	}))
+	defer server.Close()
+
+	url := server.URL + "/baselines/prod.json"
+
+	location, err := Save("", url, []byte(testOutput))
+	require.NoError(t, err)
+	assert.Equal(t, url, location)
+	assert.Equal(t, testOutput, string(stored))
+
+	root, err := Load(url)
+	require.NoError(t, err)
+	assert.Equal(t, "USD", root.Currency)
+}
+
+func TestLoadInvalid(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "invalid.json")
+	require.NoError(t, os.WriteFile(path, []byte("not json"), 0600))
+
+	_, err := Load(path)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "it must be the JSON output of Infracost")
+}
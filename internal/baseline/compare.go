@@ -0,0 +1,157 @@
+package baseline
+
+import (
+	"github.com/shopspring/decimal"
+
+	"github.com/infracost/infracost/internal/output"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+// Apply replaces the past resources of each project with the resources of the
+// matching project of the baseline, so the diff is between the baseline and the
+// project's planned state. Projects are matched by name, then by path. Projects
+// that aren't in the baseline are diffed against no resources, i.e. everything
+// is added. It returns the names of those projects.
+func Apply(base output.Root, projects []*schema.Project) []string {
+	var unmatched []string
+
+	for _, project := range projects {
+		var past []*schema.Resource
+
+		baseProject := findProject(base, project)
+		if baseProject == nil {
+			unmatched = append(unmatched, project.Name)
+		} else if baseProject.Breakdown != nil {
+			past = schemaResources(baseProject.Breakdown.Resources, unitMultipliers(project.Resources))
+		}
+
+		project.PastResources = past
+		project.HasDiff = true
+		project.CalculateDiff()
+	}
+
+	return unmatched
+}
+
+// Projects returns the projects of the output with their resources, so two
+// outputs can be diffed with Apply without running either of them. The
+// quantities and prices are kept in the units of the output.
+func Projects(r output.Root) []*schema.Project {
+	projects := make([]*schema.Project, 0, len(r.Projects))
+
+	for _, p := range r.Projects {
+		project := &schema.Project{
+			Name:     p.Name,
+			Metadata: p.Metadata,
+			Currency: p.Currency,
+		}
+
+		if p.Breakdown != nil {
+			project.Resources = schemaResources(p.Breakdown.Resources, nil)
+		}
+
+		projects = append(projects, project)
+	}
+
+	return projects
+}
+
+func findProject(base output.Root, project *schema.Project) *output.Project {
+	for i := range base.Projects {
+		if base.Projects[i].Name == project.Name {
+			return &base.Projects[i]
+		}
+	}
+
+	if project.Metadata == nil {
+		return nil
+	}
+
+	for i := range base.Projects {
+		p := &base.Projects[i]
+		if p.Metadata != nil && p.Metadata.Path == project.Metadata.Path && p.Metadata.TerraformWorkspace == project.Metadata.TerraformWorkspace {
+			return p
+		}
+	}
+
+	return nil
+}
+
+// unitMultipliers returns the unit multipliers of the cost components of the
+// resources by resource and cost component name. The quantities and prices of
+// the JSON output are in the units of the multiplier, so the baseline's are
+// converted back with the multiplier of the same cost component in the project.
+func unitMultipliers(resources []*schema.Resource) map[string]decimal.Decimal {
+	m := map[string]decimal.Decimal{}
+
+	var add func(prefix string, resources []*schema.Resource)
+	add = func(prefix string, resources []*schema.Resource) {
+		for _, r := range resources {
+			key := prefix + r.Name
+			for _, c := range r.CostComponents {
+				m[key+"\x00"+c.Name] = c.UnitMultiplier
+			}
+
+			add(key+".", r.SubResources)
+		}
+	}
+	add("", resources)
+
+	return m
+}
+
+func schemaResources(resources []output.Resource, multipliers map[string]decimal.Decimal) []*schema.Resource {
+	var add func(prefix string, resources []output.Resource) []*schema.Resource
+	add = func(prefix string, resources []output.Resource) []*schema.Resource {
+		result := make([]*schema.Resource, 0, len(resources))
+
+		for _, r := range resources {
+			key := prefix + r.Name
+
+			s := &schema.Resource{
+				Name:        r.Name,
+				Tags:        r.Tags,
+				HourlyCost:  r.HourlyCost,
+				MonthlyCost: r.MonthlyCost,
+			}
+
+			for _, c := range r.CostComponents {
+				multiplier, ok := multipliers[key+"\x00"+c.Name]
+				if !ok || multiplier.IsZero() {
+					multiplier = decimal.NewFromInt(1)
+				}
+
+				cc := &schema.CostComponent{
+					Name:             c.Name,
+					Unit:             c.Unit,
+					UnitMultiplier:   multiplier,
+					HourlyQuantity:   mulDecimal(c.HourlyQuantity, multiplier),
+					MonthlyQuantity:  mulDecimal(c.MonthlyQuantity, multiplier),
+					HourlyCost:       c.HourlyCost,
+					MonthlyCost:      c.MonthlyCost,
+					PriceUnavailable: c.PriceUnavailable,
+					PriceIssue:       c.PriceIssue,
+				}
+				cc.SetPrice(c.Price.Div(multiplier))
+
+				s.CostComponents = append(s.CostComponents, cc)
+			}
+
+			s.SubResources = add(key+".", r.SubResources)
+			result = append(result, s)
+		}
+
+		return result
+	}
+
+	return add("", resources)
+}
+
+func mulDecimal(d *decimal.Decimal, m decimal.Decimal) *decimal.Decimal {
+	if d == nil {
+		return nil
+	}
+
+	v := d.Mul(m)
+	return &v
+}
@@ -0,0 +1,139 @@
+package baseline
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/infracost/infracost/internal/output"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+func decimalPtr(v string) *decimal.Decimal {
+	d := decimal.RequireFromString(v)
+	return &d
+}
+
+func TestApply(t *testing.T) {
+	base := output.Root{
+		Projects: []output.Project{
+			{
+				Name:     "prod",
+				Metadata: &schema.ProjectMetadata{Path: "prod"},
+				Breakdown: &output.Breakdown{
+					Resources: []output.Resource{
+						{
+							Name:        "aws_instance.web",
+							MonthlyCost: decimalPtr("60.74"),
+							CostComponents: []output.CostComponent{
+								{
+									Name:            "Instance usage (Linux/UNIX, on-demand, t3.medium)",
+									Unit:            "hours",
+									MonthlyQuantity: decimalPtr("730"),
+									Price:           decimal.RequireFromString("0.0832"),
+									MonthlyCost:     decimalPtr("60.74"),
+								},
+							},
+						},
+						{
+							Name:        "aws_s3_bucket.logs",
+							MonthlyCost: decimalPtr("2.3"),
+							CostComponents: []output.CostComponent{
+								{
+									Name:            "Storage",
+									Unit:            "GB",
+									MonthlyQuantity: decimalPtr("100"),
+									Price:           decimal.RequireFromString("0.023"),
+									MonthlyCost:     decimalPtr("2.3"),
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	webCost := &schema.CostComponent{
+		Name:            "Instance usage (Linux/UNIX, on-demand, t3.large)",
+		Unit:            "hours",
+		UnitMultiplier:  decimal.NewFromInt(1),
+		MonthlyQuantity: decimalPtr("730"),
+		MonthlyCost:     decimalPtr("121.47"),
+	}
+	webCost.SetPrice(decimal.RequireFromString("0.1664"))
+
+	prod := &schema.Project{
+		Name:     "prod",
+		Metadata: &schema.ProjectMetadata{Path: "prod"},
+		PastResources: []*schema.Resource{
+			{Name: "aws_instance.old", MonthlyCost: decimalPtr("1")},
+		},
+		Resources: []*schema.Resource{
+			{
+				Name:           "aws_instance.web",
+				MonthlyCost:    decimalPtr("121.47"),
+				CostComponents: []*schema.CostComponent{webCost},
+			},
+		},
+	}
+	staging := &schema.Project{
+		Name:     "staging",
+		Metadata: &schema.ProjectMetadata{Path: "staging"},
+		Resources: []*schema.Resource{
+			{Name: "aws_instance.web", MonthlyCost: decimalPtr("10")},
+		},
+	}
+
+	unmatched := Apply(base, []*schema.Project{prod, staging})
+	assert.Equal(t, []string{"staging"}, unmatched)
+
+	require.Len(t, prod.PastResources, 2)
+	assert.Equal(t, "aws_instance.web", prod.PastResources[0].Name)
+
+	require.Len(t, prod.Diff, 2)
+	assert.Equal(t, "aws_instance.web", prod.Diff[0].Name)
+	assert.Equal(t, "60.73", prod.Diff[0].MonthlyCost.String())
+	assert.Equal(t, "Instance usage (Linux/UNIX, on-demand, t3.medium → t3.large)", prod.Diff[0].CostComponents[0].Name)
+	assert.Equal(t, "aws_s3_bucket.logs", prod.Diff[1].Name)
+	assert.Equal(t, "-2.3", prod.Diff[1].MonthlyCost.String())
+
+	require.Len(t, staging.Diff, 1)
+	assert.Equal(t, "10", staging.Diff[0].MonthlyCost.String())
+}
+
+func TestProjects(t *testing.T) {
+	r := output.Root{
+		Projects: []output.Project{
+			{
+				Name:     "prod",
+				Metadata: &schema.ProjectMetadata{Path: "prod"},
+				Breakdown: &output.Breakdown{
+					Resources: []output.Resource{
+						{
+							Name:        "aws_s3_bucket.logs",
+							MonthlyCost: decimalPtr("2.3"),
+							CostComponents: []output.CostComponent{
+								{Name: "Storage", Unit: "GB", MonthlyQuantity: decimalPtr("100"), Price: decimal.RequireFromString("0.023"), MonthlyCost: decimalPtr("2.3")},
+							},
+						},
+					},
+				},
+			},
+			{Name: "dev"},
+		},
+	}
+
+	projects := Projects(r)
+	require.Len(t, projects, 2)
+
+	assert.Equal(t, "prod", projects[0].Name)
+	require.Len(t, projects[0].Resources, 1)
+	c := projects[0].Resources[0].CostComponents[0]
+	assert.Equal(t, "100", c.MonthlyQuantity.String())
+	assert.Equal(t, "0.023", c.Price().String())
+
+	assert.Empty(t, projects[1].Resources)
+}
@@ -0,0 +1,222 @@
+// Package bestpractice checks the resources of projects for well-known savings
+// opportunities, e.g. gp2 volumes that would be cheaper as gp3, and estimates the
+// monthly savings of each. The savings are estimates since actual prices vary by
+// region.
+package bestpractice
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/infracost/infracost/internal/output"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+// check finds the savings opportunities of a resource of a project.
+type check struct {
+	ID  string
+	Run func(p *schema.Project, r *schema.Resource) []finding
+}
+
+type finding struct {
+	message string
+	savings decimal.Decimal
+}
+
+// checks are the best practice checks, in the order their findings for the same
+// resource are listed.
+var checks = []check{
+	{ID: "aws_gp2_to_gp3", Run: checkGP2Volumes},
+	{ID: "aws_idle_nat_gateway", Run: checkIdleNATGateway},
+	{ID: "aws_unattached_eip", Run: checkUnattachedEIP},
+	{ID: "aws_previous_generation_instance", Run: checkPreviousGenerationInstances},
+}
+
+// gp3Discount is how much cheaper gp3 storage is than gp2, $0.08 vs $0.10 per
+// GB-month in most regions.
+var gp3Discount = decimal.NewFromFloat(0.2)
+
+// gp2ResourceTypes are the resources whose gp2 volumes are EBS volumes, gp3
+// storage of RDS databases costs the same as gp2.
+var gp2ResourceTypes = map[string]bool{
+	"aws_ebs_volume":           true,
+	"aws_instance":             true,
+	"aws_launch_configuration": true,
+	"aws_launch_template":      true,
+	"aws_autoscaling_group":    true,
+	"aws_eks_node_group":       true,
+}
+
+// currentGenerations are the current generation instance families that replace
+// previous generation ones, with how much cheaper their on-demand prices are.
+var currentGenerations = map[string]struct {
+	family   string
+	discount decimal.Decimal
+}{
+	"t2": {"t3", decimal.NewFromFloat(0.1)},
+	"m3": {"m5", decimal.NewFromFloat(0.27)},
+	"m4": {"m5", decimal.NewFromFloat(0.04)},
+	"c3": {"c5", decimal.NewFromFloat(0.19)},
+	"c4": {"c5", decimal.NewFromFloat(0.15)},
+	"r3": {"r5", decimal.NewFromFloat(0.24)},
+	"r4": {"r5", decimal.NewFromFloat(0.05)},
+	"i2": {"i3", decimal.NewFromFloat(0.63)},
+}
+
+var (
+	costComponentDetailsRegex = regexp.MustCompile(`\(([^()]*)\)$`)
+	instanceTypeRegex         = regexp.MustCompile(`^(db\.)?([a-z][a-z0-9]*)\.([a-z0-9]+)$`)
+)
+
+// Check runs the best practice checks on the resources of the projects and
+// returns the recommendations, largest savings first. The savings of projects
+// priced in their own currency are converted to the currency of the report with
+// the rates keyed by project currency.
+func Check(projects []*schema.Project, rates map[string]decimal.Decimal) []output.Recommendation {
+	var recs []output.Recommendation
+
+	for _, p := range projects {
+		rate := decimal.NewFromInt(1)
+		if r, ok := rates[strings.ToUpper(p.Currency)]; ok && p.Currency != "" {
+			rate = r
+		}
+
+		for _, r := range p.Resources {
+			if r.IsSkipped {
+				continue
+			}
+
+			for _, c := range checks {
+				for _, f := range c.Run(p, r) {
+					if !f.savings.IsPositive() {
+						continue
+					}
+
+					recs = append(recs, output.Recommendation{
+						Check:                   c.ID,
+						ProjectName:             p.Name,
+						Address:                 r.Name,
+						Message:                 f.message,
+						EstimatedMonthlySavings: f.savings.Mul(rate).Round(2),
+					})
+				}
+			}
+		}
+	}
+
+	return output.SortRecommendations(recs)
+}
+
+func checkGP2Volumes(p *schema.Project, r *schema.Resource) []finding {
+	if !gp2ResourceTypes[r.ResourceType] {
+		return nil
+	}
+
+	cost := decimal.Zero
+	for _, c := range allCostComponents(r) {
+		if strings.Contains(c.Name, "gp2") && c.MonthlyCost != nil {
+			cost = cost.Add(*c.MonthlyCost)
+		}
+	}
+
+	return []finding{{
+		message: "Use gp3 volumes instead of gp2, gp3 storage is 20% cheaper with the same baseline performance",
+		savings: cost.Mul(gp3Discount),
+	}}
+}
+
+// checkIdleNATGateway finds NAT gateways whose usage says they process no data,
+// so they only add their hourly cost.
+func checkIdleNATGateway(p *schema.Project, r *schema.Resource) []finding {
+	if r.ResourceType != "aws_nat_gateway" || r.MonthlyCost == nil {
+		return nil
+	}
+
+	for _, c := range r.CostComponents {
+		if c.Name == "Data processed" && c.MonthlyQuantity != nil && c.MonthlyQuantity.IsZero() {
+			return []finding{{
+				message: "NAT gateway processes no data, remove it if it's unused",
+				savings: *r.MonthlyCost,
+			}}
+		}
+	}
+
+	return nil
+}
+
+// checkUnattachedEIP finds Elastic IPs that aren't attached to an instance or
+// network interface, which are charged for while unused. The attributes of
+// aws_eip_association resources aren't parsed since they're free, so projects
+// with any aren't checked.
+func checkUnattachedEIP(p *schema.Project, r *schema.Resource) []finding {
+	if r.ResourceType != "aws_eip" || r.MonthlyCost == nil {
+		return nil
+	}
+
+	for _, other := range p.Resources {
+		if other.ResourceType == "aws_eip_association" {
+			return nil
+		}
+	}
+
+	return []finding{{
+		message: "Elastic IP is not attached to an instance or network interface, release it if it's unused",
+		savings: *r.MonthlyCost,
+	}}
+}
+
+// checkPreviousGenerationInstances finds instances and databases of previous
+// generation instance families, whose current generation replacements are
+// cheaper and faster.
+func checkPreviousGenerationInstances(p *schema.Project, r *schema.Resource) []finding {
+	if !strings.HasPrefix(r.ResourceType, "aws_") {
+		return nil
+	}
+
+	var findings []finding
+
+	for _, c := range allCostComponents(r) {
+		if c.MonthlyCost == nil || !(strings.HasPrefix(c.Name, "Instance usage") || strings.HasPrefix(c.Name, "Database instance")) {
+			continue
+		}
+
+		m := costComponentDetailsRegex.FindStringSubmatch(c.Name)
+		if m == nil {
+			continue
+		}
+
+		for _, detail := range strings.Split(m[1], ",") {
+			t := instanceTypeRegex.FindStringSubmatch(strings.TrimSpace(detail))
+			if t == nil {
+				continue
+			}
+
+			next, ok := currentGenerations[t[2]]
+			if !ok {
+				continue
+			}
+
+			instanceType := strings.TrimSpace(detail)
+			nextType := fmt.Sprintf("%s%s.%s", t[1], next.family, t[3])
+
+			findings = append(findings, finding{
+				message: fmt.Sprintf("%s is a previous generation instance type, %s is about %s%% cheaper", instanceType, nextType, next.discount.Mul(decimal.NewFromInt(100)).String()),
+				savings: c.MonthlyCost.Mul(next.discount),
+			})
+		}
+	}
+
+	return findings
+}
+
+func allCostComponents(r *schema.Resource) []*schema.CostComponent {
+	components := append([]*schema.CostComponent{}, r.CostComponents...)
+	for _, s := range r.SubResources {
+		components = append(components, allCostComponents(s)...)
+	}
+
+	return components
+}
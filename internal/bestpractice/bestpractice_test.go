@@ -0,0 +1,142 @@
+package bestpractice
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/infracost/infracost/internal/schema"
+)
+
+func costComponent(name string, monthlyCost float64) *schema.CostComponent {
+	return &schema.CostComponent{Name: name, MonthlyCost: decimalPtr(decimal.NewFromFloat(monthlyCost))}
+}
+
+func decimalPtr(d decimal.Decimal) *decimal.Decimal {
+	return &d
+}
+
+func TestCheck(t *testing.T) {
+	project := &schema.Project{
+		Name: "infracost/repo",
+		Resources: []*schema.Resource{
+			{
+				Name:         "aws_ebs_volume.data",
+				ResourceType: "aws_ebs_volume",
+				MonthlyCost:  decimalPtr(decimal.NewFromInt(100)),
+				CostComponents: []*schema.CostComponent{
+					costComponent("Storage (general purpose SSD, gp2)", 100),
+				},
+			},
+			{
+				Name:         "aws_instance.web",
+				ResourceType: "aws_instance",
+				MonthlyCost:  decimalPtr(decimal.NewFromInt(80)),
+				CostComponents: []*schema.CostComponent{
+					costComponent("Instance usage (Linux/UNIX, on-demand, m4.large)", 75),
+				},
+				SubResources: []*schema.Resource{
+					{
+						Name:           "root_block_device",
+						CostComponents: []*schema.CostComponent{costComponent("Storage (general purpose SSD, gp3)", 5)},
+					},
+				},
+			},
+			{
+				Name:         "aws_db_instance.db",
+				ResourceType: "aws_db_instance",
+				MonthlyCost:  decimalPtr(decimal.NewFromInt(200)),
+				CostComponents: []*schema.CostComponent{
+					costComponent("Database instance (on-demand, Single-AZ, db.r4.large)", 200),
+				},
+			},
+			{
+				Name:         "aws_nat_gateway.idle",
+				ResourceType: "aws_nat_gateway",
+				MonthlyCost:  decimalPtr(decimal.NewFromFloat(32.85)),
+				CostComponents: []*schema.CostComponent{
+					costComponent("NAT gateway", 32.85),
+					{Name: "Data processed", MonthlyQuantity: decimalPtr(decimal.Zero), MonthlyCost: decimalPtr(decimal.Zero)},
+				},
+			},
+			{
+				Name:         "aws_nat_gateway.unknown_usage",
+				ResourceType: "aws_nat_gateway",
+				MonthlyCost:  decimalPtr(decimal.NewFromFloat(32.85)),
+				CostComponents: []*schema.CostComponent{
+					costComponent("NAT gateway", 32.85),
+					{Name: "Data processed"},
+				},
+			},
+			{
+				Name:         "aws_eip.unused",
+				ResourceType: "aws_eip",
+				MonthlyCost:  decimalPtr(decimal.NewFromFloat(3.65)),
+				CostComponents: []*schema.CostComponent{
+					costComponent("IP address (if unused)", 3.65),
+				},
+			},
+			{
+				Name:         "aws_eip.attached",
+				ResourceType: "aws_eip",
+				IsSkipped:    true,
+				NoPrice:      true,
+			},
+		},
+	}
+
+	recs := Check([]*schema.Project{project}, nil)
+
+	actual := make([]string, 0, len(recs))
+	for _, r := range recs {
+		actual = append(actual, r.Check+" "+r.Address+" "+r.EstimatedMonthlySavings.String())
+	}
+
+	assert.Equal(t, []string{
+		"aws_idle_nat_gateway aws_nat_gateway.idle 32.85",
+		"aws_gp2_to_gp3 aws_ebs_volume.data 20",
+		"aws_previous_generation_instance aws_db_instance.db 10",
+		"aws_unattached_eip aws_eip.unused 3.65",
+		"aws_previous_generation_instance aws_instance.web 3",
+	}, actual)
+
+	assert.Equal(t, "db.r4.large is a previous generation instance type, db.r5.large is about 5% cheaper", recs[2].Message)
+}
+
+func TestCheckEIPAssociation(t *testing.T) {
+	project := &schema.Project{
+		Name: "infracost/repo",
+		Resources: []*schema.Resource{
+			{
+				Name:           "aws_eip.ip",
+				ResourceType:   "aws_eip",
+				MonthlyCost:    decimalPtr(decimal.NewFromFloat(3.65)),
+				CostComponents: []*schema.CostComponent{costComponent("IP address (if unused)", 3.65)},
+			},
+			{Name: "aws_eip_association.ip", ResourceType: "aws_eip_association", IsSkipped: true, NoPrice: true},
+		},
+	}
+
+	assert.Empty(t, Check([]*schema.Project{project}, nil))
+}
+
+func TestCheckProjectCurrency(t *testing.T) {
+	project := &schema.Project{
+		Name:     "infracost/repo",
+		Currency: "EUR",
+		Resources: []*schema.Resource{
+			{
+				Name:           "aws_ebs_volume.data",
+				ResourceType:   "aws_ebs_volume",
+				MonthlyCost:    decimalPtr(decimal.NewFromInt(100)),
+				CostComponents: []*schema.CostComponent{costComponent("Storage (general purpose SSD, gp2)", 100)},
+			},
+		},
+	}
+
+	recs := Check([]*schema.Project{project}, map[string]decimal.Decimal{"EUR": decimal.NewFromFloat(1.1)})
+	require.Len(t, recs, 1)
+	assert.Equal(t, "22", recs[0].EstimatedMonthlySavings.String())
+}
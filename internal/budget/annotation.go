@@ -0,0 +1,91 @@
+package budget
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/infracost/infracost/internal/config"
+	"github.com/infracost/infracost/internal/output"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+// annotationGuardrail is the name of the guardrail the budget annotations of
+// resources are reported as.
+const annotationGuardrail = "infracost: budget comment"
+
+// CheckAnnotations returns the violations of the budget annotations of the
+// resources, e.g. # infracost: budget=200/month. The budgets are in the currency
+// of the run, per month unless they end with /hour. They fail the run like a
+// blocking guardrail, unless the resource is exempted by an ignore annotation.
+func CheckAnnotations(r output.Root) []output.GuardrailViolation {
+	var violations []output.GuardrailViolation
+
+	now := time.Now()
+
+	for _, p := range r.Projects {
+		if p.Breakdown == nil {
+			continue
+		}
+
+		for _, res := range p.Breakdown.Resources {
+			v, ok := res.Annotations[output.AnnotationBudget]
+			if !ok || res.IsExempt(now) {
+				continue
+			}
+
+			limit, err := parseAnnotationBudget(v)
+			if err != nil {
+				log.Warnf("Ignoring the budget annotation of %s: %s", res.Name, err)
+				continue
+			}
+
+			current := decimal.Zero
+			if res.MonthlyCost != nil {
+				current = *res.MonthlyCost
+			}
+
+			for _, e := range checkLimits(r.Currency, "budget", &limit, nil, nil, current, decimal.Zero, false) {
+				violations = append(violations, output.GuardrailViolation{
+					Guardrail:   annotationGuardrail,
+					Scope:       config.GuardrailScopeResource,
+					ProjectName: p.Name,
+					Address:     res.Name,
+					Limit:       e.limit,
+					Message:     e.message,
+					Blocking:    true,
+				})
+			}
+		}
+	}
+
+	return violations
+}
+
+// parseAnnotationBudget returns the monthly budget of a budget annotation, e.g.
+// 200/month or 0.5/hour.
+func parseAnnotationBudget(v string) (float64, error) {
+	parts := strings.SplitN(v, "/", 2)
+	amount, period := parts[0], ""
+	if len(parts) == 2 {
+		period = parts[1]
+	}
+
+	d, err := decimal.NewFromString(strings.TrimSpace(amount))
+	if err != nil || d.IsNegative() {
+		return 0, fmt.Errorf("budget %q must be a positive amount, optionally followed by /month or /hour", v)
+	}
+
+	switch strings.TrimSpace(period) {
+	case "", "month", "mo":
+	case "hour", "hr", "h":
+		d = d.Mul(schema.HourToMonthUnitMultiplier)
+	default:
+		return 0, fmt.Errorf("budget %q must be per month or per hour", v)
+	}
+
+	return d.InexactFloat64(), nil
+}
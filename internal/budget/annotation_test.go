@@ -0,0 +1,58 @@
+package budget
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/infracost/infracost/internal/config"
+	"github.com/infracost/infracost/internal/output"
+)
+
+func TestCheckAnnotations(t *testing.T) {
+	r := output.Root{
+		Currency: "USD",
+		Projects: []output.Project{
+			{
+				Name: "infracost/repo/environments/prod",
+				Breakdown: &output.Breakdown{
+					Resources: []output.Resource{
+						{Name: "aws_instance.api", Annotations: map[string]string{"budget": "100/month"}, MonthlyCost: decimalPtr("150")},
+						{Name: "aws_instance.worker", Annotations: map[string]string{"budget": "0.1/hour"}, MonthlyCost: decimalPtr("50")},
+						{Name: "aws_instance.poc", Annotations: map[string]string{"budget": "10", "ignore": "", "until": "2999-01-01"}, MonthlyCost: decimalPtr("50")},
+						{Name: "aws_instance.old", Annotations: map[string]string{"budget": "10", "ignore": "", "until": "2000-01-01"}, MonthlyCost: decimalPtr("50")},
+						{Name: "aws_instance.invalid", Annotations: map[string]string{"budget": "ten"}, MonthlyCost: decimalPtr("50")},
+					},
+				},
+			},
+		},
+	}
+
+	violations := CheckAnnotations(r)
+	require.Len(t, violations, 2)
+
+	assert.Equal(t, output.GuardrailViolation{
+		Guardrail:   annotationGuardrail,
+		Scope:       config.GuardrailScopeResource,
+		ProjectName: "infracost/repo/environments/prod",
+		Address:     "aws_instance.api",
+		Limit:       "max_monthly_cost",
+		Message:     "monthly cost $150 is over the budget of $100",
+		Blocking:    true,
+	}, violations[0])
+	assert.Equal(t, "aws_instance.old", violations[1].Address)
+}
+
+func TestParseAnnotationBudget(t *testing.T) {
+	v, err := parseAnnotationBudget("200/month")
+	require.NoError(t, err)
+	assert.Equal(t, 200.0, v)
+
+	v, err = parseAnnotationBudget("0.5/hour")
+	require.NoError(t, err)
+	assert.Equal(t, 365.0, v)
+
+	_, err = parseAnnotationBudget("200/day")
+	assert.Error(t, err)
+}
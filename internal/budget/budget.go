@@ -0,0 +1,280 @@
+// Package budget checks the costs of a run against the budgets of the config
+// file, so pipelines can fail when a change goes over budget.
+package budget
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/infracost/infracost/internal/config"
+	"github.com/infracost/infracost/internal/output"
+)
+
+// Violation is a limit of a budget that the costs exceed.
+type Violation struct {
+	Budget *config.Budget
+	// Limit is the name of the exceeded limit, e.g. max_monthly_cost.
+	Limit string
+	// Message describes the costs and the limit they exceed.
+	Message string
+}
+
+// Check returns the violations of the budgets by the costs of the projects. The
+// increase limits are only checked for projects that have past costs, e.g. with
+// diff.
+func Check(r output.Root, budgets []*config.Budget) []Violation {
+	var violations []Violation
+
+	for _, b := range budgets {
+		violations = append(violations, check(r, b)...)
+	}
+
+	return violations
+}
+
+func check(r output.Root, b *config.Budget) []Violation {
+	current := decimal.Zero
+	past := decimal.Zero
+	hasPast := false
+	matched := false
+
+	for _, p := range r.Projects {
+		if !matchesProject(b.Project, p) {
+			continue
+		}
+		matched = true
+
+		if p.Breakdown != nil {
+			current = current.Add(sumResources(b, p.Breakdown.Resources))
+		}
+
+		if p.PastBreakdown != nil {
+			hasPast = true
+			past = past.Add(sumResources(b, p.PastBreakdown.Resources))
+		}
+	}
+
+	if !matched {
+		return nil
+	}
+
+	var violations []Violation
+	for _, e := range checkLimits(r.Currency, "budget", b.MaxMonthlyCost, b.MaxMonthlyIncrease, b.MaxMonthlyIncreasePercent, current, past, hasPast) {
+		violations = append(violations, Violation{
+			Budget:  b,
+			Limit:   e.limit,
+			Message: e.message,
+		})
+	}
+
+	return violations
+}
+
+// exceededLimit is a limit that the costs exceed, with a message describing them.
+type exceededLimit struct {
+	limit   string
+	message string
+}
+
+// checkLimits returns the limits that the current and past costs exceed. The
+// increase limits are only checked when hasPast is true. The noun is what the
+// limits are called in the messages, e.g. budget.
+func checkLimits(currency string, noun string, maxCost, maxIncrease, maxIncreasePercent *float64, current, past decimal.Decimal, hasPast bool) []exceededLimit {
+	var exceeded []exceededLimit
+	format := func(d decimal.Decimal) string {
+		return output.FormatCost(currency, &d)
+	}
+
+	if maxCost != nil {
+		limit := decimal.NewFromFloat(*maxCost)
+		if current.GreaterThan(limit) {
+			exceeded = append(exceeded, exceededLimit{
+				limit:   "max_monthly_cost",
+				message: fmt.Sprintf("monthly cost %s is over the %s of %s", format(current), noun, format(limit)),
+			})
+		}
+	}
+
+	if !hasPast {
+		return exceeded
+	}
+
+	increase := current.Sub(past)
+
+	if maxIncrease != nil {
+		limit := decimal.NewFromFloat(*maxIncrease)
+		if increase.GreaterThan(limit) {
+			exceeded = append(exceeded, exceededLimit{
+				limit:   "max_monthly_increase",
+				message: fmt.Sprintf("monthly cost increase of %s is over the %s of %s", format(increase), noun, format(limit)),
+			})
+		}
+	}
+
+	// A percentage increase from nothing can't be calculated, new costs are
+	// limited by the absolute limits instead.
+	if maxIncreasePercent != nil && past.IsPositive() {
+		limit := decimal.NewFromFloat(*maxIncreasePercent)
+		percent := increase.Div(past).Mul(decimal.NewFromInt(100))
+		if percent.GreaterThan(limit) {
+			exceeded = append(exceeded, exceededLimit{
+				limit:   "max_monthly_increase_percent",
+				message: fmt.Sprintf("monthly cost increase of %s%% (%s to %s) is over the %s of %s%%", percent.Round(1).String(), format(past), format(current), noun, limit.String()),
+			})
+		}
+	}
+
+	return exceeded
+}
+
+func matchesProject(pattern string, p output.Project) bool {
+	if pattern == "" {
+		return true
+	}
+
+	if globMatch(pattern, p.Name) {
+		return true
+	}
+
+	return p.Metadata != nil && globMatch(pattern, p.Metadata.Path)
+}
+
+func globMatch(pattern string, s string) bool {
+	if pattern == s {
+		return true
+	}
+
+	ok, _ := path.Match(pattern, s)
+	return ok
+}
+
+func sumResources(b *config.Budget, resources []output.Resource) decimal.Decimal {
+	total := decimal.Zero
+
+	now := time.Now()
+
+	for _, r := range resources {
+		if !matchesModule(b.Module, r.Name) || !matchesTag(b.Tag, r.Tags) || !matchesOwner(b.Owner, r.Owner) || r.IsExempt(now) {
+			continue
+		}
+
+		if r.MonthlyCost != nil {
+			total = total.Add(*r.MonthlyCost)
+		}
+	}
+
+	return total
+}
+
+func matchesModule(module string, name string) bool {
+	if module == "" {
+		return true
+	}
+
+	module = strings.TrimSuffix(module, ".")
+	return strings.HasPrefix(name, module+".") || strings.HasPrefix(name, module+"[")
+}
+
+func matchesTag(tag string, tags map[string]string) bool {
+	if tag == "" {
+		return true
+	}
+
+	parts := strings.SplitN(tag, "=", 2)
+	v, ok := tags[parts[0]]
+	if !ok {
+		return false
+	}
+
+	return len(parts) == 1 || v == parts[1]
+}
+
+func matchesOwner(owner string, resourceOwner string) bool {
+	return owner == "" || owner == resourceOwner
+}
+
+// Report returns a summary of the violations, one line per exceeded limit.
+func Report(violations []Violation) string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "%d budget limits exceeded:\n", len(violations))
+	for _, v := range violations {
+		fmt.Fprintf(&b, "  %s: %s (%s)\n", v.Budget.Label(), v.Message, v.Limit)
+	}
+
+	return b.String()
+}
+
+// ParseThresholds returns a budget of all projects from the values of the
+// --fail-on-increase and --fail-on-total flags of diff, or nil if both are
+// empty. The increase is a percentage when it ends with %, e.g. 10%, otherwise
+// it's an amount in the currency of the run like the total.
+func ParseThresholds(increase string, total string) (*config.Budget, error) {
+	if increase == "" && total == "" {
+		return nil, nil
+	}
+
+	b := &config.Budget{Name: "diff thresholds"}
+
+	if increase != "" {
+		v, err := parseThreshold(strings.TrimSuffix(increase, "%"))
+		if err != nil {
+			return nil, fmt.Errorf("invalid --fail-on-increase %q: %w", increase, err)
+		}
+
+		if strings.HasSuffix(increase, "%") {
+			b.MaxMonthlyIncreasePercent = &v
+		} else {
+			b.MaxMonthlyIncrease = &v
+		}
+	}
+
+	if total != "" {
+		v, err := parseThreshold(total)
+		if err != nil {
+			return nil, fmt.Errorf("invalid --fail-on-total %q: %w", total, err)
+		}
+
+		b.MaxMonthlyCost = &v
+	}
+
+	return b, nil
+}
+
+func parseThreshold(s string) (float64, error) {
+	d, err := decimal.NewFromString(strings.TrimSpace(s))
+	if err != nil {
+		return 0, errors.New("must be a number")
+	}
+
+	if d.IsNegative() {
+		return 0, errors.New("must be at least 0")
+	}
+
+	return d.InexactFloat64(), nil
+}
+
+// thresholdFlags are the diff flags that set each limit of the thresholds.
+var thresholdFlags = map[string]string{
+	"max_monthly_cost":             "--fail-on-total",
+	"max_monthly_increase":         "--fail-on-increase",
+	"max_monthly_increase_percent": "--fail-on-increase",
+}
+
+// ThresholdReport returns a summary of the violations of the diff thresholds
+// to annotate the output with, one line per exceeded flag.
+func ThresholdReport(violations []Violation) string {
+	var b strings.Builder
+
+	b.WriteString("Thresholds exceeded:\n")
+	for _, v := range violations {
+		fmt.Fprintf(&b, "  %s: %s\n", thresholdFlags[v.Limit], v.Message)
+	}
+
+	return b.String()
+}
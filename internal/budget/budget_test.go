@@ -0,0 +1,172 @@
+package budget
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/infracost/infracost/internal/config"
+	"github.com/infracost/infracost/internal/output"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+func decimalPtr(v string) *decimal.Decimal {
+	d := decimal.RequireFromString(v)
+	return &d
+}
+
+func float64Ptr(v float64) *float64 {
+	return &v
+}
+
+func testRoot() output.Root {
+	return output.Root{
+		Currency: "USD",
+		Projects: []output.Project{
+			{
+				Name:     "infracost/repo/environments/prod",
+				Metadata: &schema.ProjectMetadata{Path: "environments/prod"},
+				PastBreakdown: &output.Breakdown{
+					Resources: []output.Resource{
+						{Name: "module.eks.aws_eks_cluster.this", Owner: "@acme/platform", MonthlyCost: decimalPtr("73")},
+						{Name: "aws_instance.api", Tags: map[string]string{"team": "payments"}, MonthlyCost: decimalPtr("100")},
+					},
+				},
+				Breakdown: &output.Breakdown{
+					Resources: []output.Resource{
+						{Name: "module.eks.aws_eks_cluster.this", Owner: "@acme/platform", MonthlyCost: decimalPtr("73")},
+						{Name: "module.eks.aws_eks_node_group.this[0]", Owner: "@acme/platform", MonthlyCost: decimalPtr("200")},
+						{Name: "aws_instance.api", Tags: map[string]string{"team": "payments"}, MonthlyCost: decimalPtr("150")},
+					},
+				},
+			},
+			{
+				Name:     "infracost/repo/environments/dev",
+				Metadata: &schema.ProjectMetadata{Path: "environments/dev"},
+				Breakdown: &output.Breakdown{
+					Resources: []output.Resource{
+						{Name: "aws_instance.api", Tags: map[string]string{"team": "payments"}, MonthlyCost: decimalPtr("50")},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestCheck(t *testing.T) {
+	tests := []struct {
+		name     string
+		budget   *config.Budget
+		expected []string
+	}{
+		{
+			name:     "total under budget",
+			budget:   &config.Budget{MaxMonthlyCost: float64Ptr(1000)},
+			expected: nil,
+		},
+		{
+			name:     "total over budget",
+			budget:   &config.Budget{MaxMonthlyCost: float64Ptr(400)},
+			expected: []string{"monthly cost $473 is over the budget of $400"},
+		},
+		{
+			name:     "project path glob",
+			budget:   &config.Budget{Project: "environments/p*", MaxMonthlyCost: float64Ptr(400)},
+			expected: []string{"monthly cost $423 is over the budget of $400"},
+		},
+		{
+			name:     "module increase",
+			budget:   &config.Budget{Project: "environments/prod", Module: "module.eks", MaxMonthlyIncrease: float64Ptr(100)},
+			expected: []string{"monthly cost increase of $200 is over the budget of $100"},
+		},
+		{
+			name:     "tag percent increase",
+			budget:   &config.Budget{Project: "environments/prod", Tag: "team=payments", MaxMonthlyIncreasePercent: float64Ptr(20)},
+			expected: []string{"monthly cost increase of 50% ($100 to $150) is over the budget of 20%"},
+		},
+		{
+			name:     "tag value does not match",
+			budget:   &config.Budget{Tag: "team=data", MaxMonthlyCost: float64Ptr(0)},
+			expected: nil,
+		},
+		{
+			name:     "owner over budget",
+			budget:   &config.Budget{Owner: "@acme/platform", MaxMonthlyCost: float64Ptr(250)},
+			expected: []string{"monthly cost $273 is over the budget of $250"},
+		},
+		{
+			name:     "no matching project",
+			budget:   &config.Budget{Project: "environments/staging", MaxMonthlyCost: float64Ptr(0)},
+			expected: nil,
+		},
+		{
+			name:     "increase not checked without past costs",
+			budget:   &config.Budget{Project: "environments/dev", MaxMonthlyIncrease: float64Ptr(0)},
+			expected: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			violations := Check(testRoot(), []*config.Budget{tt.budget})
+
+			var messages []string
+			for _, v := range violations {
+				messages = append(messages, v.Message)
+			}
+
+			assert.Equal(t, tt.expected, messages)
+		})
+	}
+}
+
+func TestReport(t *testing.T) {
+	violations := Check(testRoot(), []*config.Budget{
+		{Name: "Prod", Project: "environments/prod", MaxMonthlyCost: float64Ptr(300)},
+		{Module: "module.eks", MaxMonthlyIncrease: float64Ptr(0)},
+	})
+	require.Len(t, violations, 2)
+
+	assert.Equal(t, `2 budget limits exceeded:
+  Prod: monthly cost $423 is over the budget of $300 (max_monthly_cost)
+  module module.eks: monthly cost increase of $200 is over the budget of $0.00 (max_monthly_increase)
+`, Report(violations))
+}
+
+func TestParseThresholds(t *testing.T) {
+	b, err := ParseThresholds("", "")
+	require.NoError(t, err)
+	assert.Nil(t, b)
+
+	b, err = ParseThresholds("10%", "5000")
+	require.NoError(t, err)
+	assert.Equal(t, float64Ptr(10), b.MaxMonthlyIncreasePercent)
+	assert.Nil(t, b.MaxMonthlyIncrease)
+	assert.Equal(t, float64Ptr(5000), b.MaxMonthlyCost)
+
+	b, err = ParseThresholds("250.5", "")
+	require.NoError(t, err)
+	assert.Equal(t, float64Ptr(250.5), b.MaxMonthlyIncrease)
+	assert.Nil(t, b.MaxMonthlyCost)
+
+	_, err = ParseThresholds("ten%", "")
+	assert.EqualError(t, err, `invalid --fail-on-increase "ten%": must be a number`)
+
+	_, err = ParseThresholds("", "-1")
+	assert.EqualError(t, err, `invalid --fail-on-total "-1": must be at least 0`)
+}
+
+func TestThresholdReport(t *testing.T) {
+	b, err := ParseThresholds("10%", "400")
+	require.NoError(t, err)
+
+	violations := Check(testRoot(), []*config.Budget{b})
+	require.Len(t, violations, 2)
+
+	assert.Equal(t, `Thresholds exceeded:
+  --fail-on-total: monthly cost $473 is over the budget of $400
+  --fail-on-increase: monthly cost increase of 173.4% ($173 to $473) is over the budget of 10%
+`, ThresholdReport(violations))
+}
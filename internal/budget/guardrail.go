@@ -0,0 +1,254 @@
+package budget
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/infracost/infracost/internal/celexpr"
+	"github.com/infracost/infracost/internal/config"
+	"github.com/infracost/infracost/internal/output"
+)
+
+// CheckGuardrails returns the violations of the guardrails by the costs of each
+// project, module or resource in their scope. Unlike budgets, the costs of the
+// matching projects, modules and resources aren't summed, each of them is
+// checked against the limits on its own.
+func CheckGuardrails(r output.Root, guardrails []*config.Guardrail) []output.GuardrailViolation {
+	var violations []output.GuardrailViolation
+
+	for _, g := range guardrails {
+		violations = append(violations, checkGuardrail(r, g)...)
+	}
+
+	return violations
+}
+
+func checkGuardrail(r output.Root, g *config.Guardrail) []output.GuardrailViolation {
+	var violations []output.GuardrailViolation
+
+	scope := g.EffectiveScope()
+
+	cond, err := g.ConditionExpression()
+	if err != nil {
+		log.Warnf("Skipping guardrail %s: %s", g.Label(), err)
+		return nil
+	}
+
+	for _, p := range r.Projects {
+		if !matchesProject(g.Project, p) || p.Breakdown == nil {
+			continue
+		}
+
+		current := guardrailCosts(g, scope, p.Breakdown.Resources)
+
+		// Only the projects, modules and resources with changed resources are
+		// checked, on their total costs.
+		if g.ApplyTo == config.ApplyToChanged {
+			changed := guardrailCosts(g, scope, p.ChangedResources())
+			for subject := range current {
+				if _, ok := changed[subject]; !ok {
+					delete(current, subject)
+				}
+			}
+		}
+
+		hasPast := p.PastBreakdown != nil
+		past := map[string]decimal.Decimal{}
+		if hasPast {
+			past = guardrailCosts(g, scope, p.PastBreakdown.Resources)
+		}
+
+		resources := make(map[string]output.Resource, len(p.Breakdown.Resources))
+		for _, res := range p.Breakdown.Resources {
+			resources[res.Name] = res
+		}
+
+		subjects := make([]string, 0, len(current))
+		for subject := range current {
+			subjects = append(subjects, subject)
+		}
+		sort.Strings(subjects)
+
+		for _, subject := range subjects {
+			var exceeded []exceededLimit
+
+			if cond != nil {
+				vars := conditionVars(scope, p, subject, resources[subject], current[subject], past[subject])
+
+				ok, err := cond.Eval(vars)
+				if err != nil {
+					log.Warnf("Skipping guardrail %s for %s: %s", g.Label(), p.Name, err)
+					break
+				}
+
+				if ok {
+					exceeded = append(exceeded, exceededLimit{
+						limit:   "condition",
+						message: fmt.Sprintf("matches the condition %s", cond),
+					})
+				}
+			} else {
+				exceeded = checkLimits(r.Currency, "guardrail", g.MaxMonthlyCost, g.MaxMonthlyIncrease, g.MaxMonthlyIncreasePercent, current[subject], past[subject], hasPast)
+			}
+
+			for _, e := range exceeded {
+				violations = append(violations, output.GuardrailViolation{
+					Guardrail:   g.Label(),
+					Scope:       scope,
+					ProjectName: p.Name,
+					Address:     subject,
+					Limit:       e.limit,
+					Message:     e.message,
+					Blocking:    g.Block,
+					SourceRange: resources[subject].SourceRange,
+				})
+			}
+		}
+	}
+
+	return violations
+}
+
+// conditionVars returns the variables the condition of a guardrail is evaluated
+// against for a subject: the project, and the module or resource for their
+// scopes. Costs are monthly, the increase is from the past costs of a diff or
+// the whole cost in a breakdown.
+func conditionVars(scope string, p output.Project, subject string, res output.Resource, current, past decimal.Decimal) map[string]interface{} {
+	projectCurrent := decimal.Zero
+	if p.Breakdown != nil && p.Breakdown.TotalMonthlyCost != nil {
+		projectCurrent = *p.Breakdown.TotalMonthlyCost
+	}
+
+	projectPast := decimal.Zero
+	if p.PastBreakdown != nil && p.PastBreakdown.TotalMonthlyCost != nil {
+		projectPast = *p.PastBreakdown.TotalMonthlyCost
+	}
+
+	project := costVars(projectCurrent, projectPast)
+	project["name"] = p.Name
+	project["path"] = ""
+	project["labels"] = map[string]string{}
+	if p.Metadata != nil {
+		project["path"] = p.Metadata.Path
+		if p.Metadata.Labels != nil {
+			project["labels"] = p.Metadata.Labels
+		}
+	}
+
+	vars := map[string]interface{}{celexpr.VarProject: project}
+
+	switch scope {
+	case config.GuardrailScopeModule:
+		module := costVars(current, past)
+		module["address"] = subject
+		vars[celexpr.VarModule] = module
+	case config.GuardrailScopeResource:
+		resource := costVars(current, past)
+		resource["address"] = subject
+		resource["type"] = output.ResourceType(subject)
+		resource["owner"] = res.Owner
+		resource["tags"] = map[string]string{}
+		if res.Tags != nil {
+			resource["tags"] = res.Tags
+		}
+		vars[celexpr.VarResource] = resource
+	}
+
+	return vars
+}
+
+func costVars(current, past decimal.Decimal) map[string]interface{} {
+	return map[string]interface{}{
+		"monthlyCost":         current.InexactFloat64(),
+		"pastMonthlyCost":     past.InexactFloat64(),
+		"monthlyCostIncrease": current.Sub(past).InexactFloat64(),
+	}
+}
+
+// guardrailCosts returns the monthly costs of the resources matching the
+// guardrail by the address of the module or resource they're for, or by an
+// empty address for the project scope.
+func guardrailCosts(g *config.Guardrail, scope string, resources []output.Resource) map[string]decimal.Decimal {
+	costs := map[string]decimal.Decimal{}
+	now := time.Now()
+
+	for _, r := range resources {
+		if r.IsExempt(now) {
+			continue
+		}
+
+		if g.Module != "" && !output.InModule(r.Name, g.Module) {
+			continue
+		}
+
+		if g.ResourceType != "" && !globMatch(g.ResourceType, output.ResourceType(r.Name)) {
+			continue
+		}
+
+		var subject string
+		switch scope {
+		case config.GuardrailScopeModule:
+			subject = output.ModuleAddress(r.Name, moduleDepth(g.Module))
+			if subject == "" {
+				continue
+			}
+		case config.GuardrailScopeResource:
+			subject = r.Name
+		}
+
+		cost := costs[subject]
+		if r.MonthlyCost != nil {
+			cost = cost.Add(*r.MonthlyCost)
+		}
+		costs[subject] = cost
+	}
+
+	return costs
+}
+
+// moduleDepth returns how many levels of nested modules the module pattern of a
+// guardrail has, e.g. 2 for module.a.module.b*, so modules are checked at the
+// level they're selected at. Without a pattern the top-level modules are
+// checked.
+func moduleDepth(pattern string) int {
+	depth := 0
+	for _, part := range strings.Split(pattern, ".") {
+		if part != "" && part != "module" {
+			depth++
+		}
+	}
+
+	if depth == 0 {
+		return 1
+	}
+
+	return depth
+}
+
+// GuardrailReport returns a summary of the blocking violations, one line per
+// exceeded limit.
+func GuardrailReport(violations []output.GuardrailViolation) string {
+	var b strings.Builder
+
+	n := 0
+	for _, v := range violations {
+		if v.Blocking {
+			n++
+		}
+	}
+
+	fmt.Fprintf(&b, "%d guardrail limits exceeded:\n", n)
+	for _, v := range violations {
+		if !v.Blocking {
+			continue
+		}
+		fmt.Fprintf(&b, "  %s: %s %s (%s)\n", v.Guardrail, v.Subject(), v.Message, v.Limit)
+	}
+
+	return b.String()
+}
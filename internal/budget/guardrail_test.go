@@ -0,0 +1,137 @@
+package budget
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/infracost/infracost/internal/config"
+	"github.com/infracost/infracost/internal/output"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+func TestCheckGuardrails(t *testing.T) {
+	tests := []struct {
+		name      string
+		guardrail *config.Guardrail
+		expected  []string
+	}{
+		{
+			name:      "each project",
+			guardrail: &config.Guardrail{MaxMonthlyCost: float64Ptr(100)},
+			expected:  []string{"infracost/repo/environments/prod: monthly cost $423 is over the guardrail of $100"},
+		},
+		{
+			name:      "module glob increase",
+			guardrail: &config.Guardrail{Module: "module.ek*", MaxMonthlyIncrease: float64Ptr(100)},
+			expected:  []string{"module.eks: monthly cost increase of $200 is over the guardrail of $100"},
+		},
+		{
+			name:      "each resource of a type",
+			guardrail: &config.Guardrail{ResourceType: "aws_instance", MaxMonthlyCost: float64Ptr(100)},
+			expected:  []string{"aws_instance.api: monthly cost $150 is over the guardrail of $100"},
+		},
+		{
+			name:      "resource percent increase",
+			guardrail: &config.Guardrail{Project: "environments/*", ResourceType: "aws_*", MaxMonthlyIncreasePercent: float64Ptr(20)},
+			expected:  []string{"aws_instance.api: monthly cost increase of 50% ($100 to $150) is over the guardrail of 20%"},
+		},
+		{
+			name:      "project scope of a resource type",
+			guardrail: &config.Guardrail{Scope: config.GuardrailScopeProject, ResourceType: "aws_instance", MaxMonthlyCost: float64Ptr(100)},
+			expected:  []string{"infracost/repo/environments/prod: monthly cost $150 is over the guardrail of $100"},
+		},
+		{
+			name:      "no matching project",
+			guardrail: &config.Guardrail{Project: "environments/staging", MaxMonthlyCost: float64Ptr(0)},
+			expected:  nil,
+		},
+		{
+			name:      "resource condition",
+			guardrail: &config.Guardrail{ResourceType: "aws_*", Condition: `resource.monthlyCost > 100.0 && resource.tags["team"] == "payments"`},
+			expected:  []string{`aws_instance.api: matches the condition resource.monthlyCost > 100.0 && resource.tags["team"] == "payments"`},
+		},
+		{
+			name:      "module condition",
+			guardrail: &config.Guardrail{Module: "module.*", Condition: "module.monthlyCostIncrease > 100.0"},
+			expected:  []string{"module.eks: matches the condition module.monthlyCostIncrease > 100.0"},
+		},
+		{
+			name:      "project condition",
+			guardrail: &config.Guardrail{Condition: `project.path.endsWith("/dev")`},
+			expected:  []string{`infracost/repo/environments/dev: matches the condition project.path.endsWith("/dev")`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			violations := CheckGuardrails(testRoot(), []*config.Guardrail{tt.guardrail})
+
+			var messages []string
+			for _, v := range violations {
+				messages = append(messages, v.Subject()+": "+v.Message)
+			}
+
+			assert.Equal(t, tt.expected, messages)
+		})
+	}
+}
+
+func TestCheckGuardrailsChangedResources(t *testing.T) {
+	r := testRoot()
+	r.Projects[0].Diff = &output.Breakdown{
+		Resources: []output.Resource{
+			{Name: "module.eks.aws_eks_node_group.this[0]", MonthlyCost: decimalPtr("200")},
+		},
+	}
+
+	violations := CheckGuardrails(r, []*config.Guardrail{
+		{ResourceType: "aws_*", MaxMonthlyCost: float64Ptr(100), ApplyTo: config.ApplyToChanged},
+		{Module: "module.*", MaxMonthlyCost: float64Ptr(100), ApplyTo: config.ApplyToChanged},
+		{Scope: config.GuardrailScopeProject, MaxMonthlyCost: float64Ptr(0), ApplyTo: config.ApplyToChanged},
+	})
+
+	var messages []string
+	for _, v := range violations {
+		messages = append(messages, v.Guardrail+": "+v.Subject()+": "+v.Message)
+	}
+
+	// aws_instance.api of the prod project is over the limit but unchanged,
+	// the dev project has no diff so all its resources are checked.
+	assert.Equal(t, []string{
+		"each changed resource matching type aws_*: module.eks.aws_eks_node_group.this[0]: monthly cost $200 is over the guardrail of $100",
+		"each changed module matching module module.*: module.eks: monthly cost $273 is over the guardrail of $100",
+		"each changed project: infracost/repo/environments/prod: monthly cost $423 is over the guardrail of $0",
+		"each changed project: infracost/repo/environments/dev: monthly cost $50 is over the guardrail of $0",
+	}, messages)
+}
+
+func TestGuardrailReport(t *testing.T) {
+	violations := CheckGuardrails(testRoot(), []*config.Guardrail{
+		{Name: "Instances", ResourceType: "aws_instance", MaxMonthlyCost: float64Ptr(100), Block: true},
+		{Module: "module.eks", MaxMonthlyIncrease: float64Ptr(0)},
+	})
+	assert.Len(t, violations, 2)
+
+	assert.Equal(t, `1 guardrail limits exceeded:
+  Instances: aws_instance.api monthly cost $150 is over the guardrail of $100 (max_monthly_cost)
+`, GuardrailReport(violations))
+}
+
+func TestCheckGuardrailsSourceRange(t *testing.T) {
+	r := testRoot()
+	sourceRange := &schema.SourceRange{Filename: "environments/prod/main.tf", StartLine: 3, EndLine: 9}
+	for i, res := range r.Projects[0].Breakdown.Resources {
+		if res.Name == "aws_instance.api" {
+			r.Projects[0].Breakdown.Resources[i].SourceRange = sourceRange
+		}
+	}
+
+	violations := CheckGuardrails(r, []*config.Guardrail{
+		{ResourceType: "aws_instance", MaxMonthlyCost: float64Ptr(100)},
+		{Scope: config.GuardrailScopeProject, MaxMonthlyCost: float64Ptr(100)},
+	})
+	assert.Len(t, violations, 2)
+	assert.Equal(t, sourceRange, violations[0].SourceRange)
+	assert.Nil(t, violations[1].SourceRange)
+}
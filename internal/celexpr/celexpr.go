@@ -0,0 +1,82 @@
+// Package celexpr compiles and evaluates the CEL expressions of the config file,
+// e.g. the conditions of guardrails, so policies can be written inline without
+// Rego.
+package celexpr
+
+import (
+	"fmt"
+
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/checker/decls"
+	"google.golang.org/protobuf/proto"
+)
+
+// The variables the expressions are evaluated against. They're maps so new
+// fields can be added without breaking existing expressions.
+const (
+	VarResource = "resource"
+	VarModule   = "module"
+	VarProject  = "project"
+)
+
+// Expression is a compiled CEL expression that returns a bool.
+type Expression struct {
+	source  string
+	program cel.Program
+}
+
+// Compile parses and type checks the expression, it returns an error if it's
+// invalid or doesn't return a bool.
+func Compile(source string) (*Expression, error) {
+	env, err := cel.NewEnv(cel.Declarations(
+		decls.NewVar(VarResource, decls.NewMapType(decls.String, decls.Dyn)),
+		decls.NewVar(VarModule, decls.NewMapType(decls.String, decls.Dyn)),
+		decls.NewVar(VarProject, decls.NewMapType(decls.String, decls.Dyn)),
+	))
+	if err != nil {
+		return nil, err
+	}
+
+	ast, iss := env.Compile(source)
+	if iss != nil && iss.Err() != nil {
+		return nil, fmt.Errorf("Invalid expression %q: %w", source, iss.Err())
+	}
+
+	if t := ast.ResultType(); !proto.Equal(t, decls.Bool) && !proto.Equal(t, decls.Dyn) {
+		return nil, fmt.Errorf("Invalid expression %q: must return a bool", source)
+	}
+
+	program, err := env.Program(ast)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid expression %q: %w", source, err)
+	}
+
+	return &Expression{source: source, program: program}, nil
+}
+
+// String returns the source of the expression.
+func (e *Expression) String() string {
+	return e.source
+}
+
+// Eval evaluates the expression with the variables, the ones it doesn't use
+// can be missing.
+func (e *Expression) Eval(vars map[string]interface{}) (bool, error) {
+	for _, name := range []string{VarResource, VarModule, VarProject} {
+		if _, ok := vars[name]; !ok {
+			vars[name] = map[string]interface{}{}
+		}
+	}
+
+	out, _, err := e.program.Eval(vars)
+	if err != nil {
+		return false, fmt.Errorf("Error evaluating expression %q: %w", e.source, err)
+	}
+
+	b, ok := out.Value().(bool)
+	if !ok {
+		return false, fmt.Errorf("Error evaluating expression %q: returned %v instead of a bool", e.source, out.Value())
+	}
+
+	return b, nil
+}
@@ -0,0 +1,52 @@
+package celexpr
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEval(t *testing.T) {
+	expr, err := Compile(`resource.monthlyCost > 500.0 && resource.type == "aws_nat_gateway"`)
+	require.NoError(t, err)
+	assert.Equal(t, `resource.monthlyCost > 500.0 && resource.type == "aws_nat_gateway"`, expr.String())
+
+	ok, err := expr.Eval(map[string]interface{}{
+		VarResource: map[string]interface{}{"monthlyCost": 600.5, "type": "aws_nat_gateway"},
+	})
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	ok, err = expr.Eval(map[string]interface{}{
+		VarResource: map[string]interface{}{"monthlyCost": 600.5, "type": "aws_instance"},
+	})
+	require.NoError(t, err)
+	assert.False(t, ok)
+}
+
+func TestEvalMapsAndMissingVars(t *testing.T) {
+	expr, err := Compile(`"team" in project.labels && project.labels["team"] == "payments"`)
+	require.NoError(t, err)
+
+	ok, err := expr.Eval(map[string]interface{}{
+		VarProject: map[string]interface{}{"labels": map[string]string{"team": "payments"}},
+	})
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	_, err = expr.Eval(map[string]interface{}{})
+	assert.Error(t, err)
+}
+
+func TestCompileInvalid(t *testing.T) {
+	_, err := Compile("resource.monthlyCost >")
+	assert.Error(t, err)
+
+	_, err = Compile(`"not a bool"`)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "must return a bool")
+
+	_, err = Compile("unknown.monthlyCost > 1.0")
+	assert.Error(t, err)
+}
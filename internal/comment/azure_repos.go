@@ -15,6 +15,7 @@ import (
 
 	"github.com/pkg/errors"
 	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/clientcredentials"
 )
 
 // azureReposComment represents a comment on an Azure Repos pull request. It
@@ -60,6 +61,11 @@ func (c *azureReposComment) IsHidden() bool {
 type AzureReposExtra struct {
 	// Token is the Azure DevOps access token.
 	Token string
+	// TenantID, ClientID and ClientSecret are the service principal of an
+	// Azure service connection. They're used instead of Token when it's empty.
+	TenantID     string
+	ClientID     string
+	ClientSecret string
 	// Tag is used to identify the Infracost comment.
 	Tag string
 }
@@ -80,8 +86,33 @@ type azureAPIComment struct {
 // azurePATLength helps to determine if token is an Azure DevOps Personal Access Token.
 const azurePATLength = 52
 
-// newAzureReposAPIClient creates a HTTP client.
-func newAzureReposAPIClient(ctx context.Context, token string) (*http.Client, error) {
+// azureDevOpsScope is the scope of the tokens of service principals for the
+// Azure DevOps API.
+const azureDevOpsScope = "499b84ac-1321-427f-aa17-267ca6975798/.default"
+
+// azureADTokenURL is the token endpoint of an Azure AD tenant.
+const azureADTokenURL = "https://login.microsoftonline.com/%s/oauth2/v2.0/token"
+
+// newAzureReposAPIClient creates a HTTP client. It authenticates with the
+// access token, or with the service principal if there's no access token.
+func newAzureReposAPIClient(ctx context.Context, extra AzureReposExtra) (*http.Client, error) {
+	token := extra.Token
+
+	if token == "" {
+		if extra.TenantID == "" || extra.ClientID == "" || extra.ClientSecret == "" {
+			return nil, errors.New("An access token or the tenant ID, client ID and client secret of a service principal are required")
+		}
+
+		cfg := clientcredentials.Config{
+			ClientID:     extra.ClientID,
+			ClientSecret: extra.ClientSecret,
+			TokenURL:     fmt.Sprintf(azureADTokenURL, extra.TenantID),
+			Scopes:       []string{azureDevOpsScope},
+		}
+
+		return cfg.Client(ctx), nil
+	}
+
 	accessToken, tokenType := token, "Bearer"
 
 	if len(token) == azurePATLength {
@@ -142,7 +173,7 @@ func NewAzureReposPRHandler(ctx context.Context, repoURL string, targetRef strin
 		return nil, errors.Wrap(err, "Error parsing targetRef as pull request number")
 	}
 
-	httpClient, err := newAzureReposAPIClient(ctx, extra.Token)
+	httpClient, err := newAzureReposAPIClient(ctx, extra)
 	if err != nil {
 		return nil, err
 	}
@@ -342,9 +373,10 @@ func (h *azureReposPRHandler) CallDeleteComment(ctx context.Context, comment Com
 	return err
 }
 
-// CallHideComment calls the Azure Repos API to minimize the pull request comment.
+// CallHideComment returns ErrHideNotSupported since Azure Repos doesn't have a
+// feature for hiding comments.
 func (h *azureReposPRHandler) CallHideComment(ctx context.Context, comment Comment) error {
-	return errors.New("Not implemented")
+	return ErrHideNotSupported
 }
 
 // AddMarkdownTag prepends a tag as a markdown comment to the given string.
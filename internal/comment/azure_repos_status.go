@@ -0,0 +1,204 @@
+package comment
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// The states of an Azure Repos pull request status.
+const (
+	AzureReposStatusSucceeded = "succeeded"
+	AzureReposStatusFailed    = "failed"
+)
+
+// azureReposStatusGenre is the genre of the pull request statuses, branch
+// policies require the statuses by their genre and name.
+const azureReposStatusGenre = "infracost"
+
+// AzureReposPRStatus is a status of a pull request, e.g. to block its merge
+// with a branch policy when the costs are over the thresholds.
+type AzureReposPRStatus struct {
+	State       string
+	Description string
+	TargetURL   string
+}
+
+// SetAzureReposPRStatus sets the status of the pull request. Its name is the
+// tag of the comments, or infracost-comment by default, so each pipeline can
+// set its own status.
+func SetAzureReposPRStatus(ctx context.Context, repoURL string, prNumber int, extra AzureReposExtra, status AzureReposPRStatus) error {
+	httpClient, err := newAzureReposAPIClient(ctx, extra)
+	if err != nil {
+		return err
+	}
+
+	apiURL, err := buildAzureAPIURL(repoURL)
+	if err != nil {
+		return err
+	}
+
+	name := extra.Tag
+	if name == "" {
+		name = defaultTag
+	}
+
+	reqData := map[string]interface{}{
+		"state":       status.State,
+		"description": status.Description,
+		"context": map[string]string{
+			"genre": azureReposStatusGenre,
+			"name":  name,
+		},
+	}
+	if status.TargetURL != "" {
+		reqData["targetUrl"] = status.TargetURL
+	}
+
+	url := fmt.Sprintf("%spullRequests/%d/statuses?api-version=6.0-preview.1", apiURL, prNumber)
+
+	err = azureReposRequest(httpClient, "POST", url, reqData, nil)
+	if err != nil {
+		return errors.Wrap(err, "Error setting pull request status")
+	}
+
+	return nil
+}
+
+// azureAPIThread represents API response structure of Azure Repos thread.
+type azureAPIThread struct {
+	ID        int64             `json:"id"`
+	Status    string            `json:"status"`
+	IsDeleted bool              `json:"isDeleted"`
+	Comments  []azureAPIComment `json:"comments"`
+}
+
+// ResolveAzureReposThreads resolves the threads of the Infracost comments of
+// previous runs that are still active, e.g. because a reviewer replied to them,
+// so they don't block the pull request when comments must be resolved. The
+// thread of the latest comment is kept as it is. It returns the number of
+// resolved threads.
+func ResolveAzureReposThreads(ctx context.Context, repoURL string, prNumber int, extra AzureReposExtra) (int, error) {
+	httpClient, err := newAzureReposAPIClient(ctx, extra)
+	if err != nil {
+		return 0, err
+	}
+
+	apiURL, err := buildAzureAPIURL(repoURL)
+	if err != nil {
+		return 0, err
+	}
+
+	tag := extra.Tag
+	if tag == "" {
+		tag = defaultTag
+	}
+
+	var resData struct {
+		Value []azureAPIThread `json:"value"`
+	}
+
+	err = azureReposRequest(httpClient, "GET", fmt.Sprintf("%spullRequests/%d/threads?api-version=6.0", apiURL, prNumber), nil, &resData)
+	if err != nil {
+		return 0, errors.Wrap(err, "Error getting threads")
+	}
+
+	type infracostThread struct {
+		azureAPIThread
+		publishedDate string
+	}
+
+	var threads []infracostThread
+	for _, t := range resData.Value {
+		if t.IsDeleted || len(t.Comments) == 0 {
+			continue
+		}
+
+		first := t.Comments[0]
+		if first.IsDeleted || !strings.Contains(first.Content, tag) {
+			continue
+		}
+
+		threads = append(threads, infracostThread{azureAPIThread: t, publishedDate: first.PublishedDate})
+	}
+
+	sort.Slice(threads, func(i, j int) bool {
+		return threads[i].publishedDate < threads[j].publishedDate
+	})
+
+	resolved := 0
+	for i, t := range threads {
+		if i == len(threads)-1 {
+			break
+		}
+
+		if t.Status != "active" && t.Status != "pending" {
+			continue
+		}
+
+		url := fmt.Sprintf("%spullRequests/%d/threads/%d?api-version=6.0", apiURL, prNumber, t.ID)
+
+		err = azureReposRequest(httpClient, "PATCH", url, map[string]string{"status": "fixed"}, nil)
+		if err != nil {
+			return resolved, errors.Wrap(err, "Error resolving thread")
+		}
+
+		resolved++
+	}
+
+	return resolved, nil
+}
+
+// azureReposRequest sends the request with the JSON of reqData as its body, if
+// it's set, and unmarshals the response into resData, if it's set.
+func azureReposRequest(httpClient *http.Client, method string, url string, reqData interface{}, resData interface{}) error {
+	body := &bytes.Buffer{}
+	if reqData != nil {
+		b, err := json.Marshal(reqData)
+		if err != nil {
+			return errors.Wrap(err, "Error marshaling request body")
+		}
+		body = bytes.NewBuffer(b)
+	}
+
+	req, err := http.NewRequest(method, url, body)
+	if err != nil {
+		return errors.Wrap(err, "Error creating request")
+	}
+	if reqData != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	res, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		return errors.Errorf("%s", res.Status)
+	}
+
+	if resData == nil {
+		return nil
+	}
+
+	resBody, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return errors.Wrap(err, "Error reading response body")
+	}
+
+	err = json.Unmarshal(resBody, resData)
+	if err != nil {
+		return errors.Wrap(err, "Error unmarshaling response body")
+	}
+
+	return nil
+}
@@ -323,9 +323,10 @@ func (h *bitbucketPRHandler) CallDeleteComment(ctx context.Context, comment Comm
 	return err
 }
 
-// CallHideComment calls the Bitbucket API to minimize the pull request comment.
+// CallHideComment returns ErrHideNotSupported since Bitbucket doesn't have a
+// feature for hiding comments.
 func (h *bitbucketPRHandler) CallHideComment(ctx context.Context, comment Comment) error {
-	return errors.New("Not implemented")
+	return ErrHideNotSupported
 }
 
 // AddMarkdownTag appends a tag to the end of the given string. Bitbucket
@@ -528,9 +529,10 @@ func (h *bitbucketCommitHandler) CallDeleteComment(ctx context.Context, comment
 	return err
 }
 
-// CallHideComment calls the Bitbucket API to minimize the pull request comment.
+// CallHideComment returns ErrHideNotSupported since Bitbucket doesn't have a
+// feature for hiding comments.
 func (h *bitbucketCommitHandler) CallHideComment(ctx context.Context, comment Comment) error {
-	return errors.New("Not implemented")
+	return ErrHideNotSupported
 }
 
 // AddMarkdownTag appends a tag to the end of the given string. Bitbucket
@@ -750,9 +752,10 @@ func (h *bitbucketServerPRHandler) CallDeleteComment(ctx context.Context, commen
 	return err
 }
 
-// CallHideComment calls the Bitbucket Server API to minimize the pull request comment.
+// CallHideComment returns ErrHideNotSupported since Bitbucket doesn't have a
+// feature for hiding comments.
 func (h *bitbucketServerPRHandler) CallHideComment(ctx context.Context, comment Comment) error {
-	return errors.New("Not implemented")
+	return ErrHideNotSupported
 }
 
 // AddMarkdownTag appends a tag to the end of the given string. Bitbucket
@@ -0,0 +1,286 @@
+package comment
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// bitbucketReportID is the key of the Code Insights report, so each run
+// replaces the report of the previous run on the commit.
+const bitbucketReportID = "infracost"
+
+// The number of annotations Bitbucket Cloud accepts per request and Bitbucket
+// Server accepts per report.
+const (
+	maxBitbucketReportAnnotations       = 100
+	maxBitbucketServerReportAnnotations = 1000
+)
+
+// The severities of Code Insights annotations.
+const (
+	BitbucketSeverityLow    = "LOW"
+	BitbucketSeverityMedium = "MEDIUM"
+	BitbucketSeverityHigh   = "HIGH"
+)
+
+// BitbucketReport is a Code Insights report of the costs of a commit, with
+// annotations at the lines of its files. Data are the key figures shown in the
+// report, as text.
+type BitbucketReport struct {
+	Title       string
+	Details     string
+	Passed      bool
+	Data        []BitbucketReportData
+	Annotations []BitbucketReportAnnotation
+}
+
+// BitbucketReportData is a key figure of a Code Insights report.
+type BitbucketReportData struct {
+	Title string
+	Value string
+}
+
+// BitbucketReportAnnotation is a message about a line of a file of a Code
+// Insights report. ExternalID identifies it in the report.
+type BitbucketReportAnnotation struct {
+	ExternalID string
+	Path       string
+	Line       int
+	Summary    string
+	Severity   string
+}
+
+// CreateBitbucketReport creates the Code Insights report on the commit, or on
+// the source commit of the pull request if commitSHA is empty, replacing the
+// report of a previous run, for Bitbucket Cloud or Server.
+func CreateBitbucketReport(ctx context.Context, repo string, commitSHA string, prNumber int, extra BitbucketExtra, report BitbucketReport) error {
+	httpClient, err := newBitbucketAPIClient(ctx, extra.Token)
+	if err != nil {
+		return err
+	}
+
+	if strings.EqualFold(extra.ServerURL, bitbucketDefaultServerURL) || extra.ServerURL == "" {
+		return createBitbucketCloudReport(httpClient, repo, commitSHA, prNumber, report)
+	}
+
+	return createBitbucketServerReport(httpClient, extra.ServerURL, repo, commitSHA, prNumber, report)
+}
+
+func createBitbucketCloudReport(httpClient *http.Client, repo string, commitSHA string, prNumber int, report BitbucketReport) error {
+	apiURL := fmt.Sprintf("https://api.bitbucket.org/2.0/repositories/%s/", repo)
+
+	if commitSHA == "" {
+		var pr struct {
+			Source struct {
+				Commit struct {
+					Hash string `json:"hash"`
+				} `json:"commit"`
+			} `json:"source"`
+		}
+
+		err := bitbucketRequest(httpClient, "GET", fmt.Sprintf("%spullrequests/%d", apiURL, prNumber), nil, &pr)
+		if err != nil {
+			return errors.Wrap(err, "Error getting pull request")
+		}
+		commitSHA = pr.Source.Commit.Hash
+	}
+
+	reportURL := fmt.Sprintf("%scommit/%s/reports/%s", apiURL, commitSHA, bitbucketReportID)
+
+	// Deleting the report deletes its annotations, which would otherwise be
+	// added to the new ones.
+	err := bitbucketRequest(httpClient, "DELETE", reportURL, nil, nil)
+	if err != nil {
+		return errors.Wrap(err, "Error deleting Code Insights report")
+	}
+
+	result := "FAILED"
+	if report.Passed {
+		result = "PASSED"
+	}
+
+	data := make([]map[string]interface{}, 0, len(report.Data))
+	for _, d := range report.Data {
+		data = append(data, map[string]interface{}{"title": d.Title, "type": "TEXT", "value": d.Value})
+	}
+
+	err = bitbucketRequest(httpClient, "PUT", reportURL, map[string]interface{}{
+		"title":       report.Title,
+		"details":     report.Details,
+		"report_type": "TEST",
+		"reporter":    "Infracost",
+		"result":      result,
+		"data":        data,
+	}, nil)
+	if err != nil {
+		return errors.Wrap(err, "Error creating Code Insights report")
+	}
+
+	for start := 0; start < len(report.Annotations); start += maxBitbucketReportAnnotations {
+		end := start + maxBitbucketReportAnnotations
+		if end > len(report.Annotations) {
+			end = len(report.Annotations)
+		}
+
+		annotations := make([]map[string]interface{}, 0, end-start)
+		for _, a := range report.Annotations[start:end] {
+			annotations = append(annotations, map[string]interface{}{
+				"external_id":     a.ExternalID,
+				"annotation_type": "CODE_SMELL",
+				"summary":         a.Summary,
+				"severity":        a.Severity,
+				"path":            a.Path,
+				"line":            a.Line,
+			})
+		}
+
+		err = bitbucketRequest(httpClient, "POST", reportURL+"/annotations", annotations, nil)
+		if err != nil {
+			return errors.Wrap(err, "Error adding annotations to Code Insights report")
+		}
+	}
+
+	return nil
+}
+
+func createBitbucketServerReport(httpClient *http.Client, serverURL string, repo string, commitSHA string, prNumber int, report BitbucketReport) error {
+	serverRepo := strings.Split(repo, "/")
+	if len(serverRepo) != 2 {
+		return fmt.Errorf("Invalid Bitbucket Server repository name: %s, expecting project/repo", repo)
+	}
+
+	if !strings.HasSuffix(serverURL, "/") {
+		serverURL += "/"
+	}
+
+	if commitSHA == "" {
+		var pr struct {
+			FromRef struct {
+				LatestCommit string `json:"latestCommit"`
+			} `json:"fromRef"`
+		}
+
+		prURL := fmt.Sprintf("%srest/api/1.0/projects/%s/repos/%s/pull-requests/%d", serverURL, serverRepo[0], serverRepo[1], prNumber)
+		err := bitbucketRequest(httpClient, "GET", prURL, nil, &pr)
+		if err != nil {
+			return errors.Wrap(err, "Error getting pull request")
+		}
+		commitSHA = pr.FromRef.LatestCommit
+	}
+
+	reportURL := fmt.Sprintf("%srest/insights/1.0/projects/%s/repos/%s/commits/%s/reports/%s", serverURL, serverRepo[0], serverRepo[1], commitSHA, bitbucketReportID)
+
+	err := bitbucketRequest(httpClient, "DELETE", reportURL, nil, nil)
+	if err != nil {
+		return errors.Wrap(err, "Error deleting Code Insights report")
+	}
+
+	result := "FAIL"
+	if report.Passed {
+		result = "PASS"
+	}
+
+	data := make([]map[string]interface{}, 0, len(report.Data))
+	for _, d := range report.Data {
+		data = append(data, map[string]interface{}{"title": d.Title, "type": "TEXT", "value": d.Value})
+	}
+
+	err = bitbucketRequest(httpClient, "PUT", reportURL, map[string]interface{}{
+		"title":    report.Title,
+		"details":  report.Details,
+		"reporter": "Infracost",
+		"result":   result,
+		"data":     data,
+	}, nil)
+	if err != nil {
+		return errors.Wrap(err, "Error creating Code Insights report")
+	}
+
+	if len(report.Annotations) == 0 {
+		return nil
+	}
+
+	annotations := make([]map[string]interface{}, 0, len(report.Annotations))
+	for i, a := range report.Annotations {
+		if i == maxBitbucketServerReportAnnotations {
+			break
+		}
+
+		annotations = append(annotations, map[string]interface{}{
+			"externalId": a.ExternalID,
+			"type":       "CODE_SMELL",
+			"message":    a.Summary,
+			"severity":   a.Severity,
+			"path":       a.Path,
+			"line":       a.Line,
+		})
+	}
+
+	err = bitbucketRequest(httpClient, "POST", reportURL+"/annotations", map[string]interface{}{"annotations": annotations}, nil)
+	if err != nil {
+		return errors.Wrap(err, "Error adding annotations to Code Insights report")
+	}
+
+	return nil
+}
+
+// bitbucketRequest sends the request with the JSON of reqData as its body, if
+// it's set, and unmarshals the response into resData, if it's set. Deleting
+// something that doesn't exist isn't an error.
+func bitbucketRequest(httpClient *http.Client, method string, url string, reqData interface{}, resData interface{}) error {
+	var body *bytes.Buffer
+	if reqData != nil {
+		b, err := json.Marshal(reqData)
+		if err != nil {
+			return errors.Wrap(err, "Error marshaling request body")
+		}
+		body = bytes.NewBuffer(b)
+	} else {
+		body = &bytes.Buffer{}
+	}
+
+	req, err := http.NewRequest(method, url, body)
+	if err != nil {
+		return errors.Wrap(err, "Error creating request")
+	}
+	if reqData != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	res, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if method == "DELETE" && res.StatusCode == http.StatusNotFound {
+		return nil
+	}
+
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		return errors.Errorf("%s", res.Status)
+	}
+
+	if resData == nil {
+		return nil
+	}
+
+	resBody, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return errors.Wrap(err, "Error reading response body")
+	}
+
+	err = json.Unmarshal(resBody, resData)
+	if err != nil {
+		return errors.Wrap(err, "Error unmarshaling response body")
+	}
+
+	return nil
+}
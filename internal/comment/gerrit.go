@@ -0,0 +1,100 @@
+package comment
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// gerritReviewTag is the tag of the review messages. Gerrit treats the
+// messages of tags starting with autogenerated: as bot messages, which can be
+// hidden, and only shows the latest one of each tag by default.
+const gerritReviewTag = "autogenerated:infracost"
+
+// GerritExtra contains any extra inputs that can be passed to the Gerrit
+// review functions.
+type GerritExtra struct {
+	// Username and Password are the HTTP credentials of the Gerrit account,
+	// the password being the generated HTTP password.
+	Username string
+	Password string
+	// Tag is added to the tag of the review messages, so the messages of
+	// several pipelines don't hide each other.
+	Tag string
+}
+
+// GerritReview is a review of a revision of a change, a message and an
+// optional vote on a label.
+type GerritReview struct {
+	Message string
+	// Label is the name of the label to vote on, e.g. Cost-Review. There's no
+	// vote if it's empty.
+	Label string
+	Vote  int
+}
+
+// PostGerritReview posts the review on the revision of the change, or on its
+// current revision if revision is empty. The change is its number or any of
+// the identifiers Gerrit accepts, e.g. project~number. Review messages can't be
+// updated, so each run posts a new message.
+func PostGerritReview(ctx context.Context, serverURL string, change string, revision string, extra GerritExtra, review GerritReview) error {
+	if !strings.HasSuffix(serverURL, "/") {
+		serverURL += "/"
+	}
+
+	if revision == "" {
+		revision = "current"
+	}
+
+	tag := gerritReviewTag
+	if extra.Tag != "" {
+		tag = fmt.Sprintf("%s:%s", tag, extra.Tag)
+	}
+
+	reqData := map[string]interface{}{
+		"message": review.Message,
+		"tag":     tag,
+	}
+	if review.Label != "" {
+		reqData["labels"] = map[string]int{review.Label: review.Vote}
+	}
+
+	reqBody, err := json.Marshal(reqData)
+	if err != nil {
+		return errors.Wrap(err, "Error marshaling review")
+	}
+
+	// The /a/ prefix makes the request authenticated.
+	reviewURL := fmt.Sprintf("%sa/changes/%s/revisions/%s/review", serverURL, url.PathEscape(change), url.PathEscape(revision))
+
+	req, err := http.NewRequestWithContext(ctx, "POST", reviewURL, bytes.NewBuffer(reqBody))
+	if err != nil {
+		return errors.Wrap(err, "Error creating request")
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if extra.Username != "" {
+		req.SetBasicAuth(extra.Username, extra.Password)
+	}
+
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "Error posting review")
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		// Gerrit explains the errors in plain text, e.g. when the vote is out of
+		// the range of the label.
+		resBody, _ := ioutil.ReadAll(res.Body)
+		return errors.Errorf("Error posting review: %s %s", res.Status, strings.TrimSpace(string(resBody)))
+	}
+
+	return nil
+}
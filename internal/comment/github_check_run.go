@@ -0,0 +1,123 @@
+package comment
+
+import (
+	"context"
+
+	"github.com/google/go-github/v41/github"
+	"github.com/pkg/errors"
+)
+
+// maxGitHubCheckRunAnnotations is the number of annotations the GitHub API
+// accepts per request, the others are added by updating the check run.
+const maxGitHubCheckRunAnnotations = 50
+
+// maxGitHubCheckRunSummary is the max length of the summary of a check run.
+const maxGitHubCheckRunSummary = 65535
+
+// The conclusions of a GitHub check run.
+const (
+	GitHubCheckRunSuccess = "success"
+	GitHubCheckRunNeutral = "neutral"
+	GitHubCheckRunFailure = "failure"
+)
+
+// GitHubCheckRun is a completed check run with the costs of a commit as its
+// markdown Summary and annotations at the lines of its files.
+type GitHubCheckRun struct {
+	Name        string
+	Title       string
+	Summary     string
+	Conclusion  string
+	Annotations []GitHubCheckRunAnnotation
+}
+
+// GitHubCheckRunAnnotation is a message about lines of a file of a check run.
+// Level is notice, warning or failure.
+type GitHubCheckRunAnnotation struct {
+	Path      string
+	StartLine int
+	EndLine   int
+	Level     string
+	Title     string
+	Message   string
+}
+
+// CreateGitHubCheckRun creates the check run on the commit, or on the head
+// commit of the pull request if commitSHA is empty, and returns its URL.
+func CreateGitHubCheckRun(ctx context.Context, project string, commitSHA string, prNumber int, extra GitHubExtra, run GitHubCheckRun) (string, error) {
+	owner, repo, err := splitGitHubProject(project)
+	if err != nil {
+		return "", err
+	}
+
+	v3client, _, err := newGitHubAPIClients(ctx, extra.Token, extra.APIURL)
+	if err != nil {
+		return "", err
+	}
+
+	if commitSHA == "" {
+		pr, _, err := v3client.PullRequests.Get(ctx, owner, repo, prNumber)
+		if err != nil {
+			return "", errors.Wrap(err, "Error getting pull request")
+		}
+
+		commitSHA = pr.GetHead().GetSHA()
+	}
+
+	summary := run.Summary
+	if len(summary) > maxGitHubCheckRunSummary {
+		summary = summary[:maxGitHubCheckRunSummary]
+	}
+
+	var batches [][]*github.CheckRunAnnotation
+	for i, a := range run.Annotations {
+		if i%maxGitHubCheckRunAnnotations == 0 {
+			batches = append(batches, nil)
+		}
+
+		batches[len(batches)-1] = append(batches[len(batches)-1], &github.CheckRunAnnotation{
+			Path:            github.String(a.Path),
+			StartLine:       github.Int(a.StartLine),
+			EndLine:         github.Int(a.EndLine),
+			AnnotationLevel: github.String(a.Level),
+			Title:           github.String(a.Title),
+			Message:         github.String(a.Message),
+		})
+	}
+
+	output := func(i int) *github.CheckRunOutput {
+		o := &github.CheckRunOutput{
+			Title:   github.String(run.Title),
+			Summary: github.String(summary),
+		}
+		if i < len(batches) {
+			o.Annotations = batches[i]
+		}
+
+		return o
+	}
+
+	checkRun, _, err := v3client.Checks.CreateCheckRun(ctx, owner, repo, github.CreateCheckRunOptions{
+		Name:       run.Name,
+		HeadSHA:    commitSHA,
+		Status:     github.String("completed"),
+		Conclusion: github.String(run.Conclusion),
+		Output:     output(0),
+	})
+	if err != nil {
+		return "", errors.Wrap(err, "Error creating check run")
+	}
+
+	// Updating a check run adds the annotations to the ones it already has.
+	for i := 1; i < len(batches); i++ {
+		_, _, err = v3client.Checks.UpdateCheckRun(ctx, owner, repo, checkRun.GetID(), github.UpdateCheckRunOptions{
+			Name:   run.Name,
+			Output: output(i),
+		})
+		if err != nil {
+			return "", errors.Wrap(err, "Error adding annotations to check run")
+		}
+	}
+
+	return checkRun.GetHTMLURL(), nil
+}
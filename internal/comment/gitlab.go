@@ -287,9 +287,10 @@ func (h *gitlabPRHandler) CallDeleteComment(ctx context.Context, comment Comment
 	return h.graphqlClient.Mutate(ctx, &m, variables)
 }
 
-// CallHideComment calls the GitLab API to minimize the merge request comment.
+// CallHideComment returns ErrHideNotSupported since GitLab doesn't have a
+// feature for hiding comments.
 func (h *gitlabPRHandler) CallHideComment(ctx context.Context, comment Comment) error {
-	return errors.New("Not implemented")
+	return ErrHideNotSupported
 }
 
 // AddMarkdownTag prepends a tag as a markdown comment to the given string.
@@ -536,9 +537,10 @@ func (h *gitlabCommitHandler) CallDeleteComment(ctx context.Context, comment Com
 	return nil
 }
 
-// CallHideComment calls the GitLab API to minimize the commit comment.
+// CallHideComment returns ErrHideNotSupported since GitLab doesn't have a
+// feature for hiding comments.
 func (h *gitlabCommitHandler) CallHideComment(ctx context.Context, comment Comment) error {
-	return errors.New("Not implemented")
+	return ErrHideNotSupported
 }
 
 // AddMarkdownTag prepends a tag as a markdown comment to the given string.
@@ -3,13 +3,29 @@ package comment
 import (
 	"context"
 	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
 	"github.com/fatih/color"
+	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
-	"sort"
 )
 
 var defaultTag = "infracost-comment"
 
+// ErrHideNotSupported is returned by the platform handlers of the platforms
+// that don't have a feature for hiding comments. The body of the comments is
+// replaced with outdatedCommentNote instead.
+var ErrHideNotSupported = errors.New("Hiding comments is not supported")
+
+// outdatedCommentNote is the body of the hidden comments on the platforms that
+// don't have a feature for hiding comments.
+const outdatedCommentNote = "*This Infracost comment is outdated, see the latest one below.*"
+
+// commentKeyPattern matches the key of a run that follows the tag of a comment.
+var commentKeyPattern = regexp.MustCompile(`^, key: ([0-9a-f]+)`)
+
 // Comment is an interface that represents a comment on any platform. It wraps
 // the platform specific comment structures and is used to abstract the
 // logic for finding, creating, updating, and deleting the comments.
@@ -48,7 +64,7 @@ type PlatformHandler interface {
 
 	// CallHideComment calls the platform-specific API to minimize the comment.
 	// This functionality is not supported by all platforms, in which case this
-	// will throw a NotImplemented error.
+	// returns ErrHideNotSupported.
 	CallHideComment(ctx context.Context, comment Comment) error
 
 	// AddMarkdownTag adds a tag to the given string.
@@ -60,6 +76,11 @@ type PlatformHandler interface {
 type CommentHandler struct { //nolint
 	PlatformHandler PlatformHandler
 	Tag             string
+	// Key identifies the comments of the runs on the same projects, see
+	// CommentKey. When it's set, it's added after the tag, and updating a
+	// comment deletes the visible comments of previous runs on other projects
+	// and the duplicates of the comment.
+	Key string
 }
 
 // NewCommentHandler creates a new CommentHandler.
@@ -74,6 +95,10 @@ func NewCommentHandler(ctx context.Context, platformHandler PlatformHandler, tag
 	}
 }
 
+// Behaviors are the behaviors supported by CommentWithBehavior on every
+// platform.
+var Behaviors = []string{"update", "new", "hide-and-new", "delete-and-new"}
+
 // CommentWithBehavior parses the behavior and calls the corresponding *Comment method.
 func (h *CommentHandler) CommentWithBehavior(ctx context.Context, behavior, body string) error {
 	var err error
@@ -114,6 +139,21 @@ func (h *CommentHandler) matchingComments(ctx context.Context) ([]Comment, error
 
 // LatestMatchingComment returns the latest matching comment.
 func (h *CommentHandler) LatestMatchingComment(ctx context.Context) (Comment, error) {
+	matchingComments, err := h.sortedMatchingComments(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(matchingComments) == 0 {
+		return nil, nil
+	}
+
+	return matchingComments[len(matchingComments)-1], nil
+}
+
+// sortedMatchingComments returns all comments that match the tag, from the
+// oldest to the latest.
+func (h *CommentHandler) sortedMatchingComments(ctx context.Context) ([]Comment, error) {
 	matchingComments, err := h.matchingComments(ctx)
 	if err != nil {
 		return nil, err
@@ -123,33 +163,97 @@ func (h *CommentHandler) LatestMatchingComment(ctx context.Context) (Comment, er
 		return matchingComments[i].Less(matchingComments[j])
 	})
 
-	if len(matchingComments) == 0 {
-		return nil, nil
+	return matchingComments, nil
+}
+
+// tag returns the tag of the comments of the run, with its key if it's set.
+func (h *CommentHandler) tag() string {
+	if h.Key == "" {
+		return h.Tag
 	}
 
-	return matchingComments[len(matchingComments)-1], nil
+	return fmt.Sprintf("%s, key: %s", h.Tag, h.Key)
 }
 
-// UpdateComment updates the comment with the given body.
+// isCurrent returns true if the comment can be updated by the run, i.e. it's
+// visible and has the key of the run, or no key because it was posted by an
+// older version.
+func (h *CommentHandler) isCurrent(comment Comment) bool {
+	if h.isHidden(comment) {
+		return false
+	}
+
+	if h.Key == "" {
+		return true
+	}
+
+	key := h.commentKey(comment)
+
+	return key == "" || key == h.Key
+}
+
+// commentKey returns the key that follows the tag of the comment, or an empty
+// string if it has none.
+func (h *CommentHandler) commentKey(comment Comment) string {
+	body := comment.Body()
+
+	i := strings.Index(body, h.Tag)
+	if i == -1 {
+		return ""
+	}
+
+	m := commentKeyPattern.FindStringSubmatch(body[i+len(h.Tag):])
+	if m == nil {
+		return ""
+	}
+
+	return m[1]
+}
+
+// isHidden returns true if the comment is hidden, or its body has been replaced
+// with outdatedCommentNote.
+func (h *CommentHandler) isHidden(comment Comment) bool {
+	return comment.IsHidden() || strings.Contains(comment.Body(), outdatedCommentNote)
+}
+
+// UpdateComment updates the latest comment of the run with the given body, or
+// creates it if there's none. When the handler has a key, the other visible
+// matching comments are stale, e.g. they're about projects that are no longer
+// in the change, and they're deleted.
 func (h *CommentHandler) UpdateComment(ctx context.Context, body string) error {
-	bodyWithTag := h.PlatformHandler.AddMarkdownTag(body, h.Tag)
+	bodyWithTag := h.PlatformHandler.AddMarkdownTag(body, h.tag())
 
-	latestMatchingComment, err := h.LatestMatchingComment(ctx)
+	matchingComments, err := h.sortedMatchingComments(ctx)
 	if err != nil {
 		return err
 	}
 
+	var latestMatchingComment Comment
+	var staleComments []Comment
+
+	for i := len(matchingComments) - 1; i >= 0; i-- {
+		comment := matchingComments[i]
+
+		if latestMatchingComment == nil && h.isCurrent(comment) {
+			latestMatchingComment = comment
+			continue
+		}
+
+		if h.Key != "" && !h.isHidden(comment) {
+			staleComments = append(staleComments, comment)
+		}
+	}
+
 	if latestMatchingComment != nil {
 		if latestMatchingComment.Body() == bodyWithTag {
 			log.Infof("Not updating comment since the latest one matches exactly: %s", color.HiBlueString(latestMatchingComment.Ref()))
-			return nil
-		}
-
-		log.Infof("Updating comment %s", color.HiBlueString(latestMatchingComment.Ref()))
+		} else {
+			log.Infof("Updating comment %s", color.HiBlueString(latestMatchingComment.Ref()))
 
-		err := h.PlatformHandler.CallUpdateComment(ctx, latestMatchingComment, bodyWithTag)
-		if err != nil {
-			return err
+			err := h.PlatformHandler.CallUpdateComment(ctx, latestMatchingComment, bodyWithTag)
+			if err != nil {
+				return err
+			}
 		}
 	} else {
 		log.Info("Creating new comment")
@@ -162,12 +266,12 @@ func (h *CommentHandler) UpdateComment(ctx context.Context, body string) error {
 		log.Infof("Created new comment %s", color.HiBlueString(comment.Ref()))
 	}
 
-	return nil
+	return h.deleteComments(ctx, staleComments)
 }
 
 // NewComment creates a new comment with the given body.
 func (h *CommentHandler) NewComment(ctx context.Context, body string) error {
-	bodyWithTag := h.PlatformHandler.AddMarkdownTag(body, h.Tag)
+	bodyWithTag := h.PlatformHandler.AddMarkdownTag(body, h.tag())
 
 	log.Info("Creating new comment")
 
@@ -182,6 +286,8 @@ func (h *CommentHandler) NewComment(ctx context.Context, body string) error {
 }
 
 // HideAndNewComment hides/minimizes all existing matching comment and creates a new one with the given body.
+// On the platforms that don't have a feature for hiding comments, the body of
+// the comments is replaced with a note that they're outdated.
 func (h *CommentHandler) HideAndNewComment(ctx context.Context, body string) error {
 	matchingComments, err := h.matchingComments(ctx)
 	if err != nil {
@@ -201,7 +307,7 @@ func (h *CommentHandler) hideComments(ctx context.Context, comments []Comment) e
 	visibleComments := []Comment{}
 
 	for _, comment := range comments {
-		if !comment.IsHidden() {
+		if !h.isHidden(comment) {
 			visibleComments = append(visibleComments, comment)
 		}
 	}
@@ -223,6 +329,9 @@ func (h *CommentHandler) hideComments(ctx context.Context, comments []Comment) e
 	for _, comment := range visibleComments {
 		log.Infof("Hiding comment %s", color.HiBlueString(comment.Ref()))
 		err := h.PlatformHandler.CallHideComment(ctx, comment)
+		if errors.Is(err, ErrHideNotSupported) {
+			err = h.PlatformHandler.CallUpdateComment(ctx, comment, h.PlatformHandler.AddMarkdownTag(outdatedCommentNote, h.Tag))
+		}
 		if err != nil {
 			return err
 		}
@@ -246,8 +355,12 @@ func (h *CommentHandler) DeleteAndNewComment(ctx context.Context, body string) e
 	return h.NewComment(ctx, body)
 }
 
-// deleteComments hides/minimizes all the given comments.
+// deleteComments deletes all the given comments.
 func (h *CommentHandler) deleteComments(ctx context.Context, comments []Comment) error {
+	if len(comments) == 0 {
+		return nil
+	}
+
 	if len(comments) == 1 {
 		log.Info("Deleting 1 comment")
 	} else {
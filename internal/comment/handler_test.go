@@ -0,0 +1,145 @@
+package comment
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeComment struct {
+	id     int
+	body   string
+	hidden bool
+}
+
+func (c *fakeComment) Body() string { return c.body }
+
+func (c *fakeComment) Ref() string { return strconv.Itoa(c.id) }
+
+func (c *fakeComment) Less(other Comment) bool { return c.id < other.(*fakeComment).id }
+
+func (c *fakeComment) IsHidden() bool { return c.hidden }
+
+// fakePlatformHandler keeps the comments in memory. It can hide comments if
+// canHide is set.
+type fakePlatformHandler struct {
+	comments []*fakeComment
+	canHide  bool
+}
+
+func (h *fakePlatformHandler) CallFindMatchingComments(ctx context.Context, tag string) ([]Comment, error) {
+	var comments []Comment
+	for _, c := range h.comments {
+		if strings.Contains(c.body, tag) {
+			comments = append(comments, c)
+		}
+	}
+
+	return comments, nil
+}
+
+func (h *fakePlatformHandler) CallCreateComment(ctx context.Context, body string) (Comment, error) {
+	c := &fakeComment{id: len(h.comments) + 1, body: body}
+	h.comments = append(h.comments, c)
+
+	return c, nil
+}
+
+func (h *fakePlatformHandler) CallUpdateComment(ctx context.Context, comment Comment, body string) error {
+	comment.(*fakeComment).body = body
+
+	return nil
+}
+
+func (h *fakePlatformHandler) CallDeleteComment(ctx context.Context, comment Comment) error {
+	for i, c := range h.comments {
+		if c == comment {
+			h.comments = append(h.comments[:i], h.comments[i+1:]...)
+			break
+		}
+	}
+
+	return nil
+}
+
+func (h *fakePlatformHandler) CallHideComment(ctx context.Context, comment Comment) error {
+	if !h.canHide {
+		return ErrHideNotSupported
+	}
+
+	comment.(*fakeComment).hidden = true
+
+	return nil
+}
+
+func (h *fakePlatformHandler) AddMarkdownTag(s string, tag string) string {
+	return addMarkdownTag(s, tag)
+}
+
+func bodies(h *fakePlatformHandler) []string {
+	var b []string
+	for _, c := range h.comments {
+		b = append(b, c.body)
+	}
+
+	return b
+}
+
+func TestUpdateCommentWithKey(t *testing.T) {
+	ctx := context.Background()
+	p := &fakePlatformHandler{}
+
+	prodKey := CommentKey("my-org/my-repo", "3", []string{"prod", "dev"})
+	assert.Equal(t, prodKey, CommentKey("my-org/my-repo", "3", []string{"dev", "prod"}))
+
+	h := NewCommentHandler(ctx, p, "")
+	h.Key = prodKey
+	require.NoError(t, h.UpdateComment(ctx, "first"))
+	require.NoError(t, h.UpdateComment(ctx, "second"))
+	assert.Equal(t, []string{"[//]: <> (infracost-comment, key: " + prodKey + ")\nsecond"}, bodies(p))
+
+	// The comment of the previous run on other projects is stale.
+	h.Key = CommentKey("my-org/my-repo", "3", []string{"dev"})
+	require.NoError(t, h.UpdateComment(ctx, "third"))
+	assert.Equal(t, []string{"[//]: <> (infracost-comment, key: " + h.Key + ")\nthird"}, bodies(p))
+}
+
+func TestUpdateCommentWithoutKey(t *testing.T) {
+	ctx := context.Background()
+	p := &fakePlatformHandler{comments: []*fakeComment{
+		{id: 1, body: "[//]: <> (infracost-comment)\nold"},
+		{id: 2, body: "[//]: <> (infracost-comment)\nlatest"},
+	}}
+
+	// The comments posted without a key are updated in place.
+	h := NewCommentHandler(ctx, p, "")
+	h.Key = CommentKey("my-org/my-repo", "3", []string{"prod"})
+	require.NoError(t, h.UpdateComment(ctx, "new"))
+	assert.Equal(t, []string{"[//]: <> (infracost-comment, key: " + h.Key + ")\nnew"}, bodies(p))
+}
+
+func TestHideAndNewComment(t *testing.T) {
+	ctx := context.Background()
+
+	p := &fakePlatformHandler{canHide: true, comments: []*fakeComment{{id: 1, body: "[//]: <> (infracost-comment)\nold"}}}
+	h := NewCommentHandler(ctx, p, "")
+	require.NoError(t, h.HideAndNewComment(ctx, "new"))
+	require.Len(t, p.comments, 2)
+	assert.True(t, p.comments[0].hidden)
+	assert.Equal(t, "[//]: <> (infracost-comment)\nold", p.comments[0].body)
+
+	// The platforms that can't hide comments get an outdated note instead.
+	p = &fakePlatformHandler{comments: []*fakeComment{{id: 1, body: "[//]: <> (infracost-comment)\nold"}}}
+	h = NewCommentHandler(ctx, p, "")
+	require.NoError(t, h.HideAndNewComment(ctx, "new"))
+	assert.Equal(t, []string{"[//]: <> (infracost-comment)\n" + outdatedCommentNote, "[//]: <> (infracost-comment)\nnew"}, bodies(p))
+
+	// Hidden comments aren't updated nor deleted as stale.
+	h.Key = CommentKey("my-org/my-repo", "3", []string{"prod"})
+	require.NoError(t, h.UpdateComment(ctx, "newer"))
+	assert.Equal(t, []string{"[//]: <> (infracost-comment)\n" + outdatedCommentNote, "[//]: <> (infracost-comment, key: " + h.Key + ")\nnewer"}, bodies(p))
+}
@@ -0,0 +1,120 @@
+package comment
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/google/go-github/v41/github"
+	"github.com/pkg/errors"
+)
+
+// otherLabels returns the labels that aren't the label.
+func otherLabels(labels []string, label string) []string {
+	var others []string
+	for _, l := range labels {
+		if l != label {
+			others = append(others, l)
+		}
+	}
+
+	return others
+}
+
+// SetGitHubPullRequestLabel adds the label to the pull request and removes the
+// other labels of bandLabels it has, so it's only labeled with the band of its
+// current cost change. All the labels are removed if label is empty.
+func SetGitHubPullRequestLabel(ctx context.Context, project string, prNumber int, extra GitHubExtra, label string, bandLabels []string) error {
+	owner, repo, err := splitGitHubProject(project)
+	if err != nil {
+		return err
+	}
+
+	v3client, _, err := newGitHubAPIClients(ctx, extra.Token, extra.APIURL)
+	if err != nil {
+		return err
+	}
+
+	current, _, err := v3client.Issues.ListLabelsByIssue(ctx, owner, repo, prNumber, &github.ListOptions{PerPage: 100})
+	if err != nil {
+		return errors.Wrap(err, "Error getting pull request labels")
+	}
+
+	has := map[string]bool{}
+	for _, l := range current {
+		has[l.GetName()] = true
+	}
+
+	for _, l := range otherLabels(bandLabels, label) {
+		if !has[l] {
+			continue
+		}
+
+		_, err = v3client.Issues.RemoveLabelForIssue(ctx, owner, repo, prNumber, l)
+		if err != nil {
+			return errors.Wrapf(err, "Error removing label %s", l)
+		}
+	}
+
+	if label != "" && !has[label] {
+		_, _, err = v3client.Issues.AddLabelsToIssue(ctx, owner, repo, prNumber, []string{label})
+		if err != nil {
+			return errors.Wrapf(err, "Error adding label %s", label)
+		}
+	}
+
+	return nil
+}
+
+// SetGitLabMergeRequestLabel adds the label to the merge request and removes
+// the other labels of bandLabels, so it's only labeled with the band of its
+// current cost change. All the labels are removed if label is empty.
+func SetGitLabMergeRequestLabel(ctx context.Context, project string, mrNumber int, extra GitLabExtra, label string, bandLabels []string) error {
+	serverURL := extra.ServerURL
+	if serverURL == "" {
+		serverURL = "https://gitlab.com"
+	}
+
+	httpClient, _, err := newGitLabAPIClients(ctx, extra.Token, serverURL)
+	if err != nil {
+		return err
+	}
+
+	// GitLab adds and removes the labels in a single update, and creates the
+	// labels that don't exist yet.
+	data := map[string]string{
+		"remove_labels": strings.Join(otherLabels(bandLabels, label), ","),
+	}
+	if label != "" {
+		data["add_labels"] = label
+	}
+
+	reqData, err := json.Marshal(data)
+	if err != nil {
+		return errors.Wrap(err, "Error marshaling labels")
+	}
+
+	u := fmt.Sprintf("%s/api/v4/projects/%s/merge_requests/%d", strings.TrimSuffix(serverURL, "/"), url.PathEscape(project), mrNumber)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, u, bytes.NewBuffer(reqData))
+	if err != nil {
+		return errors.Wrap(err, "Error creating request")
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	res, err := httpClient.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "Error updating merge request labels")
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return errors.Errorf("Error updating merge request labels: %s", res.Status)
+	}
+
+	return nil
+}
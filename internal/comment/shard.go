@@ -0,0 +1,306 @@
+package comment
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// shardTag is the tag of the comments that store the Infracost JSON of the
+// shards, it's followed by the run and shard of the comment.
+const shardTag = "infracost-shard"
+
+// maxShardCommentLength is the longest comment that stores a shard, below the
+// 65536 character limit of GitHub comments, the lowest of the platforms.
+const maxShardCommentLength = 60000
+
+var shardPattern = regexp.MustCompile(`^(\d+)/(\d+)$`)
+
+var shardCommentPattern = regexp.MustCompile(`shard: (\d+)/(\d+)\)`)
+
+var shardDataPattern = regexp.MustCompile(`<!-- infracost-shard-data: ([A-Za-z0-9+/=]+) -->`)
+
+// Shard is one of the parallel CI jobs that an estimate is split across, e.g.
+// the projects of a monorepo. Index is from 1 to Count.
+type Shard struct {
+	Index int
+	Count int
+}
+
+// ParseShard parses a shard given as index/count, e.g. 2/4.
+func ParseShard(s string) (Shard, error) {
+	m := shardPattern.FindStringSubmatch(strings.TrimSpace(s))
+	if m == nil {
+		return Shard{}, fmt.Errorf("Invalid shard %q, it must be the index of the shard and the number of shards, e.g. 2/4", s)
+	}
+
+	index, _ := strconv.Atoi(m[1])
+	count, _ := strconv.Atoi(m[2])
+	if index < 1 || index > count {
+		return Shard{}, fmt.Errorf("Invalid shard %q, the index must be from 1 to the number of shards", s)
+	}
+
+	return Shard{Index: index, Count: count}, nil
+}
+
+func (s Shard) String() string {
+	return fmt.Sprintf("%d/%d", s.Index, s.Count)
+}
+
+// ShardStore stores the Infracost JSON of the shards of a CI run until all of
+// them have reported, so the last one to finish can post the comment of their
+// combined estimate. run identifies the CI run, e.g. its pipeline ID.
+type ShardStore interface {
+	// PutShard stores the Infracost JSON of the shard, replacing the JSON it
+	// stored before if the shard's job is rerun.
+	PutShard(ctx context.Context, run string, shard Shard, data []byte) error
+
+	// GetShards returns the Infracost JSON of the shards of the run that have
+	// been stored, by shard index.
+	GetShards(ctx context.Context, run string, count int) (map[int][]byte, error)
+
+	// DeleteShards deletes the Infracost JSON of the shards of the run once
+	// their combined estimate is posted, if the store supports it.
+	DeleteShards(ctx context.Context, run string) error
+}
+
+// CollectShards stores the Infracost JSON of the shard, unless dryRun is set,
+// and returns the Infracost JSON of all the shards of the run once they've all
+// reported. Otherwise it returns nil and the number of shards that have
+// reported.
+func CollectShards(ctx context.Context, store ShardStore, run string, shard Shard, data []byte, dryRun bool) ([][]byte, int, error) {
+	if !dryRun {
+		err := store.PutShard(ctx, run, shard, data)
+		if err != nil {
+			return nil, 0, errors.Wrapf(err, "Error storing shard %s", shard)
+		}
+	}
+
+	stored, err := store.GetShards(ctx, run, shard.Count)
+	if err != nil {
+		return nil, 0, errors.Wrap(err, "Error getting the shards")
+	}
+	stored[shard.Index] = data
+
+	if len(stored) < shard.Count {
+		return nil, len(stored), nil
+	}
+
+	all := make([][]byte, 0, shard.Count)
+	for i := 1; i <= shard.Count; i++ {
+		all = append(all, stored[i])
+	}
+
+	return all, shard.Count, nil
+}
+
+// vcsShardStore stores the shards in comments of the pull request or commit,
+// which are deleted once the combined estimate is posted.
+type vcsShardStore struct {
+	platformHandler PlatformHandler
+}
+
+// NewVCSShardStore returns a ShardStore that stores the shards in comments of
+// the pull request or commit of the platform handler.
+func NewVCSShardStore(platformHandler PlatformHandler) ShardStore {
+	return &vcsShardStore{platformHandler: platformHandler}
+}
+
+func (s *vcsShardStore) runTag(run string) string {
+	return fmt.Sprintf("%s, run: %s,", shardTag, run)
+}
+
+func (s *vcsShardStore) PutShard(ctx context.Context, run string, shard Shard, data []byte) error {
+	encoded, err := encodeShardData(data)
+	if err != nil {
+		return err
+	}
+
+	body := fmt.Sprintf("*Infracost shard %d of %d has reported, the cost estimate is posted once all the shards have.*\n\n<!-- infracost-shard-data: %s -->", shard.Index, shard.Count, encoded)
+	tag := fmt.Sprintf("%s shard: %s", s.runTag(run), shard)
+	body = s.platformHandler.AddMarkdownTag(body, tag)
+
+	if len(body) > maxShardCommentLength {
+		return errors.New("its Infracost JSON is too large to store in a comment, set --shard-state to a URL instead")
+	}
+
+	comments, err := s.platformHandler.CallFindMatchingComments(ctx, tag+")")
+	if err != nil {
+		return err
+	}
+
+	if len(comments) > 0 {
+		return s.platformHandler.CallUpdateComment(ctx, comments[0], body)
+	}
+
+	_, err = s.platformHandler.CallCreateComment(ctx, body)
+	return err
+}
+
+func (s *vcsShardStore) GetShards(ctx context.Context, run string, count int) (map[int][]byte, error) {
+	comments, err := s.platformHandler.CallFindMatchingComments(ctx, s.runTag(run))
+	if err != nil {
+		return nil, err
+	}
+
+	shards := map[int][]byte{}
+	for _, c := range comments {
+		m := shardCommentPattern.FindStringSubmatch(c.Body())
+		d := shardDataPattern.FindStringSubmatch(c.Body())
+		if m == nil || d == nil || m[2] != strconv.Itoa(count) {
+			continue
+		}
+
+		index, _ := strconv.Atoi(m[1])
+
+		data, err := decodeShardData(d[1])
+		if err != nil {
+			return nil, errors.Wrapf(err, "Error reading shard %s of comment %s", m[1]+"/"+m[2], c.Ref())
+		}
+
+		shards[index] = data
+	}
+
+	return shards, nil
+}
+
+func (s *vcsShardStore) DeleteShards(ctx context.Context, run string) error {
+	comments, err := s.platformHandler.CallFindMatchingComments(ctx, s.runTag(run))
+	if err != nil {
+		return err
+	}
+
+	for _, c := range comments {
+		err := s.platformHandler.CallDeleteComment(ctx, c)
+		if err != nil {
+			return errors.Wrapf(err, "Error deleting shard comment %s", c.Ref())
+		}
+	}
+
+	return nil
+}
+
+// encodeShardData gzips the Infracost JSON so more projects fit in a comment.
+func encodeShardData(data []byte) (string, error) {
+	var buf bytes.Buffer
+
+	zw := gzip.NewWriter(&buf)
+	_, err := zw.Write(data)
+	if err != nil {
+		return "", err
+	}
+
+	err = zw.Close()
+	if err != nil {
+		return "", err
+	}
+
+	return base64.StdEncoding.EncodeToString(buf.Bytes()), nil
+}
+
+func decodeShardData(encoded string) ([]byte, error) {
+	b, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, err
+	}
+
+	zr, err := gzip.NewReader(bytes.NewReader(b))
+	if err != nil {
+		return nil, err
+	}
+	defer zr.Close()
+
+	return io.ReadAll(zr)
+}
+
+// urlShardStore stores the shards as files under an HTTP(S) URL, which are
+// uploaded with PUT requests and downloaded with GET requests.
+type urlShardStore struct {
+	baseURL    string
+	httpClient *http.Client
+}
+
+// NewURLShardStore returns a ShardStore that stores the shards at
+// <baseURL>/<run>/shard-<index>-of-<count>.json.
+func NewURLShardStore(baseURL string) ShardStore {
+	return &urlShardStore{
+		baseURL:    strings.TrimSuffix(baseURL, "/"),
+		httpClient: &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+func (s *urlShardStore) shardURL(run string, index int, count int) string {
+	return fmt.Sprintf("%s/%s/shard-%d-of-%d.json", s.baseURL, run, index, count)
+}
+
+func (s *urlShardStore) PutShard(ctx context.Context, run string, shard Shard, data []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, s.shardURL(run, shard.Index, shard.Count), bytes.NewReader(data))
+	if err != nil {
+		return errors.Wrap(err, "Error creating request")
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	res, err := s.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return errors.Errorf("unexpected status %s", res.Status)
+	}
+
+	return nil
+}
+
+func (s *urlShardStore) GetShards(ctx context.Context, run string, count int) (map[int][]byte, error) {
+	shards := map[int][]byte{}
+
+	for i := 1; i <= count; i++ {
+		u := s.shardURL(run, i, count)
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+		if err != nil {
+			return nil, errors.Wrap(err, "Error creating request")
+		}
+
+		res, err := s.httpClient.Do(req)
+		if err != nil {
+			return nil, err
+		}
+
+		data, err := io.ReadAll(res.Body)
+		res.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+
+		// The shards that haven't reported yet aren't found.
+		if res.StatusCode == http.StatusNotFound || res.StatusCode == http.StatusForbidden {
+			continue
+		}
+		if res.StatusCode != http.StatusOK {
+			return nil, errors.Errorf("unexpected status %s getting %s", res.Status, u)
+		}
+
+		shards[i] = data
+	}
+
+	return shards, nil
+}
+
+// DeleteShards leaves the shards, the storage behind the URL is expected to
+// expire them.
+func (s *urlShardStore) DeleteShards(ctx context.Context, run string) error {
+	return nil
+}
@@ -0,0 +1,101 @@
+package comment
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseShard(t *testing.T) {
+	s, err := ParseShard("2/4")
+	require.NoError(t, err)
+	assert.Equal(t, Shard{Index: 2, Count: 4}, s)
+	assert.Equal(t, "2/4", s.String())
+
+	for _, invalid := range []string{"", "2", "0/4", "5/4", "a/b"} {
+		_, err := ParseShard(invalid)
+		assert.Error(t, err, invalid)
+	}
+}
+
+func TestCollectShardsVCS(t *testing.T) {
+	ctx := context.Background()
+	p := &fakePlatformHandler{}
+	store := NewVCSShardStore(p)
+
+	all, reported, err := CollectShards(ctx, store, "42", Shard{Index: 2, Count: 3}, []byte(`{"shard":2}`), false)
+	require.NoError(t, err)
+	assert.Nil(t, all)
+	assert.Equal(t, 1, reported)
+
+	// A rerun of a shard replaces its comment.
+	_, reported, err = CollectShards(ctx, store, "42", Shard{Index: 2, Count: 3}, []byte(`{"shard":2}`), false)
+	require.NoError(t, err)
+	assert.Equal(t, 1, reported)
+	assert.Len(t, p.comments, 1)
+
+	// The shards of other runs aren't combined.
+	_, _, err = CollectShards(ctx, store, "41", Shard{Index: 1, Count: 3}, []byte(`{"shard":"old"}`), false)
+	require.NoError(t, err)
+
+	_, reported, err = CollectShards(ctx, store, "42", Shard{Index: 3, Count: 3}, []byte(`{"shard":3}`), false)
+	require.NoError(t, err)
+	assert.Equal(t, 2, reported)
+
+	all, reported, err = CollectShards(ctx, store, "42", Shard{Index: 1, Count: 3}, []byte(`{"shard":1}`), false)
+	require.NoError(t, err)
+	assert.Equal(t, 3, reported)
+	assert.Equal(t, [][]byte{[]byte(`{"shard":1}`), []byte(`{"shard":2}`), []byte(`{"shard":3}`)}, all)
+
+	require.NoError(t, store.DeleteShards(ctx, "42"))
+	require.Len(t, p.comments, 1)
+	assert.Contains(t, p.comments[0].body, "run: 41,")
+}
+
+func TestCollectShardsURL(t *testing.T) {
+	var mu sync.Mutex
+	stored := map[string][]byte{}
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		switch r.Method {
+		case http.MethodPut:
+			stored[r.URL.Path], _ = io.ReadAll(r.Body)
+			w.WriteHeader(http.StatusCreated)
+		case http.MethodGet:
+			data, ok := stored[r.URL.Path]
+			if !ok {
+				http.NotFound(w, r)
+				return
+			}
+			_, _ = w.Write(data)
+		}
+	}))
+	defer ts.Close()
+
+	ctx := context.Background()
+	store := NewURLShardStore(ts.URL + "/shards/")
+
+	// A dry run doesn't store the shard.
+	_, reported, err := CollectShards(ctx, store, "42", Shard{Index: 1, Count: 2}, []byte(`{"shard":1}`), true)
+	require.NoError(t, err)
+	assert.Equal(t, 1, reported)
+	assert.Empty(t, stored)
+
+	_, _, err = CollectShards(ctx, store, "42", Shard{Index: 1, Count: 2}, []byte(`{"shard":1}`), false)
+	require.NoError(t, err)
+	assert.Contains(t, stored, "/shards/42/shard-1-of-2.json")
+
+	all, reported, err := CollectShards(ctx, store, "42", Shard{Index: 2, Count: 2}, []byte(`{"shard":2}`), false)
+	require.NoError(t, err)
+	assert.Equal(t, 2, reported)
+	assert.Equal(t, [][]byte{[]byte(`{"shard":1}`), []byte(`{"shard":2}`)}, all)
+}
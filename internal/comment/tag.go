@@ -1,6 +1,12 @@
 package comment
 
-import "fmt"
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+)
 
 // markdownTag wraps a tag in a markdown comment.
 func markdownTag(s string) string {
@@ -17,3 +23,15 @@ func addMarkdownTag(s string, tag string) string {
 
 	return comment
 }
+
+// CommentKey returns the key of the comments of the runs on the projects of a
+// target, e.g. a pull request of a repo. It doesn't depend on the order of the
+// projects.
+func CommentKey(repo string, target string, projects []string) string {
+	sorted := append([]string{}, projects...)
+	sort.Strings(sorted)
+
+	sum := sha256.Sum256([]byte(strings.Join(append([]string{repo, target}, sorted...), "\x00")))
+
+	return hex.EncodeToString(sum[:])[:12]
+}
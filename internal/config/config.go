@@ -4,12 +4,26 @@ import (
 	"io"
 	"log"
 	"os"
+	"path"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/joho/godotenv"
 	"github.com/kelseyhightower/envconfig"
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
 	"github.com/sirupsen/logrus"
+
+	"github.com/infracost/infracost/internal/azurepricesheet"
+	"github.com/infracost/infracost/internal/celexpr"
+	"github.com/infracost/infracost/internal/currency"
+	"github.com/infracost/infracost/internal/policypack"
+	"github.com/infracost/infracost/internal/pricebook"
+	"github.com/infracost/infracost/internal/pricecache"
+	"github.com/infracost/infracost/internal/pricesnapshot"
+	"github.com/infracost/infracost/internal/resultcache"
+	"github.com/infracost/infracost/internal/schema"
 )
 
 // Project defines a specific terraform project config. This can be used
@@ -20,6 +34,14 @@ type Project struct {
 	// Path to the Terraform directory or JSON/plan file.
 	// A path can be repeated with different parameters, e.g. for multiple workspaces.
 	Path string `yaml:"path,omitempty" ignored:"true"`
+	// Name overrides the generated name of the project in the outputs. Projects
+	// found under the Path, e.g. the modules of a Terragrunt directory, are named
+	// Name followed by their path relative to Path.
+	Name string `yaml:"name,omitempty" ignored:"true"`
+	// Labels describe the project in the outputs, e.g. its env, team and service.
+	Labels map[string]string `yaml:"labels,omitempty" ignored:"true"`
+	// Group is the group the project is subtotaled in, e.g. prod.
+	Group string `yaml:"group,omitempty" ignored:"true"`
 	// TerraformParseHCL will run a project by parsing hcl files the given Path rather than using a plan.json or terraform binary.
 	TerraformParseHCL bool `yaml:"hcl_only,omitempty"`
 	// TerraformVarFiles is the number of var files that are needed to run an TerraformParseHCL run
@@ -44,11 +66,452 @@ type Project struct {
 	TerragruntFlags string `envconfig:"INFRACOST_TERRAGRUNT_FLAGS"`
 	// UsageFile is the full path to usage file that specifies values for usage-based resources
 	UsageFile string `yaml:"usage_file,omitempty" ignored:"true"`
+	// UsageFiles are usage files, e.g. org-wide defaults maintained by a platform
+	// team, that are merged in order before UsageFile. Later files override the
+	// values of earlier ones. They can be local paths or HTTP(S), S3 or GCS URLs.
+	UsageFiles []string `yaml:"usage_files,omitempty" ignored:"true"`
+	// UsageProfile is the profile of the usage file whose values override its
+	// shared values, e.g. prod
+	UsageProfile string `yaml:"usage_profile,omitempty" ignored:"true"`
+	// Currency is the currency the costs of the project are shown in, when it
+	// differs from the currency of the run. The project's totals are converted to
+	// the currency of the run for the overall totals.
+	Currency string `yaml:"currency,omitempty" ignored:"true"`
 	// TerraformUseState sets if the users wants to use the terraform state for infracost ops.
 	TerraformUseState bool              `yaml:"terraform_use_state,omitempty" ignored:"true"`
 	Env               map[string]string `yaml:"env,omitempty" ignored:"true"`
 }
 
+// UsageFilePaths returns the usage files of the project in the order they're
+// merged, with UsageFile last so its values take precedence.
+func (p *Project) UsageFilePaths() []string {
+	paths := append([]string{}, p.UsageFiles...)
+	if p.UsageFile != "" {
+		paths = append(paths, p.UsageFile)
+	}
+
+	return paths
+}
+
+// Discount is a percentage discount applied to the list prices of the cost
+// components matching its vendor, service and region, e.g. an enterprise discount
+// program. Empty fields match everything.
+type Discount struct {
+	Name    string  `yaml:"name,omitempty"`
+	Vendor  string  `yaml:"vendor,omitempty"`
+	Service string  `yaml:"service,omitempty"`
+	Region  string  `yaml:"region,omitempty"`
+	Percent float64 `yaml:"percent"`
+}
+
+// ExchangeRate pins the rate used to convert USD prices to a currency, e.g. to the
+// rate a finance team budgets with. Date optionally records when it was taken.
+type ExchangeRate struct {
+	Currency string  `yaml:"currency"`
+	Rate     float64 `yaml:"rate"`
+	Date     string  `yaml:"date,omitempty"`
+}
+
+// Budget limits the monthly cost of the resources of the projects matching
+// Project, a project name or path that can be a glob, or of all projects if it's
+// empty. Module limits it to the resources of a module, e.g. module.vpc, and Tag
+// to the resources with a tag, given as key or key=value. The limits are in the
+// currency of the run and increases are compared to the past costs of a diff.
+// Owner limits it to the resources of a team of the owners file.
+type Budget struct {
+	Name                      string   `yaml:"name,omitempty"`
+	Project                   string   `yaml:"project,omitempty"`
+	Module                    string   `yaml:"module,omitempty"`
+	Tag                       string   `yaml:"tag,omitempty"`
+	Owner                     string   `yaml:"owner,omitempty"`
+	MaxMonthlyCost            *float64 `yaml:"max_monthly_cost,omitempty"`
+	MaxMonthlyIncrease        *float64 `yaml:"max_monthly_increase,omitempty"`
+	MaxMonthlyIncreasePercent *float64 `yaml:"max_monthly_increase_percent,omitempty"`
+}
+
+// Validate returns an error if the budget has no limits or a negative limit.
+func (b *Budget) Validate() error {
+	if b.MaxMonthlyCost == nil && b.MaxMonthlyIncrease == nil && b.MaxMonthlyIncreasePercent == nil {
+		return errors.New("budget must have a max_monthly_cost, max_monthly_increase or max_monthly_increase_percent")
+	}
+
+	for _, v := range []*float64{b.MaxMonthlyCost, b.MaxMonthlyIncrease, b.MaxMonthlyIncreasePercent} {
+		if v != nil && *v < 0 {
+			return errors.New("budget limits must be at least 0")
+		}
+	}
+
+	return nil
+}
+
+// Label returns the name of the budget, or a description of what it limits if
+// it has no name.
+func (b *Budget) Label() string {
+	if b.Name != "" {
+		return b.Name
+	}
+
+	var parts []string
+	if b.Project != "" {
+		parts = append(parts, "project "+b.Project)
+	}
+	if b.Module != "" {
+		parts = append(parts, "module "+b.Module)
+	}
+	if b.Tag != "" {
+		parts = append(parts, "tag "+b.Tag)
+	}
+	if b.Owner != "" {
+		parts = append(parts, "owner "+b.Owner)
+	}
+
+	if len(parts) == 0 {
+		return "all projects"
+	}
+
+	return strings.Join(parts, ", ")
+}
+
+const (
+	GuardrailScopeProject  = "project"
+	GuardrailScopeModule   = "module"
+	GuardrailScopeResource = "resource"
+)
+
+// GuardrailScopes are the valid values of Guardrail.Scope.
+var GuardrailScopes = []string{GuardrailScopeProject, GuardrailScopeModule, GuardrailScopeResource}
+
+const (
+	ApplyToAll     = "all"
+	ApplyToChanged = "changed"
+)
+
+// ApplyToValues are the valid values of the apply_to of guardrails and tag
+// policies. Policies that apply to changed resources only check the resources
+// that are added or whose costs change in a diff, so the existing spend doesn't
+// block unrelated changes.
+var ApplyToValues = []string{ApplyToAll, ApplyToChanged}
+
+func validateApplyTo(kind string, applyTo string) error {
+	switch applyTo {
+	case "", ApplyToAll, ApplyToChanged:
+		return nil
+	default:
+		return errors.Errorf("%s apply_to must be one of %s", kind, strings.Join(ApplyToValues, ", "))
+	}
+}
+
+// Guardrail limits the monthly cost of each project, module or resource in its
+// Scope, unlike a Budget that limits their sum. Project, Module and ResourceType
+// are globs that select what it applies to, e.g. module.db* or aws_db_instance.
+// The Scope defaults to resource when ResourceType is set, module when Module is
+// set and project otherwise. Guardrails are checked on every run and their
+// violations are listed in the output, they only fail the run when Block is set.
+// Instead of the limits, a guardrail can have a Condition, a CEL expression of
+// the resource, module or project in its scope that's a violation when it's
+// true, e.g. resource.monthlyCost > 500.0 && resource.type == "aws_nat_gateway".
+// ApplyTo limits it to the projects, modules or resources with changed
+// resources, see ApplyToValues.
+type Guardrail struct {
+	Name                      string   `yaml:"name,omitempty"`
+	Scope                     string   `yaml:"scope,omitempty"`
+	Project                   string   `yaml:"project,omitempty"`
+	Module                    string   `yaml:"module,omitempty"`
+	ResourceType              string   `yaml:"resource_type,omitempty"`
+	MaxMonthlyCost            *float64 `yaml:"max_monthly_cost,omitempty"`
+	MaxMonthlyIncrease        *float64 `yaml:"max_monthly_increase,omitempty"`
+	MaxMonthlyIncreasePercent *float64 `yaml:"max_monthly_increase_percent,omitempty"`
+	Condition                 string   `yaml:"condition,omitempty"`
+	ApplyTo                   string   `yaml:"apply_to,omitempty"`
+	Block                     bool     `yaml:"block,omitempty"`
+
+	condition *celexpr.Expression
+}
+
+// Validate returns an error if the guardrail has no limits or condition, both
+// limits and a condition, a negative limit, an invalid condition or an unknown
+// scope.
+func (g *Guardrail) Validate() error {
+	hasLimits := g.MaxMonthlyCost != nil || g.MaxMonthlyIncrease != nil || g.MaxMonthlyIncreasePercent != nil
+
+	switch {
+	case g.Condition != "" && hasLimits:
+		return errors.New("guardrail can't have both a condition and limits")
+	case g.Condition != "":
+		if _, err := g.ConditionExpression(); err != nil {
+			return err
+		}
+	case !hasLimits:
+		return errors.New("guardrail must have a max_monthly_cost, max_monthly_increase, max_monthly_increase_percent or condition")
+	}
+
+	for _, v := range []*float64{g.MaxMonthlyCost, g.MaxMonthlyIncrease, g.MaxMonthlyIncreasePercent} {
+		if v != nil && *v < 0 {
+			return errors.New("guardrail limits must be at least 0")
+		}
+	}
+
+	switch g.Scope {
+	case "", GuardrailScopeProject, GuardrailScopeModule, GuardrailScopeResource:
+	default:
+		return errors.Errorf("guardrail scope must be one of %s", strings.Join(GuardrailScopes, ", "))
+	}
+
+	return validateApplyTo("guardrail", g.ApplyTo)
+}
+
+// ConditionExpression returns the compiled Condition of the guardrail, or nil if
+// it has none.
+func (g *Guardrail) ConditionExpression() (*celexpr.Expression, error) {
+	if g.Condition == "" {
+		return nil, nil
+	}
+
+	if g.condition == nil {
+		expr, err := celexpr.Compile(g.Condition)
+		if err != nil {
+			return nil, err
+		}
+		g.condition = expr
+	}
+
+	return g.condition, nil
+}
+
+// EffectiveScope returns the scope of the guardrail, see Guardrail.
+func (g *Guardrail) EffectiveScope() string {
+	switch {
+	case g.Scope != "":
+		return g.Scope
+	case g.ResourceType != "":
+		return GuardrailScopeResource
+	case g.Module != "":
+		return GuardrailScopeModule
+	default:
+		return GuardrailScopeProject
+	}
+}
+
+// Label returns the name of the guardrail, or a description of what it limits
+// if it has no name.
+func (g *Guardrail) Label() string {
+	if g.Name != "" {
+		return g.Name
+	}
+
+	var parts []string
+	if g.Project != "" {
+		parts = append(parts, "project "+g.Project)
+	}
+	if g.Module != "" {
+		parts = append(parts, "module "+g.Module)
+	}
+	if g.ResourceType != "" {
+		parts = append(parts, "type "+g.ResourceType)
+	}
+
+	subject := g.EffectiveScope()
+	if g.ApplyTo == ApplyToChanged {
+		subject = "changed " + subject
+	}
+
+	if len(parts) == 0 {
+		return "each " + subject
+	}
+
+	return "each " + subject + " matching " + strings.Join(parts, ", ")
+}
+
+// TagPolicy requires the resources of the projects matching Project, a project
+// name or path that can be a glob, to have the Tags. ResourceTypes are globs of
+// the resource types it applies to, e.g. aws_*, it applies to all resources if
+// it's empty. The monthly cost of the resources that don't comply is reported as
+// untagged spend, and fails the run when Block is set. ApplyTo limits it to the
+// changed resources, see ApplyToValues.
+type TagPolicy struct {
+	Name          string         `yaml:"name,omitempty"`
+	Project       string         `yaml:"project,omitempty"`
+	ResourceTypes []string       `yaml:"resource_types,omitempty"`
+	Tags          []*RequiredTag `yaml:"tags"`
+	ApplyTo       string         `yaml:"apply_to,omitempty"`
+	Block         bool           `yaml:"block,omitempty"`
+}
+
+// RequiredTag is a tag key a resource must have, with a value matching one of
+// the Values globs if any are given, e.g. prod*.
+type RequiredTag struct {
+	Key    string   `yaml:"key"`
+	Values []string `yaml:"values,omitempty"`
+}
+
+// Validate returns an error if the tag policy has no tags, a tag without a key
+// or an invalid glob.
+func (t *TagPolicy) Validate() error {
+	if len(t.Tags) == 0 {
+		return errors.New("tag policy must have at least one tag")
+	}
+
+	patterns := append([]string{t.Project}, t.ResourceTypes...)
+
+	for _, tag := range t.Tags {
+		if tag == nil || tag.Key == "" {
+			return errors.New("tag policy tags must have a key")
+		}
+
+		patterns = append(patterns, tag.Values...)
+	}
+
+	for _, p := range patterns {
+		if _, err := path.Match(p, ""); err != nil {
+			return errors.Errorf("tag policy pattern %q is invalid", p)
+		}
+	}
+
+	return validateApplyTo("tag policy", t.ApplyTo)
+}
+
+// Label returns the name of the tag policy, or the tags it requires if it has
+// no name.
+func (t *TagPolicy) Label() string {
+	if t.Name != "" {
+		return t.Name
+	}
+
+	keys := make([]string, 0, len(t.Tags))
+	for _, tag := range t.Tags {
+		keys = append(keys, tag.Key)
+	}
+
+	return "required tags " + strings.Join(keys, ", ")
+}
+
+const (
+	NotificationTypeSlack   = "slack"
+	NotificationTypeTeams   = "teams"
+	NotificationTypeDiscord = "discord"
+	NotificationTypeWebhook = "webhook"
+)
+
+// NotificationTypes are the valid values of Notification.Type. Webhooks are
+// sent the Infracost JSON of the projects.
+var NotificationTypes = []string{NotificationTypeSlack, NotificationTypeTeams, NotificationTypeDiscord, NotificationTypeWebhook}
+
+// Notification is a chat webhook that infracost notify posts the cost changes
+// to. The URL can reference environment variables, e.g. ${SLACK_WEBHOOK_URL},
+// so it isn't committed, and Channel overrides the channel of Slack webhooks
+// that allow it. The other fields are the rules of when it's notified, which
+// must all match: Project is a glob of the names of the projects whose changes
+// are notified, MinMonthlyIncrease and MinMonthlyIncreasePercent are the
+// smallest increases of their total monthly cost that are notified, and
+// OnGuardrailViolation only notifies when their guardrails are violated.
+// Without rules it's notified when their monthly cost changes.
+type Notification struct {
+	Name                      string   `yaml:"name,omitempty"`
+	Type                      string   `yaml:"type"`
+	URL                       string   `yaml:"url"`
+	Channel                   string   `yaml:"channel,omitempty"`
+	Project                   string   `yaml:"project,omitempty"`
+	MinMonthlyIncrease        *float64 `yaml:"min_monthly_increase,omitempty"`
+	MinMonthlyIncreasePercent *float64 `yaml:"min_monthly_increase_percent,omitempty"`
+	OnGuardrailViolation      bool     `yaml:"on_guardrail_violation,omitempty"`
+}
+
+// Validate returns an error if the notification has an unknown type, no URL,
+// a negative limit or an invalid project glob.
+func (n *Notification) Validate() error {
+	switch n.Type {
+	case NotificationTypeSlack, NotificationTypeTeams, NotificationTypeDiscord, NotificationTypeWebhook:
+	default:
+		return errors.Errorf("notification type must be one of %s", strings.Join(NotificationTypes, ", "))
+	}
+
+	if n.URL == "" {
+		return errors.New("notification must have a url")
+	}
+
+	for _, v := range []*float64{n.MinMonthlyIncrease, n.MinMonthlyIncreasePercent} {
+		if v != nil && *v < 0 {
+			return errors.New("notification limits must be at least 0")
+		}
+	}
+
+	if _, err := path.Match(n.Project, ""); err != nil {
+		return errors.Errorf("notification project pattern %q is invalid", n.Project)
+	}
+
+	return nil
+}
+
+// Label returns the name of the notification, or its type and channel if it
+// has no name.
+func (n *Notification) Label() string {
+	if n.Name != "" {
+		return n.Name
+	}
+
+	if n.Channel != "" {
+		return n.Type + " " + n.Channel
+	}
+
+	return n.Type
+}
+
+// JiraIssue opens a Jira issue in JiraProject when the guardrails of the
+// projects matching Project, a glob of their names, are violated. The issue of
+// a project is updated instead while it's open, so each breach is tracked by a
+// single issue. IssueType defaults to Task and Labels are added to the issue
+// with the label that identifies the project.
+type JiraIssue struct {
+	Project     string   `yaml:"project,omitempty"`
+	JiraProject string   `yaml:"jira_project"`
+	IssueType   string   `yaml:"issue_type,omitempty"`
+	Labels      []string `yaml:"labels,omitempty"`
+	// BlockingOnly only opens issues for the violations of guardrails that
+	// fail the run.
+	BlockingOnly bool `yaml:"blocking_only,omitempty"`
+}
+
+// Validate returns an error if the Jira issue has no Jira project, a label
+// with spaces or an invalid project glob.
+func (j *JiraIssue) Validate() error {
+	if j.JiraProject == "" {
+		return errors.New("jira issue must have a jira_project")
+	}
+
+	for _, l := range j.Labels {
+		if l == "" || strings.ContainsAny(l, " \t") {
+			return errors.Errorf("jira issue label %q can't be empty or contain spaces", l)
+		}
+	}
+
+	if _, err := path.Match(j.Project, ""); err != nil {
+		return errors.Errorf("jira issue project pattern %q is invalid", j.Project)
+	}
+
+	return nil
+}
+
+// IssueTypeOrDefault returns the issue type of the Jira issue, or Task if it
+// isn't set.
+func (j *JiraIssue) IssueTypeOrDefault() string {
+	if j.IssueType == "" {
+		return "Task"
+	}
+
+	return j.IssueType
+}
+
+// dateFormat is the format of the dates in the config, e.g. 2022-03-01.
+const dateFormat = "2006-01-02"
+
+const (
+	PricingBackendInfracost    = "infracost"
+	PricingBackendAWSPriceList = "aws-price-list"
+)
+
+// PricingBackends are the valid values of Config.PricingBackend.
+var PricingBackends = []string{PricingBackendInfracost, PricingBackendAWSPriceList}
+
 type Config struct {
 	Credentials   Credentials
 	Configuration Configuration
@@ -59,6 +522,10 @@ type Config struct {
 	SkipUpdateCheck bool   `yaml:"skip_update_check,omitempty" envconfig:"INFRACOST_SKIP_UPDATE_CHECK"`
 	Parallelism     *int   `envconfig:"INFRACOST_PARALLELISM"`
 
+	// AutodetectMaxDepth is how many directories deep the root modules of a repo
+	// are looked for when the path isn't a Terraform project.
+	AutodetectMaxDepth int `envconfig:"INFRACOST_AUTODETECT_MAX_DEPTH"`
+
 	APIKey                    string `envconfig:"INFRACOST_API_KEY"`
 	PricingAPIEndpoint        string `yaml:"pricing_api_endpoint,omitempty" envconfig:"INFRACOST_PRICING_API_ENDPOINT"`
 	DefaultPricingAPIEndpoint string `yaml:"default_pricing_api_endpoint,omitempty" envconfig:"INFRACOST_DEFAULT_PRICING_API_ENDPOINT"`
@@ -70,6 +537,128 @@ type Config struct {
 	TLSCACertFile         string `envconfig:"INFRACOST_TLS_CA_CERT_FILE"`
 
 	Currency string `envconfig:"INFRACOST_CURRENCY"`
+	// currencyDefaulted is set when neither INFRACOST_CURRENCY nor the
+	// configuration file set Currency, so it's USD by default.
+	currencyDefaulted bool
+
+	// ExchangeRate pins the rate used to convert USD prices to Currency, taking
+	// precedence over ExchangeRates and the built-in rates.
+	ExchangeRate     float64         `envconfig:"INFRACOST_EXCHANGE_RATE"`
+	ExchangeRateDate string          `envconfig:"INFRACOST_EXCHANGE_RATE_DATE"`
+	ExchangeRates    []*ExchangeRate `yaml:"exchange_rates,omitempty" ignored:"true"`
+
+	// PricingBackend is the source of AWS prices, either the Cloud Pricing API or
+	// the AWS Price List API using the default AWS credentials.
+	PricingBackend string `envconfig:"INFRACOST_PRICING_BACKEND"`
+
+	// PricingOffline prices resources from the pricing snapshot at PricingSnapshotPath
+	// instead of the Cloud Pricing API.
+	PricingOffline      bool   `envconfig:"INFRACOST_PRICING_OFFLINE"`
+	PricingSnapshotPath string `envconfig:"INFRACOST_PRICING_SNAPSHOT_PATH"`
+
+	// NoPriceCache disables the on-disk cache of Cloud Pricing API results that's
+	// shared by runs on the same machine.
+	NoPriceCache  bool          `envconfig:"INFRACOST_NO_PRICE_CACHE"`
+	PriceCacheDir string        `envconfig:"INFRACOST_PRICE_CACHE_DIR"`
+	PriceCacheTTL time.Duration `envconfig:"INFRACOST_PRICE_CACHE_TTL"`
+
+	// PricingConcurrency is the number of requests sent to the Cloud Pricing API
+	// at once. Defaults to 4 per CPU, up to 16.
+	PricingConcurrency int `envconfig:"INFRACOST_PRICING_CONCURRENCY"`
+
+	// PricingAPIRetries is the number of times failed Cloud Pricing API requests are
+	// retried and PricingAPITimeout limits how long each request can take.
+	PricingAPIRetries int           `envconfig:"INFRACOST_PRICING_API_RETRIES"`
+	PricingAPITimeout time.Duration `envconfig:"INFRACOST_PRICING_API_TIMEOUT"`
+
+	// AllowUnavailablePrices marks the cost components whose prices couldn't be
+	// looked up as price unavailable instead of failing the run.
+	AllowUnavailablePrices bool `envconfig:"INFRACOST_ALLOW_UNAVAILABLE_PRICES"`
+
+	// FailOnPricingIssues fails the run when the prices of cost components couldn't
+	// be found or matched more than one price.
+	FailOnPricingIssues bool `envconfig:"INFRACOST_FAIL_ON_PRICING_ISSUES"`
+
+	// PriceBookPath is the path to a CSV or YAML file of prices that override the
+	// Cloud Pricing API prices, e.g. negotiated rates.
+	PriceBookPath string               `yaml:"price_book,omitempty" envconfig:"INFRACOST_PRICE_BOOK"`
+	PriceBook     *pricebook.PriceBook `ignored:"true"`
+
+	// AzurePriceSheetPath is the path to an Azure EA or CSP price sheet export whose
+	// prices replace the Azure list prices. Alternatively the price sheet is fetched
+	// from the Consumption API when AzureSubscriptionID and AzureAccessToken are set.
+	AzurePriceSheetPath   string                      `yaml:"azure_price_sheet,omitempty" envconfig:"INFRACOST_AZURE_PRICE_SHEET"`
+	AzureSubscriptionID   string                      `envconfig:"INFRACOST_AZURE_SUBSCRIPTION_ID"`
+	AzureBillingPeriod    string                      `envconfig:"INFRACOST_AZURE_BILLING_PERIOD"`
+	AzureAccessToken      string                      `envconfig:"INFRACOST_AZURE_ACCESS_TOKEN"`
+	AzureManagementAPIURL string                      `envconfig:"INFRACOST_AZURE_MANAGEMENT_API_URL"`
+	AzurePriceSheet       *azurepricesheet.PriceSheet `ignored:"true"`
+
+	// SpotDiscountPercent is the discount over the on-demand price used to estimate
+	// the price of spot and preemptible instances when no spot price is found.
+	SpotDiscountPercent float64 `yaml:"spot_discount_percent,omitempty" envconfig:"INFRACOST_SPOT_DISCOUNT_PERCENT"`
+
+	// FreeTier subtracts the cloud providers' free tier allowances from the costs.
+	// Allowances that only last for the first 12 months of an account are only
+	// applied when FreeTierAccountCreated, a YYYY-MM-DD date, is within 12 months.
+	FreeTier               bool   `yaml:"free_tier,omitempty" envconfig:"INFRACOST_FREE_TIER"`
+	FreeTierAccountCreated string `yaml:"free_tier_account_created,omitempty" envconfig:"INFRACOST_FREE_TIER_ACCOUNT_CREATED"`
+
+	// DeriveUsage infers usage values the usage file doesn't set from the
+	// resources' attributes, e.g. the instances of an autoscaling group from its
+	// min_size and max_size.
+	DeriveUsage bool `yaml:"derive_usage,omitempty" envconfig:"INFRACOST_DERIVE_USAGE"`
+
+	// Discounts are matched in order and the first matching discount is applied.
+	Discounts []*Discount `yaml:"discounts,omitempty" ignored:"true"`
+
+	// Budgets fail the run when the monthly costs of the resources they match
+	// exceed their limits.
+	Budgets []*Budget `yaml:"budgets,omitempty" ignored:"true"`
+
+	// Guardrails limit the monthly costs of each project, module or resource
+	// they match, their violations are added to the output of every run.
+	Guardrails []*Guardrail `yaml:"guardrails,omitempty" ignored:"true"`
+
+	// TagPolicies are the tags the resources must have, the cost of the resources
+	// that don't have them is reported as untagged spend.
+	TagPolicies []*TagPolicy `yaml:"tag_policies,omitempty" ignored:"true"`
+
+	// Notifications are the chat webhooks infracost notify posts the cost
+	// changes to, see Notification.
+	Notifications []*Notification `yaml:"notifications,omitempty" ignored:"true"`
+
+	// JiraIssues open Jira issues for the projects whose guardrails are
+	// violated, see JiraIssue. They're opened on JiraURL, as JiraEmail with the
+	// JiraToken API token on Jira Cloud, or with JiraToken as a personal access
+	// token on Jira Data Center if JiraEmail is empty.
+	JiraIssues []*JiraIssue `yaml:"jira_issues,omitempty" ignored:"true"`
+	JiraURL    string       `envconfig:"INFRACOST_JIRA_URL"`
+	JiraEmail  string       `envconfig:"INFRACOST_JIRA_EMAIL"`
+	JiraToken  string       `envconfig:"INFRACOST_JIRA_TOKEN"`
+
+	// PolicyPacks are fetched at the start of the run and add their budgets,
+	// guardrails, tag policies and price book to the ones of the config file.
+	// PolicyPackToken is sent as a bearer token when fetching them and they're
+	// extracted to PolicyPackCacheDir.
+	PolicyPacks        []*PolicyPack `yaml:"policy_packs,omitempty" ignored:"true"`
+	PolicyPackToken    string        `envconfig:"INFRACOST_POLICY_PACK_TOKEN"`
+	PolicyPackCacheDir string        `envconfig:"INFRACOST_POLICY_PACK_CACHE_DIR"`
+
+	// OwnersFile is the path to a CODEOWNERS file, or a YAML file mapping paths
+	// and modules to owners, used to attribute the resources to teams.
+	OwnersFile string `yaml:"owners_file,omitempty" envconfig:"INFRACOST_OWNERS_FILE"`
+
+	// WaiversFile is the path to a YAML file of waivers, exceptions to the
+	// guardrails and tag policies with a reason, an owner and an expiry date.
+	WaiversFile string `yaml:"waivers_file,omitempty" envconfig:"INFRACOST_WAIVERS_FILE"`
+
+	// PolicyPaths are the Rego policies of the policy packs.
+	PolicyPaths []string `ignored:"true"`
+
+	// Commitments are the reserved capacity covering the resources of all projects,
+	// usage files can add commitments for their own project.
+	Commitments []*schema.Commitment `yaml:"commitments,omitempty" ignored:"true"`
 
 	Projects      []*Project `yaml:"projects" ignored:"true"`
 	Format        string     `yaml:"format,omitempty" ignored:"true"`
@@ -77,6 +666,90 @@ type Config struct {
 	SyncUsageFile bool       `yaml:"sync_usage_file,omitempty" ignored:"true"`
 	Fields        []string   `yaml:"fields,omitempty" ignored:"true"`
 
+	// ShowSkippedJSON outputs a report of each skipped resource as JSON instead
+	// of the costs, see --show-skipped=json.
+	ShowSkippedJSON bool `ignored:"true"`
+
+	// DiffThresholds is the budget of all projects built from the --fail-on-increase
+	// and --fail-on-total flags of diff.
+	DiffThresholds *Budget `ignored:"true"`
+
+	// ExplainResource is the address of the resource whose costs are explained
+	// instead of output, see infracost explain.
+	ExplainResource string `ignored:"true"`
+
+	// ShowPriceTiers adds the usage range of graduated prices to the cost components.
+	ShowPriceTiers bool `ignored:"true"`
+
+	// ShowRecommendations adds the savings opportunities found by the best
+	// practice checks to the output, see --show-recommendations.
+	ShowRecommendations bool `ignored:"true"`
+
+	// Filters are the key=value expressions the resources of the output have to
+	// match to be shown, e.g. type=aws_nat_gateway.
+	Filters []string `ignored:"true"`
+
+	// GroupBy is the tag the costs of the resources are grouped by, e.g. tag:team.
+	GroupBy string `ignored:"true"`
+
+	// Granularity is the time period the costs of the table output are shown for,
+	// e.g. annual.
+	Granularity string `ignored:"true"`
+
+	// ProjectionMonths is the number of months the total monthly cost is projected
+	// over, growing by ProjectionGrowthPercent each month.
+	ProjectionMonths        int     `ignored:"true"`
+	ProjectionGrowthPercent float64 `ignored:"true"`
+
+	// UsageScenario is the scenario of the usage values given per scenario to
+	// estimate costs with, or all to estimate the cost of each scenario.
+	UsageScenario string `yaml:"usage_scenario,omitempty" ignored:"true"`
+
+	// ComparePricesToPath is the JSON output of a previous run whose prices are
+	// compared to the prices of this run.
+	ComparePricesToPath string `ignored:"true"`
+
+	// CompareToBaseline is the name, path or URL of a baseline saved with
+	// baseline save that projects are diffed against instead of their current state.
+	CompareToBaseline string `ignored:"true"`
+
+	// CompareToRef is the git ref whose projects are run in a temporary worktree
+	// and diffed against instead of the current state, e.g. origin/main.
+	CompareToRef string `ignored:"true"`
+
+	// SelectChangedProjects only runs the projects affected by the files changed
+	// since the base of the pull request, see --select-changed-projects.
+	SelectChangedProjects bool `ignored:"true"`
+
+	// WriteLockFile records the module versions and provider regions of the
+	// projects parsed from HCL in their lock files, see --write-lock-file.
+	WriteLockFile bool `ignored:"true"`
+
+	// CacheResults reuses the estimates of projects whose files haven't changed
+	// since they were cached in ResultCacheDir, see --cache-results.
+	CacheResults   bool          `ignored:"true"`
+	ResultCacheDir string        `envconfig:"INFRACOST_RESULT_CACHE_DIR"`
+	ResultCacheTTL time.Duration `envconfig:"INFRACOST_RESULT_CACHE_TTL"`
+
+	// ApprovalWebhookURL is sent the diff of runs that breach guardrails, and
+	// with WaitForApproval the run polls ApprovalCallbackURL, where {id} is the
+	// ID of the request, until the change is approved or rejected, see
+	// --wait-for-approval.
+	ApprovalWebhookURL   string        `envconfig:"INFRACOST_APPROVAL_WEBHOOK_URL"`
+	ApprovalWebhookToken string        `envconfig:"INFRACOST_APPROVAL_WEBHOOK_TOKEN"`
+	ApprovalCallbackURL  string        `envconfig:"INFRACOST_APPROVAL_CALLBACK_URL"`
+	ApprovalPollInterval time.Duration `envconfig:"INFRACOST_APPROVAL_POLL_INTERVAL"`
+	ApprovalTimeout      time.Duration `envconfig:"INFRACOST_APPROVAL_TIMEOUT"`
+	WaitForApproval      bool          `ignored:"true"`
+
+	// Locked pins the module versions and provider regions of the projects
+	// parsed from HCL to the ones in their lock files, see --locked.
+	Locked bool `ignored:"true"`
+
+	// NoModuleDownloads only loads the remote modules of the projects parsed
+	// from HCL that have already been downloaded, see infracost precommit.
+	NoModuleDownloads bool `ignored:"true"`
+
 	NoCache bool `yaml:"fields,omitempty" ignored:"true"`
 
 	SkipErrLine bool
@@ -104,15 +777,165 @@ func DefaultConfig() *Config {
 		DashboardAPIEndpoint:      "https://dashboard.api.infracost.io",
 		EnableDashboard:           false,
 
+		PricingBackend:        PricingBackendInfracost,
+		PricingSnapshotPath:   pricesnapshot.DefaultPath,
+		PriceCacheDir:         pricecache.DefaultDir(),
+		PolicyPackCacheDir:    policypack.DefaultCacheDir(),
+		PriceCacheTTL:         pricecache.DefaultTTL,
+		ResultCacheDir:        resultcache.DefaultDir(),
+		ResultCacheTTL:        resultcache.DefaultTTL,
+		ApprovalPollInterval:  15 * time.Second,
+		ApprovalTimeout:       time.Hour,
+		PricingAPIRetries:     3,
+		PricingAPITimeout:     60 * time.Second,
+		AzureManagementAPIURL: azurepricesheet.DefaultEndpoint,
+		SpotDiscountPercent:   70,
+
 		Projects: []*Project{{}},
 
 		Format: "table",
 		Fields: []string{"monthlyQuantity", "unit", "monthlyCost"},
 
 		EventsDisabled: IsTest(),
+		NoPriceCache:   IsTest(),
 	}
 }
 
+// UsesAWSPriceList returns true if AWS resources are priced with the AWS Price
+// List API instead of the Cloud Pricing API.
+func (c *Config) UsesAWSPriceList() bool {
+	return c.PricingBackend == PricingBackendAWSPriceList
+}
+
+// FreeTierAccountCreatedAt returns the date the account was created, or the zero
+// time if it isn't set or is invalid.
+func (c *Config) FreeTierAccountCreatedAt() time.Time {
+	t, err := time.Parse(dateFormat, c.FreeTierAccountCreated)
+	if err != nil {
+		return time.Time{}
+	}
+
+	return t
+}
+
+// UsesPriceCache returns true if Cloud Pricing API results are cached on disk.
+// Offline runs don't query the API so they don't use the cache.
+func (c *Config) UsesPriceCache() bool {
+	return !c.NoPriceCache && !c.PricingOffline && c.PriceCacheDir != ""
+}
+
+// CurrencyIsDefault returns true if the user hasn't set a currency, so projects
+// can be shown in the currency their provider bills in.
+func (c *Config) CurrencyIsDefault() bool {
+	return c.currencyDefaulted && c.Currency == "USD"
+}
+
+// CurrencyRate returns the rate used to convert USD prices to Currency, or nil if
+// prices are looked up in Currency.
+func (c *Config) CurrencyRate() *currency.Rate {
+	return c.RateFor(c.Currency)
+}
+
+// RateFor returns the rate used to convert USD prices to cur, or nil if prices
+// are looked up in cur. Prices are only converted when the rate of cur is pinned
+// with ExchangeRate or ExchangeRates, or when they're looked up with the AWS
+// Price List API, which only has USD prices, in which case the built-in rate is
+// used. Otherwise the Cloud Pricing API returns prices in cur.
+func (c *Config) RateFor(cur string) *currency.Rate {
+	if cur == "" || strings.EqualFold(cur, currency.USD) {
+		return nil
+	}
+
+	if r := c.pinnedRate(cur); r != nil {
+		return r
+	}
+
+	if c.UsesAWSPriceList() {
+		if r, ok := currency.BuiltInRate(cur); ok {
+			return r
+		}
+	}
+
+	return nil
+}
+
+// pinnedRate returns the rate of cur pinned by the user, or nil if it isn't
+// pinned. ExchangeRate only pins the rate of Currency.
+func (c *Config) pinnedRate(cur string) *currency.Rate {
+	if c.ExchangeRate > 0 && strings.EqualFold(cur, c.Currency) {
+		return currency.PinnedRate(cur, c.ExchangeRate, c.ExchangeRateDate)
+	}
+
+	for _, r := range c.ExchangeRates {
+		if strings.EqualFold(r.Currency, cur) {
+			return currency.PinnedRate(cur, r.Rate, r.Date)
+		}
+	}
+
+	return nil
+}
+
+// PricingCurrency returns the currency prices are looked up in, this is USD when
+// they're converted to Currency.
+func (c *Config) PricingCurrency() string {
+	return c.PricingCurrencyFor(c.Currency)
+}
+
+// PricingCurrencyFor returns the currency prices in cur are looked up in.
+func (c *Config) PricingCurrencyFor(cur string) string {
+	if cur == "" || c.RateFor(cur) != nil {
+		return currency.USD
+	}
+
+	return cur
+}
+
+// ConversionRate returns the rate for converting amounts in the from currency to
+// the to currency. It's worked out from the rates of both currencies from USD,
+// which are the pinned or built-in rates, so it's false if either currency has
+// neither.
+func (c *Config) ConversionRate(from, to string) (decimal.Decimal, bool) {
+	if strings.EqualFold(currencyOrUSD(from), currencyOrUSD(to)) {
+		return decimal.NewFromInt(1), true
+	}
+
+	fromRate, ok := c.usdRate(from)
+	if !ok {
+		return decimal.Zero, false
+	}
+
+	toRate, ok := c.usdRate(to)
+	if !ok {
+		return decimal.Zero, false
+	}
+
+	return toRate.Div(fromRate), true
+}
+
+func (c *Config) usdRate(cur string) (decimal.Decimal, bool) {
+	if strings.EqualFold(currencyOrUSD(cur), currency.USD) {
+		return decimal.NewFromInt(1), true
+	}
+
+	r := c.RateFor(cur)
+	if r == nil {
+		r, _ = currency.BuiltInRate(cur)
+	}
+	if r == nil || !r.Rate.IsPositive() {
+		return decimal.Zero, false
+	}
+
+	return r.Rate, true
+}
+
+func currencyOrUSD(cur string) string {
+	if cur == "" {
+		return currency.USD
+	}
+
+	return cur
+}
+
 func (c *Config) LoadFromConfigFile(path string) error {
 	cfgFile, err := loadConfigFile(path)
 	if err != nil {
@@ -120,6 +943,35 @@ func (c *Config) LoadFromConfigFile(path string) error {
 	}
 
 	c.Projects = cfgFile.Projects
+	c.PriceBookPath = cfgFile.PriceBook
+	// The price book is relative to the config file, like its includes
+	if c.PriceBookPath != "" && !filepath.IsAbs(c.PriceBookPath) {
+		c.PriceBookPath = filepath.Join(filepath.Dir(path), c.PriceBookPath)
+	}
+	c.AzurePriceSheetPath = cfgFile.AzurePriceSheet
+	c.Discounts = cfgFile.Discounts
+	c.Budgets = cfgFile.Budgets
+	c.Guardrails = cfgFile.Guardrails
+	c.TagPolicies = cfgFile.TagPolicies
+	c.Notifications = cfgFile.Notifications
+	c.JiraIssues = cfgFile.JiraIssues
+	c.PolicyPacks = cfgFile.PolicyPacks
+	if cfgFile.OwnersFile != "" {
+		c.OwnersFile = cfgFile.OwnersFile
+	}
+	if cfgFile.WaiversFile != "" {
+		c.WaiversFile = cfgFile.WaiversFile
+	}
+	c.Commitments = cfgFile.Commitments
+	c.ExchangeRates = cfgFile.ExchangeRates
+	c.FreeTier = c.FreeTier || cfgFile.FreeTier
+	c.DeriveUsage = c.DeriveUsage || cfgFile.DeriveUsage
+	if cfgFile.FreeTierAccountCreated != "" {
+		c.FreeTierAccountCreated = cfgFile.FreeTierAccountCreated
+	}
+	if cfgFile.SpotDiscountPercent != nil {
+		c.SpotDiscountPercent = *cfgFile.SpotDiscountPercent
+	}
 
 	// Reload the environment to overwrite any of the config file configs
 	err = c.LoadFromEnv()
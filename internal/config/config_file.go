@@ -2,14 +2,16 @@ package config
 
 import (
 	"fmt"
-	"os"
 	"reflect"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/pkg/errors"
 	"golang.org/x/mod/semver"
 	"gopkg.in/yaml.v2"
+
+	"github.com/infracost/infracost/internal/schema"
 )
 
 const (
@@ -109,8 +111,25 @@ func (y *YamlError) Error() string {
 }
 
 type fileSpec struct {
-	Version  string     `yaml:"version"`
-	Projects []*Project `yaml:"projects" ignored:"true"`
+	Version                string               `yaml:"version"`
+	PriceBook              string               `yaml:"price_book,omitempty"`
+	AzurePriceSheet        string               `yaml:"azure_price_sheet,omitempty"`
+	Discounts              []*Discount          `yaml:"discounts,omitempty"`
+	Budgets                []*Budget            `yaml:"budgets,omitempty"`
+	Guardrails             []*Guardrail         `yaml:"guardrails,omitempty"`
+	TagPolicies            []*TagPolicy         `yaml:"tag_policies,omitempty"`
+	Notifications          []*Notification      `yaml:"notifications,omitempty"`
+	JiraIssues             []*JiraIssue         `yaml:"jira_issues,omitempty"`
+	PolicyPacks            []*PolicyPack        `yaml:"policy_packs,omitempty"`
+	OwnersFile             string               `yaml:"owners_file,omitempty"`
+	WaiversFile            string               `yaml:"waivers_file,omitempty"`
+	Commitments            []*schema.Commitment `yaml:"commitments,omitempty"`
+	SpotDiscountPercent    *float64             `yaml:"spot_discount_percent,omitempty"`
+	ExchangeRates          []*ExchangeRate      `yaml:"exchange_rates,omitempty"`
+	FreeTier               bool                 `yaml:"free_tier,omitempty"`
+	FreeTierAccountCreated string               `yaml:"free_tier_account_created,omitempty"`
+	DeriveUsage            bool                 `yaml:"derive_usage,omitempty"`
+	Projects               []*Project           `yaml:"projects" ignored:"true"`
 }
 
 // UnmarshalYAML implements the yaml.v2.Unmarshaller interface. Marshalls the
@@ -119,8 +138,25 @@ type fileSpec struct {
 // type so that we don't run into error collisions with the base yaml.v2 errors.
 func (f *fileSpec) UnmarshalYAML(unmarshal func(interface{}) error) error {
 	type roughFile struct {
-		Version  string                   `yaml:"version"`
-		Projects []map[string]interface{} `yaml:"projects"`
+		Version                string                   `yaml:"version"`
+		PriceBook              string                   `yaml:"price_book"`
+		AzurePriceSheet        string                   `yaml:"azure_price_sheet"`
+		Discounts              []*Discount              `yaml:"discounts"`
+		Budgets                []*Budget                `yaml:"budgets"`
+		Guardrails             []*Guardrail             `yaml:"guardrails"`
+		TagPolicies            []*TagPolicy             `yaml:"tag_policies"`
+		Notifications          []*Notification          `yaml:"notifications"`
+		JiraIssues             []*JiraIssue             `yaml:"jira_issues"`
+		PolicyPacks            []*PolicyPack            `yaml:"policy_packs"`
+		OwnersFile             string                   `yaml:"owners_file"`
+		WaiversFile            string                   `yaml:"waivers_file"`
+		Commitments            []*schema.Commitment     `yaml:"commitments"`
+		SpotDiscountPercent    *float64                 `yaml:"spot_discount_percent"`
+		ExchangeRates          []*ExchangeRate          `yaml:"exchange_rates"`
+		FreeTier               bool                     `yaml:"free_tier"`
+		FreeTierAccountCreated string                   `yaml:"free_tier_account_created"`
+		DeriveUsage            bool                     `yaml:"derive_usage"`
+		Projects               []map[string]interface{} `yaml:"projects"`
 	}
 
 	var r roughFile
@@ -180,6 +216,147 @@ func (f *fileSpec) UnmarshalYAML(unmarshal func(interface{}) error) error {
 		}
 	}
 
+	for i, d := range r.Discounts {
+		if d == nil || d.Percent <= 0 || d.Percent > 100 {
+			validationError.add(&YamlError{
+				base:   fmt.Sprintf("discount config at index %d was invalid", i),
+				errors: []error{errors.New("discount must have a percent greater than 0 and at most 100")},
+			})
+		}
+	}
+
+	for i, b := range r.Budgets {
+		var err error
+		if b == nil {
+			err = errors.New("budget must have a max_monthly_cost, max_monthly_increase or max_monthly_increase_percent")
+		} else {
+			err = b.Validate()
+		}
+
+		if err != nil {
+			validationError.add(&YamlError{
+				base:   fmt.Sprintf("budget config at index %d was invalid", i),
+				errors: []error{err},
+			})
+		}
+	}
+
+	for i, g := range r.Guardrails {
+		var err error
+		if g == nil {
+			err = errors.New("guardrail must have a max_monthly_cost, max_monthly_increase, max_monthly_increase_percent or condition")
+		} else {
+			err = g.Validate()
+		}
+
+		if err != nil {
+			validationError.add(&YamlError{
+				base:   fmt.Sprintf("guardrail config at index %d was invalid", i),
+				errors: []error{err},
+			})
+		}
+	}
+
+	for i, t := range r.TagPolicies {
+		var err error
+		if t == nil {
+			err = errors.New("tag policy must have at least one tag")
+		} else {
+			err = t.Validate()
+		}
+
+		if err != nil {
+			validationError.add(&YamlError{
+				base:   fmt.Sprintf("tag policy config at index %d was invalid", i),
+				errors: []error{err},
+			})
+		}
+	}
+
+	for i, n := range r.Notifications {
+		var err error
+		if n == nil {
+			err = errors.New("notification must have a type and a url")
+		} else {
+			err = n.Validate()
+		}
+
+		if err != nil {
+			validationError.add(&YamlError{
+				base:   fmt.Sprintf("notification config at index %d was invalid", i),
+				errors: []error{err},
+			})
+		}
+	}
+
+	for i, j := range r.JiraIssues {
+		var err error
+		if j == nil {
+			err = errors.New("jira issue must have a jira_project")
+		} else {
+			err = j.Validate()
+		}
+
+		if err != nil {
+			validationError.add(&YamlError{
+				base:   fmt.Sprintf("jira issue config at index %d was invalid", i),
+				errors: []error{err},
+			})
+		}
+	}
+
+	for i, p := range r.PolicyPacks {
+		var err error
+		if p == nil {
+			err = errors.New("policy pack must have a source")
+		} else {
+			err = p.Validate()
+		}
+
+		if err != nil {
+			validationError.add(&YamlError{
+				base:   fmt.Sprintf("policy pack config at index %d was invalid", i),
+				errors: []error{err},
+			})
+		}
+	}
+
+	if r.SpotDiscountPercent != nil && (*r.SpotDiscountPercent < 0 || *r.SpotDiscountPercent >= 100) {
+		validationError.add(errors.New("spot_discount_percent must be at least 0 and less than 100"))
+	}
+
+	for i, e := range r.ExchangeRates {
+		if e == nil || e.Currency == "" || e.Rate <= 0 {
+			validationError.add(&YamlError{
+				base:   fmt.Sprintf("exchange rate config at index %d was invalid", i),
+				errors: []error{errors.New("exchange rate must have a currency and a rate greater than 0")},
+			})
+		}
+	}
+
+	if r.FreeTierAccountCreated != "" {
+		if _, err := time.Parse(dateFormat, r.FreeTierAccountCreated); err != nil {
+			validationError.add(errors.New("free_tier_account_created must be a date in the format YYYY-MM-DD"))
+		}
+	}
+
+	for i, c := range r.Commitments {
+		if c == nil {
+			validationError.add(&YamlError{
+				base:   fmt.Sprintf("commitment config at index %d was invalid", i),
+				errors: []error{errors.New("commitment must have a type and coverage_percent")},
+			})
+			continue
+		}
+
+		if err := c.Validate(); err != nil {
+			validationError.add(&YamlError{
+				base:   fmt.Sprintf("commitment config at index %d was invalid", i),
+				errors: []error{err},
+			})
+		}
+	}
+
 	if validationError.isValid() {
 		return validationError
 	}
@@ -202,6 +379,23 @@ func (f *fileSpec) UnmarshalYAML(unmarshal func(interface{}) error) error {
 	}
 
 	f.Version = c.Version
+	f.PriceBook = c.PriceBook
+	f.AzurePriceSheet = c.AzurePriceSheet
+	f.Discounts = c.Discounts
+	f.Budgets = c.Budgets
+	f.Guardrails = c.Guardrails
+	f.TagPolicies = c.TagPolicies
+	f.Notifications = c.Notifications
+	f.JiraIssues = c.JiraIssues
+	f.PolicyPacks = c.PolicyPacks
+	f.OwnersFile = c.OwnersFile
+	f.WaiversFile = c.WaiversFile
+	f.Commitments = c.Commitments
+	f.SpotDiscountPercent = c.SpotDiscountPercent
+	f.ExchangeRates = c.ExchangeRates
+	f.FreeTier = c.FreeTier
+	f.FreeTierAccountCreated = c.FreeTierAccountCreated
+	f.DeriveUsage = c.DeriveUsage
 	f.Projects = c.Projects
 	return nil
 }
@@ -213,12 +407,15 @@ func loadConfigFile(path string) (fileSpec, error) {
 		return cfgFile, fmt.Errorf("config file does not exist at %s", path)
 	}
 
-	content, err := os.ReadFile(path)
+	content, err := readConfigFile(path)
 	if err != nil {
-		return cfgFile, fmt.Errorf("%w: %s", ErrorInvalidConfigFile, err)
+		return cfgFile, err
 	}
 
-	content = []byte(os.ExpandEnv(string(content)))
+	content, err = resolveConfigIncludes(path, content)
+	if err != nil {
+		return cfgFile, err
+	}
 
 	err = yaml.Unmarshal(content, &cfgFile)
 	if err != nil {
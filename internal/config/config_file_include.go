@@ -0,0 +1,370 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	configIncludeKey   = "include"
+	configTemplatesKey = "templates"
+	configProjectsKey  = "projects"
+	configTemplateKey  = "template"
+)
+
+// configEnvRegex matches the environment variables of a config file: $VAR,
+// ${VAR}, ${VAR:-default}, ${VAR-default}, ${VAR:?message}, ${VAR?message}, and
+// $$ for a literal $.
+var configEnvRegex = regexp.MustCompile(`\$(?:(\$)|\{([A-Za-z_][A-Za-z0-9_]*)(?:(:?[-?])([^}]*))?\}|([A-Za-z_][A-Za-z0-9_]*))`)
+
+// expandConfigEnv replaces the environment variables of a config file with their
+// values. Like the shell, ${VAR:-default} uses the default if VAR is unset or
+// empty and ${VAR:?message} fails with the message, while ${VAR-default} and
+// ${VAR?message} only do so if VAR is unset.
+func expandConfigEnv(content string) (string, error) {
+	var missing []string
+
+	expanded := configEnvRegex.ReplaceAllStringFunc(content, func(m string) string {
+		sub := configEnvRegex.FindStringSubmatch(m)
+
+		if sub[1] == "$" {
+			return "$"
+		}
+
+		if sub[5] != "" {
+			return os.Getenv(sub[5])
+		}
+
+		name, op, arg := sub[2], sub[3], sub[4]
+		value, ok := os.LookupEnv(name)
+		unset := !ok || (strings.HasPrefix(op, ":") && value == "")
+
+		if !unset {
+			return value
+		}
+
+		switch strings.TrimPrefix(op, ":") {
+		case "-":
+			return arg
+		case "?":
+			if arg == "" {
+				arg = "not set"
+			}
+			missing = append(missing, fmt.Sprintf("%s: %s", name, arg))
+		}
+
+		return value
+	})
+
+	if len(missing) > 0 {
+		return "", fmt.Errorf("missing environment variables:\n\t%s", strings.Join(missing, "\n\t"))
+	}
+
+	return expanded, nil
+}
+
+// readConfigFile reads a config file, replacing its environment variables.
+func readConfigFile(path string) ([]byte, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrorInvalidConfigFile, err)
+	}
+
+	expanded, err := expandConfigEnv(string(content))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s: %s", ErrorInvalidConfigFile, path, err)
+	}
+
+	return []byte(expanded), nil
+}
+
+// configIncludes collects the projects and templates of a config file and the
+// fragments it includes.
+type configIncludes struct {
+	visited   map[string]bool
+	templates map[string]yaml.MapSlice
+	projects  []interface{}
+}
+
+// resolveConfigIncludes returns the contents of the config file with the
+// projects of the config fragments it includes and with its project templates
+// applied, so it can be parsed like any other config file:
+//
+//	include:
+//	  - infracost/*.yml
+//	templates:
+//	  prod:
+//	    terraform_workspace: prod
+//	    usage_file: usage/prod.yml
+//	projects:
+//	  - path: apps/api
+//	    template: prod
+//
+// Included fragments can only have include, templates and projects. Their
+// includes are relative to the fragment, while the paths of their projects are
+// relative to where infracost is run, like the config file's own projects. The
+// contents are returned unchanged if the config file doesn't use either.
+func resolveConfigIncludes(path string, content []byte) ([]byte, error) {
+	var doc yaml.MapSlice
+	err := yaml.Unmarshal(content, &doc)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrorInvalidConfigFile, err)
+	}
+
+	if !usesConfigIncludes(doc) {
+		return content, nil
+	}
+
+	absPath, _ := filepath.Abs(path)
+	inc := &configIncludes{
+		visited:   map[string]bool{absPath: true},
+		templates: map[string]yaml.MapSlice{},
+	}
+
+	err = inc.add(path, doc, false)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrorInvalidConfigFile, err)
+	}
+
+	projects := make([]interface{}, 0, len(inc.projects))
+	for i, p := range inc.projects {
+		project, err := inc.applyTemplate(p)
+		if err != nil {
+			return nil, fmt.Errorf("%w: project at index %d: %s", ErrorInvalidConfigFile, i, err)
+		}
+
+		projects = append(projects, project)
+	}
+
+	resolved := make(yaml.MapSlice, 0, len(doc))
+	hasProjects := false
+	for _, item := range doc {
+		switch item.Key {
+		case configIncludeKey, configTemplatesKey:
+			continue
+		case configProjectsKey:
+			item.Value = projects
+			hasProjects = true
+		}
+
+		resolved = append(resolved, item)
+	}
+
+	if !hasProjects && len(projects) > 0 {
+		resolved = append(resolved, yaml.MapItem{Key: configProjectsKey, Value: projects})
+	}
+
+	return yaml.Marshal(resolved)
+}
+
+func usesConfigIncludes(doc yaml.MapSlice) bool {
+	for _, item := range doc {
+		switch item.Key {
+		case configIncludeKey, configTemplatesKey:
+			return true
+		case configProjectsKey:
+			projects, _ := item.Value.([]interface{})
+			for _, p := range projects {
+				if _, ok := mapSliceValue(toMapSlice(p), configTemplateKey); ok {
+					return true
+				}
+			}
+		}
+	}
+
+	return false
+}
+
+// add collects the projects and templates of the config file or fragment at
+// path, in order, with the ones of the fragments it includes where they're
+// included.
+func (c *configIncludes) add(path string, doc yaml.MapSlice, isFragment bool) error {
+	for _, item := range doc {
+		key, _ := item.Key.(string)
+
+		switch key {
+		case configIncludeKey:
+			patterns, ok := item.Value.([]interface{})
+			if !ok {
+				return fmt.Errorf("%s: include must be a list of paths", path)
+			}
+
+			for _, pattern := range patterns {
+				err := c.include(path, fmt.Sprint(pattern))
+				if err != nil {
+					return err
+				}
+			}
+		case configTemplatesKey:
+			templates := toMapSlice(item.Value)
+			if templates == nil {
+				return fmt.Errorf("%s: templates must be a map of template names to project options", path)
+			}
+
+			for _, t := range templates {
+				name := fmt.Sprint(t.Key)
+				if _, ok := c.templates[name]; ok {
+					return fmt.Errorf("%s: template %s is defined more than once", path, name)
+				}
+
+				c.templates[name] = toMapSlice(t.Value)
+			}
+		case configProjectsKey:
+			projects, _ := item.Value.([]interface{})
+			c.projects = append(c.projects, projects...)
+		default:
+			if isFragment {
+				return fmt.Errorf("%s: %s can't be set in an included config file, only include, templates and projects can", path, key)
+			}
+		}
+	}
+
+	return nil
+}
+
+// include adds the fragments matching the pattern, which is relative to the
+// config file or fragment that includes them.
+func (c *configIncludes) include(from string, pattern string) error {
+	if !filepath.IsAbs(pattern) {
+		pattern = filepath.Join(filepath.Dir(from), pattern)
+	}
+
+	matches, err := filepath.Glob(pattern)
+	if err != nil {
+		return fmt.Errorf("%s: invalid include %s: %s", from, pattern, err)
+	}
+
+	if len(matches) == 0 && !strings.ContainsAny(pattern, "*?[") {
+		return fmt.Errorf("%s: included config file %s does not exist", from, pattern)
+	}
+
+	sort.Strings(matches)
+
+	for _, match := range matches {
+		absMatch, _ := filepath.Abs(match)
+		if c.visited[absMatch] {
+			return fmt.Errorf("%s: %s is included more than once", from, match)
+		}
+		c.visited[absMatch] = true
+
+		content, err := readConfigFile(match)
+		if err != nil {
+			return err
+		}
+
+		var doc yaml.MapSlice
+		err = yaml.Unmarshal(content, &doc)
+		if err != nil {
+			return fmt.Errorf("%s: %s", match, err)
+		}
+
+		err = c.add(match, doc, true)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// applyTemplate returns the project with the options of its template that it
+// doesn't override. Map options, e.g. env, are merged with the template's.
+func (c *configIncludes) applyTemplate(p interface{}) (interface{}, error) {
+	project := toMapSlice(p)
+	if project == nil {
+		return p, nil
+	}
+
+	name, ok := mapSliceValue(project, configTemplateKey)
+	if !ok {
+		return p, nil
+	}
+
+	template, ok := c.templates[fmt.Sprint(name)]
+	if !ok {
+		return nil, fmt.Errorf("template %v is not defined", name)
+	}
+
+	merged := make(yaml.MapSlice, 0, len(template)+len(project))
+	for _, item := range template {
+		if v, ok := mapSliceValue(project, item.Key); ok {
+			if overrides := toMapSlice(v); overrides != nil && toMapSlice(item.Value) != nil {
+				item.Value = mergeMapSlices(toMapSlice(item.Value), overrides)
+			} else {
+				item.Value = v
+			}
+		}
+
+		merged = append(merged, item)
+	}
+
+	for _, item := range project {
+		if item.Key == configTemplateKey {
+			continue
+		}
+
+		if _, ok := mapSliceValue(template, item.Key); ok {
+			continue
+		}
+
+		merged = append(merged, item)
+	}
+
+	return merged, nil
+}
+
+func mergeMapSlices(base yaml.MapSlice, overrides yaml.MapSlice) yaml.MapSlice {
+	merged := make(yaml.MapSlice, 0, len(base)+len(overrides))
+	for _, item := range base {
+		if v, ok := mapSliceValue(overrides, item.Key); ok {
+			item.Value = v
+		}
+
+		merged = append(merged, item)
+	}
+
+	for _, item := range overrides {
+		if _, ok := mapSliceValue(base, item.Key); !ok {
+			merged = append(merged, item)
+		}
+	}
+
+	return merged
+}
+
+// toMapSlice returns the YAML mapping as a MapSlice, or nil if it isn't one.
+func toMapSlice(v interface{}) yaml.MapSlice {
+	switch m := v.(type) {
+	case yaml.MapSlice:
+		return m
+	case map[interface{}]interface{}:
+		s := make(yaml.MapSlice, 0, len(m))
+		for k, v := range m {
+			s = append(s, yaml.MapItem{Key: k, Value: v})
+		}
+
+		sort.Slice(s, func(i, j int) bool {
+			return fmt.Sprint(s[i].Key) < fmt.Sprint(s[j].Key)
+		})
+
+		return s
+	}
+
+	return nil
+}
+
+func mapSliceValue(m yaml.MapSlice, key interface{}) (interface{}, bool) {
+	for _, item := range m {
+		if item.Key == key {
+			return item.Value, true
+		}
+	}
+
+	return nil, false
+}
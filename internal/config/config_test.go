@@ -116,3 +116,613 @@ projects:
 		})
 	}
 }
+
+func TestConfigConversionRate(t *testing.T) {
+	c := Config{
+		Currency: "EUR",
+		ExchangeRates: []*ExchangeRate{
+			{Currency: "EUR", Rate: 0.9},
+			{Currency: "GBP", Rate: 0.75},
+		},
+	}
+
+	rate, ok := c.ConversionRate("GBP", "EUR")
+	require.True(t, ok)
+	require.Equal(t, "1.2", rate.String())
+
+	rate, ok = c.ConversionRate("", "EUR")
+	require.True(t, ok)
+	require.Equal(t, "0.9", rate.String())
+
+	rate, ok = c.ConversionRate("eur", "EUR")
+	require.True(t, ok)
+	require.Equal(t, "1", rate.String())
+
+	_, ok = c.ConversionRate("XYZ", "EUR")
+	require.False(t, ok)
+}
+
+func TestConfigRateFor(t *testing.T) {
+	c := Config{
+		Currency: "EUR",
+		ExchangeRates: []*ExchangeRate{
+			{Currency: "GBP", Rate: 0.75},
+		},
+	}
+
+	// Prices are looked up in EUR from the Cloud Pricing API without a pinned rate
+	require.Nil(t, c.RateFor("EUR"))
+	require.Equal(t, "EUR", c.PricingCurrency())
+
+	r := c.RateFor("GBP")
+	require.NotNil(t, r)
+	require.Equal(t, "0.75", r.Rate.String())
+	require.Equal(t, "USD", c.PricingCurrencyFor("GBP"))
+
+	c.ExchangeRate = 0.9
+	r = c.RateFor("EUR")
+	require.NotNil(t, r)
+	require.Equal(t, "0.9", r.Rate.String())
+
+	// The AWS Price List API only has USD prices so they're converted with the built-in rate
+	c = Config{Currency: "EUR", PricingBackend: PricingBackendAWSPriceList}
+	r = c.RateFor("EUR")
+	require.NotNil(t, r)
+	require.Equal(t, "built-in", r.Source)
+
+	// The built-in rates convert the costs of projects with another currency
+	c = Config{Currency: "USD"}
+	rate, ok := c.ConversionRate("EUR", "USD")
+	require.True(t, ok)
+	require.True(t, rate.IsPositive())
+}
+
+func TestConfigCurrencyIsDefault(t *testing.T) {
+	c := Config{Currency: "USD", currencyDefaulted: true}
+	require.True(t, c.CurrencyIsDefault())
+
+	// A currency set after loading the configuration, e.g. by a test, isn't the default
+	c.Currency = "EUR"
+	require.False(t, c.CurrencyIsDefault())
+
+	c = Config{Currency: "USD"}
+	require.False(t, c.CurrencyIsDefault())
+}
+
+func TestConfigLoadFromConfigFileEnv(t *testing.T) {
+	tmp := t.TempDir()
+	t.Setenv("INFRACOST_TEST_WORKSPACE", "prod")
+	t.Setenv("INFRACOST_TEST_EMPTY", "")
+
+	path := filepath.Join(tmp, "infracost.yml")
+	err := os.WriteFile(path, []byte(`version: 0.1
+
+projects:
+  - path: path/to/${INFRACOST_TEST_WORKSPACE}
+    terraform_workspace: $INFRACOST_TEST_WORKSPACE
+    usage_file: ${INFRACOST_TEST_EMPTY:-usage/default.yml}
+    terraform_plan_flags: "${INFRACOST_TEST_EMPTY-unused} $${literal}"
+    terraform_binary: ${INFRACOST_TEST_UNSET:-terraform}
+`), os.ModePerm)
+	require.NoError(t, err)
+
+	c := Config{}
+	err = c.LoadFromConfigFile(path)
+	require.NoError(t, err)
+
+	require.EqualValues(t, []*Project{
+		{
+			Path:               "path/to/prod",
+			TerraformWorkspace: "prod",
+			UsageFile:          "usage/default.yml",
+			TerraformPlanFlags: " ${literal}",
+			TerraformBinary:    "terraform",
+		},
+	}, c.Projects)
+
+	err = os.WriteFile(path, []byte(`version: 0.1
+
+projects:
+  - path: path/to/my_terraform
+    terraform_cloud_token: ${INFRACOST_TEST_UNSET:?set it to the Terraform Cloud token}
+`), os.ModePerm)
+	require.NoError(t, err)
+
+	c = Config{}
+	err = c.LoadFromConfigFile(path)
+	require.Error(t, err)
+	require.True(t, errors.Is(err, ErrorInvalidConfigFile))
+	require.Contains(t, err.Error(), "INFRACOST_TEST_UNSET: set it to the Terraform Cloud token")
+}
+
+func TestConfigLoadFromConfigFilePriceBook(t *testing.T) {
+	tmp := t.TempDir()
+	path := filepath.Join(tmp, "infra", "infracost.yml")
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), os.ModePerm))
+
+	// The price book is relative to the config file rather than the working directory
+	err := os.WriteFile(path, []byte(`version: 0.1
+price_book: ../prices/negotiated.csv
+
+projects:
+  - path: prod
+`), os.ModePerm)
+	require.NoError(t, err)
+
+	c := Config{}
+	err = c.LoadFromConfigFile(path)
+	require.NoError(t, err)
+	require.Equal(t, filepath.Join(tmp, "prices", "negotiated.csv"), c.PriceBookPath)
+
+	abs := filepath.Join(tmp, "negotiated.csv")
+	err = os.WriteFile(path, []byte(fmt.Sprintf(`version: 0.1
+price_book: %s
+
+projects:
+  - path: prod
+`, abs)), os.ModePerm)
+	require.NoError(t, err)
+
+	c = Config{}
+	err = c.LoadFromConfigFile(path)
+	require.NoError(t, err)
+	require.Equal(t, abs, c.PriceBookPath)
+}
+
+func TestConfigLoadFromConfigFileIncludes(t *testing.T) {
+	tmp := t.TempDir()
+
+	write := func(name string, contents string) string {
+		path := filepath.Join(tmp, name)
+		err := os.MkdirAll(filepath.Dir(path), os.ModePerm)
+		require.NoError(t, err)
+		err = os.WriteFile(path, []byte(contents), os.ModePerm)
+		require.NoError(t, err)
+		return path
+	}
+
+	write("infracost/templates.yml", `templates:
+  prod:
+    terraform_workspace: prod
+    usage_file: usage/prod.yml
+    env:
+      REGION: us-east-1
+      STAGE: prod
+`)
+	write("infracost/teams/a.yml", `projects:
+  - path: apps/a
+    template: prod
+`)
+	write("infracost/teams/b.yml", `projects:
+  - path: apps/b
+    template: prod
+    usage_file: usage/b.yml
+    env:
+      REGION: eu-west-1
+      OWNER: team-b
+`)
+
+	path := write("infracost.yml", `version: 0.1
+
+include:
+  - infracost/templates.yml
+  - infracost/teams/*.yml
+
+projects:
+  - path: apps/root
+`)
+
+	c := Config{}
+	err := c.LoadFromConfigFile(path)
+	require.NoError(t, err)
+
+	require.EqualValues(t, []*Project{
+		{
+			Path:               "apps/a",
+			TerraformWorkspace: "prod",
+			UsageFile:          "usage/prod.yml",
+			Env:                map[string]string{"REGION": "us-east-1", "STAGE": "prod"},
+		},
+		{
+			Path:               "apps/b",
+			TerraformWorkspace: "prod",
+			UsageFile:          "usage/b.yml",
+			Env:                map[string]string{"REGION": "eu-west-1", "STAGE": "prod", "OWNER": "team-b"},
+		},
+		{
+			Path: "apps/root",
+		},
+	}, c.Projects)
+}
+
+func TestConfigLoadFromConfigFileIncludeErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		files    map[string]string
+		contains string
+	}{
+		{
+			name: "should error if template is not defined",
+			files: map[string]string{
+				"infracost.yml": `version: 0.1
+
+projects:
+  - path: apps/a
+    template: missing
+`,
+			},
+			contains: "project at index 0: template missing is not defined",
+		},
+		{
+			name: "should error if includes are cyclic",
+			files: map[string]string{
+				"infracost.yml": `version: 0.1
+
+include:
+  - a.yml
+`,
+				"a.yml": `include:
+  - infracost.yml
+`,
+			},
+			contains: "infracost.yml is included more than once",
+		},
+		{
+			name: "should error if an included file does not exist",
+			files: map[string]string{
+				"infracost.yml": `version: 0.1
+
+include:
+  - missing.yml
+`,
+			},
+			contains: "missing.yml does not exist",
+		},
+		{
+			name: "should error if an included file sets other options",
+			files: map[string]string{
+				"infracost.yml": `version: 0.1
+
+include:
+  - a.yml
+`,
+				"a.yml": `version: 0.1
+`,
+			},
+			contains: "version can't be set in an included config file",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmp := t.TempDir()
+			for name, contents := range tt.files {
+				err := os.WriteFile(filepath.Join(tmp, name), []byte(contents), os.ModePerm)
+				require.NoError(t, err)
+			}
+
+			c := Config{}
+			err := c.LoadFromConfigFile(filepath.Join(tmp, "infracost.yml"))
+			require.Error(t, err)
+			require.True(t, errors.Is(err, ErrorInvalidConfigFile))
+			require.Contains(t, err.Error(), tt.contains)
+		})
+	}
+}
+
+func TestConfigLoadFromConfigFileBudgets(t *testing.T) {
+	tmp := t.TempDir()
+	path := filepath.Join(tmp, "infracost.yml")
+
+	err := os.WriteFile(path, []byte(`version: 0.1
+
+budgets:
+  - name: Prod
+    project: environments/prod
+    max_monthly_cost: 5000
+    max_monthly_increase_percent: 10
+
+projects:
+  - path: environments/prod
+`), os.ModePerm)
+	require.NoError(t, err)
+
+	c := Config{}
+	err = c.LoadFromConfigFile(path)
+	require.NoError(t, err)
+	require.Len(t, c.Budgets, 1)
+	require.Equal(t, "Prod", c.Budgets[0].Label())
+	require.Equal(t, 5000.0, *c.Budgets[0].MaxMonthlyCost)
+	require.Equal(t, 10.0, *c.Budgets[0].MaxMonthlyIncreasePercent)
+	require.Nil(t, c.Budgets[0].MaxMonthlyIncrease)
+
+	err = os.WriteFile(path, []byte(`version: 0.1
+
+budgets:
+  - project: environments/prod
+
+projects:
+  - path: environments/prod
+`), os.ModePerm)
+	require.NoError(t, err)
+
+	c = Config{}
+	err = c.LoadFromConfigFile(path)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "budget config at index 0 was invalid")
+	require.Contains(t, err.Error(), "budget must have a max_monthly_cost, max_monthly_increase or max_monthly_increase_percent")
+}
+
+func TestConfigLoadFromConfigFileGuardrails(t *testing.T) {
+	tmp := t.TempDir()
+	path := filepath.Join(tmp, "infracost.yml")
+
+	err := os.WriteFile(path, []byte(`version: 0.1
+
+guardrails:
+  - project: "environments/*"
+    max_monthly_cost: 5000
+    block: true
+  - module: module.db*
+    max_monthly_increase_percent: 20
+  - resource_type: aws_instance
+    max_monthly_cost: 500
+
+projects:
+  - path: environments/prod
+`), os.ModePerm)
+	require.NoError(t, err)
+
+	c := Config{}
+	err = c.LoadFromConfigFile(path)
+	require.NoError(t, err)
+	require.Len(t, c.Guardrails, 3)
+	require.Equal(t, GuardrailScopeProject, c.Guardrails[0].EffectiveScope())
+	require.True(t, c.Guardrails[0].Block)
+	require.Equal(t, GuardrailScopeModule, c.Guardrails[1].EffectiveScope())
+	require.Equal(t, "each module matching module module.db*", c.Guardrails[1].Label())
+	require.Equal(t, GuardrailScopeResource, c.Guardrails[2].EffectiveScope())
+
+	err = os.WriteFile(path, []byte(`version: 0.1
+
+guardrails:
+  - scope: team
+    max_monthly_cost: 5000
+
+projects:
+  - path: environments/prod
+`), os.ModePerm)
+	require.NoError(t, err)
+
+	c = Config{}
+	err = c.LoadFromConfigFile(path)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "guardrail config at index 0 was invalid")
+	require.Contains(t, err.Error(), "guardrail scope must be one of project, module, resource")
+
+	err = os.WriteFile(path, []byte(`version: 0.1
+
+guardrails:
+  - resource_type: aws_nat_gateway
+    condition: resource.monthlyCost > 500.0
+  - condition: project.monthlyCost >
+  - condition: project.monthlyCost > 1000.0
+    max_monthly_cost: 1000
+
+projects:
+  - path: environments/prod
+`), os.ModePerm)
+	require.NoError(t, err)
+
+	c = Config{}
+	err = c.LoadFromConfigFile(path)
+	require.Error(t, err)
+	require.NotContains(t, err.Error(), "guardrail config at index 0 was invalid")
+	require.Contains(t, err.Error(), "guardrail config at index 1 was invalid")
+	require.Contains(t, err.Error(), "guardrail config at index 2 was invalid")
+	require.Contains(t, err.Error(), "guardrail can't have both a condition and limits")
+}
+
+func TestConfigLoadFromConfigFileTagPolicies(t *testing.T) {
+	tmp := t.TempDir()
+	path := filepath.Join(tmp, "infracost.yml")
+
+	err := os.WriteFile(path, []byte(`version: 0.1
+
+tag_policies:
+  - resource_types: ["aws_*"]
+    tags:
+      - key: team
+      - key: env
+        values: [dev, prod*]
+
+projects:
+  - path: environments/prod
+`), os.ModePerm)
+	require.NoError(t, err)
+
+	c := Config{}
+	err = c.LoadFromConfigFile(path)
+	require.NoError(t, err)
+	require.Len(t, c.TagPolicies, 1)
+	require.Equal(t, "required tags team, env", c.TagPolicies[0].Label())
+	require.Equal(t, []string{"dev", "prod*"}, c.TagPolicies[0].Tags[1].Values)
+
+	err = os.WriteFile(path, []byte(`version: 0.1
+
+tag_policies:
+  - tags:
+      - values: [prod]
+
+projects:
+  - path: environments/prod
+`), os.ModePerm)
+	require.NoError(t, err)
+
+	c = Config{}
+	err = c.LoadFromConfigFile(path)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "tag policy config at index 0 was invalid")
+	require.Contains(t, err.Error(), "tag policy tags must have a key")
+}
+
+func TestConfigLoadFromConfigFileNotifications(t *testing.T) {
+	t.Setenv("SLACK_WEBHOOK_URL", "https://hooks.slack.com/services/T0/B0/abc")
+
+	tmp := t.TempDir()
+	path := filepath.Join(tmp, "infracost.yml")
+
+	err := os.WriteFile(path, []byte(`version: 0.1
+
+notifications:
+  - type: slack
+    url: ${SLACK_WEBHOOK_URL}
+    channel: "#platform-alerts"
+    min_monthly_increase: 500
+  - name: Prod changes
+    type: teams
+    url: https://example.webhook.office.com/webhookb2/abc
+    project: "environments/prod*"
+
+projects:
+  - path: environments/prod
+`), os.ModePerm)
+	require.NoError(t, err)
+
+	c := Config{}
+	err = c.LoadFromConfigFile(path)
+	require.NoError(t, err)
+	require.Len(t, c.Notifications, 2)
+	require.Equal(t, "https://hooks.slack.com/services/T0/B0/abc", c.Notifications[0].URL)
+	require.Equal(t, 500.0, *c.Notifications[0].MinMonthlyIncrease)
+	require.Equal(t, "slack #platform-alerts", c.Notifications[0].Label())
+	require.Equal(t, "Prod changes", c.Notifications[1].Label())
+
+	err = os.WriteFile(path, []byte(`version: 0.1
+
+notifications:
+  - type: email
+    url: https://example.com
+  - type: discord
+
+projects:
+  - path: environments/prod
+`), os.ModePerm)
+	require.NoError(t, err)
+
+	c = Config{}
+	err = c.LoadFromConfigFile(path)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "notification type must be one of slack, teams, discord, webhook")
+	require.Contains(t, err.Error(), "notification config at index 1 was invalid")
+	require.Contains(t, err.Error(), "notification must have a url")
+}
+
+func TestConfigLoadFromConfigFileJiraIssues(t *testing.T) {
+	tmp := t.TempDir()
+	path := filepath.Join(tmp, "infracost.yml")
+
+	err := os.WriteFile(path, []byte(`version: 0.1
+
+jira_issues:
+  - project: "environments/prod*"
+    jira_project: FINOPS
+    labels: [cost]
+    blocking_only: true
+  - jira_project: PLAT
+    issue_type: Bug
+
+projects:
+  - path: environments/prod
+`), os.ModePerm)
+	require.NoError(t, err)
+
+	c := Config{}
+	err = c.LoadFromConfigFile(path)
+	require.NoError(t, err)
+	require.Len(t, c.JiraIssues, 2)
+	require.Equal(t, "FINOPS", c.JiraIssues[0].JiraProject)
+	require.Equal(t, "Task", c.JiraIssues[0].IssueTypeOrDefault())
+	require.True(t, c.JiraIssues[0].BlockingOnly)
+	require.Equal(t, "Bug", c.JiraIssues[1].IssueTypeOrDefault())
+
+	err = os.WriteFile(path, []byte(`version: 0.1
+
+jira_issues:
+  - project: "environments/prod*"
+  - jira_project: FINOPS
+    labels: ["cost review"]
+
+projects:
+  - path: environments/prod
+`), os.ModePerm)
+	require.NoError(t, err)
+
+	c = Config{}
+	err = c.LoadFromConfigFile(path)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "jira issue must have a jira_project")
+	require.Contains(t, err.Error(), "jira issue config at index 1 was invalid")
+	require.Contains(t, err.Error(), `jira issue label "cost review" can't be empty or contain spaces`)
+}
+
+func TestConfigLoadPolicyPack(t *testing.T) {
+	dir := t.TempDir()
+
+	err := os.WriteFile(filepath.Join(dir, "infracost-pack.yml"), []byte(`version: 0.1
+name: acme
+price_book: prices.csv
+policies:
+  - policies
+
+guardrails:
+  - name: Org limit
+    max_monthly_cost: 10000
+
+tag_policies:
+  - tags:
+      - key: team
+`), os.ModePerm)
+	require.NoError(t, err)
+
+	c := Config{
+		Guardrails: []*Guardrail{{Name: "Repo limit"}},
+	}
+	err = c.LoadPolicyPack(dir)
+	require.NoError(t, err)
+	require.Equal(t, filepath.Join(dir, "prices.csv"), c.PriceBookPath)
+	require.Equal(t, []string{filepath.Join(dir, "policies")}, c.PolicyPaths)
+	require.Len(t, c.Guardrails, 2)
+	require.Equal(t, "Org limit", c.Guardrails[1].Name)
+	require.Len(t, c.TagPolicies, 1)
+
+	// The price book of the config file overrides the pack's
+	c = Config{PriceBookPath: "repo-prices.csv"}
+	err = c.LoadPolicyPack(dir)
+	require.NoError(t, err)
+	require.Equal(t, "repo-prices.csv", c.PriceBookPath)
+
+	err = os.WriteFile(filepath.Join(dir, "infracost-pack.yml"), []byte(`version: 0.1
+policies:
+  - ../other
+`), os.ModePerm)
+	require.NoError(t, err)
+
+	c = Config{}
+	err = c.LoadPolicyPack(dir)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "is outside of the pack")
+}
+
+func TestParsePolicyPack(t *testing.T) {
+	p, err := ParsePolicyPack("https://example.com/pack.tar.gz#sha256:4f2c8b0d6e1a9f3c5b7d2e4a6c8f0b1d3e5a7c9f2b4d6e8a0c2e4f6a8b0d2e4f")
+	require.NoError(t, err)
+	require.Equal(t, "https://example.com/pack.tar.gz", p.Source)
+	require.Equal(t, "sha256:4f2c8b0d6e1a9f3c5b7d2e4a6c8f0b1d3e5a7c9f2b4d6e8a0c2e4f6a8b0d2e4f", p.Checksum)
+
+	_, err = ParsePolicyPack("http://example.com/pack.tar.gz")
+	require.Error(t, err)
+
+	_, err = ParsePolicyPack("oci://ghcr.io/acme/policies:v1#md5:abc")
+	require.Error(t, err)
+}
@@ -40,6 +40,7 @@ func loadConfiguration(cfg *Config) error {
 	}
 	if cfg.Currency == "" {
 		cfg.Currency = "USD"
+		cfg.currencyDefaulted = true
 	}
 
 	if cfg.Configuration.EnableDashboard != nil {
@@ -0,0 +1,155 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
+
+	"github.com/infracost/infracost/internal/policypack"
+)
+
+// PolicyPack is a versioned bundle of Rego policies, guardrails, tag policies,
+// budgets and a price book that's shared between repos and fetched at run time,
+// see the policypack package.
+type PolicyPack struct {
+	// Source is the HTTPS URL of a .tar.gz archive of the pack or its OCI
+	// reference, e.g. oci://ghcr.io/acme/infracost-policies:v1.2.0.
+	Source string `yaml:"source"`
+	// Checksum pins the pack to the sha256 of its archive, e.g. sha256:4f2c...,
+	// the run fails if the pack changes.
+	Checksum string `yaml:"checksum,omitempty"`
+}
+
+// ParsePolicyPack parses a pack given as a flag, its checksum can be pinned by
+// adding it after a #, e.g. https://example.com/pack.tar.gz#sha256:4f2c...
+func ParsePolicyPack(s string) (*PolicyPack, error) {
+	p := &PolicyPack{Source: s}
+
+	if i := strings.LastIndex(s, "#"); i >= 0 {
+		p.Source, p.Checksum = s[:i], s[i+1:]
+	}
+
+	return p, p.Validate()
+}
+
+// Validate returns an error if the pack has no source or an invalid checksum.
+func (p *PolicyPack) Validate() error {
+	if !strings.HasPrefix(p.Source, "https://") && !policypack.IsOCI(p.Source) {
+		return errors.New("policy pack source must be an https:// URL or an oci:// reference")
+	}
+
+	if p.Checksum != "" && !policypack.ValidChecksum(p.Checksum) {
+		return errors.New("policy pack checksum must be sha256:<hex digest>")
+	}
+
+	return nil
+}
+
+// policyPackSpec is the manifest of a pack, its paths are relative to the pack.
+type policyPackSpec struct {
+	Version     string       `yaml:"version"`
+	Name        string       `yaml:"name"`
+	PriceBook   string       `yaml:"price_book,omitempty"`
+	Policies    []string     `yaml:"policies,omitempty"`
+	Budgets     []*Budget    `yaml:"budgets,omitempty"`
+	Guardrails  []*Guardrail `yaml:"guardrails,omitempty"`
+	TagPolicies []*TagPolicy `yaml:"tag_policies,omitempty"`
+}
+
+// LoadPolicyPack adds the contents of the pack extracted to dir to the config.
+// Its budgets, guardrails and tag policies are added after the ones of the
+// config file and its Rego policies to PolicyPaths. Its price book is used
+// unless the config sets one, so repos can still override it.
+func (c *Config) LoadPolicyPack(dir string) error {
+	content, err := os.ReadFile(filepath.Join(dir, policypack.ManifestFile))
+	if err != nil {
+		return fmt.Errorf("Error reading policy pack manifest: %w", err)
+	}
+
+	var spec policyPackSpec
+	err = yaml.Unmarshal(content, &spec)
+	if err != nil {
+		return fmt.Errorf("Error parsing policy pack manifest: %w", err)
+	}
+
+	if !checkVersion(spec.Version) {
+		return fmt.Errorf("policy pack version '%s' is not supported, valid versions are %s ≤ x ≤ %s", spec.Version, minConfigFileVersion, maxConfigFileVersion)
+	}
+
+	name := spec.Name
+	if name == "" {
+		name = filepath.Base(dir)
+	}
+
+	err = spec.validate()
+	if err != nil {
+		return fmt.Errorf("policy pack %s is invalid: %w", name, err)
+	}
+
+	if spec.PriceBook != "" && c.PriceBookPath == "" {
+		c.PriceBookPath, err = packPath(dir, spec.PriceBook)
+		if err != nil {
+			return fmt.Errorf("policy pack %s is invalid: %w", name, err)
+		}
+	}
+
+	for _, p := range spec.Policies {
+		path, err := packPath(dir, p)
+		if err != nil {
+			return fmt.Errorf("policy pack %s is invalid: %w", name, err)
+		}
+
+		c.PolicyPaths = append(c.PolicyPaths, path)
+	}
+
+	c.Budgets = append(c.Budgets, spec.Budgets...)
+	c.Guardrails = append(c.Guardrails, spec.Guardrails...)
+	c.TagPolicies = append(c.TagPolicies, spec.TagPolicies...)
+
+	return nil
+}
+
+func (s *policyPackSpec) validate() error {
+	for i, b := range s.Budgets {
+		if b == nil {
+			return fmt.Errorf("budget at index %d is empty", i)
+		}
+		if err := b.Validate(); err != nil {
+			return fmt.Errorf("budget at index %d: %w", i, err)
+		}
+	}
+
+	for i, g := range s.Guardrails {
+		if g == nil {
+			return fmt.Errorf("guardrail at index %d is empty", i)
+		}
+		if err := g.Validate(); err != nil {
+			return fmt.Errorf("guardrail at index %d: %w", i, err)
+		}
+	}
+
+	for i, t := range s.TagPolicies {
+		if t == nil {
+			return fmt.Errorf("tag policy at index %d is empty", i)
+		}
+		if err := t.Validate(); err != nil {
+			return fmt.Errorf("tag policy at index %d: %w", i, err)
+		}
+	}
+
+	return nil
+}
+
+// packPath returns the path of a file of the pack, which must be inside it.
+func packPath(dir string, path string) (string, error) {
+	rel := filepath.Clean(filepath.FromSlash(path))
+	if filepath.IsAbs(rel) || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("%s is outside of the pack", path)
+	}
+
+	return filepath.Join(dir, rel), nil
+}
@@ -13,6 +13,10 @@ import (
 	"github.com/google/uuid"
 	log "github.com/sirupsen/logrus"
 
+	"github.com/infracost/infracost/internal/awspricelist"
+	"github.com/infracost/infracost/internal/pricecache"
+	"github.com/infracost/infracost/internal/pricesnapshot"
+	"github.com/infracost/infracost/internal/resultcache"
 	"github.com/infracost/infracost/internal/version"
 )
 
@@ -24,6 +28,19 @@ type RunContext struct {
 	contextVals map[string]interface{}
 	StartTime   int64
 
+	// PriceSnapshot is used to price resources when running offline and to record
+	// the prices fetched by `infracost pricing download`.
+	PriceSnapshot *pricesnapshot.Snapshot
+
+	// AWSPriceList prices AWS resources when the pricing backend is aws-price-list.
+	AWSPriceList *awspricelist.Client
+
+	// PriceCache caches the results of Cloud Pricing API queries between runs.
+	PriceCache *pricecache.Cache
+
+	// ResultCache caches the estimates of projects between runs, see --cache-results.
+	ResultCache *resultcache.Cache
+
 	OutWriter io.Writer
 	ErrWriter io.Writer
 	Exit      func(code int)
@@ -180,6 +197,7 @@ var ciMap = ciEnvMap{
 		"TDDIUM":               "tddium",
 		"GREENHOUSE":           "greenhouse",
 		"CIRRUS_CI":            "cirrusci",
+		"DRONE":                "drone",
 	},
 	prefixes: map[string]string{
 		"ATLANTIS_":  "atlantis",
@@ -205,6 +223,11 @@ func ciPlatform() string {
 		}
 	}
 
+	// Woodpecker only sets CI to its name.
+	if os.Getenv("CI") == "woodpecker" {
+		return "woodpecker"
+	}
+
 	if IsEnvPresent("CI") {
 		return "ci"
 	}
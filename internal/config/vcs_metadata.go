@@ -0,0 +1,239 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/infracost/infracost/internal/schema"
+)
+
+// DetectVCSMetadata returns the commit and CI pipeline of the run from the
+// environment variables of GitHub Actions, GitLab CI, Bitbucket Pipelines and
+// Azure Pipelines. What isn't set by the CI system is read from the git repo at
+// path, and any of it can be overridden with the INFRACOST_VCS_* environment
+// variables, e.g. INFRACOST_VCS_BRANCH. It returns nil if nothing was found.
+func DetectVCSMetadata(path string) *schema.VCSMetadata {
+	var m schema.VCSMetadata
+
+	switch {
+	case IsEnvPresent("GITHUB_ACTIONS"):
+		m = githubVCSMetadata()
+	case IsEnvPresent("GITLAB_CI"):
+		m = gitlabVCSMetadata()
+	case IsEnvPresent("BITBUCKET_BUILD_NUMBER"):
+		m = bitbucketVCSMetadata()
+	case IsEnvPresent("SYSTEM_COLLECTIONURI"):
+		m = azureDevOpsVCSMetadata()
+	}
+
+	m.Platform = ciPlatform()
+	if m.RepositoryURL == "" {
+		m.RepositoryURL = ciVCSRepo()
+	}
+	if m.PullRequestURL == "" {
+		m.PullRequestURL = ciVCSPullRequestURL()
+	}
+
+	if path != "" {
+		addGitVCSMetadata(&m, path)
+	}
+
+	overrides := []struct {
+		env   string
+		field *string
+	}{
+		{"INFRACOST_VCS_REPOSITORY_URL", &m.RepositoryURL},
+		{"INFRACOST_VCS_BRANCH", &m.Branch},
+		{"INFRACOST_VCS_BASE_BRANCH", &m.BaseBranch},
+		{"INFRACOST_VCS_COMMIT_SHA", &m.CommitSHA},
+		{"INFRACOST_VCS_COMMIT_AUTHOR", &m.CommitAuthor},
+		{"INFRACOST_VCS_PIPELINE_ID", &m.PipelineID},
+		{"INFRACOST_VCS_PIPELINE_URL", &m.PipelineURL},
+		{"INFRACOST_VCS_PULL_REQUEST_NUMBER", &m.PullRequestNumber},
+		{"INFRACOST_VCS_PULL_REQUEST_URL", &m.PullRequestURL},
+	}
+	for _, o := range overrides {
+		if v := os.Getenv(o.env); v != "" {
+			*o.field = v
+		}
+	}
+
+	m.RepositoryURL = stripVCSRepoPassword(m.RepositoryURL)
+
+	if m.IsEmpty() {
+		return nil
+	}
+
+	return &m
+}
+
+func githubVCSMetadata() schema.VCSMetadata {
+	serverURL := os.Getenv("GITHUB_SERVER_URL")
+	if serverURL == "" {
+		serverURL = "https://github.com"
+	}
+
+	m := schema.VCSMetadata{
+		Branch:       os.Getenv("GITHUB_REF_NAME"),
+		BaseBranch:   os.Getenv("GITHUB_BASE_REF"),
+		CommitSHA:    os.Getenv("GITHUB_SHA"),
+		CommitAuthor: os.Getenv("GITHUB_ACTOR"),
+		PipelineID:   os.Getenv("GITHUB_RUN_ID"),
+	}
+
+	if head := os.Getenv("GITHUB_HEAD_REF"); head != "" {
+		m.Branch = head
+	}
+
+	if m.PipelineID != "" && IsEnvPresent("GITHUB_REPOSITORY") {
+		m.PipelineURL = fmt.Sprintf("%s/%s/actions/runs/%s", serverURL, os.Getenv("GITHUB_REPOSITORY"), m.PipelineID)
+	}
+
+	// GITHUB_SHA is the merge commit of a pull request, the event has the
+	// commit of its branch.
+	if IsEnvPresent("GITHUB_EVENT_PATH") && strings.HasPrefix(os.Getenv("GITHUB_EVENT_NAME"), "pull_request") {
+		var event struct {
+			PullRequest struct {
+				Number int `json:"number"`
+				Head   struct {
+					SHA string `json:"sha"`
+				} `json:"head"`
+			} `json:"pull_request"`
+		}
+
+		b, err := os.ReadFile(os.Getenv("GITHUB_EVENT_PATH"))
+		if err == nil {
+			err = json.Unmarshal(b, &event)
+		}
+		if err != nil {
+			log.Debugf("Error reading GITHUB_EVENT_PATH JSON: %v", err)
+		}
+
+		if event.PullRequest.Number > 0 {
+			m.PullRequestNumber = fmt.Sprint(event.PullRequest.Number)
+		}
+		if event.PullRequest.Head.SHA != "" {
+			m.CommitSHA = event.PullRequest.Head.SHA
+		}
+	}
+
+	return m
+}
+
+func gitlabVCSMetadata() schema.VCSMetadata {
+	m := schema.VCSMetadata{
+		Branch:            os.Getenv("CI_COMMIT_REF_NAME"),
+		BaseBranch:        os.Getenv("CI_MERGE_REQUEST_TARGET_BRANCH_NAME"),
+		CommitSHA:         os.Getenv("CI_COMMIT_SHA"),
+		CommitAuthor:      os.Getenv("CI_COMMIT_AUTHOR"),
+		PipelineID:        os.Getenv("CI_PIPELINE_ID"),
+		PipelineURL:       os.Getenv("CI_PIPELINE_URL"),
+		PullRequestNumber: os.Getenv("CI_MERGE_REQUEST_IID"),
+	}
+
+	if source := os.Getenv("CI_MERGE_REQUEST_SOURCE_BRANCH_NAME"); source != "" {
+		m.Branch = source
+	}
+
+	if m.CommitAuthor == "" {
+		m.CommitAuthor = os.Getenv("GITLAB_USER_LOGIN")
+	}
+
+	return m
+}
+
+func bitbucketVCSMetadata() schema.VCSMetadata {
+	origin := os.Getenv("BITBUCKET_GIT_HTTP_ORIGIN")
+
+	m := schema.VCSMetadata{
+		Branch:            os.Getenv("BITBUCKET_BRANCH"),
+		BaseBranch:        os.Getenv("BITBUCKET_PR_DESTINATION_BRANCH"),
+		CommitSHA:         os.Getenv("BITBUCKET_COMMIT"),
+		PipelineID:        os.Getenv("BITBUCKET_BUILD_NUMBER"),
+		PullRequestNumber: os.Getenv("BITBUCKET_PR_ID"),
+	}
+
+	if origin != "" {
+		m.PipelineURL = fmt.Sprintf("%s/addon/pipelines/home#!/results/%s", origin, m.PipelineID)
+
+		if m.PullRequestNumber != "" {
+			m.PullRequestURL = fmt.Sprintf("%s/pull-requests/%s", origin, m.PullRequestNumber)
+		}
+	}
+
+	return m
+}
+
+func azureDevOpsVCSMetadata() schema.VCSMetadata {
+	m := schema.VCSMetadata{
+		Branch:       os.Getenv("BUILD_SOURCEBRANCHNAME"),
+		BaseBranch:   strings.TrimPrefix(os.Getenv("SYSTEM_PULLREQUEST_TARGETBRANCH"), "refs/heads/"),
+		CommitSHA:    os.Getenv("BUILD_SOURCEVERSION"),
+		CommitAuthor: os.Getenv("BUILD_REQUESTEDFOR"),
+		PipelineID:   os.Getenv("BUILD_BUILDID"),
+	}
+
+	if source := os.Getenv("SYSTEM_PULLREQUEST_SOURCEBRANCH"); source != "" {
+		m.Branch = strings.TrimPrefix(source, "refs/heads/")
+	}
+
+	// Repos hosted on GitHub have the pull request number rather than its ID.
+	m.PullRequestNumber = os.Getenv("SYSTEM_PULLREQUEST_PULLREQUESTNUMBER")
+	if m.PullRequestNumber == "" {
+		m.PullRequestNumber = os.Getenv("SYSTEM_PULLREQUEST_PULLREQUESTID")
+	}
+
+	if m.PipelineID != "" && IsEnvPresent("SYSTEM_TEAMPROJECT") {
+		m.PipelineURL = fmt.Sprintf("%s%s/_build/results?buildId=%s", os.Getenv("SYSTEM_COLLECTIONURI"), os.Getenv("SYSTEM_TEAMPROJECT"), m.PipelineID)
+	}
+
+	return m
+}
+
+// addGitVCSMetadata sets the repo, branch, commit and author that aren't set
+// from the git repo at path.
+func addGitVCSMetadata(m *schema.VCSMetadata, path string) {
+	dir := path
+	if !isDir(path) {
+		dir = filepath.Dir(path)
+	}
+
+	git := func(args ...string) string {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+
+		out, err := cmd.Output()
+		if err != nil {
+			return ""
+		}
+
+		return strings.TrimSpace(string(out))
+	}
+
+	if m.CommitSHA == "" {
+		m.CommitSHA = git("rev-parse", "HEAD")
+		if m.CommitSHA == "" {
+			log.Debugf("Could not detect a git repo at %s", path)
+			return
+		}
+	}
+
+	if m.RepositoryURL == "" {
+		m.RepositoryURL = gitRepo(path)
+	}
+
+	// A detached HEAD, e.g. a CI checkout of a commit, has no branch
+	if branch := git("rev-parse", "--abbrev-ref", "HEAD"); m.Branch == "" && branch != "HEAD" {
+		m.Branch = branch
+	}
+
+	if m.CommitAuthor == "" {
+		m.CommitAuthor = git("log", "-1", "--format=%an")
+	}
+}
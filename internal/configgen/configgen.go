@@ -0,0 +1,259 @@
+// Package configgen generates a config file for the root modules of a repo
+// from a Go template, so the config of a large monorepo can be regenerated
+// deterministically in CI as projects are added.
+package configgen
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/Masterminds/sprig"
+
+	"github.com/infracost/infracost/internal/providers"
+)
+
+// DefaultTemplate is the template used when no template is given. Projects
+// with var files named after an environment, e.g. prod.tfvars, get a project
+// for each environment.
+var DefaultTemplate = `version: 0.1
+
+projects:
+{{- range $project := .Projects }}
+{{- if $project.EnvVarFiles }}
+{{- range $project.EnvVarFiles }}
+  - path: {{ $project.Path }}
+    name: {{ $project.Name }}-{{ .Env }}
+    terraform_var_files:
+      - {{ .Path }}
+    labels:
+      env: {{ .Env }}
+{{- if $project.UsageFile }}
+    usage_file: {{ $project.UsageFile }}
+{{- end }}
+{{- end }}
+{{- else }}
+  - path: {{ $project.Path }}
+    name: {{ $project.Name }}
+{{- if $project.Env }}
+    labels:
+      env: {{ $project.Env }}
+{{- end }}
+{{- if $project.VarFiles }}
+    terraform_var_files:
+{{- range $project.VarFiles }}
+      - {{ .Path }}
+{{- end }}
+{{- end }}
+{{- if $project.UsageFile }}
+    usage_file: {{ $project.UsageFile }}
+{{- end }}
+{{- end }}
+{{- end }}
+`
+
+// envNames are the names of directories and var files that are taken to be
+// environments.
+var envNames = regexp.MustCompile(`^(dev|develop|development|test|testing|qa|uat|stage|staging|preprod|prod|production|sandbox|demo)$`)
+
+// usageFileNames are the names of the usage files that are set as the usage
+// file of the project they're in.
+var usageFileNames = []string{"infracost-usage.yml", "infracost-usage.yaml"}
+
+// VarFile is a var file of a project, Env is the environment its name refers
+// to, if any, e.g. prod for prod.tfvars.
+type VarFile struct {
+	Path string
+	Env  string
+}
+
+// Project is a root module found in the repo.
+type Project struct {
+	// Path is the path of the project relative to the repo, using / separators.
+	Path string
+	// Name is the path with its separators replaced by -, e.g. envs-prod-app.
+	Name string
+	// Env is the environment the path refers to, e.g. prod for envs/prod/app.
+	Env string
+	// VarFiles are the .tfvars and .tfvars.json files of the project that
+	// aren't loaded by Terraform automatically, relative to the project.
+	VarFiles []VarFile
+	// EnvVarFiles are the VarFiles that refer to an environment.
+	EnvVarFiles []VarFile
+	// UsageFile is the infracost-usage.yml of the project, if it has one,
+	// relative to the repo.
+	UsageFile string
+}
+
+// TemplateData is what the template of the config file is rendered with.
+type TemplateData struct {
+	RepoPath string
+	Projects []Project
+}
+
+// Options sets how the config file is generated.
+type Options struct {
+	// TemplatePath is the path of the template, DefaultTemplate is used if it's
+	// empty.
+	TemplatePath string
+	// MaxDepth is how many directories deep root modules are looked for.
+	MaxDepth int
+}
+
+// Generate renders the template with the root modules found in the repo, see
+// providers.AutodetectProjects for how they're found. The projects are sorted by
+// path so the output only changes when the repo does.
+func Generate(repoPath string, opts Options) ([]byte, error) {
+	text := DefaultTemplate
+	name := "default"
+
+	if opts.TemplatePath != "" {
+		b, err := os.ReadFile(opts.TemplatePath)
+		if err != nil {
+			return nil, fmt.Errorf("Error reading template: %w", err)
+		}
+
+		text = string(b)
+		name = filepath.Base(opts.TemplatePath)
+	}
+
+	tmpl, err := template.New(name).Funcs(sprig.TxtFuncMap()).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("Error parsing template: %w", err)
+	}
+
+	data, err := FindProjects(repoPath, opts.MaxDepth)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	err = tmpl.Execute(&buf, data)
+	if err != nil {
+		return nil, fmt.Errorf("Error rendering template: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// FindProjects returns the root modules of the repo with the hints used to
+// render the template.
+func FindProjects(repoPath string, maxDepth int) (TemplateData, error) {
+	data := TemplateData{RepoPath: repoPath}
+
+	results, err := providers.AutodetectProjects(repoPath, maxDepth)
+	if err != nil {
+		return data, err
+	}
+
+	for _, r := range results {
+		if !r.Included {
+			continue
+		}
+
+		rel, err := filepath.Rel(repoPath, r.Path)
+		if err != nil {
+			return data, err
+		}
+		rel = filepath.ToSlash(rel)
+
+		p := Project{
+			Path: rel,
+			Name: projectName(rel),
+			Env:  pathEnv(rel),
+		}
+
+		p.VarFiles, err = varFiles(r.Path)
+		if err != nil {
+			return data, err
+		}
+
+		for _, f := range p.VarFiles {
+			if f.Env != "" {
+				p.EnvVarFiles = append(p.EnvVarFiles, f)
+			}
+		}
+
+		for _, name := range usageFileNames {
+			if _, err := os.Stat(filepath.Join(r.Path, name)); err == nil {
+				p.UsageFile = filepath.ToSlash(filepath.Join(rel, name))
+				break
+			}
+		}
+
+		data.Projects = append(data.Projects, p)
+	}
+
+	sort.SliceStable(data.Projects, func(i, j int) bool {
+		return data.Projects[i].Path < data.Projects[j].Path
+	})
+
+	return data, nil
+}
+
+func projectName(rel string) string {
+	if rel == "." {
+		return "root"
+	}
+
+	return strings.ReplaceAll(rel, "/", "-")
+}
+
+// pathEnv returns the last directory of the path that's named after an
+// environment.
+func pathEnv(rel string) string {
+	parts := strings.Split(rel, "/")
+	for i := len(parts) - 1; i >= 0; i-- {
+		if envNames.MatchString(strings.ToLower(parts[i])) {
+			return parts[i]
+		}
+	}
+
+	return ""
+}
+
+// varFiles returns the var files of the directory that Terraform doesn't load
+// automatically, i.e. other than terraform.tfvars and *.auto.tfvars.
+func varFiles(dir string) ([]VarFile, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("Error reading directory %s: %w", dir, err)
+	}
+
+	var files []VarFile
+
+	for _, e := range entries {
+		name := e.Name()
+		if e.IsDir() {
+			continue
+		}
+
+		var base string
+		switch {
+		case strings.HasSuffix(name, ".tfvars"):
+			base = strings.TrimSuffix(name, ".tfvars")
+		case strings.HasSuffix(name, ".tfvars.json"):
+			base = strings.TrimSuffix(name, ".tfvars.json")
+		default:
+			continue
+		}
+
+		if base == "terraform" || strings.HasSuffix(base, ".auto") {
+			continue
+		}
+
+		f := VarFile{Path: name}
+		if envNames.MatchString(strings.ToLower(base)) {
+			f.Env = base
+		}
+
+		files = append(files, f)
+	}
+
+	return files, nil
+}
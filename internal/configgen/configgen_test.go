@@ -0,0 +1,100 @@
+package configgen
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeFiles(t *testing.T, root string, files map[string]string) {
+	t.Helper()
+
+	for name, contents := range files {
+		path := filepath.Join(root, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), os.ModePerm))
+		require.NoError(t, os.WriteFile(path, []byte(contents), 0600))
+	}
+}
+
+func TestGenerateDefaultTemplate(t *testing.T) {
+	root := t.TempDir()
+	writeFiles(t, root, map[string]string{
+		"apps/api/main.tf":              `provider "aws" {}`,
+		"apps/api/prod.tfvars":          `instance_type = "m5.large"`,
+		"apps/api/dev.tfvars":           `instance_type = "t3.micro"`,
+		"apps/api/terraform.tfvars":     `region = "us-east-1"`,
+		"apps/api/infracost-usage.yml":  "version: 0.1\n",
+		"envs/staging/web/main.tf":      `provider "aws" {}`,
+		"envs/staging/web/extra.tfvars": `count = 2`,
+		"modules/vpc/main.tf":           `resource "aws_vpc" "vpc" {}`,
+	})
+
+	b, err := Generate(root, Options{})
+	require.NoError(t, err)
+
+	assert.Equal(t, `version: 0.1
+
+projects:
+  - path: apps/api
+    name: apps-api-dev
+    terraform_var_files:
+      - dev.tfvars
+    labels:
+      env: dev
+    usage_file: apps/api/infracost-usage.yml
+  - path: apps/api
+    name: apps-api-prod
+    terraform_var_files:
+      - prod.tfvars
+    labels:
+      env: prod
+    usage_file: apps/api/infracost-usage.yml
+  - path: envs/staging/web
+    name: envs-staging-web
+    labels:
+      env: staging
+    terraform_var_files:
+      - extra.tfvars
+`, string(b))
+}
+
+func TestGenerateTemplatePath(t *testing.T) {
+	root := t.TempDir()
+	writeFiles(t, root, map[string]string{
+		"b/main.tf": `provider "google" {}`,
+		"a/main.tf": `provider "aws" {}`,
+	})
+
+	tmpl := filepath.Join(t.TempDir(), "infracost.yml.tmpl")
+	require.NoError(t, os.WriteFile(tmpl, []byte(`{{ range .Projects }}{{ .Name | upper }}
+{{ end }}`), 0600))
+
+	b, err := Generate(root, Options{TemplatePath: tmpl})
+	require.NoError(t, err)
+	assert.Equal(t, "A\nB\n", string(b))
+}
+
+func TestGenerateTemplateErrors(t *testing.T) {
+	root := t.TempDir()
+	writeFiles(t, root, map[string]string{
+		"a/main.tf": `provider "aws" {}`,
+	})
+
+	tmpl := filepath.Join(t.TempDir(), "infracost.yml.tmpl")
+	require.NoError(t, os.WriteFile(tmpl, []byte(`{{ range .Projects }}{{ .Missing }}{{ end }}`), 0600))
+
+	_, err := Generate(root, Options{TemplatePath: tmpl})
+	assert.ErrorContains(t, err, "Error rendering template")
+
+	_, err = Generate(root, Options{TemplatePath: filepath.Join(root, "missing.tmpl")})
+	assert.ErrorContains(t, err, "Error reading template")
+}
+
+func TestPathEnv(t *testing.T) {
+	assert.Equal(t, "prod", pathEnv("envs/prod/app"))
+	assert.Equal(t, "Staging", pathEnv("Staging/dev-tools"))
+	assert.Equal(t, "", pathEnv("apps/api"))
+}
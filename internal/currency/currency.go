@@ -0,0 +1,105 @@
+// Package currency converts USD prices to other currencies using either the
+// built-in exchange rate table or a rate pinned by the user, so estimates in any
+// ISO currency are reproducible.
+package currency
+
+import (
+	"strings"
+
+	"github.com/shopspring/decimal"
+)
+
+// USD is the currency prices are looked up in before they're converted.
+const USD = "USD"
+
+const (
+	SourceBuiltIn = "built-in"
+	SourcePinned  = "pinned"
+)
+
+// builtInRatesDate is the date the built-in rates were last updated. The rates
+// are updated with each release.
+const builtInRatesDate = "2022-03-01"
+
+// builtInRates are the number of units of each currency per USD.
+var builtInRates = map[string]string{
+	"AED": "3.6730",
+	"ARS": "107.20",
+	"AUD": "1.3760",
+	"BRL": "5.1560",
+	"CAD": "1.2680",
+	"CHF": "0.9185",
+	"CLP": "801.00",
+	"CNY": "6.3150",
+	"COP": "3780.0",
+	"CZK": "22.770",
+	"DKK": "6.6440",
+	"EUR": "0.8929",
+	"GBP": "0.7467",
+	"HKD": "7.8160",
+	"HUF": "339.50",
+	"IDR": "14370",
+	"ILS": "3.2560",
+	"INR": "75.600",
+	"JPY": "115.00",
+	"KRW": "1203.5",
+	"MXN": "20.450",
+	"MYR": "4.1960",
+	"NOK": "8.8730",
+	"NZD": "1.4790",
+	"PHP": "51.450",
+	"PLN": "4.2110",
+	"RON": "4.4170",
+	"SAR": "3.7510",
+	"SEK": "9.5050",
+	"SGD": "1.3550",
+	"THB": "32.620",
+	"TRY": "13.890",
+	"TWD": "28.060",
+	"ZAR": "15.370",
+}
+
+// Rate converts USD prices to another currency. It's included in the JSON
+// output so it's clear which rate the costs were calculated with.
+type Rate struct {
+	From   string          `json:"from"`
+	To     string          `json:"to"`
+	Rate   decimal.Decimal `json:"rate"`
+	Date   string          `json:"date,omitempty"`
+	Source string          `json:"source"`
+}
+
+// BuiltInRate returns the built-in rate for converting USD to the currency.
+func BuiltInRate(currency string) (*Rate, bool) {
+	currency = strings.ToUpper(currency)
+
+	v, ok := builtInRates[currency]
+	if !ok {
+		return nil, false
+	}
+
+	return &Rate{
+		From:   USD,
+		To:     currency,
+		Rate:   decimal.RequireFromString(v),
+		Date:   builtInRatesDate,
+		Source: SourceBuiltIn,
+	}, true
+}
+
+// PinnedRate returns a user supplied rate for converting USD to the currency.
+// The date is optional and records when the rate was taken.
+func PinnedRate(currency string, rate float64, date string) *Rate {
+	return &Rate{
+		From:   USD,
+		To:     strings.ToUpper(currency),
+		Rate:   decimal.NewFromFloat(rate),
+		Date:   date,
+		Source: SourcePinned,
+	}
+}
+
+// Convert returns the USD amount in the rate's currency.
+func (r *Rate) Convert(d decimal.Decimal) decimal.Decimal {
+	return d.Mul(r.Rate)
+}
@@ -0,0 +1,38 @@
+package currency
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuiltInRate(t *testing.T) {
+	r, ok := BuiltInRate("eur")
+	require.True(t, ok)
+	assert.Equal(t, "USD", r.From)
+	assert.Equal(t, "EUR", r.To)
+	assert.Equal(t, SourceBuiltIn, r.Source)
+	assert.Equal(t, builtInRatesDate, r.Date)
+	assert.Equal(t, "8.929", r.Convert(decimal.NewFromInt(10)).String())
+
+	_, ok = BuiltInRate("XYZ")
+	assert.False(t, ok)
+}
+
+func TestBuiltInRatesAreValid(t *testing.T) {
+	for c, v := range builtInRates {
+		d, err := decimal.NewFromString(v)
+		require.NoError(t, err, c)
+		assert.True(t, d.IsPositive(), c)
+	}
+}
+
+func TestPinnedRate(t *testing.T) {
+	r := PinnedRate("gbp", 0.75, "2022-02-14")
+	assert.Equal(t, "GBP", r.To)
+	assert.Equal(t, SourcePinned, r.Source)
+	assert.Equal(t, "2022-02-14", r.Date)
+	assert.Equal(t, "1.5", r.Convert(decimal.NewFromInt(2)).String())
+}
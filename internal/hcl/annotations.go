@@ -0,0 +1,163 @@
+package hcl
+
+import (
+	"os"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+)
+
+// annotationPrefix starts the comments that annotate the block below them, or
+// the block they're on the first line of, e.g.
+//
+//	# infracost: budget=200/month
+//	resource "aws_instance" "web" { # infracost: ignore reason="POC" until=2025-09-01
+//
+// The comments can start with #, // or /*.
+const annotationPrefix = "infracost:"
+
+// Annotations are the key=value settings of the infracost: comments of a block.
+// Keys without a value, e.g. ignore, are set to an empty string.
+type Annotations map[string]string
+
+// fileLines caches the lines of the files that blocks are annotated in, since
+// the blocks of a file, and the blocks expanded from them, are read in turn.
+var fileLines sync.Map
+
+type cachedFileLines struct {
+	modTime time.Time
+	lines   []string
+}
+
+// Annotations returns the annotations of the block from the infracost: comments
+// directly above it or on its first line. Later comments override the keys of
+// earlier ones. It returns nil if the block has none.
+func (b *Block) Annotations() Annotations {
+	if b.hclBlock == nil {
+		return nil
+	}
+
+	r := b.hclBlock.DefRange
+	lines := readFileLines(r.Filename)
+	if r.Start.Line < 1 || r.Start.Line > len(lines) {
+		return nil
+	}
+
+	// Collect the comment lines above the block, stopping at the first line
+	// that isn't a comment.
+	var comments []string
+	for i := r.Start.Line - 2; i >= 0; i-- {
+		line := strings.TrimSpace(lines[i])
+		if !isComment(line) {
+			break
+		}
+		comments = append([]string{line}, comments...)
+	}
+
+	first := lines[r.Start.Line-1]
+	for _, marker := range []string{"#", "//", "/*"} {
+		if i := strings.Index(first, marker); i != -1 {
+			comments = append(comments, first[i:])
+			break
+		}
+	}
+
+	var annotations Annotations
+	for _, c := range comments {
+		for k, v := range ParseAnnotation(c) {
+			if annotations == nil {
+				annotations = Annotations{}
+			}
+			annotations[k] = v
+		}
+	}
+
+	return annotations
+}
+
+// ParseAnnotation returns the annotations of a comment, or nil if it isn't an
+// infracost: comment. Values with spaces can be double quoted, e.g.
+// reason="proof of concept".
+func ParseAnnotation(comment string) Annotations {
+	s := strings.TrimSpace(comment)
+	for _, p := range []string{"#", "//", "/*"} {
+		s = strings.TrimPrefix(s, p)
+	}
+	s = strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(s), "*/"))
+
+	if !strings.HasPrefix(s, annotationPrefix) {
+		return nil
+	}
+
+	annotations := Annotations{}
+	for _, field := range splitAnnotationFields(strings.TrimPrefix(s, annotationPrefix)) {
+		parts := strings.SplitN(field, "=", 2)
+		if parts[0] == "" {
+			continue
+		}
+
+		v := ""
+		if len(parts) == 2 {
+			v = strings.Trim(parts[1], `"`)
+		}
+
+		annotations[parts[0]] = v
+	}
+
+	return annotations
+}
+
+// splitAnnotationFields splits the fields of an annotation on the spaces that
+// aren't in double quotes.
+func splitAnnotationFields(s string) []string {
+	var fields []string
+	var field strings.Builder
+
+	quoted := false
+	for _, c := range s {
+		switch {
+		case c == '"':
+			quoted = !quoted
+			field.WriteRune(c)
+		case unicode.IsSpace(c) && !quoted:
+			if field.Len() > 0 {
+				fields = append(fields, field.String())
+				field.Reset()
+			}
+		default:
+			field.WriteRune(c)
+		}
+	}
+
+	if field.Len() > 0 {
+		fields = append(fields, field.String())
+	}
+
+	return fields
+}
+
+func isComment(line string) bool {
+	return strings.HasPrefix(line, "#") || strings.HasPrefix(line, "//") || strings.HasPrefix(line, "/*")
+}
+
+func readFileLines(filename string) []string {
+	info, err := os.Stat(filename)
+	if err != nil {
+		return nil
+	}
+
+	if v, ok := fileLines.Load(filename); ok && v.(cachedFileLines).modTime.Equal(info.ModTime()) {
+		return v.(cachedFileLines).lines
+	}
+
+	b, err := os.ReadFile(filename)
+	if err != nil {
+		return nil
+	}
+
+	lines := strings.Split(strings.ReplaceAll(string(b), "\r\n", "\n"), "\n")
+	fileLines.Store(filename, cachedFileLines{modTime: info.ModTime(), lines: lines})
+
+	return lines
+}
@@ -0,0 +1,51 @@
+package hcl
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseAnnotation(t *testing.T) {
+	assert.Equal(t, Annotations{"budget": "200/month"}, ParseAnnotation("# infracost: budget=200/month"))
+	assert.Equal(t, Annotations{"ignore": "", "reason": "proof of concept", "until": "2025-09-01"}, ParseAnnotation(`// infracost: ignore reason="proof of concept" until=2025-09-01`))
+	assert.Equal(t, Annotations{"budget": "10/hour"}, ParseAnnotation("/* infracost: budget=10/hour */"))
+	assert.Nil(t, ParseAnnotation("# TODO: infracost"))
+}
+
+func TestBlockAnnotations(t *testing.T) {
+	path := createTestFile("test.tf", `
+# Web servers
+# infracost: budget=200/month
+resource "cats_cat" "mittens" {
+	name = "mittens"
+}
+
+resource "cats_cat" "boots" { # infracost: ignore reason="POC" until=2025-09-01
+	name = "boots"
+}
+
+# infracost: budget=100/month
+
+resource "cats_cat" "tiddles" {
+	name = "tiddles"
+}
+`)
+
+	parser := New(filepath.Dir(path), OptionStopOnHCLError())
+	modules, err := parser.ParseDirectory()
+	require.NoError(t, err)
+
+	annotations := map[string]Annotations{}
+	for _, b := range modules[0].Blocks.OfType("resource") {
+		annotations[b.FullName()] = b.Annotations()
+	}
+
+	assert.Equal(t, map[string]Annotations{
+		"cats_cat.mittens": {"budget": "200/month"},
+		"cats_cat.boots":   {"ignore": "", "reason": "POC", "until": "2025-09-01"},
+		"cats_cat.tiddles": nil,
+	}, annotations)
+}
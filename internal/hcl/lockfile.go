@@ -0,0 +1,127 @@
+package hcl
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/infracost/infracost/internal/hcl/modules"
+)
+
+// LockFileName is the name of the file in a project directory that records the
+// module versions and provider regions the project's HCL was evaluated with.
+const LockFileName = ".infracost.lock.json"
+
+// lockFileVersion is the version of the lock file format.
+const lockFileVersion = "0.1"
+
+// LockFile records the versions of the registry modules and the regions of the
+// providers used to estimate a project from its HCL. Running with the lock
+// file pins them, so runs of the same commit give identical estimates even
+// after new versions of the modules are published.
+type LockFile struct {
+	Version   string            `json:"version"`
+	Modules   []*LockedModule   `json:"modules"`
+	Providers []*LockedProvider `json:"providers"`
+}
+
+// LockedModule is a module call, by its key in the module manifest, e.g.
+// vpc.subnets, and the version it was loaded with. Modules that aren't from a
+// registry have no version since their source already includes any ref.
+type LockedModule struct {
+	Key     string `json:"key"`
+	Source  string `json:"source"`
+	Version string `json:"version,omitempty"`
+}
+
+// LockedProvider is a provider config, by its name or alias, and its region.
+type LockedProvider struct {
+	Key    string `json:"key"`
+	Region string `json:"region"`
+}
+
+// NewLockFile returns a lock file of the remote modules in the manifest.
+// Local modules are skipped since they're part of the commit.
+func NewLockFile(manifest *modules.Manifest) *LockFile {
+	lockFile := &LockFile{
+		Version:   lockFileVersion,
+		Modules:   []*LockedModule{},
+		Providers: []*LockedProvider{},
+	}
+
+	if manifest == nil {
+		return lockFile
+	}
+
+	for _, m := range manifest.Modules {
+		if modules.IsLocalSource(m.Source) {
+			continue
+		}
+
+		lockFile.Modules = append(lockFile.Modules, &LockedModule{
+			Key:     m.Key,
+			Source:  m.Source,
+			Version: m.Version,
+		})
+	}
+
+	return lockFile
+}
+
+// ReadLockFile reads the lock file at the given path.
+func ReadLockFile(path string) (*LockFile, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("Error reading lock file: %w", err)
+	}
+
+	var lockFile LockFile
+	err = json.Unmarshal(b, &lockFile)
+	if err != nil {
+		return nil, fmt.Errorf("Error parsing lock file %s: %w", path, err)
+	}
+
+	return &lockFile, nil
+}
+
+// WriteLockFile writes the lock file to the given path.
+func WriteLockFile(lockFile *LockFile, path string) error {
+	b, err := json.MarshalIndent(lockFile, "", "  ")
+	if err != nil {
+		return fmt.Errorf("Error marshaling lock file: %w", err)
+	}
+
+	err = os.WriteFile(path, append(b, '\n'), 0644) // nolint:gosec
+	if err != nil {
+		return fmt.Errorf("Error writing lock file: %w", err)
+	}
+
+	return nil
+}
+
+// PinnedModules returns the locked modules as a manifest keyed by module key,
+// see modules.ModuleLoader.PinnedModules.
+func (l *LockFile) PinnedModules() map[string]*modules.ManifestModule {
+	pinned := make(map[string]*modules.ManifestModule, len(l.Modules))
+
+	for _, m := range l.Modules {
+		pinned[m.Key] = &modules.ManifestModule{
+			Key:     m.Key,
+			Source:  m.Source,
+			Version: m.Version,
+		}
+	}
+
+	return pinned
+}
+
+// ProviderRegion returns the locked region of the provider, if any.
+func (l *LockFile) ProviderRegion(key string) (string, bool) {
+	for _, p := range l.Providers {
+		if p.Key == key {
+			return p.Region, true
+		}
+	}
+
+	return "", false
+}
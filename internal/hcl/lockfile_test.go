@@ -0,0 +1,38 @@
+package hcl
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/infracost/infracost/internal/hcl/modules"
+)
+
+func TestLockFile(t *testing.T) {
+	lockFile := NewLockFile(&modules.Manifest{Modules: []*modules.ManifestModule{
+		{Key: "vpc", Source: "registry.terraform.io/terraform-aws-modules/vpc/aws", Version: "3.11.0", Dir: ".infracost/terraform_modules/vpc"},
+		{Key: "vpc.subnets", Source: "./modules/subnets", Dir: ".infracost/terraform_modules/vpc/modules/subnets"},
+		{Key: "app", Source: "../app", Dir: "../app"},
+	}})
+	lockFile.Providers = append(lockFile.Providers, &LockedProvider{Key: "aws", Region: "eu-west-1"})
+
+	path := filepath.Join(t.TempDir(), LockFileName)
+	require.NoError(t, WriteLockFile(lockFile, path))
+
+	read, err := ReadLockFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, lockFile, read)
+
+	assert.Equal(t, map[string]*modules.ManifestModule{
+		"vpc": {Key: "vpc", Source: "registry.terraform.io/terraform-aws-modules/vpc/aws", Version: "3.11.0"},
+	}, read.PinnedModules())
+
+	region, ok := read.ProviderRegion("aws")
+	assert.True(t, ok)
+	assert.Equal(t, "eu-west-1", region)
+
+	_, ok = read.ProviderRegion("aws.west")
+	assert.False(t, ok)
+}
@@ -45,19 +45,7 @@ func (c *Cache) lookupModule(key string, moduleCall *tfconfig.ModuleCall) (*Mani
 		return nil, errors.New("not in cache")
 	}
 
-	// If the module could be a valid registry module, we should generate the normalized registry source and check against that
-	// so we can check the cache against that since we convert to this format before storing in the manifest
-	// We don't care about errors here since we only want to check against the registry source if the address is a valid registry address
-	var registrySource = ""
-	moduleAddr, submodulePath, err := splitModuleSubDir(moduleCall.Source)
-	if err == nil {
-		registryModuleAddr, err := normalizeRegistrySource(moduleAddr)
-		if err == nil {
-			registrySource = joinModuleSubDir(registryModuleAddr, submodulePath)
-		}
-	}
-
-	if manifestModule.Source != moduleCall.Source && (registrySource == "" || manifestModule.Source != registrySource) {
+	if !sourceMatches(manifestModule.Source, moduleCall.Source) {
 		return nil, errors.New("source has changed")
 	}
 
@@ -79,3 +67,26 @@ func (c *Cache) lookupModule(key string, moduleCall *tfconfig.ModuleCall) (*Mani
 
 	return manifestModule, nil
 }
+
+// sourceMatches returns true if the source of a module in the manifest is the
+// source of the module call.
+func sourceMatches(manifestSource string, callSource string) bool {
+	if manifestSource == callSource {
+		return true
+	}
+
+	// If the module could be a valid registry module, we should generate the normalized registry source and check against that
+	// so we can check the cache against that since we convert to this format before storing in the manifest
+	// We don't care about errors here since we only want to check against the registry source if the address is a valid registry address
+	moduleAddr, submodulePath, err := splitModuleSubDir(callSource)
+	if err != nil {
+		return false
+	}
+
+	registryModuleAddr, err := normalizeRegistrySource(moduleAddr)
+	if err != nil {
+		return false
+	}
+
+	return manifestSource == joinModuleSubDir(registryModuleAddr, submodulePath)
+}
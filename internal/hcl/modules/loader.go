@@ -6,9 +6,11 @@ import (
 	"os"
 	"path"
 	"path/filepath"
+	"sort"
 	"strings"
 
 	getter "github.com/hashicorp/go-getter"
+	goversion "github.com/hashicorp/go-version"
 	"github.com/hashicorp/terraform-config-inspect/tfconfig"
 	log "github.com/sirupsen/logrus"
 )
@@ -29,7 +31,16 @@ var (
 // .infracost/terraform_modules directory. We could implement a global cache in the future, but for now have decided
 // to go with the same approach as Terraform.
 type ModuleLoader struct {
-	Path           string
+	Path string
+	// PinnedModules are the modules, by key, that are loaded with the version
+	// they have here rather than the latest version matching their version
+	// constraint, e.g. the modules of a lock file.
+	PinnedModules map[string]*ManifestModule
+	// Offline skips the remote modules that haven't already been downloaded
+	// instead of downloading them, so their resources aren't estimated. The
+	// manifest isn't written so the next run that isn't offline loads them.
+	Offline bool
+
 	cache          *Cache
 	packageFetcher *PackageFetcher
 	registryLoader *RegistryLoader
@@ -75,6 +86,9 @@ func (m *ModuleLoader) Load() (*Manifest, error) {
 		_, err = os.Stat(m.tfManifestFilePath())
 		if err == nil {
 			manifest, err = readManifest(m.tfManifestFilePath())
+			if err == nil && !m.matchesPinnedModules(manifest) {
+				err = errors.New("module versions don't match the pinned versions")
+			}
 			if err == nil {
 				return manifest, nil
 			}
@@ -99,6 +113,10 @@ func (m *ModuleLoader) Load() (*Manifest, error) {
 
 	manifest.Modules = metadatas
 
+	if m.Offline {
+		return manifest, nil
+	}
+
 	err = writeManifest(manifest, m.manifestFilePath())
 	if err != nil {
 		log.Debugf("Error writing module manifest: %s", err)
@@ -122,6 +140,10 @@ func (m *ModuleLoader) loadModules(path string, prefix string) ([]*ManifestModul
 			return nil, err
 		}
 
+		if metadata == nil {
+			continue
+		}
+
 		manifestModules = append(manifestModules, metadata)
 
 		nestedManifestModules, err := m.loadModules(filepath.Join(m.Path, metadata.Dir), metadata.Key+".")
@@ -141,8 +163,12 @@ func (m *ModuleLoader) loadModules(path string, prefix string) ([]*ManifestModul
 // 2. Checks if the module is a local module.
 // 3. Checks if the module is a registry module and downloads it.
 // 4. Checks if the module is a remote module and downloads it.
+//
+// It returns nil for remote modules that need downloading if the loader is
+// Offline.
 func (m *ModuleLoader) loadModule(moduleCall *tfconfig.ModuleCall, parentPath string, prefix string) (*ManifestModule, error) {
 	key := prefix + moduleCall.Name
+	moduleCall = m.pinModuleCall(key, moduleCall)
 
 	manifestModule, err := m.cache.lookupModule(key, moduleCall)
 	if err == nil {
@@ -177,6 +203,11 @@ func (m *ModuleLoader) loadModule(moduleCall *tfconfig.ModuleCall, parentPath st
 		return manifestModule, nil
 	}
 
+	if m.Offline {
+		log.Warnf("Skipping module %s, it isn't downloaded and modules aren't downloaded offline", key)
+		return nil, nil
+	}
+
 	dest := filepath.Join(m.downloadDir(), key)
 
 	// Since we're downloading the module, make sure any old installation of it is removed
@@ -223,13 +254,118 @@ func (m *ModuleLoader) loadModule(moduleCall *tfconfig.ModuleCall, parentPath st
 	return manifestModule, nil
 }
 
+// pinModuleCall returns the module call with its version constraint set to the
+// pinned version of the module. Modules whose source or version constraint has
+// changed so that the pinned version no longer applies are loaded as usual.
+func (m *ModuleLoader) pinModuleCall(key string, moduleCall *tfconfig.ModuleCall) *tfconfig.ModuleCall {
+	pinned, ok := m.PinnedModules[key]
+	if !ok || pinned.Version == "" {
+		return moduleCall
+	}
+
+	if !sourceMatches(pinned.Source, moduleCall.Source) {
+		log.Warnf("Module %s is not pinned to version %s since its source has changed from %s to %s", key, pinned.Version, pinned.Source, moduleCall.Source)
+		return moduleCall
+	}
+
+	if moduleCall.Version != "" {
+		constraints, err := goversion.NewConstraint(moduleCall.Version)
+		if err != nil {
+			return moduleCall
+		}
+
+		version, err := goversion.NewVersion(pinned.Version)
+		if err != nil || !constraints.Check(version) {
+			log.Warnf("Module %s is not pinned to version %s since it doesn't match its version constraint %s", key, pinned.Version, moduleCall.Version)
+			return moduleCall
+		}
+	}
+
+	pinnedCall := *moduleCall
+	pinnedCall.Version = "= " + pinned.Version
+
+	return &pinnedCall
+}
+
+// matchesPinnedModules returns true if the modules of the manifest have the
+// versions they're pinned to.
+func (m *ModuleLoader) matchesPinnedModules(manifest *Manifest) bool {
+	for _, module := range manifest.Modules {
+		pinned, ok := m.PinnedModules[module.Key]
+		if ok && pinned.Version != "" && module.Version != pinned.Version {
+			return false
+		}
+	}
+
+	return true
+}
+
 // isLocalModule checks if the module is a local module by checking
 // if the module source starts with any known local prefixes
 func (m *ModuleLoader) isLocalModule(moduleCall *tfconfig.ModuleCall) bool {
-	return (strings.HasPrefix(moduleCall.Source, "./") ||
-		strings.HasPrefix(moduleCall.Source, "../") ||
-		strings.HasPrefix(moduleCall.Source, ".\\") ||
-		strings.HasPrefix(moduleCall.Source, "..\\"))
+	return IsLocalSource(moduleCall.Source)
+}
+
+// IsLocalSource returns true if the module source is a path to a module in
+// the same repo.
+func IsLocalSource(source string) bool {
+	return (strings.HasPrefix(source, "./") ||
+		strings.HasPrefix(source, "../") ||
+		strings.HasPrefix(source, ".\\") ||
+		strings.HasPrefix(source, "..\\"))
+}
+
+// LocalModuleDirs returns the absolute path of the directory and of the
+// directories of the local modules it calls, recursively, sorted so they're in
+// a stable order. Only the directory is returned if it isn't a Terraform module.
+func LocalModuleDirs(dir string) ([]string, error) {
+	seen := map[string]bool{}
+
+	var walk func(dir string) error
+	walk = func(dir string) error {
+		abs, err := filepath.Abs(dir)
+		if err != nil {
+			return err
+		}
+
+		if seen[abs] {
+			return nil
+		}
+		seen[abs] = true
+
+		module, diags := tfconfig.LoadModule(abs)
+		if diags.HasErrors() {
+			// The directory isn't a Terraform module, e.g. a Terragrunt or
+			// CloudFormation project, so it has no module calls.
+			return nil
+		}
+
+		for _, call := range module.ModuleCalls {
+			if !IsLocalSource(call.Source) {
+				continue
+			}
+
+			err := walk(filepath.Join(abs, call.Source))
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	err := walk(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	dirs := make([]string, 0, len(seen))
+	for d := range seen {
+		dirs = append(dirs, d)
+	}
+	sort.Strings(dirs)
+
+	return dirs, nil
 }
 
 func splitModuleSubDir(moduleSource string) (string, string, error) {
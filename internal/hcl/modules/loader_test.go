@@ -6,6 +6,7 @@ import (
 	"sort"
 	"testing"
 
+	"github.com/hashicorp/terraform-config-inspect/tfconfig"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -188,3 +189,42 @@ func TestWithCachedModules(t *testing.T) {
 	assert.Equal(t, string(regModContents), "// Placeholder file\n")
 	assert.Equal(t, string(gitModContents), "// Placeholder file\n")
 }
+
+func TestPinModuleCall(t *testing.T) {
+	moduleLoader := NewModuleLoader(t.TempDir())
+	moduleLoader.PinnedModules = map[string]*ManifestModule{
+		"vpc":    {Key: "vpc", Source: "registry.terraform.io/terraform-aws-modules/vpc/aws", Version: "3.11.0"},
+		"eks":    {Key: "eks", Source: "registry.terraform.io/terraform-aws-modules/eks/aws", Version: "17.0.0"},
+		"moved":  {Key: "moved", Source: "registry.terraform.io/terraform-aws-modules/vpc/aws", Version: "3.11.0"},
+		"remote": {Key: "remote", Source: "git::https://github.com/infracost/example.git?ref=v1"},
+	}
+
+	call := &tfconfig.ModuleCall{Name: "vpc", Source: "terraform-aws-modules/vpc/aws", Version: "~> 3.0"}
+	pinned := moduleLoader.pinModuleCall("vpc", call)
+	assert.Equal(t, "= 3.11.0", pinned.Version)
+	assert.Equal(t, "~> 3.0", call.Version)
+
+	call = &tfconfig.ModuleCall{Name: "eks", Source: "terraform-aws-modules/eks/aws", Version: "~> 18.0"}
+	assert.Equal(t, "~> 18.0", moduleLoader.pinModuleCall("eks", call).Version)
+
+	call = &tfconfig.ModuleCall{Name: "moved", Source: "terraform-aws-modules/eks/aws"}
+	assert.Equal(t, "", moduleLoader.pinModuleCall("moved", call).Version)
+
+	call = &tfconfig.ModuleCall{Name: "remote", Source: "git::https://github.com/infracost/example.git?ref=v1"}
+	assert.Equal(t, call, moduleLoader.pinModuleCall("remote", call))
+}
+
+func TestMatchesPinnedModules(t *testing.T) {
+	moduleLoader := NewModuleLoader(t.TempDir())
+	moduleLoader.PinnedModules = map[string]*ManifestModule{
+		"vpc": {Key: "vpc", Source: "registry.terraform.io/terraform-aws-modules/vpc/aws", Version: "3.11.0"},
+	}
+
+	assert.True(t, moduleLoader.matchesPinnedModules(&Manifest{Modules: []*ManifestModule{
+		{Key: "vpc", Version: "3.11.0"},
+		{Key: "local", Dir: "modules/local"},
+	}}))
+	assert.False(t, moduleLoader.matchesPinnedModules(&Manifest{Modules: []*ManifestModule{
+		{Key: "vpc", Version: "3.12.0"},
+	}}))
+}
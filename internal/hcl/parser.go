@@ -74,6 +74,22 @@ func OptionWithWorkspaceName(workspaceName string) Option {
 	}
 }
 
+// OptionWithLockFile pins the versions of the modules to the versions in the
+// lock file, see LockFile.
+func OptionWithLockFile(lockFile *LockFile) Option {
+	return func(p *Parser) {
+		p.moduleLoader.PinnedModules = lockFile.PinnedModules()
+	}
+}
+
+// OptionWithoutModuleDownloads only loads the remote modules that have already
+// been downloaded, see modules.ModuleLoader.Offline.
+func OptionWithoutModuleDownloads() Option {
+	return func(p *Parser) {
+		p.moduleLoader.Offline = true
+	}
+}
+
 // Parser is a tool for parsing terraform templates at a given file system location.
 type Parser struct {
 	initialPath     string
@@ -83,6 +99,7 @@ type Parser struct {
 	stopOnHCLError  bool
 	workspaceName   string
 	moduleLoader    *modules.ModuleLoader
+	modulesManifest *modules.Manifest
 }
 
 // New creates a new Parser with the provided options, it inits the workspace as under the default name
@@ -159,6 +176,7 @@ func (p *Parser) ParseDirectory() ([]*Module, error) {
 	if err != nil {
 		return nil, fmt.Errorf("Error loading Terraform modules: %s", err)
 	}
+	p.modulesManifest = modulesManifest
 
 	log.Debug("Evaluating expressions...")
 	workingDir, err := os.Getwd()
@@ -186,6 +204,11 @@ func (p *Parser) ParseDirectory() ([]*Module, error) {
 	return modules, nil
 }
 
+// ModulesManifest returns the modules loaded by the last call to ParseDirectory.
+func (p *Parser) ModulesManifest() *modules.Manifest {
+	return p.modulesManifest
+}
+
 func (p *Parser) parseDirectoryFiles(files []*hcl.File) (Blocks, error) {
 	var blocks Blocks
 
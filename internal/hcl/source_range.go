@@ -0,0 +1,50 @@
+package hcl
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+)
+
+// downloadedModulesDir is the directory remote modules are downloaded to, see
+// modules.ModuleLoader.
+const downloadedModulesDir = ".infracost"
+
+// SourceRange returns the range of the whole block in the file it's defined in.
+// The files of remote modules aren't part of the repo, so for their blocks the
+// range of the call of the outermost remote module is returned instead. The
+// range has an empty filename for blocks that weren't parsed from a file.
+func (b *Block) SourceRange() hcl.Range {
+	for block := b; block != nil; block = block.moduleBlock {
+		if block.hclBlock == nil {
+			return hcl.Range{}
+		}
+
+		if !isDownloadedModuleFile(block.hclBlock.DefRange.Filename) {
+			return block.fullRange()
+		}
+	}
+
+	return hcl.Range{}
+}
+
+// fullRange returns the range from the type of the block to its closing brace.
+func (b *Block) fullRange() hcl.Range {
+	if body, ok := b.hclBlock.Body.(*hclsyntax.Body); ok {
+		return hcl.RangeBetween(b.hclBlock.DefRange, body.SrcRange)
+	}
+
+	return b.hclBlock.DefRange
+}
+
+func isDownloadedModuleFile(filename string) bool {
+	for _, part := range strings.Split(filepath.ToSlash(filename), "/") {
+		if part == downloadedModulesDir {
+			return true
+		}
+	}
+
+	return false
+}
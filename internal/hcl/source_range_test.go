@@ -0,0 +1,46 @@
+package hcl
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBlockSourceRange(t *testing.T) {
+	path := createTestFile("test.tf", `
+resource "cats_cat" "mittens" {
+	name = "mittens"
+}
+
+resource "cats_cat" "boots" {
+	name = "boots"
+
+	collar {
+		color = "red"
+	}
+}
+`)
+
+	parser := New(filepath.Dir(path), OptionStopOnHCLError())
+	modules, err := parser.ParseDirectory()
+	require.NoError(t, err)
+
+	lines := map[string][]int{}
+	for _, b := range modules[0].Blocks.OfType("resource") {
+		r := b.SourceRange()
+		assert.Equal(t, filepath.Base(path), filepath.Base(r.Filename))
+		lines[b.FullName()] = []int{r.Start.Line, r.End.Line}
+	}
+
+	assert.Equal(t, map[string][]int{
+		"cats_cat.mittens": {2, 4},
+		"cats_cat.boots":   {6, 12},
+	}, lines)
+}
+
+func TestIsDownloadedModuleFile(t *testing.T) {
+	assert.True(t, isDownloadedModuleFile("examples/.infracost/terraform_modules/abc/main.tf"))
+	assert.False(t, isDownloadedModuleFile("examples/modules/web/main.tf"))
+}
@@ -0,0 +1,177 @@
+// Package idle finds the resources of Terraform states that are likely idle or
+// orphaned, e.g. EBS volumes that aren't attached to an instance, by
+// cross-referencing the attachments of the resources in the state, and reports
+// what they're still billed.
+package idle
+
+import (
+	"strings"
+
+	"github.com/shopspring/decimal"
+	"github.com/tidwall/gjson"
+
+	"github.com/infracost/infracost/internal/output"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+// attachments are the IDs and ARNs that resources of a state are attached to.
+type attachments struct {
+	volumes      map[string]bool
+	targetGroups map[string]bool
+	// lbTargetGroups are the ARNs of the target groups of each load balancer ARN.
+	lbTargetGroups map[string][]string
+	classicELBs    map[string]bool
+}
+
+// IsState returns true if the resources of the project are from a Terraform
+// state, i.e. a state JSON file or a Terraform directory with
+// terraform_use_state, so they exist with all their attributes known.
+func IsState(p *schema.Project) bool {
+	if p.Metadata != nil && p.Metadata.Type == "terraform_state_json" {
+		return true
+	}
+
+	return p.Metadata != nil && p.Metadata.Type == "terraform_dir" && !p.HasDiff
+}
+
+// Detect returns the idle resources of the projects from Terraform states,
+// largest cost first. The costs of projects priced in their own currency are
+// converted to the currency of the report with the rates keyed by project
+// currency.
+func Detect(projects []*schema.Project, rates map[string]decimal.Decimal) []output.IdleResource {
+	var idle []output.IdleResource
+
+	for _, p := range projects {
+		if !IsState(p) {
+			continue
+		}
+
+		rate := decimal.NewFromInt(1)
+		if r, ok := rates[strings.ToUpper(p.Currency)]; ok && p.Currency != "" {
+			rate = r
+		}
+
+		a := findAttachments(p.Resources)
+
+		for _, r := range p.Resources {
+			check, reason, cost := checkResource(a, r)
+			if check == "" || !cost.IsPositive() {
+				continue
+			}
+
+			idle = append(idle, output.IdleResource{
+				Check:       check,
+				ProjectName: p.Name,
+				Address:     r.Name,
+				Reason:      reason,
+				MonthlyCost: cost.Mul(rate).Round(2),
+			})
+		}
+	}
+
+	return output.SortIdleResources(idle)
+}
+
+func checkResource(a attachments, r *schema.Resource) (string, string, decimal.Decimal) {
+	v := r.RawValues
+
+	switch r.ResourceType {
+	case "aws_ebs_volume":
+		if id := v.Get("id").String(); id != "" && !a.volumes[id] {
+			return "aws_unattached_ebs_volume", "EBS volume is not attached to an instance", monthlyCost(r)
+		}
+	case "aws_lb", "aws_alb":
+		arn := v.Get("arn").String()
+		if arn == "" {
+			return "", "", decimal.Zero
+		}
+		for _, tg := range a.lbTargetGroups[arn] {
+			if a.targetGroups[tg] {
+				return "", "", decimal.Zero
+			}
+		}
+		return "aws_load_balancer_without_targets", "load balancer has no targets", monthlyCost(r)
+	case "aws_elb":
+		if len(v.Get("instances").Array()) == 0 && !a.classicELBs[v.Get("name").String()] && !a.classicELBs[v.Get("id").String()] {
+			return "aws_load_balancer_without_targets", "classic load balancer has no instances", monthlyCost(r)
+		}
+	case "aws_instance":
+		if v.Get("instance_state").String() == "stopped" {
+			cost := decimal.Zero
+			for _, s := range r.SubResources {
+				cost = cost.Add(monthlyCost(s))
+			}
+			return "aws_stopped_instance", "instance is stopped but its volumes are still billed", cost
+		}
+	case "aws_db_instance":
+		if v.Get("status").String() == "stopped" {
+			cost := decimal.Zero
+			for _, c := range r.CostComponents {
+				if !strings.HasPrefix(c.Name, "Database instance") && c.MonthlyCost != nil {
+					cost = cost.Add(*c.MonthlyCost)
+				}
+			}
+			return "aws_stopped_db_instance", "database is stopped but its storage is still billed, and it restarts after 7 days", cost
+		}
+	}
+
+	return "", "", decimal.Zero
+}
+
+// findAttachments indexes the volumes, target groups and classic load balancers
+// that are attached to by the resources.
+func findAttachments(resources []*schema.Resource) attachments {
+	a := attachments{
+		volumes:        make(map[string]bool),
+		targetGroups:   make(map[string]bool),
+		lbTargetGroups: make(map[string][]string),
+		classicELBs:    make(map[string]bool),
+	}
+
+	add := func(m map[string]bool, values ...gjson.Result) {
+		for _, v := range values {
+			if s := v.String(); s != "" {
+				m[s] = true
+			}
+		}
+	}
+
+	for _, r := range resources {
+		v := r.RawValues
+
+		switch r.ResourceType {
+		case "aws_volume_attachment":
+			add(a.volumes, v.Get("volume_id"))
+		case "aws_instance":
+			add(a.volumes, v.Get("root_block_device.#.volume_id").Array()...)
+			add(a.volumes, v.Get("ebs_block_device.#.volume_id").Array()...)
+		case "aws_lb_target_group", "aws_alb_target_group":
+			for _, lb := range v.Get("load_balancer_arns").Array() {
+				a.lbTargetGroups[lb.String()] = append(a.lbTargetGroups[lb.String()], v.Get("arn").String())
+			}
+		case "aws_lb_target_group_attachment", "aws_alb_target_group_attachment":
+			add(a.targetGroups, v.Get("target_group_arn"))
+		case "aws_autoscaling_group":
+			add(a.targetGroups, v.Get("target_group_arns").Array()...)
+			add(a.classicELBs, v.Get("load_balancers").Array()...)
+		case "aws_autoscaling_attachment":
+			add(a.targetGroups, v.Get("lb_target_group_arn"), v.Get("alb_target_group_arn"))
+			add(a.classicELBs, v.Get("elb"))
+		case "aws_ecs_service":
+			add(a.targetGroups, v.Get("load_balancer.#.target_group_arn").Array()...)
+			add(a.classicELBs, v.Get("load_balancer.#.elb_name").Array()...)
+		case "aws_elb_attachment":
+			add(a.classicELBs, v.Get("elb"))
+		}
+	}
+
+	return a
+}
+
+func monthlyCost(r *schema.Resource) decimal.Decimal {
+	if r.MonthlyCost == nil {
+		return decimal.Zero
+	}
+
+	return *r.MonthlyCost
+}
@@ -0,0 +1,77 @@
+package idle
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+
+	"github.com/infracost/infracost/internal/schema"
+)
+
+func resource(address, resourceType string, monthlyCost float64, values string) *schema.Resource {
+	cost := decimal.NewFromFloat(monthlyCost)
+	return &schema.Resource{
+		Name:         address,
+		ResourceType: resourceType,
+		MonthlyCost:  &cost,
+		RawValues:    gjson.Parse(values),
+	}
+}
+
+func TestDetect(t *testing.T) {
+	stopped := resource("aws_instance.stopped", "aws_instance", 70, `{"instance_state": "stopped", "root_block_device": [{"volume_id": "vol-root"}]}`)
+	stopped.SubResources = []*schema.Resource{resource("root_block_device", "", 8, `{}`)}
+
+	resources := []*schema.Resource{
+		resource("aws_instance.web", "aws_instance", 70, `{"instance_state": "running", "root_block_device": [{"volume_id": "vol-web"}], "ebs_block_device": [{"volume_id": "vol-inline"}]}`),
+		stopped,
+		resource("aws_ebs_volume.inline", "aws_ebs_volume", 10, `{"id": "vol-inline"}`),
+		resource("aws_ebs_volume.attached", "aws_ebs_volume", 10, `{"id": "vol-attached"}`),
+		resource("aws_ebs_volume.orphan", "aws_ebs_volume", 25, `{"id": "vol-orphan"}`),
+		resource("aws_volume_attachment.attached", "aws_volume_attachment", 0, `{"volume_id": "vol-attached"}`),
+		resource("aws_lb.used", "aws_lb", 18, `{"arn": "arn:lb/used"}`),
+		resource("aws_lb.unused", "aws_lb", 18, `{"arn": "arn:lb/unused"}`),
+		resource("aws_lb_target_group.used", "aws_lb_target_group", 0, `{"arn": "arn:tg/used", "load_balancer_arns": ["arn:lb/used"]}`),
+		resource("aws_lb_target_group.empty", "aws_lb_target_group", 0, `{"arn": "arn:tg/empty", "load_balancer_arns": ["arn:lb/unused"]}`),
+		resource("aws_autoscaling_group.web", "aws_autoscaling_group", 140, `{"target_group_arns": ["arn:tg/used"], "load_balancers": ["classic-used"]}`),
+		resource("aws_elb.used", "aws_elb", 18, `{"name": "classic-used", "instances": []}`),
+		resource("aws_elb.unused", "aws_elb", 18, `{"name": "classic-unused", "instances": []}`),
+	}
+
+	project := &schema.Project{
+		Name:      "infracost/repo",
+		Metadata:  &schema.ProjectMetadata{Type: "terraform_state_json"},
+		Resources: resources,
+	}
+
+	actual := make([]string, 0)
+	for _, r := range Detect([]*schema.Project{project}, nil) {
+		actual = append(actual, r.Check+" "+r.Address+" "+r.MonthlyCost.String())
+	}
+
+	assert.Equal(t, []string{
+		"aws_unattached_ebs_volume aws_ebs_volume.orphan 25",
+		"aws_load_balancer_without_targets aws_elb.unused 18",
+		"aws_load_balancer_without_targets aws_lb.unused 18",
+		"aws_stopped_instance aws_instance.stopped 8",
+	}, actual)
+}
+
+func TestDetectSkipsPlans(t *testing.T) {
+	project := schema.NewProject("infracost/repo", &schema.ProjectMetadata{Type: "terraform_plan_json"})
+	project.Resources = []*schema.Resource{
+		resource("aws_ebs_volume.orphan", "aws_ebs_volume", 25, `{"id": "vol-orphan"}`),
+	}
+
+	assert.Empty(t, Detect([]*schema.Project{project}, nil))
+}
+
+func TestIsState(t *testing.T) {
+	dir := schema.NewProject("dir", &schema.ProjectMetadata{Type: "terraform_dir"})
+	assert.False(t, IsState(dir))
+
+	dir.HasDiff = false
+	assert.True(t, IsState(dir))
+}
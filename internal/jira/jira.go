@@ -0,0 +1,242 @@
+// Package jira opens Jira issues for the projects whose guardrails are
+// violated, so the FinOps follow-ups of cost breaches are tracked. Each project
+// has a single open issue, found by a label derived from the repo and project
+// name, which is updated on later breaches instead of opening another one.
+package jira
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/infracost/infracost/internal/config"
+	"github.com/infracost/infracost/internal/output"
+	"github.com/infracost/infracost/internal/version"
+)
+
+// Issue is a Jira issue opened or updated for the guardrail violations of a
+// project.
+type Issue struct {
+	Key     string
+	Project string
+	Created bool
+}
+
+// Client opens and updates issues with the Jira REST API.
+type Client struct {
+	URL string
+	// Email and Token authenticate to Jira Cloud, Token is sent as a personal
+	// access token to Jira Data Center if Email is empty.
+	Email string
+	Token string
+
+	httpClient *http.Client
+}
+
+// NewClient returns a Client of the Jira server at url.
+func NewClient(url string, email string, token string) *Client {
+	return &Client{
+		URL:        strings.TrimSuffix(url, "/"),
+		Email:      email,
+		Token:      token,
+		httpClient: &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+// Sync opens or updates the issues of the projects of the output with guardrail
+// violations, for the first of the Jira issue configs that matches each of them.
+// The issues of the other projects are still synced if one fails.
+func (c *Client) Sync(ctx context.Context, out output.Root, configs []*config.JiraIssue) ([]Issue, error) {
+	var issues []Issue
+	var errs []string
+
+	for _, p := range out.Projects {
+		cfg := matchConfig(p.Name, configs)
+		if cfg == nil {
+			continue
+		}
+
+		projectOut := output.FilterProjects(out, func(o output.Project) bool {
+			return o.Name == p.Name
+		})
+
+		if cfg.BlockingOnly && projectOut.BlockingGuardrailViolations() == 0 {
+			continue
+		}
+
+		if len(projectOut.GuardrailViolations) == 0 {
+			continue
+		}
+
+		issue, err := c.syncProject(ctx, projectOut, p.Name, cfg)
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("%s: %s", p.Name, err))
+			continue
+		}
+
+		issues = append(issues, issue)
+	}
+
+	if len(errs) > 0 {
+		return issues, fmt.Errorf("Error syncing Jira issues:\n\t%s", strings.Join(errs, "\n\t"))
+	}
+
+	return issues, nil
+}
+
+func (c *Client) syncProject(ctx context.Context, out output.Root, projectName string, cfg *config.JiraIssue) (Issue, error) {
+	description, err := output.ToJiraDescription(out, output.Options{NoColor: true})
+	if err != nil {
+		return Issue{}, err
+	}
+
+	n := len(out.GuardrailViolations)
+	violations := "1 guardrail violation"
+	if n != 1 {
+		violations = fmt.Sprintf("%d guardrail violations", n)
+	}
+
+	summary := fmt.Sprintf("Infracost: %s in %s", violations, projectName)
+
+	repo := ""
+	if out.VCS != nil {
+		repo = out.VCS.RepositoryURL
+	}
+	label := IssueLabel(repo, projectName)
+
+	key, err := c.findOpenIssue(ctx, cfg.JiraProject, label)
+	if err != nil {
+		return Issue{}, err
+	}
+
+	fields := map[string]interface{}{
+		"summary":     summary,
+		"description": string(description),
+	}
+
+	if key != "" {
+		_, err = c.do(ctx, http.MethodPut, "/rest/api/2/issue/"+key, map[string]interface{}{"fields": fields})
+		if err != nil {
+			return Issue{}, err
+		}
+
+		return Issue{Key: key, Project: projectName}, nil
+	}
+
+	fields["project"] = map[string]string{"key": cfg.JiraProject}
+	fields["issuetype"] = map[string]string{"name": cfg.IssueTypeOrDefault()}
+	fields["labels"] = append([]string{label}, cfg.Labels...)
+
+	b, err := c.do(ctx, http.MethodPost, "/rest/api/2/issue", map[string]interface{}{"fields": fields})
+	if err != nil {
+		return Issue{}, err
+	}
+
+	var created struct {
+		Key string `json:"key"`
+	}
+	err = json.Unmarshal(b, &created)
+	if err != nil {
+		return Issue{}, fmt.Errorf("invalid response creating issue: %w", err)
+	}
+
+	return Issue{Key: created.Key, Project: projectName, Created: true}, nil
+}
+
+// findOpenIssue returns the key of the unresolved issue of the Jira project
+// with the label, or an empty key if there's none.
+func (c *Client) findOpenIssue(ctx context.Context, jiraProject string, label string) (string, error) {
+	jql := fmt.Sprintf(`project = "%s" AND labels = "%s" AND statusCategory != Done ORDER BY created DESC`, jiraProject, label)
+
+	b, err := c.do(ctx, http.MethodPost, "/rest/api/2/search", map[string]interface{}{
+		"jql":        jql,
+		"maxResults": 1,
+		"fields":     []string{"key"},
+	})
+	if err != nil {
+		return "", err
+	}
+
+	var res struct {
+		Issues []struct {
+			Key string `json:"key"`
+		} `json:"issues"`
+	}
+	err = json.Unmarshal(b, &res)
+	if err != nil {
+		return "", fmt.Errorf("invalid response searching issues: %w", err)
+	}
+
+	if len(res.Issues) == 0 {
+		return "", nil
+	}
+
+	return res.Issues[0].Key, nil
+}
+
+func (c *Client) do(ctx context.Context, method string, endpoint string, body interface{}) ([]byte, error) {
+	j, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.URL+endpoint, bytes.NewReader(j))
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", fmt.Sprintf("infracost-%s", version.Version))
+	if c.Email != "" {
+		req.SetBasicAuth(c.Email, c.Token)
+	} else if c.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.Token)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("Jira returned %s: %s", resp.Status, strings.TrimSpace(string(b)))
+	}
+
+	return b, nil
+}
+
+// IssueLabel returns the label that identifies the issues of a project of a
+// repo, since Jira labels can't contain the spaces and slashes of the names.
+func IssueLabel(repo string, projectName string) string {
+	h := sha256.Sum256([]byte(repo + "\n" + projectName))
+	return "infracost-" + hex.EncodeToString(h[:])[:12]
+}
+
+func matchConfig(projectName string, configs []*config.JiraIssue) *config.JiraIssue {
+	for _, cfg := range configs {
+		if cfg.Project == "" {
+			return cfg
+		}
+
+		if ok, _ := path.Match(cfg.Project, projectName); ok {
+			return cfg
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,150 @@
+package jira
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/infracost/infracost/internal/config"
+	"github.com/infracost/infracost/internal/output"
+)
+
+type fakeJira struct {
+	open    map[string]string
+	created []map[string]interface{}
+	updated map[string]map[string]interface{}
+	auth    string
+}
+
+func (f *fakeJira) handler() http.Handler {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/rest/api/2/search", func(w http.ResponseWriter, r *http.Request) {
+		f.auth = r.Header.Get("Authorization")
+
+		var req struct {
+			JQL string `json:"jql"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&req)
+
+		issues := []map[string]string{}
+		for label, key := range f.open {
+			if strings.Contains(req.JQL, `labels = "`+label+`"`) {
+				issues = append(issues, map[string]string{"key": key})
+			}
+		}
+
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"issues": issues})
+	})
+
+	mux.HandleFunc("/rest/api/2/issue", func(w http.ResponseWriter, r *http.Request) {
+		var req map[string]interface{}
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		f.created = append(f.created, req["fields"].(map[string]interface{}))
+
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"key":"FINOPS-2"}`))
+	})
+
+	mux.HandleFunc("/rest/api/2/issue/", func(w http.ResponseWriter, r *http.Request) {
+		var req map[string]interface{}
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		f.updated[strings.TrimPrefix(r.URL.Path, "/rest/api/2/issue/")] = req["fields"].(map[string]interface{})
+
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	return mux
+}
+
+func testOutput() output.Root {
+	cost := func(v int64) *decimal.Decimal {
+		d := decimal.NewFromInt(v)
+		return &d
+	}
+
+	out := output.Root{
+		Currency: "USD",
+		Projects: []output.Project{
+			{Name: "environments/prod", Breakdown: &output.Breakdown{TotalMonthlyCost: cost(1600)}},
+			{Name: "environments/dev", Breakdown: &output.Breakdown{TotalMonthlyCost: cost(120)}},
+			{Name: "environments/staging", Breakdown: &output.Breakdown{TotalMonthlyCost: cost(300)}},
+		},
+		GuardrailViolations: []output.GuardrailViolation{
+			{Guardrail: "prod", ProjectName: "environments/prod", Message: "monthly cost $1,600 is over $1,000", Blocking: true},
+			{Guardrail: "dev", ProjectName: "environments/dev", Message: "monthly cost $120 is over $100"},
+		},
+	}
+
+	return output.FilterProjects(out, func(output.Project) bool { return true })
+}
+
+func TestSync(t *testing.T) {
+	f := &fakeJira{
+		open:    map[string]string{IssueLabel("", "environments/dev"): "PLAT-7"},
+		updated: map[string]map[string]interface{}{},
+	}
+	ts := httptest.NewServer(f.handler())
+	defer ts.Close()
+
+	c := NewClient(ts.URL+"/", "finops@example.com", "secret")
+
+	issues, err := c.Sync(context.Background(), testOutput(), []*config.JiraIssue{
+		{Project: "environments/prod", JiraProject: "FINOPS", Labels: []string{"cost"}},
+		{JiraProject: "PLAT"},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, []Issue{
+		{Key: "FINOPS-2", Project: "environments/prod", Created: true},
+		{Key: "PLAT-7", Project: "environments/dev"},
+	}, issues)
+	assert.True(t, strings.HasPrefix(f.auth, "Basic "))
+
+	require.Len(t, f.created, 1)
+	assert.Equal(t, "Infracost: 1 guardrail violation in environments/prod", f.created[0]["summary"])
+	assert.Equal(t, map[string]interface{}{"key": "FINOPS"}, f.created[0]["project"])
+	assert.Equal(t, map[string]interface{}{"name": "Task"}, f.created[0]["issuetype"])
+	assert.Equal(t, []interface{}{IssueLabel("", "environments/prod"), "cost"}, f.created[0]["labels"])
+	assert.Contains(t, f.created[0]["description"], "monthly cost $1,600 is over $1,000")
+
+	require.Contains(t, f.updated, "PLAT-7")
+	assert.Equal(t, "Infracost: 1 guardrail violation in environments/dev", f.updated["PLAT-7"]["summary"])
+	assert.NotContains(t, f.updated["PLAT-7"], "labels")
+}
+
+func TestSyncBlockingOnly(t *testing.T) {
+	f := &fakeJira{updated: map[string]map[string]interface{}{}}
+	ts := httptest.NewServer(f.handler())
+	defer ts.Close()
+
+	c := NewClient(ts.URL, "", "pat")
+
+	issues, err := c.Sync(context.Background(), testOutput(), []*config.JiraIssue{
+		{Project: "environments/*", JiraProject: "FINOPS", BlockingOnly: true},
+	})
+	require.NoError(t, err)
+
+	require.Len(t, issues, 1)
+	assert.Equal(t, "environments/prod", issues[0].Project)
+	assert.Equal(t, "Bearer pat", f.auth)
+}
+
+func TestSyncError(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"errorMessages":["Unauthorized"]}`, http.StatusUnauthorized)
+	}))
+	defer ts.Close()
+
+	c := NewClient(ts.URL, "", "pat")
+
+	_, err := c.Sync(context.Background(), testOutput(), []*config.JiraIssue{{JiraProject: "FINOPS"}})
+	assert.EqualError(t, err, "Error syncing Jira issues:\n\tenvironments/prod: Jira returned 401 Unauthorized: {\"errorMessages\":[\"Unauthorized\"]}\n\tenvironments/dev: Jira returned 401 Unauthorized: {\"errorMessages\":[\"Unauthorized\"]}")
+}
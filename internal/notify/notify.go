@@ -0,0 +1,134 @@
+// Package notify posts the cost changes of a run to the chat webhooks of the
+// config file, e.g. Slack or Microsoft Teams. Each notification has rules of
+// which projects and changes it's posted for, so teams are only notified of the
+// changes they care about, separately from the pull request comments.
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/infracost/infracost/internal/config"
+	"github.com/infracost/infracost/internal/output"
+	"github.com/infracost/infracost/internal/version"
+)
+
+// Route returns the projects of the output that the notification is posted
+// for, and whether its rules match them. If they don't, the reason is returned
+// so it can be reported.
+func Route(out output.Root, n *config.Notification) (output.Root, bool, string) {
+	if n.Project != "" {
+		out = output.FilterProjects(out, func(p output.Project) bool {
+			ok, _ := path.Match(n.Project, p.Name)
+			return ok
+		})
+
+		if len(out.Projects) == 0 {
+			return out, false, fmt.Sprintf("no projects match %s", n.Project)
+		}
+	}
+
+	if n.OnGuardrailViolation && len(out.GuardrailViolations) == 0 {
+		return out, false, "no guardrails were violated"
+	}
+
+	diff := decimal.Zero
+	if out.DiffTotalMonthlyCost != nil {
+		diff = *out.DiffTotalMonthlyCost
+	}
+
+	if n.MinMonthlyIncrease == nil && n.MinMonthlyIncreasePercent == nil {
+		if !n.OnGuardrailViolation && diff.IsZero() {
+			return out, false, "monthly cost won't change"
+		}
+
+		return out, true, ""
+	}
+
+	if !diff.IsPositive() {
+		return out, false, "monthly cost won't increase"
+	}
+
+	if n.MinMonthlyIncrease != nil {
+		limit := decimal.NewFromFloat(*n.MinMonthlyIncrease)
+		if diff.LessThan(limit) {
+			return out, false, fmt.Sprintf("monthly cost increase of %s %s is under %s", diff.StringFixed(2), out.Currency, limit.String())
+		}
+	}
+
+	// An increase from no cost is an infinite percentage, so it's always over
+	// the limit.
+	if n.MinMonthlyIncreasePercent != nil && out.PastTotalMonthlyCost != nil && out.PastTotalMonthlyCost.IsPositive() {
+		percent := diff.Div(*out.PastTotalMonthlyCost).Mul(decimal.NewFromInt(100))
+		limit := decimal.NewFromFloat(*n.MinMonthlyIncreasePercent)
+		if percent.LessThan(limit) {
+			return out, false, fmt.Sprintf("monthly cost increase of %s%% is under %s%%", percent.StringFixed(0), limit.String())
+		}
+	}
+
+	return out, true, ""
+}
+
+// Message returns the body of the notification of the output, in the format of
+// its type.
+func Message(out output.Root, n *config.Notification) ([]byte, error) {
+	opts := output.Options{NoColor: true}
+
+	switch n.Type {
+	case config.NotificationTypeSlack:
+		b, err := output.ToSlackMessage(out, opts)
+		if err != nil || n.Channel == "" {
+			return b, err
+		}
+
+		var msg map[string]interface{}
+		err = json.Unmarshal(b, &msg)
+		if err != nil {
+			return nil, err
+		}
+		msg["channel"] = n.Channel
+
+		return json.Marshal(msg)
+	case config.NotificationTypeTeams:
+		return output.ToTeamsMessage(out, opts)
+	case config.NotificationTypeDiscord:
+		return output.ToDiscordMessage(out, opts)
+	default:
+		return output.ToJSON(out, opts)
+	}
+}
+
+var httpClient = &http.Client{Timeout: 30 * time.Second}
+
+// Send posts the body to the URL of the notification.
+func Send(ctx context.Context, n *config.Notification, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.URL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("Error creating %s notification request: %w", n.Label(), err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", fmt.Sprintf("infracost-%s", version.Version))
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("Error sending %s notification: %w", n.Label(), err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		b, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("Notification webhook of %s returned %s: %s", n.Label(), resp.Status, strings.TrimSpace(string(b)))
+	}
+
+	return nil
+}
@@ -0,0 +1,115 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/infracost/infracost/internal/config"
+	"github.com/infracost/infracost/internal/output"
+)
+
+func costs(past, total int64) (*output.Breakdown, *output.Breakdown, *output.Breakdown) {
+	d := func(v int64) *decimal.Decimal {
+		c := decimal.NewFromInt(v)
+		return &c
+	}
+
+	return &output.Breakdown{TotalMonthlyCost: d(past)},
+		&output.Breakdown{TotalMonthlyCost: d(total)},
+		&output.Breakdown{Resources: []output.Resource{{Name: "aws_instance.web"}}, TotalMonthlyCost: d(total - past)}
+}
+
+func testOutput() output.Root {
+	prodPast, prod, prodDiff := costs(1000, 1600)
+	devPast, dev, devDiff := costs(100, 120)
+
+	out := output.Root{
+		Currency: "USD",
+		Projects: []output.Project{
+			{Name: "environments/prod", PastBreakdown: prodPast, Breakdown: prod, Diff: prodDiff},
+			{Name: "environments/dev", PastBreakdown: devPast, Breakdown: dev, Diff: devDiff},
+		},
+		GuardrailViolations: []output.GuardrailViolation{{Guardrail: "dev", ProjectName: "environments/dev"}},
+	}
+
+	return output.FilterProjects(out, func(output.Project) bool { return true })
+}
+
+func float64Ptr(f float64) *float64 {
+	return &f
+}
+
+func TestRoute(t *testing.T) {
+	out := testOutput()
+
+	routed, ok, _ := Route(out, &config.Notification{Type: "slack"})
+	assert.True(t, ok)
+	assert.Len(t, routed.Projects, 2)
+
+	_, ok, reason := Route(out, &config.Notification{Type: "slack", MinMonthlyIncrease: float64Ptr(1000)})
+	assert.False(t, ok)
+	assert.Equal(t, "monthly cost increase of 620.00 USD is under 1000", reason)
+
+	routed, ok, _ = Route(out, &config.Notification{Type: "slack", Project: "environments/prod", MinMonthlyIncrease: float64Ptr(500)})
+	assert.True(t, ok)
+	assert.Len(t, routed.Projects, 1)
+
+	_, ok, reason = Route(out, &config.Notification{Type: "slack", Project: "environments/dev", MinMonthlyIncreasePercent: float64Ptr(50)})
+	assert.False(t, ok)
+	assert.Equal(t, "monthly cost increase of 20% is under 50%", reason)
+
+	routed, ok, _ = Route(out, &config.Notification{Type: "slack", OnGuardrailViolation: true})
+	assert.True(t, ok)
+	assert.Len(t, routed.GuardrailViolations, 1)
+
+	_, ok, reason = Route(out, &config.Notification{Type: "slack", Project: "environments/prod", OnGuardrailViolation: true})
+	assert.False(t, ok)
+	assert.Equal(t, "no guardrails were violated", reason)
+
+	_, ok, reason = Route(out, &config.Notification{Type: "slack", Project: "modules/*"})
+	assert.False(t, ok)
+	assert.Equal(t, "no projects match modules/*", reason)
+}
+
+func TestMessage(t *testing.T) {
+	b, err := Message(testOutput(), &config.Notification{Type: "slack", Channel: "#platform-alerts"})
+	require.NoError(t, err)
+
+	var msg map[string]interface{}
+	require.NoError(t, json.Unmarshal(b, &msg))
+	assert.Equal(t, "#platform-alerts", msg["channel"])
+	assert.NotEmpty(t, msg["blocks"])
+
+	b, err = Message(testOutput(), &config.Notification{Type: "webhook"})
+	require.NoError(t, err)
+
+	out, err := output.Load(b)
+	require.NoError(t, err)
+	assert.Len(t, out.Projects, 2)
+}
+
+func TestSend(t *testing.T) {
+	var body []byte
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ = io.ReadAll(r.Body)
+		if r.URL.Path == "/fail" {
+			http.Error(w, "invalid_token", http.StatusForbidden)
+		}
+	}))
+	defer ts.Close()
+
+	err := Send(context.Background(), &config.Notification{Type: "discord", URL: ts.URL}, []byte(`{"content":"hi"}`))
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"content":"hi"}`, string(body))
+
+	err = Send(context.Background(), &config.Notification{Type: "discord", URL: ts.URL + "/fail"}, []byte(`{}`))
+	assert.EqualError(t, err, "Notification webhook of discord returned 403 Forbidden: invalid_token")
+}
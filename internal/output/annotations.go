@@ -0,0 +1,123 @@
+package output
+
+import (
+	"fmt"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/infracost/infracost/internal/ui"
+)
+
+// The keys of the infracost: comment annotations of resources, e.g.
+//
+//	# infracost: budget=200/month
+//	# infracost: ignore reason="POC" until=2025-09-01
+const (
+	AnnotationBudget = "budget"
+	AnnotationIgnore = "ignore"
+	AnnotationReason = "reason"
+	AnnotationUntil  = "until"
+)
+
+// annotationDateFormat is the format of the until date of ignore annotations.
+const annotationDateFormat = "2006-01-02"
+
+// Exemption is a resource that an ignore annotation excludes from the budgets,
+// guardrails and tag policies. An exemption has Expired once the day of its
+// Until date has passed, or if its Until date is invalid, and no longer applies.
+type Exemption struct {
+	ProjectName string `json:"projectName"`
+	Address     string `json:"address"`
+	Reason      string `json:"reason,omitempty"`
+	Until       string `json:"until,omitempty"`
+	Expired     bool   `json:"expired"`
+}
+
+// IsExempt returns true if the resource has an ignore annotation that hasn't
+// expired at now.
+func (r Resource) IsExempt(now time.Time) bool {
+	if _, ok := r.Annotations[AnnotationIgnore]; !ok {
+		return false
+	}
+
+	return !exemptionExpired(r.Annotations[AnnotationUntil], now)
+}
+
+func exemptionExpired(until string, now time.Time) bool {
+	if until == "" {
+		return false
+	}
+
+	t, err := time.Parse(annotationDateFormat, until)
+	if err != nil {
+		log.Warnf("Invalid until date %q of an infracost: ignore annotation, it must be in the format YYYY-MM-DD", until)
+		return true
+	}
+
+	return !now.Before(t.AddDate(0, 0, 1))
+}
+
+// exemptions returns the resources of the projects with an ignore annotation.
+func exemptions(projects []Project, now time.Time) []Exemption {
+	var exemptions []Exemption
+
+	for _, p := range projects {
+		if p.Breakdown == nil {
+			continue
+		}
+
+		for _, r := range p.Breakdown.Resources {
+			if _, ok := r.Annotations[AnnotationIgnore]; !ok {
+				continue
+			}
+
+			exemptions = append(exemptions, Exemption{
+				ProjectName: p.Name,
+				Address:     r.Name,
+				Reason:      r.Annotations[AnnotationReason],
+				Until:       r.Annotations[AnnotationUntil],
+				Expired:     exemptionExpired(r.Annotations[AnnotationUntil], now),
+			})
+		}
+	}
+
+	return exemptions
+}
+
+// exemptionsToTable lists the exempted resources so exemptions aren't forgotten,
+// with the expired ones highlighted.
+func exemptionsToTable(out Root) string {
+	if len(out.Exemptions) == 0 {
+		return ""
+	}
+
+	s := "──────────────────────────────────\n"
+
+	if len(out.Exemptions) == 1 {
+		s += ui.BoldString("1 resource is exempted by an infracost: ignore comment:")
+	} else {
+		s += ui.BoldString(fmt.Sprintf("%d resources are exempted by infracost: ignore comments:", len(out.Exemptions)))
+	}
+
+	for _, e := range out.Exemptions {
+		s += fmt.Sprintf("\n∙ %s", e.Address)
+
+		if e.Reason != "" {
+			s += fmt.Sprintf(": %s", e.Reason)
+		}
+
+		switch {
+		case e.Expired:
+			s += " " + ui.WarningStringf("(expired %s, no longer exempted)", e.Until)
+		case e.Until != "":
+			s += " " + ui.FaintStringf("(until %s)", e.Until)
+		}
+
+		if len(out.Projects) > 1 {
+			s += ui.FaintStringf(" (%s)", e.ProjectName)
+		}
+	}
+
+	return s
+}
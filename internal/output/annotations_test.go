@@ -0,0 +1,34 @@
+package output
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExemptions(t *testing.T) {
+	now := time.Date(2025, 9, 1, 12, 0, 0, 0, time.UTC)
+
+	assert.True(t, Resource{Annotations: map[string]string{"ignore": ""}}.IsExempt(now))
+	assert.True(t, Resource{Annotations: map[string]string{"ignore": "", "until": "2025-09-01"}}.IsExempt(now))
+	assert.False(t, Resource{Annotations: map[string]string{"ignore": "", "until": "2025-08-31"}}.IsExempt(now))
+	assert.False(t, Resource{Annotations: map[string]string{"ignore": "", "until": "September"}}.IsExempt(now))
+	assert.False(t, Resource{Annotations: map[string]string{"budget": "200"}}.IsExempt(now))
+
+	projects := []Project{
+		{
+			Name: "prod",
+			Breakdown: &Breakdown{
+				Resources: []Resource{
+					{Name: "aws_instance.poc", Annotations: map[string]string{"ignore": "", "reason": "POC", "until": "2025-08-31"}},
+					{Name: "aws_instance.web"},
+				},
+			},
+		},
+	}
+
+	assert.Equal(t, []Exemption{
+		{ProjectName: "prod", Address: "aws_instance.poc", Reason: "POC", Until: "2025-08-31", Expired: true},
+	}, exemptions(projects, now))
+}
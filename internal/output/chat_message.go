@@ -0,0 +1,137 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/shopspring/decimal"
+)
+
+// chatSummary is the summary of the cost change of the projects that's posted
+// to chat platforms without blocks like Slack's.
+type chatSummary struct {
+	Title string
+	Lines []string
+	URL   string
+}
+
+func newChatSummary(out Root) chatSummary {
+	total := out.TotalMonthlyCost
+	if total == nil {
+		total = decimalPtr(decimal.Zero)
+	}
+
+	s := chatSummary{
+		Title: fmt.Sprintf("Infracost estimate: %s", formatCostChangeSentence(out.Currency, out.PastTotalMonthlyCost, total, false)),
+	}
+
+	for _, p := range out.Projects {
+		if len(out.Projects) != 1 && (p.Diff == nil || len(p.Diff.Resources) == 0) {
+			continue
+		}
+
+		totals := p.reportCurrencyTotals()
+		s.Lines = append(s.Lines, fmt.Sprintf("%s: %s%s", p.Name,
+			formatCostChange(out.Currency, totals.DiffTotalMonthlyCost),
+			formatCostChangeDetails(out.Currency, totals.PastTotalMonthlyCost, totals.TotalMonthlyCost)))
+	}
+
+	if n := len(out.GuardrailViolations); n == 1 {
+		s.Lines = append(s.Lines, "1 guardrail violation")
+	} else if n > 1 {
+		s.Lines = append(s.Lines, fmt.Sprintf("%d guardrail violations", n))
+	}
+
+	if out.VCS != nil {
+		s.URL = out.VCS.PullRequestURL
+		if s.URL == "" {
+			s.URL = out.VCS.PipelineURL
+		}
+	}
+
+	return s
+}
+
+// teamsMessage is a Microsoft Teams connector card.
+type teamsMessage struct {
+	Type            string         `json:"@type"`
+	Context         string         `json:"@context"`
+	Summary         string         `json:"summary"`
+	ThemeColor      string         `json:"themeColor"`
+	Title           string         `json:"title"`
+	Text            string         `json:"text,omitempty"`
+	PotentialAction []teamsAction  `json:"potentialAction,omitempty"`
+	Sections        []teamsSection `json:"sections,omitempty"`
+}
+
+type teamsSection struct {
+	Text string `json:"text"`
+}
+
+type teamsAction struct {
+	Type    string        `json:"@type"`
+	Name    string        `json:"name"`
+	Targets []teamsTarget `json:"targets"`
+}
+
+type teamsTarget struct {
+	OS  string `json:"os"`
+	URI string `json:"uri"`
+}
+
+// ToTeamsMessage outputs the cost change of the projects as a Microsoft Teams
+// message card that can be posted to an incoming webhook.
+func ToTeamsMessage(out Root, opts Options) ([]byte, error) {
+	s := newChatSummary(out)
+
+	msg := teamsMessage{
+		Type:       "MessageCard",
+		Context:    "https://schema.org/extensions",
+		Summary:    s.Title,
+		ThemeColor: "dcd8e1",
+		Title:      s.Title,
+	}
+
+	if len(s.Lines) > 0 {
+		// Teams only breaks lines in the text of a card on blank lines.
+		msg.Sections = []teamsSection{{Text: strings.Join(s.Lines, "\n\n")}}
+	}
+
+	if s.URL != "" {
+		msg.PotentialAction = []teamsAction{{
+			Type:    "OpenUri",
+			Name:    "View changes",
+			Targets: []teamsTarget{{OS: "default", URI: s.URL}},
+		}}
+	}
+
+	return json.Marshal(msg)
+}
+
+// discordMessageLimit is the longest content of a Discord message.
+const discordMessageLimit = 2000
+
+type discordMessage struct {
+	Content  string `json:"content"`
+	Username string `json:"username"`
+}
+
+// ToDiscordMessage outputs the cost change of the projects as a Discord message
+// that can be posted to a webhook.
+func ToDiscordMessage(out Root, opts Options) ([]byte, error) {
+	s := newChatSummary(out)
+
+	lines := []string{fmt.Sprintf("💰 **%s**", s.Title)}
+	for _, l := range s.Lines {
+		lines = append(lines, "• "+l)
+	}
+
+	if s.URL != "" {
+		lines = append(lines, s.URL)
+	}
+
+	content := truncateMiddle(strings.Join(lines, "\n"), discordMessageLimit, "\n...\n")
+
+	return json.Marshal(discordMessage{Content: content, Username: "Infracost"})
+}
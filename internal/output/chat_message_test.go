@@ -0,0 +1,81 @@
+package output
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/infracost/infracost/internal/schema"
+)
+
+func chatMessageTestOutput() Root {
+	breakdown := func(cost int64, resources ...Resource) *Breakdown {
+		return &Breakdown{Resources: resources, TotalMonthlyCost: decimalPtr(decimal.NewFromInt(cost))}
+	}
+
+	out := Root{
+		Currency: "USD",
+		Projects: []Project{
+			{
+				Name:          "infracost/prod",
+				PastBreakdown: breakdown(300),
+				Breakdown:     breakdown(420),
+				Diff:          breakdown(120, Resource{Name: "aws_instance.web"}),
+			},
+			{
+				Name:          "infracost/dev",
+				PastBreakdown: breakdown(80),
+				Breakdown:     breakdown(80),
+				Diff:          breakdown(0),
+			},
+		},
+		GuardrailViolations: []GuardrailViolation{{Guardrail: "prod", ProjectName: "infracost/prod"}},
+		VCS:                 &schema.VCSMetadata{PullRequestURL: "https://github.com/infracost/infracost/pull/3"},
+	}
+	setTotalsFromProjects(&out)
+
+	return out
+}
+
+func TestToDiscordMessage(t *testing.T) {
+	b, err := ToDiscordMessage(chatMessageTestOutput(), Options{})
+	require.NoError(t, err)
+
+	var msg map[string]string
+	require.NoError(t, json.Unmarshal(b, &msg))
+
+	assert.Equal(t, "Infracost", msg["username"])
+	assert.Equal(t, `💰 **Infracost estimate: monthly cost will increase by $120 (+32%) ↑**
+• infracost/prod: +$120 ($300 → $420)
+• 1 guardrail violation
+https://github.com/infracost/infracost/pull/3`, msg["content"])
+}
+
+func TestToTeamsMessage(t *testing.T) {
+	b, err := ToTeamsMessage(chatMessageTestOutput(), Options{})
+	require.NoError(t, err)
+
+	var msg teamsMessage
+	require.NoError(t, json.Unmarshal(b, &msg))
+
+	assert.Equal(t, "MessageCard", msg.Type)
+	assert.Equal(t, "Infracost estimate: monthly cost will increase by $120 (+32%) ↑", msg.Title)
+	require.Len(t, msg.Sections, 1)
+	assert.Equal(t, "infracost/prod: +$120 ($300 → $420)\n\n1 guardrail violation", msg.Sections[0].Text)
+	require.Len(t, msg.PotentialAction, 1)
+	assert.Equal(t, "https://github.com/infracost/infracost/pull/3", msg.PotentialAction[0].Targets[0].URI)
+}
+
+func TestFilterProjects(t *testing.T) {
+	out := FilterProjects(chatMessageTestOutput(), func(p Project) bool {
+		return p.Name == "infracost/dev"
+	})
+
+	require.Len(t, out.Projects, 1)
+	assert.Empty(t, out.GuardrailViolations)
+	assert.Equal(t, "80", out.TotalMonthlyCost.String())
+	assert.Equal(t, "0", out.DiffTotalMonthlyCost.String())
+}
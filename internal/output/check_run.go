@@ -0,0 +1,179 @@
+package output
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/infracost/infracost/internal/schema"
+)
+
+// The levels of check annotations, the ones of GitHub check runs.
+const (
+	CheckAnnotationNotice  = "notice"
+	CheckAnnotationWarning = "warning"
+	CheckAnnotationFailure = "failure"
+)
+
+// CheckAnnotationCostChange is the check of the annotations of the resources
+// whose monthly costs change.
+const CheckAnnotationCostChange = "infracost/cost-change"
+
+// CheckAnnotation is a message about the lines of a file where a resource is
+// defined, e.g. an annotation of a GitHub check run. Check is what it's about,
+// a cost change, a guardrail or a tag policy.
+type CheckAnnotation struct {
+	Check     string
+	Address   string
+	Path      string
+	StartLine int
+	EndLine   int
+	Level     string
+	Title     string
+	Message   string
+}
+
+// CheckAnnotations returns the annotations of the maxCostChanges resources whose
+// monthly costs change the most, and of the guardrail violations and the
+// resources that don't comply with the tag policies. Only the resources whose
+// source range is known, i.e. of Terraform directories, can be annotated.
+// Blocking violations are failures and the others warnings.
+func CheckAnnotations(out Root, maxCostChanges int) []CheckAnnotation {
+	var annotations []CheckAnnotation
+
+	type costChange struct {
+		annotation CheckAnnotation
+		change     decimal.Decimal
+	}
+	var changes []costChange
+
+	for _, p := range out.Projects {
+		if p.Diff == nil || p.Breakdown == nil {
+			continue
+		}
+
+		rate := decimal.NewFromInt(1)
+		if p.ReportCurrencyTotals != nil {
+			rate = p.ReportCurrencyTotals.Rate
+		}
+
+		for _, diff := range p.Diff.Resources {
+			if diff.MonthlyCost == nil || diff.MonthlyCost.IsZero() {
+				continue
+			}
+
+			res := findResourceByName(p.Breakdown.Resources, diff.Name)
+			if res == nil || res.SourceRange == nil {
+				continue
+			}
+
+			var past *decimal.Decimal
+			if p.PastBreakdown != nil {
+				if pastRes := findResourceByName(p.PastBreakdown.Resources, diff.Name); pastRes != nil {
+					past = convertCost(pastRes.MonthlyCost, rate)
+				}
+			}
+
+			change := convertCost(diff.MonthlyCost, rate)
+			verb := "increase"
+			if change.IsNegative() {
+				verb = "decrease"
+			}
+
+			message := fmt.Sprintf("Monthly cost will %s by %s", verb, formatCost(out.Currency, decimalPtr(change.Abs())))
+			if past != nil {
+				message += formatCostChangeDetails(out.Currency, past, convertCost(res.MonthlyCost, rate))
+			}
+
+			changes = append(changes, costChange{
+				annotation: newCheckAnnotation(CheckAnnotationCostChange, diff.Name, res.SourceRange, CheckAnnotationNotice,
+					fmt.Sprintf("%s: %s/month", diff.Name, formatCostChange(out.Currency, change)),
+					message,
+				),
+				change: change.Abs(),
+			})
+		}
+	}
+
+	sort.SliceStable(changes, func(i, j int) bool {
+		return changes[i].change.GreaterThan(changes[j].change)
+	})
+
+	for i, c := range changes {
+		if i == maxCostChanges {
+			break
+		}
+		annotations = append(annotations, c.annotation)
+	}
+
+	for _, v := range out.GuardrailViolations {
+		if v.SourceRange == nil {
+			continue
+		}
+
+		annotations = append(annotations, newCheckAnnotation(sarifRuleGuardrail, v.Address, v.SourceRange, checkAnnotationLevel(v.Blocking),
+			"Guardrail: "+v.Guardrail,
+			fmt.Sprintf("%s %s (%s)", v.Subject(), v.Message, v.Limit),
+		))
+	}
+
+	for _, t := range out.TagPolicies {
+		for _, r := range t.NonCompliantResources {
+			if r.SourceRange == nil {
+				continue
+			}
+
+			annotations = append(annotations, newCheckAnnotation(sarifRuleTagPolicy, r.Address, r.SourceRange, checkAnnotationLevel(t.Blocking),
+				"Tag policy: "+t.Name,
+				fmt.Sprintf("%s has %s, %s/month is untagged spend", r.Address, tagPolicyProblems(r), formatCost(out.Currency, r.MonthlyCost)),
+			))
+		}
+	}
+
+	return annotations
+}
+
+func newCheckAnnotation(check string, address string, r *schema.SourceRange, level string, title string, message string) CheckAnnotation {
+	return CheckAnnotation{
+		Check:     check,
+		Address:   address,
+		Path:      r.Filename,
+		StartLine: r.StartLine,
+		EndLine:   r.EndLine,
+		Level:     level,
+		Title:     title,
+		Message:   message,
+	}
+}
+
+func checkAnnotationLevel(blocking bool) string {
+	if blocking {
+		return CheckAnnotationFailure
+	}
+
+	return CheckAnnotationWarning
+}
+
+// CheckRunTitle returns the title of a check run of the output, its total
+// monthly cost change or its total monthly cost if it has no diff.
+func CheckRunTitle(out Root) string {
+	if out.DiffTotalMonthlyCost == nil || out.PastTotalMonthlyCost == nil {
+		return fmt.Sprintf("Monthly cost is %s", formatCost(out.Currency, out.TotalMonthlyCost))
+	}
+
+	if out.DiffTotalMonthlyCost.IsZero() {
+		return fmt.Sprintf("Monthly cost will not change (%s)", formatCost(out.Currency, out.TotalMonthlyCost))
+	}
+
+	verb := "increase"
+	if out.DiffTotalMonthlyCost.IsNegative() {
+		verb = "decrease"
+	}
+
+	return fmt.Sprintf("Monthly cost will %s by %s%s",
+		verb,
+		formatCost(out.Currency, decimalPtr(out.DiffTotalMonthlyCost.Abs())),
+		formatCostChangeDetails(out.Currency, out.PastTotalMonthlyCost, out.TotalMonthlyCost),
+	)
+}
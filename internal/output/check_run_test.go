@@ -0,0 +1,90 @@
+package output
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/infracost/infracost/internal/schema"
+)
+
+func TestCheckAnnotations(t *testing.T) {
+	cost := func(i int64) *decimal.Decimal {
+		return decimalPtr(decimal.NewFromInt(i))
+	}
+
+	out := Root{
+		Currency: "USD",
+		Projects: []Project{
+			{
+				Name: "infracost/prod",
+				PastBreakdown: &Breakdown{
+					Resources: []Resource{
+						{Name: "aws_instance.web", MonthlyCost: cost(300)},
+						{Name: "aws_db_instance.db", MonthlyCost: cost(500)},
+					},
+				},
+				Breakdown: &Breakdown{
+					Resources: []Resource{
+						{Name: "aws_instance.web", MonthlyCost: cost(400), SourceRange: &schema.SourceRange{Filename: "prod/main.tf", StartLine: 1, EndLine: 10}},
+						{Name: "aws_db_instance.db", MonthlyCost: cost(200), SourceRange: &schema.SourceRange{Filename: "prod/db.tf", StartLine: 3, EndLine: 15}},
+						{Name: "aws_s3_bucket.logs", MonthlyCost: cost(150), SourceRange: &schema.SourceRange{Filename: "prod/s3.tf", StartLine: 1, EndLine: 4}},
+						{Name: "aws_lambda_function.api", MonthlyCost: cost(1000)},
+					},
+				},
+				Diff: &Breakdown{
+					Resources: []Resource{
+						{Name: "aws_instance.web", MonthlyCost: cost(100)},
+						{Name: "aws_db_instance.db", MonthlyCost: cost(-300)},
+						{Name: "aws_s3_bucket.logs", MonthlyCost: cost(150)},
+						{Name: "aws_lambda_function.api", MonthlyCost: cost(1000)},
+					},
+				},
+			},
+		},
+		GuardrailViolations: []GuardrailViolation{
+			{
+				Guardrail:   "Instance limit",
+				ProjectName: "infracost/prod",
+				Address:     "aws_instance.web",
+				Limit:       "max_monthly_cost",
+				Message:     "monthly cost $400 is over the guardrail of $350",
+				Blocking:    true,
+				SourceRange: &schema.SourceRange{Filename: "prod/main.tf", StartLine: 1, EndLine: 10},
+			},
+			{
+				Guardrail:   "prod",
+				ProjectName: "infracost/prod",
+				Limit:       "max_monthly_increase",
+				Message:     "monthly cost increase $950 is over the guardrail of $500",
+			},
+		},
+		TagPolicies: []TagPolicyResult{
+			{
+				Name:        "Cost allocation",
+				ProjectName: "infracost/prod",
+				NonCompliantResources: []TagPolicyResource{
+					{Address: "aws_s3_bucket.logs", MonthlyCost: cost(150), MissingTags: []string{"team"}, SourceRange: &schema.SourceRange{Filename: "prod/s3.tf", StartLine: 1, EndLine: 4}},
+				},
+			},
+		},
+	}
+
+	assert.Equal(t, []CheckAnnotation{
+		{Check: CheckAnnotationCostChange, Address: "aws_db_instance.db", Path: "prod/db.tf", StartLine: 3, EndLine: 15, Level: CheckAnnotationNotice, Title: "aws_db_instance.db: -$300/month", Message: "Monthly cost will decrease by $300 ($500 → $200)"},
+		{Check: CheckAnnotationCostChange, Address: "aws_s3_bucket.logs", Path: "prod/s3.tf", StartLine: 1, EndLine: 4, Level: CheckAnnotationNotice, Title: "aws_s3_bucket.logs: +$150/month", Message: "Monthly cost will increase by $150"},
+		{Check: sarifRuleGuardrail, Address: "aws_instance.web", Path: "prod/main.tf", StartLine: 1, EndLine: 10, Level: CheckAnnotationFailure, Title: "Guardrail: Instance limit", Message: "aws_instance.web monthly cost $400 is over the guardrail of $350 (max_monthly_cost)"},
+		{Check: sarifRuleTagPolicy, Address: "aws_s3_bucket.logs", Path: "prod/s3.tf", StartLine: 1, EndLine: 4, Level: CheckAnnotationWarning, Title: "Tag policy: Cost allocation", Message: "aws_s3_bucket.logs has missing tags team, $150/month is untagged spend"},
+	}, CheckAnnotations(out, 2))
+}
+
+func TestCheckRunTitle(t *testing.T) {
+	assert.Equal(t, "Monthly cost is $1,200", CheckRunTitle(Root{Currency: "USD", TotalMonthlyCost: decimalPtr(decimal.NewFromInt(1200))}))
+	assert.Equal(t, "Monthly cost will increase by $200 ($1,000 → $1,200)", CheckRunTitle(Root{
+		Currency:             "USD",
+		TotalMonthlyCost:     decimalPtr(decimal.NewFromInt(1200)),
+		PastTotalMonthlyCost: decimalPtr(decimal.NewFromInt(1000)),
+		DiffTotalMonthlyCost: decimalPtr(decimal.NewFromInt(200)),
+	}))
+}
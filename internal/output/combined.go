@@ -84,7 +84,29 @@ func LoadPaths(paths []string) ([]ReportInput, error) {
 	return inputs, nil
 }
 
-func Combine(inputs []ReportInput) (Root, error) {
+// Merge strategies for projects that are in more than one input with the same
+// path and workspace, e.g. when fanned out CI jobs upload overlapping files.
+const (
+	// MergeStrategySum keeps every project so their costs are summed.
+	MergeStrategySum = "sum"
+	// MergeStrategyReplace keeps the project of the last input, replacing the
+	// projects of earlier inputs.
+	MergeStrategyReplace = "replace"
+)
+
+// MergeStrategies are the valid merge strategies of CombineOptions.
+var MergeStrategies = []string{MergeStrategySum, MergeStrategyReplace}
+
+// CombineOptions sets how the projects of the inputs are combined.
+type CombineOptions struct {
+	// MergeStrategy is how projects with the same path and workspace are merged,
+	// defaults to MergeStrategySum.
+	MergeStrategy string
+}
+
+// Combine merges the inputs into one output. The inputs must have the same
+// version, currency and exchange rate.
+func Combine(inputs []ReportInput, opts CombineOptions) (Root, error) {
 	var combined Root
 
 	var totalHourlyCost *decimal.Decimal
@@ -95,17 +117,70 @@ func Combine(inputs []ReportInput) (Root, error) {
 	var diffTotalMonthlyCost *decimal.Decimal
 
 	projects := make([]Project, 0)
+	var pricingIssues []PricingIssue
+	var guardrailViolations []GuardrailViolation
+	var tagPolicies []TagPolicyResult
+	var resourceExemptions []Exemption
+	var waivers []Waiver
+	waiverIndexes := make(map[string]int)
+	var recommendations []Recommendation
+	var rightsizing []RightsizingRecommendation
+	var idleResources []IdleResource
 	summaries := make([]*Summary, 0, len(inputs))
 	currency := ""
+	version := ""
 
-	for _, input := range inputs {
+	// replaced are the names of the projects of each input that were replaced
+	// by a later input.
+	replaced := make([]map[string]bool, len(inputs))
+	projectIndexes := make(map[string]int)
+	projectInputs := make([]int, 0)
+
+	for i, input := range inputs {
 		var err error
 		currency, err = checkCurrency(currency, input.Root.Currency)
 		if err != nil {
 			return combined, err
 		}
 
-		projects = append(projects, input.Root.Projects...)
+		version, err = checkVersion(version, input.Root.Version)
+		if err != nil {
+			return combined, err
+		}
+
+		if combined.VCS == nil {
+			combined.VCS = input.Root.VCS
+		}
+
+		if combined.ExchangeRate == nil {
+			combined.ExchangeRate = input.Root.ExchangeRate
+		} else if input.Root.ExchangeRate != nil && !input.Root.ExchangeRate.Rate.Equal(combined.ExchangeRate.Rate) {
+			return combined, fmt.Errorf("Invalid Infracost JSON file exchange rate mismatch.  Can't combine rates %s and %s", combined.ExchangeRate.Rate, input.Root.ExchangeRate.Rate)
+		}
+
+		replaced[i] = make(map[string]bool)
+
+		for _, p := range input.Root.Projects {
+			if opts.MergeStrategy != MergeStrategyReplace {
+				projects = append(projects, p)
+				projectInputs = append(projectInputs, i)
+				continue
+			}
+
+			key := projectKey(p)
+			if j, ok := projectIndexes[key]; ok {
+				if projectInputs[j] != i {
+					replaced[projectInputs[j]][projects[j].Name] = true
+				}
+				projects[j] = p
+				projectInputs[j] = i
+				continue
+			}
+
+			projectIndexes[key] = len(projects)
+			projects = append(projects, p)
+			projectInputs = append(projectInputs, i)
+		}
 
 		summaries = append(summaries, input.Root.Summary)
 
@@ -154,6 +229,92 @@ func Combine(inputs []ReportInput) (Root, error) {
 		}
 	}
 
+	hasReplaced := false
+	for i, input := range inputs {
+		for _, issue := range input.Root.PricingIssues {
+			if replaced[i][issue.ProjectName] {
+				continue
+			}
+			pricingIssues = append(pricingIssues, issue)
+		}
+
+		for _, v := range input.Root.GuardrailViolations {
+			if replaced[i][v.ProjectName] {
+				continue
+			}
+			guardrailViolations = append(guardrailViolations, v)
+		}
+
+		for _, t := range input.Root.TagPolicies {
+			if replaced[i][t.ProjectName] {
+				continue
+			}
+			tagPolicies = append(tagPolicies, t)
+		}
+
+		for _, e := range input.Root.Exemptions {
+			if replaced[i][e.ProjectName] {
+				continue
+			}
+			resourceExemptions = append(resourceExemptions, e)
+		}
+
+		// The inputs usually share the same waivers file, so their waivers are
+		// merged with the failures they match in each input.
+		for _, w := range input.Root.Waivers {
+			key := strings.Join([]string{w.Rule, w.Address, w.Project, w.Reason, w.Owner, w.Expires}, "\x00")
+
+			j, ok := waiverIndexes[key]
+			if !ok {
+				j = len(waivers)
+				waiverIndexes[key] = j
+				waivers = append(waivers, w)
+				waivers[j].Failures = []WaivedFailure{}
+			}
+
+			for _, f := range w.Failures {
+				if replaced[i][f.ProjectName] {
+					continue
+				}
+				waivers[j].Failures = append(waivers[j].Failures, f)
+			}
+		}
+
+		for _, rec := range input.Root.Recommendations {
+			if replaced[i][rec.ProjectName] {
+				continue
+			}
+			recommendations = append(recommendations, rec)
+		}
+
+		for _, rec := range input.Root.Rightsizing {
+			if replaced[i][rec.ProjectName] {
+				continue
+			}
+			rightsizing = append(rightsizing, rec)
+		}
+
+		for _, r := range input.Root.IdleResources {
+			if replaced[i][r.ProjectName] {
+				continue
+			}
+			idleResources = append(idleResources, r)
+		}
+
+		hasReplaced = hasReplaced || len(replaced[i]) > 0
+	}
+
+	// The totals of the inputs include the replaced projects, so they're summed
+	// from the projects that are kept instead.
+	if hasReplaced {
+		totalHourlyCost, totalMonthlyCost, pastTotalHourlyCost, pastTotalMonthlyCost, diffTotalHourlyCost, diffTotalMonthlyCost = sumProjectTotals(projects)
+
+		summaries = make([]*Summary, 0, len(projects))
+		for _, p := range projects {
+			summaries = append(summaries, p.Summary)
+		}
+	}
+
 	combined.Version = outputVersion
 	combined.Currency = currency
 	combined.Projects = projects
@@ -165,6 +326,14 @@ func Combine(inputs []ReportInput) (Root, error) {
 	combined.DiffTotalMonthlyCost = diffTotalMonthlyCost
 	combined.TimeGenerated = time.Now()
 	combined.Summary = MergeSummaries(summaries)
+	combined.PricingIssues = pricingIssues
+	combined.GuardrailViolations = guardrailViolations
+	combined.TagPolicies = tagPolicies
+	combined.Exemptions = resourceExemptions
+	combined.Waivers = waivers
+	combined.Recommendations = SortRecommendations(recommendations)
+	combined.Rightsizing = SortRightsizing(rightsizing)
+	combined.IdleResources = SortIdleResources(idleResources)
 
 	return combined, nil
 }
@@ -186,9 +355,67 @@ func checkCurrency(inputCurrency, fileCurrency string) (string, error) {
 	return inputCurrency, nil
 }
 
-func checkOutputVersion(v string) bool {
+func checkVersion(inputVersion, fileVersion string) (string, error) {
+	if inputVersion == "" {
+		return fileVersion, nil
+	}
+
+	if semver.MajorMinor(semverPrefix(inputVersion)) != semver.MajorMinor(semverPrefix(fileVersion)) {
+		return "", fmt.Errorf("Invalid Infracost JSON file version mismatch.  Can't combine %s and %s", inputVersion, fileVersion)
+	}
+
+	return inputVersion, nil
+}
+
+// projectKey identifies the projects that are merged by MergeStrategyReplace,
+// falling back to the name for projects without metadata.
+func projectKey(p Project) string {
+	if p.Metadata == nil {
+		return "name:" + p.Name
+	}
+
+	return p.Metadata.Path + "|" + p.Metadata.TerraformWorkspace
+}
+
+func sumProjectTotals(projects []Project) (totalHourlyCost, totalMonthlyCost, pastTotalHourlyCost, pastTotalMonthlyCost, diffTotalHourlyCost, diffTotalMonthlyCost *decimal.Decimal) {
+	add := func(total *decimal.Decimal, v *decimal.Decimal) *decimal.Decimal {
+		if v == nil {
+			return total
+		}
+
+		if total == nil {
+			total = decimalPtr(decimal.Zero)
+		}
+
+		return decimalPtr(total.Add(*v))
+	}
+
+	for _, p := range projects {
+		if p.Breakdown != nil {
+			totalHourlyCost = add(totalHourlyCost, p.Breakdown.TotalHourlyCost)
+			totalMonthlyCost = add(totalMonthlyCost, p.Breakdown.TotalMonthlyCost)
+		}
+		if p.PastBreakdown != nil {
+			pastTotalHourlyCost = add(pastTotalHourlyCost, p.PastBreakdown.TotalHourlyCost)
+			pastTotalMonthlyCost = add(pastTotalMonthlyCost, p.PastBreakdown.TotalMonthlyCost)
+		}
+		if p.Diff != nil {
+			diffTotalHourlyCost = add(diffTotalHourlyCost, p.Diff.TotalHourlyCost)
+			diffTotalMonthlyCost = add(diffTotalMonthlyCost, p.Diff.TotalMonthlyCost)
+		}
+	}
+
+	return
+}
+
+func semverPrefix(v string) string {
 	if !strings.HasPrefix(v, "v") {
-		v = "v" + v
+		return "v" + v
 	}
+	return v
+}
+
+func checkOutputVersion(v string) bool {
+	v = semverPrefix(v)
 	return semver.Compare(v, "v"+minOutputVersion) >= 0 && semver.Compare(v, "v"+maxOutputVersion) <= 0
 }
@@ -0,0 +1,91 @@
+package output
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/infracost/infracost/internal/currency"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+func combineTestInput(path string, workspace string, monthlyCost int64, issue string) ReportInput {
+	cost := decimalPtr(decimal.NewFromInt(monthlyCost))
+	name := path + "/" + workspace
+
+	root := Root{
+		Version:          outputVersion,
+		Currency:         "USD",
+		TotalMonthlyCost: cost,
+		Projects: []Project{
+			{
+				Name:      name,
+				Metadata:  &schema.ProjectMetadata{Path: path, TerraformWorkspace: workspace},
+				Breakdown: &Breakdown{TotalMonthlyCost: cost},
+			},
+		},
+	}
+
+	if issue != "" {
+		root.PricingIssues = []PricingIssue{{ProjectName: name, ResourceName: issue}}
+	}
+
+	return ReportInput{Root: root}
+}
+
+func TestCombineSum(t *testing.T) {
+	inputs := []ReportInput{
+		combineTestInput("prod", "default", 100, ""),
+		combineTestInput("prod", "default", 150, ""),
+	}
+
+	combined, err := Combine(inputs, CombineOptions{})
+	require.NoError(t, err)
+	assert.Len(t, combined.Projects, 2)
+	assert.Equal(t, "250", combined.TotalMonthlyCost.String())
+}
+
+func TestCombineReplace(t *testing.T) {
+	inputs := []ReportInput{
+		combineTestInput("prod", "default", 100, "aws_instance.old"),
+		combineTestInput("dev", "default", 10, ""),
+		combineTestInput("prod", "default", 150, "aws_instance.new"),
+		combineTestInput("prod", "staging", 50, ""),
+	}
+
+	combined, err := Combine(inputs, CombineOptions{MergeStrategy: MergeStrategyReplace})
+	require.NoError(t, err)
+
+	var names []string
+	for _, p := range combined.Projects {
+		names = append(names, p.Name)
+	}
+	assert.Equal(t, []string{"prod/default", "dev/default", "prod/staging"}, names)
+	assert.Equal(t, "150", combined.Projects[0].Breakdown.TotalMonthlyCost.String())
+	assert.Equal(t, "210", combined.TotalMonthlyCost.String())
+
+	require.Len(t, combined.PricingIssues, 1)
+	assert.Equal(t, "aws_instance.new", combined.PricingIssues[0].ResourceName)
+}
+
+func TestCombineConflicts(t *testing.T) {
+	a := combineTestInput("prod", "default", 100, "")
+	b := combineTestInput("dev", "default", 10, "")
+
+	b.Root.Currency = "EUR"
+	_, err := Combine([]ReportInput{a, b}, CombineOptions{})
+	assert.EqualError(t, err, "Invalid Infracost JSON file currency mismatch.  Can't combine USD and EUR")
+
+	b.Root.Currency = "USD"
+	b.Root.Version = "0.1"
+	_, err = Combine([]ReportInput{a, b}, CombineOptions{})
+	assert.EqualError(t, err, "Invalid Infracost JSON file version mismatch.  Can't combine 0.2 and 0.1")
+
+	b.Root.Version = outputVersion
+	a.Root.ExchangeRate = &currency.Rate{From: "USD", To: "USD", Rate: decimal.NewFromInt(1)}
+	b.Root.ExchangeRate = &currency.Rate{From: "USD", To: "USD", Rate: decimal.RequireFromString("1.1")}
+	_, err = Combine([]ReportInput{a, b}, CombineOptions{})
+	assert.EqualError(t, err, "Invalid Infracost JSON file exchange rate mismatch.  Can't combine rates 1 and 1.1")
+}
@@ -0,0 +1,366 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/jedib0t/go-pretty/v6/text"
+	"github.com/shopspring/decimal"
+
+	"github.com/infracost/infracost/internal/schema"
+	"github.com/infracost/infracost/internal/ui"
+)
+
+// commitmentTerms are the terms recommended for each commitment.
+var commitmentTerms = []string{"1_year", "3_year"}
+
+// commitmentOffers are the commitments that can be recommended for each kind of
+// on-demand usage. The discount rates are the typical no upfront discounts over
+// the on-demand rates, they're estimates since actual discounts vary by instance
+// type and region.
+var commitmentOffers = map[string][]commitmentOffer{
+	"aws/compute": {
+		{Name: "Compute Savings Plan", Type: schema.CommitmentTypeSavingsPlan, Rates: map[string]float64{"1_year": 0.27, "3_year": 0.50}},
+		{Name: "EC2 Instance Savings Plan", Type: schema.CommitmentTypeSavingsPlan, ByFamily: true, Rates: map[string]float64{"1_year": 0.37, "3_year": 0.58}},
+	},
+	"aws/database": {
+		{Name: "RDS Reserved Instance", Type: schema.CommitmentTypeReservedInstance, ByFamily: true, Rates: map[string]float64{"1_year": 0.31, "3_year": 0.52}},
+	},
+	"gcp/compute": {
+		{Name: "Committed Use Discount", Type: schema.CommitmentTypeCommittedUse, ByFamily: true, Rates: map[string]float64{"1_year": 0.37, "3_year": 0.55}},
+	},
+	"azure/compute": {
+		{Name: "Reserved VM Instance", Type: schema.CommitmentTypeReservedInstance, ByFamily: true, Rates: map[string]float64{"1_year": 0.40, "3_year": 0.60}},
+	},
+}
+
+var commitmentKinds = []string{"aws/compute", "aws/database", "gcp/compute", "azure/compute"}
+
+var (
+	costComponentDetailsRegex = regexp.MustCompile(`\(([^()]*)\)$`)
+	azureInstanceTypeRegex    = regexp.MustCompile(`(?i)^(standard_[a-z]+)\d+(-\d+)?([a-z]*)(_v\d+)?$`)
+)
+
+type commitmentOffer struct {
+	Name     string
+	Type     string
+	ByFamily bool
+	Rates    map[string]float64
+}
+
+// CommitmentOptions are the options used to recommend commitments.
+type CommitmentOptions struct {
+	// CoveragePercent is the percentage of the eligible on-demand usage to cover.
+	CoveragePercent float64
+}
+
+// CommitmentsReport is the list of commitments recommended for the on-demand
+// instance usage of a breakdown.
+type CommitmentsReport struct {
+	Currency            string                      `json:"currency"`
+	CoveragePercent     float64                     `json:"coveragePercent"`
+	EligibleMonthlyCost decimal.Decimal             `json:"eligibleMonthlyCost"`
+	Recommendations     []*CommitmentRecommendation `json:"recommendations"`
+}
+
+// CommitmentRecommendation is a commitment purchase and its estimated savings.
+type CommitmentRecommendation struct {
+	Name                    string          `json:"name"`
+	Type                    string          `json:"type"`
+	Vendor                  string          `json:"vendor"`
+	InstanceFamily          string          `json:"instanceFamily,omitempty"`
+	Term                    string          `json:"term"`
+	DiscountPercent         decimal.Decimal `json:"discountPercent"`
+	HourlyCommitment        decimal.Decimal `json:"hourlyCommitment"`
+	CoveredMonthlyCost      decimal.Decimal `json:"coveredMonthlyCost"`
+	EstimatedMonthlyCost    decimal.Decimal `json:"estimatedMonthlyCost"`
+	EstimatedMonthlySavings decimal.Decimal `json:"estimatedMonthlySavings"`
+	Resources               []string        `json:"resources"`
+}
+
+type onDemandUsage struct {
+	kind        string
+	family      string
+	monthlyCost decimal.Decimal
+	resources   map[string]struct{}
+}
+
+// RecommendCommitments analyzes the on-demand instance usage of the breakdown of
+// each project and returns the commitments that would cover it with their
+// estimated savings, largest savings first.
+func RecommendCommitments(out Root, opts CommitmentOptions) *CommitmentsReport {
+	report := &CommitmentsReport{
+		Currency:        out.Currency,
+		CoveragePercent: opts.CoveragePercent,
+		Recommendations: []*CommitmentRecommendation{},
+	}
+
+	usages := make(map[string]*onDemandUsage)
+
+	for _, project := range out.Projects {
+		if project.Breakdown == nil {
+			continue
+		}
+
+		for _, r := range project.Breakdown.Resources {
+			addOnDemandUsage(usages, r.Name, r)
+		}
+	}
+
+	coverage := decimal.NewFromFloat(opts.CoveragePercent).Div(decimal.NewFromInt(100))
+
+	keys := make([]string, 0, len(usages))
+	for k := range usages {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, kind := range commitmentKinds {
+		var kindCost decimal.Decimal
+		kindResources := make(map[string]struct{})
+
+		for _, k := range keys {
+			u := usages[k]
+			if u.kind != kind {
+				continue
+			}
+
+			kindCost = kindCost.Add(u.monthlyCost)
+			for name := range u.resources {
+				kindResources[name] = struct{}{}
+			}
+		}
+
+		report.EligibleMonthlyCost = report.EligibleMonthlyCost.Add(kindCost)
+
+		for _, offer := range commitmentOffers[kind] {
+			if !offer.ByFamily {
+				if kindCost.IsPositive() {
+					report.Recommendations = append(report.Recommendations, offerRecommendations(offer, kind, "", kindCost, coverage, kindResources)...)
+				}
+				continue
+			}
+
+			for _, k := range keys {
+				u := usages[k]
+				if u.kind == kind && u.monthlyCost.IsPositive() {
+					report.Recommendations = append(report.Recommendations, offerRecommendations(offer, kind, u.family, u.monthlyCost, coverage, u.resources)...)
+				}
+			}
+		}
+	}
+
+	sort.SliceStable(report.Recommendations, func(i, j int) bool {
+		return report.Recommendations[i].EstimatedMonthlySavings.GreaterThan(report.Recommendations[j].EstimatedMonthlySavings)
+	})
+
+	return report
+}
+
+func offerRecommendations(offer commitmentOffer, kind, family string, monthlyCost, coverage decimal.Decimal, resources map[string]struct{}) []*CommitmentRecommendation {
+	names := make([]string, 0, len(resources))
+	for name := range resources {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	covered := monthlyCost.Mul(coverage)
+
+	recs := make([]*CommitmentRecommendation, 0, len(commitmentTerms))
+	for _, term := range commitmentTerms {
+		rate := decimal.NewFromFloat(offer.Rates[term])
+		cost := covered.Mul(decimal.NewFromInt(1).Sub(rate))
+
+		recs = append(recs, &CommitmentRecommendation{
+			Name:                    offer.Name,
+			Type:                    offer.Type,
+			Vendor:                  strings.Split(kind, "/")[0],
+			InstanceFamily:          family,
+			Term:                    term,
+			DiscountPercent:         rate.Mul(decimal.NewFromInt(100)),
+			HourlyCommitment:        cost.Div(schema.HourToMonthUnitMultiplier).Round(4),
+			CoveredMonthlyCost:      covered.Round(2),
+			EstimatedMonthlyCost:    cost.Round(2),
+			EstimatedMonthlySavings: covered.Sub(cost).Round(2),
+			Resources:               names,
+		})
+	}
+
+	return recs
+}
+
+func addOnDemandUsage(usages map[string]*onDemandUsage, resourceName string, r Resource) {
+	vendor := resourceVendor(resourceName)
+
+	for _, c := range r.CostComponents {
+		if c.MonthlyCost == nil || !c.MonthlyCost.IsPositive() {
+			continue
+		}
+
+		kind, instanceType := onDemandInstanceType(vendor, c.Name)
+		if kind == "" {
+			continue
+		}
+
+		family := instanceFamily(vendor, instanceType)
+		key := kind + "/" + family
+
+		u, ok := usages[key]
+		if !ok {
+			u = &onDemandUsage{kind: kind, family: family, resources: make(map[string]struct{})}
+			usages[key] = u
+		}
+
+		u.monthlyCost = u.monthlyCost.Add(*c.MonthlyCost)
+		u.resources[resourceName] = struct{}{}
+	}
+
+	for _, s := range r.SubResources {
+		addOnDemandUsage(usages, resourceName, s)
+	}
+}
+
+func resourceVendor(resourceName string) string {
+	// Resources in modules are prefixed with the module address
+	parts := strings.Split(resourceName, ".")
+	for i, p := range parts {
+		if p == "module" || (i > 0 && parts[i-1] == "module") {
+			continue
+		}
+
+		switch {
+		case strings.HasPrefix(p, "aws_"):
+			return "aws"
+		case strings.HasPrefix(p, "google_"):
+			return "gcp"
+		case strings.HasPrefix(p, "azurerm_"):
+			return "azure"
+		}
+
+		break
+	}
+
+	return ""
+}
+
+// onDemandInstanceType returns the kind of usage and the instance type of cost
+// components that are on-demand instance usage, e.g. Instance usage (Linux/UNIX,
+// on-demand, m5.large) or Database instance (on-demand, Single-AZ, db.t3.large).
+func onDemandInstanceType(vendor, name string) (string, string) {
+	if vendor == "" {
+		return "", ""
+	}
+
+	m := costComponentDetailsRegex.FindStringSubmatch(name)
+	if m == nil {
+		return "", ""
+	}
+
+	details := strings.Split(m[1], ", ")
+	if !contains(details, "on-demand") && !contains(details, "pay as you go") {
+		return "", ""
+	}
+
+	kind := vendor + "/compute"
+	if strings.HasPrefix(name, "Database instance") {
+		kind = vendor + "/database"
+	} else if !strings.HasPrefix(name, "Instance usage") {
+		return "", ""
+	}
+
+	if _, ok := commitmentOffers[kind]; !ok {
+		return "", ""
+	}
+
+	return kind, details[len(details)-1]
+}
+
+// instanceFamily returns the instance family of the instance type in the format
+// used by the instance_family of commitments, e.g. m5 for m5.large, n2 for
+// n2-standard-4 and Standard_D*s_v3 for Standard_D2s_v3.
+func instanceFamily(vendor, instanceType string) string {
+	switch vendor {
+	case "aws":
+		if i := strings.LastIndex(instanceType, "."); i > 0 {
+			return instanceType[:i]
+		}
+	case "gcp":
+		return strings.Split(instanceType, "-")[0]
+	case "azure":
+		m := azureInstanceTypeRegex.FindStringSubmatch(instanceType)
+		if m != nil {
+			return m[1] + "*" + m[3] + m[4]
+		}
+	}
+
+	return instanceType
+}
+
+// ToCommitmentsJSON outputs the commitments report as JSON.
+func ToCommitmentsJSON(report *CommitmentsReport) ([]byte, error) {
+	return json.MarshalIndent(report, "", "  ")
+}
+
+// ToCommitmentsTable outputs the commitments report as a table.
+func ToCommitmentsTable(report *CommitmentsReport) ([]byte, error) {
+	currency := report.Currency
+
+	if len(report.Recommendations) == 0 {
+		return []byte("No on-demand instance usage found that can be covered by commitments."), nil
+	}
+
+	t := table.NewWriter()
+	t.Style().Options.DrawBorder = false
+	t.Style().Options.SeparateColumns = false
+	t.Style().Options.SeparateRows = false
+	t.Style().Options.SeparateHeader = false
+	t.Style().Format.Header = text.FormatDefault
+
+	t.AppendHeader(table.Row{
+		ui.UnderlineString("Commitment"),
+		ui.UnderlineString("Family"),
+		ui.UnderlineString("Term"),
+		ui.UnderlineString(formatTitleWithCurrency("Hourly commitment", currency)),
+		ui.UnderlineString(formatTitleWithCurrency("On-demand cost", currency)),
+		ui.UnderlineString(formatTitleWithCurrency("Monthly savings", currency)),
+	})
+
+	t.SetColumnConfigs([]table.ColumnConfig{
+		{Number: 1, Align: text.AlignLeft, AlignHeader: text.AlignLeft},
+		{Number: 2, Align: text.AlignLeft, AlignHeader: text.AlignLeft},
+		{Number: 3, Align: text.AlignLeft, AlignHeader: text.AlignLeft},
+		{Number: 4, Align: text.AlignRight, AlignHeader: text.AlignRight},
+		{Number: 5, Align: text.AlignRight, AlignHeader: text.AlignRight},
+		{Number: 6, Align: text.AlignRight, AlignHeader: text.AlignRight},
+	})
+
+	for _, rec := range report.Recommendations {
+		family := rec.InstanceFamily
+		if family == "" {
+			family = "any"
+		}
+
+		t.AppendRow(table.Row{
+			rec.Name,
+			family,
+			strings.Replace(rec.Term, "_", " ", 1),
+			formatPrice(currency, rec.HourlyCommitment),
+			formatCost2DP(currency, &rec.CoveredMonthlyCost),
+			fmt.Sprintf("%s (%s%%)", formatCost2DP(currency, &rec.EstimatedMonthlySavings), rec.DiscountPercent.String()),
+		})
+	}
+
+	s := fmt.Sprintf("%s %s of %s eligible on-demand instance usage per month\n\n",
+		ui.BoldString("Covering"),
+		decimal.NewFromFloat(report.CoveragePercent).String()+"%",
+		formatCost2DP(currency, &report.EligibleMonthlyCost),
+	)
+	s += t.Render() + "\n\n"
+	s += "Savings are estimated using typical no upfront discounts, the options for the same usage are alternatives."
+
+	return []byte(s), nil
+}
@@ -0,0 +1,56 @@
+package output
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInstanceFamily(t *testing.T) {
+	assert.Equal(t, "m5", instanceFamily("aws", "m5.large"))
+	assert.Equal(t, "db.r5", instanceFamily("aws", "db.r5.xlarge"))
+	assert.Equal(t, "n2", instanceFamily("gcp", "n2-standard-4"))
+	assert.Equal(t, "Standard_D*s_v3", instanceFamily("azure", "Standard_D2s_v3"))
+	assert.Equal(t, "Standard_B*ms", instanceFamily("azure", "Standard_B2ms"))
+}
+
+func TestRecommendCommitments(t *testing.T) {
+	out := Root{
+		Currency: "USD",
+		Projects: []Project{
+			{
+				Breakdown: &Breakdown{
+					Resources: []Resource{
+						{
+							Name: "module.db.aws_db_instance.db",
+							CostComponents: []CostComponent{
+								{Name: "Database instance (on-demand, Single-AZ, db.t3.large)", MonthlyCost: decimalPtr(decimal.NewFromInt(100))},
+							},
+						},
+						{
+							Name: "google_compute_instance.spot",
+							CostComponents: []CostComponent{
+								{Name: "Instance usage (Linux/UNIX, preemptible, n2-standard-4)", MonthlyCost: decimalPtr(decimal.NewFromInt(50))},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	report := RecommendCommitments(out, CommitmentOptions{CoveragePercent: 50})
+
+	assert.Equal(t, "100", report.EligibleMonthlyCost.String())
+	require.Len(t, report.Recommendations, 2)
+
+	rec := report.Recommendations[0]
+	assert.Equal(t, "RDS Reserved Instance", rec.Name)
+	assert.Equal(t, "db.t3", rec.InstanceFamily)
+	assert.Equal(t, "3_year", rec.Term)
+	assert.Equal(t, "50", rec.CoveredMonthlyCost.String())
+	assert.Equal(t, "26", rec.EstimatedMonthlySavings.String())
+	assert.Equal(t, []string{"module.db.aws_db_instance.db"}, rec.Resources)
+}
@@ -0,0 +1,186 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"golang.org/x/mod/semver"
+
+	"github.com/infracost/infracost/internal/schema"
+)
+
+// legacyRoot has the fields of older versions of the JSON output that were
+// renamed or moved, e.g. projects were identified by their path before they had
+// a name and metadata.
+type legacyRoot struct {
+	Projects []struct {
+		Path string `json:"path"`
+	} `json:"projects"`
+}
+
+// LoadCompatible loads the JSON output of any version of the CLI, normalizing
+// the differences from the current version so it can be compared with it. It
+// returns notes on what was normalized.
+func LoadCompatible(data []byte) (Root, []string, error) {
+	out, err := Load(data)
+	if err != nil {
+		return out, nil, err
+	}
+
+	var legacy legacyRoot
+	err = json.Unmarshal(data, &legacy)
+	if err != nil {
+		return out, nil, err
+	}
+
+	var notes []string
+
+	v := semverPrefix(out.Version)
+	switch {
+	case out.Version == "":
+		notes = append(notes, "the file has no version, it's read as the current version")
+	case semver.Compare(v, "v"+minOutputVersion) < 0:
+		notes = append(notes, fmt.Sprintf("the file is version %s, it was converted to version %s", out.Version, outputVersion))
+	case semver.Compare(v, "v"+maxOutputVersion) > 0:
+		notes = append(notes, fmt.Sprintf("the file is version %s, newer than the supported version %s, so new fields are ignored", out.Version, maxOutputVersion))
+	}
+
+	for i := range out.Projects {
+		p := &out.Projects[i]
+		if i >= len(legacy.Projects) || legacy.Projects[i].Path == "" {
+			continue
+		}
+
+		path := legacy.Projects[i].Path
+		if p.Name == "" {
+			p.Name = path
+		}
+		if p.Metadata == nil {
+			p.Metadata = &schema.ProjectMetadata{Path: path}
+		} else if p.Metadata.Path == "" {
+			p.Metadata.Path = path
+		}
+	}
+
+	if out.Currency == "" {
+		out.Currency = "USD"
+	}
+
+	if out.TotalMonthlyCost == nil && len(out.Projects) > 0 {
+		out.TotalHourlyCost, out.TotalMonthlyCost, out.PastTotalHourlyCost, out.PastTotalMonthlyCost, out.DiffTotalHourlyCost, out.DiffTotalMonthlyCost = sumProjectTotals(out.Projects)
+		notes = append(notes, "the file has no totals, they were summed from its projects")
+	}
+
+	out.Version = outputVersion
+
+	return out, notes, nil
+}
+
+// The changes of a SupportChange.
+const (
+	SupportChangeSupported   = "supported"
+	SupportChangeUnsupported = "unsupported"
+)
+
+// SupportChange is a resource that's estimated in one output but not the other,
+// since its type became supported or unsupported between CLI versions.
+type SupportChange struct {
+	Project      string
+	ResourceType string
+	Change       string
+	// Resources are the names of the resources of the type in the output they're
+	// estimated in.
+	Resources []string
+}
+
+// SupportChanges returns the resource types whose support changed from past to
+// current, for the projects in both. The costs of their resources only appear
+// on one side of a diff, so the diff of those resources isn't a cost change.
+func SupportChanges(past Root, current Root) []SupportChange {
+	var changes []SupportChange
+
+	for _, p := range current.Projects {
+		pastProject := findProjectByName(past, p.Name)
+		if pastProject == nil {
+			continue
+		}
+
+		changes = append(changes, supportChanges(p.Name, unsupportedCounts(pastProject), p.Breakdown, SupportChangeSupported)...)
+		changes = append(changes, supportChanges(p.Name, unsupportedCounts(&p), pastProject.Breakdown, SupportChangeUnsupported)...)
+	}
+
+	return changes
+}
+
+// supportChanges returns the resource types of the breakdown that are in the
+// unsupported counts of the other output.
+func supportChanges(project string, unsupported map[string]int, b *Breakdown, change string) []SupportChange {
+	if b == nil || len(unsupported) == 0 {
+		return nil
+	}
+
+	byType := map[string][]string{}
+	for _, r := range b.Resources {
+		t := ResourceType(r.Name)
+		if _, ok := unsupported[t]; ok {
+			byType[t] = append(byType[t], r.Name)
+		}
+	}
+
+	types := make([]string, 0, len(byType))
+	for t := range byType {
+		types = append(types, t)
+	}
+	sort.Strings(types)
+
+	changes := make([]SupportChange, 0, len(types))
+	for _, t := range types {
+		changes = append(changes, SupportChange{
+			Project:      project,
+			ResourceType: t,
+			Change:       change,
+			Resources:    byType[t],
+		})
+	}
+
+	return changes
+}
+
+func unsupportedCounts(p *Project) map[string]int {
+	if p.Summary == nil || p.Summary.UnsupportedResourceCounts == nil {
+		return nil
+	}
+
+	return *p.Summary.UnsupportedResourceCounts
+}
+
+func findProjectByName(r Root, name string) *Project {
+	for i := range r.Projects {
+		if r.Projects[i].Name == name {
+			return &r.Projects[i]
+		}
+	}
+
+	return nil
+}
+
+// SupportChangesReport returns a summary of the support changes, one line per
+// resource type.
+func SupportChangesReport(changes []SupportChange) string {
+	if len(changes) == 0 {
+		return ""
+	}
+
+	s := "Resource types whose support changed between the CLI versions of the files, their resources only have costs on one side of the diff:\n"
+	for _, c := range changes {
+		verb := "now supported"
+		if c.Change == SupportChangeUnsupported {
+			verb = "no longer supported"
+		}
+
+		s += fmt.Sprintf("  %s: %s is %s (%d resources)\n", c.Project, c.ResourceType, verb, len(c.Resources))
+	}
+
+	return s
+}
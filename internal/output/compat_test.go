@@ -0,0 +1,80 @@
+package output
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadCompatible(t *testing.T) {
+	data := []byte(`{
+		"version": "0.1",
+		"projects": [
+			{
+				"path": "environments/prod",
+				"breakdown": {
+					"resources": [{"name": "aws_instance.web", "monthlyCost": "30"}],
+					"totalHourlyCost": "0.0411",
+					"totalMonthlyCost": "30"
+				}
+			}
+		]
+	}`)
+
+	r, notes, err := LoadCompatible(data)
+	require.NoError(t, err)
+
+	assert.Equal(t, outputVersion, r.Version)
+	assert.Equal(t, "USD", r.Currency)
+	assert.Equal(t, "environments/prod", r.Projects[0].Name)
+	assert.Equal(t, "environments/prod", r.Projects[0].Metadata.Path)
+	assert.Equal(t, "30", r.TotalMonthlyCost.String())
+	assert.Equal(t, []string{
+		"the file is version 0.1, it was converted to version 0.2",
+		"the file has no totals, they were summed from its projects",
+	}, notes)
+
+	_, notes, err = LoadCompatible([]byte(`{"version": "0.3", "currency": "EUR", "totalMonthlyCost": "0", "projects": []}`))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"the file is version 0.3, newer than the supported version 0.2, so new fields are ignored"}, notes)
+}
+
+func TestSupportChanges(t *testing.T) {
+	past := Root{
+		Projects: []Project{
+			{
+				Name:    "prod",
+				Summary: &Summary{UnsupportedResourceCounts: &map[string]int{"aws_mq_broker": 1}},
+				Breakdown: &Breakdown{Resources: []Resource{
+					{Name: "aws_instance.web"},
+					{Name: "aws_legacy_thing.a"},
+				}},
+			},
+		},
+	}
+
+	current := Root{
+		Projects: []Project{
+			{
+				Name:    "prod",
+				Summary: &Summary{UnsupportedResourceCounts: &map[string]int{"aws_legacy_thing": 1}},
+				Breakdown: &Breakdown{Resources: []Resource{
+					{Name: "aws_instance.web"},
+					{Name: "module.mq.aws_mq_broker.this"},
+				}},
+			},
+		},
+	}
+
+	changes := SupportChanges(past, current)
+	assert.Equal(t, []SupportChange{
+		{Project: "prod", ResourceType: "aws_mq_broker", Change: SupportChangeSupported, Resources: []string{"module.mq.aws_mq_broker.this"}},
+		{Project: "prod", ResourceType: "aws_legacy_thing", Change: SupportChangeUnsupported, Resources: []string{"aws_legacy_thing.a"}},
+	}, changes)
+
+	assert.Equal(t, `Resource types whose support changed between the CLI versions of the files, their resources only have costs on one side of the diff:
+  prod: aws_mq_broker is now supported (1 resources)
+  prod: aws_legacy_thing is no longer supported (1 resources)
+`, SupportChangesReport(changes))
+}
@@ -0,0 +1,143 @@
+package output
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/jedib0t/go-pretty/v6/text"
+	"github.com/shopspring/decimal"
+
+	"github.com/infracost/infracost/internal/ui"
+)
+
+// UntaggedGroup is the name of the cost group of the resources that don't have
+// the tag the costs are grouped by.
+const UntaggedGroup = "(untagged)"
+
+const groupByTagPrefix = "tag:"
+
+// CostGroup is the cost of the resources that have the same value of the tag
+// the costs are grouped by, e.g. the resources of a team.
+type CostGroup struct {
+	Name             string           `json:"name"`
+	Resources        int              `json:"resources"`
+	TotalHourlyCost  *decimal.Decimal `json:"totalHourlyCost"`
+	TotalMonthlyCost *decimal.Decimal `json:"totalMonthlyCost"`
+}
+
+// ParseGroupBy returns the tag key of a group by expression of the form tag:KEY.
+func ParseGroupBy(groupBy string) (string, error) {
+	if !strings.HasPrefix(groupBy, groupByTagPrefix) || strings.TrimPrefix(groupBy, groupByTagPrefix) == "" {
+		return "", fmt.Errorf("Invalid group by %q, costs can only be grouped by a tag, e.g. tag:team", groupBy)
+	}
+
+	return strings.TrimPrefix(groupBy, groupByTagPrefix), nil
+}
+
+// AddCostGroups groups the costs of the resources of the output by the value of
+// the tag of the group by expression, see ParseGroupBy. The costs are in the
+// currency of the report. Groups are sorted by their monthly cost, with the
+// untagged resources last.
+func AddCostGroups(r *Root, groupBy string) error {
+	key, err := ParseGroupBy(groupBy)
+	if err != nil {
+		return err
+	}
+
+	groups := map[string]*CostGroup{}
+
+	for _, p := range r.Projects {
+		if p.Breakdown == nil {
+			continue
+		}
+
+		rate := decimal.NewFromInt(1)
+		if p.ReportCurrencyTotals != nil {
+			rate = p.ReportCurrencyTotals.Rate
+		}
+
+		for _, res := range p.Breakdown.Resources {
+			name, ok := res.Tags[key]
+			if !ok || name == "" {
+				name = UntaggedGroup
+			}
+
+			g, ok := groups[name]
+			if !ok {
+				g = &CostGroup{Name: name}
+				groups[name] = g
+			}
+
+			g.Resources++
+			g.TotalHourlyCost = addCost(g.TotalHourlyCost, convertCost(res.HourlyCost, rate))
+			g.TotalMonthlyCost = addCost(g.TotalMonthlyCost, convertCost(res.MonthlyCost, rate))
+		}
+	}
+
+	r.GroupBy = groupBy
+	r.CostGroups = make([]CostGroup, 0, len(groups))
+	for _, g := range groups {
+		r.CostGroups = append(r.CostGroups, *g)
+	}
+
+	sort.Slice(r.CostGroups, func(i, j int) bool {
+		a, b := r.CostGroups[i], r.CostGroups[j]
+		if (a.Name == UntaggedGroup) != (b.Name == UntaggedGroup) {
+			return b.Name == UntaggedGroup
+		}
+
+		ac, bc := decimal.Zero, decimal.Zero
+		if a.TotalMonthlyCost != nil {
+			ac = *a.TotalMonthlyCost
+		}
+		if b.TotalMonthlyCost != nil {
+			bc = *b.TotalMonthlyCost
+		}
+
+		if !ac.Equal(bc) {
+			return ac.GreaterThan(bc)
+		}
+
+		return a.Name < b.Name
+	})
+
+	return nil
+}
+
+// costGroupsToTable shows the cost of each group so the costs can be allocated
+// to the teams or services of the tag, period is the title of the time period of
+// the costs, e.g. Monthly.
+func costGroupsToTable(out Root, period string) string {
+	if len(out.CostGroups) == 0 {
+		return ""
+	}
+
+	t := table.NewWriter()
+	t.Style().Options.DrawBorder = false
+	t.Style().Options.SeparateColumns = false
+	t.Style().Options.SeparateRows = false
+	t.Style().Options.SeparateHeader = false
+	t.Style().Format.Header = text.FormatDefault
+
+	t.AppendHeader(table.Row{
+		ui.UnderlineString(strings.TrimPrefix(out.GroupBy, groupByTagPrefix)),
+		ui.UnderlineString("Resources"),
+		ui.UnderlineString(formatTitleWithCurrency(period+" Cost", out.Currency)),
+	})
+	t.SetColumnConfigs([]table.ColumnConfig{
+		{Number: 1, Align: text.AlignLeft},
+		{Number: 2, Align: text.AlignRight, AlignHeader: text.AlignRight},
+		{Number: 3, Align: text.AlignRight, AlignHeader: text.AlignRight},
+	})
+
+	for _, g := range out.CostGroups {
+		t.AppendRow(table.Row{g.Name, g.Resources, formatCost2DP(out.Currency, g.TotalMonthlyCost)})
+	}
+
+	return fmt.Sprintf("──────────────────────────────────\n%s\n\n%s",
+		ui.BoldString(fmt.Sprintf("Costs by tag %s:", strings.TrimPrefix(out.GroupBy, groupByTagPrefix))),
+		t.Render(),
+	)
+}
@@ -0,0 +1,68 @@
+package output
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseGroupBy(t *testing.T) {
+	key, err := ParseGroupBy("tag:team")
+	require.NoError(t, err)
+	assert.Equal(t, "team", key)
+
+	for _, groupBy := range []string{"team", "tag:", "label:team"} {
+		_, err := ParseGroupBy(groupBy)
+		assert.Error(t, err, groupBy)
+	}
+}
+
+func TestAddCostGroups(t *testing.T) {
+	r := Root{
+		Currency: "USD",
+		Projects: []Project{
+			{
+				Name: "infracost/infracost/examples",
+				Breakdown: &Breakdown{
+					Resources: []Resource{
+						{Name: "aws_instance.web", Tags: map[string]string{"team": "web"}, MonthlyCost: decimalPtr(decimal.NewFromInt(50))},
+						{Name: "aws_instance.api", Tags: map[string]string{"team": "api"}, MonthlyCost: decimalPtr(decimal.NewFromInt(80))},
+						{Name: "aws_lambda_function.web", Tags: map[string]string{"team": "web"}, MonthlyCost: decimalPtr(decimal.NewFromInt(40))},
+						{Name: "aws_nat_gateway.this", MonthlyCost: decimalPtr(decimal.NewFromInt(32))},
+					},
+				},
+			},
+			{
+				Name:                 "infracost/infracost/examples/eu",
+				Currency:             "EUR",
+				ReportCurrencyTotals: &ReportCurrencyTotals{Currency: "USD", Rate: decimal.NewFromInt(2)},
+				Breakdown: &Breakdown{
+					Resources: []Resource{
+						{Name: "aws_instance.api", Tags: map[string]string{"team": "api"}, MonthlyCost: decimalPtr(decimal.NewFromInt(10))},
+					},
+				},
+			},
+		},
+	}
+
+	require.NoError(t, AddCostGroups(&r, "tag:team"))
+	assert.Equal(t, "tag:team", r.GroupBy)
+	require.Len(t, r.CostGroups, 3)
+
+	assert.Equal(t, "api", r.CostGroups[0].Name)
+	assert.Equal(t, 2, r.CostGroups[0].Resources)
+	assert.Equal(t, "100", r.CostGroups[0].TotalMonthlyCost.String())
+
+	assert.Equal(t, "web", r.CostGroups[1].Name)
+	assert.Equal(t, "90", r.CostGroups[1].TotalMonthlyCost.String())
+
+	assert.Equal(t, UntaggedGroup, r.CostGroups[2].Name)
+	assert.Equal(t, "32", r.CostGroups[2].TotalMonthlyCost.String())
+
+	s := costGroupsToTable(r, "Monthly")
+	assert.Contains(t, s, "Costs by tag team:")
+	assert.Contains(t, s, "100.00")
+	assert.Contains(t, s, UntaggedGroup)
+}
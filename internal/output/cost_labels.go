@@ -0,0 +1,139 @@
+package output
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/shopspring/decimal"
+)
+
+// DefaultCostLabelBands are the bands of the pull request labels when they
+// aren't customized.
+var DefaultCostLabelBands = []string{
+	"cost/increase-high>=1000",
+	"cost/increase-medium>=100",
+	"cost/increase-low>0",
+	"cost/decrease<0",
+}
+
+// costLabelOps are the comparisons of the bands, longest first so >= isn't
+// parsed as >.
+var costLabelOps = []string{">=", "<=", ">", "<", "="}
+
+// CostLabelBand is a label of pull requests whose monthly cost change is in a
+// range, e.g. cost/increase-high>=1000. The change is compared as a percentage
+// of the past monthly cost when the value ends with %, e.g. cost/review>20%.
+type CostLabelBand struct {
+	Label   string
+	Op      string
+	Value   decimal.Decimal
+	Percent bool
+}
+
+// ParseCostLabelBands parses bands like cost/increase-high>=1000.
+func ParseCostLabelBands(specs []string) ([]CostLabelBand, error) {
+	bands := make([]CostLabelBand, 0, len(specs))
+
+	for _, spec := range specs {
+		b, err := parseCostLabelBand(spec)
+		if err != nil {
+			return nil, err
+		}
+
+		bands = append(bands, b)
+	}
+
+	return bands, nil
+}
+
+func parseCostLabelBand(spec string) (CostLabelBand, error) {
+	i := strings.IndexAny(spec, "<>=")
+	if i <= 0 {
+		return CostLabelBand{}, fmt.Errorf("Invalid cost label band %q, bands must be of the form label>=value, e.g. cost/increase-high>=1000", spec)
+	}
+
+	b := CostLabelBand{Label: strings.TrimSpace(spec[:i])}
+	rest := spec[i:]
+
+	for _, op := range costLabelOps {
+		if strings.HasPrefix(rest, op) {
+			b.Op = op
+			rest = strings.TrimSpace(strings.TrimPrefix(rest, op))
+			break
+		}
+	}
+
+	if strings.HasSuffix(rest, "%") {
+		b.Percent = true
+		rest = strings.TrimSuffix(rest, "%")
+	}
+
+	v, err := decimal.NewFromString(rest)
+	if err != nil {
+		return CostLabelBand{}, fmt.Errorf("Invalid cost label band %q, the value must be a number or a percentage", spec)
+	}
+	b.Value = v
+
+	return b, nil
+}
+
+// matches returns true if the change of the monthly cost from the past cost is
+// in the band. An increase from no cost is an infinite percentage.
+func (b CostLabelBand) matches(past decimal.Decimal, diff decimal.Decimal) bool {
+	v := diff
+	if b.Percent {
+		switch {
+		case past.IsPositive():
+			v = diff.Div(past).Mul(decimal.NewFromInt(100))
+		case diff.IsPositive():
+			return b.Op == ">" || b.Op == ">="
+		case diff.IsNegative():
+			return b.Op == "<" || b.Op == "<="
+		}
+	}
+
+	switch b.Op {
+	case ">=":
+		return v.GreaterThanOrEqual(b.Value)
+	case "<=":
+		return v.LessThanOrEqual(b.Value)
+	case ">":
+		return v.GreaterThan(b.Value)
+	case "<":
+		return v.LessThan(b.Value)
+	default:
+		return v.Equal(b.Value)
+	}
+}
+
+// CostLabel returns the label of the first band that the change of the monthly
+// cost of the output is in, or an empty label if it's in none of them.
+func CostLabel(out Root, bands []CostLabelBand) string {
+	past := decimal.Zero
+	if out.PastTotalMonthlyCost != nil {
+		past = *out.PastTotalMonthlyCost
+	}
+
+	diff := decimal.Zero
+	if out.DiffTotalMonthlyCost != nil {
+		diff = *out.DiffTotalMonthlyCost
+	}
+
+	for _, b := range bands {
+		if b.matches(past, diff) {
+			return b.Label
+		}
+	}
+
+	return ""
+}
+
+// CostLabels returns the labels of the bands.
+func CostLabels(bands []CostLabelBand) []string {
+	labels := make([]string, 0, len(bands))
+	for _, b := range bands {
+		labels = append(labels, b.Label)
+	}
+
+	return labels
+}
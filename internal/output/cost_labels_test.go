@@ -0,0 +1,47 @@
+package output
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCostLabelBands(t *testing.T) {
+	bands, err := ParseCostLabelBands([]string{"cost/increase-high>=1000", "cost/review > 20%", "cost/none=0"})
+	require.NoError(t, err)
+
+	assert.Equal(t, []CostLabelBand{
+		{Label: "cost/increase-high", Op: ">=", Value: decimal.NewFromInt(1000)},
+		{Label: "cost/review", Op: ">", Value: decimal.NewFromInt(20), Percent: true},
+		{Label: "cost/none", Op: "=", Value: decimal.NewFromInt(0)},
+	}, bands)
+
+	for _, spec := range []string{"cost/increase", ">=100", "cost/increase>=ten"} {
+		_, err := ParseCostLabelBands([]string{spec})
+		assert.Error(t, err, spec)
+	}
+}
+
+func TestCostLabel(t *testing.T) {
+	bands, err := ParseCostLabelBands(append([]string{"cost/review>50%"}, DefaultCostLabelBands...))
+	require.NoError(t, err)
+
+	label := func(past, diff int64) string {
+		return CostLabel(Root{
+			PastTotalMonthlyCost: decimalPtr(decimal.NewFromInt(past)),
+			DiffTotalMonthlyCost: decimalPtr(decimal.NewFromInt(diff)),
+		}, bands)
+	}
+
+	assert.Equal(t, "cost/review", label(100, 60))
+	assert.Equal(t, "cost/review", label(0, 10))
+	assert.Equal(t, "cost/increase-high", label(10000, 1200))
+	assert.Equal(t, "cost/increase-medium", label(10000, 100))
+	assert.Equal(t, "cost/increase-low", label(10000, 5))
+	assert.Equal(t, "cost/decrease", label(10000, -5))
+	assert.Equal(t, "", label(10000, 0))
+
+	assert.Equal(t, []string{"cost/review", "cost/increase-high", "cost/increase-medium", "cost/increase-low", "cost/decrease"}, CostLabels(bands))
+}
@@ -36,16 +36,15 @@ func ToDiff(out Root, opts Options) ([]byte, error) {
 			s += "──────────────────────────────────\n"
 		}
 
-		s += fmt.Sprintf("%s %s\n\n",
-			ui.BoldString("Project:"),
-			project.Label(opts.DashboardEnabled),
-		)
+		s += projectHeader(project, opts.DashboardEnabled) + "\n"
+
+		projectCurrency := out.projectCurrency(project)
 
 		for _, diffResource := range project.Diff.Resources {
 			oldResource := findResourceByName(project.PastBreakdown.Resources, diffResource.Name)
 			newResource := findResourceByName(project.Breakdown.Resources, diffResource.Name)
 
-			s += resourceToDiff(out.Currency, diffResource, oldResource, newResource, true)
+			s += resourceToDiff(projectCurrency, diffResource, oldResource, newResource, true)
 			s += "\n"
 		}
 
@@ -62,8 +61,8 @@ func ToDiff(out Root, opts Options) ([]byte, error) {
 		s += fmt.Sprintf("%s %s\nAmount:  %s %s",
 			ui.BoldString("Monthly cost change for"),
 			ui.BoldString(project.Label(opts.DashboardEnabled)),
-			formatTitleWithCurrency(formatCostChange(out.Currency, project.Diff.TotalMonthlyCost), out.Currency),
-			ui.FaintStringf("(%s → %s)", formatCost(out.Currency, oldCost), formatCost(out.Currency, newCost)),
+			formatTitleWithCurrency(formatCostChange(projectCurrency, project.Diff.TotalMonthlyCost), projectCurrency),
+			ui.FaintStringf("(%s → %s)", formatCost(projectCurrency, oldCost), formatCost(projectCurrency, newCost)),
 		)
 
 		percent := formatPercentChange(oldCost, newCost)
@@ -83,6 +82,40 @@ func ToDiff(out Root, opts Options) ([]byte, error) {
 		s += "\n\n"
 	}
 
+	s += projectGroupsToDiff(out)
+
+	s += ownersToDiff(out)
+
+	s += priceChangesToDiff(out, opts)
+
+	if guardrailsMsg := guardrailViolationsToTable(out); guardrailsMsg != "" {
+		s += guardrailsMsg + "\n\n"
+	}
+
+	if tagPoliciesMsg := tagPoliciesToTable(out); tagPoliciesMsg != "" {
+		s += tagPoliciesMsg + "\n\n"
+	}
+
+	if exemptionsMsg := exemptionsToTable(out); exemptionsMsg != "" {
+		s += exemptionsMsg + "\n\n"
+	}
+
+	if waiversMsg := waiversToTable(out); waiversMsg != "" {
+		s += waiversMsg + "\n\n"
+	}
+
+	if recommendationsMsg := recommendationsToTable(out); recommendationsMsg != "" {
+		s += recommendationsMsg + "\n\n"
+	}
+
+	if rightsizingMsg := rightsizingToTable(out); opts.ShowRightsizing && rightsizingMsg != "" {
+		s += rightsizingMsg + "\n\n"
+	}
+
+	if idleMsg := idleResourcesToTable(out); idleMsg != "" {
+		s += idleMsg + "\n\n"
+	}
+
 	s += "──────────────────────────────────\n"
 	if len(noDiffProjects) != len(out.Projects) {
 		s += fmt.Sprintf("Key: %s changed, %s added, %s removed\n",
@@ -317,3 +350,31 @@ func getSym(d decimal.Decimal) string {
 
 	return ""
 }
+
+// ChangedResources returns the resources of the breakdown of the project that
+// are added or whose costs change in its diff. All the resources are changed if
+// the project has no diff, e.g. in a breakdown, since there are no past
+// resources to compare them to.
+func (p Project) ChangedResources() []Resource {
+	if p.Breakdown == nil {
+		return nil
+	}
+
+	if p.Diff == nil {
+		return p.Breakdown.Resources
+	}
+
+	changed := make(map[string]bool, len(p.Diff.Resources))
+	for _, r := range p.Diff.Resources {
+		changed[r.Name] = true
+	}
+
+	var resources []Resource
+	for _, r := range p.Breakdown.Resources {
+		if changed[r.Name] {
+			resources = append(resources, r)
+		}
+	}
+
+	return resources
+}
@@ -0,0 +1,243 @@
+package output
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/shopspring/decimal"
+	"github.com/tidwall/gjson"
+
+	"github.com/infracost/infracost/internal/schema"
+)
+
+// ToExplanation returns how the costs of the resource with the address were
+// derived in each project it's in: the filters used to look up the price of each
+// cost component, the attributes of the resource the filters match, the unit
+// price and its tier, the quantity, and the usage keys that change the costs.
+func ToExplanation(projects []*schema.Project, address string, currency string) ([]byte, error) {
+	var b strings.Builder
+
+	found := false
+
+	for _, p := range projects {
+		for _, r := range p.Resources {
+			if r.Name != address {
+				continue
+			}
+
+			if found {
+				b.WriteString("\n")
+			}
+			found = true
+
+			cur := currencyOrUSD(currency)
+			if p.Currency != "" {
+				cur = p.Currency
+			}
+
+			fmt.Fprintf(&b, "Project: %s\n\n", p.Name)
+			explainResource(&b, r, cur)
+		}
+	}
+
+	if !found {
+		return nil, fmt.Errorf("Resource %s not found, check its address in the output of infracost breakdown --show-skipped", address)
+	}
+
+	return []byte(strings.TrimRight(b.String(), "\n")), nil
+}
+
+func explainResource(b *strings.Builder, r *schema.Resource, currency string) {
+	fmt.Fprintf(b, "%s (%s)\n", r.Name, r.ResourceType)
+
+	if r.IsSkipped {
+		fmt.Fprintf(b, "  Not estimated: %s\n", r.SkipMessage)
+		return
+	}
+
+	explainCostComponents(b, r, r.RawValues, "", currency)
+
+	fmt.Fprintf(b, "\n  Monthly cost: %s\n", formatCost2DP(currency, r.MonthlyCost))
+
+	if len(r.UsageSchema) == 0 {
+		return
+	}
+
+	b.WriteString("\n  Usage keys that change these costs:\n")
+	for _, u := range r.UsageSchema {
+		v := u.Value
+		source := ""
+		if v == nil {
+			v = u.DefaultValue
+			source = " (default)"
+		}
+		if reason, ok := r.DerivedUsage[u.Key]; ok {
+			source = fmt.Sprintf(" (derived from %s)", reason)
+		}
+
+		line := fmt.Sprintf("    %s = %v%s", u.Key, v, source)
+		if u.Description != "" {
+			line += ": " + u.Description
+		}
+		b.WriteString(line + "\n")
+	}
+}
+
+// explainCostComponents explains the cost components of the resource and its
+// sub-resources, whose names prefix the names of their cost components.
+func explainCostComponents(b *strings.Builder, r *schema.Resource, raw gjson.Result, prefix string, currency string) {
+	for _, c := range r.CostComponents {
+		fmt.Fprintf(b, "\n  %s%s\n", prefix, c.Name)
+
+		if c.ProductFilter != nil {
+			b.WriteString("    Product filter:\n")
+			for _, f := range productFilterValues(c.ProductFilter) {
+				fmt.Fprintf(b, "      %s\n", f)
+			}
+		}
+
+		if c.PriceFilter != nil {
+			if filters := priceFilterValues(c.PriceFilter); len(filters) > 0 {
+				b.WriteString("    Price filter:\n")
+				for _, f := range filters {
+					fmt.Fprintf(b, "      %s\n", f)
+				}
+			}
+		}
+
+		if attrs := matchingAttributes(raw, c); len(attrs) > 0 {
+			fmt.Fprintf(b, "    Attributes used: %s\n", strings.Join(attrs, ", "))
+		}
+
+		if c.PriceUnavailable {
+			b.WriteString("    Price: unavailable, priced at 0.00\n")
+			continue
+		}
+
+		if c.PriceIssue != "" {
+			fmt.Fprintf(b, "    Price issue: %s\n", pricingIssueDescriptions[c.PriceIssue])
+		}
+
+		fmt.Fprintf(b, "    Unit price: %s per %s\n", formatPrice(currency, c.Price()), c.Unit)
+
+		if c.PriceTier != nil {
+			fmt.Fprintf(b, "    Price tier: %s\n", formatPriceTier(outputPriceTier(c), c.Unit))
+		}
+
+		if c.MonthlyQuantity == nil {
+			b.WriteString("    Monthly cost: depends on usage, set the usage keys below in the usage file\n")
+			continue
+		}
+
+		calc := fmt.Sprintf("%s %s × %s", formatQuantity(c.MonthlyQuantity), c.Unit, formatPrice(currency, c.Price()))
+		if c.MonthlyDiscountPerc > 0 {
+			calc += fmt.Sprintf(" less %s%% discount", decimal.NewFromFloat(c.MonthlyDiscountPerc*100).Round(2).String())
+		}
+		fmt.Fprintf(b, "    Monthly cost: %s = %s\n", calc, formatCost2DP(currency, c.MonthlyCost))
+	}
+
+	for _, s := range r.SubResources {
+		explainCostComponents(b, s, raw, prefix+s.Name+" / ", currency)
+	}
+}
+
+func productFilterValues(f *schema.ProductFilter) []string {
+	var values []string
+
+	add := func(key string, v *string) {
+		if v != nil {
+			values = append(values, fmt.Sprintf("%s = %s", key, *v))
+		}
+	}
+
+	add("vendorName", f.VendorName)
+	add("service", f.Service)
+	add("productFamily", f.ProductFamily)
+	add("region", f.Region)
+	add("sku", f.Sku)
+
+	for _, a := range f.AttributeFilters {
+		if a.Value != nil {
+			values = append(values, fmt.Sprintf("%s = %s", a.Key, *a.Value))
+		} else if a.ValueRegex != nil {
+			values = append(values, fmt.Sprintf("%s =~ %s", a.Key, *a.ValueRegex))
+		}
+	}
+
+	return values
+}
+
+func priceFilterValues(f *schema.PriceFilter) []string {
+	var values []string
+
+	add := func(key string, v *string) {
+		if v != nil {
+			values = append(values, fmt.Sprintf("%s = %s", key, *v))
+		}
+	}
+
+	add("purchaseOption", f.PurchaseOption)
+	add("unit", f.Unit)
+	add("description", f.Description)
+	if f.DescriptionRegex != nil {
+		values = append(values, fmt.Sprintf("description =~ %s", *f.DescriptionRegex))
+	}
+	add("startUsageAmount", f.StartUsageAmount)
+	add("endUsageAmount", f.EndUsageAmount)
+	add("termLength", f.TermLength)
+	add("termPurchaseOption", f.TermPurchaseOption)
+	add("termOfferingClass", f.TermOfferingClass)
+
+	return values
+}
+
+// matchingAttributes returns the string attributes of the resource whose values
+// are in the filters of the cost component, i.e. the attributes the price was
+// looked up with. The filters don't record where their values came from, so
+// this can miss attributes that were transformed, e.g. mapped to a SKU.
+func matchingAttributes(raw gjson.Result, c *schema.CostComponent) []string {
+	var filterValues []string
+
+	if c.ProductFilter != nil {
+		for _, f := range []*string{c.ProductFilter.Region, c.ProductFilter.Sku} {
+			if f != nil {
+				filterValues = append(filterValues, strings.ToLower(*f))
+			}
+		}
+
+		for _, a := range c.ProductFilter.AttributeFilters {
+			if a.Value != nil {
+				filterValues = append(filterValues, strings.ToLower(*a.Value))
+			} else if a.ValueRegex != nil {
+				filterValues = append(filterValues, strings.ToLower(*a.ValueRegex))
+			}
+		}
+	}
+
+	if c.PriceFilter != nil && c.PriceFilter.DescriptionRegex != nil {
+		filterValues = append(filterValues, strings.ToLower(*c.PriceFilter.DescriptionRegex))
+	}
+
+	var attrs []string
+
+	raw.ForEach(func(key, value gjson.Result) bool {
+		if value.Type != gjson.String || len(value.String()) < 2 {
+			return true
+		}
+
+		v := strings.ToLower(value.String())
+		for _, f := range filterValues {
+			if f == v || (strings.HasPrefix(f, "/") && strings.Contains(f, v)) {
+				attrs = append(attrs, fmt.Sprintf("%s = %s", key.String(), value.String()))
+				break
+			}
+		}
+
+		return true
+	})
+
+	sort.Strings(attrs)
+
+	return attrs
+}
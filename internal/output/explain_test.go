@@ -0,0 +1,73 @@
+package output
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+
+	"github.com/infracost/infracost/internal/schema"
+)
+
+func strPtr(s string) *string {
+	return &s
+}
+
+func TestToExplanation(t *testing.T) {
+	instance := &schema.CostComponent{
+		Name:           "Instance usage (Linux/UNIX, on-demand, t3.medium)",
+		Unit:           "hours",
+		UnitMultiplier: decimal.NewFromInt(1),
+		HourlyQuantity: decimalPtr(decimal.NewFromInt(1)),
+		ProductFilter: &schema.ProductFilter{
+			VendorName: strPtr("aws"),
+			Region:     strPtr("us-east-1"),
+			AttributeFilters: []*schema.AttributeFilter{
+				{Key: "instanceType", Value: strPtr("t3.medium")},
+			},
+		},
+		PriceFilter: &schema.PriceFilter{PurchaseOption: strPtr("on_demand")},
+	}
+	instance.SetPrice(decimal.RequireFromString("0.0416"))
+
+	r := &schema.Resource{
+		Name:           "aws_instance.web",
+		ResourceType:   "aws_instance",
+		CostComponents: []*schema.CostComponent{instance},
+		RawValues:      gjson.Parse(`{"instance_type": "t3.medium", "ami": "ami-123"}`),
+		UsageSchema: []*schema.UsageItem{
+			{Key: "monthly_cpu_credit_hrs", DefaultValue: 0, Description: "Number of hours the instance uses CPU credits"},
+		},
+	}
+	r.CalculateCosts()
+
+	projects := []*schema.Project{{Name: "infracost/example", Resources: []*schema.Resource{r}}}
+
+	b, err := ToExplanation(projects, "aws_instance.web", "USD")
+	require.NoError(t, err)
+
+	assert.Equal(t, `Project: infracost/example
+
+aws_instance.web (aws_instance)
+
+  Instance usage (Linux/UNIX, on-demand, t3.medium)
+    Product filter:
+      vendorName = aws
+      region = us-east-1
+      instanceType = t3.medium
+    Price filter:
+      purchaseOption = on_demand
+    Attributes used: instance_type = t3.medium
+    Unit price: $0.0416 per hours
+    Monthly cost: 730 hours × $0.0416 = $30.37
+
+  Monthly cost: $30.37
+
+  Usage keys that change these costs:
+    monthly_cpu_credit_hrs = 0 (default): Number of hours the instance uses CPU credits`, string(b))
+
+	_, err = ToExplanation(projects, "aws_instance.api", "USD")
+	assert.EqualError(t, err, "Resource aws_instance.api not found, check its address in the output of infracost breakdown --show-skipped")
+}
@@ -0,0 +1,378 @@
+package output
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/shopspring/decimal"
+)
+
+// The keys of the filters resources can be filtered by.
+const (
+	FilterType           = "type"
+	FilterModule         = "module"
+	FilterTag            = "tag"
+	FilterProvider       = "provider"
+	FilterMinMonthlyCost = "min-monthly-cost"
+)
+
+// FilterKeys are the keys of the filter expressions, see ParseFilter.
+var FilterKeys = []string{FilterType, FilterModule, FilterTag, FilterProvider, FilterMinMonthlyCost}
+
+// Filter is a condition resources have to match to be shown.
+type Filter struct {
+	Key string
+	// Values are the alternatives of the filter, a resource matches the filter
+	// when it matches any of them.
+	Values []string
+
+	minMonthlyCost decimal.Decimal
+}
+
+// ParseFilter parses a filter expression of the form key=value, where value is a
+// comma separated list of alternatives:
+//
+//	type=aws_nat_gateway,aws_eip   resource types, glob patterns are supported
+//	module=module.vpc              module addresses, including nested modules
+//	tag=env=prod                   tag keys, or key=value to match the value too
+//	provider=aws                   providers, from the prefix of the resource type
+//	min-monthly-cost=10            resources costing at least this per month
+func ParseFilter(expr string) (Filter, error) {
+	parts := strings.SplitN(expr, "=", 2)
+	if len(parts) != 2 || strings.TrimSpace(parts[1]) == "" {
+		return Filter{}, fmt.Errorf("Invalid filter %q, filters must be of the form key=value", expr)
+	}
+
+	f := Filter{Key: strings.TrimSpace(parts[0])}
+	if !contains(FilterKeys, f.Key) {
+		return Filter{}, fmt.Errorf("Invalid filter %q, supported keys are: %s", expr, strings.Join(FilterKeys, ", "))
+	}
+
+	for _, v := range strings.Split(parts[1], ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			f.Values = append(f.Values, v)
+		}
+	}
+
+	if f.Key == FilterMinMonthlyCost {
+		if len(f.Values) != 1 {
+			return Filter{}, fmt.Errorf("Invalid filter %q, %s takes a single value", expr, FilterMinMonthlyCost)
+		}
+
+		d, err := decimal.NewFromString(f.Values[0])
+		if err != nil {
+			return Filter{}, fmt.Errorf("Invalid filter %q, %s must be a number", expr, FilterMinMonthlyCost)
+		}
+		f.minMonthlyCost = d
+	}
+
+	for _, v := range f.Values {
+		if _, err := path.Match(v, ""); err != nil {
+			return Filter{}, fmt.Errorf("Invalid filter %q, %s is not a valid pattern", expr, v)
+		}
+	}
+
+	return f, nil
+}
+
+// ParseFilters parses each of the filter expressions, see ParseFilter.
+func ParseFilters(exprs []string) ([]Filter, error) {
+	filters := make([]Filter, 0, len(exprs))
+
+	for _, expr := range exprs {
+		f, err := ParseFilter(expr)
+		if err != nil {
+			return nil, err
+		}
+
+		filters = append(filters, f)
+	}
+
+	return filters, nil
+}
+
+// matches returns true if the resource matches any value of the filter. The
+// monthly cost is the one used for min-monthly-cost.
+func (f Filter) matches(r Resource, monthlyCost decimal.Decimal) bool {
+	if f.Key == FilterMinMonthlyCost {
+		return monthlyCost.Abs().GreaterThanOrEqual(f.minMonthlyCost)
+	}
+
+	for _, v := range f.Values {
+		switch f.Key {
+		case FilterType:
+			if globMatch(v, ResourceType(r.Name)) {
+				return true
+			}
+		case FilterProvider:
+			if globMatch(v, strings.SplitN(ResourceType(r.Name), "_", 2)[0]) {
+				return true
+			}
+		case FilterModule:
+			if InModule(r.Name, v) {
+				return true
+			}
+		case FilterTag:
+			parts := strings.SplitN(v, "=", 2)
+			tag, ok := r.Tags[parts[0]]
+			if ok && (len(parts) == 1 || globMatch(parts[1], tag)) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// FilterResources returns a copy of the output with only the resources that
+// match all of the filters, and its totals recalculated from them. A resource
+// is kept in the past breakdown and diff of a project when it matches in either
+// the current or the past breakdown, so the diff of the kept resources is
+// unchanged. Usage scenario totals can't be recalculated so they're removed.
+func FilterResources(r Root, filters []Filter) Root {
+	if len(filters) == 0 {
+		return r
+	}
+
+	projects := make([]Project, len(r.Projects))
+	for i, p := range r.Projects {
+		keep := map[string]bool{}
+		for _, b := range []*Breakdown{p.Breakdown, p.PastBreakdown} {
+			if b == nil {
+				continue
+			}
+
+			for _, res := range b.Resources {
+				if matchesFilters(res, filters) {
+					keep[res.Name] = true
+				}
+			}
+		}
+
+		p.Breakdown = filterBreakdown(p.Breakdown, keep)
+		p.PastBreakdown = filterBreakdown(p.PastBreakdown, keep)
+		p.Diff = filterBreakdown(p.Diff, keep)
+		p.UsageScenarios = nil
+
+		if p.ReportCurrencyTotals != nil {
+			rate := p.ReportCurrencyTotals.Rate
+			totals := p.totals()
+
+			p.ReportCurrencyTotals = &ReportCurrencyTotals{
+				Currency:             p.ReportCurrencyTotals.Currency,
+				Rate:                 rate,
+				TotalHourlyCost:      convertCost(totals.TotalHourlyCost, rate),
+				TotalMonthlyCost:     convertCost(totals.TotalMonthlyCost, rate),
+				PastTotalHourlyCost:  convertCost(totals.PastTotalHourlyCost, rate),
+				PastTotalMonthlyCost: convertCost(totals.PastTotalMonthlyCost, rate),
+				DiffTotalHourlyCost:  convertCost(totals.DiffTotalHourlyCost, rate),
+				DiffTotalMonthlyCost: convertCost(totals.DiffTotalMonthlyCost, rate),
+			}
+		}
+
+		projects[i] = p
+	}
+
+	r.Projects = projects
+	r.UsageScenarios = nil
+	r.PricingIssues = pricingIssues(projects)
+	setTotalsFromProjects(&r)
+
+	return r
+}
+
+// FilterProjects returns a copy of the output with only the projects that keep
+// returns true for, their guardrail violations and the totals recalculated from
+// them.
+func FilterProjects(r Root, keep func(Project) bool) Root {
+	var projects []Project
+	names := map[string]bool{}
+
+	for _, p := range r.Projects {
+		if keep(p) {
+			projects = append(projects, p)
+			names[p.Name] = true
+		}
+	}
+
+	var violations []GuardrailViolation
+	for _, v := range r.GuardrailViolations {
+		if names[v.ProjectName] {
+			violations = append(violations, v)
+		}
+	}
+
+	r.Projects = projects
+	r.GuardrailViolations = violations
+	r.UsageScenarios = nil
+	r.PricingIssues = pricingIssues(projects)
+	setTotalsFromProjects(&r)
+
+	return r
+}
+
+// setTotalsFromProjects sets the totals of the output to the sums of the
+// totals of its projects.
+func setTotalsFromProjects(r *Root) {
+	r.TotalHourlyCost, r.TotalMonthlyCost = nil, nil
+	r.PastTotalHourlyCost, r.PastTotalMonthlyCost = nil, nil
+	r.DiffTotalHourlyCost, r.DiffTotalMonthlyCost = nil, nil
+
+	for _, p := range r.Projects {
+		totals := p.reportCurrencyTotals()
+
+		r.TotalHourlyCost = addCost(r.TotalHourlyCost, totals.TotalHourlyCost)
+		r.TotalMonthlyCost = addCost(r.TotalMonthlyCost, totals.TotalMonthlyCost)
+		r.PastTotalHourlyCost = addCost(r.PastTotalHourlyCost, totals.PastTotalHourlyCost)
+		r.PastTotalMonthlyCost = addCost(r.PastTotalMonthlyCost, totals.PastTotalMonthlyCost)
+		r.DiffTotalHourlyCost = addCost(r.DiffTotalHourlyCost, totals.DiffTotalHourlyCost)
+		r.DiffTotalMonthlyCost = addCost(r.DiffTotalMonthlyCost, totals.DiffTotalMonthlyCost)
+	}
+}
+
+func matchesFilters(r Resource, filters []Filter) bool {
+	monthlyCost := decimal.Zero
+	if r.MonthlyCost != nil {
+		monthlyCost = *r.MonthlyCost
+	}
+
+	for _, f := range filters {
+		if !f.matches(r, monthlyCost) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func filterBreakdown(b *Breakdown, keep map[string]bool) *Breakdown {
+	if b == nil {
+		return nil
+	}
+
+	resources := make([]Resource, 0, len(b.Resources))
+	for _, r := range b.Resources {
+		if keep[r.Name] {
+			resources = append(resources, r)
+		}
+	}
+
+	totalHourlyCost, totalMonthlyCost := calculateTotalCosts(resources)
+
+	return &Breakdown{
+		Resources:        resources,
+		TotalHourlyCost:  totalHourlyCost,
+		TotalMonthlyCost: totalMonthlyCost,
+	}
+}
+
+// ResourceType returns the type of the resource from its address, e.g.
+// aws_nat_gateway for module.vpc.aws_nat_gateway.this[0].
+func ResourceType(address string) string {
+	parts := splitAddress(address)
+
+	for i := 0; i < len(parts); i++ {
+		switch parts[i] {
+		case "module":
+			i++
+		case "data":
+		default:
+			return parts[i]
+		}
+	}
+
+	return ""
+}
+
+// InModule returns true if the resource is in the module or one of its nested
+// modules. The module can be given with or without its module. prefix and
+// instance keys, e.g. vpc matches module.vpc["a"].aws_subnet.b.
+func InModule(address string, module string) bool {
+	var modules []string
+
+	parts := splitAddress(address)
+	for i := 0; i+1 < len(parts) && parts[i] == "module"; i += 2 {
+		modules = append(modules, "module."+parts[i+1])
+	}
+
+	want := splitAddress(module)
+	var wantModules []string
+	for i := 0; i < len(want); i++ {
+		if want[i] == "module" {
+			continue
+		}
+		wantModules = append(wantModules, "module."+stripIndex(want[i]))
+	}
+
+	if len(wantModules) == 0 || len(wantModules) > len(modules) {
+		return false
+	}
+
+	for i, m := range wantModules {
+		if !globMatch(m, stripIndex(modules[i])) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// ModuleAddress returns the address of the module the resource is in, up to
+// depth levels of nested modules, or all of them if depth is 0, e.g.
+// module.vpc["a"] for module.vpc["a"].module.subnets.aws_subnet.b and a depth
+// of 1. It returns an empty string for the resources of the root module.
+func ModuleAddress(address string, depth int) string {
+	var modules []string
+
+	parts := splitAddress(address)
+	for i := 0; i+1 < len(parts) && parts[i] == "module"; i += 2 {
+		if depth > 0 && len(modules) == depth {
+			break
+		}
+		modules = append(modules, "module."+parts[i+1])
+	}
+
+	return strings.Join(modules, ".")
+}
+
+// splitAddress splits a resource address on the dots that aren't in an index,
+// e.g. module.a["x.y"].b is split into module, a["x.y"] and b.
+func splitAddress(address string) []string {
+	var parts []string
+
+	depth := 0
+	start := 0
+	for i, c := range address {
+		switch c {
+		case '[':
+			depth++
+		case ']':
+			depth--
+		case '.':
+			if depth == 0 {
+				parts = append(parts, address[start:i])
+				start = i + 1
+			}
+		}
+	}
+
+	return append(parts, address[start:])
+}
+
+func stripIndex(s string) string {
+	if i := strings.Index(s, "["); i != -1 {
+		return s[:i]
+	}
+
+	return s
+}
+
+func globMatch(pattern string, s string) bool {
+	if pattern == s {
+		return true
+	}
+
+	ok, _ := path.Match(pattern, s)
+	return ok
+}
@@ -0,0 +1,137 @@
+package output
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseFilter(t *testing.T) {
+	f, err := ParseFilter("type=aws_nat_gateway, aws_eip")
+	require.NoError(t, err)
+	assert.Equal(t, FilterType, f.Key)
+	assert.Equal(t, []string{"aws_nat_gateway", "aws_eip"}, f.Values)
+
+	f, err = ParseFilter("tag=env=prod")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"env=prod"}, f.Values)
+
+	for _, expr := range []string{"aws_nat_gateway", "type=", "size=large", "min-monthly-cost=ten", "min-monthly-cost=1,2"} {
+		_, err := ParseFilter(expr)
+		assert.Error(t, err, expr)
+	}
+}
+
+func TestResourceType(t *testing.T) {
+	assert.Equal(t, "aws_instance", ResourceType("aws_instance.web"))
+	assert.Equal(t, "aws_nat_gateway", ResourceType("module.vpc.aws_nat_gateway.this[0]"))
+	assert.Equal(t, "aws_subnet", ResourceType(`module.a["x.y"].module.b.aws_subnet.c`))
+	assert.Equal(t, "aws_ami", ResourceType("data.aws_ami.ubuntu"))
+}
+
+func TestInModule(t *testing.T) {
+	assert.True(t, InModule("module.vpc.aws_nat_gateway.this[0]", "module.vpc"))
+	assert.True(t, InModule("module.vpc.aws_nat_gateway.this[0]", "vpc"))
+	assert.True(t, InModule(`module.vpc["a"].module.subnets.aws_subnet.b`, "module.vpc.module.subnets"))
+	assert.True(t, InModule(`module.vpc["a"].aws_subnet.b`, "module.vp*"))
+	assert.False(t, InModule("module.vpc2.aws_nat_gateway.this", "module.vpc"))
+	assert.False(t, InModule("aws_nat_gateway.this", "module.vpc"))
+	assert.False(t, InModule("module.vpc.aws_subnet.b", "module.vpc.module.subnets"))
+}
+
+func TestModuleAddress(t *testing.T) {
+	assert.Equal(t, "module.vpc", ModuleAddress("module.vpc.aws_nat_gateway.this[0]", 0))
+	assert.Equal(t, `module.vpc["a"].module.subnets`, ModuleAddress(`module.vpc["a"].module.subnets.aws_subnet.b`, 0))
+	assert.Equal(t, `module.vpc["a"]`, ModuleAddress(`module.vpc["a"].module.subnets.aws_subnet.b`, 1))
+	assert.Equal(t, "", ModuleAddress("aws_nat_gateway.this", 0))
+}
+
+func TestFilterResources(t *testing.T) {
+	r := Root{
+		Projects: []Project{
+			{
+				Name: "infracost/infracost/examples",
+				Breakdown: &Breakdown{
+					Resources: []Resource{
+						{Name: "aws_instance.web", Tags: map[string]string{"env": "prod"}, MonthlyCost: decimalPtr(decimal.NewFromInt(50))},
+						{Name: "module.vpc.aws_nat_gateway.this[0]", Tags: map[string]string{"env": "prod"}, MonthlyCost: decimalPtr(decimal.NewFromInt(32))},
+						{Name: "module.vpc.aws_nat_gateway.this[1]", Tags: map[string]string{"env": "dev"}, MonthlyCost: decimalPtr(decimal.NewFromInt(32))},
+						{Name: "google_compute_instance.web", MonthlyCost: decimalPtr(decimal.NewFromInt(5))},
+					},
+				},
+				UsageScenarios: map[string]*decimal.Decimal{"low": decimalPtr(decimal.NewFromInt(1))},
+			},
+		},
+		TotalMonthlyCost: decimalPtr(decimal.NewFromInt(119)),
+	}
+
+	names := func(out Root) []string {
+		var n []string
+		for _, res := range out.Projects[0].Breakdown.Resources {
+			n = append(n, res.Name)
+		}
+		return n
+	}
+
+	filter := func(exprs ...string) Root {
+		filters, err := ParseFilters(exprs)
+		require.NoError(t, err)
+		return FilterResources(r, filters)
+	}
+
+	out := filter("type=aws_nat_gateway")
+	assert.Equal(t, []string{"module.vpc.aws_nat_gateway.this[0]", "module.vpc.aws_nat_gateway.this[1]"}, names(out))
+	assert.Equal(t, "64", out.TotalMonthlyCost.String())
+	assert.Equal(t, "64", out.Projects[0].Breakdown.TotalMonthlyCost.String())
+	assert.Nil(t, out.Projects[0].UsageScenarios)
+
+	assert.Equal(t, []string{"module.vpc.aws_nat_gateway.this[0]"}, names(filter("type=aws_nat_gateway", "tag=env=prod")))
+	assert.Equal(t, []string{"aws_instance.web", "module.vpc.aws_nat_gateway.this[0]"}, names(filter("tag=env=prod")))
+	assert.Equal(t, []string{"google_compute_instance.web"}, names(filter("provider=google")))
+	assert.Equal(t, []string{"aws_instance.web"}, names(filter("min-monthly-cost=40")))
+	assert.Equal(t, []string{"aws_instance.web", "google_compute_instance.web"}, names(filter("type=aws_instance,google_*")))
+
+	// The original output isn't changed.
+	assert.Len(t, r.Projects[0].Breakdown.Resources, 4)
+	assert.Equal(t, "119", r.TotalMonthlyCost.String())
+}
+
+func TestFilterResourcesDiff(t *testing.T) {
+	r := Root{
+		Projects: []Project{
+			{
+				Name: "infracost/infracost/examples",
+				PastBreakdown: &Breakdown{
+					Resources: []Resource{
+						{Name: "aws_nat_gateway.a", MonthlyCost: decimalPtr(decimal.NewFromInt(32))},
+					},
+				},
+				Breakdown: &Breakdown{
+					Resources: []Resource{
+						{Name: "aws_instance.web", MonthlyCost: decimalPtr(decimal.NewFromInt(50))},
+					},
+				},
+				Diff: &Breakdown{
+					Resources: []Resource{
+						{Name: "aws_instance.web", MonthlyCost: decimalPtr(decimal.NewFromInt(50))},
+						{Name: "aws_nat_gateway.a", MonthlyCost: decimalPtr(decimal.NewFromInt(-32))},
+					},
+				},
+			},
+		},
+	}
+
+	filters, err := ParseFilters([]string{"type=aws_nat_gateway"})
+	require.NoError(t, err)
+
+	out := FilterResources(r, filters)
+	p := out.Projects[0]
+	assert.Empty(t, p.Breakdown.Resources)
+	assert.Len(t, p.PastBreakdown.Resources, 1)
+	require.Len(t, p.Diff.Resources, 1)
+	assert.Equal(t, "aws_nat_gateway.a", p.Diff.Resources[0].Name)
+	assert.Equal(t, "-32", out.DiffTotalMonthlyCost.String())
+	assert.Equal(t, "32", out.PastTotalMonthlyCost.String())
+}
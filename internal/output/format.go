@@ -19,6 +19,29 @@ func formatQuantity(q *decimal.Decimal) string {
 	return humanize.CommafWithDigits(f, 4)
 }
 
+// formatPriceTier formats the range of usage of a price tier, e.g. "51,200 to 512,000 GB".
+func formatPriceTier(t *PriceTier, unit string) string {
+	if t == nil {
+		return ""
+	}
+
+	if t.EndUsageAmount == nil {
+		return fmt.Sprintf("over %s %s", formatQuantity(&t.StartUsageAmount), unit)
+	}
+
+	return fmt.Sprintf("%s to %s %s", formatQuantity(&t.StartUsageAmount), formatQuantity(t.EndUsageAmount), unit)
+}
+
+// FormatCost formats a cost in currency the way the table output shows it.
+func FormatCost(currency string, d *decimal.Decimal) string {
+	return formatCost(currency, d)
+}
+
+// FormatPrice formats a unit price in currency the way the table output shows it.
+func FormatPrice(currency string, d decimal.Decimal) string {
+	return formatPrice(currency, d)
+}
+
 func formatCost(currency string, d *decimal.Decimal) string {
 	if d == nil {
 		return "-"
@@ -0,0 +1,110 @@
+package output
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/shopspring/decimal"
+)
+
+// gitlabCodeQualitySeverities maps the levels of check annotations to the
+// severities of GitLab code quality issues.
+var gitlabCodeQualitySeverities = map[string]string{
+	CheckAnnotationNotice:  "info",
+	CheckAnnotationWarning: "minor",
+	CheckAnnotationFailure: "major",
+}
+
+type gitlabCodeQualityIssue struct {
+	Description string                    `json:"description"`
+	CheckName   string                    `json:"check_name"`
+	Fingerprint string                    `json:"fingerprint"`
+	Severity    string                    `json:"severity"`
+	Location    gitlabCodeQualityLocation `json:"location"`
+}
+
+type gitlabCodeQualityLocation struct {
+	Path  string                 `json:"path"`
+	Lines gitlabCodeQualityLines `json:"lines"`
+}
+
+type gitlabCodeQualityLines struct {
+	Begin int `json:"begin"`
+	End   int `json:"end"`
+}
+
+// ToGitLabCodeQuality outputs the check annotations of the output as a GitLab
+// code quality report, so the MR widget and the diff show the costliest changes
+// and the policy violations at the lines of their resources. The fingerprints
+// identify the findings across pipelines, so they don't include the costs.
+func ToGitLabCodeQuality(out Root, maxCostChanges int) ([]byte, error) {
+	annotations := CheckAnnotations(out, maxCostChanges)
+
+	issues := make([]gitlabCodeQualityIssue, 0, len(annotations))
+	for _, a := range annotations {
+		key := []string{a.Check, a.Path, a.Address}
+		if a.Check != CheckAnnotationCostChange {
+			key = append(key, a.Title)
+		}
+		sum := sha256.Sum256([]byte(strings.Join(key, "\x00")))
+
+		issues = append(issues, gitlabCodeQualityIssue{
+			Description: fmt.Sprintf("%s: %s", a.Title, a.Message),
+			CheckName:   a.Check,
+			Fingerprint: hex.EncodeToString(sum[:]),
+			Severity:    gitlabCodeQualitySeverities[a.Level],
+			Location: gitlabCodeQualityLocation{
+				Path:  a.Path,
+				Lines: gitlabCodeQualityLines{Begin: a.StartLine, End: a.EndLine},
+			},
+		})
+	}
+
+	return json.MarshalIndent(issues, "", "  ")
+}
+
+// ToGitLabMetrics outputs the monthly costs as a GitLab metrics report in the
+// OpenMetrics text format. GitLab compares it to the report of the target
+// branch, so the MR widget shows how the costs change. The costs are in the
+// currency of the report.
+func ToGitLabMetrics(out Root) []byte {
+	var b strings.Builder
+
+	metric := func(name string, labels string, d *decimal.Decimal) {
+		if d == nil {
+			return
+		}
+
+		fmt.Fprintf(&b, "%s%s %s\n", name, labels, d.Round(2).String())
+	}
+
+	currency := fmt.Sprintf("{currency=%q}", out.Currency)
+	metric("infracost_total_monthly_cost", currency, out.TotalMonthlyCost)
+	metric("infracost_diff_total_monthly_cost", currency, out.DiffTotalMonthlyCost)
+
+	for _, p := range out.Projects {
+		if p.Breakdown == nil {
+			continue
+		}
+
+		cost := p.Breakdown.TotalMonthlyCost
+		if p.ReportCurrencyTotals != nil {
+			cost = p.ReportCurrencyTotals.TotalMonthlyCost
+		}
+
+		metric("infracost_project_monthly_cost", fmt.Sprintf("{project=%q,currency=%q}", p.Name, out.Currency), cost)
+	}
+
+	nonCompliant := 0
+	for _, t := range out.TagPolicies {
+		nonCompliant += len(t.NonCompliantResources)
+	}
+
+	fmt.Fprintf(&b, "infracost_guardrail_violations %d\n", len(out.GuardrailViolations))
+	fmt.Fprintf(&b, "infracost_tag_policy_non_compliant_resources %d\n", nonCompliant)
+
+	return []byte(b.String())
+}
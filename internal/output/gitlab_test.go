@@ -0,0 +1,75 @@
+package output
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/infracost/infracost/internal/schema"
+)
+
+func TestToGitLabMetrics(t *testing.T) {
+	out := Root{
+		Currency:             "EUR",
+		TotalMonthlyCost:     decimalPtr(decimal.RequireFromString("1234.567")),
+		DiffTotalMonthlyCost: decimalPtr(decimal.NewFromInt(200)),
+		Projects: []Project{
+			{Name: "infracost/prod", Breakdown: &Breakdown{TotalMonthlyCost: decimalPtr(decimal.NewFromInt(1000))}},
+			{
+				Name:                 "infracost/dev",
+				Breakdown:            &Breakdown{TotalMonthlyCost: decimalPtr(decimal.NewFromInt(300))},
+				ReportCurrencyTotals: &ReportCurrencyTotals{Currency: "EUR", TotalMonthlyCost: decimalPtr(decimal.NewFromInt(234))},
+			},
+		},
+		GuardrailViolations: []GuardrailViolation{{Guardrail: "prod", ProjectName: "infracost/prod"}},
+	}
+
+	assert.Equal(t, `infracost_total_monthly_cost{currency="EUR"} 1234.57
+infracost_diff_total_monthly_cost{currency="EUR"} 200
+infracost_project_monthly_cost{project="infracost/prod",currency="EUR"} 1000
+infracost_project_monthly_cost{project="infracost/dev",currency="EUR"} 234
+infracost_guardrail_violations 1
+infracost_tag_policy_non_compliant_resources 0
+`, string(ToGitLabMetrics(out)))
+}
+
+func TestToGitLabCodeQuality(t *testing.T) {
+	violation := GuardrailViolation{
+		Guardrail:   "Instance limit",
+		ProjectName: "infracost/prod",
+		Address:     "aws_instance.web",
+		Limit:       "max_monthly_cost",
+		Message:     "monthly cost $400 is over the guardrail of $350",
+		Blocking:    true,
+		SourceRange: &schema.SourceRange{Filename: "prod/main.tf", StartLine: 12, EndLine: 20},
+	}
+
+	b, err := ToGitLabCodeQuality(Root{Currency: "USD", GuardrailViolations: []GuardrailViolation{violation}}, 10)
+	require.NoError(t, err)
+
+	var issues []gitlabCodeQualityIssue
+	require.NoError(t, json.Unmarshal(b, &issues))
+	require.Len(t, issues, 1)
+
+	assert.Equal(t, "Guardrail: Instance limit: aws_instance.web monthly cost $400 is over the guardrail of $350 (max_monthly_cost)", issues[0].Description)
+	assert.Equal(t, "infracost/guardrail", issues[0].CheckName)
+	assert.Equal(t, "major", issues[0].Severity)
+	assert.Equal(t, gitlabCodeQualityLocation{Path: "prod/main.tf", Lines: gitlabCodeQualityLines{Begin: 12, End: 20}}, issues[0].Location)
+
+	// The fingerprint doesn't change with the message so the finding is tracked
+	// across pipelines.
+	violation.Message = "monthly cost $500 is over the guardrail of $350"
+	b, err = ToGitLabCodeQuality(Root{Currency: "USD", GuardrailViolations: []GuardrailViolation{violation}}, 10)
+	require.NoError(t, err)
+
+	var updated []gitlabCodeQualityIssue
+	require.NoError(t, json.Unmarshal(b, &updated))
+	assert.Equal(t, issues[0].Fingerprint, updated[0].Fingerprint)
+
+	b, err = ToGitLabCodeQuality(Root{}, 10)
+	require.NoError(t, err)
+	assert.Equal(t, "[]", string(b))
+}
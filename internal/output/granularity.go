@@ -0,0 +1,232 @@
+package output
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/jedib0t/go-pretty/v6/text"
+	"github.com/shopspring/decimal"
+
+	"github.com/infracost/infracost/internal/ui"
+)
+
+// The time periods the costs of the table output can be shown for.
+const (
+	GranularityHourly  = "hourly"
+	GranularityDaily   = "daily"
+	GranularityMonthly = "monthly"
+	GranularityAnnual  = "annual"
+)
+
+// Granularities are the valid values of Options.Granularity.
+var Granularities = []string{GranularityHourly, GranularityDaily, GranularityMonthly, GranularityAnnual}
+
+// granularityHours are the hours in the period of each granularity. Monthly
+// costs and quantities are converted using the 730 hours a month of the prices.
+var granularityHours = map[string]decimal.Decimal{
+	GranularityHourly:  decimal.NewFromInt(1),
+	GranularityDaily:   decimal.NewFromInt(24),
+	GranularityMonthly: decimal.NewFromInt(730),
+	GranularityAnnual:  decimal.NewFromInt(8760),
+}
+
+var hoursInMonth = decimal.NewFromInt(730)
+
+// granularityTitle returns the title of the period of the granularity used in
+// column titles, e.g. Annual for Annual Cost.
+func granularityTitle(granularity string) string {
+	if _, ok := granularityHours[granularity]; !ok || granularity == "" {
+		return "Monthly"
+	}
+
+	return strings.ToUpper(granularity[:1]) + granularity[1:]
+}
+
+// withGranularity returns a copy of the output with the monthly costs and
+// quantities of the breakdowns converted to the period of the granularity.
+func (r Root) withGranularity(granularity string) Root {
+	hours, ok := granularityHours[granularity]
+	if !ok || granularity == GranularityMonthly {
+		return r
+	}
+
+	r.TotalMonthlyCost = scaleDecimal(r.TotalMonthlyCost, hours)
+	r.PastTotalMonthlyCost = scaleDecimal(r.PastTotalMonthlyCost, hours)
+	r.DiffTotalMonthlyCost = scaleDecimal(r.DiffTotalMonthlyCost, hours)
+
+	projects := make([]Project, len(r.Projects))
+	for i, p := range r.Projects {
+		p.Breakdown = scaleBreakdown(p.Breakdown, hours)
+		p.PastBreakdown = scaleBreakdown(p.PastBreakdown, hours)
+		p.Diff = scaleBreakdown(p.Diff, hours)
+		projects[i] = p
+	}
+	r.Projects = projects
+
+	groups := make([]CostGroup, len(r.CostGroups))
+	for i, g := range r.CostGroups {
+		g.TotalMonthlyCost = scaleDecimal(g.TotalMonthlyCost, hours)
+		groups[i] = g
+	}
+	r.CostGroups = groups
+
+	projectGroups := make([]ProjectGroup, len(r.ProjectGroups))
+	for i, g := range r.ProjectGroups {
+		g.TotalMonthlyCost = scaleDecimal(g.TotalMonthlyCost, hours)
+		g.PastTotalMonthlyCost = scaleDecimal(g.PastTotalMonthlyCost, hours)
+		g.DiffTotalMonthlyCost = scaleDecimal(g.DiffTotalMonthlyCost, hours)
+		projectGroups[i] = g
+	}
+	r.ProjectGroups = projectGroups
+
+	owners := make([]OwnerCost, len(r.Owners))
+	for i, o := range r.Owners {
+		o.TotalMonthlyCost = scaleDecimal(o.TotalMonthlyCost, hours)
+		o.PastTotalMonthlyCost = scaleDecimal(o.PastTotalMonthlyCost, hours)
+		o.DiffTotalMonthlyCost = scaleDecimal(o.DiffTotalMonthlyCost, hours)
+		owners[i] = o
+	}
+	r.Owners = owners
+
+	return r
+}
+
+func scaleBreakdown(b *Breakdown, hours decimal.Decimal) *Breakdown {
+	if b == nil {
+		return nil
+	}
+
+	return &Breakdown{
+		Resources:        scaleResources(b.Resources, hours),
+		TotalHourlyCost:  b.TotalHourlyCost,
+		TotalMonthlyCost: scaleDecimal(b.TotalMonthlyCost, hours),
+	}
+}
+
+func scaleResources(resources []Resource, hours decimal.Decimal) []Resource {
+	if resources == nil {
+		return nil
+	}
+
+	scaled := make([]Resource, len(resources))
+	for i, r := range resources {
+		r.MonthlyCost = scaleDecimal(r.MonthlyCost, hours)
+
+		components := make([]CostComponent, len(r.CostComponents))
+		for j, c := range r.CostComponents {
+			c.MonthlyQuantity = scaleDecimal(c.MonthlyQuantity, hours)
+			c.MonthlyCost = scaleDecimal(c.MonthlyCost, hours)
+			components[j] = c
+		}
+		r.CostComponents = components
+
+		r.SubResources = scaleResources(r.SubResources, hours)
+		scaled[i] = r
+	}
+
+	return scaled
+}
+
+func scaleDecimal(d *decimal.Decimal, hours decimal.Decimal) *decimal.Decimal {
+	if d == nil {
+		return nil
+	}
+
+	return decimalPtr(d.Mul(hours).Div(hoursInMonth))
+}
+
+// Projection is the monthly cost of the run projected over a number of months,
+// with the costs growing by a percentage each month, e.g. as usage ramps up.
+type Projection struct {
+	Months        int               `json:"months"`
+	GrowthPercent float64           `json:"growthPercent"`
+	MonthlyCosts  []ProjectionMonth `json:"monthlyCosts"`
+	TotalCost     *decimal.Decimal  `json:"totalCost"`
+}
+
+// ProjectionMonth is the projected cost of a month of a Projection.
+type ProjectionMonth struct {
+	Month          int              `json:"month"`
+	MonthlyCost    *decimal.Decimal `json:"monthlyCost"`
+	CumulativeCost *decimal.Decimal `json:"cumulativeCost"`
+}
+
+// AddProjection projects the total monthly cost of the output over the months,
+// growing by growthPercent each month. The first month is the current cost.
+func AddProjection(r *Root, months int, growthPercent float64) {
+	if months <= 0 {
+		return
+	}
+
+	growth := decimal.NewFromInt(1).Add(decimal.NewFromFloat(growthPercent).Div(decimal.NewFromInt(100)))
+
+	monthly := decimal.Zero
+	if r.TotalMonthlyCost != nil {
+		monthly = *r.TotalMonthlyCost
+	}
+
+	p := &Projection{
+		Months:        months,
+		GrowthPercent: growthPercent,
+		MonthlyCosts:  make([]ProjectionMonth, 0, months),
+	}
+
+	cumulative := decimal.Zero
+	for month := 1; month <= months; month++ {
+		cumulative = cumulative.Add(monthly)
+		p.MonthlyCosts = append(p.MonthlyCosts, ProjectionMonth{
+			Month:          month,
+			MonthlyCost:    decimalPtr(monthly.Round(2)),
+			CumulativeCost: decimalPtr(cumulative.Round(2)),
+		})
+
+		monthly = monthly.Mul(growth)
+	}
+
+	p.TotalCost = decimalPtr(cumulative.Round(2))
+	r.Projection = p
+}
+
+// projectionToTable shows the projected cost of each month and the cumulative
+// cost, so the cost of a ramp-up can be budgeted for.
+func projectionToTable(out Root) string {
+	p := out.Projection
+	if p == nil || len(p.MonthlyCosts) == 0 {
+		return ""
+	}
+
+	t := table.NewWriter()
+	t.Style().Options.DrawBorder = false
+	t.Style().Options.SeparateColumns = false
+	t.Style().Options.SeparateRows = false
+	t.Style().Options.SeparateHeader = false
+	t.Style().Format.Header = text.FormatDefault
+
+	t.AppendHeader(table.Row{
+		ui.UnderlineString("Month"),
+		ui.UnderlineString(formatTitleWithCurrency("Monthly Cost", out.Currency)),
+		ui.UnderlineString(formatTitleWithCurrency("Cumulative Cost", out.Currency)),
+	})
+	t.SetColumnConfigs([]table.ColumnConfig{
+		{Number: 1, Align: text.AlignLeft},
+		{Number: 2, Align: text.AlignRight, AlignHeader: text.AlignRight},
+		{Number: 3, Align: text.AlignRight, AlignHeader: text.AlignRight},
+	})
+
+	for _, m := range p.MonthlyCosts {
+		t.AppendRow(table.Row{m.Month, formatCost2DP(out.Currency, m.MonthlyCost), formatCost2DP(out.Currency, m.CumulativeCost)})
+	}
+
+	title := fmt.Sprintf("Projected cost over %d months", p.Months)
+	if p.GrowthPercent != 0 {
+		title += fmt.Sprintf(" with %s%% monthly growth", decimal.NewFromFloat(p.GrowthPercent).String())
+	}
+
+	return fmt.Sprintf("──────────────────────────────────\n%s\n\n%s\n\n%s %s",
+		ui.BoldString(title+":"),
+		t.Render(),
+		ui.BoldString("Total:"),
+		formatCost2DP(out.Currency, p.TotalCost),
+	)
+}
@@ -0,0 +1,81 @@
+package output
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithGranularity(t *testing.T) {
+	r := Root{
+		TotalMonthlyCost: decimalPtr(decimal.NewFromInt(73)),
+		Projects: []Project{
+			{
+				Name: "infracost/infracost/examples",
+				Breakdown: &Breakdown{
+					TotalMonthlyCost: decimalPtr(decimal.NewFromInt(73)),
+					Resources: []Resource{
+						{
+							Name:        "aws_instance.web",
+							MonthlyCost: decimalPtr(decimal.NewFromInt(73)),
+							CostComponents: []CostComponent{
+								{
+									Name:            "Instance usage",
+									MonthlyQuantity: decimalPtr(decimal.NewFromInt(730)),
+									MonthlyCost:     decimalPtr(decimal.NewFromInt(73)),
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	annual := r.withGranularity(GranularityAnnual)
+	assert.Equal(t, "876", annual.TotalMonthlyCost.String())
+	c := annual.Projects[0].Breakdown.Resources[0].CostComponents[0]
+	assert.Equal(t, "8760", c.MonthlyQuantity.String())
+	assert.Equal(t, "876", c.MonthlyCost.String())
+
+	daily := r.withGranularity(GranularityDaily)
+	assert.Equal(t, "2.4", daily.Projects[0].Breakdown.TotalMonthlyCost.String())
+
+	// The original output isn't changed.
+	assert.Equal(t, "73", r.Projects[0].Breakdown.Resources[0].CostComponents[0].MonthlyCost.String())
+	assert.Equal(t, r, r.withGranularity(GranularityMonthly))
+}
+
+func TestGranularityTitle(t *testing.T) {
+	assert.Equal(t, "Monthly", granularityTitle(""))
+	assert.Equal(t, "Annual", granularityTitle(GranularityAnnual))
+	assert.Equal(t, "Hourly", granularityTitle(GranularityHourly))
+}
+
+func TestAddProjection(t *testing.T) {
+	r := Root{Currency: "USD", TotalMonthlyCost: decimalPtr(decimal.NewFromInt(100))}
+
+	AddProjection(&r, 3, 10)
+	require.NotNil(t, r.Projection)
+	require.Len(t, r.Projection.MonthlyCosts, 3)
+
+	assert.Equal(t, "100", r.Projection.MonthlyCosts[0].MonthlyCost.String())
+	assert.Equal(t, "110", r.Projection.MonthlyCosts[1].MonthlyCost.String())
+	assert.Equal(t, "121", r.Projection.MonthlyCosts[2].MonthlyCost.String())
+	assert.Equal(t, "331", r.Projection.MonthlyCosts[2].CumulativeCost.String())
+	assert.Equal(t, "331", r.Projection.TotalCost.String())
+
+	s := projectionToTable(r)
+	assert.Contains(t, s, "Projected cost over 3 months with 10% monthly growth:")
+	assert.Contains(t, s, "331.00")
+}
+
+func TestAddProjectionNoMonths(t *testing.T) {
+	r := Root{TotalMonthlyCost: decimalPtr(decimal.NewFromInt(100))}
+
+	AddProjection(&r, 0, 10)
+	assert.Nil(t, r.Projection)
+	assert.Equal(t, "", projectionToTable(r))
+}
@@ -0,0 +1,81 @@
+package output
+
+import (
+	"fmt"
+
+	"github.com/infracost/infracost/internal/schema"
+	"github.com/infracost/infracost/internal/ui"
+)
+
+// GuardrailViolation is a limit of a guardrail of the config file that the costs
+// of a project, module or resource exceed.
+type GuardrailViolation struct {
+	Guardrail   string `json:"guardrail"`
+	Scope       string `json:"scope"`
+	ProjectName string `json:"projectName"`
+	// Address is the address of the module or resource, it's empty for the
+	// project scope.
+	Address string `json:"address,omitempty"`
+	// Limit is the name of the exceeded limit, e.g. max_monthly_cost.
+	Limit   string `json:"limit"`
+	Message string `json:"message"`
+	// Blocking is true if the violation fails the run.
+	Blocking bool `json:"blocking"`
+	// SourceRange is where the resource is defined for the resource scope, if
+	// it's known.
+	SourceRange *schema.SourceRange `json:"sourceRange,omitempty"`
+}
+
+// Subject returns what exceeded the limit, the address of the module or
+// resource, or the name of the project.
+func (v GuardrailViolation) Subject() string {
+	if v.Address != "" {
+		return v.Address
+	}
+
+	return v.ProjectName
+}
+
+// BlockingGuardrailViolations returns the number of violations that fail the run.
+func (r *Root) BlockingGuardrailViolations() int {
+	n := 0
+	for _, v := range r.GuardrailViolations {
+		if v.Blocking {
+			n++
+		}
+	}
+
+	return n
+}
+
+// guardrailViolationsToTable lists the guardrail violations with the guardrail
+// they violate.
+func guardrailViolationsToTable(out Root) string {
+	if len(out.GuardrailViolations) == 0 {
+		return ""
+	}
+
+	s := "──────────────────────────────────\n"
+
+	if len(out.GuardrailViolations) == 1 {
+		s += ui.BoldString("1 guardrail limit exceeded:")
+	} else {
+		s += ui.BoldString(fmt.Sprintf("%d guardrail limits exceeded:", len(out.GuardrailViolations)))
+	}
+
+	for _, v := range out.GuardrailViolations {
+		status := ui.WarningString("warn")
+		if v.Blocking {
+			status = ui.ErrorString("block")
+		}
+
+		source := v.Guardrail
+		if v.Address != "" && len(out.Projects) > 1 {
+			source += ", " + v.ProjectName
+		}
+
+		s += fmt.Sprintf("\n∙ %s %s: %s %s", status, v.Subject(), v.Message, ui.FaintStringf("(%s)", source))
+	}
+
+	return s
+}
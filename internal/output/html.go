@@ -8,7 +8,6 @@ import (
 	"strings"
 
 	"github.com/infracost/infracost/internal/ui"
-	"github.com/shopspring/decimal"
 
 	"github.com/Masterminds/sprig"
 
@@ -42,10 +41,12 @@ func ToHTML(out Root, opts Options) ([]byte, error) {
 		},
 		"filterZeroValComponents": filterZeroValComponents,
 		"filterZeroValResources":  filterZeroValResources,
-		"formatCost2DP":           func(d *decimal.Decimal) string { return formatCost2DP(out.Currency, d) },
-		"formatPrice":             func(d decimal.Decimal) string { return formatPrice(out.Currency, d) },
-		"formatTitleWithCurrency": func(title string) string { return formatTitleWithCurrency(title, out.Currency) },
+		"formatCost2DP":           formatCost2DP,
+		"formatPrice":             formatPrice,
+		"formatTitleWithCurrency": formatTitleWithCurrency,
 		"formatQuantity":          formatQuantity,
+		"formatPriceTier":         formatPriceTier,
+		"projectCurrency":         out.projectCurrency,
 		"projectLabel": func(p Project) string {
 			return p.Label(opts.DashboardEnabled)
 		},
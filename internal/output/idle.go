@@ -0,0 +1,71 @@
+package output
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/infracost/infracost/internal/ui"
+)
+
+// IdleResource is a resource in a Terraform state that's likely idle or
+// orphaned, e.g. an EBS volume that isn't attached to an instance.
+type IdleResource struct {
+	// Check is the ID of the check that found it, e.g. aws_unattached_ebs_volume.
+	Check       string `json:"check"`
+	ProjectName string `json:"projectName"`
+	Address     string `json:"address"`
+	Reason      string `json:"reason"`
+	// MonthlyCost is what the resource is still billed while it's idle, in the
+	// currency of the report.
+	MonthlyCost decimal.Decimal `json:"monthlyCost"`
+}
+
+// SortIdleResources sorts the idle resources by their monthly cost, largest
+// first, then by project and address.
+func SortIdleResources(resources []IdleResource) []IdleResource {
+	sort.SliceStable(resources, func(i, j int) bool {
+		if !resources[i].MonthlyCost.Equal(resources[j].MonthlyCost) {
+			return resources[i].MonthlyCost.GreaterThan(resources[j].MonthlyCost)
+		}
+		if resources[i].ProjectName != resources[j].ProjectName {
+			return resources[i].ProjectName < resources[j].ProjectName
+		}
+		return resources[i].Address < resources[j].Address
+	})
+
+	return resources
+}
+
+// idleResourcesToTable lists the idle resources with the largest costs and the
+// total cost of all of them.
+func idleResourcesToTable(out Root) string {
+	if len(out.IdleResources) == 0 {
+		return ""
+	}
+
+	total := decimal.Zero
+	for _, r := range out.IdleResources {
+		total = total.Add(r.MonthlyCost)
+	}
+
+	s := "──────────────────────────────────\n"
+	s += ui.BoldString(fmt.Sprintf("Idle resources (%s/month):", formatCost(out.Currency, &total)))
+
+	for i, r := range out.IdleResources {
+		if i == maxRecommendations {
+			s += ui.FaintStringf("\n∙ and %d more, see the JSON output", len(out.IdleResources)-maxRecommendations)
+			break
+		}
+
+		cost := r.MonthlyCost
+		s += fmt.Sprintf("\n∙ %s: %s %s", r.Address, r.Reason, ui.FaintStringf("(%s/month)", formatCost(out.Currency, &cost)))
+
+		if len(out.Projects) > 1 {
+			s += ui.FaintStringf(" (%s)", r.ProjectName)
+		}
+	}
+
+	return s
+}
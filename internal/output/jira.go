@@ -0,0 +1,74 @@
+package output
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/infracost/infracost/internal/ui"
+)
+
+// jiraDiffLimit is the longest diff in a Jira issue description, which is
+// limited to 32,767 characters.
+const jiraDiffLimit = 20000
+
+// ToJiraDescription outputs the guardrail violations and cost change of the
+// projects as the Jira wiki markup of an issue description.
+func ToJiraDescription(out Root, opts Options) ([]byte, error) {
+	diff, err := ToDiff(out, opts)
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to generate diff")
+	}
+
+	var b strings.Builder
+
+	s := newChatSummary(out)
+	b.WriteString(fmt.Sprintf("*%s*\n", s.Title))
+	if s.URL != "" {
+		b.WriteString(fmt.Sprintf("[View the changes|%s]\n", s.URL))
+	}
+
+	if len(out.GuardrailViolations) > 0 {
+		b.WriteString("\nh3. Guardrail violations\n")
+		b.WriteString("||Guardrail||Project||Exceeded by||Message||Blocking||\n")
+
+		for _, v := range out.GuardrailViolations {
+			blocking := "no"
+			if v.Blocking {
+				blocking = "yes"
+			}
+
+			b.WriteString(fmt.Sprintf("|%s|%s|%s|%s|%s|\n", jiraCell(v.Guardrail), jiraCell(v.ProjectName), jiraCell(v.Subject()), jiraCell(v.Message), blocking))
+		}
+	}
+
+	b.WriteString("\nh3. Monthly cost change\n")
+	b.WriteString("||Project||Monthly cost||Change||\n")
+
+	for _, p := range out.Projects {
+		totals := p.reportCurrencyTotals()
+
+		change := formatCostChange(out.Currency, totals.DiffTotalMonthlyCost)
+		if change == "" {
+			change = "-"
+		}
+
+		b.WriteString(fmt.Sprintf("|%s|%s|%s|\n", jiraCell(p.Name), formatCost(out.Currency, totals.TotalMonthlyCost), change))
+	}
+
+	diffMsg := truncateMiddle(ui.StripColor(string(diff)), jiraDiffLimit, "\n\n...(truncated due to Jira description length)...\n\n")
+	b.WriteString(fmt.Sprintf("\nh3. Infracost output\n{noformat}\n%s\n{noformat}\n", strings.TrimSpace(diffMsg)))
+
+	return []byte(b.String()), nil
+}
+
+// jiraCell escapes the text of a Jira table cell, whose columns are separated
+// by pipes.
+func jiraCell(s string) string {
+	if s == "" {
+		return " "
+	}
+
+	return strings.ReplaceAll(s, "|", "\\|")
+}
@@ -0,0 +1,23 @@
+package output
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestToJiraDescription(t *testing.T) {
+	out := chatMessageTestOutput()
+	out.GuardrailViolations[0].Message = "monthly cost $420 is over the limit of $400 | prod"
+	out.GuardrailViolations[0].Blocking = true
+
+	b, err := ToJiraDescription(out, Options{NoColor: true})
+	require.NoError(t, err)
+
+	s := string(b)
+	assert.Contains(t, s, "*Infracost estimate: monthly cost will increase by $120 (+32%) ↑*\n[View the changes|https://github.com/infracost/infracost/pull/3]\n")
+	assert.Contains(t, s, "||Guardrail||Project||Exceeded by||Message||Blocking||\n|prod|infracost/prod|infracost/prod|monthly cost $420 is over the limit of $400 \\| prod|yes|\n")
+	assert.Contains(t, s, "|infracost/prod|$420|+$120|\n|infracost/dev|$80.00|$0.00|\n")
+	assert.Contains(t, s, "h3. Infracost output\n{noformat}\n")
+}
@@ -0,0 +1,153 @@
+package output
+
+import (
+	"encoding/xml"
+	"fmt"
+	"strings"
+
+	"github.com/shopspring/decimal"
+)
+
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	TestCases []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+	SystemOut string        `xml:"system-out,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Text    string `xml:",chardata"`
+}
+
+// ToJUnit outputs the projects as a JUnit XML report, e.g. for the Jenkins JUnit
+// plugin. Each project is a test suite whose test cases are its monthly cost,
+// its guardrail violations and its tag policies. The blocking violations and
+// tag policies are failures, the others pass with their messages as output so
+// they don't fail the build.
+func ToJUnit(out Root, opts Options) ([]byte, error) {
+	report := junitTestSuites{Name: "Infracost"}
+
+	for _, p := range out.Projects {
+		suite := junitTestSuite{Name: p.Name}
+
+		suite.TestCases = append(suite.TestCases, junitTestCase{
+			Name:      "Monthly cost",
+			ClassName: p.Name,
+			SystemOut: junitProjectCost(out.Currency, p),
+		})
+
+		for _, v := range out.GuardrailViolations {
+			if v.ProjectName != p.Name {
+				continue
+			}
+
+			message := fmt.Sprintf("%s %s (%s)", v.Subject(), v.Message, v.Limit)
+			tc := junitTestCase{
+				Name:      fmt.Sprintf("Guardrail: %s: %s", v.Guardrail, v.Subject()),
+				ClassName: p.Name,
+			}
+			if v.Blocking {
+				tc.Failure = &junitFailure{Message: message, Type: sarifRuleGuardrail, Text: message}
+			} else {
+				tc.SystemOut = "Warning: " + message
+			}
+
+			suite.TestCases = append(suite.TestCases, tc)
+		}
+
+		for _, t := range out.TagPolicies {
+			if t.ProjectName != p.Name {
+				continue
+			}
+
+			tc := junitTestCase{
+				Name:      "Tag policy: " + t.Name,
+				ClassName: p.Name,
+			}
+
+			if len(t.NonCompliantResources) == 0 {
+				tc.SystemOut = fmt.Sprintf("All %d resources comply", t.TotalResources)
+			} else {
+				message := fmt.Sprintf("%d of %d resources don't comply, %s/month is untagged spend", len(t.NonCompliantResources), t.TotalResources, formatCost(out.Currency, t.UntaggedMonthlyCost))
+
+				var lines []string
+				for _, r := range t.NonCompliantResources {
+					lines = append(lines, fmt.Sprintf("%s has %s", r.Address, tagPolicyProblems(r)))
+				}
+
+				if t.Blocking {
+					tc.Failure = &junitFailure{Message: message, Type: sarifRuleTagPolicy, Text: strings.Join(lines, "\n")}
+				} else {
+					tc.SystemOut = fmt.Sprintf("Warning: %s\n%s", message, strings.Join(lines, "\n"))
+				}
+			}
+
+			suite.TestCases = append(suite.TestCases, tc)
+		}
+
+		for _, tc := range suite.TestCases {
+			if tc.Failure != nil {
+				suite.Failures++
+			}
+		}
+		suite.Tests = len(suite.TestCases)
+
+		report.Tests += suite.Tests
+		report.Failures += suite.Failures
+		report.Suites = append(report.Suites, suite)
+	}
+
+	b, err := xml.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	return append([]byte(xml.Header), b...), nil
+}
+
+// junitProjectCost describes the monthly cost of the project, in the currency
+// of the report, and how it changes if it has a diff.
+func junitProjectCost(currency string, p Project) string {
+	if p.Breakdown == nil {
+		return ""
+	}
+
+	cost := p.Breakdown.TotalMonthlyCost
+
+	var past, diff *decimal.Decimal
+	if p.PastBreakdown != nil {
+		past = p.PastBreakdown.TotalMonthlyCost
+	}
+	if p.Diff != nil {
+		diff = p.Diff.TotalMonthlyCost
+	}
+
+	if p.ReportCurrencyTotals != nil {
+		cost = p.ReportCurrencyTotals.TotalMonthlyCost
+		past = p.ReportCurrencyTotals.PastTotalMonthlyCost
+		diff = p.ReportCurrencyTotals.DiffTotalMonthlyCost
+	}
+
+	if diff == nil || past == nil {
+		return fmt.Sprintf("Monthly cost is %s", formatCost(currency, cost))
+	}
+
+	return fmt.Sprintf("Monthly cost will change by %s%s", formatCostChange(currency, diff), formatCostChangeDetails(currency, past, cost))
+}
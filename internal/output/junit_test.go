@@ -0,0 +1,92 @@
+package output
+
+import (
+	"encoding/xml"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestToJUnit(t *testing.T) {
+	cost := decimal.NewFromInt(120)
+
+	out := Root{
+		Currency: "USD",
+		Projects: []Project{
+			{
+				Name:          "infracost/prod",
+				Breakdown:     &Breakdown{TotalMonthlyCost: decimalPtr(decimal.NewFromInt(500))},
+				PastBreakdown: &Breakdown{TotalMonthlyCost: decimalPtr(decimal.NewFromInt(380))},
+				Diff:          &Breakdown{TotalMonthlyCost: decimalPtr(decimal.NewFromInt(120))},
+			},
+			{
+				Name:      "infracost/dev",
+				Breakdown: &Breakdown{TotalMonthlyCost: decimalPtr(decimal.NewFromInt(30))},
+			},
+		},
+		GuardrailViolations: []GuardrailViolation{
+			{
+				Guardrail:   "Instance limit",
+				ProjectName: "infracost/prod",
+				Address:     "aws_instance.web",
+				Limit:       "max_monthly_cost",
+				Message:     "monthly cost $120 is over the guardrail of $100",
+				Blocking:    true,
+			},
+			{
+				Guardrail:   "prod",
+				ProjectName: "infracost/prod",
+				Limit:       "max_monthly_increase",
+				Message:     "monthly cost increase $120 is over the guardrail of $50",
+			},
+		},
+		TagPolicies: []TagPolicyResult{
+			{
+				Name:                "Cost allocation",
+				ProjectName:         "infracost/prod",
+				Blocking:            true,
+				TotalResources:      3,
+				UntaggedMonthlyCost: &cost,
+				NonCompliantResources: []TagPolicyResource{
+					{Address: "aws_instance.web", MonthlyCost: &cost, MissingTags: []string{"team"}},
+				},
+			},
+			{
+				Name:           "Cost allocation",
+				ProjectName:    "infracost/dev",
+				Blocking:       true,
+				TotalResources: 2,
+			},
+		},
+	}
+
+	b, err := ToJUnit(out, Options{})
+	require.NoError(t, err)
+
+	var report junitTestSuites
+	require.NoError(t, xml.Unmarshal(b, &report))
+
+	assert.Equal(t, 6, report.Tests)
+	assert.Equal(t, 2, report.Failures)
+	require.Len(t, report.Suites, 2)
+
+	prod := report.Suites[0]
+	assert.Equal(t, "infracost/prod", prod.Name)
+	assert.Equal(t, 4, prod.Tests)
+	assert.Equal(t, 2, prod.Failures)
+
+	assert.Equal(t, "Monthly cost will change by +$120 ($380 → $500)", prod.TestCases[0].SystemOut)
+	assert.Equal(t, "Guardrail: Instance limit: aws_instance.web", prod.TestCases[1].Name)
+	assert.Equal(t, "aws_instance.web monthly cost $120 is over the guardrail of $100 (max_monthly_cost)", prod.TestCases[1].Failure.Message)
+	assert.Nil(t, prod.TestCases[2].Failure)
+	assert.Equal(t, "Warning: infracost/prod monthly cost increase $120 is over the guardrail of $50 (max_monthly_increase)", prod.TestCases[2].SystemOut)
+	assert.Equal(t, "1 of 3 resources don't comply, $120/month is untagged spend", prod.TestCases[3].Failure.Message)
+	assert.Equal(t, "aws_instance.web has missing tags team", prod.TestCases[3].Failure.Text)
+
+	dev := report.Suites[1]
+	assert.Equal(t, 0, dev.Failures)
+	assert.Equal(t, "Monthly cost is $30.00", dev.TestCases[0].SystemOut)
+	assert.Equal(t, "All 2 resources comply", dev.TestCases[1].SystemOut)
+}
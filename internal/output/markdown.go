@@ -95,6 +95,13 @@ func ToMarkdown(out Root, opts Options, markdownOpts MarkdownOptions) ([]byte, e
 		"projectLabel": func(p Project) string {
 			return p.Label(opts.DashboardEnabled)
 		},
+		// Projects with their own currency are summarized in the currency of the report
+		"pastTotalMonthlyCost": func(p Project) *decimal.Decimal {
+			return p.reportCurrencyTotals().PastTotalMonthlyCost
+		},
+		"totalMonthlyCost": func(p Project) *decimal.Decimal {
+			return p.reportCurrencyTotals().TotalMonthlyCost
+		},
 		"truncateMiddle": truncateMiddle,
 	})
 
@@ -0,0 +1,32 @@
+package output
+
+import (
+	"fmt"
+)
+
+// ToOneLine outputs the change of the total monthly cost of the projects as a
+// single line, e.g. for pre-commit hooks where the output should stay short.
+func ToOneLine(out Root, opts Options) ([]byte, error) {
+	projects := fmt.Sprintf("%d projects", len(out.Projects))
+	if len(out.Projects) == 1 {
+		projects = "1 project"
+	}
+
+	diff := out.DiffTotalMonthlyCost
+	if diff == nil || out.PastTotalMonthlyCost == nil {
+		return []byte(fmt.Sprintf("Infracost: monthly cost is %s in %s", formatCost(out.Currency, out.TotalMonthlyCost), projects)), nil
+	}
+
+	if diff.IsZero() {
+		return []byte(fmt.Sprintf("Infracost: monthly cost won't change (%s) in %s", formatCost(out.Currency, out.TotalMonthlyCost), projects)), nil
+	}
+
+	change := "increase"
+	if diff.IsNegative() {
+		change = "decrease"
+	}
+
+	abs := diff.Abs()
+
+	return []byte(fmt.Sprintf("Infracost: monthly cost will %s by %s%s in %s", change, formatCost(out.Currency, &abs), formatCostChangeDetails(out.Currency, out.PastTotalMonthlyCost, out.TotalMonthlyCost), projects)), nil
+}
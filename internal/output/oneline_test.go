@@ -0,0 +1,38 @@
+package output
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestToOneLine(t *testing.T) {
+	out := Root{
+		Currency:             "USD",
+		Projects:             []Project{{Name: "infracost/prod"}, {Name: "infracost/dev"}},
+		TotalMonthlyCost:     decimalPtr(decimal.NewFromInt(500)),
+		PastTotalMonthlyCost: decimalPtr(decimal.NewFromInt(380)),
+		DiffTotalMonthlyCost: decimalPtr(decimal.NewFromInt(120)),
+	}
+
+	b, err := ToOneLine(out, Options{})
+	require.NoError(t, err)
+	assert.Equal(t, "Infracost: monthly cost will increase by $120 ($380 → $500) in 2 projects", string(b))
+
+	out.Projects = out.Projects[:1]
+	out.TotalMonthlyCost = decimalPtr(decimal.NewFromInt(260))
+	out.DiffTotalMonthlyCost = decimalPtr(decimal.NewFromInt(-120))
+
+	b, err = ToOneLine(out, Options{})
+	require.NoError(t, err)
+	assert.Equal(t, "Infracost: monthly cost will decrease by $120 ($380 → $260) in 1 project", string(b))
+
+	out.TotalMonthlyCost = decimalPtr(decimal.NewFromInt(380))
+	out.DiffTotalMonthlyCost = decimalPtr(decimal.Zero)
+
+	b, err = ToOneLine(out, Options{})
+	require.NoError(t, err)
+	assert.Equal(t, "Infracost: monthly cost won't change ($380) in 1 project", string(b))
+}
@@ -9,6 +9,7 @@ import (
 
 	"github.com/shopspring/decimal"
 
+	"github.com/infracost/infracost/internal/currency"
 	"github.com/infracost/infracost/internal/providers/terraform"
 	"github.com/infracost/infracost/internal/schema"
 	"github.com/infracost/infracost/internal/ui"
@@ -18,21 +19,63 @@ import (
 var outputVersion = "0.2"
 
 type Root struct {
-	Version              string           `json:"version"`
-	RunID                string           `json:"runId,omitempty"`
-	ShareURL             string           `json:"shareUrl,omitempty"`
-	Currency             string           `json:"currency"`
-	Projects             []Project        `json:"projects"`
-	TotalHourlyCost      *decimal.Decimal `json:"totalHourlyCost"`
-	TotalMonthlyCost     *decimal.Decimal `json:"totalMonthlyCost"`
-	PastTotalHourlyCost  *decimal.Decimal `json:"pastTotalHourlyCost"`
-	PastTotalMonthlyCost *decimal.Decimal `json:"pastTotalMonthlyCost"`
-	DiffTotalHourlyCost  *decimal.Decimal `json:"diffTotalHourlyCost"`
-	DiffTotalMonthlyCost *decimal.Decimal `json:"diffTotalMonthlyCost"`
-	TimeGenerated        time.Time        `json:"timeGenerated"`
-	Summary              *Summary         `json:"summary"`
-	FullSummary          *Summary         `json:"-"`
-	IsCIRun              bool             `json:"-"`
+	Version              string               `json:"version"`
+	RunID                string               `json:"runId,omitempty"`
+	ShareURL             string               `json:"shareUrl,omitempty"`
+	Currency             string               `json:"currency"`
+	ExchangeRate         *currency.Rate       `json:"exchangeRate,omitempty"`
+	Projects             []Project            `json:"projects"`
+	TotalHourlyCost      *decimal.Decimal     `json:"totalHourlyCost"`
+	TotalMonthlyCost     *decimal.Decimal     `json:"totalMonthlyCost"`
+	PastTotalHourlyCost  *decimal.Decimal     `json:"pastTotalHourlyCost"`
+	PastTotalMonthlyCost *decimal.Decimal     `json:"pastTotalMonthlyCost"`
+	DiffTotalHourlyCost  *decimal.Decimal     `json:"diffTotalHourlyCost"`
+	DiffTotalMonthlyCost *decimal.Decimal     `json:"diffTotalMonthlyCost"`
+	TimeGenerated        time.Time            `json:"timeGenerated"`
+	Summary              *Summary             `json:"summary"`
+	PricingIssues        []PricingIssue       `json:"pricingIssues,omitempty"`
+	GuardrailViolations  []GuardrailViolation `json:"guardrailViolations,omitempty"`
+	TagPolicies          []TagPolicyResult    `json:"tagPolicies,omitempty"`
+	Exemptions           []Exemption          `json:"exemptions,omitempty"`
+	Recommendations      []Recommendation     `json:"recommendations,omitempty"`
+	FullSummary          *Summary             `json:"-"`
+	IsCIRun              bool                 `json:"-"`
+
+	// UsageScenarios are the total monthly costs with the low, expected and high
+	// usage of the usage files, set when they're compared.
+	UsageScenarios map[string]*decimal.Decimal `json:"usageScenarios,omitempty"`
+
+	// Projection is the total monthly cost projected over a number of months,
+	// set when a projection is requested.
+	Projection *Projection `json:"projection,omitempty"`
+
+	// CostGroups are the costs of the resources grouped by the tag of GroupBy,
+	// e.g. tag:team, set when the costs are grouped.
+	GroupBy    string      `json:"groupBy,omitempty"`
+	CostGroups []CostGroup `json:"costGroups,omitempty"`
+
+	// ProjectGroups are the subtotals of the projects grouped by the group they
+	// have in the config file, set when any project has a group.
+	ProjectGroups []ProjectGroup `json:"projectGroups,omitempty"`
+
+	// VCS is the commit and CI pipeline of the run, set for CI runs.
+	VCS *schema.VCSMetadata `json:"vcs,omitempty"`
+
+	// Rightsizing are the smaller sizes recommended for resources from the
+	// utilization in their usage.
+	Rightsizing []RightsizingRecommendation `json:"rightsizing,omitempty"`
+
+	// IdleResources are the resources of Terraform states that are likely idle
+	// or orphaned, with what they're still billed.
+	IdleResources []IdleResource `json:"idleResources,omitempty"`
+
+	// Owners are the costs of the resources of each owner, set when the
+	// resources have owners.
+	Owners []OwnerCost `json:"owners,omitempty"`
+
+	// Waivers are the waivers of the waivers file with the failures they
+	// match, set when a waivers file is used.
+	Waivers []Waiver `json:"waivers,omitempty"`
 }
 
 type Project struct {
@@ -42,7 +85,21 @@ type Project struct {
 	Breakdown     *Breakdown              `json:"breakdown"`
 	Diff          *Breakdown              `json:"diff"`
 	Summary       *Summary                `json:"summary"`
-	fullSummary   *Summary
+	PriceChanges  []PriceChange           `json:"priceChanges,omitempty"`
+
+	// UsageScenarios are the monthly costs of the project with the low, expected
+	// and high usage of its usage file, set when they're compared.
+	UsageScenarios map[string]*decimal.Decimal `json:"usageScenarios,omitempty"`
+
+	// Currency is only set when the costs of the project are in its own currency,
+	// ReportCurrencyTotals are then its totals in the currency of the report.
+	Currency             string                `json:"currency,omitempty"`
+	ReportCurrencyTotals *ReportCurrencyTotals `json:"reportCurrencyTotals,omitempty"`
+
+	fullSummary *Summary
+	// skippedResources are only set for the projects of a run, they're output
+	// with --show-skipped=json.
+	skippedResources []SkippedResource
 }
 
 var exampleProjectsRegex = regexp.MustCompile(`^infracost\/(infracost\/examples|example-terraform)\/`)
@@ -82,16 +139,38 @@ type CostComponent struct {
 	Price           decimal.Decimal  `json:"price"`
 	HourlyCost      *decimal.Decimal `json:"hourlyCost"`
 	MonthlyCost     *decimal.Decimal `json:"monthlyCost"`
+
+	PriceUnavailable bool   `json:"priceUnavailable,omitempty"`
+	PriceIssue       string `json:"priceIssue,omitempty"`
+
+	// PriceTier is only set for graduated prices when price tiers are shown
+	PriceTier *PriceTier `json:"priceTier,omitempty"`
+}
+
+// PriceTier is the range of usage, in the units of the cost component, that a
+// graduated price applies to. EndUsageAmount is null for the last tier.
+type PriceTier struct {
+	StartUsageAmount decimal.Decimal  `json:"startUsageAmount"`
+	EndUsageAmount   *decimal.Decimal `json:"endUsageAmount"`
 }
 
 type Resource struct {
 	Name           string            `json:"name"`
 	Tags           map[string]string `json:"tags,omitempty"`
+	Annotations    map[string]string `json:"annotations,omitempty"`
 	Metadata       map[string]string `json:"metadata"`
 	HourlyCost     *decimal.Decimal  `json:"hourlyCost"`
 	MonthlyCost    *decimal.Decimal  `json:"monthlyCost"`
 	CostComponents []CostComponent   `json:"costComponents,omitempty"`
 	SubResources   []Resource        `json:"subresources,omitempty"`
+
+	// SourceRange is where the resource is defined, it's only known for
+	// Terraform directories.
+	SourceRange *schema.SourceRange `json:"sourceRange,omitempty"`
+
+	// Owner is the team that owns the resource from the CODEOWNERS or owners
+	// mapping file of the run, set when one is used.
+	Owner string `json:"owner,omitempty"`
 }
 
 type Summary struct {
@@ -106,6 +185,11 @@ type Summary struct {
 	UnsupportedResourceCounts *map[string]int `json:"unsupportedResourceCounts,omitempty"`
 	NoPriceResourceCounts     *map[string]int `json:"noPriceResourceCounts,omitempty"`
 
+	// TotalPriceUnavailableResources and PriceUnavailableResourceCounts are only set
+	// when prices couldn't be looked up, see --allow-unavailable-prices.
+	TotalPriceUnavailableResources *int            `json:"totalPriceUnavailableResources,omitempty"`
+	PriceUnavailableResourceCounts *map[string]int `json:"priceUnavailableResourceCounts,omitempty"`
+
 	EstimatedUsageCounts   *map[string]int `json:"-"`
 	UnestimatedUsageCounts *map[string]int `json:"-"`
 	TotalEstimatedUsages   *int            `json:"-"`
@@ -124,6 +208,12 @@ type Options struct {
 	Fields           []string
 	IncludeHTML      bool
 	PolicyChecks     PolicyCheck
+	// Granularity is the time period the costs of the table output are shown
+	// for, one of Granularities. Costs are monthly when it's empty.
+	Granularity string
+	// ShowRightsizing adds the rightsizing recommendations to the table and diff
+	// outputs, e.g. for comments.
+	ShowRightsizing bool
 }
 
 // PolicyCheck holds information if a given run has any policy checks enabled.
@@ -197,6 +287,10 @@ func outputResource(r *schema.Resource) Resource {
 			Price:           c.UnitMultiplierPrice(),
 			HourlyCost:      c.HourlyCost,
 			MonthlyCost:     c.MonthlyCost,
+
+			PriceUnavailable: c.PriceUnavailable,
+			PriceIssue:       c.PriceIssue,
+			PriceTier:        outputPriceTier(c),
 		})
 	}
 
@@ -205,15 +299,43 @@ func outputResource(r *schema.Resource) Resource {
 		subresources = append(subresources, outputResource(s))
 	}
 
+	metadata := map[string]string{}
+	for key, reason := range r.DerivedUsage {
+		metadata["derivedUsage."+key] = reason
+	}
+
 	return Resource{
 		Name:           r.Name,
-		Metadata:       map[string]string{},
+		Metadata:       metadata,
 		Tags:           r.Tags,
+		Annotations:    r.Annotations,
 		HourlyCost:     r.HourlyCost,
 		MonthlyCost:    r.MonthlyCost,
 		CostComponents: comps,
 		SubResources:   subresources,
+		SourceRange:    r.SourceRange,
+	}
+}
+
+func outputPriceTier(c *schema.CostComponent) *PriceTier {
+	if c.PriceTier == nil {
+		return nil
+	}
+
+	m := c.UnitMultiplier
+	if m.IsZero() {
+		m = decimal.NewFromInt(1)
+	}
+
+	t := &PriceTier{
+		StartUsageAmount: c.PriceTier.StartUsageAmount.Div(m),
+	}
+
+	if c.PriceTier.EndUsageAmount != nil {
+		t.EndUsageAmount = decimalPtr(c.PriceTier.EndUsageAmount.Div(m))
 	}
+
+	return t
 }
 
 func ToOutputFormat(projects []*schema.Project) (Root, error) {
@@ -292,6 +414,8 @@ func ToOutputFormat(projects []*schema.Project) (Root, error) {
 				"TotalNoPriceResources",
 				"UnsupportedResourceCounts",
 				"NoPriceResourceCounts",
+				"TotalPriceUnavailableResources",
+				"PriceUnavailableResourceCounts",
 			},
 		})
 		if err != nil {
@@ -312,13 +436,20 @@ func ToOutputFormat(projects []*schema.Project) (Root, error) {
 			Breakdown:     breakdown,
 			Diff:          diff,
 			Summary:       summary,
+			Currency:      project.Currency,
 			fullSummary:   fullSummary,
+
+			skippedResources: skippedResources(project.Name, project.Resources),
+			UsageScenarios:   outputUsageScenarios(project),
 		})
 	}
 
 	out := Root{
 		Version:              outputVersion,
 		Projects:             outProjects,
+		PricingIssues:        pricingIssues(outProjects),
+		Exemptions:           exemptions(outProjects, time.Now()),
+		UsageScenarios:       usageScenarioTotals(outProjects),
 		TotalHourlyCost:      totalHourlyCost,
 		TotalMonthlyCost:     totalMonthlyCost,
 		PastTotalHourlyCost:  pastTotalHourlyCost,
@@ -395,6 +526,21 @@ func (r *Root) summaryMessage(showSkipped bool) string {
 		}
 	}
 
+	if r.Summary.TotalPriceUnavailableResources != nil && *r.Summary.TotalPriceUnavailableResources > 0 {
+		count := "1 has"
+		if *r.Summary.TotalPriceUnavailableResources > 1 {
+			count = fmt.Sprintf("%d have", *r.Summary.TotalPriceUnavailableResources)
+		}
+		msg += fmt.Sprintf("\n∙ %s prices that couldn't be looked up, their costs are incomplete", count)
+
+		if showSkipped {
+			msg += ":"
+			msg += formatCounts(r.Summary.PriceUnavailableResourceCounts)
+		} else {
+			msg += seeDetailsMessage
+		}
+	}
+
 	if r.Summary.TotalUnsupportedResources != nil && *r.Summary.TotalUnsupportedResources > 0 {
 		count := "1 is"
 		if *r.Summary.TotalUnsupportedResources > 1 {
@@ -454,11 +600,13 @@ func BuildSummary(resources []*schema.Resource, opts SummaryOptions) (*Summary,
 	supportedResourceCounts := make(map[string]int)
 	unsupportedResourceCounts := make(map[string]int)
 	noPriceResourceCounts := make(map[string]int)
+	priceUnavailableResourceCounts := make(map[string]int)
 	totalDetectedResources := 0
 	totalSupportedResources := 0
 	totalUnsupportedResources := 0
 	totalUsageBasedResources := 0
 	totalNoPriceResources := 0
+	totalPriceUnavailableResources := 0
 
 	estimatedUsageCounts := make(map[string]int)
 	unestimatedUsageCounts := make(map[string]int)
@@ -499,6 +647,11 @@ func BuildSummary(resources []*schema.Resource, opts SummaryOptions) (*Summary,
 			if refFile.FindMatchingResourceUsage(r.Name) != nil {
 				totalUsageBasedResources++
 			}
+
+			if r.HasPriceUnavailable() {
+				totalPriceUnavailableResources++
+				priceUnavailableResourceCounts[r.ResourceType]++
+			}
 		}
 
 		for usage, isEstimated := range r.EstimationSummary {
@@ -549,6 +702,16 @@ func BuildSummary(resources []*schema.Resource, opts SummaryOptions) (*Summary,
 		s.NoPriceResourceCounts = &noPriceResourceCounts
 	}
 
+	// Only set when prices couldn't be looked up so the output is unchanged otherwise
+	if totalPriceUnavailableResources > 0 {
+		if len(opts.OnlyFields) == 0 || contains(opts.OnlyFields, "TotalPriceUnavailableResources") {
+			s.TotalPriceUnavailableResources = &totalPriceUnavailableResources
+		}
+		if len(opts.OnlyFields) == 0 || contains(opts.OnlyFields, "PriceUnavailableResourceCounts") {
+			s.PriceUnavailableResourceCounts = &priceUnavailableResourceCounts
+		}
+	}
+
 	if len(opts.OnlyFields) == 0 || contains(opts.OnlyFields, "EstimatedUsageCounts") {
 		s.EstimatedUsageCounts = &estimatedUsageCounts
 	}
@@ -582,6 +745,8 @@ func MergeSummaries(summaries []*Summary) *Summary {
 		merged.SupportedResourceCounts = mergeCounts(merged.SupportedResourceCounts, s.SupportedResourceCounts)
 		merged.UnsupportedResourceCounts = mergeCounts(merged.UnsupportedResourceCounts, s.UnsupportedResourceCounts)
 		merged.NoPriceResourceCounts = mergeCounts(merged.NoPriceResourceCounts, s.NoPriceResourceCounts)
+		merged.TotalPriceUnavailableResources = addIntPtrs(merged.TotalPriceUnavailableResources, s.TotalPriceUnavailableResources)
+		merged.PriceUnavailableResourceCounts = mergeCounts(merged.PriceUnavailableResourceCounts, s.PriceUnavailableResourceCounts)
 
 		merged.EstimatedUsageCounts = mergeCounts(merged.EstimatedUsageCounts, s.EstimatedUsageCounts)
 		merged.UnestimatedUsageCounts = mergeCounts(merged.UnestimatedUsageCounts, s.UnestimatedUsageCounts)
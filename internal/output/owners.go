@@ -0,0 +1,188 @@
+package output
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/jedib0t/go-pretty/v6/text"
+	"github.com/shopspring/decimal"
+
+	"github.com/infracost/infracost/internal/ui"
+)
+
+// UnownedResources is the name of the owner cost of the resources that no
+// rule of the CODEOWNERS or owners mapping file matches.
+const UnownedResources = "(unowned)"
+
+// OwnerCost is the cost of the resources owned by a team, e.g. to preview what
+// the team would be charged back for a change.
+type OwnerCost struct {
+	Name                 string           `json:"name"`
+	Projects             []string         `json:"projects"`
+	Resources            int              `json:"resources"`
+	TotalMonthlyCost     *decimal.Decimal `json:"totalMonthlyCost"`
+	PastTotalMonthlyCost *decimal.Decimal `json:"pastTotalMonthlyCost"`
+	DiffTotalMonthlyCost *decimal.Decimal `json:"diffTotalMonthlyCost"`
+}
+
+// AddOwnerCosts sums the costs of the resources of the output by their owner.
+// The costs are in the currency of the report. Owners are sorted by their
+// monthly cost, with the unowned resources last. Nothing is added if none of
+// the resources have an owner.
+func AddOwnerCosts(r *Root) {
+	r.Owners = nil
+
+	owners := map[string]*OwnerCost{}
+	hasOwners := false
+
+	add := func(p Project, res Resource, past bool) {
+		name := res.Owner
+		if name == "" {
+			name = UnownedResources
+		} else {
+			hasOwners = true
+		}
+
+		o, ok := owners[name]
+		if !ok {
+			o = &OwnerCost{Name: name, Projects: []string{}}
+			owners[name] = o
+		}
+
+		if !contains(o.Projects, p.Name) {
+			o.Projects = append(o.Projects, p.Name)
+		}
+
+		rate := decimal.NewFromInt(1)
+		if p.ReportCurrencyTotals != nil {
+			rate = p.ReportCurrencyTotals.Rate
+		}
+
+		if past {
+			o.PastTotalMonthlyCost = addCost(o.PastTotalMonthlyCost, convertCost(res.MonthlyCost, rate))
+			return
+		}
+
+		o.Resources++
+		o.TotalMonthlyCost = addCost(o.TotalMonthlyCost, convertCost(res.MonthlyCost, rate))
+	}
+
+	for _, p := range r.Projects {
+		if p.Breakdown != nil {
+			for _, res := range p.Breakdown.Resources {
+				add(p, res, false)
+			}
+		}
+
+		if p.PastBreakdown != nil {
+			for _, res := range p.PastBreakdown.Resources {
+				add(p, res, true)
+			}
+		}
+	}
+
+	if !hasOwners {
+		return
+	}
+
+	r.Owners = make([]OwnerCost, 0, len(owners))
+	for _, o := range owners {
+		if o.PastTotalMonthlyCost != nil || o.TotalMonthlyCost != nil {
+			diff := decimal.Zero
+			if o.TotalMonthlyCost != nil {
+				diff = *o.TotalMonthlyCost
+			}
+			if o.PastTotalMonthlyCost != nil {
+				diff = diff.Sub(*o.PastTotalMonthlyCost)
+			}
+			o.DiffTotalMonthlyCost = &diff
+		}
+
+		r.Owners = append(r.Owners, *o)
+	}
+
+	sort.Slice(r.Owners, func(i, j int) bool {
+		a, b := r.Owners[i], r.Owners[j]
+		if (a.Name == UnownedResources) != (b.Name == UnownedResources) {
+			return b.Name == UnownedResources
+		}
+
+		ac, bc := decimal.Zero, decimal.Zero
+		if a.TotalMonthlyCost != nil {
+			ac = *a.TotalMonthlyCost
+		}
+		if b.TotalMonthlyCost != nil {
+			bc = *b.TotalMonthlyCost
+		}
+
+		if !ac.Equal(bc) {
+			return ac.GreaterThan(bc)
+		}
+
+		return a.Name < b.Name
+	})
+}
+
+// ownersToTable shows the cost of each owner so the costs can be charged back
+// to the teams, period is the title of the time period of the costs, e.g.
+// Monthly.
+func ownersToTable(out Root, period string) string {
+	if len(out.Owners) == 0 {
+		return ""
+	}
+
+	t := table.NewWriter()
+	t.Style().Options.DrawBorder = false
+	t.Style().Options.SeparateColumns = false
+	t.Style().Options.SeparateRows = false
+	t.Style().Options.SeparateHeader = false
+	t.Style().Format.Header = text.FormatDefault
+
+	t.AppendHeader(table.Row{
+		ui.UnderlineString("Owner"),
+		ui.UnderlineString("Projects"),
+		ui.UnderlineString("Resources"),
+		ui.UnderlineString(formatTitleWithCurrency(period+" Cost", out.Currency)),
+	})
+	t.SetColumnConfigs([]table.ColumnConfig{
+		{Number: 1, Align: text.AlignLeft},
+		{Number: 2, Align: text.AlignRight, AlignHeader: text.AlignRight},
+		{Number: 3, Align: text.AlignRight, AlignHeader: text.AlignRight},
+		{Number: 4, Align: text.AlignRight, AlignHeader: text.AlignRight},
+	})
+
+	for _, o := range out.Owners {
+		t.AppendRow(table.Row{o.Name, len(o.Projects), o.Resources, formatCost2DP(out.Currency, o.TotalMonthlyCost)})
+	}
+
+	return fmt.Sprintf("──────────────────────────────────\n%s\n\n%s",
+		ui.BoldString("Costs by owner:"),
+		t.Render(),
+	)
+}
+
+// ownersToDiff shows the monthly cost change of each owner.
+func ownersToDiff(out Root) string {
+	if len(out.Owners) == 0 {
+		return ""
+	}
+
+	s := "──────────────────────────────────\n"
+	s += ui.BoldString("Monthly cost change by owner:") + "\n\n"
+
+	for _, o := range out.Owners {
+		change := o.DiffTotalMonthlyCost
+		if change == nil {
+			change = decimalPtr(decimal.Zero)
+		}
+
+		s += fmt.Sprintf("%s: %s %s\n",
+			o.Name,
+			formatCostChange(out.Currency, change),
+			ui.FaintStringf("(%s → %s)", formatCost(out.Currency, o.PastTotalMonthlyCost), formatCost(out.Currency, o.TotalMonthlyCost)),
+		)
+	}
+
+	return s + "\n"
+}
@@ -0,0 +1,85 @@
+package output
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAddOwnerCosts(t *testing.T) {
+	r := Root{
+		Currency: "USD",
+		Projects: []Project{
+			{
+				Name: "infracost/infracost/examples",
+				PastBreakdown: &Breakdown{
+					Resources: []Resource{
+						{Name: "aws_instance.web", Owner: "@acme/web", MonthlyCost: decimalPtr(decimal.NewFromInt(30))},
+						{Name: "aws_instance.old", Owner: "@acme/api", MonthlyCost: decimalPtr(decimal.NewFromInt(20))},
+					},
+				},
+				Breakdown: &Breakdown{
+					Resources: []Resource{
+						{Name: "aws_instance.web", Owner: "@acme/web", MonthlyCost: decimalPtr(decimal.NewFromInt(50))},
+						{Name: "aws_instance.api", Owner: "@acme/api", MonthlyCost: decimalPtr(decimal.NewFromInt(80))},
+						{Name: "aws_nat_gateway.this", MonthlyCost: decimalPtr(decimal.NewFromInt(32))},
+					},
+				},
+			},
+			{
+				Name:                 "infracost/infracost/examples/eu",
+				Currency:             "EUR",
+				ReportCurrencyTotals: &ReportCurrencyTotals{Currency: "USD", Rate: decimal.NewFromInt(2)},
+				Breakdown: &Breakdown{
+					Resources: []Resource{
+						{Name: "aws_instance.api", Owner: "@acme/api", MonthlyCost: decimalPtr(decimal.NewFromInt(10))},
+					},
+				},
+			},
+		},
+	}
+
+	AddOwnerCosts(&r)
+	require.Len(t, r.Owners, 3)
+
+	assert.Equal(t, "@acme/api", r.Owners[0].Name)
+	assert.Equal(t, []string{"infracost/infracost/examples", "infracost/infracost/examples/eu"}, r.Owners[0].Projects)
+	assert.Equal(t, 2, r.Owners[0].Resources)
+	assert.Equal(t, "100", r.Owners[0].TotalMonthlyCost.String())
+	assert.Equal(t, "20", r.Owners[0].PastTotalMonthlyCost.String())
+	assert.Equal(t, "80", r.Owners[0].DiffTotalMonthlyCost.String())
+
+	assert.Equal(t, "@acme/web", r.Owners[1].Name)
+	assert.Equal(t, "20", r.Owners[1].DiffTotalMonthlyCost.String())
+
+	assert.Equal(t, UnownedResources, r.Owners[2].Name)
+	assert.Equal(t, "32", r.Owners[2].TotalMonthlyCost.String())
+
+	s := ownersToTable(r, "Monthly")
+	assert.Contains(t, s, "Costs by owner:")
+	assert.Contains(t, s, "100.00")
+
+	s = ownersToDiff(r)
+	assert.Contains(t, s, "Monthly cost change by owner:")
+	assert.Contains(t, s, "@acme/api: +$80")
+}
+
+func TestAddOwnerCostsWithoutOwners(t *testing.T) {
+	r := Root{
+		Projects: []Project{
+			{
+				Name: "infracost/infracost/examples",
+				Breakdown: &Breakdown{
+					Resources: []Resource{
+						{Name: "aws_instance.web", MonthlyCost: decimalPtr(decimal.NewFromInt(50))},
+					},
+				},
+			},
+		},
+	}
+
+	AddOwnerCosts(&r)
+	assert.Nil(t, r.Owners)
+}
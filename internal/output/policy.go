@@ -0,0 +1,60 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/infracost/infracost/internal/ui"
+)
+
+// PolicyReport is the JSON output of the policy checks.
+type PolicyReport struct {
+	Passed   []string `json:"passed"`
+	Failures []string `json:"failures"`
+	Summary  struct {
+		TotalPassed int `json:"totalPassed"`
+		TotalFailed int `json:"totalFailed"`
+	} `json:"summary"`
+}
+
+// ToPolicyTable lists the passed and failed policy checks.
+func ToPolicyTable(checks PolicyCheck, opts Options) ([]byte, error) {
+	var b strings.Builder
+
+	for _, msg := range checks.Failures {
+		fmt.Fprintf(&b, "%s %s\n", ui.ErrorString("FAIL"), msg)
+	}
+
+	for _, msg := range checks.Passed {
+		fmt.Fprintf(&b, "%s %s\n", ui.SuccessString("PASS"), msg)
+	}
+
+	if b.Len() > 0 {
+		b.WriteString("\n")
+	}
+
+	fmt.Fprintf(&b, "%d policy checks passed, %d failed", len(checks.Passed), len(checks.Failures))
+
+	return []byte(b.String()), nil
+}
+
+// ToPolicyJSON returns the passed and failed policy checks as JSON.
+func ToPolicyJSON(checks PolicyCheck, opts Options) ([]byte, error) {
+	report := PolicyReport{
+		Passed:   checks.Passed,
+		Failures: checks.Failures,
+	}
+
+	if report.Passed == nil {
+		report.Passed = []string{}
+	}
+	if report.Failures == nil {
+		report.Failures = []string{}
+	}
+
+	report.Summary.TotalPassed = len(checks.Passed)
+	report.Summary.TotalFailed = len(checks.Failures)
+
+	return json.Marshal(report)
+}
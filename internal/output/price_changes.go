@@ -0,0 +1,163 @@
+package output
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/infracost/infracost/internal/currency"
+	"github.com/infracost/infracost/internal/ui"
+)
+
+// PriceChange is a cost component whose unit price changed since a previous run.
+// Its cost changed because the cloud provider's price changed, not because the
+// config changed.
+type PriceChange struct {
+	ResourceName      string           `json:"resourceName"`
+	CostComponentName string           `json:"costComponentName"`
+	Unit              string           `json:"unit"`
+	PastPrice         decimal.Decimal  `json:"pastPrice"`
+	Price             decimal.Decimal  `json:"price"`
+	MonthlyCostChange *decimal.Decimal `json:"monthlyCostChange"`
+}
+
+// AddPriceChanges compares the unit prices of the cost components of out with
+// the prices of the same cost components in prior, the output of a previous run,
+// and adds the price changes to the projects of out.
+func AddPriceChanges(out *Root, prior Root) error {
+	if !strings.EqualFold(currencyOrUSD(out.Currency), currencyOrUSD(prior.Currency)) {
+		return fmt.Errorf("Prices can't be compared to a run in %s since this run is in %s", currencyOrUSD(prior.Currency), currencyOrUSD(out.Currency))
+	}
+
+	priorPrices := make(map[string]map[string]CostComponent)
+	for _, p := range prior.Projects {
+		if p.Breakdown != nil {
+			priorPrices[p.Name] = costComponentsByName(p.Breakdown.Resources, "")
+		}
+	}
+
+	for i, p := range out.Projects {
+		pastComponents, ok := priorPrices[p.Name]
+		if !ok || p.Breakdown == nil {
+			continue
+		}
+
+		out.Projects[i].PriceChanges = priceChanges(p.Breakdown.Resources, "", pastComponents)
+	}
+
+	return nil
+}
+
+func priceChanges(resources []Resource, prefix string, pastComponents map[string]CostComponent) []PriceChange {
+	changes := make([]PriceChange, 0)
+
+	for _, r := range resources {
+		name := prefix + r.Name
+
+		for _, c := range r.CostComponents {
+			past, ok := pastComponents[costComponentKey(name, c.Name)]
+			if !ok || c.PriceUnavailable || past.PriceUnavailable || c.Price.Equal(past.Price) {
+				continue
+			}
+
+			var monthlyCostChange *decimal.Decimal
+			if c.MonthlyQuantity != nil {
+				monthlyCostChange = decimalPtr(c.Price.Sub(past.Price).Mul(*c.MonthlyQuantity))
+			}
+
+			changes = append(changes, PriceChange{
+				ResourceName:      name,
+				CostComponentName: c.Name,
+				Unit:              c.Unit,
+				PastPrice:         past.Price,
+				Price:             c.Price,
+				MonthlyCostChange: monthlyCostChange,
+			})
+		}
+
+		changes = append(changes, priceChanges(r.SubResources, name+".", pastComponents)...)
+	}
+
+	return changes
+}
+
+func costComponentsByName(resources []Resource, prefix string) map[string]CostComponent {
+	m := make(map[string]CostComponent)
+
+	for _, r := range resources {
+		name := prefix + r.Name
+
+		for _, c := range r.CostComponents {
+			m[costComponentKey(name, c.Name)] = c
+		}
+
+		for k, c := range costComponentsByName(r.SubResources, name+".") {
+			m[k] = c
+		}
+	}
+
+	return m
+}
+
+func costComponentKey(resourceName, costComponentName string) string {
+	return resourceName + "\x00" + costComponentName
+}
+
+func currencyOrUSD(c string) string {
+	if c == "" {
+		return currency.USD
+	}
+
+	return c
+}
+
+// priceChangesToDiff lists the price changes of the projects so they can be
+// told apart from the cost changes caused by config changes.
+func priceChangesToDiff(out Root, opts Options) string {
+	s := ""
+
+	for _, project := range out.Projects {
+		if len(project.PriceChanges) == 0 {
+			continue
+		}
+
+		if s == "" {
+			s += "──────────────────────────────────\n"
+			s += fmt.Sprintf("%s\n\n", ui.BoldString("Price changes since the previous run, these are not caused by config changes:"))
+		}
+
+		s += fmt.Sprintf("%s %s\n\n",
+			ui.BoldString("Project:"),
+			project.Label(opts.DashboardEnabled),
+		)
+
+		projectCurrency := out.projectCurrency(project)
+		total := decimal.Zero
+
+		for _, c := range project.PriceChanges {
+			pastPrice, price := c.PastPrice, c.Price
+
+			s += fmt.Sprintf("%s %s → %s\n", opChar(UPDATED), c.ResourceName, colorizeDiffName(c.CostComponentName))
+			s += fmt.Sprintf("  %s per %s%s\n",
+				formatPriceChange(projectCurrency, price.Sub(pastPrice)),
+				c.Unit,
+				ui.FaintString(formatPriceChangeDetails(projectCurrency, &pastPrice, &price)),
+			)
+
+			if c.MonthlyCostChange != nil {
+				s += fmt.Sprintf("  %s per month\n", formatCostChange(projectCurrency, c.MonthlyCostChange))
+				total = total.Add(*c.MonthlyCostChange)
+			}
+
+			s += "\n"
+		}
+
+		s += fmt.Sprintf("%s %s\n\n",
+			ui.BoldString("Monthly cost change from price changes:"),
+			formatTitleWithCurrency(formatCostChange(projectCurrency, &total), projectCurrency),
+		)
+	}
+
+	return s
+}
@@ -0,0 +1,85 @@
+package output
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func priceChangesRoot(instancePrice, storagePrice string) Root {
+	return Root{
+		Currency: "USD",
+		Projects: []Project{
+			{
+				Name: "infracost/infracost/examples",
+				Breakdown: &Breakdown{
+					Resources: []Resource{
+						{
+							Name: "aws_instance.web",
+							CostComponents: []CostComponent{
+								{
+									Name:            "Instance usage (Linux/UNIX, on-demand, t3.medium)",
+									Unit:            "hours",
+									Price:           decimal.RequireFromString(instancePrice),
+									MonthlyQuantity: decimalPtr(decimal.NewFromInt(730)),
+								},
+							},
+							SubResources: []Resource{
+								{
+									Name: "root_block_device",
+									CostComponents: []CostComponent{
+										{
+											Name:            "Storage (general purpose SSD, gp2)",
+											Unit:            "GB",
+											Price:           decimal.RequireFromString(storagePrice),
+											MonthlyQuantity: decimalPtr(decimal.NewFromInt(50)),
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestAddPriceChanges(t *testing.T) {
+	out := priceChangesRoot("0.045", "0.1")
+
+	err := AddPriceChanges(&out, priceChangesRoot("0.0416", "0.1"))
+	require.NoError(t, err)
+
+	changes := out.Projects[0].PriceChanges
+	require.Len(t, changes, 1)
+	assert.Equal(t, "aws_instance.web", changes[0].ResourceName)
+	assert.Equal(t, "0.0416", changes[0].PastPrice.String())
+	assert.Equal(t, "0.045", changes[0].Price.String())
+	assert.Equal(t, "2.482", changes[0].MonthlyCostChange.String())
+}
+
+func TestAddPriceChangesSubResource(t *testing.T) {
+	out := priceChangesRoot("0.0416", "0.08")
+
+	err := AddPriceChanges(&out, priceChangesRoot("0.0416", "0.1"))
+	require.NoError(t, err)
+
+	changes := out.Projects[0].PriceChanges
+	require.Len(t, changes, 1)
+	assert.Equal(t, "aws_instance.web.root_block_device", changes[0].ResourceName)
+	assert.Equal(t, "-1", changes[0].MonthlyCostChange.String())
+}
+
+func TestAddPriceChangesCurrencyMismatch(t *testing.T) {
+	out := priceChangesRoot("0.045", "0.1")
+
+	prior := priceChangesRoot("0.0416", "0.1")
+	prior.Currency = "EUR"
+
+	err := AddPriceChanges(&out, prior)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "EUR")
+}
@@ -0,0 +1,96 @@
+package output
+
+import (
+	"fmt"
+
+	"github.com/infracost/infracost/internal/schema"
+	"github.com/infracost/infracost/internal/ui"
+)
+
+// PricingIssue is a cost component whose price lookup found nothing, so it's
+// priced at 0.00, or found more than one match, so its price may be wrong.
+type PricingIssue struct {
+	ProjectName       string `json:"projectName"`
+	ResourceName      string `json:"resourceName"`
+	CostComponentName string `json:"costComponentName"`
+	Issue             string `json:"issue"`
+}
+
+var pricingIssueDescriptions = map[string]string{
+	schema.PriceIssueNoProducts:       "no products found, priced at 0.00",
+	schema.PriceIssueMultipleProducts: "multiple products found, the first was used",
+	schema.PriceIssueNoPrices:         "no prices found, priced at 0.00",
+	schema.PriceIssueMultiplePrices:   "multiple prices found, the first was used",
+	schema.PriceIssueInvalidPrice:     "the price couldn't be read, priced at 0.00",
+}
+
+func (i PricingIssue) description() string {
+	if d, ok := pricingIssueDescriptions[i.Issue]; ok {
+		return d
+	}
+
+	return i.Issue
+}
+
+// pricingIssues returns the pricing issues of the planned resources of the projects.
+func pricingIssues(projects []Project) []PricingIssue {
+	var issues []PricingIssue
+
+	for _, p := range projects {
+		if p.Breakdown != nil {
+			issues = append(issues, resourcePricingIssues(p.Name, p.Breakdown.Resources, "")...)
+		}
+	}
+
+	return issues
+}
+
+func resourcePricingIssues(projectName string, resources []Resource, prefix string) []PricingIssue {
+	var issues []PricingIssue
+
+	for _, r := range resources {
+		name := prefix + r.Name
+
+		for _, c := range r.CostComponents {
+			if c.PriceIssue == "" {
+				continue
+			}
+
+			issues = append(issues, PricingIssue{
+				ProjectName:       projectName,
+				ResourceName:      name,
+				CostComponentName: c.Name,
+				Issue:             c.PriceIssue,
+			})
+		}
+
+		issues = append(issues, resourcePricingIssues(projectName, r.SubResources, name+".")...)
+	}
+
+	return issues
+}
+
+// pricingIssuesToTable lists the pricing issues so $0.00 cost components don't
+// hide real costs.
+func pricingIssuesToTable(out Root) string {
+	if len(out.PricingIssues) == 0 {
+		return ""
+	}
+
+	s := "──────────────────────────────────\n"
+
+	if len(out.PricingIssues) == 1 {
+		s += ui.BoldString("1 cost component has a pricing issue:")
+	} else {
+		s += ui.BoldString(fmt.Sprintf("%d cost components have pricing issues:", len(out.PricingIssues)))
+	}
+
+	for _, i := range out.PricingIssues {
+		s += fmt.Sprintf("\n∙ %s → %s: %s", i.ResourceName, i.CostComponentName, i.description())
+		if len(out.Projects) > 1 {
+			s += ui.FaintStringf(" (%s)", i.ProjectName)
+		}
+	}
+
+	return s
+}
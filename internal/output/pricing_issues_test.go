@@ -0,0 +1,54 @@
+package output
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/infracost/infracost/internal/schema"
+)
+
+func TestPricingIssues(t *testing.T) {
+	projects := []Project{
+		{
+			Name: "infracost/infracost/examples",
+			Breakdown: &Breakdown{
+				Resources: []Resource{
+					{
+						Name: "aws_instance.web",
+						CostComponents: []CostComponent{
+							{Name: "Instance usage"},
+						},
+						SubResources: []Resource{
+							{
+								Name: "root_block_device",
+								CostComponents: []CostComponent{
+									{Name: "Storage", PriceIssue: schema.PriceIssueNoProducts},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	issues := pricingIssues(projects)
+	require.Len(t, issues, 1)
+	assert.Equal(t, PricingIssue{
+		ProjectName:       "infracost/infracost/examples",
+		ResourceName:      "aws_instance.web.root_block_device",
+		CostComponentName: "Storage",
+		Issue:             schema.PriceIssueNoProducts,
+	}, issues[0])
+
+	s := pricingIssuesToTable(Root{Projects: projects, PricingIssues: issues})
+	assert.Contains(t, s, "1 cost component has a pricing issue:")
+	assert.Contains(t, s, "aws_instance.web.root_block_device → Storage: no products found, priced at 0.00")
+}
+
+func TestPricingIssuesNone(t *testing.T) {
+	assert.Empty(t, pricingIssues([]Project{{Name: "empty"}}))
+	assert.Equal(t, "", pricingIssuesToTable(Root{}))
+}
@@ -0,0 +1,149 @@
+package output
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/shopspring/decimal"
+)
+
+// ReportCurrencyTotals are the totals of a project whose costs are in its own
+// currency, converted to the currency of the report.
+type ReportCurrencyTotals struct {
+	Currency             string           `json:"currency"`
+	Rate                 decimal.Decimal  `json:"rate"`
+	TotalHourlyCost      *decimal.Decimal `json:"totalHourlyCost"`
+	TotalMonthlyCost     *decimal.Decimal `json:"totalMonthlyCost"`
+	PastTotalHourlyCost  *decimal.Decimal `json:"pastTotalHourlyCost"`
+	PastTotalMonthlyCost *decimal.Decimal `json:"pastTotalMonthlyCost"`
+	DiffTotalHourlyCost  *decimal.Decimal `json:"diffTotalHourlyCost"`
+	DiffTotalMonthlyCost *decimal.Decimal `json:"diffTotalMonthlyCost"`
+
+	UsageScenarios map[string]*decimal.Decimal `json:"usageScenarios,omitempty"`
+}
+
+// ConvertProjectCurrencies converts the totals of the projects that have their
+// own currency to the currency of the report, using rates keyed by the project
+// currencies, then recalculates the report totals from the converted totals.
+func ConvertProjectCurrencies(out *Root, rates map[string]decimal.Decimal) error {
+	converted := false
+
+	for i, p := range out.Projects {
+		if p.Currency == "" || strings.EqualFold(p.Currency, currencyOrUSD(out.Currency)) {
+			out.Projects[i].Currency = ""
+			continue
+		}
+
+		rate, ok := rates[strings.ToUpper(p.Currency)]
+		if !ok {
+			return fmt.Errorf("No exchange rate to convert the costs of project %s from %s to %s", p.Name, p.Currency, currencyOrUSD(out.Currency))
+		}
+
+		totals := p.totals()
+		out.Projects[i].ReportCurrencyTotals = &ReportCurrencyTotals{
+			Currency:             currencyOrUSD(out.Currency),
+			Rate:                 rate,
+			TotalHourlyCost:      convertCost(totals.TotalHourlyCost, rate),
+			TotalMonthlyCost:     convertCost(totals.TotalMonthlyCost, rate),
+			PastTotalHourlyCost:  convertCost(totals.PastTotalHourlyCost, rate),
+			PastTotalMonthlyCost: convertCost(totals.PastTotalMonthlyCost, rate),
+			DiffTotalHourlyCost:  convertCost(totals.DiffTotalHourlyCost, rate),
+			DiffTotalMonthlyCost: convertCost(totals.DiffTotalMonthlyCost, rate),
+		}
+
+		for scenario, cost := range totals.UsageScenarios {
+			if out.Projects[i].ReportCurrencyTotals.UsageScenarios == nil {
+				out.Projects[i].ReportCurrencyTotals.UsageScenarios = make(map[string]*decimal.Decimal)
+			}
+
+			out.Projects[i].ReportCurrencyTotals.UsageScenarios[scenario] = convertCost(cost, rate)
+		}
+		converted = true
+	}
+
+	if !converted {
+		return nil
+	}
+
+	out.TotalHourlyCost, out.TotalMonthlyCost = nil, nil
+	out.PastTotalHourlyCost, out.PastTotalMonthlyCost = nil, nil
+	out.DiffTotalHourlyCost, out.DiffTotalMonthlyCost = nil, nil
+
+	for _, p := range out.Projects {
+		totals := p.reportCurrencyTotals()
+
+		out.TotalHourlyCost = addCost(out.TotalHourlyCost, totals.TotalHourlyCost)
+		out.TotalMonthlyCost = addCost(out.TotalMonthlyCost, totals.TotalMonthlyCost)
+		out.PastTotalHourlyCost = addCost(out.PastTotalHourlyCost, totals.PastTotalHourlyCost)
+		out.PastTotalMonthlyCost = addCost(out.PastTotalMonthlyCost, totals.PastTotalMonthlyCost)
+		out.DiffTotalHourlyCost = addCost(out.DiffTotalHourlyCost, totals.DiffTotalHourlyCost)
+		out.DiffTotalMonthlyCost = addCost(out.DiffTotalMonthlyCost, totals.DiffTotalMonthlyCost)
+	}
+
+	out.UsageScenarios = usageScenarioTotals(out.Projects)
+
+	return nil
+}
+
+// projectCurrency returns the currency the costs of the project are in.
+func (r Root) projectCurrency(p Project) string {
+	if p.Currency != "" {
+		return p.Currency
+	}
+
+	return r.Currency
+}
+
+// totals returns the totals of the project in its own currency.
+func (p Project) totals() ReportCurrencyTotals {
+	var t ReportCurrencyTotals
+
+	if p.Breakdown != nil {
+		t.TotalHourlyCost = p.Breakdown.TotalHourlyCost
+		t.TotalMonthlyCost = p.Breakdown.TotalMonthlyCost
+	}
+
+	if p.PastBreakdown != nil {
+		t.PastTotalHourlyCost = p.PastBreakdown.TotalHourlyCost
+		t.PastTotalMonthlyCost = p.PastBreakdown.TotalMonthlyCost
+	}
+
+	if p.Diff != nil {
+		t.DiffTotalHourlyCost = p.Diff.TotalHourlyCost
+		t.DiffTotalMonthlyCost = p.Diff.TotalMonthlyCost
+	}
+
+	t.UsageScenarios = p.UsageScenarios
+
+	return t
+}
+
+// reportCurrencyTotals returns the totals of the project in the currency of the
+// report.
+func (p Project) reportCurrencyTotals() ReportCurrencyTotals {
+	if p.ReportCurrencyTotals != nil {
+		return *p.ReportCurrencyTotals
+	}
+
+	return p.totals()
+}
+
+func convertCost(d *decimal.Decimal, rate decimal.Decimal) *decimal.Decimal {
+	if d == nil {
+		return nil
+	}
+
+	return decimalPtr(d.Mul(rate))
+}
+
+func addCost(total *decimal.Decimal, d *decimal.Decimal) *decimal.Decimal {
+	if d == nil {
+		return total
+	}
+
+	if total == nil {
+		return decimalPtr(*d)
+	}
+
+	return decimalPtr(total.Add(*d))
+}
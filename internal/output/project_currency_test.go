@@ -0,0 +1,76 @@
+package output
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func currencyProject(name, currency, monthlyCost string) Project {
+	return Project{
+		Name:     name,
+		Currency: currency,
+		Breakdown: &Breakdown{
+			TotalMonthlyCost: decimalPtr(decimal.RequireFromString(monthlyCost)),
+		},
+	}
+}
+
+func TestConvertProjectCurrencies(t *testing.T) {
+	out := Root{
+		Currency: "EUR",
+		Projects: []Project{
+			currencyProject("eu", "", "100"),
+			currencyProject("uk", "GBP", "50"),
+		},
+		TotalMonthlyCost: decimalPtr(decimal.NewFromInt(150)),
+	}
+
+	err := ConvertProjectCurrencies(&out, map[string]decimal.Decimal{"GBP": decimal.RequireFromString("1.2")})
+	require.NoError(t, err)
+
+	assert.Nil(t, out.Projects[0].ReportCurrencyTotals)
+
+	totals := out.Projects[1].ReportCurrencyTotals
+	require.NotNil(t, totals)
+	assert.Equal(t, "EUR", totals.Currency)
+	assert.Equal(t, "60", totals.TotalMonthlyCost.String())
+
+	// The project's own costs are kept in its currency
+	assert.Equal(t, "50", out.Projects[1].Breakdown.TotalMonthlyCost.String())
+
+	assert.Equal(t, "160", out.TotalMonthlyCost.String())
+	assert.Nil(t, out.PastTotalMonthlyCost)
+}
+
+func TestConvertProjectCurrenciesSameCurrency(t *testing.T) {
+	out := Root{
+		Currency: "EUR",
+		Projects: []Project{
+			currencyProject("eu", "eur", "100"),
+		},
+		TotalMonthlyCost: decimalPtr(decimal.NewFromInt(100)),
+	}
+
+	err := ConvertProjectCurrencies(&out, nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, "", out.Projects[0].Currency)
+	assert.Nil(t, out.Projects[0].ReportCurrencyTotals)
+	assert.Equal(t, "100", out.TotalMonthlyCost.String())
+}
+
+func TestConvertProjectCurrenciesMissingRate(t *testing.T) {
+	out := Root{
+		Currency: "EUR",
+		Projects: []Project{
+			currencyProject("uk", "GBP", "50"),
+		},
+	}
+
+	err := ConvertProjectCurrencies(&out, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "GBP")
+}
@@ -0,0 +1,197 @@
+package output
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/jedib0t/go-pretty/v6/text"
+	"github.com/shopspring/decimal"
+
+	"github.com/infracost/infracost/internal/ui"
+)
+
+// UngroupedProjects is the name of the project group of the projects that
+// don't have a group in the config file.
+const UngroupedProjects = "(ungrouped)"
+
+// ProjectGroup is the subtotal of the projects with the same group in the
+// config file, e.g. the projects of an environment.
+type ProjectGroup struct {
+	Name                 string           `json:"name"`
+	Projects             int              `json:"projects"`
+	TotalHourlyCost      *decimal.Decimal `json:"totalHourlyCost"`
+	TotalMonthlyCost     *decimal.Decimal `json:"totalMonthlyCost"`
+	PastTotalHourlyCost  *decimal.Decimal `json:"pastTotalHourlyCost"`
+	PastTotalMonthlyCost *decimal.Decimal `json:"pastTotalMonthlyCost"`
+	DiffTotalHourlyCost  *decimal.Decimal `json:"diffTotalHourlyCost"`
+	DiffTotalMonthlyCost *decimal.Decimal `json:"diffTotalMonthlyCost"`
+}
+
+// AddProjectGroups subtotals the costs of the projects of the output by their
+// group. The costs are in the currency of the report. Groups are in the order
+// of their first project, with the ungrouped projects last. Nothing is added if
+// none of the projects have a group.
+func AddProjectGroups(r *Root) {
+	r.ProjectGroups = nil
+
+	hasGroups := false
+	for _, p := range r.Projects {
+		if projectGroup(p) != UngroupedProjects {
+			hasGroups = true
+			break
+		}
+	}
+
+	if !hasGroups {
+		return
+	}
+
+	var names []string
+	groups := map[string]*ProjectGroup{}
+
+	for _, p := range r.Projects {
+		name := projectGroup(p)
+
+		g, ok := groups[name]
+		if !ok {
+			g = &ProjectGroup{Name: name}
+			groups[name] = g
+
+			if name != UngroupedProjects {
+				names = append(names, name)
+			}
+		}
+
+		t := p.reportCurrencyTotals()
+
+		g.Projects++
+		g.TotalHourlyCost = addCost(g.TotalHourlyCost, t.TotalHourlyCost)
+		g.TotalMonthlyCost = addCost(g.TotalMonthlyCost, t.TotalMonthlyCost)
+		g.PastTotalHourlyCost = addCost(g.PastTotalHourlyCost, t.PastTotalHourlyCost)
+		g.PastTotalMonthlyCost = addCost(g.PastTotalMonthlyCost, t.PastTotalMonthlyCost)
+		g.DiffTotalHourlyCost = addCost(g.DiffTotalHourlyCost, t.DiffTotalHourlyCost)
+		g.DiffTotalMonthlyCost = addCost(g.DiffTotalMonthlyCost, t.DiffTotalMonthlyCost)
+	}
+
+	if _, ok := groups[UngroupedProjects]; ok {
+		names = append(names, UngroupedProjects)
+	}
+
+	r.ProjectGroups = make([]ProjectGroup, 0, len(names))
+	for _, name := range names {
+		r.ProjectGroups = append(r.ProjectGroups, *groups[name])
+	}
+}
+
+func projectGroup(p Project) string {
+	if p.Metadata == nil || p.Metadata.Group == "" {
+		return UngroupedProjects
+	}
+
+	return p.Metadata.Group
+}
+
+// LabelsString returns the labels of the project from the config file as
+// key=value pairs sorted by key, e.g. env=prod, team=payments.
+func (p Project) LabelsString() string {
+	if p.Metadata == nil || len(p.Metadata.Labels) == 0 {
+		return ""
+	}
+
+	keys := make([]string, 0, len(p.Metadata.Labels))
+	for k := range p.Metadata.Labels {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	pairs := make([]string, 0, len(keys))
+	for _, k := range keys {
+		pairs = append(pairs, fmt.Sprintf("%s=%s", k, p.Metadata.Labels[k]))
+	}
+
+	return strings.Join(pairs, ", ")
+}
+
+// projectHeader returns the name of the project shown above its breakdown or
+// diff, with its group and labels when they're set and a marker when its
+// estimate is from the result cache.
+func projectHeader(p Project, dashboardEnabled bool) string {
+	label := p.Label(dashboardEnabled)
+	if p.Metadata != nil && p.Metadata.Cached {
+		label += " " + ui.FaintString("(cached)")
+	}
+
+	s := fmt.Sprintf("%s %s\n", ui.BoldString("Project:"), label)
+
+	if group := projectGroup(p); group != UngroupedProjects {
+		s += fmt.Sprintf("%s %s\n", ui.BoldString("Group:"), group)
+	}
+
+	if labels := p.LabelsString(); labels != "" {
+		s += fmt.Sprintf("%s %s\n", ui.BoldString("Labels:"), labels)
+	}
+
+	return s
+}
+
+// projectGroupsToTable shows the subtotal of each project group, period is the
+// title of the time period of the costs, e.g. Monthly.
+func projectGroupsToTable(out Root, period string) string {
+	if len(out.ProjectGroups) == 0 {
+		return ""
+	}
+
+	t := table.NewWriter()
+	t.Style().Options.DrawBorder = false
+	t.Style().Options.SeparateColumns = false
+	t.Style().Options.SeparateRows = false
+	t.Style().Options.SeparateHeader = false
+	t.Style().Format.Header = text.FormatDefault
+
+	t.AppendHeader(table.Row{
+		ui.UnderlineString("Group"),
+		ui.UnderlineString("Projects"),
+		ui.UnderlineString(formatTitleWithCurrency(period+" Cost", out.Currency)),
+	})
+	t.SetColumnConfigs([]table.ColumnConfig{
+		{Number: 1, Align: text.AlignLeft},
+		{Number: 2, Align: text.AlignRight, AlignHeader: text.AlignRight},
+		{Number: 3, Align: text.AlignRight, AlignHeader: text.AlignRight},
+	})
+
+	for _, g := range out.ProjectGroups {
+		t.AppendRow(table.Row{g.Name, g.Projects, formatCost2DP(out.Currency, g.TotalMonthlyCost)})
+	}
+
+	return fmt.Sprintf("──────────────────────────────────\n%s\n\n%s",
+		ui.BoldString("Costs by project group:"),
+		t.Render(),
+	)
+}
+
+// projectGroupsToDiff shows the monthly cost change of each project group.
+func projectGroupsToDiff(out Root) string {
+	if len(out.ProjectGroups) == 0 {
+		return ""
+	}
+
+	s := "──────────────────────────────────\n"
+	s += ui.BoldString("Monthly cost change by project group:") + "\n\n"
+
+	for _, g := range out.ProjectGroups {
+		change := g.DiffTotalMonthlyCost
+		if change == nil {
+			change = decimalPtr(decimal.Zero)
+		}
+
+		s += fmt.Sprintf("%s: %s %s\n",
+			g.Name,
+			formatCostChange(out.Currency, change),
+			ui.FaintStringf("(%s → %s)", formatCost(out.Currency, g.PastTotalMonthlyCost), formatCost(out.Currency, g.TotalMonthlyCost)),
+		)
+	}
+
+	return s + "\n"
+}
@@ -0,0 +1,79 @@
+package output
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/infracost/infracost/internal/schema"
+)
+
+func TestAddProjectGroups(t *testing.T) {
+	r := Root{
+		Currency: "USD",
+		Projects: []Project{
+			{
+				Name:      "payments-dev",
+				Metadata:  &schema.ProjectMetadata{Path: "dev/payments", Group: "dev"},
+				Breakdown: &Breakdown{TotalMonthlyCost: decimalPtr(decimal.NewFromInt(20))},
+			},
+			{
+				Name:          "payments-prod",
+				Metadata:      &schema.ProjectMetadata{Path: "prod/payments", Group: "prod", Labels: map[string]string{"team": "payments", "env": "prod"}},
+				PastBreakdown: &Breakdown{TotalMonthlyCost: decimalPtr(decimal.NewFromInt(100))},
+				Breakdown:     &Breakdown{TotalMonthlyCost: decimalPtr(decimal.NewFromInt(150))},
+				Diff:          &Breakdown{TotalMonthlyCost: decimalPtr(decimal.NewFromInt(50))},
+			},
+			{
+				Name:      "shared",
+				Metadata:  &schema.ProjectMetadata{Path: "shared"},
+				Breakdown: &Breakdown{TotalMonthlyCost: decimalPtr(decimal.NewFromInt(5))},
+			},
+			{
+				Name:                 "checkout-prod",
+				Metadata:             &schema.ProjectMetadata{Path: "prod/checkout", Group: "prod"},
+				Currency:             "EUR",
+				ReportCurrencyTotals: &ReportCurrencyTotals{Currency: "USD", Rate: decimal.NewFromInt(2), TotalMonthlyCost: decimalPtr(decimal.NewFromInt(60))},
+				Breakdown:            &Breakdown{TotalMonthlyCost: decimalPtr(decimal.NewFromInt(30))},
+			},
+		},
+	}
+
+	AddProjectGroups(&r)
+	require.Len(t, r.ProjectGroups, 3)
+
+	assert.Equal(t, "dev", r.ProjectGroups[0].Name)
+	assert.Equal(t, 1, r.ProjectGroups[0].Projects)
+	assert.Equal(t, "20", r.ProjectGroups[0].TotalMonthlyCost.String())
+
+	assert.Equal(t, "prod", r.ProjectGroups[1].Name)
+	assert.Equal(t, 2, r.ProjectGroups[1].Projects)
+	assert.Equal(t, "210", r.ProjectGroups[1].TotalMonthlyCost.String())
+	assert.Equal(t, "100", r.ProjectGroups[1].PastTotalMonthlyCost.String())
+	assert.Equal(t, "50", r.ProjectGroups[1].DiffTotalMonthlyCost.String())
+
+	assert.Equal(t, UngroupedProjects, r.ProjectGroups[2].Name)
+	assert.Equal(t, "5", r.ProjectGroups[2].TotalMonthlyCost.String())
+
+	s := projectGroupsToTable(r, "Monthly")
+	assert.Contains(t, s, "Costs by project group:")
+	assert.Contains(t, s, "210.00")
+
+	assert.Equal(t, "env=prod, team=payments", r.Projects[1].LabelsString())
+	assert.Contains(t, projectHeader(r.Projects[1], false), "env=prod, team=payments")
+}
+
+func TestAddProjectGroupsWithoutGroups(t *testing.T) {
+	r := Root{
+		Projects: []Project{
+			{Name: "a", Metadata: &schema.ProjectMetadata{Path: "a"}},
+			{Name: "b"},
+		},
+		ProjectGroups: []ProjectGroup{{Name: "stale"}},
+	}
+
+	AddProjectGroups(&r)
+	assert.Nil(t, r.ProjectGroups)
+}
@@ -0,0 +1,82 @@
+package output
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/infracost/infracost/internal/ui"
+)
+
+// maxRecommendations is how many recommendations are listed in the table and
+// diff outputs, the rest are only in the JSON output.
+const maxRecommendations = 10
+
+// Recommendation is a savings opportunity found in a resource by a best
+// practice check, e.g. a gp2 volume that would be cheaper as gp3.
+type Recommendation struct {
+	// Check is the ID of the check that found it, e.g. aws_gp2_to_gp3.
+	Check       string `json:"check"`
+	ProjectName string `json:"projectName"`
+	Address     string `json:"address"`
+	Message     string `json:"message"`
+	// EstimatedMonthlySavings is in the currency of the report.
+	EstimatedMonthlySavings decimal.Decimal `json:"estimatedMonthlySavings"`
+}
+
+// TotalRecommendedSavings returns the sum of the estimated monthly savings of
+// the recommendations.
+func (r *Root) TotalRecommendedSavings() decimal.Decimal {
+	total := decimal.Zero
+	for _, rec := range r.Recommendations {
+		total = total.Add(rec.EstimatedMonthlySavings)
+	}
+
+	return total
+}
+
+// SortRecommendations sorts the recommendations by their savings, largest
+// first, then by project and address.
+func SortRecommendations(recs []Recommendation) []Recommendation {
+	sort.SliceStable(recs, func(i, j int) bool {
+		if !recs[i].EstimatedMonthlySavings.Equal(recs[j].EstimatedMonthlySavings) {
+			return recs[i].EstimatedMonthlySavings.GreaterThan(recs[j].EstimatedMonthlySavings)
+		}
+		if recs[i].ProjectName != recs[j].ProjectName {
+			return recs[i].ProjectName < recs[j].ProjectName
+		}
+		return recs[i].Address < recs[j].Address
+	})
+
+	return recs
+}
+
+// recommendationsToTable lists the recommendations with the largest savings and
+// the total savings of all of them.
+func recommendationsToTable(out Root) string {
+	if len(out.Recommendations) == 0 {
+		return ""
+	}
+
+	total := out.TotalRecommendedSavings()
+
+	s := "──────────────────────────────────\n"
+	s += ui.BoldString(fmt.Sprintf("Recommendations (save %s/month):", formatCost(out.Currency, &total)))
+
+	for i, rec := range out.Recommendations {
+		if i == maxRecommendations {
+			s += ui.FaintStringf("\n∙ and %d more, see the JSON output", len(out.Recommendations)-maxRecommendations)
+			break
+		}
+
+		savings := rec.EstimatedMonthlySavings
+		s += fmt.Sprintf("\n∙ %s: %s %s", rec.Address, rec.Message, ui.FaintStringf("(save %s/month)", formatCost(out.Currency, &savings)))
+
+		if len(out.Projects) > 1 {
+			s += ui.FaintStringf(" (%s)", rec.ProjectName)
+		}
+	}
+
+	return s
+}
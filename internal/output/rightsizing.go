@@ -0,0 +1,76 @@
+package output
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/infracost/infracost/internal/ui"
+)
+
+// RightsizingRecommendation is a smaller size recommended for a resource from
+// its utilization, e.g. a smaller instance type for an instance whose CPU is
+// mostly idle.
+type RightsizingRecommendation struct {
+	ProjectName  string `json:"projectName"`
+	Address      string `json:"address"`
+	ResourceType string `json:"resourceType"`
+	// Current and Recommended are the sizes, e.g. m5.2xlarge or 100 RCU.
+	Current     string `json:"current"`
+	Recommended string `json:"recommended"`
+	// Reason is the utilization the recommendation is based on.
+	Reason string `json:"reason"`
+	// CurrentMonthlyCost and EstimatedMonthlySavings are of the resized cost
+	// component, in the currency of the report.
+	CurrentMonthlyCost      decimal.Decimal `json:"currentMonthlyCost"`
+	EstimatedMonthlySavings decimal.Decimal `json:"estimatedMonthlySavings"`
+}
+
+// SortRightsizing sorts the rightsizing recommendations by their savings,
+// largest first, then by project and address.
+func SortRightsizing(recs []RightsizingRecommendation) []RightsizingRecommendation {
+	sort.SliceStable(recs, func(i, j int) bool {
+		if !recs[i].EstimatedMonthlySavings.Equal(recs[j].EstimatedMonthlySavings) {
+			return recs[i].EstimatedMonthlySavings.GreaterThan(recs[j].EstimatedMonthlySavings)
+		}
+		if recs[i].ProjectName != recs[j].ProjectName {
+			return recs[i].ProjectName < recs[j].ProjectName
+		}
+		return recs[i].Address < recs[j].Address
+	})
+
+	return recs
+}
+
+// rightsizingToTable lists the rightsizing recommendations with the largest
+// savings and the total savings of all of them.
+func rightsizingToTable(out Root) string {
+	if len(out.Rightsizing) == 0 {
+		return ""
+	}
+
+	total := decimal.Zero
+	for _, rec := range out.Rightsizing {
+		total = total.Add(rec.EstimatedMonthlySavings)
+	}
+
+	s := "──────────────────────────────────\n"
+	s += ui.BoldString(fmt.Sprintf("Rightsizing (save %s/month):", formatCost(out.Currency, &total)))
+
+	for i, rec := range out.Rightsizing {
+		if i == maxRecommendations {
+			s += ui.FaintStringf("\n∙ and %d more, see the JSON output", len(out.Rightsizing)-maxRecommendations)
+			break
+		}
+
+		savings := rec.EstimatedMonthlySavings
+		s += fmt.Sprintf("\n∙ %s: %s → %s, %s %s", rec.Address, rec.Current, rec.Recommended, rec.Reason, ui.FaintStringf("(save %s/month)", formatCost(out.Currency, &savings)))
+
+		if len(out.Projects) > 1 {
+			s += ui.FaintStringf(" (%s)", rec.ProjectName)
+		}
+	}
+
+	return s
+}
@@ -0,0 +1,181 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/infracost/infracost/internal/schema"
+	"github.com/infracost/infracost/internal/version"
+)
+
+const (
+	sarifSchema  = "https://json.schemastore.org/sarif-2.1.0.json"
+	sarifVersion = "2.1.0"
+
+	sarifRuleGuardrail = "infracost/guardrail"
+	sarifRuleTagPolicy = "infracost/tag-policy"
+)
+
+type sarifReport struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	Version        string      `json:"version"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID               string       `json:"id"`
+	ShortDescription sarifMessage `json:"shortDescription"`
+	HelpURI          string       `json:"helpUri"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleID    string          `json:"ruleId"`
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation *sarifPhysicalLocation `json:"physicalLocation,omitempty"`
+	LogicalLocations []sarifLogicalLocation `json:"logicalLocations,omitempty"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           sarifRegion           `json:"region"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type sarifRegion struct {
+	StartLine int `json:"startLine"`
+	EndLine   int `json:"endLine"`
+}
+
+type sarifLogicalLocation struct {
+	FullyQualifiedName string `json:"fullyQualifiedName"`
+	Kind               string `json:"kind"`
+}
+
+// ToSARIF outputs the guardrail violations and the resources that don't comply
+// with the tag policies as SARIF results, so code scanning tools can annotate
+// the lines of the resources in pull requests. Results are located at the
+// source range of their resource when it's known, i.e. for Terraform
+// directories, and at the address of the resource, module or project otherwise.
+// Blocking results are errors and the others warnings.
+func ToSARIF(out Root, opts Options) ([]byte, error) {
+	results := make([]sarifResult, 0, len(out.GuardrailViolations))
+
+	for _, v := range out.GuardrailViolations {
+		results = append(results, sarifResult{
+			RuleID:    sarifRuleGuardrail,
+			Level:     sarifLevel(v.Blocking),
+			Message:   sarifMessage{Text: fmt.Sprintf("%s: %s %s (%s)", v.Guardrail, v.Subject(), v.Message, v.Limit)},
+			Locations: sarifLocations(v.ProjectName, v.Address, v.SourceRange),
+		})
+	}
+
+	for _, t := range out.TagPolicies {
+		for _, r := range t.NonCompliantResources {
+			var problems []string
+			if len(r.MissingTags) > 0 {
+				problems = append(problems, "missing tags "+strings.Join(r.MissingTags, ", "))
+			}
+			if len(r.InvalidTags) > 0 {
+				problems = append(problems, "invalid tags "+strings.Join(r.InvalidTags, ", "))
+			}
+
+			results = append(results, sarifResult{
+				RuleID:    sarifRuleTagPolicy,
+				Level:     sarifLevel(t.Blocking),
+				Message:   sarifMessage{Text: fmt.Sprintf("%s: %s has %s, %s/month is untagged spend", t.Name, r.Address, strings.Join(problems, " and "), formatCost(out.Currency, r.MonthlyCost))},
+				Locations: sarifLocations(t.ProjectName, r.Address, r.SourceRange),
+			})
+		}
+	}
+
+	report := sarifReport{
+		Schema:  sarifSchema,
+		Version: sarifVersion,
+		Runs: []sarifRun{
+			{
+				Tool: sarifTool{
+					Driver: sarifDriver{
+						Name:           "Infracost",
+						Version:        version.Version,
+						InformationURI: "https://www.infracost.io",
+						Rules: []sarifRule{
+							{
+								ID:               sarifRuleGuardrail,
+								ShortDescription: sarifMessage{Text: "Monthly cost exceeds a guardrail"},
+								HelpURI:          "https://infracost.io/config-file",
+							},
+							{
+								ID:               sarifRuleTagPolicy,
+								ShortDescription: sarifMessage{Text: "Resource doesn't comply with a tag policy"},
+								HelpURI:          "https://infracost.io/config-file",
+							},
+						},
+					},
+				},
+				Results: results,
+			},
+		},
+	}
+
+	return json.MarshalIndent(report, "", "  ")
+}
+
+func sarifLevel(blocking bool) string {
+	if blocking {
+		return "error"
+	}
+
+	return "warning"
+}
+
+func sarifLocations(projectName string, address string, r *schema.SourceRange) []sarifLocation {
+	loc := sarifLocation{}
+
+	if r != nil {
+		loc.PhysicalLocation = &sarifPhysicalLocation{
+			ArtifactLocation: sarifArtifactLocation{URI: r.Filename},
+			Region:           sarifRegion{StartLine: r.StartLine, EndLine: r.EndLine},
+		}
+	}
+
+	name, kind := address, "resource"
+	switch {
+	case address == "":
+		name, kind = projectName, "project"
+	case ResourceType(address) == "":
+		kind = "module"
+	}
+
+	loc.LogicalLocations = []sarifLogicalLocation{{FullyQualifiedName: name, Kind: kind}}
+
+	return []sarifLocation{loc}
+}
@@ -0,0 +1,73 @@
+package output
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/infracost/infracost/internal/schema"
+)
+
+func TestToSARIF(t *testing.T) {
+	cost := decimal.NewFromInt(120)
+
+	out := Root{
+		Currency: "USD",
+		GuardrailViolations: []GuardrailViolation{
+			{
+				Guardrail:   "Instance limit",
+				Scope:       "resource",
+				ProjectName: "infracost/prod",
+				Address:     "aws_instance.web",
+				Limit:       "max_monthly_cost",
+				Message:     "monthly cost $120 is over the guardrail of $100",
+				Blocking:    true,
+				SourceRange: &schema.SourceRange{Filename: "prod/main.tf", StartLine: 12, EndLine: 20},
+			},
+			{
+				Guardrail:   "prod",
+				Scope:       "project",
+				ProjectName: "infracost/prod",
+				Limit:       "max_monthly_increase",
+				Message:     "monthly cost increase $120 is over the guardrail of $50",
+			},
+		},
+		TagPolicies: []TagPolicyResult{
+			{
+				Name:        "Cost allocation",
+				ProjectName: "infracost/prod",
+				NonCompliantResources: []TagPolicyResource{
+					{Address: "aws_instance.web", MonthlyCost: &cost, MissingTags: []string{"team"}, SourceRange: &schema.SourceRange{Filename: "prod/main.tf", StartLine: 12, EndLine: 20}},
+				},
+			},
+		},
+	}
+
+	b, err := ToSARIF(out, Options{})
+	require.NoError(t, err)
+
+	var report sarifReport
+	require.NoError(t, json.Unmarshal(b, &report))
+	require.Len(t, report.Runs, 1)
+
+	results := report.Runs[0].Results
+	require.Len(t, results, 3)
+
+	assert.Equal(t, sarifRuleGuardrail, results[0].RuleID)
+	assert.Equal(t, "error", results[0].Level)
+	assert.Equal(t, "Instance limit: aws_instance.web monthly cost $120 is over the guardrail of $100 (max_monthly_cost)", results[0].Message.Text)
+	assert.Equal(t, &sarifPhysicalLocation{
+		ArtifactLocation: sarifArtifactLocation{URI: "prod/main.tf"},
+		Region:           sarifRegion{StartLine: 12, EndLine: 20},
+	}, results[0].Locations[0].PhysicalLocation)
+
+	assert.Equal(t, "warning", results[1].Level)
+	assert.Nil(t, results[1].Locations[0].PhysicalLocation)
+	assert.Equal(t, []sarifLogicalLocation{{FullyQualifiedName: "infracost/prod", Kind: "project"}}, results[1].Locations[0].LogicalLocations)
+
+	assert.Equal(t, sarifRuleTagPolicy, results[2].RuleID)
+	assert.Equal(t, "Cost allocation: aws_instance.web has missing tags team, $120/month is untagged spend", results[2].Message.Text)
+}
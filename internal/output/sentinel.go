@@ -0,0 +1,155 @@
+package output
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/shopspring/decimal"
+)
+
+// ToSentinelMock outputs the costs as the mock data of the Sentinel tfrun
+// import, so Sentinel policies written against the cost estimates of Terraform
+// Enterprise can be run against Infracost's estimates, e.g. with a sentinel.hcl
+// of:
+//
+//	mock "tfrun" {
+//	  module {
+//	    source = "mock-tfrun.sentinel"
+//	  }
+//	}
+//
+// cost_estimate has the totals of all projects like the tfrun import, and
+// infracost has the totals of each project, the guardrail violations and the
+// tag policy results so policies can check them too.
+func ToSentinelMock(out Root, opts Options) ([]byte, error) {
+	projects := make(map[string]interface{}, len(out.Projects))
+	for _, p := range out.Projects {
+		totals := p.reportCurrencyTotals()
+		projects[p.Name] = sentinelCostEstimate(totals.PastTotalMonthlyCost, totals.TotalMonthlyCost, totals.DiffTotalMonthlyCost)
+	}
+
+	violations := make([]interface{}, 0, len(out.GuardrailViolations))
+	for _, v := range out.GuardrailViolations {
+		violations = append(violations, map[string]interface{}{
+			"guardrail":    v.Guardrail,
+			"scope":        v.Scope,
+			"project_name": v.ProjectName,
+			"address":      v.Address,
+			"limit":        v.Limit,
+			"message":      v.Message,
+			"blocking":     v.Blocking,
+		})
+	}
+
+	tagPolicies := make([]interface{}, 0, len(out.TagPolicies))
+	for _, t := range out.TagPolicies {
+		tagPolicies = append(tagPolicies, map[string]interface{}{
+			"name":                    t.Name,
+			"project_name":            t.ProjectName,
+			"blocking":                t.Blocking,
+			"total_resources":         t.TotalResources,
+			"non_compliant_resources": len(t.NonCompliantResources),
+			"total_monthly_cost":      sentinelCost(t.TotalMonthlyCost),
+			"untagged_monthly_cost":   sentinelCost(t.UntaggedMonthlyCost),
+		})
+	}
+
+	var b strings.Builder
+
+	b.WriteString("# Mock data of the tfrun import generated by Infracost, costs are monthly in " + out.Currency + ".\n\n")
+	b.WriteString("cost_estimate = ")
+	writeSentinelValue(&b, sentinelCostEstimate(out.PastTotalMonthlyCost, out.TotalMonthlyCost, out.DiffTotalMonthlyCost), 0)
+	b.WriteString("\n\ninfracost = ")
+	writeSentinelValue(&b, map[string]interface{}{
+		"currency":             out.Currency,
+		"projects":             projects,
+		"guardrail_violations": violations,
+		"tag_policies":         tagPolicies,
+	}, 0)
+	b.WriteString("\n")
+
+	return []byte(b.String()), nil
+}
+
+// sentinelCostEstimate returns the costs with the keys of the cost_estimate of
+// the tfrun import. The delta is the proposed cost when there's no prior cost,
+// like in a breakdown.
+func sentinelCostEstimate(prior, proposed, delta *decimal.Decimal) map[string]interface{} {
+	if delta == nil {
+		d := decimal.Zero
+		if proposed != nil {
+			d = *proposed
+		}
+		if prior != nil {
+			d = d.Sub(*prior)
+		}
+		delta = &d
+	}
+
+	return map[string]interface{}{
+		"prior_monthly_cost":    sentinelCost(prior),
+		"proposed_monthly_cost": sentinelCost(proposed),
+		"delta_monthly_cost":    sentinelCost(delta),
+	}
+}
+
+// sentinelCost formats a cost as a string like the tfrun import, so policies
+// can parse it with the decimal import.
+func sentinelCost(d *decimal.Decimal) string {
+	if d == nil {
+		return "0.00"
+	}
+
+	return d.StringFixed(2)
+}
+
+// writeSentinelValue writes the value as a Sentinel literal, the keys of maps
+// are sorted so the output only changes when the costs do.
+func writeSentinelValue(b *strings.Builder, v interface{}, indent int) {
+	switch v := v.(type) {
+	case nil:
+		b.WriteString("null")
+	case string:
+		b.WriteString(strconv.Quote(v))
+	case bool:
+		b.WriteString(strconv.FormatBool(v))
+	case int:
+		b.WriteString(strconv.Itoa(v))
+	case map[string]interface{}:
+		if len(v) == 0 {
+			b.WriteString("{}")
+			return
+		}
+
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		b.WriteString("{\n")
+		for _, k := range keys {
+			b.WriteString(strings.Repeat("\t", indent+1) + strconv.Quote(k) + ": ")
+			writeSentinelValue(b, v[k], indent+1)
+			b.WriteString(",\n")
+		}
+		b.WriteString(strings.Repeat("\t", indent) + "}")
+	case []interface{}:
+		if len(v) == 0 {
+			b.WriteString("[]")
+			return
+		}
+
+		b.WriteString("[\n")
+		for _, e := range v {
+			b.WriteString(strings.Repeat("\t", indent+1))
+			writeSentinelValue(b, e, indent+1)
+			b.WriteString(",\n")
+		}
+		b.WriteString(strings.Repeat("\t", indent) + "]")
+	default:
+		b.WriteString(strconv.Quote(fmt.Sprint(v)))
+	}
+}
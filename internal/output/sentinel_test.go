@@ -0,0 +1,86 @@
+package output
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestToSentinelMock(t *testing.T) {
+	cost := func(v float64) *decimal.Decimal {
+		d := decimal.NewFromFloat(v)
+		return &d
+	}
+
+	out := Root{
+		Currency:             "USD",
+		TotalMonthlyCost:     cost(150.5),
+		PastTotalMonthlyCost: cost(100),
+		DiffTotalMonthlyCost: cost(50.5),
+		Projects: []Project{
+			{
+				Name:    "infracost/prod",
+				Summary: &Summary{},
+				Breakdown: &Breakdown{
+					TotalMonthlyCost: cost(150.5),
+				},
+				PastBreakdown: &Breakdown{
+					TotalMonthlyCost: cost(100),
+				},
+				Diff: &Breakdown{
+					TotalMonthlyCost: cost(50.5),
+				},
+			},
+		},
+		GuardrailViolations: []GuardrailViolation{
+			{Guardrail: "prod", Scope: "project", ProjectName: "infracost/prod", Limit: "max_monthly_increase", Message: "monthly cost increase $50.50 is over the guardrail of $20", Blocking: true},
+		},
+	}
+
+	b, err := ToSentinelMock(out, Options{})
+	require.NoError(t, err)
+
+	assert.Equal(t, `# Mock data of the tfrun import generated by Infracost, costs are monthly in USD.
+
+cost_estimate = {
+	"delta_monthly_cost": "50.50",
+	"prior_monthly_cost": "100.00",
+	"proposed_monthly_cost": "150.50",
+}
+
+infracost = {
+	"currency": "USD",
+	"guardrail_violations": [
+		{
+			"address": "",
+			"blocking": true,
+			"guardrail": "prod",
+			"limit": "max_monthly_increase",
+			"message": "monthly cost increase $50.50 is over the guardrail of $20",
+			"project_name": "infracost/prod",
+			"scope": "project",
+		},
+	],
+	"projects": {
+		"infracost/prod": {
+			"delta_monthly_cost": "50.50",
+			"prior_monthly_cost": "100.00",
+			"proposed_monthly_cost": "150.50",
+		},
+	},
+	"tag_policies": [],
+}
+`, string(b))
+}
+
+func TestSentinelCostEstimateWithoutDiff(t *testing.T) {
+	proposed := decimal.NewFromInt(42)
+
+	assert.Equal(t, map[string]interface{}{
+		"prior_monthly_cost":    "0.00",
+		"proposed_monthly_cost": "42.00",
+		"delta_monthly_cost":    "42.00",
+	}, sentinelCostEstimate(nil, &proposed, nil))
+}
@@ -0,0 +1,139 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/infracost/infracost/internal/providers/terraform"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+// The reasons resources aren't fully estimated, see SkippedResource.
+const (
+	// SkippedReasonFree is for resources that don't cost anything.
+	SkippedReasonFree = "free"
+	// SkippedReasonUnsupported is for resource types Infracost doesn't support yet.
+	SkippedReasonUnsupported = "unsupported"
+	// SkippedReasonNotEstimated is for resources of supported types that couldn't
+	// be estimated with their attributes, e.g. an unsupported SKU.
+	SkippedReasonNotEstimated = "not_estimated"
+	// SkippedReasonPriceUnavailable is for resources whose costs are incomplete
+	// since some of their prices couldn't be looked up.
+	SkippedReasonPriceUnavailable = "price_unavailable"
+)
+
+const unsupportedMessage = "This resource is not currently supported"
+
+// SkippedResource is a resource whose costs aren't included, or are incomplete,
+// with why and what can be done about it.
+type SkippedResource struct {
+	Project      string `json:"project"`
+	Name         string `json:"name"`
+	ResourceType string `json:"resourceType"`
+	Reason       string `json:"reason"`
+	Message      string `json:"message,omitempty"`
+	Suggestion   string `json:"suggestion,omitempty"`
+	URL          string `json:"url,omitempty"`
+}
+
+// SkippedReport is the report of the skipped resources output by
+// --show-skipped=json.
+type SkippedReport struct {
+	Version   string            `json:"version"`
+	Counts    map[string]int    `json:"counts"`
+	Resources []SkippedResource `json:"resources"`
+}
+
+// skippedResources returns the resources of the project that aren't fully
+// estimated. Resources of providers Infracost doesn't support are left out, like
+// in the summary.
+func skippedResources(projectName string, resources []*schema.Resource) []SkippedResource {
+	var skipped []SkippedResource
+
+	for _, r := range resources {
+		if !terraform.HasSupportedProvider(r.ResourceType) {
+			continue
+		}
+
+		s := SkippedResource{
+			Project:      projectName,
+			Name:         r.Name,
+			ResourceType: r.ResourceType,
+			Message:      r.SkipMessage,
+		}
+
+		switch {
+		case r.NoPrice:
+			s.Reason = SkippedReasonFree
+			s.Message = "Free resource"
+		case r.IsSkipped && (r.SkipMessage == "" || r.SkipMessage == unsupportedMessage):
+			s.Reason = SkippedReasonUnsupported
+			s.Message = unsupportedMessage
+			s.Suggestion = "Upvote or request support for the resource type"
+			s.URL = fmt.Sprintf("https://github.com/infracost/infracost/issues?q=%s", url.QueryEscape("is:issue "+r.ResourceType))
+		case r.IsSkipped:
+			s.Reason = SkippedReasonNotEstimated
+			s.Suggestion = "Check the resource's attributes against the message, other values may be supported"
+		case r.HasPriceUnavailable():
+			s.Reason = SkippedReasonPriceUnavailable
+			s.Message = "Prices couldn't be looked up for: " + strings.Join(priceUnavailableComponents(r), ", ")
+			s.Suggestion = "Rerun without --allow-unavailable-prices to see the error"
+		default:
+			continue
+		}
+
+		skipped = append(skipped, s)
+	}
+
+	return skipped
+}
+
+func priceUnavailableComponents(r *schema.Resource) []string {
+	var names []string
+
+	for _, res := range append([]*schema.Resource{r}, r.FlattenedSubResources()...) {
+		for _, c := range res.CostComponents {
+			if c.PriceUnavailable {
+				names = append(names, c.Name)
+			}
+		}
+	}
+
+	return names
+}
+
+// ToSkippedJSON returns the report of the skipped resources of the projects as
+// JSON, sorted by reason and resource type so the most common gaps are
+// grouped together.
+func ToSkippedJSON(out Root, opts Options) ([]byte, error) {
+	report := SkippedReport{
+		Version:   outputVersion,
+		Counts:    map[string]int{},
+		Resources: []SkippedResource{},
+	}
+
+	for _, p := range out.Projects {
+		for _, s := range p.skippedResources {
+			report.Counts[s.Reason]++
+			report.Resources = append(report.Resources, s)
+		}
+	}
+
+	sort.SliceStable(report.Resources, func(i, j int) bool {
+		a, b := report.Resources[i], report.Resources[j]
+		if a.Reason != b.Reason {
+			return a.Reason < b.Reason
+		}
+
+		if a.ResourceType != b.ResourceType {
+			return a.ResourceType < b.ResourceType
+		}
+
+		return a.Name < b.Name
+	})
+
+	return json.MarshalIndent(report, "", "  ")
+}
@@ -0,0 +1,71 @@
+package output
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/infracost/infracost/internal/schema"
+)
+
+func TestSkippedResources(t *testing.T) {
+	resources := []*schema.Resource{
+		{Name: "aws_instance.web", ResourceType: "aws_instance"},
+		{Name: "aws_iam_role.web", ResourceType: "aws_iam_role", IsSkipped: true, NoPrice: true, SkipMessage: "Free resource."},
+		{Name: "aws_new_thing.a", ResourceType: "aws_new_thing", IsSkipped: true, SkipMessage: "This resource is not currently supported"},
+		{Name: "azurerm_lb.lb", ResourceType: "azurerm_lb", IsSkipped: true, SkipMessage: "Basic SKU is not supported"},
+		{
+			Name:         "aws_db_instance.db",
+			ResourceType: "aws_db_instance",
+			CostComponents: []*schema.CostComponent{
+				{Name: "Database instance"},
+				{Name: "Storage", PriceUnavailable: true},
+			},
+		},
+		{Name: "kubernetes_pod.p", ResourceType: "kubernetes_pod", IsSkipped: true},
+	}
+
+	skipped := skippedResources("infracost/infracost/examples", resources)
+	require.Len(t, skipped, 4)
+
+	assert.Equal(t, SkippedReasonFree, skipped[0].Reason)
+	assert.Equal(t, "aws_iam_role.web", skipped[0].Name)
+
+	assert.Equal(t, SkippedReasonUnsupported, skipped[1].Reason)
+	assert.Equal(t, "https://github.com/infracost/infracost/issues?q=is%3Aissue+aws_new_thing", skipped[1].URL)
+
+	assert.Equal(t, SkippedReasonNotEstimated, skipped[2].Reason)
+	assert.Equal(t, "Basic SKU is not supported", skipped[2].Message)
+
+	assert.Equal(t, SkippedReasonPriceUnavailable, skipped[3].Reason)
+	assert.Equal(t, "Prices couldn't be looked up for: Storage", skipped[3].Message)
+	assert.Equal(t, "infracost/infracost/examples", skipped[3].Project)
+}
+
+func TestToSkippedJSON(t *testing.T) {
+	out := Root{
+		Projects: []Project{
+			{skippedResources: []SkippedResource{
+				{Name: "aws_new_thing.b", ResourceType: "aws_new_thing", Reason: SkippedReasonUnsupported},
+				{Name: "aws_iam_role.a", ResourceType: "aws_iam_role", Reason: SkippedReasonFree},
+			}},
+			{skippedResources: []SkippedResource{
+				{Name: "aws_new_thing.a", ResourceType: "aws_new_thing", Reason: SkippedReasonUnsupported},
+			}},
+		},
+	}
+
+	b, err := ToSkippedJSON(out, Options{})
+	require.NoError(t, err)
+
+	var report SkippedReport
+	require.NoError(t, json.Unmarshal(b, &report))
+
+	assert.Equal(t, map[string]int{SkippedReasonFree: 1, SkippedReasonUnsupported: 2}, report.Counts)
+	require.Len(t, report.Resources, 3)
+	assert.Equal(t, "aws_iam_role.a", report.Resources[0].Name)
+	assert.Equal(t, "aws_new_thing.a", report.Resources[1].Name)
+	assert.Equal(t, "aws_new_thing.b", report.Resources[2].Name)
+}
@@ -42,21 +42,10 @@ func slackSummaryBlock(name string, currency string, cost, pastCost, diffCost *d
 }
 
 func slackProjectSummaryBlock(project Project, currency string) []*slack.TextBlockObject {
-	var pastCost, cost, diffCost *decimal.Decimal
+	// Projects with their own currency are summarized in the currency of the report
+	totals := project.reportCurrencyTotals()
 
-	if project.PastBreakdown != nil {
-		pastCost = project.PastBreakdown.TotalMonthlyCost
-	}
-
-	if project.Breakdown != nil {
-		cost = project.Breakdown.TotalMonthlyCost
-	}
-
-	if project.Diff != nil {
-		diffCost = project.Diff.TotalMonthlyCost
-	}
-
-	return slackSummaryBlock(truncateMiddle(project.Name, 42, "..."), currency, cost, pastCost, diffCost)
+	return slackSummaryBlock(truncateMiddle(project.Name, 42, "..."), currency, totals.TotalMonthlyCost, totals.PastTotalMonthlyCost, totals.DiffTotalMonthlyCost)
 }
 
 func slackAllProjectsSummaryBlock(out Root, currency string) []*slack.TextBlockObject {
@@ -15,6 +15,9 @@ import (
 func ToTable(out Root, opts Options) ([]byte, error) {
 	var tableLen int
 
+	period := granularityTitle(opts.Granularity)
+	out = out.withGranularity(opts.Granularity)
+
 	s := ""
 
 	// Don't show the project total if there's only one project result
@@ -30,12 +33,9 @@ func ToTable(out Root, opts Options) ([]byte, error) {
 			s += "──────────────────────────────────\n"
 		}
 
-		s += fmt.Sprintf("%s %s\n\n",
-			ui.BoldString("Project:"),
-			project.Label(opts.DashboardEnabled),
-		)
+		s += projectHeader(project, opts.DashboardEnabled) + "\n"
 
-		tableOut := tableForBreakdown(out.Currency, *project.Breakdown, opts.Fields, includeProjectTotals)
+		tableOut := tableForBreakdown(out.projectCurrency(project), *project.Breakdown, opts.Fields, period, includeProjectTotals)
 
 		// Get the last table length so we can align the overall total with it
 		if i == len(out.Projects)-1 {
@@ -57,12 +57,94 @@ func ToTable(out Root, opts Options) ([]byte, error) {
 
 	totalOut := formatCost2DP(out.Currency, out.TotalMonthlyCost)
 
-	overallTitle := formatTitleWithCurrency(" OVERALL TOTAL", out.Currency)
+	overallTitle := " OVERALL TOTAL"
+	if period != "Monthly" {
+		overallTitle = fmt.Sprintf(" OVERALL %s TOTAL", strings.ToUpper(period))
+	}
+	overallTitle = formatTitleWithCurrency(overallTitle, out.Currency)
 	s += fmt.Sprintf("%s%s",
 		ui.BoldString(overallTitle),
 		fmt.Sprintf("%*s ", tableLen-(len(overallTitle)+1), totalOut), // pad based on the last line length
 	)
 
+	projectGroupsMsg := projectGroupsToTable(out, period)
+
+	if projectGroupsMsg != "" {
+		s += "\n" + projectGroupsMsg
+	}
+
+	costGroupsMsg := costGroupsToTable(out, period)
+
+	if costGroupsMsg != "" {
+		s += "\n" + costGroupsMsg
+	}
+
+	ownersMsg := ownersToTable(out, period)
+
+	if ownersMsg != "" {
+		s += "\n" + ownersMsg
+	}
+
+	usageScenariosMsg := usageScenariosToTable(out, opts)
+
+	if usageScenariosMsg != "" {
+		s += "\n" + usageScenariosMsg
+	}
+
+	projectionMsg := projectionToTable(out)
+
+	if projectionMsg != "" {
+		s += "\n" + projectionMsg
+	}
+
+	pricingIssuesMsg := pricingIssuesToTable(out)
+
+	if pricingIssuesMsg != "" {
+		s += "\n" + pricingIssuesMsg
+	}
+
+	guardrailsMsg := guardrailViolationsToTable(out)
+
+	if guardrailsMsg != "" {
+		s += "\n" + guardrailsMsg
+	}
+
+	tagPoliciesMsg := tagPoliciesToTable(out)
+
+	if tagPoliciesMsg != "" {
+		s += "\n" + tagPoliciesMsg
+	}
+
+	exemptionsMsg := exemptionsToTable(out)
+
+	if exemptionsMsg != "" {
+		s += "\n" + exemptionsMsg
+	}
+
+	waiversMsg := waiversToTable(out)
+
+	if waiversMsg != "" {
+		s += "\n" + waiversMsg
+	}
+
+	recommendationsMsg := recommendationsToTable(out)
+
+	if recommendationsMsg != "" {
+		s += "\n" + recommendationsMsg
+	}
+
+	rightsizingMsg := rightsizingToTable(out)
+
+	if opts.ShowRightsizing && rightsizingMsg != "" {
+		s += "\n" + rightsizingMsg
+	}
+
+	idleMsg := idleResourcesToTable(out)
+
+	if idleMsg != "" {
+		s += "\n" + idleMsg
+	}
+
 	summaryMsg := out.summaryMessage(opts.ShowSkipped)
 
 	if summaryMsg != "" {
@@ -72,7 +154,9 @@ func ToTable(out Root, opts Options) ([]byte, error) {
 	return []byte(s), nil
 }
 
-func tableForBreakdown(currency string, breakdown Breakdown, fields []string, includeTotal bool) string {
+// tableForBreakdown renders the resources of the breakdown, period is the title
+// of the time period of its costs, e.g. Monthly.
+func tableForBreakdown(currency string, breakdown Breakdown, fields []string, period string, includeTotal bool) string {
 	t := table.NewWriter()
 	t.Style().Options.DrawBorder = false
 	t.Style().Options.SeparateColumns = false
@@ -105,7 +189,7 @@ func tableForBreakdown(currency string, breakdown Breakdown, fields []string, in
 		i++
 	}
 	if contains(fields, "monthlyQuantity") {
-		headers = append(headers, ui.UnderlineString("Monthly Qty"))
+		headers = append(headers, ui.UnderlineString(period+" Qty"))
 		columns = append(columns, table.ColumnConfig{
 			Number:      i,
 			Align:       text.AlignRight,
@@ -132,7 +216,7 @@ func tableForBreakdown(currency string, breakdown Breakdown, fields []string, in
 		i++
 	}
 	if contains(fields, "monthlyCost") {
-		headers = append(headers, ui.UnderlineString(formatTitleWithCurrency("Monthly Cost", currency)))
+		headers = append(headers, ui.UnderlineString(formatTitleWithCurrency(period+" Cost", currency)))
 		columns = append(columns, table.ColumnConfig{
 			Number:      i,
 			Align:       text.AlignRight,
@@ -207,7 +291,15 @@ func buildCostComponentRows(t table.Writer, currency string, costComponents []Co
 
 		label := fmt.Sprintf("%s %s", ui.FaintString(labelPrefix), c.Name)
 
-		if c.MonthlyCost == nil {
+		if c.PriceUnavailable {
+			t.AppendRow(table.Row{
+				label,
+				"Price unavailable",
+				"Price unavailable",
+				"Price unavailable",
+			}, table.RowConfig{AutoMerge: true, AlignAutoMerge: text.AlignLeft})
+
+		} else if c.MonthlyCost == nil {
 			price := fmt.Sprintf("Monthly cost depends on usage: %s per %s",
 				formatPrice(currency, c.Price),
 				c.Unit,
@@ -0,0 +1,186 @@
+package output
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/infracost/infracost/internal/schema"
+	"github.com/infracost/infracost/internal/ui"
+)
+
+// maxTagPolicyResources is how many of the non-compliant resources of a tag
+// policy the table and diff outputs list, the JSON output has all of them.
+const maxTagPolicyResources = 10
+
+// TagPolicyResult is how the resources of a project comply with a tag policy of
+// the config file. UntaggedMonthlyCost is the monthly cost of the resources that
+// don't comply, out of the TotalMonthlyCost of the resources it applies to.
+type TagPolicyResult struct {
+	Name                  string              `json:"name"`
+	ProjectName           string              `json:"projectName"`
+	Blocking              bool                `json:"blocking"`
+	TotalResources        int                 `json:"totalResources"`
+	TotalMonthlyCost      *decimal.Decimal    `json:"totalMonthlyCost"`
+	UntaggedMonthlyCost   *decimal.Decimal    `json:"untaggedMonthlyCost"`
+	NonCompliantResources []TagPolicyResource `json:"nonCompliantResources"`
+}
+
+// TagPolicyResource is a resource that doesn't comply with a tag policy.
+// InvalidTags are the tags whose values aren't allowed, as key=value.
+type TagPolicyResource struct {
+	Address     string           `json:"address"`
+	MonthlyCost *decimal.Decimal `json:"monthlyCost"`
+	MissingTags []string         `json:"missingTags,omitempty"`
+	InvalidTags []string         `json:"invalidTags,omitempty"`
+	// SourceRange is where the resource is defined, if it's known.
+	SourceRange *schema.SourceRange `json:"sourceRange,omitempty"`
+}
+
+// tagPolicyProblems describes why the resource doesn't comply, e.g. missing
+// tags team and invalid tags env=test.
+func tagPolicyProblems(r TagPolicyResource) string {
+	var problems []string
+	if len(r.MissingTags) > 0 {
+		problems = append(problems, "missing tags "+strings.Join(r.MissingTags, ", "))
+	}
+	if len(r.InvalidTags) > 0 {
+		problems = append(problems, "invalid tags "+strings.Join(r.InvalidTags, ", "))
+	}
+
+	return strings.Join(problems, " and ")
+}
+
+// BlockingTagPolicies returns the number of tag policies with non-compliant
+// resources that fail the run.
+func (r *Root) BlockingTagPolicies() int {
+	blocking := map[string]bool{}
+	for _, t := range r.TagPolicies {
+		if t.Blocking && len(t.NonCompliantResources) > 0 {
+			blocking[t.Name] = true
+		}
+	}
+
+	return len(blocking)
+}
+
+// tagPolicyTotal is the results of a tag policy summed over the projects.
+type tagPolicyTotal struct {
+	name                string
+	blocking            bool
+	totalResources      int
+	totalMonthlyCost    decimal.Decimal
+	untaggedMonthlyCost decimal.Decimal
+	resources           []tagPolicyTotalResource
+}
+
+type tagPolicyTotalResource struct {
+	TagPolicyResource
+	projectName string
+}
+
+// tagPolicyTotals sums the results of each tag policy over the projects, in the
+// order of the policies, with the non-compliant resources sorted by their
+// monthly cost so the most expensive ones are listed first.
+func tagPolicyTotals(results []TagPolicyResult) []*tagPolicyTotal {
+	var totals []*tagPolicyTotal
+	byName := map[string]*tagPolicyTotal{}
+
+	for _, r := range results {
+		t, ok := byName[r.Name]
+		if !ok {
+			t = &tagPolicyTotal{name: r.Name, blocking: r.Blocking}
+			byName[r.Name] = t
+			totals = append(totals, t)
+		}
+
+		t.totalResources += r.TotalResources
+		if r.TotalMonthlyCost != nil {
+			t.totalMonthlyCost = t.totalMonthlyCost.Add(*r.TotalMonthlyCost)
+		}
+		if r.UntaggedMonthlyCost != nil {
+			t.untaggedMonthlyCost = t.untaggedMonthlyCost.Add(*r.UntaggedMonthlyCost)
+		}
+
+		for _, res := range r.NonCompliantResources {
+			t.resources = append(t.resources, tagPolicyTotalResource{TagPolicyResource: res, projectName: r.ProjectName})
+		}
+	}
+
+	for _, t := range totals {
+		sort.SliceStable(t.resources, func(i, j int) bool {
+			return costOrZero(t.resources[i].MonthlyCost).GreaterThan(costOrZero(t.resources[j].MonthlyCost))
+		})
+	}
+
+	return totals
+}
+
+func costOrZero(d *decimal.Decimal) decimal.Decimal {
+	if d == nil {
+		return decimal.Zero
+	}
+
+	return *d
+}
+
+// tagPoliciesToTable lists the untagged spend of each tag policy, with its most
+// expensive non-compliant resources.
+func tagPoliciesToTable(out Root) string {
+	if len(out.TagPolicies) == 0 {
+		return ""
+	}
+
+	s := "──────────────────────────────────\n"
+	s += ui.BoldString("Tag policies:")
+
+	for _, t := range tagPolicyTotals(out.TagPolicies) {
+		if len(t.resources) == 0 {
+			s += fmt.Sprintf("\n%s %s: all %d resources are compliant", ui.SuccessString("✔"), t.name, t.totalResources)
+			continue
+		}
+
+		status := ui.WarningString("!")
+		if t.blocking {
+			status = ui.ErrorString("✖")
+		}
+
+		s += fmt.Sprintf("\n%s %s: %d of %d resources are non-compliant, %s of %s monthly cost is untagged",
+			status,
+			t.name,
+			len(t.resources),
+			t.totalResources,
+			formatCost(out.Currency, &t.untaggedMonthlyCost),
+			formatCost(out.Currency, &t.totalMonthlyCost),
+		)
+
+		if t.totalMonthlyCost.IsPositive() {
+			percent := t.untaggedMonthlyCost.Div(t.totalMonthlyCost).Mul(decimal.NewFromInt(100)).Round(1)
+			s += fmt.Sprintf(" (%s%%)", percent.String())
+		}
+
+		for i, r := range t.resources {
+			if i == maxTagPolicyResources {
+				s += ui.FaintStringf("\n  ∙ and %d more, see the JSON output", len(t.resources)-maxTagPolicyResources)
+				break
+			}
+
+			var issues []string
+			if len(r.MissingTags) > 0 {
+				issues = append(issues, "missing "+strings.Join(r.MissingTags, ", "))
+			}
+			if len(r.InvalidTags) > 0 {
+				issues = append(issues, "invalid "+strings.Join(r.InvalidTags, ", "))
+			}
+
+			s += fmt.Sprintf("\n  ∙ %s %s: %s", r.Address, ui.FaintStringf("(%s)", formatCost(out.Currency, r.MonthlyCost)), strings.Join(issues, "; "))
+			if len(out.Projects) > 1 {
+				s += ui.FaintStringf(" (%s)", r.projectName)
+			}
+		}
+	}
+
+	return s
+}
@@ -0,0 +1,47 @@
+package output
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTagPolicyTotals(t *testing.T) {
+	cost := func(v int64) *decimal.Decimal {
+		d := decimal.NewFromInt(v)
+		return &d
+	}
+
+	totals := tagPolicyTotals([]TagPolicyResult{
+		{
+			Name:                "Cost allocation",
+			ProjectName:         "prod",
+			TotalResources:      3,
+			TotalMonthlyCost:    cost(500),
+			UntaggedMonthlyCost: cost(50),
+			NonCompliantResources: []TagPolicyResource{
+				{Address: "aws_db_instance.db", MonthlyCost: cost(50)},
+			},
+		},
+		{
+			Name:                "Cost allocation",
+			ProjectName:         "dev",
+			TotalResources:      2,
+			TotalMonthlyCost:    cost(200),
+			UntaggedMonthlyCost: cost(120),
+			NonCompliantResources: []TagPolicyResource{
+				{Address: "aws_instance.web", MonthlyCost: cost(120)},
+			},
+		},
+	})
+	require.Len(t, totals, 1)
+
+	assert.Equal(t, 5, totals[0].totalResources)
+	assert.Equal(t, "700", totals[0].totalMonthlyCost.String())
+	assert.Equal(t, "170", totals[0].untaggedMonthlyCost.String())
+	require.Len(t, totals[0].resources, 2)
+	assert.Equal(t, "aws_instance.web", totals[0].resources[0].Address)
+	assert.Equal(t, "dev", totals[0].resources[0].projectName)
+}
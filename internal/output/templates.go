@@ -51,7 +51,12 @@ td.name {
   max-width: 32rem;
 }
 
-td.monthly-quantity, td.price, td.hourly-cost, td.monthly-cost {
+td.name .price-tier {
+  color: #6b7280;
+  font-size: 0.75rem;
+}
+
+td.monthly-quantity, td.price, td.hourly-cost, td.monthly-cost, td.resources {
   text-align: right;
 }
 
@@ -139,7 +144,7 @@ iVBORw0KGgoAAAANSUhEUgAAAMAAAADACAMAAABlApw1AAAABGdBTUEAALGPC/xhBQAAAAFzUkdCAK7O
 {{end}}
 
 {{define "resourceRows"}}
-  {{$fields := .Fields}}
+  {{$fields := .Fields}}{{$currency := .Currency}}
   {{- $filteredCostComponents := filterZeroValComponents .Resource.CostComponents .Resource.Name}}
   {{- $filteredSubResources := filterZeroValResources .Resource.SubResources .Resource.Name}}
   {{- if hasCost $filteredCostComponents $filteredSubResources .Resource.Name }}
@@ -167,10 +172,10 @@ iVBORw0KGgoAAAANSUhEUgAAAMAAAADACAMAAABlApw1AAAABGdBTUEAALGPC/xhBQAAAAFzUkdCAK7O
   {{end}}
   {{$ident := add .Indent 1}}
   {{range $filteredCostComponents}}
-    {{template "costComponentRow" dict "CostComponent" . "Fields" $fields "Indent" $ident}}
+    {{template "costComponentRow" dict "CostComponent" . "Fields" $fields "Indent" $ident "Currency" $currency}}
   {{end}}
   {{range $filteredSubResources}}
-    {{template "resourceRows" dict "Resource" . "Fields" $fields "Indent" $ident}}
+    {{template "resourceRows" dict "Resource" . "Fields" $fields "Indent" $ident "Currency" $currency}}
   {{end}}
   {{- end}}
 {{end}}
@@ -181,6 +186,7 @@ iVBORw0KGgoAAAANSUhEUgAAAMAAAADACAMAAABlApw1AAAABGdBTUEAALGPC/xhBQAAAAFzUkdCAK7O
       {{if gt .Indent 1}}{{repeat (int (add .Indent -1)) "&nbsp;&nbsp;&nbsp;&nbsp;" | safeHTML}}{{end}}
       {{if gt .Indent 0}}<span class="arrow">&#8627;</span>{{end}}
       {{.CostComponent.Name}}
+      {{if .CostComponent.PriceTier}}<span class="price-tier">{{formatPriceTier .CostComponent.PriceTier .CostComponent.Unit}}</span>{{end}}
     </td>
     {{if .CostComponent.MonthlyCost}}
       {{if contains .Fields "monthlyQuantity"}}
@@ -190,16 +196,16 @@ iVBORw0KGgoAAAANSUhEUgAAAMAAAADACAMAAABlApw1AAAABGdBTUEAALGPC/xhBQAAAAFzUkdCAK7O
         <td class="unit">{{.CostComponent.Unit}}</td>
       {{end}}
       {{if contains .Fields "price"}}
-        <td class="price">{{.CostComponent.Price | formatPrice }}</td>
+        <td class="price">{{formatPrice .Currency .CostComponent.Price}}</td>
       {{end}}
       {{if contains .Fields "hourlyCost"}}
-        <td class="hourly-cost">{{.CostComponent.HourlyCost | formatCost2DP}}</td>
+        <td class="hourly-cost">{{formatCost2DP .Currency .CostComponent.HourlyCost}}</td>
       {{end}}
       {{if contains .Fields "monthlyCost"}}
-        <td class="monthly-cost">{{.CostComponent.MonthlyCost | formatCost2DP}}</td>
+        <td class="monthly-cost">{{formatCost2DP .Currency .CostComponent.MonthlyCost}}</td>
       {{end}}
     {{else}}
-      <td colspan="{{len .Fields}}" class="usage-cost">Cost depends on usage: {{.CostComponent.Price | formatPrice}} per {{.CostComponent.Unit}}</td>
+      <td colspan="{{len .Fields}}" class="usage-cost">Cost depends on usage: {{formatPrice .Currency .CostComponent.Price}} per {{.CostComponent.Unit}}</td>
     {{end}}
   </tr>
 {{end}}
@@ -213,30 +219,36 @@ iVBORw0KGgoAAAANSUhEUgAAAMAAAADACAMAAABlApw1AAAABGdBTUEAALGPC/xhBQAAAAFzUkdCAK7O
     <td class="unit">Unit</td>
   {{end}}
   {{if contains .Fields "price"}}
-    <td class="price">{{ "Price" | formatTitleWithCurrency }}</td>
+    <td class="price">{{formatTitleWithCurrency "Price" .Currency}}</td>
   {{end}}
   {{if contains .Fields "hourlyCost"}}
-    <td class="hourly-cost">{{ "Hourly Cost" | formatTitleWithCurrency }}</td>
+    <td class="hourly-cost">{{formatTitleWithCurrency "Hourly Cost" .Currency}}</td>
   {{end}}
   {{if contains .Fields "monthlyCost"}}
-    <td class="monthly-cost">{{ "Monthly Cost" | formatTitleWithCurrency }}</td>
+    <td class="monthly-cost">{{formatTitleWithCurrency "Monthly Cost" .Currency}}</td>
   {{end}}
 {{end}}
 
 {{define "projectBlock"}}
-  {{$fields := .Options.Fields}}
+  {{$fields := .Options.Fields}}{{$currency := projectCurrency .Project}}
   <p class="project-name">Project: {{.Project | projectLabel}}</p>
+  {{- if .Project.Metadata}}{{if .Project.Metadata.Group}}
+  <p class="project-group">Group: {{.Project.Metadata.Group}}</p>
+  {{- end}}{{end}}
+  {{- if .Project.LabelsString}}
+  <p class="project-labels">Labels: {{.Project.LabelsString}}</p>
+  {{- end}}
   <table class="breakdown">
     <thead>
-      {{template "tableHeaders" dict "Fields" $fields}}
+      {{template "tableHeaders" dict "Fields" $fields "Currency" $currency}}
     </thead>
     <tbody>
       {{range .Resources}}
-        {{template "resourceRows" dict "Resource" . "Fields" $fields "Indent" 0}}
+        {{template "resourceRows" dict "Resource" . "Fields" $fields "Indent" 0 "Currency" $currency}}
       {{end}}
       <tr class="total">
         <td class="name" colspan="{{len .Options.Fields}}">Project total</td>
-        <td class="monthly-cost">{{.Project.Breakdown.TotalMonthlyCost | formatCost2DP}}</td>
+        <td class="monthly-cost">{{formatCost2DP $currency .Project.Breakdown.TotalMonthlyCost}}</td>
       </tr>
     </tbody>
   </table>
@@ -276,11 +288,79 @@ iVBORw0KGgoAAAANSUhEUgAAAMAAAADACAMAAABlApw1AAAABGdBTUEAALGPC/xhBQAAAAFzUkdCAK7O
     <table class="overall-total">
       <tbody>
         <tr class="total">
-          <td class="name" colspan="{{len .Options.Fields}}">{{ "Overall total" | formatTitleWithCurrency }}</td>
-          <td class="monthly-cost">{{.Root.TotalMonthlyCost | formatCost2DP}}</td>
+          <td class="name" colspan="{{len .Options.Fields}}">{{formatTitleWithCurrency "Overall total" .Root.Currency}}</td>
+          <td class="monthly-cost">{{formatCost2DP .Root.Currency .Root.TotalMonthlyCost}}</td>
+        </tr>
+      </tbody>
+    </table>
+    {{- if .Root.ProjectGroups}}
+
+    <p class="project-name">Costs by project group</p>
+    <table class="breakdown project-groups">
+      <thead>
+        <tr>
+          <td class="name">Group</td>
+          <td class="resources">Projects</td>
+          <td class="monthly-cost">{{formatTitleWithCurrency "Monthly Cost" .Root.Currency}}</td>
+        </tr>
+      </thead>
+      <tbody>
+        {{- range .Root.ProjectGroups}}
+        <tr>
+          <td class="name">{{.Name}}</td>
+          <td class="resources">{{.Projects}}</td>
+          <td class="monthly-cost">{{formatCost2DP $.Root.Currency .TotalMonthlyCost}}</td>
+        </tr>
+        {{- end}}
+      </tbody>
+    </table>
+    {{- end}}
+    {{- if .Root.CostGroups}}
+
+    <p class="project-name">Costs by {{.Root.GroupBy}}</p>
+    <table class="breakdown cost-groups">
+      <thead>
+        <tr>
+          <td class="name">{{.Root.GroupBy | trimPrefix "tag:"}}</td>
+          <td class="resources">Resources</td>
+          <td class="monthly-cost">{{formatTitleWithCurrency "Monthly Cost" .Root.Currency}}</td>
+        </tr>
+      </thead>
+      <tbody>
+        {{- range .Root.CostGroups}}
+        <tr>
+          <td class="name">{{.Name}}</td>
+          <td class="resources">{{.Resources}}</td>
+          <td class="monthly-cost">{{formatCost2DP $.Root.Currency .TotalMonthlyCost}}</td>
         </tr>
+        {{- end}}
       </tbody>
     </table>
+    {{- end}}
+    {{- if .Root.TagPolicies}}
+
+    <p class="project-name">Tag policies</p>
+    <table class="breakdown tag-policies">
+      <thead>
+        <tr>
+          <td class="name">Policy</td>
+          <td class="name">Project</td>
+          <td class="resources">Non-compliant resources</td>
+          <td class="monthly-cost">{{formatTitleWithCurrency "Untagged Monthly Cost" .Root.Currency}}</td>
+        </tr>
+      </thead>
+      <tbody>
+        {{- range .Root.TagPolicies}}
+        <tr>
+          <td class="name">{{.Name}}</td>
+          <td class="name">{{.ProjectName}}</td>
+          <td class="resources">{{len .NonCompliantResources}} of {{.TotalResources}}</td>
+          <td class="monthly-cost">{{formatCost2DP $.Root.Currency .UntaggedMonthlyCost}}</td>
+        </tr>
+        {{- end}}
+      </tbody>
+    </table>
+    {{- end}}
 
     <div class="warnings">
       <p>{{.SummaryMessage | stripColor | replaceNewLines}}</p>
@@ -309,9 +389,12 @@ var CommentMarkdownWithHTMLTemplate = `
   <tbody>
   {{- range .Root.Projects }}
     {{- if hasDiff . }}
-      {{- template "summaryRow" dict "Name" .Name "PastCost" .PastBreakdown.TotalMonthlyCost "Cost" .Breakdown.TotalMonthlyCost  }}
+      {{- template "summaryRow" dict "Name" .Name "PastCost" (pastTotalMonthlyCost .) "Cost" (totalMonthlyCost .)  }}
     {{- end }}
   {{- end }}
+  {{- range .Root.ProjectGroups }}
+    {{- template "summaryRow" dict "Name" (print "Group: " .Name) "PastCost" .PastTotalMonthlyCost "Cost" .TotalMonthlyCost  }}
+  {{- end }}
   {{- template "summaryRow" dict "Name" "All projects" "PastCost" .Root.PastTotalMonthlyCost "Cost" .Root.TotalMonthlyCost  }}
   </tbody>
 </table>
@@ -326,7 +409,7 @@ var CommentMarkdownWithHTMLTemplate = `
 {{- else }}
   <tbody>
   {{- range .Root.Projects }}
-    {{- template "summaryRow" dict "Name" .Name "PastCost" .PastBreakdown.TotalMonthlyCost "Cost" .Breakdown.TotalMonthlyCost  }}
+    {{- template "summaryRow" dict "Name" .Name "PastCost" (pastTotalMonthlyCost .) "Cost" (totalMonthlyCost .)  }}
   {{- end }}
   </tbody>
 </table>
@@ -387,9 +470,12 @@ var CommentMarkdownTemplate = `
 {{- if gt (len .Root.Projects) 1  }}
   {{- range .Root.Projects }}
     {{- if hasDiff . }}
-      {{- template "summaryRow" dict "Name" .Name "PastCost" .PastBreakdown.TotalMonthlyCost "Cost" .Breakdown.TotalMonthlyCost  }}
+      {{- template "summaryRow" dict "Name" .Name "PastCost" (pastTotalMonthlyCost .) "Cost" (totalMonthlyCost .)  }}
     {{- end }}
   {{- end }}
+  {{- range .Root.ProjectGroups }}
+    {{- template "summaryRow" dict "Name" (print "Group: " .Name) "PastCost" .PastTotalMonthlyCost "Cost" .TotalMonthlyCost  }}
+  {{- end }}
   {{- template "totalRow" dict "Name" "All projects" "PastCost" .Root.PastTotalMonthlyCost "Cost" .Root.TotalMonthlyCost  }}
 
   {{- if eq .SkippedProjectCount 1 }}
@@ -401,7 +487,7 @@ var CommentMarkdownTemplate = `
   {{- end }}
 {{- else }}
   {{- range .Root.Projects }}
-    {{- template "summaryRow" dict "Name" .Name "PastCost" .PastBreakdown.TotalMonthlyCost "Cost" .Breakdown.TotalMonthlyCost  }}
+    {{- template "summaryRow" dict "Name" .Name "PastCost" (pastTotalMonthlyCost .) "Cost" (totalMonthlyCost .)  }}
   {{- end }}
 {{- end }}
 
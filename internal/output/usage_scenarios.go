@@ -0,0 +1,106 @@
+package output
+
+import (
+	"fmt"
+
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/jedib0t/go-pretty/v6/text"
+	"github.com/shopspring/decimal"
+
+	"github.com/infracost/infracost/internal/schema"
+	"github.com/infracost/infracost/internal/ui"
+)
+
+// usageScenarioColumns are the usage scenarios in the order they're shown, with
+// their column titles.
+var usageScenarioColumns = []struct {
+	scenario string
+	title    string
+}{
+	{"low", "Low"},
+	{"expected", "Expected"},
+	{"high", "High"},
+}
+
+// outputUsageScenarios returns the monthly cost of the project for each usage
+// scenario, or nil if they weren't compared.
+func outputUsageScenarios(project *schema.Project) map[string]*decimal.Decimal {
+	if len(project.UsageScenarioCosts) == 0 {
+		return nil
+	}
+
+	m := make(map[string]*decimal.Decimal, len(project.UsageScenarioCosts))
+	for scenario, cost := range project.UsageScenarioCosts {
+		m[scenario] = decimalPtr(cost)
+	}
+
+	return m
+}
+
+// usageScenarioTotals sums the monthly cost of each usage scenario of the
+// projects in the currency of the report.
+func usageScenarioTotals(projects []Project) map[string]*decimal.Decimal {
+	var totals map[string]*decimal.Decimal
+
+	for _, p := range projects {
+		for scenario, cost := range p.reportCurrencyTotals().UsageScenarios {
+			if totals == nil {
+				totals = make(map[string]*decimal.Decimal)
+			}
+
+			totals[scenario] = addCost(totals[scenario], cost)
+		}
+	}
+
+	return totals
+}
+
+// usageScenariosToTable shows the monthly cost of each project with the low,
+// expected and high usage, so reviewers can see how uncertain the estimate is.
+func usageScenariosToTable(out Root, opts Options) string {
+	if len(out.UsageScenarios) == 0 {
+		return ""
+	}
+
+	t := table.NewWriter()
+	t.Style().Options.DrawBorder = false
+	t.Style().Options.SeparateColumns = false
+	t.Style().Options.SeparateRows = false
+	t.Style().Options.SeparateHeader = false
+	t.Style().Format.Header = text.FormatDefault
+
+	headers := table.Row{ui.UnderlineString("Project")}
+	columns := []table.ColumnConfig{{Number: 1, Align: text.AlignLeft}}
+	for i, c := range usageScenarioColumns {
+		headers = append(headers, ui.UnderlineString(formatTitleWithCurrency(c.title, out.Currency)))
+		columns = append(columns, table.ColumnConfig{Number: i + 2, Align: text.AlignRight, AlignHeader: text.AlignRight})
+	}
+	t.AppendHeader(headers)
+	t.SetColumnConfigs(columns)
+
+	for _, p := range out.Projects {
+		scenarios := p.reportCurrencyTotals().UsageScenarios
+		if len(scenarios) == 0 {
+			continue
+		}
+
+		row := table.Row{p.Label(opts.DashboardEnabled)}
+		for _, c := range usageScenarioColumns {
+			row = append(row, formatCost2DP(out.Currency, scenarios[c.scenario]))
+		}
+		t.AppendRow(row)
+	}
+
+	if len(out.Projects) > 1 {
+		row := table.Row{ui.BoldString("Overall total")}
+		for _, c := range usageScenarioColumns {
+			row = append(row, formatCost2DP(out.Currency, out.UsageScenarios[c.scenario]))
+		}
+		t.AppendRow(row)
+	}
+
+	return fmt.Sprintf("──────────────────────────────────\n%s\n\n%s",
+		ui.BoldString("Monthly cost by usage scenario:"),
+		t.Render(),
+	)
+}
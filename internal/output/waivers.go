@@ -0,0 +1,89 @@
+package output
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/infracost/infracost/internal/ui"
+)
+
+// Waiver is an exception of the waivers file to the guardrails and tag
+// policies. It matches the failures of the Rule, i.e. the name of a guardrail
+// or tag policy, for the resources or modules at Address in the projects
+// matching Project, and any of them that's empty matches everything. A waiver
+// has Expired once the day of its Expires date has passed and no longer applies,
+// so the failures it matches fail the run again.
+type Waiver struct {
+	Rule    string `json:"rule,omitempty"`
+	Address string `json:"address,omitempty"`
+	Project string `json:"project,omitempty"`
+	Reason  string `json:"reason"`
+	Owner   string `json:"owner"`
+	Expires string `json:"expires"`
+	Expired bool   `json:"expired"`
+	// Failures are the guardrail violations and non-compliant resources the
+	// waiver matches, they're waived unless it has expired.
+	Failures []WaivedFailure `json:"failures"`
+}
+
+// WaivedFailure is a guardrail violation or a resource that doesn't comply with
+// a tag policy that a waiver matches. Address is empty for the violations of a
+// project.
+type WaivedFailure struct {
+	Rule        string `json:"rule"`
+	ProjectName string `json:"projectName"`
+	Address     string `json:"address,omitempty"`
+}
+
+// Target returns what the waiver applies to, e.g. the rule and address.
+func (w Waiver) Target() string {
+	var parts []string
+	if w.Rule != "" {
+		parts = append(parts, w.Rule)
+	}
+	if w.Address != "" {
+		parts = append(parts, w.Address)
+	}
+	if w.Project != "" {
+		parts = append(parts, "project "+w.Project)
+	}
+
+	return strings.Join(parts, ", ")
+}
+
+// waiversToTable lists the waivers so they're reviewed before they expire, with
+// the expired ones highlighted.
+func waiversToTable(out Root) string {
+	if len(out.Waivers) == 0 {
+		return ""
+	}
+
+	s := "──────────────────────────────────\n"
+
+	if len(out.Waivers) == 1 {
+		s += ui.BoldString("1 waiver:")
+	} else {
+		s += ui.BoldString(fmt.Sprintf("%d waivers:", len(out.Waivers)))
+	}
+
+	for _, w := range out.Waivers {
+		s += fmt.Sprintf("\n∙ %s: %s", w.Target(), w.Reason)
+
+		if w.Expired {
+			s += " " + ui.WarningStringf("(%s, expired %s, %s no longer waived)", w.Owner, w.Expires, pluralize(len(w.Failures), "failure", "failures"))
+			continue
+		}
+
+		s += " " + ui.FaintStringf("(%s, until %s, %s waived)", w.Owner, w.Expires, pluralize(len(w.Failures), "failure", "failures"))
+	}
+
+	return s
+}
+
+func pluralize(n int, singular string, plural string) string {
+	if n == 1 {
+		return fmt.Sprintf("%d %s", n, singular)
+	}
+
+	return fmt.Sprintf("%d %s", n, plural)
+}
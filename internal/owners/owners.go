@@ -0,0 +1,307 @@
+// Package owners attributes the resources of a run to the teams that own them,
+// from a CODEOWNERS file or an owners mapping file, so their costs can be
+// summed per team and checked against the teams' budgets.
+package owners
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+
+	"github.com/infracost/infracost/internal/output"
+)
+
+// rule is a line of a CODEOWNERS file or an entry of a mapping file. The files
+// and resources it matches are owned by the first of its owners.
+type rule struct {
+	pattern string
+	re      *regexp.Regexp
+	dirOnly bool
+	module  string
+	owners  []string
+}
+
+// Owners are the rules of a CODEOWNERS or mapping file. Like CODEOWNERS, the
+// last rule that matches a resource wins.
+type Owners struct {
+	rules []rule
+}
+
+type mappingFile struct {
+	Version string `yaml:"version"`
+	Owners  []struct {
+		Path   string `yaml:"path"`
+		Module string `yaml:"module"`
+		Owner  string `yaml:"owner"`
+	} `yaml:"owners"`
+}
+
+// Load reads the rules of a CODEOWNERS file, or of a mapping file if it has a
+// .yml or .yaml extension, e.g.:
+//
+//	version: 0.1
+//	owners:
+//	  - path: environments/prod/
+//	    owner: "@acme/payments"
+//	  - module: module.eks
+//	    owner: "@acme/platform"
+//
+// The paths of a mapping file are CODEOWNERS patterns, module matches the
+// resources of a module and its submodules.
+func Load(path string) (*Owners, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("Error reading owners file: %w", err)
+	}
+	defer f.Close()
+
+	ext := strings.ToLower(filepath.Ext(path))
+	if ext == ".yml" || ext == ".yaml" {
+		return parseMapping(f)
+	}
+
+	return ParseCODEOWNERS(f)
+}
+
+// ParseCODEOWNERS parses the rules of a CODEOWNERS file, one pattern followed by
+// its owners per line.
+func ParseCODEOWNERS(r io.Reader) (*Owners, error) {
+	o := &Owners{}
+
+	scanner := bufio.NewScanner(r)
+	line := 0
+	for scanner.Scan() {
+		line++
+
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+
+		// Owners can be followed by a comment
+		if i := strings.Index(text, " #"); i >= 0 {
+			text = text[:i]
+		}
+
+		fields := strings.Fields(text)
+
+		ru, err := newRule(fields[0], "", fields[1:])
+		if err != nil {
+			return nil, fmt.Errorf("Invalid CODEOWNERS line %d: %w", line, err)
+		}
+
+		o.rules = append(o.rules, ru)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("Error reading CODEOWNERS file: %w", err)
+	}
+
+	return o, nil
+}
+
+func parseMapping(r io.Reader) (*Owners, error) {
+	b, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("Error reading owners file: %w", err)
+	}
+
+	var m mappingFile
+	if err := yaml.UnmarshalStrict(b, &m); err != nil {
+		return nil, fmt.Errorf("Error parsing owners file: %w", err)
+	}
+
+	o := &Owners{}
+	for i, e := range m.Owners {
+		if e.Path == "" && e.Module == "" {
+			return nil, fmt.Errorf("Invalid owners entry %d: path or module is required", i+1)
+		}
+
+		if e.Owner == "" {
+			return nil, fmt.Errorf("Invalid owners entry %d: owner is required", i+1)
+		}
+
+		ru, err := newRule(e.Path, strings.TrimSuffix(e.Module, "."), []string{e.Owner})
+		if err != nil {
+			return nil, fmt.Errorf("Invalid owners entry %d: %w", i+1, err)
+		}
+
+		o.rules = append(o.rules, ru)
+	}
+
+	return o, nil
+}
+
+func newRule(pattern string, module string, owners []string) (rule, error) {
+	ru := rule{pattern: pattern, module: module, owners: owners}
+	if pattern == "" {
+		return ru, nil
+	}
+
+	p := pattern
+	if strings.HasSuffix(p, "/") {
+		ru.dirOnly = true
+		p = strings.TrimSuffix(p, "/")
+	}
+
+	// Like gitignore, patterns without a slash match at any depth and the
+	// others are relative to the root of the repo.
+	prefix := "(?:.*/)?"
+	if strings.Contains(p, "/") {
+		prefix = ""
+		p = strings.TrimPrefix(p, "/")
+	}
+
+	if p == "" {
+		return ru, fmt.Errorf("invalid pattern %q", pattern)
+	}
+
+	re, err := regexp.Compile("^" + prefix + globToRegexp(p) + "$")
+	if err != nil {
+		return ru, fmt.Errorf("invalid pattern %q: %w", pattern, err)
+	}
+	ru.re = re
+
+	return ru, nil
+}
+
+// globToRegexp converts a CODEOWNERS glob to a regexp, * and ? don't match
+// slashes and ** matches any number of directories.
+func globToRegexp(glob string) string {
+	var b strings.Builder
+
+	for i := 0; i < len(glob); i++ {
+		c := glob[i]
+		switch {
+		case strings.HasPrefix(glob[i:], "**/"):
+			b.WriteString("(?:.*/)?")
+			i += 2
+		case strings.HasPrefix(glob[i:], "**"):
+			b.WriteString(".*")
+			i++
+		case c == '*':
+			b.WriteString("[^/]*")
+		case c == '?':
+			b.WriteString("[^/]")
+		default:
+			b.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+
+	return b.String()
+}
+
+// matchesPath returns true if the pattern of the rule matches the path or one
+// of its parent directories, isDir is true if the path is a directory.
+func (ru rule) matchesPath(path string, isDir bool) bool {
+	if ru.re == nil {
+		return true
+	}
+
+	if ru.re.MatchString(path) && (isDir || !ru.dirOnly) {
+		return true
+	}
+
+	for dir := parentDir(path); dir != ""; dir = parentDir(dir) {
+		if ru.re.MatchString(dir) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func parentDir(path string) string {
+	i := strings.LastIndex(path, "/")
+	if i <= 0 {
+		return ""
+	}
+
+	return path[:i]
+}
+
+func (ru rule) matchesModule(address string) bool {
+	if ru.module == "" {
+		return true
+	}
+
+	return strings.HasPrefix(address, ru.module+".") || strings.HasPrefix(address, ru.module+"[")
+}
+
+// Owner returns the owner of the resource with the address defined in the file
+// or the project directory, or an empty string if it's unowned. Paths are
+// relative to the root of the repo.
+func (o *Owners) Owner(path string, isDir bool, address string) string {
+	path = strings.Trim(filepath.ToSlash(filepath.Clean(path)), "/")
+	if path == "." {
+		path = ""
+	}
+
+	for i := len(o.rules) - 1; i >= 0; i-- {
+		ru := o.rules[i]
+		if !ru.matchesModule(address) || !ru.matchesPath(path, isDir) {
+			continue
+		}
+
+		if len(ru.owners) == 0 {
+			return ""
+		}
+
+		return ru.owners[0]
+	}
+
+	return ""
+}
+
+// Assign sets the owner of the resources of the output. Resources are matched
+// by the file they're defined in when it's known, i.e. for Terraform
+// directories, and by the directory of their project otherwise. The paths are
+// taken relative to the working directory, which should be the root of the
+// repo.
+func (o *Owners) Assign(r *output.Root) {
+	for _, p := range r.Projects {
+		projectPath := ""
+		if p.Metadata != nil {
+			projectPath = relativeToWorkingDir(p.Metadata.Path)
+		}
+
+		for _, b := range []*output.Breakdown{p.Breakdown, p.PastBreakdown, p.Diff} {
+			if b == nil {
+				continue
+			}
+
+			for i, res := range b.Resources {
+				if res.SourceRange != nil && res.SourceRange.Filename != "" {
+					b.Resources[i].Owner = o.Owner(relativeToWorkingDir(res.SourceRange.Filename), false, res.Name)
+					continue
+				}
+
+				b.Resources[i].Owner = o.Owner(projectPath, true, res.Name)
+			}
+		}
+	}
+}
+
+func relativeToWorkingDir(path string) string {
+	if !filepath.IsAbs(path) {
+		return path
+	}
+
+	wd, err := os.Getwd()
+	if err != nil {
+		return path
+	}
+
+	rel, err := filepath.Rel(wd, path)
+	if err != nil {
+		return path
+	}
+
+	return rel
+}
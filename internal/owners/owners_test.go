@@ -0,0 +1,109 @@
+package owners
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/infracost/infracost/internal/output"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+const codeowners = `# Platform owns everything by default
+*                     @acme/platform
+
+/environments/prod/   @acme/payments @acme/sre # payments is charged back
+modules/**/db.tf      @acme/data
+docs/
+*.md                  @acme/docs
+`
+
+func TestCODEOWNERSOwner(t *testing.T) {
+	o, err := ParseCODEOWNERS(strings.NewReader(codeowners))
+	require.NoError(t, err)
+
+	tests := []struct {
+		path  string
+		isDir bool
+		owner string
+	}{
+		{"main.tf", false, "@acme/platform"},
+		{"environments/prod", true, "@acme/payments"},
+		{"environments/prod/main.tf", false, "@acme/payments"},
+		{"environments/prod/app/main.tf", false, "@acme/payments"},
+		{"environments/dev/main.tf", false, "@acme/platform"},
+		{"modules/rds/db.tf", false, "@acme/data"},
+		{"modules/db.tf", false, "@acme/data"},
+		{"modules/rds/main.tf", false, "@acme/platform"},
+		{"docs/main.tf", false, ""},
+		{"environments/prod/README.md", false, "@acme/docs"},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.owner, o.Owner(tt.path, tt.isDir, "aws_instance.web"), tt.path)
+	}
+}
+
+func TestLoadMapping(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "owners.yml")
+	require.NoError(t, os.WriteFile(path, []byte(`version: 0.1
+owners:
+  - path: environments/
+    owner: "@acme/platform"
+  - module: module.eks
+    owner: "@acme/k8s"
+`), 0600))
+
+	o, err := Load(path)
+	require.NoError(t, err)
+
+	assert.Equal(t, "@acme/platform", o.Owner("environments/prod", true, "aws_instance.web"))
+	assert.Equal(t, "@acme/k8s", o.Owner("environments/prod", true, "module.eks.aws_eks_cluster.this"))
+	assert.Equal(t, "@acme/k8s", o.Owner("environments/prod", true, "module.eks[0].aws_eks_cluster.this"))
+	assert.Equal(t, "@acme/k8s", o.Owner("other", true, "module.eks.module.nodes.aws_eks_node_group.this"))
+	assert.Equal(t, "", o.Owner("other", true, "module.eks_addons.aws_eks_addon.this"))
+}
+
+func TestLoadMappingInvalid(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "owners.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("owners:\n  - path: environments/\n"), 0600))
+
+	_, err := Load(path)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "owner is required")
+}
+
+func TestAssign(t *testing.T) {
+	o, err := ParseCODEOWNERS(strings.NewReader(codeowners))
+	require.NoError(t, err)
+
+	r := output.Root{
+		Projects: []output.Project{
+			{
+				Name:     "infracost/prod",
+				Metadata: &schema.ProjectMetadata{Path: "environments/prod"},
+				Breakdown: &output.Breakdown{
+					Resources: []output.Resource{
+						{Name: "aws_instance.web"},
+						{Name: "module.db.aws_db_instance.this", SourceRange: &schema.SourceRange{Filename: "modules/rds/db.tf", StartLine: 1, EndLine: 10}},
+					},
+				},
+				PastBreakdown: &output.Breakdown{
+					Resources: []output.Resource{
+						{Name: "aws_instance.web"},
+					},
+				},
+			},
+		},
+	}
+
+	o.Assign(&r)
+
+	assert.Equal(t, "@acme/payments", r.Projects[0].Breakdown.Resources[0].Owner)
+	assert.Equal(t, "@acme/data", r.Projects[0].Breakdown.Resources[1].Owner)
+	assert.Equal(t, "@acme/payments", r.Projects[0].PastBreakdown.Resources[0].Owner)
+}
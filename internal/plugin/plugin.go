@@ -0,0 +1,254 @@
+// Package plugin runs Infracost as a Drone or Woodpecker plugin. The plugin is
+// configured by the settings of its pipeline step, which both CI systems pass
+// as PLUGIN_* environment variables, and by the environment variables of the
+// build, so it can estimate the projects and comment on the pull request
+// without a script.
+package plugin
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/kelseyhightower/envconfig"
+)
+
+const (
+	PlatformGitHub     = "github"
+	PlatformGitLab     = "gitlab"
+	PlatformBitbucket  = "bitbucket"
+	PlatformAzureRepos = "azure-repos"
+)
+
+// Platforms are the platforms the plugin can comment on.
+var Platforms = []string{PlatformGitHub, PlatformGitLab, PlatformBitbucket, PlatformAzureRepos}
+
+// Settings are the settings of the plugin step, e.g. the fail_on_increase
+// setting is read from PLUGIN_FAIL_ON_INCREASE.
+type Settings struct {
+	// Path and ConfigFile are the projects to estimate, like --path and
+	// --config-file.
+	Path              string `default:"."`
+	ConfigFile        string `split_words:"true"`
+	UsageFile         string `split_words:"true"`
+	TerraformParseHCL bool   `split_words:"true" default:"true"`
+	// CompareToRef is the git ref the projects are diffed against, e.g.
+	// origin/main. The costs aren't diffed if it's empty.
+	CompareToRef string `split_words:"true"`
+
+	// Platform is where the comment is posted, see Platforms. It's detected
+	// from the forge or repo link of the build if it's empty.
+	Platform string
+	// ServerURL is the URL of the GitHub API, GitLab or Bitbucket server when
+	// they're self-hosted.
+	ServerURL string `split_words:"true"`
+	Token     string
+	Behavior  string `default:"update"`
+	Tag       string
+	// Status also reports the result as a GitHub check run, a Bitbucket Code
+	// Insights report or an Azure Repos pull request status.
+	Status bool
+
+	FailOnIncrease string `split_words:"true"`
+	FailOnTotal    string `split_words:"true"`
+
+	// APIKey is the Infracost API key, so it can be set from a secret like the
+	// other settings.
+	APIKey string `split_words:"true"`
+	DryRun bool   `split_words:"true"`
+}
+
+// LoadSettings reads the settings from the PLUGIN_* environment variables.
+func LoadSettings() (*Settings, error) {
+	var s Settings
+
+	err := envconfig.Process("plugin", &s)
+	if err != nil {
+		return nil, fmt.Errorf("Error reading the plugin settings: %w", err)
+	}
+
+	return &s, nil
+}
+
+// Build is the repo and commit or pull request that the CI build is of.
+type Build struct {
+	// Repo is the name of the repo, e.g. my-org/my-repo, and RepoURL its link.
+	Repo    string
+	RepoURL string
+	// PullRequest is the number of the pull request of the build, if any.
+	PullRequest string
+	Commit      string
+	// Forge is the platform of the repo as set by Woodpecker, e.g. github.
+	Forge string
+}
+
+// DetectBuild reads the build from the environment variables of Drone or
+// Woodpecker.
+func DetectBuild() Build {
+	return Build{
+		Repo:        firstEnv("DRONE_REPO", "CI_REPO"),
+		RepoURL:     firstEnv("DRONE_REPO_LINK", "CI_REPO_URL", "CI_REPO_LINK"),
+		PullRequest: firstEnv("DRONE_PULL_REQUEST", "CI_COMMIT_PULL_REQUEST"),
+		Commit:      firstEnv("DRONE_COMMIT_SHA", "CI_COMMIT_SHA"),
+		Forge:       os.Getenv("CI_FORGE_TYPE"),
+	}
+}
+
+// BreakdownArgs returns the args of the infracost run that estimates the
+// projects and saves them to the Infracost JSON file at outFile.
+func (s *Settings) BreakdownArgs(outFile string) []string {
+	args := []string{"breakdown", "--format", "json", "--out-file", outFile}
+
+	if s.ConfigFile != "" {
+		args = append(args, "--config-file", s.ConfigFile)
+	} else {
+		args = append(args, "--path", s.Path)
+		if s.UsageFile != "" {
+			args = append(args, "--usage-file", s.UsageFile)
+		}
+	}
+
+	if s.TerraformParseHCL {
+		args = append(args, "--terraform-parse-hcl")
+	}
+
+	if s.CompareToRef != "" {
+		args = append(args, "--compare-to-ref", s.CompareToRef)
+	}
+
+	return args
+}
+
+// CommentArgs returns the args of the infracost run that posts the comment of
+// the Infracost JSON file at path to the pull request of the build, or to its
+// commit if it isn't a pull request build.
+func (s *Settings) CommentArgs(b Build, path string) ([]string, error) {
+	platform, err := s.platform(b)
+	if err != nil {
+		return nil, err
+	}
+
+	if s.Token == "" {
+		return nil, fmt.Errorf("The token setting is required to comment on %s", platform)
+	}
+
+	args := []string{"comment", platform, "--path", path, "--behavior", s.Behavior}
+
+	switch platform {
+	case PlatformGitHub:
+		args = append(args, "--repo", b.Repo, "--github-token", s.Token)
+		if s.ServerURL != "" {
+			args = append(args, "--github-api-url", s.ServerURL)
+		}
+		if s.Status {
+			args = append(args, "--check-run")
+		}
+	case PlatformGitLab:
+		args = append(args, "--repo", b.Repo, "--gitlab-token", s.Token)
+		if s.ServerURL != "" {
+			args = append(args, "--gitlab-server-url", s.ServerURL)
+		}
+		if s.Status {
+			return nil, fmt.Errorf("The status setting isn't supported on %s", platform)
+		}
+	case PlatformBitbucket:
+		args = append(args, "--repo", b.Repo, "--bitbucket-token", s.Token)
+		if s.ServerURL != "" {
+			args = append(args, "--bitbucket-server-url", s.ServerURL)
+		}
+		if s.Status {
+			args = append(args, "--code-insights")
+		}
+	case PlatformAzureRepos:
+		if b.PullRequest == "" {
+			return nil, fmt.Errorf("Comments can only be posted to pull requests on %s", platform)
+		}
+
+		args = append(args, "--repo-url", b.RepoURL, "--azure-access-token", s.Token)
+		if s.Status {
+			args = append(args, "--status")
+			if s.FailOnIncrease != "" {
+				args = append(args, "--fail-on-increase", s.FailOnIncrease)
+			}
+			if s.FailOnTotal != "" {
+				args = append(args, "--fail-on-total", s.FailOnTotal)
+			}
+		}
+	}
+
+	switch {
+	case b.PullRequest != "" && platform == PlatformGitLab:
+		args = append(args, "--merge-request", b.PullRequest)
+	case b.PullRequest != "":
+		args = append(args, "--pull-request", b.PullRequest)
+	case b.Commit != "":
+		args = append(args, "--commit", b.Commit)
+	default:
+		return nil, fmt.Errorf("Could not find the pull request or commit of the build")
+	}
+
+	if s.Tag != "" {
+		args = append(args, "--tag", s.Tag)
+	}
+
+	if s.DryRun {
+		args = append(args, "--dry-run")
+	}
+
+	return args, nil
+}
+
+// platform returns the platform setting, or the platform detected from the
+// forge or the host of the repo link of the build.
+func (s *Settings) platform(b Build) (string, error) {
+	if s.Platform != "" {
+		if !contains(Platforms, s.Platform) {
+			return "", fmt.Errorf("The platform setting only supports %s", strings.Join(Platforms, ", "))
+		}
+
+		return s.Platform, nil
+	}
+
+	if contains(Platforms, b.Forge) {
+		return b.Forge, nil
+	}
+
+	u, err := url.Parse(b.RepoURL)
+	if err == nil {
+		host := strings.ToLower(u.Hostname())
+
+		switch {
+		case strings.Contains(host, "github"):
+			return PlatformGitHub, nil
+		case strings.Contains(host, "gitlab"):
+			return PlatformGitLab, nil
+		case strings.Contains(host, "bitbucket"):
+			return PlatformBitbucket, nil
+		case host == "dev.azure.com" || strings.HasSuffix(host, ".visualstudio.com"):
+			return PlatformAzureRepos, nil
+		}
+	}
+
+	return "", fmt.Errorf("Could not detect the platform of %s, set the platform setting to one of %s", b.RepoURL, strings.Join(Platforms, ", "))
+}
+
+func firstEnv(names ...string) string {
+	for _, name := range names {
+		if v := os.Getenv(name); v != "" {
+			return v
+		}
+	}
+
+	return ""
+}
+
+func contains(arr []string, e string) bool {
+	for _, a := range arr {
+		if a == e {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,80 @@
+package plugin
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadSettings(t *testing.T) {
+	t.Setenv("PLUGIN_CONFIG_FILE", "infracost.yml")
+	t.Setenv("PLUGIN_FAIL_ON_INCREASE", "10%")
+	t.Setenv("PLUGIN_API_KEY", "ico-abc")
+	t.Setenv("PLUGIN_STATUS", "true")
+
+	s, err := LoadSettings()
+	require.NoError(t, err)
+
+	assert.Equal(t, ".", s.Path)
+	assert.Equal(t, "infracost.yml", s.ConfigFile)
+	assert.Equal(t, "10%", s.FailOnIncrease)
+	assert.Equal(t, "ico-abc", s.APIKey)
+	assert.Equal(t, "update", s.Behavior)
+	assert.True(t, s.Status)
+	assert.True(t, s.TerraformParseHCL)
+}
+
+func TestDetectBuild(t *testing.T) {
+	for _, env := range []string{"DRONE_REPO", "DRONE_REPO_LINK", "DRONE_PULL_REQUEST", "DRONE_COMMIT_SHA"} {
+		t.Setenv(env, "")
+	}
+
+	t.Setenv("CI_REPO", "my-org/my-repo")
+	t.Setenv("CI_REPO_URL", "https://gitlab.example.com/my-org/my-repo")
+	t.Setenv("CI_COMMIT_PULL_REQUEST", "3")
+	t.Setenv("CI_COMMIT_SHA", "2ca7182")
+	t.Setenv("CI_FORGE_TYPE", "gitlab")
+
+	assert.Equal(t, Build{
+		Repo:        "my-org/my-repo",
+		RepoURL:     "https://gitlab.example.com/my-org/my-repo",
+		PullRequest: "3",
+		Commit:      "2ca7182",
+		Forge:       "gitlab",
+	}, DetectBuild())
+
+	// Drone's variables are used first.
+	t.Setenv("DRONE_REPO", "my-org/other-repo")
+	assert.Equal(t, "my-org/other-repo", DetectBuild().Repo)
+}
+
+func TestBreakdownArgs(t *testing.T) {
+	s := &Settings{Path: "infra", UsageFile: "usage.yml", TerraformParseHCL: true, CompareToRef: "origin/main"}
+	assert.Equal(t,
+		[]string{"breakdown", "--format", "json", "--out-file", "infracost.json", "--path", "infra", "--usage-file", "usage.yml", "--terraform-parse-hcl", "--compare-to-ref", "origin/main"},
+		s.BreakdownArgs("infracost.json"))
+}
+
+func TestCommentArgs(t *testing.T) {
+	s := &Settings{Token: "secret", Behavior: "update", Status: true}
+
+	args, err := s.CommentArgs(Build{Repo: "my-org/my-repo", RepoURL: "https://github.com/my-org/my-repo", PullRequest: "3", Commit: "2ca7182"}, "infracost.json")
+	require.NoError(t, err)
+	assert.Equal(t,
+		[]string{"comment", "github", "--path", "infracost.json", "--behavior", "update", "--repo", "my-org/my-repo", "--github-token", "secret", "--check-run", "--pull-request", "3"},
+		args)
+
+	s.Status = false
+	args, err = s.CommentArgs(Build{Repo: "my-org/my-repo", Commit: "2ca7182", Forge: "gitlab"}, "infracost.json")
+	require.NoError(t, err)
+	assert.Equal(t,
+		[]string{"comment", "gitlab", "--path", "infracost.json", "--behavior", "update", "--repo", "my-org/my-repo", "--gitlab-token", "secret", "--commit", "2ca7182"},
+		args)
+
+	_, err = s.CommentArgs(Build{RepoURL: "https://dev.azure.com/my-org/my-project/_git/my-repo", Commit: "2ca7182"}, "infracost.json")
+	assert.EqualError(t, err, "Comments can only be posted to pull requests on azure-repos")
+
+	_, err = s.CommentArgs(Build{RepoURL: "https://git.example.com/my-org/my-repo", PullRequest: "3"}, "infracost.json")
+	assert.EqualError(t, err, "Could not detect the platform of https://git.example.com/my-org/my-repo, set the platform setting to one of github, gitlab, bitbucket, azure-repos")
+}
@@ -0,0 +1,97 @@
+// Package policy evaluates Rego policies against Infracost JSON output.
+package policy
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/open-policy-agent/opa/ast"
+	"github.com/open-policy-agent/opa/rego"
+
+	"github.com/infracost/infracost/internal/output"
+)
+
+// Query is the rule the policies define the results of their checks in.
+const Query = "data.infracost.deny"
+
+// Evaluate evaluates the policies in the given files and directories against the
+// output. Each result of the data.infracost.deny rule is either an object with a
+// msg and whether the check failed, e.g. {"msg": "Cost is under $1000", "failed":
+// false}, so that passed checks are shown too, or a message of a failed check.
+func Evaluate(policyPaths []string, input output.Root) (output.PolicyCheck, error) {
+	checks := output.PolicyCheck{
+		Enabled: true,
+	}
+
+	inputValue, err := ast.InterfaceToValue(input)
+	if err != nil {
+		return checks, fmt.Errorf("Unable to process Infracost output into Rego input: %s", err.Error())
+	}
+
+	ctx := context.Background()
+	r := rego.New(
+		rego.Query(Query),
+		rego.ParsedInput(inputValue),
+		rego.Load(policyPaths, func(abspath string, info os.FileInfo, depth int) bool {
+			return false
+		}),
+	)
+	pq, err := r.PrepareForEval(ctx)
+	if err != nil {
+		return checks, fmt.Errorf("Unable to query provided policies: %s", err.Error())
+	}
+
+	res, err := pq.Eval(ctx)
+	if err != nil {
+		return checks, err
+	}
+
+	if len(res) == 0 {
+		return checks, fmt.Errorf("The provided polices returned no valid data.infracost.deny rules. Please check that the policies are formatted correctly.")
+	}
+
+	for _, e := range res[0].Expressions {
+		switch v := e.Value.(type) {
+		case map[string]interface{}:
+			readPolicyOut(v, &checks)
+		case []interface{}:
+			for _, ii := range v {
+				switch m := ii.(type) {
+				case map[string]interface{}:
+					readPolicyOut(m, &checks)
+				case string:
+					checks.Failures = append(checks.Failures, m)
+				}
+			}
+		}
+	}
+
+	sort.Strings(checks.Failures)
+	sort.Strings(checks.Passed)
+
+	return checks, nil
+}
+
+func readPolicyOut(v map[string]interface{}, checks *output.PolicyCheck) {
+	if _, ok := v["msg"]; !ok {
+		checks.Failures = append(checks.Failures, "Policy rule invalid as it did not contain {msg: string} property in output object. Please edit rule output object.")
+		return
+	}
+	msg := v["msg"].(string)
+
+	if _, ok := v["failed"]; !ok {
+		checks.Failures = append(checks.Failures, fmt.Sprintf("Policy rule: [%s] did not contain {failed: bool} output property. Please edit rule output object.", msg))
+		return
+	}
+
+	failed, _ := v["failed"].(bool)
+
+	if failed {
+		checks.Failures = append(checks.Failures, msg)
+		return
+	}
+
+	checks.Passed = append(checks.Passed, msg)
+}
@@ -0,0 +1,96 @@
+package policy
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/infracost/infracost/internal/output"
+)
+
+func TestEvaluate(t *testing.T) {
+	dir := t.TempDir()
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "cost.rego"), []byte(`package infracost
+
+deny[out] {
+	out := {
+		"msg": "Total monthly cost must be less than $100",
+		"failed": to_number(input.totalMonthlyCost) >= 100,
+	}
+}
+
+deny[out] {
+	out := {
+		"msg": "Total monthly cost must be less than $1000",
+		"failed": to_number(input.totalMonthlyCost) >= 1000,
+	}
+}
+`), 0600))
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "instances.rego"), []byte(`package infracost
+
+deny[msg] {
+	r := input.projects[_].breakdown.resources[_]
+	c := r.costComponents[_]
+	contains(c.name, "m5.24xlarge")
+	msg := sprintf("%s must not use m5.24xlarge", [r.name])
+}
+`), 0600))
+
+	cost := decimal.NewFromInt(500)
+	root := output.Root{
+		TotalMonthlyCost: &cost,
+		Projects: []output.Project{
+			{
+				Name: "infracost/infracost/examples",
+				Breakdown: &output.Breakdown{
+					Resources: []output.Resource{
+						{
+							Name: "aws_instance.web",
+							CostComponents: []output.CostComponent{
+								{Name: "Instance usage (Linux/UNIX, on-demand, m5.24xlarge)"},
+							},
+						},
+						{
+							Name: "aws_instance.db",
+							CostComponents: []output.CostComponent{
+								{Name: "Instance usage (Linux/UNIX, on-demand, t3.micro)"},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	checks, err := Evaluate([]string{dir}, root)
+	require.NoError(t, err)
+
+	assert.True(t, checks.Enabled)
+	assert.ElementsMatch(t, []string{
+		"Total monthly cost must be less than $100",
+		"aws_instance.web must not use m5.24xlarge",
+	}, checks.Failures)
+	assert.Equal(t, []string{"Total monthly cost must be less than $1000"}, checks.Passed)
+}
+
+func TestEvaluateInvalidRule(t *testing.T) {
+	dir := t.TempDir()
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "invalid.rego"), []byte(`package infracost
+
+deny[out] {
+	out := {"msg": "Missing failed"}
+}
+`), 0600))
+
+	checks, err := Evaluate([]string{dir}, output.Root{})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"Policy rule: [Missing failed] did not contain {failed: bool} output property. Please edit rule output object."}, []string(checks.Failures))
+}
@@ -0,0 +1,463 @@
+// Package policypack fetches policy packs, versioned bundles of Rego policies,
+// guardrails, tag policies, budgets and a price book, from an HTTPS URL of a
+// .tar.gz archive or from an OCI registry, so platform teams can distribute the
+// same governance rules to many repos.
+package policypack
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/infracost/infracost/internal/version"
+)
+
+// ManifestFile is the file at the root of a pack that lists its contents.
+const ManifestFile = "infracost-pack.yml"
+
+// LayerMediaType is the media type of the layer of an OCI artifact that holds
+// the pack. Artifacts with a single layer can use any media type.
+const LayerMediaType = "application/vnd.infracost.policy-pack.layer.v1.tar+gzip"
+
+const ociScheme = "oci://"
+
+// maxArchiveSize limits the size of a pack, packs are small text files.
+const maxArchiveSize = 50 << 20
+
+var checksumRegex = regexp.MustCompile(`^sha256:[a-f0-9]{64}$`)
+
+var challengeParamRegex = regexp.MustCompile(`(\w+)="([^"]*)"`)
+
+var manifestMediaTypes = []string{
+	"application/vnd.oci.image.manifest.v1+json",
+	"application/vnd.docker.distribution.manifest.v2+json",
+}
+
+// DefaultCacheDir returns the directory packs are extracted to by default.
+func DefaultCacheDir() string {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return ""
+	}
+
+	return filepath.Join(dir, "infracost", "policy-packs")
+}
+
+// ValidChecksum returns true if the checksum is a sha256:<hex> digest.
+func ValidChecksum(checksum string) bool {
+	return checksumRegex.MatchString(checksum)
+}
+
+// IsOCI returns true if the source is an OCI reference, e.g.
+// oci://ghcr.io/acme/infracost-policies:v1.2.0.
+func IsOCI(source string) bool {
+	return strings.HasPrefix(source, ociScheme)
+}
+
+// Fetcher downloads and extracts packs.
+type Fetcher struct {
+	// CacheDir is where packs are extracted to, packs pinned with a checksum
+	// are only downloaded once.
+	CacheDir string
+	// Token is sent as a bearer token to the HTTPS URL or OCI registry of the
+	// packs.
+	Token string
+
+	httpClient *http.Client
+}
+
+// NewFetcher returns a Fetcher that extracts packs to cacheDir.
+func NewFetcher(cacheDir string, token string) *Fetcher {
+	return &Fetcher{
+		CacheDir:   cacheDir,
+		Token:      token,
+		httpClient: &http.Client{Timeout: 60 * time.Second},
+	}
+}
+
+// Fetch downloads the pack at source, verifies the sha256 of its archive
+// against checksum if it's set, and returns the directory it's extracted to.
+func (f *Fetcher) Fetch(ctx context.Context, source string, checksum string) (string, error) {
+	if checksum != "" && !ValidChecksum(checksum) {
+		return "", fmt.Errorf("Invalid checksum %s, it must be sha256:<hex digest>", checksum)
+	}
+
+	if checksum != "" && f.CacheDir != "" {
+		dir := filepath.Join(f.CacheDir, strings.TrimPrefix(checksum, "sha256:"))
+		if _, err := os.Stat(filepath.Join(dir, ManifestFile)); err == nil {
+			log.Debugf("Using cached policy pack %s from %s", source, dir)
+			return dir, nil
+		}
+	}
+
+	var archive []byte
+	var err error
+
+	switch {
+	case IsOCI(source):
+		archive, err = f.fetchOCI(ctx, strings.TrimPrefix(source, ociScheme))
+	case strings.HasPrefix(source, "https://"):
+		archive, err = f.fetchHTTPS(ctx, source)
+	default:
+		return "", fmt.Errorf("Policy pack source %s must be an https:// URL or an oci:// reference", source)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	sum := sha256.Sum256(archive)
+	digest := "sha256:" + hex.EncodeToString(sum[:])
+
+	if checksum != "" && digest != checksum {
+		return "", fmt.Errorf("Checksum mismatch for policy pack %s, expected %s but got %s", source, checksum, digest)
+	}
+	if checksum == "" {
+		log.Infof("Policy pack %s has checksum %s, set it as the checksum of the pack to pin it", source, digest)
+	}
+
+	return f.extract(archive, strings.TrimPrefix(digest, "sha256:"))
+}
+
+func (f *Fetcher) fetchHTTPS(ctx context.Context, source string) ([]byte, error) {
+	resp, err := f.get(ctx, source, nil, f.Token)
+	if err != nil {
+		return nil, fmt.Errorf("Error downloading policy pack %s: %w", source, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("Error downloading policy pack %s: %s", source, resp.Status)
+	}
+
+	return readLimited(resp.Body)
+}
+
+// fetchOCI downloads the layer of the pack from the registry with the
+// distribution API, ref is the reference without its oci:// scheme, e.g.
+// ghcr.io/acme/policies:v1, ghcr.io/acme/policies@sha256:... or
+// ghcr.io/acme/policies for the latest tag.
+func (f *Fetcher) fetchOCI(ctx context.Context, ref string) ([]byte, error) {
+	registry, repo, tag, err := parseOCIReference(ref)
+	if err != nil {
+		return nil, err
+	}
+
+	scheme := "https"
+	if strings.HasPrefix(registry, "localhost") || strings.HasPrefix(registry, "127.0.0.1") {
+		scheme = "http"
+	}
+	baseURL := fmt.Sprintf("%s://%s/v2/%s", scheme, registry, repo)
+
+	r := &registryClient{fetcher: f, token: f.Token}
+
+	resp, err := r.get(ctx, fmt.Sprintf("%s/manifests/%s", baseURL, tag), map[string]string{"Accept": strings.Join(manifestMediaTypes, ", ")})
+	if err != nil {
+		return nil, fmt.Errorf("Error fetching manifest of policy pack %s: %w", ref, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("Error fetching manifest of policy pack %s: %s", ref, resp.Status)
+	}
+
+	var manifest struct {
+		Layers []struct {
+			MediaType string `json:"mediaType"`
+			Digest    string `json:"digest"`
+		} `json:"layers"`
+	}
+	err = json.NewDecoder(resp.Body).Decode(&manifest)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid manifest of policy pack %s: %w", ref, err)
+	}
+
+	var layerDigest string
+	for _, l := range manifest.Layers {
+		if l.MediaType == LayerMediaType {
+			layerDigest = l.Digest
+			break
+		}
+	}
+	if layerDigest == "" && len(manifest.Layers) == 1 {
+		layerDigest = manifest.Layers[0].Digest
+	}
+	if layerDigest == "" {
+		return nil, fmt.Errorf("Policy pack %s must have a single layer or a layer of type %s", ref, LayerMediaType)
+	}
+
+	blob, err := r.get(ctx, fmt.Sprintf("%s/blobs/%s", baseURL, layerDigest), nil)
+	if err != nil {
+		return nil, fmt.Errorf("Error downloading policy pack %s: %w", ref, err)
+	}
+	defer blob.Body.Close()
+
+	if blob.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("Error downloading policy pack %s: %s", ref, blob.Status)
+	}
+
+	b, err := readLimited(blob.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	sum := sha256.Sum256(b)
+	if "sha256:"+hex.EncodeToString(sum[:]) != layerDigest {
+		return nil, fmt.Errorf("Layer of policy pack %s doesn't match its digest %s", ref, layerDigest)
+	}
+
+	return b, nil
+}
+
+// registryClient sends requests to an OCI registry, exchanging the
+// WWW-Authenticate challenge of the registry for a bearer token when it
+// requires one, e.g. for anonymous pulls of public packs.
+type registryClient struct {
+	fetcher *Fetcher
+	token   string
+}
+
+func (r *registryClient) get(ctx context.Context, u string, headers map[string]string) (*http.Response, error) {
+	resp, err := r.fetcher.get(ctx, u, headers, r.token)
+	if err != nil || resp.StatusCode != http.StatusUnauthorized {
+		return resp, err
+	}
+
+	challenge := resp.Header.Get("WWW-Authenticate")
+	resp.Body.Close()
+
+	token, err := r.fetcher.exchangeToken(ctx, challenge)
+	if err != nil {
+		return nil, err
+	}
+	r.token = token
+
+	return r.fetcher.get(ctx, u, headers, r.token)
+}
+
+// exchangeToken requests a bearer token from the realm of a Bearer challenge,
+// e.g. Bearer realm="https://ghcr.io/token",service="ghcr.io",scope="repository:acme/policies:pull".
+func (f *Fetcher) exchangeToken(ctx context.Context, challenge string) (string, error) {
+	if !strings.HasPrefix(strings.ToLower(challenge), "bearer ") {
+		return "", fmt.Errorf("Registry requires unsupported authentication %q", challenge)
+	}
+
+	params := make(map[string]string)
+	for _, m := range challengeParamRegex.FindAllStringSubmatch(challenge, -1) {
+		params[strings.ToLower(m[1])] = m[2]
+	}
+
+	realm, err := url.Parse(params["realm"])
+	if err != nil || params["realm"] == "" {
+		return "", fmt.Errorf("Registry returned an invalid authentication challenge %q", challenge)
+	}
+
+	q := realm.Query()
+	for _, k := range []string{"service", "scope"} {
+		if v := params[k]; v != "" {
+			q.Set(k, v)
+		}
+	}
+	realm.RawQuery = q.Encode()
+
+	resp, err := f.get(ctx, realm.String(), nil, f.Token)
+	if err != nil {
+		return "", fmt.Errorf("Error requesting registry token: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("Error requesting registry token: %s", resp.Status)
+	}
+
+	var body struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}
+	err = json.NewDecoder(resp.Body).Decode(&body)
+	if err != nil {
+		return "", fmt.Errorf("Invalid registry token response: %w", err)
+	}
+
+	if body.Token != "" {
+		return body.Token, nil
+	}
+
+	return body.AccessToken, nil
+}
+
+func (f *Fetcher) get(ctx context.Context, u string, headers map[string]string, token string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("User-Agent", fmt.Sprintf("infracost-%s", version.Version))
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	return f.httpClient.Do(req)
+}
+
+// extract unpacks the gzipped tarball of the pack to a directory of the cache
+// dir named after its digest.
+func (f *Fetcher) extract(archive []byte, digest string) (string, error) {
+	var dir string
+	var err error
+
+	if f.CacheDir != "" {
+		err = os.MkdirAll(f.CacheDir, 0700)
+		if err != nil {
+			return "", fmt.Errorf("Error creating policy pack cache directory: %w", err)
+		}
+
+		dir = filepath.Join(f.CacheDir, digest)
+		if _, err := os.Stat(filepath.Join(dir, ManifestFile)); err == nil {
+			return dir, nil
+		}
+	}
+
+	tmp, err := os.MkdirTemp(f.CacheDir, ".policy-pack-")
+	if err != nil {
+		return "", fmt.Errorf("Error creating policy pack directory: %w", err)
+	}
+
+	err = untar(archive, tmp)
+	if err == nil {
+		_, err = os.Stat(filepath.Join(tmp, ManifestFile))
+		if err != nil {
+			err = fmt.Errorf("Policy pack has no %s", ManifestFile)
+		}
+	}
+	if err != nil {
+		os.RemoveAll(tmp)
+		return "", err
+	}
+
+	if dir == "" {
+		return tmp, nil
+	}
+
+	err = os.RemoveAll(dir)
+	if err == nil {
+		err = os.Rename(tmp, dir)
+	}
+	if err != nil {
+		os.RemoveAll(tmp)
+		return "", fmt.Errorf("Error saving policy pack: %w", err)
+	}
+
+	return dir, nil
+}
+
+func untar(archive []byte, dir string) error {
+	gz, err := gzip.NewReader(bytes.NewReader(archive))
+	if err != nil {
+		return fmt.Errorf("Policy pack is not a .tar.gz archive: %w", err)
+	}
+	defer gz.Close()
+
+	tr := tar.NewReader(gz)
+	for {
+		h, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("Error reading policy pack archive: %w", err)
+		}
+
+		name := filepath.Clean(filepath.FromSlash(h.Name))
+		if filepath.IsAbs(name) || name == ".." || strings.HasPrefix(name, ".."+string(filepath.Separator)) {
+			return fmt.Errorf("Policy pack archive has a file outside of the pack: %s", h.Name)
+		}
+		path := filepath.Join(dir, name)
+
+		switch h.Typeflag {
+		case tar.TypeDir:
+			err = os.MkdirAll(path, 0700)
+		case tar.TypeReg:
+			err = writeFile(path, tr)
+		default:
+			log.Debugf("Skipping %s of policy pack archive, only files and directories are extracted", h.Name)
+		}
+		if err != nil {
+			return fmt.Errorf("Error extracting policy pack: %w", err)
+		}
+	}
+}
+
+func writeFile(path string, r io.Reader) error {
+	err := os.MkdirAll(filepath.Dir(path), 0700)
+	if err != nil {
+		return err
+	}
+
+	out, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600)
+	if err != nil {
+		return err
+	}
+
+	_, err = io.Copy(out, io.LimitReader(r, maxArchiveSize))
+	if err != nil {
+		out.Close()
+		return err
+	}
+
+	return out.Close()
+}
+
+func readLimited(r io.Reader) ([]byte, error) {
+	b, err := io.ReadAll(io.LimitReader(r, maxArchiveSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("Error reading policy pack: %w", err)
+	}
+
+	if len(b) > maxArchiveSize {
+		return nil, fmt.Errorf("Policy pack is larger than %d MB", maxArchiveSize>>20)
+	}
+
+	return b, nil
+}
+
+// parseOCIReference splits a reference into its registry, repository and tag
+// or digest.
+func parseOCIReference(ref string) (string, string, string, error) {
+	i := strings.Index(ref, "/")
+	if i <= 0 || i == len(ref)-1 {
+		return "", "", "", fmt.Errorf("Invalid OCI reference %s, it must be oci://<registry>/<repository>[:<tag>|@<digest>]", ref)
+	}
+
+	registry, repo := ref[:i], ref[i+1:]
+	tag := "latest"
+
+	if j := strings.Index(repo, "@"); j >= 0 {
+		repo, tag = repo[:j], repo[j+1:]
+	} else if j := strings.LastIndex(repo, ":"); j > strings.LastIndex(repo, "/") {
+		repo, tag = repo[:j], repo[j+1:]
+	}
+
+	if repo == "" || tag == "" {
+		return "", "", "", fmt.Errorf("Invalid OCI reference %s, it must be oci://<registry>/<repository>[:<tag>|@<digest>]", ref)
+	}
+
+	return registry, repo, tag, nil
+}
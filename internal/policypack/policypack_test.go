@@ -0,0 +1,176 @@
+package policypack
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func archive(t *testing.T, files map[string]string) []byte {
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+
+	for name, content := range files {
+		require.NoError(t, tw.WriteHeader(&tar.Header{Name: name, Mode: 0600, Size: int64(len(content)), Typeflag: tar.TypeReg}))
+		_, err := tw.Write([]byte(content))
+		require.NoError(t, err)
+	}
+
+	require.NoError(t, tw.Close())
+	require.NoError(t, gz.Close())
+
+	return buf.Bytes()
+}
+
+func digest(b []byte) string {
+	sum := sha256.Sum256(b)
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
+
+var packFiles = map[string]string{
+	ManifestFile:         "version: 0.1\nname: acme\npolicies: [policies]\n",
+	"policies/cost.rego": "package infracost\n",
+}
+
+func TestFetchHTTPS(t *testing.T) {
+	b := archive(t, packFiles)
+
+	var requests int
+	ts := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		assert.Equal(t, "Bearer secret", r.Header.Get("Authorization"))
+		_, _ = w.Write(b)
+	}))
+	defer ts.Close()
+
+	f := NewFetcher(t.TempDir(), "secret")
+	f.httpClient = ts.Client()
+
+	dir, err := f.Fetch(context.Background(), ts.URL+"/pack.tar.gz", digest(b))
+	require.NoError(t, err)
+
+	content, err := os.ReadFile(filepath.Join(dir, "policies", "cost.rego"))
+	require.NoError(t, err)
+	assert.Equal(t, "package infracost\n", string(content))
+
+	// Packs pinned with a checksum are read from the cache
+	_, err = f.Fetch(context.Background(), ts.URL+"/pack.tar.gz", digest(b))
+	require.NoError(t, err)
+	assert.Equal(t, 1, requests)
+}
+
+func TestFetchChecksumMismatch(t *testing.T) {
+	b := archive(t, packFiles)
+
+	ts := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write(b)
+	}))
+	defer ts.Close()
+
+	f := NewFetcher(t.TempDir(), "")
+	f.httpClient = ts.Client()
+
+	_, err := f.Fetch(context.Background(), ts.URL+"/pack.tar.gz", "sha256:"+strings.Repeat("0", 64))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "Checksum mismatch")
+}
+
+func TestFetchRejectsPathsOutsideOfThePack(t *testing.T) {
+	b := archive(t, map[string]string{
+		ManifestFile:  "version: 0.1\n",
+		"../evil.txt": "evil",
+	})
+
+	ts := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write(b)
+	}))
+	defer ts.Close()
+
+	f := NewFetcher(t.TempDir(), "")
+	f.httpClient = ts.Client()
+
+	_, err := f.Fetch(context.Background(), ts.URL+"/pack.tar.gz", "")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "outside of the pack")
+}
+
+func TestFetchOCI(t *testing.T) {
+	b := archive(t, packFiles)
+	layerDigest := digest(b)
+
+	mux := http.NewServeMux()
+	var ts *httptest.Server
+
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "repository:acme/policies:pull", r.URL.Query().Get("scope"))
+		_ = json.NewEncoder(w).Encode(map[string]string{"token": "anonymous"})
+	})
+	mux.HandleFunc("/v2/acme/policies/", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer anonymous" {
+			w.Header().Set("WWW-Authenticate", fmt.Sprintf(`Bearer realm="%s/token",service="registry",scope="repository:acme/policies:pull"`, ts.URL))
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		switch r.URL.Path {
+		case "/v2/acme/policies/manifests/v1":
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"schemaVersion": 2,
+				"layers": []map[string]string{
+					{"mediaType": LayerMediaType, "digest": layerDigest},
+				},
+			})
+		case "/v2/acme/policies/blobs/" + layerDigest:
+			_, _ = w.Write(b)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+
+	ts = httptest.NewServer(mux)
+	defer ts.Close()
+
+	f := NewFetcher(t.TempDir(), "")
+
+	dir, err := f.Fetch(context.Background(), "oci://"+strings.TrimPrefix(ts.URL, "http://")+"/acme/policies:v1", "")
+	require.NoError(t, err)
+	assert.FileExists(t, filepath.Join(dir, ManifestFile))
+	assert.Equal(t, strings.TrimPrefix(layerDigest, "sha256:"), filepath.Base(dir))
+}
+
+func TestParseOCIReference(t *testing.T) {
+	tests := []struct {
+		ref      string
+		registry string
+		repo     string
+		tag      string
+	}{
+		{"ghcr.io/acme/policies:v1.2.0", "ghcr.io", "acme/policies", "v1.2.0"},
+		{"ghcr.io/acme/policies", "ghcr.io", "acme/policies", "latest"},
+		{"localhost:5000/policies@sha256:abc", "localhost:5000", "policies", "sha256:abc"},
+	}
+
+	for _, tt := range tests {
+		registry, repo, tag, err := parseOCIReference(tt.ref)
+		require.NoError(t, err, tt.ref)
+		assert.Equal(t, []string{tt.registry, tt.repo, tt.tag}, []string{registry, repo, tag}, tt.ref)
+	}
+
+	_, _, _, err := parseOCIReference("ghcr.io")
+	assert.Error(t, err)
+}
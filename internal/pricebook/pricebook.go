@@ -0,0 +1,230 @@
+// Package pricebook loads user supplied price overrides, e.g. negotiated rates,
+// and applies them to cost components after their prices have been looked up.
+package pricebook
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+	log "github.com/sirupsen/logrus"
+	"gopkg.in/yaml.v2"
+
+	"github.com/infracost/infracost/internal/schema"
+)
+
+const wildcard = "*"
+
+var csvHeader = []string{"resource_type", "region", "cost_component", "price"}
+
+// Entry overrides the price of the cost components matching its resource type,
+// region and cost component name. Any of them can contain * wildcards, an empty
+// region matches all regions.
+type Entry struct {
+	ResourceType  string
+	Region        string
+	CostComponent string
+	Price         decimal.Decimal
+
+	resourceTypeRegex  *regexp.Regexp
+	regionRegex        *regexp.Regexp
+	costComponentRegex *regexp.Regexp
+}
+
+// PriceBook is a list of price overrides. Prices are in the currency the run is
+// configured with. Entries are matched in order and the first matching entry wins.
+type PriceBook struct {
+	Prices []*Entry
+}
+
+type yamlFile struct {
+	Version string `yaml:"version"`
+	Prices  []struct {
+		ResourceType  string `yaml:"resource_type"`
+		Region        string `yaml:"region"`
+		CostComponent string `yaml:"cost_component"`
+		Price         string `yaml:"price"`
+	} `yaml:"prices"`
+}
+
+// Load reads a price book from a YAML or CSV file, the format is detected from
+// the file extension.
+func Load(path string) (*PriceBook, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error opening price book")
+	}
+	defer f.Close()
+
+	var b *PriceBook
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".csv":
+		b, err = parseCSV(f)
+	case ".yml", ".yaml":
+		b, err = parseYAML(f)
+	default:
+		return nil, fmt.Errorf("Price book %s must be a .csv, .yml or .yaml file", path)
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "Error parsing price book %s", path)
+	}
+
+	for i, e := range b.Prices {
+		err := e.compile()
+		if err != nil {
+			return nil, errors.Wrapf(err, "Error parsing price book %s entry %d", path, i+1)
+		}
+	}
+
+	return b, nil
+}
+
+func parseYAML(r io.Reader) (*PriceBook, error) {
+	var f yamlFile
+
+	err := yaml.NewDecoder(r).Decode(&f)
+	if err != nil && err != io.EOF {
+		return nil, err
+	}
+
+	b := &PriceBook{}
+
+	for i, p := range f.Prices {
+		price, err := decimal.NewFromString(strings.TrimSpace(p.Price))
+		if err != nil {
+			return nil, fmt.Errorf("invalid price for entry %d: %w", i+1, err)
+		}
+
+		b.Prices = append(b.Prices, &Entry{
+			ResourceType:  p.ResourceType,
+			Region:        p.Region,
+			CostComponent: p.CostComponent,
+			Price:         price,
+		})
+	}
+
+	return b, nil
+}
+
+func parseCSV(r io.Reader) (*PriceBook, error) {
+	records, err := csv.NewReader(r).ReadAll()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(records) == 0 {
+		return &PriceBook{}, nil
+	}
+
+	cols := make(map[string]int, len(records[0]))
+	for i, h := range records[0] {
+		cols[strings.TrimSpace(strings.ToLower(h))] = i
+	}
+
+	for _, h := range csvHeader {
+		if _, ok := cols[h]; !ok {
+			return nil, fmt.Errorf("missing %s column, the header must contain %s", h, strings.Join(csvHeader, ","))
+		}
+	}
+
+	b := &PriceBook{}
+
+	for i, rec := range records[1:] {
+		price, err := decimal.NewFromString(strings.TrimSpace(rec[cols["price"]]))
+		if err != nil {
+			return nil, fmt.Errorf("invalid price on line %d: %w", i+2, err)
+		}
+
+		b.Prices = append(b.Prices, &Entry{
+			ResourceType:  strings.TrimSpace(rec[cols["resource_type"]]),
+			Region:        strings.TrimSpace(rec[cols["region"]]),
+			CostComponent: strings.TrimSpace(rec[cols["cost_component"]]),
+			Price:         price,
+		})
+	}
+
+	return b, nil
+}
+
+func (e *Entry) compile() error {
+	if e.ResourceType == "" {
+		return errors.New("resource_type is required")
+	}
+
+	if e.CostComponent == "" {
+		return errors.New("cost_component is required")
+	}
+
+	region := e.Region
+	if region == "" {
+		region = wildcard
+	}
+
+	e.resourceTypeRegex = wildcardRegex(e.ResourceType)
+	e.regionRegex = wildcardRegex(region)
+	e.costComponentRegex = wildcardRegex(e.CostComponent)
+
+	return nil
+}
+
+func (e *Entry) matches(resourceType, region, costComponent string) bool {
+	return e.resourceTypeRegex.MatchString(resourceType) &&
+		e.regionRegex.MatchString(region) &&
+		e.costComponentRegex.MatchString(costComponent)
+}
+
+// wildcardRegex returns a case-insensitive regex matching the whole of s where
+// each * matches any characters.
+func wildcardRegex(s string) *regexp.Regexp {
+	parts := strings.Split(s, wildcard)
+	for i, p := range parts {
+		parts[i] = regexp.QuoteMeta(p)
+	}
+
+	return regexp.MustCompile("(?i)^" + strings.Join(parts, ".*") + "$")
+}
+
+// Apply overrides the prices of the cost components of r and its sub resources
+// that match an entry. Sub resources are matched using the resource type of r.
+func (b *PriceBook) Apply(r *schema.Resource) {
+	if b == nil || len(b.Prices) == 0 {
+		return
+	}
+
+	resources := append([]*schema.Resource{r}, r.FlattenedSubResources()...)
+
+	for _, res := range resources {
+		for _, c := range res.CostComponents {
+			e := b.match(r.ResourceType, c)
+			if e == nil {
+				continue
+			}
+
+			log.Debugf("Using price book price %s for %s %s", e.Price, res.Name, c.Name)
+			c.SetPrice(e.Price)
+			c.PriceIssue = ""
+		}
+	}
+}
+
+func (b *PriceBook) match(resourceType string, c *schema.CostComponent) *Entry {
+	region := ""
+	if c.ProductFilter != nil && c.ProductFilter.Region != nil {
+		region = *c.ProductFilter.Region
+	}
+
+	for _, e := range b.Prices {
+		if e.matches(resourceType, region, c.Name) {
+			return e
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,138 @@
+package pricebook
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/infracost/infracost/internal/schema"
+)
+
+func writeFile(t *testing.T, name, content string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0600))
+
+	return path
+}
+
+func costComponent(name, region string) *schema.CostComponent {
+	c := &schema.CostComponent{
+		Name:          name,
+		ProductFilter: &schema.ProductFilter{Region: &region},
+	}
+	c.SetPrice(decimal.NewFromFloat(1))
+
+	return c
+}
+
+func TestLoadYAML(t *testing.T) {
+	path := writeFile(t, "prices.yml", `version: 0.1
+prices:
+  - resource_type: aws_instance
+    region: us-east-1
+    cost_component: Instance usage (Linux/UNIX, on-demand, m5.large)
+    price: 0.085
+  - resource_type: aws_*
+    cost_component: "*"
+    price: "0"
+`)
+
+	b, err := Load(path)
+	require.NoError(t, err)
+	require.Len(t, b.Prices, 2)
+
+	assert.Equal(t, "aws_instance", b.Prices[0].ResourceType)
+	assert.Equal(t, "us-east-1", b.Prices[0].Region)
+	assert.True(t, decimal.RequireFromString("0.085").Equal(b.Prices[0].Price))
+	assert.Equal(t, "", b.Prices[1].Region)
+}
+
+func TestLoadCSV(t *testing.T) {
+	path := writeFile(t, "prices.csv", `resource_type,region,cost_component,price
+aws_instance,us-east-1,"Instance usage (Linux/UNIX, on-demand, m5.large)",0.085
+google_compute_instance,,Instance usage*,0.05
+`)
+
+	b, err := Load(path)
+	require.NoError(t, err)
+	require.Len(t, b.Prices, 2)
+
+	assert.Equal(t, "Instance usage (Linux/UNIX, on-demand, m5.large)", b.Prices[0].CostComponent)
+	assert.Equal(t, "", b.Prices[1].Region)
+}
+
+func TestLoadErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		file    string
+		content string
+	}{
+		{"unknown extension", "prices.txt", ""},
+		{"missing csv column", "prices.csv", "resource_type,cost_component,price\naws_instance,Storage,1\n"},
+		{"invalid csv price", "prices.csv", "resource_type,region,cost_component,price\naws_instance,,Storage,abc\n"},
+		{"invalid yaml price", "prices.yml", "prices:\n  - resource_type: aws_instance\n    cost_component: Storage\n    price: abc\n"},
+		{"missing resource type", "prices.yml", "prices:\n  - cost_component: Storage\n    price: 1\n"},
+		{"missing cost component", "prices.yml", "prices:\n  - resource_type: aws_instance\n    price: 1\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Load(writeFile(t, tt.file, tt.content))
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestApply(t *testing.T) {
+	path := writeFile(t, "prices.yml", `prices:
+  - resource_type: aws_instance
+    region: us-east-1
+    cost_component: Instance usage (*)
+    price: 0.5
+  - resource_type: aws_instance
+    cost_component: Instance usage (*)
+    price: 0.75
+  - resource_type: aws_*
+    cost_component: storage (gp3)
+    price: 0.25
+`)
+
+	b, err := Load(path)
+	require.NoError(t, err)
+
+	instanceUsEast := costComponent("Instance usage (Linux/UNIX, on-demand, m5.large)", "us-east-1")
+	instanceEuWest := costComponent("Instance usage (Linux/UNIX, on-demand, m5.large)", "eu-west-1")
+	storage := costComponent("Storage (gp3)", "us-east-1")
+	other := costComponent("CPU credits", "us-east-1")
+
+	r := &schema.Resource{
+		Name:           "aws_instance.web",
+		ResourceType:   "aws_instance",
+		CostComponents: []*schema.CostComponent{instanceUsEast, instanceEuWest, other},
+		SubResources: []*schema.Resource{
+			{Name: "root_block_device", CostComponents: []*schema.CostComponent{storage}},
+		},
+	}
+
+	b.Apply(r)
+
+	assert.Equal(t, "0.5", instanceUsEast.Price().String())
+	assert.Equal(t, "0.75", instanceEuWest.Price().String())
+	assert.Equal(t, "0.25", storage.Price().String())
+	assert.Equal(t, "1", other.Price().String())
+}
+
+func TestApplyNilPriceBook(t *testing.T) {
+	var b *PriceBook
+
+	c := costComponent("Storage", "us-east-1")
+	b.Apply(&schema.Resource{ResourceType: "aws_ebs_volume", CostComponents: []*schema.CostComponent{c}})
+
+	assert.Equal(t, "1", c.Price().String())
+}
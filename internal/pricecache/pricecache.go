@@ -0,0 +1,125 @@
+// Package pricecache caches the results of Cloud Pricing API queries on disk so
+// repeated runs, e.g. CI runs of the projects of a monorepo, don't query the API
+// again for prices they've already looked up.
+package pricecache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/mitchellh/go-homedir"
+	log "github.com/sirupsen/logrus"
+	"github.com/tidwall/gjson"
+)
+
+// DefaultTTL is how long cached results are used before they're queried again.
+const DefaultTTL = 24 * time.Hour
+
+// Cache stores query results as files named by the hash of the query. Files are
+// written atomically so the cache can be shared by concurrent runs.
+type Cache struct {
+	Dir string
+	TTL time.Duration
+}
+
+// New returns a cache that stores results in dir for ttl.
+func New(dir string, ttl time.Duration) *Cache {
+	return &Cache{
+		Dir: dir,
+		TTL: ttl,
+	}
+}
+
+// DefaultDir returns the directory results are cached in by default.
+func DefaultDir() string {
+	dir, _ := homedir.Expand("~/.infracost/cache/prices")
+	return dir
+}
+
+// Key returns the key the query sent to the endpoint is cached under. The endpoint
+// is part of the key so results from different pricing APIs aren't mixed up.
+func Key(endpoint string, query interface{}) (string, error) {
+	b, err := json.Marshal(query)
+	if err != nil {
+		return "", fmt.Errorf("Error generating price cache key: %w", err)
+	}
+
+	h := sha256.New()
+	h.Write([]byte(endpoint))
+	h.Write([]byte{0})
+	h.Write(b)
+
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// Get returns the result cached for the key and whether it was found. Results
+// older than the TTL aren't returned.
+func (c *Cache) Get(key string) (gjson.Result, bool) {
+	path := c.path(key)
+
+	info, err := os.Stat(path)
+	if err != nil || time.Since(info.ModTime()) > c.TTL {
+		return gjson.Result{}, false
+	}
+
+	b, err := os.ReadFile(path)
+	if err != nil {
+		log.Debugf("Error reading price cache %s: %s", path, err)
+		return gjson.Result{}, false
+	}
+
+	if !gjson.ValidBytes(b) {
+		log.Debugf("Invalid price cache %s", path)
+		return gjson.Result{}, false
+	}
+
+	return gjson.ParseBytes(b), true
+}
+
+// Set caches the result for the key, replacing any existing result.
+func (c *Cache) Set(key string, result gjson.Result) {
+	if result.Raw == "" {
+		return
+	}
+
+	path := c.path(key)
+
+	err := os.MkdirAll(filepath.Dir(path), 0700)
+	if err != nil {
+		log.Debugf("Error creating price cache directory: %s", err)
+		return
+	}
+
+	f, err := os.CreateTemp(filepath.Dir(path), key+".*.tmp")
+	if err != nil {
+		log.Debugf("Error writing price cache: %s", err)
+		return
+	}
+
+	_, err = f.WriteString(result.Raw)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(f.Name(), path)
+	}
+	if err != nil {
+		_ = os.Remove(f.Name())
+		log.Debugf("Error writing price cache: %s", err)
+	}
+}
+
+// path shards the files into sub directories by the first bytes of the key so
+// no single directory gets too large.
+func (c *Cache) path(key string) string {
+	if len(key) < 2 {
+		return filepath.Join(c.Dir, key+".json")
+	}
+
+	return filepath.Join(c.Dir, key[:2], key+".json")
+}
@@ -0,0 +1,51 @@
+package pricecache
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+)
+
+func TestCache(t *testing.T) {
+	c := New(t.TempDir(), time.Hour)
+
+	key, err := Key("https://pricing.api.infracost.io", map[string]interface{}{"query": "{ products { prices { USD } } }"})
+	require.NoError(t, err)
+
+	_, ok := c.Get(key)
+	assert.False(t, ok)
+
+	c.Set(key, gjson.Parse(`{"data": {"products": [{"prices": [{"USD": "0.1"}]}]}}`))
+
+	res, ok := c.Get(key)
+	require.True(t, ok)
+	assert.Equal(t, "0.1", res.Get("data.products.0.prices.0.USD").String())
+}
+
+func TestCacheExpired(t *testing.T) {
+	c := New(t.TempDir(), time.Hour)
+
+	c.Set("abcd", gjson.Parse(`{"data": {"products": []}}`))
+
+	old := time.Now().Add(-2 * time.Hour)
+	require.NoError(t, os.Chtimes(c.path("abcd"), old, old))
+
+	_, ok := c.Get("abcd")
+	assert.False(t, ok)
+}
+
+func TestKeyIncludesEndpoint(t *testing.T) {
+	q := map[string]interface{}{"query": "{}"}
+
+	a, err := Key("https://pricing.api.infracost.io", q)
+	require.NoError(t, err)
+
+	b, err := Key("https://pricing.example.com", q)
+	require.NoError(t, err)
+
+	assert.NotEqual(t, a, b)
+}
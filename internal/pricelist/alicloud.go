@@ -0,0 +1,182 @@
+package pricelist
+
+// Alibaba Cloud prices are pay-as-you-go prices for the mainland China regions, which
+// share the same price list. They're registered without a region and in both USD and
+// CNY since teams operating in China are usually billed in CNY.
+// Prices are taken from the Alibaba Cloud pricing calculator: https://www.alibabacloud.com/pricing-calculator
+func init() {
+	products := []Product{
+		// Internet traffic and bandwidth shared by ECS, SLB and EIP
+		{Service: "Internet", ProductFamily: "Networking", Attributes: map[string]string{"resource": "Traffic"}, Prices: alicloudPrices("0.117", "0.8")},
+		{Service: "Internet", ProductFamily: "Networking", Attributes: map[string]string{"resource": "Bandwidth", "tier": "0-5"}, Prices: alicloudPrices("0.0092", "0.063")},
+		{Service: "Internet", ProductFamily: "Networking", Attributes: map[string]string{"resource": "Bandwidth", "tier": "5+"}, Prices: alicloudPrices("0.0365", "0.25")},
+
+		// Cloud disks
+		{Service: "ECS", ProductFamily: "Storage", Attributes: map[string]string{"category": "cloud"}, Prices: alicloudPrices("0.044", "0.3")},
+		{Service: "ECS", ProductFamily: "Storage", Attributes: map[string]string{"category": "cloud_efficiency"}, Prices: alicloudPrices("0.051", "0.35")},
+		{Service: "ECS", ProductFamily: "Storage", Attributes: map[string]string{"category": "cloud_ssd"}, Prices: alicloudPrices("0.146", "1")},
+		{Service: "ECS", ProductFamily: "Storage", Attributes: map[string]string{"category": "cloud_auto"}, Prices: alicloudPrices("0.146", "1")},
+		{Service: "ECS", ProductFamily: "Storage", Attributes: map[string]string{"category": "cloud_essd", "performanceLevel": "PL0"}, Prices: alicloudPrices("0.073", "0.5")},
+		{Service: "ECS", ProductFamily: "Storage", Attributes: map[string]string{"category": "cloud_essd", "performanceLevel": "PL1"}, Prices: alicloudPrices("0.146", "1")},
+		{Service: "ECS", ProductFamily: "Storage", Attributes: map[string]string{"category": "cloud_essd", "performanceLevel": "PL2"}, Prices: alicloudPrices("0.292", "2")},
+		{Service: "ECS", ProductFamily: "Storage", Attributes: map[string]string{"category": "cloud_essd", "performanceLevel": "PL3"}, Prices: alicloudPrices("0.584", "4")},
+
+		// ApsaraDB RDS storage
+		{Service: "RDS", ProductFamily: "Storage", Attributes: map[string]string{"storageType": "local_ssd"}, Prices: alicloudPrices("0.117", "0.8")},
+		{Service: "RDS", ProductFamily: "Storage", Attributes: map[string]string{"storageType": "cloud_ssd"}, Prices: alicloudPrices("0.117", "0.8")},
+		{Service: "RDS", ProductFamily: "Storage", Attributes: map[string]string{"storageType": "cloud_essd"}, Prices: alicloudPrices("0.146", "1")},
+		{Service: "RDS", ProductFamily: "Storage", Attributes: map[string]string{"storageType": "cloud_essd2"}, Prices: alicloudPrices("0.292", "2")},
+		{Service: "RDS", ProductFamily: "Storage", Attributes: map[string]string{"storageType": "cloud_essd3"}, Prices: alicloudPrices("0.584", "4")},
+
+		// Server Load Balancer (CLB)
+		{Service: "SLB", ProductFamily: "Networking", Attributes: map[string]string{"resource": "Instance"}, Prices: alicloudPrices("0.0029", "0.02")},
+		{Service: "SLB", ProductFamily: "Networking", Attributes: map[string]string{"resource": "LCU"}, Prices: alicloudPrices("0.0307", "0.21")},
+
+		// NAT Gateway
+		{Service: "NAT Gateway", ProductFamily: "Networking", Attributes: map[string]string{"resource": "Instance"}, Prices: alicloudPrices("0.0292", "0.2")},
+		{Service: "NAT Gateway", ProductFamily: "Networking", Attributes: map[string]string{"resource": "CU"}, Prices: alicloudPrices("0.0292", "0.2")},
+
+		// Object Storage Service
+		{Service: "OSS", ProductFamily: "Networking", Attributes: map[string]string{"resource": "Outbound traffic"}, Prices: alicloudPrices("0.073", "0.5")},
+	}
+
+	// ECS instance types are charged per hour.
+	instanceTypes := map[string][2]string{
+		"ecs.t6-c1m1.large": {"0.0189", "0.13"},
+		"ecs.t6-c1m2.large": {"0.0272", "0.187"},
+		"ecs.t6-c1m4.large": {"0.0394", "0.27"},
+		"ecs.c6.large":      {"0.0919", "0.63"},
+		"ecs.c6.xlarge":     {"0.1838", "1.26"},
+		"ecs.c6.2xlarge":    {"0.3676", "2.52"},
+		"ecs.c6.4xlarge":    {"0.7352", "5.04"},
+		"ecs.g6.large":      {"0.1138", "0.78"},
+		"ecs.g6.xlarge":     {"0.2276", "1.56"},
+		"ecs.g6.2xlarge":    {"0.4552", "3.12"},
+		"ecs.g6.4xlarge":    {"0.9104", "6.24"},
+		"ecs.r6.large":      {"0.1473", "1.01"},
+		"ecs.r6.xlarge":     {"0.2946", "2.02"},
+		"ecs.r6.2xlarge":    {"0.5892", "4.04"},
+		"ecs.c7.large":      {"0.0963", "0.66"},
+		"ecs.c7.xlarge":     {"0.1926", "1.32"},
+		"ecs.c7.2xlarge":    {"0.3852", "2.64"},
+		"ecs.g7.large":      {"0.1196", "0.82"},
+		"ecs.g7.xlarge":     {"0.2392", "1.64"},
+		"ecs.g7.2xlarge":    {"0.4784", "3.28"},
+		"ecs.g7.4xlarge":    {"0.9568", "6.56"},
+		"ecs.r7.large":      {"0.1546", "1.06"},
+		"ecs.r7.xlarge":     {"0.3092", "2.12"},
+		"ecs.r7.2xlarge":    {"0.6184", "4.24"},
+	}
+	for instanceType, p := range instanceTypes {
+		products = append(products,
+			Product{Service: "ECS", ProductFamily: "Compute Instance", Attributes: map[string]string{"instanceType": instanceType}, Prices: alicloudPrices(p[0], p[1])},
+		)
+	}
+
+	// ApsaraDB RDS instance classes are charged per hour. High-availability classes
+	// (ending in .2c) run a primary and a secondary node.
+	dbInstanceClasses := map[string][2]string{
+		"mysql.n2.small.1":    {"0.0335", "0.23"},
+		"mysql.n2.medium.1":   {"0.0671", "0.46"},
+		"mysql.n2.large.1":    {"0.1342", "0.92"},
+		"mysql.n4.medium.1":   {"0.0861", "0.59"},
+		"mysql.n4.large.1":    {"0.1721", "1.18"},
+		"mysql.n8.large.1":    {"0.2232", "1.53"},
+		"mysql.n2.small.2c":   {"0.0671", "0.46"},
+		"mysql.n2.medium.2c":  {"0.1342", "0.92"},
+		"mysql.n2.large.2c":   {"0.2684", "1.84"},
+		"mysql.n4.medium.2c":  {"0.1721", "1.18"},
+		"mysql.n4.large.2c":   {"0.3443", "2.36"},
+		"mysql.n8.large.2c":   {"0.4464", "3.06"},
+		"rds.mysql.s2.large":  {"0.1415", "0.97"},
+		"rds.mysql.s3.large":  {"0.283", "1.94"},
+		"rds.mysql.m1.medium": {"0.566", "3.88"},
+		"pg.n2.medium.1":      {"0.0715", "0.49"},
+		"pg.n2.large.1":       {"0.1415", "0.97"},
+		"pg.n2.medium.2c":     {"0.1429", "0.98"},
+		"pg.n2.large.2c":      {"0.283", "1.94"},
+		"rds.pg.s2.large":     {"0.1517", "1.04"},
+		"rds.pg.s3.large":     {"0.3034", "2.08"},
+		"mssql.x4.medium.e2":  {"0.2305", "1.58"},
+	}
+	for class, p := range dbInstanceClasses {
+		products = append(products,
+			Product{Service: "RDS", ProductFamily: "Database Instance", Attributes: map[string]string{"instanceClass": class}, Prices: alicloudPrices(p[0], p[1])},
+		)
+	}
+
+	// Legacy SLB specifications are charged a specification fee per hour on top of
+	// the instance fee. slb.s1.small has no specification fee.
+	slbSpecs := map[string][2]string{
+		"slb.s1.small":  {"0", "0"},
+		"slb.s2.small":  {"0.0102", "0.07"},
+		"slb.s2.medium": {"0.0204", "0.14"},
+		"slb.s3.small":  {"0.0408", "0.28"},
+		"slb.s3.medium": {"0.0802", "0.55"},
+		"slb.s3.large":  {"0.1605", "1.1"},
+	}
+	for spec, p := range slbSpecs {
+		products = append(products,
+			Product{Service: "SLB", ProductFamily: "Networking", Attributes: map[string]string{"resource": "Specification", "specification": spec}, Prices: alicloudPrices(p[0], p[1])},
+		)
+	}
+
+	// Legacy NAT Gateway specifications are charged per hour.
+	natSpecs := map[string][2]string{
+		"Small":    {"0.0729", "0.5"},
+		"Middle":   {"0.1459", "1"},
+		"Large":    {"0.2918", "2"},
+		"XLarge.1": {"0.5835", "4"},
+	}
+	for spec, p := range natSpecs {
+		products = append(products,
+			Product{Service: "NAT Gateway", ProductFamily: "Networking", Attributes: map[string]string{"resource": "Specification", "specification": spec}, Prices: alicloudPrices(p[0], p[1])},
+		)
+	}
+
+	// OSS storage is charged per GB-month by storage class and redundancy type, requests
+	// are charged per 10K requests and infrequent access classes are charged for data
+	// retrieval per GB.
+	ossStorageClasses := map[string]map[string][2]string{
+		"Standard": {
+			"Storage (LRS)": {"0.0175", "0.12"},
+			"Storage (ZRS)": {"0.0219", "0.15"},
+			"PUT requests":  {"0.0015", "0.01"},
+			"GET requests":  {"0.0015", "0.01"},
+		},
+		"IA": {
+			"Storage (LRS)":  {"0.0117", "0.08"},
+			"Storage (ZRS)":  {"0.0146", "0.1"},
+			"PUT requests":   {"0.0146", "0.1"},
+			"GET requests":   {"0.0146", "0.1"},
+			"Data retrieval": {"0.0047", "0.0325"},
+		},
+		"Archive": {
+			"Storage (LRS)":  {"0.0048", "0.033"},
+			"Storage (ZRS)":  {"0.0061", "0.042"},
+			"PUT requests":   {"0.0146", "0.1"},
+			"GET requests":   {"0.0146", "0.1"},
+			"Data retrieval": {"0.0088", "0.06"},
+		},
+		"ColdArchive": {
+			"Storage (LRS)":  {"0.0022", "0.015"},
+			"PUT requests":   {"0.0146", "0.1"},
+			"GET requests":   {"0.0146", "0.1"},
+			"Data retrieval": {"0.0044", "0.03"},
+		},
+	}
+	for storageClass, prices := range ossStorageClasses {
+		for resource, p := range prices {
+			attributes := map[string]string{"storageClass": storageClass, "resource": resource}
+			products = append(products,
+				Product{Service: "OSS", ProductFamily: "Storage", Attributes: attributes, Prices: alicloudPrices(p[0], p[1])},
+			)
+		}
+	}
+
+	Register("alicloud", products)
+}
+
+func alicloudPrices(usd, cny string) map[string]string {
+	return map[string]string{"USD": usd, "CNY": cny}
+}
@@ -0,0 +1,31 @@
+package pricelist
+
+// Cloudflare prices are global so they're registered without a region. Plan fees
+// and the amounts included in them are account-wide and aren't part of the price list.
+// Prices are taken from the Cloudflare plans pages:
+// https://developers.cloudflare.com/workers/platform/pricing/
+// https://developers.cloudflare.com/r2/pricing/
+// https://developers.cloudflare.com/load-balancing/reference/billing/
+func init() {
+	products := []Product{
+		// Workers Standard usage model
+		{Service: "Workers", ProductFamily: "Compute", Attributes: map[string]string{"resource": "Requests"}, Prices: map[string]string{"USD": "0.30"}},
+		{Service: "Workers", ProductFamily: "Compute", Attributes: map[string]string{"resource": "CPU time"}, Prices: map[string]string{"USD": "0.02"}},
+
+		// R2 Standard and Infrequent Access storage
+		{Service: "R2", ProductFamily: "Storage", Attributes: map[string]string{"storageClass": "Standard", "resource": "Storage"}, Prices: map[string]string{"USD": "0.015"}},
+		{Service: "R2", ProductFamily: "Storage", Attributes: map[string]string{"storageClass": "Standard", "resource": "Class A operations"}, Prices: map[string]string{"USD": "4.50"}},
+		{Service: "R2", ProductFamily: "Storage", Attributes: map[string]string{"storageClass": "Standard", "resource": "Class B operations"}, Prices: map[string]string{"USD": "0.36"}},
+		{Service: "R2", ProductFamily: "Storage", Attributes: map[string]string{"storageClass": "InfrequentAccess", "resource": "Storage"}, Prices: map[string]string{"USD": "0.01"}},
+		{Service: "R2", ProductFamily: "Storage", Attributes: map[string]string{"storageClass": "InfrequentAccess", "resource": "Class A operations"}, Prices: map[string]string{"USD": "9.00"}},
+		{Service: "R2", ProductFamily: "Storage", Attributes: map[string]string{"storageClass": "InfrequentAccess", "resource": "Class B operations"}, Prices: map[string]string{"USD": "0.90"}},
+		{Service: "R2", ProductFamily: "Storage", Attributes: map[string]string{"storageClass": "InfrequentAccess", "resource": "Data retrieval"}, Prices: map[string]string{"USD": "0.01"}},
+
+		// Load Balancing
+		{Service: "Load Balancing", ProductFamily: "Networking", Attributes: map[string]string{"resource": "Load balancer"}, Prices: map[string]string{"USD": "5"}},
+		{Service: "Load Balancing", ProductFamily: "Networking", Attributes: map[string]string{"resource": "Origin"}, Prices: map[string]string{"USD": "5"}},
+		{Service: "Load Balancing", ProductFamily: "Networking", Attributes: map[string]string{"resource": "DNS queries"}, Prices: map[string]string{"USD": "0.50"}},
+	}
+
+	Register("cloudflare", products)
+}
@@ -0,0 +1,41 @@
+package pricelist
+
+// Databricks charges per Databricks Unit (DBU) consumed, the price of a DBU depends
+// on the cloud the workspace runs on and the type of workload. The prices are the
+// pay-as-you-go prices of the Premium tier which are the same in all regions, so
+// they're registered without a region. The cloud VMs used by clusters are priced
+// by the Cloud Pricing API of their cloud.
+// Prices are taken from the Databricks pricing page: https://www.databricks.com/product/pricing
+func init() {
+	products := []Product{
+		// SQL warehouses are charged the same on AWS and Azure
+		{Service: "SQL", ProductFamily: "DBU", Attributes: map[string]string{"workload": "SQL Classic"}, Prices: map[string]string{"USD": "0.22"}},
+		{Service: "SQL", ProductFamily: "DBU", Attributes: map[string]string{"workload": "SQL Pro"}, Prices: map[string]string{"USD": "0.55"}},
+		{Service: "SQL", ProductFamily: "DBU", Attributes: map[string]string{"workload": "SQL Serverless"}, Prices: map[string]string{"USD": "0.70"}},
+	}
+
+	// Cluster workloads are charged per DBU by cloud.
+	computeWorkloads := map[string]map[string]string{
+		"AWS": {
+			"All-Purpose Compute": "0.55",
+			"Jobs Compute":        "0.15",
+		},
+		"AZURE": {
+			"All-Purpose Compute": "0.55",
+			"Jobs Compute":        "0.30",
+		},
+		"GCP": {
+			"All-Purpose Compute": "0.55",
+			"Jobs Compute":        "0.15",
+		},
+	}
+	for cloud, workloads := range computeWorkloads {
+		for workload, p := range workloads {
+			products = append(products,
+				Product{Service: "Compute", ProductFamily: "DBU", Attributes: map[string]string{"cloud": cloud, "workload": workload}, Prices: map[string]string{"USD": p}},
+			)
+		}
+	}
+
+	Register("databricks", products)
+}
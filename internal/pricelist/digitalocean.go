@@ -0,0 +1,103 @@
+package pricelist
+
+import (
+	"github.com/shopspring/decimal"
+)
+
+// digitalOceanBackupRate is the share of the Droplet price charged for weekly backups.
+var digitalOceanBackupRate = decimal.NewFromFloat(0.2)
+
+// DigitalOcean uses flat monthly prices which are the same in all regions, so they're
+// registered without a region.
+// Prices are taken from the DigitalOcean pricing page: https://www.digitalocean.com/pricing
+func init() {
+	products := []Product{
+		// Kubernetes
+		{Service: "Kubernetes", ProductFamily: "Control Plane", Attributes: map[string]string{"ha": "true"}, Prices: map[string]string{"USD": "40"}},
+
+		// Volumes
+		{Service: "Volumes", ProductFamily: "Storage", Attributes: map[string]string{"resource": "Storage"}, Prices: map[string]string{"USD": "0.10"}},
+
+		// Load Balancers are charged per node, legacy sizes are charged per size
+		{Service: "Load Balancers", ProductFamily: "Networking", Attributes: map[string]string{"size": "lb-small"}, Prices: map[string]string{"USD": "12"}},
+		{Service: "Load Balancers", ProductFamily: "Networking", Attributes: map[string]string{"size": "lb-medium"}, Prices: map[string]string{"USD": "36"}},
+		{Service: "Load Balancers", ProductFamily: "Networking", Attributes: map[string]string{"size": "lb-large"}, Prices: map[string]string{"USD": "72"}},
+		{Service: "Load Balancers", ProductFamily: "Networking", Attributes: map[string]string{"size": "node"}, Prices: map[string]string{"USD": "12"}},
+
+		// Spaces storage and transfer above the amounts included in the subscription
+		{Service: "Spaces", ProductFamily: "Storage", Attributes: map[string]string{"resource": "Storage"}, Prices: map[string]string{"USD": "0.02"}},
+		{Service: "Spaces", ProductFamily: "Storage", Attributes: map[string]string{"resource": "Outbound transfer"}, Prices: map[string]string{"USD": "0.01"}},
+	}
+
+	// Droplet sizes are charged per month. Kubernetes nodes are charged the same as
+	// the equivalent Droplet.
+	dropletSizes := map[string]string{
+		"s-1vcpu-512mb-10gb": "4",
+		"s-1vcpu-1gb":        "6",
+		"s-1vcpu-1gb-amd":    "7",
+		"s-1vcpu-1gb-intel":  "7",
+		"s-1vcpu-2gb":        "12",
+		"s-1vcpu-2gb-amd":    "14",
+		"s-1vcpu-2gb-intel":  "14",
+		"s-2vcpu-2gb":        "18",
+		"s-2vcpu-2gb-amd":    "21",
+		"s-2vcpu-2gb-intel":  "21",
+		"s-2vcpu-4gb":        "24",
+		"s-2vcpu-4gb-amd":    "28",
+		"s-2vcpu-4gb-intel":  "28",
+		"s-4vcpu-8gb":        "48",
+		"s-4vcpu-8gb-amd":    "56",
+		"s-4vcpu-8gb-intel":  "56",
+		"s-8vcpu-16gb":       "96",
+		"s-8vcpu-16gb-amd":   "112",
+		"s-8vcpu-16gb-intel": "112",
+		"c-2":                "42",
+		"c-4":                "84",
+		"c-8":                "168",
+		"c-16":               "336",
+		"c-32":               "672",
+		"g-2vcpu-8gb":        "63",
+		"g-4vcpu-16gb":       "126",
+		"g-8vcpu-32gb":       "252",
+		"g-16vcpu-64gb":      "504",
+		"m-2vcpu-16gb":       "84",
+		"m-4vcpu-32gb":       "168",
+		"m-8vcpu-64gb":       "336",
+		"m-16vcpu-128gb":     "672",
+		"so-2vcpu-16gb":      "131",
+		"so-4vcpu-32gb":      "262",
+		"so-8vcpu-64gb":      "524",
+	}
+	for size, p := range dropletSizes {
+		backup := decimal.RequireFromString(p).Mul(digitalOceanBackupRate).String()
+
+		products = append(products,
+			Product{Service: "Droplets", ProductFamily: "Compute", Attributes: map[string]string{"size": size}, Prices: map[string]string{"USD": p}},
+			Product{Service: "Droplets", ProductFamily: "Backups", Attributes: map[string]string{"size": size}, Prices: map[string]string{"USD": backup}},
+		)
+	}
+
+	// Managed database sizes are charged per node per month.
+	databaseSizes := map[string]string{
+		"db-s-1vcpu-1gb":   "15",
+		"db-s-1vcpu-2gb":   "30",
+		"db-s-2vcpu-4gb":   "60",
+		"db-s-4vcpu-8gb":   "120",
+		"db-s-6vcpu-16gb":  "240",
+		"db-s-8vcpu-32gb":  "480",
+		"db-s-16vcpu-64gb": "960",
+		"gd-2vcpu-8gb":     "136",
+		"gd-4vcpu-16gb":    "272",
+		"gd-8vcpu-32gb":    "544",
+		"m-2vcpu-16gb":     "195",
+		"m-4vcpu-32gb":     "390",
+		"m-8vcpu-64gb":     "780",
+	}
+	for size, p := range databaseSizes {
+		products = append(products,
+			Product{Service: "Databases", ProductFamily: "Database", Attributes: map[string]string{"size": size}, Prices: map[string]string{"USD": p}},
+		)
+	}
+
+	Register("digitalocean", products)
+}
@@ -0,0 +1,74 @@
+package pricelist
+
+import (
+	"github.com/shopspring/decimal"
+)
+
+// hcloudBackupRate is the share of the server price charged for backups.
+var hcloudBackupRate = decimal.NewFromFloat(0.2)
+
+// Hetzner Cloud bills in EUR so EUR is the primary currency of its price list, the
+// USD prices are the ones Hetzner publishes for customers billed in USD. Prices are
+// monthly net prices which are the same in all locations, so they're registered
+// without a region.
+// Prices are taken from the Hetzner Cloud pricing page: https://www.hetzner.com/cloud
+func init() {
+	products := []Product{
+		// Networking
+		{Service: "Networking", ProductFamily: "Primary IP", Attributes: map[string]string{"type": "ipv4"}, Prices: hcloudPrices("0.50", "0.60")},
+		{Service: "Networking", ProductFamily: "Floating IP", Attributes: map[string]string{"type": "ipv4"}, Prices: hcloudPrices("3.00", "3.60")},
+		{Service: "Networking", ProductFamily: "Floating IP", Attributes: map[string]string{"type": "ipv6"}, Prices: hcloudPrices("3.00", "3.60")},
+		{Service: "Networking", ProductFamily: "Traffic", Attributes: map[string]string{"resource": "Outbound traffic"}, Prices: hcloudPrices("1.00", "1.20")},
+
+		// Volumes
+		{Service: "Volumes", ProductFamily: "Storage", Attributes: map[string]string{"resource": "Storage"}, Prices: hcloudPrices("0.044", "0.0484")},
+
+		// Load Balancers
+		{Service: "Load Balancers", ProductFamily: "Networking", Attributes: map[string]string{"loadBalancerType": "lb11"}, Prices: hcloudPrices("5.39", "5.99")},
+		{Service: "Load Balancers", ProductFamily: "Networking", Attributes: map[string]string{"loadBalancerType": "lb21"}, Prices: hcloudPrices("16.40", "17.99")},
+		{Service: "Load Balancers", ProductFamily: "Networking", Attributes: map[string]string{"loadBalancerType": "lb31"}, Prices: hcloudPrices("32.90", "35.99")},
+	}
+
+	// Server types are charged per month, backups add 20% of the server price.
+	serverTypes := map[string][2]string{
+		"cx11":  {"3.29", "3.92"},
+		"cx21":  {"5.83", "6.41"},
+		"cx31":  {"10.59", "11.65"},
+		"cx41":  {"19.52", "21.47"},
+		"cx51":  {"38.56", "42.42"},
+		"cx22":  {"3.79", "4.59"},
+		"cx32":  {"6.80", "7.59"},
+		"cx42":  {"16.40", "18.59"},
+		"cx52":  {"32.40", "36.59"},
+		"cpx11": {"4.35", "4.99"},
+		"cpx21": {"7.55", "8.99"},
+		"cpx31": {"13.60", "15.99"},
+		"cpx41": {"25.20", "29.99"},
+		"cpx51": {"54.90", "64.99"},
+		"cax11": {"3.79", "4.59"},
+		"cax21": {"6.49", "7.59"},
+		"cax31": {"12.49", "14.59"},
+		"cax41": {"24.49", "28.59"},
+		"ccx13": {"12.49", "14.59"},
+		"ccx23": {"24.49", "28.59"},
+		"ccx33": {"48.49", "56.59"},
+		"ccx43": {"96.49", "112.59"},
+		"ccx53": {"192.49", "224.59"},
+		"ccx63": {"288.49", "336.59"},
+	}
+	for serverType, p := range serverTypes {
+		eurBackup := decimal.RequireFromString(p[0]).Mul(hcloudBackupRate).String()
+		usdBackup := decimal.RequireFromString(p[1]).Mul(hcloudBackupRate).String()
+
+		products = append(products,
+			Product{Service: "Servers", ProductFamily: "Compute", Attributes: map[string]string{"serverType": serverType}, Prices: hcloudPrices(p[0], p[1])},
+			Product{Service: "Servers", ProductFamily: "Backups", Attributes: map[string]string{"serverType": serverType}, Prices: hcloudPrices(eurBackup, usdBackup)},
+		)
+	}
+
+	Register("hcloud", products)
+}
+
+func hcloudPrices(eur, usd string) map[string]string {
+	return map[string]string{"EUR": eur, "USD": usd}
+}
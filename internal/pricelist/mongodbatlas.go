@@ -0,0 +1,52 @@
+package pricelist
+
+// MongoDB Atlas prices are the hourly prices of the US regions of each cloud provider,
+// which are the cheapest regions. They're registered without a region.
+// Prices are taken from the MongoDB Atlas pricing page: https://www.mongodb.com/pricing
+func init() {
+	products := []Product{
+		// Shared tier clusters are charged per month
+		{Service: "Clusters", ProductFamily: "Shared Cluster", Attributes: map[string]string{"instanceSize": "M0"}, Prices: map[string]string{"USD": "0"}},
+		{Service: "Clusters", ProductFamily: "Shared Cluster", Attributes: map[string]string{"instanceSize": "M2"}, Prices: map[string]string{"USD": "9"}},
+		{Service: "Clusters", ProductFamily: "Shared Cluster", Attributes: map[string]string{"instanceSize": "M5"}, Prices: map[string]string{"USD": "25"}},
+	}
+
+	// Dedicated cluster nodes are charged per hour by cloud provider and instance size.
+	instanceSizes := map[string]map[string]string{
+		"AWS": {
+			"M10": "0.08", "M20": "0.20", "M30": "0.54", "M40": "1.04", "M50": "2.00",
+			"M60": "3.95", "M80": "7.30", "M140": "10.99", "M200": "14.59", "M300": "21.85",
+		},
+		"GCP": {
+			"M10": "0.08", "M20": "0.21", "M30": "0.59", "M40": "1.11", "M50": "2.13",
+			"M60": "4.21", "M80": "7.52", "M140": "11.31", "M200": "15.03", "M300": "22.51",
+		},
+		"AZURE": {
+			"M10": "0.09", "M20": "0.22", "M30": "0.58", "M40": "1.10", "M50": "2.13",
+			"M60": "4.23", "M80": "7.45", "M140": "11.22", "M200": "14.89", "M300": "22.30",
+		},
+	}
+	for provider, sizes := range instanceSizes {
+		for size, p := range sizes {
+			products = append(products,
+				Product{Service: "Clusters", ProductFamily: "Dedicated Cluster", Attributes: map[string]string{"cloudProvider": provider, "instanceSize": size}, Prices: map[string]string{"USD": p}},
+			)
+		}
+	}
+
+	// Storage above the amount included with the instance size is charged per GB-month,
+	// cloud backup snapshots are charged per GB-month.
+	storage := map[string][2]string{
+		"AWS":   {"0.115", "0.14"},
+		"GCP":   {"0.17", "0.12"},
+		"AZURE": {"0.15", "0.13"},
+	}
+	for provider, p := range storage {
+		products = append(products,
+			Product{Service: "Clusters", ProductFamily: "Storage", Attributes: map[string]string{"cloudProvider": provider, "resource": "Storage"}, Prices: map[string]string{"USD": p[0]}},
+			Product{Service: "Clusters", ProductFamily: "Storage", Attributes: map[string]string{"cloudProvider": provider, "resource": "Backup"}, Prices: map[string]string{"USD": p[1]}},
+		)
+	}
+
+	Register("mongodbatlas", products)
+}
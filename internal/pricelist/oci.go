@@ -0,0 +1,63 @@
+package pricelist
+
+// OCI prices are the same in all commercial regions so they're registered without a region.
+// Prices are taken from the Oracle Cloud price list: https://www.oracle.com/cloud/price-list/
+func init() {
+	products := []Product{
+		// Block Volume
+		{Service: "Block Volume", ProductFamily: "Storage", Attributes: map[string]string{"resource": "Storage"}, Prices: map[string]string{"USD": "0.0255"}},
+		{Service: "Block Volume", ProductFamily: "Storage", Attributes: map[string]string{"resource": "Performance Units"}, Prices: map[string]string{"USD": "0.0017"}},
+
+		// Flexible Load Balancer
+		{Service: "Load Balancer", ProductFamily: "Networking", Attributes: map[string]string{"resource": "Base"}, Prices: map[string]string{"USD": "0.0113"}},
+		{Service: "Load Balancer", ProductFamily: "Networking", Attributes: map[string]string{"resource": "Bandwidth"}, Prices: map[string]string{"USD": "0.0001"}},
+
+		// Autonomous Database
+		{Service: "Autonomous Database", ProductFamily: "Database", Attributes: map[string]string{"resource": "Compute", "computeModel": "ECPU", "licenseModel": "LICENSE_INCLUDED"}, Prices: map[string]string{"USD": "0.336"}},
+		{Service: "Autonomous Database", ProductFamily: "Database", Attributes: map[string]string{"resource": "Compute", "computeModel": "ECPU", "licenseModel": "BRING_YOUR_OWN_LICENSE"}, Prices: map[string]string{"USD": "0.0807"}},
+		{Service: "Autonomous Database", ProductFamily: "Database", Attributes: map[string]string{"resource": "Compute", "computeModel": "OCPU", "licenseModel": "LICENSE_INCLUDED"}, Prices: map[string]string{"USD": "1.3441"}},
+		{Service: "Autonomous Database", ProductFamily: "Database", Attributes: map[string]string{"resource": "Compute", "computeModel": "OCPU", "licenseModel": "BRING_YOUR_OWN_LICENSE"}, Prices: map[string]string{"USD": "0.3226"}},
+		{Service: "Autonomous Database", ProductFamily: "Database", Attributes: map[string]string{"resource": "Storage", "workload": "OLTP"}, Prices: map[string]string{"USD": "0.1156"}},
+		{Service: "Autonomous Database", ProductFamily: "Database", Attributes: map[string]string{"resource": "Storage", "workload": "DW"}, Prices: map[string]string{"USD": "0.0244"}},
+	}
+
+	// Compute flexible shapes are charged per OCPU and per GB of memory.
+	flexShapes := map[string][2]string{
+		"VM.Standard.E3.Flex":  {"0.025", "0.0015"},
+		"VM.Standard.E4.Flex":  {"0.025", "0.0015"},
+		"VM.Standard.E5.Flex":  {"0.03", "0.002"},
+		"VM.Standard3.Flex":    {"0.04", "0.0015"},
+		"VM.Standard.A1.Flex":  {"0.01", "0.0015"},
+		"VM.Optimized3.Flex":   {"0.054", "0.0015"},
+		"VM.DenseIO.E4.Flex":   {"0.025", "0.0015"},
+		"VM.Standard.AMD.Flex": {"0.025", "0.0015"},
+	}
+	for shape, p := range flexShapes {
+		products = append(products,
+			Product{Service: "Compute", ProductFamily: "Compute", Attributes: map[string]string{"shape": shape, "resource": "OCPU"}, Prices: map[string]string{"USD": p[0]}},
+			Product{Service: "Compute", ProductFamily: "Compute", Attributes: map[string]string{"shape": shape, "resource": "Memory"}, Prices: map[string]string{"USD": p[1]}},
+		)
+	}
+
+	// Fixed shapes are charged per OCPU with the memory included.
+	fixedShapes := map[string]string{
+		"VM.Standard2.1":         "0.0638",
+		"VM.Standard2.2":         "0.0638",
+		"VM.Standard2.4":         "0.0638",
+		"VM.Standard2.8":         "0.0638",
+		"VM.Standard2.16":        "0.0638",
+		"VM.Standard2.24":        "0.0638",
+		"VM.Standard.E2.1":       "0.03",
+		"VM.Standard.E2.2":       "0.03",
+		"VM.Standard.E2.4":       "0.03",
+		"VM.Standard.E2.8":       "0.03",
+		"VM.Standard.E2.1.Micro": "0",
+	}
+	for shape, p := range fixedShapes {
+		products = append(products,
+			Product{Service: "Compute", ProductFamily: "Compute", Attributes: map[string]string{"shape": shape, "resource": "OCPU"}, Prices: map[string]string{"USD": p}},
+		)
+	}
+
+	Register("oci", products)
+}
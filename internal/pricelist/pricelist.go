@@ -0,0 +1,185 @@
+// Package pricelist provides prices for vendors that are not available in the
+// Cloud Pricing API. Each vendor registers a static list of products which is
+// queried using the same product filters the resources use for the Cloud
+// Pricing API, so resources don't need to know where their prices come from.
+package pricelist
+
+import (
+	"crypto/md5" // nolint:gosec
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/tidwall/gjson"
+
+	"github.com/infracost/infracost/internal/schema"
+)
+
+// Product is a single priced product in a vendor price list.
+type Product struct {
+	Service       string
+	ProductFamily string
+	// Region is the region the price applies to. An empty region means the
+	// price applies to all regions.
+	Region     string
+	Attributes map[string]string
+	// Prices is a map of currency code to price, e.g. {"USD": "0.025"}.
+	Prices map[string]string
+}
+
+var (
+	vendors   = map[string][]Product{}
+	vendorsMu sync.RWMutex
+
+	regexCache   = map[string]*regexp.Regexp{}
+	regexCacheMu sync.Mutex
+)
+
+// Register adds the products to the price list for the vendor. It is called
+// from the init functions of the vendor price list files.
+func Register(vendorName string, products []Product) {
+	vendorsMu.Lock()
+	defer vendorsMu.Unlock()
+
+	vendors[vendorName] = append(vendors[vendorName], products...)
+}
+
+// Has returns true if the vendor prices are provided by a price list rather
+// than the Cloud Pricing API.
+func Has(vendorName *string) bool {
+	if vendorName == nil {
+		return false
+	}
+
+	vendorsMu.RLock()
+	defer vendorsMu.RUnlock()
+
+	_, ok := vendors[*vendorName]
+	return ok
+}
+
+// Query finds the products matching the product filter and returns them in the
+// same format as the Cloud Pricing API GraphQL response so they can be handled
+// the same way. Price filters are not supported since the price lists only
+// contain a single on-demand price for each product.
+func Query(currency string, product *schema.ProductFilter) gjson.Result {
+	vendorsMu.RLock()
+	products := vendors[*product.VendorName]
+	vendorsMu.RUnlock()
+
+	results := make([]interface{}, 0)
+
+	for _, p := range products {
+		if !matches(product, p) {
+			continue
+		}
+
+		price := map[string]string{
+			"priceHash": priceHash(*product.VendorName, p),
+		}
+		if v, ok := p.Prices[currency]; ok {
+			price[currency] = v
+		} else {
+			log.Debugf("No %s price in the %s price list for %s %s", currency, *product.VendorName, p.Service, p.ProductFamily)
+		}
+
+		results = append(results, map[string]interface{}{
+			"prices": []map[string]string{price},
+		})
+	}
+
+	b, err := json.Marshal(map[string]interface{}{
+		"data": map[string]interface{}{
+			"products": results,
+		},
+	})
+	if err != nil {
+		log.Errorf("Error building price list result: %s", err)
+		return gjson.Result{}
+	}
+
+	return gjson.ParseBytes(b)
+}
+
+func matches(f *schema.ProductFilter, p Product) bool {
+	if f.Service != nil && *f.Service != p.Service {
+		return false
+	}
+
+	if f.ProductFamily != nil && *f.ProductFamily != p.ProductFamily {
+		return false
+	}
+
+	if f.Region != nil && p.Region != "" && *f.Region != p.Region {
+		return false
+	}
+
+	for _, a := range f.AttributeFilters {
+		v, ok := p.Attributes[a.Key]
+		if !ok {
+			return false
+		}
+
+		if a.Value != nil && *a.Value != v {
+			return false
+		}
+
+		if a.ValueRegex != nil && !MatchRegex(*a.ValueRegex, v) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// MatchRegex matches the value against a regex in the `/pattern/flags` format
+// used by the Cloud Pricing API.
+func MatchRegex(pattern, value string) bool {
+	regexCacheMu.Lock()
+	defer regexCacheMu.Unlock()
+
+	re, ok := regexCache[pattern]
+	if !ok {
+		expr := pattern
+		if end := strings.LastIndex(expr, "/"); strings.HasPrefix(expr, "/") && end > 0 {
+			flags := expr[end+1:]
+			expr = expr[1:end]
+
+			if strings.Contains(flags, "i") {
+				expr = "(?i)" + expr
+			}
+		}
+
+		var err error
+		re, err = regexp.Compile(expr)
+		if err != nil {
+			log.Debugf("Invalid price list regex %s: %s", pattern, err)
+			return false
+		}
+
+		regexCache[pattern] = re
+	}
+
+	return re.MatchString(value)
+}
+
+func priceHash(vendorName string, p Product) string {
+	keys := make([]string, 0, len(p.Attributes))
+	for k := range p.Attributes {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	parts := []string{vendorName, p.Service, p.ProductFamily, p.Region}
+	for _, k := range keys {
+		parts = append(parts, fmt.Sprintf("%s=%s", k, p.Attributes[k]))
+	}
+
+	h := md5.Sum([]byte(strings.Join(parts, "|"))) // nolint:gosec
+	return hex.EncodeToString(h[:])
+}
@@ -0,0 +1,124 @@
+package pricelist
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/infracost/infracost/internal/schema"
+)
+
+func strPtr(s string) *string { return &s }
+
+func TestQuery(t *testing.T) {
+	Register("test", []Product{
+		{
+			Service:       "Compute",
+			ProductFamily: "Instance",
+			Region:        "region-1",
+			Attributes:    map[string]string{"shape": "Standard.E4.Flex", "resource": "OCPU"},
+			Prices:        map[string]string{"USD": "0.025", "EUR": "0.023"},
+		},
+		{
+			Service:       "Compute",
+			ProductFamily: "Instance",
+			Region:        "region-1",
+			Attributes:    map[string]string{"shape": "Standard.E4.Flex", "resource": "Memory"},
+			Prices:        map[string]string{"USD": "0.0015"},
+		},
+		{
+			Service:       "Storage",
+			ProductFamily: "Block Volume",
+			Attributes:    map[string]string{"resource": "Storage"},
+			Prices:        map[string]string{"USD": "0.0255"},
+		},
+	})
+
+	tests := []struct {
+		name     string
+		currency string
+		filter   *schema.ProductFilter
+		expected []string
+	}{
+		{
+			name:     "matches attribute value",
+			currency: "USD",
+			filter: &schema.ProductFilter{
+				VendorName:    strPtr("test"),
+				Region:        strPtr("region-1"),
+				Service:       strPtr("Compute"),
+				ProductFamily: strPtr("Instance"),
+				AttributeFilters: []*schema.AttributeFilter{
+					{Key: "shape", Value: strPtr("Standard.E4.Flex")},
+					{Key: "resource", Value: strPtr("OCPU")},
+				},
+			},
+			expected: []string{"0.025"},
+		},
+		{
+			name:     "matches attribute regex",
+			currency: "USD",
+			filter: &schema.ProductFilter{
+				VendorName: strPtr("test"),
+				Service:    strPtr("Compute"),
+				AttributeFilters: []*schema.AttributeFilter{
+					{Key: "resource", ValueRegex: strPtr("/^memory$/i")},
+				},
+			},
+			expected: []string{"0.0015"},
+		},
+		{
+			name:     "product without region matches any region",
+			currency: "USD",
+			filter: &schema.ProductFilter{
+				VendorName: strPtr("test"),
+				Region:     strPtr("region-2"),
+				Service:    strPtr("Storage"),
+			},
+			expected: []string{"0.0255"},
+		},
+		{
+			name:     "other region does not match",
+			currency: "USD",
+			filter: &schema.ProductFilter{
+				VendorName: strPtr("test"),
+				Region:     strPtr("region-2"),
+				Service:    strPtr("Compute"),
+			},
+			expected: []string{},
+		},
+		{
+			name:     "uses the requested currency",
+			currency: "EUR",
+			filter: &schema.ProductFilter{
+				VendorName: strPtr("test"),
+				AttributeFilters: []*schema.AttributeFilter{
+					{Key: "resource", Value: strPtr("OCPU")},
+				},
+			},
+			expected: []string{"0.023"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := Query(tt.currency, tt.filter)
+
+			actual := []string{}
+			for _, p := range result.Get("data.products").Array() {
+				assert.NotEmpty(t, p.Get("prices.0.priceHash").String())
+				actual = append(actual, p.Get("prices.0."+tt.currency).String())
+			}
+
+			assert.Equal(t, tt.expected, actual)
+		})
+	}
+}
+
+func TestHas(t *testing.T) {
+	Register("has-test", []Product{})
+
+	assert.True(t, Has(strPtr("has-test")))
+	assert.False(t, Has(strPtr("missing")))
+	assert.False(t, Has(nil))
+}
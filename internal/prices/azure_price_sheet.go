@@ -0,0 +1,88 @@
+package prices
+
+import (
+	"strings"
+
+	"github.com/shopspring/decimal"
+	log "github.com/sirupsen/logrus"
+	"github.com/tidwall/gjson"
+
+	"github.com/infracost/infracost/internal/apiclient"
+	"github.com/infracost/infracost/internal/azurepricesheet"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+// applyAzurePriceSheet replaces the list prices of the azure cost components with
+// the prices of their meters in the price sheet. Meters are matched using the
+// meterId attribute of the products the cost components were priced with.
+func applyAzurePriceSheet(c *apiclient.PricingAPIClient, sheet *azurepricesheet.PriceSheet, resources []*schema.Resource) {
+	if sheet.Len() == 0 {
+		return
+	}
+
+	if sheet.Currency != "" && !strings.EqualFold(sheet.Currency, c.Currency) {
+		log.Warnf("Azure price sheet prices are in %s but Infracost is configured to use %s, the price sheet will not be used", sheet.Currency, c.Currency)
+		return
+	}
+
+	for _, r := range resources {
+		if r.IsSkipped {
+			continue
+		}
+
+		lookups := &schema.Resource{Name: r.Name}
+		for _, res := range append([]*schema.Resource{r}, r.FlattenedSubResources()...) {
+			for _, component := range res.CostComponents {
+				if isAzureCostComponent(component) {
+					lookups.CostComponents = append(lookups.CostComponents, component)
+				}
+			}
+		}
+
+		if len(lookups.CostComponents) == 0 {
+			continue
+		}
+
+		results, err := c.RunProductAttributeQueries(lookups)
+		if err != nil {
+			log.Warnf("Error looking up Azure meters for %s, using list prices: %s", r.Name, err)
+			continue
+		}
+
+		for _, res := range results {
+			p, ok := azurePriceSheetPrice(sheet, res.Result)
+			if !ok {
+				continue
+			}
+
+			log.Debugf("Using Azure price sheet price %s for %s %s", p, r.Name, res.CostComponent.Name)
+			res.CostComponent.SetPrice(p)
+			res.CostComponent.PriceIssue = ""
+		}
+	}
+}
+
+// azurePriceSheetPrice returns the price sheet price of the meter of the product
+// in the result, converted to the unit of the product's price.
+func azurePriceSheetPrice(sheet *azurepricesheet.PriceSheet, res gjson.Result) (decimal.Decimal, bool) {
+	product := res.Get("data.products.0")
+	if !product.Exists() {
+		return decimal.Zero, false
+	}
+
+	meterID := product.Get(`attributes.#(key=="meterId").value`).String()
+	if meterID == "" {
+		return decimal.Zero, false
+	}
+
+	item, ok := sheet.Get(meterID)
+	if !ok {
+		return decimal.Zero, false
+	}
+
+	return item.PriceFor(product.Get("prices.0.unit").String()), true
+}
+
+func isAzureCostComponent(c *schema.CostComponent) bool {
+	return c.ProductFilter != nil && c.ProductFilter.VendorName != nil && *c.ProductFilter.VendorName == "azure"
+}
@@ -0,0 +1,51 @@
+package prices
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+
+	"github.com/infracost/infracost/internal/azurepricesheet"
+)
+
+func TestAzurePriceSheetPrice(t *testing.T) {
+	sheet, err := azurepricesheet.New([]*azurepricesheet.Item{
+		{MeterID: "D1A0E8C3-0001", UnitOfMeasure: "100 Hours", UnitPrice: decimal.NewFromFloat(8.1), CurrencyCode: "USD"},
+	})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		result   string
+		expected string
+		ok       bool
+	}{
+		{
+			name:     "matching meter",
+			result:   `{"data": {"products": [{"attributes": [{"key": "skuName", "value": "D2s v3"}, {"key": "meterId", "value": "d1a0e8c3-0001"}], "prices": [{"unit": "1 Hour"}]}]}}`,
+			expected: "0.081",
+			ok:       true,
+		},
+		{
+			name:   "other meter",
+			result: `{"data": {"products": [{"attributes": [{"key": "meterId", "value": "other"}], "prices": [{"unit": "1 Hour"}]}]}}`,
+		},
+		{
+			name:   "no products",
+			result: `{"data": {"products": []}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, ok := azurePriceSheetPrice(sheet, gjson.Parse(tt.result))
+			assert.Equal(t, tt.ok, ok)
+			if tt.ok {
+				assert.Equal(t, tt.expected, p.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,284 @@
+package prices
+
+import (
+	"strings"
+
+	"github.com/shopspring/decimal"
+	log "github.com/sirupsen/logrus"
+	"github.com/tidwall/gjson"
+
+	"github.com/infracost/infracost/internal/apiclient"
+	"github.com/infracost/infracost/internal/config"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+// instanceTypeAttributes are the attribute filter keys that hold the instance type
+// of compute cost components.
+var instanceTypeAttributes = []string{"instanceType", "machineType", "armSkuName"}
+
+var onDemandPurchaseOptions = []string{"on_demand", "consumption"}
+
+var reservedTermLengths = map[string]string{
+	"1_year": "1yr",
+	"3_year": "3yr",
+}
+
+// gcpCommittedUseDiscounts are the resource-based Committed Use Discount
+// percentages of each term used when a GCP commitment has no discount_percent.
+// Memory-optimized machine types have higher discounts.
+// See https://cloud.google.com/compute/docs/instances/signing-up-committed-use-discounts
+var gcpCommittedUseDiscounts = map[string]float64{
+	"1_year": 37,
+	"3_year": 55,
+}
+
+var gcpMemoryOptimizedCommittedUseDiscounts = map[string]float64{
+	"1_year": 41,
+	"3_year": 70,
+}
+
+type commitmentBlend struct {
+	costComponent   *schema.CostComponent
+	commitment      *schema.Commitment
+	discountPercent float64
+	reserved        *schema.CostComponent
+}
+
+// ApplyCommitments blends the reserved and on-demand prices of the on-demand
+// instance cost components covered by the commitments, e.g. a 60% coverage prices
+// 60% of the usage at the reserved rate and 40% at the on-demand rate.
+// It must be called after the prices of the project have been populated and before
+// the costs are calculated. Commitments are matched in order and the first matching
+// commitment is applied. Usage covered by a GCP Committed Use Discount doesn't get
+// sustained use discounts, so they are only applied to the uncovered usage.
+func ApplyCommitments(ctx *config.RunContext, commitments []*schema.Commitment, project *schema.Project) {
+	if len(commitments) == 0 {
+		return
+	}
+
+	c := apiclient.NewPricingAPIClient(ctx)
+	if project.Currency != "" {
+		c = apiclient.NewPricingAPIClientForCurrency(ctx, project.Currency)
+	}
+
+	for _, r := range project.AllResources() {
+		if !r.IsSkipped {
+			applyResourceCommitments(c, commitments, r)
+		}
+	}
+}
+
+func applyResourceCommitments(c *apiclient.PricingAPIClient, commitments []*schema.Commitment, r *schema.Resource) {
+	var blends []*commitmentBlend
+
+	// Reserved rates which aren't derived from a discount are looked up in one batch
+	lookups := &schema.Resource{Name: r.Name}
+
+	resources := append([]*schema.Resource{r}, r.FlattenedSubResources()...)
+	for _, res := range resources {
+		for _, component := range res.CostComponents {
+			commitment := matchCommitment(commitments, component)
+			if commitment == nil {
+				continue
+			}
+
+			b := &commitmentBlend{
+				costComponent:   component,
+				commitment:      commitment,
+				discountPercent: commitmentDiscountPercent(commitment, componentInstanceType(component)),
+			}
+			if b.discountPercent == 0 {
+				b.reserved = reservedCostComponent(component, commitment)
+				lookups.CostComponents = append(lookups.CostComponents, b.reserved)
+			}
+
+			blends = append(blends, b)
+		}
+	}
+
+	reservedPrices := make(map[*schema.CostComponent]decimal.Decimal)
+
+	if len(lookups.CostComponents) > 0 {
+		results, err := c.RunQueries(lookups)
+		if err != nil {
+			log.Warnf("Error looking up reserved prices for %s, using on-demand prices: %s", r.Name, err)
+		}
+
+		for _, res := range results {
+			if p, ok := firstPrice(c.Currency, res.Result); ok {
+				reservedPrices[res.CostComponent] = p
+			}
+		}
+	}
+
+	for _, b := range blends {
+		onDemand := b.costComponent.Price()
+
+		var reserved decimal.Decimal
+		if b.discountPercent > 0 {
+			discount := decimal.NewFromFloat(b.discountPercent).Div(decimal.NewFromInt(100))
+			reserved = onDemand.Mul(decimal.NewFromInt(1).Sub(discount))
+		} else {
+			p, ok := reservedPrices[b.reserved]
+			if !ok {
+				log.Warnf("No reserved prices found for %s %s, using the on-demand price", r.Name, b.costComponent.Name)
+				continue
+			}
+			reserved = p
+		}
+
+		// The sustained use discount only applies to the uncovered usage, so it's
+		// folded into the on-demand rate rather than discounting the blended price.
+		if b.costComponent.MonthlyDiscountPerc > 0 {
+			onDemand = onDemand.Mul(decimal.NewFromFloat(1.0 - b.costComponent.MonthlyDiscountPerc))
+			b.costComponent.MonthlyDiscountPerc = 0
+		}
+
+		coverage := decimal.NewFromFloat(b.commitment.CoveragePercent).Div(decimal.NewFromInt(100))
+		blended := reserved.Mul(coverage).Add(onDemand.Mul(decimal.NewFromInt(1).Sub(coverage)))
+
+		log.Debugf("Blending %s %s with %s%% reserved coverage", r.Name, b.costComponent.Name, decimal.NewFromFloat(b.commitment.CoveragePercent).String())
+		b.costComponent.SetPrice(blended)
+	}
+}
+
+func matchCommitment(commitments []*schema.Commitment, c *schema.CostComponent) *schema.Commitment {
+	if c.ProductFilter == nil || c.PriceFilter == nil || c.PriceFilter.PurchaseOption == nil {
+		return nil
+	}
+
+	if !stringInSliceFold(onDemandPurchaseOptions, *c.PriceFilter.PurchaseOption) {
+		return nil
+	}
+
+	instanceType := componentInstanceType(c)
+	if instanceType == "" {
+		return nil
+	}
+
+	for _, commitment := range commitments {
+		if matchesFilter(commitment.Vendor, c.ProductFilter.VendorName) &&
+			matchesFilter(commitment.Region, c.ProductFilter.Region) &&
+			commitment.MatchesInstanceType(instanceType) {
+			return commitment
+		}
+	}
+
+	return nil
+}
+
+// commitmentDiscountPercent returns the discount of the reserved rate over the
+// on-demand rate, or 0 if the reserved rate has to be looked up.
+func commitmentDiscountPercent(commitment *schema.Commitment, instanceType string) float64 {
+	if commitment.DiscountPercent > 0 || !commitment.IsGCPCommittedUse() {
+		return commitment.DiscountPercent
+	}
+
+	term := commitment.Term
+	if term == "" {
+		term = "1_year"
+	}
+
+	switch strings.ToLower(strings.Split(instanceType, "-")[0]) {
+	case "m1", "m2":
+		return gcpMemoryOptimizedCommittedUseDiscounts[term]
+	}
+
+	return gcpCommittedUseDiscounts[term]
+}
+
+// componentInstanceType returns the instance type the cost component is filtered
+// by, removing the regex anchors of regex filters, e.g. /^n1-standard-1$/i.
+func componentInstanceType(c *schema.CostComponent) string {
+	for _, f := range c.ProductFilter.AttributeFilters {
+		if !stringInSliceFold(instanceTypeAttributes, f.Key) {
+			continue
+		}
+
+		if f.Value != nil {
+			return *f.Value
+		}
+
+		if f.ValueRegex != nil {
+			v := strings.TrimPrefix(*f.ValueRegex, "/")
+			v = strings.TrimSuffix(v, "/i")
+			v = strings.TrimSuffix(v, "/")
+			v = strings.TrimPrefix(v, "^")
+			return strings.TrimSuffix(v, "$")
+		}
+	}
+
+	return ""
+}
+
+// reservedCostComponent returns a copy of the on-demand cost component that looks
+// up the reserved rate of the commitment. Savings Plans default to the convertible
+// offering class since their rates are closest to convertible reserved rates.
+func reservedCostComponent(c *schema.CostComponent, commitment *schema.Commitment) *schema.CostComponent {
+	productFilter := *c.ProductFilter
+	productFilter.AttributeFilters = nil
+
+	for _, f := range c.ProductFilter.AttributeFilters {
+		// Reserved prices aren't filtered by license model
+		if f.Key != "licenseModel" {
+			productFilter.AttributeFilters = append(productFilter.AttributeFilters, f)
+		}
+	}
+
+	term := commitment.Term
+	if term == "" {
+		term = "1_year"
+	}
+
+	offeringClass := commitment.OfferingClass
+	if offeringClass == "" {
+		offeringClass = "standard"
+		if commitment.Type == schema.CommitmentTypeSavingsPlan {
+			offeringClass = "convertible"
+		}
+	}
+
+	return &schema.CostComponent{
+		Name:          c.Name,
+		ProductFilter: &productFilter,
+		PriceFilter: &schema.PriceFilter{
+			StartUsageAmount:   strPtr("0"),
+			TermLength:         strPtr(reservedTermLengths[term]),
+			TermPurchaseOption: strPtr("No Upfront"),
+			TermOfferingClass:  strPtr(offeringClass),
+		},
+	}
+}
+
+func firstPrice(currency string, res gjson.Result) (decimal.Decimal, bool) {
+	products := res.Get("data.products").Array()
+	if len(products) == 0 {
+		return decimal.Zero, false
+	}
+
+	prices := products[0].Get("prices").Array()
+	if len(prices) == 0 {
+		return decimal.Zero, false
+	}
+
+	p, err := decimal.NewFromString(prices[0].Get(currency).String())
+	if err != nil {
+		return decimal.Zero, false
+	}
+
+	return p, true
+}
+
+func stringInSliceFold(s []string, v string) bool {
+	for _, i := range s {
+		if strings.EqualFold(i, v) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func strPtr(s string) *string {
+	return &s
+}
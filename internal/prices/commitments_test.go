@@ -0,0 +1,106 @@
+package prices
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/infracost/infracost/internal/schema"
+)
+
+func testInstanceCostComponent(vendor, region, purchaseOption string, attributeFilter *schema.AttributeFilter, price float64) *schema.CostComponent {
+	c := &schema.CostComponent{
+		Name:           "Instance usage",
+		UnitMultiplier: decimal.NewFromInt(1),
+		HourlyQuantity: decimalPtr(decimal.NewFromInt(1)),
+		ProductFilter: &schema.ProductFilter{
+			VendorName:       strPtr(vendor),
+			Region:           strPtr(region),
+			AttributeFilters: []*schema.AttributeFilter{attributeFilter},
+		},
+		PriceFilter: &schema.PriceFilter{
+			PurchaseOption: strPtr(purchaseOption),
+		},
+	}
+	c.SetPrice(decimal.NewFromFloat(price))
+
+	return c
+}
+
+func TestApplyResourceCommitments(t *testing.T) {
+	commitments := []*schema.Commitment{
+		{Type: schema.CommitmentTypeReservedInstance, Vendor: "aws", InstanceFamily: "m5", Region: "us-east-1", CoveragePercent: 60, DiscountPercent: 40},
+		{Type: schema.CommitmentTypeCommittedUse, Vendor: "gcp", InstanceFamily: "n2", CoveragePercent: 100, DiscountPercent: 37},
+	}
+
+	tests := []struct {
+		name      string
+		component *schema.CostComponent
+		expected  string
+	}{
+		{
+			name:      "covered instance",
+			component: testInstanceCostComponent("aws", "us-east-1", "on_demand", &schema.AttributeFilter{Key: "instanceType", Value: strPtr("m5.large")}, 0.1),
+			expected:  "0.076",
+		},
+		{
+			name:      "regex instance type",
+			component: testInstanceCostComponent("gcp", "us-central1", "on_demand", &schema.AttributeFilter{Key: "machineType", ValueRegex: strPtr("/^n2-standard-4$/i")}, 0.2),
+			expected:  "0.126",
+		},
+		{
+			name:      "other family",
+			component: testInstanceCostComponent("aws", "us-east-1", "on_demand", &schema.AttributeFilter{Key: "instanceType", Value: strPtr("m5a.large")}, 0.1),
+			expected:  "0.1",
+		},
+		{
+			name:      "other region",
+			component: testInstanceCostComponent("aws", "eu-west-1", "on_demand", &schema.AttributeFilter{Key: "instanceType", Value: strPtr("m5.large")}, 0.1),
+			expected:  "0.1",
+		},
+		{
+			name:      "spot instance",
+			component: testInstanceCostComponent("aws", "us-east-1", "spot", &schema.AttributeFilter{Key: "instanceType", Value: strPtr("m5.large")}, 0.03),
+			expected:  "0.03",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &schema.Resource{
+				Name:           "instance",
+				CostComponents: []*schema.CostComponent{tt.component},
+			}
+
+			applyResourceCommitments(nil, commitments, r)
+
+			assert.Equal(t, tt.expected, tt.component.Price().String())
+		})
+	}
+}
+
+func TestApplyResourceCommitmentsGCPCommittedUse(t *testing.T) {
+	commitments := []*schema.Commitment{
+		{Type: schema.CommitmentTypeCommittedUse, Vendor: "gcp", InstanceFamily: "m1", CoveragePercent: 100, Term: "3_year"},
+		{Type: schema.CommitmentTypeCommittedUse, Vendor: "gcp", CoveragePercent: 50},
+	}
+
+	t.Run("sustained use discount on uncovered usage", func(t *testing.T) {
+		component := testInstanceCostComponent("gcp", "us-central1", "on_demand", &schema.AttributeFilter{Key: "machineType", ValueRegex: strPtr("/^n1-standard-1$/i")}, 0.1)
+		component.MonthlyDiscountPerc = 0.3
+
+		applyResourceCommitments(nil, commitments, &schema.Resource{Name: "instance", CostComponents: []*schema.CostComponent{component}})
+
+		assert.Equal(t, "0.0665", component.Price().String())
+		assert.Equal(t, 0.0, component.MonthlyDiscountPerc)
+	})
+
+	t.Run("memory-optimized discount", func(t *testing.T) {
+		component := testInstanceCostComponent("gcp", "us-central1", "on_demand", &schema.AttributeFilter{Key: "machineType", ValueRegex: strPtr("/^m1-ultramem-40$/i")}, 1)
+
+		applyResourceCommitments(nil, commitments, &schema.Resource{Name: "instance", CostComponents: []*schema.CostComponent{component}})
+
+		assert.Equal(t, "0.3", component.Price().String())
+	})
+}
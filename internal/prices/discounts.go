@@ -0,0 +1,110 @@
+package prices
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/infracost/infracost/internal/config"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+// ApplyDiscounts adds a discount cost component to each resource for every
+// discount that matches its cost components, then recalculates the resource costs.
+// It must be called after the costs of the project have been calculated since the
+// discount is a percentage of the monthly cost of the matching cost components.
+func ApplyDiscounts(discounts []*config.Discount, project *schema.Project) {
+	if len(discounts) == 0 {
+		return
+	}
+
+	for _, r := range project.AllResources() {
+		if r.IsSkipped {
+			continue
+		}
+
+		if applyResourceDiscounts(discounts, r) {
+			r.CalculateCosts()
+		}
+	}
+}
+
+func applyResourceDiscounts(discounts []*config.Discount, r *schema.Resource) bool {
+	amounts := make(map[*config.Discount]decimal.Decimal)
+
+	resources := append([]*schema.Resource{r}, r.FlattenedSubResources()...)
+	for _, res := range resources {
+		for _, c := range res.CostComponents {
+			if c.MonthlyCost == nil || c.MonthlyCost.IsZero() {
+				continue
+			}
+
+			d := matchDiscount(discounts, c)
+			if d == nil {
+				continue
+			}
+
+			perc := decimal.NewFromFloat(d.Percent).Div(decimal.NewFromInt(100))
+			amounts[d] = amounts[d].Add(c.MonthlyCost.Mul(perc))
+		}
+	}
+
+	// Add the discounts in the order they're configured so the output is stable
+	for _, d := range discounts {
+		amount, ok := amounts[d]
+		if !ok {
+			continue
+		}
+
+		c := &schema.CostComponent{
+			Name:            discountName(d),
+			Unit:            "months",
+			UnitMultiplier:  decimal.NewFromInt(1),
+			MonthlyQuantity: decimalPtr(decimal.NewFromInt(1)),
+		}
+		c.SetPrice(amount.Neg())
+
+		r.CostComponents = append(r.CostComponents, c)
+	}
+
+	return len(amounts) > 0
+}
+
+func matchDiscount(discounts []*config.Discount, c *schema.CostComponent) *config.Discount {
+	if c.ProductFilter == nil {
+		return nil
+	}
+
+	for _, d := range discounts {
+		if matchesFilter(d.Vendor, c.ProductFilter.VendorName) &&
+			matchesFilter(d.Service, c.ProductFilter.Service) &&
+			matchesFilter(d.Region, c.ProductFilter.Region) {
+			return d
+		}
+	}
+
+	return nil
+}
+
+func matchesFilter(want string, got *string) bool {
+	if want == "" {
+		return true
+	}
+
+	return got != nil && strings.EqualFold(want, *got)
+}
+
+func discountName(d *config.Discount) string {
+	perc := decimal.NewFromFloat(d.Percent).String()
+
+	if d.Name != "" {
+		return fmt.Sprintf("Discount (%s, %s%%)", d.Name, perc)
+	}
+
+	return fmt.Sprintf("Discount (%s%%)", perc)
+}
+
+func decimalPtr(d decimal.Decimal) *decimal.Decimal {
+	return &d
+}
@@ -0,0 +1,112 @@
+package prices
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/infracost/infracost/internal/config"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+func testCostComponent(name, vendor, service, region string, monthlyCost float64) *schema.CostComponent {
+	c := &schema.CostComponent{
+		Name:            name,
+		UnitMultiplier:  decimal.NewFromInt(1),
+		MonthlyQuantity: decimalPtr(decimal.NewFromInt(1)),
+		ProductFilter: &schema.ProductFilter{
+			VendorName: strPtr(vendor),
+			Service:    strPtr(service),
+			Region:     strPtr(region),
+		},
+	}
+	c.SetPrice(decimal.NewFromFloat(monthlyCost))
+
+	return c
+}
+
+func TestApplyDiscounts(t *testing.T) {
+	discounts := []*config.Discount{
+		{Name: "EC2", Vendor: "aws", Service: "AmazonEC2", Percent: 30},
+		{Name: "EDP", Vendor: "aws", Percent: 12},
+	}
+
+	instance := &schema.Resource{
+		Name: "aws_instance.web",
+		CostComponents: []*schema.CostComponent{
+			testCostComponent("Instance usage", "aws", "AmazonEC2", "us-east-1", 100),
+		},
+		SubResources: []*schema.Resource{
+			{
+				Name: "root_block_device",
+				CostComponents: []*schema.CostComponent{
+					testCostComponent("Storage", "aws", "AmazonEC2", "us-east-1", 10),
+				},
+			},
+		},
+	}
+
+	bucket := &schema.Resource{
+		Name: "aws_s3_bucket.bucket",
+		CostComponents: []*schema.CostComponent{
+			testCostComponent("Storage", "aws", "AmazonS3", "us-east-1", 50),
+		},
+	}
+
+	disk := &schema.Resource{
+		Name: "google_compute_disk.disk",
+		CostComponents: []*schema.CostComponent{
+			testCostComponent("Storage", "gcp", "Compute Engine", "us-central1", 20),
+		},
+	}
+
+	project := &schema.Project{Resources: []*schema.Resource{instance, bucket, disk}}
+	schema.CalculateCosts(project)
+
+	ApplyDiscounts(discounts, project)
+
+	require.Len(t, instance.CostComponents, 2)
+	assert.Equal(t, "Discount (EC2, 30%)", instance.CostComponents[1].Name)
+	assert.Equal(t, "-33", instance.CostComponents[1].MonthlyCost.String())
+	assert.Equal(t, "77", instance.MonthlyCost.String())
+
+	require.Len(t, bucket.CostComponents, 2)
+	assert.Equal(t, "Discount (EDP, 12%)", bucket.CostComponents[1].Name)
+	assert.Equal(t, "44", bucket.MonthlyCost.String())
+
+	assert.Len(t, disk.CostComponents, 1)
+	assert.Equal(t, "20", disk.MonthlyCost.String())
+}
+
+func TestApplyDiscountsRegion(t *testing.T) {
+	discounts := []*config.Discount{
+		{Vendor: "azure", Region: "westeurope", Percent: 10},
+	}
+
+	matching := &schema.Resource{
+		Name: "azurerm_linux_virtual_machine.weu",
+		CostComponents: []*schema.CostComponent{
+			testCostComponent("Instance usage", "azure", "Virtual Machines", "westeurope", 200),
+		},
+	}
+
+	other := &schema.Resource{
+		Name: "azurerm_linux_virtual_machine.eus",
+		CostComponents: []*schema.CostComponent{
+			testCostComponent("Instance usage", "azure", "Virtual Machines", "eastus", 200),
+		},
+	}
+
+	project := &schema.Project{Resources: []*schema.Resource{matching, other}}
+	schema.CalculateCosts(project)
+
+	ApplyDiscounts(discounts, project)
+
+	require.Len(t, matching.CostComponents, 2)
+	assert.Equal(t, "Discount (10%)", matching.CostComponents[1].Name)
+	assert.Equal(t, "180", matching.MonthlyCost.String())
+
+	assert.Len(t, other.CostComponents, 1)
+}
@@ -0,0 +1,284 @@
+package prices
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/infracost/infracost/internal/schema"
+)
+
+// freeTierAllowance is a monthly quantity of a cloud provider's usage that's free,
+// either always or for the first months after the account was created.
+type freeTierAllowance struct {
+	name          string
+	vendor        string
+	service       string
+	productFamily string
+	regions       []string
+	attributes    map[string][]string
+	// excludeAttributes are attribute values that contain any of the strings
+	excludeAttributes map[string][]string
+	onDemandOnly      bool
+	// quantity is in the units of the prices, e.g. requests not 1M requests
+	quantity decimal.Decimal
+	// months is how long the allowance lasts after the account was created, 0
+	// means it's always free
+	months int
+}
+
+var freeTierAllowances = []freeTierAllowance{
+	{
+		name:          "750 EC2 t2.micro/t3.micro Linux hours",
+		vendor:        "aws",
+		service:       "AmazonEC2",
+		productFamily: "Compute Instance",
+		attributes: map[string][]string{
+			"instanceType":    {"t2.micro", "t3.micro"},
+			"operatingSystem": {"Linux"},
+		},
+		onDemandOnly: true,
+		quantity:     decimal.NewFromInt(750),
+		months:       12,
+	},
+	{
+		name:          "30 GB of EBS storage",
+		vendor:        "aws",
+		service:       "AmazonEC2",
+		productFamily: "Storage",
+		attributes: map[string][]string{
+			"volumeApiName": {"gp2", "gp3", "standard"},
+		},
+		quantity: decimal.NewFromInt(30),
+		months:   12,
+	},
+	{
+		name:          "1M Lambda requests",
+		vendor:        "aws",
+		service:       "AWSLambda",
+		productFamily: "Serverless",
+		attributes: map[string][]string{
+			"group": {"AWS-Lambda-Requests"},
+		},
+		quantity: decimal.NewFromInt(1000000),
+	},
+	{
+		name:          "400,000 Lambda GB-seconds",
+		vendor:        "aws",
+		service:       "AWSLambda",
+		productFamily: "Serverless",
+		attributes: map[string][]string{
+			"group": {"AWS-Lambda-Duration"},
+		},
+		quantity: decimal.NewFromInt(400000),
+	},
+	{
+		name:          "1 e2-micro instance",
+		vendor:        "gcp",
+		service:       "Compute Engine",
+		productFamily: "Compute Instance",
+		regions:       []string{"us-west1", "us-central1", "us-east1"},
+		attributes: map[string][]string{
+			"machineType": {"e2-micro"},
+		},
+		onDemandOnly: true,
+		quantity:     decimal.NewFromInt(730),
+	},
+	{
+		name:          "30 GB of standard persistent disk",
+		vendor:        "gcp",
+		service:       "Compute Engine",
+		productFamily: "Storage",
+		regions:       []string{"us-west1", "us-central1", "us-east1"},
+		attributes: map[string][]string{
+			"description": {"Storage PD Capacity"},
+		},
+		quantity: decimal.NewFromInt(30),
+	},
+	{
+		name:          "750 B1s Linux VM hours",
+		vendor:        "azure",
+		service:       "Virtual Machines",
+		productFamily: "Compute",
+		attributes: map[string][]string{
+			"armSkuName": {"Standard_B1s"},
+		},
+		excludeAttributes: map[string][]string{
+			"productName": {"Windows"},
+		},
+		quantity: decimal.NewFromInt(750),
+		months:   12,
+	},
+}
+
+// ApplyFreeTier adds a free tier cost component to each resource that uses a
+// free tier allowance, then recalculates the resource costs. Allowances are shared
+// by the resources of the project, so they're used up in the order of the resources.
+// Allowances that only last for the first months of an account are only applied
+// when the account was created within those months, so they're skipped when
+// accountCreated is zero. It must be called after the costs of the project have
+// been calculated.
+func ApplyFreeTier(enabled bool, accountCreated time.Time, project *schema.Project) {
+	if !enabled {
+		return
+	}
+
+	allowances := activeFreeTierAllowances(accountCreated, time.Now())
+
+	// The past and planned resources are separate states so they each get the full allowances
+	applyFreeTierAllowances(allowances, project.PastResources)
+	applyFreeTierAllowances(allowances, project.Resources)
+}
+
+func activeFreeTierAllowances(accountCreated time.Time, now time.Time) []freeTierAllowance {
+	active := make([]freeTierAllowance, 0, len(freeTierAllowances))
+
+	for _, a := range freeTierAllowances {
+		if a.months > 0 && (accountCreated.IsZero() || !now.Before(accountCreated.AddDate(0, a.months, 0))) {
+			continue
+		}
+
+		active = append(active, a)
+	}
+
+	return active
+}
+
+func applyFreeTierAllowances(allowances []freeTierAllowance, resources []*schema.Resource) {
+	remaining := make([]decimal.Decimal, len(allowances))
+	for i, a := range allowances {
+		remaining[i] = a.quantity
+	}
+
+	for _, r := range resources {
+		if r.IsSkipped {
+			continue
+		}
+
+		amounts := make(map[int]decimal.Decimal)
+
+		withSubResources := append([]*schema.Resource{r}, r.FlattenedSubResources()...)
+		for _, res := range withSubResources {
+			for _, c := range res.CostComponents {
+				if c.MonthlyQuantity == nil || !c.MonthlyQuantity.IsPositive() || c.Price().IsZero() {
+					continue
+				}
+
+				for i, a := range allowances {
+					if !remaining[i].IsPositive() || !a.matches(c) {
+						continue
+					}
+
+					free := decimal.Min(remaining[i], *c.MonthlyQuantity)
+					remaining[i] = remaining[i].Sub(free)
+					amounts[i] = amounts[i].Add(free.Mul(c.Price()))
+
+					log.Debugf("Using %s of the %s free tier for %s %s", free.String(), a.name, res.Name, c.Name)
+					break
+				}
+			}
+		}
+
+		if len(amounts) == 0 {
+			continue
+		}
+
+		// Add the allowances in order so the output is stable
+		for i, a := range allowances {
+			amount, ok := amounts[i]
+			if !ok {
+				continue
+			}
+
+			c := &schema.CostComponent{
+				Name:            fmt.Sprintf("Free tier (%s)", a.name),
+				Unit:            "months",
+				UnitMultiplier:  decimal.NewFromInt(1),
+				MonthlyQuantity: decimalPtr(decimal.NewFromInt(1)),
+			}
+			c.SetPrice(amount.Neg())
+
+			r.CostComponents = append(r.CostComponents, c)
+		}
+
+		r.CalculateCosts()
+	}
+}
+
+func (a freeTierAllowance) matches(c *schema.CostComponent) bool {
+	f := c.ProductFilter
+	if f == nil ||
+		!matchesFilter(a.vendor, f.VendorName) ||
+		!matchesFilter(a.service, f.Service) ||
+		!matchesFilter(a.productFamily, f.ProductFamily) {
+		return false
+	}
+
+	if len(a.regions) > 0 && (f.Region == nil || !containsFold(a.regions, *f.Region)) {
+		return false
+	}
+
+	if a.onDemandOnly && c.PriceFilter != nil {
+		if c.PriceFilter.TermLength != nil {
+			return false
+		}
+
+		if c.PriceFilter.PurchaseOption != nil && !containsFold([]string{"on_demand", "OnDemand"}, *c.PriceFilter.PurchaseOption) {
+			return false
+		}
+	}
+
+	for key, values := range a.attributes {
+		v, ok := attributeFilterValue(f, key)
+		if !ok || !containsFold(values, v) {
+			return false
+		}
+	}
+
+	for key, values := range a.excludeAttributes {
+		v, _ := attributeFilterValue(f, key)
+		for _, exclude := range values {
+			if strings.Contains(strings.ToLower(v), strings.ToLower(exclude)) {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// attributeFilterValue returns the value an attribute filter matches. Regex
+// filters of a single value, e.g. /^t3\.micro$/i, are unwrapped to the value.
+func attributeFilterValue(f *schema.ProductFilter, key string) (string, bool) {
+	for _, a := range f.AttributeFilters {
+		if a.Key != key {
+			continue
+		}
+
+		if a.Value != nil {
+			return *a.Value, true
+		}
+
+		if a.ValueRegex != nil {
+			v := strings.TrimSuffix(strings.TrimPrefix(*a.ValueRegex, "/"), "/i")
+			v = strings.TrimSuffix(v, "/")
+			v = strings.TrimSuffix(strings.TrimPrefix(v, "^"), "$")
+			return strings.ReplaceAll(v, `\.`, "."), true
+		}
+	}
+
+	return "", false
+}
+
+func containsFold(values []string, v string) bool {
+	for _, s := range values {
+		if strings.EqualFold(s, v) {
+			return true
+		}
+	}
+
+	return false
+}
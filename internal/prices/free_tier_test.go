@@ -0,0 +1,111 @@
+package prices
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/infracost/infracost/internal/schema"
+)
+
+func freeTierInstance(name, instanceType string) *schema.Resource {
+	c := &schema.CostComponent{
+		Name:            "Instance usage",
+		Unit:            "hours",
+		UnitMultiplier:  decimal.NewFromInt(1),
+		MonthlyQuantity: decimalPtr(decimal.NewFromInt(730)),
+		ProductFilter: &schema.ProductFilter{
+			VendorName:    strPtr("aws"),
+			Service:       strPtr("AmazonEC2"),
+			ProductFamily: strPtr("Compute Instance"),
+			Region:        strPtr("us-east-1"),
+			AttributeFilters: []*schema.AttributeFilter{
+				{Key: "instanceType", Value: strPtr(instanceType)},
+				{Key: "operatingSystem", Value: strPtr("Linux")},
+			},
+		},
+		PriceFilter: &schema.PriceFilter{
+			PurchaseOption: strPtr("on_demand"),
+		},
+	}
+	c.SetPrice(decimal.NewFromFloat(0.01))
+
+	r := &schema.Resource{
+		Name:           name,
+		CostComponents: []*schema.CostComponent{c},
+	}
+	r.CalculateCosts()
+
+	return r
+}
+
+func TestApplyFreeTier(t *testing.T) {
+	first := freeTierInstance("aws_instance.first", "t3.micro")
+	second := freeTierInstance("aws_instance.second", "t3.micro")
+	large := freeTierInstance("aws_instance.large", "t3.large")
+
+	project := &schema.Project{
+		Resources: []*schema.Resource{first, second, large},
+	}
+
+	ApplyFreeTier(true, time.Now().AddDate(0, -1, 0), project)
+
+	// The first instance uses 730 of the 750 free hours, the second uses the rest
+	require.Len(t, first.CostComponents, 2)
+	assert.Equal(t, "Free tier (750 EC2 t2.micro/t3.micro Linux hours)", first.CostComponents[1].Name)
+	assert.Equal(t, "0", first.MonthlyCost.String())
+
+	require.Len(t, second.CostComponents, 2)
+	assert.Equal(t, "-0.2", second.CostComponents[1].Price().String())
+	assert.Equal(t, "7.1", second.MonthlyCost.String())
+
+	assert.Len(t, large.CostComponents, 1)
+}
+
+func TestApplyFreeTierExpired(t *testing.T) {
+	instance := freeTierInstance("aws_instance.web", "t3.micro")
+
+	project := &schema.Project{
+		Resources: []*schema.Resource{instance},
+	}
+
+	ApplyFreeTier(true, time.Now().AddDate(-2, 0, 0), project)
+	assert.Len(t, instance.CostComponents, 1)
+
+	// The 12 month offers need the account creation date
+	ApplyFreeTier(true, time.Time{}, project)
+	assert.Len(t, instance.CostComponents, 1)
+}
+
+func TestApplyFreeTierDisabled(t *testing.T) {
+	instance := freeTierInstance("aws_instance.web", "t3.micro")
+
+	ApplyFreeTier(false, time.Now(), &schema.Project{Resources: []*schema.Resource{instance}})
+	assert.Len(t, instance.CostComponents, 1)
+}
+
+func TestApplyFreeTierSkipsReserved(t *testing.T) {
+	instance := freeTierInstance("aws_instance.web", "t3.micro")
+	instance.CostComponents[0].PriceFilter.TermLength = strPtr("1yr")
+
+	ApplyFreeTier(true, time.Now(), &schema.Project{Resources: []*schema.Resource{instance}})
+	assert.Len(t, instance.CostComponents, 1)
+}
+
+func TestAttributeFilterValue(t *testing.T) {
+	f := &schema.ProductFilter{
+		AttributeFilters: []*schema.AttributeFilter{
+			{Key: "instanceType", ValueRegex: strPtr(`/^t3\.micro$/i`)},
+		},
+	}
+
+	v, ok := attributeFilterValue(f, "instanceType")
+	require.True(t, ok)
+	assert.Equal(t, "t3.micro", v)
+
+	_, ok = attributeFilterValue(f, "operatingSystem")
+	assert.False(t, ok)
+}
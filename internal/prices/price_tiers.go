@@ -0,0 +1,105 @@
+package prices
+
+import (
+	"encoding/json"
+
+	"github.com/shopspring/decimal"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/infracost/infracost/internal/schema"
+)
+
+// AddPriceTiers sets the price tier of each cost component that uses a graduated
+// price, e.g. the first 50 TB of S3 storage. Unless the price filter sets the end
+// of the tier, the tier ends where the next tier of the same price used by the
+// resource starts, so the last tier used has no end.
+func AddPriceTiers(project *schema.Project) {
+	for _, r := range project.AllResources() {
+		if r.IsSkipped {
+			continue
+		}
+
+		resources := append([]*schema.Resource{r}, r.FlattenedSubResources()...)
+		for _, res := range resources {
+			addResourcePriceTiers(res)
+		}
+	}
+}
+
+func addResourcePriceTiers(r *schema.Resource) {
+	starts := make(map[string][]decimal.Decimal)
+	keys := make(map[*schema.CostComponent]string)
+
+	for _, c := range r.CostComponents {
+		start, ok := startUsageAmount(c)
+		if !ok {
+			continue
+		}
+
+		key, err := priceTierKey(c)
+		if err != nil {
+			log.Debugf("Error getting price tier of %s %s: %s", r.Name, c.Name, err)
+			continue
+		}
+
+		keys[c] = key
+		starts[key] = append(starts[key], start)
+	}
+
+	for _, c := range r.CostComponents {
+		key, ok := keys[c]
+		if !ok {
+			continue
+		}
+
+		start, _ := startUsageAmount(c)
+		tier := &schema.PriceTier{StartUsageAmount: start}
+
+		if c.PriceFilter.EndUsageAmount != nil {
+			if end, err := decimal.NewFromString(*c.PriceFilter.EndUsageAmount); err == nil {
+				tier.EndUsageAmount = decimalPtr(end)
+				c.PriceTier = tier
+				continue
+			}
+		}
+
+		for _, s := range starts[key] {
+			if s.GreaterThan(start) && (tier.EndUsageAmount == nil || s.LessThan(*tier.EndUsageAmount)) {
+				tier.EndUsageAmount = decimalPtr(s)
+			}
+		}
+
+		c.PriceTier = tier
+	}
+}
+
+func startUsageAmount(c *schema.CostComponent) (decimal.Decimal, bool) {
+	if c.PriceFilter == nil || c.PriceFilter.StartUsageAmount == nil {
+		return decimal.Zero, false
+	}
+
+	d, err := decimal.NewFromString(*c.PriceFilter.StartUsageAmount)
+	if err != nil {
+		return decimal.Zero, false
+	}
+
+	return d, true
+}
+
+// priceTierKey identifies the graduated price of a cost component. Cost components
+// of the same price only differ by the usage amounts of their price filter.
+func priceTierKey(c *schema.CostComponent) (string, error) {
+	priceFilter := *c.PriceFilter
+	priceFilter.StartUsageAmount = nil
+	priceFilter.EndUsageAmount = nil
+
+	b, err := json.Marshal(struct {
+		ProductFilter *schema.ProductFilter
+		PriceFilter   schema.PriceFilter
+	}{c.ProductFilter, priceFilter})
+	if err != nil {
+		return "", err
+	}
+
+	return string(b), nil
+}
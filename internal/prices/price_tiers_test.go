@@ -0,0 +1,83 @@
+package prices
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/infracost/infracost/internal/schema"
+)
+
+func storageTier(name, startUsageAmount string) *schema.CostComponent {
+	return &schema.CostComponent{
+		Name:            name,
+		Unit:            "GB",
+		UnitMultiplier:  decimal.NewFromInt(1),
+		MonthlyQuantity: decimalPtr(decimal.NewFromInt(100)),
+		ProductFilter: &schema.ProductFilter{
+			VendorName: strPtr("aws"),
+			Service:    strPtr("AmazonS3"),
+			Region:     strPtr("us-east-1"),
+		},
+		PriceFilter: &schema.PriceFilter{
+			StartUsageAmount: strPtr(startUsageAmount),
+		},
+	}
+}
+
+func TestAddPriceTiers(t *testing.T) {
+	bucket := &schema.Resource{
+		Name: "aws_s3_bucket.bucket",
+		CostComponents: []*schema.CostComponent{
+			storageTier("Storage (first 50TB)", "0"),
+			storageTier("Storage (next 450TB)", "51200"),
+			storageTier("Storage (over 500TB)", "512000"),
+			{
+				Name:           "PUT requests",
+				UnitMultiplier: decimal.NewFromInt(1),
+				ProductFilter:  &schema.ProductFilter{VendorName: strPtr("aws")},
+			},
+		},
+	}
+
+	AddPriceTiers(&schema.Project{Resources: []*schema.Resource{bucket}})
+
+	first := bucket.CostComponents[0].PriceTier
+	require.NotNil(t, first)
+	assert.Equal(t, "0", first.StartUsageAmount.String())
+	require.NotNil(t, first.EndUsageAmount)
+	assert.Equal(t, "51200", first.EndUsageAmount.String())
+
+	next := bucket.CostComponents[1].PriceTier
+	require.NotNil(t, next)
+	require.NotNil(t, next.EndUsageAmount)
+	assert.Equal(t, "512000", next.EndUsageAmount.String())
+
+	last := bucket.CostComponents[2].PriceTier
+	require.NotNil(t, last)
+	assert.Equal(t, "512000", last.StartUsageAmount.String())
+	assert.Nil(t, last.EndUsageAmount)
+
+	assert.Nil(t, bucket.CostComponents[3].PriceTier)
+}
+
+func TestAddPriceTiersSeparatePrices(t *testing.T) {
+	standard := storageTier("Standard storage (first 50TB)", "0")
+	infrequent := storageTier("Infrequent access storage (over 50TB)", "51200")
+	infrequent.ProductFilter.ProductFamily = strPtr("Storage")
+
+	bucket := &schema.Resource{
+		Name:           "aws_s3_bucket.bucket",
+		CostComponents: []*schema.CostComponent{standard, infrequent},
+	}
+
+	AddPriceTiers(&schema.Project{Resources: []*schema.Resource{bucket}})
+
+	// The tiers are of different prices so neither ends where the other starts
+	require.NotNil(t, standard.PriceTier)
+	assert.Nil(t, standard.PriceTier.EndUsageAmount)
+	require.NotNil(t, infrequent.PriceTier)
+	assert.Nil(t, infrequent.PriceTier.EndUsageAmount)
+}
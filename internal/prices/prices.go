@@ -1,8 +1,6 @@
 package prices
 
 import (
-	"runtime"
-
 	"github.com/infracost/infracost/internal/apiclient"
 	"github.com/infracost/infracost/internal/config"
 	"github.com/infracost/infracost/internal/schema"
@@ -16,53 +14,57 @@ func PopulatePrices(ctx *config.RunContext, project *schema.Project) error {
 	resources := project.AllResources()
 
 	c := apiclient.NewPricingAPIClient(ctx)
+	if project.Currency != "" {
+		c = apiclient.NewPricingAPIClientForCurrency(ctx, project.Currency)
+	}
 
 	err := GetPricesConcurrent(c, resources)
 	if err != nil {
 		return err
 	}
+
+	applySpotFallbacks(c, ctx.Config.SpotDiscountPercent, resources)
+
+	applyAzurePriceSheet(c, ctx.Config.AzurePriceSheet, resources)
+
+	// Price book prices take precedence over the looked up prices
+	for _, r := range resources {
+		if !r.IsSkipped {
+			ctx.Config.PriceBook.Apply(r)
+		}
+	}
+
 	return nil
 }
 
-// GetPricesConcurrent gets the prices of all resources concurrently.
-// Concurrency level is calculated using the following formula:
-// max(min(4, numCPU * 4), 16)
+// GetPricesConcurrent gets the prices of all resources. The queries of all the
+// resources are coalesced and run in concurrent batches by the pricing client.
 func GetPricesConcurrent(c *apiclient.PricingAPIClient, resources []*schema.Resource) error {
-	// Set the number of workers
-	numWorkers := 4
-	numCPU := runtime.NumCPU()
-	if numCPU*4 > numWorkers {
-		numWorkers = numCPU * 4
-	}
-	if numWorkers > 16 {
-		numWorkers = 16
+	toPrice := make([]*schema.Resource, 0, len(resources))
+	for _, r := range resources {
+		if !r.IsSkipped {
+			toPrice = append(toPrice, r)
+		}
 	}
-	numJobs := len(resources)
-	jobs := make(chan *schema.Resource, numJobs)
-	resultErrors := make(chan error, numJobs)
-
-	// Fire up the workers
-	for i := 0; i < numWorkers; i++ {
-		go func(jobs <-chan *schema.Resource, resultErrors chan<- error) {
-			for r := range jobs {
-				err := GetPrices(c, r)
-				resultErrors <- err
-			}
-		}(jobs, resultErrors)
+
+	results, err := c.RunBatchedQueries(toPrice)
+	if err != nil {
+		return err
 	}
 
-	// Feed the workers the jobs of getting prices
-	for _, r := range resources {
-		jobs <- r
+	for _, r := range toPrice {
+		results = append(results, c.RunPriceListQueries(r)...)
 	}
 
-	// Get the result of the jobs
-	for i := 0; i < numJobs; i++ {
-		err := <-resultErrors
-		if err != nil {
-			return err
+	for _, r := range results {
+		if r.Err != nil {
+			setCostComponentPriceUnavailable(r.Resource, r.CostComponent)
+			continue
 		}
+
+		setCostComponentPrice(c.Currency, r.Resource, r.CostComponent, r.Result)
 	}
+
 	return nil
 }
 
@@ -76,18 +78,37 @@ func GetPrices(c *apiclient.PricingAPIClient, r *schema.Resource) error {
 		return err
 	}
 
+	results = append(results, c.RunPriceListQueries(r)...)
+
 	for _, r := range results {
+		if r.Err != nil {
+			setCostComponentPriceUnavailable(r.Resource, r.CostComponent)
+			continue
+		}
+
 		setCostComponentPrice(c.Currency, r.Resource, r.CostComponent, r.Result)
 	}
 
 	return nil
 }
 
+func setCostComponentPriceUnavailable(r *schema.Resource, c *schema.CostComponent) {
+	log.Debugf("Price for %s %s is unavailable, using 0.00", r.Name, c.Name)
+	c.PriceUnavailable = true
+	c.SetPrice(decimal.Zero)
+}
+
 func setCostComponentPrice(currency string, r *schema.Resource, c *schema.CostComponent, res gjson.Result) {
 	var p decimal.Decimal
 
 	products := res.Get("data.products").Array()
 	if len(products) == 0 {
+		if c.SpotFallback != nil {
+			log.Debugf("No spot products found for %s %s, using the on-demand price", r.Name, c.Name)
+			c.SetPrice(decimal.Zero)
+			return
+		}
+
 		if c.IgnoreIfMissingPrice {
 			log.Debugf("No products found for %s %s, ignoring since IgnoreIfMissingPrice is set.", r.Name, c.Name)
 			r.RemoveCostComponent(c)
@@ -95,15 +116,27 @@ func setCostComponentPrice(currency string, r *schema.Resource, c *schema.CostCo
 		}
 
 		log.Warnf("No products found for %s %s, using 0.00", r.Name, c.Name)
+		c.PriceIssue = schema.PriceIssueNoProducts
 		c.SetPrice(decimal.Zero)
 		return
 	}
 	if len(products) > 1 {
-		log.Warnf("Multiple products found for %s %s, using the first product", r.Name, c.Name)
+		if c.IgnoreMultipleProducts {
+			log.Debugf("Multiple products found for %s %s, using the first product since they're known to be ambiguous", r.Name, c.Name)
+		} else {
+			log.Warnf("Multiple products found for %s %s, using the first product", r.Name, c.Name)
+			c.PriceIssue = schema.PriceIssueMultipleProducts
+		}
 	}
 
 	prices := products[0].Get("prices").Array()
 	if len(prices) == 0 {
+		if c.SpotFallback != nil {
+			log.Debugf("No spot prices found for %s %s, using the on-demand price", r.Name, c.Name)
+			c.SetPrice(decimal.Zero)
+			return
+		}
+
 		if c.IgnoreIfMissingPrice {
 			log.Debugf("No prices found for %s %s, ignoring since IgnoreIfMissingPrice is set.", r.Name, c.Name)
 			r.RemoveCostComponent(c)
@@ -111,17 +144,20 @@ func setCostComponentPrice(currency string, r *schema.Resource, c *schema.CostCo
 		}
 
 		log.Warnf("No prices found for %s %s, using 0.00", r.Name, c.Name)
+		c.PriceIssue = schema.PriceIssueNoPrices
 		c.SetPrice(decimal.Zero)
 		return
 	}
 	if len(prices) > 1 {
 		log.Warnf("Multiple prices found for %s %s, using the first price", r.Name, c.Name)
+		c.PriceIssue = schema.PriceIssueMultiplePrices
 	}
 
 	var err error
 	p, err = decimal.NewFromString(prices[0].Get(currency).String())
 	if err != nil {
 		log.Warnf("Error converting price to '%v' (using 0.00)  '%v': %s", currency, prices[0].Get(currency).String(), err.Error())
+		c.PriceIssue = schema.PriceIssueInvalidPrice
 		c.SetPrice(decimal.Zero)
 		return
 	}
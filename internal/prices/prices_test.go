@@ -0,0 +1,27 @@
+package prices
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+
+	"github.com/infracost/infracost/internal/schema"
+)
+
+func TestSetCostComponentPriceMultipleProducts(t *testing.T) {
+	res := gjson.Parse(`{"data":{"products":[{"prices":[{"priceHash":"a","USD":"0.5"}]},{"prices":[{"priceHash":"b","USD":"0.5"}]}]}}`)
+	r := &schema.Resource{Name: "azurerm_mssql_database.db"}
+
+	c := &schema.CostComponent{Name: "Compute"}
+	setCostComponentPrice("USD", r, c, res)
+	assert.Equal(t, schema.PriceIssueMultipleProducts, c.PriceIssue)
+	assert.True(t, decimal.NewFromFloat(0.5).Equal(c.Price()))
+
+	// Known-ambiguous lookups are priced the same way without an issue.
+	c = &schema.CostComponent{Name: "Compute", IgnoreMultipleProducts: true}
+	setCostComponentPrice("USD", r, c, res)
+	assert.Empty(t, c.PriceIssue)
+	assert.True(t, decimal.NewFromFloat(0.5).Equal(c.Price()))
+}
@@ -0,0 +1,73 @@
+package prices
+
+import (
+	"github.com/shopspring/decimal"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/infracost/infracost/internal/apiclient"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+// applySpotFallbacks estimates the price of the spot and preemptible cost
+// components that have no spot price from the price of their on-demand
+// equivalent, less the spot discount.
+func applySpotFallbacks(c *apiclient.PricingAPIClient, spotDiscountPercent float64, resources []*schema.Resource) {
+	discount := decimal.NewFromFloat(spotDiscountPercent).Div(decimal.NewFromInt(100))
+
+	for _, r := range resources {
+		if r.IsSkipped {
+			continue
+		}
+
+		missing := missingSpotPrices(r)
+		if len(missing) == 0 {
+			continue
+		}
+
+		// Look up the on-demand prices in one batch
+		lookups := &schema.Resource{Name: r.Name}
+		for _, component := range missing {
+			lookups.CostComponents = append(lookups.CostComponents, component.SpotFallback)
+		}
+
+		results, err := c.RunQueries(lookups)
+		if err != nil {
+			log.Warnf("Error looking up on-demand prices for %s spot instances: %s", r.Name, err)
+			continue
+		}
+
+		onDemandPrices := make(map[*schema.CostComponent]decimal.Decimal)
+		for _, res := range results {
+			if p, ok := firstPrice(c.Currency, res.Result); ok {
+				onDemandPrices[res.CostComponent] = p
+			}
+		}
+
+		for _, component := range missing {
+			p, ok := onDemandPrices[component.SpotFallback]
+			if !ok {
+				log.Warnf("No spot or on-demand prices found for %s %s, using 0.00", r.Name, component.Name)
+				component.PriceIssue = schema.PriceIssueNoPrices
+				continue
+			}
+
+			log.Debugf("No spot prices found for %s %s, using the on-demand price less %s%%", r.Name, component.Name, decimal.NewFromFloat(spotDiscountPercent).String())
+			component.SetPrice(p.Mul(decimal.NewFromInt(1).Sub(discount)))
+		}
+	}
+}
+
+func missingSpotPrices(r *schema.Resource) []*schema.CostComponent {
+	var missing []*schema.CostComponent
+
+	resources := append([]*schema.Resource{r}, r.FlattenedSubResources()...)
+	for _, res := range resources {
+		for _, component := range res.CostComponents {
+			if component.SpotFallback != nil && !component.PriceUnavailable && component.Price().IsZero() {
+				missing = append(missing, component)
+			}
+		}
+	}
+
+	return missing
+}
@@ -0,0 +1,33 @@
+package prices
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/infracost/infracost/internal/schema"
+)
+
+func TestMissingSpotPrices(t *testing.T) {
+	onDemand := &schema.CostComponent{Name: "Instance usage (on-demand)"}
+
+	priced := &schema.CostComponent{Name: "Instance usage (spot, priced)", SpotFallback: onDemand}
+	priced.SetPrice(decimal.NewFromFloat(0.03))
+
+	missing := &schema.CostComponent{Name: "Instance usage (spot, missing)", SpotFallback: onDemand}
+	missing.SetPrice(decimal.Zero)
+
+	notSpot := &schema.CostComponent{Name: "Storage"}
+	notSpot.SetPrice(decimal.Zero)
+
+	r := &schema.Resource{
+		Name:           "instance",
+		CostComponents: []*schema.CostComponent{priced, notSpot},
+		SubResources: []*schema.Resource{
+			{Name: "node", CostComponents: []*schema.CostComponent{missing}},
+		},
+	}
+
+	assert.Equal(t, []*schema.CostComponent{missing}, missingSpotPrices(r))
+}
@@ -0,0 +1,142 @@
+// Package pricesnapshot stores the results of Cloud Pricing API queries in a
+// compressed file so that projects can be priced without network access.
+package pricesnapshot
+
+import (
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/tidwall/gjson"
+)
+
+// DefaultPath is the path the snapshot is saved to and loaded from when no path is given.
+const DefaultPath = "infracost-pricing-snapshot.json.gz"
+
+// formatVersion is incremented whenever the snapshot format changes in a way that
+// older versions of the CLI can't read.
+const formatVersion = "0.1"
+
+// ErrVersionMismatch is returned when loading a snapshot written with an
+// incompatible format version.
+var ErrVersionMismatch = errors.New("Pricing snapshot was created with an incompatible version of Infracost")
+
+// Snapshot is a set of Cloud Pricing API query results keyed by a hash of the query.
+// It's safe for concurrent use.
+type Snapshot struct {
+	Version   string                     `json:"version"`
+	Currency  string                     `json:"currency"`
+	CreatedAt time.Time                  `json:"createdAt"`
+	Results   map[string]json.RawMessage `json:"results"`
+
+	mu sync.RWMutex
+}
+
+// New returns an empty snapshot for prices in the given currency.
+func New(currency string) *Snapshot {
+	return &Snapshot{
+		Version:   formatVersion,
+		Currency:  currency,
+		CreatedAt: time.Now().UTC(),
+		Results:   map[string]json.RawMessage{},
+	}
+}
+
+// Load reads a gzip compressed snapshot from path.
+func Load(path string) (*Snapshot, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error opening pricing snapshot")
+	}
+	defer f.Close()
+
+	r, err := gzip.NewReader(f)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error decompressing pricing snapshot")
+	}
+	defer r.Close()
+
+	var s Snapshot
+	err = json.NewDecoder(r).Decode(&s)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error parsing pricing snapshot")
+	}
+
+	if s.Version != formatVersion {
+		return nil, ErrVersionMismatch
+	}
+
+	if s.Results == nil {
+		s.Results = map[string]json.RawMessage{}
+	}
+
+	return &s, nil
+}
+
+// Save writes the snapshot to path as gzip compressed JSON.
+func (s *Snapshot) Save(path string) error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	f, err := os.Create(path)
+	if err != nil {
+		return errors.Wrap(err, "Error creating pricing snapshot")
+	}
+	defer f.Close()
+
+	w := gzip.NewWriter(f)
+
+	err = json.NewEncoder(w).Encode(s)
+	if err != nil {
+		return errors.Wrap(err, "Error writing pricing snapshot")
+	}
+
+	return w.Close()
+}
+
+// Key returns the key a query is stored under. Queries are hashed from their
+// JSON representation so any value that can be marshalled can be used.
+func Key(query interface{}) (string, error) {
+	b, err := json.Marshal(query)
+	if err != nil {
+		return "", fmt.Errorf("Error generating pricing snapshot key: %w", err)
+	}
+
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// Add stores the result for the given key, replacing any existing result.
+func (s *Snapshot) Add(key string, result gjson.Result) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.Results[key] = json.RawMessage(result.Raw)
+}
+
+// Get returns the result stored for the given key and whether it was found.
+func (s *Snapshot) Get(key string) (gjson.Result, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	raw, ok := s.Results[key]
+	if !ok {
+		return gjson.Result{}, false
+	}
+
+	return gjson.ParseBytes(raw), true
+}
+
+// Len returns the number of results in the snapshot.
+func (s *Snapshot) Len() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return len(s.Results)
+}
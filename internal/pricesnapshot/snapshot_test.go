@@ -0,0 +1,61 @@
+package pricesnapshot
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+)
+
+func TestKey(t *testing.T) {
+	a, err := Key(map[string]string{"vendorName": "aws", "region": "us-east-1"})
+	require.NoError(t, err)
+
+	b, err := Key(map[string]string{"region": "us-east-1", "vendorName": "aws"})
+	require.NoError(t, err)
+
+	c, err := Key(map[string]string{"vendorName": "aws", "region": "eu-west-1"})
+	require.NoError(t, err)
+
+	assert.Equal(t, a, b)
+	assert.NotEqual(t, a, c)
+}
+
+func TestSaveAndLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "snapshot.json.gz")
+
+	s := New("EUR")
+	s.Add("key1", gjson.Parse(`{"data":{"products":[{"prices":[{"priceHash":"abc","EUR":"0.1"}]}]}}`))
+	require.NoError(t, s.Save(path))
+
+	loaded, err := Load(path)
+	require.NoError(t, err)
+
+	assert.Equal(t, "EUR", loaded.Currency)
+	assert.Equal(t, 1, loaded.Len())
+
+	res, ok := loaded.Get("key1")
+	assert.True(t, ok)
+	assert.Equal(t, "0.1", res.Get("data.products.0.prices.0.EUR").String())
+
+	_, ok = loaded.Get("key2")
+	assert.False(t, ok)
+}
+
+func TestLoadVersionMismatch(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "snapshot.json.gz")
+
+	s := New("USD")
+	s.Version = "0.0"
+	require.NoError(t, s.Save(path))
+
+	_, err := Load(path)
+	assert.ErrorIs(t, err, ErrVersionMismatch)
+}
+
+func TestLoadMissingFile(t *testing.T) {
+	_, err := Load(filepath.Join(t.TempDir(), "missing.json.gz"))
+	assert.Error(t, err)
+}
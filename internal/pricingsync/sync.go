@@ -0,0 +1,239 @@
+// Package pricingsync copies the products and prices used by a set of projects
+// from the Cloud Pricing API to the Postgres database of a self-hosted Cloud
+// Pricing API, so it only holds the services and regions that are needed.
+package pricingsync
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/tidwall/gjson"
+
+	"github.com/infracost/infracost/internal/schema"
+)
+
+// Scope is a service in a region whose products are synced. Region is empty for
+// services that are priced globally.
+type Scope struct {
+	VendorName string
+	Service    string
+	Region     string
+}
+
+func (s Scope) String() string {
+	if s.Region == "" {
+		return fmt.Sprintf("%s/%s", s.VendorName, s.Service)
+	}
+
+	return fmt.Sprintf("%s/%s/%s", s.VendorName, s.Service, s.Region)
+}
+
+// ProductFilter returns the filter that matches every product in the scope.
+func (s Scope) ProductFilter() *schema.ProductFilter {
+	f := &schema.ProductFilter{
+		VendorName: strPtr(s.VendorName),
+		Service:    strPtr(s.Service),
+	}
+
+	if s.Region != "" {
+		f.Region = strPtr(s.Region)
+	}
+
+	return f
+}
+
+// Scopes returns the services and regions of the cost components of the projects,
+// sorted so the generated SQL is stable.
+func Scopes(projects []*schema.Project) []Scope {
+	seen := map[Scope]bool{}
+
+	for _, p := range projects {
+		for _, r := range p.AllResources() {
+			addResourceScopes(seen, r)
+		}
+	}
+
+	scopes := make([]Scope, 0, len(seen))
+	for s := range seen {
+		scopes = append(scopes, s)
+	}
+
+	sort.Slice(scopes, func(i, j int) bool {
+		return scopes[i].String() < scopes[j].String()
+	})
+
+	return scopes
+}
+
+func addResourceScopes(seen map[Scope]bool, r *schema.Resource) {
+	for _, c := range r.CostComponents {
+		f := c.ProductFilter
+		if f == nil || f.VendorName == nil || f.Service == nil {
+			continue
+		}
+
+		s := Scope{VendorName: *f.VendorName, Service: *f.Service}
+		if f.Region != nil {
+			s.Region = *f.Region
+		}
+
+		seen[s] = true
+	}
+
+	for _, sub := range r.SubResources {
+		addResourceScopes(seen, sub)
+	}
+}
+
+// Product is a product of the Cloud Pricing API in the shape it's stored in the
+// products table, with its prices grouped by price hash.
+type Product struct {
+	ProductHash   string
+	Sku           string
+	VendorName    string
+	Region        string
+	Service       string
+	ProductFamily string
+	Attributes    map[string]string
+	Prices        map[string][]json.RawMessage
+}
+
+// ParseProducts reads the products from the result of a products query.
+func ParseProducts(result gjson.Result) ([]Product, error) {
+	if errs := result.Get("errors"); errs.Exists() {
+		return nil, fmt.Errorf("Error getting products: %s", errs.Raw)
+	}
+
+	var products []Product
+
+	for _, p := range result.Get("data.products").Array() {
+		product := Product{
+			ProductHash:   p.Get("productHash").String(),
+			Sku:           p.Get("sku").String(),
+			VendorName:    p.Get("vendorName").String(),
+			Region:        p.Get("region").String(),
+			Service:       p.Get("service").String(),
+			ProductFamily: p.Get("productFamily").String(),
+			Attributes:    map[string]string{},
+			Prices:        map[string][]json.RawMessage{},
+		}
+
+		if product.ProductHash == "" {
+			return nil, errors.New("Error getting products: product without a productHash")
+		}
+
+		for _, a := range p.Get("attributes").Array() {
+			product.Attributes[a.Get("key").String()] = a.Get("value").String()
+		}
+
+		for _, price := range p.Get("prices").Array() {
+			hash := price.Get("priceHash").String()
+			product.Prices[hash] = append(product.Prices[hash], json.RawMessage(price.Raw))
+		}
+
+		products = append(products, product)
+	}
+
+	return products, nil
+}
+
+// WriteSQL writes a script that replaces the products of the scopes in the
+// products table with the given products in a single transaction, so a failed
+// sync leaves the database unchanged.
+func WriteSQL(w io.Writer, scopes []Scope, products []Product) error {
+	var b bytes.Buffer
+
+	b.WriteString("BEGIN;\n")
+
+	for _, s := range scopes {
+		where := fmt.Sprintf(`"vendorName" = %s AND "service" = %s`, quote(s.VendorName), quote(s.Service))
+		if s.Region != "" {
+			where += fmt.Sprintf(` AND "region" = %s`, quote(s.Region))
+		}
+
+		fmt.Fprintf(&b, "DELETE FROM products WHERE %s;\n", where)
+	}
+
+	for _, p := range products {
+		attributes, err := json.Marshal(p.Attributes)
+		if err != nil {
+			return errors.Wrap(err, "Error marshaling product attributes")
+		}
+
+		prices, err := json.Marshal(p.Prices)
+		if err != nil {
+			return errors.Wrap(err, "Error marshaling product prices")
+		}
+
+		fmt.Fprintf(&b, `INSERT INTO products ("productHash", "sku", "vendorName", "region", "service", "productFamily", "attributes", "prices") VALUES (%s, %s, %s, %s, %s, %s, %s::jsonb, %s::jsonb) ON CONFLICT ("productHash") DO UPDATE SET "attributes" = EXCLUDED."attributes", "prices" = EXCLUDED."prices";`+"\n",
+			quote(p.ProductHash),
+			quote(p.Sku),
+			quote(p.VendorName),
+			quote(p.Region),
+			quote(p.Service),
+			quote(p.ProductFamily),
+			quote(string(attributes)),
+			quote(string(prices)),
+		)
+	}
+
+	b.WriteString("COMMIT;\n")
+
+	_, err := w.Write(b.Bytes())
+	return err
+}
+
+// Run runs the SQL script against the database at target, a postgres:// URL,
+// using psql since it's installed wherever the self-hosted Cloud Pricing API's
+// database is managed.
+func Run(target string, sql io.Reader) error {
+	exe, err := exec.LookPath("psql")
+	if err != nil {
+		return errors.New("psql is needed to sync prices to a Postgres database, install it or use --out-file to write the SQL to a file instead")
+	}
+
+	cmd := exec.Command(exe, "--quiet", "--no-psqlrc", "--set", "ON_ERROR_STOP=1", "--dbname", target)
+	cmd.Stdin = sql
+	cmd.Stdout = io.Discard
+
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("Error syncing prices to the database: %s", strings.TrimSpace(stderr.String()))
+	}
+
+	return nil
+}
+
+// IsPostgresURL returns true if target is a URL that psql can connect to.
+func IsPostgresURL(target string) bool {
+	return strings.HasPrefix(target, "postgres://") || strings.HasPrefix(target, "postgresql://")
+}
+
+// WriteFile writes the SQL script to path.
+func WriteFile(path string, scopes []Scope, products []Product) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return errors.Wrap(err, "Error creating SQL file")
+	}
+	defer f.Close()
+
+	return WriteSQL(f, scopes, products)
+}
+
+// quote returns s as a Postgres string literal.
+func quote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
+
+func strPtr(s string) *string {
+	return &s
+}
@@ -0,0 +1,305 @@
+package providers
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclparse"
+
+	"github.com/infracost/infracost/internal/providers/terraform"
+)
+
+// DefaultAutodetectMaxDepth is how many directories deep AutodetectProjects looks
+// for root modules by default.
+const DefaultAutodetectMaxDepth = 5
+
+// IgnoreFileName is the name of the file listing the directories that
+// AutodetectProjects skips, with the same syntax as a .gitignore.
+const IgnoreFileName = ".infracostignore"
+
+// AutodetectResult is a directory with Terraform files found by
+// AutodetectProjects, and why it was or wasn't included as a project.
+type AutodetectResult struct {
+	Path     string
+	Included bool
+	Reason   string
+}
+
+// ShouldAutodetect returns true if the path is a directory of a repo with
+// multiple Terraform projects, rather than a Terraform or Terragrunt project.
+func ShouldAutodetect(path string) bool {
+	info, err := os.Stat(path)
+	if err != nil || !info.IsDir() {
+		return false
+	}
+
+	return !isTerraformDir(path) && !isTerragruntNestedDir(path, DefaultAutodetectMaxDepth)
+}
+
+// AutodetectProjects returns the directories with Terraform files under root, up
+// to maxDepth directories deep. Root modules, the directories with a backend,
+// cloud or provider block, are included. Shared modules, which are used by root
+// modules and can't be run on their own, and the directories matching a pattern
+// of root's .infracostignore are skipped. Hidden directories, e.g. .terraform,
+// are skipped without being returned.
+func AutodetectProjects(root string, maxDepth int) ([]AutodetectResult, error) {
+	if maxDepth <= 0 {
+		maxDepth = DefaultAutodetectMaxDepth
+	}
+
+	ignore, err := loadIgnoreFile(filepath.Join(root, IgnoreFileName))
+	if err != nil {
+		return nil, err
+	}
+
+	var results []AutodetectResult
+
+	var walk func(dir string, rel string, depth int) error
+	walk = func(dir string, rel string, depth int) error {
+		if rel != "" && ignore.matches(rel) {
+			if hasTerraformFiles(dir) || hasTerraformSubdirs(dir) {
+				results = append(results, AutodetectResult{Path: dir, Reason: fmt.Sprintf("matches a pattern in %s", IgnoreFileName)})
+			}
+			return nil
+		}
+
+		if depth > maxDepth {
+			if hasTerraformFiles(dir) {
+				results = append(results, AutodetectResult{Path: dir, Reason: fmt.Sprintf("is deeper than the max depth of %d", maxDepth)})
+			}
+			return nil
+		}
+
+		if hasTerraformFiles(dir) {
+			results = append(results, detectRootModule(dir))
+		}
+
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			return fmt.Errorf("Error reading directory %s: %w", dir, err)
+		}
+
+		for _, entry := range entries {
+			if !entry.IsDir() || strings.HasPrefix(entry.Name(), ".") {
+				continue
+			}
+
+			err := walk(filepath.Join(dir, entry.Name()), filepath.ToSlash(filepath.Join(rel, entry.Name())), depth+1)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	err = walk(root, "", 0)
+	if err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].Path < results[j].Path
+	})
+
+	return results, nil
+}
+
+func hasTerraformFiles(dir string) bool {
+	return terraform.IsTerraformDir(dir)
+}
+
+// hasTerraformSubdirs returns true if any directory under dir has Terraform
+// files, so that ignoring a parent of projects is reported.
+func hasTerraformSubdirs(dir string) bool {
+	found := false
+	_ = filepath.WalkDir(dir, func(path string, d os.DirEntry, err error) error {
+		if err != nil || found {
+			return filepath.SkipDir
+		}
+
+		if d.IsDir() && path != dir && strings.HasPrefix(d.Name(), ".") {
+			return filepath.SkipDir
+		}
+
+		if !d.IsDir() && (strings.HasSuffix(path, ".tf") || strings.HasSuffix(path, ".tf.json")) {
+			found = true
+			return filepath.SkipDir
+		}
+
+		return nil
+	})
+
+	return found
+}
+
+var (
+	terraformFileSchema = &hcl.BodySchema{
+		Blocks: []hcl.BlockHeaderSchema{
+			{Type: "terraform"},
+			{Type: "provider", LabelNames: []string{"name"}},
+		},
+	}
+	terraformBlockSchema = &hcl.BodySchema{
+		Blocks: []hcl.BlockHeaderSchema{
+			{Type: "backend", LabelNames: []string{"type"}},
+			{Type: "cloud"},
+		},
+	}
+)
+
+// detectRootModule returns whether the directory is a root module, i.e. it has
+// a backend, cloud or provider block. A backend or cloud block is preferred as
+// the reason since shared modules sometimes configure providers too.
+func detectRootModule(dir string) AutodetectResult {
+	files, _ := filepath.Glob(filepath.Join(dir, "*.tf"))
+	jsonFiles, _ := filepath.Glob(filepath.Join(dir, "*.tf.json"))
+	files = append(files, jsonFiles...)
+	sort.Strings(files)
+
+	parser := hclparse.NewParser()
+	var provider string
+
+	for _, file := range files {
+		var f *hcl.File
+		var diags hcl.Diagnostics
+
+		if strings.HasSuffix(file, ".json") {
+			f, diags = parser.ParseJSONFile(file)
+		} else {
+			f, diags = parser.ParseHCLFile(file)
+		}
+
+		if diags.HasErrors() || f == nil {
+			return AutodetectResult{Path: dir, Reason: fmt.Sprintf("could not be parsed: %s", diags.Error())}
+		}
+
+		content, _, _ := f.Body.PartialContent(terraformFileSchema)
+		for _, block := range content.Blocks {
+			switch block.Type {
+			case "terraform":
+				tfContent, _, _ := block.Body.PartialContent(terraformBlockSchema)
+				for _, b := range tfContent.Blocks {
+					if b.Type == "cloud" {
+						return AutodetectResult{Path: dir, Included: true, Reason: "has a cloud block"}
+					}
+
+					return AutodetectResult{Path: dir, Included: true, Reason: fmt.Sprintf("has a backend %q block", b.Labels[0])}
+				}
+			case "provider":
+				if provider == "" {
+					provider = block.Labels[0]
+				}
+			}
+		}
+	}
+
+	if provider != "" {
+		return AutodetectResult{Path: dir, Included: true, Reason: fmt.Sprintf("has a provider %q block", provider)}
+	}
+
+	return AutodetectResult{Path: dir, Reason: "has no backend or provider blocks, so it's a shared module"}
+}
+
+// ignorePattern is a pattern of a .infracostignore, matched against the paths
+// of directories relative to the directory of the .infracostignore.
+type ignorePattern struct {
+	re     *regexp.Regexp
+	negate bool
+}
+
+type ignoreFile []ignorePattern
+
+// loadIgnoreFile loads the patterns of a .infracostignore. Like a .gitignore,
+// patterns with a / other than at the end are relative to its directory, while
+// others match at any depth, * and ? don't match /, ** matches any number of
+// directories and ! re-includes a directory an earlier pattern ignored.
+func loadIgnoreFile(path string) (ignoreFile, error) {
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("Error reading %s: %w", path, err)
+	}
+	defer f.Close()
+
+	var patterns ignoreFile
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		p := ignorePattern{}
+		if strings.HasPrefix(line, "!") {
+			p.negate = true
+			line = line[1:]
+		}
+
+		line = strings.TrimSuffix(line, "/")
+		anchored := strings.Contains(line, "/")
+		line = strings.TrimPrefix(line, "/")
+
+		expr := ignorePatternRegex(line)
+		if !anchored {
+			expr = "(.*/)?" + expr
+		}
+
+		p.re, err = regexp.Compile("^" + expr + "$")
+		if err != nil {
+			return nil, fmt.Errorf("Invalid pattern %s in %s: %w", line, path, err)
+		}
+
+		patterns = append(patterns, p)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("Error reading %s: %w", path, err)
+	}
+
+	return patterns, nil
+}
+
+func ignorePatternRegex(pattern string) string {
+	var b strings.Builder
+
+	for i := 0; i < len(pattern); i++ {
+		switch {
+		case strings.HasPrefix(pattern[i:], "**/"):
+			b.WriteString("(.*/)?")
+			i += 2
+		case strings.HasPrefix(pattern[i:], "**"):
+			b.WriteString(".*")
+			i++
+		case pattern[i] == '*':
+			b.WriteString("[^/]*")
+		case pattern[i] == '?':
+			b.WriteString("[^/]")
+		default:
+			b.WriteString(regexp.QuoteMeta(string(pattern[i])))
+		}
+	}
+
+	return b.String()
+}
+
+// matches returns true if the last pattern that matches the path ignores it.
+func (f ignoreFile) matches(rel string) bool {
+	ignored := false
+	for _, p := range f {
+		if p.re.MatchString(rel) {
+			ignored = !p.negate
+		}
+	}
+
+	return ignored
+}
@@ -0,0 +1,95 @@
+package providers
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeAutodetectFiles(t *testing.T, root string, files map[string]string) {
+	t.Helper()
+
+	for name, contents := range files {
+		path := filepath.Join(root, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), os.ModePerm))
+		require.NoError(t, os.WriteFile(path, []byte(contents), 0600))
+	}
+}
+
+func TestAutodetectProjects(t *testing.T) {
+	root := t.TempDir()
+	writeAutodetectFiles(t, root, map[string]string{
+		".infracostignore": "# Examples aren't deployed\nexamples/\nlegacy/*\n!legacy/keep\n",
+		"apps/api/main.tf": `terraform {
+  backend "s3" {}
+}
+`,
+		"apps/web/main.tf": `provider "aws" {
+  region = "us-east-1"
+}
+`,
+		"apps/cloud/main.tf": `terraform {
+  cloud {}
+}
+`,
+		"apps/json/main.tf.json":                  `{"provider": {"google": {"project": "p"}}}`,
+		"modules/vpc/main.tf":                     `resource "aws_vpc" "vpc" {}`,
+		"examples/vpc/main.tf":                    `provider "aws" {}`,
+		"legacy/old/main.tf":                      `provider "aws" {}`,
+		"legacy/keep/main.tf":                     `provider "aws" {}`,
+		"a/b/c/d/e/f/main.tf":                     `provider "aws" {}`,
+		"apps/api/.terraform/modules/vpc/main.tf": `provider "aws" {}`,
+	})
+
+	results, err := AutodetectProjects(root, 0)
+	require.NoError(t, err)
+
+	j := func(p string) string { return filepath.Join(root, p) }
+	assert.Equal(t, []AutodetectResult{
+		{Path: j("a/b/c/d/e/f"), Reason: "is deeper than the max depth of 5"},
+		{Path: j("apps/api"), Included: true, Reason: `has a backend "s3" block`},
+		{Path: j("apps/cloud"), Included: true, Reason: "has a cloud block"},
+		{Path: j("apps/json"), Included: true, Reason: `has a provider "google" block`},
+		{Path: j("apps/web"), Included: true, Reason: `has a provider "aws" block`},
+		{Path: j("examples"), Reason: "matches a pattern in .infracostignore"},
+		{Path: j("legacy/keep"), Included: true, Reason: `has a provider "aws" block`},
+		{Path: j("legacy/old"), Reason: "matches a pattern in .infracostignore"},
+		{Path: j("modules/vpc"), Reason: "has no backend or provider blocks, so it's a shared module"},
+	}, results)
+}
+
+func TestShouldAutodetect(t *testing.T) {
+	root := t.TempDir()
+	writeAutodetectFiles(t, root, map[string]string{
+		"repo/apps/api/main.tf":               `provider "aws" {}`,
+		"project/main.tf":                     `provider "aws" {}`,
+		"terragrunt/live/prod/terragrunt.hcl": ``,
+	})
+
+	assert.True(t, ShouldAutodetect(filepath.Join(root, "repo")))
+	assert.False(t, ShouldAutodetect(filepath.Join(root, "project")))
+	assert.False(t, ShouldAutodetect(filepath.Join(root, "project/main.tf")))
+	assert.False(t, ShouldAutodetect(filepath.Join(root, "terragrunt")))
+}
+
+func TestIgnoreFileMatches(t *testing.T) {
+	root := t.TempDir()
+	writeAutodetectFiles(t, root, map[string]string{
+		IgnoreFileName: "/top\nnested/dir\n**/sandbox\ntmp-*\n",
+	})
+
+	ignore, err := loadIgnoreFile(filepath.Join(root, IgnoreFileName))
+	require.NoError(t, err)
+
+	assert.True(t, ignore.matches("top"))
+	assert.False(t, ignore.matches("a/top"))
+	assert.True(t, ignore.matches("nested/dir"))
+	assert.False(t, ignore.matches("a/nested/dir"))
+	assert.True(t, ignore.matches("a/b/sandbox"))
+	assert.True(t, ignore.matches("sandbox"))
+	assert.True(t, ignore.matches("a/tmp-1"))
+	assert.False(t, ignore.matches("a/tmp"))
+}
@@ -65,19 +65,11 @@ func (p *Parser) parseTemplate(t *cloudformation.Template, usage map[string]*sch
 	var resources []*schema.Resource
 	resources = append(resources, baseResources...)
 
+	finder := schema.NewUsageDataFinder(usage)
+
 	for name, d := range t.Resources {
 		tags := map[string]string{} // TODO: Where do I get tags?
-		var usageData *schema.UsageData
-
-		if ud := usage[name]; ud != nil {
-			usageData = ud
-		} else if strings.HasSuffix(name, "]") {
-			lastIndexOfOpenBracket := strings.LastIndex(name, "[")
-
-			if arrayUsageData := usage[fmt.Sprintf("%s[*]", name[:lastIndexOfOpenBracket])]; arrayUsageData != nil {
-				usageData = arrayUsageData
-			}
-		}
+		usageData := finder.Find(name, d.AWSCloudFormationType())
 		resourceData := schema.NewCFResourceData(d.AWSCloudFormationType(), "aws", name, tags, d)
 
 		if r := p.createResource(resourceData, usageData); r != nil {
@@ -92,6 +84,11 @@ func (p *Parser) loadUsageFileResources(u map[string]*schema.UsageData) []*schem
 	resources := make([]*schema.Resource, 0)
 
 	for k, v := range u {
+		// Patterns set the usage of resources in the project rather than adding resources
+		if schema.IsUsagePattern(k) {
+			continue
+		}
+
 		for _, t := range GetUsageOnlyResources() {
 			if strings.HasPrefix(k, fmt.Sprintf("%s.", t)) {
 				d := schema.NewResourceData(t, "global", k, map[string]string{}, gjson.Result{})
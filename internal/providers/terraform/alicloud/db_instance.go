@@ -0,0 +1,27 @@
+package alicloud
+
+import (
+	"github.com/infracost/infracost/internal/resources/alicloud"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+func getDBInstanceRegistryItem() *schema.RegistryItem {
+	return &schema.RegistryItem{
+		Name:  "alicloud_db_instance",
+		RFunc: newDBInstance,
+	}
+}
+
+func newDBInstance(d *schema.ResourceData, u *schema.UsageData) *schema.Resource {
+	r := &alicloud.DBInstance{
+		Address:       d.Address,
+		Region:        d.Get("region").String(),
+		Engine:        d.Get("engine").String(),
+		InstanceClass: d.Get("instance_type").String(),
+		StorageType:   d.GetStringOrDefault("db_instance_storage_type", "local_ssd"),
+		StorageGB:     d.Get("instance_storage").Float(),
+	}
+	r.PopulateUsage(u)
+
+	return r.BuildResource()
+}
@@ -0,0 +1,46 @@
+package alicloud
+
+import (
+	"github.com/infracost/infracost/internal/resources/alicloud"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+func getInstanceRegistryItem() *schema.RegistryItem {
+	return &schema.RegistryItem{
+		Name:  "alicloud_instance",
+		RFunc: newInstance,
+	}
+}
+
+func newInstance(d *schema.ResourceData, u *schema.UsageData) *schema.Resource {
+	systemDiskSize := float64(40)
+	if !d.IsEmpty("system_disk_size") {
+		systemDiskSize = d.Get("system_disk_size").Float()
+	}
+
+	var dataDisks []alicloud.InstanceDisk
+	for _, disk := range d.Get("data_disks").Array() {
+		dataDisks = append(dataDisks, alicloud.InstanceDisk{
+			Category:         stringOrDefault(disk.Get("category").String(), "cloud_efficiency"),
+			PerformanceLevel: disk.Get("performance_level").String(),
+			SizeGB:           disk.Get("size").Float(),
+		})
+	}
+
+	r := &alicloud.Instance{
+		Address:      d.Address,
+		Region:       d.Get("region").String(),
+		InstanceType: d.Get("instance_type").String(),
+		SystemDisk: alicloud.InstanceDisk{
+			Category:         d.GetStringOrDefault("system_disk_category", "cloud_efficiency"),
+			PerformanceLevel: d.Get("system_disk_performance_level").String(),
+			SizeGB:           systemDiskSize,
+		},
+		DataDisks:               dataDisks,
+		InternetChargeType:      d.GetStringOrDefault("internet_charge_type", "PayByTraffic"),
+		InternetMaxBandwidthOut: d.Get("internet_max_bandwidth_out").Float(),
+	}
+	r.PopulateUsage(u)
+
+	return r.BuildResource()
+}
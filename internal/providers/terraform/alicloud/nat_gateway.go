@@ -0,0 +1,32 @@
+package alicloud
+
+import (
+	"github.com/infracost/infracost/internal/resources/alicloud"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+func getNATGatewayRegistryItem() *schema.RegistryItem {
+	return &schema.RegistryItem{
+		Name:  "alicloud_nat_gateway",
+		RFunc: newNATGateway,
+	}
+}
+
+func newNATGateway(d *schema.ResourceData, u *schema.UsageData) *schema.Resource {
+	// Enhanced NAT gateways are billed by capacity unit, legacy NAT gateways are
+	// billed by specification.
+	chargeType := "PayByLcu"
+	if d.Get("nat_type").String() == "Normal" || !d.IsEmpty("specification") {
+		chargeType = "PayBySpec"
+	}
+
+	r := &alicloud.NATGateway{
+		Address:            d.Address,
+		Region:             d.Get("region").String(),
+		InternetChargeType: d.GetStringOrDefault("internet_charge_type", chargeType),
+		Specification:      d.GetStringOrDefault("specification", "Small"),
+	}
+	r.PopulateUsage(u)
+
+	return r.BuildResource()
+}
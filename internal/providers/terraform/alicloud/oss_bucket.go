@@ -0,0 +1,25 @@
+package alicloud
+
+import (
+	"github.com/infracost/infracost/internal/resources/alicloud"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+func getOSSBucketRegistryItem() *schema.RegistryItem {
+	return &schema.RegistryItem{
+		Name:  "alicloud_oss_bucket",
+		RFunc: newOSSBucket,
+	}
+}
+
+func newOSSBucket(d *schema.ResourceData, u *schema.UsageData) *schema.Resource {
+	r := &alicloud.OSSBucket{
+		Address:        d.Address,
+		Region:         d.Get("region").String(),
+		StorageClass:   d.GetStringOrDefault("storage_class", "Standard"),
+		RedundancyType: d.GetStringOrDefault("redundancy_type", "LRS"),
+	}
+	r.PopulateUsage(u)
+
+	return r.BuildResource()
+}
@@ -0,0 +1,51 @@
+package alicloud
+
+import "github.com/infracost/infracost/internal/schema"
+
+// ResourceRegistry grouped alphabetically
+var ResourceRegistry []*schema.RegistryItem = []*schema.RegistryItem{
+	getDBInstanceRegistryItem(),
+	getInstanceRegistryItem(),
+	getNATGatewayRegistryItem(),
+	getOSSBucketRegistryItem(),
+	getSLBRegistryItem(),
+	getSLBLoadBalancerRegistryItem(),
+}
+
+// FreeResources grouped alphabetically
+var FreeResources = []string{
+	"alicloud_db_account",
+	"alicloud_db_account_privilege",
+	"alicloud_db_backup_policy",
+	"alicloud_db_connection",
+	"alicloud_db_database",
+	"alicloud_disk_attachment",
+	"alicloud_ecs_key_pair",
+	"alicloud_eip_association",
+	"alicloud_forward_entry",
+	"alicloud_key_pair",
+	"alicloud_oss_bucket_acl",
+	"alicloud_oss_bucket_object",
+	"alicloud_oss_bucket_policy",
+	"alicloud_ram_policy",
+	"alicloud_ram_role",
+	"alicloud_ram_role_policy_attachment",
+	"alicloud_ram_user",
+	"alicloud_route_entry",
+	"alicloud_route_table",
+	"alicloud_route_table_attachment",
+	"alicloud_security_group",
+	"alicloud_security_group_rule",
+	"alicloud_slb_acl",
+	"alicloud_slb_attachment",
+	"alicloud_slb_backend_server",
+	"alicloud_slb_listener",
+	"alicloud_slb_rule",
+	"alicloud_slb_server_certificate",
+	"alicloud_slb_server_group",
+	"alicloud_snat_entry",
+	"alicloud_vpc",
+	"alicloud_vswitch",
+}
+
+var UsageOnlyResources = []string{}
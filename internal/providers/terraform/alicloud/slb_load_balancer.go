@@ -0,0 +1,48 @@
+package alicloud
+
+import (
+	"github.com/infracost/infracost/internal/resources/alicloud"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+func getSLBLoadBalancerRegistryItem() *schema.RegistryItem {
+	return &schema.RegistryItem{
+		Name:  "alicloud_slb_load_balancer",
+		RFunc: newSLBLoadBalancer,
+	}
+}
+
+// getSLBRegistryItem registers the deprecated alicloud_slb resource which has the
+// same attributes as alicloud_slb_load_balancer.
+func getSLBRegistryItem() *schema.RegistryItem {
+	return &schema.RegistryItem{
+		Name:  "alicloud_slb",
+		RFunc: newSLBLoadBalancer,
+	}
+}
+
+func newSLBLoadBalancer(d *schema.ResourceData, u *schema.UsageData) *schema.Resource {
+	specification := d.Get("load_balancer_spec").String()
+	if specification == "" {
+		specification = d.Get("specification").String()
+	}
+
+	// Load balancers without a vswitch are internet facing by default.
+	addressType := "internet"
+	if !d.IsEmpty("vswitch_id") {
+		addressType = "intranet"
+	}
+
+	r := &alicloud.SLBLoadBalancer{
+		Address:            d.Address,
+		Region:             d.Get("region").String(),
+		Specification:      specification,
+		InstanceChargeType: d.GetStringOrDefault("instance_charge_type", "PayBySpec"),
+		AddressType:        d.GetStringOrDefault("address_type", addressType),
+		InternetChargeType: d.GetStringOrDefault("internet_charge_type", "PayByTraffic"),
+		BandwidthMbps:      d.Get("bandwidth").Float(),
+	}
+	r.PopulateUsage(u)
+
+	return r.BuildResource()
+}
@@ -0,0 +1,39 @@
+terraform {
+  required_providers {
+    alicloud = {
+      source = "aliyun/alicloud"
+    }
+  }
+}
+
+provider "alicloud" {
+  region     = "cn-hangzhou"
+  access_key = "mock_access_key"
+  secret_key = "mock_secret_key"
+}
+
+resource "alicloud_db_instance" "mysql" {
+  engine           = "MySQL"
+  engine_version   = "8.0"
+  instance_type    = "mysql.n2.medium.1"
+  instance_storage = 100
+  vswitch_id       = "vsw-fake"
+}
+
+resource "alicloud_db_instance" "mysql_high_availability" {
+  engine                   = "MySQL"
+  engine_version           = "8.0"
+  instance_type            = "mysql.n4.large.2c"
+  instance_storage         = 500
+  db_instance_storage_type = "cloud_essd"
+  vswitch_id               = "vsw-fake"
+}
+
+resource "alicloud_db_instance" "postgres" {
+  engine                   = "PostgreSQL"
+  engine_version           = "14.0"
+  instance_type            = "pg.n2.large.1"
+  instance_storage         = 200
+  db_instance_storage_type = "cloud_essd2"
+  vswitch_id               = "vsw-fake"
+}
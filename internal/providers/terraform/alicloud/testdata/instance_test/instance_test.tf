@@ -0,0 +1,67 @@
+terraform {
+  required_providers {
+    alicloud = {
+      source = "aliyun/alicloud"
+    }
+  }
+}
+
+provider "alicloud" {
+  region     = "cn-hangzhou"
+  access_key = "mock_access_key"
+  secret_key = "mock_secret_key"
+}
+
+resource "alicloud_instance" "default" {
+  instance_type     = "ecs.g6.large"
+  image_id          = "ubuntu_22_04_x64_20G_alibase_20230208.vhd"
+  security_groups   = ["sg-fake"]
+  vswitch_id        = "vsw-fake"
+  availability_zone = "cn-hangzhou-i"
+}
+
+resource "alicloud_instance" "essd_with_data_disks" {
+  instance_type                 = "ecs.c7.xlarge"
+  image_id                      = "ubuntu_22_04_x64_20G_alibase_20230208.vhd"
+  security_groups               = ["sg-fake"]
+  vswitch_id                    = "vsw-fake"
+  system_disk_category          = "cloud_essd"
+  system_disk_performance_level = "PL0"
+  system_disk_size              = 100
+
+  data_disks {
+    category = "cloud_essd"
+    size     = 500
+  }
+
+  data_disks {
+    category          = "cloud_essd"
+    performance_level = "PL2"
+    size              = 1000
+  }
+}
+
+resource "alicloud_instance" "pay_by_traffic" {
+  instance_type              = "ecs.r7.large"
+  image_id                   = "ubuntu_22_04_x64_20G_alibase_20230208.vhd"
+  security_groups            = ["sg-fake"]
+  vswitch_id                 = "vsw-fake"
+  internet_max_bandwidth_out = 100
+}
+
+resource "alicloud_instance" "pay_by_traffic_with_usage" {
+  instance_type              = "ecs.r7.large"
+  image_id                   = "ubuntu_22_04_x64_20G_alibase_20230208.vhd"
+  security_groups            = ["sg-fake"]
+  vswitch_id                 = "vsw-fake"
+  internet_max_bandwidth_out = 100
+}
+
+resource "alicloud_instance" "pay_by_bandwidth" {
+  instance_type              = "ecs.c6.large"
+  image_id                   = "ubuntu_22_04_x64_20G_alibase_20230208.vhd"
+  security_groups            = ["sg-fake"]
+  vswitch_id                 = "vsw-fake"
+  internet_charge_type       = "PayByBandwidth"
+  internet_max_bandwidth_out = 10
+}
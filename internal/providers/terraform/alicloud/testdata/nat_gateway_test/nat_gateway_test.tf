@@ -0,0 +1,36 @@
+terraform {
+  required_providers {
+    alicloud = {
+      source = "aliyun/alicloud"
+    }
+  }
+}
+
+provider "alicloud" {
+  region     = "cn-hangzhou"
+  access_key = "mock_access_key"
+  secret_key = "mock_secret_key"
+}
+
+resource "alicloud_nat_gateway" "enhanced" {
+  vpc_id           = "vpc-fake"
+  nat_gateway_name = "enhanced"
+  nat_type         = "Enhanced"
+  payment_type     = "PayAsYouGo"
+  vswitch_id       = "vsw-fake"
+}
+
+resource "alicloud_nat_gateway" "enhanced_with_usage" {
+  vpc_id           = "vpc-fake"
+  nat_gateway_name = "enhanced_with_usage"
+  nat_type         = "Enhanced"
+  payment_type     = "PayAsYouGo"
+  vswitch_id       = "vsw-fake"
+}
+
+resource "alicloud_nat_gateway" "legacy" {
+  vpc_id        = "vpc-fake"
+  name          = "legacy"
+  nat_type      = "Normal"
+  specification = "Middle"
+}
@@ -0,0 +1,37 @@
+terraform {
+  required_providers {
+    alicloud = {
+      source = "aliyun/alicloud"
+    }
+  }
+}
+
+provider "alicloud" {
+  region     = "cn-hangzhou"
+  access_key = "mock_access_key"
+  secret_key = "mock_secret_key"
+}
+
+resource "alicloud_oss_bucket" "standard" {
+  bucket = "standard"
+}
+
+resource "alicloud_oss_bucket" "standard_zrs_with_usage" {
+  bucket          = "standard-zrs"
+  redundancy_type = "ZRS"
+}
+
+resource "alicloud_oss_bucket" "infrequent_access_with_usage" {
+  bucket        = "infrequent-access"
+  storage_class = "IA"
+}
+
+resource "alicloud_oss_bucket" "archive" {
+  bucket        = "archive"
+  storage_class = "Archive"
+}
+
+resource "alicloud_oss_bucket" "cold_archive_with_usage" {
+  bucket        = "cold-archive"
+  storage_class = "ColdArchive"
+}
@@ -0,0 +1,53 @@
+terraform {
+  required_providers {
+    alicloud = {
+      source = "aliyun/alicloud"
+    }
+  }
+}
+
+provider "alicloud" {
+  region     = "cn-hangzhou"
+  access_key = "mock_access_key"
+  secret_key = "mock_secret_key"
+}
+
+resource "alicloud_slb_load_balancer" "internet" {
+  load_balancer_name = "internet"
+  load_balancer_spec = "slb.s2.small"
+  address_type       = "internet"
+}
+
+resource "alicloud_slb_load_balancer" "internet_with_usage" {
+  load_balancer_name = "internet_with_usage"
+  load_balancer_spec = "slb.s3.medium"
+  address_type       = "internet"
+}
+
+resource "alicloud_slb_load_balancer" "intranet" {
+  load_balancer_name = "intranet"
+  load_balancer_spec = "slb.s1.small"
+  address_type       = "intranet"
+  vswitch_id         = "vsw-fake"
+}
+
+resource "alicloud_slb_load_balancer" "pay_by_bandwidth" {
+  load_balancer_name   = "pay_by_bandwidth"
+  load_balancer_spec   = "slb.s2.medium"
+  address_type         = "internet"
+  internet_charge_type = "PayByBandwidth"
+  bandwidth            = 20
+}
+
+resource "alicloud_slb_load_balancer" "pay_by_clcu" {
+  load_balancer_name   = "pay_by_clcu"
+  address_type         = "intranet"
+  vswitch_id           = "vsw-fake"
+  instance_charge_type = "PayByCLCU"
+}
+
+resource "alicloud_slb" "legacy" {
+  name          = "legacy"
+  specification = "slb.s2.small"
+  vswitch_id    = "vsw-fake"
+}
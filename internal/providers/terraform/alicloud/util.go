@@ -0,0 +1,9 @@
+package alicloud
+
+func stringOrDefault(s, def string) string {
+	if s == "" {
+		return def
+	}
+
+	return s
+}
@@ -2,6 +2,7 @@ package aws
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/infracost/infracost/internal/resources/aws"
 	"github.com/infracost/infracost/internal/schema"
@@ -27,7 +28,7 @@ func NewInstance(d *schema.ResourceData, u *schema.UsageData) *schema.Resource {
 	region := d.Get("region").String()
 
 	purchaseOption := "on_demand"
-	if d.Get("spot_price").String() != "" {
+	if d.Get("spot_price").String() != "" || strings.ToLower(d.Get("instance_market_options.0.market_type").String()) == "spot" {
 		purchaseOption = "spot"
 	}
 
@@ -43,6 +44,12 @@ func NewInstance(d *schema.ResourceData, u *schema.UsageData) *schema.Resource {
 		CPUCredits:       d.Get("credit_specification.0.cpu_credits").String(),
 	}
 
+	// Only the IDs of instances that exist can be used to look up their
+	// utilization, e.g. not the placeholder IDs of HCL parsed resources
+	if id := d.Get("id").String(); strings.HasPrefix(id, "i-") {
+		a.ID = id
+	}
+
 	a.RootBlockDevice = &aws.EBSVolume{
 		Address: "root_block_device",
 		Region:  region,
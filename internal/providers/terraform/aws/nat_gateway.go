@@ -1,6 +1,8 @@
 package aws
 
 import (
+	"strings"
+
 	"github.com/infracost/infracost/internal/resources/aws"
 	"github.com/infracost/infracost/internal/schema"
 )
@@ -19,6 +21,13 @@ func NewNATGateway(d *schema.ResourceData, u *schema.UsageData) *schema.Resource
 		Address: d.Address,
 		Region:  region,
 	}
+
+	// Only the IDs of NAT gateways that exist can be used to look up their usage,
+	// e.g. not the placeholder IDs of HCL parsed resources
+	if id := d.Get("id").String(); strings.HasPrefix(id, "nat-") {
+		a.ID = id
+	}
+
 	a.PopulateUsage(u)
 
 	return a.BuildResource()
@@ -0,0 +1,28 @@
+package azure
+
+import (
+	"github.com/infracost/infracost/internal/resources/azure"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+func getAzureRMCognitiveAccountRegistryItem() *schema.RegistryItem {
+	return &schema.RegistryItem{
+		Name:  "azurerm_cognitive_account",
+		RFunc: newCognitiveAccount,
+		ReferenceAttributes: []string{
+			"resource_group_name",
+		},
+	}
+}
+
+func newCognitiveAccount(d *schema.ResourceData, u *schema.UsageData) *schema.Resource {
+	r := &azure.CognitiveAccount{
+		Address: d.Address,
+		Region:  lookupRegion(d, []string{"resource_group_name"}),
+		Kind:    d.Get("kind").String(),
+		SKUName: d.Get("sku_name").String(),
+	}
+	r.PopulateUsage(u)
+
+	return r.BuildResource()
+}
@@ -0,0 +1,49 @@
+package azure
+
+import (
+	"github.com/infracost/infracost/internal/resources/azure"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+func getAzureRMCognitiveDeploymentRegistryItem() *schema.RegistryItem {
+	return &schema.RegistryItem{
+		Name:  "azurerm_cognitive_deployment",
+		RFunc: newCognitiveDeployment,
+		ReferenceAttributes: []string{
+			"cognitive_account_id",
+		},
+	}
+}
+
+func newCognitiveDeployment(d *schema.ResourceData, u *schema.UsageData) *schema.Resource {
+	region := lookupRegion(d, []string{"cognitive_account_id"})
+
+	skuName := "Standard"
+	capacity := int64(1)
+
+	// The scale block was replaced by sku in v3.x of the azurerm provider but we
+	// still support it for older configurations.
+	if !d.IsEmpty("sku") {
+		skuName = d.Get("sku.0.name").String()
+		if !d.IsEmpty("sku.0.capacity") {
+			capacity = d.Get("sku.0.capacity").Int()
+		}
+	} else if !d.IsEmpty("scale") {
+		skuName = d.Get("scale.0.type").String()
+		if !d.IsEmpty("scale.0.capacity") {
+			capacity = d.Get("scale.0.capacity").Int()
+		}
+	}
+
+	r := &azure.CognitiveDeployment{
+		Address:      d.Address,
+		Region:       region,
+		SKUName:      skuName,
+		Capacity:     capacity,
+		ModelName:    d.Get("model.0.name").String(),
+		ModelVersion: d.Get("model.0.version").String(),
+	}
+	r.PopulateUsage(u)
+
+	return r.BuildResource()
+}
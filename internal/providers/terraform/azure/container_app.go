@@ -0,0 +1,52 @@
+package azure
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/infracost/infracost/internal/resources/azure"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+func getAzureRMContainerAppRegistryItem() *schema.RegistryItem {
+	return &schema.RegistryItem{
+		Name:  "azurerm_container_app",
+		RFunc: newContainerApp,
+		ReferenceAttributes: []string{
+			"container_app_environment_id",
+			"resource_group_name",
+		},
+	}
+}
+
+func newContainerApp(d *schema.ResourceData, u *schema.UsageData) *schema.Resource {
+	region := lookupRegion(d, []string{"container_app_environment_id", "resource_group_name"})
+
+	var cpu, memory float64
+	for _, c := range d.Get("template.0.container").Array() {
+		cpu += c.Get("cpu").Float()
+		memory += parseContainerAppMemory(c.Get("memory").String())
+	}
+
+	r := &azure.ContainerApp{
+		Address:             d.Address,
+		Region:              region,
+		WorkloadProfileName: d.Get("workload_profile_name").String(),
+		MinReplicas:         d.Get("template.0.min_replicas").Int(),
+		CPU:                 cpu,
+		MemoryGiB:           memory,
+	}
+	r.PopulateUsage(u)
+
+	return r.BuildResource()
+}
+
+// parseContainerAppMemory converts memory values such as "0.5Gi" to GiB.
+func parseContainerAppMemory(s string) float64 {
+	v, err := strconv.ParseFloat(strings.TrimSuffix(strings.TrimSpace(s), "Gi"), 64)
+	if err != nil {
+		return 0
+	}
+
+	return v
+}
@@ -0,0 +1,36 @@
+package azure
+
+import (
+	"github.com/infracost/infracost/internal/resources/azure"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+func getAzureRMContainerAppEnvironmentRegistryItem() *schema.RegistryItem {
+	return &schema.RegistryItem{
+		Name:  "azurerm_container_app_environment",
+		RFunc: newContainerAppEnvironment,
+		ReferenceAttributes: []string{
+			"resource_group_name",
+		},
+	}
+}
+
+func newContainerAppEnvironment(d *schema.ResourceData, u *schema.UsageData) *schema.Resource {
+	var profiles []azure.ContainerAppWorkloadProfile
+	for _, p := range d.Get("workload_profile").Array() {
+		profiles = append(profiles, azure.ContainerAppWorkloadProfile{
+			Name:          p.Get("name").String(),
+			Type:          p.Get("workload_profile_type").String(),
+			InstanceCount: p.Get("minimum_count").Int(),
+		})
+	}
+
+	r := &azure.ContainerAppEnvironment{
+		Address:          d.Address,
+		Region:           lookupRegion(d, []string{"resource_group_name"}),
+		WorkloadProfiles: profiles,
+	}
+	r.PopulateUsage(u)
+
+	return r.BuildResource()
+}
@@ -1,10 +1,13 @@
 package azure
 
 import (
+	"context"
 	"fmt"
+	"math"
 	"strings"
 
 	"github.com/infracost/infracost/internal/schema"
+	azureusage "github.com/infracost/infracost/internal/usage/azure"
 	"github.com/shopspring/decimal"
 	log "github.com/sirupsen/logrus"
 	"github.com/tidwall/gjson"
@@ -86,10 +89,23 @@ func NewAzureRMAppFunction(d *schema.ResourceData, u *schema.UsageData) *schema.
 		}
 	}
 
+	var estimate schema.EstimateFunc
+	if id := d.Get("id").String(); azureusage.IsResourceID(id) {
+		estimate = func(ctx context.Context, values map[string]interface{}) error {
+			executions, err := azureusage.FunctionAppGetExecutions(ctx, id)
+			if err != nil {
+				return err
+			}
+			values["monthly_executions"] = int64(math.Round(executions))
+			return nil
+		}
+	}
+
 	if len(costComponents) > 1 {
 		return &schema.Resource{
 			Name:           d.Address,
 			CostComponents: costComponents,
+			EstimateUsage:  estimate,
 		}
 	}
 	log.Warnf("Skipping resource %s. Could not find a way to get its cost components from the resource or usage file.", d.Address)
@@ -40,7 +40,11 @@ func aksClusterNodePool(name, region string, n gjson.Result, nodeCount decimal.D
 		Name: name,
 	}
 	instanceType := n.Get("vm_size").String()
-	costComponents = append(costComponents, linuxVirtualMachineCostComponent(region, instanceType))
+	instanceUsage := linuxVirtualMachineCostComponent(region, instanceType)
+	if isSpotPriority(n.Get("priority").String()) {
+		spotVirtualMachineCostComponent(instanceUsage, u)
+	}
+	costComponents = append(costComponents, instanceUsage)
 	mainResource.CostComponents = costComponents
 	schema.MultiplyQuantities(mainResource, nodeCount)
 
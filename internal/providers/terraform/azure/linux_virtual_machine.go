@@ -15,7 +15,7 @@ func GetAzureRMLinuxVirtualMachineRegistryItem() *schema.RegistryItem {
 		RFunc: NewAzureRMLinuxVirtualMachine,
 		Notes: []string{
 			"Non-standard images such as RHEL are not supported.",
-			"Low priority, Spot and Reserved instances are not supported.",
+			"Low priority and Reserved instances are not supported.",
 		},
 	}
 }
@@ -25,7 +25,12 @@ func NewAzureRMLinuxVirtualMachine(d *schema.ResourceData, u *schema.UsageData)
 
 	instanceType := d.Get("size").String()
 
-	costComponents := []*schema.CostComponent{linuxVirtualMachineCostComponent(region, instanceType)}
+	instanceUsage := linuxVirtualMachineCostComponent(region, instanceType)
+	if isSpotPriority(d.Get("priority").String()) {
+		spotVirtualMachineCostComponent(instanceUsage, u)
+	}
+
+	costComponents := []*schema.CostComponent{instanceUsage}
 
 	if d.Get("additional_capabilities.0.ultra_ssd_enabled").Bool() {
 		costComponents = append(costComponents, ultraSSDReservationCostComponent(region))
@@ -18,7 +18,12 @@ func NewAzureRMLinuxVirtualMachineScaleSet(d *schema.ResourceData, u *schema.Usa
 
 	instanceType := d.Get("sku").String()
 
-	costComponents := []*schema.CostComponent{linuxVirtualMachineCostComponent(region, instanceType)}
+	instanceUsage := linuxVirtualMachineCostComponent(region, instanceType)
+	if isSpotPriority(d.Get("priority").String()) {
+		spotVirtualMachineCostComponent(instanceUsage, u)
+	}
+
+	costComponents := []*schema.CostComponent{instanceUsage}
 	subResources := make([]*schema.Resource, 0)
 
 	if d.Get("additional_capabilities.0.ultra_ssd_enabled").Bool() {
@@ -24,6 +24,10 @@ var ResourceRegistry []*schema.RegistryItem = []*schema.RegistryItem{
 	GetAzureRMAutomationJobScheduleRegistryItem(),
 	GetAzureRMBastionHostRegistryItem(),
 	GetAzureRMCDNEndpointRegistryItem(),
+	getAzureRMCognitiveAccountRegistryItem(),
+	getAzureRMCognitiveDeploymentRegistryItem(),
+	getAzureRMContainerAppRegistryItem(),
+	getAzureRMContainerAppEnvironmentRegistryItem(),
 	GetAzureRMContainerRegistryRegistryItem(),
 	GetAzureRMCosmosdbCassandraKeyspaceRegistryItem(),
 	GetAzureRMCosmosdbCassandraTableRegistryItem(),
@@ -0,0 +1,46 @@
+package azure
+
+import (
+	"strings"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/infracost/infracost/internal/schema"
+)
+
+// isSpotPriority returns true if the priority of a virtual machine, scale set or
+// node pool is Spot.
+func isSpotPriority(priority string) bool {
+	return strings.EqualFold(priority, "Spot")
+}
+
+// spotVirtualMachineCostComponent changes a virtual machine instance usage cost
+// component to use the Spot price. Spot instances only run until they're evicted,
+// so the hourly quantity is adjusted by the spot_uptime_percent usage.
+func spotVirtualMachineCostComponent(c *schema.CostComponent, u *schema.UsageData) {
+	onDemand := *c
+	c.SpotFallback = &onDemand
+
+	if strings.Contains(c.Name, "pay as you go") {
+		c.Name = strings.Replace(c.Name, "pay as you go", "spot", 1)
+	} else {
+		c.Name = strings.Replace(c.Name, "Instance usage (", "Instance usage (spot, ", 1)
+	}
+
+	attributeFilters := make([]*schema.AttributeFilter, 0, len(c.ProductFilter.AttributeFilters))
+	for _, f := range c.ProductFilter.AttributeFilters {
+		if f.Key == "skuName" {
+			f = &schema.AttributeFilter{Key: "skuName", ValueRegex: strPtr("/ Spot$/i")}
+		}
+		attributeFilters = append(attributeFilters, f)
+	}
+
+	productFilter := *c.ProductFilter
+	productFilter.AttributeFilters = attributeFilters
+	c.ProductFilter = &productFilter
+
+	if u != nil && u.GetFloat("spot_uptime_percent") != nil && c.HourlyQuantity != nil {
+		uptime := decimal.NewFromFloat(*u.GetFloat("spot_uptime_percent")).Div(decimal.NewFromInt(100))
+		c.HourlyQuantity = decimalPtr(c.HourlyQuantity.Mul(uptime))
+	}
+}
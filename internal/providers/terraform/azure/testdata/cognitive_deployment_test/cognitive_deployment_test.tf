@@ -0,0 +1,93 @@
+provider "azurerm" {
+  skip_provider_registration = true
+  features {}
+}
+
+resource "azurerm_resource_group" "example" {
+  name     = "example-resources"
+  location = "East US"
+}
+
+resource "azurerm_cognitive_account" "example" {
+  name                = "example-openai"
+  location            = azurerm_resource_group.example.location
+  resource_group_name = azurerm_resource_group.example.name
+  kind                = "OpenAI"
+  sku_name            = "S0"
+}
+
+resource "azurerm_cognitive_deployment" "standard" {
+  name                 = "gpt-4o-standard"
+  cognitive_account_id = azurerm_cognitive_account.example.id
+
+  model {
+    format  = "OpenAI"
+    name    = "gpt-4o"
+    version = "2024-05-13"
+  }
+
+  sku {
+    name = "Standard"
+  }
+}
+
+resource "azurerm_cognitive_deployment" "standard_with_usage" {
+  name                 = "gpt-4o-standard-usage"
+  cognitive_account_id = azurerm_cognitive_account.example.id
+
+  model {
+    format  = "OpenAI"
+    name    = "gpt-4o"
+    version = "2024-05-13"
+  }
+
+  sku {
+    name = "Standard"
+  }
+}
+
+resource "azurerm_cognitive_deployment" "global_standard_with_usage" {
+  name                 = "gpt-4o-global-usage"
+  cognitive_account_id = azurerm_cognitive_account.example.id
+
+  model {
+    format  = "OpenAI"
+    name    = "gpt-4o"
+    version = "2024-05-13"
+  }
+
+  sku {
+    name = "GlobalStandard"
+  }
+}
+
+resource "azurerm_cognitive_deployment" "scale_block" {
+  name                 = "gpt-35-turbo-scale"
+  cognitive_account_id = azurerm_cognitive_account.example.id
+
+  model {
+    format  = "OpenAI"
+    name    = "gpt-35-turbo"
+    version = "0613"
+  }
+
+  scale {
+    type = "Standard"
+  }
+}
+
+resource "azurerm_cognitive_deployment" "provisioned" {
+  name                 = "gpt-4o-ptu"
+  cognitive_account_id = azurerm_cognitive_account.example.id
+
+  model {
+    format  = "OpenAI"
+    name    = "gpt-4o"
+    version = "2024-05-13"
+  }
+
+  sku {
+    name     = "ProvisionedManaged"
+    capacity = 50
+  }
+}
@@ -0,0 +1,40 @@
+provider "azurerm" {
+  skip_provider_registration = true
+  features {}
+}
+
+resource "azurerm_resource_group" "example" {
+  name     = "example-resources"
+  location = "West Europe"
+}
+
+resource "azurerm_container_app_environment" "consumption" {
+  name                = "consumption"
+  location            = azurerm_resource_group.example.location
+  resource_group_name = azurerm_resource_group.example.name
+}
+
+resource "azurerm_container_app_environment" "dedicated" {
+  name                = "dedicated"
+  location            = azurerm_resource_group.example.location
+  resource_group_name = azurerm_resource_group.example.name
+
+  workload_profile {
+    name                  = "Consumption"
+    workload_profile_type = "Consumption"
+  }
+
+  workload_profile {
+    name                  = "general"
+    workload_profile_type = "D4"
+    minimum_count         = 2
+    maximum_count         = 5
+  }
+
+  workload_profile {
+    name                  = "memory"
+    workload_profile_type = "E16"
+    minimum_count         = 1
+    maximum_count         = 3
+  }
+}
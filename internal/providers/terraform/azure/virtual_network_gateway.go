@@ -1,13 +1,16 @@
 package azure
 
 import (
+	"context"
 	"fmt"
+	"math"
 
 	"github.com/shopspring/decimal"
 	"github.com/tidwall/gjson"
 
 	"github.com/infracost/infracost/internal/schema"
 	"github.com/infracost/infracost/internal/usage"
+	azureusage "github.com/infracost/infracost/internal/usage/azure"
 )
 
 func GetAzureRMVirtualNetworkGatewayRegistryItem() *schema.RegistryItem {
@@ -59,9 +62,22 @@ func NewAzureRMVirtualNetworkGateway(d *schema.ResourceData, u *schema.UsageData
 		costComponents = append(costComponents, vpnGatewayDataTransfers(zone, sku, dataTransfers))
 	}
 
+	var estimate schema.EstimateFunc
+	if id := d.Get("id").String(); azureusage.IsResourceID(id) {
+		estimate = func(ctx context.Context, values map[string]interface{}) error {
+			bytes, err := azureusage.VirtualNetworkGatewayGetEgressBytes(ctx, id)
+			if err != nil {
+				return err
+			}
+			values["monthly_data_transfer_gb"] = int64(math.Round(bytes / 1000 / 1000 / 1000))
+			return nil
+		}
+	}
+
 	return &schema.Resource{
 		Name:           d.Address,
 		CostComponents: costComponents,
+		EstimateUsage:  estimate,
 	}
 }
 
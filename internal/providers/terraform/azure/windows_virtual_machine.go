@@ -13,7 +13,7 @@ func GetAzureRMWindowsVirtualMachineRegistryItem() *schema.RegistryItem {
 		Name:  "azurerm_windows_virtual_machine",
 		RFunc: NewAzureRMWindowsVirtualMachine,
 		Notes: []string{
-			"Low priority, Spot and Reserved instances are not supported.",
+			"Low priority and Reserved instances are not supported.",
 		},
 	}
 }
@@ -24,7 +24,12 @@ func NewAzureRMWindowsVirtualMachine(d *schema.ResourceData, u *schema.UsageData
 	instanceType := d.Get("size").String()
 	licenseType := d.Get("license_type").String()
 
-	costComponents := []*schema.CostComponent{windowsVirtualMachineCostComponent(region, instanceType, licenseType)}
+	instanceUsage := windowsVirtualMachineCostComponent(region, instanceType, licenseType)
+	if isSpotPriority(d.Get("priority").String()) {
+		spotVirtualMachineCostComponent(instanceUsage, u)
+	}
+
+	costComponents := []*schema.CostComponent{instanceUsage}
 
 	if d.Get("additional_capabilities.0.ultra_ssd_enabled").Bool() {
 		costComponents = append(costComponents, ultraSSDReservationCostComponent(region))
@@ -19,7 +19,12 @@ func NewAzureRMWindowsVirtualMachineScaleSet(d *schema.ResourceData, u *schema.U
 	instanceType := d.Get("sku").String()
 	licenseType := d.Get("license_type").String()
 
-	costComponents := []*schema.CostComponent{windowsVirtualMachineCostComponent(region, instanceType, licenseType)}
+	instanceUsage := windowsVirtualMachineCostComponent(region, instanceType, licenseType)
+	if isSpotPriority(d.Get("priority").String()) {
+		spotVirtualMachineCostComponent(instanceUsage, u)
+	}
+
+	costComponents := []*schema.CostComponent{instanceUsage}
 
 	if d.Get("additional_capabilities.0.ultra_ssd_enabled").Bool() {
 		costComponents = append(costComponents, ultraSSDReservationCostComponent(region))
@@ -0,0 +1,23 @@
+package cloudflare
+
+import (
+	"github.com/infracost/infracost/internal/resources/cloudflare"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+func getLoadBalancerRegistryItem() *schema.RegistryItem {
+	return &schema.RegistryItem{
+		Name:  "cloudflare_load_balancer",
+		RFunc: newLoadBalancer,
+	}
+}
+
+func newLoadBalancer(d *schema.ResourceData, u *schema.UsageData) *schema.Resource {
+	r := &cloudflare.LoadBalancer{
+		Address: d.Address,
+		Proxied: d.Get("proxied").Bool(),
+	}
+	r.PopulateUsage(u)
+
+	return r.BuildResource()
+}
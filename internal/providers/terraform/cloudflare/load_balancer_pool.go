@@ -0,0 +1,23 @@
+package cloudflare
+
+import (
+	"github.com/infracost/infracost/internal/resources/cloudflare"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+func getLoadBalancerPoolRegistryItem() *schema.RegistryItem {
+	return &schema.RegistryItem{
+		Name:  "cloudflare_load_balancer_pool",
+		RFunc: newLoadBalancerPool,
+	}
+}
+
+func newLoadBalancerPool(d *schema.ResourceData, u *schema.UsageData) *schema.Resource {
+	r := &cloudflare.LoadBalancerPool{
+		Address: d.Address,
+		Origins: int64(len(d.Get("origins").Array())),
+	}
+	r.PopulateUsage(u)
+
+	return r.BuildResource()
+}
@@ -0,0 +1,23 @@
+package cloudflare
+
+import (
+	"github.com/infracost/infracost/internal/resources/cloudflare"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+func getR2BucketRegistryItem() *schema.RegistryItem {
+	return &schema.RegistryItem{
+		Name:  "cloudflare_r2_bucket",
+		RFunc: newR2Bucket,
+	}
+}
+
+func newR2Bucket(d *schema.ResourceData, u *schema.UsageData) *schema.Resource {
+	r := &cloudflare.R2Bucket{
+		Address:      d.Address,
+		StorageClass: d.GetStringOrDefault("storage_class", "Standard"),
+	}
+	r.PopulateUsage(u)
+
+	return r.BuildResource()
+}
@@ -0,0 +1,30 @@
+package cloudflare
+
+import "github.com/infracost/infracost/internal/schema"
+
+// ResourceRegistry grouped alphabetically
+var ResourceRegistry []*schema.RegistryItem = []*schema.RegistryItem{
+	getLoadBalancerRegistryItem(),
+	getLoadBalancerPoolRegistryItem(),
+	getR2BucketRegistryItem(),
+	getWorkerScriptRegistryItem(),
+	getWorkersScriptRegistryItem(),
+}
+
+// FreeResources grouped alphabetically
+var FreeResources = []string{
+	"cloudflare_api_token",
+	"cloudflare_load_balancer_monitor",
+	"cloudflare_page_rule",
+	"cloudflare_record",
+	"cloudflare_ruleset",
+	"cloudflare_worker_cron_trigger",
+	"cloudflare_worker_domain",
+	"cloudflare_worker_route",
+	"cloudflare_workers_cron_trigger",
+	"cloudflare_workers_domain",
+	"cloudflare_workers_route",
+	"cloudflare_workers_secret",
+}
+
+var UsageOnlyResources = []string{}
@@ -0,0 +1,32 @@
+terraform {
+  required_providers {
+    cloudflare = {
+      source  = "cloudflare/cloudflare"
+      version = "~> 4.0"
+    }
+  }
+}
+
+provider "cloudflare" {
+  api_token = "mock_api_token_mock_api_token_mock_api"
+}
+
+resource "cloudflare_load_balancer_pool" "pool" {
+  account_id = "f037e56e89293a057740de681ac9abbe"
+  name       = "pool"
+
+  origins {
+    name    = "origin-1"
+    address = "192.0.2.1"
+  }
+
+  origins {
+    name    = "origin-2"
+    address = "192.0.2.2"
+  }
+
+  origins {
+    name    = "origin-3"
+    address = "192.0.2.3"
+  }
+}
@@ -0,0 +1,44 @@
+terraform {
+  required_providers {
+    cloudflare = {
+      source  = "cloudflare/cloudflare"
+      version = "~> 4.0"
+    }
+  }
+}
+
+provider "cloudflare" {
+  api_token = "mock_api_token_mock_api_token_mock_api"
+}
+
+resource "cloudflare_load_balancer_pool" "pool" {
+  account_id = "f037e56e89293a057740de681ac9abbe"
+  name       = "pool"
+
+  origins {
+    name    = "origin-1"
+    address = "192.0.2.1"
+  }
+}
+
+resource "cloudflare_load_balancer" "proxied" {
+  zone_id          = "0da42c8d2132a9ddaf714f9e7c920711"
+  name             = "proxied.example.com"
+  fallback_pool_id = cloudflare_load_balancer_pool.pool.id
+  default_pool_ids = [cloudflare_load_balancer_pool.pool.id]
+  proxied          = true
+}
+
+resource "cloudflare_load_balancer" "dns_only" {
+  zone_id          = "0da42c8d2132a9ddaf714f9e7c920711"
+  name             = "dns-only.example.com"
+  fallback_pool_id = cloudflare_load_balancer_pool.pool.id
+  default_pool_ids = [cloudflare_load_balancer_pool.pool.id]
+}
+
+resource "cloudflare_load_balancer" "dns_only_with_usage" {
+  zone_id          = "0da42c8d2132a9ddaf714f9e7c920711"
+  name             = "dns-only-with-usage.example.com"
+  fallback_pool_id = cloudflare_load_balancer_pool.pool.id
+  default_pool_ids = [cloudflare_load_balancer_pool.pool.id]
+}
@@ -0,0 +1,23 @@
+terraform {
+  required_providers {
+    cloudflare = {
+      source  = "cloudflare/cloudflare"
+      version = "~> 4.0"
+    }
+  }
+}
+
+provider "cloudflare" {
+  api_token = "mock_api_token_mock_api_token_mock_api"
+}
+
+resource "cloudflare_r2_bucket" "bucket" {
+  account_id = "f037e56e89293a057740de681ac9abbe"
+  name       = "bucket"
+}
+
+resource "cloudflare_r2_bucket" "bucket_with_usage" {
+  account_id = "f037e56e89293a057740de681ac9abbe"
+  name       = "bucket-with-usage"
+  location   = "WEUR"
+}
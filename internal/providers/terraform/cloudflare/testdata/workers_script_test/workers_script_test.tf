@@ -0,0 +1,32 @@
+terraform {
+  required_providers {
+    cloudflare = {
+      source  = "cloudflare/cloudflare"
+      version = "~> 4.0"
+    }
+  }
+}
+
+provider "cloudflare" {
+  api_token = "mock_api_token_mock_api_token_mock_api"
+}
+
+resource "cloudflare_workers_script" "script" {
+  account_id = "f037e56e89293a057740de681ac9abbe"
+  name       = "script"
+  content    = "export default { fetch() { return new Response('ok') } }"
+  module     = true
+}
+
+resource "cloudflare_workers_script" "script_with_usage" {
+  account_id = "f037e56e89293a057740de681ac9abbe"
+  name       = "script-with-usage"
+  content    = "export default { fetch() { return new Response('ok') } }"
+  module     = true
+}
+
+resource "cloudflare_worker_script" "legacy_with_usage" {
+  account_id = "f037e56e89293a057740de681ac9abbe"
+  name       = "legacy-with-usage"
+  content    = "addEventListener('fetch', e => e.respondWith(new Response('ok')))"
+}
@@ -0,0 +1,31 @@
+package cloudflare
+
+import (
+	"github.com/infracost/infracost/internal/resources/cloudflare"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+func getWorkersScriptRegistryItem() *schema.RegistryItem {
+	return &schema.RegistryItem{
+		Name:  "cloudflare_workers_script",
+		RFunc: newWorkersScript,
+	}
+}
+
+// getWorkerScriptRegistryItem registers the deprecated cloudflare_worker_script
+// resource which was renamed to cloudflare_workers_script.
+func getWorkerScriptRegistryItem() *schema.RegistryItem {
+	return &schema.RegistryItem{
+		Name:  "cloudflare_worker_script",
+		RFunc: newWorkersScript,
+	}
+}
+
+func newWorkersScript(d *schema.ResourceData, u *schema.UsageData) *schema.Resource {
+	r := &cloudflare.WorkersScript{
+		Address: d.Address,
+	}
+	r.PopulateUsage(u)
+
+	return r.BuildResource()
+}
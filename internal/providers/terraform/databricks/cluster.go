@@ -0,0 +1,46 @@
+package databricks
+
+import (
+	"strings"
+
+	"github.com/infracost/infracost/internal/resources/databricks"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+func getClusterRegistryItem() *schema.RegistryItem {
+	return &schema.RegistryItem{
+		Name:                "databricks_cluster",
+		RFunc:               newCluster,
+		ReferenceAttributes: []string{"instance_pool_id", "driver_instance_pool_id"},
+	}
+}
+
+func newCluster(d *schema.ResourceData, u *schema.UsageData) *schema.Resource {
+	// Clusters that use instance pools take their node types from the pools
+	nodeType := d.Get("node_type_id").String()
+	if pools := d.References("instance_pool_id"); len(pools) > 0 {
+		nodeType = pools[0].Get("node_type_id").String()
+	}
+
+	driverNodeType := d.Get("driver_node_type_id").String()
+	if pools := d.References("driver_instance_pool_id"); len(pools) > 0 {
+		driverNodeType = pools[0].Get("node_type_id").String()
+	}
+
+	cloud := databricks.NodeTypeCloud(nodeType)
+
+	r := &databricks.Cluster{
+		Address:        d.Address,
+		Cloud:          cloud,
+		Region:         nodeRegion(cloud, d.RawValues),
+		NodeType:       nodeType,
+		DriverNodeType: driverNodeType,
+		NumWorkers:     d.Get("num_workers").Int(),
+		Autoscale:      len(d.Get("autoscale").Array()) > 0,
+		MinWorkers:     d.Get("autoscale.0.min_workers").Int(),
+		Photon:         strings.EqualFold(d.Get("runtime_engine").String(), "PHOTON"),
+	}
+	r.PopulateUsage(u)
+
+	return r.BuildResource()
+}
@@ -0,0 +1,29 @@
+package databricks
+
+import (
+	"github.com/infracost/infracost/internal/resources/databricks"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+func getInstancePoolRegistryItem() *schema.RegistryItem {
+	return &schema.RegistryItem{
+		Name:  "databricks_instance_pool",
+		RFunc: newInstancePool,
+	}
+}
+
+func newInstancePool(d *schema.ResourceData, u *schema.UsageData) *schema.Resource {
+	nodeType := d.Get("node_type_id").String()
+	cloud := databricks.NodeTypeCloud(nodeType)
+
+	r := &databricks.InstancePool{
+		Address:          d.Address,
+		Cloud:            cloud,
+		Region:           nodeRegion(cloud, d.RawValues),
+		NodeType:         nodeType,
+		MinIdleInstances: d.Get("min_idle_instances").Int(),
+	}
+	r.PopulateUsage(u)
+
+	return r.BuildResource()
+}
@@ -0,0 +1,35 @@
+package databricks
+
+import "github.com/infracost/infracost/internal/schema"
+
+// ResourceRegistry grouped alphabetically
+var ResourceRegistry []*schema.RegistryItem = []*schema.RegistryItem{
+	getClusterRegistryItem(),
+	getInstancePoolRegistryItem(),
+	getSQLEndpointRegistryItem(),
+}
+
+// FreeResources grouped alphabetically
+var FreeResources = []string{
+	"databricks_cluster_policy",
+	"databricks_directory",
+	"databricks_git_credential",
+	"databricks_global_init_script",
+	"databricks_group",
+	"databricks_group_member",
+	"databricks_job",
+	"databricks_notebook",
+	"databricks_permissions",
+	"databricks_repo",
+	"databricks_secret",
+	"databricks_secret_acl",
+	"databricks_secret_scope",
+	"databricks_service_principal",
+	"databricks_sql_dashboard",
+	"databricks_sql_query",
+	"databricks_token",
+	"databricks_user",
+	"databricks_workspace_conf",
+}
+
+var UsageOnlyResources = []string{}
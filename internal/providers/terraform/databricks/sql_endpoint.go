@@ -0,0 +1,28 @@
+package databricks
+
+import (
+	"github.com/infracost/infracost/internal/resources/databricks"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+func getSQLEndpointRegistryItem() *schema.RegistryItem {
+	return &schema.RegistryItem{
+		Name:  "databricks_sql_endpoint",
+		RFunc: newSQLEndpoint,
+	}
+}
+
+func newSQLEndpoint(d *schema.ResourceData, u *schema.UsageData) *schema.Resource {
+	minClusters := d.GetInt64OrDefault("min_num_clusters", 1)
+
+	r := &databricks.SQLEndpoint{
+		Address:       d.Address,
+		ClusterSize:   d.Get("cluster_size").String(),
+		WarehouseType: d.Get("warehouse_type").String(),
+		Serverless:    d.Get("enable_serverless_compute").Bool(),
+		MinClusters:   minClusters,
+	}
+	r.PopulateUsage(u)
+
+	return r.BuildResource()
+}
@@ -0,0 +1,75 @@
+terraform {
+  required_providers {
+    databricks = {
+      source  = "databricks/databricks"
+      version = "~> 1.30"
+    }
+  }
+}
+
+provider "databricks" {
+  host  = "https://mock.cloud.databricks.com"
+  token = "mock_token"
+}
+
+resource "databricks_cluster" "fixed_size" {
+  cluster_name            = "fixed-size"
+  spark_version           = "13.3.x-scala2.12"
+  node_type_id            = "i3.xlarge"
+  num_workers             = 4
+  autotermination_minutes = 30
+
+  aws_attributes {
+    availability = "ON_DEMAND"
+    zone_id      = "us-west-2a"
+  }
+}
+
+resource "databricks_cluster" "autoscaling" {
+  cluster_name            = "autoscaling"
+  spark_version           = "13.3.x-scala2.12"
+  node_type_id            = "Standard_DS3_v2"
+  driver_node_type_id     = "Standard_DS4_v2"
+  autotermination_minutes = 30
+
+  autoscale {
+    min_workers = 2
+    max_workers = 8
+  }
+}
+
+resource "databricks_cluster" "photon" {
+  cluster_name            = "photon"
+  spark_version           = "13.3.x-scala2.12"
+  node_type_id            = "n2-standard-8"
+  num_workers             = 2
+  runtime_engine          = "PHOTON"
+  autotermination_minutes = 30
+
+  gcp_attributes {
+    zone_id = "europe-west1-b"
+  }
+}
+
+resource "databricks_instance_pool" "pool" {
+  instance_pool_name                    = "pool"
+  node_type_id                          = "m5.xlarge"
+  min_idle_instances                    = 0
+  idle_instance_autotermination_minutes = 10
+}
+
+resource "databricks_cluster" "from_pool" {
+  cluster_name            = "from-pool"
+  spark_version           = "13.3.x-scala2.12"
+  instance_pool_id        = databricks_instance_pool.pool.id
+  num_workers             = 2
+  autotermination_minutes = 30
+}
+
+resource "databricks_cluster" "without_usage" {
+  cluster_name            = "without-usage"
+  spark_version           = "13.3.x-scala2.12"
+  node_type_id            = "i3.xlarge"
+  num_workers             = 1
+  autotermination_minutes = 30
+}
@@ -0,0 +1,38 @@
+terraform {
+  required_providers {
+    databricks = {
+      source  = "databricks/databricks"
+      version = "~> 1.30"
+    }
+  }
+}
+
+provider "databricks" {
+  host  = "https://mock.cloud.databricks.com"
+  token = "mock_token"
+}
+
+resource "databricks_instance_pool" "idle" {
+  instance_pool_name                    = "idle"
+  node_type_id                          = "i3.xlarge"
+  min_idle_instances                    = 2
+  idle_instance_autotermination_minutes = 10
+
+  aws_attributes {
+    zone_id = "eu-west-1b"
+  }
+}
+
+resource "databricks_instance_pool" "with_usage" {
+  instance_pool_name                    = "with-usage"
+  node_type_id                          = "Standard_DS3_v2"
+  min_idle_instances                    = 0
+  idle_instance_autotermination_minutes = 10
+}
+
+resource "databricks_instance_pool" "no_idle" {
+  instance_pool_name                    = "no-idle"
+  node_type_id                          = "n1-standard-4"
+  min_idle_instances                    = 0
+  idle_instance_autotermination_minutes = 10
+}
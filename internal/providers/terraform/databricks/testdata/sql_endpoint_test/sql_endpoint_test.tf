@@ -0,0 +1,43 @@
+terraform {
+  required_providers {
+    databricks = {
+      source  = "databricks/databricks"
+      version = "~> 1.30"
+    }
+  }
+}
+
+provider "databricks" {
+  host  = "https://mock.cloud.databricks.com"
+  token = "mock_token"
+}
+
+resource "databricks_sql_endpoint" "classic" {
+  name             = "classic"
+  cluster_size     = "Small"
+  warehouse_type   = "CLASSIC"
+  max_num_clusters = 1
+  auto_stop_mins   = 30
+}
+
+resource "databricks_sql_endpoint" "pro" {
+  name             = "pro"
+  cluster_size     = "Medium"
+  warehouse_type   = "PRO"
+  min_num_clusters = 1
+  max_num_clusters = 4
+  auto_stop_mins   = 30
+}
+
+resource "databricks_sql_endpoint" "serverless" {
+  name                      = "serverless"
+  cluster_size              = "2X-Small"
+  warehouse_type            = "PRO"
+  enable_serverless_compute = true
+  auto_stop_mins            = 10
+}
+
+resource "databricks_sql_endpoint" "without_usage" {
+  name         = "without-usage"
+  cluster_size = "Large"
+}
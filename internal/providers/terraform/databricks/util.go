@@ -0,0 +1,33 @@
+package databricks
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/tidwall/gjson"
+
+	"github.com/infracost/infracost/internal/resources/databricks"
+)
+
+var (
+	awsZoneSuffixRegex = regexp.MustCompile(`^([a-z]+-[a-z]+-\d+)[a-z]$`)
+	gcpZoneSuffixRegex = regexp.MustCompile(`^([a-z]+-[a-z]+\d+)-[a-z]$`)
+)
+
+// nodeRegion returns the cloud region of the nodes from the zone set in the cloud
+// specific attributes of a cluster or instance pool. Azure doesn't expose a zone, so
+// an empty region is returned and the default region of the cloud is used.
+func nodeRegion(cloud string, d gjson.Result) string {
+	switch cloud {
+	case databricks.CloudAWS:
+		if m := awsZoneSuffixRegex.FindStringSubmatch(strings.ToLower(d.Get("aws_attributes.0.zone_id").String())); m != nil {
+			return m[1]
+		}
+	case databricks.CloudGCP:
+		if m := gcpZoneSuffixRegex.FindStringSubmatch(strings.ToLower(d.Get("gcp_attributes.0.zone_id").String())); m != nil {
+			return m[1]
+		}
+	}
+
+	return ""
+}
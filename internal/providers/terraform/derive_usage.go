@@ -0,0 +1,224 @@
+package terraform
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/tidwall/gjson"
+
+	"github.com/infracost/infracost/internal/schema"
+)
+
+// derivedUsage is a usage value inferred from the attributes of a resource, with
+// the reason it was inferred so it can be shown in the output.
+type derivedUsage struct {
+	key    string
+	value  interface{}
+	reason string
+}
+
+// usageDerivationFunc infers usage values from the attributes of a resource. It's
+// given the usage data from the usage file so it doesn't override the values the
+// user has set.
+type usageDerivationFunc func(d *schema.ResourceData, u *schema.UsageData) []derivedUsage
+
+// usageDerivations are the rules for inferring the usage of each resource type
+// from its attributes when deriving usage is enabled.
+var usageDerivations = map[string]usageDerivationFunc{
+	"aws_autoscaling_group":      deriveAutoscalingGroupUsage,
+	"aws_s3_bucket":              deriveS3BucketUsage,
+	"azurerm_eventhub_namespace": deriveEventHubsNamespaceUsage,
+}
+
+// deriveUsageData sets the usage values that can be inferred from the resources'
+// attributes and aren't set by the usage file, and records what they were
+// inferred from on the ResourceData.
+func (p *Parser) deriveUsageData(resData map[string]*schema.ResourceData) {
+	if !p.ctx.RunContext.Config.DeriveUsage {
+		return
+	}
+
+	for _, d := range resData {
+		derive, ok := usageDerivations[d.Type]
+		if !ok {
+			continue
+		}
+
+		derived := derive(d, d.UsageData)
+		if len(derived) == 0 {
+			continue
+		}
+
+		// Copy the usage data since it can be shared by the resources matching the
+		// same pattern or type
+		attributes := make(map[string]gjson.Result)
+		if d.UsageData != nil {
+			for k, v := range d.UsageData.Attributes {
+				attributes[k] = v
+			}
+		}
+
+		d.DerivedUsage = make(map[string]string, len(derived))
+		for _, du := range derived {
+			log.Debugf("Derived %s of %s from %s", du.key, d.Address, du.reason)
+
+			attributes[du.key] = schema.ParseAttributes(map[string]interface{}{du.key: du.value})[du.key]
+			d.DerivedUsage[du.key] = du.reason
+		}
+
+		d.UsageData = schema.NewUsageData(d.Address, attributes)
+	}
+}
+
+func usageIsSet(u *schema.UsageData, key string) bool {
+	return u != nil && u.Get(key).Type != gjson.Null
+}
+
+// deriveAutoscalingGroupUsage uses the midpoint of the group's min_size and
+// max_size as its number of instances when it has no desired_capacity, instead
+// of min_size, since the group scales between them.
+func deriveAutoscalingGroupUsage(d *schema.ResourceData, u *schema.UsageData) []derivedUsage {
+	if usageIsSet(u, "instances") || !d.IsEmpty("desired_capacity") {
+		return nil
+	}
+
+	minSize := d.Get("min_size").Int()
+	maxSize := d.Get("max_size").Int()
+	if maxSize <= minSize {
+		return nil
+	}
+
+	return []derivedUsage{{
+		key:    "instances",
+		value:  int64(math.Ceil(float64(minSize+maxSize) / 2)),
+		reason: fmt.Sprintf("midpoint of min_size %d and max_size %d", minSize, maxSize),
+	}}
+}
+
+// s3LifecycleStorageClassKeys are the usage keys of the storage classes objects
+// can be transitioned to whose usage is set by storage_gb.
+var s3LifecycleStorageClassKeys = map[string]string{
+	"STANDARD_IA":  "standard_infrequent_access",
+	"ONEZONE_IA":   "one_zone_infrequent_access",
+	"GLACIER":      "glacier_flexible_retrieval",
+	"DEEP_ARCHIVE": "glacier_deep_archive",
+}
+
+// deriveS3BucketUsage splits the standard storage_gb of the usage file across
+// the storage classes of the bucket's lifecycle rule, in proportion to how many
+// days objects spend in each class before they expire. It assumes objects are
+// added at a steady rate and the rule applies to all of them, so it's only used
+// when the rule has an expiration and the usage file doesn't set the storage of
+// the other classes.
+func deriveS3BucketUsage(d *schema.ResourceData, u *schema.UsageData) []derivedUsage {
+	if u == nil {
+		return nil
+	}
+
+	standard := u.Get("standard")
+	total := standard.Get("storage_gb")
+	if total.Type != gjson.Number || total.Float() <= 0 {
+		return nil
+	}
+
+	for _, key := range s3LifecycleStorageClassKeys {
+		if usageIsSet(u, key) {
+			return nil
+		}
+	}
+
+	for _, rule := range d.Get("lifecycle_rule").Array() {
+		if !rule.Get("enabled").Bool() || len(rule.Get("transition").Array()) == 0 {
+			continue
+		}
+
+		expirationDays := rule.Get("expiration.0.days").Int()
+		if expirationDays <= 0 {
+			return nil
+		}
+
+		type transition struct {
+			days int64
+			key  string
+		}
+
+		var transitions []transition
+		for _, t := range rule.Get("transition").Array() {
+			key, ok := s3LifecycleStorageClassKeys[t.Get("storage_class").String()]
+			if !ok {
+				return nil
+			}
+
+			if days := t.Get("days").Int(); days < expirationDays {
+				transitions = append(transitions, transition{days, key})
+			}
+		}
+
+		sort.Slice(transitions, func(i, j int) bool {
+			return transitions[i].days < transitions[j].days
+		})
+
+		reason := fmt.Sprintf("standard storage_gb %s split by the lifecycle rule's transitions and expiration after %d days", total.Raw, expirationDays)
+
+		standardUsage, ok := standard.Value().(map[string]interface{})
+		if !ok {
+			return nil
+		}
+
+		from := int64(0)
+		key := "standard"
+
+		var derived []derivedUsage
+		for i := 0; i <= len(transitions); i++ {
+			to := expirationDays
+			if i < len(transitions) {
+				to = transitions[i].days
+			}
+
+			storageGB := math.Round(total.Float()*float64(to-from)/float64(expirationDays)*100) / 100
+
+			if key == "standard" {
+				standardUsage["storage_gb"] = storageGB
+				derived = append(derived, derivedUsage{key: key, value: standardUsage, reason: reason})
+			} else {
+				derived = append(derived, derivedUsage{key: key, value: map[string]interface{}{"storage_gb": storageGB}, reason: reason})
+			}
+
+			if i < len(transitions) {
+				from = transitions[i].days
+				key = transitions[i].key
+			}
+		}
+
+		return derived
+	}
+
+	return nil
+}
+
+// deriveEventHubsNamespaceUsage uses the midpoint of the namespace's capacity and
+// maximum_throughput_units as its throughput units when auto-inflate is enabled,
+// since the namespace scales up between them.
+func deriveEventHubsNamespaceUsage(d *schema.ResourceData, u *schema.UsageData) []derivedUsage {
+	if usageIsSet(u, "throughput_or_capacity_units") || !d.Get("auto_inflate_enabled").Bool() {
+		return nil
+	}
+
+	capacity := d.Get("capacity").Int()
+	if capacity <= 0 {
+		capacity = 1
+	}
+
+	maxUnits := d.Get("maximum_throughput_units").Int()
+	if maxUnits <= capacity {
+		return nil
+	}
+
+	return []derivedUsage{{
+		key:    "throughput_or_capacity_units",
+		value:  int64(math.Ceil(float64(capacity+maxUnits) / 2)),
+		reason: fmt.Sprintf("midpoint of capacity %d and maximum_throughput_units %d with auto-inflate", capacity, maxUnits),
+	}}
+}
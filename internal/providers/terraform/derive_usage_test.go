@@ -0,0 +1,81 @@
+package terraform
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+
+	"github.com/infracost/infracost/internal/config"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+func TestDeriveUsageData(t *testing.T) {
+	asg := schema.NewResourceData("aws_autoscaling_group", "aws", "aws_autoscaling_group.web", nil, gjson.Parse(`{"min_size": 2, "max_size": 7}`))
+	asgDesired := schema.NewResourceData("aws_autoscaling_group", "aws", "aws_autoscaling_group.desired", nil, gjson.Parse(`{"min_size": 2, "max_size": 7, "desired_capacity": 3}`))
+	asgUsage := schema.NewResourceData("aws_autoscaling_group", "aws", "aws_autoscaling_group.usage", nil, gjson.Parse(`{"min_size": 2, "max_size": 7}`))
+	asgUsage.UsageData = schema.NewUsageData("aws_autoscaling_group.usage", schema.ParseAttributes(map[string]interface{}{"instances": 10}))
+
+	eventHub := schema.NewResourceData("azurerm_eventhub_namespace", "azurerm", "azurerm_eventhub_namespace.events", nil, gjson.Parse(`{"capacity": 2, "auto_inflate_enabled": true, "maximum_throughput_units": 10}`))
+
+	resData := map[string]*schema.ResourceData{
+		asg.Address:        asg,
+		asgDesired.Address: asgDesired,
+		asgUsage.Address:   asgUsage,
+		eventHub.Address:   eventHub,
+	}
+
+	ctx := config.EmptyProjectContext()
+	NewParser(ctx).deriveUsageData(resData)
+	assert.Nil(t, asg.UsageData)
+
+	ctx.RunContext.Config.DeriveUsage = true
+	NewParser(ctx).deriveUsageData(resData)
+
+	require.NotNil(t, asg.UsageData)
+	assert.Equal(t, int64(5), *asg.UsageData.GetInt("instances"))
+	assert.Equal(t, map[string]string{"instances": "midpoint of min_size 2 and max_size 7"}, asg.DerivedUsage)
+
+	assert.Nil(t, asgDesired.UsageData)
+	assert.Equal(t, int64(10), *asgUsage.UsageData.GetInt("instances"))
+	assert.Nil(t, asgUsage.DerivedUsage)
+
+	assert.Equal(t, int64(6), *eventHub.UsageData.GetInt("throughput_or_capacity_units"))
+}
+
+func TestDeriveS3BucketUsage(t *testing.T) {
+	d := schema.NewResourceData("aws_s3_bucket", "aws", "aws_s3_bucket.logs", nil, gjson.Parse(`{
+		"lifecycle_rule": [{
+			"enabled": true,
+			"transition": [
+				{"days": 90, "storage_class": "GLACIER"},
+				{"days": 30, "storage_class": "STANDARD_IA"}
+			],
+			"expiration": [{"days": 360}]
+		}]
+	}`))
+	u := schema.NewUsageData("aws_s3_bucket.logs", schema.ParseAttributes(map[string]interface{}{
+		"standard": map[string]interface{}{
+			"storage_gb":              1200,
+			"monthly_tier_1_requests": 1000,
+		},
+	}))
+
+	derived := deriveS3BucketUsage(d, u)
+	require.Len(t, derived, 3)
+
+	assert.Equal(t, "standard", derived[0].key)
+	assert.Equal(t, 100.0, derived[0].value.(map[string]interface{})["storage_gb"])
+	assert.Equal(t, 1000.0, derived[0].value.(map[string]interface{})["monthly_tier_1_requests"])
+
+	assert.Equal(t, "standard_infrequent_access", derived[1].key)
+	assert.Equal(t, map[string]interface{}{"storage_gb": 200.0}, derived[1].value)
+
+	assert.Equal(t, "glacier_flexible_retrieval", derived[2].key)
+	assert.Equal(t, map[string]interface{}{"storage_gb": 900.0}, derived[2].value)
+
+	// The storage of the other classes is already set
+	u.Attributes["glacier_flexible_retrieval"] = gjson.Parse(`{"storage_gb": 10}`)
+	assert.Nil(t, deriveS3BucketUsage(d, u))
+}
@@ -0,0 +1,26 @@
+package digitalocean
+
+import (
+	"github.com/infracost/infracost/internal/resources/digitalocean"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+func getDatabaseClusterRegistryItem() *schema.RegistryItem {
+	return &schema.RegistryItem{
+		Name:  "digitalocean_database_cluster",
+		RFunc: newDatabaseCluster,
+	}
+}
+
+func newDatabaseCluster(d *schema.ResourceData, u *schema.UsageData) *schema.Resource {
+	r := &digitalocean.DatabaseCluster{
+		Address:   d.Address,
+		Region:    d.Get("region").String(),
+		Engine:    d.Get("engine").String(),
+		Size:      d.Get("size").String(),
+		NodeCount: d.GetInt64OrDefault("node_count", 1),
+	}
+	r.PopulateUsage(u)
+
+	return r.BuildResource()
+}
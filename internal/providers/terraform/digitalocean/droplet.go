@@ -0,0 +1,25 @@
+package digitalocean
+
+import (
+	"github.com/infracost/infracost/internal/resources/digitalocean"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+func getDropletRegistryItem() *schema.RegistryItem {
+	return &schema.RegistryItem{
+		Name:  "digitalocean_droplet",
+		RFunc: newDroplet,
+	}
+}
+
+func newDroplet(d *schema.ResourceData, u *schema.UsageData) *schema.Resource {
+	r := &digitalocean.Droplet{
+		Address: d.Address,
+		Region:  d.Get("region").String(),
+		Size:    d.Get("size").String(),
+		Backups: d.Get("backups").Bool(),
+	}
+	r.PopulateUsage(u)
+
+	return r.BuildResource()
+}
@@ -0,0 +1,32 @@
+package digitalocean
+
+import (
+	"github.com/infracost/infracost/internal/resources/digitalocean"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+func getKubernetesClusterRegistryItem() *schema.RegistryItem {
+	return &schema.RegistryItem{
+		Name:  "digitalocean_kubernetes_cluster",
+		RFunc: newKubernetesCluster,
+	}
+}
+
+func newKubernetesCluster(d *schema.ResourceData, u *schema.UsageData) *schema.Resource {
+	region := d.Get("region").String()
+
+	var nodePool *digitalocean.KubernetesNodePool
+	if !d.IsEmpty("node_pool") {
+		nodePool = newKubernetesNodePoolFromValues("node_pool", region, d.Get("node_pool.0"))
+	}
+
+	r := &digitalocean.KubernetesCluster{
+		Address:  d.Address,
+		Region:   region,
+		HA:       d.Get("ha").Bool(),
+		NodePool: nodePool,
+	}
+	r.PopulateUsage(u)
+
+	return r.BuildResource()
+}
@@ -0,0 +1,39 @@
+package digitalocean
+
+import (
+	"github.com/tidwall/gjson"
+
+	"github.com/infracost/infracost/internal/resources/digitalocean"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+func getKubernetesNodePoolRegistryItem() *schema.RegistryItem {
+	return &schema.RegistryItem{
+		Name:  "digitalocean_kubernetes_node_pool",
+		RFunc: newKubernetesNodePool,
+	}
+}
+
+func newKubernetesNodePool(d *schema.ResourceData, u *schema.UsageData) *schema.Resource {
+	r := newKubernetesNodePoolFromValues(d.Address, d.Get("region").String(), d.RawValues)
+	r.PopulateUsage(u)
+
+	return r.BuildResource()
+}
+
+// newKubernetesNodePoolFromValues builds a node pool from a digitalocean_kubernetes_node_pool
+// resource or the node_pool block of a digitalocean_kubernetes_cluster, which have the
+// same attributes. Autoscaling pools default to their minimum number of nodes.
+func newKubernetesNodePoolFromValues(address, region string, values gjson.Result) *digitalocean.KubernetesNodePool {
+	nodeCount := values.Get("node_count").Int()
+	if values.Get("auto_scale").Bool() && values.Get("min_nodes").Exists() {
+		nodeCount = values.Get("min_nodes").Int()
+	}
+
+	return &digitalocean.KubernetesNodePool{
+		Address:   address,
+		Region:    region,
+		Size:      values.Get("size").String(),
+		NodeCount: nodeCount,
+	}
+}
@@ -0,0 +1,25 @@
+package digitalocean
+
+import (
+	"github.com/infracost/infracost/internal/resources/digitalocean"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+func getLoadbalancerRegistryItem() *schema.RegistryItem {
+	return &schema.RegistryItem{
+		Name:  "digitalocean_loadbalancer",
+		RFunc: newLoadbalancer,
+	}
+}
+
+func newLoadbalancer(d *schema.ResourceData, u *schema.UsageData) *schema.Resource {
+	r := &digitalocean.Loadbalancer{
+		Address:  d.Address,
+		Region:   d.Get("region").String(),
+		Size:     d.Get("size").String(),
+		SizeUnit: d.GetInt64OrDefault("size_unit", 1),
+	}
+	r.PopulateUsage(u)
+
+	return r.BuildResource()
+}
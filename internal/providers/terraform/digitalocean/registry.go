@@ -0,0 +1,38 @@
+package digitalocean
+
+import "github.com/infracost/infracost/internal/schema"
+
+// ResourceRegistry grouped alphabetically
+var ResourceRegistry []*schema.RegistryItem = []*schema.RegistryItem{
+	getDatabaseClusterRegistryItem(),
+	getDropletRegistryItem(),
+	getKubernetesClusterRegistryItem(),
+	getKubernetesNodePoolRegistryItem(),
+	getLoadbalancerRegistryItem(),
+	getSpacesBucketRegistryItem(),
+	getVolumeRegistryItem(),
+}
+
+// FreeResources grouped alphabetically
+var FreeResources = []string{
+	"digitalocean_certificate",
+	"digitalocean_container_registry_docker_credentials",
+	"digitalocean_database_connection_pool",
+	"digitalocean_database_db",
+	"digitalocean_database_firewall",
+	"digitalocean_database_user",
+	"digitalocean_domain",
+	"digitalocean_firewall",
+	"digitalocean_floating_ip_assignment",
+	"digitalocean_project",
+	"digitalocean_project_resources",
+	"digitalocean_record",
+	"digitalocean_spaces_bucket_object",
+	"digitalocean_spaces_bucket_policy",
+	"digitalocean_ssh_key",
+	"digitalocean_tag",
+	"digitalocean_volume_attachment",
+	"digitalocean_vpc",
+}
+
+var UsageOnlyResources = []string{}
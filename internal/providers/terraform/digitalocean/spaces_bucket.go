@@ -0,0 +1,23 @@
+package digitalocean
+
+import (
+	"github.com/infracost/infracost/internal/resources/digitalocean"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+func getSpacesBucketRegistryItem() *schema.RegistryItem {
+	return &schema.RegistryItem{
+		Name:  "digitalocean_spaces_bucket",
+		RFunc: newSpacesBucket,
+	}
+}
+
+func newSpacesBucket(d *schema.ResourceData, u *schema.UsageData) *schema.Resource {
+	r := &digitalocean.SpacesBucket{
+		Address: d.Address,
+		Region:  d.Get("region").String(),
+	}
+	r.PopulateUsage(u)
+
+	return r.BuildResource()
+}
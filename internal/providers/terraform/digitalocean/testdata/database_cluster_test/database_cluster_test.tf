@@ -0,0 +1,29 @@
+terraform {
+  required_providers {
+    digitalocean = {
+      source = "digitalocean/digitalocean"
+    }
+  }
+}
+
+provider "digitalocean" {
+  token = "mock_token"
+}
+
+resource "digitalocean_database_cluster" "postgres" {
+  name       = "postgres"
+  engine     = "pg"
+  version    = "15"
+  size       = "db-s-1vcpu-1gb"
+  region     = "nyc1"
+  node_count = 1
+}
+
+resource "digitalocean_database_cluster" "mysql_with_standby" {
+  name       = "mysql"
+  engine     = "mysql"
+  version    = "8"
+  size       = "db-s-2vcpu-4gb"
+  region     = "nyc1"
+  node_count = 3
+}
@@ -0,0 +1,26 @@
+terraform {
+  required_providers {
+    digitalocean = {
+      source = "digitalocean/digitalocean"
+    }
+  }
+}
+
+provider "digitalocean" {
+  token = "mock_token"
+}
+
+resource "digitalocean_droplet" "basic" {
+  image  = "ubuntu-22-04-x64"
+  name   = "basic"
+  region = "nyc3"
+  size   = "s-1vcpu-1gb"
+}
+
+resource "digitalocean_droplet" "with_backups" {
+  image   = "ubuntu-22-04-x64"
+  name    = "with-backups"
+  region  = "ams3"
+  size    = "g-2vcpu-8gb"
+  backups = true
+}
@@ -0,0 +1,52 @@
+terraform {
+  required_providers {
+    digitalocean = {
+      source = "digitalocean/digitalocean"
+    }
+  }
+}
+
+provider "digitalocean" {
+  token = "mock_token"
+}
+
+resource "digitalocean_kubernetes_cluster" "basic" {
+  name    = "basic"
+  region  = "nyc1"
+  version = "1.28.2-do.0"
+
+  node_pool {
+    name       = "default"
+    size       = "s-2vcpu-4gb"
+    node_count = 3
+  }
+}
+
+resource "digitalocean_kubernetes_cluster" "ha_autoscale" {
+  name    = "ha-autoscale"
+  region  = "fra1"
+  version = "1.28.2-do.0"
+  ha      = true
+
+  node_pool {
+    name       = "default"
+    size       = "c-4"
+    auto_scale = true
+    min_nodes  = 2
+    max_nodes  = 10
+  }
+}
+
+resource "digitalocean_kubernetes_cluster" "with_usage" {
+  name    = "with-usage"
+  region  = "fra1"
+  version = "1.28.2-do.0"
+
+  node_pool {
+    name       = "default"
+    size       = "s-4vcpu-8gb"
+    auto_scale = true
+    min_nodes  = 1
+    max_nodes  = 10
+  }
+}
@@ -0,0 +1,48 @@
+terraform {
+  required_providers {
+    digitalocean = {
+      source = "digitalocean/digitalocean"
+    }
+  }
+}
+
+provider "digitalocean" {
+  token = "mock_token"
+}
+
+resource "digitalocean_kubernetes_cluster" "cluster" {
+  name    = "cluster"
+  region  = "nyc1"
+  version = "1.28.2-do.0"
+
+  node_pool {
+    name       = "default"
+    size       = "s-1vcpu-2gb"
+    node_count = 1
+  }
+}
+
+resource "digitalocean_kubernetes_node_pool" "fixed" {
+  cluster_id = digitalocean_kubernetes_cluster.cluster.id
+  name       = "fixed"
+  size       = "m-2vcpu-16gb"
+  node_count = 2
+}
+
+resource "digitalocean_kubernetes_node_pool" "autoscale" {
+  cluster_id = digitalocean_kubernetes_cluster.cluster.id
+  name       = "autoscale"
+  size       = "s-2vcpu-4gb"
+  auto_scale = true
+  min_nodes  = 1
+  max_nodes  = 5
+}
+
+resource "digitalocean_kubernetes_node_pool" "autoscale_with_usage" {
+  cluster_id = digitalocean_kubernetes_cluster.cluster.id
+  name       = "autoscale-with-usage"
+  size       = "s-2vcpu-4gb"
+  auto_scale = true
+  min_nodes  = 1
+  max_nodes  = 5
+}
@@ -0,0 +1,49 @@
+terraform {
+  required_providers {
+    digitalocean = {
+      source = "digitalocean/digitalocean"
+    }
+  }
+}
+
+provider "digitalocean" {
+  token = "mock_token"
+}
+
+resource "digitalocean_loadbalancer" "default" {
+  name   = "default"
+  region = "nyc3"
+
+  forwarding_rule {
+    entry_port      = 80
+    entry_protocol  = "http"
+    target_port     = 80
+    target_protocol = "http"
+  }
+}
+
+resource "digitalocean_loadbalancer" "scaled" {
+  name      = "scaled"
+  region    = "nyc3"
+  size_unit = 3
+
+  forwarding_rule {
+    entry_port      = 80
+    entry_protocol  = "http"
+    target_port     = 80
+    target_protocol = "http"
+  }
+}
+
+resource "digitalocean_loadbalancer" "legacy" {
+  name   = "legacy"
+  region = "nyc3"
+  size   = "lb-medium"
+
+  forwarding_rule {
+    entry_port      = 80
+    entry_protocol  = "http"
+    target_port     = 80
+    target_protocol = "http"
+  }
+}
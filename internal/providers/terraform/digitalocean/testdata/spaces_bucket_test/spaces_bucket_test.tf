@@ -0,0 +1,21 @@
+terraform {
+  required_providers {
+    digitalocean = {
+      source = "digitalocean/digitalocean"
+    }
+  }
+}
+
+provider "digitalocean" {
+  token = "mock_token"
+}
+
+resource "digitalocean_spaces_bucket" "bucket" {
+  name   = "bucket"
+  region = "nyc3"
+}
+
+resource "digitalocean_spaces_bucket" "bucket_with_usage" {
+  name   = "bucket-with-usage"
+  region = "nyc3"
+}
@@ -0,0 +1,17 @@
+terraform {
+  required_providers {
+    digitalocean = {
+      source = "digitalocean/digitalocean"
+    }
+  }
+}
+
+provider "digitalocean" {
+  token = "mock_token"
+}
+
+resource "digitalocean_volume" "volume" {
+  region = "nyc1"
+  name   = "volume"
+  size   = 100
+}
@@ -0,0 +1,24 @@
+package digitalocean
+
+import (
+	"github.com/infracost/infracost/internal/resources/digitalocean"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+func getVolumeRegistryItem() *schema.RegistryItem {
+	return &schema.RegistryItem{
+		Name:  "digitalocean_volume",
+		RFunc: newVolume,
+	}
+}
+
+func newVolume(d *schema.ResourceData, u *schema.UsageData) *schema.Resource {
+	r := &digitalocean.Volume{
+		Address: d.Address,
+		Region:  d.Get("region").String(),
+		SizeGB:  d.Get("size").Float(),
+	}
+	r.PopulateUsage(u)
+
+	return r.BuildResource()
+}
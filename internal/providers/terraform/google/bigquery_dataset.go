@@ -3,6 +3,7 @@ package google
 import (
 	"github.com/infracost/infracost/internal/resources/google"
 	"github.com/infracost/infracost/internal/schema"
+	googleusage "github.com/infracost/infracost/internal/usage/google"
 )
 
 func getBigQueryDatasetRegistryItem() *schema.RegistryItem {
@@ -16,6 +17,14 @@ func NewBigQueryDataset(d *schema.ResourceData, u *schema.UsageData) *schema.Res
 	r := &google.BigQueryDataset{
 		Address: d.Address,
 		Region:  d.Get("region").String(),
+		Project: googleusage.ProjectFromID(d.Get("id").String()),
+		// Datasets are created in the US multi-region if they don't set a location.
+		Location:  "US",
+		DatasetID: d.Get("dataset_id").String(),
+	}
+
+	if d.Get("location").String() != "" {
+		r.Location = d.Get("location").String()
 	}
 
 	r.PopulateUsage(u)
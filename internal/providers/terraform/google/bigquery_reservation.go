@@ -0,0 +1,33 @@
+package google
+
+import (
+	"strings"
+
+	"github.com/infracost/infracost/internal/resources/google"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+func getBigQueryReservationRegistryItem() *schema.RegistryItem {
+	return &schema.RegistryItem{
+		Name:  "google_bigquery_reservation",
+		RFunc: NewBigQueryReservation,
+	}
+}
+
+func NewBigQueryReservation(d *schema.ResourceData, u *schema.UsageData) *schema.Resource {
+	region := d.Get("region").String()
+	if !d.IsEmpty("location") {
+		region = strings.ToLower(d.Get("location").String())
+	}
+
+	r := &google.BigQueryReservation{
+		Address:      d.Address,
+		Region:       region,
+		Edition:      d.Get("edition").String(),
+		SlotCapacity: d.Get("slot_capacity").Int(),
+		MaxSlots:     d.Get("autoscale.0.max_slots").Int(),
+	}
+
+	r.PopulateUsage(u)
+	return r.BuildResource()
+}
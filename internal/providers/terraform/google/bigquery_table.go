@@ -9,13 +9,23 @@ func getBigQueryTableRegistryItem() *schema.RegistryItem {
 	return &schema.RegistryItem{
 		Name:  "google_bigquery_table",
 		RFunc: NewBigQueryTable,
+		ReferenceAttributes: []string{
+			"dataset_id",
+		},
 	}
 }
 
 func NewBigQueryTable(d *schema.ResourceData, u *schema.UsageData) *schema.Resource {
+	storageBillingModel := "LOGICAL"
+	datasets := d.References("dataset_id")
+	if len(datasets) > 0 && !datasets[0].IsEmpty("storage_billing_model") {
+		storageBillingModel = datasets[0].Get("storage_billing_model").String()
+	}
+
 	r := &google.BigQueryTable{
-		Address: d.Address,
-		Region:  d.Get("region").String(),
+		Address:             d.Address,
+		Region:              d.Get("region").String(),
+		StorageBillingModel: storageBillingModel,
 	}
 
 	r.PopulateUsage(u)
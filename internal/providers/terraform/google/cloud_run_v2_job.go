@@ -0,0 +1,28 @@
+package google
+
+import (
+	"github.com/infracost/infracost/internal/resources/google"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+func getCloudRunV2JobRegistryItem() *schema.RegistryItem {
+	return &schema.RegistryItem{
+		Name:  "google_cloud_run_v2_job",
+		RFunc: NewCloudRunV2Job,
+	}
+}
+
+func NewCloudRunV2Job(d *schema.ResourceData, u *schema.UsageData) *schema.Resource {
+	cpu, memory := cloudRunContainerResources(d.Get("template.0.template.0.containers").Array())
+
+	r := &google.CloudRunV2Job{
+		Address:   d.Address,
+		Region:    d.Get("location").String(),
+		CPU:       cpu,
+		MemoryGiB: memory,
+		TaskCount: d.GetInt64OrDefault("template.0.task_count", 1),
+	}
+
+	r.PopulateUsage(u)
+	return r.BuildResource()
+}
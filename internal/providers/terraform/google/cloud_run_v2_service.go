@@ -0,0 +1,118 @@
+package google
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/tidwall/gjson"
+
+	"github.com/infracost/infracost/internal/resources/google"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+func getCloudRunV2ServiceRegistryItem() *schema.RegistryItem {
+	return &schema.RegistryItem{
+		Name:  "google_cloud_run_v2_service",
+		RFunc: NewCloudRunV2Service,
+	}
+}
+
+func NewCloudRunV2Service(d *schema.ResourceData, u *schema.UsageData) *schema.Resource {
+	containers := d.Get("template.0.containers").Array()
+	cpu, memory := cloudRunContainerResources(containers)
+
+	cpuIdle := true
+	for _, c := range containers {
+		if c.Get("resources.0.cpu_idle").Exists() && !c.Get("resources.0.cpu_idle").Bool() {
+			cpuIdle = false
+		}
+	}
+
+	concurrency := d.GetInt64OrDefault("template.0.max_instance_request_concurrency", 80)
+	if cpu < 1 {
+		concurrency = 1
+	}
+
+	r := &google.CloudRunV2Service{
+		Address:          d.Address,
+		Region:           d.Get("location").String(),
+		CPU:              cpu,
+		MemoryGiB:        memory,
+		CPUIdle:          cpuIdle,
+		MinInstanceCount: d.Get("template.0.scaling.0.min_instance_count").Int(),
+		Concurrency:      concurrency,
+	}
+
+	r.PopulateUsage(u)
+	return r.BuildResource()
+}
+
+// cloudRunContainerResources returns the total vCPU and memory in GiB requested by
+// the containers of a Cloud Run service or job. Containers without limits get the
+// Cloud Run defaults of 1 vCPU and 512MiB.
+func cloudRunContainerResources(containers []gjson.Result) (float64, float64) {
+	if len(containers) == 0 {
+		return 1, 0.5
+	}
+
+	var cpu, memory float64
+	for _, c := range containers {
+		cpu += parseCloudRunCPU(c.Get("resources.0.limits.cpu").String())
+		memory += parseCloudRunMemory(c.Get("resources.0.limits.memory").String())
+	}
+
+	return cpu, memory
+}
+
+func parseCloudRunCPU(s string) float64 {
+	if s == "" {
+		return 1
+	}
+
+	if strings.HasSuffix(s, "m") {
+		v, err := strconv.ParseFloat(strings.TrimSuffix(s, "m"), 64)
+		if err != nil {
+			return 1
+		}
+		return v / 1000
+	}
+
+	v, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 1
+	}
+	return v
+}
+
+func parseCloudRunMemory(s string) float64 {
+	if s == "" {
+		return 0.5
+	}
+
+	units := []struct {
+		suffix string
+		gib    float64
+	}{
+		{"Gi", 1},
+		{"Mi", 1.0 / 1024},
+		{"Ki", 1.0 / 1024 / 1024},
+		{"G", 1000.0 * 1000 * 1000 / 1024 / 1024 / 1024},
+		{"M", 1000.0 * 1000 / 1024 / 1024 / 1024},
+	}
+
+	for _, u := range units {
+		if strings.HasSuffix(s, u.suffix) {
+			v, err := strconv.ParseFloat(strings.TrimSuffix(s, u.suffix), 64)
+			if err != nil {
+				return 0.5
+			}
+			return v * u.gib
+		}
+	}
+
+	v, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0.5
+	}
+	return v / 1024 / 1024 / 1024
+}
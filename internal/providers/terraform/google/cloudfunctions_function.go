@@ -1,8 +1,11 @@
 package google
 
 import (
+	"strings"
+
 	"github.com/infracost/infracost/internal/resources/google"
 	"github.com/infracost/infracost/internal/schema"
+	googleusage "github.com/infracost/infracost/internal/usage/google"
 )
 
 func getCloudFunctionsRegistryItem() *schema.RegistryItem {
@@ -18,6 +21,12 @@ func NewCloudFunctionsFunction(d *schema.ResourceData, u *schema.UsageData) *sch
 		Region:  d.Get("region").String(),
 	}
 
+	// The project is only known from the ID once the function exists
+	if id := d.Get("id").String(); googleusage.ProjectFromID(id) != "" {
+		r.Project = googleusage.ProjectFromID(id)
+		r.Name = id[strings.LastIndex(id, "/")+1:]
+	}
+
 	if !d.IsEmpty("available_memory_mb") {
 		r.AvailableMemoryMB = intPtr(d.Get("available_memory_mb").Int())
 	}
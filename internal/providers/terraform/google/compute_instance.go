@@ -73,7 +73,8 @@ func newComputeInstance(d *schema.ResourceData, u *schema.UsageData) *schema.Res
 // resources.
 func getComputePurchaseOption(d gjson.Result) string {
 	purchaseOption := "on_demand"
-	if d.Get("scheduling.0.preemptible").Bool() {
+	// Spot VMs are priced the same as preemptible VMs
+	if d.Get("scheduling.0.preemptible").Bool() || strings.ToUpper(d.Get("scheduling.0.provisioning_model").String()) == "SPOT" {
 		purchaseOption = "preemptible"
 	}
 
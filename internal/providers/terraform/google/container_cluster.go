@@ -125,7 +125,7 @@ func newContainerNodeConfig(d gjson.Result) *google.ContainerNodeConfig {
 	}
 
 	purchaseOption := "on_demand"
-	if d.Get("preemptible").Bool() {
+	if d.Get("preemptible").Bool() || d.Get("spot").Bool() {
 		purchaseOption = "preemptible"
 	}
 
@@ -29,6 +29,17 @@ func newContainerNodePool(d *schema.ResourceData, u *schema.UsageData) *schema.R
 		cluster = d.References("cluster")[0]
 	}
 
+	// Autopilot clusters manage their own nodes and are charged for the pod
+	// resource requests instead, so there's nothing to price for the node pool.
+	if cluster != nil && cluster.Get("enable_autopilot").Bool() {
+		log.Debugf("Skipping node pool %s since its cluster is in Autopilot mode", d.Address)
+		return &schema.Resource{
+			Name:      d.Address,
+			IsSkipped: true,
+			NoPrice:   true,
+		}
+	}
+
 	r := newNodePool(d.Address, d.RawValues, cluster)
 
 	if r == nil {
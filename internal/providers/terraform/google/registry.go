@@ -5,8 +5,11 @@ import "github.com/infracost/infracost/internal/schema"
 var ResourceRegistry []*schema.RegistryItem = []*schema.RegistryItem{
 	getArtifactRegistryRepositoryRegistryItem(),
 	getBigQueryDatasetRegistryItem(),
+	getBigQueryReservationRegistryItem(),
 	getBigQueryTableRegistryItem(),
 	getCloudFunctionsRegistryItem(),
+	getCloudRunV2JobRegistryItem(),
+	getCloudRunV2ServiceRegistryItem(),
 	getComputeAddressRegistryItem(),
 	getComputeDiskRegistryItem(),
 	getComputeExternalVPNGatewayRegistryItem(),
@@ -51,8 +54,12 @@ var ResourceRegistry []*schema.RegistryItem = []*schema.RegistryItem{
 	getSecretManagerSecretRegistryItem(),
 	getSecretManagerSecretVersionRegistryItem(),
 	getServiceNetworkingConnectionRegistryItem(),
+	getSpannerDatabaseRegistryItem(),
+	getSpannerInstanceRegistryItem(),
 	GetSQLInstanceRegistryItem(),
 	GetStorageBucketRegistryItem(),
+	getVertexAIDatasetRegistryItem(),
+	getVertexAIEndpointRegistryItem(),
 }
 
 // FreeResources grouped alphabetically
@@ -62,6 +69,7 @@ var FreeResources = []string{
 	"google_bigquery_dataset_iam_member",
 	"google_bigquery_dataset_iam_policy",
 	"google_bigquery_job",
+	"google_bigquery_reservation_assignment",
 	"google_bigquery_routine",
 	"google_bigquery_table_iam_binding",
 	"google_bigquery_table_iam_member",
@@ -176,6 +184,12 @@ var FreeResources = []string{
 	"google_service_account_iam_member",
 	"google_service_account_iam_policy",
 	"google_service_account_key",
+	"google_spanner_database_iam_binding",
+	"google_spanner_database_iam_member",
+	"google_spanner_database_iam_policy",
+	"google_spanner_instance_iam_binding",
+	"google_spanner_instance_iam_member",
+	"google_spanner_instance_iam_policy",
 	"google_sql_database",
 	"google_sql_ssl_cert",
 	"google_sql_user",
@@ -0,0 +1,33 @@
+package google
+
+import (
+	"github.com/infracost/infracost/internal/resources/google"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+func getSpannerDatabaseRegistryItem() *schema.RegistryItem {
+	return &schema.RegistryItem{
+		Name:  "google_spanner_database",
+		RFunc: NewSpannerDatabase,
+		ReferenceAttributes: []string{
+			"instance",
+		},
+	}
+}
+
+func NewSpannerDatabase(d *schema.ResourceData, u *schema.UsageData) *schema.Resource {
+	var config string
+	instances := d.References("instance")
+	if len(instances) > 0 {
+		config = instances[0].Get("config").String()
+	}
+
+	r := &google.SpannerDatabase{
+		Address: d.Address,
+		Region:  d.Get("region").String(),
+		Config:  config,
+	}
+
+	r.PopulateUsage(u)
+	return r.BuildResource()
+}
@@ -0,0 +1,49 @@
+package google
+
+import (
+	"github.com/infracost/infracost/internal/resources/google"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+func getSpannerInstanceRegistryItem() *schema.RegistryItem {
+	return &schema.RegistryItem{
+		Name:  "google_spanner_instance",
+		RFunc: NewSpannerInstance,
+	}
+}
+
+func NewSpannerInstance(d *schema.ResourceData, u *schema.UsageData) *schema.Resource {
+	r := &google.SpannerInstance{
+		Address:         d.Address,
+		Region:          d.Get("region").String(),
+		Config:          d.Get("config").String(),
+		Edition:         d.Get("edition").String(),
+		ProcessingUnits: spannerProcessingUnits(d),
+	}
+
+	r.PopulateUsage(u)
+	return r.BuildResource()
+}
+
+// spannerProcessingUnits returns the compute capacity of the instance in processing
+// units. Autoscaled instances use their minimum limits as the baseline.
+func spannerProcessingUnits(d *schema.ResourceData) int64 {
+	if !d.IsEmpty("processing_units") {
+		return d.Get("processing_units").Int()
+	}
+
+	if !d.IsEmpty("num_nodes") {
+		return d.Get("num_nodes").Int() * 1000
+	}
+
+	limits := "autoscaling_config.0.autoscaling_limits.0"
+	if !d.IsEmpty(limits + ".min_processing_units") {
+		return d.Get(limits + ".min_processing_units").Int()
+	}
+
+	if !d.IsEmpty(limits + ".min_nodes") {
+		return d.Get(limits+".min_nodes").Int() * 1000
+	}
+
+	return 1000
+}
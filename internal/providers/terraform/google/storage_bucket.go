@@ -1,12 +1,15 @@
 package google
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"strings"
 
 	"github.com/shopspring/decimal"
 
 	"github.com/infracost/infracost/internal/schema"
+	googleusage "github.com/infracost/infracost/internal/usage/google"
 )
 
 func GetStorageBucketRegistryItem() *schema.RegistryItem {
@@ -27,9 +30,35 @@ func NewStorageBucket(d *schema.ResourceData, u *schema.UsageData) *schema.Resou
 		components = append(components, data)
 	}
 	components = append(components, operations(d, u)...)
+
+	// The ID of a bucket is its name once it exists, so there's nothing to
+	// estimate from before then
+	var estimate schema.EstimateFunc
+	name := d.Get("name").String()
+	if name != "" && d.Get("id").String() == name {
+		project := d.Get("project").String()
+		if project == "" {
+			project = googleusage.DefaultProject()
+		}
+
+		estimate = func(ctx context.Context, values map[string]interface{}) error {
+			if project == "" {
+				return errors.New("the project of the bucket isn't set, set GOOGLE_PROJECT to the default project")
+			}
+
+			bytes, err := googleusage.StorageBucketGetSizeBytes(ctx, project, name)
+			if err != nil {
+				return err
+			}
+			values["storage_gb"] = bytes / 1000 / 1000 / 1000
+			return nil
+		}
+	}
+
 	return &schema.Resource{
 		Name:           d.Address,
 		CostComponents: components,
+		EstimateUsage:  estimate,
 		SubResources: []*schema.Resource{
 			networkEgress(region, u, "Network egress", "Data transfer", StorageBucketEgress),
 		},
@@ -0,0 +1,33 @@
+provider "google" {
+  credentials = "{\"type\":\"service_account\"}"
+  region      = "us-central1"
+}
+
+resource "google_bigquery_reservation" "standard" {
+  name          = "standard"
+  location      = "us-central1"
+  edition       = "STANDARD"
+  slot_capacity = 0
+
+  autoscale {
+    max_slots = 100
+  }
+}
+
+resource "google_bigquery_reservation" "enterprise" {
+  name          = "enterprise"
+  location      = "us-central1"
+  edition       = "ENTERPRISE"
+  slot_capacity = 100
+}
+
+resource "google_bigquery_reservation" "enterprise_plus_autoscale" {
+  name          = "enterprise-plus"
+  location      = "us-central1"
+  edition       = "ENTERPRISE_PLUS"
+  slot_capacity = 100
+
+  autoscale {
+    max_slots = 400
+  }
+}
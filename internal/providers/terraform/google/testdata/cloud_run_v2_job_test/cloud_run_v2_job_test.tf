@@ -0,0 +1,40 @@
+provider "google" {
+  credentials = "{\"type\":\"service_account\"}"
+  region      = "us-central1"
+}
+
+resource "google_cloud_run_v2_job" "default" {
+  name     = "default"
+  location = "us-central1"
+
+  template {
+    template {
+      containers {
+        image = "us-docker.pkg.dev/cloudrun/container/job"
+      }
+    }
+  }
+}
+
+resource "google_cloud_run_v2_job" "with_usage" {
+  name     = "with-usage"
+  location = "us-central1"
+
+  template {
+    task_count  = 5
+    parallelism = 5
+
+    template {
+      containers {
+        image = "us-docker.pkg.dev/cloudrun/container/job"
+
+        resources {
+          limits = {
+            cpu    = "2"
+            memory = "4Gi"
+          }
+        }
+      }
+    }
+  }
+}
@@ -0,0 +1,81 @@
+provider "google" {
+  credentials = "{\"type\":\"service_account\"}"
+  region      = "us-central1"
+}
+
+resource "google_cloud_run_v2_service" "default" {
+  name     = "default"
+  location = "us-central1"
+
+  template {
+    containers {
+      image = "us-docker.pkg.dev/cloudrun/container/hello"
+    }
+  }
+}
+
+resource "google_cloud_run_v2_service" "with_usage" {
+  name     = "with-usage"
+  location = "us-central1"
+
+  template {
+    max_instance_request_concurrency = 50
+
+    containers {
+      image = "us-docker.pkg.dev/cloudrun/container/hello"
+
+      resources {
+        limits = {
+          cpu    = "2"
+          memory = "1Gi"
+        }
+      }
+    }
+  }
+}
+
+resource "google_cloud_run_v2_service" "min_instances" {
+  name     = "min-instances"
+  location = "europe-west1"
+
+  template {
+    scaling {
+      min_instance_count = 2
+      max_instance_count = 10
+    }
+
+    containers {
+      image = "us-docker.pkg.dev/cloudrun/container/hello"
+
+      resources {
+        limits = {
+          cpu    = "1000m"
+          memory = "512Mi"
+        }
+      }
+    }
+  }
+}
+
+resource "google_cloud_run_v2_service" "always_allocated" {
+  name     = "always-allocated"
+  location = "us-central1"
+
+  template {
+    scaling {
+      min_instance_count = 1
+    }
+
+    containers {
+      image = "us-docker.pkg.dev/cloudrun/container/hello"
+
+      resources {
+        cpu_idle = false
+        limits = {
+          cpu    = "4"
+          memory = "2Gi"
+        }
+      }
+    }
+  }
+}
@@ -221,6 +221,13 @@ resource "google_container_cluster" "autopilot" {
   enable_autopilot = true
 }
 
+resource "google_container_cluster" "autopilot_with_pod_usage" {
+  name     = "autopilot-with-pod-usage"
+  location = "us-central1"
+
+  enable_autopilot = true
+}
+
 resource "google_container_cluster" "autopilot_with_usage" {
   name     = "autopilot-with-usage"
   location = "us-central1"
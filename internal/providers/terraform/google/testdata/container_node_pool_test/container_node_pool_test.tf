@@ -163,4 +163,16 @@ resource "google_container_node_pool" "node_locations_usage" {
   name       = "node-locations"
   cluster    = google_container_cluster.node_locations_usage.id
   node_count = 3
-}
\ No newline at end of file
+}
+resource "google_container_cluster" "autopilot" {
+  name     = "autopilot"
+  location = "us-central1"
+
+  enable_autopilot = true
+}
+
+resource "google_container_node_pool" "autopilot" {
+  name       = "autopilot"
+  cluster    = google_container_cluster.autopilot.id
+  node_count = 3
+}
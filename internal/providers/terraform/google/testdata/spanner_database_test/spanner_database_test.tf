@@ -0,0 +1,33 @@
+provider "google" {
+  credentials = "{\"type\":\"service_account\"}"
+  region      = "us-central1"
+}
+
+resource "google_spanner_instance" "regional" {
+  name         = "regional"
+  config       = "regional-us-central1"
+  display_name = "Regional"
+  num_nodes    = 1
+}
+
+resource "google_spanner_instance" "multi_region" {
+  name         = "multi-region"
+  config       = "nam3"
+  display_name = "Multi region"
+  num_nodes    = 1
+}
+
+resource "google_spanner_database" "regional" {
+  instance = google_spanner_instance.regional.name
+  name     = "regional"
+}
+
+resource "google_spanner_database" "regional_with_usage" {
+  instance = google_spanner_instance.regional.name
+  name     = "regional-with-usage"
+}
+
+resource "google_spanner_database" "multi_region_with_usage" {
+  instance = google_spanner_instance.multi_region.name
+  name     = "multi-region-with-usage"
+}
@@ -0,0 +1,60 @@
+provider "google" {
+  credentials = "{\"type\":\"service_account\"}"
+  region      = "us-central1"
+}
+
+resource "google_spanner_instance" "nodes" {
+  name         = "nodes"
+  config       = "regional-us-central1"
+  display_name = "Nodes"
+  num_nodes    = 2
+}
+
+resource "google_spanner_instance" "processing_units" {
+  name             = "processing-units"
+  config           = "regional-europe-west1"
+  display_name     = "Processing units"
+  processing_units = 300
+}
+
+resource "google_spanner_instance" "multi_region" {
+  name         = "multi-region"
+  config       = "nam3"
+  display_name = "Multi region"
+  num_nodes    = 1
+  edition      = "ENTERPRISE_PLUS"
+}
+
+resource "google_spanner_instance" "autoscaling" {
+  name         = "autoscaling"
+  config       = "regional-us-central1"
+  display_name = "Autoscaling"
+
+  autoscaling_config {
+    autoscaling_limits {
+      min_processing_units = 1000
+      max_processing_units = 5000
+    }
+    autoscaling_targets {
+      high_priority_cpu_utilization_percent = 65
+      storage_utilization_percent           = 95
+    }
+  }
+}
+
+resource "google_spanner_instance" "autoscaling_with_usage" {
+  name         = "autoscaling-with-usage"
+  config       = "regional-us-central1"
+  display_name = "Autoscaling with usage"
+
+  autoscaling_config {
+    autoscaling_limits {
+      min_nodes = 1
+      max_nodes = 5
+    }
+    autoscaling_targets {
+      high_priority_cpu_utilization_percent = 65
+      storage_utilization_percent           = 95
+    }
+  }
+}
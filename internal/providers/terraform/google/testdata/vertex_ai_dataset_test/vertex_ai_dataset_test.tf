@@ -0,0 +1,22 @@
+provider "google" {
+  credentials = "{\"type\":\"service_account\"}"
+  region      = "us-central1"
+}
+
+resource "google_vertex_ai_dataset" "no_usage" {
+  display_name        = "no-usage"
+  metadata_schema_uri = "gs://google-cloud-aiplatform/schema/dataset/metadata/image_1.0.0.yaml"
+  region              = "us-central1"
+}
+
+resource "google_vertex_ai_dataset" "cpu" {
+  display_name        = "cpu"
+  metadata_schema_uri = "gs://google-cloud-aiplatform/schema/dataset/metadata/tabular_1.0.0.yaml"
+  region              = "us-central1"
+}
+
+resource "google_vertex_ai_dataset" "gpu" {
+  display_name        = "gpu"
+  metadata_schema_uri = "gs://google-cloud-aiplatform/schema/dataset/metadata/image_1.0.0.yaml"
+  region              = "us-central1"
+}
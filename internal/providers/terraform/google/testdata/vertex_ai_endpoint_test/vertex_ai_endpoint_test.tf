@@ -0,0 +1,22 @@
+provider "google" {
+  credentials = "{\"type\":\"service_account\"}"
+  region      = "us-central1"
+}
+
+resource "google_vertex_ai_endpoint" "no_usage" {
+  name         = "no-usage"
+  display_name = "no-usage"
+  location     = "us-central1"
+}
+
+resource "google_vertex_ai_endpoint" "cpu" {
+  name         = "cpu"
+  display_name = "cpu"
+  location     = "us-central1"
+}
+
+resource "google_vertex_ai_endpoint" "gpu" {
+  name         = "gpu"
+  display_name = "gpu"
+  location     = "us-central1"
+}
@@ -0,0 +1,23 @@
+package google
+
+import (
+	"github.com/infracost/infracost/internal/resources/google"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+func getVertexAIDatasetRegistryItem() *schema.RegistryItem {
+	return &schema.RegistryItem{
+		Name:  "google_vertex_ai_dataset",
+		RFunc: NewVertexAIDataset,
+	}
+}
+
+func NewVertexAIDataset(d *schema.ResourceData, u *schema.UsageData) *schema.Resource {
+	r := &google.VertexAIDataset{
+		Address: d.Address,
+		Region:  d.Get("region").String(),
+	}
+
+	r.PopulateUsage(u)
+	return r.BuildResource()
+}
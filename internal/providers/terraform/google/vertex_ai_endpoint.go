@@ -0,0 +1,28 @@
+package google
+
+import (
+	"github.com/infracost/infracost/internal/resources/google"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+func getVertexAIEndpointRegistryItem() *schema.RegistryItem {
+	return &schema.RegistryItem{
+		Name:  "google_vertex_ai_endpoint",
+		RFunc: NewVertexAIEndpoint,
+	}
+}
+
+func NewVertexAIEndpoint(d *schema.ResourceData, u *schema.UsageData) *schema.Resource {
+	region := d.Get("region").String()
+	if !d.IsEmpty("location") {
+		region = d.Get("location").String()
+	}
+
+	r := &google.VertexAIEndpoint{
+		Address: d.Address,
+		Region:  region,
+	}
+
+	r.PopulateUsage(u)
+	return r.BuildResource()
+}
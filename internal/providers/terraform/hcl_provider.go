@@ -4,19 +4,30 @@ import (
 	"encoding/json"
 	"flag"
 	"fmt"
+	"os"
+	"path/filepath"
 	"regexp"
+	"sort"
+	"strings"
 
+	log "github.com/sirupsen/logrus"
 	"github.com/zclconf/go-cty/cty"
 	ctyJson "github.com/zclconf/go-cty/cty/json"
 
 	"github.com/infracost/infracost/internal/config"
 	"github.com/infracost/infracost/internal/hcl"
+	"github.com/infracost/infracost/internal/hcl/modules"
 	"github.com/infracost/infracost/internal/schema"
 )
 
 type HCLProvider struct {
 	Parser   *hcl.Parser
 	Provider *PlanJSONProvider
+
+	// lockFile pins the module versions and provider regions, see --locked.
+	lockFile *hcl.LockFile
+	// lockFilePath is where the lock file is written, see --write-lock-file.
+	lockFilePath string
 }
 
 type flagStringSlice []string
@@ -75,11 +86,37 @@ func NewHCLProvider(ctx *config.ProjectContext, provider *PlanJSONProvider) (HCL
 		options = append(options, withVars)
 	}
 
+	var lockFile *hcl.LockFile
+	var lockFilePath string
+
+	if ctx.RunContext != nil {
+		path := filepath.Join(ctx.ProjectConfig.Path, hcl.LockFileName)
+
+		if ctx.RunContext.Config.Locked {
+			lockFile, err = hcl.ReadLockFile(path)
+			if err != nil {
+				return HCLProvider{}, fmt.Errorf("%w, run with --write-lock-file to create it", err)
+			}
+
+			options = append(options, hcl.OptionWithLockFile(lockFile))
+		}
+
+		if ctx.RunContext.Config.NoModuleDownloads {
+			options = append(options, hcl.OptionWithoutModuleDownloads())
+		}
+
+		if ctx.RunContext.Config.WriteLockFile {
+			lockFilePath = path
+		}
+	}
+
 	p := hcl.New(ctx.ProjectConfig.Path, options...)
 
 	return HCLProvider{
-		Parser:   p,
-		Provider: provider,
+		Parser:       p,
+		Provider:     provider,
+		lockFile:     lockFile,
+		lockFilePath: lockFilePath,
 	}, err
 }
 
@@ -106,6 +143,18 @@ func (p HCLProvider) loadPlanJSON() ([]byte, error) {
 	}
 
 	sch := p.modulesToPlanJSON(modules)
+
+	if p.lockFile != nil {
+		pinProviderRegions(sch, p.lockFile)
+	}
+
+	if p.lockFilePath != "" {
+		err = hcl.WriteLockFile(newLockFile(p.Parser.ModulesManifest(), sch), p.lockFilePath)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	b, err := json.Marshal(sch)
 	if err != nil {
 		return nil, fmt.Errorf("error handling built plan json from hcl %w", err)
@@ -192,6 +241,24 @@ func (p HCLProvider) modulesToPlanJSON(modules []*hcl.Module) PlanSchema {
 					},
 				}
 
+				if annotations := block.Annotations(); annotations != nil {
+					if sch.Annotations == nil {
+						sch.Annotations = map[string]hcl.Annotations{}
+					}
+					sch.Annotations[block.FullName()] = annotations
+				}
+
+				if r := block.SourceRange(); r.Filename != "" {
+					if sch.SourceRanges == nil {
+						sch.SourceRanges = map[string]schema.SourceRange{}
+					}
+					sch.SourceRanges[block.FullName()] = schema.SourceRange{
+						Filename:  relativeToWorkingDir(r.Filename),
+						StartLine: r.Start.Line,
+						EndLine:   r.End.Line,
+					}
+				}
+
 				jsonValues := marshalAttributeValues(block.Type(), block.Values())
 				marshalBlock(block, jsonValues)
 
@@ -250,6 +317,50 @@ func (p HCLProvider) modulesToPlanJSON(modules []*hcl.Module) PlanSchema {
 	return sch
 }
 
+// newLockFile returns a lock file of the modules in the manifest and the
+// provider regions of the plan JSON.
+func newLockFile(manifest *modules.Manifest, sch PlanSchema) *hcl.LockFile {
+	lockFile := hcl.NewLockFile(manifest)
+
+	keys := make([]string, 0, len(sch.Configuration.ProviderConfig))
+	for key := range sch.Configuration.ProviderConfig {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		lockFile.Providers = append(lockFile.Providers, &hcl.LockedProvider{
+			Key:    key,
+			Region: providerConfigRegion(sch.Configuration.ProviderConfig[key]),
+		})
+	}
+
+	return lockFile
+}
+
+// pinProviderRegions sets the regions of the providers to their regions in the
+// lock file, so that regions that depend on the environment, e.g. variables
+// set by TF_VAR_*, are the same as when the lock file was written.
+func pinProviderRegions(sch PlanSchema, lockFile *hcl.LockFile) {
+	for key, c := range sch.Configuration.ProviderConfig {
+		region, ok := lockFile.ProviderRegion(key)
+		if !ok || region == providerConfigRegion(c) {
+			continue
+		}
+
+		log.Debugf("Pinning the region of provider %s to %s from the lock file", key, region)
+		c.Expressions["region"] = map[string]interface{}{
+			"constant_value": region,
+		}
+	}
+}
+
+func providerConfigRegion(c ProviderConfig) string {
+	expr, _ := c.Expressions["region"].(map[string]interface{})
+	region, _ := expr["constant_value"].(string)
+	return region
+}
+
 func blockToReferences(block *hcl.Block) map[string]interface{} {
 	expressionValues := make(map[string]interface{})
 
@@ -284,6 +395,28 @@ func blockToReferences(block *hcl.Block) map[string]interface{} {
 	return expressionValues
 }
 
+// relativeToWorkingDir returns the filename relative to the working directory
+// if it's under it, e.g. the root of the repo in CI, so it can be matched to
+// the files of a pull request.
+func relativeToWorkingDir(filename string) string {
+	abs, err := filepath.Abs(filename)
+	if err != nil {
+		return filepath.ToSlash(filename)
+	}
+
+	wd, err := os.Getwd()
+	if err != nil {
+		return filepath.ToSlash(filename)
+	}
+
+	rel, err := filepath.Rel(wd, abs)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return filepath.ToSlash(abs)
+	}
+
+	return filepath.ToSlash(rel)
+}
+
 func marshalBlock(block *hcl.Block, jsonValues map[string]interface{}) {
 	for _, b := range block.Children() {
 		childValues := marshalAttributeValues(b.Type(), b.Values())
@@ -354,6 +487,11 @@ type PlanSchema struct {
 	} `json:"planned_values"`
 	ResourceChanges []ResourceChangesJSON `json:"resource_changes"`
 	Configuration   Configuration         `json:"configuration"`
+	// Annotations are the settings of the infracost: comments of the resources
+	// by their address, they aren't part of Terraform's plan JSON.
+	Annotations map[string]hcl.Annotations `json:"infracost_annotations,omitempty"`
+	// SourceRanges are where the resources are defined by their address.
+	SourceRanges map[string]schema.SourceRange `json:"infracost_source_ranges,omitempty"`
 }
 
 type PlanRootModule struct {
@@ -0,0 +1,24 @@
+package hcloud
+
+import (
+	"github.com/infracost/infracost/internal/resources/hcloud"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+func getFloatingIPRegistryItem() *schema.RegistryItem {
+	return &schema.RegistryItem{
+		Name:  "hcloud_floating_ip",
+		RFunc: newFloatingIP,
+	}
+}
+
+func newFloatingIP(d *schema.ResourceData, u *schema.UsageData) *schema.Resource {
+	r := &hcloud.FloatingIP{
+		Address:  d.Address,
+		Location: location(d),
+		Type:     d.Get("type").String(),
+	}
+	r.PopulateUsage(u)
+
+	return r.BuildResource()
+}
@@ -0,0 +1,24 @@
+package hcloud
+
+import (
+	"github.com/infracost/infracost/internal/resources/hcloud"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+func getLoadBalancerRegistryItem() *schema.RegistryItem {
+	return &schema.RegistryItem{
+		Name:  "hcloud_load_balancer",
+		RFunc: newLoadBalancer,
+	}
+}
+
+func newLoadBalancer(d *schema.ResourceData, u *schema.UsageData) *schema.Resource {
+	r := &hcloud.LoadBalancer{
+		Address:          d.Address,
+		Location:         location(d),
+		LoadBalancerType: d.Get("load_balancer_type").String(),
+	}
+	r.PopulateUsage(u)
+
+	return r.BuildResource()
+}
@@ -0,0 +1,24 @@
+package hcloud
+
+import (
+	"github.com/infracost/infracost/internal/resources/hcloud"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+func getPrimaryIPRegistryItem() *schema.RegistryItem {
+	return &schema.RegistryItem{
+		Name:  "hcloud_primary_ip",
+		RFunc: newPrimaryIP,
+	}
+}
+
+func newPrimaryIP(d *schema.ResourceData, u *schema.UsageData) *schema.Resource {
+	r := &hcloud.PrimaryIP{
+		Address:  d.Address,
+		Location: location(d),
+		Type:     d.Get("type").String(),
+	}
+	r.PopulateUsage(u)
+
+	return r.BuildResource()
+}
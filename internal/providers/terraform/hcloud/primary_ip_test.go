@@ -0,0 +1,18 @@
+package hcloud_test
+
+import (
+	"testing"
+
+	"github.com/infracost/infracost/internal/providers/terraform/tftest"
+)
+
+func TestPrimaryIP(t *testing.T) {
+	t.Parallel()
+	if testing.Short() {
+		t.Skip("skipping test in short mode")
+	}
+
+	tftest.GoldenFileResourceTestsWithOpts(t, "primary_ip_test", &tftest.GoldenFileOptions{
+		Currency: "EUR",
+	})
+}
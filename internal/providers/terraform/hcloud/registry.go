@@ -0,0 +1,36 @@
+package hcloud
+
+import "github.com/infracost/infracost/internal/schema"
+
+// ResourceRegistry grouped alphabetically
+var ResourceRegistry []*schema.RegistryItem = []*schema.RegistryItem{
+	getFloatingIPRegistryItem(),
+	getLoadBalancerRegistryItem(),
+	getPrimaryIPRegistryItem(),
+	getServerRegistryItem(),
+	getVolumeRegistryItem(),
+}
+
+// FreeResources grouped alphabetically
+var FreeResources = []string{
+	"hcloud_certificate",
+	"hcloud_firewall",
+	"hcloud_firewall_attachment",
+	"hcloud_floating_ip_assignment",
+	"hcloud_load_balancer_network",
+	"hcloud_load_balancer_service",
+	"hcloud_load_balancer_target",
+	"hcloud_managed_certificate",
+	"hcloud_network",
+	"hcloud_network_route",
+	"hcloud_network_subnet",
+	"hcloud_placement_group",
+	"hcloud_rdns",
+	"hcloud_server_network",
+	"hcloud_snapshot",
+	"hcloud_ssh_key",
+	"hcloud_uploaded_certificate",
+	"hcloud_volume_attachment",
+}
+
+var UsageOnlyResources = []string{}
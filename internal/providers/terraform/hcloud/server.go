@@ -0,0 +1,33 @@
+package hcloud
+
+import (
+	"github.com/infracost/infracost/internal/resources/hcloud"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+func getServerRegistryItem() *schema.RegistryItem {
+	return &schema.RegistryItem{
+		Name:  "hcloud_server",
+		RFunc: newServer,
+	}
+}
+
+func newServer(d *schema.ResourceData, u *schema.UsageData) *schema.Resource {
+	// Servers get a public IPv4 address unless it's disabled or an existing primary IP
+	// is assigned, which is priced by the hcloud_primary_ip resource.
+	ipv4Enabled := true
+	if !d.IsEmpty("public_net") {
+		ipv4Enabled = d.Get("public_net.0.ipv4_enabled").Bool() && d.IsEmpty("public_net.0.ipv4")
+	}
+
+	r := &hcloud.Server{
+		Address:     d.Address,
+		Location:    location(d),
+		ServerType:  d.Get("server_type").String(),
+		Backups:     d.Get("backups").Bool(),
+		IPv4Enabled: ipv4Enabled,
+	}
+	r.PopulateUsage(u)
+
+	return r.BuildResource()
+}
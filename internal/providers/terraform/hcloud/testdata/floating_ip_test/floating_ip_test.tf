@@ -0,0 +1,21 @@
+terraform {
+  required_providers {
+    hcloud = {
+      source = "hetznercloud/hcloud"
+    }
+  }
+}
+
+provider "hcloud" {
+  token = "mock_token_mock_token_mock_token_mock_token_mock_token_mock_tok"
+}
+
+resource "hcloud_floating_ip" "ipv4" {
+  type          = "ipv4"
+  home_location = "nbg1"
+}
+
+resource "hcloud_floating_ip" "ipv6" {
+  type          = "ipv6"
+  home_location = "nbg1"
+}
@@ -0,0 +1,23 @@
+terraform {
+  required_providers {
+    hcloud = {
+      source = "hetznercloud/hcloud"
+    }
+  }
+}
+
+provider "hcloud" {
+  token = "mock_token_mock_token_mock_token_mock_token_mock_token_mock_tok"
+}
+
+resource "hcloud_load_balancer" "small" {
+  name               = "small"
+  load_balancer_type = "lb11"
+  location           = "nbg1"
+}
+
+resource "hcloud_load_balancer" "large" {
+  name               = "large"
+  load_balancer_type = "lb31"
+  location           = "fsn1"
+}
@@ -0,0 +1,27 @@
+terraform {
+  required_providers {
+    hcloud = {
+      source = "hetznercloud/hcloud"
+    }
+  }
+}
+
+provider "hcloud" {
+  token = "mock_token_mock_token_mock_token_mock_token_mock_token_mock_tok"
+}
+
+resource "hcloud_primary_ip" "ipv4" {
+  name          = "ipv4"
+  datacenter    = "fsn1-dc14"
+  type          = "ipv4"
+  assignee_type = "server"
+  auto_delete   = false
+}
+
+resource "hcloud_primary_ip" "ipv6" {
+  name          = "ipv6"
+  datacenter    = "fsn1-dc14"
+  type          = "ipv6"
+  assignee_type = "server"
+  auto_delete   = false
+}
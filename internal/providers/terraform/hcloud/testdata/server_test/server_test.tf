@@ -0,0 +1,52 @@
+terraform {
+  required_providers {
+    hcloud = {
+      source = "hetznercloud/hcloud"
+    }
+  }
+}
+
+provider "hcloud" {
+  token = "mock_token_mock_token_mock_token_mock_token_mock_token_mock_tok"
+}
+
+resource "hcloud_server" "basic" {
+  name        = "basic"
+  image       = "ubuntu-22.04"
+  server_type = "cx22"
+  location    = "fsn1"
+}
+
+resource "hcloud_server" "with_backups" {
+  name        = "with-backups"
+  image       = "ubuntu-22.04"
+  server_type = "cpx31"
+  location    = "nbg1"
+  backups     = true
+}
+
+resource "hcloud_server" "ipv6_only" {
+  name        = "ipv6-only"
+  image       = "ubuntu-22.04"
+  server_type = "cax11"
+  location    = "hel1"
+
+  public_net {
+    ipv4_enabled = false
+    ipv6_enabled = true
+  }
+}
+
+resource "hcloud_server" "with_usage" {
+  name        = "with-usage"
+  image       = "ubuntu-22.04"
+  server_type = "ccx23"
+  location    = "fsn1"
+}
+
+resource "hcloud_server" "us_with_usage" {
+  name        = "us-with-usage"
+  image       = "ubuntu-22.04"
+  server_type = "cpx21"
+  location    = "ash"
+}
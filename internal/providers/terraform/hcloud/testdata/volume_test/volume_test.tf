@@ -0,0 +1,17 @@
+terraform {
+  required_providers {
+    hcloud = {
+      source = "hetznercloud/hcloud"
+    }
+  }
+}
+
+provider "hcloud" {
+  token = "mock_token_mock_token_mock_token_mock_token_mock_token_mock_tok"
+}
+
+resource "hcloud_volume" "volume" {
+  name     = "volume"
+  size     = 100
+  location = "fsn1"
+}
@@ -0,0 +1,44 @@
+package hcloud
+
+import (
+	"strings"
+
+	"github.com/infracost/infracost/internal/schema"
+)
+
+// location returns the location of a resource, falling back to the location of its
+// datacenter, e.g. fsn1-dc14 is in fsn1, for resources placed in a datacenter.
+func location(d *schema.ResourceData) string {
+	if !d.IsEmpty("location") {
+		return d.Get("location").String()
+	}
+
+	if !d.IsEmpty("datacenter") {
+		return strings.Split(d.Get("datacenter").String(), "-")[0]
+	}
+
+	return d.Get("home_location").String()
+}
+
+// Currency is the currency Hetzner Cloud bills in. Projects that only have priced
+// Hetzner Cloud resources are shown in it when the user hasn't set a currency.
+const Currency = "EUR"
+
+// IsProject returns true if the project has Hetzner Cloud resources and no other
+// resources that are priced.
+func IsProject(project *schema.Project) bool {
+	found := false
+
+	for _, r := range project.AllResources() {
+		if strings.HasPrefix(r.ResourceType, "hcloud_") {
+			found = true
+			continue
+		}
+
+		if !r.IsSkipped && !r.NoPrice {
+			return false
+		}
+	}
+
+	return found
+}
@@ -0,0 +1,24 @@
+package hcloud
+
+import (
+	"github.com/infracost/infracost/internal/resources/hcloud"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+func getVolumeRegistryItem() *schema.RegistryItem {
+	return &schema.RegistryItem{
+		Name:  "hcloud_volume",
+		RFunc: newVolume,
+	}
+}
+
+func newVolume(d *schema.ResourceData, u *schema.UsageData) *schema.Resource {
+	r := &hcloud.Volume{
+		Address:  d.Address,
+		Location: location(d),
+		SizeGB:   d.Get("size").Float(),
+	}
+	r.PopulateUsage(u)
+
+	return r.BuildResource()
+}
@@ -0,0 +1,87 @@
+package mongodbatlas
+
+import (
+	"github.com/tidwall/gjson"
+
+	"github.com/infracost/infracost/internal/resources/mongodbatlas"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+func getAdvancedClusterRegistryItem() *schema.RegistryItem {
+	return &schema.RegistryItem{
+		Name:  "mongodbatlas_advanced_cluster",
+		RFunc: newAdvancedCluster,
+	}
+}
+
+func newAdvancedCluster(d *schema.ResourceData, u *schema.UsageData) *schema.Resource {
+	var nodeGroups []*mongodbatlas.ClusterNodeGroup
+	var diskSizeGB float64
+
+	specs := d.Get("replication_specs").Array()
+
+	for _, spec := range specs {
+		shards := spec.Get("num_shards").Int()
+		if shards < 1 {
+			shards = 1
+		}
+
+		for _, region := range spec.Get("region_configs").Array() {
+			provider := providerName(region.Get("provider_name"), region.Get("backing_provider_name"))
+			regionName := region.Get("region_name").String()
+
+			for _, nodeType := range []struct {
+				name string
+				key  string
+			}{
+				{"Electable", "electable_specs"},
+				{"Read-only", "read_only_specs"},
+				{"Analytics", "analytics_specs"},
+			} {
+				s := region.Get(nodeType.key)
+				if s.IsArray() {
+					s = s.Get("0")
+				}
+				if !s.Exists() {
+					continue
+				}
+
+				if s.Get("disk_size_gb").Exists() {
+					diskSizeGB = s.Get("disk_size_gb").Float()
+				}
+
+				nodeGroups = append(nodeGroups, &mongodbatlas.ClusterNodeGroup{
+					Type:         nodeType.name,
+					ProviderName: provider,
+					RegionName:   regionName,
+					InstanceSize: s.Get("instance_size").String(),
+					NodeCount:    nodeCount(s) * shards,
+				})
+			}
+		}
+	}
+
+	if d.Get("disk_size_gb").Exists() {
+		diskSizeGB = d.Get("disk_size_gb").Float()
+	}
+
+	r := &mongodbatlas.Cluster{
+		Address:       d.Address,
+		NodeGroups:    nodeGroups,
+		DiskSizeGB:    diskSizeGB,
+		BackupEnabled: d.Get("backup_enabled").Bool(),
+	}
+	r.PopulateUsage(u)
+
+	return r.BuildResource()
+}
+
+// nodeCount returns the node count of a node spec. Shared tier clusters don't set a
+// node count, they're always deployed as a single replica set.
+func nodeCount(s gjson.Result) int64 {
+	if s.Get("node_count").Exists() {
+		return s.Get("node_count").Int()
+	}
+
+	return defaultElectableNodes
+}
@@ -0,0 +1,64 @@
+package mongodbatlas
+
+import (
+	"github.com/infracost/infracost/internal/resources/mongodbatlas"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+func getClusterRegistryItem() *schema.RegistryItem {
+	return &schema.RegistryItem{
+		Name:  "mongodbatlas_cluster",
+		RFunc: newCluster,
+	}
+}
+
+func newCluster(d *schema.ResourceData, u *schema.UsageData) *schema.Resource {
+	provider := providerName(d.Get("provider_name"), d.Get("backing_provider_name"))
+	instanceSize := d.Get("provider_instance_size_name").String()
+
+	var nodeGroups []*mongodbatlas.ClusterNodeGroup
+	var shards int64 = 1
+
+	specs := d.Get("replication_specs").Array()
+	if len(specs) > 0 && specs[0].Get("num_shards").Exists() {
+		shards = specs[0].Get("num_shards").Int()
+	}
+
+	for _, spec := range specs {
+		for _, region := range spec.Get("regions_config").Array() {
+			regionName := region.Get("region_name").String()
+
+			electable := int64(defaultElectableNodes)
+			if region.Get("electable_nodes").Exists() {
+				electable = region.Get("electable_nodes").Int()
+			}
+
+			nodeGroups = append(nodeGroups,
+				&mongodbatlas.ClusterNodeGroup{Type: "Electable", ProviderName: provider, RegionName: regionName, InstanceSize: instanceSize, NodeCount: electable},
+				&mongodbatlas.ClusterNodeGroup{Type: "Read-only", ProviderName: provider, RegionName: regionName, InstanceSize: instanceSize, NodeCount: region.Get("read_only_nodes").Int()},
+				&mongodbatlas.ClusterNodeGroup{Type: "Analytics", ProviderName: provider, RegionName: regionName, InstanceSize: instanceSize, NodeCount: region.Get("analytics_nodes").Int()},
+			)
+		}
+	}
+
+	if len(nodeGroups) == 0 {
+		nodeGroups = append(nodeGroups, &mongodbatlas.ClusterNodeGroup{
+			Type:         "Electable",
+			ProviderName: provider,
+			RegionName:   d.Get("provider_region_name").String(),
+			InstanceSize: instanceSize,
+			NodeCount:    defaultElectableNodes,
+		})
+	}
+
+	r := &mongodbatlas.Cluster{
+		Address:       d.Address,
+		NodeGroups:    nodeGroups,
+		Shards:        shards,
+		DiskSizeGB:    d.Get("disk_size_gb").Float(),
+		BackupEnabled: d.Get("cloud_backup").Bool(),
+	}
+	r.PopulateUsage(u)
+
+	return r.BuildResource()
+}
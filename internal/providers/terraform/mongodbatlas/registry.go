@@ -0,0 +1,29 @@
+package mongodbatlas
+
+import "github.com/infracost/infracost/internal/schema"
+
+// ResourceRegistry grouped alphabetically
+var ResourceRegistry []*schema.RegistryItem = []*schema.RegistryItem{
+	getAdvancedClusterRegistryItem(),
+	getClusterRegistryItem(),
+}
+
+// FreeResources grouped alphabetically
+var FreeResources = []string{
+	"mongodbatlas_alert_configuration",
+	"mongodbatlas_auditing",
+	"mongodbatlas_cloud_backup_schedule",
+	"mongodbatlas_custom_db_role",
+	"mongodbatlas_database_user",
+	"mongodbatlas_maintenance_window",
+	"mongodbatlas_network_container",
+	"mongodbatlas_network_peering",
+	"mongodbatlas_private_endpoint_regional_mode",
+	"mongodbatlas_privatelink_endpoint",
+	"mongodbatlas_privatelink_endpoint_service",
+	"mongodbatlas_project",
+	"mongodbatlas_project_ip_access_list",
+	"mongodbatlas_team",
+}
+
+var UsageOnlyResources = []string{}
@@ -0,0 +1,95 @@
+terraform {
+  required_providers {
+    mongodbatlas = {
+      source  = "mongodb/mongodbatlas"
+      version = "~> 1.15"
+    }
+  }
+}
+
+provider "mongodbatlas" {
+  public_key  = "mock_public_key"
+  private_key = "mock_private_key"
+}
+
+resource "mongodbatlas_advanced_cluster" "shared" {
+  project_id   = "5f8d5b2c9f1b2a3c4d5e6f70"
+  name         = "shared"
+  cluster_type = "REPLICASET"
+
+  replication_specs {
+    region_configs {
+      provider_name         = "TENANT"
+      backing_provider_name = "AWS"
+      region_name           = "US_EAST_1"
+      priority              = 7
+
+      electable_specs {
+        instance_size = "M5"
+      }
+    }
+  }
+}
+
+resource "mongodbatlas_advanced_cluster" "multi_region" {
+  project_id     = "5f8d5b2c9f1b2a3c4d5e6f70"
+  name           = "multi-region"
+  cluster_type   = "REPLICASET"
+  backup_enabled = true
+
+  replication_specs {
+    region_configs {
+      provider_name = "AWS"
+      region_name   = "US_EAST_1"
+      priority      = 7
+
+      electable_specs {
+        instance_size = "M30"
+        node_count    = 3
+        disk_size_gb  = 200
+      }
+
+      analytics_specs {
+        instance_size = "M30"
+        node_count    = 1
+      }
+    }
+
+    region_configs {
+      provider_name = "AWS"
+      region_name   = "US_WEST_2"
+      priority      = 6
+
+      electable_specs {
+        instance_size = "M30"
+        node_count    = 2
+      }
+
+      read_only_specs {
+        instance_size = "M30"
+        node_count    = 1
+      }
+    }
+  }
+}
+
+resource "mongodbatlas_advanced_cluster" "sharded" {
+  project_id   = "5f8d5b2c9f1b2a3c4d5e6f70"
+  name         = "sharded"
+  cluster_type = "SHARDED"
+
+  replication_specs {
+    num_shards = 3
+
+    region_configs {
+      provider_name = "AZURE"
+      region_name   = "US_EAST_2"
+      priority      = 7
+
+      electable_specs {
+        instance_size = "M50"
+        node_count    = 3
+      }
+    }
+  }
+}
@@ -0,0 +1,71 @@
+terraform {
+  required_providers {
+    mongodbatlas = {
+      source  = "mongodb/mongodbatlas"
+      version = "~> 1.15"
+    }
+  }
+}
+
+provider "mongodbatlas" {
+  public_key  = "mock_public_key"
+  private_key = "mock_private_key"
+}
+
+resource "mongodbatlas_cluster" "shared" {
+  project_id                  = "5f8d5b2c9f1b2a3c4d5e6f70"
+  name                        = "shared"
+  provider_name               = "TENANT"
+  backing_provider_name       = "AWS"
+  provider_region_name        = "US_EAST_1"
+  provider_instance_size_name = "M2"
+}
+
+resource "mongodbatlas_cluster" "dedicated" {
+  project_id                  = "5f8d5b2c9f1b2a3c4d5e6f70"
+  name                        = "dedicated"
+  cluster_type                = "REPLICASET"
+  provider_name               = "AWS"
+  provider_instance_size_name = "M30"
+  disk_size_gb                = 100
+  cloud_backup                = true
+
+  replication_specs {
+    num_shards = 1
+
+    regions_config {
+      region_name     = "US_EAST_1"
+      electable_nodes = 3
+      priority        = 7
+      read_only_nodes = 1
+    }
+  }
+}
+
+resource "mongodbatlas_cluster" "sharded" {
+  project_id                  = "5f8d5b2c9f1b2a3c4d5e6f70"
+  name                        = "sharded"
+  cluster_type                = "SHARDED"
+  provider_name               = "GCP"
+  provider_instance_size_name = "M40"
+  cloud_backup                = true
+
+  replication_specs {
+    num_shards = 2
+
+    regions_config {
+      region_name     = "CENTRAL_US"
+      electable_nodes = 3
+      priority        = 7
+      analytics_nodes = 1
+    }
+  }
+}
+
+resource "mongodbatlas_cluster" "default_nodes" {
+  project_id                  = "5f8d5b2c9f1b2a3c4d5e6f70"
+  name                        = "default-nodes"
+  provider_name               = "AZURE"
+  provider_region_name        = "US_EAST_2"
+  provider_instance_size_name = "M10"
+}
@@ -0,0 +1,17 @@
+package mongodbatlas
+
+import (
+	"github.com/tidwall/gjson"
+)
+
+const defaultElectableNodes = 3
+
+// providerName returns the cloud provider the cluster runs on. Shared tier clusters
+// use the TENANT provider and set the backing provider separately.
+func providerName(d gjson.Result, backingProviderName gjson.Result) string {
+	if d.String() == "TENANT" && backingProviderName.String() != "" {
+		return backingProviderName.String()
+	}
+
+	return d.String()
+}
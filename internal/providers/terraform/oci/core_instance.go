@@ -0,0 +1,44 @@
+package oci
+
+import (
+	"github.com/infracost/infracost/internal/resources/oci"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+func getCoreInstanceRegistryItem() *schema.RegistryItem {
+	return &schema.RegistryItem{
+		Name:  "oci_core_instance",
+		RFunc: newCoreInstance,
+	}
+}
+
+func newCoreInstance(d *schema.ResourceData, u *schema.UsageData) *schema.Resource {
+	ocpus := float64(1)
+	if !d.IsEmpty("shape_config.0.ocpus") {
+		ocpus = d.Get("shape_config.0.ocpus").Float()
+	}
+
+	// Flexible shapes default to 16GB of memory per OCPU when no memory is specified.
+	memory := ocpus * 16
+	if !d.IsEmpty("shape_config.0.memory_in_gbs") {
+		memory = d.Get("shape_config.0.memory_in_gbs").Float()
+	}
+
+	bootVolumeSize := float64(defaultBootVolumeSizeGB)
+	if !d.IsEmpty("source_details.0.boot_volume_size_in_gbs") {
+		bootVolumeSize = d.Get("source_details.0.boot_volume_size_in_gbs").Float()
+	}
+
+	r := &oci.CoreInstance{
+		Address:             d.Address,
+		Region:              d.Get("region").String(),
+		Shape:               d.Get("shape").String(),
+		OCPUs:               ocpus,
+		MemoryGB:            memory,
+		BootVolumeSizeGB:    bootVolumeSize,
+		BootVolumeVPUsPerGB: volumeVPUsPerGB(d, "source_details.0.boot_volume_vpus_per_gb"),
+	}
+	r.PopulateUsage(u)
+
+	return r.BuildResource()
+}
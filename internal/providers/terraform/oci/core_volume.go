@@ -0,0 +1,31 @@
+package oci
+
+import (
+	"github.com/infracost/infracost/internal/resources/oci"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+func getCoreVolumeRegistryItem() *schema.RegistryItem {
+	return &schema.RegistryItem{
+		Name:  "oci_core_volume",
+		RFunc: newCoreVolume,
+	}
+}
+
+func newCoreVolume(d *schema.ResourceData, u *schema.UsageData) *schema.Resource {
+	// Volumes default to 1TB when no size is specified.
+	size := float64(1024)
+	if !d.IsEmpty("size_in_gbs") {
+		size = d.Get("size_in_gbs").Float()
+	}
+
+	r := &oci.CoreVolume{
+		Address:   d.Address,
+		Region:    d.Get("region").String(),
+		SizeGB:    size,
+		VPUsPerGB: volumeVPUsPerGB(d, "vpus_per_gb"),
+	}
+	r.PopulateUsage(u)
+
+	return r.BuildResource()
+}
@@ -0,0 +1,48 @@
+package oci
+
+import (
+	"github.com/infracost/infracost/internal/resources/oci"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+func getDatabaseAutonomousDatabaseRegistryItem() *schema.RegistryItem {
+	return &schema.RegistryItem{
+		Name:  "oci_database_autonomous_database",
+		RFunc: newDatabaseAutonomousDatabase,
+	}
+}
+
+func newDatabaseAutonomousDatabase(d *schema.ResourceData, u *schema.UsageData) *schema.Resource {
+	computeModel := d.GetStringOrDefault("compute_model", "ECPU")
+
+	// Older databases specify the number of OCPUs with cpu_core_count.
+	computeCount := float64(2)
+	if !d.IsEmpty("compute_count") {
+		computeCount = d.Get("compute_count").Float()
+	} else if !d.IsEmpty("cpu_core_count") {
+		computeCount = d.Get("cpu_core_count").Float()
+		if d.IsEmpty("compute_model") {
+			computeModel = "OCPU"
+		}
+	}
+
+	storage := float64(1024)
+	if !d.IsEmpty("data_storage_size_in_gb") {
+		storage = d.Get("data_storage_size_in_gb").Float()
+	} else if !d.IsEmpty("data_storage_size_in_tbs") {
+		storage = d.Get("data_storage_size_in_tbs").Float() * 1024
+	}
+
+	r := &oci.DatabaseAutonomousDatabase{
+		Address:      d.Address,
+		Region:       d.Get("region").String(),
+		Workload:     d.GetStringOrDefault("db_workload", "OLTP"),
+		ComputeModel: computeModel,
+		ComputeCount: computeCount,
+		LicenseModel: d.GetStringOrDefault("license_model", "LICENSE_INCLUDED"),
+		StorageGB:    storage,
+	}
+	r.PopulateUsage(u)
+
+	return r.BuildResource()
+}
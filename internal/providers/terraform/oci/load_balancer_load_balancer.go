@@ -0,0 +1,30 @@
+package oci
+
+import (
+	"github.com/infracost/infracost/internal/resources/oci"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+func getLoadBalancerLoadBalancerRegistryItem() *schema.RegistryItem {
+	return &schema.RegistryItem{
+		Name:  "oci_load_balancer_load_balancer",
+		RFunc: newLoadBalancerLoadBalancer,
+	}
+}
+
+func newLoadBalancerLoadBalancer(d *schema.ResourceData, u *schema.UsageData) *schema.Resource {
+	minBandwidth := float64(10)
+	if !d.IsEmpty("shape_details.0.minimum_bandwidth_in_mbps") {
+		minBandwidth = d.Get("shape_details.0.minimum_bandwidth_in_mbps").Float()
+	}
+
+	r := &oci.LoadBalancer{
+		Address:          d.Address,
+		Region:           d.Get("region").String(),
+		Shape:            d.Get("shape").String(),
+		MinBandwidthMbps: minBandwidth,
+	}
+	r.PopulateUsage(u)
+
+	return r.BuildResource()
+}
@@ -0,0 +1,41 @@
+package oci
+
+import "github.com/infracost/infracost/internal/schema"
+
+// ResourceRegistry grouped alphabetically
+var ResourceRegistry []*schema.RegistryItem = []*schema.RegistryItem{
+	getCoreInstanceRegistryItem(),
+	getCoreVolumeRegistryItem(),
+	getDatabaseAutonomousDatabaseRegistryItem(),
+	getLoadBalancerLoadBalancerRegistryItem(),
+}
+
+// FreeResources grouped alphabetically
+var FreeResources = []string{
+	"oci_core_default_route_table",
+	"oci_core_default_security_list",
+	"oci_core_internet_gateway",
+	"oci_core_network_security_group",
+	"oci_core_network_security_group_security_rule",
+	"oci_core_route_table",
+	"oci_core_route_table_attachment",
+	"oci_core_security_list",
+	"oci_core_service_gateway",
+	"oci_core_subnet",
+	"oci_core_vcn",
+	"oci_core_volume_attachment",
+	"oci_core_volume_backup_policy_assignment",
+	"oci_identity_compartment",
+	"oci_identity_dynamic_group",
+	"oci_identity_group",
+	"oci_identity_policy",
+	"oci_identity_user",
+	"oci_load_balancer_backend",
+	"oci_load_balancer_backend_set",
+	"oci_load_balancer_certificate",
+	"oci_load_balancer_hostname",
+	"oci_load_balancer_listener",
+	"oci_load_balancer_rule_set",
+}
+
+var UsageOnlyResources = []string{}
@@ -0,0 +1,55 @@
+terraform {
+  required_providers {
+    oci = {
+      source = "oracle/oci"
+    }
+  }
+}
+
+provider "oci" {
+  region = "us-ashburn-1"
+}
+
+resource "oci_core_instance" "flex" {
+  availability_domain = "Uocm:US-ASHBURN-AD-1"
+  compartment_id      = "ocid1.compartment.oc1..fake"
+  shape               = "VM.Standard.E4.Flex"
+
+  shape_config {
+    ocpus         = 2
+    memory_in_gbs = 32
+  }
+
+  source_details {
+    source_type = "image"
+    source_id   = "ocid1.image.oc1..fake"
+  }
+}
+
+resource "oci_core_instance" "flex_default_memory" {
+  availability_domain = "Uocm:US-ASHBURN-AD-1"
+  compartment_id      = "ocid1.compartment.oc1..fake"
+  shape               = "VM.Standard.A1.Flex"
+
+  shape_config {
+    ocpus = 4
+  }
+
+  source_details {
+    source_type             = "image"
+    source_id               = "ocid1.image.oc1..fake"
+    boot_volume_size_in_gbs = 100
+    boot_volume_vpus_per_gb = 20
+  }
+}
+
+resource "oci_core_instance" "fixed" {
+  availability_domain = "Uocm:US-ASHBURN-AD-1"
+  compartment_id      = "ocid1.compartment.oc1..fake"
+  shape               = "VM.Standard2.2"
+
+  source_details {
+    source_type = "image"
+    source_id   = "ocid1.image.oc1..fake"
+  }
+}
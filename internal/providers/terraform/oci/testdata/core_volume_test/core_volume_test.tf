@@ -0,0 +1,36 @@
+terraform {
+  required_providers {
+    oci = {
+      source = "oracle/oci"
+    }
+  }
+}
+
+provider "oci" {
+  region = "us-ashburn-1"
+}
+
+resource "oci_core_volume" "default" {
+  availability_domain = "Uocm:US-ASHBURN-AD-1"
+  compartment_id      = "ocid1.compartment.oc1..fake"
+}
+
+resource "oci_core_volume" "balanced" {
+  availability_domain = "Uocm:US-ASHBURN-AD-1"
+  compartment_id      = "ocid1.compartment.oc1..fake"
+  size_in_gbs         = 500
+}
+
+resource "oci_core_volume" "higher_performance" {
+  availability_domain = "Uocm:US-ASHBURN-AD-1"
+  compartment_id      = "ocid1.compartment.oc1..fake"
+  size_in_gbs         = 500
+  vpus_per_gb         = 20
+}
+
+resource "oci_core_volume" "lower_cost" {
+  availability_domain = "Uocm:US-ASHBURN-AD-1"
+  compartment_id      = "ocid1.compartment.oc1..fake"
+  size_in_gbs         = 2000
+  vpus_per_gb         = 0
+}
@@ -0,0 +1,44 @@
+terraform {
+  required_providers {
+    oci = {
+      source = "oracle/oci"
+    }
+  }
+}
+
+provider "oci" {
+  region = "us-ashburn-1"
+}
+
+resource "oci_database_autonomous_database" "ecpu" {
+  compartment_id          = "ocid1.compartment.oc1..fake"
+  db_name                 = "ecpu"
+  compute_model           = "ECPU"
+  compute_count           = 4
+  data_storage_size_in_gb = 512
+}
+
+resource "oci_database_autonomous_database" "ecpu_byol" {
+  compartment_id          = "ocid1.compartment.oc1..fake"
+  db_name                 = "ecpubyol"
+  compute_model           = "ECPU"
+  compute_count           = 4
+  data_storage_size_in_gb = 512
+  license_model           = "BRING_YOUR_OWN_LICENSE"
+}
+
+resource "oci_database_autonomous_database" "ocpu_dw" {
+  compartment_id           = "ocid1.compartment.oc1..fake"
+  db_name                  = "ocpudw"
+  cpu_core_count           = 2
+  data_storage_size_in_tbs = 2
+  db_workload              = "DW"
+}
+
+resource "oci_database_autonomous_database" "with_usage" {
+  compartment_id          = "ocid1.compartment.oc1..fake"
+  db_name                 = "withusage"
+  compute_model           = "ECPU"
+  compute_count           = 2
+  data_storage_size_in_gb = 1024
+}
@@ -0,0 +1,42 @@
+terraform {
+  required_providers {
+    oci = {
+      source = "oracle/oci"
+    }
+  }
+}
+
+provider "oci" {
+  region = "us-ashburn-1"
+}
+
+resource "oci_load_balancer_load_balancer" "flexible" {
+  compartment_id = "ocid1.compartment.oc1..fake"
+  display_name   = "flexible"
+  shape          = "flexible"
+  subnet_ids     = ["ocid1.subnet.oc1..fake"]
+
+  shape_details {
+    minimum_bandwidth_in_mbps = 10
+    maximum_bandwidth_in_mbps = 100
+  }
+}
+
+resource "oci_load_balancer_load_balancer" "flexible_with_usage" {
+  compartment_id = "ocid1.compartment.oc1..fake"
+  display_name   = "flexible_with_usage"
+  shape          = "flexible"
+  subnet_ids     = ["ocid1.subnet.oc1..fake"]
+
+  shape_details {
+    minimum_bandwidth_in_mbps = 50
+    maximum_bandwidth_in_mbps = 400
+  }
+}
+
+resource "oci_load_balancer_load_balancer" "legacy" {
+  compartment_id = "ocid1.compartment.oc1..fake"
+  display_name   = "legacy"
+  shape          = "100Mbps"
+  subnet_ids     = ["ocid1.subnet.oc1..fake"]
+}
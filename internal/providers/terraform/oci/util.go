@@ -0,0 +1,19 @@
+package oci
+
+import (
+	"github.com/infracost/infracost/internal/schema"
+)
+
+// defaultBootVolumeSizeGB is the size of the boot volume OCI creates for Linux
+// platform images when no size is specified.
+const defaultBootVolumeSizeGB = 47
+
+// volumeVPUsPerGB returns the volume performance units per GB of a volume or boot
+// volume, defaulting to the balanced performance level.
+func volumeVPUsPerGB(d *schema.ResourceData, key string) float64 {
+	if d.IsEmpty(key) {
+		return 10
+	}
+
+	return d.Get(key).Float()
+}
@@ -18,9 +18,11 @@ import (
 // These show differently in the plan JSON for Terraform 0.12 and 0.13.
 var infracostProviderNames = []string{"infracost", "registry.terraform.io/infracost/infracost"}
 var defaultProviderRegions = map[string]string{
-	"aws":     "us-east-1",
-	"google":  "us-central1",
-	"azurerm": "eastus",
+	"aws":      "us-east-1",
+	"google":   "us-central1",
+	"azurerm":  "eastus",
+	"oci":      "us-ashburn-1",
+	"alicloud": "cn-hangzhou",
 }
 
 // ARN attribute mapping for resources that don't have a standard 'arn' attribute
@@ -62,6 +64,7 @@ func (p *Parser) createResource(d *schema.ResourceData, u *schema.UsageData) *sc
 				IsSkipped:    true,
 				NoPrice:      true,
 				SkipMessage:  "Free resource.",
+				RawValues:    d.RawValues,
 			}
 		}
 
@@ -69,6 +72,8 @@ func (p *Parser) createResource(d *schema.ResourceData, u *schema.UsageData) *sc
 		if res != nil {
 			res.ResourceType = d.Type
 			res.Tags = d.Tags
+			res.DerivedUsage = d.DerivedUsage
+			res.RawValues = d.RawValues
 			if u != nil {
 				res.EstimationSummary = u.CalcEstimationSummary()
 			}
@@ -82,6 +87,7 @@ func (p *Parser) createResource(d *schema.ResourceData, u *schema.UsageData) *sc
 		Tags:         d.Tags,
 		IsSkipped:    true,
 		SkipMessage:  "This resource is not currently supported",
+		RawValues:    d.RawValues,
 	}
 }
 
@@ -108,6 +114,7 @@ func (p *Parser) parseJSONResources(parsePrior bool, baseResources []*schema.Res
 	p.loadInfracostProviderUsageData(usage, resData)
 	p.stripDataResources(resData)
 	p.populateUsageData(resData, usage)
+	p.deriveUsageData(resData)
 
 	for _, d := range resData {
 		if r := p.createResource(d, d.UsageData); r != nil {
@@ -121,15 +128,11 @@ func (p *Parser) parseJSONResources(parsePrior bool, baseResources []*schema.Res
 // populateUsageData finds the UsageData for each ResourceData and sets the ResourceData.UsageData field
 // in case it is needed when processing a reference attribute
 func (p *Parser) populateUsageData(resData map[string]*schema.ResourceData, usage map[string]*schema.UsageData) {
+	finder := schema.NewUsageDataFinder(usage)
+
 	for _, d := range resData {
-		if ud := usage[d.Address]; ud != nil {
+		if ud := finder.Find(d.Address, d.Type); ud != nil {
 			d.UsageData = ud
-		} else if strings.HasSuffix(d.Address, "]") {
-			lastIndexOfOpenBracket := strings.LastIndex(d.Address, "[")
-
-			if arrayUsageData := usage[fmt.Sprintf("%s[*]", d.Address[:lastIndexOfOpenBracket])]; arrayUsageData != nil {
-				d.UsageData = arrayUsageData
-			}
 		}
 	}
 }
@@ -156,9 +159,53 @@ func (p *Parser) parseJSON(j []byte, usage map[string]*schema.UsageData) ([]*sch
 	resourceChanges := parsed.Get("resource_changes").Array()
 	pastResources = stripNonTargetResources(pastResources, resources, resourceChanges)
 
+	annotations := parsed.Get("infracost_annotations")
+	if annotations.Exists() {
+		addAnnotations(pastResources, annotations)
+		addAnnotations(resources, annotations)
+	}
+
+	sourceRanges := parsed.Get("infracost_source_ranges")
+	if sourceRanges.Exists() {
+		addSourceRanges(pastResources, sourceRanges)
+		addSourceRanges(resources, sourceRanges)
+	}
+
 	return pastResources, resources, nil
 }
 
+// addAnnotations sets the annotations of the infracost: comments of the
+// resources, see hcl.Annotations.
+func addAnnotations(resources []*schema.Resource, annotations gjson.Result) {
+	for _, r := range resources {
+		v := annotations.Get(gjsonEscape(r.Name))
+		if !v.IsObject() {
+			continue
+		}
+
+		r.Annotations = make(map[string]string)
+		for k, val := range v.Map() {
+			r.Annotations[k] = val.String()
+		}
+	}
+}
+
+// addSourceRanges sets where the resources are defined in the Terraform files.
+func addSourceRanges(resources []*schema.Resource, sourceRanges gjson.Result) {
+	for _, r := range resources {
+		v := sourceRanges.Get(gjsonEscape(r.Name))
+		if !v.IsObject() {
+			continue
+		}
+
+		r.SourceRange = &schema.SourceRange{
+			Filename:  v.Get("filename").String(),
+			StartLine: int(v.Get("startLine").Int()),
+			EndLine:   int(v.Get("endLine").Int()),
+		}
+	}
+}
+
 // StripTerraformWrapper removes any output added from the setup-terraform
 // GitHub action terraform wrapper, so we can parse the output of this as
 // valid JSON. It returns the stripped out JSON and a boolean that is true
@@ -177,6 +224,11 @@ func (p *Parser) loadUsageFileResources(u map[string]*schema.UsageData) []*schem
 	resources := make([]*schema.Resource, 0)
 
 	for k, v := range u {
+		// Patterns set the usage of resources in the project rather than adding resources
+		if schema.IsUsagePattern(k) {
+			continue
+		}
+
 		for _, t := range GetUsageOnlyResources() {
 			if strings.HasPrefix(k, fmt.Sprintf("%s.", t)) {
 				d := schema.NewResourceData(t, "global", k, map[string]string{}, gjson.Result{})
@@ -259,7 +311,7 @@ func (p *Parser) parseResourceData(isState bool, providerConf, planVals gjson.Re
 
 		v = schema.AddRawValue(v, "region", region)
 
-		tags := parseTags(t, v)
+		tags := parseTags(t, v, providerDefaultTags(providerConf, t, resConf))
 
 		resources[addr] = schema.NewResourceData(t, provider, addr, tags, v)
 	}
@@ -274,15 +326,56 @@ func (p *Parser) parseResourceData(isState bool, providerConf, planVals gjson.Re
 	return resources
 }
 
-func parseTags(resourceType string, v gjson.Result) map[string]string {
+// parseTags returns the tags of the resource, including the default tags of its
+// provider. Tags set on the resource override the default tags.
+func parseTags(resourceType string, v gjson.Result, defaultTags map[string]string) map[string]string {
 	tags := make(map[string]string)
 
+	for k, v := range defaultTags {
+		tags[k] = v
+	}
+
 	a := "tags"
 	if strings.HasPrefix(resourceType, "google_") {
 		a = "labels"
 	}
 
-	for k, v := range v.Get(a).Map() {
+	// tags_all is only known in plans when the default tags are constants, it
+	// includes them merged with the resource's tags.
+	for _, attr := range []string{a + "_all", a} {
+		for k, v := range v.Get(attr).Map() {
+			tags[k] = v.String()
+		}
+	}
+
+	return tags
+}
+
+// providerDefaultTags returns the constant default tags of the provider of the
+// resource, i.e. the default_tags block of the AWS provider and default_labels
+// of the Google provider.
+func providerDefaultTags(providerConf gjson.Result, resourceType string, resConf gjson.Result) map[string]string {
+	providerKey := parseProviderKey(resConf)
+	if providerKey == "" || !providerConf.Get(gjsonEscape(providerKey)).Exists() {
+		providerKey = strings.Split(resourceType, "_")[0]
+	}
+
+	exprs := providerConf.Get(fmt.Sprintf("%s.expressions", gjsonEscape(providerKey)))
+
+	var defaults gjson.Result
+	switch {
+	case strings.HasPrefix(resourceType, "aws_"):
+		defaults = exprs.Get("default_tags.0.tags.constant_value")
+	case strings.HasPrefix(resourceType, "google_"):
+		defaults = exprs.Get("default_labels.constant_value")
+	}
+
+	if !defaults.IsObject() {
+		return nil
+	}
+
+	tags := make(map[string]string)
+	for k, v := range defaults.Map() {
 		tags[k] = v.String()
 	}
 
@@ -931,3 +931,41 @@ func TestGetModuleNames(t *testing.T) {
 		assert.Equal(t, test.expected, actual)
 	}
 }
+
+func TestParseTagsWithProviderDefaultTags(t *testing.T) {
+	providerConf := gjson.Parse(`{
+		"aws": {
+			"name": "aws",
+			"expressions": {
+				"default_tags": [{"tags": {"constant_value": {"team": "platform", "env": "dev"}}}]
+			}
+		},
+		"aws.prod": {
+			"name": "aws",
+			"alias": "prod",
+			"expressions": {
+				"default_tags": [{"tags": {"constant_value": {"env": "prod"}}}]
+			}
+		},
+		"google": {
+			"name": "google",
+			"expressions": {
+				"default_labels": {"constant_value": {"team": "data"}}
+			}
+		}
+	}`)
+
+	values := gjson.Parse(`{"tags": {"env": "staging", "service": "api"}}`)
+
+	defaults := providerDefaultTags(providerConf, "aws_instance", gjson.Parse(`{"provider_config_key": "aws"}`))
+	assert.Equal(t, map[string]string{"team": "platform", "env": "staging", "service": "api"}, parseTags("aws_instance", values, defaults))
+
+	defaults = providerDefaultTags(providerConf, "aws_instance", gjson.Parse(`{"provider_config_key": "aws.prod"}`))
+	assert.Equal(t, map[string]string{"env": "prod"}, parseTags("aws_instance", gjson.Parse(`{}`), defaults))
+
+	defaults = providerDefaultTags(providerConf, "aws_instance", gjson.Result{})
+	assert.Equal(t, map[string]string{"team": "platform", "env": "dev"}, parseTags("aws_instance", gjson.Parse(`{"tags": null}`), defaults))
+
+	defaults = providerDefaultTags(providerConf, "google_compute_instance", gjson.Result{})
+	assert.Equal(t, map[string]string{"team": "data", "app": "web"}, parseTags("google_compute_instance", gjson.Parse(`{"labels": {"app": "web"}}`), defaults))
+}
@@ -6,9 +6,16 @@ import (
 
 	"github.com/infracost/infracost/internal/schema"
 
+	"github.com/infracost/infracost/internal/providers/terraform/alicloud"
 	"github.com/infracost/infracost/internal/providers/terraform/aws"
 	"github.com/infracost/infracost/internal/providers/terraform/azure"
+	"github.com/infracost/infracost/internal/providers/terraform/cloudflare"
+	"github.com/infracost/infracost/internal/providers/terraform/databricks"
+	"github.com/infracost/infracost/internal/providers/terraform/digitalocean"
 	"github.com/infracost/infracost/internal/providers/terraform/google"
+	"github.com/infracost/infracost/internal/providers/terraform/hcloud"
+	"github.com/infracost/infracost/internal/providers/terraform/mongodbatlas"
+	"github.com/infracost/infracost/internal/providers/terraform/oci"
 )
 
 type ResourceRegistryMap map[string]*schema.RegistryItem
@@ -43,6 +50,55 @@ func GetResourceRegistryMap() *ResourceRegistryMap {
 		for _, registryItem := range createFreeResources(google.FreeResources) {
 			resourceRegistryMap[registryItem.Name] = registryItem
 		}
+
+		for _, registryItem := range alicloud.ResourceRegistry {
+			resourceRegistryMap[registryItem.Name] = registryItem
+		}
+		for _, registryItem := range createFreeResources(alicloud.FreeResources) {
+			resourceRegistryMap[registryItem.Name] = registryItem
+		}
+
+		for _, registryItem := range cloudflare.ResourceRegistry {
+			resourceRegistryMap[registryItem.Name] = registryItem
+		}
+		for _, registryItem := range createFreeResources(cloudflare.FreeResources) {
+			resourceRegistryMap[registryItem.Name] = registryItem
+		}
+
+		for _, registryItem := range digitalocean.ResourceRegistry {
+			resourceRegistryMap[registryItem.Name] = registryItem
+		}
+		for _, registryItem := range createFreeResources(digitalocean.FreeResources) {
+			resourceRegistryMap[registryItem.Name] = registryItem
+		}
+
+		for _, registryItem := range hcloud.ResourceRegistry {
+			resourceRegistryMap[registryItem.Name] = registryItem
+		}
+		for _, registryItem := range createFreeResources(hcloud.FreeResources) {
+			resourceRegistryMap[registryItem.Name] = registryItem
+		}
+
+		for _, registryItem := range mongodbatlas.ResourceRegistry {
+			resourceRegistryMap[registryItem.Name] = registryItem
+		}
+		for _, registryItem := range createFreeResources(mongodbatlas.FreeResources) {
+			resourceRegistryMap[registryItem.Name] = registryItem
+		}
+
+		for _, registryItem := range databricks.ResourceRegistry {
+			resourceRegistryMap[registryItem.Name] = registryItem
+		}
+		for _, registryItem := range createFreeResources(databricks.FreeResources) {
+			resourceRegistryMap[registryItem.Name] = registryItem
+		}
+
+		for _, registryItem := range oci.ResourceRegistry {
+			resourceRegistryMap[registryItem.Name] = registryItem
+		}
+		for _, registryItem := range createFreeResources(oci.FreeResources) {
+			resourceRegistryMap[registryItem.Name] = registryItem
+		}
 	})
 
 	return &resourceRegistryMap
@@ -70,11 +126,27 @@ func GetUsageOnlyResources() []string {
 	r = append(r, aws.UsageOnlyResources...)
 	r = append(r, azure.UsageOnlyResources...)
 	r = append(r, google.UsageOnlyResources...)
+	r = append(r, oci.UsageOnlyResources...)
+	r = append(r, alicloud.UsageOnlyResources...)
+	r = append(r, cloudflare.UsageOnlyResources...)
+	r = append(r, digitalocean.UsageOnlyResources...)
+	r = append(r, hcloud.UsageOnlyResources...)
+	r = append(r, mongodbatlas.UsageOnlyResources...)
+	r = append(r, databricks.UsageOnlyResources...)
 	return r
 }
 
 func HasSupportedProvider(rType string) bool {
-	return strings.HasPrefix(rType, "aws_") || strings.HasPrefix(rType, "google_") || strings.HasPrefix(rType, "azurerm_")
+	return strings.HasPrefix(rType, "aws_") ||
+		strings.HasPrefix(rType, "google_") ||
+		strings.HasPrefix(rType, "azurerm_") ||
+		strings.HasPrefix(rType, "oci_") ||
+		strings.HasPrefix(rType, "alicloud_") ||
+		strings.HasPrefix(rType, "cloudflare_") ||
+		strings.HasPrefix(rType, "digitalocean_") ||
+		strings.HasPrefix(rType, "hcloud_") ||
+		strings.HasPrefix(rType, "mongodbatlas_") ||
+		strings.HasPrefix(rType, "databricks_")
 }
 
 func createFreeResources(l []string) []*schema.RegistryItem {
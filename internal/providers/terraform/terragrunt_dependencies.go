@@ -0,0 +1,404 @@
+package terraform
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclparse"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	log "github.com/sirupsen/logrus"
+	"github.com/zclconf/go-cty/cty"
+	ctyjson "github.com/zclconf/go-cty/cty/json"
+)
+
+var (
+	terragruntFileSchema = &hcl.BodySchema{
+		Attributes: []hcl.AttributeSchema{
+			{Name: "inputs"},
+		},
+		Blocks: []hcl.BlockHeaderSchema{
+			{Type: "dependency", LabelNames: []string{"name"}},
+		},
+	}
+
+	terragruntDependencySchema = &hcl.BodySchema{
+		Attributes: []hcl.AttributeSchema{
+			{Name: "config_path"},
+			{Name: "mock_outputs"},
+		},
+	}
+)
+
+// terragruntDependency is a dependency block of a Terragrunt unit.
+type terragruntDependency struct {
+	Name      string
+	ConfigDir string
+	// MockOutputs are the outputs used when the dependency has no state, they're
+	// nil if they aren't set or can't be evaluated without the Terragrunt
+	// functions.
+	MockOutputs map[string]interface{}
+}
+
+// terragruntOutputRef is an input of a Terragrunt unit that's set to an output
+// of one of its dependencies, e.g. dependency.vpc.outputs.vpc_id.
+type terragruntOutputRef struct {
+	Dependency string
+	Output     string
+}
+
+// terragruntUnit is the dependency config of the terragrunt.hcl of a unit.
+type terragruntUnit struct {
+	Dependencies []terragruntDependency
+	// Inputs are the inputs set to the outputs of dependencies, by input name.
+	Inputs map[string]terragruntOutputRef
+}
+
+func (u terragruntUnit) dependency(name string) *terragruntDependency {
+	for i := range u.Dependencies {
+		if u.Dependencies[i].Name == name {
+			return &u.Dependencies[i]
+		}
+	}
+
+	return nil
+}
+
+// loadTerragruntUnit reads the dependency blocks of the terragrunt.hcl in the
+// config dir and the inputs that use their outputs. Only values that can be
+// evaluated without the Terragrunt functions are read, so a config_path using
+// a function like find_in_parent_folders is skipped.
+func loadTerragruntUnit(configDir string) terragruntUnit {
+	unit := terragruntUnit{Inputs: map[string]terragruntOutputRef{}}
+
+	f, diags := hclparse.NewParser().ParseHCLFile(filepath.Join(configDir, "terragrunt.hcl"))
+	if diags.HasErrors() || f == nil {
+		log.Debugf("Could not parse the Terragrunt dependencies of %s: %s", configDir, diags.Error())
+		return unit
+	}
+
+	content, _, _ := f.Body.PartialContent(terragruntFileSchema)
+
+	for _, block := range content.Blocks {
+		depContent, _, _ := block.Body.PartialContent(terragruntDependencySchema)
+
+		attr, ok := depContent.Attributes["config_path"]
+		if !ok {
+			continue
+		}
+
+		v, diags := attr.Expr.Value(nil)
+		if diags.HasErrors() || !v.IsWhollyKnown() || v.IsNull() || v.Type() != cty.String {
+			log.Debugf("Skipping Terragrunt dependency %s of %s, its config_path can't be evaluated", block.Labels[0], configDir)
+			continue
+		}
+
+		dep := terragruntDependency{
+			Name:      block.Labels[0],
+			ConfigDir: filepath.Clean(filepath.Join(configDir, v.AsString())),
+		}
+
+		if mockAttr, ok := depContent.Attributes["mock_outputs"]; ok {
+			dep.MockOutputs = evalJSONValue(mockAttr.Expr)
+		}
+
+		unit.Dependencies = append(unit.Dependencies, dep)
+	}
+
+	inputs, ok := content.Attributes["inputs"]
+	if !ok {
+		return unit
+	}
+
+	obj, ok := inputs.Expr.(*hclsyntax.ObjectConsExpr)
+	if !ok {
+		return unit
+	}
+
+	for _, item := range obj.Items {
+		name := hcl.ExprAsKeyword(item.KeyExpr)
+		if name == "" {
+			v, diags := item.KeyExpr.Value(nil)
+			if diags.HasErrors() || v.IsNull() || !v.IsKnown() || v.Type() != cty.String {
+				continue
+			}
+			name = v.AsString()
+		}
+
+		traversal, diags := hcl.AbsTraversalForExpr(item.ValueExpr)
+		if diags.HasErrors() || len(traversal) != 4 || traversal.RootName() != "dependency" {
+			continue
+		}
+
+		dep, ok1 := traversal[1].(hcl.TraverseAttr)
+		outputs, ok2 := traversal[2].(hcl.TraverseAttr)
+		output, ok3 := traversal[3].(hcl.TraverseAttr)
+		if !ok1 || !ok2 || !ok3 || outputs.Name != "outputs" {
+			continue
+		}
+
+		unit.Inputs[name] = terragruntOutputRef{Dependency: dep.Name, Output: output.Name}
+	}
+
+	return unit
+}
+
+// evalJSONValue returns the value of an object expression that doesn't use any
+// variables or functions, converted to its JSON representation.
+func evalJSONValue(expr hcl.Expression) map[string]interface{} {
+	v, diags := expr.Value(nil)
+	if diags.HasErrors() || !v.IsWhollyKnown() || v.IsNull() {
+		return nil
+	}
+
+	b, err := ctyjson.Marshal(v, v.Type())
+	if err != nil {
+		return nil
+	}
+
+	var m map[string]interface{}
+	if err := json.Unmarshal(b, &m); err != nil {
+		return nil
+	}
+
+	return m
+}
+
+// sortByDependencies sorts the dirs so each unit comes after the units it
+// depends on, and returns the run group of each config dir: 1 for units with
+// no dependencies, otherwise one more than the highest group of their
+// dependencies, like the groups of terragrunt run-all. Units in the same group
+// keep their order. Dependencies that aren't in the dirs, e.g. external ones,
+// are ignored, and units in a dependency cycle are put last.
+func sortByDependencies(dirs []terragruntProjectDirs, units map[string]terragruntUnit) ([]terragruntProjectDirs, map[string]int) {
+	inDirs := make(map[string]bool, len(dirs))
+	for _, d := range dirs {
+		inDirs[d.ConfigDir] = true
+	}
+
+	groups := make(map[string]int, len(dirs))
+	sorted := make([]terragruntProjectDirs, 0, len(dirs))
+
+	for group := 1; len(sorted) < len(dirs); group++ {
+		var ready []terragruntProjectDirs
+
+		for _, d := range dirs {
+			if groups[d.ConfigDir] != 0 {
+				continue
+			}
+
+			isReady := true
+			for _, dep := range units[d.ConfigDir].Dependencies {
+				if inDirs[dep.ConfigDir] && groups[dep.ConfigDir] == 0 {
+					isReady = false
+					break
+				}
+			}
+
+			if isReady {
+				ready = append(ready, d)
+			}
+		}
+
+		if len(ready) == 0 {
+			// The remaining units depend on each other.
+			for _, d := range dirs {
+				if groups[d.ConfigDir] == 0 {
+					log.Warnf("Terragrunt unit %s is in a dependency cycle, its dependency outputs are not resolved", d.ConfigDir)
+					groups[d.ConfigDir] = group
+					sorted = append(sorted, d)
+				}
+			}
+			break
+		}
+
+		for _, d := range ready {
+			groups[d.ConfigDir] = group
+		}
+		sorted = append(sorted, ready...)
+	}
+
+	return sorted, groups
+}
+
+// terragruntDependencyNames returns the config dirs of the dependencies of the
+// unit that are in the run, sorted.
+func terragruntDependencyNames(unit terragruntUnit, groups map[string]int) []string {
+	var names []string
+	for _, dep := range unit.Dependencies {
+		if _, ok := groups[dep.ConfigDir]; ok {
+			names = append(names, dep.ConfigDir)
+		}
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// resolveDependencyOutputs sets the values of the inputs of each unit that use
+// the outputs of its dependencies when they're unknown in its plan, e.g.
+// because the dependency hasn't been applied yet. The value is the output of
+// the dependency's plan when it's known, otherwise the dependency's mock
+// output. The attributes of the unit's root module resources that are set
+// directly from those inputs are filled in with the same values. The outs are
+// the plan JSONs of the dirs, which must be sorted by their dependencies.
+func resolveDependencyOutputs(dirs []terragruntProjectDirs, units map[string]terragruntUnit, outs [][]byte) [][]byte {
+	if len(outs) != len(dirs) {
+		return outs
+	}
+
+	plannedOutputs := make(map[string]map[string]interface{}, len(dirs))
+	resolved := make([][]byte, len(outs))
+
+	for i, d := range dirs {
+		out := outs[i]
+		unit := units[d.ConfigDir]
+
+		values := map[string]interface{}{}
+		for input, ref := range unit.Inputs {
+			dep := unit.dependency(ref.Dependency)
+			if dep == nil {
+				continue
+			}
+
+			if v, ok := plannedOutputs[dep.ConfigDir][ref.Output]; ok {
+				values[input] = v
+			} else if v, ok := dep.MockOutputs[ref.Output]; ok {
+				values[input] = v
+			}
+		}
+
+		if len(values) > 0 {
+			patched, err := setUnknownInputs(out, values)
+			if err != nil {
+				log.Debugf("Could not resolve the Terragrunt dependency outputs of %s: %s", d.ConfigDir, err)
+			} else {
+				out = patched
+			}
+		}
+
+		resolved[i] = out
+		plannedOutputs[d.ConfigDir] = knownPlannedOutputs(out)
+	}
+
+	return resolved
+}
+
+// knownPlannedOutputs returns the outputs of the plan JSON whose values are
+// known.
+func knownPlannedOutputs(out []byte) map[string]interface{} {
+	var plan struct {
+		PlannedValues struct {
+			Outputs map[string]struct {
+				Value *json.RawMessage `json:"value"`
+			} `json:"outputs"`
+		} `json:"planned_values"`
+	}
+
+	if err := json.Unmarshal(out, &plan); err != nil {
+		return nil
+	}
+
+	outputs := map[string]interface{}{}
+	for name, o := range plan.PlannedValues.Outputs {
+		if o.Value == nil {
+			continue
+		}
+
+		var v interface{}
+		if err := json.Unmarshal(*o.Value, &v); err == nil && v != nil {
+			outputs[name] = v
+		}
+	}
+
+	return outputs
+}
+
+// setUnknownInputs sets the values of the variables of the plan JSON, and of
+// the root module resource attributes that only reference them, when they're
+// unknown.
+func setUnknownInputs(out []byte, values map[string]interface{}) ([]byte, error) {
+	var plan map[string]interface{}
+	if err := json.Unmarshal(out, &plan); err != nil {
+		return out, err
+	}
+
+	vars, _ := plan["variables"].(map[string]interface{})
+	for name, v := range values {
+		variable, ok := vars[name].(map[string]interface{})
+		if ok && variable["value"] == nil {
+			variable["value"] = v
+		}
+	}
+
+	// The attributes of each resource address that are set from the inputs.
+	attrs := map[string]map[string]interface{}{}
+
+	conf := jsonPath(plan, "configuration", "root_module")
+	confResources, _ := conf["resources"].([]interface{})
+	for _, r := range confResources {
+		res, _ := r.(map[string]interface{})
+		address, _ := res["address"].(string)
+		expressions, _ := res["expressions"].(map[string]interface{})
+
+		for attr, e := range expressions {
+			expr, _ := e.(map[string]interface{})
+			refs, _ := expr["references"].([]interface{})
+			if len(refs) != 1 {
+				continue
+			}
+
+			ref, _ := refs[0].(string)
+			if !strings.HasPrefix(ref, "var.") {
+				continue
+			}
+
+			if v, ok := values[strings.TrimPrefix(ref, "var.")]; ok {
+				if attrs[address] == nil {
+					attrs[address] = map[string]interface{}{}
+				}
+				attrs[address][attr] = v
+			}
+		}
+	}
+
+	if len(attrs) > 0 {
+		planned := jsonPath(plan, "planned_values", "root_module")
+		resources, _ := planned["resources"].([]interface{})
+		for _, r := range resources {
+			res, _ := r.(map[string]interface{})
+			address, _ := res["address"].(string)
+			// Strip the count or for_each key, e.g. the [0] of aws_instance.web[0]
+			if i := strings.IndexByte(address, '['); i >= 0 {
+				address = address[:i]
+			}
+
+			resValues, _ := res["values"].(map[string]interface{})
+			if resValues == nil {
+				continue
+			}
+
+			for attr, v := range attrs[address] {
+				if resValues[attr] == nil {
+					resValues[attr] = v
+				}
+			}
+		}
+	}
+
+	return json.Marshal(plan)
+}
+
+func jsonPath(m map[string]interface{}, keys ...string) map[string]interface{} {
+	for _, k := range keys {
+		next, ok := m[k].(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		m = next
+	}
+
+	return m
+}
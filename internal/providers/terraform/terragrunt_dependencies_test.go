@@ -0,0 +1,104 @@
+package terraform
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+)
+
+func writeTerragruntUnit(t *testing.T, dir string, hcl string) string {
+	t.Helper()
+
+	require.NoError(t, os.MkdirAll(dir, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "terragrunt.hcl"), []byte(hcl), 0600))
+
+	return dir
+}
+
+func TestLoadTerragruntUnit(t *testing.T) {
+	root := t.TempDir()
+	app := writeTerragruntUnit(t, filepath.Join(root, "app"), `
+dependency "vpc" {
+  config_path = "../vpc"
+
+  mock_outputs = {
+    vpc_id = "vpc-mock"
+  }
+}
+
+dependency "sizing" {
+  config_path = find_in_parent_folders("sizing")
+}
+
+inputs = {
+  vpc_id        = dependency.vpc.outputs.vpc_id
+  instance_type = dependency.vpc.outputs.instance_type
+  name          = "app"
+}
+`)
+
+	unit := loadTerragruntUnit(app)
+	require.Len(t, unit.Dependencies, 1)
+	assert.Equal(t, "vpc", unit.Dependencies[0].Name)
+	assert.Equal(t, filepath.Join(root, "vpc"), unit.Dependencies[0].ConfigDir)
+	assert.Equal(t, map[string]interface{}{"vpc_id": "vpc-mock"}, unit.Dependencies[0].MockOutputs)
+
+	assert.Equal(t, map[string]terragruntOutputRef{
+		"vpc_id":        {Dependency: "vpc", Output: "vpc_id"},
+		"instance_type": {Dependency: "vpc", Output: "instance_type"},
+	}, unit.Inputs)
+}
+
+func TestSortByDependencies(t *testing.T) {
+	dirs := []terragruntProjectDirs{{ConfigDir: "/app"}, {ConfigDir: "/db"}, {ConfigDir: "/vpc"}}
+	units := map[string]terragruntUnit{
+		"/app": {Dependencies: []terragruntDependency{{Name: "db", ConfigDir: "/db"}, {Name: "vpc", ConfigDir: "/vpc"}}},
+		"/db":  {Dependencies: []terragruntDependency{{Name: "vpc", ConfigDir: "/vpc"}, {Name: "external", ConfigDir: "/external"}}},
+	}
+
+	sorted, groups := sortByDependencies(dirs, units)
+	assert.Equal(t, []terragruntProjectDirs{{ConfigDir: "/vpc"}, {ConfigDir: "/db"}, {ConfigDir: "/app"}}, sorted)
+	assert.Equal(t, map[string]int{"/vpc": 1, "/db": 2, "/app": 3}, groups)
+	assert.Equal(t, []string{"/vpc"}, terragruntDependencyNames(units["/db"], groups))
+}
+
+func TestResolveDependencyOutputs(t *testing.T) {
+	dirs := []terragruntProjectDirs{{ConfigDir: "/vpc"}, {ConfigDir: "/app"}}
+	units := map[string]terragruntUnit{
+		"/app": {
+			Dependencies: []terragruntDependency{{Name: "vpc", ConfigDir: "/vpc", MockOutputs: map[string]interface{}{"vpc_id": "vpc-mock"}}},
+			Inputs: map[string]terragruntOutputRef{
+				"instance_type": {Dependency: "vpc", Output: "instance_type"},
+				"vpc_id":        {Dependency: "vpc", Output: "vpc_id"},
+			},
+		},
+	}
+
+	vpc := []byte(`{"planned_values": {"outputs": {"instance_type": {"sensitive": false, "value": "m5.large"}, "vpc_id": {"sensitive": false}}}}`)
+	app := []byte(`{
+  "variables": {"instance_type": {}, "vpc_id": {}},
+  "planned_values": {"root_module": {"resources": [
+    {"address": "aws_instance.web[0]", "values": {"instance_type": null, "ami": "ami-123"}}
+  ]}},
+  "configuration": {"root_module": {"resources": [
+    {"address": "aws_instance.web", "expressions": {
+      "instance_type": {"references": ["var.instance_type"]},
+      "subnet_id": {"references": ["var.vpc_id", "data.aws_subnets.this"]}
+    }}
+  ]}}
+}`)
+
+	outs := resolveDependencyOutputs(dirs, units, [][]byte{vpc, app})
+	require.Len(t, outs, 2)
+	assert.Equal(t, vpc, outs[0])
+
+	parsed := gjson.ParseBytes(outs[1])
+	assert.Equal(t, "m5.large", parsed.Get("variables.instance_type.value").String())
+	assert.Equal(t, "vpc-mock", parsed.Get("variables.vpc_id.value").String())
+	assert.Equal(t, "m5.large", parsed.Get("planned_values.root_module.resources.0.values.instance_type").String())
+	assert.False(t, parsed.Get("planned_values.root_module.resources.0.values.subnet_id").Exists())
+}
@@ -80,6 +80,14 @@ func (p *TerragruntProvider) LoadResources(usage map[string]*schema.UsageData) (
 		return []*schema.Project{}, err
 	}
 
+	// Units are estimated after the units they depend on, so their inputs can
+	// use the outputs of the dependencies' plans.
+	units := make(map[string]terragruntUnit, len(projectDirs))
+	for _, projectDir := range projectDirs {
+		units[projectDir.ConfigDir] = loadTerragruntUnit(projectDir.ConfigDir)
+	}
+	projectDirs, groups := sortByDependencies(projectDirs, units)
+
 	var outs [][]byte
 
 	if p.UseState {
@@ -91,6 +99,10 @@ func (p *TerragruntProvider) LoadResources(usage map[string]*schema.UsageData) (
 		return []*schema.Project{}, err
 	}
 
+	if !p.UseState {
+		outs = resolveDependencyOutputs(projectDirs, units, outs)
+	}
+
 	projects := make([]*schema.Project, 0, len(projectDirs))
 
 	spinner := ui.NewSpinner("Extracting only cost-related params from terragrunt plan", ui.SpinnerOptions{
@@ -102,6 +114,8 @@ func (p *TerragruntProvider) LoadResources(usage map[string]*schema.UsageData) (
 	for i, projectDir := range projectDirs {
 		metadata := config.DetectProjectMetadata(projectDir.ConfigDir)
 		metadata.Type = p.Type()
+		metadata.TerragruntDependencies = terragruntDependencyNames(units[projectDir.ConfigDir], groups)
+		metadata.TerragruntDependencyOrder = groups[projectDir.ConfigDir]
 		p.AddMetadata(metadata)
 		name := schema.GenerateProjectName(metadata, p.ctx.RunContext.Config.EnableDashboard)
 
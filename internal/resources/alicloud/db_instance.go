@@ -0,0 +1,75 @@
+package alicloud
+
+import (
+	"fmt"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/infracost/infracost/internal/resources"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+// DBInstance struct represents an ApsaraDB RDS instance. Instances are charged per
+// hour for the instance class and per GB-month for the storage.
+//
+// Resource information: https://www.alibabacloud.com/help/en/rds/
+// Pricing information: https://www.alibabacloud.com/product/apsaradb-for-rds-mysql/pricing
+type DBInstance struct {
+	Address       string
+	Region        string
+	Engine        string
+	InstanceClass string
+	StorageType   string
+	StorageGB     float64
+}
+
+// DBInstanceUsageSchema defines a list which represents the usage schema of DBInstance.
+var DBInstanceUsageSchema = []*schema.UsageItem{}
+
+// PopulateUsage parses the u schema.UsageData into the DBInstance.
+// It uses the `infracost_usage` struct tags to populate data into the DBInstance.
+func (r *DBInstance) PopulateUsage(u *schema.UsageData) {
+	resources.PopulateArgsWithUsage(r, u)
+}
+
+// BuildResource builds a schema.Resource from a valid DBInstance struct.
+// This method is called after the resource is initialised by an IaC provider.
+// See providers folder for more information.
+func (r *DBInstance) BuildResource() *schema.Resource {
+	return &schema.Resource{
+		Name:        r.Address,
+		UsageSchema: DBInstanceUsageSchema,
+		CostComponents: []*schema.CostComponent{
+			{
+				Name:           fmt.Sprintf("Database instance (%s, %s)", r.Engine, r.InstanceClass),
+				Unit:           "hours",
+				UnitMultiplier: decimal.NewFromInt(1),
+				HourlyQuantity: decimalPtr(decimal.NewFromInt(1)),
+				ProductFilter: &schema.ProductFilter{
+					VendorName:    strPtr(vendorName),
+					Region:        strPtr(r.Region),
+					Service:       strPtr("RDS"),
+					ProductFamily: strPtr("Database Instance"),
+					AttributeFilters: []*schema.AttributeFilter{
+						{Key: "instanceClass", Value: strPtr(r.InstanceClass)},
+					},
+				},
+			},
+			{
+				Name:            fmt.Sprintf("Storage (%s)", r.StorageType),
+				Unit:            "GB",
+				UnitMultiplier:  decimal.NewFromInt(1),
+				MonthlyQuantity: decimalPtr(decimal.NewFromFloat(r.StorageGB)),
+				ProductFilter: &schema.ProductFilter{
+					VendorName:    strPtr(vendorName),
+					Region:        strPtr(r.Region),
+					Service:       strPtr("RDS"),
+					ProductFamily: strPtr("Storage"),
+					AttributeFilters: []*schema.AttributeFilter{
+						{Key: "storageType", Value: strPtr(r.StorageType)},
+					},
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,192 @@
+package alicloud
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/infracost/infracost/internal/resources"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+const (
+	internetChargeTypePayByBandwidth = "paybybandwidth"
+	internetBandwidthTierLimitMbps   = 5
+)
+
+// InstanceDisk represents a system or data disk attached to an ECS instance.
+type InstanceDisk struct {
+	Category         string
+	PerformanceLevel string
+	SizeGB           float64
+}
+
+// Instance struct represents an Alibaba Cloud ECS instance.
+//
+// Instances are charged per hour for the instance type, per GB-month for the system
+// and data disks, and for public internet access either per GB of outbound traffic
+// (PayByTraffic) or per Mbps of fixed bandwidth (PayByBandwidth). Subscription
+// (PrePaid) instances are estimated using pay-as-you-go prices.
+//
+// Resource information: https://www.alibabacloud.com/help/en/ecs/
+// Pricing information: https://www.alibabacloud.com/product/ecs/pricing
+type Instance struct {
+	Address                 string
+	Region                  string
+	InstanceType            string
+	SystemDisk              InstanceDisk
+	DataDisks               []InstanceDisk
+	InternetChargeType      string
+	InternetMaxBandwidthOut float64
+
+	MonthlyOutboundDataGB *float64 `infracost_usage:"monthly_outbound_data_gb"`
+}
+
+// InstanceUsageSchema defines a list which represents the usage schema of Instance.
+var InstanceUsageSchema = []*schema.UsageItem{
+	{Key: "monthly_outbound_data_gb", DefaultValue: 0, ValueType: schema.Float64},
+}
+
+// PopulateUsage parses the u schema.UsageData into the Instance.
+// It uses the `infracost_usage` struct tags to populate data into the Instance.
+func (r *Instance) PopulateUsage(u *schema.UsageData) {
+	resources.PopulateArgsWithUsage(r, u)
+}
+
+// BuildResource builds a schema.Resource from a valid Instance struct.
+// This method is called after the resource is initialised by an IaC provider.
+// See providers folder for more information.
+func (r *Instance) BuildResource() *schema.Resource {
+	costComponents := []*schema.CostComponent{
+		{
+			Name:           fmt.Sprintf("Instance usage (%s)", r.InstanceType),
+			Unit:           "hours",
+			UnitMultiplier: decimal.NewFromInt(1),
+			HourlyQuantity: decimalPtr(decimal.NewFromInt(1)),
+			ProductFilter: &schema.ProductFilter{
+				VendorName:    strPtr(vendorName),
+				Region:        strPtr(r.Region),
+				Service:       strPtr("ECS"),
+				ProductFamily: strPtr("Compute Instance"),
+				AttributeFilters: []*schema.AttributeFilter{
+					{Key: "instanceType", Value: strPtr(r.InstanceType)},
+				},
+			},
+		},
+		diskCostComponent("System disk", r.Region, r.SystemDisk),
+	}
+
+	for i, disk := range r.DataDisks {
+		costComponents = append(costComponents, diskCostComponent(fmt.Sprintf("Data disk #%d", i+1), r.Region, disk))
+	}
+
+	if r.InternetMaxBandwidthOut > 0 {
+		costComponents = append(costComponents, internetCostComponents(r.Region, r.InternetChargeType, r.InternetMaxBandwidthOut, r.MonthlyOutboundDataGB)...)
+	}
+
+	return &schema.Resource{
+		Name:           r.Address,
+		UsageSchema:    InstanceUsageSchema,
+		CostComponents: costComponents,
+	}
+}
+
+func diskCostComponent(name, region string, disk InstanceDisk) *schema.CostComponent {
+	attributeFilters := []*schema.AttributeFilter{
+		{Key: "category", Value: strPtr(disk.Category)},
+	}
+
+	if disk.Category == "cloud_essd" {
+		performanceLevel := disk.PerformanceLevel
+		if performanceLevel == "" {
+			performanceLevel = "PL1"
+		}
+
+		name = fmt.Sprintf("%s (%s %s)", name, disk.Category, performanceLevel)
+		attributeFilters = append(attributeFilters, &schema.AttributeFilter{Key: "performanceLevel", Value: strPtr(performanceLevel)})
+	} else {
+		name = fmt.Sprintf("%s (%s)", name, disk.Category)
+	}
+
+	return &schema.CostComponent{
+		Name:            name,
+		Unit:            "GB",
+		UnitMultiplier:  decimal.NewFromInt(1),
+		MonthlyQuantity: decimalPtr(decimal.NewFromFloat(disk.SizeGB)),
+		ProductFilter: &schema.ProductFilter{
+			VendorName:       strPtr(vendorName),
+			Region:           strPtr(region),
+			Service:          strPtr("ECS"),
+			ProductFamily:    strPtr("Storage"),
+			AttributeFilters: attributeFilters,
+		},
+	}
+}
+
+// internetCostComponents returns the cost components for public internet access which
+// is shared by ECS instances and SLB instances. Fixed bandwidth is charged per
+// Mbps-hour with a higher price for the bandwidth above 5Mbps.
+func internetCostComponents(region, chargeType string, bandwidthMbps float64, monthlyOutboundDataGB *float64) []*schema.CostComponent {
+	if strings.ToLower(chargeType) != internetChargeTypePayByBandwidth {
+		var quantity *decimal.Decimal
+		if monthlyOutboundDataGB != nil {
+			quantity = decimalPtr(decimal.NewFromFloat(*monthlyOutboundDataGB))
+		}
+
+		return []*schema.CostComponent{
+			{
+				Name:            "Outbound data transfer",
+				Unit:            "GB",
+				UnitMultiplier:  decimal.NewFromInt(1),
+				MonthlyQuantity: quantity,
+				ProductFilter:   internetProductFilter(region, "Traffic", ""),
+			},
+		}
+	}
+
+	firstTier := bandwidthMbps
+	if firstTier > internetBandwidthTierLimitMbps {
+		firstTier = internetBandwidthTierLimitMbps
+	}
+
+	costComponents := []*schema.CostComponent{
+		{
+			Name:           "Internet bandwidth (first 5 Mbps)",
+			Unit:           "Mbps",
+			UnitMultiplier: schema.HourToMonthUnitMultiplier,
+			HourlyQuantity: decimalPtr(decimal.NewFromFloat(firstTier)),
+			ProductFilter:  internetProductFilter(region, "Bandwidth", "0-5"),
+		},
+	}
+
+	if bandwidthMbps > internetBandwidthTierLimitMbps {
+		costComponents = append(costComponents, &schema.CostComponent{
+			Name:           "Internet bandwidth (over 5 Mbps)",
+			Unit:           "Mbps",
+			UnitMultiplier: schema.HourToMonthUnitMultiplier,
+			HourlyQuantity: decimalPtr(decimal.NewFromFloat(bandwidthMbps - internetBandwidthTierLimitMbps)),
+			ProductFilter:  internetProductFilter(region, "Bandwidth", "5+"),
+		})
+	}
+
+	return costComponents
+}
+
+func internetProductFilter(region, resource, tier string) *schema.ProductFilter {
+	attributeFilters := []*schema.AttributeFilter{
+		{Key: "resource", Value: strPtr(resource)},
+	}
+
+	if tier != "" {
+		attributeFilters = append(attributeFilters, &schema.AttributeFilter{Key: "tier", Value: strPtr(tier)})
+	}
+
+	return &schema.ProductFilter{
+		VendorName:       strPtr(vendorName),
+		Region:           strPtr(region),
+		Service:          strPtr("Internet"),
+		ProductFamily:    strPtr("Networking"),
+		AttributeFilters: attributeFilters,
+	}
+}
@@ -0,0 +1,99 @@
+package alicloud
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/infracost/infracost/internal/resources"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+const natGatewayChargeTypePayBySpec = "paybyspec"
+
+// NATGateway struct represents an Alibaba Cloud NAT gateway.
+//
+// Legacy NAT gateways (PayBySpec) are charged an hourly fee for their specification.
+// Enhanced NAT gateways (PayByLcu) are charged an hourly instance fee plus a fee for
+// each capacity unit (CU) used per hour.
+//
+// Resource information: https://www.alibabacloud.com/help/en/nat-gateway/
+// Pricing information: https://www.alibabacloud.com/product/nat/pricing
+type NATGateway struct {
+	Address            string
+	Region             string
+	InternetChargeType string
+	Specification      string
+
+	AverageCapacityUnits *float64 `infracost_usage:"average_capacity_units"`
+}
+
+// NATGatewayUsageSchema defines a list which represents the usage schema of NATGateway.
+var NATGatewayUsageSchema = []*schema.UsageItem{
+	{Key: "average_capacity_units", DefaultValue: 0, ValueType: schema.Float64},
+}
+
+// PopulateUsage parses the u schema.UsageData into the NATGateway.
+// It uses the `infracost_usage` struct tags to populate data into the NATGateway.
+func (r *NATGateway) PopulateUsage(u *schema.UsageData) {
+	resources.PopulateArgsWithUsage(r, u)
+}
+
+// BuildResource builds a schema.Resource from a valid NATGateway struct.
+// This method is called after the resource is initialised by an IaC provider.
+// See providers folder for more information.
+func (r *NATGateway) BuildResource() *schema.Resource {
+	var costComponents []*schema.CostComponent
+
+	if strings.ToLower(r.InternetChargeType) == natGatewayChargeTypePayBySpec {
+		costComponents = append(costComponents, &schema.CostComponent{
+			Name:           fmt.Sprintf("NAT gateway (%s)", r.Specification),
+			Unit:           "hours",
+			UnitMultiplier: decimal.NewFromInt(1),
+			HourlyQuantity: decimalPtr(decimal.NewFromInt(1)),
+			ProductFilter: r.productFilter([]*schema.AttributeFilter{
+				{Key: "resource", Value: strPtr("Specification")},
+				{Key: "specification", Value: strPtr(r.Specification)},
+			}),
+		})
+	} else {
+		var quantity *decimal.Decimal
+		if r.AverageCapacityUnits != nil {
+			quantity = decimalPtr(decimal.NewFromFloat(*r.AverageCapacityUnits))
+		}
+
+		costComponents = append(costComponents,
+			&schema.CostComponent{
+				Name:           "NAT gateway",
+				Unit:           "hours",
+				UnitMultiplier: decimal.NewFromInt(1),
+				HourlyQuantity: decimalPtr(decimal.NewFromInt(1)),
+				ProductFilter:  r.productFilter([]*schema.AttributeFilter{{Key: "resource", Value: strPtr("Instance")}}),
+			},
+			&schema.CostComponent{
+				Name:           "Capacity units",
+				Unit:           "CU",
+				UnitMultiplier: schema.HourToMonthUnitMultiplier,
+				HourlyQuantity: quantity,
+				ProductFilter:  r.productFilter([]*schema.AttributeFilter{{Key: "resource", Value: strPtr("CU")}}),
+			},
+		)
+	}
+
+	return &schema.Resource{
+		Name:           r.Address,
+		UsageSchema:    NATGatewayUsageSchema,
+		CostComponents: costComponents,
+	}
+}
+
+func (r *NATGateway) productFilter(attributeFilters []*schema.AttributeFilter) *schema.ProductFilter {
+	return &schema.ProductFilter{
+		VendorName:       strPtr(vendorName),
+		Region:           strPtr(r.Region),
+		Service:          strPtr("NAT Gateway"),
+		ProductFamily:    strPtr("Networking"),
+		AttributeFilters: attributeFilters,
+	}
+}
@@ -0,0 +1,144 @@
+package alicloud
+
+import (
+	"fmt"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/infracost/infracost/internal/resources"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+// OSSBucket struct represents an Alibaba Cloud Object Storage Service bucket.
+//
+// Buckets are charged per GB-month of storage depending on the storage class and
+// redundancy type, per 10K PUT and GET requests, per GB of outbound internet traffic
+// and, for the infrequent access and archive classes, per GB of data retrieved.
+//
+// Resource information: https://www.alibabacloud.com/help/en/oss/
+// Pricing information: https://www.alibabacloud.com/product/oss/pricing
+type OSSBucket struct {
+	Address        string
+	Region         string
+	StorageClass   string
+	RedundancyType string
+
+	StorageGB              *float64 `infracost_usage:"storage_gb"`
+	MonthlyPutRequests     *int64   `infracost_usage:"monthly_put_requests"`
+	MonthlyGetRequests     *int64   `infracost_usage:"monthly_get_requests"`
+	MonthlyDataRetrievalGB *float64 `infracost_usage:"monthly_data_retrieval_gb"`
+	MonthlyOutboundDataGB  *float64 `infracost_usage:"monthly_outbound_data_gb"`
+}
+
+// OSSBucketUsageSchema defines a list which represents the usage schema of OSSBucket.
+var OSSBucketUsageSchema = []*schema.UsageItem{
+	{Key: "storage_gb", DefaultValue: 0, ValueType: schema.Float64},
+	{Key: "monthly_put_requests", DefaultValue: 0, ValueType: schema.Int64},
+	{Key: "monthly_get_requests", DefaultValue: 0, ValueType: schema.Int64},
+	{Key: "monthly_data_retrieval_gb", DefaultValue: 0, ValueType: schema.Float64},
+	{Key: "monthly_outbound_data_gb", DefaultValue: 0, ValueType: schema.Float64},
+}
+
+// PopulateUsage parses the u schema.UsageData into the OSSBucket.
+// It uses the `infracost_usage` struct tags to populate data into the OSSBucket.
+func (r *OSSBucket) PopulateUsage(u *schema.UsageData) {
+	resources.PopulateArgsWithUsage(r, u)
+}
+
+// BuildResource builds a schema.Resource from a valid OSSBucket struct.
+// This method is called after the resource is initialised by an IaC provider.
+// See providers folder for more information.
+func (r *OSSBucket) BuildResource() *schema.Resource {
+	redundancyType := r.RedundancyType
+	if r.StorageClass == "ColdArchive" {
+		// Cold Archive is only available with locally redundant storage
+		redundancyType = "LRS"
+	}
+
+	var storage *decimal.Decimal
+	if r.StorageGB != nil {
+		storage = decimalPtr(decimal.NewFromFloat(*r.StorageGB))
+	}
+
+	costComponents := []*schema.CostComponent{
+		{
+			Name:            fmt.Sprintf("Storage (%s, %s)", r.StorageClass, redundancyType),
+			Unit:            "GB",
+			UnitMultiplier:  decimal.NewFromInt(1),
+			MonthlyQuantity: storage,
+			ProductFilter:   r.storageProductFilter(fmt.Sprintf("Storage (%s)", redundancyType)),
+		},
+		r.requestsCostComponent("PUT requests", r.MonthlyPutRequests),
+		r.requestsCostComponent("GET requests", r.MonthlyGetRequests),
+	}
+
+	if r.StorageClass != "Standard" {
+		var retrieval *decimal.Decimal
+		if r.MonthlyDataRetrievalGB != nil {
+			retrieval = decimalPtr(decimal.NewFromFloat(*r.MonthlyDataRetrievalGB))
+		}
+
+		costComponents = append(costComponents, &schema.CostComponent{
+			Name:            "Data retrieval",
+			Unit:            "GB",
+			UnitMultiplier:  decimal.NewFromInt(1),
+			MonthlyQuantity: retrieval,
+			ProductFilter:   r.storageProductFilter("Data retrieval"),
+		})
+	}
+
+	var outbound *decimal.Decimal
+	if r.MonthlyOutboundDataGB != nil {
+		outbound = decimalPtr(decimal.NewFromFloat(*r.MonthlyOutboundDataGB))
+	}
+
+	costComponents = append(costComponents, &schema.CostComponent{
+		Name:            "Outbound data transfer",
+		Unit:            "GB",
+		UnitMultiplier:  decimal.NewFromInt(1),
+		MonthlyQuantity: outbound,
+		ProductFilter: &schema.ProductFilter{
+			VendorName:    strPtr(vendorName),
+			Region:        strPtr(r.Region),
+			Service:       strPtr("OSS"),
+			ProductFamily: strPtr("Networking"),
+			AttributeFilters: []*schema.AttributeFilter{
+				{Key: "resource", Value: strPtr("Outbound traffic")},
+			},
+		},
+	})
+
+	return &schema.Resource{
+		Name:           r.Address,
+		UsageSchema:    OSSBucketUsageSchema,
+		CostComponents: costComponents,
+	}
+}
+
+func (r *OSSBucket) requestsCostComponent(name string, requests *int64) *schema.CostComponent {
+	var quantity *decimal.Decimal
+	if requests != nil {
+		quantity = decimalPtr(decimal.NewFromInt(*requests).Div(decimal.NewFromInt(10000)))
+	}
+
+	return &schema.CostComponent{
+		Name:            name,
+		Unit:            "10k requests",
+		UnitMultiplier:  decimal.NewFromInt(1),
+		MonthlyQuantity: quantity,
+		ProductFilter:   r.storageProductFilter(name),
+	}
+}
+
+func (r *OSSBucket) storageProductFilter(resource string) *schema.ProductFilter {
+	return &schema.ProductFilter{
+		VendorName:    strPtr(vendorName),
+		Region:        strPtr(r.Region),
+		Service:       strPtr("OSS"),
+		ProductFamily: strPtr("Storage"),
+		AttributeFilters: []*schema.AttributeFilter{
+			{Key: "storageClass", Value: strPtr(r.StorageClass)},
+			{Key: "resource", Value: strPtr(resource)},
+		},
+	}
+}
@@ -0,0 +1,108 @@
+package alicloud
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/infracost/infracost/internal/resources"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+const slbChargeTypePayByCLCU = "paybyclcu"
+
+// SLBLoadBalancer struct represents an Alibaba Cloud Server Load Balancer (CLB)
+// instance.
+//
+// Load balancers are charged an hourly instance fee plus either an hourly
+// specification fee (PayBySpec) or a fee per load balancer capacity unit (PayByCLCU).
+// Internet facing load balancers are also charged for public internet access.
+//
+// Resource information: https://www.alibabacloud.com/help/en/slb/
+// Pricing information: https://www.alibabacloud.com/product/server-load-balancer/pricing
+type SLBLoadBalancer struct {
+	Address            string
+	Region             string
+	Specification      string
+	InstanceChargeType string
+	AddressType        string
+	InternetChargeType string
+	BandwidthMbps      float64
+
+	AverageCapacityUnits  *float64 `infracost_usage:"average_capacity_units"`
+	MonthlyOutboundDataGB *float64 `infracost_usage:"monthly_outbound_data_gb"`
+}
+
+// SLBLoadBalancerUsageSchema defines a list which represents the usage schema of SLBLoadBalancer.
+var SLBLoadBalancerUsageSchema = []*schema.UsageItem{
+	{Key: "average_capacity_units", DefaultValue: 0, ValueType: schema.Float64},
+	{Key: "monthly_outbound_data_gb", DefaultValue: 0, ValueType: schema.Float64},
+}
+
+// PopulateUsage parses the u schema.UsageData into the SLBLoadBalancer.
+// It uses the `infracost_usage` struct tags to populate data into the SLBLoadBalancer.
+func (r *SLBLoadBalancer) PopulateUsage(u *schema.UsageData) {
+	resources.PopulateArgsWithUsage(r, u)
+}
+
+// BuildResource builds a schema.Resource from a valid SLBLoadBalancer struct.
+// This method is called after the resource is initialised by an IaC provider.
+// See providers folder for more information.
+func (r *SLBLoadBalancer) BuildResource() *schema.Resource {
+	costComponents := []*schema.CostComponent{
+		{
+			Name:           "Instance fee",
+			Unit:           "hours",
+			UnitMultiplier: decimal.NewFromInt(1),
+			HourlyQuantity: decimalPtr(decimal.NewFromInt(1)),
+			ProductFilter:  r.productFilter([]*schema.AttributeFilter{{Key: "resource", Value: strPtr("Instance")}}),
+		},
+	}
+
+	if strings.ToLower(r.InstanceChargeType) == slbChargeTypePayByCLCU {
+		var quantity *decimal.Decimal
+		if r.AverageCapacityUnits != nil {
+			quantity = decimalPtr(decimal.NewFromFloat(*r.AverageCapacityUnits))
+		}
+
+		costComponents = append(costComponents, &schema.CostComponent{
+			Name:           "Capacity units",
+			Unit:           "LCU",
+			UnitMultiplier: schema.HourToMonthUnitMultiplier,
+			HourlyQuantity: quantity,
+			ProductFilter:  r.productFilter([]*schema.AttributeFilter{{Key: "resource", Value: strPtr("LCU")}}),
+		})
+	} else if r.Specification != "" {
+		costComponents = append(costComponents, &schema.CostComponent{
+			Name:           fmt.Sprintf("Specification fee (%s)", r.Specification),
+			Unit:           "hours",
+			UnitMultiplier: decimal.NewFromInt(1),
+			HourlyQuantity: decimalPtr(decimal.NewFromInt(1)),
+			ProductFilter: r.productFilter([]*schema.AttributeFilter{
+				{Key: "resource", Value: strPtr("Specification")},
+				{Key: "specification", Value: strPtr(r.Specification)},
+			}),
+		})
+	}
+
+	if strings.ToLower(r.AddressType) == "internet" {
+		costComponents = append(costComponents, internetCostComponents(r.Region, r.InternetChargeType, r.BandwidthMbps, r.MonthlyOutboundDataGB)...)
+	}
+
+	return &schema.Resource{
+		Name:           r.Address,
+		UsageSchema:    SLBLoadBalancerUsageSchema,
+		CostComponents: costComponents,
+	}
+}
+
+func (r *SLBLoadBalancer) productFilter(attributeFilters []*schema.AttributeFilter) *schema.ProductFilter {
+	return &schema.ProductFilter{
+		VendorName:       strPtr(vendorName),
+		Region:           strPtr(r.Region),
+		Service:          strPtr("SLB"),
+		ProductFamily:    strPtr("Networking"),
+		AttributeFilters: attributeFilters,
+	}
+}
@@ -0,0 +1,17 @@
+package alicloud
+
+import (
+	"github.com/shopspring/decimal"
+)
+
+const (
+	vendorName = "alicloud"
+)
+
+func strPtr(s string) *string {
+	return &s
+}
+
+func decimalPtr(d decimal.Decimal) *decimal.Decimal {
+	return &d
+}
@@ -32,6 +32,9 @@ type DynamoDBTable struct {
 	OnDemandBackupStorageGB        *int64 `infracost_usage:"on_demand_backup_storage_gb"`
 	MonthlyDataRestoredGB          *int64 `infracost_usage:"monthly_data_restored_gb"`
 	MonthlyStreamsReadRequestUnits *int64 `infracost_usage:"monthly_streams_read_request_units"`
+
+	ReadCapacityUtilizationPercent  *float64 `infracost_usage:"read_capacity_utilization_percent"`
+	WriteCapacityUtilizationPercent *float64 `infracost_usage:"write_capacity_utilization_percent"`
 }
 
 var DynamoDBTableUsageSchema = []*schema.UsageItem{
@@ -42,6 +45,8 @@ var DynamoDBTableUsageSchema = []*schema.UsageItem{
 	{Key: "on_demand_backup_storage_gb", DefaultValue: 0, ValueType: schema.Int64},
 	{Key: "monthly_data_restored_gb", DefaultValue: 0, ValueType: schema.Int64},
 	{Key: "monthly_streams_read_request_units", DefaultValue: 0, ValueType: schema.Int64},
+	{Key: "read_capacity_utilization_percent", DefaultValue: 0.0, ValueType: schema.Float64},
+	{Key: "write_capacity_utilization_percent", DefaultValue: 0.0, ValueType: schema.Float64},
 }
 
 func (a *DynamoDBTable) PopulateUsage(u *schema.UsageData) {
@@ -125,15 +130,49 @@ func (a *DynamoDBTable) BuildResource() *schema.Resource {
 			values["monthly_read_request_units"] = ceil64(reads)
 			values["monthly_write_request_units"] = ceil64(writes)
 		}
+
+		if a.BillingMode == "PROVISIONED" {
+			if a.ReadCapacity != nil {
+				utilization, err := aws.DynamoDBGetCapacityUtilization(ctx, a.Region, a.Name, "ConsumedReadCapacityUnits", *a.ReadCapacity)
+				if err != nil {
+					return err
+				}
+				values["read_capacity_utilization_percent"] = utilization
+			}
+			if a.WriteCapacity != nil {
+				utilization, err := aws.DynamoDBGetCapacityUtilization(ctx, a.Region, a.Name, "ConsumedWriteCapacityUnits", *a.WriteCapacity)
+				if err != nil {
+					return err
+				}
+				values["write_capacity_utilization_percent"] = utilization
+			}
+		}
 		return nil
 	}
 
+	var utilization map[string]float64
+	if a.BillingMode == "PROVISIONED" {
+		for key, value := range map[string]*float64{
+			"read_capacity_utilization_percent":  a.ReadCapacityUtilizationPercent,
+			"write_capacity_utilization_percent": a.WriteCapacityUtilizationPercent,
+		} {
+			if value == nil {
+				continue
+			}
+			if utilization == nil {
+				utilization = make(map[string]float64)
+			}
+			utilization[key] = *value
+		}
+	}
+
 	return &schema.Resource{
 		Name:           a.Address,
 		UsageSchema:    DynamoDBTableUsageSchema,
 		EstimateUsage:  estimate,
 		CostComponents: costComponents,
 		SubResources:   subResources,
+		Utilization:    utilization,
 	}
 }
 
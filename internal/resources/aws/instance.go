@@ -19,6 +19,7 @@ type Instance struct {
 	// "required" args that can't really be missing.
 	Address          string
 	Region           string
+	ID               string
 	Tenancy          string
 	PurchaseOption   string
 	AMI              string
@@ -33,12 +34,23 @@ type Instance struct {
 	EBSBlockDevices                 []*EBSVolume
 
 	// "usage" args
-	OperatingSystem               *string `infracost_usage:"operating_system"`
-	ReservedInstanceType          *string `infracost_usage:"reserved_instance_type"`
-	ReservedInstanceTerm          *string `infracost_usage:"reserved_instance_term"`
-	ReservedInstancePaymentOption *string `infracost_usage:"reserved_instance_payment_option"`
-	MonthlyCPUCreditHours         *int64  `infracost_usage:"monthly_cpu_credit_hrs"`
-	VCPUCount                     *int64  `infracost_usage:"vcpu_count"`
+	OperatingSystem               *string  `infracost_usage:"operating_system"`
+	ReservedInstanceType          *string  `infracost_usage:"reserved_instance_type"`
+	ReservedInstanceTerm          *string  `infracost_usage:"reserved_instance_term"`
+	ReservedInstancePaymentOption *string  `infracost_usage:"reserved_instance_payment_option"`
+	MonthlyCPUCreditHours         *int64   `infracost_usage:"monthly_cpu_credit_hrs"`
+	VCPUCount                     *int64   `infracost_usage:"vcpu_count"`
+	SpotUptimePercent             *float64 `infracost_usage:"spot_uptime_percent"`
+	CPUUtilizationPercent         *float64 `infracost_usage:"cpu_utilization_percent"`
+	MaxCPUUtilizationPercent      *float64 `infracost_usage:"max_cpu_utilization_percent"`
+}
+
+// instanceUtilizationUsageSchema are the usage keys of the utilization of
+// instances that exist, which aren't shared with autoscaling groups and launch
+// templates.
+var instanceUtilizationUsageSchema = []*schema.UsageItem{
+	{Key: "cpu_utilization_percent", DefaultValue: 0.0, ValueType: schema.Float64},
+	{Key: "max_cpu_utilization_percent", DefaultValue: 0.0, ValueType: schema.Float64},
 }
 
 var InstanceUsageSchema = []*schema.UsageItem{
@@ -48,6 +60,7 @@ var InstanceUsageSchema = []*schema.UsageItem{
 	{Key: "reserved_instance_payment_option", DefaultValue: "", ValueType: schema.String},
 	{Key: "monthly_cpu_credit_hrs", DefaultValue: 0, ValueType: schema.Int64},
 	{Key: "vcpu_count", DefaultValue: 0, ValueType: schema.Int64},
+	{Key: "spot_uptime_percent", DefaultValue: 100, ValueType: schema.Float64},
 }
 
 func (a *Instance) PopulateUsage(u *schema.UsageData) {
@@ -117,15 +130,37 @@ func (a *Instance) BuildResource() *schema.Resource {
 				values["operating_system"] = platform
 			}
 		}
+
+		// The ID is only known once the instance exists, so there's no
+		// utilization before then
+		if a.ID != "" {
+			average, maximum, err := aws.EC2GetCPUUtilization(ctx, a.Region, a.ID)
+			if err != nil {
+				return err
+			}
+			if average > 0 {
+				values["cpu_utilization_percent"] = average
+				values["max_cpu_utilization_percent"] = maximum
+			}
+		}
 		return nil
 	}
 
+	var utilization map[string]float64
+	if a.CPUUtilizationPercent != nil {
+		utilization = map[string]float64{"cpu_utilization_percent": *a.CPUUtilizationPercent}
+		if a.MaxCPUUtilizationPercent != nil {
+			utilization["max_cpu_utilization_percent"] = *a.MaxCPUUtilizationPercent
+		}
+	}
+
 	return &schema.Resource{
 		Name:           a.Address,
-		UsageSchema:    InstanceUsageSchema,
+		UsageSchema:    append(append([]*schema.UsageItem{}, InstanceUsageSchema...), instanceUtilizationUsageSchema...),
 		CostComponents: costComponents,
 		SubResources:   subResources,
 		EstimateUsage:  estimate,
+		Utilization:    utilization,
 	}
 }
 
@@ -165,11 +200,17 @@ func (a *Instance) computeCostComponent() *schema.CostComponent {
 		}
 	}
 
-	return &schema.CostComponent{
+	// Spot instances only run until they're interrupted
+	hourlyQuantity := decimal.NewFromInt(1)
+	if a.PurchaseOption == "spot" && a.SpotUptimePercent != nil {
+		hourlyQuantity = decimal.NewFromFloat(*a.SpotUptimePercent).Div(decimal.NewFromInt(100))
+	}
+
+	c := &schema.CostComponent{
 		Name:           fmt.Sprintf("Instance usage (%s, %s, %s)", osLabel, purchaseOptionLabel, a.InstanceType),
 		Unit:           "hours",
 		UnitMultiplier: decimal.NewFromInt(1),
-		HourlyQuantity: decimalPtr(decimal.NewFromInt(1)),
+		HourlyQuantity: decimalPtr(hourlyQuantity),
 		ProductFilter: &schema.ProductFilter{
 			VendorName:    strPtr("aws"),
 			Region:        strPtr(a.Region),
@@ -188,6 +229,14 @@ func (a *Instance) computeCostComponent() *schema.CostComponent {
 			PurchaseOption: strPtr(a.PurchaseOption),
 		},
 	}
+
+	if a.PurchaseOption == "spot" {
+		onDemand := *c
+		onDemand.PriceFilter = &schema.PriceFilter{PurchaseOption: strPtr("on_demand")}
+		c.SpotFallback = &onDemand
+	}
+
+	return c
 }
 
 func (a *Instance) validateReserveInstanceParams() (bool, string) {
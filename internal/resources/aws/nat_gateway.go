@@ -1,14 +1,18 @@
 package aws
 
 import (
+	"context"
+
 	"github.com/infracost/infracost/internal/resources"
 	"github.com/infracost/infracost/internal/schema"
+	"github.com/infracost/infracost/internal/usage/aws"
 	"github.com/shopspring/decimal"
 )
 
 type NATGateway struct {
 	Address string
 	Region  string
+	ID      string
 
 	MonthlyDataProcessedGB *float64 `infracost_usage:"monthly_data_processed_gb"`
 }
@@ -27,9 +31,24 @@ func (a *NATGateway) BuildResource() *schema.Resource {
 		gbDataProcessed = decimalPtr(decimal.NewFromFloat(*a.MonthlyDataProcessedGB))
 	}
 
+	// The ID is only known once the NAT gateway exists, so there's nothing to
+	// estimate from before then
+	var estimate schema.EstimateFunc
+	if a.ID != "" {
+		estimate = func(ctx context.Context, values map[string]interface{}) error {
+			bytes, err := aws.NATGatewayGetDataProcessedBytes(ctx, a.Region, a.ID)
+			if err != nil {
+				return err
+			}
+			values["monthly_data_processed_gb"] = bytes / 1000 / 1000 / 1000
+			return nil
+		}
+	}
+
 	return &schema.Resource{
-		Name:        a.Address,
-		UsageSchema: NATGatewayUsageSchema,
+		Name:          a.Address,
+		UsageSchema:   NATGatewayUsageSchema,
+		EstimateUsage: estimate,
 		CostComponents: []*schema.CostComponent{
 			{
 				Name:           "NAT gateway",
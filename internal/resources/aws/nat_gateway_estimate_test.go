@@ -0,0 +1,46 @@
+package aws_test
+
+import (
+	"testing"
+
+	resources "github.com/infracost/infracost/internal/resources/aws"
+	"github.com/stretchr/testify/assert"
+)
+
+func natGatewayBytesResponse(sum string) string {
+	return `
+		<GetMetricStatisticsResponse xmlns="http://monitoring.amazonaws.com/doc/2010-08-01/">
+		  <GetMetricStatisticsResult>
+		    <Datapoints>
+		      <member>
+		        <Unit>Bytes</Unit>
+		        <Sum>` + sum + `</Sum>
+		        <Timestamp>1970-01-01T00:00:00Z</Timestamp>
+		      </member>
+		    </Datapoints>
+		  </GetMetricStatisticsResult>
+		  <ResponseMetadata>
+		    <RequestId>00000000-0000-0000-0000-000000000000</RequestId>
+		  </ResponseMetadata>
+		</GetMetricStatisticsResponse>
+	`
+}
+
+func TestNATGatewayDataProcessed(t *testing.T) {
+	stub := stubAWS(t)
+	defer stub.Close()
+
+	stub.WhenBody("GetMetricStatistics", "MetricName=BytesInFromSource", "nat-0123456789").Then(200, natGatewayBytesResponse("3000000000.0"))
+	stub.WhenBody("GetMetricStatistics", "MetricName=BytesInFromDestination", "nat-0123456789").Then(200, natGatewayBytesResponse("1500000000.0"))
+
+	args := &resources.NATGateway{ID: "nat-0123456789"}
+	resource := args.BuildResource()
+	estimates := newEstimates(stub.ctx, t, resource)
+	assert.Equal(t, 4.5, estimates.usage["monthly_data_processed_gb"])
+}
+
+func TestNATGatewayWithoutID(t *testing.T) {
+	args := &resources.NATGateway{}
+	resource := args.BuildResource()
+	assert.Nil(t, resource.EstimateUsage)
+}
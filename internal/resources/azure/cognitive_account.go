@@ -0,0 +1,62 @@
+package azure
+
+import (
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/infracost/infracost/internal/resources"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+const (
+	cognitiveServicesServiceName   = "Cognitive Services"
+	cognitiveServicesProductFamily = "AI + Machine Learning"
+
+	cognitiveAccountKindOpenAI = "openai"
+)
+
+// CognitiveAccount struct represents an Azure Cognitive Services account. An account
+// is the container that models are deployed into, it doesn't have a cost of its own
+// for the OpenAI kind as all the charges are made against the deployments.
+//
+// Resource information: https://azure.microsoft.com/en-us/products/cognitive-services/
+// Pricing information: https://azure.microsoft.com/en-us/pricing/details/cognitive-services/openai-service/
+type CognitiveAccount struct {
+	Address string
+	Region  string
+	Kind    string
+	SKUName string
+}
+
+// CognitiveAccountUsageSchema defines a list which represents the usage schema of CognitiveAccount.
+var CognitiveAccountUsageSchema = []*schema.UsageItem{}
+
+// PopulateUsage parses the u schema.UsageData into the CognitiveAccount.
+// It uses the `infracost_usage` struct tags to populate data into the CognitiveAccount.
+func (r *CognitiveAccount) PopulateUsage(u *schema.UsageData) {
+	resources.PopulateArgsWithUsage(r, u)
+}
+
+// BuildResource builds a schema.Resource from a valid CognitiveAccount struct.
+// OpenAI accounts are returned as free resources since their costs are reported by the
+// azurerm_cognitive_deployment resources. Other kinds of accounts are not supported yet
+// and are marked as skipped.
+func (r *CognitiveAccount) BuildResource() *schema.Resource {
+	if strings.ToLower(r.Kind) == cognitiveAccountKindOpenAI {
+		return &schema.Resource{
+			Name:        r.Address,
+			IsSkipped:   true,
+			NoPrice:     true,
+			UsageSchema: CognitiveAccountUsageSchema,
+		}
+	}
+
+	log.Warnf("Skipping resource %s. Kind %s is not supported yet", r.Address, r.Kind)
+
+	return &schema.Resource{
+		Name:        r.Address,
+		IsSkipped:   true,
+		UsageSchema: CognitiveAccountUsageSchema,
+	}
+}
@@ -0,0 +1,137 @@
+package azure
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/infracost/infracost/internal/resources"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+const (
+	cognitiveDeploymentSKUGlobalStandard    = "globalstandard"
+	cognitiveDeploymentSKUProvisioned       = "provisionedmanaged"
+	cognitiveDeploymentSKUGlobalProvisioned = "globalprovisionedmanaged"
+)
+
+var cognitiveModelNameSeparators = regexp.MustCompile(`[-._ ]+`)
+
+// CognitiveDeployment struct represents a model deployment inside an Azure OpenAI
+// cognitive account. Deployments are billed in one of two ways:
+//
+//  1. Pay-as-you-go (Standard and GlobalStandard SKUs) which charges per 1K input
+//     and output tokens processed by the model.
+//  2. Provisioned throughput (ProvisionedManaged SKUs) which charges an hourly rate
+//     for each provisioned throughput unit (PTU) reserved for the deployment.
+//
+// Resource information: https://learn.microsoft.com/en-us/azure/cognitive-services/openai/how-to/create-resource
+// Pricing information: https://azure.microsoft.com/en-us/pricing/details/cognitive-services/openai-service/
+type CognitiveDeployment struct {
+	Address      string
+	Region       string
+	SKUName      string
+	Capacity     int64
+	ModelName    string
+	ModelVersion string
+
+	MonthlyInputTokens  *int64 `infracost_usage:"monthly_input_tokens"`
+	MonthlyOutputTokens *int64 `infracost_usage:"monthly_output_tokens"`
+}
+
+// CognitiveDeploymentUsageSchema defines a list which represents the usage schema of CognitiveDeployment.
+var CognitiveDeploymentUsageSchema = []*schema.UsageItem{
+	{Key: "monthly_input_tokens", DefaultValue: 0, ValueType: schema.Int64},
+	{Key: "monthly_output_tokens", DefaultValue: 0, ValueType: schema.Int64},
+}
+
+// PopulateUsage parses the u schema.UsageData into the CognitiveDeployment.
+// It uses the `infracost_usage` struct tags to populate data into the CognitiveDeployment.
+func (r *CognitiveDeployment) PopulateUsage(u *schema.UsageData) {
+	resources.PopulateArgsWithUsage(r, u)
+}
+
+// BuildResource builds a schema.Resource from a valid CognitiveDeployment struct.
+// Provisioned deployments return a single PTU cost component, all other SKUs are
+// priced using the input and output token usage parameters.
+func (r *CognitiveDeployment) BuildResource() *schema.Resource {
+	var costComponents []*schema.CostComponent
+
+	switch strings.ToLower(r.SKUName) {
+	case cognitiveDeploymentSKUProvisioned, cognitiveDeploymentSKUGlobalProvisioned:
+		costComponents = append(costComponents, r.provisionedThroughputCostComponent())
+	default:
+		costComponents = append(costComponents,
+			r.tokensCostComponent("Input tokens", "Inp", r.MonthlyInputTokens),
+			r.tokensCostComponent("Output tokens", "Outp", r.MonthlyOutputTokens),
+		)
+	}
+
+	return &schema.Resource{
+		Name:           r.Address,
+		UsageSchema:    CognitiveDeploymentUsageSchema,
+		CostComponents: costComponents,
+	}
+}
+
+func (r *CognitiveDeployment) provisionedThroughputCostComponent() *schema.CostComponent {
+	meterName := "Provisioned Managed Unit"
+	if strings.ToLower(r.SKUName) == cognitiveDeploymentSKUGlobalProvisioned {
+		meterName = "Global Provisioned Managed Unit"
+	}
+
+	return &schema.CostComponent{
+		Name:           fmt.Sprintf("Provisioned throughput (%s)", r.ModelName),
+		Unit:           "PTU-hours",
+		UnitMultiplier: decimal.NewFromInt(1),
+		HourlyQuantity: decimalPtr(decimal.NewFromInt(r.Capacity)),
+		ProductFilter: &schema.ProductFilter{
+			VendorName:    strPtr(vendorName),
+			Region:        strPtr(r.Region),
+			Service:       strPtr(cognitiveServicesServiceName),
+			ProductFamily: strPtr(cognitiveServicesProductFamily),
+			AttributeFilters: []*schema.AttributeFilter{
+				{Key: "productName", Value: strPtr("Azure OpenAI")},
+				{Key: "meterName", Value: strPtr(meterName)},
+			},
+		},
+		PriceFilter: priceFilterConsumption,
+	}
+}
+
+// tokensCostComponent returns a pay-as-you-go token cost component. Azure meters
+// tokens with names such as "gpt-4o-0513-Inp-regnl Tokens" so we match on the
+// normalized model name and the direction of the tokens.
+func (r *CognitiveDeployment) tokensCostComponent(name, direction string, tokens *int64) *schema.CostComponent {
+	var quantity *decimal.Decimal
+	if tokens != nil {
+		quantity = decimalPtr(decimal.NewFromInt(*tokens).Div(decimal.NewFromInt(1000)))
+	}
+
+	scope := "regnl"
+	if strings.ToLower(r.SKUName) == cognitiveDeploymentSKUGlobalStandard {
+		scope = "glbl"
+	}
+
+	model := cognitiveModelNameSeparators.ReplaceAllString(r.ModelName, "[-. ]?")
+
+	return &schema.CostComponent{
+		Name:            fmt.Sprintf("%s (%s)", name, r.ModelName),
+		Unit:            "1K tokens",
+		UnitMultiplier:  decimal.NewFromInt(1),
+		MonthlyQuantity: quantity,
+		ProductFilter: &schema.ProductFilter{
+			VendorName:    strPtr(vendorName),
+			Region:        strPtr(r.Region),
+			Service:       strPtr(cognitiveServicesServiceName),
+			ProductFamily: strPtr(cognitiveServicesProductFamily),
+			AttributeFilters: []*schema.AttributeFilter{
+				{Key: "productName", Value: strPtr("Azure OpenAI")},
+				{Key: "meterName", ValueRegex: regexPtr(fmt.Sprintf("^%s.*%s.*%s", model, direction, scope))},
+			},
+		},
+		PriceFilter: priceFilterConsumption,
+	}
+}
@@ -0,0 +1,141 @@
+package azure
+
+import (
+	"strings"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/infracost/infracost/internal/resources"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+const (
+	containerAppsServiceName = "Azure Container Apps"
+	containerAppsProductName = "Azure Container Apps"
+
+	containerAppConsumptionProfile = "consumption"
+	containerAppSecondsPerMonth    = 730 * 60 * 60
+)
+
+// ContainerApp struct represents an Azure Container App.
+//
+// Apps that run on the consumption workload profile are charged per vCPU-second and
+// GiB-second of the replicas as well as per million requests. Replicas that are not
+// processing requests are charged at a reduced idle rate, the duty_cycle_percent usage
+// param controls the split between active and idle usage.
+//
+// Apps that run on a dedicated workload profile are charged through the instances of
+// the profile, which are reported by the azurerm_container_app_environment resource.
+//
+// Resource information: https://learn.microsoft.com/en-us/azure/container-apps/overview
+// Pricing information: https://azure.microsoft.com/en-us/pricing/details/container-apps/
+type ContainerApp struct {
+	Address             string
+	Region              string
+	WorkloadProfileName string
+	MinReplicas         int64
+	CPU                 float64
+	MemoryGiB           float64
+
+	AverageReplicaCount *float64 `infracost_usage:"average_replica_count"`
+	DutyCyclePercent    *float64 `infracost_usage:"duty_cycle_percent"`
+	MonthlyRequests     *int64   `infracost_usage:"monthly_requests"`
+}
+
+// ContainerAppUsageSchema defines a list which represents the usage schema of ContainerApp.
+var ContainerAppUsageSchema = []*schema.UsageItem{
+	{Key: "average_replica_count", DefaultValue: 0, ValueType: schema.Float64},
+	{Key: "duty_cycle_percent", DefaultValue: 0, ValueType: schema.Float64},
+	{Key: "monthly_requests", DefaultValue: 0, ValueType: schema.Int64},
+}
+
+// PopulateUsage parses the u schema.UsageData into the ContainerApp.
+// It uses the `infracost_usage` struct tags to populate data into the ContainerApp.
+func (r *ContainerApp) PopulateUsage(u *schema.UsageData) {
+	resources.PopulateArgsWithUsage(r, u)
+}
+
+// BuildResource builds a schema.Resource from a valid ContainerApp struct.
+// Apps on dedicated workload profiles are returned as free resources since
+// their cost is included in the environment.
+func (r *ContainerApp) BuildResource() *schema.Resource {
+	if r.WorkloadProfileName != "" && strings.ToLower(r.WorkloadProfileName) != containerAppConsumptionProfile {
+		return &schema.Resource{
+			Name:        r.Address,
+			IsSkipped:   true,
+			NoPrice:     true,
+			UsageSchema: ContainerAppUsageSchema,
+		}
+	}
+
+	var activeVCPU, idleVCPU, activeMemory, idleMemory *decimal.Decimal
+
+	replicaSeconds := r.replicaSeconds()
+	if replicaSeconds != nil {
+		activePerc := decimal.NewFromInt(100)
+		if r.DutyCyclePercent != nil {
+			activePerc = decimal.NewFromFloat(*r.DutyCyclePercent)
+		}
+		active := replicaSeconds.Mul(activePerc).Div(decimal.NewFromInt(100))
+		idle := replicaSeconds.Sub(active)
+
+		activeVCPU = decimalPtr(active.Mul(decimal.NewFromFloat(r.CPU)))
+		idleVCPU = decimalPtr(idle.Mul(decimal.NewFromFloat(r.CPU)))
+		activeMemory = decimalPtr(active.Mul(decimal.NewFromFloat(r.MemoryGiB)))
+		idleMemory = decimalPtr(idle.Mul(decimal.NewFromFloat(r.MemoryGiB)))
+	}
+
+	var requests *decimal.Decimal
+	if r.MonthlyRequests != nil {
+		requests = decimalPtr(decimal.NewFromInt(*r.MonthlyRequests).Div(decimal.NewFromInt(1000000)))
+	}
+
+	costComponents := []*schema.CostComponent{
+		r.consumptionCostComponent("vCPU (active)", "vCPU-seconds", "Standard vCPU Active Usage", activeVCPU),
+		r.consumptionCostComponent("vCPU (idle)", "vCPU-seconds", "Standard vCPU Idle Usage", idleVCPU),
+		r.consumptionCostComponent("Memory (active)", "GiB-seconds", "Standard Memory Active Usage", activeMemory),
+		r.consumptionCostComponent("Memory (idle)", "GiB-seconds", "Standard Memory Idle Usage", idleMemory),
+		r.consumptionCostComponent("Requests", "1M requests", "Standard Requests", requests),
+	}
+
+	return &schema.Resource{
+		Name:           r.Address,
+		UsageSchema:    ContainerAppUsageSchema,
+		CostComponents: costComponents,
+	}
+}
+
+// replicaSeconds returns the number of replica seconds the app runs for in a month.
+// If no replica count is given in the usage file we fall back to the minimum replicas
+// configured for the app, since apps that can scale to zero have no baseline cost
+// this returns nil in that case.
+func (r *ContainerApp) replicaSeconds() *decimal.Decimal {
+	replicas := decimal.NewFromInt(r.MinReplicas)
+	if r.AverageReplicaCount != nil {
+		replicas = decimal.NewFromFloat(*r.AverageReplicaCount)
+	} else if r.MinReplicas == 0 {
+		return nil
+	}
+
+	return decimalPtr(replicas.Mul(decimal.NewFromInt(containerAppSecondsPerMonth)))
+}
+
+func (r *ContainerApp) consumptionCostComponent(name, unit, meterName string, quantity *decimal.Decimal) *schema.CostComponent {
+	return &schema.CostComponent{
+		Name:            name,
+		Unit:            unit,
+		UnitMultiplier:  decimal.NewFromInt(1),
+		MonthlyQuantity: quantity,
+		ProductFilter: &schema.ProductFilter{
+			VendorName:    strPtr(vendorName),
+			Region:        strPtr(r.Region),
+			Service:       strPtr(containerAppsServiceName),
+			ProductFamily: strPtr("Containers"),
+			AttributeFilters: []*schema.AttributeFilter{
+				{Key: "productName", Value: strPtr(containerAppsProductName)},
+				{Key: "meterName", Value: strPtr(meterName)},
+			},
+		},
+		PriceFilter: priceFilterConsumption,
+	}
+}
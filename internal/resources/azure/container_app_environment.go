@@ -0,0 +1,117 @@
+package azure
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/shopspring/decimal"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/infracost/infracost/internal/resources"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+// containerAppWorkloadProfileSpecs maps dedicated workload profile types to their
+// vCPU and memory (GiB) allocation per instance.
+var containerAppWorkloadProfileSpecs = map[string][2]int64{
+	"D4":  {4, 16},
+	"D8":  {8, 32},
+	"D16": {16, 64},
+	"D32": {32, 128},
+	"E4":  {4, 32},
+	"E8":  {8, 64},
+	"E16": {16, 128},
+	"E32": {32, 256},
+}
+
+// ContainerAppWorkloadProfile represents a dedicated workload profile of a
+// ContainerAppEnvironment.
+type ContainerAppWorkloadProfile struct {
+	Name          string
+	Type          string
+	InstanceCount int64
+}
+
+// ContainerAppEnvironment struct represents an Azure Container Apps environment.
+// Environments with only the consumption profile are free. Dedicated workload profiles
+// incur a fixed plan management charge plus an hourly vCPU and memory charge for each
+// running instance of the profile.
+//
+// Resource information: https://learn.microsoft.com/en-us/azure/container-apps/environment
+// Pricing information: https://azure.microsoft.com/en-us/pricing/details/container-apps/
+type ContainerAppEnvironment struct {
+	Address          string
+	Region           string
+	WorkloadProfiles []ContainerAppWorkloadProfile
+}
+
+// ContainerAppEnvironmentUsageSchema defines a list which represents the usage schema of ContainerAppEnvironment.
+var ContainerAppEnvironmentUsageSchema = []*schema.UsageItem{}
+
+// PopulateUsage parses the u schema.UsageData into the ContainerAppEnvironment.
+// It uses the `infracost_usage` struct tags to populate data into the ContainerAppEnvironment.
+func (r *ContainerAppEnvironment) PopulateUsage(u *schema.UsageData) {
+	resources.PopulateArgsWithUsage(r, u)
+}
+
+// BuildResource builds a schema.Resource from a valid ContainerAppEnvironment struct.
+func (r *ContainerAppEnvironment) BuildResource() *schema.Resource {
+	var costComponents []*schema.CostComponent
+
+	for _, p := range r.WorkloadProfiles {
+		if strings.ToLower(p.Type) == containerAppConsumptionProfile {
+			continue
+		}
+
+		spec, ok := containerAppWorkloadProfileSpecs[strings.ToUpper(p.Type)]
+		if !ok {
+			log.Warnf("Skipping workload profile %s of resource %s. Type %s is not supported yet", p.Name, r.Address, p.Type)
+			continue
+		}
+
+		instances := decimal.NewFromInt(p.InstanceCount)
+		costComponents = append(costComponents,
+			r.dedicatedCostComponent(fmt.Sprintf("Workload profile vCPU (%s, %s)", p.Name, p.Type), "vCPU-hours", "Dedicated vCPU Usage", instances.Mul(decimal.NewFromInt(spec[0]))),
+			r.dedicatedCostComponent(fmt.Sprintf("Workload profile memory (%s, %s)", p.Name, p.Type), "GiB-hours", "Dedicated Memory Usage", instances.Mul(decimal.NewFromInt(spec[1]))),
+		)
+	}
+
+	if len(costComponents) == 0 {
+		return &schema.Resource{
+			Name:        r.Address,
+			IsSkipped:   true,
+			NoPrice:     true,
+			UsageSchema: ContainerAppEnvironmentUsageSchema,
+		}
+	}
+
+	costComponents = append([]*schema.CostComponent{
+		r.dedicatedCostComponent("Dedicated plan management", "hours", "Dedicated Plan Management", decimal.NewFromInt(1)),
+	}, costComponents...)
+
+	return &schema.Resource{
+		Name:           r.Address,
+		UsageSchema:    ContainerAppEnvironmentUsageSchema,
+		CostComponents: costComponents,
+	}
+}
+
+func (r *ContainerAppEnvironment) dedicatedCostComponent(name, unit, meterName string, quantity decimal.Decimal) *schema.CostComponent {
+	return &schema.CostComponent{
+		Name:           name,
+		Unit:           unit,
+		UnitMultiplier: decimal.NewFromInt(1),
+		HourlyQuantity: decimalPtr(quantity),
+		ProductFilter: &schema.ProductFilter{
+			VendorName:    strPtr(vendorName),
+			Region:        strPtr(r.Region),
+			Service:       strPtr(containerAppsServiceName),
+			ProductFamily: strPtr("Containers"),
+			AttributeFilters: []*schema.AttributeFilter{
+				{Key: "productName", Value: strPtr(containerAppsProductName)},
+				{Key: "meterName", Value: strPtr(meterName)},
+			},
+		},
+		PriceFilter: priceFilterConsumption,
+	}
+}
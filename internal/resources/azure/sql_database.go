@@ -5,7 +5,6 @@ import (
 	"strings"
 
 	"github.com/shopspring/decimal"
-	log "github.com/sirupsen/logrus"
 
 	"github.com/infracost/infracost/internal/resources"
 	"github.com/infracost/infracost/internal/schema"
@@ -227,13 +226,14 @@ func (r *SQLDatabase) provisionedComputeCostComponent() *schema.CostComponent {
 	productNameRegex := fmt.Sprintf("/%s - %s/", r.Tier, r.Family)
 	name := fmt.Sprintf("Compute (provisioned, %s)", r.SKU)
 
-	log.Warnf("'Multiple products found' are safe to ignore for '%s' due to limitations in the Azure API.", name)
-
+	// The Azure API has several products with the same price for the
+	// provisioned compute of each SKU.
 	return &schema.CostComponent{
-		Name:           name,
-		Unit:           "hours",
-		UnitMultiplier: decimal.NewFromInt(1),
-		HourlyQuantity: decimalPtr(decimal.NewFromInt(1)),
+		Name:                   name,
+		Unit:                   "hours",
+		UnitMultiplier:         decimal.NewFromInt(1),
+		HourlyQuantity:         decimalPtr(decimal.NewFromInt(1)),
+		IgnoreMultipleProducts: true,
 		ProductFilter: r.productFilter([]*schema.AttributeFilter{
 			{Key: "productName", ValueRegex: strPtr(productNameRegex)},
 			{Key: "skuName", Value: strPtr(skuName)},
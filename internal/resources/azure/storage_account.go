@@ -1,12 +1,14 @@
 package azure
 
 import (
+	"context"
 	"fmt"
 	"strings"
 
 	"github.com/infracost/infracost/internal/resources"
 	"github.com/infracost/infracost/internal/schema"
 	"github.com/infracost/infracost/internal/usage"
+	azureusage "github.com/infracost/infracost/internal/usage/azure"
 	"github.com/shopspring/decimal"
 	log "github.com/sirupsen/logrus"
 )
@@ -22,6 +24,7 @@ import (
 type StorageAccount struct {
 	Address string
 	Region  string
+	ID      string
 
 	AccessTier             string
 	AccountKind            string
@@ -113,6 +116,25 @@ func (r *StorageAccount) BuildResource() *schema.Resource {
 		Name:           r.Address,
 		UsageSchema:    StorageAccountUsageSchema,
 		CostComponents: costComponents,
+		EstimateUsage:  r.estimateUsage(),
+	}
+}
+
+// estimateUsage returns a function that sets the storage of the Storage Account
+// from its used capacity reported by Azure Monitor. The ID is only known once
+// the Storage Account exists, so there's nothing to estimate from before then.
+func (r *StorageAccount) estimateUsage() schema.EstimateFunc {
+	if !azureusage.IsResourceID(r.ID) {
+		return nil
+	}
+
+	return func(ctx context.Context, values map[string]interface{}) error {
+		bytes, err := azureusage.StorageAccountGetUsedCapacityBytes(ctx, r.ID)
+		if err != nil {
+			return err
+		}
+		values["storage_gb"] = bytes / 1000 / 1000 / 1000
+		return nil
 	}
 }
 
@@ -0,0 +1,80 @@
+package cloudflare
+
+import (
+	"github.com/shopspring/decimal"
+
+	"github.com/infracost/infracost/internal/resources"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+// LoadBalancer struct represents a Cloudflare load balancer. Load balancers are
+// charged a flat monthly fee plus a fee per 500K DNS queries for DNS-only load
+// balancers. Origins are priced by the LoadBalancerPool resource.
+//
+// Resource information: https://developers.cloudflare.com/load-balancing/
+// Pricing information: https://developers.cloudflare.com/load-balancing/reference/billing/
+type LoadBalancer struct {
+	Address string
+	Proxied bool
+
+	MonthlyDNSQueries *int64 `infracost_usage:"monthly_dns_queries"`
+}
+
+// LoadBalancerUsageSchema defines a list which represents the usage schema of LoadBalancer.
+var LoadBalancerUsageSchema = []*schema.UsageItem{
+	{Key: "monthly_dns_queries", DefaultValue: 0, ValueType: schema.Int64},
+}
+
+// PopulateUsage parses the u schema.UsageData into the LoadBalancer.
+// It uses the `infracost_usage` struct tags to populate data into the LoadBalancer.
+func (r *LoadBalancer) PopulateUsage(u *schema.UsageData) {
+	resources.PopulateArgsWithUsage(r, u)
+}
+
+// BuildResource builds a schema.Resource from a valid LoadBalancer struct.
+// This method is called after the resource is initialised by an IaC provider.
+// See providers folder for more information.
+func (r *LoadBalancer) BuildResource() *schema.Resource {
+	costComponents := []*schema.CostComponent{
+		{
+			Name:            "Load balancer",
+			Unit:            "months",
+			UnitMultiplier:  decimal.NewFromInt(1),
+			MonthlyQuantity: decimalPtr(decimal.NewFromInt(1)),
+			ProductFilter:   loadBalancingProductFilter("Load balancer"),
+		},
+	}
+
+	// Proxied load balancers aren't charged for DNS queries
+	if !r.Proxied {
+		var queries *decimal.Decimal
+		if r.MonthlyDNSQueries != nil {
+			queries = decimalPtr(decimal.NewFromInt(*r.MonthlyDNSQueries).Div(decimal.NewFromInt(500000)))
+		}
+
+		costComponents = append(costComponents, &schema.CostComponent{
+			Name:            "DNS queries",
+			Unit:            "500K queries",
+			UnitMultiplier:  decimal.NewFromInt(1),
+			MonthlyQuantity: queries,
+			ProductFilter:   loadBalancingProductFilter("DNS queries"),
+		})
+	}
+
+	return &schema.Resource{
+		Name:           r.Address,
+		UsageSchema:    LoadBalancerUsageSchema,
+		CostComponents: costComponents,
+	}
+}
+
+func loadBalancingProductFilter(resource string) *schema.ProductFilter {
+	return &schema.ProductFilter{
+		VendorName:    strPtr(vendorName),
+		Service:       strPtr("Load Balancing"),
+		ProductFamily: strPtr("Networking"),
+		AttributeFilters: []*schema.AttributeFilter{
+			{Key: "resource", Value: strPtr(resource)},
+		},
+	}
+}
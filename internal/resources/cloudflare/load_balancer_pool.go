@@ -0,0 +1,47 @@
+package cloudflare
+
+import (
+	"github.com/shopspring/decimal"
+
+	"github.com/infracost/infracost/internal/resources"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+// LoadBalancerPool struct represents a Cloudflare load balancer pool. Each origin in
+// the pool is charged a flat monthly fee. The origins included in the Load Balancing
+// subscription are shared by all the pools in the account, so they aren't deducted.
+//
+// Resource information: https://developers.cloudflare.com/load-balancing/pools/
+// Pricing information: https://developers.cloudflare.com/load-balancing/reference/billing/
+type LoadBalancerPool struct {
+	Address string
+	Origins int64
+}
+
+// LoadBalancerPoolUsageSchema defines a list which represents the usage schema of LoadBalancerPool.
+var LoadBalancerPoolUsageSchema = []*schema.UsageItem{}
+
+// PopulateUsage parses the u schema.UsageData into the LoadBalancerPool.
+// It uses the `infracost_usage` struct tags to populate data into the LoadBalancerPool.
+func (r *LoadBalancerPool) PopulateUsage(u *schema.UsageData) {
+	resources.PopulateArgsWithUsage(r, u)
+}
+
+// BuildResource builds a schema.Resource from a valid LoadBalancerPool struct.
+// This method is called after the resource is initialised by an IaC provider.
+// See providers folder for more information.
+func (r *LoadBalancerPool) BuildResource() *schema.Resource {
+	return &schema.Resource{
+		Name:        r.Address,
+		UsageSchema: LoadBalancerPoolUsageSchema,
+		CostComponents: []*schema.CostComponent{
+			{
+				Name:            "Origins",
+				Unit:            "origins",
+				UnitMultiplier:  decimal.NewFromInt(1),
+				MonthlyQuantity: decimalPtr(decimal.NewFromInt(r.Origins)),
+				ProductFilter:   loadBalancingProductFilter("Origin"),
+			},
+		},
+	}
+}
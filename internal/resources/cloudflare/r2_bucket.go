@@ -0,0 +1,99 @@
+package cloudflare
+
+import (
+	"fmt"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/infracost/infracost/internal/resources"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+// R2Bucket struct represents a Cloudflare R2 bucket.
+//
+// Buckets are charged per GB-month of storage and per million Class A (writes and
+// lists) and Class B (reads) operations. Infrequent Access buckets are also charged
+// per GB of data retrieved. R2 has no egress fees.
+//
+// Resource information: https://developers.cloudflare.com/r2/
+// Pricing information: https://developers.cloudflare.com/r2/pricing/
+type R2Bucket struct {
+	Address      string
+	StorageClass string
+
+	StorageGB               *float64 `infracost_usage:"storage_gb"`
+	MonthlyClassAOperations *int64   `infracost_usage:"monthly_class_a_operations"`
+	MonthlyClassBOperations *int64   `infracost_usage:"monthly_class_b_operations"`
+	MonthlyDataRetrievalGB  *float64 `infracost_usage:"monthly_data_retrieval_gb"`
+}
+
+// R2BucketUsageSchema defines a list which represents the usage schema of R2Bucket.
+var R2BucketUsageSchema = []*schema.UsageItem{
+	{Key: "storage_gb", DefaultValue: 0, ValueType: schema.Float64},
+	{Key: "monthly_class_a_operations", DefaultValue: 0, ValueType: schema.Int64},
+	{Key: "monthly_class_b_operations", DefaultValue: 0, ValueType: schema.Int64},
+	{Key: "monthly_data_retrieval_gb", DefaultValue: 0, ValueType: schema.Float64},
+}
+
+// PopulateUsage parses the u schema.UsageData into the R2Bucket.
+// It uses the `infracost_usage` struct tags to populate data into the R2Bucket.
+func (r *R2Bucket) PopulateUsage(u *schema.UsageData) {
+	resources.PopulateArgsWithUsage(r, u)
+}
+
+// BuildResource builds a schema.Resource from a valid R2Bucket struct.
+// This method is called after the resource is initialised by an IaC provider.
+// See providers folder for more information.
+func (r *R2Bucket) BuildResource() *schema.Resource {
+	var storage *decimal.Decimal
+	if r.StorageGB != nil {
+		storage = decimalPtr(decimal.NewFromFloat(*r.StorageGB))
+	}
+
+	costComponents := []*schema.CostComponent{
+		r.costComponent(fmt.Sprintf("Storage (%s)", r.StorageClass), "GB", "Storage", storage),
+		r.costComponent("Class A operations", "1M operations", "Class A operations", millions(r.MonthlyClassAOperations)),
+		r.costComponent("Class B operations", "1M operations", "Class B operations", millions(r.MonthlyClassBOperations)),
+	}
+
+	if r.StorageClass == "InfrequentAccess" {
+		var retrieval *decimal.Decimal
+		if r.MonthlyDataRetrievalGB != nil {
+			retrieval = decimalPtr(decimal.NewFromFloat(*r.MonthlyDataRetrievalGB))
+		}
+
+		costComponents = append(costComponents, r.costComponent("Data retrieval", "GB", "Data retrieval", retrieval))
+	}
+
+	return &schema.Resource{
+		Name:           r.Address,
+		UsageSchema:    R2BucketUsageSchema,
+		CostComponents: costComponents,
+	}
+}
+
+func (r *R2Bucket) costComponent(name, unit, resource string, quantity *decimal.Decimal) *schema.CostComponent {
+	return &schema.CostComponent{
+		Name:            name,
+		Unit:            unit,
+		UnitMultiplier:  decimal.NewFromInt(1),
+		MonthlyQuantity: quantity,
+		ProductFilter: &schema.ProductFilter{
+			VendorName:    strPtr(vendorName),
+			Service:       strPtr("R2"),
+			ProductFamily: strPtr("Storage"),
+			AttributeFilters: []*schema.AttributeFilter{
+				{Key: "storageClass", Value: strPtr(r.StorageClass)},
+				{Key: "resource", Value: strPtr(resource)},
+			},
+		},
+	}
+}
+
+func millions(v *int64) *decimal.Decimal {
+	if v == nil {
+		return nil
+	}
+
+	return decimalPtr(decimal.NewFromInt(*v).Div(decimal.NewFromInt(1000000)))
+}
@@ -0,0 +1,17 @@
+package cloudflare
+
+import (
+	"github.com/shopspring/decimal"
+)
+
+const (
+	vendorName = "cloudflare"
+)
+
+func strPtr(s string) *string {
+	return &s
+}
+
+func decimalPtr(d decimal.Decimal) *decimal.Decimal {
+	return &d
+}
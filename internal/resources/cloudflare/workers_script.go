@@ -0,0 +1,75 @@
+package cloudflare
+
+import (
+	"github.com/shopspring/decimal"
+
+	"github.com/infracost/infracost/internal/resources"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+// WorkersScript struct represents a Cloudflare Worker.
+//
+// Workers on the Standard usage model are charged per million requests and per
+// million CPU milliseconds. The Workers Paid plan fee and the requests and CPU time
+// it includes are shared by all the Workers in the account, so they aren't included.
+//
+// Resource information: https://developers.cloudflare.com/workers/
+// Pricing information: https://developers.cloudflare.com/workers/platform/pricing/
+type WorkersScript struct {
+	Address string
+
+	MonthlyRequests *int64   `infracost_usage:"monthly_requests"`
+	AverageCPUMs    *float64 `infracost_usage:"average_cpu_ms"`
+}
+
+// WorkersScriptUsageSchema defines a list which represents the usage schema of WorkersScript.
+var WorkersScriptUsageSchema = []*schema.UsageItem{
+	{Key: "monthly_requests", DefaultValue: 0, ValueType: schema.Int64},
+	{Key: "average_cpu_ms", DefaultValue: 0, ValueType: schema.Float64},
+}
+
+// PopulateUsage parses the u schema.UsageData into the WorkersScript.
+// It uses the `infracost_usage` struct tags to populate data into the WorkersScript.
+func (r *WorkersScript) PopulateUsage(u *schema.UsageData) {
+	resources.PopulateArgsWithUsage(r, u)
+}
+
+// BuildResource builds a schema.Resource from a valid WorkersScript struct.
+// This method is called after the resource is initialised by an IaC provider.
+// See providers folder for more information.
+func (r *WorkersScript) BuildResource() *schema.Resource {
+	var requests, cpuTime *decimal.Decimal
+	if r.MonthlyRequests != nil {
+		requests = decimalPtr(decimal.NewFromInt(*r.MonthlyRequests).Div(decimal.NewFromInt(1000000)))
+
+		if r.AverageCPUMs != nil {
+			cpuTime = decimalPtr(decimal.NewFromInt(*r.MonthlyRequests).Mul(decimal.NewFromFloat(*r.AverageCPUMs)).Div(decimal.NewFromInt(1000000)))
+		}
+	}
+
+	return &schema.Resource{
+		Name:        r.Address,
+		UsageSchema: WorkersScriptUsageSchema,
+		CostComponents: []*schema.CostComponent{
+			r.costComponent("Requests", "1M requests", "Requests", requests),
+			r.costComponent("CPU time", "1M CPU ms", "CPU time", cpuTime),
+		},
+	}
+}
+
+func (r *WorkersScript) costComponent(name, unit, resource string, quantity *decimal.Decimal) *schema.CostComponent {
+	return &schema.CostComponent{
+		Name:            name,
+		Unit:            unit,
+		UnitMultiplier:  decimal.NewFromInt(1),
+		MonthlyQuantity: quantity,
+		ProductFilter: &schema.ProductFilter{
+			VendorName:    strPtr(vendorName),
+			Service:       strPtr("Workers"),
+			ProductFamily: strPtr("Compute"),
+			AttributeFilters: []*schema.AttributeFilter{
+				{Key: "resource", Value: strPtr(resource)},
+			},
+		},
+	}
+}
@@ -0,0 +1,108 @@
+package databricks
+
+import (
+	"github.com/shopspring/decimal"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/infracost/infracost/internal/resources"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+// photonDBUMultiplier is the factor applied to the DBUs consumed by clusters that
+// use the Photon runtime engine.
+var photonDBUMultiplier = decimal.NewFromInt(2)
+
+// Cluster struct represents a Databricks all-purpose cluster.
+//
+// Clusters are charged for the DBUs consumed by their driver and worker nodes while
+// they're running, on top of the cost of the cloud VMs backing the nodes which is
+// charged by the cloud provider. DBUs are priced at the Premium tier rate of the
+// cloud the node types belong to and VMs are priced as on-demand Linux VMs.
+//
+// Resource information: https://docs.databricks.com/clusters/index.html
+// Pricing information: https://www.databricks.com/product/pricing
+type Cluster struct {
+	Address        string
+	Cloud          string
+	Region         string
+	NodeType       string
+	DriverNodeType string
+	NumWorkers     int64
+	Autoscale      bool
+	MinWorkers     int64
+	Photon         bool
+
+	MonthlyHours   *float64 `infracost_usage:"monthly_hours"`
+	AverageWorkers *float64 `infracost_usage:"average_workers"`
+}
+
+// ClusterUsageSchema defines a list which represents the usage schema of Cluster.
+var ClusterUsageSchema = []*schema.UsageItem{
+	{Key: "monthly_hours", DefaultValue: 0, ValueType: schema.Float64},
+	{Key: "average_workers", DefaultValue: 0, ValueType: schema.Float64},
+}
+
+// PopulateUsage parses the u schema.UsageData into the Cluster.
+// It uses the `infracost_usage` struct tags to populate data into the Cluster.
+func (r *Cluster) PopulateUsage(u *schema.UsageData) {
+	resources.PopulateArgsWithUsage(r, u)
+}
+
+// BuildResource builds a schema.Resource from a valid Cluster struct.
+// This method is called after the resource is initialised by an IaC provider.
+// See providers folder for more information.
+func (r *Cluster) BuildResource() *schema.Resource {
+	driverNodeType := r.DriverNodeType
+	if driverNodeType == "" {
+		driverNodeType = r.NodeType
+	}
+
+	// Autoscaling clusters run with their minimum number of workers unless
+	// the average number of workers is given in the usage file.
+	workers := decimal.NewFromInt(r.NumWorkers)
+	if r.Autoscale {
+		workers = decimal.NewFromInt(r.MinWorkers)
+	}
+	if r.AverageWorkers != nil {
+		workers = decimal.NewFromFloat(*r.AverageWorkers)
+	}
+
+	var driverHours, workerHours, dbus *decimal.Decimal
+	if r.MonthlyHours != nil {
+		hours := decimal.NewFromFloat(*r.MonthlyHours)
+		driverHours = decimalPtr(hours)
+		workerHours = decimalPtr(hours.Mul(workers))
+
+		driverDBUs, driverOk := nodeDBUs(driverNodeType)
+		workerDBUs, workerOk := nodeDBUs(r.NodeType)
+
+		switch {
+		case !driverOk:
+			log.Warnf("Skipping DBUs for %s, unknown node type %s", r.Address, driverNodeType)
+		case !workerOk:
+			log.Warnf("Skipping DBUs for %s, unknown node type %s", r.Address, r.NodeType)
+		default:
+			perHour := driverDBUs.Add(workerDBUs.Mul(workers))
+			if r.Photon {
+				perHour = perHour.Mul(photonDBUMultiplier)
+			}
+
+			dbus = decimalPtr(perHour.Mul(hours))
+		}
+	}
+
+	costComponents := []*schema.CostComponent{
+		computeDBUCostComponent("All-purpose compute", r.Cloud, "All-Purpose Compute", dbus),
+		vmCostComponent("Driver VM", r.Cloud, r.Region, driverNodeType, driverHours),
+	}
+
+	if r.Autoscale || r.NumWorkers > 0 {
+		costComponents = append(costComponents, vmCostComponent("Worker VMs", r.Cloud, r.Region, r.NodeType, workerHours))
+	}
+
+	return &schema.Resource{
+		Name:           r.Address,
+		UsageSchema:    ClusterUsageSchema,
+		CostComponents: costComponents,
+	}
+}
@@ -0,0 +1,64 @@
+package databricks
+
+import (
+	"github.com/shopspring/decimal"
+
+	"github.com/infracost/infracost/internal/resources"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+// InstancePool struct represents a Databricks instance pool.
+//
+// Pools don't consume DBUs, but the idle instances they keep ready are cloud VMs
+// charged by the cloud provider. Instances in use by clusters are included in the
+// cost of those clusters.
+//
+// Resource information: https://docs.databricks.com/clusters/instance-pools/index.html
+// Pricing information: https://www.databricks.com/product/pricing
+type InstancePool struct {
+	Address          string
+	Cloud            string
+	Region           string
+	NodeType         string
+	MinIdleInstances int64
+
+	AverageIdleInstances *float64 `infracost_usage:"average_idle_instances"`
+}
+
+// InstancePoolUsageSchema defines a list which represents the usage schema of InstancePool.
+var InstancePoolUsageSchema = []*schema.UsageItem{
+	{Key: "average_idle_instances", DefaultValue: 0, ValueType: schema.Float64},
+}
+
+// PopulateUsage parses the u schema.UsageData into the InstancePool.
+// It uses the `infracost_usage` struct tags to populate data into the InstancePool.
+func (r *InstancePool) PopulateUsage(u *schema.UsageData) {
+	resources.PopulateArgsWithUsage(r, u)
+}
+
+// BuildResource builds a schema.Resource from a valid InstancePool struct.
+// This method is called after the resource is initialised by an IaC provider.
+// See providers folder for more information.
+func (r *InstancePool) BuildResource() *schema.Resource {
+	idle := decimal.NewFromInt(r.MinIdleInstances)
+	if r.AverageIdleInstances != nil {
+		idle = decimal.NewFromFloat(*r.AverageIdleInstances)
+	}
+
+	if idle.IsZero() {
+		return &schema.Resource{
+			Name:        r.Address,
+			NoPrice:     true,
+			IsSkipped:   true,
+			UsageSchema: InstancePoolUsageSchema,
+		}
+	}
+
+	return &schema.Resource{
+		Name:        r.Address,
+		UsageSchema: InstancePoolUsageSchema,
+		CostComponents: []*schema.CostComponent{
+			vmCostComponent("Idle instance VMs", r.Cloud, r.Region, r.NodeType, decimalPtr(idle.Mul(schema.HourToMonthUnitMultiplier))),
+		},
+	}
+}
@@ -0,0 +1,193 @@
+package databricks
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/infracost/infracost/internal/schema"
+)
+
+// Clouds a Databricks workspace can run on.
+const (
+	CloudAWS   = "AWS"
+	CloudAzure = "AZURE"
+	CloudGCP   = "GCP"
+)
+
+// defaultRegions are the regions used to price cloud VMs when a resource doesn't
+// specify the zone its nodes run in.
+var defaultRegions = map[string]string{
+	CloudAWS:   "us-east-1",
+	CloudAzure: "eastus",
+	CloudGCP:   "us-central1",
+}
+
+// nodeTypeDBUs are the DBUs consumed per hour by a node of each node type.
+var nodeTypeDBUs = map[string]float64{
+	// AWS
+	"c5.xlarge":   0.61,
+	"c5.2xlarge":  1.22,
+	"c5.4xlarge":  2.43,
+	"g4dn.xlarge": 0.71,
+	"i3.xlarge":   1,
+	"i3.2xlarge":  2,
+	"i3.4xlarge":  4,
+	"i3.8xlarge":  8,
+	"i3.16xlarge": 16,
+	"m5.xlarge":   0.69,
+	"m5.2xlarge":  1.37,
+	"m5.4xlarge":  2.74,
+	"m5.8xlarge":  5.48,
+	"m5d.xlarge":  0.69,
+	"m5d.2xlarge": 1.37,
+	"m5d.4xlarge": 2.74,
+	"r5.xlarge":   0.9,
+	"r5.2xlarge":  1.8,
+	"r5.4xlarge":  3.6,
+	"r5d.xlarge":  0.9,
+	"r5d.2xlarge": 1.8,
+
+	// Azure
+	"Standard_D4s_v3":  0.75,
+	"Standard_D8s_v3":  1.5,
+	"Standard_D16s_v3": 3,
+	"Standard_DS3_v2":  0.75,
+	"Standard_DS4_v2":  1.5,
+	"Standard_DS5_v2":  3,
+	"Standard_E4s_v3":  1,
+	"Standard_E8s_v3":  2,
+	"Standard_E16s_v3": 4,
+	"Standard_F4s":     0.5,
+	"Standard_F8s":     1,
+	"Standard_L4s":     0.75,
+	"Standard_L8s":     1.5,
+	"Standard_NC6s_v3": 3,
+
+	// GCP
+	"n1-highmem-4":   1.13,
+	"n1-highmem-8":   2.26,
+	"n1-standard-4":  0.87,
+	"n1-standard-8":  1.74,
+	"n1-standard-16": 3.48,
+	"n2-highmem-4":   1.31,
+	"n2-highmem-8":   2.62,
+	"n2-standard-4":  1.07,
+	"n2-standard-8":  2.14,
+	"n2-standard-16": 4.28,
+}
+
+// NodeTypeCloud returns the cloud a node type belongs to. Azure node types are VM
+// sizes prefixed with Standard_ and AWS node types are EC2 instance types, which
+// always contain a dot. Anything else is a GCP machine type.
+func NodeTypeCloud(nodeType string) string {
+	switch {
+	case strings.HasPrefix(nodeType, "Standard_"):
+		return CloudAzure
+	case strings.Contains(nodeType, "."):
+		return CloudAWS
+	default:
+		return CloudGCP
+	}
+}
+
+// nodeDBUs returns the DBUs consumed per hour by a node of the given node type and
+// whether the node type is known.
+func nodeDBUs(nodeType string) (decimal.Decimal, bool) {
+	dbus, ok := nodeTypeDBUs[nodeType]
+	return decimal.NewFromFloat(dbus), ok
+}
+
+func regionOrDefault(cloud, region string) string {
+	if region != "" {
+		return region
+	}
+
+	return defaultRegions[cloud]
+}
+
+// vmCostComponent returns a cost component for the on-demand cloud VMs backing
+// Databricks nodes. Quantity is the number of node hours per month.
+func vmCostComponent(name, cloud, region, nodeType string, quantity *decimal.Decimal) *schema.CostComponent {
+	region = regionOrDefault(cloud, region)
+
+	c := &schema.CostComponent{
+		Name:            fmt.Sprintf("%s (%s)", name, nodeType),
+		Unit:            "hours",
+		UnitMultiplier:  decimal.NewFromInt(1),
+		MonthlyQuantity: quantity,
+	}
+
+	switch cloud {
+	case CloudAWS:
+		c.ProductFilter = &schema.ProductFilter{
+			VendorName:    strPtr("aws"),
+			Region:        strPtr(region),
+			Service:       strPtr("AmazonEC2"),
+			ProductFamily: strPtr("Compute Instance"),
+			AttributeFilters: []*schema.AttributeFilter{
+				{Key: "instanceType", Value: strPtr(nodeType)},
+				{Key: "tenancy", Value: strPtr("Shared")},
+				{Key: "operatingSystem", Value: strPtr("Linux")},
+				{Key: "preInstalledSw", Value: strPtr("NA")},
+				{Key: "licenseModel", Value: strPtr("No License required")},
+				{Key: "capacitystatus", Value: strPtr("Used")},
+			},
+		}
+		c.PriceFilter = &schema.PriceFilter{
+			PurchaseOption: strPtr("on_demand"),
+		}
+	case CloudAzure:
+		c.ProductFilter = &schema.ProductFilter{
+			VendorName:    strPtr("azure"),
+			Region:        strPtr(region),
+			Service:       strPtr("Virtual Machines"),
+			ProductFamily: strPtr("Compute"),
+			AttributeFilters: []*schema.AttributeFilter{
+				{Key: "skuName", ValueRegex: strPtr("/^(?!.*(Low Priority|Spot)$).*$/i")},
+				{Key: "armSkuName", ValueRegex: strPtr(fmt.Sprintf("/^%s$/i", nodeType))},
+				{Key: "productName", ValueRegex: strPtr("/Virtual Machines .* Series$/")},
+			},
+		}
+		c.PriceFilter = &schema.PriceFilter{
+			PurchaseOption: strPtr("Consumption"),
+			Unit:           strPtr("1 Hour"),
+		}
+	default:
+		c.ProductFilter = &schema.ProductFilter{
+			VendorName:    strPtr("gcp"),
+			Region:        strPtr(region),
+			Service:       strPtr("Compute Engine"),
+			ProductFamily: strPtr("Compute Instance"),
+			AttributeFilters: []*schema.AttributeFilter{
+				{Key: "machineType", ValueRegex: strPtr(fmt.Sprintf("/^%s$/i", nodeType))},
+			},
+		}
+		c.PriceFilter = &schema.PriceFilter{
+			PurchaseOption: strPtr("on_demand"),
+		}
+	}
+
+	return c
+}
+
+// computeDBUCostComponent returns a cost component for the DBUs consumed by a
+// cluster workload. Quantity is the number of DBUs per month.
+func computeDBUCostComponent(name, cloud, workload string, quantity *decimal.Decimal) *schema.CostComponent {
+	return &schema.CostComponent{
+		Name:            name,
+		Unit:            "DBU",
+		UnitMultiplier:  decimal.NewFromInt(1),
+		MonthlyQuantity: quantity,
+		ProductFilter: &schema.ProductFilter{
+			VendorName:    strPtr(vendorName),
+			Service:       strPtr("Compute"),
+			ProductFamily: strPtr("DBU"),
+			AttributeFilters: []*schema.AttributeFilter{
+				{Key: "cloud", Value: strPtr(cloud)},
+				{Key: "workload", Value: strPtr(workload)},
+			},
+		},
+	}
+}
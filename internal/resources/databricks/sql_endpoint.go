@@ -0,0 +1,109 @@
+package databricks
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/shopspring/decimal"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/infracost/infracost/internal/resources"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+// sqlEndpointClusterDBUs are the DBUs consumed per hour by a single cluster of
+// each SQL warehouse size.
+var sqlEndpointClusterDBUs = map[string]int64{
+	"2X-Small": 4,
+	"X-Small":  6,
+	"Small":    12,
+	"Medium":   24,
+	"Large":    40,
+	"X-Large":  80,
+	"2X-Large": 144,
+	"3X-Large": 272,
+	"4X-Large": 528,
+}
+
+// SQLEndpoint struct represents a Databricks SQL warehouse, previously called a SQL
+// endpoint.
+//
+// SQL warehouses are charged for the DBUs consumed by each of their clusters while
+// they're running. The DBU rate depends on the warehouse type: classic, pro or
+// serverless. The cloud VMs of classic and pro warehouses run in the customer's
+// cloud account, they aren't included since their instance types aren't exposed.
+//
+// Resource information: https://docs.databricks.com/sql/admin/sql-endpoints.html
+// Pricing information: https://www.databricks.com/product/pricing/databricks-sql
+type SQLEndpoint struct {
+	Address       string
+	ClusterSize   string
+	WarehouseType string
+	Serverless    bool
+	MinClusters   int64
+
+	MonthlyHours    *float64 `infracost_usage:"monthly_hours"`
+	AverageClusters *float64 `infracost_usage:"average_clusters"`
+}
+
+// SQLEndpointUsageSchema defines a list which represents the usage schema of SQLEndpoint.
+var SQLEndpointUsageSchema = []*schema.UsageItem{
+	{Key: "monthly_hours", DefaultValue: 0, ValueType: schema.Float64},
+	{Key: "average_clusters", DefaultValue: 0, ValueType: schema.Float64},
+}
+
+// PopulateUsage parses the u schema.UsageData into the SQLEndpoint.
+// It uses the `infracost_usage` struct tags to populate data into the SQLEndpoint.
+func (r *SQLEndpoint) PopulateUsage(u *schema.UsageData) {
+	resources.PopulateArgsWithUsage(r, u)
+}
+
+// BuildResource builds a schema.Resource from a valid SQLEndpoint struct.
+// This method is called after the resource is initialised by an IaC provider.
+// See providers folder for more information.
+func (r *SQLEndpoint) BuildResource() *schema.Resource {
+	workload := "SQL Classic"
+	if r.Serverless {
+		workload = "SQL Serverless"
+	} else if strings.EqualFold(r.WarehouseType, "PRO") {
+		workload = "SQL Pro"
+	}
+
+	// Warehouses scale between their min and max number of clusters, use the min
+	// unless the average number of clusters is given in the usage file.
+	clusters := decimal.NewFromInt(r.MinClusters)
+	if r.AverageClusters != nil {
+		clusters = decimal.NewFromFloat(*r.AverageClusters)
+	}
+
+	var dbus *decimal.Decimal
+	if r.MonthlyHours != nil {
+		clusterDBUs, ok := sqlEndpointClusterDBUs[r.ClusterSize]
+		if !ok {
+			log.Warnf("Skipping DBUs for %s, unknown cluster size %s", r.Address, r.ClusterSize)
+		} else {
+			dbus = decimalPtr(decimal.NewFromInt(clusterDBUs).Mul(clusters).Mul(decimal.NewFromFloat(*r.MonthlyHours)))
+		}
+	}
+
+	return &schema.Resource{
+		Name:        r.Address,
+		UsageSchema: SQLEndpointUsageSchema,
+		CostComponents: []*schema.CostComponent{
+			{
+				Name:            fmt.Sprintf("%s (%s)", workload, r.ClusterSize),
+				Unit:            "DBU",
+				UnitMultiplier:  decimal.NewFromInt(1),
+				MonthlyQuantity: dbus,
+				ProductFilter: &schema.ProductFilter{
+					VendorName:    strPtr(vendorName),
+					Service:       strPtr("SQL"),
+					ProductFamily: strPtr("DBU"),
+					AttributeFilters: []*schema.AttributeFilter{
+						{Key: "workload", Value: strPtr(workload)},
+					},
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,17 @@
+package databricks
+
+import (
+	"github.com/shopspring/decimal"
+)
+
+const (
+	vendorName = "databricks"
+)
+
+func strPtr(s string) *string {
+	return &s
+}
+
+func decimalPtr(d decimal.Decimal) *decimal.Decimal {
+	return &d
+}
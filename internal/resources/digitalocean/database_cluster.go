@@ -0,0 +1,60 @@
+package digitalocean
+
+import (
+	"fmt"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/infracost/infracost/internal/resources"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+// DatabaseCluster struct represents a DigitalOcean managed database cluster. Clusters
+// are charged a flat monthly price per node for their size, standby nodes are charged
+// the same as the primary node.
+//
+// Resource information: https://docs.digitalocean.com/products/databases/
+// Pricing information: https://www.digitalocean.com/pricing/managed-databases
+type DatabaseCluster struct {
+	Address   string
+	Region    string
+	Engine    string
+	Size      string
+	NodeCount int64
+}
+
+// DatabaseClusterUsageSchema defines a list which represents the usage schema of DatabaseCluster.
+var DatabaseClusterUsageSchema = []*schema.UsageItem{}
+
+// PopulateUsage parses the u schema.UsageData into the DatabaseCluster.
+// It uses the `infracost_usage` struct tags to populate data into the DatabaseCluster.
+func (r *DatabaseCluster) PopulateUsage(u *schema.UsageData) {
+	resources.PopulateArgsWithUsage(r, u)
+}
+
+// BuildResource builds a schema.Resource from a valid DatabaseCluster struct.
+// This method is called after the resource is initialised by an IaC provider.
+// See providers folder for more information.
+func (r *DatabaseCluster) BuildResource() *schema.Resource {
+	return &schema.Resource{
+		Name:        r.Address,
+		UsageSchema: DatabaseClusterUsageSchema,
+		CostComponents: []*schema.CostComponent{
+			{
+				Name:            fmt.Sprintf("Database nodes (%s, %s)", r.Engine, r.Size),
+				Unit:            "months",
+				UnitMultiplier:  decimal.NewFromInt(1),
+				MonthlyQuantity: decimalPtr(decimal.NewFromInt(r.NodeCount)),
+				ProductFilter: &schema.ProductFilter{
+					VendorName:    strPtr(vendorName),
+					Region:        strPtr(r.Region),
+					Service:       strPtr("Databases"),
+					ProductFamily: strPtr("Database"),
+					AttributeFilters: []*schema.AttributeFilter{
+						{Key: "size", Value: strPtr(r.Size)},
+					},
+				},
+			},
+		},
+	}
+}
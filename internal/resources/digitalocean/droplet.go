@@ -0,0 +1,85 @@
+package digitalocean
+
+import (
+	"fmt"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/infracost/infracost/internal/resources"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+// Droplet struct represents a DigitalOcean Droplet. Droplets are charged a flat
+// monthly price for their size, weekly backups add 20% of the Droplet price.
+//
+// Resource information: https://docs.digitalocean.com/products/droplets/
+// Pricing information: https://www.digitalocean.com/pricing/droplets
+type Droplet struct {
+	Address string
+	Region  string
+	Size    string
+	Backups bool
+}
+
+// DropletUsageSchema defines a list which represents the usage schema of Droplet.
+var DropletUsageSchema = []*schema.UsageItem{}
+
+// PopulateUsage parses the u schema.UsageData into the Droplet.
+// It uses the `infracost_usage` struct tags to populate data into the Droplet.
+func (r *Droplet) PopulateUsage(u *schema.UsageData) {
+	resources.PopulateArgsWithUsage(r, u)
+}
+
+// BuildResource builds a schema.Resource from a valid Droplet struct.
+// This method is called after the resource is initialised by an IaC provider.
+// See providers folder for more information.
+func (r *Droplet) BuildResource() *schema.Resource {
+	costComponents := []*schema.CostComponent{
+		dropletCostComponent(fmt.Sprintf("Droplet (%s)", r.Size), r.Region, r.Size, decimal.NewFromInt(1)),
+	}
+
+	if r.Backups {
+		costComponents = append(costComponents, &schema.CostComponent{
+			Name:            "Backups",
+			Unit:            "months",
+			UnitMultiplier:  decimal.NewFromInt(1),
+			MonthlyQuantity: decimalPtr(decimal.NewFromInt(1)),
+			ProductFilter: &schema.ProductFilter{
+				VendorName:    strPtr(vendorName),
+				Region:        strPtr(r.Region),
+				Service:       strPtr("Droplets"),
+				ProductFamily: strPtr("Backups"),
+				AttributeFilters: []*schema.AttributeFilter{
+					{Key: "size", Value: strPtr(r.Size)},
+				},
+			},
+		})
+	}
+
+	return &schema.Resource{
+		Name:           r.Address,
+		UsageSchema:    DropletUsageSchema,
+		CostComponents: costComponents,
+	}
+}
+
+// dropletCostComponent returns the monthly cost component for a number of Droplets
+// of the given size. It's shared by Droplets and Kubernetes node pools since nodes
+// are charged the same as Droplets.
+func dropletCostComponent(name, region, size string, count decimal.Decimal) *schema.CostComponent {
+	return &schema.CostComponent{
+		Name:            name,
+		Unit:            "months",
+		UnitMultiplier:  decimal.NewFromInt(1),
+		MonthlyQuantity: decimalPtr(count),
+		ProductFilter: &schema.ProductFilter{
+			VendorName:    strPtr(vendorName),
+			Region:        strPtr(region),
+			Service:       strPtr("Droplets"),
+			ProductFamily: strPtr("Compute"),
+			AttributeFilters: []*schema.AttributeFilter{
+				{Key: "size", Value: strPtr(size)},
+			},
+		},
+	}
+}
@@ -0,0 +1,75 @@
+package digitalocean
+
+import (
+	"github.com/shopspring/decimal"
+
+	"github.com/infracost/infracost/internal/resources"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+// KubernetesCluster struct represents a DigitalOcean Kubernetes (DOKS) cluster. The
+// control plane is free unless high availability is enabled. The cluster's default
+// node pool is added as a sub resource.
+//
+// Resource information: https://docs.digitalocean.com/products/kubernetes/
+// Pricing information: https://www.digitalocean.com/pricing/kubernetes
+type KubernetesCluster struct {
+	Address  string
+	Region   string
+	HA       bool
+	NodePool *KubernetesNodePool
+
+	NodePoolNodes *int64 `infracost_usage:"nodes"`
+}
+
+// KubernetesClusterUsageSchema defines a list which represents the usage schema of KubernetesCluster.
+var KubernetesClusterUsageSchema = []*schema.UsageItem{
+	{Key: "nodes", DefaultValue: 0, ValueType: schema.Int64},
+}
+
+// PopulateUsage parses the u schema.UsageData into the KubernetesCluster.
+// It uses the `infracost_usage` struct tags to populate data into the KubernetesCluster.
+func (r *KubernetesCluster) PopulateUsage(u *schema.UsageData) {
+	resources.PopulateArgsWithUsage(r, u)
+
+	if r.NodePool != nil {
+		r.NodePool.PopulateUsage(u)
+	}
+}
+
+// BuildResource builds a schema.Resource from a valid KubernetesCluster struct.
+// This method is called after the resource is initialised by an IaC provider.
+// See providers folder for more information.
+func (r *KubernetesCluster) BuildResource() *schema.Resource {
+	costComponents := []*schema.CostComponent{}
+
+	if r.HA {
+		costComponents = append(costComponents, &schema.CostComponent{
+			Name:            "High availability control plane",
+			Unit:            "months",
+			UnitMultiplier:  decimal.NewFromInt(1),
+			MonthlyQuantity: decimalPtr(decimal.NewFromInt(1)),
+			ProductFilter: &schema.ProductFilter{
+				VendorName:    strPtr(vendorName),
+				Region:        strPtr(r.Region),
+				Service:       strPtr("Kubernetes"),
+				ProductFamily: strPtr("Control Plane"),
+				AttributeFilters: []*schema.AttributeFilter{
+					{Key: "ha", Value: strPtr("true")},
+				},
+			},
+		})
+	}
+
+	subresources := []*schema.Resource{}
+	if r.NodePool != nil {
+		subresources = append(subresources, r.NodePool.BuildResource())
+	}
+
+	return &schema.Resource{
+		Name:           r.Address,
+		UsageSchema:    KubernetesClusterUsageSchema,
+		CostComponents: costComponents,
+		SubResources:   subresources,
+	}
+}
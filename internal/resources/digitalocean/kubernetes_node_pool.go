@@ -0,0 +1,54 @@
+package digitalocean
+
+import (
+	"fmt"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/infracost/infracost/internal/resources"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+// KubernetesNodePool struct represents a DigitalOcean Kubernetes (DOKS) node pool.
+// Nodes are charged the same flat monthly price as the equivalent Droplet. The nodes
+// usage param can be used to set the average number of nodes of autoscaling pools.
+//
+// Resource information: https://docs.digitalocean.com/products/kubernetes/
+// Pricing information: https://www.digitalocean.com/pricing/kubernetes
+type KubernetesNodePool struct {
+	Address   string
+	Region    string
+	Size      string
+	NodeCount int64
+
+	Nodes *int64 `infracost_usage:"nodes"`
+}
+
+// KubernetesNodePoolUsageSchema defines a list which represents the usage schema of KubernetesNodePool.
+var KubernetesNodePoolUsageSchema = []*schema.UsageItem{
+	{Key: "nodes", DefaultValue: 0, ValueType: schema.Int64},
+}
+
+// PopulateUsage parses the u schema.UsageData into the KubernetesNodePool.
+// It uses the `infracost_usage` struct tags to populate data into the KubernetesNodePool.
+func (r *KubernetesNodePool) PopulateUsage(u *schema.UsageData) {
+	resources.PopulateArgsWithUsage(r, u)
+}
+
+// BuildResource builds a schema.Resource from a valid KubernetesNodePool struct.
+// This method is called after the resource is initialised by an IaC provider.
+// See providers folder for more information.
+func (r *KubernetesNodePool) BuildResource() *schema.Resource {
+	nodeCount := r.NodeCount
+	if r.Nodes != nil {
+		nodeCount = *r.Nodes
+	}
+
+	return &schema.Resource{
+		Name:        r.Address,
+		UsageSchema: KubernetesNodePoolUsageSchema,
+		CostComponents: []*schema.CostComponent{
+			dropletCostComponent(fmt.Sprintf("Nodes (%s)", r.Size), r.Region, r.Size, decimal.NewFromInt(nodeCount)),
+		},
+	}
+}
@@ -0,0 +1,69 @@
+package digitalocean
+
+import (
+	"fmt"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/infracost/infracost/internal/resources"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+// Loadbalancer struct represents a DigitalOcean load balancer. Load balancers are
+// charged a flat monthly price per node, load balancers created with the legacy
+// size slugs (lb-small, lb-medium, lb-large) are charged per size.
+//
+// Resource information: https://docs.digitalocean.com/products/networking/load-balancers/
+// Pricing information: https://www.digitalocean.com/pricing/load-balancers
+type Loadbalancer struct {
+	Address  string
+	Region   string
+	Size     string
+	SizeUnit int64
+}
+
+// LoadbalancerUsageSchema defines a list which represents the usage schema of Loadbalancer.
+var LoadbalancerUsageSchema = []*schema.UsageItem{}
+
+// PopulateUsage parses the u schema.UsageData into the Loadbalancer.
+// It uses the `infracost_usage` struct tags to populate data into the Loadbalancer.
+func (r *Loadbalancer) PopulateUsage(u *schema.UsageData) {
+	resources.PopulateArgsWithUsage(r, u)
+}
+
+// BuildResource builds a schema.Resource from a valid Loadbalancer struct.
+// This method is called after the resource is initialised by an IaC provider.
+// See providers folder for more information.
+func (r *Loadbalancer) BuildResource() *schema.Resource {
+	name := fmt.Sprintf("Load balancer (%s)", r.Size)
+	size := r.Size
+	quantity := decimal.NewFromInt(1)
+
+	if size == "" {
+		name = "Load balancer nodes"
+		size = "node"
+		quantity = decimal.NewFromInt(r.SizeUnit)
+	}
+
+	return &schema.Resource{
+		Name:        r.Address,
+		UsageSchema: LoadbalancerUsageSchema,
+		CostComponents: []*schema.CostComponent{
+			{
+				Name:            name,
+				Unit:            "months",
+				UnitMultiplier:  decimal.NewFromInt(1),
+				MonthlyQuantity: decimalPtr(quantity),
+				ProductFilter: &schema.ProductFilter{
+					VendorName:    strPtr(vendorName),
+					Region:        strPtr(r.Region),
+					Service:       strPtr("Load Balancers"),
+					ProductFamily: strPtr("Networking"),
+					AttributeFilters: []*schema.AttributeFilter{
+						{Key: "size", Value: strPtr(size)},
+					},
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,79 @@
+package digitalocean
+
+import (
+	"github.com/shopspring/decimal"
+
+	"github.com/infracost/infracost/internal/resources"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+// SpacesBucket struct represents a DigitalOcean Spaces bucket.
+//
+// Spaces is billed as a $5 monthly subscription per account which includes 250GB of
+// storage and 1TB of outbound transfer. Since the subscription is shared by all the
+// buckets in the account it isn't included here. The storage_gb and
+// monthly_outbound_data_gb usage params should be set to the amounts above those
+// included in the subscription.
+//
+// Resource information: https://docs.digitalocean.com/products/spaces/
+// Pricing information: https://www.digitalocean.com/pricing/spaces-object-storage
+type SpacesBucket struct {
+	Address string
+	Region  string
+
+	StorageGB             *float64 `infracost_usage:"storage_gb"`
+	MonthlyOutboundDataGB *float64 `infracost_usage:"monthly_outbound_data_gb"`
+}
+
+// SpacesBucketUsageSchema defines a list which represents the usage schema of SpacesBucket.
+var SpacesBucketUsageSchema = []*schema.UsageItem{
+	{Key: "storage_gb", DefaultValue: 0, ValueType: schema.Float64},
+	{Key: "monthly_outbound_data_gb", DefaultValue: 0, ValueType: schema.Float64},
+}
+
+// PopulateUsage parses the u schema.UsageData into the SpacesBucket.
+// It uses the `infracost_usage` struct tags to populate data into the SpacesBucket.
+func (r *SpacesBucket) PopulateUsage(u *schema.UsageData) {
+	resources.PopulateArgsWithUsage(r, u)
+}
+
+// BuildResource builds a schema.Resource from a valid SpacesBucket struct.
+// This method is called after the resource is initialised by an IaC provider.
+// See providers folder for more information.
+func (r *SpacesBucket) BuildResource() *schema.Resource {
+	var storage, outbound *decimal.Decimal
+	if r.StorageGB != nil {
+		storage = decimalPtr(decimal.NewFromFloat(*r.StorageGB))
+	}
+
+	if r.MonthlyOutboundDataGB != nil {
+		outbound = decimalPtr(decimal.NewFromFloat(*r.MonthlyOutboundDataGB))
+	}
+
+	return &schema.Resource{
+		Name:        r.Address,
+		UsageSchema: SpacesBucketUsageSchema,
+		CostComponents: []*schema.CostComponent{
+			r.costComponent("Storage", "Storage", storage),
+			r.costComponent("Outbound data transfer", "Outbound transfer", outbound),
+		},
+	}
+}
+
+func (r *SpacesBucket) costComponent(name, resource string, quantity *decimal.Decimal) *schema.CostComponent {
+	return &schema.CostComponent{
+		Name:            name,
+		Unit:            "GB",
+		UnitMultiplier:  decimal.NewFromInt(1),
+		MonthlyQuantity: quantity,
+		ProductFilter: &schema.ProductFilter{
+			VendorName:    strPtr(vendorName),
+			Region:        strPtr(r.Region),
+			Service:       strPtr("Spaces"),
+			ProductFamily: strPtr("Storage"),
+			AttributeFilters: []*schema.AttributeFilter{
+				{Key: "resource", Value: strPtr(resource)},
+			},
+		},
+	}
+}
@@ -0,0 +1,17 @@
+package digitalocean
+
+import (
+	"github.com/shopspring/decimal"
+)
+
+const (
+	vendorName = "digitalocean"
+)
+
+func strPtr(s string) *string {
+	return &s
+}
+
+func decimalPtr(d decimal.Decimal) *decimal.Decimal {
+	return &d
+}
@@ -1,8 +1,11 @@
 package google
 
 import (
+	"context"
+
 	"github.com/infracost/infracost/internal/resources"
 	"github.com/infracost/infracost/internal/schema"
+	googleusage "github.com/infracost/infracost/internal/usage/google"
 
 	"fmt"
 
@@ -12,6 +15,9 @@ import (
 type BigQueryDataset struct {
 	Address          string
 	Region           string
+	Project          string
+	Location         string
+	DatasetID        string
 	MonthlyQueriesTB *float64 `infracost_usage:"monthly_queries_tb"`
 }
 
@@ -29,8 +35,21 @@ func (r *BigQueryDataset) BuildResource() *schema.Resource {
 		queriesTB = decimalPtr(decimal.NewFromFloat(*r.MonthlyQueriesTB))
 	}
 
+	var estimate schema.EstimateFunc
+	if r.Project != "" && r.DatasetID != "" {
+		estimate = func(ctx context.Context, values map[string]interface{}) error {
+			tb, err := googleusage.BigQueryGetScannedTB(ctx, r.Project, r.Location, r.DatasetID)
+			if err != nil {
+				return err
+			}
+			values["monthly_queries_tb"] = tb
+			return nil
+		}
+	}
+
 	return &schema.Resource{
-		Name: r.Address,
+		Name:          r.Address,
+		EstimateUsage: estimate,
 		CostComponents: []*schema.CostComponent{
 			{
 				Name:            "Queries (on-demand)",
@@ -0,0 +1,91 @@
+package google
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/infracost/infracost/internal/resources"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+// BigQueryReservation struct represents a BigQuery editions reservation. Reservations are
+// charged per slot-hour for the baseline slots, which are always allocated, and for any
+// additional slots the reservation autoscales to. Queries that run in a reservation are
+// not charged per TB scanned.
+//
+// Resource information: https://cloud.google.com/bigquery/docs/reservations-intro
+// Pricing information: https://cloud.google.com/bigquery/pricing#capacity_compute_analysis_pricing
+type BigQueryReservation struct {
+	Address      string
+	Region       string
+	Edition      string
+	SlotCapacity int64
+	MaxSlots     int64
+
+	MonthlyAutoscaleSlotHours *float64 `infracost_usage:"monthly_autoscale_slot_hours"`
+}
+
+var BigQueryReservationUsageSchema = []*schema.UsageItem{
+	{Key: "monthly_autoscale_slot_hours", ValueType: schema.Float64, DefaultValue: 0},
+}
+
+func (r *BigQueryReservation) PopulateUsage(u *schema.UsageData) {
+	resources.PopulateArgsWithUsage(r, u)
+}
+
+func (r *BigQueryReservation) BuildResource() *schema.Resource {
+	edition := r.editionName()
+
+	costComponents := []*schema.CostComponent{
+		r.slotsCostComponent(fmt.Sprintf("Baseline slots (%s)", edition), edition, decimalPtr(decimal.NewFromInt(r.SlotCapacity)), nil),
+	}
+
+	if r.MaxSlots > 0 {
+		costComponents = append(costComponents,
+			r.slotsCostComponent(fmt.Sprintf("Autoscale slots (%s)", edition), edition, nil, floatPtrToDecimalPtr(r.MonthlyAutoscaleSlotHours)),
+		)
+	}
+
+	return &schema.Resource{
+		Name:           r.Address,
+		CostComponents: costComponents,
+		UsageSchema:    BigQueryReservationUsageSchema,
+	}
+}
+
+func (r *BigQueryReservation) slotsCostComponent(name, edition string, hourlyQuantity, monthlyQuantity *decimal.Decimal) *schema.CostComponent {
+	return &schema.CostComponent{
+		Name:            name,
+		Unit:            "slot-hours",
+		UnitMultiplier:  decimal.NewFromInt(1),
+		HourlyQuantity:  hourlyQuantity,
+		MonthlyQuantity: monthlyQuantity,
+		ProductFilter: &schema.ProductFilter{
+			VendorName:    strPtr("gcp"),
+			Region:        strPtr(r.Region),
+			Service:       strPtr("BigQuery Reservation API"),
+			ProductFamily: strPtr("ApplicationServices"),
+			AttributeFilters: []*schema.AttributeFilter{
+				{Key: "description", ValueRegex: regexPtr(fmt.Sprintf("^%s Edition", edition))},
+			},
+		},
+		PriceFilter: &schema.PriceFilter{
+			PurchaseOption: strPtr("OnDemand"),
+		},
+	}
+}
+
+// editionName returns the display name of the reservation edition. Reservations
+// created without an edition default to the Enterprise edition.
+func (r *BigQueryReservation) editionName() string {
+	switch strings.ToUpper(r.Edition) {
+	case "STANDARD":
+		return "Standard"
+	case "ENTERPRISE_PLUS":
+		return "Enterprise Plus"
+	default:
+		return "Enterprise"
+	}
+}
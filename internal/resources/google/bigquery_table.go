@@ -11,8 +11,10 @@ import (
 )
 
 type BigQueryTable struct {
-	Address                   string
-	Region                    string
+	Address string
+	Region  string
+	// StorageBillingModel is inherited from the dataset and is either LOGICAL or PHYSICAL.
+	StorageBillingModel       string
 	MonthlyStreamingInsertsMB *float64 `infracost_usage:"monthly_streaming_inserts_mb"`
 	MonthlyStorageWriteAPIGB  *float64 `infracost_usage:"monthly_storage_write_api_gb"`
 	MonthlyStorageReadAPITB   *float64 `infracost_usage:"monthly_storage_read_api_tb"`
@@ -63,7 +65,7 @@ func (r *BigQueryTable) activeStorageCostComponent() *schema.CostComponent {
 	}
 
 	return &schema.CostComponent{
-		Name:            "Active storage",
+		Name:            r.storageName("Active storage"),
 		Unit:            "GB",
 		UnitMultiplier:  decimal.NewFromInt(1),
 		MonthlyQuantity: activeStorageGB,
@@ -73,7 +75,7 @@ func (r *BigQueryTable) activeStorageCostComponent() *schema.CostComponent {
 			Service:       strPtr("BigQuery"),
 			ProductFamily: strPtr("ApplicationServices"),
 			AttributeFilters: []*schema.AttributeFilter{
-				{Key: "description", Value: strPtr(fmt.Sprintf("Active Storage (%s)", r.Region))},
+				{Key: "description", Value: strPtr(fmt.Sprintf("%s (%s)", r.storageDescription("Active"), r.Region))},
 			},
 		},
 		PriceFilter: &schema.PriceFilter{
@@ -89,7 +91,7 @@ func (r *BigQueryTable) longTermStorageCostComponent() *schema.CostComponent {
 	}
 
 	return &schema.CostComponent{
-		Name:            "Long-term storage",
+		Name:            r.storageName("Long-term storage"),
 		Unit:            "GB",
 		UnitMultiplier:  decimal.NewFromInt(1),
 		MonthlyQuantity: longTermStorageGB,
@@ -99,7 +101,7 @@ func (r *BigQueryTable) longTermStorageCostComponent() *schema.CostComponent {
 			Service:       strPtr("BigQuery"),
 			ProductFamily: strPtr("ApplicationServices"),
 			AttributeFilters: []*schema.AttributeFilter{
-				{Key: "description", Value: strPtr(fmt.Sprintf("Long Term Storage (%s)", r.Region))},
+				{Key: "description", Value: strPtr(fmt.Sprintf("%s (%s)", r.storageDescription("Long Term"), r.Region))},
 			},
 		},
 		PriceFilter: &schema.PriceFilter{
@@ -108,6 +110,25 @@ func (r *BigQueryTable) longTermStorageCostComponent() *schema.CostComponent {
 	}
 }
 
+func (r *BigQueryTable) storageName(name string) string {
+	if strings.ToUpper(r.StorageBillingModel) == "PHYSICAL" {
+		return fmt.Sprintf("%s (physical)", name)
+	}
+
+	return name
+}
+
+// storageDescription returns the SKU description prefix for the given storage
+// class. Datasets using the physical storage billing model are charged for
+// compressed bytes which have their own SKUs.
+func (r *BigQueryTable) storageDescription(class string) string {
+	if strings.ToUpper(r.StorageBillingModel) == "PHYSICAL" {
+		return fmt.Sprintf("%s Physical Storage", class)
+	}
+
+	return fmt.Sprintf("%s Storage", class)
+}
+
 func (r *BigQueryTable) streamingInsertsCostComponent() *schema.CostComponent {
 	var streamingInsertsMB *decimal.Decimal
 	if r.MonthlyStreamingInsertsMB != nil {
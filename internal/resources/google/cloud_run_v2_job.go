@@ -0,0 +1,57 @@
+package google
+
+import (
+	"github.com/shopspring/decimal"
+
+	"github.com/infracost/infracost/internal/resources"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+// CloudRunV2Job struct represents a Cloud Run job. Jobs are charged for the CPU and
+// memory allocated to each task for the duration of its execution, using the
+// instance-based billing rates.
+//
+// Resource information: https://cloud.google.com/run/docs/create-jobs
+// Pricing information: https://cloud.google.com/run/pricing
+type CloudRunV2Job struct {
+	Address   string
+	Region    string
+	CPU       float64
+	MemoryGiB float64
+	TaskCount int64
+
+	MonthlyExecutions          *int64   `infracost_usage:"monthly_executions"`
+	AverageTaskDurationSeconds *float64 `infracost_usage:"average_task_duration_seconds"`
+}
+
+var CloudRunV2JobUsageSchema = []*schema.UsageItem{
+	{Key: "monthly_executions", ValueType: schema.Int64, DefaultValue: 0},
+	{Key: "average_task_duration_seconds", ValueType: schema.Float64, DefaultValue: 0},
+}
+
+func (r *CloudRunV2Job) PopulateUsage(u *schema.UsageData) {
+	resources.PopulateArgsWithUsage(r, u)
+}
+
+func (r *CloudRunV2Job) BuildResource() *schema.Resource {
+	var taskSeconds *decimal.Decimal
+	if r.MonthlyExecutions != nil && r.AverageTaskDurationSeconds != nil {
+		tasks := r.TaskCount
+		if tasks == 0 {
+			tasks = 1
+		}
+
+		taskSeconds = decimalPtr(decimal.NewFromInt(*r.MonthlyExecutions).
+			Mul(decimal.NewFromInt(tasks)).
+			Mul(decimal.NewFromFloat(*r.AverageTaskDurationSeconds)))
+	}
+
+	return &schema.Resource{
+		Name: r.Address,
+		CostComponents: []*schema.CostComponent{
+			cloudRunCPUCostComponent("CPU allocation time", r.Region, "CPU Allocation Time (always-on CPU)", r.CPU, taskSeconds),
+			cloudRunMemoryCostComponent("Memory allocation time", r.Region, "Memory Allocation Time (always-on CPU)", r.MemoryGiB, taskSeconds),
+		},
+		UsageSchema: CloudRunV2JobUsageSchema,
+	}
+}
@@ -0,0 +1,179 @@
+package google
+
+import (
+	"github.com/shopspring/decimal"
+
+	"github.com/infracost/infracost/internal/resources"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+const cloudRunSecondsPerMonth = 730 * 60 * 60
+
+// CloudRunV2Service struct represents a Cloud Run service.
+//
+// Services with request-based billing (cpu_idle = true) are charged for the CPU and
+// memory allocated while requests are being processed as well as per request. Any
+// minimum instances are charged at a reduced idle rate for the rest of the time.
+//
+// Services with instance-based billing (cpu_idle = false) are charged for the CPU and
+// memory allocated for the entire lifetime of the instances and have no request fee.
+//
+// Resource information: https://cloud.google.com/run/docs/overview/what-is-cloud-run
+// Pricing information: https://cloud.google.com/run/pricing
+type CloudRunV2Service struct {
+	Address          string
+	Region           string
+	CPU              float64
+	MemoryGiB        float64
+	CPUIdle          bool
+	MinInstanceCount int64
+	Concurrency      int64
+
+	MonthlyRequests          *int64   `infracost_usage:"monthly_requests"`
+	AverageRequestDurationMs *int64   `infracost_usage:"average_request_duration_ms"`
+	AverageInstanceCount     *float64 `infracost_usage:"average_instance_count"`
+}
+
+var CloudRunV2ServiceUsageSchema = []*schema.UsageItem{
+	{Key: "monthly_requests", ValueType: schema.Int64, DefaultValue: 0},
+	{Key: "average_request_duration_ms", ValueType: schema.Int64, DefaultValue: 0},
+	{Key: "average_instance_count", ValueType: schema.Float64, DefaultValue: 0},
+}
+
+func (r *CloudRunV2Service) PopulateUsage(u *schema.UsageData) {
+	resources.PopulateArgsWithUsage(r, u)
+}
+
+func (r *CloudRunV2Service) BuildResource() *schema.Resource {
+	var costComponents []*schema.CostComponent
+
+	if r.CPUIdle {
+		costComponents = r.requestBasedCostComponents()
+	} else {
+		costComponents = r.instanceBasedCostComponents()
+	}
+
+	return &schema.Resource{
+		Name:           r.Address,
+		CostComponents: costComponents,
+		UsageSchema:    CloudRunV2ServiceUsageSchema,
+	}
+}
+
+func (r *CloudRunV2Service) requestBasedCostComponents() []*schema.CostComponent {
+	var requests, instanceSeconds *decimal.Decimal
+	if r.MonthlyRequests != nil {
+		requests = decimalPtr(decimal.NewFromInt(*r.MonthlyRequests))
+
+		if r.AverageRequestDurationMs != nil {
+			concurrency := decimal.NewFromInt(r.Concurrency)
+			if concurrency.IsZero() {
+				concurrency = decimal.NewFromInt(1)
+			}
+
+			instanceSeconds = decimalPtr(requests.
+				Mul(decimal.NewFromInt(*r.AverageRequestDurationMs)).
+				Div(decimal.NewFromInt(1000)).
+				Div(concurrency))
+		}
+	}
+
+	costComponents := []*schema.CostComponent{
+		cloudRunCPUCostComponent("CPU allocation time", r.Region, "CPU Allocation Time", r.CPU, instanceSeconds),
+		cloudRunMemoryCostComponent("Memory allocation time", r.Region, "Memory Allocation Time", r.MemoryGiB, instanceSeconds),
+		{
+			Name:            "Requests",
+			Unit:            "1M requests",
+			UnitMultiplier:  decimal.NewFromInt(1000000),
+			MonthlyQuantity: requests,
+			ProductFilter: &schema.ProductFilter{
+				VendorName:    vendorName,
+				Region:        strPtr(r.Region),
+				Service:       strPtr("Cloud Run"),
+				ProductFamily: strPtr("ApplicationServices"),
+				AttributeFilters: []*schema.AttributeFilter{
+					{Key: "description", ValueRegex: strPtr("/^Requests/")},
+				},
+			},
+		},
+	}
+
+	if r.MinInstanceCount > 0 {
+		// The min instances are only idle while they're not processing requests,
+		// which is already charged at the active rate.
+		idle := decimal.NewFromInt(r.MinInstanceCount * cloudRunSecondsPerMonth)
+		if instanceSeconds != nil {
+			idle = decimal.Max(idle.Sub(*instanceSeconds), decimal.Zero)
+		}
+		idleSeconds := decimalPtr(idle)
+		costComponents = append(costComponents,
+			cloudRunCPUCostComponent("Idle min-instance CPU", r.Region, "Idle Min-Instance CPU Allocation Time", r.CPU, idleSeconds),
+			cloudRunMemoryCostComponent("Idle min-instance memory", r.Region, "Idle Min-Instance Memory Allocation Time", r.MemoryGiB, idleSeconds),
+		)
+	}
+
+	return costComponents
+}
+
+func (r *CloudRunV2Service) instanceBasedCostComponents() []*schema.CostComponent {
+	instances := decimal.NewFromInt(r.MinInstanceCount)
+	if r.AverageInstanceCount != nil {
+		instances = decimal.NewFromFloat(*r.AverageInstanceCount)
+	}
+
+	var instanceSeconds *decimal.Decimal
+	if r.AverageInstanceCount != nil || r.MinInstanceCount > 0 {
+		instanceSeconds = decimalPtr(instances.Mul(decimal.NewFromInt(cloudRunSecondsPerMonth)))
+	}
+
+	return []*schema.CostComponent{
+		cloudRunCPUCostComponent("CPU allocation time (always allocated)", r.Region, "CPU Allocation Time (always-on CPU)", r.CPU, instanceSeconds),
+		cloudRunMemoryCostComponent("Memory allocation time (always allocated)", r.Region, "Memory Allocation Time (always-on CPU)", r.MemoryGiB, instanceSeconds),
+	}
+}
+
+func cloudRunCPUCostComponent(name, region, description string, cpu float64, instanceSeconds *decimal.Decimal) *schema.CostComponent {
+	var quantity *decimal.Decimal
+	if instanceSeconds != nil {
+		quantity = decimalPtr(instanceSeconds.Mul(decimal.NewFromFloat(cpu)))
+	}
+
+	return &schema.CostComponent{
+		Name:            name,
+		Unit:            "vCPU-seconds",
+		UnitMultiplier:  decimal.NewFromInt(1),
+		MonthlyQuantity: quantity,
+		ProductFilter: &schema.ProductFilter{
+			VendorName:    vendorName,
+			Region:        strPtr(region),
+			Service:       strPtr("Cloud Run"),
+			ProductFamily: strPtr("ApplicationServices"),
+			AttributeFilters: []*schema.AttributeFilter{
+				{Key: "description", Value: strPtr(description)},
+			},
+		},
+	}
+}
+
+func cloudRunMemoryCostComponent(name, region, description string, memoryGiB float64, instanceSeconds *decimal.Decimal) *schema.CostComponent {
+	var quantity *decimal.Decimal
+	if instanceSeconds != nil {
+		quantity = decimalPtr(instanceSeconds.Mul(decimal.NewFromFloat(memoryGiB)))
+	}
+
+	return &schema.CostComponent{
+		Name:            name,
+		Unit:            "GiB-seconds",
+		UnitMultiplier:  decimal.NewFromInt(1),
+		MonthlyQuantity: quantity,
+		ProductFilter: &schema.ProductFilter{
+			VendorName:    vendorName,
+			Region:        strPtr(region),
+			Service:       strPtr("Cloud Run"),
+			ProductFamily: strPtr("ApplicationServices"),
+			AttributeFilters: []*schema.AttributeFilter{
+				{Key: "description", Value: strPtr(description)},
+			},
+		},
+	}
+}
@@ -0,0 +1,80 @@
+package google_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	resources "github.com/infracost/infracost/internal/resources/google"
+)
+
+func TestCloudRunV2ServiceIdleMinInstanceSeconds(t *testing.T) {
+	t.Parallel()
+
+	requests := int64(1000000)
+	durationMs := int64(1000)
+
+	tests := []struct {
+		name     string
+		requests *int64
+		expected string
+	}{
+		// 2 min instances are idle for the whole month without requests
+		{name: "no usage", expected: "5256000"},
+		// 1M requests of 1s are 1M active instance-seconds, which aren't idle
+		{name: "with requests", requests: &requests, expected: "4256000"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := resources.CloudRunV2Service{
+				Address:                  "google_cloud_run_v2_service.test",
+				Region:                   "us-central1",
+				CPU:                      1,
+				MemoryGiB:                1,
+				CPUIdle:                  true,
+				MinInstanceCount:         2,
+				Concurrency:              1,
+				MonthlyRequests:          tt.requests,
+				AverageRequestDurationMs: &durationMs,
+			}
+
+			var idle string
+			for _, c := range r.BuildResource().CostComponents {
+				if c.Name == "Idle min-instance CPU" {
+					require.NotNil(t, c.MonthlyQuantity)
+					idle = c.MonthlyQuantity.String()
+				}
+			}
+
+			assert.Equal(t, tt.expected, idle)
+		})
+	}
+}
+
+func TestCloudRunV2ServiceIdleMinInstanceSecondsNotNegative(t *testing.T) {
+	t.Parallel()
+
+	requests := int64(100000000)
+	durationMs := int64(1000)
+
+	r := resources.CloudRunV2Service{
+		Address:                  "google_cloud_run_v2_service.test",
+		Region:                   "us-central1",
+		CPU:                      1,
+		MemoryGiB:                1,
+		CPUIdle:                  true,
+		MinInstanceCount:         1,
+		Concurrency:              1,
+		MonthlyRequests:          &requests,
+		AverageRequestDurationMs: &durationMs,
+	}
+
+	for _, c := range r.BuildResource().CostComponents {
+		if c.Name == "Idle min-instance memory" {
+			require.NotNil(t, c.MonthlyQuantity)
+			assert.True(t, c.MonthlyQuantity.IsZero())
+		}
+	}
+}
@@ -1,8 +1,12 @@
 package google
 
 import (
+	"context"
+	"math"
+
 	"github.com/infracost/infracost/internal/resources"
 	"github.com/infracost/infracost/internal/schema"
+	googleusage "github.com/infracost/infracost/internal/usage/google"
 
 	"github.com/shopspring/decimal"
 )
@@ -10,6 +14,8 @@ import (
 type CloudFunctionsFunction struct {
 	Address                    string
 	Region                     string
+	Project                    string
+	Name                       string
 	AvailableMemoryMB          *int64
 	RequestDurationMs          *int64   `infracost_usage:"request_duration_ms"`
 	MonthlyFunctionInvocations *int64   `infracost_usage:"monthly_function_invocations"`
@@ -61,8 +67,27 @@ func (r *CloudFunctionsFunction) BuildResource() *schema.Resource {
 		networkEgress = decimalPtr(decimal.NewFromFloat(*r.MonthlyOutboundDataGB))
 	}
 
+	var estimate schema.EstimateFunc
+	if r.Project != "" {
+		estimate = func(ctx context.Context, values map[string]interface{}) error {
+			executions, err := googleusage.CloudFunctionsGetExecutions(ctx, r.Project, r.Name)
+			if err != nil {
+				return err
+			}
+			values["monthly_function_invocations"] = int64(math.Round(executions))
+
+			egress, err := googleusage.CloudFunctionsGetEgressBytes(ctx, r.Project, r.Name)
+			if err != nil {
+				return err
+			}
+			values["monthly_outbound_data_gb"] = egress / 1000 / 1000 / 1000
+			return nil
+		}
+	}
+
 	return &schema.Resource{
-		Name: r.Address,
+		Name:          r.Address,
+		EstimateUsage: estimate,
 		CostComponents: []*schema.CostComponent{
 			{
 				Name:            "CPU",
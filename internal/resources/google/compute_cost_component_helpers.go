@@ -28,15 +28,10 @@ type ContainerNodeConfig struct {
 func computeCostComponent(region, machineType string, purchaseOption string, instanceCount int64) *schema.CostComponent {
 	sustainedUseDiscount := 0.0
 	if strings.ToLower(purchaseOption) == "on_demand" {
-		switch strings.ToLower(strings.Split(machineType, "-")[0]) {
-		case "c2", "n2", "n2d":
-			sustainedUseDiscount = 0.2
-		case "n1", "f1", "g1", "m1":
-			sustainedUseDiscount = 0.3
-		}
+		sustainedUseDiscount = machineTypeSustainedUseDiscount(machineType)
 	}
 
-	return &schema.CostComponent{
+	c := &schema.CostComponent{
 		Name:                fmt.Sprintf("Instance usage (Linux/UNIX, %s, %s)", purchaseOptionLabel(purchaseOption), machineType),
 		Unit:                "hours",
 		UnitMultiplier:      decimal.NewFromInt(1),
@@ -55,6 +50,32 @@ func computeCostComponent(region, machineType string, purchaseOption string, ins
 			PurchaseOption: strPtr(purchaseOption),
 		},
 	}
+
+	if strings.ToLower(purchaseOption) == "preemptible" {
+		onDemand := *c
+		onDemand.PriceFilter = &schema.PriceFilter{PurchaseOption: strPtr("on_demand")}
+		c.SpotFallback = &onDemand
+	}
+
+	return c
+}
+
+// machineTypeSustainedUseDiscount returns the sustained use discount of a machine
+// type running for the whole month. N1 predefined and custom machine types,
+// shared-core, M1 and M2 get up to 30% off, N2, N2D and C2 get up to 20% off and
+// other machine series, e.g. E2, A2, C2D and T2D, aren't eligible.
+// See https://cloud.google.com/compute/docs/sustained-use-discounts
+func machineTypeSustainedUseDiscount(machineType string) float64 {
+	series := strings.ToLower(strings.Split(machineType, "-")[0])
+
+	switch series {
+	case "n1", "f1", "g1", "m1", "m2", "custom":
+		return 0.3
+	case "n2", "n2d", "c2":
+		return 0.2
+	}
+
+	return 0.0
 }
 
 // bootDiskCostComponent returns a cost component for Boot Disk storage for
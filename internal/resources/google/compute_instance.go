@@ -1,6 +1,10 @@
 package google
 
 import (
+	"strings"
+
+	"github.com/shopspring/decimal"
+
 	"github.com/infracost/infracost/internal/resources"
 	"github.com/infracost/infracost/internal/schema"
 )
@@ -18,10 +22,14 @@ type ComputeInstance struct {
 	BootDiskType      string
 	ScratchDisks      int
 	GuestAccelerators []*ComputeGuestAccelerator
+
+	SpotUptimePercent *float64 `infracost_usage:"spot_uptime_percent"`
 }
 
 // ComputeInstanceUsageSchema defines a list which represents the usage schema of ComputeInstance.
-var ComputeInstanceUsageSchema = []*schema.UsageItem{}
+var ComputeInstanceUsageSchema = []*schema.UsageItem{
+	{Key: "spot_uptime_percent", DefaultValue: 100, ValueType: schema.Float64},
+}
 
 // PopulateUsage parses the u schema.UsageData into the ComputeInstance.
 // It uses the `infracost_usage` struct tags to populate data into the ComputeInstance.
@@ -33,10 +41,17 @@ func (r *ComputeInstance) PopulateUsage(u *schema.UsageData) {
 // This method is called after the resource is initialised by an IaC provider.
 // See providers folder for more information.
 func (r *ComputeInstance) BuildResource() *schema.Resource {
-	costComponents := []*schema.CostComponent{
-		computeCostComponent(r.Region, r.MachineType, r.PurchaseOption, r.Size),
+	// Preemptible and spot VMs only run until they're preempted
+	size := decimal.NewFromInt(r.Size)
+	if strings.ToLower(r.PurchaseOption) == "preemptible" && r.SpotUptimePercent != nil {
+		size = size.Mul(decimal.NewFromFloat(*r.SpotUptimePercent).Div(decimal.NewFromInt(100)))
 	}
 
+	instanceUsage := computeCostComponent(r.Region, r.MachineType, r.PurchaseOption, r.Size)
+	instanceUsage.HourlyQuantity = decimalPtr(size)
+
+	costComponents := []*schema.CostComponent{instanceUsage}
+
 	if r.HasBootDisk {
 		costComponents = append(costComponents, bootDiskCostComponent(r.Region, r.BootDiskSize, r.BootDiskType))
 	}
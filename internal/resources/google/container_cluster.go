@@ -22,6 +22,13 @@ type ContainerCluster struct {
 	AutopilotVCPUCount          *float64 `infracost_usage:"autopilot_vcpu_count"`
 	AutopilotMemoryGB           *float64 `infracost_usage:"autopilot_memory_gb"`
 	AutopilotEphemeralStorageGB *float64 `infracost_usage:"autopilot_ephemeral_storage_gb"`
+
+	// Per-pod Autopilot usage args, these are used to calculate the totals above
+	// when they are not set directly.
+	AutopilotPodCount              *float64 `infracost_usage:"autopilot_pod_count"`
+	AutopilotPodVCPU               *float64 `infracost_usage:"autopilot_pod_vcpu"`
+	AutopilotPodMemoryGB           *float64 `infracost_usage:"autopilot_pod_memory_gb"`
+	AutopilotPodEphemeralStorageGB *float64 `infracost_usage:"autopilot_pod_ephemeral_storage_gb"`
 }
 
 // ContainerClusterUsageSchema defines a list which represents the usage schema of ContainerCluster.
@@ -31,6 +38,10 @@ var ContainerClusterUsageSchema = []*schema.UsageItem{
 	{Key: "autopilot_vcpu_count", DefaultValue: 0, ValueType: schema.Float64},
 	{Key: "autopilot_memory_gb", DefaultValue: 0, ValueType: schema.Float64},
 	{Key: "autopilot_ephemeral_storage_gb", DefaultValue: 0, ValueType: schema.Float64},
+	{Key: "autopilot_pod_count", DefaultValue: 0, ValueType: schema.Float64},
+	{Key: "autopilot_pod_vcpu", DefaultValue: 0, ValueType: schema.Float64},
+	{Key: "autopilot_pod_memory_gb", DefaultValue: 0, ValueType: schema.Float64},
+	{Key: "autopilot_pod_ephemeral_storage_gb", DefaultValue: 0, ValueType: schema.Float64},
 }
 
 // PopulateUsage parses the u schema.UsageData into the ContainerCluster.
@@ -41,6 +52,7 @@ func (r *ContainerCluster) PopulateUsage(u *schema.UsageData) {
 	}
 
 	resources.PopulateArgsWithUsage(r, u)
+	r.populateAutopilotTotals()
 
 	if r.DefaultNodePool != nil {
 		r.DefaultNodePool.PopulateUsage(u)
@@ -53,6 +65,35 @@ func (r *ContainerCluster) PopulateUsage(u *schema.UsageData) {
 	}
 }
 
+// populateAutopilotTotals calculates the total Autopilot resource requests from the
+// per-pod usage args. Totals that are set directly in the usage file take precedence.
+func (r *ContainerCluster) populateAutopilotTotals() {
+	if r.AutopilotPodCount == nil {
+		return
+	}
+
+	total := func(perPod *float64) *float64 {
+		if perPod == nil {
+			return nil
+		}
+
+		v := *r.AutopilotPodCount * *perPod
+		return &v
+	}
+
+	if r.AutopilotVCPUCount == nil {
+		r.AutopilotVCPUCount = total(r.AutopilotPodVCPU)
+	}
+
+	if r.AutopilotMemoryGB == nil {
+		r.AutopilotMemoryGB = total(r.AutopilotPodMemoryGB)
+	}
+
+	if r.AutopilotEphemeralStorageGB == nil {
+		r.AutopilotEphemeralStorageGB = total(r.AutopilotPodEphemeralStorageGB)
+	}
+}
+
 // BuildResource builds a schema.Resource from a valid ContainerCluster struct.
 // This method is called after the resource is initialised by an IaC provider.
 // See providers folder for more information.
@@ -0,0 +1,72 @@
+package google
+
+import (
+	"github.com/shopspring/decimal"
+
+	"github.com/infracost/infracost/internal/resources"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+// SpannerDatabase struct represents a Cloud Spanner database. Compute capacity is
+// charged on the instance, the database is charged for the data and backups it stores.
+//
+// Resource information: https://cloud.google.com/spanner/docs/databases
+// Pricing information: https://cloud.google.com/spanner/pricing#storage
+type SpannerDatabase struct {
+	Address string
+	Region  string
+	Config  string
+
+	StorageGB       *float64 `infracost_usage:"storage_gb"`
+	BackupStorageGB *float64 `infracost_usage:"backup_storage_gb"`
+}
+
+var SpannerDatabaseUsageSchema = []*schema.UsageItem{
+	{Key: "storage_gb", ValueType: schema.Float64, DefaultValue: 0},
+	{Key: "backup_storage_gb", ValueType: schema.Float64, DefaultValue: 0},
+}
+
+func (r *SpannerDatabase) PopulateUsage(u *schema.UsageData) {
+	resources.PopulateArgsWithUsage(r, u)
+}
+
+func (r *SpannerDatabase) BuildResource() *schema.Resource {
+	region := spannerConfigRegion(r.Config, r.Region)
+
+	return &schema.Resource{
+		Name: r.Address,
+		CostComponents: []*schema.CostComponent{
+			{
+				Name:            "Storage",
+				Unit:            "GB",
+				UnitMultiplier:  decimal.NewFromInt(1),
+				MonthlyQuantity: floatPtrToDecimalPtr(r.StorageGB),
+				ProductFilter: &schema.ProductFilter{
+					VendorName:    strPtr("gcp"),
+					Region:        strPtr(region),
+					Service:       strPtr("Cloud Spanner"),
+					ProductFamily: strPtr("ApplicationServices"),
+					AttributeFilters: []*schema.AttributeFilter{
+						{Key: "description", ValueRegex: regexPtr("^Spanner.*Storage")},
+					},
+				},
+			},
+			{
+				Name:            "Backup storage",
+				Unit:            "GB",
+				UnitMultiplier:  decimal.NewFromInt(1),
+				MonthlyQuantity: floatPtrToDecimalPtr(r.BackupStorageGB),
+				ProductFilter: &schema.ProductFilter{
+					VendorName:    strPtr("gcp"),
+					Region:        strPtr(region),
+					Service:       strPtr("Cloud Spanner"),
+					ProductFamily: strPtr("ApplicationServices"),
+					AttributeFilters: []*schema.AttributeFilter{
+						{Key: "description", ValueRegex: regexPtr("^Spanner.*Backup Storage")},
+					},
+				},
+			},
+		},
+		UsageSchema: SpannerDatabaseUsageSchema,
+	}
+}
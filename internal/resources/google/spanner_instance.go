@@ -0,0 +1,95 @@
+package google
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/infracost/infracost/internal/resources"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+// SpannerInstance struct represents a Cloud Spanner instance. Instances are charged
+// per hour for their compute capacity, which is expressed either in nodes or in
+// processing units (1000 processing units = 1 node). Regional and multi-region
+// instance configurations have different rates.
+//
+// Resource information: https://cloud.google.com/spanner/docs/instances
+// Pricing information: https://cloud.google.com/spanner/pricing
+type SpannerInstance struct {
+	Address         string
+	Region          string
+	Config          string
+	Edition         string
+	ProcessingUnits int64
+
+	AverageProcessingUnits *float64 `infracost_usage:"average_processing_units"`
+}
+
+var SpannerInstanceUsageSchema = []*schema.UsageItem{
+	{Key: "average_processing_units", ValueType: schema.Float64, DefaultValue: 0},
+}
+
+func (r *SpannerInstance) PopulateUsage(u *schema.UsageData) {
+	resources.PopulateArgsWithUsage(r, u)
+}
+
+func (r *SpannerInstance) BuildResource() *schema.Resource {
+	processingUnits := decimal.NewFromInt(r.ProcessingUnits)
+	if r.AverageProcessingUnits != nil {
+		processingUnits = decimal.NewFromFloat(*r.AverageProcessingUnits)
+	}
+
+	configType := "regional"
+	if spannerIsMultiRegion(r.Config) {
+		configType = "multi-region"
+	}
+
+	edition := "Standard"
+	switch strings.ToUpper(r.Edition) {
+	case "ENTERPRISE":
+		edition = "Enterprise"
+	case "ENTERPRISE_PLUS":
+		edition = "Enterprise Plus"
+	}
+
+	return &schema.Resource{
+		Name: r.Address,
+		CostComponents: []*schema.CostComponent{
+			{
+				Name:           fmt.Sprintf("Compute capacity (%s, %s)", configType, strings.ToLower(edition)),
+				Unit:           "nodes",
+				UnitMultiplier: decimal.NewFromInt(1),
+				HourlyQuantity: decimalPtr(processingUnits.Div(decimal.NewFromInt(1000))),
+				ProductFilter: &schema.ProductFilter{
+					VendorName:    strPtr("gcp"),
+					Region:        strPtr(spannerConfigRegion(r.Config, r.Region)),
+					Service:       strPtr("Cloud Spanner"),
+					ProductFamily: strPtr("ApplicationServices"),
+					AttributeFilters: []*schema.AttributeFilter{
+						{Key: "description", ValueRegex: regexPtr(fmt.Sprintf("^Spanner %s.*Node", edition))},
+					},
+				},
+			},
+		},
+		UsageSchema: SpannerInstanceUsageSchema,
+	}
+}
+
+// spannerIsMultiRegion returns true for multi-region instance configurations such as
+// nam3 or nam-eur-asia1. Regional configurations are prefixed with "regional-".
+func spannerIsMultiRegion(config string) bool {
+	return config != "" && !strings.HasPrefix(config, "regional-")
+}
+
+// spannerConfigRegion returns the pricing region of an instance configuration.
+// Regional configurations map to their GCP region, multi-region configurations
+// are priced under their own name.
+func spannerConfigRegion(config, defaultRegion string) string {
+	if config == "" {
+		return defaultRegion
+	}
+
+	return strings.TrimPrefix(config, "regional-")
+}
@@ -0,0 +1,84 @@
+package google
+
+import (
+	"github.com/shopspring/decimal"
+
+	"github.com/infracost/infracost/internal/resources"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+// VertexAIDataset struct represents a Vertex AI dataset. The dataset itself is
+// free, its costs are the custom training jobs and pipeline runs that use it,
+// which aren't managed by Terraform so they're set in the usage file.
+//
+// Resource information: https://cloud.google.com/vertex-ai/docs/training/overview
+// Pricing information: https://cloud.google.com/vertex-ai/pricing#custom-trained_models
+type VertexAIDataset struct {
+	Address string
+	Region  string
+
+	MachineType          *string  `infracost_usage:"machine_type"`
+	AcceleratorType      *string  `infracost_usage:"accelerator_type"`
+	AcceleratorCount     *int64   `infracost_usage:"accelerator_count"`
+	MonthlyTrainingHours *float64 `infracost_usage:"monthly_training_hours"`
+	MonthlyPipelineRuns  *int64   `infracost_usage:"monthly_pipeline_runs"`
+}
+
+var VertexAIDatasetUsageSchema = []*schema.UsageItem{
+	{Key: "machine_type", ValueType: schema.String, DefaultValue: ""},
+	{Key: "accelerator_type", ValueType: schema.String, DefaultValue: ""},
+	{Key: "accelerator_count", ValueType: schema.Int64, DefaultValue: 0},
+	{Key: "monthly_training_hours", ValueType: schema.Float64, DefaultValue: 0},
+	{Key: "monthly_pipeline_runs", ValueType: schema.Int64, DefaultValue: 0},
+}
+
+func (r *VertexAIDataset) PopulateUsage(u *schema.UsageData) {
+	resources.PopulateArgsWithUsage(r, u)
+}
+
+func (r *VertexAIDataset) BuildResource() *schema.Resource {
+	var costComponents []*schema.CostComponent
+
+	var hours decimal.Decimal
+	if r.MonthlyTrainingHours != nil {
+		hours = decimal.NewFromFloat(*r.MonthlyTrainingHours)
+	}
+
+	if r.MachineType != nil && *r.MachineType != "" {
+		c := vertexAIMachineCostComponent("Training", r.Region, *r.MachineType, hours)
+		if r.MonthlyTrainingHours == nil {
+			c.MonthlyQuantity = nil
+		}
+		costComponents = append(costComponents, c)
+
+		if r.AcceleratorType != nil && *r.AcceleratorType != "" && r.AcceleratorCount != nil && *r.AcceleratorCount > 0 {
+			c := vertexAIAcceleratorCostComponent("Training", r.Region, *r.AcceleratorType, hours.Mul(decimal.NewFromInt(*r.AcceleratorCount)))
+			if r.MonthlyTrainingHours == nil {
+				c.MonthlyQuantity = nil
+			}
+			costComponents = append(costComponents, c)
+		}
+	}
+
+	costComponents = append(costComponents, &schema.CostComponent{
+		Name:            "Pipeline runs",
+		Unit:            "runs",
+		UnitMultiplier:  decimal.NewFromInt(1),
+		MonthlyQuantity: intPtrToDecimalPtr(r.MonthlyPipelineRuns),
+		ProductFilter: &schema.ProductFilter{
+			VendorName:    strPtr("gcp"),
+			Region:        strPtr(r.Region),
+			Service:       strPtr("Vertex AI"),
+			ProductFamily: strPtr("ApplicationServices"),
+			AttributeFilters: []*schema.AttributeFilter{
+				{Key: "description", ValueRegex: regexPtr("Pipelines.*Run")},
+			},
+		},
+	})
+
+	return &schema.Resource{
+		Name:           r.Address,
+		CostComponents: costComponents,
+		UsageSchema:    VertexAIDatasetUsageSchema,
+	}
+}
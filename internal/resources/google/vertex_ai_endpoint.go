@@ -0,0 +1,135 @@
+package google
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/infracost/infracost/internal/resources"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+// VertexAIEndpoint struct represents a Vertex AI endpoint. Endpoints are free, the cost
+// comes from the models deployed to them, which are charged per node-hour for the
+// machine type and any attached accelerators. Models are deployed outside of Terraform
+// so the deployment details are taken from the usage file.
+//
+// Resource information: https://cloud.google.com/vertex-ai/docs/predictions/overview
+// Pricing information: https://cloud.google.com/vertex-ai/pricing#prediction-prices
+type VertexAIEndpoint struct {
+	Address string
+	Region  string
+
+	MachineType      *string  `infracost_usage:"machine_type"`
+	ReplicaCount     *float64 `infracost_usage:"replica_count"`
+	AcceleratorType  *string  `infracost_usage:"accelerator_type"`
+	AcceleratorCount *int64   `infracost_usage:"accelerator_count"`
+	MonthlyHours     *float64 `infracost_usage:"monthly_hours"`
+}
+
+var VertexAIEndpointUsageSchema = []*schema.UsageItem{
+	{Key: "machine_type", ValueType: schema.String, DefaultValue: ""},
+	{Key: "replica_count", ValueType: schema.Float64, DefaultValue: 0},
+	{Key: "accelerator_type", ValueType: schema.String, DefaultValue: ""},
+	{Key: "accelerator_count", ValueType: schema.Int64, DefaultValue: 0},
+	{Key: "monthly_hours", ValueType: schema.Float64, DefaultValue: 730},
+}
+
+func (r *VertexAIEndpoint) PopulateUsage(u *schema.UsageData) {
+	resources.PopulateArgsWithUsage(r, u)
+}
+
+func (r *VertexAIEndpoint) BuildResource() *schema.Resource {
+	if r.MachineType == nil || *r.MachineType == "" {
+		return &schema.Resource{
+			Name:        r.Address,
+			NoPrice:     true,
+			IsSkipped:   true,
+			UsageSchema: VertexAIEndpointUsageSchema,
+		}
+	}
+
+	replicas := decimal.NewFromInt(1)
+	if r.ReplicaCount != nil {
+		replicas = decimal.NewFromFloat(*r.ReplicaCount)
+	}
+
+	hours := decimal.NewFromInt(730)
+	if r.MonthlyHours != nil {
+		hours = decimal.NewFromFloat(*r.MonthlyHours)
+	}
+
+	nodeHours := replicas.Mul(hours)
+
+	costComponents := []*schema.CostComponent{
+		vertexAIMachineCostComponent("Prediction", r.Region, *r.MachineType, nodeHours),
+	}
+
+	if r.AcceleratorType != nil && *r.AcceleratorType != "" && r.AcceleratorCount != nil && *r.AcceleratorCount > 0 {
+		costComponents = append(costComponents,
+			vertexAIAcceleratorCostComponent("Prediction", r.Region, *r.AcceleratorType, nodeHours.Mul(decimal.NewFromInt(*r.AcceleratorCount))),
+		)
+	}
+
+	return &schema.Resource{
+		Name:           r.Address,
+		CostComponents: costComponents,
+		UsageSchema:    VertexAIEndpointUsageSchema,
+	}
+}
+
+func vertexAIMachineCostComponent(workload, region, machineType string, hours decimal.Decimal) *schema.CostComponent {
+	return &schema.CostComponent{
+		Name:            fmt.Sprintf("%s node (%s)", workload, machineType),
+		Unit:            "hours",
+		UnitMultiplier:  decimal.NewFromInt(1),
+		MonthlyQuantity: decimalPtr(hours),
+		ProductFilter: &schema.ProductFilter{
+			VendorName:    strPtr("gcp"),
+			Region:        strPtr(region),
+			Service:       strPtr("Vertex AI"),
+			ProductFamily: strPtr("ApplicationServices"),
+			AttributeFilters: []*schema.AttributeFilter{
+				{Key: "description", ValueRegex: regexPtr(fmt.Sprintf("%s.*%s", workload, machineType))},
+			},
+		},
+	}
+}
+
+func vertexAIAcceleratorCostComponent(workload, region, acceleratorType string, hours decimal.Decimal) *schema.CostComponent {
+	name := vertexAIAcceleratorName(acceleratorType)
+
+	return &schema.CostComponent{
+		Name:            fmt.Sprintf("%s accelerator (%s)", workload, name),
+		Unit:            "hours",
+		UnitMultiplier:  decimal.NewFromInt(1),
+		MonthlyQuantity: decimalPtr(hours),
+		ProductFilter: &schema.ProductFilter{
+			VendorName:    strPtr("gcp"),
+			Region:        strPtr(region),
+			Service:       strPtr("Vertex AI"),
+			ProductFamily: strPtr("ApplicationServices"),
+			AttributeFilters: []*schema.AttributeFilter{
+				{Key: "description", ValueRegex: regexPtr(fmt.Sprintf("%s.*%s", workload, name))},
+			},
+		},
+	}
+}
+
+// vertexAIAcceleratorName converts accelerator types as used in the Vertex AI API,
+// e.g. NVIDIA_TESLA_T4, to the names used in the pricing SKUs, e.g. Tesla T4.
+func vertexAIAcceleratorName(acceleratorType string) string {
+	parts := strings.Split(strings.ToUpper(acceleratorType), "_")
+	if len(parts) > 0 && parts[0] == "NVIDIA" {
+		parts = parts[1:]
+	}
+
+	for i, p := range parts {
+		if p == "TESLA" {
+			parts[i] = "Tesla"
+		}
+	}
+
+	return strings.Join(parts, " ")
+}
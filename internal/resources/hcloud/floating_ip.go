@@ -0,0 +1,57 @@
+package hcloud
+
+import (
+	"fmt"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/infracost/infracost/internal/resources"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+// FloatingIP struct represents a Hetzner Cloud floating IP. Floating IPs are charged
+// a flat monthly price.
+//
+// Resource information: https://docs.hetzner.com/cloud/floating-ips/overview
+// Pricing information: https://www.hetzner.com/cloud
+type FloatingIP struct {
+	Address  string
+	Location string
+	Type     string
+}
+
+// FloatingIPUsageSchema defines a list which represents the usage schema of FloatingIP.
+var FloatingIPUsageSchema = []*schema.UsageItem{}
+
+// PopulateUsage parses the u schema.UsageData into the FloatingIP.
+// It uses the `infracost_usage` struct tags to populate data into the FloatingIP.
+func (r *FloatingIP) PopulateUsage(u *schema.UsageData) {
+	resources.PopulateArgsWithUsage(r, u)
+}
+
+// BuildResource builds a schema.Resource from a valid FloatingIP struct.
+// This method is called after the resource is initialised by an IaC provider.
+// See providers folder for more information.
+func (r *FloatingIP) BuildResource() *schema.Resource {
+	return &schema.Resource{
+		Name:        r.Address,
+		UsageSchema: FloatingIPUsageSchema,
+		CostComponents: []*schema.CostComponent{
+			{
+				Name:            fmt.Sprintf("Floating IP (%s)", r.Type),
+				Unit:            "months",
+				UnitMultiplier:  decimal.NewFromInt(1),
+				MonthlyQuantity: decimalPtr(decimal.NewFromInt(1)),
+				ProductFilter: &schema.ProductFilter{
+					VendorName:    strPtr(vendorName),
+					Region:        strPtr(r.Location),
+					Service:       strPtr("Networking"),
+					ProductFamily: strPtr("Floating IP"),
+					AttributeFilters: []*schema.AttributeFilter{
+						{Key: "type", Value: strPtr(r.Type)},
+					},
+				},
+			},
+		},
+	}
+}
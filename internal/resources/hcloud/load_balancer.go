@@ -0,0 +1,57 @@
+package hcloud
+
+import (
+	"fmt"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/infracost/infracost/internal/resources"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+// LoadBalancer struct represents a Hetzner Cloud load balancer. Load balancers are
+// charged a flat monthly price for their type.
+//
+// Resource information: https://docs.hetzner.com/cloud/load-balancers/overview
+// Pricing information: https://www.hetzner.com/cloud/load-balancer
+type LoadBalancer struct {
+	Address          string
+	Location         string
+	LoadBalancerType string
+}
+
+// LoadBalancerUsageSchema defines a list which represents the usage schema of LoadBalancer.
+var LoadBalancerUsageSchema = []*schema.UsageItem{}
+
+// PopulateUsage parses the u schema.UsageData into the LoadBalancer.
+// It uses the `infracost_usage` struct tags to populate data into the LoadBalancer.
+func (r *LoadBalancer) PopulateUsage(u *schema.UsageData) {
+	resources.PopulateArgsWithUsage(r, u)
+}
+
+// BuildResource builds a schema.Resource from a valid LoadBalancer struct.
+// This method is called after the resource is initialised by an IaC provider.
+// See providers folder for more information.
+func (r *LoadBalancer) BuildResource() *schema.Resource {
+	return &schema.Resource{
+		Name:        r.Address,
+		UsageSchema: LoadBalancerUsageSchema,
+		CostComponents: []*schema.CostComponent{
+			{
+				Name:            fmt.Sprintf("Load balancer (%s)", r.LoadBalancerType),
+				Unit:            "months",
+				UnitMultiplier:  decimal.NewFromInt(1),
+				MonthlyQuantity: decimalPtr(decimal.NewFromInt(1)),
+				ProductFilter: &schema.ProductFilter{
+					VendorName:    strPtr(vendorName),
+					Region:        strPtr(r.Location),
+					Service:       strPtr("Load Balancers"),
+					ProductFamily: strPtr("Networking"),
+					AttributeFilters: []*schema.AttributeFilter{
+						{Key: "loadBalancerType", Value: strPtr(r.LoadBalancerType)},
+					},
+				},
+			},
+		},
+	}
+}
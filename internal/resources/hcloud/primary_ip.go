@@ -0,0 +1,68 @@
+package hcloud
+
+import (
+	"github.com/shopspring/decimal"
+
+	"github.com/infracost/infracost/internal/resources"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+// PrimaryIP struct represents a Hetzner Cloud primary IP. IPv4 primary IPs are charged
+// a flat monthly price, IPv6 primary IPs are free.
+//
+// Resource information: https://docs.hetzner.com/cloud/servers/primary-ips/overview
+// Pricing information: https://www.hetzner.com/cloud
+type PrimaryIP struct {
+	Address  string
+	Location string
+	Type     string
+}
+
+// PrimaryIPUsageSchema defines a list which represents the usage schema of PrimaryIP.
+var PrimaryIPUsageSchema = []*schema.UsageItem{}
+
+// PopulateUsage parses the u schema.UsageData into the PrimaryIP.
+// It uses the `infracost_usage` struct tags to populate data into the PrimaryIP.
+func (r *PrimaryIP) PopulateUsage(u *schema.UsageData) {
+	resources.PopulateArgsWithUsage(r, u)
+}
+
+// BuildResource builds a schema.Resource from a valid PrimaryIP struct.
+// This method is called after the resource is initialised by an IaC provider.
+// See providers folder for more information.
+func (r *PrimaryIP) BuildResource() *schema.Resource {
+	if r.Type != "ipv4" {
+		return &schema.Resource{
+			Name:        r.Address,
+			IsSkipped:   true,
+			NoPrice:     true,
+			UsageSchema: PrimaryIPUsageSchema,
+		}
+	}
+
+	return &schema.Resource{
+		Name:           r.Address,
+		UsageSchema:    PrimaryIPUsageSchema,
+		CostComponents: []*schema.CostComponent{primaryIPCostComponent(r.Location)},
+	}
+}
+
+// primaryIPCostComponent returns the cost component for an IPv4 primary IP. It's
+// shared by primary IPs and servers which create their own primary IPv4 address.
+func primaryIPCostComponent(location string) *schema.CostComponent {
+	return &schema.CostComponent{
+		Name:            "Primary IPv4",
+		Unit:            "months",
+		UnitMultiplier:  decimal.NewFromInt(1),
+		MonthlyQuantity: decimalPtr(decimal.NewFromInt(1)),
+		ProductFilter: &schema.ProductFilter{
+			VendorName:    strPtr(vendorName),
+			Region:        strPtr(location),
+			Service:       strPtr("Networking"),
+			ProductFamily: strPtr("Primary IP"),
+			AttributeFilters: []*schema.AttributeFilter{
+				{Key: "type", Value: strPtr("ipv4")},
+			},
+		},
+	}
+}
@@ -0,0 +1,126 @@
+package hcloud
+
+import (
+	"fmt"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/infracost/infracost/internal/resources"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+// includedTrafficTB is the outbound traffic included with each server per location.
+// Locations that aren't listed include 20TB.
+var includedTrafficTB = map[string]float64{
+	"ash": 1,
+	"hil": 1,
+	"sin": 0.5,
+}
+
+const defaultIncludedTrafficTB = 20
+
+// Server struct represents a Hetzner Cloud server.
+//
+// Servers are charged a flat monthly price for their server type, backups add 20% of
+// the server price and servers with a public IPv4 address are charged for the primary
+// IP. Each server includes an amount of outbound traffic depending on its location,
+// traffic above that is charged per TB.
+//
+// Resource information: https://docs.hetzner.com/cloud/servers/overview
+// Pricing information: https://www.hetzner.com/cloud
+type Server struct {
+	Address     string
+	Location    string
+	ServerType  string
+	Backups     bool
+	IPv4Enabled bool
+
+	MonthlyOutboundDataGB *float64 `infracost_usage:"monthly_outbound_data_gb"`
+}
+
+// ServerUsageSchema defines a list which represents the usage schema of Server.
+var ServerUsageSchema = []*schema.UsageItem{
+	{Key: "monthly_outbound_data_gb", DefaultValue: 0, ValueType: schema.Float64},
+}
+
+// PopulateUsage parses the u schema.UsageData into the Server.
+// It uses the `infracost_usage` struct tags to populate data into the Server.
+func (r *Server) PopulateUsage(u *schema.UsageData) {
+	resources.PopulateArgsWithUsage(r, u)
+}
+
+// BuildResource builds a schema.Resource from a valid Server struct.
+// This method is called after the resource is initialised by an IaC provider.
+// See providers folder for more information.
+func (r *Server) BuildResource() *schema.Resource {
+	costComponents := []*schema.CostComponent{
+		r.serverCostComponent(fmt.Sprintf("Server (%s)", r.ServerType), "Compute"),
+	}
+
+	if r.Backups {
+		costComponents = append(costComponents, r.serverCostComponent("Backups", "Backups"))
+	}
+
+	if r.IPv4Enabled {
+		costComponents = append(costComponents, primaryIPCostComponent(r.Location))
+	}
+
+	costComponents = append(costComponents, r.trafficCostComponent())
+
+	return &schema.Resource{
+		Name:           r.Address,
+		UsageSchema:    ServerUsageSchema,
+		CostComponents: costComponents,
+	}
+}
+
+func (r *Server) serverCostComponent(name, productFamily string) *schema.CostComponent {
+	return &schema.CostComponent{
+		Name:            name,
+		Unit:            "months",
+		UnitMultiplier:  decimal.NewFromInt(1),
+		MonthlyQuantity: decimalPtr(decimal.NewFromInt(1)),
+		ProductFilter: &schema.ProductFilter{
+			VendorName:    strPtr(vendorName),
+			Region:        strPtr(r.Location),
+			Service:       strPtr("Servers"),
+			ProductFamily: strPtr(productFamily),
+			AttributeFilters: []*schema.AttributeFilter{
+				{Key: "serverType", Value: strPtr(r.ServerType)},
+			},
+		},
+	}
+}
+
+func (r *Server) trafficCostComponent() *schema.CostComponent {
+	included, ok := includedTrafficTB[r.Location]
+	if !ok {
+		included = defaultIncludedTrafficTB
+	}
+
+	var quantity *decimal.Decimal
+	if r.MonthlyOutboundDataGB != nil {
+		overage := decimal.NewFromFloat(*r.MonthlyOutboundDataGB).Div(decimal.NewFromInt(1000)).Sub(decimal.NewFromFloat(included))
+		if overage.IsNegative() {
+			overage = decimal.Zero
+		}
+
+		quantity = decimalPtr(overage)
+	}
+
+	return &schema.CostComponent{
+		Name:            fmt.Sprintf("Outbound data transfer (over %sTB)", decimal.NewFromFloat(included).String()),
+		Unit:            "TB",
+		UnitMultiplier:  decimal.NewFromInt(1),
+		MonthlyQuantity: quantity,
+		ProductFilter: &schema.ProductFilter{
+			VendorName:    strPtr(vendorName),
+			Region:        strPtr(r.Location),
+			Service:       strPtr("Networking"),
+			ProductFamily: strPtr("Traffic"),
+			AttributeFilters: []*schema.AttributeFilter{
+				{Key: "resource", Value: strPtr("Outbound traffic")},
+			},
+		},
+	}
+}
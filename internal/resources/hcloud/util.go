@@ -0,0 +1,17 @@
+package hcloud
+
+import (
+	"github.com/shopspring/decimal"
+)
+
+const (
+	vendorName = "hcloud"
+)
+
+func strPtr(s string) *string {
+	return &s
+}
+
+func decimalPtr(d decimal.Decimal) *decimal.Decimal {
+	return &d
+}
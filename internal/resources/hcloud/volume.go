@@ -0,0 +1,55 @@
+package hcloud
+
+import (
+	"github.com/shopspring/decimal"
+
+	"github.com/infracost/infracost/internal/resources"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+// Volume struct represents a Hetzner Cloud volume. Volumes are charged per GB-month
+// of provisioned storage.
+//
+// Resource information: https://docs.hetzner.com/cloud/volumes/overview
+// Pricing information: https://www.hetzner.com/cloud
+type Volume struct {
+	Address  string
+	Location string
+	SizeGB   float64
+}
+
+// VolumeUsageSchema defines a list which represents the usage schema of Volume.
+var VolumeUsageSchema = []*schema.UsageItem{}
+
+// PopulateUsage parses the u schema.UsageData into the Volume.
+// It uses the `infracost_usage` struct tags to populate data into the Volume.
+func (r *Volume) PopulateUsage(u *schema.UsageData) {
+	resources.PopulateArgsWithUsage(r, u)
+}
+
+// BuildResource builds a schema.Resource from a valid Volume struct.
+// This method is called after the resource is initialised by an IaC provider.
+// See providers folder for more information.
+func (r *Volume) BuildResource() *schema.Resource {
+	return &schema.Resource{
+		Name:        r.Address,
+		UsageSchema: VolumeUsageSchema,
+		CostComponents: []*schema.CostComponent{
+			{
+				Name:            "Storage",
+				Unit:            "GB",
+				UnitMultiplier:  decimal.NewFromInt(1),
+				MonthlyQuantity: decimalPtr(decimal.NewFromFloat(r.SizeGB)),
+				ProductFilter: &schema.ProductFilter{
+					VendorName:    strPtr(vendorName),
+					Region:        strPtr(r.Location),
+					Service:       strPtr("Volumes"),
+					ProductFamily: strPtr("Storage"),
+					AttributeFilters: []*schema.AttributeFilter{
+						{Key: "resource", Value: strPtr("Storage")},
+					},
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,171 @@
+package mongodbatlas
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/infracost/infracost/internal/resources"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+// includedStorageGB is the storage included with each dedicated instance size.
+var includedStorageGB = map[string]float64{
+	"M10":  10,
+	"M20":  20,
+	"M30":  40,
+	"M40":  80,
+	"M50":  160,
+	"M60":  320,
+	"M80":  750,
+	"M140": 1000,
+	"M200": 1500,
+	"M300": 2000,
+}
+
+var sharedInstanceSizes = map[string]bool{
+	"M0": true,
+	"M2": true,
+	"M5": true,
+}
+
+// ClusterNodeGroup represents a group of nodes of the same type in one region of a
+// MongoDB Atlas cluster, e.g. the electable nodes in AWS US_EAST_1.
+type ClusterNodeGroup struct {
+	Type         string
+	ProviderName string
+	RegionName   string
+	InstanceSize string
+	NodeCount    int64
+}
+
+// Cluster struct represents a MongoDB Atlas cluster. It's used for both the
+// mongodbatlas_cluster and mongodbatlas_advanced_cluster resources.
+//
+// Dedicated clusters are charged per node-hour for the instance size of each node,
+// electable, read-only and analytics nodes are all charged the same way. Storage above
+// the amount included with the instance size is charged per GB-month for each node and
+// cloud backup snapshots are charged per GB-month. Shared tier clusters are charged a
+// flat monthly price.
+//
+// Resource information: https://www.mongodb.com/docs/atlas/
+// Pricing information: https://www.mongodb.com/pricing
+type Cluster struct {
+	Address       string
+	NodeGroups    []*ClusterNodeGroup
+	Shards        int64
+	DiskSizeGB    float64
+	BackupEnabled bool
+
+	BackupStorageGB *float64 `infracost_usage:"backup_storage_gb"`
+}
+
+// ClusterUsageSchema defines a list which represents the usage schema of Cluster.
+var ClusterUsageSchema = []*schema.UsageItem{
+	{Key: "backup_storage_gb", DefaultValue: 0, ValueType: schema.Float64},
+}
+
+// PopulateUsage parses the u schema.UsageData into the Cluster.
+// It uses the `infracost_usage` struct tags to populate data into the Cluster.
+func (r *Cluster) PopulateUsage(u *schema.UsageData) {
+	resources.PopulateArgsWithUsage(r, u)
+}
+
+// BuildResource builds a schema.Resource from a valid Cluster struct.
+// This method is called after the resource is initialised by an IaC provider.
+// See providers folder for more information.
+func (r *Cluster) BuildResource() *schema.Resource {
+	if len(r.NodeGroups) > 0 && sharedInstanceSizes[r.NodeGroups[0].InstanceSize] {
+		return &schema.Resource{
+			Name:           r.Address,
+			UsageSchema:    ClusterUsageSchema,
+			CostComponents: []*schema.CostComponent{r.sharedClusterCostComponent(r.NodeGroups[0])},
+		}
+	}
+
+	shards := r.Shards
+	if shards < 1 {
+		shards = 1
+	}
+
+	costComponents := []*schema.CostComponent{}
+
+	for _, g := range r.NodeGroups {
+		if g.NodeCount == 0 {
+			continue
+		}
+
+		nodes := decimal.NewFromInt(g.NodeCount * shards)
+
+		costComponents = append(costComponents, &schema.CostComponent{
+			Name:           fmt.Sprintf("%s nodes (%s, %s %s)", g.Type, g.InstanceSize, g.ProviderName, g.RegionName),
+			Unit:           "hours",
+			UnitMultiplier: decimal.NewFromInt(1),
+			HourlyQuantity: decimalPtr(nodes),
+			ProductFilter: r.productFilter("Dedicated Cluster", []*schema.AttributeFilter{
+				{Key: "cloudProvider", Value: strPtr(g.ProviderName)},
+				{Key: "instanceSize", Value: strPtr(g.InstanceSize)},
+			}),
+		})
+
+		extraStorage := r.DiskSizeGB - includedStorageGB[g.InstanceSize]
+		if r.DiskSizeGB > 0 && extraStorage > 0 {
+			costComponents = append(costComponents, &schema.CostComponent{
+				Name:            fmt.Sprintf("%s nodes additional storage", g.Type),
+				Unit:            "GB",
+				UnitMultiplier:  decimal.NewFromInt(1),
+				MonthlyQuantity: decimalPtr(decimal.NewFromFloat(extraStorage).Mul(nodes)),
+				ProductFilter: r.productFilter("Storage", []*schema.AttributeFilter{
+					{Key: "cloudProvider", Value: strPtr(g.ProviderName)},
+					{Key: "resource", Value: strPtr("Storage")},
+				}),
+			})
+		}
+	}
+
+	if r.BackupEnabled && len(r.NodeGroups) > 0 {
+		var quantity *decimal.Decimal
+		if r.BackupStorageGB != nil {
+			quantity = decimalPtr(decimal.NewFromFloat(*r.BackupStorageGB))
+		}
+
+		costComponents = append(costComponents, &schema.CostComponent{
+			Name:            "Cloud backup storage",
+			Unit:            "GB",
+			UnitMultiplier:  decimal.NewFromInt(1),
+			MonthlyQuantity: quantity,
+			ProductFilter: r.productFilter("Storage", []*schema.AttributeFilter{
+				{Key: "cloudProvider", Value: strPtr(r.NodeGroups[0].ProviderName)},
+				{Key: "resource", Value: strPtr("Backup")},
+			}),
+		})
+	}
+
+	return &schema.Resource{
+		Name:           r.Address,
+		UsageSchema:    ClusterUsageSchema,
+		CostComponents: costComponents,
+	}
+}
+
+func (r *Cluster) sharedClusterCostComponent(g *ClusterNodeGroup) *schema.CostComponent {
+	return &schema.CostComponent{
+		Name:            fmt.Sprintf("Shared cluster (%s)", g.InstanceSize),
+		Unit:            "months",
+		UnitMultiplier:  decimal.NewFromInt(1),
+		MonthlyQuantity: decimalPtr(decimal.NewFromInt(1)),
+		ProductFilter: r.productFilter("Shared Cluster", []*schema.AttributeFilter{
+			{Key: "instanceSize", Value: strPtr(strings.ToUpper(g.InstanceSize))},
+		}),
+	}
+}
+
+func (r *Cluster) productFilter(productFamily string, attributeFilters []*schema.AttributeFilter) *schema.ProductFilter {
+	return &schema.ProductFilter{
+		VendorName:       strPtr(vendorName),
+		Service:          strPtr("Clusters"),
+		ProductFamily:    strPtr(productFamily),
+		AttributeFilters: attributeFilters,
+	}
+}
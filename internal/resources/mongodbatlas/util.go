@@ -0,0 +1,17 @@
+package mongodbatlas
+
+import (
+	"github.com/shopspring/decimal"
+)
+
+const (
+	vendorName = "mongodbatlas"
+)
+
+func strPtr(s string) *string {
+	return &s
+}
+
+func decimalPtr(d decimal.Decimal) *decimal.Decimal {
+	return &d
+}
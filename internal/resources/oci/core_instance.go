@@ -0,0 +1,103 @@
+package oci
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/infracost/infracost/internal/resources"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+var fixedShapeOCPUs = regexp.MustCompile(`\.(\d+)(\.Micro)?$`)
+
+// CoreInstance struct represents an OCI compute instance.
+//
+// Flexible shapes are charged per OCPU-hour and per GB-hour of memory, fixed
+// shapes are charged per OCPU-hour with the memory included. The boot volume is
+// charged the same way as a block volume.
+//
+// Resource information: https://docs.oracle.com/en-us/iaas/Content/Compute/home.htm
+// Pricing information: https://www.oracle.com/cloud/compute/pricing/
+type CoreInstance struct {
+	Address             string
+	Region              string
+	Shape               string
+	OCPUs               float64
+	MemoryGB            float64
+	BootVolumeSizeGB    float64
+	BootVolumeVPUsPerGB float64
+}
+
+// CoreInstanceUsageSchema defines a list which represents the usage schema of CoreInstance.
+var CoreInstanceUsageSchema = []*schema.UsageItem{}
+
+// PopulateUsage parses the u schema.UsageData into the CoreInstance.
+// It uses the `infracost_usage` struct tags to populate data into the CoreInstance.
+func (r *CoreInstance) PopulateUsage(u *schema.UsageData) {
+	resources.PopulateArgsWithUsage(r, u)
+}
+
+// BuildResource builds a schema.Resource from a valid CoreInstance struct.
+// This method is called after the resource is initialised by an IaC provider.
+// See providers folder for more information.
+func (r *CoreInstance) BuildResource() *schema.Resource {
+	var costComponents []*schema.CostComponent
+
+	if strings.HasSuffix(r.Shape, ".Flex") {
+		costComponents = append(costComponents,
+			r.shapeCostComponent(fmt.Sprintf("Instance OCPUs (%s)", r.Shape), "OCPU", "OCPU", r.OCPUs),
+			r.shapeCostComponent(fmt.Sprintf("Instance memory (%s)", r.Shape), "GB", "Memory", r.MemoryGB),
+		)
+	} else {
+		costComponents = append(costComponents,
+			r.shapeCostComponent(fmt.Sprintf("Instance OCPUs (%s)", r.Shape), "OCPU", "OCPU", r.fixedShapeOCPUs()),
+		)
+	}
+
+	costComponents = append(costComponents, blockVolumeCostComponents("Boot volume", r.Region, r.BootVolumeSizeGB, r.BootVolumeVPUsPerGB)...)
+
+	return &schema.Resource{
+		Name:           r.Address,
+		UsageSchema:    CoreInstanceUsageSchema,
+		CostComponents: costComponents,
+	}
+}
+
+func (r *CoreInstance) shapeCostComponent(name, unit, resource string, quantity float64) *schema.CostComponent {
+	return &schema.CostComponent{
+		Name:           name,
+		Unit:           unit,
+		UnitMultiplier: schema.HourToMonthUnitMultiplier,
+		HourlyQuantity: decimalPtr(decimal.NewFromFloat(quantity)),
+		ProductFilter: &schema.ProductFilter{
+			VendorName:    strPtr(vendorName),
+			Region:        strPtr(r.Region),
+			Service:       strPtr("Compute"),
+			ProductFamily: strPtr("Compute"),
+			AttributeFilters: []*schema.AttributeFilter{
+				{Key: "shape", Value: strPtr(r.Shape)},
+				{Key: "resource", Value: strPtr(resource)},
+			},
+		},
+	}
+}
+
+// fixedShapeOCPUs returns the number of OCPUs of a fixed shape, which is the
+// number at the end of the shape name, e.g. VM.Standard2.4 has 4 OCPUs.
+func (r *CoreInstance) fixedShapeOCPUs() float64 {
+	m := fixedShapeOCPUs.FindStringSubmatch(r.Shape)
+	if len(m) < 2 {
+		return 1
+	}
+
+	v, err := strconv.ParseFloat(m[1], 64)
+	if err != nil {
+		return 1
+	}
+
+	return v
+}
@@ -0,0 +1,83 @@
+package oci
+
+import (
+	"github.com/shopspring/decimal"
+
+	"github.com/infracost/infracost/internal/resources"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+// CoreVolume struct represents an OCI block volume. Volumes are charged per GB-month
+// of storage and per GB-month for each volume performance unit (VPU) per GB.
+//
+// Resource information: https://docs.oracle.com/en-us/iaas/Content/Block/home.htm
+// Pricing information: https://www.oracle.com/cloud/storage/pricing/
+type CoreVolume struct {
+	Address   string
+	Region    string
+	SizeGB    float64
+	VPUsPerGB float64
+}
+
+// CoreVolumeUsageSchema defines a list which represents the usage schema of CoreVolume.
+var CoreVolumeUsageSchema = []*schema.UsageItem{}
+
+// PopulateUsage parses the u schema.UsageData into the CoreVolume.
+// It uses the `infracost_usage` struct tags to populate data into the CoreVolume.
+func (r *CoreVolume) PopulateUsage(u *schema.UsageData) {
+	resources.PopulateArgsWithUsage(r, u)
+}
+
+// BuildResource builds a schema.Resource from a valid CoreVolume struct.
+// This method is called after the resource is initialised by an IaC provider.
+// See providers folder for more information.
+func (r *CoreVolume) BuildResource() *schema.Resource {
+	return &schema.Resource{
+		Name:           r.Address,
+		UsageSchema:    CoreVolumeUsageSchema,
+		CostComponents: blockVolumeCostComponents("Block volume", r.Region, r.SizeGB, r.VPUsPerGB),
+	}
+}
+
+// blockVolumeCostComponents returns the storage and performance cost components
+// shared by block volumes and instance boot volumes. Volumes with 0 VPUs per GB
+// use the lower cost tier and only pay for storage.
+func blockVolumeCostComponents(prefix, region string, sizeGB, vpusPerGB float64) []*schema.CostComponent {
+	costComponents := []*schema.CostComponent{
+		{
+			Name:            prefix + " storage",
+			Unit:            "GB",
+			UnitMultiplier:  decimal.NewFromInt(1),
+			MonthlyQuantity: decimalPtr(decimal.NewFromFloat(sizeGB)),
+			ProductFilter: &schema.ProductFilter{
+				VendorName:    strPtr(vendorName),
+				Region:        strPtr(region),
+				Service:       strPtr("Block Volume"),
+				ProductFamily: strPtr("Storage"),
+				AttributeFilters: []*schema.AttributeFilter{
+					{Key: "resource", Value: strPtr("Storage")},
+				},
+			},
+		},
+	}
+
+	if vpusPerGB > 0 {
+		costComponents = append(costComponents, &schema.CostComponent{
+			Name:            prefix + " performance units",
+			Unit:            "VPU-GB",
+			UnitMultiplier:  decimal.NewFromInt(1),
+			MonthlyQuantity: decimalPtr(decimal.NewFromFloat(sizeGB * vpusPerGB)),
+			ProductFilter: &schema.ProductFilter{
+				VendorName:    strPtr(vendorName),
+				Region:        strPtr(region),
+				Service:       strPtr("Block Volume"),
+				ProductFamily: strPtr("Storage"),
+				AttributeFilters: []*schema.AttributeFilter{
+					{Key: "resource", Value: strPtr("Performance Units")},
+				},
+			},
+		})
+	}
+
+	return costComponents
+}
@@ -0,0 +1,105 @@
+package oci
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/infracost/infracost/internal/resources"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+// DatabaseAutonomousDatabase struct represents an OCI Autonomous Database.
+//
+// Databases are charged per ECPU-hour (or OCPU-hour for the older compute model) and
+// per GB-month of storage. Databases with auto scaling enabled can use up to three
+// times their base compute, the average_compute_count usage param can be used to
+// set the average compute used over the month.
+//
+// Resource information: https://docs.oracle.com/en-us/iaas/autonomous-database-serverless/index.html
+// Pricing information: https://www.oracle.com/autonomous-database/pricing/
+type DatabaseAutonomousDatabase struct {
+	Address      string
+	Region       string
+	Workload     string
+	ComputeModel string
+	ComputeCount float64
+	LicenseModel string
+	StorageGB    float64
+
+	AverageComputeCount *float64 `infracost_usage:"average_compute_count"`
+}
+
+// DatabaseAutonomousDatabaseUsageSchema defines a list which represents the usage schema of DatabaseAutonomousDatabase.
+var DatabaseAutonomousDatabaseUsageSchema = []*schema.UsageItem{
+	{Key: "average_compute_count", DefaultValue: 0, ValueType: schema.Float64},
+}
+
+// PopulateUsage parses the u schema.UsageData into the DatabaseAutonomousDatabase.
+// It uses the `infracost_usage` struct tags to populate data into the DatabaseAutonomousDatabase.
+func (r *DatabaseAutonomousDatabase) PopulateUsage(u *schema.UsageData) {
+	resources.PopulateArgsWithUsage(r, u)
+}
+
+// BuildResource builds a schema.Resource from a valid DatabaseAutonomousDatabase struct.
+// This method is called after the resource is initialised by an IaC provider.
+// See providers folder for more information.
+func (r *DatabaseAutonomousDatabase) BuildResource() *schema.Resource {
+	compute := r.ComputeCount
+	if r.AverageComputeCount != nil {
+		compute = *r.AverageComputeCount
+	}
+
+	computeModel := strings.ToUpper(r.ComputeModel)
+	licenseModel := strings.ToUpper(r.LicenseModel)
+	licenseName := "license included"
+	if licenseModel == "BRING_YOUR_OWN_LICENSE" {
+		licenseName = "BYOL"
+	}
+
+	workload := "OLTP"
+	if strings.ToUpper(r.Workload) == "DW" {
+		workload = "DW"
+	}
+
+	return &schema.Resource{
+		Name:        r.Address,
+		UsageSchema: DatabaseAutonomousDatabaseUsageSchema,
+		CostComponents: []*schema.CostComponent{
+			{
+				Name:           fmt.Sprintf("Compute (%s, %s)", computeModel, licenseName),
+				Unit:           computeModel,
+				UnitMultiplier: schema.HourToMonthUnitMultiplier,
+				HourlyQuantity: decimalPtr(decimal.NewFromFloat(compute)),
+				ProductFilter: &schema.ProductFilter{
+					VendorName:    strPtr(vendorName),
+					Region:        strPtr(r.Region),
+					Service:       strPtr("Autonomous Database"),
+					ProductFamily: strPtr("Database"),
+					AttributeFilters: []*schema.AttributeFilter{
+						{Key: "resource", Value: strPtr("Compute")},
+						{Key: "computeModel", Value: strPtr(computeModel)},
+						{Key: "licenseModel", Value: strPtr(licenseModel)},
+					},
+				},
+			},
+			{
+				Name:            "Storage",
+				Unit:            "GB",
+				UnitMultiplier:  decimal.NewFromInt(1),
+				MonthlyQuantity: decimalPtr(decimal.NewFromFloat(r.StorageGB)),
+				ProductFilter: &schema.ProductFilter{
+					VendorName:    strPtr(vendorName),
+					Region:        strPtr(r.Region),
+					Service:       strPtr("Autonomous Database"),
+					ProductFamily: strPtr("Database"),
+					AttributeFilters: []*schema.AttributeFilter{
+						{Key: "resource", Value: strPtr("Storage")},
+						{Key: "workload", Value: strPtr(workload)},
+					},
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,103 @@
+package oci
+
+import (
+	"regexp"
+	"strconv"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/infracost/infracost/internal/resources"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+const loadBalancerFreeBandwidthMbps = 10
+
+var legacyLoadBalancerShape = regexp.MustCompile(`^(\d+)Mbps`)
+
+// LoadBalancer struct represents an OCI load balancer.
+//
+// Flexible load balancers are charged a base hourly rate plus an hourly rate per Mbps
+// of bandwidth above the first 10Mbps. The bandwidth defaults to the minimum bandwidth
+// of the shape, the average_bandwidth_mbps usage param can be used to set a different
+// value for load balancers that scale up.
+//
+// Resource information: https://docs.oracle.com/en-us/iaas/Content/Balance/home.htm
+// Pricing information: https://www.oracle.com/cloud/networking/load-balancing/pricing/
+type LoadBalancer struct {
+	Address          string
+	Region           string
+	Shape            string
+	MinBandwidthMbps float64
+
+	AverageBandwidthMbps *float64 `infracost_usage:"average_bandwidth_mbps"`
+}
+
+// LoadBalancerUsageSchema defines a list which represents the usage schema of LoadBalancer.
+var LoadBalancerUsageSchema = []*schema.UsageItem{
+	{Key: "average_bandwidth_mbps", DefaultValue: 0, ValueType: schema.Float64},
+}
+
+// PopulateUsage parses the u schema.UsageData into the LoadBalancer.
+// It uses the `infracost_usage` struct tags to populate data into the LoadBalancer.
+func (r *LoadBalancer) PopulateUsage(u *schema.UsageData) {
+	resources.PopulateArgsWithUsage(r, u)
+}
+
+// BuildResource builds a schema.Resource from a valid LoadBalancer struct.
+// Legacy fixed bandwidth shapes, e.g. 100Mbps, are priced as a flexible load
+// balancer with the same bandwidth.
+func (r *LoadBalancer) BuildResource() *schema.Resource {
+	bandwidth := r.MinBandwidthMbps
+	if m := legacyLoadBalancerShape.FindStringSubmatch(r.Shape); len(m) > 1 {
+		v, err := strconv.ParseFloat(m[1], 64)
+		if err == nil {
+			bandwidth = v
+		}
+	}
+
+	if r.AverageBandwidthMbps != nil {
+		bandwidth = *r.AverageBandwidthMbps
+	}
+
+	billableBandwidth := bandwidth - loadBalancerFreeBandwidthMbps
+	if billableBandwidth < 0 {
+		billableBandwidth = 0
+	}
+
+	return &schema.Resource{
+		Name:        r.Address,
+		UsageSchema: LoadBalancerUsageSchema,
+		CostComponents: []*schema.CostComponent{
+			{
+				Name:           "Load balancer",
+				Unit:           "hours",
+				UnitMultiplier: decimal.NewFromInt(1),
+				HourlyQuantity: decimalPtr(decimal.NewFromInt(1)),
+				ProductFilter: &schema.ProductFilter{
+					VendorName:    strPtr(vendorName),
+					Region:        strPtr(r.Region),
+					Service:       strPtr("Load Balancer"),
+					ProductFamily: strPtr("Networking"),
+					AttributeFilters: []*schema.AttributeFilter{
+						{Key: "resource", Value: strPtr("Base")},
+					},
+				},
+			},
+			{
+				Name:           "Bandwidth (over 10Mbps)",
+				Unit:           "Mbps",
+				UnitMultiplier: schema.HourToMonthUnitMultiplier,
+				HourlyQuantity: decimalPtr(decimal.NewFromFloat(billableBandwidth)),
+				ProductFilter: &schema.ProductFilter{
+					VendorName:    strPtr(vendorName),
+					Region:        strPtr(r.Region),
+					Service:       strPtr("Load Balancer"),
+					ProductFamily: strPtr("Networking"),
+					AttributeFilters: []*schema.AttributeFilter{
+						{Key: "resource", Value: strPtr("Bandwidth")},
+					},
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,17 @@
+package oci
+
+import (
+	"github.com/shopspring/decimal"
+)
+
+const (
+	vendorName = "oci"
+)
+
+func strPtr(s string) *string {
+	return &s
+}
+
+func decimalPtr(d decimal.Decimal) *decimal.Decimal {
+	return &d
+}
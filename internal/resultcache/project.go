@@ -0,0 +1,197 @@
+package resultcache
+
+import (
+	"github.com/shopspring/decimal"
+	"github.com/tidwall/gjson"
+
+	"github.com/infracost/infracost/internal/schema"
+)
+
+// cachedProject is the part of a schema.Project that's needed to output it.
+// The functions that estimate usage and the spot fallbacks are only used while
+// the project is estimated so they aren't cached.
+type cachedProject struct {
+	Name               string                     `json:"name"`
+	Metadata           *schema.ProjectMetadata    `json:"metadata"`
+	PastResources      []*cachedResource          `json:"pastResources"`
+	Resources          []*cachedResource          `json:"resources"`
+	HasDiff            bool                       `json:"hasDiff"`
+	Currency           string                     `json:"currency,omitempty"`
+	UsageScenarioCosts map[string]decimal.Decimal `json:"usageScenarioCosts,omitempty"`
+}
+
+type cachedResource struct {
+	Name              string                 `json:"name"`
+	ResourceType      string                 `json:"resourceType"`
+	CostComponents    []*cachedCostComponent `json:"costComponents,omitempty"`
+	SubResources      []*cachedResource      `json:"subResources,omitempty"`
+	HourlyCost        *decimal.Decimal       `json:"hourlyCost"`
+	MonthlyCost       *decimal.Decimal       `json:"monthlyCost"`
+	IsSkipped         bool                   `json:"isSkipped,omitempty"`
+	NoPrice           bool                   `json:"noPrice,omitempty"`
+	SkipMessage       string                 `json:"skipMessage,omitempty"`
+	Tags              map[string]string      `json:"tags,omitempty"`
+	EstimationSummary map[string]bool        `json:"estimationSummary,omitempty"`
+	DerivedUsage      map[string]string      `json:"derivedUsage,omitempty"`
+	RawValues         string                 `json:"rawValues,omitempty"`
+	Annotations       map[string]string      `json:"annotations,omitempty"`
+	Utilization       map[string]float64     `json:"utilization,omitempty"`
+	SourceRange       *schema.SourceRange    `json:"sourceRange,omitempty"`
+}
+
+type cachedCostComponent struct {
+	Name                 string                `json:"name"`
+	Unit                 string                `json:"unit"`
+	UnitMultiplier       decimal.Decimal       `json:"unitMultiplier"`
+	IgnoreIfMissingPrice bool                  `json:"ignoreIfMissingPrice,omitempty"`
+	ProductFilter        *schema.ProductFilter `json:"productFilter,omitempty"`
+	PriceFilter          *schema.PriceFilter   `json:"priceFilter,omitempty"`
+	HourlyQuantity       *decimal.Decimal      `json:"hourlyQuantity"`
+	MonthlyQuantity      *decimal.Decimal      `json:"monthlyQuantity"`
+	MonthlyDiscountPerc  float64               `json:"monthlyDiscountPerc,omitempty"`
+	Price                decimal.Decimal       `json:"price"`
+	PriceHash            string                `json:"priceHash,omitempty"`
+	HourlyCost           *decimal.Decimal      `json:"hourlyCost"`
+	MonthlyCost          *decimal.Decimal      `json:"monthlyCost"`
+	PriceUnavailable     bool                  `json:"priceUnavailable,omitempty"`
+	PriceIssue           string                `json:"priceIssue,omitempty"`
+	PriceTier            *schema.PriceTier     `json:"priceTier,omitempty"`
+}
+
+func newCachedProject(p *schema.Project) *cachedProject {
+	return &cachedProject{
+		Name:               p.Name,
+		Metadata:           p.Metadata,
+		PastResources:      newCachedResources(p.PastResources),
+		Resources:          newCachedResources(p.Resources),
+		HasDiff:            p.HasDiff,
+		Currency:           p.Currency,
+		UsageScenarioCosts: p.UsageScenarioCosts,
+	}
+}
+
+func (p *cachedProject) toProject() *schema.Project {
+	project := &schema.Project{
+		Name:               p.Name,
+		Metadata:           p.Metadata,
+		PastResources:      toResources(p.PastResources),
+		Resources:          toResources(p.Resources),
+		HasDiff:            p.HasDiff,
+		Currency:           p.Currency,
+		UsageScenarioCosts: p.UsageScenarioCosts,
+	}
+	project.CalculateDiff()
+
+	return project
+}
+
+func newCachedResources(resources []*schema.Resource) []*cachedResource {
+	if resources == nil {
+		return nil
+	}
+
+	cached := make([]*cachedResource, 0, len(resources))
+
+	for _, r := range resources {
+		c := &cachedResource{
+			Name:              r.Name,
+			ResourceType:      r.ResourceType,
+			SubResources:      newCachedResources(r.SubResources),
+			HourlyCost:        r.HourlyCost,
+			MonthlyCost:       r.MonthlyCost,
+			IsSkipped:         r.IsSkipped,
+			NoPrice:           r.NoPrice,
+			SkipMessage:       r.SkipMessage,
+			Tags:              r.Tags,
+			EstimationSummary: r.EstimationSummary,
+			DerivedUsage:      r.DerivedUsage,
+			RawValues:         r.RawValues.Raw,
+			Annotations:       r.Annotations,
+			Utilization:       r.Utilization,
+			SourceRange:       r.SourceRange,
+		}
+
+		for _, cc := range r.CostComponents {
+			c.CostComponents = append(c.CostComponents, &cachedCostComponent{
+				Name:                 cc.Name,
+				Unit:                 cc.Unit,
+				UnitMultiplier:       cc.UnitMultiplier,
+				IgnoreIfMissingPrice: cc.IgnoreIfMissingPrice,
+				ProductFilter:        cc.ProductFilter,
+				PriceFilter:          cc.PriceFilter,
+				HourlyQuantity:       cc.HourlyQuantity,
+				MonthlyQuantity:      cc.MonthlyQuantity,
+				MonthlyDiscountPerc:  cc.MonthlyDiscountPerc,
+				Price:                cc.Price(),
+				PriceHash:            cc.PriceHash(),
+				HourlyCost:           cc.HourlyCost,
+				MonthlyCost:          cc.MonthlyCost,
+				PriceUnavailable:     cc.PriceUnavailable,
+				PriceIssue:           cc.PriceIssue,
+				PriceTier:            cc.PriceTier,
+			})
+		}
+
+		cached = append(cached, c)
+	}
+
+	return cached
+}
+
+func toResources(cached []*cachedResource) []*schema.Resource {
+	if cached == nil {
+		return nil
+	}
+
+	resources := make([]*schema.Resource, 0, len(cached))
+
+	for _, c := range cached {
+		r := &schema.Resource{
+			Name:              c.Name,
+			ResourceType:      c.ResourceType,
+			SubResources:      toResources(c.SubResources),
+			HourlyCost:        c.HourlyCost,
+			MonthlyCost:       c.MonthlyCost,
+			IsSkipped:         c.IsSkipped,
+			NoPrice:           c.NoPrice,
+			SkipMessage:       c.SkipMessage,
+			Tags:              c.Tags,
+			EstimationSummary: c.EstimationSummary,
+			DerivedUsage:      c.DerivedUsage,
+			Annotations:       c.Annotations,
+			Utilization:       c.Utilization,
+			SourceRange:       c.SourceRange,
+		}
+
+		if c.RawValues != "" {
+			r.RawValues = gjson.Parse(c.RawValues)
+		}
+
+		for _, cc := range c.CostComponents {
+			component := &schema.CostComponent{
+				Name:                 cc.Name,
+				Unit:                 cc.Unit,
+				UnitMultiplier:       cc.UnitMultiplier,
+				IgnoreIfMissingPrice: cc.IgnoreIfMissingPrice,
+				ProductFilter:        cc.ProductFilter,
+				PriceFilter:          cc.PriceFilter,
+				HourlyQuantity:       cc.HourlyQuantity,
+				MonthlyQuantity:      cc.MonthlyQuantity,
+				MonthlyDiscountPerc:  cc.MonthlyDiscountPerc,
+				HourlyCost:           cc.HourlyCost,
+				MonthlyCost:          cc.MonthlyCost,
+				PriceUnavailable:     cc.PriceUnavailable,
+				PriceIssue:           cc.PriceIssue,
+				PriceTier:            cc.PriceTier,
+			}
+			component.SetPrice(cc.Price)
+			component.SetPriceHash(cc.PriceHash)
+
+			r.CostComponents = append(r.CostComponents, component)
+		}
+
+		resources = append(resources, r)
+	}
+
+	return resources
+}
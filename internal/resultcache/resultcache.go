@@ -0,0 +1,266 @@
+// Package resultcache caches the estimates of projects on disk, keyed by the git
+// tree hashes of their files, so re-running the projects of a monorepo only
+// re-estimates the projects whose inputs have changed.
+package resultcache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/mitchellh/go-homedir"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/infracost/infracost/internal/hcl/modules"
+	"github.com/infracost/infracost/internal/schema"
+	"github.com/infracost/infracost/internal/version"
+)
+
+// DefaultTTL is how long cached estimates are used before the project is
+// estimated again, so they pick up price changes.
+const DefaultTTL = 24 * time.Hour
+
+// cacheFileVersion is the version of the format of the cached estimates, it's
+// part of the key so estimates cached in an older format aren't read.
+var cacheFileVersion = "0.1"
+
+// Cache stores the estimates of projects as files named by their key. Files are
+// written atomically so the cache can be shared by concurrent runs.
+type Cache struct {
+	Dir string
+	TTL time.Duration
+}
+
+// New returns a cache that stores estimates in dir for ttl.
+func New(dir string, ttl time.Duration) *Cache {
+	return &Cache{
+		Dir: dir,
+		TTL: ttl,
+	}
+}
+
+// DefaultDir returns the directory estimates are cached in by default.
+func DefaultDir() string {
+	dir, _ := homedir.Expand("~/.infracost/cache/results")
+	return dir
+}
+
+// Key returns the key the estimate of the project at path is cached under. It
+// depends on the git tree hashes of the project and the local modules it calls,
+// the contents of its usage files and var files, the CLI version, the currency
+// and settings, which is anything else the estimate depends on, e.g. the
+// project's config. Var files are hashed by their contents since they can be
+// outside the project, e.g. ../env/prod.tfvars.
+// It returns an error if the project isn't committed to a git repo or has
+// uncommitted changes, since its tree hashes wouldn't match its files.
+func Key(path string, usageFiles []string, varFiles []string, currency string, settings interface{}) (string, error) {
+	treeHashes, err := TreeHashes(path)
+	if err != nil {
+		return "", err
+	}
+
+	usageFileHashes, err := fileHashes(usageFiles)
+	if err != nil {
+		return "", fmt.Errorf("Error reading usage file: %w", err)
+	}
+
+	varFileHashes, err := fileHashes(varFiles)
+	if err != nil {
+		return "", fmt.Errorf("Error reading var file: %w", err)
+	}
+
+	b, err := json.Marshal(struct {
+		CacheFileVersion string
+		TreeHashes       []string
+		UsageFileHashes  []string
+		VarFileHashes    []string
+		Version          string
+		Currency         string
+		Settings         interface{}
+	}{cacheFileVersion, treeHashes, usageFileHashes, varFileHashes, version.Version, currency, settings})
+	if err != nil {
+		return "", fmt.Errorf("Error generating result cache key: %w", err)
+	}
+
+	sum := sha256.Sum256(b)
+
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// fileHashes returns the SHA256 hashes of the contents of the files.
+func fileHashes(paths []string) ([]string, error) {
+	hashes := make([]string, 0, len(paths))
+	for _, path := range paths {
+		b, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+
+		sum := sha256.Sum256(b)
+		hashes = append(hashes, hex.EncodeToString(sum[:]))
+	}
+
+	return hashes, nil
+}
+
+// TreeHashes returns the git tree hashes of the project at path and of the
+// local modules it calls, or the blob hash if path is a file, e.g. a plan JSON
+// file. The .infracost and .terraform directories are ignored since runs write
+// to them.
+func TreeHashes(path string) ([]string, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if !info.IsDir() {
+		hash, err := gitObjectHash(filepath.Dir(path), filepath.Base(path))
+		if err != nil {
+			return nil, err
+		}
+
+		return []string{hash}, nil
+	}
+
+	dirs, err := modules.LocalModuleDirs(path)
+	if err != nil {
+		return nil, err
+	}
+
+	hashes := make([]string, 0, len(dirs))
+	for _, dir := range dirs {
+		hash, err := gitObjectHash(dir, ".")
+		if err != nil {
+			return nil, err
+		}
+
+		hashes = append(hashes, hash)
+	}
+
+	return hashes, nil
+}
+
+// gitObjectHash returns the hash of the tree or blob at the path relative to
+// dir in the HEAD commit, or an error if it has uncommitted changes.
+func gitObjectHash(dir string, rel string) (string, error) {
+	git := func(args ...string) (string, error) {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+
+		out, err := cmd.Output()
+		if err != nil {
+			return "", fmt.Errorf("Error running git %s in %s: %w", strings.Join(args, " "), dir, err)
+		}
+
+		return strings.TrimSpace(string(out)), nil
+	}
+
+	status, err := git("status", "--porcelain", "--untracked-files=all", "--", rel, ":(exclude,glob)**/.infracost/**", ":(exclude,glob)**/.terraform/**")
+	if err != nil {
+		return "", err
+	}
+
+	if status != "" {
+		return "", fmt.Errorf("%s has uncommitted changes", filepath.Join(dir, rel))
+	}
+
+	return git("rev-parse", "HEAD:./"+filepath.ToSlash(rel))
+}
+
+type cacheFile struct {
+	Projects []*cachedProject `json:"projects"`
+}
+
+// Get returns the projects cached for the key and whether they were found.
+// Estimates older than the TTL aren't returned. The metadata of the projects
+// is marked as cached.
+func (c *Cache) Get(key string) ([]*schema.Project, bool) {
+	path := c.path(key)
+
+	info, err := os.Stat(path)
+	if err != nil || time.Since(info.ModTime()) > c.TTL {
+		return nil, false
+	}
+
+	b, err := os.ReadFile(path)
+	if err != nil {
+		log.Debugf("Error reading result cache %s: %s", path, err)
+		return nil, false
+	}
+
+	var f cacheFile
+	err = json.Unmarshal(b, &f)
+	if err != nil {
+		log.Debugf("Invalid result cache %s: %s", path, err)
+		return nil, false
+	}
+
+	projects := make([]*schema.Project, 0, len(f.Projects))
+	for _, p := range f.Projects {
+		project := p.toProject()
+		if project.Metadata == nil {
+			project.Metadata = &schema.ProjectMetadata{}
+		}
+		project.Metadata.Cached = true
+
+		projects = append(projects, project)
+	}
+
+	return projects, true
+}
+
+// Set caches the projects for the key, replacing any existing estimate.
+func (c *Cache) Set(key string, projects []*schema.Project) {
+	f := cacheFile{Projects: make([]*cachedProject, 0, len(projects))}
+	for _, p := range projects {
+		f.Projects = append(f.Projects, newCachedProject(p))
+	}
+
+	b, err := json.Marshal(f)
+	if err != nil {
+		log.Debugf("Error marshaling result cache: %s", err)
+		return
+	}
+
+	path := c.path(key)
+
+	err = os.MkdirAll(filepath.Dir(path), 0700)
+	if err != nil {
+		log.Debugf("Error creating result cache directory: %s", err)
+		return
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), key+".*.tmp")
+	if err != nil {
+		log.Debugf("Error writing result cache: %s", err)
+		return
+	}
+
+	_, err = tmp.Write(b)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), path)
+	}
+	if err != nil {
+		_ = os.Remove(tmp.Name())
+		log.Debugf("Error writing result cache: %s", err)
+	}
+}
+
+// path shards the files into sub directories by the first bytes of the key so
+// no single directory gets too large.
+func (c *Cache) path(key string) string {
+	if len(key) < 2 {
+		return filepath.Join(c.Dir, key+".json")
+	}
+
+	return filepath.Join(c.Dir, key[:2], key+".json")
+}
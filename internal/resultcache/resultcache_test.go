@@ -0,0 +1,176 @@
+package resultcache
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/infracost/infracost/internal/schema"
+)
+
+func decimalPtr(v string) *decimal.Decimal {
+	d := decimal.RequireFromString(v)
+	return &d
+}
+
+func gitRepo(t *testing.T, files map[string]string) string {
+	t.Helper()
+
+	dir := t.TempDir()
+	writeFiles(t, dir, files)
+
+	git(t, dir, "init", "-q")
+	commit(t, dir)
+
+	return dir
+}
+
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+
+	for name, contents := range files {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), os.ModePerm))
+		require.NoError(t, os.WriteFile(path, []byte(contents), 0600))
+	}
+}
+
+func commit(t *testing.T, dir string) {
+	t.Helper()
+
+	git(t, dir, "add", "-A")
+	git(t, dir, "-c", "user.name=test", "-c", "user.email=test@example.com", "commit", "-q", "-m", "commit")
+}
+
+func git(t *testing.T, dir string, args ...string) {
+	t.Helper()
+
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	out, err := cmd.CombinedOutput()
+	require.NoError(t, err, string(out))
+}
+
+func TestKey(t *testing.T) {
+	repo := gitRepo(t, map[string]string{
+		"app/main.tf":         `module "vpc" { source = "../modules/vpc" }`,
+		"modules/vpc/main.tf": `resource "aws_vpc" "vpc" {}`,
+		"other/main.tf":       `resource "aws_instance" "web" {}`,
+		"usage.yml":           "version: 0.1\n",
+		"env/prod.tfvars":     "size = 2\n",
+	})
+	app := filepath.Join(repo, "app")
+	usageFiles := []string{filepath.Join(repo, "usage.yml")}
+	varFiles := []string{filepath.Join(repo, "env", "prod.tfvars")}
+
+	key, err := Key(app, usageFiles, varFiles, "USD", nil)
+	require.NoError(t, err)
+
+	// Runs write to .infracost, which doesn't change the key
+	writeFiles(t, repo, map[string]string{"app/.infracost/cache": "{}"})
+	sameKey, err := Key(app, usageFiles, varFiles, "USD", nil)
+	require.NoError(t, err)
+	assert.Equal(t, key, sameKey)
+
+	currencyKey, err := Key(app, usageFiles, varFiles, "EUR", nil)
+	require.NoError(t, err)
+	assert.NotEqual(t, key, currencyKey)
+
+	// Projects that don't call a module aren't affected by its changes
+	writeFiles(t, repo, map[string]string{"other/main.tf": `resource "aws_instance" "api" {}`})
+	sameKey, err = Key(app, usageFiles, varFiles, "USD", nil)
+	require.NoError(t, err)
+	assert.Equal(t, key, sameKey)
+
+	writeFiles(t, repo, map[string]string{"modules/vpc/main.tf": `resource "aws_vpc" "main" {}`})
+	_, err = Key(app, usageFiles, varFiles, "USD", nil)
+	assert.ErrorContains(t, err, "uncommitted changes")
+
+	commit(t, repo)
+	changedKey, err := Key(app, usageFiles, varFiles, "USD", nil)
+	require.NoError(t, err)
+	assert.NotEqual(t, key, changedKey)
+
+	writeFiles(t, repo, map[string]string{"usage.yml": "version: 0.1\nresource_usage: {}\n"})
+	usageKey, err := Key(app, usageFiles, varFiles, "USD", nil)
+	require.NoError(t, err)
+	assert.NotEqual(t, changedKey, usageKey)
+
+	// Var files outside the project change the key with their contents
+	writeFiles(t, repo, map[string]string{"env/prod.tfvars": "size = 4\n"})
+	varKey, err := Key(app, usageFiles, varFiles, "USD", nil)
+	require.NoError(t, err)
+	assert.NotEqual(t, usageKey, varKey)
+}
+
+func TestKeyNotGitRepo(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{"main.tf": `resource "aws_vpc" "vpc" {}`})
+
+	_, err := Key(dir, nil, nil, "USD", nil)
+	assert.Error(t, err)
+}
+
+func TestCache(t *testing.T) {
+	c := New(t.TempDir(), time.Hour)
+
+	cc := &schema.CostComponent{
+		Name:            "Instance usage",
+		Unit:            "hours",
+		UnitMultiplier:  decimal.NewFromInt(1),
+		HourlyQuantity:  decimalPtr("1"),
+		MonthlyQuantity: decimalPtr("730"),
+		HourlyCost:      decimalPtr("0.1"),
+		MonthlyCost:     decimalPtr("73"),
+	}
+	cc.SetPrice(decimal.RequireFromString("0.1"))
+
+	project := schema.NewProject("infracost/app", &schema.ProjectMetadata{Path: "app"})
+	project.Resources = []*schema.Resource{
+		{
+			Name:           "aws_instance.web",
+			ResourceType:   "aws_instance",
+			HourlyCost:     decimalPtr("0.1"),
+			MonthlyCost:    decimalPtr("73"),
+			CostComponents: []*schema.CostComponent{cc},
+		},
+		{Name: "aws_vpc.vpc", ResourceType: "aws_vpc", IsSkipped: true, NoPrice: true},
+	}
+	project.CalculateDiff()
+
+	_, ok := c.Get("key")
+	assert.False(t, ok)
+
+	c.Set("key", []*schema.Project{project})
+	assert.False(t, project.Metadata.Cached)
+
+	projects, ok := c.Get("key")
+	require.True(t, ok)
+	require.Len(t, projects, 1)
+
+	cached := projects[0]
+	assert.True(t, cached.Metadata.Cached)
+	assert.Equal(t, "infracost/app", cached.Name)
+	require.Len(t, cached.Resources, 2)
+	assert.True(t, cached.Resources[1].IsSkipped)
+	assert.Equal(t, "0.1", cached.Resources[0].CostComponents[0].Price().String())
+	assert.Equal(t, "73", cached.Resources[0].MonthlyCost.String())
+	assert.Len(t, cached.Diff, len(project.Diff))
+}
+
+func TestCacheExpired(t *testing.T) {
+	c := New(t.TempDir(), time.Hour)
+	c.Set("key", []*schema.Project{schema.NewProject("app", &schema.ProjectMetadata{Path: "app"})})
+
+	old := time.Now().Add(-2 * time.Hour)
+	require.NoError(t, os.Chtimes(c.path("key"), old, old))
+
+	_, ok := c.Get("key")
+	assert.False(t, ok)
+}
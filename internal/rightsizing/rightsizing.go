@@ -0,0 +1,222 @@
+// Package rightsizing recommends smaller sizes for resources whose utilization,
+// e.g. synced into the usage file from CloudWatch, shows they're
+// overprovisioned, and estimates the monthly savings of each.
+package rightsizing
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/infracost/infracost/internal/output"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+const (
+	// targetCPUPercent is the highest average CPU utilization an instance is
+	// resized to.
+	targetCPUPercent = 40.0
+	// targetMaxCPUPercent is the highest maximum CPU utilization an instance is
+	// resized to, when it's known.
+	targetMaxCPUPercent = 80.0
+	// targetCapacityPercent is the utilization provisioned throughput is
+	// resized to, the default target of DynamoDB autoscaling.
+	targetCapacityPercent = 70.0
+)
+
+// instanceSizes are the sizes instances are resized to with their relative
+// capacity, which their prices are proportional to within a family.
+var instanceSizes = []struct {
+	name   string
+	factor float64
+}{
+	{"nano", 0.25},
+	{"micro", 0.5},
+	{"small", 1},
+	{"medium", 2},
+	{"large", 4},
+	{"xlarge", 8},
+	{"2xlarge", 16},
+	{"4xlarge", 32},
+	{"8xlarge", 64},
+	{"16xlarge", 128},
+}
+
+// currentSizeFactors are the relative capacities of the sizes instances can be
+// resized from, which also include sizes that aren't in every family.
+var currentSizeFactors = map[string]float64{
+	"12xlarge": 96,
+	"24xlarge": 192,
+}
+
+func init() {
+	for _, s := range instanceSizes {
+		currentSizeFactors[s.name] = s.factor
+	}
+}
+
+// Recommend returns the rightsizing recommendations of the resources of the
+// projects, largest savings first. The savings of projects priced in their own
+// currency are converted to the currency of the report with the rates keyed by
+// project currency.
+func Recommend(projects []*schema.Project, rates map[string]decimal.Decimal) []output.RightsizingRecommendation {
+	var recs []output.RightsizingRecommendation
+
+	for _, p := range projects {
+		rate := decimal.NewFromInt(1)
+		if r, ok := rates[strings.ToUpper(p.Currency)]; ok && p.Currency != "" {
+			rate = r
+		}
+
+		for _, r := range p.Resources {
+			if r.IsSkipped || len(r.Utilization) == 0 {
+				continue
+			}
+
+			var resourceRecs []output.RightsizingRecommendation
+			switch r.ResourceType {
+			case "aws_instance":
+				resourceRecs = recommendInstance(r)
+			case "aws_dynamodb_table":
+				resourceRecs = recommendDynamoDBTable(r)
+			}
+
+			for _, rec := range resourceRecs {
+				if !rec.EstimatedMonthlySavings.IsPositive() {
+					continue
+				}
+
+				rec.ProjectName = p.Name
+				rec.Address = r.Name
+				rec.ResourceType = r.ResourceType
+				rec.CurrentMonthlyCost = rec.CurrentMonthlyCost.Mul(rate).Round(2)
+				rec.EstimatedMonthlySavings = rec.EstimatedMonthlySavings.Mul(rate).Round(2)
+				recs = append(recs, rec)
+			}
+		}
+	}
+
+	return output.SortRightsizing(recs)
+}
+
+// recommendInstance recommends the smallest instance type of the same family
+// that keeps the CPU utilization under the targets, assuming the utilization
+// scales with the size of the instance.
+func recommendInstance(r *schema.Resource) []output.RightsizingRecommendation {
+	average, ok := r.Utilization["cpu_utilization_percent"]
+	if !ok || average <= 0 {
+		return nil
+	}
+	maximum, hasMaximum := r.Utilization["max_cpu_utilization_percent"]
+
+	instanceType := r.RawValues.Get("instance_type").String()
+	parts := strings.SplitN(instanceType, ".", 2)
+	if len(parts) != 2 {
+		return nil
+	}
+	family, size := parts[0], parts[1]
+
+	factor, ok := currentSizeFactors[size]
+	if !ok {
+		return nil
+	}
+
+	var component *schema.CostComponent
+	for _, c := range r.CostComponents {
+		if strings.HasPrefix(c.Name, "Instance usage") && c.MonthlyCost != nil {
+			component = c
+			break
+		}
+	}
+	if component == nil {
+		return nil
+	}
+
+	// Only burstable families have sizes smaller than large
+	minFactor := 4.0
+	if strings.HasPrefix(family, "t") {
+		minFactor = 0.25
+	}
+
+	recommended := ""
+	recommendedFactor := factor
+	for _, s := range instanceSizes {
+		if s.factor < minFactor || s.factor >= recommendedFactor {
+			continue
+		}
+
+		ratio := factor / s.factor
+		if average*ratio > targetCPUPercent || (hasMaximum && maximum*ratio > targetMaxCPUPercent) {
+			continue
+		}
+
+		recommended = fmt.Sprintf("%s.%s", family, s.name)
+		recommendedFactor = s.factor
+		break
+	}
+
+	if recommended == "" {
+		return nil
+	}
+
+	reason := fmt.Sprintf("average CPU utilization %s%%", formatNumber(average))
+	if hasMaximum {
+		reason += fmt.Sprintf(", maximum %s%%", formatNumber(maximum))
+	}
+
+	savings := component.MonthlyCost.Mul(decimal.NewFromFloat(1 - recommendedFactor/factor))
+
+	return []output.RightsizingRecommendation{{
+		Current:                 instanceType,
+		Recommended:             recommended,
+		Reason:                  reason,
+		CurrentMonthlyCost:      *component.MonthlyCost,
+		EstimatedMonthlySavings: savings,
+	}}
+}
+
+// recommendDynamoDBTable recommends lower provisioned read and write capacity
+// for tables whose consumed capacity is under the target. Capacity that's
+// autoscaled is already adjusted to the consumed capacity so it's skipped.
+func recommendDynamoDBTable(r *schema.Resource) []output.RightsizingRecommendation {
+	var recs []output.RightsizingRecommendation
+
+	for _, c := range r.CostComponents {
+		var key, label string
+		switch c.Name {
+		case "Read capacity unit (RCU)":
+			key, label = "read_capacity_utilization_percent", "RCU"
+		case "Write capacity unit (WCU)":
+			key, label = "write_capacity_utilization_percent", "WCU"
+		default:
+			continue
+		}
+
+		utilization, ok := r.Utilization[key]
+		if !ok || c.HourlyQuantity == nil || c.MonthlyCost == nil || !c.HourlyQuantity.IsPositive() {
+			continue
+		}
+
+		current := c.HourlyQuantity.InexactFloat64()
+		recommended := math.Max(1, math.Ceil(current*utilization/targetCapacityPercent))
+		if recommended >= current {
+			continue
+		}
+
+		recs = append(recs, output.RightsizingRecommendation{
+			Current:                 fmt.Sprintf("%s %s", formatNumber(current), label),
+			Recommended:             fmt.Sprintf("%s %s", formatNumber(recommended), label),
+			Reason:                  fmt.Sprintf("average %s utilization %s%%", label, formatNumber(utilization)),
+			CurrentMonthlyCost:      *c.MonthlyCost,
+			EstimatedMonthlySavings: c.MonthlyCost.Mul(decimal.NewFromFloat(1 - recommended/current)),
+		})
+	}
+
+	return recs
+}
+
+func formatNumber(f float64) string {
+	return decimal.NewFromFloat(f).Round(1).String()
+}
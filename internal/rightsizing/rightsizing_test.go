@@ -0,0 +1,103 @@
+package rightsizing
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+
+	"github.com/infracost/infracost/internal/schema"
+)
+
+func decimalPtr(d decimal.Decimal) *decimal.Decimal {
+	return &d
+}
+
+func instance(address, instanceType string, monthlyCost float64, utilization map[string]float64) *schema.Resource {
+	return &schema.Resource{
+		Name:         address,
+		ResourceType: "aws_instance",
+		RawValues:    gjson.Parse(`{"instance_type": "` + instanceType + `"}`),
+		CostComponents: []*schema.CostComponent{
+			{Name: "Instance usage (Linux/UNIX, on-demand, " + instanceType + ")", MonthlyCost: decimalPtr(decimal.NewFromFloat(monthlyCost))},
+		},
+		Utilization: utilization,
+	}
+}
+
+func TestRecommendInstance(t *testing.T) {
+	tests := []struct {
+		name        string
+		resource    *schema.Resource
+		recommended string
+		savings     string
+	}{
+		{
+			name:        "idle instance",
+			resource:    instance("aws_instance.idle", "m5.4xlarge", 560, map[string]float64{"cpu_utilization_percent": 5}),
+			recommended: "m5.large",
+			savings:     "490",
+		},
+		{
+			name:        "limited by maximum",
+			resource:    instance("aws_instance.spiky", "m5.4xlarge", 560, map[string]float64{"cpu_utilization_percent": 5, "max_cpu_utilization_percent": 30}),
+			recommended: "m5.2xlarge",
+			savings:     "280",
+		},
+		{
+			name:        "burstable",
+			resource:    instance("aws_instance.small", "t3.large", 60, map[string]float64{"cpu_utilization_percent": 8}),
+			recommended: "t3.small",
+			savings:     "45",
+		},
+		{
+			name:     "busy instance",
+			resource: instance("aws_instance.busy", "m5.xlarge", 140, map[string]float64{"cpu_utilization_percent": 30}),
+		},
+		{
+			name:     "no utilization",
+			resource: instance("aws_instance.unknown", "m5.xlarge", 140, nil),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recs := Recommend([]*schema.Project{{Name: "prod", Resources: []*schema.Resource{tt.resource}}}, nil)
+
+			if tt.recommended == "" {
+				assert.Empty(t, recs)
+				return
+			}
+
+			require.Len(t, recs, 1)
+			assert.Equal(t, tt.resource.Name, recs[0].Address)
+			assert.Equal(t, tt.recommended, recs[0].Recommended)
+			assert.Equal(t, tt.savings, recs[0].EstimatedMonthlySavings.String())
+		})
+	}
+}
+
+func TestRecommendDynamoDBTable(t *testing.T) {
+	table := &schema.Resource{
+		Name:         "aws_dynamodb_table.orders",
+		ResourceType: "aws_dynamodb_table",
+		CostComponents: []*schema.CostComponent{
+			{Name: "Write capacity unit (WCU)", HourlyQuantity: decimalPtr(decimal.NewFromInt(100)), MonthlyCost: decimalPtr(decimal.NewFromFloat(47.45))},
+			{Name: "Read capacity unit (RCU, autoscaling)", HourlyQuantity: decimalPtr(decimal.NewFromInt(100)), MonthlyCost: decimalPtr(decimal.NewFromFloat(9.49))},
+		},
+		Utilization: map[string]float64{
+			"read_capacity_utilization_percent":  10,
+			"write_capacity_utilization_percent": 14,
+		},
+	}
+
+	recs := Recommend([]*schema.Project{{Name: "prod", Resources: []*schema.Resource{table}}}, nil)
+
+	require.Len(t, recs, 1)
+	assert.Equal(t, "100 WCU", recs[0].Current)
+	assert.Equal(t, "20 WCU", recs[0].Recommended)
+	assert.Equal(t, "average WCU utilization 14%", recs[0].Reason)
+	assert.Equal(t, "37.96", recs[0].EstimatedMonthlySavings.String())
+}
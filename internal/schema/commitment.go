@@ -0,0 +1,112 @@
+package schema
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+)
+
+const (
+	CommitmentTypeReservedInstance = "reserved_instance"
+	CommitmentTypeSavingsPlan      = "savings_plan"
+	CommitmentTypeCommittedUse     = "committed_use"
+)
+
+var validCommitmentTypes = []string{CommitmentTypeReservedInstance, CommitmentTypeSavingsPlan, CommitmentTypeCommittedUse}
+
+// Commitment is existing reserved capacity, e.g. AWS Reserved Instances or Savings
+// Plans, or GCP Committed Use Discounts, that covers a percentage of the usage of the
+// instances matching its vendor, instance family and region. Empty fields match
+// everything.
+//
+// The covered usage is priced at the reserved rate, which is either the on-demand
+// rate less DiscountPercent, for AWS with no DiscountPercent, the reserved rate
+// looked up for the Term, PaymentOption and OfferingClass, or for GCP Committed Use
+// Discounts with no DiscountPercent, the published resource-based discount of the
+// Term.
+type Commitment struct {
+	Name            string  `yaml:"name,omitempty"`
+	Type            string  `yaml:"type"`
+	Vendor          string  `yaml:"vendor,omitempty"`
+	InstanceFamily  string  `yaml:"instance_family,omitempty"`
+	Region          string  `yaml:"region,omitempty"`
+	CoveragePercent float64 `yaml:"coverage_percent"`
+	DiscountPercent float64 `yaml:"discount_percent,omitempty"`
+	Term            string  `yaml:"term,omitempty"`
+	PaymentOption   string  `yaml:"payment_option,omitempty"`
+	OfferingClass   string  `yaml:"offering_class,omitempty"`
+}
+
+// Validate returns an error if the commitment can't be used to price resources.
+func (c *Commitment) Validate() error {
+	if !stringInSlice(validCommitmentTypes, c.Type) {
+		return fmt.Errorf("commitment type must be one of %s", strings.Join(validCommitmentTypes, ", "))
+	}
+
+	if c.CoveragePercent <= 0 || c.CoveragePercent > 100 {
+		return errors.New("commitment must have a coverage_percent greater than 0 and at most 100")
+	}
+
+	if c.DiscountPercent < 0 || c.DiscountPercent > 100 {
+		return errors.New("commitment discount_percent must be between 0 and 100")
+	}
+
+	if c.DiscountPercent == 0 && !strings.EqualFold(c.Vendor, "aws") && !c.IsGCPCommittedUse() {
+		return errors.New("commitment must have a discount_percent, reserved rates can only be looked up for the aws vendor or gcp committed_use")
+	}
+
+	if c.Term != "" && c.Term != "1_year" && c.Term != "3_year" {
+		return errors.New("commitment term must be one of 1_year, 3_year")
+	}
+
+	if c.DiscountPercent == 0 && c.PaymentOption != "" && c.PaymentOption != "no_upfront" {
+		return errors.New("commitment must have a discount_percent when the payment_option is not no_upfront")
+	}
+
+	if c.OfferingClass != "" && c.OfferingClass != "standard" && c.OfferingClass != "convertible" {
+		return errors.New("commitment offering_class must be one of standard, convertible")
+	}
+
+	return nil
+}
+
+// IsGCPCommittedUse returns true if the commitment is a GCP Committed Use Discount.
+func (c *Commitment) IsGCPCommittedUse() bool {
+	return c.Type == CommitmentTypeCommittedUse && strings.EqualFold(c.Vendor, "gcp")
+}
+
+// MatchesInstanceType returns true if the instance type belongs to the instance
+// family of the commitment. The family can be a prefix of the instance type up to
+// a separator, e.g. m5 matches m5.large and n2 matches n2-standard-4, or a glob
+// pattern, e.g. Standard_D*s_v3.
+func (c *Commitment) MatchesInstanceType(instanceType string) bool {
+	if c.InstanceFamily == "" {
+		return true
+	}
+
+	family := strings.ToLower(c.InstanceFamily)
+	instanceType = strings.ToLower(instanceType)
+
+	if strings.Contains(family, "*") {
+		ok, _ := path.Match(family, instanceType)
+		return ok
+	}
+
+	if !strings.HasPrefix(instanceType, family) {
+		return false
+	}
+
+	rest := strings.TrimPrefix(instanceType, family)
+	return rest == "" || strings.ContainsAny(rest[:1], ".-_")
+}
+
+func stringInSlice(s []string, v string) bool {
+	for _, i := range s {
+		if i == v {
+			return true
+		}
+	}
+
+	return false
+}
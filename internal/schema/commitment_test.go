@@ -0,0 +1,30 @@
+package schema
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCommitmentMatchesInstanceType(t *testing.T) {
+	tests := []struct {
+		family       string
+		instanceType string
+		expected     bool
+	}{
+		{"", "m5.large", true},
+		{"m5", "m5.large", true},
+		{"M5", "m5.xlarge", true},
+		{"m5", "m5a.large", false},
+		{"db.r5", "db.r5.large", true},
+		{"n2", "n2-standard-4", true},
+		{"n2", "n2d-standard-4", false},
+		{"Standard_D*s_v3", "Standard_D2s_v3", true},
+		{"Standard_D*s_v3", "Standard_E2s_v3", false},
+	}
+
+	for _, tt := range tests {
+		c := &Commitment{InstanceFamily: tt.family}
+		assert.Equal(t, tt.expected, c.MatchesInstanceType(tt.instanceType), "%s %s", tt.family, tt.instanceType)
+	}
+}
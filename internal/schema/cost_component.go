@@ -4,6 +4,15 @@ import (
 	"github.com/shopspring/decimal"
 )
 
+// The price issues of cost components.
+const (
+	PriceIssueNoProducts       = "no_products"
+	PriceIssueMultipleProducts = "multiple_products"
+	PriceIssueNoPrices         = "no_prices"
+	PriceIssueMultiplePrices   = "multiple_prices"
+	PriceIssueInvalidPrice     = "invalid_price"
+)
+
 type CostComponent struct {
 	Name                 string
 	Unit                 string
@@ -18,6 +27,35 @@ type CostComponent struct {
 	priceHash            string
 	HourlyCost           *decimal.Decimal
 	MonthlyCost          *decimal.Decimal
+
+	// SpotFallback is the on-demand equivalent of a spot or preemptible cost
+	// component. Its price, less the configured spot discount, is used when no
+	// spot price is found.
+	SpotFallback *CostComponent
+
+	// PriceUnavailable is set when the price couldn't be looked up, e.g. because the
+	// pricing API was unavailable, so the cost component is priced at 0.00.
+	PriceUnavailable bool
+
+	// IgnoreMultipleProducts is set when the product filter is known to match
+	// more than one product with the same price, e.g. because of limitations of
+	// the cloud provider's pricing API, so no PriceIssue is set when it does.
+	IgnoreMultipleProducts bool
+
+	// PriceIssue is set when the price lookup found nothing, so the cost component
+	// is priced at 0.00, or found more than one match, so the price may be wrong.
+	PriceIssue string
+
+	// PriceTier is the range of usage the price applies to when the price is
+	// graduated. It's only set when price tiers are shown.
+	PriceTier *PriceTier
+}
+
+// PriceTier is a range of usage, in the units of the price, that a graduated
+// price applies to. EndUsageAmount is nil for the last tier.
+type PriceTier struct {
+	StartUsageAmount decimal.Decimal
+	EndUsageAmount   *decimal.Decimal
 }
 
 func (c *CostComponent) CalculateCosts() {
@@ -10,6 +10,7 @@ import (
 	"regexp"
 	"strings"
 
+	"github.com/shopspring/decimal"
 	log "github.com/sirupsen/logrus"
 )
 
@@ -20,6 +21,17 @@ type ProjectMetadata struct {
 	VCSSubPath         string `json:"vcsSubPath,omitempty"`
 	VCSPullRequestURL  string `json:"vcsPullRequestUrl,omitempty"`
 	TerraformWorkspace string `json:"terraformWorkspace,omitempty"`
+	// Labels and Group are set from the project's config file options.
+	Labels map[string]string `json:"labels,omitempty"`
+	Group  string            `json:"group,omitempty"`
+	// TerragruntDependencies are the paths of the Terragrunt units the project
+	// depends on, TerragruntDependencyOrder is the group of units it's run in,
+	// starting at 1 for units with no dependencies.
+	TerragruntDependencies    []string `json:"terragruntDependencies,omitempty"`
+	TerragruntDependencyOrder int      `json:"terragruntDependencyOrder,omitempty"`
+	// Cached is set when the project's estimate is from the result cache since
+	// its files haven't changed, see --cache-results.
+	Cached bool `json:"cached,omitempty"`
 }
 
 // Project contains the existing, planned state of
@@ -31,6 +43,14 @@ type Project struct {
 	Resources     []*Resource
 	Diff          []*Resource
 	HasDiff       bool
+
+	// Currency is the currency the project is priced in when it has its own
+	// currency, it's empty when the project uses the currency of the run.
+	Currency string
+
+	// UsageScenarioCosts are the monthly costs of the project with the low,
+	// expected and high usage of the usage file, when they're compared.
+	UsageScenarioCosts map[string]decimal.Decimal
 }
 
 func NewProject(name string, metadata *ProjectMetadata) *Project {
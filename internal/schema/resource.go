@@ -5,6 +5,7 @@ import (
 
 	"github.com/shopspring/decimal"
 	log "github.com/sirupsen/logrus"
+	"github.com/tidwall/gjson"
 )
 
 var HourToMonthUnitMultiplier = decimal.NewFromInt(730)
@@ -25,6 +26,30 @@ type Resource struct {
 	UsageSchema       []*UsageItem
 	EstimateUsage     EstimateFunc
 	EstimationSummary map[string]bool
+	// DerivedUsage are the usage keys inferred from the resource's attributes,
+	// with what they were inferred from.
+	DerivedUsage map[string]string
+	// RawValues are the attributes the resource was created from, used to
+	// explain its costs.
+	RawValues gjson.Result
+	// Annotations are the settings of the infracost: comments of the resource,
+	// e.g. budget=200/month.
+	Annotations map[string]string
+	// Utilization are the utilization percentages of the resource from its usage,
+	// keyed by usage key, e.g. cpu_utilization_percent, used to recommend
+	// smaller sizes.
+	Utilization map[string]float64
+	// SourceRange is where the resource is defined in the Terraform files, it's
+	// only known for Terraform directories.
+	SourceRange *SourceRange
+}
+
+// SourceRange is the file and lines of the block of a resource, the filename
+// is relative to the working directory when it's under it.
+type SourceRange struct {
+	Filename  string `json:"filename"`
+	StartLine int    `json:"startLine"`
+	EndLine   int    `json:"endLine"`
 }
 
 func CalculateCosts(project *Project) {
@@ -87,6 +112,22 @@ func (r *Resource) FlattenedSubResources() []*Resource {
 	return resources
 }
 
+// HasPriceUnavailable returns true if the price of any of the cost components of
+// the resource or its sub resources couldn't be looked up.
+func (r *Resource) HasPriceUnavailable() bool {
+	resources := append([]*Resource{r}, r.FlattenedSubResources()...)
+
+	for _, res := range resources {
+		for _, c := range res.CostComponents {
+			if c.PriceUnavailable {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
 func (r *Resource) RemoveCostComponent(costComponent *CostComponent) {
 	n := make([]*CostComponent, 0, len(r.CostComponents)-1)
 	for _, c := range r.CostComponents {
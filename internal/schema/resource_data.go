@@ -17,6 +17,9 @@ type ResourceData struct {
 	referencesMap map[string][]*ResourceData
 	CFResource    cloudformation.Resource
 	UsageData     *UsageData
+	// DerivedUsage are the usage keys inferred from the resource's attributes,
+	// with what they were inferred from.
+	DerivedUsage map[string]string
 }
 
 func NewResourceData(resourceType string, providerName string, address string, tags map[string]string, rawValues gjson.Result) *ResourceData {
@@ -2,12 +2,16 @@ package schema
 
 import (
 	"fmt"
+	"regexp"
+	"sort"
 	"strings"
 
 	jsoniter "github.com/json-iterator/go"
 	"github.com/tidwall/gjson"
 )
 
+var moduleInstanceIndexRegex = regexp.MustCompile(`(module\.[^.\[]+)\[[^\]]*\]`)
+
 type UsageData struct {
 	Address    string
 	Attributes map[string]gjson.Result
@@ -112,6 +116,162 @@ func (u *UsageData) CalcEstimationSummary() map[string]bool {
 	return estimationMap
 }
 
+// UsageDataFinder finds the usage data of resources in the usage data of a usage
+// file, whose keys can be addresses, glob patterns, module addresses or resource
+// types. Keys match in order of precedence:
+//
+//  1. the exact address, e.g. module.storage.aws_s3_bucket.logs
+//  2. the address without the indexes of its modules, e.g. module.storage[0].aws_s3_bucket.logs
+//     matches module.storage.aws_s3_bucket.logs, so a key applies to every instance
+//     of a module
+//  3. glob patterns and module addresses, the longest first, where * matches any
+//     characters and a module address matches every resource inside the module,
+//     e.g. module.storage.aws_s3_bucket.*, aws_lambda_function.api-* or module.storage
+//  4. the resource type on its own, e.g. aws_s3_bucket, as a default for every
+//     resource of that type
+//
+// Attributes a key doesn't set are taken from the next one that matches, so an
+// address only needs the attributes that differ from its pattern, module or type.
+type UsageDataFinder struct {
+	usage    map[string]*UsageData
+	patterns []string
+}
+
+// NewUsageDataFinder returns a finder for the usage data, keyed by usage file key.
+func NewUsageDataFinder(usage map[string]*UsageData) *UsageDataFinder {
+	patterns := make([]string, 0)
+	for k := range usage {
+		if IsUsagePattern(k) || IsModuleAddress(k) {
+			patterns = append(patterns, k)
+		}
+	}
+
+	sort.Slice(patterns, func(i, j int) bool {
+		if len(patterns[i]) != len(patterns[j]) {
+			return len(patterns[i]) > len(patterns[j])
+		}
+		return patterns[i] < patterns[j]
+	})
+
+	return &UsageDataFinder{
+		usage:    usage,
+		patterns: patterns,
+	}
+}
+
+// Find returns the usage data of the resource at address with resourceType, or
+// nil if no keys match it.
+func (f *UsageDataFinder) Find(address string, resourceType string) *UsageData {
+	matches := make([]*UsageData, 0)
+
+	if ud := f.usage[address]; ud != nil {
+		matches = append(matches, ud)
+	}
+
+	moduleAddress := moduleInstanceIndexRegex.ReplaceAllString(address, "$1")
+	if moduleAddress != address {
+		if ud := f.usage[moduleAddress]; ud != nil {
+			matches = append(matches, ud)
+		}
+	}
+
+	for _, p := range f.patterns {
+		if p == address || p == moduleAddress {
+			continue
+		}
+
+		if matchUsageKey(p, address) || (moduleAddress != address && matchUsageKey(p, moduleAddress)) {
+			matches = append(matches, f.usage[p])
+		}
+	}
+
+	if resourceType != "" && resourceType != address {
+		if ud := f.usage[resourceType]; ud != nil {
+			matches = append(matches, ud)
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return nil
+	case 1:
+		return matches[0]
+	}
+
+	attributes := make(map[string]gjson.Result)
+	for i := len(matches) - 1; i >= 0; i-- {
+		for k, v := range matches[i].Attributes {
+			if v.Type != gjson.Null {
+				attributes[k] = v
+			}
+		}
+	}
+
+	return NewUsageData(address, attributes)
+}
+
+// IsUsagePattern returns true if the usage file key is a glob pattern rather than
+// an address, e.g. aws_instance.web[*].
+func IsUsagePattern(key string) bool {
+	return strings.Contains(key, "*")
+}
+
+// MatchUsagePattern returns true if the address matches the glob pattern, where *
+// matches any characters, including dots, so it can match module paths.
+func MatchUsagePattern(pattern string, address string) bool {
+	parts := strings.Split(pattern, "*")
+	if !strings.HasPrefix(address, parts[0]) {
+		return false
+	}
+	rest := address[len(parts[0]):]
+
+	if len(parts) == 1 {
+		return rest == ""
+	}
+
+	for _, part := range parts[1 : len(parts)-1] {
+		i := strings.Index(rest, part)
+		if i == -1 {
+			return false
+		}
+		rest = rest[i+len(part):]
+	}
+
+	return strings.HasSuffix(rest, parts[len(parts)-1])
+}
+
+// IsModuleAddress returns true if the usage file key is the address of a module,
+// e.g. module.api or module.api.module.db, whose usage applies to every resource
+// inside the module.
+func IsModuleAddress(key string) bool {
+	parts := strings.Split(moduleInstanceIndexRegex.ReplaceAllString(key, "$1"), ".")
+	if len(parts)%2 != 0 {
+		return false
+	}
+
+	for i := 0; i < len(parts); i += 2 {
+		if parts[i] != "module" || parts[i+1] == "" {
+			return false
+		}
+	}
+
+	return true
+}
+
+// MatchModuleAddress returns true if the address is of a resource inside the
+// module, including inside any of the module's instances or child modules.
+func MatchModuleAddress(module string, address string) bool {
+	return strings.HasPrefix(address, module+".") || strings.HasPrefix(address, module+"[")
+}
+
+func matchUsageKey(key string, address string) bool {
+	if IsUsagePattern(key) {
+		return MatchUsagePattern(key, address)
+	}
+
+	return MatchModuleAddress(key, address)
+}
+
 func NewUsageMap(m map[string]interface{}) map[string]*UsageData {
 	usageMap := make(map[string]*UsageData)
 
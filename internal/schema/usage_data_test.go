@@ -114,3 +114,84 @@ func TestParseAttributes(t *testing.T) {
 		})
 	}
 }
+
+func TestUsageDataFinder(t *testing.T) {
+	t.Parallel()
+
+	finder := NewUsageDataFinder(NewUsageMap(map[string]interface{}{
+		"aws_lambda_function":                   map[string]interface{}{"monthly_requests": 1, "request_duration_ms": 100},
+		"aws_lambda_function.api-*":             map[string]interface{}{"monthly_requests": 2},
+		"module.*.aws_lambda_function.api-*":    map[string]interface{}{"monthly_requests": 3},
+		"module.api.aws_lambda_function.api-v1": map[string]interface{}{"request_duration_ms": 200},
+		"aws_instance.web[*]":                   map[string]interface{}{"operating_system": "windows"},
+	}))
+
+	ud := finder.Find("module.api.aws_lambda_function.api-v1", "aws_lambda_function")
+	require.NotNil(t, ud)
+	assert.Equal(t, "module.api.aws_lambda_function.api-v1", ud.Address)
+	assert.Equal(t, int64(3), *ud.GetInt("monthly_requests"))
+	assert.Equal(t, int64(200), *ud.GetInt("request_duration_ms"))
+
+	ud = finder.Find("aws_lambda_function.api-v2", "aws_lambda_function")
+	require.NotNil(t, ud)
+	assert.Equal(t, int64(2), *ud.GetInt("monthly_requests"))
+	assert.Equal(t, int64(100), *ud.GetInt("request_duration_ms"))
+
+	ud = finder.Find("aws_lambda_function.worker", "aws_lambda_function")
+	require.NotNil(t, ud)
+	assert.Equal(t, "aws_lambda_function", ud.Address)
+
+	ud = finder.Find(`aws_instance.web["a"]`, "aws_instance")
+	require.NotNil(t, ud)
+	assert.Equal(t, "windows", *ud.GetString("operating_system"))
+
+	assert.Nil(t, finder.Find("aws_instance.db", "aws_instance"))
+}
+
+func TestMatchUsagePattern(t *testing.T) {
+	t.Parallel()
+
+	assert.True(t, MatchUsagePattern("module.storage.aws_s3_bucket.*", "module.storage.aws_s3_bucket.logs"))
+	assert.True(t, MatchUsagePattern("*.aws_s3_bucket.*", "module.a.module.b.aws_s3_bucket.logs"))
+	assert.True(t, MatchUsagePattern("aws_instance.web[*]", "aws_instance.web[0]"))
+	assert.False(t, MatchUsagePattern("module.storage.aws_s3_bucket.*", "aws_s3_bucket.logs"))
+	assert.False(t, MatchUsagePattern("aws_lambda_function.api-*", "aws_lambda_function.worker"))
+	assert.False(t, MatchUsagePattern("aws_s3_bucket.logs", "aws_s3_bucket.logs2"))
+}
+
+func TestUsageDataFinderModules(t *testing.T) {
+	t.Parallel()
+
+	finder := NewUsageDataFinder(NewUsageMap(map[string]interface{}{
+		"module.api":                             map[string]interface{}{"monthly_requests": 1, "request_duration_ms": 100},
+		"module.api.module.db":                   map[string]interface{}{"storage_gb": 50},
+		"module.api.aws_lambda_function.handler": map[string]interface{}{"monthly_requests": 2},
+	}))
+
+	ud := finder.Find("module.api.aws_lambda_function.handler", "aws_lambda_function")
+	require.NotNil(t, ud)
+	assert.Equal(t, int64(2), *ud.GetInt("monthly_requests"))
+	assert.Equal(t, int64(100), *ud.GetInt("request_duration_ms"))
+
+	ud = finder.Find(`module.api["eu"].aws_lambda_function.handler`, "aws_lambda_function")
+	require.NotNil(t, ud)
+	assert.Equal(t, int64(2), *ud.GetInt("monthly_requests"))
+
+	ud = finder.Find("module.api[0].module.db.aws_dynamodb_table.table", "aws_dynamodb_table")
+	require.NotNil(t, ud)
+	assert.Equal(t, int64(50), *ud.GetInt("storage_gb"))
+	assert.Equal(t, int64(1), *ud.GetInt("monthly_requests"))
+
+	assert.Nil(t, finder.Find("module.apiv2.aws_lambda_function.handler", "aws_lambda_function"))
+}
+
+func TestIsModuleAddress(t *testing.T) {
+	t.Parallel()
+
+	assert.True(t, IsModuleAddress("module.api"))
+	assert.True(t, IsModuleAddress("module.api.module.db"))
+	assert.True(t, IsModuleAddress(`module.api["eu"]`))
+	assert.False(t, IsModuleAddress("module.api.aws_lambda_function.handler"))
+	assert.False(t, IsModuleAddress("aws_lambda_function"))
+	assert.False(t, IsModuleAddress("module"))
+}
@@ -1,5 +1,7 @@
 package schema
 
+import "time"
+
 type UsageVariableType int
 
 const (
@@ -16,4 +18,14 @@ type UsageItem struct {
 	Value        interface{}
 	ValueType    UsageVariableType
 	Description  string
+
+	// Unit is the unit Value is given in when it's set in a version 0.2 usage
+	// file, e.g. TB for a value whose key is in GB.
+	Unit string
+	// Source is where Value came from, e.g. manual or synced, and UpdatedAt is
+	// when it was set, if known.
+	Source    string
+	UpdatedAt time.Time
+	// Comment is the comment above the key in the usage file.
+	Comment string
 }
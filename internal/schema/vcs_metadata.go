@@ -0,0 +1,21 @@
+package schema
+
+// VCSMetadata is the commit and CI pipeline a run was for, so the estimates
+// can be attributed to them without wrapper scripts.
+type VCSMetadata struct {
+	Platform          string `json:"platform,omitempty"`
+	RepositoryURL     string `json:"repositoryUrl,omitempty"`
+	Branch            string `json:"branch,omitempty"`
+	BaseBranch        string `json:"baseBranch,omitempty"`
+	CommitSHA         string `json:"commitSha,omitempty"`
+	CommitAuthor      string `json:"commitAuthor,omitempty"`
+	PipelineID        string `json:"pipelineId,omitempty"`
+	PipelineURL       string `json:"pipelineUrl,omitempty"`
+	PullRequestNumber string `json:"pullRequestNumber,omitempty"`
+	PullRequestURL    string `json:"pullRequestUrl,omitempty"`
+}
+
+// IsEmpty returns true if none of the metadata was detected.
+func (m VCSMetadata) IsEmpty() bool {
+	return m == VCSMetadata{}
+}
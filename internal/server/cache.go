@@ -0,0 +1,83 @@
+package server
+
+import (
+	"sync"
+	"time"
+)
+
+// maxCacheEntries is how many outputs are cached before the oldest are evicted.
+const maxCacheEntries = 1000
+
+type cacheEntry struct {
+	output    []byte
+	expiresAt time.Time
+}
+
+// resultCache keeps the output of finished jobs in memory for a TTL, so the same
+// plan or code submitted by several pipelines is only run once.
+type resultCache struct {
+	ttl time.Duration
+
+	mu      sync.Mutex
+	entries map[string]cacheEntry
+}
+
+func newResultCache(ttl time.Duration) *resultCache {
+	return &resultCache{
+		ttl:     ttl,
+		entries: make(map[string]cacheEntry),
+	}
+}
+
+func (c *resultCache) get(key string) ([]byte, bool) {
+	if c.ttl <= 0 {
+		return nil, false
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e, ok := c.entries[key]
+	if !ok || time.Now().After(e.expiresAt) {
+		delete(c.entries, key)
+		return nil, false
+	}
+
+	return e.output, true
+}
+
+func (c *resultCache) set(key string, output []byte) {
+	if c.ttl <= 0 {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := time.Now()
+
+	if len(c.entries) >= maxCacheEntries {
+		var oldestKey string
+		var oldest time.Time
+
+		for k, e := range c.entries {
+			if now.After(e.expiresAt) {
+				delete(c.entries, k)
+				continue
+			}
+
+			if oldestKey == "" || e.expiresAt.Before(oldest) {
+				oldestKey, oldest = k, e.expiresAt
+			}
+		}
+
+		if len(c.entries) >= maxCacheEntries {
+			delete(c.entries, oldestKey)
+		}
+	}
+
+	c.entries[key] = cacheEntry{
+		output:    output,
+		expiresAt: now.Add(c.ttl),
+	}
+}
@@ -0,0 +1,258 @@
+package server
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+
+	"github.com/tidwall/gjson"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const costEstimationServiceName = "infracost.v1.CostEstimation"
+
+// costEstimationServer is the CostEstimation service of
+// proto/infracost/v1/cost_estimation.proto.
+type costEstimationServer interface {
+	EstimateProject(ctx context.Context, in *EstimateRequest) (*EstimateResponse, error)
+	DiffProjects(ctx context.Context, in *EstimateRequest) (*EstimateResponse, error)
+	StreamEstimate(in *EstimateRequest, stream grpc.ServerStream) error
+}
+
+var costEstimationServiceDesc = grpc.ServiceDesc{
+	ServiceName: costEstimationServiceName,
+	HandlerType: (*costEstimationServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "EstimateProject",
+			Handler:    unaryHandler("EstimateProject", costEstimationServer.EstimateProject),
+		},
+		{
+			MethodName: "DiffProjects",
+			Handler:    unaryHandler("DiffProjects", costEstimationServer.DiffProjects),
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName: "StreamEstimate",
+			Handler: func(srv interface{}, stream grpc.ServerStream) error {
+				in := &EstimateRequest{}
+				if err := stream.RecvMsg(in); err != nil {
+					return err
+				}
+
+				return srv.(costEstimationServer).StreamEstimate(in, stream)
+			},
+			ServerStreams: true,
+		},
+	},
+	Metadata: "proto/infracost/v1/cost_estimation.proto",
+}
+
+func unaryHandler(name string, method func(costEstimationServer, context.Context, *EstimateRequest) (*EstimateResponse, error)) func(interface{}, context.Context, func(interface{}) error, grpc.UnaryServerInterceptor) (interface{}, error) {
+	return func(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+		in := &EstimateRequest{}
+		if err := dec(in); err != nil {
+			return nil, err
+		}
+
+		handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+			return method(srv.(costEstimationServer), ctx, req.(*EstimateRequest))
+		}
+
+		if interceptor == nil {
+			return handler(ctx, in)
+		}
+
+		info := &grpc.UnaryServerInfo{
+			Server:     srv,
+			FullMethod: "/" + costEstimationServiceName + "/" + name,
+		}
+
+		return interceptor(ctx, in, info, handler)
+	}
+}
+
+// GRPCServer returns a gRPC server with the CostEstimation service, running its
+// jobs with the same workers and cache as the HTTP API.
+func (s *Server) GRPCServer() *grpc.Server {
+	g := grpc.NewServer(
+		grpc.ForceServerCodec(wireCodec{}),
+		grpc.UnaryInterceptor(func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+			if err := s.authorizeGRPC(ctx); err != nil {
+				return nil, err
+			}
+
+			return handler(ctx, req)
+		}),
+		grpc.StreamInterceptor(func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+			if err := s.authorizeGRPC(ss.Context()); err != nil {
+				return err
+			}
+
+			return handler(srv, ss)
+		}),
+	)
+	g.RegisterService(&costEstimationServiceDesc, s)
+
+	return g
+}
+
+func (s *Server) authorizeGRPC(ctx context.Context) error {
+	if s.opts.APIKey == "" {
+		return nil
+	}
+
+	md, _ := metadata.FromIncomingContext(ctx)
+	keys := md.Get("x-api-key")
+	if len(keys) == 0 || subtle.ConstantTimeCompare([]byte(keys[0]), []byte(s.opts.APIKey)) != 1 {
+		return status.Error(codes.Unauthenticated, "Invalid API key")
+	}
+
+	return nil
+}
+
+// EstimateProject returns the breakdown of the project in the upload.
+func (s *Server) EstimateProject(ctx context.Context, in *EstimateRequest) (*EstimateResponse, error) {
+	return s.runRPC(ctx, CommandBreakdown, in)
+}
+
+// DiffProjects returns the diff of the projects in the upload.
+func (s *Server) DiffProjects(ctx context.Context, in *EstimateRequest) (*EstimateResponse, error) {
+	return s.runRPC(ctx, CommandDiff, in)
+}
+
+// StreamEstimate streams the progress of the job for the upload until it
+// finishes with its response or fails.
+func (s *Server) StreamEstimate(in *EstimateRequest, stream grpc.ServerStream) error {
+	command := in.Command
+	if command == "" {
+		command = CommandBreakdown
+	}
+
+	job, err := s.submitRPC(command, in)
+	if err != nil {
+		return err
+	}
+
+	ctx := stream.Context()
+
+	if !job.Cached {
+		err = stream.SendMsg(&EstimateProgress{JobID: job.ID, Status: ProgressStatusQueued})
+		if err != nil {
+			return err
+		}
+
+		select {
+		case <-job.Started():
+		case <-ctx.Done():
+			return contextError(ctx)
+		}
+
+		err = stream.SendMsg(&EstimateProgress{JobID: job.ID, Status: ProgressStatusRunning})
+		if err != nil {
+			return err
+		}
+	}
+
+	select {
+	case <-job.Done():
+	case <-ctx.Done():
+		return contextError(ctx)
+	}
+
+	resp, err := s.rpcResponse(job)
+	if err != nil {
+		return stream.SendMsg(&EstimateProgress{JobID: job.ID, Status: ProgressStatusFailed, Error: err.Error()})
+	}
+
+	return stream.SendMsg(&EstimateProgress{JobID: job.ID, Status: ProgressStatusFinished, Response: resp})
+}
+
+func (s *Server) runRPC(ctx context.Context, command string, in *EstimateRequest) (*EstimateResponse, error) {
+	job, err := s.submitRPC(command, in)
+	if err != nil {
+		return nil, err
+	}
+
+	select {
+	case <-job.Done():
+	case <-ctx.Done():
+		return nil, contextError(ctx)
+	}
+
+	resp, err := s.rpcResponse(job)
+	if err != nil {
+		return nil, status.Error(codes.Aborted, err.Error())
+	}
+
+	return resp, nil
+}
+
+func (s *Server) submitRPC(command string, in *EstimateRequest) (*Job, error) {
+	format := in.Format
+	if format == "" {
+		format = "json"
+	}
+
+	if err := ValidateFormat(command, format); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	job, err := s.Submit(RunRequest{
+		Command:            command,
+		Format:             format,
+		Path:               in.Path,
+		UsageFile:          in.UsageFile,
+		TerraformWorkspace: in.TerraformWorkspace,
+		TerraformVarFiles:  in.TerraformVarFiles,
+		TerraformParseHCL:  in.TerraformParseHCL,
+	}, in.Upload)
+	if err != nil {
+		var uploadErr *UploadError
+		if errors.As(err, &uploadErr) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+
+		return nil, status.Error(codes.Unavailable, err.Error())
+	}
+
+	return job, nil
+}
+
+// rpcResponse returns the response of a finished job, with the totals of its
+// output if it's JSON.
+func (s *Server) rpcResponse(job *Job) (*EstimateResponse, error) {
+	output, err := s.Result(job)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &EstimateResponse{
+		JobID:  job.ID,
+		Format: job.Format,
+		Output: output,
+		Cached: job.Cached,
+	}
+
+	if job.Format == "json" {
+		totals := gjson.GetManyBytes(output, "currency", "totalMonthlyCost", "pastTotalMonthlyCost", "diffTotalMonthlyCost")
+		resp.Currency = totals[0].String()
+		resp.TotalMonthlyCost = totals[1].String()
+		resp.PastTotalMonthlyCost = totals[2].String()
+		resp.DiffTotalMonthlyCost = totals[3].String()
+	}
+
+	return resp, nil
+}
+
+func contextError(ctx context.Context) error {
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return status.Error(codes.DeadlineExceeded, ctx.Err().Error())
+	}
+
+	return status.Error(codes.Canceled, ctx.Err().Error())
+}
@@ -0,0 +1,286 @@
+package server
+
+import (
+	"fmt"
+
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// The messages of proto/infracost/v1/cost_estimation.proto. They're encoded to
+// and from the protobuf wire format by hand, rather than generated, so the
+// server doesn't need protoc to build, and are kept in sync with the .proto by
+// their field numbers.
+
+// EstimateRequest is the request of every method of the gRPC API.
+type EstimateRequest struct {
+	Upload             []byte
+	Path               string
+	UsageFile          string
+	TerraformWorkspace string
+	TerraformVarFiles  []string
+	TerraformParseHCL  bool
+	Format             string
+	Command            string
+}
+
+// EstimateResponse is the output of a finished job.
+type EstimateResponse struct {
+	JobID                string
+	Format               string
+	Output               []byte
+	Cached               bool
+	Currency             string
+	TotalMonthlyCost     string
+	PastTotalMonthlyCost string
+	DiffTotalMonthlyCost string
+}
+
+// The statuses of EstimateProgress.
+const (
+	ProgressStatusUnspecified = 0
+	ProgressStatusQueued      = 1
+	ProgressStatusRunning     = 2
+	ProgressStatusFinished    = 3
+	ProgressStatusFailed      = 4
+)
+
+// EstimateProgress is streamed by StreamEstimate as the job progresses.
+type EstimateProgress struct {
+	JobID    string
+	Status   int32
+	Error    string
+	Response *EstimateResponse
+}
+
+// wireMessage is a message that's encoded to the protobuf wire format by hand.
+type wireMessage interface {
+	marshalWire() []byte
+	unmarshalWire(b []byte) error
+}
+
+func appendString(b []byte, num protowire.Number, s string) []byte {
+	if s == "" {
+		return b
+	}
+
+	b = protowire.AppendTag(b, num, protowire.BytesType)
+	return protowire.AppendString(b, s)
+}
+
+func appendBytes(b []byte, num protowire.Number, v []byte) []byte {
+	if len(v) == 0 {
+		return b
+	}
+
+	b = protowire.AppendTag(b, num, protowire.BytesType)
+	return protowire.AppendBytes(b, v)
+}
+
+func appendBool(b []byte, num protowire.Number, v bool) []byte {
+	if !v {
+		return b
+	}
+
+	b = protowire.AppendTag(b, num, protowire.VarintType)
+	return protowire.AppendVarint(b, 1)
+}
+
+func appendEnum(b []byte, num protowire.Number, v int32) []byte {
+	if v == 0 {
+		return b
+	}
+
+	b = protowire.AppendTag(b, num, protowire.VarintType)
+	return protowire.AppendVarint(b, uint64(v))
+}
+
+// consumeFields calls field with the value of each field of the message, as the
+// bytes of length-delimited fields or the varint of varint fields. Fields of
+// other types are skipped.
+func consumeFields(b []byte, field func(num protowire.Number, typ protowire.Type, v []byte, varint uint64) error) error {
+	for len(b) > 0 {
+		num, typ, n := protowire.ConsumeTag(b)
+		if n < 0 {
+			return protowire.ParseError(n)
+		}
+		b = b[n:]
+
+		switch typ {
+		case protowire.BytesType:
+			v, n := protowire.ConsumeBytes(b)
+			if n < 0 {
+				return protowire.ParseError(n)
+			}
+			b = b[n:]
+
+			err := field(num, typ, v, 0)
+			if err != nil {
+				return err
+			}
+		case protowire.VarintType:
+			v, n := protowire.ConsumeVarint(b)
+			if n < 0 {
+				return protowire.ParseError(n)
+			}
+			b = b[n:]
+
+			err := field(num, typ, nil, v)
+			if err != nil {
+				return err
+			}
+		default:
+			n := protowire.ConsumeFieldValue(num, typ, b)
+			if n < 0 {
+				return protowire.ParseError(n)
+			}
+			b = b[n:]
+		}
+	}
+
+	return nil
+}
+
+func (m *EstimateRequest) marshalWire() []byte {
+	var b []byte
+	b = appendBytes(b, 1, m.Upload)
+	b = appendString(b, 2, m.Path)
+	b = appendString(b, 3, m.UsageFile)
+	b = appendString(b, 4, m.TerraformWorkspace)
+	for _, f := range m.TerraformVarFiles {
+		b = protowire.AppendTag(b, 5, protowire.BytesType)
+		b = protowire.AppendString(b, f)
+	}
+	b = appendBool(b, 6, m.TerraformParseHCL)
+	b = appendString(b, 7, m.Format)
+	b = appendString(b, 8, m.Command)
+
+	return b
+}
+
+func (m *EstimateRequest) unmarshalWire(b []byte) error {
+	*m = EstimateRequest{}
+
+	return consumeFields(b, func(num protowire.Number, typ protowire.Type, v []byte, varint uint64) error {
+		switch num {
+		case 1:
+			m.Upload = append([]byte{}, v...)
+		case 2:
+			m.Path = string(v)
+		case 3:
+			m.UsageFile = string(v)
+		case 4:
+			m.TerraformWorkspace = string(v)
+		case 5:
+			m.TerraformVarFiles = append(m.TerraformVarFiles, string(v))
+		case 6:
+			m.TerraformParseHCL = varint != 0
+		case 7:
+			m.Format = string(v)
+		case 8:
+			m.Command = string(v)
+		}
+
+		return nil
+	})
+}
+
+func (m *EstimateResponse) marshalWire() []byte {
+	var b []byte
+	b = appendString(b, 1, m.JobID)
+	b = appendString(b, 2, m.Format)
+	b = appendBytes(b, 3, m.Output)
+	b = appendBool(b, 4, m.Cached)
+	b = appendString(b, 5, m.Currency)
+	b = appendString(b, 6, m.TotalMonthlyCost)
+	b = appendString(b, 7, m.PastTotalMonthlyCost)
+	b = appendString(b, 8, m.DiffTotalMonthlyCost)
+
+	return b
+}
+
+func (m *EstimateResponse) unmarshalWire(b []byte) error {
+	*m = EstimateResponse{}
+
+	return consumeFields(b, func(num protowire.Number, typ protowire.Type, v []byte, varint uint64) error {
+		switch num {
+		case 1:
+			m.JobID = string(v)
+		case 2:
+			m.Format = string(v)
+		case 3:
+			m.Output = append([]byte{}, v...)
+		case 4:
+			m.Cached = varint != 0
+		case 5:
+			m.Currency = string(v)
+		case 6:
+			m.TotalMonthlyCost = string(v)
+		case 7:
+			m.PastTotalMonthlyCost = string(v)
+		case 8:
+			m.DiffTotalMonthlyCost = string(v)
+		}
+
+		return nil
+	})
+}
+
+func (m *EstimateProgress) marshalWire() []byte {
+	var b []byte
+	b = appendString(b, 1, m.JobID)
+	b = appendEnum(b, 2, m.Status)
+	b = appendString(b, 3, m.Error)
+	if m.Response != nil {
+		b = protowire.AppendTag(b, 4, protowire.BytesType)
+		b = protowire.AppendBytes(b, m.Response.marshalWire())
+	}
+
+	return b
+}
+
+func (m *EstimateProgress) unmarshalWire(b []byte) error {
+	*m = EstimateProgress{}
+
+	return consumeFields(b, func(num protowire.Number, typ protowire.Type, v []byte, varint uint64) error {
+		switch num {
+		case 1:
+			m.JobID = string(v)
+		case 2:
+			m.Status = int32(varint)
+		case 3:
+			m.Error = string(v)
+		case 4:
+			m.Response = &EstimateResponse{}
+			return m.Response.unmarshalWire(v)
+		}
+
+		return nil
+	})
+}
+
+// wireCodec is the gRPC codec of the hand-encoded messages. It's named proto
+// since they're in the protobuf wire format, so clients generated from the
+// .proto can call the server.
+type wireCodec struct{}
+
+func (wireCodec) Marshal(v interface{}) ([]byte, error) {
+	m, ok := v.(wireMessage)
+	if !ok {
+		return nil, fmt.Errorf("Unsupported message type %T", v)
+	}
+
+	return m.marshalWire(), nil
+}
+
+func (wireCodec) Unmarshal(data []byte, v interface{}) error {
+	m, ok := v.(wireMessage)
+	if !ok {
+		return fmt.Errorf("Unsupported message type %T", v)
+	}
+
+	return m.unmarshalWire(data)
+}
+
+func (wireCodec) Name() string {
+	return "proto"
+}
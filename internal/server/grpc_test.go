@@ -0,0 +1,140 @@
+package server
+
+import (
+	"context"
+	"io"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+func newTestGRPCClient(t *testing.T, opts Options) *grpc.ClientConn {
+	opts.Runner = func(ctx context.Context, req RunRequest) ([]byte, error) {
+		if req.Format != "json" {
+			return []byte(req.Command + " " + req.Format), nil
+		}
+
+		return []byte(`{"currency": "USD", "totalMonthlyCost": "12.5", "pastTotalMonthlyCost": "10", "diffTotalMonthlyCost": "2.5"}`), nil
+	}
+
+	s := New(opts)
+	g := s.GRPCServer()
+
+	lis := bufconn.Listen(1 << 20)
+	go func() {
+		_ = g.Serve(lis)
+	}()
+
+	conn, err := grpc.DialContext(context.Background(), "bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithDefaultCallOptions(grpc.ForceCodec(wireCodec{})),
+	)
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		conn.Close()
+		g.Stop()
+		s.Close()
+	})
+
+	return conn
+}
+
+func TestGRPCEstimateProject(t *testing.T) {
+	conn := newTestGRPCClient(t, Options{})
+
+	resp := &EstimateResponse{}
+	err := conn.Invoke(context.Background(), "/infracost.v1.CostEstimation/EstimateProject", &EstimateRequest{Upload: []byte(`{}`)}, resp)
+	require.NoError(t, err)
+
+	assert.Equal(t, "json", resp.Format)
+	assert.Equal(t, "USD", resp.Currency)
+	assert.Equal(t, "12.5", resp.TotalMonthlyCost)
+	assert.Equal(t, "10", resp.PastTotalMonthlyCost)
+	assert.Equal(t, "2.5", resp.DiffTotalMonthlyCost)
+	assert.NotEmpty(t, resp.JobID)
+
+	err = conn.Invoke(context.Background(), "/infracost.v1.CostEstimation/DiffProjects", &EstimateRequest{Upload: []byte(`{}`), Format: "diff"}, resp)
+	require.NoError(t, err)
+	assert.Equal(t, "diff diff", string(resp.Output))
+
+	err = conn.Invoke(context.Background(), "/infracost.v1.CostEstimation/DiffProjects", &EstimateRequest{Upload: []byte(`{}`), Format: "html"}, resp)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestGRPCStreamEstimate(t *testing.T) {
+	conn := newTestGRPCClient(t, Options{})
+
+	stream, err := conn.NewStream(context.Background(), &grpc.StreamDesc{ServerStreams: true}, "/infracost.v1.CostEstimation/StreamEstimate")
+	require.NoError(t, err)
+	require.NoError(t, stream.SendMsg(&EstimateRequest{Upload: []byte(`{}`), Command: CommandDiff}))
+	require.NoError(t, stream.CloseSend())
+
+	var statuses []int32
+	var last *EstimateProgress
+	for {
+		progress := &EstimateProgress{}
+		err := stream.RecvMsg(progress)
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+
+		statuses = append(statuses, progress.Status)
+		last = progress
+	}
+
+	assert.Equal(t, []int32{ProgressStatusQueued, ProgressStatusRunning, ProgressStatusFinished}, statuses)
+	require.NotNil(t, last.Response)
+	assert.Equal(t, "12.5", last.Response.TotalMonthlyCost)
+}
+
+func TestGRPCAPIKey(t *testing.T) {
+	conn := newTestGRPCClient(t, Options{APIKey: "secret"})
+
+	resp := &EstimateResponse{}
+	err := conn.Invoke(context.Background(), "/infracost.v1.CostEstimation/EstimateProject", &EstimateRequest{Upload: []byte(`{}`)}, resp)
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "x-api-key", "secret")
+	err = conn.Invoke(ctx, "/infracost.v1.CostEstimation/EstimateProject", &EstimateRequest{Upload: []byte(`{}`)}, resp)
+	require.NoError(t, err)
+}
+
+func TestWireMessagesRoundTrip(t *testing.T) {
+	req := &EstimateRequest{
+		Upload:             []byte("{}"),
+		Path:               "infra",
+		UsageFile:          "infracost-usage.yml",
+		TerraformWorkspace: "prod",
+		TerraformVarFiles:  []string{"a.tfvars", "b.tfvars"},
+		TerraformParseHCL:  true,
+		Format:             "json",
+		Command:            CommandDiff,
+	}
+
+	decoded := &EstimateRequest{}
+	require.NoError(t, decoded.unmarshalWire(req.marshalWire()))
+	assert.Equal(t, req, decoded)
+
+	progress := &EstimateProgress{
+		JobID:    "job",
+		Status:   ProgressStatusFinished,
+		Response: &EstimateResponse{JobID: "job", Output: []byte("out"), Cached: true, TotalMonthlyCost: "1"},
+	}
+
+	decodedProgress := &EstimateProgress{}
+	require.NoError(t, decodedProgress.unmarshalWire(progress.marshalWire()))
+	assert.Equal(t, progress, decodedProgress)
+}
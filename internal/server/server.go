@@ -0,0 +1,539 @@
+// Package server runs Infracost as a long-running HTTP API, so platform teams
+// can run it as an internal service that pipelines send their plans or code to,
+// instead of installing and running the CLI in every pipeline.
+package server
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	// DefaultParallelism is how many jobs are run at the same time by default.
+	DefaultParallelism = 4
+	// DefaultQueueSize is how many jobs can wait to be run by default before new
+	// ones are rejected.
+	DefaultQueueSize = 100
+	// DefaultCacheTTL is how long the output of a job is reused by default for
+	// jobs with the same input.
+	DefaultCacheTTL = time.Hour
+	// DefaultMaxUploadBytes is the default size limit of a plan JSON or tarball.
+	DefaultMaxUploadBytes = 100 << 20
+)
+
+// The commands jobs can run.
+const (
+	CommandBreakdown = "breakdown"
+	CommandDiff      = "diff"
+)
+
+// The statuses of a job.
+const (
+	StatusQueued   = "queued"
+	StatusRunning  = "running"
+	StatusFinished = "finished"
+	StatusFailed   = "failed"
+)
+
+// validFormats are the output formats of each command. The JSON output of a diff
+// is the same as a breakdown's since it includes the past and diff costs.
+var validFormats = map[string][]string{
+	CommandBreakdown: {"json", "table", "html"},
+	CommandDiff:      {"json", "diff"},
+}
+
+// RunRequest is a breakdown or diff of the code or plan JSON extracted to Dir.
+type RunRequest struct {
+	Command string
+	Format  string
+	// Dir is the temporary directory the upload was extracted to.
+	Dir string
+	// Path is the path of the Terraform directory or plan JSON inside Dir.
+	Path               string
+	UsageFile          string
+	TerraformWorkspace string
+	TerraformVarFiles  []string
+	TerraformParseHCL  bool
+}
+
+// Runner runs a breakdown or diff and returns its output.
+type Runner func(ctx context.Context, req RunRequest) ([]byte, error)
+
+// Options configures the server.
+type Options struct {
+	// Runner runs the jobs.
+	Runner Runner
+	// Parallelism is how many jobs are run at the same time.
+	Parallelism int
+	// QueueSize is how many jobs can wait to be run before new ones are rejected.
+	QueueSize int
+	// CacheTTL is how long the output of a job is reused for jobs with the same
+	// input. Caching is disabled if it's 0.
+	CacheTTL time.Duration
+	// MaxUploadBytes is the size limit of a plan JSON or tarball.
+	MaxUploadBytes int64
+	// APIKey is the key requests must send in the X-Api-Key header, if it's set.
+	APIKey string
+}
+
+// Job is a breakdown or diff submitted to the server.
+type Job struct {
+	ID         string     `json:"id"`
+	Command    string     `json:"command"`
+	Format     string     `json:"format"`
+	Status     string     `json:"status"`
+	Error      string     `json:"error,omitempty"`
+	Cached     bool       `json:"cached"`
+	CreatedAt  time.Time  `json:"createdAt"`
+	FinishedAt *time.Time `json:"finishedAt,omitempty"`
+
+	req      RunRequest
+	cacheKey string
+	output   []byte
+	started  chan struct{}
+	done     chan struct{}
+}
+
+// Started is closed when the job starts running.
+func (j *Job) Started() <-chan struct{} {
+	return j.started
+}
+
+// Done is closed when the job has finished or failed.
+func (j *Job) Done() <-chan struct{} {
+	return j.done
+}
+
+// Server queues the jobs submitted to its HTTP API and runs them with a pool of
+// workers, caching their output by the hash of their input.
+type Server struct {
+	opts  Options
+	queue chan *Job
+	cache *resultCache
+
+	mu     sync.Mutex
+	jobs   map[string]*Job
+	closed bool
+
+	wg sync.WaitGroup
+}
+
+// New returns a server and starts its workers. Close stops them.
+func New(opts Options) *Server {
+	if opts.Parallelism <= 0 {
+		opts.Parallelism = DefaultParallelism
+	}
+
+	if opts.QueueSize <= 0 {
+		opts.QueueSize = DefaultQueueSize
+	}
+
+	if opts.MaxUploadBytes <= 0 {
+		opts.MaxUploadBytes = DefaultMaxUploadBytes
+	}
+
+	s := &Server{
+		opts:  opts,
+		queue: make(chan *Job, opts.QueueSize),
+		cache: newResultCache(opts.CacheTTL),
+		jobs:  make(map[string]*Job),
+	}
+
+	for i := 0; i < opts.Parallelism; i++ {
+		s.wg.Add(1)
+		go s.work()
+	}
+
+	return s
+}
+
+// Close stops accepting jobs and waits for the queued ones to finish.
+func (s *Server) Close() {
+	s.mu.Lock()
+	s.closed = true
+	close(s.queue)
+	s.mu.Unlock()
+
+	s.wg.Wait()
+}
+
+// Handler returns the handler of the server's HTTP API:
+//
+//	POST /v1/breakdown   run a breakdown of the uploaded plan JSON or tarball
+//	POST /v1/diff        run a diff of the uploaded plan JSON or tarball
+//	GET  /v1/jobs/{id}   get the status of a job
+//	GET  /v1/jobs/{id}/output   get the output of a finished job
+//	GET  /health         check the server is up
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/health", s.handleHealth)
+	mux.HandleFunc("/v1/breakdown", s.authorize(s.handleSubmit(CommandBreakdown)))
+	mux.HandleFunc("/v1/diff", s.authorize(s.handleSubmit(CommandDiff)))
+	mux.HandleFunc("/v1/jobs/", s.authorize(s.handleJob))
+
+	return mux
+}
+
+func (s *Server) authorize(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if s.opts.APIKey != "" && subtle.ConstantTimeCompare([]byte(r.Header.Get("X-Api-Key")), []byte(s.opts.APIKey)) != 1 {
+			writeError(w, http.StatusUnauthorized, "Invalid API key")
+			return
+		}
+
+		next(w, r)
+	}
+}
+
+func (s *Server) handleHealth(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"status": "ok",
+		"queued": len(s.queue),
+	})
+}
+
+// handleSubmit queues a job for the uploaded plan JSON or tarball. Unless async
+// is set, it waits for the job to finish and responds with its output.
+func (s *Server) handleSubmit(command string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			writeError(w, http.StatusMethodNotAllowed, "Method not allowed")
+			return
+		}
+
+		query := r.URL.Query()
+
+		format := query.Get("format")
+		if format == "" {
+			format = "json"
+		}
+
+		if err := ValidateFormat(command, format); err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, s.opts.MaxUploadBytes))
+		if err != nil {
+			writeError(w, http.StatusRequestEntityTooLarge, fmt.Sprintf("The upload must be smaller than %d bytes", s.opts.MaxUploadBytes))
+			return
+		}
+
+		job, err := s.Submit(RunRequest{
+			Command:            command,
+			Format:             format,
+			Path:               query.Get("path"),
+			UsageFile:          query.Get("usage_file"),
+			TerraformWorkspace: query.Get("terraform_workspace"),
+			TerraformVarFiles:  query["terraform_var_file"],
+			TerraformParseHCL:  query.Get("terraform_parse_hcl") == "true",
+		}, body)
+		if err != nil {
+			var uploadErr *UploadError
+			if errors.As(err, &uploadErr) {
+				writeError(w, http.StatusBadRequest, err.Error())
+				return
+			}
+
+			writeError(w, http.StatusServiceUnavailable, err.Error())
+			return
+		}
+
+		if query.Get("async") == "true" {
+			writeJSON(w, http.StatusAccepted, s.Snapshot(job))
+			return
+		}
+
+		select {
+		case <-job.done:
+		case <-r.Context().Done():
+			return
+		}
+
+		s.writeOutput(w, job)
+	}
+}
+
+// handleJob responds with the status of a job, or its output.
+func (s *Server) handleJob(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	id := strings.TrimPrefix(r.URL.Path, "/v1/jobs/")
+	wantOutput := strings.HasSuffix(id, "/output")
+	id = strings.TrimSuffix(id, "/output")
+
+	s.mu.Lock()
+	job, ok := s.jobs[id]
+	s.mu.Unlock()
+
+	if !ok {
+		writeError(w, http.StatusNotFound, "Job not found")
+		return
+	}
+
+	if !wantOutput {
+		writeJSON(w, http.StatusOK, s.Snapshot(job))
+		return
+	}
+
+	select {
+	case <-job.done:
+		s.writeOutput(w, job)
+	default:
+		writeError(w, http.StatusConflict, "The job hasn't finished")
+	}
+}
+
+// Result returns the output of a finished job, or its error if it failed.
+func (s *Server) Result(job *Job) ([]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if job.Status == StatusFailed {
+		return nil, errors.New(job.Error)
+	}
+
+	return job.output, nil
+}
+
+func (s *Server) writeOutput(w http.ResponseWriter, job *Job) {
+	output, err := s.Result(job)
+	if err != nil {
+		writeError(w, http.StatusUnprocessableEntity, err.Error())
+		return
+	}
+
+	w.Header().Set("Content-Type", contentType(job.Format))
+	w.Header().Set("X-Infracost-Job-Id", job.ID)
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(output)
+}
+
+// ValidateFormat returns an error if the command doesn't support the format.
+func ValidateFormat(command string, format string) error {
+	formats, ok := validFormats[command]
+	if !ok {
+		return fmt.Errorf("Unknown command %s", command)
+	}
+
+	if !contains(formats, format) {
+		return fmt.Errorf("format must be one of: %s", strings.Join(formats, ", "))
+	}
+
+	return nil
+}
+
+// Submit extracts the upload and queues a job for it, or returns a finished job
+// with the cached output of the same input. The paths of req are relative to the
+// upload, and its Dir is set to the directory the upload is extracted to.
+// Errors in the upload are returned as an *UploadError.
+func (s *Server) Submit(req RunRequest, body []byte) (*Job, error) {
+	job := &Job{
+		ID:        uuid.NewString(),
+		Command:   req.Command,
+		Format:    req.Format,
+		Status:    StatusQueued,
+		CreatedAt: time.Now().UTC(),
+		cacheKey:  cacheKey(req, body),
+		started:   make(chan struct{}),
+		done:      make(chan struct{}),
+	}
+
+	s.pruneJobs()
+
+	if output, ok := s.cache.get(job.cacheKey); ok {
+		finishedAt := time.Now().UTC()
+		job.Status = StatusFinished
+		job.Cached = true
+		job.FinishedAt = &finishedAt
+		job.output = output
+		close(job.started)
+		close(job.done)
+
+		s.addJob(job)
+
+		return job, nil
+	}
+
+	dir, err := os.MkdirTemp("", "infracost-serve-")
+	if err != nil {
+		return nil, err
+	}
+
+	req.Dir = dir
+	err = resolveUpload(&req, body)
+	if err != nil {
+		os.RemoveAll(dir)
+		return nil, err
+	}
+
+	job.req = req
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		os.RemoveAll(dir)
+		return nil, errors.New("The server is shutting down")
+	}
+
+	select {
+	case s.queue <- job:
+	default:
+		os.RemoveAll(dir)
+		return nil, errors.New("The job queue is full, try again later")
+	}
+
+	s.jobs[job.ID] = job
+
+	return job, nil
+}
+
+func (s *Server) addJob(job *Job) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.jobs[job.ID] = job
+}
+
+// pruneJobs forgets the jobs that finished longer ago than the cache TTL, or an
+// hour if caching is disabled, so the server doesn't keep every output.
+func (s *Server) pruneJobs() {
+	ttl := s.opts.CacheTTL
+	if ttl <= 0 {
+		ttl = time.Hour
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for id, job := range s.jobs {
+		if job.FinishedAt != nil && time.Since(*job.FinishedAt) > ttl {
+			delete(s.jobs, id)
+		}
+	}
+}
+
+func (s *Server) work() {
+	defer s.wg.Done()
+
+	for job := range s.queue {
+		s.run(job)
+	}
+}
+
+func (s *Server) run(job *Job) {
+	defer os.RemoveAll(job.req.Dir)
+
+	s.mu.Lock()
+	job.Status = StatusRunning
+	s.mu.Unlock()
+
+	close(job.started)
+
+	log.Infof("Running %s job %s", job.Command, job.ID)
+
+	output, err := s.opts.Runner(context.Background(), job.req)
+
+	s.mu.Lock()
+	finishedAt := time.Now().UTC()
+	job.FinishedAt = &finishedAt
+
+	if err != nil {
+		log.Infof("Job %s failed: %s", job.ID, err)
+		job.Status = StatusFailed
+		job.Error = err.Error()
+	} else {
+		log.Infof("Job %s finished", job.ID)
+		job.Status = StatusFinished
+		job.output = output
+	}
+	s.mu.Unlock()
+
+	if err == nil {
+		s.cache.set(job.cacheKey, output)
+	}
+
+	close(job.done)
+}
+
+// Snapshot returns a copy of the job that's safe to read while it's running.
+func (s *Server) Snapshot(j *Job) Job {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return Job{
+		ID:         j.ID,
+		Command:    j.Command,
+		Format:     j.Format,
+		Status:     j.Status,
+		Error:      j.Error,
+		Cached:     j.Cached,
+		CreatedAt:  j.CreatedAt,
+		FinishedAt: j.FinishedAt,
+	}
+}
+
+// cacheKey returns the hash of everything that changes the output of a job.
+func cacheKey(req RunRequest, body []byte) string {
+	varFiles := append([]string{}, req.TerraformVarFiles...)
+	sort.Strings(varFiles)
+
+	h := sha256.New()
+	for _, s := range []string{req.Command, req.Format, req.Path, req.UsageFile, req.TerraformWorkspace, strings.Join(varFiles, ","), fmt.Sprint(req.TerraformParseHCL)} {
+		h.Write([]byte(s))
+		h.Write([]byte{0})
+	}
+	h.Write(body)
+
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func contentType(format string) string {
+	switch format {
+	case "json":
+		return "application/json"
+	case "html":
+		return "text/html; charset=utf-8"
+	}
+
+	return "text/plain; charset=utf-8"
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, msg string) {
+	writeJSON(w, status, map[string]string{"error": msg})
+}
+
+func contains(a []string, s string) bool {
+	for _, v := range a {
+		if v == s {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,269 @@
+package server
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestServer(t *testing.T, opts Options) (*httptest.Server, *int32) {
+	var runs int32
+
+	if opts.Runner == nil {
+		opts.Runner = func(ctx context.Context, req RunRequest) ([]byte, error) {
+			atomic.AddInt32(&runs, 1)
+
+			b, err := os.ReadFile(req.Path)
+			if err != nil {
+				return nil, err
+			}
+
+			return []byte(req.Command + " " + req.Format + " " + strings.TrimSpace(string(b))), nil
+		}
+	}
+
+	s := New(opts)
+	ts := httptest.NewServer(s.Handler())
+	t.Cleanup(func() {
+		ts.Close()
+		s.Close()
+	})
+
+	return ts, &runs
+}
+
+func post(t *testing.T, url string, body []byte, header map[string]string) (*http.Response, string) {
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+	require.NoError(t, err)
+
+	for k, v := range header {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	b, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+
+	return resp, string(b)
+}
+
+func TestBreakdownPlanJSON(t *testing.T) {
+	ts, runs := newTestServer(t, Options{CacheTTL: time.Hour})
+
+	resp, body := post(t, ts.URL+"/v1/breakdown", []byte(`{"format_version": "1.0"}`), nil)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "application/json", resp.Header.Get("Content-Type"))
+	assert.Equal(t, `breakdown json {"format_version": "1.0"}`, body)
+
+	// The same plan is served from the cache
+	resp, body = post(t, ts.URL+"/v1/breakdown", []byte(`{"format_version": "1.0"}`), nil)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, `breakdown json {"format_version": "1.0"}`, body)
+	assert.Equal(t, int32(1), atomic.LoadInt32(runs))
+
+	// A different format isn't
+	resp, body = post(t, ts.URL+"/v1/diff?format=diff", []byte(`{"format_version": "1.0"}`), nil)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, `diff diff {"format_version": "1.0"}`, body)
+	assert.Equal(t, int32(2), atomic.LoadInt32(runs))
+}
+
+func TestInvalidFormat(t *testing.T) {
+	ts, _ := newTestServer(t, Options{})
+
+	resp, body := post(t, ts.URL+"/v1/diff?format=html", []byte(`{}`), nil)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	assert.Contains(t, body, "format must be one of: json, diff")
+}
+
+func TestAsyncJob(t *testing.T) {
+	release := make(chan struct{})
+	ts, _ := newTestServer(t, Options{
+		Runner: func(ctx context.Context, req RunRequest) ([]byte, error) {
+			<-release
+			return []byte(`{"totalMonthlyCost": "10"}`), nil
+		},
+	})
+
+	resp, body := post(t, ts.URL+"/v1/breakdown?async=true", []byte(`{}`), nil)
+	require.Equal(t, http.StatusAccepted, resp.StatusCode)
+
+	var job Job
+	require.NoError(t, json.Unmarshal([]byte(body), &job))
+	assert.Equal(t, CommandBreakdown, job.Command)
+
+	resp, err := http.Get(ts.URL + "/v1/jobs/" + job.ID + "/output")
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusConflict, resp.StatusCode)
+
+	close(release)
+
+	require.Eventually(t, func() bool {
+		resp, err := http.Get(ts.URL + "/v1/jobs/" + job.ID)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		var status Job
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&status))
+
+		return status.Status == StatusFinished
+	}, 5*time.Second, 10*time.Millisecond)
+
+	resp, err = http.Get(ts.URL + "/v1/jobs/" + job.ID + "/output")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	b, _ := io.ReadAll(resp.Body)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, `{"totalMonthlyCost": "10"}`, string(b))
+
+	resp, err = http.Get(ts.URL + "/v1/jobs/missing")
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
+
+func TestFailedJob(t *testing.T) {
+	ts, _ := newTestServer(t, Options{
+		Runner: func(ctx context.Context, req RunRequest) ([]byte, error) {
+			return nil, errors.New("No INFRACOST_API_KEY environment variable is set")
+		},
+	})
+
+	resp, body := post(t, ts.URL+"/v1/breakdown", []byte(`{}`), nil)
+	assert.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+	assert.Contains(t, body, "No INFRACOST_API_KEY")
+}
+
+func TestAPIKey(t *testing.T) {
+	ts, _ := newTestServer(t, Options{APIKey: "secret"})
+
+	resp, _ := post(t, ts.URL+"/v1/breakdown", []byte(`{}`), nil)
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+
+	resp, _ = post(t, ts.URL+"/v1/breakdown", []byte(`{}`), map[string]string{"X-Api-Key": "secret"})
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	resp, err := http.Get(ts.URL + "/health")
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+}
+
+func TestQueueFull(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+
+	ts, _ := newTestServer(t, Options{
+		Parallelism: 1,
+		QueueSize:   1,
+		Runner: func(ctx context.Context, req RunRequest) ([]byte, error) {
+			<-release
+			return []byte("{}"), nil
+		},
+	})
+
+	statuses := make([]int, 0, 3)
+	for i := 0; i < 3; i++ {
+		resp, _ := post(t, ts.URL+"/v1/breakdown?async=true", []byte(`{"i": `+string(rune('0'+i))+`}`), nil)
+		statuses = append(statuses, resp.StatusCode)
+	}
+
+	assert.Contains(t, statuses, http.StatusServiceUnavailable)
+}
+
+func tarball(t *testing.T, files map[string]string) []byte {
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+
+	for name, contents := range files {
+		require.NoError(t, tw.WriteHeader(&tar.Header{
+			Name:     name,
+			Mode:     0600,
+			Size:     int64(len(contents)),
+			Typeflag: tar.TypeReg,
+		}))
+		_, err := tw.Write([]byte(contents))
+		require.NoError(t, err)
+	}
+
+	require.NoError(t, tw.Close())
+	require.NoError(t, gz.Close())
+
+	return buf.Bytes()
+}
+
+func TestResolveUploadTarball(t *testing.T) {
+	body := tarball(t, map[string]string{
+		"infra/main.tf":                `resource "aws_instance" "web" {}`,
+		"infra/prod.tfvars":            `instance_type = "m5.large"`,
+		"infra/infracost-usage.yml":    "version: 0.1\n",
+		"other/unrelated/variables.tf": "",
+	})
+
+	req := RunRequest{
+		Dir:               t.TempDir(),
+		Path:              "infra",
+		UsageFile:         "infra/infracost-usage.yml",
+		TerraformVarFiles: []string{"prod.tfvars"},
+	}
+	require.NoError(t, resolveUpload(&req, body))
+
+	assert.Equal(t, filepath.Join(req.Dir, "infra"), req.Path)
+	assert.Equal(t, filepath.Join(req.Dir, "infra", "infracost-usage.yml"), req.UsageFile)
+	assert.FileExists(t, filepath.Join(req.Dir, "infra", "main.tf"))
+
+	req = RunRequest{Dir: t.TempDir(), Path: "missing"}
+	assert.Error(t, resolveUpload(&req, body))
+
+	req = RunRequest{Dir: t.TempDir(), Path: "infra", TerraformVarFiles: []string{"../../../etc/passwd"}}
+	assert.Error(t, resolveUpload(&req, body))
+}
+
+func TestResolveUploadPathTraversal(t *testing.T) {
+	body := tarball(t, map[string]string{
+		"../escape.tf": "",
+	})
+
+	req := RunRequest{Dir: t.TempDir()}
+	err := resolveUpload(&req, body)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "outside of the tarball")
+}
+
+func TestResultCache(t *testing.T) {
+	c := newResultCache(time.Hour)
+	c.set("a", []byte("output"))
+
+	output, ok := c.get("a")
+	assert.True(t, ok)
+	assert.Equal(t, "output", string(output))
+
+	_, ok = c.get("b")
+	assert.False(t, ok)
+
+	disabled := newResultCache(0)
+	disabled.set("a", []byte("output"))
+	_, ok = disabled.get("a")
+	assert.False(t, ok)
+}
@@ -0,0 +1,164 @@
+package server
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// UploadError is an error in the upload rather than the server, which is
+// returned to the client as a bad request.
+type UploadError struct {
+	msg string
+}
+
+func (e *UploadError) Error() string {
+	return e.msg
+}
+
+// planFileName is the name a plan JSON upload is saved as.
+const planFileName = "plan.json"
+
+// resolveUpload extracts the upload to the request's Dir and resolves the paths
+// of the request inside it.
+func resolveUpload(req *RunRequest, body []byte) error {
+	var err error
+
+	req.Path, err = extractUpload(body, req.Dir, req.Path)
+	if err != nil {
+		return err
+	}
+
+	if req.UsageFile != "" {
+		req.UsageFile, err = safeJoin(req.Dir, req.UsageFile)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Var files are relative to the Terraform directory, like Terraform's
+	// -var-file flag
+	rel, err := filepath.Rel(req.Dir, req.Path)
+	if err != nil {
+		return err
+	}
+
+	for _, f := range req.TerraformVarFiles {
+		if filepath.IsAbs(f) {
+			return &UploadError{fmt.Sprintf("The var file %s must be relative to the path", f)}
+		}
+
+		_, err = safeJoin(req.Dir, filepath.Join(rel, f))
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// extractUpload writes the upload to dir and returns the path of the project
+// inside it. A plan JSON is saved as plan.json, and a tarball, optionally
+// gzipped, is extracted with path being the Terraform directory or plan JSON in
+// it, or its root if path is empty.
+func extractUpload(body []byte, dir string, path string) (string, error) {
+	if len(bytes.TrimSpace(body)) == 0 {
+		return "", &UploadError{"The request body must be a Terraform plan JSON or a tarball of Terraform code"}
+	}
+
+	if trimmed := bytes.TrimSpace(body); trimmed[0] == '{' {
+		err := os.WriteFile(filepath.Join(dir, planFileName), body, 0600)
+		if err != nil {
+			return "", err
+		}
+
+		return filepath.Join(dir, planFileName), nil
+	}
+
+	var r io.Reader = bytes.NewReader(body)
+	if len(body) > 2 && body[0] == 0x1f && body[1] == 0x8b {
+		gz, err := gzip.NewReader(r)
+		if err != nil {
+			return "", &UploadError{fmt.Sprintf("Invalid gzipped tarball: %s", err)}
+		}
+		defer gz.Close()
+
+		r = gz
+	}
+
+	err := extractTar(r, dir)
+	if err != nil {
+		return "", err
+	}
+
+	target, err := safeJoin(dir, path)
+	if err != nil {
+		return "", err
+	}
+
+	if _, err := os.Stat(target); err != nil {
+		return "", &UploadError{fmt.Sprintf("The path %s isn't in the tarball", path)}
+	}
+
+	return target, nil
+}
+
+// extractTar extracts the regular files and directories of the tarball to dir.
+// Links are skipped so the tarball can't point outside of dir.
+func extractTar(r io.Reader, dir string) error {
+	tr := tar.NewReader(r)
+
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return &UploadError{fmt.Sprintf("Invalid tarball: %s", err)}
+		}
+
+		target, err := safeJoin(dir, hdr.Name)
+		if err != nil {
+			return err
+		}
+
+		switch hdr.Typeflag {
+		case tar.TypeDir:
+			err = os.MkdirAll(target, 0700)
+			if err != nil {
+				return err
+			}
+		case tar.TypeReg:
+			err = os.MkdirAll(filepath.Dir(target), 0700)
+			if err != nil {
+				return err
+			}
+
+			f, err := os.OpenFile(target, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600)
+			if err != nil {
+				return err
+			}
+
+			_, err = io.Copy(f, tr) // nolint:gosec // the upload size is limited by the server
+			f.Close()
+			if err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// safeJoin joins name to dir, returning an error if it's outside of dir.
+func safeJoin(dir string, name string) (string, error) {
+	target := filepath.Join(dir, filepath.FromSlash(name))
+	if target != dir && !strings.HasPrefix(target, dir+string(filepath.Separator)) {
+		return "", &UploadError{fmt.Sprintf("The path %s is outside of the tarball", name)}
+	}
+
+	return target, nil
+}
@@ -0,0 +1,180 @@
+// Package tagpolicy checks the tags of the resources of a run against the tag
+// policies of the config file, and sums the monthly cost of the resources that
+// don't comply so untagged spend can be tracked.
+package tagpolicy
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/infracost/infracost/internal/config"
+	"github.com/infracost/infracost/internal/output"
+)
+
+// Check returns the results of each tag policy for each project it applies to.
+// Only the top-level resources are checked, sub-resources are tagged by their
+// parent.
+func Check(r output.Root, policies []*config.TagPolicy) []output.TagPolicyResult {
+	var results []output.TagPolicyResult
+
+	for _, t := range policies {
+		for _, p := range r.Projects {
+			if !matchesProject(t.Project, p) || p.Breakdown == nil {
+				continue
+			}
+
+			results = append(results, check(t, p))
+		}
+	}
+
+	return results
+}
+
+func check(t *config.TagPolicy, p output.Project) output.TagPolicyResult {
+	total := decimal.Zero
+	untagged := decimal.Zero
+
+	result := output.TagPolicyResult{
+		Name:                  t.Label(),
+		ProjectName:           p.Name,
+		Blocking:              t.Block,
+		NonCompliantResources: []output.TagPolicyResource{},
+	}
+
+	resources := p.Breakdown.Resources
+	if t.ApplyTo == config.ApplyToChanged {
+		resources = p.ChangedResources()
+	}
+
+	now := time.Now()
+
+	for _, r := range resources {
+		if !matchesResourceType(t.ResourceTypes, output.ResourceType(r.Name)) || r.IsExempt(now) {
+			continue
+		}
+
+		result.TotalResources++
+
+		cost := decimal.Zero
+		if r.MonthlyCost != nil {
+			cost = *r.MonthlyCost
+		}
+		total = total.Add(cost)
+
+		missing, invalid := checkTags(t.Tags, r.Tags)
+		if len(missing) == 0 && len(invalid) == 0 {
+			continue
+		}
+
+		untagged = untagged.Add(cost)
+		result.NonCompliantResources = append(result.NonCompliantResources, output.TagPolicyResource{
+			Address:     r.Name,
+			MonthlyCost: r.MonthlyCost,
+			MissingTags: missing,
+			InvalidTags: invalid,
+			SourceRange: r.SourceRange,
+		})
+	}
+
+	sort.SliceStable(result.NonCompliantResources, func(i, j int) bool {
+		return costOrZero(result.NonCompliantResources[i].MonthlyCost).GreaterThan(costOrZero(result.NonCompliantResources[j].MonthlyCost))
+	})
+
+	result.TotalMonthlyCost = &total
+	result.UntaggedMonthlyCost = &untagged
+
+	return result
+}
+
+// checkTags returns the required tags that are missing and the tags whose
+// values aren't allowed, as key=value.
+func checkTags(required []*config.RequiredTag, tags map[string]string) ([]string, []string) {
+	var missing []string
+	var invalid []string
+
+	for _, req := range required {
+		v, ok := tags[req.Key]
+		if !ok || v == "" {
+			missing = append(missing, req.Key)
+			continue
+		}
+
+		if len(req.Values) > 0 && !matchesAny(req.Values, v) {
+			invalid = append(invalid, req.Key+"="+v)
+		}
+	}
+
+	return missing, invalid
+}
+
+func matchesProject(pattern string, p output.Project) bool {
+	if pattern == "" {
+		return true
+	}
+
+	if globMatch(pattern, p.Name) {
+		return true
+	}
+
+	return p.Metadata != nil && globMatch(pattern, p.Metadata.Path)
+}
+
+func matchesResourceType(patterns []string, resourceType string) bool {
+	return len(patterns) == 0 || matchesAny(patterns, resourceType)
+}
+
+func matchesAny(patterns []string, s string) bool {
+	for _, p := range patterns {
+		if globMatch(p, s) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func globMatch(pattern string, s string) bool {
+	if pattern == s {
+		return true
+	}
+
+	ok, _ := path.Match(pattern, s)
+	return ok
+}
+
+func costOrZero(d *decimal.Decimal) decimal.Decimal {
+	if d == nil {
+		return decimal.Zero
+	}
+
+	return *d
+}
+
+// Report returns a summary of the blocking tag policies with non-compliant
+// resources, one line per policy and project.
+func Report(results []output.TagPolicyResult, currency string) string {
+	var b strings.Builder
+
+	blocking := 0
+	for _, t := range results {
+		if t.Blocking && len(t.NonCompliantResources) > 0 {
+			blocking++
+		}
+	}
+
+	fmt.Fprintf(&b, "%d tag policy checks failed:\n", blocking)
+	for _, t := range results {
+		if !t.Blocking || len(t.NonCompliantResources) == 0 {
+			continue
+		}
+
+		fmt.Fprintf(&b, "  %s: %d non-compliant resources in %s with %s of untagged monthly cost\n", t.Name, len(t.NonCompliantResources), t.ProjectName, output.FormatCost(currency, t.UntaggedMonthlyCost))
+	}
+
+	return b.String()
+}
@@ -0,0 +1,100 @@
+package tagpolicy
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/infracost/infracost/internal/config"
+	"github.com/infracost/infracost/internal/output"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+func decimalPtr(v string) *decimal.Decimal {
+	d := decimal.RequireFromString(v)
+	return &d
+}
+
+func testRoot() output.Root {
+	return output.Root{
+		Currency: "USD",
+		Projects: []output.Project{
+			{
+				Name:     "infracost/repo/environments/prod",
+				Metadata: &schema.ProjectMetadata{Path: "environments/prod"},
+				Breakdown: &output.Breakdown{
+					Resources: []output.Resource{
+						{Name: "aws_instance.api", Tags: map[string]string{"team": "payments", "env": "prod"}, MonthlyCost: decimalPtr("150")},
+						{Name: "aws_instance.worker", Tags: map[string]string{"env": "production"}, MonthlyCost: decimalPtr("300")},
+						{Name: "module.db.aws_db_instance.this", MonthlyCost: decimalPtr("50")},
+						{Name: "aws_s3_bucket.logs", Tags: map[string]string{"team": "platform", "env": "prod"}, MonthlyCost: decimalPtr("10")},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestCheck(t *testing.T) {
+	results := Check(testRoot(), []*config.TagPolicy{
+		{
+			Name:          "Cost allocation",
+			ResourceTypes: []string{"aws_instance", "aws_db_*"},
+			Tags: []*config.RequiredTag{
+				{Key: "team"},
+				{Key: "env", Values: []string{"dev", "staging", "prod"}},
+			},
+		},
+	})
+	require.Len(t, results, 1)
+
+	r := results[0]
+	assert.Equal(t, "Cost allocation", r.Name)
+	assert.Equal(t, 3, r.TotalResources)
+	assert.Equal(t, "500", r.TotalMonthlyCost.String())
+	assert.Equal(t, "350", r.UntaggedMonthlyCost.String())
+	assert.Equal(t, []output.TagPolicyResource{
+		{Address: "aws_instance.worker", MonthlyCost: decimalPtr("300"), MissingTags: []string{"team"}, InvalidTags: []string{"env=production"}},
+		{Address: "module.db.aws_db_instance.this", MonthlyCost: decimalPtr("50"), MissingTags: []string{"team", "env"}},
+	}, r.NonCompliantResources)
+}
+
+func TestCheckChangedResources(t *testing.T) {
+	r := testRoot()
+	r.Projects[0].Diff = &output.Breakdown{
+		Resources: []output.Resource{
+			{Name: "module.db.aws_db_instance.this", MonthlyCost: decimalPtr("20")},
+			{Name: "aws_instance.removed", MonthlyCost: decimalPtr("-40")},
+		},
+	}
+
+	results := Check(r, []*config.TagPolicy{
+		{Tags: []*config.RequiredTag{{Key: "team"}}, ApplyTo: config.ApplyToChanged},
+	})
+	require.Len(t, results, 1)
+
+	assert.Equal(t, 1, results[0].TotalResources)
+	assert.Equal(t, []output.TagPolicyResource{
+		{Address: "module.db.aws_db_instance.this", MonthlyCost: decimalPtr("50"), MissingTags: []string{"team"}},
+	}, results[0].NonCompliantResources)
+}
+
+func TestCheckNoMatchingProject(t *testing.T) {
+	results := Check(testRoot(), []*config.TagPolicy{
+		{Project: "environments/dev", Tags: []*config.RequiredTag{{Key: "team"}}},
+	})
+	assert.Empty(t, results)
+}
+
+func TestReport(t *testing.T) {
+	results := Check(testRoot(), []*config.TagPolicy{
+		{Tags: []*config.RequiredTag{{Key: "team"}}, Block: true},
+		{Name: "Env", Tags: []*config.RequiredTag{{Key: "env"}}},
+	})
+
+	assert.Equal(t, `1 tag policy checks failed:
+  required tags team: 2 non-compliant resources in infracost/repo/environments/prod with $350 of untagged monthly cost
+`, Report(results, "USD"))
+}
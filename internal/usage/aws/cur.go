@@ -0,0 +1,361 @@
+package aws
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"net/url"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+)
+
+// DefaultCURAddressTag is the cost allocation tag whose value is the Terraform
+// address of the resource a line item is for.
+const DefaultCURAddressTag = "terraform_address"
+
+// CUROptions changes which line items of a Cost and Usage Report are used.
+type CUROptions struct {
+	// AddressTag is the cost allocation tag whose value is the Terraform address
+	// of the resource, e.g. terraform_address. It must be activated in the
+	// billing console to be included in the report.
+	AddressTag string
+	// AccountID only uses the line items of the linked account if it's set.
+	AccountID string
+}
+
+// curUsageKey maps the usage type of a line item, without its region prefix, to
+// a usage key of the resource type.
+type curUsageKey struct {
+	usageType string
+	key       string
+}
+
+// curResourceUsageKeys are the usage keys of each resource type that can be
+// set from the line items of a Cost and Usage Report.
+var curResourceUsageKeys = map[string][]curUsageKey{
+	"aws_api_gateway_rest_api": {
+		{"ApiGatewayRequest", "monthly_requests"},
+	},
+	"aws_apigatewayv2_api": {
+		{"ApiGatewayHttpRequest", "monthly_requests"},
+	},
+	"aws_cloudwatch_log_group": {
+		{"DataProcessing-Bytes", "monthly_data_ingested_gb"},
+		{"TimedStorage-ByteHrs", "storage_gb"},
+		{"DataScanned-Bytes", "monthly_data_scanned_gb"},
+	},
+	"aws_dynamodb_table": {
+		{"ReadRequestUnits", "monthly_read_request_units"},
+		{"WriteRequestUnits", "monthly_write_request_units"},
+		{"TimedStorage-ByteHrs", "storage_gb"},
+	},
+	"aws_lambda_function": {
+		{"Request", "monthly_requests"},
+	},
+	"aws_nat_gateway": {
+		{"NatGateway-Bytes", "monthly_data_processed_gb"},
+	},
+	"aws_s3_bucket": {
+		{"TimedStorage-ByteHrs", "standard.storage_gb"},
+		{"Requests-Tier1", "standard.monthly_tier_1_requests"},
+		{"Requests-Tier2", "standard.monthly_tier_2_requests"},
+	},
+	"aws_sns_topic": {
+		{"Requests-Tier1", "monthly_requests"},
+	},
+	"aws_sqs_queue": {
+		{"Requests-RBP", "monthly_requests"},
+		{"Requests-FIFO-RBP", "monthly_requests"},
+	},
+}
+
+// curUsageLineItemTypes are the types of line items for usage, rather than fees,
+// credits, refunds or taxes.
+var curUsageLineItemTypes = map[string]bool{
+	"Usage":                   true,
+	"DiscountedUsage":         true,
+	"SavingsPlanCoveredUsage": true,
+}
+
+var (
+	curRegionPrefixRegex = regexp.MustCompile(`^[A-Z]{2,4}[0-9]?-`)
+	curAddressIndexRegex = regexp.MustCompile(`\[[^\]]*\]`)
+	curDateLayouts       = []string{time.RFC3339, "2006-01-02 15:04:05.000", "2006-01-02 15:04:05"}
+)
+
+// curColumns are the indexes of the columns of a Cost and Usage Report. Legacy
+// reports have a column per tag while CUR 2.0 reports have a column with a JSON
+// map of all the tags.
+type curColumns struct {
+	accountID    int
+	lineItemType int
+	usageType    int
+	usageAmount  int
+	startDate    int
+	endDate      int
+	tag          int
+	tags         int
+}
+
+// CURGetUsage reads a Cost and Usage Report from a local CSV file or an S3 URL,
+// optionally gzipped, and returns the monthly usage of each Terraform address
+// tagged on its line items. The usage is scaled to a month from the period the
+// report covers so partial months can be used.
+func CURGetUsage(ctx context.Context, path string, opts CUROptions) (map[string]interface{}, error) {
+	if opts.AddressTag == "" {
+		opts.AddressTag = DefaultCURAddressTag
+	}
+
+	b, err := readCUR(ctx, path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Error reading cost and usage report %s", path)
+	}
+
+	return parseCUR(b, opts)
+}
+
+func readCUR(ctx context.Context, path string) ([]byte, error) {
+	var b []byte
+	var err error
+
+	if strings.HasPrefix(path, "s3://") {
+		u, parseErr := url.Parse(path)
+		if parseErr != nil {
+			return nil, parseErr
+		}
+
+		log.Debugf("Downloading cost and usage report %s", path)
+		b, err = S3GetObject(ctx, "", u.Host, strings.TrimPrefix(u.Path, "/"))
+	} else {
+		b, err = os.ReadFile(path)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// Reports are delivered gzipped by default
+	if len(b) > 1 && b[0] == 0x1f && b[1] == 0x8b {
+		r, err := gzip.NewReader(bytes.NewReader(b))
+		if err != nil {
+			return nil, err
+		}
+		defer r.Close()
+
+		return io.ReadAll(r)
+	}
+
+	return b, nil
+}
+
+func parseCUR(b []byte, opts CUROptions) (map[string]interface{}, error) {
+	r := csv.NewReader(bytes.NewReader(b))
+	r.FieldsPerRecord = -1
+
+	header, err := r.Read()
+	if err != nil {
+		return nil, errors.Wrap(err, "Error reading cost and usage report header")
+	}
+
+	cols, err := findCURColumns(header, opts.AddressTag)
+	if err != nil {
+		return nil, err
+	}
+
+	totals := make(map[string]map[string]float64)
+	var start, end time.Time
+	skipped := 0
+
+	for {
+		record, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, errors.Wrap(err, "Error reading cost and usage report")
+		}
+
+		if opts.AccountID != "" && curField(record, cols.accountID) != opts.AccountID {
+			continue
+		}
+
+		if cols.lineItemType >= 0 && !curUsageLineItemTypes[curField(record, cols.lineItemType)] {
+			continue
+		}
+
+		address := curAddress(record, cols, opts.AddressTag)
+		if address == "" {
+			continue
+		}
+
+		key := curUsageKeyFor(address, curField(record, cols.usageType))
+		if key == "" {
+			skipped++
+			continue
+		}
+
+		amount, err := strconv.ParseFloat(curField(record, cols.usageAmount), 64)
+		if err != nil {
+			continue
+		}
+
+		if totals[address] == nil {
+			totals[address] = make(map[string]float64)
+		}
+		totals[address][key] += amount
+
+		if t, ok := parseCURDate(curField(record, cols.startDate)); ok && (start.IsZero() || t.Before(start)) {
+			start = t
+		}
+		if t, ok := parseCURDate(curField(record, cols.endDate)); ok && t.After(end) {
+			end = t
+		}
+	}
+
+	if skipped > 0 {
+		log.Debugf("Skipped %d tagged line items of the cost and usage report with usage types that don't map to usage keys", skipped)
+	}
+
+	// Scale the usage to a month, assuming the report covers a month if its
+	// period can't be read
+	scale := 1.0
+	if !start.IsZero() && end.After(start) {
+		scale = 730 / end.Sub(start).Hours()
+	}
+
+	usage := make(map[string]interface{}, len(totals))
+	for address, values := range totals {
+		m := make(map[string]interface{})
+		for key, v := range values {
+			setCURUsageValue(m, key, roundCURValue(v*scale))
+		}
+		usage[address] = m
+	}
+
+	return usage, nil
+}
+
+func findCURColumns(header []string, addressTag string) (curColumns, error) {
+	index := make(map[string]int, len(header))
+	for i, h := range header {
+		index[strings.TrimSpace(h)] = i
+	}
+
+	find := func(names ...string) int {
+		for _, name := range names {
+			if i, ok := index[name]; ok {
+				return i
+			}
+		}
+		return -1
+	}
+
+	cols := curColumns{
+		accountID:    find("lineItem/UsageAccountId", "line_item_usage_account_id"),
+		lineItemType: find("lineItem/LineItemType", "line_item_line_item_type"),
+		usageType:    find("lineItem/UsageType", "line_item_usage_type"),
+		usageAmount:  find("lineItem/UsageAmount", "line_item_usage_amount"),
+		startDate:    find("lineItem/UsageStartDate", "line_item_usage_start_date"),
+		endDate:      find("lineItem/UsageEndDate", "line_item_usage_end_date"),
+		tag:          find("resourceTags/user:"+addressTag, "resource_tags_user_"+addressTag),
+		tags:         find("resource_tags"),
+	}
+
+	if cols.usageType < 0 || cols.usageAmount < 0 {
+		return cols, errors.New("Cost and usage report has no usage type or usage amount columns")
+	}
+
+	if cols.tag < 0 && cols.tags < 0 {
+		return cols, fmt.Errorf("Cost and usage report has no %s tag column, check the tag is activated as a cost allocation tag", addressTag)
+	}
+
+	return cols, nil
+}
+
+func curField(record []string, i int) string {
+	if i < 0 || i >= len(record) {
+		return ""
+	}
+
+	return strings.TrimSpace(record[i])
+}
+
+// curAddress returns the Terraform address tagged on the line item.
+func curAddress(record []string, cols curColumns, addressTag string) string {
+	if cols.tag >= 0 {
+		return curField(record, cols.tag)
+	}
+
+	raw := curField(record, cols.tags)
+	if raw == "" {
+		return ""
+	}
+
+	var tags map[string]string
+	if err := json.Unmarshal([]byte(raw), &tags); err != nil {
+		return ""
+	}
+
+	return tags["user_"+addressTag]
+}
+
+// curUsageKeyFor returns the usage key that the usage type sets for the resource
+// type of the address, e.g. USE1-NatGateway-Bytes sets monthly_data_processed_gb
+// for aws_nat_gateway.
+func curUsageKeyFor(address string, usageType string) string {
+	parts := strings.Split(curAddressIndexRegex.ReplaceAllString(address, ""), ".")
+	if len(parts) < 2 {
+		return ""
+	}
+
+	usageType = curRegionPrefixRegex.ReplaceAllString(usageType, "")
+	for _, k := range curResourceUsageKeys[parts[len(parts)-2]] {
+		if k.usageType == usageType {
+			return k.key
+		}
+	}
+
+	return ""
+}
+
+func roundCURValue(v float64) float64 {
+	return math.Round(v*100) / 100
+}
+
+func parseCURDate(s string) (time.Time, bool) {
+	for _, layout := range curDateLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, true
+		}
+	}
+
+	return time.Time{}, false
+}
+
+// setCURUsageValue sets the value of the key in the usage map, adding maps for
+// the sub resources of keys like standard.storage_gb.
+func setCURUsageValue(m map[string]interface{}, key string, v float64) {
+	parts := strings.SplitN(key, ".", 2)
+	if len(parts) == 1 {
+		m[key] = v
+		return
+	}
+
+	sub, ok := m[parts[0]].(map[string]interface{})
+	if !ok {
+		sub = make(map[string]interface{})
+		m[parts[0]] = sub
+	}
+
+	setCURUsageValue(sub, parts[1], v)
+}
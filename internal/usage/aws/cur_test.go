@@ -0,0 +1,57 @@
+package aws
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCUR(t *testing.T) {
+	report := `lineItem/UsageAccountId,lineItem/LineItemType,lineItem/UsageStartDate,lineItem/UsageEndDate,lineItem/UsageType,lineItem/UsageAmount,resourceTags/user:terraform_address
+111111111111,Usage,2023-01-01T00:00:00Z,2023-01-01T01:00:00Z,USE1-NatGateway-Bytes,10,aws_nat_gateway.main
+111111111111,Usage,2023-01-15T00:00:00Z,2023-01-16T00:00:00Z,USE1-NatGateway-Bytes,20,aws_nat_gateway.main
+111111111111,Usage,2023-01-01T00:00:00Z,2023-01-01T01:00:00Z,USE1-NatGateway-Hours,1,aws_nat_gateway.main
+111111111111,DiscountedUsage,2023-01-01T00:00:00Z,2023-01-02T00:00:00Z,TimedStorage-ByteHrs,1.5,module.logs.aws_s3_bucket.logs["eu.example.com"]
+111111111111,Tax,2023-01-01T00:00:00Z,2023-01-02T00:00:00Z,TimedStorage-ByteHrs,100,module.logs.aws_s3_bucket.logs["eu.example.com"]
+111111111111,Usage,2023-01-01T00:00:00Z,2023-01-31T00:00:00Z,Request,400,
+222222222222,Usage,2023-01-01T00:00:00Z,2023-01-31T00:00:00Z,Request,400,aws_lambda_function.api
+`
+
+	usage, err := parseCUR([]byte(report), CUROptions{AddressTag: DefaultCURAddressTag, AccountID: "111111111111"})
+	require.NoError(t, err)
+
+	// The report covers 15 days, so the usage is scaled up to a month
+	scale := 730.0 / 360
+	assert.Equal(t, map[string]interface{}{
+		"aws_nat_gateway.main": map[string]interface{}{
+			"monthly_data_processed_gb": roundCURValue(30 * scale),
+		},
+		`module.logs.aws_s3_bucket.logs["eu.example.com"]`: map[string]interface{}{
+			"standard": map[string]interface{}{
+				"storage_gb": roundCURValue(1.5 * scale),
+			},
+		},
+	}, usage)
+}
+
+func TestParseCURResourceTagsColumn(t *testing.T) {
+	report := `line_item_line_item_type,line_item_usage_type,line_item_usage_amount,resource_tags
+Usage,EU-Request,1000,"{""user_terraform_address"": ""aws_lambda_function.api""}"
+`
+
+	usage, err := parseCUR([]byte(report), CUROptions{AddressTag: DefaultCURAddressTag})
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]interface{}{
+		"aws_lambda_function.api": map[string]interface{}{
+			"monthly_requests": 1000.0,
+		},
+	}, usage)
+}
+
+func TestParseCURMissingTagColumn(t *testing.T) {
+	_, err := parseCUR([]byte("lineItem/UsageType,lineItem/UsageAmount\n"), CUROptions{AddressTag: "owner"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "no owner tag column")
+}
@@ -55,3 +55,20 @@ func DynamoDBGetRRU(ctx context.Context, region string, table string) (float64,
 func DynamoDBGetWRU(ctx context.Context, region string, table string) (float64, error) {
 	return dynamodbGetRequests(ctx, region, table, "ConsumedWriteCapacityUnits")
 }
+
+// DynamoDBGetCapacityUtilization returns the average percentage of the
+// provisioned read or write capacity units of the table that were consumed over
+// the last month, metric is ConsumedReadCapacityUnits or
+// ConsumedWriteCapacityUnits.
+func DynamoDBGetCapacityUtilization(ctx context.Context, region string, table string, metric string, provisioned int64) (float64, error) {
+	if provisioned <= 0 {
+		return 0, nil
+	}
+
+	consumed, err := dynamodbGetRequests(ctx, region, table, metric)
+	if err != nil {
+		return 0, err
+	}
+
+	return consumed / timeMonth.Seconds() / float64(provisioned) * 100, nil
+}
@@ -3,6 +3,7 @@ package aws
 import (
 	"context"
 
+	cloudwatchtypes "github.com/aws/aws-sdk-go-v2/service/cloudwatch/types"
 	"github.com/aws/aws-sdk-go-v2/service/ec2"
 	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
 	log "github.com/sirupsen/logrus"
@@ -61,3 +62,35 @@ func EC2DescribeOS(ctx context.Context, region string, ami string) (string, erro
 		return "linux", nil
 	}
 }
+
+// EC2GetCPUUtilization returns the average and maximum CPU utilization
+// percentage of the instance over the last month.
+func EC2GetCPUUtilization(ctx context.Context, region string, id string) (float64, float64, error) {
+	var average, maximum float64
+
+	for _, statistic := range []cloudwatchtypes.Statistic{statAvg, cloudwatchtypes.StatisticMaximum} {
+		log.Debugf("Querying AWS CloudWatch: AWS/EC2 CPUUtilization %s (region: %s, InstanceId: %s)", statistic, region, id)
+		stats, err := cloudwatchGetMonthlyStats(ctx, statsRequest{
+			region:     region,
+			namespace:  "AWS/EC2",
+			metric:     "CPUUtilization",
+			dimensions: map[string]string{"InstanceId": id},
+			statistic:  statistic,
+			unit:       cloudwatchtypes.StandardUnitPercent,
+		})
+		if err != nil {
+			return 0, 0, err
+		}
+		if len(stats.Datapoints) == 0 {
+			return 0, 0, nil
+		}
+
+		if statistic == statAvg {
+			average = *stats.Datapoints[0].Average
+		} else {
+			maximum = *stats.Datapoints[0].Maximum
+		}
+	}
+
+	return average, maximum, nil
+}
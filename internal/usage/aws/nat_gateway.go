@@ -0,0 +1,37 @@
+package aws
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatch/types"
+	log "github.com/sirupsen/logrus"
+)
+
+// NATGatewayGetDataProcessedBytes returns the bytes processed by the NAT gateway
+// over the last month, in both directions.
+func NATGatewayGetDataProcessedBytes(ctx context.Context, region string, id string) (float64, error) {
+	var total float64
+
+	for _, metric := range []string{"BytesInFromSource", "BytesInFromDestination"} {
+		log.Debugf("Querying AWS CloudWatch: AWS/NATGateway %s (region: %s, NatGatewayId: %s)", metric, region, id)
+		stats, err := cloudwatchGetMonthlyStats(ctx, statsRequest{
+			region:    region,
+			namespace: "AWS/NATGateway",
+			metric:    metric,
+			statistic: types.StatisticSum,
+			unit:      types.StandardUnitBytes,
+			dimensions: map[string]string{
+				"NatGatewayId": id,
+			},
+		})
+		if err != nil {
+			return 0, err
+		}
+
+		if len(stats.Datapoints) > 0 {
+			total += *stats.Datapoints[0].Sum
+		}
+	}
+
+	return total, nil
+}
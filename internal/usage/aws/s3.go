@@ -2,7 +2,9 @@
 package aws
 
 import (
+	"bytes"
 	"context"
+	"io"
 
 	"github.com/aws/aws-sdk-go-v2/service/cloudwatch/types"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
@@ -113,3 +115,44 @@ func S3GetBucketDataBytes(ctx context.Context, region string, bucket string, fil
 	}
 	return *stats.Datapoints[0].Sum, nil
 }
+
+// S3GetObject returns the contents of an object in an S3 bucket, e.g. a usage file
+// shared by a platform team. The region is taken from the AWS config of the
+// environment if it's empty.
+func S3GetObject(ctx context.Context, region string, bucket string, key string) ([]byte, error) {
+	client, err := s3NewClient(ctx, region)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Debugf("Querying AWS S3 API: GetObject(region: %s, Bucket: %s, Key: %s)", region, bucket, key)
+	result, err := client.GetObject(ctx, &s3.GetObjectInput{
+		Bucket: strPtr(bucket),
+		Key:    strPtr(key),
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer result.Body.Close()
+
+	return io.ReadAll(result.Body)
+}
+
+// S3PutObject writes the contents of an object in an S3 bucket, e.g. a usage file
+// kept up to date by usage record. The region is taken from the AWS config of the
+// environment if it's empty.
+func S3PutObject(ctx context.Context, region string, bucket string, key string, contents []byte) error {
+	client, err := s3NewClient(ctx, region)
+	if err != nil {
+		return err
+	}
+
+	log.Debugf("Querying AWS S3 API: PutObject(region: %s, Bucket: %s, Key: %s)", region, bucket, key)
+	_, err = client.PutObject(ctx, &s3.PutObjectInput{
+		Bucket: strPtr(bucket),
+		Key:    strPtr(key),
+		Body:   bytes.NewReader(contents),
+	})
+
+	return err
+}
@@ -0,0 +1,61 @@
+package azure
+
+import (
+	"context"
+	"os"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// DefaultEndpoint is the Azure Resource Manager endpoint that Azure Monitor
+// metrics are queried from.
+const DefaultEndpoint = "https://management.azure.com"
+
+type ctxConfigKeyType struct{}
+
+var ctxConfigKey = &ctxConfigKeyType{}
+
+type monitorConfig struct {
+	endpoint    string
+	accessToken string
+}
+
+// getConfig returns the endpoint and the access token used to query Azure Monitor.
+// They're read from the same environment variables as the ones used to fetch the
+// Azure price sheet.
+func getConfig(ctx context.Context) (monitorConfig, error) {
+	if cfg, ok := ctx.Value(ctxConfigKey).(monitorConfig); ok {
+		return cfg, nil
+	}
+
+	cfg := monitorConfig{
+		endpoint:    strings.TrimSuffix(os.Getenv("INFRACOST_AZURE_MANAGEMENT_API_URL"), "/"),
+		accessToken: os.Getenv("INFRACOST_AZURE_ACCESS_TOKEN"),
+	}
+
+	if cfg.endpoint == "" {
+		cfg.endpoint = DefaultEndpoint
+	}
+
+	if cfg.accessToken == "" {
+		return cfg, errors.New("INFRACOST_AZURE_ACCESS_TOKEN must be set to get usage from Azure Monitor")
+	}
+
+	return cfg, nil
+}
+
+// WithTestEndpoint returns a context that makes the Azure Monitor queries go to
+// url with a fake access token.
+func WithTestEndpoint(ctx context.Context, url string) context.Context {
+	return context.WithValue(ctx, ctxConfigKey, monitorConfig{
+		endpoint:    strings.TrimSuffix(url, "/"),
+		accessToken: "test-token",
+	})
+}
+
+// IsResourceID returns true if id is the ID of an Azure resource that exists,
+// rather than e.g. the placeholder ID of an HCL parsed resource.
+func IsResourceID(id string) bool {
+	return strings.HasPrefix(strings.ToLower(id), "/subscriptions/")
+}
@@ -0,0 +1,126 @@
+package azure
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+)
+
+const metricsAPIVersion = "2018-01-01"
+
+const timeMonth = time.Hour * 24 * 30
+
+const (
+	aggregationAverage = "Average"
+	aggregationTotal   = "Total"
+)
+
+type metricsResponse struct {
+	Value []struct {
+		Timeseries []struct {
+			Data []struct {
+				Average *float64 `json:"average"`
+				Total   *float64 `json:"total"`
+			} `json:"data"`
+		} `json:"timeseries"`
+	} `json:"value"`
+}
+
+// monitorGetMonthlyMetric returns the daily values of the metric of the resource
+// over the last month, summed for totals and averaged for averages.
+func monitorGetMonthlyMetric(ctx context.Context, resourceID string, metric string, aggregation string) (float64, error) {
+	cfg, err := getConfig(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	end := time.Now().UTC()
+	start := end.Add(-timeMonth)
+
+	q := url.Values{}
+	q.Set("api-version", metricsAPIVersion)
+	q.Set("metricnames", metric)
+	q.Set("aggregation", aggregation)
+	q.Set("interval", "P1D")
+	q.Set("timespan", fmt.Sprintf("%s/%s", start.Format(time.RFC3339), end.Format(time.RFC3339)))
+
+	u := cfg.endpoint + "/" + strings.TrimPrefix(resourceID, "/") + "/providers/Microsoft.Insights/metrics?" + q.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, "GET", u, nil)
+	if err != nil {
+		return 0, errors.Wrap(err, "Error generating Azure Monitor request")
+	}
+
+	req.Header.Set("Authorization", "Bearer "+cfg.accessToken)
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return 0, errors.Wrap(err, "Error querying Azure Monitor")
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return 0, fmt.Errorf("Error querying Azure Monitor: %s %s", resp.Status, strings.TrimSpace(string(body)))
+	}
+
+	var r metricsResponse
+	err = json.NewDecoder(resp.Body).Decode(&r)
+	if err != nil {
+		return 0, errors.Wrap(err, "Error parsing Azure Monitor response")
+	}
+
+	var sum float64
+	var count int
+
+	for _, v := range r.Value {
+		for _, ts := range v.Timeseries {
+			for _, d := range ts.Data {
+				p := d.Total
+				if aggregation == aggregationAverage {
+					p = d.Average
+				}
+
+				if p != nil {
+					sum += *p
+					count++
+				}
+			}
+		}
+	}
+
+	if aggregation == aggregationAverage && count > 0 {
+		return sum / float64(count), nil
+	}
+
+	return sum, nil
+}
+
+// StorageAccountGetUsedCapacityBytes returns the average bytes stored in the
+// storage account over the last month.
+func StorageAccountGetUsedCapacityBytes(ctx context.Context, id string) (float64, error) {
+	log.Debugf("Querying Azure Monitor: UsedCapacity (resource: %s)", id)
+	return monitorGetMonthlyMetric(ctx, id, "UsedCapacity", aggregationAverage)
+}
+
+// FunctionAppGetExecutions returns the number of executions of the function app
+// over the last month.
+func FunctionAppGetExecutions(ctx context.Context, id string) (float64, error) {
+	log.Debugf("Querying Azure Monitor: FunctionExecutionCount (resource: %s)", id)
+	return monitorGetMonthlyMetric(ctx, id, "FunctionExecutionCount", aggregationTotal)
+}
+
+// VirtualNetworkGatewayGetEgressBytes returns the bytes sent through the tunnels
+// of the virtual network gateway over the last month.
+func VirtualNetworkGatewayGetEgressBytes(ctx context.Context, id string) (float64, error) {
+	log.Debugf("Querying Azure Monitor: TunnelEgressBytes (resource: %s)", id)
+	return monitorGetMonthlyMetric(ctx, id, "TunnelEgressBytes", aggregationTotal)
+}
@@ -0,0 +1,60 @@
+package azure
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const storageAccountID = "/subscriptions/sub/resourceGroups/rg/providers/Microsoft.Storage/storageAccounts/account"
+
+func TestStorageAccountGetUsedCapacityBytes(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, storageAccountID+"/providers/Microsoft.Insights/metrics", r.URL.Path)
+		assert.Equal(t, "UsedCapacity", r.URL.Query().Get("metricnames"))
+		assert.Equal(t, "Average", r.URL.Query().Get("aggregation"))
+		assert.Equal(t, "Bearer test-token", r.Header.Get("Authorization"))
+
+		_, _ = w.Write([]byte(`{"value":[{"timeseries":[{"data":[{"average":1000},{"average":3000},{}]}]}]}`))
+	}))
+	defer server.Close()
+
+	bytes, err := StorageAccountGetUsedCapacityBytes(WithTestEndpoint(context.TODO(), server.URL), storageAccountID)
+	require.NoError(t, err)
+	assert.Equal(t, 2000.0, bytes)
+}
+
+func TestFunctionAppGetExecutions(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Total", r.URL.Query().Get("aggregation"))
+
+		_, _ = w.Write([]byte(`{"value":[{"timeseries":[{"data":[{"total":100},{"total":250}]}]}]}`))
+	}))
+	defer server.Close()
+
+	executions, err := FunctionAppGetExecutions(WithTestEndpoint(context.TODO(), server.URL), "/subscriptions/sub/resourceGroups/rg/providers/Microsoft.Web/sites/fn")
+	require.NoError(t, err)
+	assert.Equal(t, 350.0, executions)
+}
+
+func TestMonitorError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte(`{"error":{"code":"AuthorizationFailed"}}`))
+	}))
+	defer server.Close()
+
+	_, err := StorageAccountGetUsedCapacityBytes(WithTestEndpoint(context.TODO(), server.URL), storageAccountID)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "AuthorizationFailed")
+}
+
+func TestIsResourceID(t *testing.T) {
+	assert.True(t, IsResourceID(storageAccountID))
+	assert.False(t, IsResourceID("9b2a4c8e-hcl-placeholder"))
+	assert.False(t, IsResourceID(""))
+}
@@ -0,0 +1,126 @@
+package google
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+)
+
+// The project and location are part of the name of the INFORMATION_SCHEMA view
+// so they can't be passed as query parameters.
+var (
+	bigQueryProjectRegex  = regexp.MustCompile(`^[A-Za-z0-9:.-]+$`)
+	bigQueryLocationRegex = regexp.MustCompile(`^[A-Za-z0-9-]+$`)
+)
+
+type queryRequest struct {
+	Query           string           `json:"query"`
+	UseLegacySQL    bool             `json:"useLegacySql"`
+	ParameterMode   string           `json:"parameterMode"`
+	QueryParameters []queryParameter `json:"queryParameters"`
+}
+
+type queryParameter struct {
+	Name          string `json:"name"`
+	ParameterType struct {
+		Type string `json:"type"`
+	} `json:"parameterType"`
+	ParameterValue struct {
+		Value string `json:"value"`
+	} `json:"parameterValue"`
+}
+
+type queryResponse struct {
+	JobComplete bool `json:"jobComplete"`
+	Rows        []struct {
+		F []struct {
+			V *string `json:"v"`
+		} `json:"f"`
+	} `json:"rows"`
+}
+
+// BigQueryGetScannedTB returns the TB billed for the on-demand queries of the
+// project over the last month that read the dataset, from the jobs in
+// INFORMATION_SCHEMA.JOBS_BY_PROJECT of its location, e.g. US. The bytes of
+// queries that read more than one dataset are split evenly between them, so
+// the datasets of a project aren't each billed for the same queries.
+func BigQueryGetScannedTB(ctx context.Context, project string, location string, dataset string) (float64, error) {
+	if !bigQueryProjectRegex.MatchString(project) {
+		return 0, fmt.Errorf("Invalid BigQuery project %q", project)
+	}
+
+	if !bigQueryLocationRegex.MatchString(location) {
+		return 0, fmt.Errorf("Invalid BigQuery location %q", location)
+	}
+
+	log.Debugf("Querying BigQuery jobs: bytes billed (project: %s, dataset: %s)", project, dataset)
+
+	query := fmt.Sprintf("SELECT SUM(total_bytes_billed / "+
+		"(SELECT COUNT(DISTINCT CONCAT(t.project_id, '.', t.dataset_id)) FROM UNNEST(referenced_tables) t)) / POW(1024, 4) "+
+		"FROM `%s`.`region-%s`.INFORMATION_SCHEMA.JOBS_BY_PROJECT "+
+		"WHERE job_type = 'QUERY' AND creation_time >= TIMESTAMP_SUB(CURRENT_TIMESTAMP(), INTERVAL 30 DAY) "+
+		"AND EXISTS (SELECT 1 FROM UNNEST(referenced_tables) t WHERE t.project_id = @project AND t.dataset_id = @dataset)",
+		project, strings.ToLower(location))
+
+	return runQuery(ctx, project, query, []queryParameter{
+		stringParameter("project", project),
+		stringParameter("dataset", dataset),
+	})
+}
+
+// runQuery runs the query as a job of the project and returns the number in the
+// first column of its first row, or 0 if it has no rows.
+func runQuery(ctx context.Context, project string, query string, params []queryParameter) (float64, error) {
+	cfg, err := getConfig(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	body, err := json.Marshal(queryRequest{
+		Query:           query,
+		ParameterMode:   "NAMED",
+		QueryParameters: params,
+	})
+	if err != nil {
+		return 0, errors.Wrap(err, "Error marshaling BigQuery query")
+	}
+
+	u := fmt.Sprintf("%s/bigquery/v2/projects/%s/queries", cfg.bigQueryEndpoint, url.PathEscape(project))
+
+	var r queryResponse
+	err = doRequest(ctx, cfg, "POST", u, bytes.NewReader(body), &r)
+	if err != nil {
+		return 0, err
+	}
+
+	if !r.JobComplete {
+		return 0, errors.New("BigQuery query didn't complete in time")
+	}
+
+	if len(r.Rows) == 0 || len(r.Rows[0].F) == 0 || r.Rows[0].F[0].V == nil {
+		return 0, nil
+	}
+
+	v, err := strconv.ParseFloat(*r.Rows[0].F[0].V, 64)
+	if err != nil {
+		return 0, errors.Wrap(err, "Error parsing BigQuery query result")
+	}
+
+	return v, nil
+}
+
+func stringParameter(name, value string) queryParameter {
+	p := queryParameter{Name: name}
+	p.ParameterType.Type = "STRING"
+	p.ParameterValue.Value = value
+
+	return p
+}
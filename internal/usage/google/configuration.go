@@ -0,0 +1,89 @@
+package google
+
+import (
+	"context"
+	"os"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// DefaultMonitoringEndpoint is the Cloud Monitoring API endpoint.
+	DefaultMonitoringEndpoint = "https://monitoring.googleapis.com"
+	// DefaultBigQueryEndpoint is the BigQuery API endpoint the jobs of datasets
+	// are queried from.
+	DefaultBigQueryEndpoint = "https://bigquery.googleapis.com"
+	// DefaultStorageEndpoint is the Cloud Storage API endpoint remote usage files
+	// are downloaded from.
+	DefaultStorageEndpoint = "https://storage.googleapis.com"
+)
+
+type ctxConfigKeyType struct{}
+
+var ctxConfigKey = &ctxConfigKeyType{}
+
+type gcpConfig struct {
+	monitoringEndpoint string
+	bigQueryEndpoint   string
+	storageEndpoint    string
+	accessToken        string
+}
+
+// getConfig returns the endpoints and the access token used to get usage from
+// GCP. They're read from the environment, e.g. the access token can be set with
+// INFRACOST_GCP_ACCESS_TOKEN=$(gcloud auth print-access-token).
+func getConfig(ctx context.Context) (gcpConfig, error) {
+	cfg, ok := ctx.Value(ctxConfigKey).(gcpConfig)
+	if !ok {
+		cfg = gcpConfig{
+			monitoringEndpoint: DefaultMonitoringEndpoint,
+			bigQueryEndpoint:   DefaultBigQueryEndpoint,
+			storageEndpoint:    DefaultStorageEndpoint,
+			accessToken:        os.Getenv("INFRACOST_GCP_ACCESS_TOKEN"),
+		}
+	}
+
+	if cfg.accessToken == "" {
+		return cfg, errors.New("INFRACOST_GCP_ACCESS_TOKEN must be set to get usage from GCP")
+	}
+
+	return cfg, nil
+}
+
+// WithTestEndpoint returns a context that makes the GCP queries go to url with a
+// fake access token.
+func WithTestEndpoint(ctx context.Context, url string) context.Context {
+	url = strings.TrimSuffix(url, "/")
+
+	return context.WithValue(ctx, ctxConfigKey, gcpConfig{
+		monitoringEndpoint: url,
+		bigQueryEndpoint:   url,
+		storageEndpoint:    url,
+		accessToken:        "test-token",
+	})
+}
+
+// DefaultProject returns the project of resources that don't set one, from the
+// same environment variables as the Google Terraform provider.
+func DefaultProject() string {
+	for _, k := range []string{"GOOGLE_PROJECT", "GOOGLE_CLOUD_PROJECT", "GCLOUD_PROJECT", "CLOUDSDK_CORE_PROJECT"} {
+		if v := os.Getenv(k); v != "" {
+			return v
+		}
+	}
+
+	return ""
+}
+
+// ProjectFromID returns the project of a resource ID in the format
+// projects/{project}/..., or an empty string if id isn't in that format, e.g.
+// it's the placeholder ID of an HCL parsed resource.
+func ProjectFromID(id string) string {
+	parts := strings.Split(id, "/")
+	if len(parts) < 4 || parts[0] != "projects" || parts[1] == "" {
+		return ""
+	}
+
+	return parts[1]
+}
@@ -0,0 +1,117 @@
+package google
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStorageBucketGetSizeBytes(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v3/projects/my-project/timeSeries", r.URL.Path)
+		assert.Contains(t, r.URL.Query().Get("filter"), `metric.type="storage.googleapis.com/storage/total_bytes"`)
+		assert.Contains(t, r.URL.Query().Get("filter"), `resource.labels.bucket_name="my-bucket"`)
+		assert.Equal(t, "ALIGN_MEAN", r.URL.Query().Get("aggregation.perSeriesAligner"))
+		assert.Equal(t, "Bearer test-token", r.Header.Get("Authorization"))
+
+		// One time series per storage class
+		_, _ = w.Write([]byte(`{"timeSeries":[
+			{"points":[{"value":{"doubleValue":1000}},{"value":{"doubleValue":3000}}]},
+			{"points":[{"value":{"doubleValue":500}}]}
+		]}`))
+	}))
+	defer server.Close()
+
+	bytes, err := StorageBucketGetSizeBytes(WithTestEndpoint(context.TODO(), server.URL), "my-project", "my-bucket")
+	require.NoError(t, err)
+	assert.Equal(t, 2500.0, bytes)
+}
+
+func TestStorageGetObject(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/storage/v1/b/my-bucket/o/usage%2Forg.yml", r.URL.EscapedPath())
+		assert.Equal(t, "media", r.URL.Query().Get("alt"))
+		assert.Equal(t, "Bearer test-token", r.Header.Get("Authorization"))
+
+		_, _ = w.Write([]byte("version: 0.1\n"))
+	}))
+	defer server.Close()
+
+	b, err := StorageGetObject(WithTestEndpoint(context.TODO(), server.URL), "my-bucket", "usage/org.yml")
+	require.NoError(t, err)
+	assert.Equal(t, "version: 0.1\n", string(b))
+}
+
+func TestStoragePutObject(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/upload/storage/v1/b/my-bucket/o", r.URL.Path)
+		assert.Equal(t, "usage/org.yml", r.URL.Query().Get("name"))
+		assert.Equal(t, "Bearer test-token", r.Header.Get("Authorization"))
+
+		b, _ := io.ReadAll(r.Body)
+		assert.Equal(t, "version: 0.1\n", string(b))
+
+		_, _ = w.Write([]byte("{}"))
+	}))
+	defer server.Close()
+
+	err := StoragePutObject(WithTestEndpoint(context.TODO(), server.URL), "my-bucket", "usage/org.yml", []byte("version: 0.1\n"))
+	require.NoError(t, err)
+}
+
+func TestCloudFunctionsGetExecutionsPaged(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "ALIGN_SUM", r.URL.Query().Get("aggregation.perSeriesAligner"))
+
+		if r.URL.Query().Get("pageToken") == "" {
+			_, _ = w.Write([]byte(`{"timeSeries":[{"points":[{"value":{"int64Value":"100"}}]}],"nextPageToken":"next"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"timeSeries":[{"points":[{"value":{"int64Value":"250"}}]}]}`))
+	}))
+	defer server.Close()
+
+	executions, err := CloudFunctionsGetExecutions(WithTestEndpoint(context.TODO(), server.URL), "my-project", "fn")
+	require.NoError(t, err)
+	assert.Equal(t, 350.0, executions)
+}
+
+func TestBigQueryGetScannedTB(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "POST", r.Method)
+		assert.Equal(t, "/bigquery/v2/projects/my-project/queries", r.URL.Path)
+
+		var req queryRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		assert.Contains(t, req.Query, "FROM `my-project`.`region-eu`.INFORMATION_SCHEMA.JOBS_BY_PROJECT")
+		assert.Equal(t, "my-project", req.QueryParameters[0].ParameterValue.Value)
+		assert.Equal(t, "my_dataset", req.QueryParameters[1].ParameterValue.Value)
+
+		_, _ = w.Write([]byte(`{"jobComplete":true,"rows":[{"f":[{"v":"12.5"}]}]}`))
+	}))
+	defer server.Close()
+
+	tb, err := BigQueryGetScannedTB(WithTestEndpoint(context.TODO(), server.URL), "my-project", "EU", "my_dataset")
+	require.NoError(t, err)
+	assert.Equal(t, 12.5, tb)
+}
+
+func TestBigQueryGetScannedTBInvalidLocation(t *testing.T) {
+	_, err := BigQueryGetScannedTB(WithTestEndpoint(context.TODO(), "http://localhost"), "my-project", "US`.x --", "my_dataset")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "Invalid BigQuery location")
+}
+
+func TestProjectFromID(t *testing.T) {
+	assert.Equal(t, "my-project", ProjectFromID("projects/my-project/locations/us-central1/functions/fn"))
+	assert.Equal(t, "my-project", ProjectFromID("projects/my-project/datasets/my_dataset"))
+	assert.Equal(t, "", ProjectFromID("9b2a4c8e-0d1f-4c7a-a5b2-3e4f5a6b7c8d"))
+	assert.Equal(t, "", ProjectFromID("projects/my-project"))
+}
@@ -0,0 +1,149 @@
+package google
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+)
+
+const timeMonth = time.Hour * 24 * 30
+
+const (
+	alignSum  = "ALIGN_SUM"
+	alignMean = "ALIGN_MEAN"
+)
+
+type timeSeriesResponse struct {
+	TimeSeries []struct {
+		Points []struct {
+			Value struct {
+				Int64Value  *string  `json:"int64Value"`
+				DoubleValue *float64 `json:"doubleValue"`
+			} `json:"value"`
+		} `json:"points"`
+	} `json:"timeSeries"`
+	NextPageToken string `json:"nextPageToken"`
+}
+
+// monitoringGetMonthlyMetric returns the daily values of the metric over the last
+// month for the resources matching the labels. Sums are added up and means are
+// averaged per time series, e.g. per storage class, then added up.
+func monitoringGetMonthlyMetric(ctx context.Context, project string, metric string, labels map[string]string, aligner string) (float64, error) {
+	cfg, err := getConfig(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	filters := []string{fmt.Sprintf("metric.type=%q", metric)}
+	for k, v := range labels {
+		filters = append(filters, fmt.Sprintf("resource.labels.%s=%q", k, v))
+	}
+
+	end := time.Now().UTC()
+	start := end.Add(-timeMonth)
+
+	q := url.Values{}
+	q.Set("filter", strings.Join(filters, " AND "))
+	q.Set("interval.startTime", start.Format(time.RFC3339))
+	q.Set("interval.endTime", end.Format(time.RFC3339))
+	q.Set("aggregation.alignmentPeriod", "86400s")
+	q.Set("aggregation.perSeriesAligner", aligner)
+
+	var total float64
+
+	for {
+		u := fmt.Sprintf("%s/v3/projects/%s/timeSeries?%s", cfg.monitoringEndpoint, url.PathEscape(project), q.Encode())
+
+		var r timeSeriesResponse
+		err := doRequest(ctx, cfg, "GET", u, nil, &r)
+		if err != nil {
+			return 0, err
+		}
+
+		for _, ts := range r.TimeSeries {
+			var sum float64
+			for _, p := range ts.Points {
+				switch {
+				case p.Value.DoubleValue != nil:
+					sum += *p.Value.DoubleValue
+				case p.Value.Int64Value != nil:
+					v, _ := strconv.ParseFloat(*p.Value.Int64Value, 64)
+					sum += v
+				}
+			}
+
+			if aligner == alignMean && len(ts.Points) > 0 {
+				sum /= float64(len(ts.Points))
+			}
+
+			total += sum
+		}
+
+		if r.NextPageToken == "" {
+			break
+		}
+		q.Set("pageToken", r.NextPageToken)
+	}
+
+	return total, nil
+}
+
+func doRequest(ctx context.Context, cfg gcpConfig, method string, u string, body io.Reader, v interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, method, u, body)
+	if err != nil {
+		return errors.Wrap(err, "Error generating GCP request")
+	}
+
+	req.Header.Set("Authorization", "Bearer "+cfg.accessToken)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "Error querying GCP")
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		b, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("Error querying GCP: %s %s", resp.Status, strings.TrimSpace(string(b)))
+	}
+
+	err = json.NewDecoder(resp.Body).Decode(v)
+	if err != nil {
+		return errors.Wrap(err, "Error parsing GCP response")
+	}
+
+	return nil
+}
+
+// StorageBucketGetSizeBytes returns the average bytes stored in the bucket over
+// the last month.
+func StorageBucketGetSizeBytes(ctx context.Context, project string, bucket string) (float64, error) {
+	log.Debugf("Querying Google Cloud Monitoring: storage/total_bytes (project: %s, bucket_name: %s)", project, bucket)
+	return monitoringGetMonthlyMetric(ctx, project, "storage.googleapis.com/storage/total_bytes", map[string]string{"bucket_name": bucket}, alignMean)
+}
+
+// CloudFunctionsGetExecutions returns the number of executions of the function
+// over the last month.
+func CloudFunctionsGetExecutions(ctx context.Context, project string, fn string) (float64, error) {
+	log.Debugf("Querying Google Cloud Monitoring: function/execution_count (project: %s, function_name: %s)", project, fn)
+	return monitoringGetMonthlyMetric(ctx, project, "cloudfunctions.googleapis.com/function/execution_count", map[string]string{"function_name": fn}, alignSum)
+}
+
+// CloudFunctionsGetEgressBytes returns the bytes sent by the function over the
+// last month.
+func CloudFunctionsGetEgressBytes(ctx context.Context, project string, fn string) (float64, error) {
+	log.Debugf("Querying Google Cloud Monitoring: function/network_egress (project: %s, function_name: %s)", project, fn)
+	return monitoringGetMonthlyMetric(ctx, project, "cloudfunctions.googleapis.com/function/network_egress", map[string]string{"function_name": fn}, alignSum)
+}
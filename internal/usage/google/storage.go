@@ -0,0 +1,80 @@
+package google
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// StorageGetObject returns the contents of an object in a Cloud Storage bucket,
+// e.g. a usage file shared by a platform team.
+func StorageGetObject(ctx context.Context, bucket string, object string) ([]byte, error) {
+	cfg, err := getConfig(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	u := fmt.Sprintf("%s/storage/v1/b/%s/o/%s?alt=media", cfg.storageEndpoint, url.PathEscape(bucket), url.PathEscape(object))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error generating GCP request")
+	}
+
+	req.Header.Set("Authorization", "Bearer "+cfg.accessToken)
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error querying GCP")
+	}
+	defer resp.Body.Close()
+
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error reading GCP response")
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("Error querying GCP: %s %s", resp.Status, strings.TrimSpace(string(b)))
+	}
+
+	return b, nil
+}
+
+// StoragePutObject writes the contents of an object in a Cloud Storage bucket,
+// e.g. a usage file kept up to date by usage record.
+func StoragePutObject(ctx context.Context, bucket string, object string, contents []byte) error {
+	cfg, err := getConfig(ctx)
+	if err != nil {
+		return err
+	}
+
+	u := fmt.Sprintf("%s/upload/storage/v1/b/%s/o?uploadType=media&name=%s", cfg.storageEndpoint, url.PathEscape(bucket), url.QueryEscape(object))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, u, bytes.NewReader(contents))
+	if err != nil {
+		return errors.Wrap(err, "Error generating GCP request")
+	}
+
+	req.Header.Set("Authorization", "Bearer "+cfg.accessToken)
+	req.Header.Set("Content-Type", "application/x-yaml")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "Error querying GCP")
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		b, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("Error querying GCP: %s %s", resp.Status, strings.TrimSpace(string(b)))
+	}
+
+	return nil
+}
@@ -0,0 +1,325 @@
+package kubernetes
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+)
+
+// DefaultMetricsWindow is how far back the utilization is averaged over when
+// it's read from Prometheus.
+const DefaultMetricsWindow = "7d"
+
+// MetricsOptions changes which pods the utilization is read for and which
+// resource it's set on.
+type MetricsOptions struct {
+	// ClusterAddress is the Terraform address of the cluster the pods run on,
+	// e.g. google_container_cluster.autopilot.
+	ClusterAddress string
+	// Namespace only uses the pods of the namespace if it's set.
+	Namespace string
+	// Window is how far back the utilization is averaged over in Prometheus,
+	// e.g. 7d. It defaults to DefaultMetricsWindow.
+	Window string
+}
+
+// clusterUsageKeys are the usage keys set from the CPU and memory utilization of
+// the pods for each resource type. They're the resources whose cost is based on
+// the CPU and memory requested by pods rather than their nodes.
+var clusterUsageKeys = map[string]struct {
+	vcpu     string
+	memoryGB string
+}{
+	"google_container_cluster": {"autopilot_vcpu_count", "autopilot_memory_gb"},
+}
+
+var metricsClient = &http.Client{}
+
+// PrometheusGetUsage queries a Prometheus endpoint for the average CPU and memory
+// utilization of the cluster's containers over the window, from the cAdvisor
+// metrics, and returns them as the usage of the cluster.
+func PrometheusGetUsage(ctx context.Context, endpoint string, opts MetricsOptions) (map[string]interface{}, error) {
+	if err := validateClusterAddress(opts.ClusterAddress); err != nil {
+		return nil, err
+	}
+
+	if opts.Window == "" {
+		opts.Window = DefaultMetricsWindow
+	}
+
+	selector := `container!="",container!="POD"`
+	if opts.Namespace != "" {
+		selector += fmt.Sprintf(`,namespace=%q`, opts.Namespace)
+	}
+
+	cpu, err := prometheusQuery(ctx, endpoint, fmt.Sprintf(`avg_over_time(sum(rate(container_cpu_usage_seconds_total{%s}[5m]))[%s:5m])`, selector, opts.Window))
+	if err != nil {
+		return nil, err
+	}
+
+	memory, err := prometheusQuery(ctx, endpoint, fmt.Sprintf(`avg_over_time(sum(container_memory_working_set_bytes{%s})[%s:5m])`, selector, opts.Window))
+	if err != nil {
+		return nil, err
+	}
+
+	return clusterUsage(opts.ClusterAddress, cpu, memory), nil
+}
+
+type prometheusResponse struct {
+	Status string `json:"status"`
+	Error  string `json:"error"`
+	Data   struct {
+		ResultType string `json:"resultType"`
+		Result     []struct {
+			Value []interface{} `json:"value"`
+		} `json:"result"`
+	} `json:"data"`
+}
+
+// prometheusQuery runs an instant query that returns a single value, returning
+// 0 if there's no data.
+func prometheusQuery(ctx context.Context, endpoint string, query string) (float64, error) {
+	u := fmt.Sprintf("%s/api/v1/query?query=%s", strings.TrimSuffix(endpoint, "/"), url.QueryEscape(query))
+
+	log.Debugf("Querying Prometheus: %s", query)
+
+	b, err := metricsGet(ctx, u)
+	if err != nil {
+		return 0, errors.Wrap(err, "Error querying Prometheus")
+	}
+
+	var resp prometheusResponse
+	err = json.Unmarshal(b, &resp)
+	if err != nil {
+		return 0, errors.Wrap(err, "Error parsing Prometheus response")
+	}
+
+	if resp.Status != "success" {
+		return 0, fmt.Errorf("Error querying Prometheus: %s", resp.Error)
+	}
+
+	if len(resp.Data.Result) == 0 {
+		return 0, nil
+	}
+
+	value := resp.Data.Result[0].Value
+	if len(value) != 2 {
+		return 0, errors.New("Error parsing Prometheus response: unexpected value")
+	}
+
+	s, _ := value[1].(string)
+	v, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0, errors.Wrapf(err, "Error parsing Prometheus value %s", s)
+	}
+
+	return v, nil
+}
+
+// MetricsServerGetUsage reads the current CPU and memory utilization of the
+// cluster's pods from the metrics API served by metrics-server, e.g. through
+// kubectl proxy, and returns them as the usage of the cluster. Since
+// metrics-server only has the latest utilization it should be read when the
+// cluster is under its usual load.
+func MetricsServerGetUsage(ctx context.Context, endpoint string, opts MetricsOptions) (map[string]interface{}, error) {
+	if err := validateClusterAddress(opts.ClusterAddress); err != nil {
+		return nil, err
+	}
+
+	path := "/apis/metrics.k8s.io/v1beta1/pods"
+	if opts.Namespace != "" {
+		path = fmt.Sprintf("/apis/metrics.k8s.io/v1beta1/namespaces/%s/pods", url.PathEscape(opts.Namespace))
+	}
+
+	log.Debugf("Querying metrics-server: %s", path)
+
+	b, err := metricsGet(ctx, strings.TrimSuffix(endpoint, "/")+path)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error querying metrics-server")
+	}
+
+	cpu, memory, err := parsePodMetrics(b)
+	if err != nil {
+		return nil, err
+	}
+
+	return clusterUsage(opts.ClusterAddress, cpu, memory), nil
+}
+
+type podMetricsList struct {
+	Items []struct {
+		Containers []struct {
+			Usage map[string]string `json:"usage"`
+		} `json:"containers"`
+	} `json:"items"`
+}
+
+// parsePodMetrics returns the total vCPUs and bytes of memory used by the pods
+// in a PodMetricsList.
+func parsePodMetrics(b []byte) (float64, float64, error) {
+	var list podMetricsList
+	err := json.Unmarshal(b, &list)
+	if err != nil {
+		return 0, 0, errors.Wrap(err, "Error parsing metrics-server response")
+	}
+
+	var cpu, memory float64
+
+	for _, pod := range list.Items {
+		for _, c := range pod.Containers {
+			v, err := parseQuantity(c.Usage["cpu"])
+			if err != nil {
+				return 0, 0, err
+			}
+			cpu += v
+
+			v, err = parseQuantity(c.Usage["memory"])
+			if err != nil {
+				return 0, 0, err
+			}
+			memory += v
+		}
+	}
+
+	return cpu, memory, nil
+}
+
+// quantitySuffixes are the multipliers of the suffixes of Kubernetes resource
+// quantities, with the two letter binary suffixes first so they match before the
+// decimal ones.
+var quantitySuffixes = []struct {
+	suffix     string
+	multiplier float64
+}{
+	{"Ki", 1 << 10},
+	{"Mi", 1 << 20},
+	{"Gi", 1 << 30},
+	{"Ti", 1 << 40},
+	{"Pi", 1 << 50},
+	{"Ei", 1 << 60},
+	{"n", 1e-9},
+	{"u", 1e-6},
+	{"m", 1e-3},
+	{"k", 1e3},
+	{"M", 1e6},
+	{"G", 1e9},
+	{"T", 1e12},
+	{"P", 1e15},
+	{"E", 1e18},
+}
+
+// parseQuantity parses a Kubernetes resource quantity, e.g. 250m vCPUs or 128Mi
+// of memory.
+func parseQuantity(s string) (float64, error) {
+	if s == "" {
+		return 0, nil
+	}
+
+	multiplier := 1.0
+	number := s
+
+	for _, q := range quantitySuffixes {
+		if strings.HasSuffix(s, q.suffix) {
+			multiplier = q.multiplier
+			number = strings.TrimSuffix(s, q.suffix)
+			break
+		}
+	}
+
+	v, err := strconv.ParseFloat(number, 64)
+	if err != nil {
+		return 0, fmt.Errorf("Invalid resource quantity %s", s)
+	}
+
+	return v * multiplier, nil
+}
+
+func metricsGet(ctx context.Context, u string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// The token of a service account that can read the metrics, if the endpoint
+	// isn't already authenticated, e.g. by kubectl proxy
+	if token := os.Getenv("INFRACOST_KUBERNETES_TOKEN"); token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	resp, err := metricsClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s %s", resp.Status, strings.TrimSpace(string(b)))
+	}
+
+	return b, nil
+}
+
+func validateClusterAddress(address string) error {
+	if address == "" {
+		return errors.New("The Terraform address of the cluster must be set to get its usage from Kubernetes metrics")
+	}
+
+	if _, ok := clusterUsageKeys[resourceType(address)]; !ok {
+		types := make([]string, 0, len(clusterUsageKeys))
+		for t := range clusterUsageKeys {
+			types = append(types, t)
+		}
+		sort.Strings(types)
+
+		return fmt.Errorf("Getting usage from Kubernetes metrics is not supported for %s, it must be one of: %s", address, strings.Join(types, ", "))
+	}
+
+	return nil
+}
+
+// resourceType returns the resource type of a Terraform address, e.g.
+// google_container_cluster for module.gke.google_container_cluster.main[0].
+func resourceType(address string) string {
+	parts := strings.Split(address, ".")
+	for i := 0; i+1 < len(parts); i++ {
+		if parts[i] == "module" {
+			i++
+			continue
+		}
+
+		return parts[i]
+	}
+
+	return ""
+}
+
+func clusterUsage(address string, cpu float64, memoryBytes float64) map[string]interface{} {
+	keys := clusterUsageKeys[resourceType(address)]
+
+	return map[string]interface{}{
+		address: map[string]interface{}{
+			keys.vcpu:     roundMetricValue(cpu),
+			keys.memoryGB: roundMetricValue(memoryBytes / (1 << 30)),
+		},
+	}
+}
+
+func roundMetricValue(v float64) float64 {
+	return math.Round(v*100) / 100
+}
@@ -0,0 +1,93 @@
+package kubernetes
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrometheusGetUsage(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v1/query", r.URL.Path)
+
+		query := r.URL.Query().Get("query")
+		assert.Contains(t, query, `namespace="shop"`)
+		assert.Contains(t, query, "[7d:5m]")
+
+		if strings.Contains(query, "container_cpu_usage_seconds_total") {
+			_, _ = w.Write([]byte(`{"status":"success","data":{"resultType":"vector","result":[{"metric":{},"value":[1700000000,"3.456"]}]}}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"status":"success","data":{"resultType":"vector","result":[{"metric":{},"value":[1700000000,"5368709120"]}]}}`))
+	}))
+	defer server.Close()
+
+	usage, err := PrometheusGetUsage(context.TODO(), server.URL, MetricsOptions{
+		ClusterAddress: "module.gke.google_container_cluster.autopilot",
+		Namespace:      "shop",
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]interface{}{
+		"module.gke.google_container_cluster.autopilot": map[string]interface{}{
+			"autopilot_vcpu_count": 3.46,
+			"autopilot_memory_gb":  5.0,
+		},
+	}, usage)
+}
+
+func TestMetricsServerGetUsage(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/apis/metrics.k8s.io/v1beta1/pods", r.URL.Path)
+
+		_, _ = w.Write([]byte(`{"kind":"PodMetricsList","items":[
+			{"containers":[{"usage":{"cpu":"250m","memory":"512Mi"}},{"usage":{"cpu":"500000000n","memory":"1Gi"}}]},
+			{"containers":[{"usage":{"cpu":"1","memory":"524288Ki"}}]}
+		]}`))
+	}))
+	defer server.Close()
+
+	usage, err := MetricsServerGetUsage(context.TODO(), server.URL, MetricsOptions{ClusterAddress: "google_container_cluster.autopilot"})
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]interface{}{
+		"google_container_cluster.autopilot": map[string]interface{}{
+			"autopilot_vcpu_count": 1.75,
+			"autopilot_memory_gb":  2.0,
+		},
+	}, usage)
+}
+
+func TestMetricsUnsupportedCluster(t *testing.T) {
+	_, err := MetricsServerGetUsage(context.TODO(), "http://localhost", MetricsOptions{ClusterAddress: "aws_eks_cluster.main"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "not supported for aws_eks_cluster.main")
+}
+
+func TestParseQuantity(t *testing.T) {
+	tests := []struct {
+		quantity string
+		expected float64
+	}{
+		{"", 0},
+		{"2", 2},
+		{"250m", 0.25},
+		{"1500u", 0.0015},
+		{"128Mi", 128 * 1024 * 1024},
+		{"1G", 1e9},
+	}
+
+	for _, tt := range tests {
+		v, err := parseQuantity(tt.quantity)
+		require.NoError(t, err)
+		assert.InDelta(t, tt.expected, v, 1e-9, tt.quantity)
+	}
+
+	_, err := parseQuantity("lots")
+	assert.Error(t, err)
+}
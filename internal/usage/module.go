@@ -0,0 +1,139 @@
+package usage
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/infracost/infracost/internal/hcl/modules"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+// ModuleUsageFileName is the name of the usage file a module can ship alongside
+// its Terraform files with the recommended usage of its resources.
+const ModuleUsageFileName = "infracost-usage.yml"
+
+// moduleManifestPaths are the module manifests, relative to the project, that
+// list where the modules of a project are, written by parsing the HCL or by
+// terraform init.
+var moduleManifestPaths = []string{
+	".infracost/terraform_modules/manifest.json",
+	".terraform/modules/modules.json",
+}
+
+// LoadModuleUsageFiles loads the usage files shipped with the modules of the
+// Terraform project at projectPath and merges them, with their keys prefixed by
+// the address of their module. For example aws_lambda_function.this in the usage
+// file of module api sets the usage of module.api.aws_lambda_function.this, and
+// the resource type aws_lambda_function sets the usage of all the Lambda functions
+// in module.api. The usage files of modules override the ones of their child
+// modules. It returns nil if none of the modules ship a usage file.
+func LoadModuleUsageFiles(projectPath string) (*UsageFile, error) {
+	manifest := readModuleManifest(projectPath)
+	if manifest == nil {
+		return nil, nil
+	}
+
+	mods := make([]*modules.ManifestModule, 0, len(manifest.Modules))
+	for _, m := range manifest.Modules {
+		if m.Key != "" {
+			mods = append(mods, m)
+		}
+	}
+
+	// Load child modules first so the modules calling them can override them
+	sort.SliceStable(mods, func(i, j int) bool {
+		return strings.Count(mods[i].Key, ".") > strings.Count(mods[j].Key, ".")
+	})
+
+	var usageFile *UsageFile
+
+	for _, m := range mods {
+		path := filepath.Join(projectPath, m.Dir, ModuleUsageFileName)
+		if _, err := os.Stat(path); err != nil {
+			continue
+		}
+
+		moduleUsageFile, err := LoadUsageFile(path)
+		if err != nil {
+			return nil, errors.Wrapf(err, "Error loading usage file of module %s", m.Key)
+		}
+
+		log.Debugf("Loaded usage file of module %s from %s", m.Key, path)
+
+		moduleUsageFile.prefixModuleAddress(moduleAddress(m.Key))
+
+		if usageFile == nil {
+			usageFile = moduleUsageFile
+			continue
+		}
+
+		usageFile.Merge(moduleUsageFile)
+	}
+
+	return usageFile, nil
+}
+
+func readModuleManifest(projectPath string) *modules.Manifest {
+	for _, p := range moduleManifestPaths {
+		data, err := os.ReadFile(filepath.Join(projectPath, p))
+		if err != nil {
+			continue
+		}
+
+		var manifest modules.Manifest
+		err = json.Unmarshal(data, &manifest)
+		if err != nil {
+			log.Debugf("Error reading module manifest %s: %s", p, err)
+			continue
+		}
+
+		return &manifest
+	}
+
+	return nil
+}
+
+// moduleAddress returns the address of the module with the manifest key, e.g.
+// module.api.module.db for api.db.
+func moduleAddress(key string) string {
+	parts := strings.Split(key, ".")
+	for i, p := range parts {
+		parts[i] = fmt.Sprintf("module.%s", p)
+	}
+
+	return strings.Join(parts, ".")
+}
+
+// prefixModuleAddress moves the usage of the usage file into the module, so its
+// keys only match the module's resources. Commitments are dropped since they're
+// for the whole project rather than a module.
+func (u *UsageFile) prefixModuleAddress(module string) {
+	for _, ru := range u.ResourceUsages {
+		ru.Name = moduleUsageKey(module, ru.Name)
+	}
+
+	for _, profile := range u.Profiles {
+		for _, ru := range profile {
+			ru.Name = moduleUsageKey(module, ru.Name)
+		}
+	}
+
+	u.Commitments = nil
+}
+
+func moduleUsageKey(module string, key string) string {
+	// A resource type on its own becomes a pattern for the module's resources of
+	// that type
+	if isUsagePatternOrType(key) && !schema.IsUsagePattern(key) && !schema.IsModuleAddress(key) {
+		return fmt.Sprintf("%s.%s.*", module, key)
+	}
+
+	return fmt.Sprintf("%s.%s", module, key)
+}
@@ -0,0 +1,66 @@
+package usage_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/infracost/infracost/internal/usage"
+)
+
+func TestLoadModuleUsageFiles(t *testing.T) {
+	dir := t.TempDir()
+
+	writeFile := func(path string, contents string) {
+		path = filepath.Join(dir, path)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte(contents), 0600))
+	}
+
+	writeFile(".terraform/modules/modules.json", `{"Modules":[
+  {"Key":"","Source":"","Dir":"."},
+  {"Key":"api","Source":"./modules/api","Dir":"modules/api"},
+  {"Key":"api.db","Source":"../db","Dir":"modules/db"},
+  {"Key":"queue","Source":"./modules/queue","Dir":"modules/queue"}
+]}`)
+
+	writeFile("modules/api/infracost-usage.yml", `
+version: 0.1
+resource_usage:
+  aws_lambda_function:
+    monthly_requests: 1000
+  aws_lambda_function.handler:
+    request_duration_ms: 250
+  module.db.aws_dynamodb_table.*:
+    storage_gb: 20
+`)
+
+	writeFile("modules/db/infracost-usage.yml", `
+version: 0.1
+resource_usage:
+  aws_dynamodb_table.*:
+    storage_gb: 5
+    monthly_read_request_units: 100
+`)
+
+	usageFile, err := usage.LoadModuleUsageFiles(dir)
+	require.NoError(t, err)
+	require.NotNil(t, usageFile)
+
+	m := usageFile.ToUsageDataMap()
+	assert.Equal(t, int64(1000), *m["module.api.aws_lambda_function.*"].GetInt("monthly_requests"))
+	assert.Equal(t, int64(250), *m["module.api.aws_lambda_function.handler"].GetInt("request_duration_ms"))
+
+	// The calling module overrides the usage of its child module
+	assert.Equal(t, int64(20), *m["module.api.module.db.aws_dynamodb_table.*"].GetInt("storage_gb"))
+	assert.Equal(t, int64(100), *m["module.api.module.db.aws_dynamodb_table.*"].GetInt("monthly_read_request_units"))
+}
+
+func TestLoadModuleUsageFilesNoManifest(t *testing.T) {
+	usageFile, err := usage.LoadModuleUsageFiles(t.TempDir())
+	require.NoError(t, err)
+	assert.Nil(t, usageFile)
+}
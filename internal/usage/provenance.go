@@ -0,0 +1,369 @@
+package usage
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	yamlv3 "gopkg.in/yaml.v3"
+
+	"github.com/infracost/infracost/internal/schema"
+)
+
+// The sources of usage values recorded in version 0.2 usage files.
+const (
+	// SourceManual values were set by hand, values without a source are manual.
+	SourceManual = "manual"
+	// SourceSynced values were set by usage sync from a cloud provider's
+	// monitoring APIs or billing data.
+	SourceSynced = "synced"
+	// SourceInferred values were inferred rather than measured, e.g. from the
+	// resource's attributes or other usage values.
+	SourceInferred = "inferred"
+)
+
+// The keys of a usage value given with its unit and provenance in a version 0.2
+// usage file, e.g.
+//
+//	storage_gb:
+//	  value: 2
+//	  unit: TB
+//	  source: synced
+//	  updated_at: 2022-03-01T10:00:00Z
+const (
+	annotatedValueKey     = "value"
+	annotatedUnitKey      = "unit"
+	annotatedSourceKey    = "source"
+	annotatedUpdatedAtKey = "updated_at"
+)
+
+// now is the time synced values are recorded as updated at, overridden in tests.
+var now = func() time.Time {
+	return time.Now().UTC().Truncate(time.Second)
+}
+
+// usageKeyUnitSuffixes are the units of usage values, from the suffix of their
+// key, e.g. storage_gb is in GB. Longer suffixes are first so they match before
+// the shorter ones they end with.
+var usageKeyUnitSuffixes = []struct {
+	suffix string
+	unit   string
+}{
+	{"_gb_seconds", "GB-seconds"},
+	{"_kb", "KB"},
+	{"_mb", "MB"},
+	{"_gb", "GB"},
+	{"_tb", "TB"},
+	{"_ms", "ms"},
+	{"_secs", "seconds"},
+	{"_seconds", "seconds"},
+	{"_mins", "minutes"},
+	{"_minutes", "minutes"},
+	{"_hrs", "hours"},
+	{"_hours", "hours"},
+	{"_days", "days"},
+	{"_percent", "%"},
+	{"_requests", "requests"},
+}
+
+// unitFactors are the units values can be converted between, in terms of the
+// first unit of their dimension.
+var unitFactors = map[string]struct {
+	dimension string
+	factor    float64
+}{
+	"kb":      {"data", 1e3},
+	"mb":      {"data", 1e6},
+	"gb":      {"data", 1e9},
+	"tb":      {"data", 1e12},
+	"pb":      {"data", 1e15},
+	"kib":     {"data", 1 << 10},
+	"mib":     {"data", 1 << 20},
+	"gib":     {"data", 1 << 30},
+	"tib":     {"data", 1 << 40},
+	"ms":      {"time", 1e-3},
+	"seconds": {"time", 1},
+	"minutes": {"time", 60},
+	"hours":   {"time", 3600},
+	"days":    {"time", 86400},
+}
+
+// UsageKeyUnit returns the unit of the values of the usage key, e.g. GB for
+// storage_gb, or an empty string if it has no unit or it's not known.
+func UsageKeyUnit(key string) string {
+	for _, s := range usageKeyUnitSuffixes {
+		if strings.HasSuffix(key, s.suffix) {
+			return s.unit
+		}
+	}
+
+	return ""
+}
+
+// validateUsageUnit returns an error if a value of the key can't be given in
+// unit.
+func validateUsageUnit(key string, unit string) error {
+	expected := UsageKeyUnit(key)
+	if unit == "" || expected == "" || strings.EqualFold(unit, expected) {
+		return nil
+	}
+
+	from, fromOK := unitFactors[strings.ToLower(unit)]
+	to, toOK := unitFactors[strings.ToLower(expected)]
+	if !fromOK || !toOK || from.dimension != to.dimension {
+		return fmt.Errorf("%s is in %s, it can't be given in %s", key, expected, unit)
+	}
+
+	return nil
+}
+
+// convertUsageValue converts the value of the usage key from unit to the unit of
+// the key. Values given per scenario have each of their values converted.
+func convertUsageValue(key string, value interface{}, unit string) interface{} {
+	expected := UsageKeyUnit(key)
+	if unit == "" || expected == "" || strings.EqualFold(unit, expected) {
+		return value
+	}
+
+	from, fromOK := unitFactors[strings.ToLower(unit)]
+	to, toOK := unitFactors[strings.ToLower(expected)]
+	if !fromOK || !toOK || from.dimension != to.dimension {
+		return value
+	}
+
+	factor := from.factor / to.factor
+
+	switch v := value.(type) {
+	case int:
+		return float64(v) * factor
+	case int64:
+		return float64(v) * factor
+	case float64:
+		return v * factor
+	case map[string]interface{}:
+		for k, sub := range v {
+			v[k] = convertUsageValue(key, sub, unit)
+		}
+		return v
+	}
+
+	return value
+}
+
+// isAnnotatedValueNode returns true if the YAML node is a usage value given with
+// its unit or provenance rather than the usage of a sub resource.
+func isAnnotatedValueNode(node *yamlv3.Node) bool {
+	if node.Kind != yamlv3.MappingNode || len(node.Content) == 0 {
+		return false
+	}
+
+	hasValue := false
+	for i := 0; i < len(node.Content); i += 2 {
+		switch node.Content[i].Value {
+		case annotatedValueKey:
+			hasValue = true
+		case annotatedUnitKey, annotatedSourceKey, annotatedUpdatedAtKey:
+		default:
+			return false
+		}
+	}
+
+	return hasValue
+}
+
+// annotatedUsageItemFromYAML parses a usage value given with its unit and
+// provenance.
+func annotatedUsageItemFromYAML(keyNode *yamlv3.Node, valNode *yamlv3.Node) (*schema.UsageItem, error) {
+	var item *schema.UsageItem
+	var unit, source, updatedAt string
+
+	for i := 0; i+1 < len(valNode.Content); i += 2 {
+		k, v := valNode.Content[i], valNode.Content[i+1]
+
+		switch k.Value {
+		case annotatedValueKey:
+			var err error
+			item, err = usageItemFromYAML(keyNode, v)
+			if err != nil {
+				return nil, err
+			}
+		case annotatedUnitKey:
+			unit = v.Value
+		case annotatedSourceKey:
+			source = v.Value
+		case annotatedUpdatedAtKey:
+			updatedAt = v.Value
+		}
+	}
+
+	if item.ValueType == schema.SubResourceUsage && !isScenarioUsageItem(item) {
+		return nil, fmt.Errorf("Invalid value of %s, it must be a value or a value per scenario", keyNode.Value)
+	}
+
+	err := validateUsageUnit(keyNode.Value, unit)
+	if err != nil {
+		return nil, err
+	}
+
+	switch source {
+	case "", SourceManual, SourceSynced, SourceInferred:
+	default:
+		return nil, fmt.Errorf("Invalid source %s of %s, it must be one of: %s, %s, %s", source, keyNode.Value, SourceManual, SourceSynced, SourceInferred)
+	}
+
+	if updatedAt != "" {
+		item.UpdatedAt, err = time.Parse(time.RFC3339, updatedAt)
+		if err != nil {
+			return nil, errors.Wrapf(err, "Invalid updated_at of %s", keyNode.Value)
+		}
+	}
+
+	item.Unit = unit
+	item.Source = source
+	item.Comment = keyNode.HeadComment
+	if item.Description == "" {
+		item.Description = valNode.LineComment
+	}
+
+	return item, nil
+}
+
+// hasProvenance returns true if the usage item is written with its unit and
+// provenance.
+func hasProvenance(item *schema.UsageItem) bool {
+	return item.Unit != "" || item.Source != "" || !item.UpdatedAt.IsZero()
+}
+
+// annotatedValueNode wraps the YAML node of a usage value with its unit and
+// provenance.
+func annotatedValueNode(item *schema.UsageItem, valNode *yamlv3.Node) *yamlv3.Node {
+	node := &yamlv3.Node{
+		Kind: yamlv3.MappingNode,
+		Content: []*yamlv3.Node{
+			{Kind: yamlv3.ScalarNode, Tag: "!!str", Value: annotatedValueKey},
+			valNode,
+		},
+	}
+
+	add := func(key string, value string) {
+		node.Content = append(node.Content,
+			&yamlv3.Node{Kind: yamlv3.ScalarNode, Tag: "!!str", Value: key},
+			&yamlv3.Node{Kind: yamlv3.ScalarNode, Tag: "!!str", Value: value},
+		)
+	}
+
+	if item.Unit != "" {
+		add(annotatedUnitKey, item.Unit)
+	}
+
+	if item.Source != "" {
+		add(annotatedSourceKey, item.Source)
+	}
+
+	if !item.UpdatedAt.IsZero() {
+		add(annotatedUpdatedAtKey, item.UpdatedAt.UTC().Format(time.RFC3339))
+	}
+
+	return node
+}
+
+// markSynced records that the usage item's value was synced now. The value is in
+// the unit of its key since it's set from the usage data of the resource.
+func markSynced(item *schema.UsageItem) {
+	item.Unit = UsageKeyUnit(item.Key)
+	item.Source = SourceSynced
+	item.UpdatedAt = now()
+}
+
+var commentedUsageKeyRegex = regexp.MustCompile(`^#\s*[a-z0-9_.\[\]"*/-]+:(\s|$)`)
+
+// userComment returns the lines of a comment in the usage file that were written
+// by the user, without the commented-out usage keys and the banners that are
+// written with the usage file, so they're not duplicated when it's written back.
+func userComment(comment string) string {
+	lines := make([]string, 0)
+
+	for _, line := range strings.Split(comment, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "##") || commentedUsageKeyRegex.MatchString(trimmed) {
+			continue
+		}
+
+		lines = append(lines, strings.TrimSpace(strings.TrimPrefix(trimmed, "#")))
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// isVersion2 returns true if the usage file is version 0.2 or later, which
+// records the unit and provenance of usage values and keeps the comments of the
+// usage file when it's written.
+func (u *UsageFile) isVersion2() bool {
+	return compareUsageFileVersions(u.Version, UsageFileVersion2) >= 0
+}
+
+// Migrate converts the usage file to version 0.2, recording the unit of each
+// usage value and whether it was synced or set by hand, from the comments left
+// by usage sync. It returns false if the usage file is already version 0.2.
+func (u *UsageFile) Migrate() bool {
+	if u.isVersion2() {
+		return false
+	}
+
+	u.Version = UsageFileVersion2
+
+	migrateResourceUsages(u.ResourceUsages)
+	for _, profile := range u.Profiles {
+		migrateResourceUsages(profile)
+	}
+
+	return true
+}
+
+func migrateResourceUsages(resourceUsages []*ResourceUsage) {
+	for _, ru := range resourceUsages {
+		migrateUsageItems(ru.Items)
+	}
+}
+
+func migrateUsageItems(items []*schema.UsageItem) {
+	for _, item := range items {
+		if item.Value == nil {
+			continue
+		}
+
+		if item.ValueType == schema.SubResourceUsage && !isScenarioUsageItem(item) {
+			migrateUsageItems(item.Value.(*ResourceUsage).Items)
+			continue
+		}
+
+		if hasProvenance(item) {
+			continue
+		}
+
+		item.Unit = UsageKeyUnit(item.Key)
+		item.Source = SourceManual
+
+		if usageSourceCommentRegex.MatchString(item.Description) {
+			item.Source = SourceSynced
+		}
+	}
+}
+
+// copyProvenance copies the unit, provenance and comment of src to dest when its
+// value is copied.
+func copyProvenance(dest *schema.UsageItem, src *schema.UsageItem) {
+	if src.Value == nil {
+		return
+	}
+
+	dest.Unit = src.Unit
+	dest.Source = src.Source
+	dest.UpdatedAt = src.UpdatedAt
+
+	if src.Comment != "" {
+		dest.Comment = src.Comment
+	}
+}
@@ -0,0 +1,100 @@
+package usage_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/infracost/infracost/internal/usage"
+)
+
+func TestUsageFileVersion2(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "infracost-usage.yml")
+	require.NoError(t, os.WriteFile(path, []byte(`
+version: 0.2
+resource_usage:
+  # The bucket the app's uploads are stored in
+  aws_s3_bucket.uploads:
+    standard:
+      storage_gb:
+        value: 2
+        unit: TB
+        source: manual
+  aws_lambda_function.api:
+    # Taken from last month's bill
+    monthly_requests:
+      value: 5000
+      source: synced
+      updated_at: 2022-03-01T10:00:00Z
+    request_duration_ms: 250
+`), 0600))
+
+	usageFile, err := usage.LoadUsageFile(path)
+	require.NoError(t, err)
+
+	m := usageFile.ToUsageDataMap()
+	assert.Equal(t, 2000.0, m["aws_s3_bucket.uploads"].Get("standard").Get("storage_gb").Float())
+	assert.Equal(t, int64(5000), *m["aws_lambda_function.api"].GetInt("monthly_requests"))
+	assert.Equal(t, int64(250), *m["aws_lambda_function.api"].GetInt("request_duration_ms"))
+
+	require.NoError(t, usageFile.WriteToPath(path))
+
+	b, err := os.ReadFile(path)
+	require.NoError(t, err)
+
+	contents := string(b)
+	assert.Contains(t, contents, "# The bucket the app's uploads are stored in")
+	assert.Contains(t, contents, "# Taken from last month's bill")
+	assert.Contains(t, contents, "unit: TB")
+	assert.Contains(t, contents, "2022-03-01T10:00:00Z")
+}
+
+func TestUsageFileVersion2InvalidUnit(t *testing.T) {
+	_, err := usage.LoadUsageFileFromString(`
+version: 0.2
+resource_usage:
+  aws_s3_bucket.uploads:
+    standard:
+      storage_gb:
+        value: 2
+        unit: hours
+`)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "storage_gb is in GB, it can't be given in hours")
+}
+
+func TestUsageFileMigrate(t *testing.T) {
+	usageFile, err := usage.LoadUsageFileFromString(`
+version: 0.1
+resource_usage:
+  aws_lambda_function.api:
+    monthly_requests: 5000 # Monthly requests to the Lambda function. [synced from CloudWatch]
+    request_duration_ms: 250
+`)
+	require.NoError(t, err)
+
+	assert.True(t, usageFile.Migrate())
+	assert.Equal(t, usage.UsageFileVersion2, usageFile.Version)
+	assert.False(t, usageFile.Migrate())
+
+	items := usageFile.ResourceUsages[0].Items
+	require.Len(t, items, 2)
+
+	assert.Equal(t, "monthly_requests", items[0].Key)
+	assert.Equal(t, usage.SourceSynced, items[0].Source)
+	assert.Equal(t, "requests", items[0].Unit)
+
+	assert.Equal(t, "request_duration_ms", items[1].Key)
+	assert.Equal(t, usage.SourceManual, items[1].Source)
+	assert.Equal(t, "ms", items[1].Unit)
+}
+
+func TestUsageKeyUnit(t *testing.T) {
+	assert.Equal(t, "GB", usage.UsageKeyUnit("storage_gb"))
+	assert.Equal(t, "GB-seconds", usage.UsageKeyUnit("monthly_gb_seconds"))
+	assert.Equal(t, "ms", usage.UsageKeyUnit("request_duration_ms"))
+	assert.Equal(t, "", usage.UsageKeyUnit("instances"))
+}
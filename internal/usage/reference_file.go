@@ -42,15 +42,16 @@ func (u *ReferenceFile) SetDefaultValues() {
 }
 
 // FindMatchingResourceUsage returns the matching resource usage for the given resource name
-// by looking for a resource with the same resource type
+// by looking for a resource with the same resource type. The name can also be a resource
+// type on its own, which usage files use to set the default usage of the type.
 func (u *ReferenceFile) FindMatchingResourceUsage(name string) *ResourceUsage {
+	wantResourceType := name
+
 	addrParts := strings.Split(name, ".")
-	if len(addrParts) < 2 {
-		return nil
+	if len(addrParts) >= 2 {
+		wantResourceType = addrParts[len(addrParts)-2]
 	}
 
-	wantResourceType := addrParts[len(addrParts)-2]
-
 	for _, resourceUsage := range u.ResourceUsages {
 		resourceType := strings.Split(resourceUsage.Name, ".")[0]
 		if resourceType == wantResourceType {
@@ -0,0 +1,116 @@
+package usage
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	awsusage "github.com/infracost/infracost/internal/usage/aws"
+	googleusage "github.com/infracost/infracost/internal/usage/google"
+)
+
+// remoteUsageFileSchemes are the URL schemes usage files can be loaded from, so
+// a platform team can maintain the usage defaults of all their repos centrally.
+var remoteUsageFileSchemes = []string{"http://", "https://", "s3://", "gs://"}
+
+var remoteUsageFileClient = &http.Client{Timeout: 30 * time.Second}
+
+// IsRemoteUsageFile returns true if path is the URL of a usage file rather than a
+// local path. Remote usage files can be read but not written.
+func IsRemoteUsageFile(path string) bool {
+	for _, scheme := range remoteUsageFileSchemes {
+		if strings.HasPrefix(path, scheme) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// readRemoteUsageFile downloads the usage file at the URL. S3 objects are read
+// with the AWS credentials of the environment and GCS objects with
+// INFRACOST_GCP_ACCESS_TOKEN.
+func readRemoteUsageFile(path string) ([]byte, error) {
+	u, err := url.Parse(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "Invalid usage file URL")
+	}
+
+	ctx := context.Background()
+	key := strings.TrimPrefix(u.Path, "/")
+
+	switch u.Scheme {
+	case "s3":
+		return awsusage.S3GetObject(ctx, "", u.Host, key)
+	case "gs":
+		return googleusage.StorageGetObject(ctx, u.Host, key)
+	}
+
+	resp, err := remoteUsageFileClient.Get(path)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %s", resp.Status)
+	}
+
+	return io.ReadAll(resp.Body)
+}
+
+// UploadUsageFile uploads the local usage file at path to the URL, e.g. so the
+// usage file kept up to date by usage record can be loaded by CI as a remote
+// usage file. HTTP URLs are uploaded with a PUT request.
+func UploadUsageFile(path string, dest string) error {
+	if !IsRemoteUsageFile(dest) {
+		return fmt.Errorf("%s is not a URL, it must start with one of: %s", dest, strings.Join(remoteUsageFileSchemes, ", "))
+	}
+
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		return errors.Wrap(err, "Error reading usage file")
+	}
+
+	u, err := url.Parse(dest)
+	if err != nil {
+		return errors.Wrap(err, "Invalid usage file URL")
+	}
+
+	ctx := context.Background()
+	key := strings.TrimPrefix(u.Path, "/")
+
+	switch u.Scheme {
+	case "s3":
+		return awsusage.S3PutObject(ctx, "", u.Host, key, contents)
+	case "gs":
+		return googleusage.StoragePutObject(ctx, u.Host, key, contents)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, dest, bytes.NewReader(contents))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/x-yaml")
+
+	resp, err := remoteUsageFileClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status %s", resp.Status)
+	}
+
+	return nil
+}
@@ -15,6 +15,8 @@ import (
 type ResourceUsage struct {
 	Name  string
 	Items []*schema.UsageItem
+	// Comment is the comment above the resource in the usage file.
+	Comment string
 }
 
 func (r *ResourceUsage) Map() map[string]interface{} {
@@ -68,6 +70,10 @@ func (r *ResourceUsage) MergeResourceUsage(src *ResourceUsage) {
 				}
 				d := destItem.Value.(*ResourceUsage)
 				d.MergeResourceUsage(srcValue)
+
+				if !hasProvenance(destItem) {
+					copyProvenance(destItem, srcItem)
+				}
 			}
 		} else if destItem.Value == nil {
 			destItem.ValueType = srcItem.ValueType
@@ -82,11 +88,14 @@ func (r *ResourceUsage) MergeResourceUsage(src *ResourceUsage) {
 
 			if srcItem.Value != nil {
 				destItem.Value = srcItem.Value
+				copyProvenance(destItem, srcItem)
 			}
 		}
 	}
 }
 
+// mapUsageItem returns the value of the usage item, converted to the unit of its
+// key if it's given in another unit.
 func mapUsageItem(item *schema.UsageItem) interface{} {
 	if item.ValueType == schema.SubResourceUsage {
 		m := make(map[string]interface{})
@@ -98,10 +107,14 @@ func mapUsageItem(item *schema.UsageItem) interface{} {
 			}
 		}
 
+		if item.Unit != "" {
+			return convertUsageValue(item.Key, m, item.Unit)
+		}
+
 		return m
 	}
 
-	return item.Value
+	return convertUsageValue(item.Key, item.Value, item.Unit)
 }
 
 func ResourceUsagesFromYAML(raw yamlv3.Node) ([]*ResourceUsage, error) {
@@ -126,8 +139,9 @@ func ResourceUsagesFromYAML(raw yamlv3.Node) ([]*ResourceUsage, error) {
 		}
 
 		resourceUsage := &ResourceUsage{
-			Name:  resourceKeyNode.Value,
-			Items: make([]*schema.UsageItem, 0, len(resourceValNode.Content)/2),
+			Name:    resourceKeyNode.Value,
+			Items:   make([]*schema.UsageItem, 0, len(resourceValNode.Content)/2),
+			Comment: resourceKeyNode.HeadComment,
 		}
 
 		for i := 0; i < len(resourceValNode.Content); i += 2 {
@@ -159,6 +173,13 @@ func resourceUsagesMap(resourceUsages []*ResourceUsage) map[string]*ResourceUsag
 }
 
 func ResourceUsagesToYAML(resourceUsages []*ResourceUsage) (yamlv3.Node, bool) {
+	return resourceUsagesToYAML(resourceUsages, false)
+}
+
+// resourceUsagesToYAML returns the YAML node of the resource usages, and whether
+// all of them are commented out. The comments of the resources and their usage
+// values are kept if preserveComments is true.
+func resourceUsagesToYAML(resourceUsages []*ResourceUsage, preserveComments bool) (yamlv3.Node, bool) {
 	rootNode := yamlv3.Node{
 		Kind: yamlv3.MappingNode,
 	}
@@ -176,6 +197,10 @@ func ResourceUsagesToYAML(resourceUsages []*ResourceUsage) (yamlv3.Node, bool) {
 			Value: resourceUsage.Name,
 		}
 
+		if preserveComments {
+			resourceKeyNode.HeadComment = userComment(resourceUsage.Comment)
+		}
+
 		resourceValNode := &yamlv3.Node{
 			Kind: yamlv3.MappingNode,
 		}
@@ -216,13 +241,24 @@ func ResourceUsagesToYAML(resourceUsages []*ResourceUsage) (yamlv3.Node, bool) {
 					}
 				}
 
-				subResourceValNode, allSubResourcesCommented := ResourceUsagesToYAML([]*ResourceUsage{subResourceUsage})
+				subResourceValNode, allSubResourcesCommented := resourceUsagesToYAML([]*ResourceUsage{subResourceUsage}, preserveComments)
 
 				if !allSubResourcesCommented {
 					resourceNodeIsCommented = false
 					rootNodeIsCommented = false
 				}
 
+				if len(subResourceValNode.Content) == 2 {
+					if preserveComments {
+						subResourceValNode.Content[0].HeadComment = userComment(item.Comment)
+					}
+
+					// Values given per scenario can have a unit and provenance
+					if item.Value != nil && hasProvenance(item) {
+						subResourceValNode.Content[1] = annotatedValueNode(item, subResourceValNode.Content[1])
+					}
+				}
+
 				resourceValNode.Content = append(resourceValNode.Content, subResourceValNode.Content...)
 
 				continue
@@ -279,6 +315,8 @@ func ResourceUsagesToYAML(resourceUsages []*ResourceUsage) (yamlv3.Node, bool) {
 			}
 			if itemNodeIsCommented {
 				markNodeAsComment(itemKeyNode)
+			} else if preserveComments {
+				itemKeyNode.HeadComment = userComment(item.Comment)
 			}
 
 			itemValNode := &yamlv3.Node{
@@ -289,6 +327,10 @@ func ResourceUsagesToYAML(resourceUsages []*ResourceUsage) (yamlv3.Node, bool) {
 				LineComment: item.Description,
 			}
 
+			if !itemNodeIsCommented && hasProvenance(item) {
+				itemValNode = annotatedValueNode(item, itemValNode)
+			}
+
 			resourceValNode.Content = append(resourceValNode.Content, itemKeyNode)
 			resourceValNode.Content = append(resourceValNode.Content, itemValNode)
 		}
@@ -362,6 +404,10 @@ func usageItemFromYAML(keyNode *yamlv3.Node, valNode *yamlv3.Node) (*schema.Usag
 		return nil, errors.New("unexpected YAML format")
 	}
 
+	if isAnnotatedValueNode(valNode) {
+		return annotatedUsageItemFromYAML(keyNode, valNode)
+	}
+
 	var value interface{}
 	var usageValueType schema.UsageVariableType
 
@@ -417,5 +463,6 @@ func usageItemFromYAML(keyNode *yamlv3.Node, valNode *yamlv3.Node) (*schema.Usag
 		ValueType:   usageValueType,
 		Value:       value,
 		Description: valNode.LineComment,
+		Comment:     keyNode.HeadComment,
 	}, nil
 }
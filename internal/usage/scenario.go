@@ -0,0 +1,182 @@
+package usage
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/infracost/infracost/internal/schema"
+)
+
+// The usage scenarios that usage values can be given for, e.g.
+//
+//	monthly_requests:
+//	  low: 1000000
+//	  expected: 5000000
+//	  high: 20000000
+const (
+	ScenarioLow      = "low"
+	ScenarioExpected = "expected"
+	ScenarioHigh     = "high"
+	// ScenarioAll estimates the cost of every scenario to show them side by side.
+	ScenarioAll = "all"
+)
+
+// Scenarios are the usage scenarios in order, from the lowest usage to the
+// highest.
+var Scenarios = []string{ScenarioLow, ScenarioExpected, ScenarioHigh}
+
+// ValidateScenario returns an error if name isn't a usage scenario or all.
+func ValidateScenario(name string) error {
+	if name == "" || name == ScenarioAll {
+		return nil
+	}
+
+	for _, s := range Scenarios {
+		if s == name {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("Usage scenario %s is not valid, it must be one of: %s, %s", name, strings.Join(Scenarios, ", "), ScenarioAll)
+}
+
+// ToScenarioUsageDataMap returns the usage data of the usage file like
+// ToUsageDataMap, with the values given per scenario set to their value for the
+// scenario.
+func (u *UsageFile) ToScenarioUsageDataMap(scenario string) map[string]*schema.UsageData {
+	m := make(map[string]*schema.UsageData)
+
+	for _, resourceUsage := range u.ResourceUsages {
+		attrs := resolveScenarioValues(resourceUsage.Map(), scenario)
+		m[resourceUsage.Name] = schema.NewUsageData(resourceUsage.Name, schema.ParseAttributes(attrs))
+	}
+
+	return m
+}
+
+// HasScenarios returns true if any of the usage values of the usage file are
+// given per scenario.
+func (u *UsageFile) HasScenarios() bool {
+	for _, resourceUsage := range u.ResourceUsages {
+		if hasScenarioValues(resourceUsage.Map()) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func hasScenarioValues(m map[string]interface{}) bool {
+	for _, v := range m {
+		sub, ok := v.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		if isScenarioValue(sub) || hasScenarioValues(sub) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func resolveScenarioValues(m map[string]interface{}, scenario string) map[string]interface{} {
+	for k, v := range m {
+		sub, ok := v.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		if isScenarioValue(sub) {
+			m[k] = scenarioValue(sub, scenario)
+			continue
+		}
+
+		m[k] = resolveScenarioValues(sub, scenario)
+	}
+
+	return m
+}
+
+// isScenarioValue returns true if the map is a usage value given per scenario
+// rather than the usage of a sub resource.
+func isScenarioValue(m map[string]interface{}) bool {
+	if len(m) == 0 {
+		return false
+	}
+
+	for k := range m {
+		if k != ScenarioLow && k != ScenarioExpected && k != ScenarioHigh {
+			return false
+		}
+	}
+
+	return true
+}
+
+// isScenarioUsageItem returns true if the value of the usage item is given per
+// scenario.
+func isScenarioUsageItem(item *schema.UsageItem) bool {
+	if item.ValueType != schema.SubResourceUsage || item.Value == nil {
+		return false
+	}
+
+	m, ok := mapUsageItem(item).(map[string]interface{})
+	return ok && isScenarioValue(m)
+}
+
+// scenarioValue returns the value for the scenario. If it's not given, the
+// expected value is used for low and high, and the midpoint of the low and high
+// values is used for expected so a range can be given as just low and high.
+func scenarioValue(m map[string]interface{}, scenario string) interface{} {
+	if v, ok := m[scenario]; ok && v != nil {
+		return v
+	}
+
+	if scenario != ScenarioExpected {
+		if v, ok := m[ScenarioExpected]; ok && v != nil {
+			return v
+		}
+	}
+
+	low, lowOK := toFloat(m[ScenarioLow])
+	high, highOK := toFloat(m[ScenarioHigh])
+
+	switch {
+	case lowOK && highOK:
+		mid := (low + high) / 2
+		if isInt(m[ScenarioLow]) && isInt(m[ScenarioHigh]) {
+			return int64(mid)
+		}
+		return mid
+	case lowOK:
+		return m[ScenarioLow]
+	case highOK:
+		return m[ScenarioHigh]
+	}
+
+	return nil
+}
+
+func toFloat(v interface{}) (float64, bool) {
+	switch t := v.(type) {
+	case int:
+		return float64(t), true
+	case int64:
+		return float64(t), true
+	case float64:
+		return t, true
+	}
+
+	return 0, false
+}
+
+func isInt(v interface{}) bool {
+	switch v.(type) {
+	case int, int64:
+		return true
+	}
+
+	return false
+}
@@ -0,0 +1,72 @@
+package usage_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/infracost/infracost/internal/usage"
+)
+
+func TestToScenarioUsageDataMap(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "infracost-usage.yml")
+	require.NoError(t, os.WriteFile(path, []byte(`
+version: 0.1
+resource_usage:
+  aws_lambda_function.api:
+    request_duration_ms: 250
+    monthly_requests:
+      low: 1000
+      expected: 5000
+      high: 20000
+  aws_s3_bucket.logs:
+    standard:
+      storage_gb:
+        low: 100
+        high: 300
+  aws_dynamodb_table.users:
+    storage_gb:
+      expected: 10
+      high: 50
+`), 0600))
+
+	usageFile, err := usage.LoadUsageFile(path)
+	require.NoError(t, err)
+	assert.True(t, usageFile.HasScenarios())
+
+	tests := []struct {
+		scenario string
+		requests int64
+		storage  int64
+		table    int64
+	}{
+		{usage.ScenarioLow, 1000, 100, 10},
+		{usage.ScenarioExpected, 5000, 200, 10},
+		{usage.ScenarioHigh, 20000, 300, 50},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.scenario, func(t *testing.T) {
+			m := usageFile.ToScenarioUsageDataMap(tt.scenario)
+			assert.Equal(t, int64(250), *m["aws_lambda_function.api"].GetInt("request_duration_ms"))
+			assert.Equal(t, tt.requests, *m["aws_lambda_function.api"].GetInt("monthly_requests"))
+			assert.Equal(t, tt.storage, m["aws_s3_bucket.logs"].Get("standard").Get("storage_gb").Int())
+			assert.Equal(t, tt.table, *m["aws_dynamodb_table.users"].GetInt("storage_gb"))
+		})
+	}
+
+	// Without a scenario the expected values are used
+	m := usageFile.ToUsageDataMap()
+	assert.Equal(t, int64(5000), *m["aws_lambda_function.api"].GetInt("monthly_requests"))
+}
+
+func TestValidateScenario(t *testing.T) {
+	for _, s := range []string{"", "low", "expected", "high", "all"} {
+		assert.NoError(t, usage.ValidateScenario(s))
+	}
+
+	assert.Error(t, usage.ValidateScenario("worst"))
+}
@@ -3,6 +3,8 @@ package usage
 import (
 	"context"
 	"fmt"
+	"reflect"
+	"regexp"
 	"runtime"
 	"sort"
 	"strings"
@@ -51,7 +53,34 @@ func (s *SyncResult) ProjectContext() map[string]interface{} {
 	return r
 }
 
+// SyncOptions changes which resources have their usage estimated when syncing a
+// usage file.
+type SyncOptions struct {
+	// Provider only estimates the usage of the resources of the given cloud
+	// provider, e.g. aws, from its monitoring APIs. The usage of all resources is
+	// estimated if it's empty.
+	Provider string
+	// SkipEstimates adds the resources to the usage file without estimating
+	// their usage.
+	SkipEstimates bool
+	// UsageData sets the usage of the resources it has an address for instead of
+	// estimating it, e.g. from the actual usage in a cost and usage report.
+	UsageData map[string]*schema.UsageData
+	// UsageDataSource is noted in the comments of the values set from UsageData.
+	UsageDataSource string
+
+	// recordProvenance records the unit of synced values, that they were synced
+	// and when, for usage files that are version 0.2 or later.
+	recordProvenance bool
+}
+
 func SyncUsageData(usageFile *UsageFile, projects []*schema.Project) (*SyncResult, error) {
+	return SyncUsageDataWithOptions(usageFile, projects, SyncOptions{})
+}
+
+// SyncUsageDataWithOptions syncs the usage file like SyncUsageData, with opts
+// controlling which resources have their usage estimated.
+func SyncUsageDataWithOptions(usageFile *UsageFile, projects []*schema.Project, opts SyncOptions) (*SyncResult, error) {
 	referenceFile, err := LoadReferenceFile()
 	if err != nil {
 		return nil, err
@@ -64,7 +93,7 @@ func SyncUsageData(usageFile *UsageFile, projects []*schema.Project) (*SyncResul
 		resources = append(resources, project.Resources...)
 	}
 
-	syncResult := syncResourceUsages(usageFile, resources, referenceFile)
+	syncResult := syncResourceUsages(usageFile, resources, referenceFile, opts)
 
 	return syncResult, nil
 }
@@ -74,11 +103,13 @@ type syncResourceResult struct {
 	sr *SyncResult
 }
 
-func syncResourceUsages(usageFile *UsageFile, resources []*schema.Resource, referenceFile *ReferenceFile) *SyncResult {
+func syncResourceUsages(usageFile *UsageFile, resources []*schema.Resource, referenceFile *ReferenceFile, opts SyncOptions) *SyncResult {
 	syncResult := &SyncResult{
 		EstimationErrors: make(map[string]error),
 	}
 
+	opts.recordProvenance = usageFile.isVersion2()
+
 	existingResourceUsagesMap := resourceUsagesMap(usageFile.ResourceUsages)
 	resourceUsages := make([]*ResourceUsage, 0, len(resources))
 
@@ -96,6 +127,14 @@ func syncResourceUsages(usageFile *UsageFile, resources []*schema.Resource, refe
 		}
 	}
 
+	// Keep the glob patterns, module addresses and resource type defaults since
+	// they set the usage of resources rather than being the address of one
+	for _, ru := range usageFile.ResourceUsages {
+		if isUsagePatternOrType(ru.Name) {
+			resourceUsages = append(resourceUsages, ru)
+		}
+	}
+
 	numWorkers := 4
 	numCPU := runtime.NumCPU()
 	if numCPU*4 > numWorkers {
@@ -112,7 +151,7 @@ func syncResourceUsages(usageFile *UsageFile, resources []*schema.Resource, refe
 	for i := 0; i < numWorkers; i++ {
 		go func(jobs <-chan *schema.Resource, results chan<- syncResourceResult) {
 			for r := range jobs {
-				ru, sr := syncResource(r, referenceFile, existingResourceUsagesMap)
+				ru, sr := syncResource(r, referenceFile, existingResourceUsagesMap, opts)
 				results <- syncResourceResult{ru, sr}
 			}
 		}(jobs, results)
@@ -174,7 +213,7 @@ func syncWildCardResource(wildCardResources map[string]bool, resource *schema.Re
 	return resourceUsage
 }
 
-func syncResource(resource *schema.Resource, referenceFile *ReferenceFile, existingResourceUsagesMap map[string]*ResourceUsage) (*ResourceUsage, *SyncResult) {
+func syncResource(resource *schema.Resource, referenceFile *ReferenceFile, existingResourceUsagesMap map[string]*ResourceUsage, opts SyncOptions) (*ResourceUsage, *SyncResult) {
 	syncResult := &SyncResult{
 		EstimationErrors: make(map[string]error),
 	}
@@ -204,10 +243,25 @@ func syncResource(resource *schema.Resource, referenceFile *ReferenceFile, exist
 	}
 
 	syncResult.ResourceCount++
-	if resource.EstimateUsage != nil {
+	if usageData := opts.UsageData[resource.Name]; usageData != nil {
+		syncResult.EstimationCount++
+
+		existingValues := resourceUsage.Map()
+		mergeResourceUsageWithUsageData(resourceUsage, usageData)
+		markUsageSource(resourceUsage.Items, existingValues, opts.UsageDataSource, opts.recordProvenance)
+
+		return resourceUsage, syncResult
+	}
+
+	if resource.EstimateUsage != nil && !opts.SkipEstimates && isProviderResource(resource, opts.Provider) {
 		syncResult.EstimationCount++
 
 		resourceUsageMap := resourceUsage.Map()
+		existingValues := make(map[string]interface{}, len(resourceUsageMap))
+		for k, v := range resourceUsageMap {
+			existingValues[k] = v
+		}
+
 		err := resource.EstimateUsage(context.TODO(), resourceUsageMap)
 		if err != nil {
 			syncResult.EstimationErrors[resource.Name] = err
@@ -217,17 +271,155 @@ func syncResource(resource *schema.Resource, referenceFile *ReferenceFile, exist
 		// Merge in the estimated usage
 		estimatedUsageData := schema.NewUsageData(resource.Name, schema.ParseAttributes(resourceUsageMap))
 		mergeResourceUsageWithUsageData(resourceUsage, estimatedUsageData)
+
+		source := providerUsageSources[resourceProvider(resource)]
+		if err == nil && (source != "" || opts.recordProvenance) {
+			for _, item := range resourceUsage.Items {
+				v := resourceUsageMap[item.Key]
+				if v != nil && item.ValueType != schema.SubResourceUsage && !reflect.DeepEqual(v, existingValues[item.Key]) {
+					if source != "" {
+						item.Description = withUsageSource(item.Description, source)
+					}
+
+					if opts.recordProvenance {
+						markSynced(item)
+					}
+				}
+			}
+		}
 	}
 
 	return resourceUsage, syncResult
 }
 
+// providerResourceTypePrefixes are the prefixes of the Terraform and CloudFormation
+// resource types of the cloud providers whose usage can be synced.
+var providerResourceTypePrefixes = map[string][]string{
+	"aws":   {"aws_", "aws::"},
+	"azure": {"azurerm_"},
+	"gcp":   {"google_"},
+}
+
+// providerUsageSources are the monitoring APIs that the usage of the resources of
+// the cloud providers is estimated from. They're noted in the comments of the
+// synced usage values so it's clear where they came from.
+var providerUsageSources = map[string]string{
+	"aws":   "AWS CloudWatch",
+	"azure": "Azure Monitor",
+	"gcp":   "Google Cloud",
+}
+
+var usageSourceCommentRegex = regexp.MustCompile(`\s*\[synced from [^\]]*\]$`)
+
+// SyncProviders returns the cloud providers whose usage can be synced from their
+// monitoring APIs.
+func SyncProviders() []string {
+	providers := make([]string, 0, len(providerResourceTypePrefixes))
+	for p := range providerResourceTypePrefixes {
+		providers = append(providers, p)
+	}
+	sort.Strings(providers)
+
+	return providers
+}
+
+// isProviderResource returns true if the resource belongs to the cloud provider,
+// e.g. aws_s3_bucket and AWS::S3::Bucket belong to aws.
+func isProviderResource(resource *schema.Resource, provider string) bool {
+	if provider == "" {
+		return true
+	}
+
+	t := strings.ToLower(resource.ResourceType)
+	for _, prefix := range providerResourceTypePrefixes[provider] {
+		if strings.HasPrefix(t, prefix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// resourceProvider returns the cloud provider the resource belongs to, or an
+// empty string if its usage can't be synced.
+func resourceProvider(resource *schema.Resource) string {
+	for provider := range providerResourceTypePrefixes {
+		if isProviderResource(resource, provider) {
+			return provider
+		}
+	}
+
+	return ""
+}
+
+// markUsageSource notes the source in the descriptions of the usage items, and
+// the items of their sub resources, whose values changed from existingValues. If
+// recordProvenance is true they're also recorded as synced.
+func markUsageSource(items []*schema.UsageItem, existingValues map[string]interface{}, source string, recordProvenance bool) {
+	if source == "" && !recordProvenance {
+		return
+	}
+
+	for _, item := range items {
+		if item.Value == nil {
+			continue
+		}
+
+		if item.ValueType == schema.SubResourceUsage {
+			subExisting, _ := existingValues[item.Key].(map[string]interface{})
+			markUsageSource(item.Value.(*ResourceUsage).Items, subExisting, source, recordProvenance)
+			continue
+		}
+
+		if !reflect.DeepEqual(item.Value, existingValues[item.Key]) {
+			if source != "" {
+				item.Description = withUsageSource(item.Description, source)
+			}
+
+			if recordProvenance {
+				markSynced(item)
+			}
+		}
+	}
+}
+
+// withUsageSource adds the source of a synced usage value to its description,
+// replacing the source of a previous sync.
+func withUsageSource(description string, source string) string {
+	description = usageSourceCommentRegex.ReplaceAllString(description, "")
+	if description == "" {
+		return fmt.Sprintf("[synced from %s]", source)
+	}
+
+	return fmt.Sprintf("%s [synced from %s]", description, source)
+}
+
+// isUsagePatternOrType returns true if the usage file key is a glob pattern, e.g.
+// aws_lambda_function.api-*, a module address, e.g. module.api, or a resource
+// type, e.g. aws_lambda_function or AWS::Lambda::Function. Array wildcards, e.g.
+// aws_instance.web[*], are synced with the resources they match instead.
+func isUsagePatternOrType(name string) bool {
+	if schema.IsUsagePattern(name) {
+		return !strings.HasSuffix(name, "[*]")
+	}
+
+	if schema.IsModuleAddress(name) {
+		return true
+	}
+
+	return !strings.Contains(name, ".") && (strings.Contains(name, "_") || strings.Contains(name, "::"))
+}
+
 // replaceResourceUsages override usageItems from dest with usageItems from src
 func replaceResourceUsages(dest *ResourceUsage, src *ResourceUsage, opts ReplaceResourceUsagesOpts) {
 	if dest == nil || src == nil {
 		return
 	}
 
+	if src.Comment != "" {
+		dest.Comment = src.Comment
+	}
+
 	destItemMap := make(map[string]*schema.UsageItem, len(dest.Items))
 	for _, item := range dest.Items {
 		destItemMap[item.Key] = item
@@ -270,6 +462,7 @@ func replaceResourceUsages(dest *ResourceUsage, src *ResourceUsage, opts Replace
 					}
 				}
 				replaceResourceUsages(destItem.Value.(*ResourceUsage), srcValue, opts)
+				copyProvenance(destItem, srcItem)
 			}
 		} else {
 			if srcItem.DefaultValue != nil {
@@ -278,6 +471,7 @@ func replaceResourceUsages(dest *ResourceUsage, src *ResourceUsage, opts Replace
 
 			if srcItem.Value != nil {
 				destItem.Value = srcItem.Value
+				copyProvenance(destItem, srcItem)
 			}
 		}
 	}
@@ -349,6 +543,11 @@ func mergeResourceUsageWithUsageData(resourceUsage *ResourceUsage, usageData *sc
 
 		if val != nil {
 			item.Value = val
+
+			// The value is in the unit of its key now
+			if item.Unit != "" && item.ValueType != schema.SubResourceUsage {
+				item.Unit = UsageKeyUnit(item.Key)
+			}
 		}
 	}
 }
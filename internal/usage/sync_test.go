@@ -1,6 +1,7 @@
 package usage
 
 import (
+	"context"
 	"testing"
 
 	"github.com/infracost/infracost/internal/schema"
@@ -428,3 +429,123 @@ func TestReplaceResourceUsageWithUsageDataDeep(t *testing.T) {
 	assert.Len(t, subResource2.Items, 1)
 	assert.Equal(t, int64(10), subResource2.Items[0].Value.(int64))
 }
+
+func TestIsProviderResource(t *testing.T) {
+	tests := []struct {
+		resourceType string
+		provider     string
+		expected     bool
+	}{
+		{"aws_s3_bucket", "aws", true},
+		{"AWS::S3::Bucket", "aws", true},
+		{"azurerm_storage_account", "aws", false},
+		{"awsx_bucket", "aws", false},
+		{"google_storage_bucket", "", true},
+		{"google_storage_bucket", "gcp", true},
+		{"azurerm_storage_account", "gcp", false},
+	}
+
+	for _, tt := range tests {
+		r := &schema.Resource{ResourceType: tt.resourceType}
+		assert.Equal(t, tt.expected, isProviderResource(r, tt.provider), tt.resourceType)
+	}
+}
+
+func TestIsUsagePatternOrType(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected bool
+	}{
+		{"aws_lambda_function.api-*", true},
+		{"module.storage.aws_s3_bucket.*", true},
+		{"aws_lambda_function", true},
+		{"AWS::Lambda::Function", true},
+		{"aws_instance.web[*]", false},
+		{"aws_lambda_function.api", false},
+		{"MyFunction", false},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, isUsagePatternOrType(tt.name), tt.name)
+	}
+}
+
+func TestSyncResourceUsagesKeepsPatterns(t *testing.T) {
+	usageFile := NewBlankUsageFile()
+	usageFile.ResourceUsages = []*ResourceUsage{
+		{Name: "aws_lambda_function", Items: []*schema.UsageItem{{Key: "monthly_requests", ValueType: schema.Int64, Value: int64(100)}}},
+		{Name: "aws_lambda_function.api-*", Items: []*schema.UsageItem{{Key: "monthly_requests", ValueType: schema.Int64, Value: int64(200)}}},
+		{Name: "aws_lambda_function.removed", Items: []*schema.UsageItem{{Key: "monthly_requests", ValueType: schema.Int64, Value: int64(300)}}},
+	}
+
+	syncResourceUsages(usageFile, []*schema.Resource{{Name: "aws_lambda_function.api-v1"}}, &ReferenceFile{UsageFile: NewBlankUsageFile()}, SyncOptions{})
+
+	names := make([]string, 0, len(usageFile.ResourceUsages))
+	for _, ru := range usageFile.ResourceUsages {
+		names = append(names, ru.Name)
+	}
+
+	assert.Equal(t, []string{"aws_lambda_function", "aws_lambda_function.api-*", "aws_lambda_function.api-v1"}, names)
+}
+
+func TestWithUsageSource(t *testing.T) {
+	assert.Equal(t, "Total storage in GB. [synced from Azure Monitor]", withUsageSource("Total storage in GB.", "Azure Monitor"))
+	assert.Equal(t, "Total storage in GB. [synced from Azure Monitor]", withUsageSource("Total storage in GB. [synced from AWS CloudWatch]", "Azure Monitor"))
+	assert.Equal(t, "[synced from Azure Monitor]", withUsageSource("", "Azure Monitor"))
+}
+
+func TestSyncResourceUsageSource(t *testing.T) {
+	resource := &schema.Resource{
+		Name:         "azurerm_storage_account.account",
+		ResourceType: "azurerm_storage_account",
+		UsageSchema: []*schema.UsageItem{
+			{Key: "storage_gb", ValueType: schema.Float64, DefaultValue: 0, Description: "Total storage in GB."},
+			{Key: "monthly_read_operations", ValueType: schema.Int64, DefaultValue: 0},
+		},
+		EstimateUsage: func(ctx context.Context, values map[string]interface{}) error {
+			values["storage_gb"] = 12.5
+			return nil
+		},
+	}
+
+	ru, sr := syncResource(resource, &ReferenceFile{UsageFile: NewBlankUsageFile()}, map[string]*ResourceUsage{}, SyncOptions{Provider: "azure"})
+	assert.Equal(t, 1, sr.EstimationCount)
+	assert.Equal(t, 12.5, ru.Items[0].Value)
+	assert.Equal(t, "Total storage in GB. [synced from Azure Monitor]", ru.Items[0].Description)
+	assert.Equal(t, "", ru.Items[1].Description)
+}
+
+func TestSyncResourceUsageData(t *testing.T) {
+	resource := &schema.Resource{
+		Name:         "aws_s3_bucket.logs",
+		ResourceType: "aws_s3_bucket",
+		UsageSchema: []*schema.UsageItem{
+			{Key: "object_tags", ValueType: schema.Int64, DefaultValue: 0},
+			{Key: "standard", ValueType: schema.SubResourceUsage, DefaultValue: &ResourceUsage{
+				Name:  "standard",
+				Items: []*schema.UsageItem{{Key: "storage_gb", ValueType: schema.Float64, DefaultValue: 0, Description: "Total storage in GB."}},
+			}},
+		},
+		EstimateUsage: func(ctx context.Context, values map[string]interface{}) error {
+			values["object_tags"] = int64(10)
+			return nil
+		},
+	}
+
+	opts := SyncOptions{
+		Provider:      "aws",
+		SkipEstimates: true,
+		UsageData: schema.NewUsageMap(map[string]interface{}{
+			"aws_s3_bucket.logs": map[string]interface{}{"standard": map[string]interface{}{"storage_gb": 25.5}},
+		}),
+		UsageDataSource: "AWS Cost and Usage Report",
+	}
+
+	ru, sr := syncResource(resource, &ReferenceFile{UsageFile: NewBlankUsageFile()}, map[string]*ResourceUsage{}, opts)
+	assert.Equal(t, 1, sr.EstimationCount)
+	assert.Nil(t, ru.Items[0].Value)
+
+	standard := ru.Items[1].Value.(*ResourceUsage)
+	assert.Equal(t, 25.5, standard.Items[0].Value)
+	assert.Equal(t, "Total storage in GB. [synced from AWS Cost and Usage Report]", standard.Items[0].Description)
+}
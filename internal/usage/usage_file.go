@@ -15,7 +15,14 @@ import (
 )
 
 const minUsageFileVersion = "0.1"
-const maxUsageFileVersion = "0.1"
+const maxUsageFileVersion = "0.2"
+
+// defaultUsageFileVersion is the version of new usage files.
+const defaultUsageFileVersion = "0.1"
+
+// UsageFileVersion2 is the usage file version that records the unit and
+// provenance of usage values and keeps the comments of the usage file.
+const UsageFileVersion2 = "0.2"
 
 type UsageFile struct { // nolint:revive
 	Version string `yaml:"version"`
@@ -23,10 +30,25 @@ type UsageFile struct { // nolint:revive
 	RawResourceUsage yamlv3.Node `yaml:"resource_usage"`
 	// The raw usage is then parsed into this struct
 	ResourceUsages []*ResourceUsage `yaml:"-"`
+	// RawProfiles are the named profiles, e.g. dev and prod, whose resource usage
+	// overrides the shared resource usage. They're kept as a YAML node so they're
+	// written back unchanged when the shared resource usage is synced.
+	RawProfiles yamlv3.Node `yaml:"profiles,omitempty"`
+	// Profiles is the resource usage of each profile parsed from RawProfiles
+	Profiles map[string][]*ResourceUsage `yaml:"-"`
+	// Commitments is the reserved capacity covering the resources of the project
+	Commitments []*schema.Commitment `yaml:"commitments,omitempty"`
+	// appliedProfile is the profile whose resource usage has been applied over
+	// the shared resource usage by ApplyProfile
+	appliedProfile string
 }
 
 // CreateUsageFile creates a blank usage file if it does not exists
 func CreateUsageFile(path string) error {
+	if IsRemoteUsageFile(path) {
+		return fmt.Errorf("%s is a remote usage file, use a local usage file to create or update it", path)
+	}
+
 	if _, err := os.Stat(path); os.IsNotExist(err) {
 
 		usageFile := NewBlankUsageFile()
@@ -44,6 +66,16 @@ func CreateUsageFile(path string) error {
 
 func LoadUsageFile(path string) (*UsageFile, error) {
 	blankUsage := NewBlankUsageFile()
+
+	if IsRemoteUsageFile(path) {
+		contents, err := readRemoteUsageFile(path)
+		if err != nil {
+			return blankUsage, errors.Wrapf(err, "Error downloading usage file %s", path)
+		}
+
+		return loadUsageFileContents(blankUsage, contents)
+	}
+
 	if _, err := os.Stat(path); os.IsNotExist(err) {
 		log.Debug("Specified usage file does not exist. Using a blank file")
 
@@ -55,6 +87,10 @@ func LoadUsageFile(path string) (*UsageFile, error) {
 		return blankUsage, errors.Wrapf(err, "Error reading usage file")
 	}
 
+	return loadUsageFileContents(blankUsage, contents)
+}
+
+func loadUsageFileContents(blankUsage *UsageFile, contents []byte) (*UsageFile, error) {
 	usageFile, err := LoadUsageFileFromString(string(contents))
 	if err != nil {
 		return blankUsage, errors.Wrapf(err, "Error loading usage file")
@@ -63,9 +99,72 @@ func LoadUsageFile(path string) (*UsageFile, error) {
 	return usageFile, nil
 }
 
+// LoadUsageFiles loads the usage files and merges them in order, so the values of
+// later files override the values of earlier ones.
+func LoadUsageFiles(paths []string) (*UsageFile, error) {
+	if len(paths) == 0 {
+		return NewBlankUsageFile(), nil
+	}
+
+	var usageFile *UsageFile
+
+	for _, path := range paths {
+		u, err := LoadUsageFile(path)
+		if err != nil {
+			return NewBlankUsageFile(), errors.Wrapf(err, "Error loading usage file %s", path)
+		}
+
+		if usageFile == nil {
+			usageFile = u
+			continue
+		}
+
+		usageFile.Merge(u)
+	}
+
+	return usageFile, nil
+}
+
+// Merge layers the resource usage, profiles and commitments of other on top of the
+// usage file, with the values of other taking precedence. The merged profiles are
+// only used for estimates, they aren't written back by WriteToPath.
+func (u *UsageFile) Merge(other *UsageFile) {
+	u.ResourceUsages = overrideResourceUsages(u.ResourceUsages, other.ResourceUsages)
+
+	for name, profile := range other.Profiles {
+		if u.Profiles == nil {
+			u.Profiles = make(map[string][]*ResourceUsage)
+		}
+
+		u.Profiles[name] = overrideResourceUsages(u.Profiles[name], profile)
+	}
+
+	u.Commitments = append(u.Commitments, other.Commitments...)
+}
+
+// overrideResourceUsages overrides the items of the resource usages in dest with
+// the items of the ones with the same name in src, adding the ones that are only
+// in src.
+func overrideResourceUsages(dest []*ResourceUsage, src []*ResourceUsage) []*ResourceUsage {
+	existing := resourceUsagesMap(dest)
+
+	for _, srcUsage := range src {
+		resourceUsage, ok := existing[srcUsage.Name]
+		if !ok {
+			resourceUsage = &ResourceUsage{Name: srcUsage.Name}
+			dest = append(dest, resourceUsage)
+			existing[srcUsage.Name] = resourceUsage
+		}
+
+		replaceResourceUsages(resourceUsage, srcUsage, ReplaceResourceUsagesOpts{})
+	}
+
+	return dest
+}
+
 func NewBlankUsageFile() *UsageFile {
 	usageFile := &UsageFile{
-		Version: maxUsageFileVersion,
+		Version: defaultUsageFileVersion,
 		RawResourceUsage: yamlv3.Node{
 			Kind: yamlv3.MappingNode,
 		},
@@ -91,10 +190,25 @@ func LoadUsageFileFromString(s string) (*UsageFile, error) {
 		return usageFile, errors.Wrap(err, "Error loading YAML file")
 	}
 
+	for i, c := range usageFile.Commitments {
+		if c == nil {
+			return usageFile, fmt.Errorf("Invalid commitment at index %d", i)
+		}
+
+		err = c.Validate()
+		if err != nil {
+			return usageFile, errors.Wrapf(err, "Invalid commitment at index %d", i)
+		}
+	}
+
 	return usageFile, nil
 }
 
 func (u *UsageFile) WriteToPath(path string) error {
+	if IsRemoteUsageFile(path) {
+		return fmt.Errorf("%s is a remote usage file, use a local usage file to create or update it", path)
+	}
+
 	allCommented := u.dumpResourceUsages()
 
 	root := &yamlv3.Node{
@@ -126,6 +240,32 @@ See https://infracost.io/usage-file/ for docs`,
 		&u.RawResourceUsage,
 	)
 
+	if len(u.RawProfiles.Content) > 0 {
+		root.Content = append(root.Content,
+			&yamlv3.Node{
+				Kind:  yamlv3.ScalarNode,
+				Value: "profiles",
+			},
+			&u.RawProfiles,
+		)
+	}
+
+	if len(u.Commitments) > 0 {
+		var commitmentsNode yamlv3.Node
+		err := commitmentsNode.Encode(u.Commitments)
+		if err != nil {
+			return err
+		}
+
+		root.Content = append(root.Content,
+			&yamlv3.Node{
+				Kind:  yamlv3.ScalarNode,
+				Value: "commitments",
+			},
+			&commitmentsNode,
+		)
+	}
+
 	// Add a comment to the first commented-out resource
 	for _, node := range u.RawResourceUsage.Content {
 		if isNodeMarkedAsCommented(node) {
@@ -151,22 +291,28 @@ See https://infracost.io/usage-file/ for docs`,
 	return os.WriteFile(path, b, 0600)
 }
 
+// ToUsageDataMap returns the usage data of the usage file keyed by resource
+// address, using the expected value of values given per scenario.
 func (u *UsageFile) ToUsageDataMap() map[string]*schema.UsageData {
-	m := make(map[string]*schema.UsageData)
-
-	for _, resourceUsage := range u.ResourceUsages {
-		m[resourceUsage.Name] = schema.NewUsageData(resourceUsage.Name, schema.ParseAttributes(resourceUsage.Map()))
-	}
-
-	return m
+	return u.ToScenarioUsageDataMap(ScenarioExpected)
 }
 
 func (u *UsageFile) checkVersion() bool {
-	v := u.Version
-	if !strings.HasPrefix(u.Version, "v") {
-		v = "v" + u.Version
+	return compareUsageFileVersions(u.Version, minUsageFileVersion) >= 0 && compareUsageFileVersions(u.Version, maxUsageFileVersion) <= 0
+}
+
+// compareUsageFileVersions compares two usage file versions, e.g. 0.1 and 0.2,
+// like semver.Compare.
+func compareUsageFileVersions(a string, b string) int {
+	if !strings.HasPrefix(a, "v") {
+		a = "v" + a
 	}
-	return semver.Compare(v, "v"+minUsageFileVersion) >= 0 && semver.Compare(v, "v"+maxUsageFileVersion) <= 0
+
+	if !strings.HasPrefix(b, "v") {
+		b = "v" + b
+	}
+
+	return semver.Compare(a, b)
 }
 
 // InvalidKeys returns a list of keys that are invalid in the usage file.
@@ -180,7 +326,12 @@ func (u *UsageFile) InvalidKeys() ([]string, error) {
 		return invalidKeys, err
 	}
 
-	for _, resourceUsage := range u.ResourceUsages {
+	resourceUsages := u.ResourceUsages
+	for _, name := range u.ProfileNames() {
+		resourceUsages = append(resourceUsages, u.Profiles[name]...)
+	}
+
+	for _, resourceUsage := range resourceUsages {
 		refResourceUsage := refFile.FindMatchingResourceUsage(resourceUsage.Name)
 		if refResourceUsage == nil {
 			continue
@@ -222,9 +373,14 @@ func findInvalidKeys(item *schema.UsageItem, refMap map[string]interface{}) []st
 
 	if refVal, ok := refMap[item.Key]; !ok {
 		invalidKeys = append(invalidKeys, item.Key)
-	} else if item.ValueType == schema.SubResourceUsage && item.Value != nil {
+	} else if item.ValueType == schema.SubResourceUsage && item.Value != nil && !isScenarioUsageItem(item) {
+		refSubMap, ok := refVal.(map[string]interface{})
+		if !ok {
+			return invalidKeys
+		}
+
 		for _, subItem := range item.Value.(*ResourceUsage).Items {
-			invalidKeys = append(invalidKeys, findInvalidKeys(subItem, refVal.(map[string]interface{}))...)
+			invalidKeys = append(invalidKeys, findInvalidKeys(subItem, refSubMap)...)
 		}
 	}
 
@@ -238,11 +394,54 @@ func (u *UsageFile) parseResourceUsages() error {
 		return errors.Wrapf(err, "Error parsing usage file")
 	}
 
+	if len(u.RawProfiles.Content)%2 != 0 {
+		return errors.New("Error parsing usage file profiles: unexpected YAML format")
+	}
+
+	u.Profiles = make(map[string][]*ResourceUsage, len(u.RawProfiles.Content)/2)
+	for i := 0; i < len(u.RawProfiles.Content); i += 2 {
+		name := u.RawProfiles.Content[i].Value
+
+		u.Profiles[name], err = ResourceUsagesFromYAML(*u.RawProfiles.Content[i+1])
+		if err != nil {
+			return errors.Wrapf(err, "Error parsing usage file profile %s", name)
+		}
+	}
+
+	return nil
+}
+
+// ProfileNames returns the names of the profiles in the usage file, sorted.
+func (u *UsageFile) ProfileNames() []string {
+	names := make([]string, 0, len(u.Profiles))
+	for name := range u.Profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// ApplyProfile overrides the shared resource usage with the resource usage of the
+// named profile. Resources that are only in the profile are added.
+func (u *UsageFile) ApplyProfile(name string) error {
+	profile, ok := u.Profiles[name]
+	if !ok {
+		if len(u.Profiles) == 0 {
+			return fmt.Errorf("Usage profile %s not found, the usage file has no profiles", name)
+		}
+
+		return fmt.Errorf("Usage profile %s not found, the usage file has the profiles: %s", name, strings.Join(u.ProfileNames(), ", "))
+	}
+
+	u.ResourceUsages = overrideResourceUsages(u.ResourceUsages, profile)
+	u.appliedProfile = name
+
 	return nil
 }
 
 func (u *UsageFile) dumpResourceUsages() bool {
 	var allCommented bool
-	u.RawResourceUsage, allCommented = ResourceUsagesToYAML(u.ResourceUsages)
+	u.RawResourceUsage, allCommented = resourceUsagesToYAML(u.ResourceUsages, u.isVersion2())
 	return allCommented
 }
@@ -1,9 +1,16 @@
 package usage_test
 
 import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
 	"github.com/infracost/infracost/internal/usage"
 	"github.com/stretchr/testify/assert"
-	"testing"
+	"github.com/stretchr/testify/require"
 
 	"github.com/infracost/infracost/internal/providers/terraform/tftest"
 )
@@ -105,3 +112,132 @@ resource_usage:
 	}
 
 }
+
+func TestUsageFileProfiles(t *testing.T) {
+	usageFile, err := usage.LoadUsageFileFromString(`
+version: 0.1
+resource_usage:
+  aws_lambda_function.api:
+    monthly_requests: 1000
+    request_duration_ms: 100
+profiles:
+  prod:
+    aws_lambda_function.api:
+      monthly_requests: 1000000
+    aws_lambda_function.worker:
+      monthly_requests: 500
+  dev:
+    aws_lambda_function.api:
+      monthly_requests: 10
+`)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"dev", "prod"}, usageFile.ProfileNames())
+
+	err = usageFile.ApplyProfile("staging")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "dev, prod")
+
+	path := filepath.Join(t.TempDir(), "infracost-usage.yml")
+	require.NoError(t, usageFile.WriteToPath(path))
+
+	err = usageFile.ApplyProfile("prod")
+	require.NoError(t, err)
+
+	m := usageFile.ToUsageDataMap()
+	assert.Equal(t, int64(1000000), *m["aws_lambda_function.api"].GetInt("monthly_requests"))
+	assert.Equal(t, int64(100), *m["aws_lambda_function.api"].GetInt("request_duration_ms"))
+	assert.Equal(t, int64(500), *m["aws_lambda_function.worker"].GetInt("monthly_requests"))
+
+	// The profiles are written back unchanged
+	b, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Contains(t, string(b), "profiles:\n  prod:\n    aws_lambda_function.api:\n      monthly_requests: 1000000\n")
+
+	written, err := usage.LoadUsageFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"dev", "prod"}, written.ProfileNames())
+}
+
+func TestLoadUsageFilesMerged(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/org/infracost-usage.yml", r.URL.Path)
+
+		_, _ = w.Write([]byte(`
+version: 0.1
+resource_usage:
+  aws_lambda_function:
+    monthly_requests: 1000
+    request_duration_ms: 100
+profiles:
+  prod:
+    aws_lambda_function:
+      monthly_requests: 1000000
+`))
+	}))
+	defer server.Close()
+
+	path := filepath.Join(t.TempDir(), "infracost-usage.yml")
+	err := os.WriteFile(path, []byte(`
+version: 0.1
+resource_usage:
+  aws_lambda_function:
+    request_duration_ms: 500
+  aws_lambda_function.api:
+    monthly_requests: 50
+`), 0600)
+	require.NoError(t, err)
+
+	remote := server.URL + "/org/infracost-usage.yml"
+	assert.True(t, usage.IsRemoteUsageFile(remote))
+	assert.False(t, usage.IsRemoteUsageFile(path))
+
+	usageFile, err := usage.LoadUsageFiles([]string{remote, path})
+	require.NoError(t, err)
+
+	m := usageFile.ToUsageDataMap()
+	assert.Equal(t, int64(1000), *m["aws_lambda_function"].GetInt("monthly_requests"))
+	assert.Equal(t, int64(500), *m["aws_lambda_function"].GetInt("request_duration_ms"))
+	assert.Equal(t, int64(50), *m["aws_lambda_function.api"].GetInt("monthly_requests"))
+
+	require.NoError(t, usageFile.ApplyProfile("prod"))
+	m = usageFile.ToUsageDataMap()
+	assert.Equal(t, int64(1000000), *m["aws_lambda_function"].GetInt("monthly_requests"))
+
+	err = usageFile.WriteToPath(remote)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "remote usage file")
+}
+
+func TestLoadUsageFilesRemoteError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	_, err := usage.LoadUsageFiles([]string{server.URL + "/missing.yml"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "404")
+}
+
+func TestUploadUsageFile(t *testing.T) {
+	var uploaded string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPut, r.Method)
+		assert.Equal(t, "/usage/infracost-usage.yml", r.URL.Path)
+
+		b, _ := io.ReadAll(r.Body)
+		uploaded = string(b)
+	}))
+	defer server.Close()
+
+	path := filepath.Join(t.TempDir(), "infracost-usage.yml")
+	require.NoError(t, os.WriteFile(path, []byte("version: 0.1\n"), 0600))
+
+	err := usage.UploadUsageFile(path, server.URL+"/usage/infracost-usage.yml")
+	require.NoError(t, err)
+	assert.Equal(t, "version: 0.1\n", uploaded)
+
+	err = usage.UploadUsageFile(path, "usage/infracost-usage.yml")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "is not a URL")
+}
@@ -0,0 +1,339 @@
+package usage
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+
+	"github.com/infracost/infracost/internal/schema"
+)
+
+// The codes of the issues found by Validate.
+const (
+	IssueUnknownAddress = "unknown_address"
+	IssueUnknownKey     = "unknown_key"
+	IssueInvalidType    = "invalid_type"
+)
+
+var usageKeyIndexRegex = regexp.MustCompile(`\[[^\]]*\]`)
+
+// ValidationIssue is a problem with a resource or value of a usage file. Profile is
+// set when it's in one of the usage file's profiles.
+type ValidationIssue struct {
+	Address string `json:"address"`
+	Profile string `json:"profile,omitempty"`
+	Key     string `json:"key,omitempty"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// expectedUsageItem is the usage item a resource type expects. It's strict when
+// its type comes from the usage schema of a resource rather than the reference
+// usage file, where a whole number could also be an example of a float.
+type expectedUsageItem struct {
+	valueType schema.UsageVariableType
+	strict    bool
+	items     map[string]*expectedUsageItem
+}
+
+// Validate checks the resource usage of the usage file and its profiles against
+// the resources of the projects. It reports addresses that don't match any
+// resources, keys that aren't in the usage schema of the resource type and values
+// of the wrong type.
+func Validate(usageFile *UsageFile, projects []*schema.Project) ([]ValidationIssue, error) {
+	referenceFile, err := LoadReferenceFile()
+	if err != nil {
+		return nil, err
+	}
+
+	resources := schema.AllProjectResources(projects)
+
+	issues := make([]ValidationIssue, 0)
+	for _, resourceUsage := range usageFile.ResourceUsages {
+		issues = append(issues, validateResourceUsage(resourceUsage, "", referenceFile, resources)...)
+	}
+
+	// The resource usage of an applied profile has already been validated as part
+	// of the shared resource usage
+	for _, name := range usageFile.ProfileNames() {
+		if name == usageFile.appliedProfile {
+			continue
+		}
+
+		for _, resourceUsage := range usageFile.Profiles[name] {
+			issues = append(issues, validateResourceUsage(resourceUsage, name, referenceFile, resources)...)
+		}
+	}
+
+	return issues, nil
+}
+
+func validateResourceUsage(resourceUsage *ResourceUsage, profile string, referenceFile *ReferenceFile, resources []*schema.Resource) []ValidationIssue {
+	issues := make([]ValidationIssue, 0)
+
+	matched := matchingResources(resourceUsage.Name, resources)
+	if len(matched) == 0 {
+		issues = append(issues, ValidationIssue{
+			Address: resourceUsage.Name,
+			Profile: profile,
+			Code:    IssueUnknownAddress,
+			Message: unknownAddressMessage(resourceUsage.Name),
+		})
+	}
+
+	// The resources of a module can be of any type, so there's no usage schema to
+	// check the keys against
+	if schema.IsModuleAddress(resourceUsage.Name) {
+		return issues
+	}
+
+	expected := make(map[string]*expectedUsageItem)
+	if refResourceUsage := referenceFile.FindMatchingResourceUsage(resourceUsage.Name); refResourceUsage != nil {
+		addExpectedUsageItems(expected, refResourceUsage.Items, false)
+	}
+	if len(matched) > 0 {
+		addExpectedUsageItems(expected, matched[0].UsageSchema, true)
+	}
+
+	// Without a usage schema or a reference for the resource type there's nothing
+	// to check the keys against
+	if len(expected) == 0 {
+		return issues
+	}
+
+	for _, issue := range validateUsageItems(resourceUsage.Items, "", expected) {
+		issue.Address = resourceUsage.Name
+		issue.Profile = profile
+		issues = append(issues, issue)
+	}
+
+	return issues
+}
+
+// matchingResources returns the resources whose usage is set by the usage file
+// key, which can be an address, a glob pattern or a resource type.
+func matchingResources(name string, resources []*schema.Resource) []*schema.Resource {
+	matched := make([]*schema.Resource, 0)
+
+	for _, r := range resources {
+		switch {
+		case r.Name == name:
+			return []*schema.Resource{r}
+		case schema.IsUsagePattern(name) && schema.MatchUsagePattern(name, r.Name):
+			matched = append(matched, r)
+		case schema.IsModuleAddress(name) && schema.MatchModuleAddress(name, r.Name):
+			matched = append(matched, r)
+		case isUsagePatternOrType(name) && r.ResourceType == name:
+			matched = append(matched, r)
+		}
+	}
+
+	return matched
+}
+
+func unknownAddressMessage(name string) string {
+	if schema.IsUsagePattern(name) {
+		return "Doesn't match any resources in the project, update the pattern or remove it"
+	}
+
+	if schema.IsModuleAddress(name) {
+		return "Not a module in the project, check the address or remove it if the module has been deleted"
+	}
+
+	if isUsagePatternOrType(name) {
+		return fmt.Sprintf("The project has no %s resources, remove it if they've been deleted", name)
+	}
+
+	return "Not a resource in the project, check the address or remove it if the resource has been deleted"
+}
+
+func addExpectedUsageItems(expected map[string]*expectedUsageItem, items []*schema.UsageItem, strict bool) {
+	for _, item := range items {
+		key := normalizeUsageKey(item.Key)
+
+		e := expected[key]
+		if e == nil {
+			e = &expectedUsageItem{}
+			expected[key] = e
+		}
+
+		e.valueType = item.ValueType
+		e.strict = strict
+
+		if item.ValueType != schema.SubResourceUsage {
+			continue
+		}
+
+		if e.items == nil {
+			e.items = make(map[string]*expectedUsageItem)
+		}
+
+		for _, v := range []interface{}{item.DefaultValue, item.Value} {
+			if sub, ok := v.(*ResourceUsage); ok {
+				addExpectedUsageItems(e.items, sub.Items, strict)
+			}
+		}
+	}
+}
+
+func validateUsageItems(items []*schema.UsageItem, prefix string, expected map[string]*expectedUsageItem) []ValidationIssue {
+	issues := make([]ValidationIssue, 0)
+
+	for _, item := range items {
+		key := prefix + item.Key
+
+		e, ok := expected[normalizeUsageKey(item.Key)]
+		if !ok {
+			message := fmt.Sprintf("%s is not a usage key of this resource type", key)
+			if suggestion := closestUsageKey(item.Key, expected); suggestion != "" {
+				message += fmt.Sprintf(", did you mean %s?", prefix+suggestion)
+			}
+
+			issues = append(issues, ValidationIssue{Key: key, Code: IssueUnknownKey, Message: message})
+			continue
+		}
+
+		if item.Value == nil {
+			continue
+		}
+
+		if isScenarioUsageItem(item) && e.valueType != schema.SubResourceUsage {
+			for _, scenarioItem := range item.Value.(*ResourceUsage).Items {
+				if scenarioItem.Value != nil && !usageValueMatches(e, scenarioItem) {
+					scenarioKey := key + "." + scenarioItem.Key
+					issues = append(issues, ValidationIssue{
+						Key:     scenarioKey,
+						Code:    IssueInvalidType,
+						Message: fmt.Sprintf("%s must be %s, got %s", scenarioKey, usageValueTypeName(e.valueType), formatIssueValue(scenarioItem)),
+					})
+				}
+			}
+			continue
+		}
+
+		if !usageValueMatches(e, item) {
+			issues = append(issues, ValidationIssue{
+				Key:     key,
+				Code:    IssueInvalidType,
+				Message: fmt.Sprintf("%s must be %s, got %s", key, usageValueTypeName(e.valueType), formatIssueValue(item)),
+			})
+			continue
+		}
+
+		if e.valueType == schema.SubResourceUsage && len(e.items) > 0 {
+			issues = append(issues, validateUsageItems(item.Value.(*ResourceUsage).Items, key+".", e.items)...)
+		}
+	}
+
+	return issues
+}
+
+func usageValueMatches(e *expectedUsageItem, item *schema.UsageItem) bool {
+	_, isList := item.Value.([]interface{})
+
+	switch e.valueType {
+	case schema.Int64:
+		return item.ValueType == schema.Int64 || (!e.strict && item.ValueType == schema.Float64)
+	case schema.Float64:
+		return item.ValueType == schema.Int64 || item.ValueType == schema.Float64
+	case schema.String:
+		return item.ValueType != schema.SubResourceUsage && !isList
+	case schema.StringArray:
+		return isList
+	case schema.SubResourceUsage:
+		return item.ValueType == schema.SubResourceUsage
+	}
+
+	return true
+}
+
+func usageValueTypeName(t schema.UsageVariableType) string {
+	switch t {
+	case schema.Int64:
+		return "a whole number"
+	case schema.Float64:
+		return "a number"
+	case schema.StringArray:
+		return "a list of strings"
+	case schema.SubResourceUsage:
+		return "a map of usage values"
+	default:
+		return "a string"
+	}
+}
+
+func formatIssueValue(item *schema.UsageItem) string {
+	if item.ValueType == schema.SubResourceUsage {
+		return "a map"
+	}
+
+	if _, ok := item.Value.([]interface{}); ok {
+		return "a list"
+	}
+
+	if s, ok := item.Value.(string); ok {
+		return fmt.Sprintf("%q", s)
+	}
+
+	return fmt.Sprintf("%v", item.Value)
+}
+
+// normalizeUsageKey replaces the indexes of keys, e.g. node_pool[1], with a
+// wildcard so they match the keys of the usage schema, e.g. node_pool[0].
+func normalizeUsageKey(key string) string {
+	return usageKeyIndexRegex.ReplaceAllString(key, "[*]")
+}
+
+// closestUsageKey returns the expected key closest to a misspelled key, or an
+// empty string if none are close enough to suggest.
+func closestUsageKey(key string, expected map[string]*expectedUsageItem) string {
+	keys := make([]string, 0, len(expected))
+	for k := range expected {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	closest := ""
+	closestDistance := 4
+
+	for _, k := range keys {
+		if d := levenshtein(key, k); d < closestDistance {
+			closest = k
+			closestDistance = d
+		}
+	}
+
+	return closest
+}
+
+func levenshtein(a string, b string) int {
+	prev := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		cur := make([]int, len(b)+1)
+		cur[0] = i
+
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+
+			cur[j] = minInt(prev[j]+1, minInt(cur[j-1]+1, prev[j-1]+cost))
+		}
+
+		prev = cur
+	}
+
+	return prev[len(b)]
+}
+
+func minInt(a int, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,100 @@
+package usage
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/infracost/infracost/internal/schema"
+)
+
+func TestValidate(t *testing.T) {
+	usageFile, err := LoadUsageFileFromString(`
+version: 0.1
+resource_usage:
+  aws_lambda_function.api:
+    monthly_requests: 1000
+    request_duration_ms: 1.5
+    monthly_request: 10
+  aws_lambda_function.old:
+    monthly_requests: 1000
+  aws_lambda_function.worker-*:
+    monthly_requests: lots
+  aws_lambda_function:
+    monthly_requests: 100
+profiles:
+  prod:
+    aws_lambda_function.api:
+      monthly_requests:
+        count: 1
+`)
+	require.NoError(t, err)
+
+	usageSchema := []*schema.UsageItem{
+		{Key: "request_duration_ms", DefaultValue: 0, ValueType: schema.Int64},
+		{Key: "monthly_requests", DefaultValue: 0, ValueType: schema.Int64},
+	}
+
+	project := &schema.Project{
+		Resources: []*schema.Resource{
+			{Name: "aws_lambda_function.api", ResourceType: "aws_lambda_function", UsageSchema: usageSchema},
+			{Name: "aws_lambda_function.worker-1", ResourceType: "aws_lambda_function", UsageSchema: usageSchema},
+		},
+	}
+
+	issues, err := Validate(usageFile, []*schema.Project{project})
+	require.NoError(t, err)
+
+	assert.Equal(t, []ValidationIssue{
+		{Address: "aws_lambda_function.api", Key: "request_duration_ms", Code: IssueInvalidType, Message: "request_duration_ms must be a whole number, got 1.5"},
+		{Address: "aws_lambda_function.api", Key: "monthly_request", Code: IssueUnknownKey, Message: "monthly_request is not a usage key of this resource type, did you mean monthly_requests?"},
+		{Address: "aws_lambda_function.old", Code: IssueUnknownAddress, Message: "Not a resource in the project, check the address or remove it if the resource has been deleted"},
+		{Address: "aws_lambda_function.worker-*", Key: "monthly_requests", Code: IssueInvalidType, Message: `monthly_requests must be a whole number, got "lots"`},
+		{Address: "aws_lambda_function.api", Profile: "prod", Key: "monthly_requests", Code: IssueInvalidType, Message: "monthly_requests must be a whole number, got a map"},
+	}, issues)
+}
+
+func TestValidateAppliedProfile(t *testing.T) {
+	usageFile, err := LoadUsageFileFromString(`
+version: 0.1
+resource_usage:
+  aws_lambda_function.api:
+    monthly_requests: 1000
+profiles:
+  prod:
+    aws_lambda_function.api:
+      monthly_requests: lots
+`)
+	require.NoError(t, err)
+	require.NoError(t, usageFile.ApplyProfile("prod"))
+
+	project := &schema.Project{
+		Resources: []*schema.Resource{
+			{Name: "aws_lambda_function.api", ResourceType: "aws_lambda_function", UsageSchema: []*schema.UsageItem{
+				{Key: "monthly_requests", DefaultValue: 0, ValueType: schema.Int64},
+			}},
+		},
+	}
+
+	issues, err := Validate(usageFile, []*schema.Project{project})
+	require.NoError(t, err)
+
+	assert.Equal(t, []ValidationIssue{
+		{Address: "aws_lambda_function.api", Key: "monthly_requests", Code: IssueInvalidType, Message: `monthly_requests must be a whole number, got "lots"`},
+	}, issues)
+}
+
+func TestMatchingResources(t *testing.T) {
+	resources := []*schema.Resource{
+		{Name: "module.a.aws_s3_bucket.logs", ResourceType: "aws_s3_bucket"},
+		{Name: "module.a.aws_s3_bucket.data", ResourceType: "aws_s3_bucket"},
+		{Name: "aws_instance.web[0]", ResourceType: "aws_instance"},
+	}
+
+	assert.Len(t, matchingResources("module.a.aws_s3_bucket.*", resources), 2)
+	assert.Len(t, matchingResources("aws_s3_bucket", resources), 2)
+	assert.Len(t, matchingResources("aws_instance.web[*]", resources), 1)
+	assert.Len(t, matchingResources("aws_instance.web[0]", resources), 1)
+	assert.Empty(t, matchingResources("aws_instance.db", resources))
+}
@@ -0,0 +1,137 @@
+package usage
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/infracost/infracost/internal/schema"
+)
+
+// Prompt asks for the value of a usage item. key is the item's key, prefixed by
+// its sub resource if it has one, and current is its value in the usage file or
+// its default. It returns the answer, or an empty string to keep the current value.
+type Prompt func(key string, description string, current string, validate func(string) error) (string, error)
+
+// AskResourceUsage walks through the usage items of the resource usage, including
+// the items of its sub resources, and sets the values answered to prompt. It
+// returns the number of items whose value was set.
+func AskResourceUsage(resourceUsage *ResourceUsage, prompt Prompt) (int, error) {
+	return askUsageItems(resourceUsage.Items, "", prompt)
+}
+
+func askUsageItems(items []*schema.UsageItem, prefix string, prompt Prompt) (int, error) {
+	answered := 0
+
+	for _, item := range items {
+		key := prefix + item.Key
+
+		if item.ValueType == schema.SubResourceUsage {
+			n, err := askSubResourceUsage(item, key, prompt)
+			if err != nil {
+				return answered, err
+			}
+
+			answered += n
+			continue
+		}
+
+		current := item.Value
+		if current == nil {
+			current = item.DefaultValue
+		}
+
+		valueType := item.ValueType
+		answer, err := prompt(key, item.Description, formatUsageValue(current), func(s string) error {
+			if s == "" {
+				return nil
+			}
+
+			_, err := parseUsageValue(valueType, s)
+			return err
+		})
+		if err != nil {
+			return answered, err
+		}
+
+		if answer == "" {
+			continue
+		}
+
+		v, err := parseUsageValue(item.ValueType, answer)
+		if err != nil {
+			return answered, err
+		}
+
+		item.Value = v
+		answered++
+	}
+
+	return answered, nil
+}
+
+// askSubResourceUsage asks for the items of a sub resource. If the sub resource
+// isn't in the usage file yet its default items are asked for, and it's only
+// added if any of them are answered.
+func askSubResourceUsage(item *schema.UsageItem, key string, prompt Prompt) (int, error) {
+	if item.Value != nil {
+		return askUsageItems(item.Value.(*ResourceUsage).Items, key+".", prompt)
+	}
+
+	if item.DefaultValue == nil {
+		return 0, nil
+	}
+
+	defaultValue := item.DefaultValue.(*ResourceUsage)
+	subResourceUsage := &ResourceUsage{Name: defaultValue.Name}
+	for _, defaultItem := range defaultValue.Items {
+		subItem := *defaultItem
+		subItem.Value = nil
+		subResourceUsage.Items = append(subResourceUsage.Items, &subItem)
+	}
+
+	answered, err := askUsageItems(subResourceUsage.Items, key+".", prompt)
+	if answered > 0 {
+		item.Value = subResourceUsage
+	}
+
+	return answered, err
+}
+
+func formatUsageValue(v interface{}) string {
+	switch t := v.(type) {
+	case nil:
+		return ""
+	case []string:
+		return strings.Join(t, ", ")
+	default:
+		return fmt.Sprintf("%v", t)
+	}
+}
+
+func parseUsageValue(valueType schema.UsageVariableType, s string) (interface{}, error) {
+	s = strings.TrimSpace(s)
+
+	switch valueType {
+	case schema.Int64:
+		v, err := strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%q is not a whole number", s)
+		}
+		return v, nil
+	case schema.Float64:
+		v, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%q is not a number", s)
+		}
+		return v, nil
+	case schema.StringArray:
+		values := strings.Split(s, ",")
+		for i, v := range values {
+			values[i] = strings.TrimSpace(v)
+		}
+		return values, nil
+	default:
+		return s, nil
+	}
+}
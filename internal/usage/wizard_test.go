@@ -0,0 +1,70 @@
+package usage
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/infracost/infracost/internal/schema"
+)
+
+func TestAskResourceUsage(t *testing.T) {
+	resourceUsage := &ResourceUsage{
+		Name: "aws_instance.web",
+		Items: []*schema.UsageItem{
+			{Key: "operating_system", ValueType: schema.String, DefaultValue: "linux"},
+			{Key: "monthly_hrs", ValueType: schema.Float64, DefaultValue: 730.0, Value: 100.0},
+			{Key: "vcpu_count", ValueType: schema.Int64, DefaultValue: 0},
+			{
+				Key:       "root_block_device",
+				ValueType: schema.SubResourceUsage,
+				DefaultValue: &ResourceUsage{
+					Name: "root_block_device",
+					Items: []*schema.UsageItem{
+						{Key: "monthly_standard_io_requests", ValueType: schema.Int64, DefaultValue: 0},
+					},
+				},
+			},
+		},
+	}
+
+	answers := map[string]string{
+		"vcpu_count": "4",
+		"root_block_device.monthly_standard_io_requests": "1000",
+	}
+
+	var asked []string
+	answered, err := AskResourceUsage(resourceUsage, func(key string, description string, current string, validate func(string) error) (string, error) {
+		asked = append(asked, key+"="+current)
+		return answers[key], validate(answers[key])
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, 2, answered)
+	assert.Equal(t, []string{
+		"operating_system=linux",
+		"monthly_hrs=100",
+		"vcpu_count=0",
+		"root_block_device.monthly_standard_io_requests=0",
+	}, asked)
+
+	m := resourceUsage.Map()
+	assert.Nil(t, m["operating_system"])
+	assert.Equal(t, 100.0, m["monthly_hrs"])
+	assert.Equal(t, int64(4), m["vcpu_count"])
+	assert.Equal(t, map[string]interface{}{"monthly_standard_io_requests": int64(1000)}, m["root_block_device"])
+}
+
+func TestParseUsageValue(t *testing.T) {
+	v, err := parseUsageValue(schema.Float64, " 1.5 ")
+	require.NoError(t, err)
+	assert.Equal(t, 1.5, v)
+
+	v, err = parseUsageValue(schema.StringArray, "a, b")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a", "b"}, v)
+
+	_, err = parseUsageValue(schema.Int64, "1.5")
+	require.Error(t, err)
+}
@@ -0,0 +1,223 @@
+// Package validate checks the config of a run without pricing it, so CI
+// pipelines can fail fast on a broken config file, project or usage file.
+package validate
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/infracost/infracost/internal/config"
+	"github.com/infracost/infracost/internal/providers/terraform"
+	"github.com/infracost/infracost/internal/usage"
+)
+
+// The statuses of a check.
+const (
+	StatusPass = "pass"
+	StatusWarn = "warn"
+	StatusFail = "fail"
+	StatusSkip = "skip"
+)
+
+// The checks of a validation.
+const (
+	CheckConfigFile = "config_file"
+	CheckPath       = "path"
+	CheckHCL        = "hcl"
+	CheckUsageFile  = "usage_file"
+	CheckAPI        = "api"
+)
+
+// Check is the result of checking one part of the config. Project is the path of
+// the project it's for, if any.
+type Check struct {
+	Name    string `json:"name"`
+	Project string `json:"project,omitempty"`
+	Status  string `json:"status"`
+	Message string `json:"message,omitempty"`
+}
+
+// Report is the result of the checks. It's valid if none of them failed.
+type Report struct {
+	Valid  bool    `json:"valid"`
+	Checks []Check `json:"checks"`
+}
+
+// Options sets what's checked.
+type Options struct {
+	// ConfigFile is the path of the config file the projects were loaded from, if
+	// any. ConfigFileErr is the error loading it.
+	ConfigFile    string
+	ConfigFileErr error
+	// SkipHCL skips parsing the HCL of the projects.
+	SkipHCL bool
+	// Ping checks the Cloud Pricing API can be reached, it's nil to skip the check.
+	Ping func() error
+}
+
+// Run checks the projects of the config: that their paths exist, their HCL can
+// be parsed and their usage files are valid, then that the Cloud Pricing API
+// can be reached.
+func Run(ctx *config.RunContext, opts Options) Report {
+	r := Report{Valid: true}
+
+	if opts.ConfigFile != "" {
+		if opts.ConfigFileErr != nil {
+			r.add(Check{Name: CheckConfigFile, Status: StatusFail, Message: opts.ConfigFileErr.Error()})
+			return r
+		}
+
+		r.add(Check{Name: CheckConfigFile, Status: StatusPass, Message: fmt.Sprintf("%d projects", len(ctx.Config.Projects))})
+	}
+
+	for _, p := range ctx.Config.Projects {
+		r.checkProject(ctx, p, opts)
+	}
+
+	if opts.Ping == nil {
+		r.add(Check{Name: CheckAPI, Status: StatusSkip, Message: "pricing offline"})
+	} else if err := opts.Ping(); err != nil {
+		r.add(Check{Name: CheckAPI, Status: StatusFail, Message: err.Error()})
+	} else {
+		r.add(Check{Name: CheckAPI, Status: StatusPass})
+	}
+
+	return r
+}
+
+func (r *Report) add(c Check) {
+	if c.Status == StatusFail {
+		r.Valid = false
+	}
+
+	r.Checks = append(r.Checks, c)
+}
+
+func (r *Report) checkProject(ctx *config.RunContext, p *config.Project, opts Options) {
+	info, err := os.Stat(p.Path)
+	if err != nil {
+		r.add(Check{Name: CheckPath, Project: p.Path, Status: StatusFail, Message: fmt.Sprintf("path does not exist: %s", err)})
+		return
+	}
+	r.add(Check{Name: CheckPath, Project: p.Path, Status: StatusPass})
+
+	for _, path := range p.UsageFilePaths() {
+		r.add(checkUsageFile(p.Path, path))
+	}
+
+	switch {
+	case opts.SkipHCL:
+		r.add(Check{Name: CheckHCL, Project: p.Path, Status: StatusSkip})
+	case !info.IsDir():
+		r.add(Check{Name: CheckHCL, Project: p.Path, Status: StatusSkip, Message: "not a Terraform directory"})
+	default:
+		r.add(checkHCL(config.NewProjectContext(ctx, p)))
+	}
+}
+
+// checkHCL parses the HCL of the project into its resources, without pricing
+// them.
+func checkHCL(ctx *config.ProjectContext) (c Check) {
+	c = Check{Name: CheckHCL, Project: ctx.ProjectConfig.Path}
+
+	// The HCL parser can panic on config it doesn't understand, that's reported
+	// as a failure of the check rather than of the validation.
+	defer func() {
+		if err := recover(); err != nil {
+			c.Status = StatusFail
+			c.Message = fmt.Sprintf("error parsing HCL: %s", err)
+		}
+	}()
+
+	provider, err := terraform.NewHCLProvider(ctx, terraform.NewPlanJSONProvider(ctx))
+	if err != nil {
+		c.Status = StatusFail
+		c.Message = err.Error()
+		return c
+	}
+
+	projects, err := provider.LoadResources(nil)
+	if err != nil {
+		c.Status = StatusFail
+		c.Message = err.Error()
+		return c
+	}
+
+	count := 0
+	for _, p := range projects {
+		count += len(p.Resources)
+	}
+
+	c.Status = StatusPass
+	c.Message = fmt.Sprintf("%d resources", count)
+
+	return c
+}
+
+func checkUsageFile(project string, path string) Check {
+	c := Check{Name: CheckUsageFile, Project: project}
+
+	if !usage.IsRemoteUsageFile(path) {
+		if _, err := os.Stat(path); err != nil {
+			c.Status = StatusFail
+			c.Message = fmt.Sprintf("usage file does not exist: %s", path)
+			return c
+		}
+	}
+
+	u, err := usage.LoadUsageFile(path)
+	if err != nil {
+		c.Status = StatusFail
+		c.Message = err.Error()
+		return c
+	}
+
+	invalidKeys, err := u.InvalidKeys()
+	if err != nil {
+		c.Status = StatusWarn
+		c.Message = fmt.Sprintf("%s: could not check the usage keys: %s", path, err)
+		return c
+	}
+
+	if len(invalidKeys) > 0 {
+		c.Status = StatusWarn
+		c.Message = fmt.Sprintf("%s: unknown usage keys %s", path, strings.Join(invalidKeys, ", "))
+		return c
+	}
+
+	c.Status = StatusPass
+	c.Message = path
+
+	return c
+}
+
+// ToJSON returns the report as JSON.
+func (r Report) ToJSON() ([]byte, error) {
+	return json.MarshalIndent(r, "", "  ")
+}
+
+// ToTable returns the report with one line per check.
+func (r Report) ToTable() string {
+	var b strings.Builder
+
+	for _, c := range r.Checks {
+		line := fmt.Sprintf("%-4s  %s", strings.ToUpper(c.Status), c.Name)
+		if c.Project != "" {
+			line += " " + c.Project
+		}
+		if c.Message != "" {
+			line += ": " + c.Message
+		}
+		b.WriteString(line + "\n")
+	}
+
+	if r.Valid {
+		b.WriteString("\nConfig is valid\n")
+	} else {
+		b.WriteString("\nConfig is invalid\n")
+	}
+
+	return b.String()
+}
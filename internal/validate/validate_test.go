@@ -0,0 +1,57 @@
+package validate
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/infracost/infracost/internal/config"
+)
+
+func TestRun(t *testing.T) {
+	dir := t.TempDir()
+	usageFile := filepath.Join(dir, "infracost-usage.yml")
+	require.NoError(t, os.WriteFile(usageFile, []byte("version: 0.1\n"), 0600))
+
+	ctx := config.EmptyRunContext()
+	ctx.Config.Projects = []*config.Project{
+		{Path: dir, UsageFile: usageFile},
+		{Path: filepath.Join(dir, "missing")},
+		{Path: dir, UsageFile: filepath.Join(dir, "missing.yml")},
+	}
+
+	r := Run(ctx, Options{SkipHCL: true, Ping: func() error { return errors.New("Invalid API key") }})
+	assert.False(t, r.Valid)
+
+	var statuses []string
+	for _, c := range r.Checks {
+		statuses = append(statuses, c.Name+":"+c.Status)
+	}
+
+	assert.Equal(t, []string{
+		"path:pass", "usage_file:pass", "hcl:skip",
+		"path:fail",
+		"path:pass", "usage_file:fail", "hcl:skip",
+		"api:fail",
+	}, statuses)
+}
+
+func TestRunConfigFileError(t *testing.T) {
+	r := Run(config.EmptyRunContext(), Options{ConfigFile: "infracost.yml", ConfigFileErr: errors.New("version is required")})
+
+	assert.False(t, r.Valid)
+	assert.Equal(t, []Check{{Name: CheckConfigFile, Status: StatusFail, Message: "version is required"}}, r.Checks)
+}
+
+func TestRunValid(t *testing.T) {
+	ctx := config.EmptyRunContext()
+	ctx.Config.Projects = []*config.Project{{Path: t.TempDir()}}
+
+	r := Run(ctx, Options{SkipHCL: true})
+	assert.True(t, r.Valid)
+	assert.Contains(t, r.ToTable(), "Config is valid")
+}
@@ -0,0 +1,203 @@
+// Package waiver applies the waivers of a waivers file, exceptions to the
+// guardrails and tag policies that are approved until an expiry date, to the
+// results of a run. The waivers are listed in the output so they're audited,
+// and they stop applying once they've expired.
+package waiver
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v2"
+
+	"github.com/infracost/infracost/internal/output"
+)
+
+// dateFormat is the format of the expiry dates of waivers.
+const dateFormat = "2006-01-02"
+
+// Waiver excuses the failures of Rule, the name of a guardrail or tag policy,
+// for the resources or modules at Address in the projects matching Project.
+// They're globs and any of them that's empty matches everything, but a waiver
+// must have a rule or an address. Address also matches the resources of a
+// module and its submodules.
+type Waiver struct {
+	Rule    string `yaml:"rule,omitempty"`
+	Address string `yaml:"address,omitempty"`
+	Project string `yaml:"project,omitempty"`
+	Reason  string `yaml:"reason"`
+	Owner   string `yaml:"owner"`
+	Expires string `yaml:"expires"`
+
+	expires time.Time
+}
+
+type waiversFile struct {
+	Version string    `yaml:"version"`
+	Waivers []*Waiver `yaml:"waivers"`
+}
+
+// Load reads the waivers of a waivers file, e.g.:
+//
+//	version: 0.1
+//	waivers:
+//	  - rule: Max instance cost
+//	    address: aws_instance.ml_training
+//	    reason: Training run approved by finance, see JIRA-123
+//	    owner: "@acme/ml"
+//	    expires: 2026-12-31
+func Load(path string) ([]*Waiver, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("Error reading waivers file: %w", err)
+	}
+
+	var f waiversFile
+	if err := yaml.UnmarshalStrict(b, &f); err != nil {
+		return nil, fmt.Errorf("Error parsing waivers file: %w", err)
+	}
+
+	for i, w := range f.Waivers {
+		if err := w.validate(); err != nil {
+			return nil, fmt.Errorf("Invalid waiver %d: %w", i+1, err)
+		}
+	}
+
+	return f.Waivers, nil
+}
+
+func (w *Waiver) validate() error {
+	if w.Rule == "" && w.Address == "" {
+		return fmt.Errorf("rule or address is required")
+	}
+
+	if w.Reason == "" {
+		return fmt.Errorf("reason is required")
+	}
+
+	if w.Owner == "" {
+		return fmt.Errorf("owner is required")
+	}
+
+	if w.Expires == "" {
+		return fmt.Errorf("expires is required")
+	}
+
+	t, err := time.Parse(dateFormat, w.Expires)
+	if err != nil {
+		return fmt.Errorf("invalid expires date %q, it must be in the format YYYY-MM-DD", w.Expires)
+	}
+	w.expires = t
+
+	return nil
+}
+
+// Expired returns true if the day of the expiry date of the waiver has passed
+// at now.
+func (w *Waiver) Expired(now time.Time) bool {
+	return !now.Before(w.expires.AddDate(0, 0, 1))
+}
+
+func (w *Waiver) matches(rule string, projectName string, projectPath string, address string) bool {
+	if w.Rule != "" && !globMatch(w.Rule, rule) {
+		return false
+	}
+
+	if w.Project != "" && !globMatch(w.Project, projectName) && (projectPath == "" || !globMatch(w.Project, projectPath)) {
+		return false
+	}
+
+	if w.Address == "" {
+		return true
+	}
+
+	if address == "" {
+		return false
+	}
+
+	return globMatch(w.Address, address) || (strings.HasPrefix(w.Address, "module.") && output.InModule(address, w.Address))
+}
+
+func globMatch(pattern string, s string) bool {
+	if pattern == s {
+		return true
+	}
+
+	ok, _ := path.Match(pattern, s)
+	return ok
+}
+
+// Apply removes the guardrail violations and the non-compliant resources of the
+// tag policies that the waivers match, unless they've expired at now, and sets
+// the waivers of the output with the failures they match.
+func Apply(r *output.Root, waivers []*Waiver, now time.Time) {
+	r.Waivers = make([]output.Waiver, 0, len(waivers))
+	for _, w := range waivers {
+		r.Waivers = append(r.Waivers, output.Waiver{
+			Rule:     w.Rule,
+			Address:  w.Address,
+			Project:  w.Project,
+			Reason:   w.Reason,
+			Owner:    w.Owner,
+			Expires:  w.Expires,
+			Expired:  w.Expired(now),
+			Failures: []output.WaivedFailure{},
+		})
+	}
+
+	projectPaths := make(map[string]string, len(r.Projects))
+	for _, p := range r.Projects {
+		if p.Metadata != nil {
+			projectPaths[p.Name] = p.Metadata.Path
+		}
+	}
+
+	// waived records the failure on the waivers that match it, and returns true
+	// if any of them hasn't expired.
+	waived := func(rule string, projectName string, address string) bool {
+		ok := false
+		for i, w := range waivers {
+			if !w.matches(rule, projectName, projectPaths[projectName], address) {
+				continue
+			}
+
+			r.Waivers[i].Failures = append(r.Waivers[i].Failures, output.WaivedFailure{
+				Rule:        rule,
+				ProjectName: projectName,
+				Address:     address,
+			})
+			ok = ok || !r.Waivers[i].Expired
+		}
+
+		return ok
+	}
+
+	var violations []output.GuardrailViolation
+	for _, v := range r.GuardrailViolations {
+		if !waived(v.Guardrail, v.ProjectName, v.Address) {
+			violations = append(violations, v)
+		}
+	}
+	r.GuardrailViolations = violations
+
+	for i, t := range r.TagPolicies {
+		resources := make([]output.TagPolicyResource, 0, len(t.NonCompliantResources))
+		for _, res := range t.NonCompliantResources {
+			if !waived(t.Name, t.ProjectName, res.Address) {
+				resources = append(resources, res)
+				continue
+			}
+
+			if t.UntaggedMonthlyCost != nil && res.MonthlyCost != nil {
+				untagged := t.UntaggedMonthlyCost.Sub(*res.MonthlyCost)
+				t.UntaggedMonthlyCost = &untagged
+			}
+		}
+
+		t.NonCompliantResources = resources
+		r.TagPolicies[i] = t
+	}
+}
@@ -0,0 +1,150 @@
+package waiver
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/infracost/infracost/internal/output"
+)
+
+func decimalPtr(s string) *decimal.Decimal {
+	d := decimal.RequireFromString(s)
+	return &d
+}
+
+func writeWaivers(t *testing.T, content string) string {
+	path := filepath.Join(t.TempDir(), "waivers.yml")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0600))
+
+	return path
+}
+
+func TestLoadInvalid(t *testing.T) {
+	tests := []struct {
+		content string
+		err     string
+	}{
+		{"waivers:\n  - reason: POC\n    owner: '@acme/ml'\n    expires: 2026-12-31\n", "rule or address is required"},
+		{"waivers:\n  - rule: Max cost\n    owner: '@acme/ml'\n    expires: 2026-12-31\n", "reason is required"},
+		{"waivers:\n  - rule: Max cost\n    reason: POC\n    expires: 2026-12-31\n", "owner is required"},
+		{"waivers:\n  - rule: Max cost\n    reason: POC\n    owner: '@acme/ml'\n", "expires is required"},
+		{"waivers:\n  - rule: Max cost\n    reason: POC\n    owner: '@acme/ml'\n    expires: 31/12/2026\n", "invalid expires date"},
+		{"waivers:\n  - rule: Max cost\n    until: 2026-12-31\n", "field until not found"},
+	}
+
+	for _, tt := range tests {
+		_, err := Load(writeWaivers(t, tt.content))
+		require.Error(t, err, tt.content)
+		assert.Contains(t, err.Error(), tt.err, tt.content)
+	}
+}
+
+func TestApply(t *testing.T) {
+	waivers, err := Load(writeWaivers(t, `version: 0.1
+waivers:
+  - rule: Max instance cost
+    address: aws_instance.ml_*
+    reason: Training runs approved by finance
+    owner: "@acme/ml"
+    expires: 2026-12-31
+  - address: module.legacy
+    reason: Being decommissioned
+    owner: "@acme/platform"
+    expires: 2026-06-30
+  - rule: Required tags
+    project: infracost/dev
+    reason: Dev isn't charged back
+    owner: "@acme/platform"
+    expires: 2026-12-31
+`))
+	require.NoError(t, err)
+
+	r := output.Root{
+		Projects: []output.Project{{Name: "infracost/prod"}},
+		GuardrailViolations: []output.GuardrailViolation{
+			{Guardrail: "Max instance cost", ProjectName: "infracost/prod", Address: "aws_instance.ml_training", Blocking: true},
+			{Guardrail: "Max instance cost", ProjectName: "infracost/prod", Address: "aws_instance.web", Blocking: true},
+			{Guardrail: "each module", ProjectName: "infracost/prod", Address: "module.legacy", Blocking: true},
+			{Guardrail: "each project", ProjectName: "infracost/prod", Blocking: true},
+		},
+		TagPolicies: []output.TagPolicyResult{
+			{
+				Name:                "Required tags",
+				ProjectName:         "infracost/prod",
+				Blocking:            true,
+				UntaggedMonthlyCost: decimalPtr("150"),
+				NonCompliantResources: []output.TagPolicyResource{
+					{Address: "module.legacy.aws_instance.this", MonthlyCost: decimalPtr("100")},
+					{Address: "aws_s3_bucket.logs", MonthlyCost: decimalPtr("50")},
+				},
+			},
+			{
+				Name:                "Required tags",
+				ProjectName:         "infracost/dev",
+				Blocking:            true,
+				UntaggedMonthlyCost: decimalPtr("20"),
+				NonCompliantResources: []output.TagPolicyResource{
+					{Address: "aws_instance.dev", MonthlyCost: decimalPtr("20")},
+				},
+			},
+		},
+	}
+
+	Apply(&r, waivers, time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC))
+
+	require.Len(t, r.GuardrailViolations, 2)
+	assert.Equal(t, "aws_instance.web", r.GuardrailViolations[0].Address)
+	assert.Equal(t, "", r.GuardrailViolations[1].Address)
+
+	assert.Equal(t, []output.TagPolicyResource{{Address: "aws_s3_bucket.logs", MonthlyCost: decimalPtr("50")}}, r.TagPolicies[0].NonCompliantResources)
+	assert.Equal(t, "50", r.TagPolicies[0].UntaggedMonthlyCost.String())
+	assert.Empty(t, r.TagPolicies[1].NonCompliantResources)
+	assert.Equal(t, "0", r.TagPolicies[1].UntaggedMonthlyCost.String())
+	assert.Equal(t, 1, r.BlockingTagPolicies())
+
+	require.Len(t, r.Waivers, 3)
+	assert.Equal(t, []output.WaivedFailure{
+		{Rule: "Max instance cost", ProjectName: "infracost/prod", Address: "aws_instance.ml_training"},
+	}, r.Waivers[0].Failures)
+	assert.Equal(t, []output.WaivedFailure{
+		{Rule: "each module", ProjectName: "infracost/prod", Address: "module.legacy"},
+		{Rule: "Required tags", ProjectName: "infracost/prod", Address: "module.legacy.aws_instance.this"},
+	}, r.Waivers[1].Failures)
+	assert.Len(t, r.Waivers[2].Failures, 1)
+	assert.False(t, r.Waivers[1].Expired)
+}
+
+func TestApplyExpired(t *testing.T) {
+	waivers, err := Load(writeWaivers(t, `waivers:
+  - address: aws_instance.ml_training
+    reason: Training run approved by finance
+    owner: "@acme/ml"
+    expires: 2026-04-30
+`))
+	require.NoError(t, err)
+
+	r := output.Root{
+		GuardrailViolations: []output.GuardrailViolation{
+			{Guardrail: "Max instance cost", ProjectName: "infracost/prod", Address: "aws_instance.ml_training", Blocking: true},
+		},
+	}
+
+	Apply(&r, waivers, time.Date(2026, 4, 30, 23, 0, 0, 0, time.UTC))
+	assert.Empty(t, r.GuardrailViolations)
+
+	r.GuardrailViolations = []output.GuardrailViolation{
+		{Guardrail: "Max instance cost", ProjectName: "infracost/prod", Address: "aws_instance.ml_training", Blocking: true},
+	}
+
+	Apply(&r, waivers, time.Date(2026, 5, 1, 0, 0, 0, 0, time.UTC))
+	assert.Equal(t, 1, r.BlockingGuardrailViolations())
+	require.Len(t, r.Waivers, 1)
+	assert.True(t, r.Waivers[0].Expired)
+	assert.Len(t, r.Waivers[0].Failures, 1)
+}
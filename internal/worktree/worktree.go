@@ -0,0 +1,247 @@
+// Package worktree checks out git refs into temporary git worktrees, so the
+// projects of another branch or commit can be run without changing the checkout
+// of the repo.
+package worktree
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+)
+
+// Worktree is a temporary worktree of a git repo with a ref checked out.
+type Worktree struct {
+	// Dir is the directory the ref is checked out to.
+	Dir string
+	// RepoDir is the top level directory of the repo the worktree is of.
+	RepoDir string
+	// Ref is the ref checked out in the worktree.
+	Ref string
+
+	tmpDir string
+}
+
+// Add checks out the ref of the git repo that path is in to a new temporary
+// worktree. Remove must be called to delete it.
+func Add(path string, ref string) (*Worktree, error) {
+	dir := path
+	if info, err := os.Stat(path); err == nil && !info.IsDir() {
+		dir = filepath.Dir(path)
+	}
+
+	repoDir, err := git(dir, "rev-parse", "--show-toplevel")
+	if err != nil {
+		return nil, errors.Wrapf(err, "%s is not in a git repo", path)
+	}
+
+	_, err = git(repoDir, "rev-parse", "--verify", "--quiet", ref+"^{commit}")
+	if err != nil {
+		return nil, fmt.Errorf("Could not find git ref %s, make sure it's been fetched", ref)
+	}
+
+	tmpDir, err := os.MkdirTemp("", "infracost-ref-")
+	if err != nil {
+		return nil, errors.Wrap(err, "Error creating temp directory for git worktree")
+	}
+
+	w := &Worktree{
+		Dir:     filepath.Join(tmpDir, "worktree"),
+		RepoDir: repoDir,
+		Ref:     ref,
+		tmpDir:  tmpDir,
+	}
+
+	log.Debugf("Checking out %s to %s", ref, w.Dir)
+
+	_, err = git(repoDir, "worktree", "add", "--detach", w.Dir, ref)
+	if err != nil {
+		os.RemoveAll(tmpDir)
+		return nil, errors.Wrapf(err, "Error checking out %s", ref)
+	}
+
+	return w, nil
+}
+
+// Path returns the path in the worktree of a path in the repo. Paths outside the
+// repo are returned unchanged.
+func (w *Worktree) Path(path string) string {
+	rel, ok := w.rel(path)
+	if !ok {
+		return path
+	}
+
+	return filepath.Join(w.Dir, rel)
+}
+
+// Contains returns true if the path is in the repo the worktree is of.
+func (w *Worktree) Contains(path string) bool {
+	_, ok := w.rel(path)
+	return ok
+}
+
+// rel returns the path relative to the top level directory of the repo, and
+// false if it's outside the repo.
+func (w *Worktree) rel(path string) (string, bool) {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return "", false
+	}
+
+	abs, err = filepath.EvalSymlinks(abs)
+	if err != nil {
+		return "", false
+	}
+
+	rel, err := filepath.Rel(w.RepoDir, abs)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", false
+	}
+
+	return rel, true
+}
+
+// Remove deletes the worktree and its temporary directory.
+func (w *Worktree) Remove() error {
+	_, err := git(w.RepoDir, "worktree", "remove", "--force", w.Dir)
+	if err != nil {
+		log.Debugf("Error removing git worktree %s: %s", w.Dir, err)
+	}
+
+	// Remove the directory even if git couldn't so nothing is left behind, prune
+	// then cleans up git's record of the worktree.
+	rmErr := os.RemoveAll(w.tmpDir)
+	if err != nil {
+		_, _ = git(w.RepoDir, "worktree", "prune")
+	}
+
+	return rmErr
+}
+
+// Set is the worktrees of a ref in each of the git repos of a set of paths, so
+// paths in different repos are each mapped to the ref of their own repo.
+type Set struct {
+	// Ref is the ref checked out in the worktrees.
+	Ref string
+
+	worktrees []*Worktree
+}
+
+// NewSet returns an empty set of worktrees of the ref. Worktrees are added as
+// the paths of their repos are mapped, and Remove must be called to delete them.
+func NewSet(ref string) *Set {
+	return &Set{Ref: ref}
+}
+
+// Path returns the path in the worktree of the repo that path is in, checking
+// out the ref of the repo to a new worktree if it's the first path in the repo.
+func (s *Set) Path(path string) (string, error) {
+	for _, w := range s.worktrees {
+		if w.Contains(path) {
+			return w.Path(path), nil
+		}
+	}
+
+	w, err := Add(path, s.Ref)
+	if err != nil {
+		return "", err
+	}
+
+	s.worktrees = append(s.worktrees, w)
+
+	return w.Path(path), nil
+}
+
+// Remove deletes all the worktrees of the set, returning the first error.
+func (s *Set) Remove() error {
+	var firstErr error
+
+	for _, w := range s.worktrees {
+		err := w.Remove()
+		if err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+
+	s.worktrees = nil
+
+	return firstErr
+}
+
+// StagedFiles returns the absolute paths of the files with staged changes in
+// the git repo that path is in, including the deleted files and both paths of
+// renamed files.
+func StagedFiles(path string) ([]string, error) {
+	repoDir, err := git(path, "rev-parse", "--show-toplevel")
+	if err != nil {
+		return nil, errors.Wrapf(err, "%s is not in a git repo", path)
+	}
+
+	out, err := git(repoDir, "diff", "--cached", "--name-only", "--no-renames")
+	if err != nil {
+		return nil, errors.Wrap(err, "Error listing staged files")
+	}
+
+	var files []string
+	for _, name := range strings.Split(out, "\n") {
+		if name != "" {
+			files = append(files, filepath.Join(repoDir, filepath.FromSlash(name)))
+		}
+	}
+
+	return files, nil
+}
+
+// ChangedFiles returns the absolute paths of the files changed in the git repo
+// that path is in since it branched off ref, including uncommitted changes,
+// deleted files and both paths of renamed files. Changes made to ref since the
+// branch point aren't included, like the files of a pull request.
+func ChangedFiles(path string, ref string) ([]string, error) {
+	repoDir, err := git(path, "rev-parse", "--show-toplevel")
+	if err != nil {
+		return nil, errors.Wrapf(err, "%s is not in a git repo", path)
+	}
+
+	base, err := git(repoDir, "merge-base", ref, "HEAD")
+	if err != nil {
+		return nil, fmt.Errorf("Could not find the merge base of git ref %s, make sure it's been fetched with enough history", ref)
+	}
+
+	out, err := git(repoDir, "diff", "--name-only", "--no-renames", base)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Error listing files changed since %s", ref)
+	}
+
+	var files []string
+	for _, name := range strings.Split(out, "\n") {
+		if name != "" {
+			files = append(files, filepath.Join(repoDir, filepath.FromSlash(name)))
+		}
+	}
+
+	return files, nil
+}
+
+func git(dir string, args ...string) (string, error) {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	out, err := cmd.Output()
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return "", errors.New(msg)
+		}
+
+		return "", err
+	}
+
+	return strings.TrimSpace(string(out)), nil
+}
@@ -0,0 +1,162 @@
+package worktree
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func initRepo(t *testing.T) string {
+	t.Helper()
+
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+
+	dir := t.TempDir()
+	run := func(args ...string) {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		cmd.Env = append(os.Environ(),
+			"GIT_AUTHOR_NAME=test", "GIT_AUTHOR_EMAIL=test@example.com",
+			"GIT_COMMITTER_NAME=test", "GIT_COMMITTER_EMAIL=test@example.com",
+		)
+		out, err := cmd.CombinedOutput()
+		require.NoError(t, err, string(out))
+	}
+
+	run("init", "-q")
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "infra"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "infra", "main.tf"), []byte("# v1\n"), 0600))
+	run("add", "-A")
+	run("commit", "-q", "-m", "v1")
+	run("tag", "v1")
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "infra", "main.tf"), []byte("# v2\n"), 0600))
+	run("commit", "-q", "-am", "v2")
+
+	return dir
+}
+
+func TestAdd(t *testing.T) {
+	dir := initRepo(t)
+
+	w, err := Add(filepath.Join(dir, "infra"), "v1")
+	require.NoError(t, err)
+
+	path := w.Path(filepath.Join(dir, "infra"))
+	assert.Equal(t, filepath.Join(w.Dir, "infra"), path)
+
+	data, err := os.ReadFile(filepath.Join(path, "main.tf"))
+	require.NoError(t, err)
+	assert.Equal(t, "# v1\n", string(data))
+
+	// The checkout of the repo isn't changed.
+	data, err = os.ReadFile(filepath.Join(dir, "infra", "main.tf"))
+	require.NoError(t, err)
+	assert.Equal(t, "# v2\n", string(data))
+
+	outside := t.TempDir()
+	assert.Equal(t, outside, w.Path(outside))
+
+	require.NoError(t, w.Remove())
+	_, err = os.Stat(w.Dir)
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestAddUnknownRef(t *testing.T) {
+	dir := initRepo(t)
+
+	_, err := Add(dir, "does-not-exist")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "Could not find git ref does-not-exist")
+}
+
+func TestStagedFiles(t *testing.T) {
+	dir := initRepo(t)
+
+	files, err := StagedFiles(filepath.Join(dir, "infra"))
+	require.NoError(t, err)
+	assert.Empty(t, files)
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "infra", "main.tf"), []byte("# v3\n"), 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "infra", "vars.tf"), []byte("# v3\n"), 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "README.md"), []byte("unstaged\n"), 0600))
+
+	cmd := exec.Command("git", "add", "infra")
+	cmd.Dir = dir
+	out, err := cmd.CombinedOutput()
+	require.NoError(t, err, string(out))
+
+	files, err = StagedFiles(filepath.Join(dir, "infra"))
+	require.NoError(t, err)
+
+	repoDir, err := filepath.EvalSymlinks(dir)
+	require.NoError(t, err)
+	assert.Equal(t, []string{filepath.Join(repoDir, "infra", "main.tf"), filepath.Join(repoDir, "infra", "vars.tf")}, files)
+}
+
+func TestChangedFiles(t *testing.T) {
+	dir := initRepo(t)
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "infra", "vars.tf"), []byte("# uncommitted\n"), 0600))
+
+	cmd := exec.Command("git", "add", "infra")
+	cmd.Dir = dir
+	out, err := cmd.CombinedOutput()
+	require.NoError(t, err, string(out))
+
+	files, err := ChangedFiles(dir, "v1")
+	require.NoError(t, err)
+
+	repoDir, err := filepath.EvalSymlinks(dir)
+	require.NoError(t, err)
+	assert.Equal(t, []string{filepath.Join(repoDir, "infra", "main.tf"), filepath.Join(repoDir, "infra", "vars.tf")}, files)
+
+	_, err = ChangedFiles(dir, "does-not-exist")
+	assert.Error(t, err)
+}
+
+func TestAddNotRepo(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+
+	_, err := Add(t.TempDir(), "main")
+	assert.Error(t, err)
+}
+
+func TestSet(t *testing.T) {
+	dirA := initRepo(t)
+	dirB := initRepo(t)
+
+	s := NewSet("v1")
+
+	pathA, err := s.Path(filepath.Join(dirA, "infra"))
+	require.NoError(t, err)
+	pathB, err := s.Path(filepath.Join(dirB, "infra"))
+	require.NoError(t, err)
+	assert.NotEqual(t, filepath.Dir(pathA), filepath.Dir(pathB))
+
+	// Another path in the same repo uses the same worktree.
+	pathA2, err := s.Path(dirA)
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Dir(pathA), pathA2)
+	assert.Len(t, s.worktrees, 2)
+
+	for _, path := range []string{pathA, pathB} {
+		data, err := os.ReadFile(filepath.Join(path, "main.tf"))
+		require.NoError(t, err)
+		assert.Equal(t, "# v1\n", string(data))
+	}
+
+	require.NoError(t, s.Remove())
+	_, err = os.Stat(pathA)
+	assert.True(t, os.IsNotExist(err))
+	_, err = os.Stat(pathB)
+	assert.True(t, os.IsNotExist(err))
+}